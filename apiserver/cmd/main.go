@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"math"
 	"net"
@@ -10,35 +11,91 @@ import (
 	"os/signal"
 	"path"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"syscall"
+	"time"
 
 	assetfs "github.com/elazarl/go-bindata-assetfs"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/encoding/protojson"
 	"k8s.io/klog/v2"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/ray-project/kuberay/apiserver/pkg/certs"
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	"github.com/ray-project/kuberay/apiserver/pkg/encryption"
+	"github.com/ray-project/kuberay/apiserver/pkg/features"
 	"github.com/ray-project/kuberay/apiserver/pkg/interceptor"
 	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/metrics"
 	"github.com/ray-project/kuberay/apiserver/pkg/server"
 	"github.com/ray-project/kuberay/apiserver/pkg/swagger"
+	"github.com/ray-project/kuberay/apiserver/pkg/tracing"
+	kuberayproto "github.com/ray-project/kuberay/proto"
 	api "github.com/ray-project/kuberay/proto/go_client"
 )
 
 var (
-	rpcPortFlag        = flag.String("rpcPortFlag", ":8887", "RPC Port")
-	httpPortFlag       = flag.String("httpPortFlag", ":8888", "Http Proxy Port")
-	collectMetricsFlag = flag.Bool("collectMetricsFlag", true, "Whether to collect Prometheus metrics in API server.")
-	logFile            = flag.String("logFilePath", "", "Synchronize logs to local file")
-	localSwaggerPath   = flag.String("localSwaggerPath", "", "Specify the root directory for `*.swagger.json` the swagger files.")
-	healthy            int32
+	rpcPortFlag             = flag.String("rpcPortFlag", ":8887", "RPC Port")
+	httpPortFlag            = flag.String("httpPortFlag", ":8888", "Http Proxy Port")
+	collectMetricsFlag      = flag.Bool("collectMetricsFlag", true, "Whether to collect Prometheus metrics in API server.")
+	logFile                 = flag.String("logFilePath", "", "Synchronize logs to local file")
+	localSwaggerPath        = flag.String("localSwaggerPath", "", "Specify the root directory for `*.swagger.json` the swagger files.")
+	configFilePath          = flag.String("configFilePath", "", "Path to the API server config file with defaults, quotas, allowlists and rate limits. Changes are applied without restart.")
+	configPollInterval      = flag.Duration("configPollInterval", 10*time.Second, "How often the API server config file is checked for changes.")
+	featureGates            = flag.String("featureGates", "", "A set of key=value pairs that describe feature gates for experimental features. Options are:\n"+strings.Join(features.KnownFeatures(), "\n"))
+	fakeBackendFlag         = flag.Bool("fakeBackendFlag", false, "Keep resources in memory instead of a Kubernetes cluster, with simulated status progression. For testing only.")
+	fakeStatusInterval      = flag.Duration("fakeStatusInterval", 5*time.Second, "How often the status of resources progresses when fakeBackendFlag is set.")
+	eventCacheWorkers       = flag.Int("eventCacheWorkers", 2, "Number of workers keeping the event cache warm when the EventCache feature gate is enabled.")
+	cacheResyncPeriod       = flag.Duration("cacheResyncPeriod", 10*time.Minute, "How often the informers of the resource cache resync when the ResourceCache feature gate is enabled. Zero disables the resync.")
+	enableAuth              = flag.Bool("enableAuth", false, "Authenticate callers with the Kubernetes TokenReview API and authorize mutating calls with SubjectAccessReviews in the target namespace.")
+	impersonateCallers      = flag.Bool("impersonateCallers", false, "Make the calls to Kubernetes of every authenticated call impersonate its caller, so that the RBAC permissions of the caller apply and the caller is recorded in the Kubernetes audit log. Bypasses the resource and event caches for these calls. Requires enableAuth.")
+	datastoreFlag           = flag.String("datastore", "", "Where the history of the changes made to the clusters, jobs and services, and the finished jobs, are recorded: memory://, sqlite3://<path> or postgres://<dsn>. Empty disables the history and the job archive, as does disabling the HistoryDB feature gate.")
+	historyEncryptionKey    = flag.String("historyEncryptionKeyFile", "", "Path to a file, e.g. of a mounted Secret, with the base64 encoded 32 bytes master key sealing the environment variable values, runtime envs and serve configs of the requests recorded in the history, with a key derived for every namespace. Empty redacts these fields instead.")
+	auditSink               = flag.String("auditSink", "", "Where the audit records of mutating calls are written: stdout, file://<path> or an http(s) webhook URL. Empty disables audit logging.")
+	workingDirStore         = flag.String("workingDirStore", "", "Where the uploaded working directories of jobs are stored: empty for the GCS of the Ray cluster, or file://<dir> for a directory shared with the Ray Pods, e.g. a PVC.")
+	cronJobSyncPeriod       = flag.Duration("cronJobSyncPeriod", 10*time.Second, "How often the cron jobs are checked for due runs. Zero disables the creation of scheduled runs.")
+	readinessCheckPeriod    = flag.Duration("readinessCheckPeriod", 10*time.Second, "How often the connectivity to the Kubernetes API server and to the datastore is checked to report the readiness of the API server.")
+	garbageCollectionPeriod = flag.Duration("garbageCollectionPeriod", time.Minute, "How often the finished jobs and the idle clusters whose time to live expired are deleted. Zero disables the deletion.")
+	notificationSyncPeriod  = flag.Duration("notificationSyncPeriod", 10*time.Second, "How often the jobs and services are checked for the state transitions notified to the notification subscriptions. Zero disables the notifications.")
+	rollingRestartPeriod    = flag.Duration("rollingRestartPeriod", 10*time.Second, "How often the outdated Pods of the restarted worker groups are deleted, a few at a time. Zero disables the rolling restarts.")
+	workerGroupDrainPeriod  = flag.Duration("workerGroupDrainPeriod", 10*time.Second, "How often the drained workers are checked for actors and running tasks and removed once they have none. Zero disables the removal of drained workers.")
+	tlsCertFile             = flag.String("tlsCertFile", "", "Path to the PEM certificate of the gRPC and HTTP listeners. Empty serves plaintext.")
+	tlsKeyFile              = flag.String("tlsKeyFile", "", "Path to the PEM private key of tlsCertFile.")
+	tlsClientCAFile         = flag.String("tlsClientCAFile", "", "Path to the PEM CA certificates verifying the client certificates. Requires the clients to present one, i.e. mutual TLS.")
+	tlsReloadInterval       = flag.Duration("tlsReloadInterval", time.Minute, "How often the TLS certificate, key and client CA files are checked for changes.")
+	clientCertIdentity      = flag.String("clientCertIdentity", "", "Authenticate the callers without a bearer token with their client certificate: subject maps its common name to the user, san its first URI, DNS or email SAN. Its organizations are the groups. Requires enableAuth and tlsClientCAFile.")
+	clientRateLimitQPS      = flag.Float64("clientRateLimitQPS", 0, "Calls per second allowed to every client, identified by its user with enableAuth and by its address otherwise. Zero disables the per-client rate limit.")
+	clientRateLimitBurst    = flag.Int("clientRateLimitBurst", 0, "Calls every client can make in a burst above clientRateLimitQPS.")
+	maxRequestBytes         = flag.Int64("maxRequestBytes", 0, "Maximum size of the HTTP request bodies and the gRPC request messages in bytes. Zero keeps the default limit of 2GiB.")
+	kubeconfigContexts      = flag.String("kubeconfigContexts", "", "Comma separated kubeconfig contexts of the Kubernetes clusters, besides the default one, which the requests can target with their targetCluster field.")
+	inventoryExporter       = flag.String("inventoryExporter", "", "Where the inventory of the clusters, services and jobs is exported: file://<path>, an http(s) URL, s3://<bucket>/<prefix> or bigquery://<project>/<dataset>/<table>. Empty disables the export.")
+	inventoryInterval       = flag.Duration("inventoryInterval", 10*time.Minute, "How often the inventory is exported when inventoryExporter is set.")
+	tracingEndpoint         = flag.String("tracingEndpoint", "", "OTLP gRPC endpoint the traces of the calls are exported to, e.g. http://otel-collector:4317. The http scheme disables TLS. Empty disables tracing.")
+	tracingSampleRatio      = flag.Float64("tracingSampleRatio", 1, "Fraction of the calls without a sampled parent trace which are traced when tracingEndpoint is set.")
+	inventoryLabelKeys      = flag.String("inventoryLabelKeys", "", "Comma separated keys of the labels exported with the inventory, e.g. cost centers. Empty exports all labels.")
+	sessionProxyPort        = flag.String("sessionProxyPort", "", "Port of the session proxy, which forwards the calls of the Ray clients to the head of their interactive session, e.g. :10001. Empty disables the session proxy.")
+	sessionProxyAddress     = flag.String("sessionProxyAddress", "", "Address of the session proxy returned with the interactive sessions, which the Ray clients connect to, e.g. ray://kuberay-apiserver:10001.")
+	kubernetesMaxRetries    = flag.Int("kubernetesMaxRetries", 3, "Number of times a call to the Kubernetes API server which failed transiently, e.g. throttled, unavailable, timed out or conflicting, is retried with exponential backoff. Zero disables the retries.")
+	kubernetesRetryBackoff  = flag.Duration("kubernetesRetryBackoff", 200*time.Millisecond, "Delay before the first retry of a call to the Kubernetes API server, which doubles at every retry up to 5s.")
+	kubernetesCallTimeout   = flag.Duration("kubernetesCallTimeout", 30*time.Second, "Timeout of every attempt of a call to the Kubernetes API server, within the deadline of the request. Zero keeps the deadline of the request only.")
+	shutdownDelay           = flag.Duration("shutdownDelay", 5*time.Second, "How long the API server keeps serving new calls after SIGTERM while it reports not ready, so that the load balancers stop sending it calls before it stops accepting them.")
+	shutdownDrainTimeout    = flag.Duration("shutdownDrainTimeout", 20*time.Second, "How long the calls in flight are given to finish once the API server stops accepting new calls, before they are cancelled.")
+	healthy                 int32
 )
 
+// shutdownFlushTimeout is how long the audit records and the traces are given to be flushed once the calls are drained.
+const shutdownFlushTimeout = 5 * time.Second
+
 func main() {
 	flag.Parse()
 
@@ -50,27 +107,264 @@ func main() {
 		_ = flagSet.Set("log_file", *logFile)
 	}
 
-	clientManager := manager.NewClientManager()
-	resourceManager := manager.NewResourceManager(&clientManager)
+	if *workingDirStore != "" && !strings.HasPrefix(*workingDirStore, "file://") {
+		klog.Fatalf("Unknown working directory store %q, expected file://<dir>", *workingDirStore)
+	}
+
+	if err := features.Set(*featureGates); err != nil {
+		klog.Fatalf("Unable to set feature gates: %v", err)
+	}
+	features.LogFeatureGates()
+
+	// The background workers, the informers of the caches included, run until the API server shuts down.
+	ctx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	if *configFilePath != "" {
+		cfg, err := config.LoadFile(*configFilePath)
+		if err != nil {
+			klog.Fatalf("Failed to load API server config: %v", err)
+		}
+		config.Set(cfg)
+		go config.Watch(ctx, *configFilePath, *configPollInterval)
+	}
+
+	shutdownTracing := func(context.Context) error { return nil }
+	if *tracingEndpoint != "" {
+		if *tracingSampleRatio < 0 || *tracingSampleRatio > 1 {
+			klog.Fatal("tracingSampleRatio must be between 0 and 1")
+		}
+		var err error
+		if shutdownTracing, err = tracing.Setup(context.Background(), *tracingEndpoint, *tracingSampleRatio); err != nil {
+			klog.Fatalf("Failed to set up tracing: %v", err)
+		}
+	}
+
+	if *fakeBackendFlag {
+		klog.Warning("Using the in-memory fake backend, resources are not created in Kubernetes")
+	}
+	var exporter manager.InventoryExporter
+	if *inventoryExporter != "" {
+		if *inventoryInterval <= 0 {
+			klog.Fatal("inventoryInterval must be positive when inventoryExporter is set")
+		}
+		var err error
+		if exporter, err = manager.NewInventoryExporter(*inventoryExporter, &http.Client{Timeout: time.Minute}); err != nil {
+			klog.Fatalf("Failed to create the inventory exporter: %v", err)
+		}
+	}
+	var historyStore datastore.Store
+	if *datastoreFlag != "" && !features.Enabled(features.HistoryDB) {
+		klog.Warningf("The history is not recorded in the datastore of --datastore until the %s feature gate is enabled", features.HistoryDB)
+	} else if *datastoreFlag != "" {
+		store, err := datastore.Open(*datastoreFlag)
+		if err != nil {
+			klog.Fatalf("Failed to open the datastore: %v", err)
+		}
+		historyStore = store
+	}
+	var historyEnvelope *encryption.Envelope
+	if *historyEncryptionKey != "" {
+		provider, err := encryption.LoadLocalKeyProvider(*historyEncryptionKey)
+		if err != nil {
+			klog.Fatalf("Failed to load the history encryption key: %v", err)
+		}
+		historyEnvelope = encryption.NewEnvelope(provider)
+	}
+	clientManager, resourceManager := newResourceManager(ctx, "", exporter, historyStore)
+	var kubeContexts []string
+	for _, kubeContext := range strings.Split(*kubeconfigContexts, ",") {
+		if kubeContext = strings.TrimSpace(kubeContext); kubeContext != "" {
+			kubeContexts = append(kubeContexts, kubeContext)
+		}
+	}
+	if len(kubeContexts) > 0 && !features.Enabled(features.MultiClusterRouting) {
+		klog.Warningf("The target clusters of --kubeconfigContexts are ignored until the %s feature gate is enabled", features.MultiClusterRouting)
+	}
+	targets := manager.NewTargetManagers(kubeContexts, func(kubeContext string) *manager.ResourceManager {
+		_, targetManager := newResourceManager(ctx, kubeContext, exporter, historyStore)
+		return targetManager
+	})
+	router := manager.NewTargetRouter(resourceManager, targets)
 
 	atomic.StoreInt32(&healthy, 1)
-	go startRpcServer(resourceManager)
-	startHttpProxy()
-	// See also https://gist.github.com/enricofoltran/10b4a980cd07cb02836f70a4ab3e72d7
+	var certReloader *certs.Reloader
+	if *tlsCertFile != "" || *tlsKeyFile != "" || *tlsClientCAFile != "" {
+		if *tlsCertFile == "" || *tlsKeyFile == "" {
+			klog.Fatal("Both tlsCertFile and tlsKeyFile are required to enable TLS")
+		}
+		var err error
+		if certReloader, err = certs.NewReloader(*tlsCertFile, *tlsKeyFile, *tlsClientCAFile); err != nil {
+			klog.Fatalf("Failed to load the TLS certificate: %v", err)
+		}
+		go certReloader.Watch(ctx, *tlsReloadInterval)
+	}
+	var clientCertificates *interceptor.ClientCertificateAuthenticator
+	if *clientCertIdentity != "" {
+		if !*enableAuth || *tlsClientCAFile == "" {
+			klog.Fatal("clientCertIdentity requires enableAuth and tlsClientCAFile")
+		}
+		var err error
+		if clientCertificates, err = interceptor.NewClientCertificateAuthenticator(*clientCertIdentity, certReloader.IsServerCertificate); err != nil {
+			klog.Fatal(err)
+		}
+	}
+	var authInterceptor *interceptor.AuthInterceptor
+	if *enableAuth {
+		kubernetesClient := clientManager.KubernetesClient()
+		authInterceptor = interceptor.NewAuthInterceptor(
+			interceptor.NewTokenReviewAuthenticator(kubernetesClient.TokenReviewClient()),
+			interceptor.NewSubjectAccessReviewAuthorizer(kubernetesClient.SubjectAccessReviewClient()),
+			clientCertificates)
+	}
+	if *impersonateCallers && !*enableAuth {
+		klog.Fatal("impersonateCallers requires enableAuth")
+	}
+	var auditInterceptor *interceptor.AuditInterceptor
+	var auditLog interceptor.AuditSink
+	if *auditSink != "" {
+		var err error
+		if auditLog, err = interceptor.NewAuditSink(*auditSink); err != nil {
+			klog.Fatalf("Failed to create the audit sink: %v", err)
+		}
+		auditInterceptor = interceptor.NewAuditInterceptor(auditLog)
+	}
+	if *clientRateLimitQPS < 0 || *clientRateLimitBurst < 0 || *maxRequestBytes < 0 {
+		klog.Fatal("clientRateLimitQPS, clientRateLimitBurst and maxRequestBytes can not be negative")
+	}
+	if *clientRateLimitQPS > 0 && *clientRateLimitBurst == 0 {
+		klog.Fatal("clientRateLimitBurst must be positive when clientRateLimitQPS is set")
+	}
+	readinessChecks := []server.ReadinessCheck{{Name: "kubernetes", Check: resourceManager.CheckKubernetesConnectivity}}
+	if historyStore != nil {
+		readinessChecks = append(readinessChecks, server.ReadinessCheck{Name: "datastore", Check: historyStore.Ping})
+	}
+	healthChecker := server.NewHealthChecker(readinessChecks...)
+	healthChecker.Start(ctx, *readinessCheckPeriod)
+	// The session proxy and the gRPC server, by name.
+	grpcServers := map[string]*grpc.Server{}
+	if *sessionProxyPort != "" {
+		grpcServers["session proxy"] = startSessionProxy(router, authInterceptor, certReloader)
+	}
+	grpcServers["gRPC server"] = startRpcServer(router, resourceManager, authInterceptor, auditInterceptor, historyStore, historyEnvelope, healthChecker, certReloader)
+	httpServer := startHttpProxy(ctx, healthChecker, certReloader)
+
 	quit := make(chan os.Signal, 1)
-	// notify about interrupts
-	signal.Notify(quit, os.Interrupt)
-	// Process interrupts
+	signal.Notify(quit, syscall.SIGTERM, os.Interrupt)
+	klog.Infof("Shutting down on %v", <-quit)
+	// The load balancers stop sending calls to the API server once it is not ready, which takes a few probes.
+	atomic.StoreInt32(&healthy, 0)
+	healthChecker.Shutdown()
+	time.Sleep(*shutdownDelay)
+
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), *shutdownDrainTimeout)
+	// The HTTP proxy is stopped first, as its calls are forwarded to the gRPC server.
+	if err := httpServer.Shutdown(drainCtx); err != nil {
+		klog.Warningf("The HTTP proxy did not finish the calls in flight within the drain timeout: %v", err)
+		_ = httpServer.Close()
+	}
+	var wg sync.WaitGroup
+	for name, s := range grpcServers {
+		wg.Add(1)
+		go func(name string, s *grpc.Server) {
+			defer wg.Done()
+			gracefulStop(drainCtx, name, s)
+		}(name, s)
+	}
+	wg.Wait()
+	cancelDrain()
+
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), shutdownFlushTimeout)
+	defer cancelFlush()
+	if auditLog != nil {
+		if err := auditLog.Close(flushCtx); err != nil {
+			klog.Errorf("Failed to flush the audit records: %v", err)
+		}
+	}
+	if err := shutdownTracing(flushCtx); err != nil {
+		klog.Errorf("Failed to flush the traces: %v", err)
+	}
+	stopWorkers()
+	if historyStore != nil {
+		if err := historyStore.Close(); err != nil {
+			klog.Errorf("Failed to close the datastore: %v", err)
+		}
+	}
+	klog.Info("API server stopped")
+}
+
+// gracefulStop stops a gRPC server once the calls in flight finish, or cancels them once ctx is done.
+func gracefulStop(ctx context.Context, name string, s *grpc.Server) {
+	stopped := make(chan struct{})
 	go func() {
-		<-quit
-		klog.Info("Unexpected interrupt")
-		atomic.StoreInt32(&healthy, 0)
+		s.GracefulStop()
+		close(stopped)
 	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		klog.Warningf("The %s did not finish the calls in flight within the drain timeout, cancelling them", name)
+		s.Stop()
+	}
+}
+
+// newResourceManager creates the ResourceManager of the Kubernetes cluster of a kubeconfig context, the default cluster
+// if it is empty, and starts its background workers until ctx is done. The inventory is exported with exporter unless
+// it is nil, and the finished jobs are archived in historyStore unless it is nil.
+func newResourceManager(ctx context.Context, kubeContext string, exporter manager.InventoryExporter, historyStore datastore.Store) (manager.ClientManagerInterface, *manager.ResourceManager) {
+	var clientManager manager.ClientManagerInterface
+	if *fakeBackendFlag {
+		clientManager = manager.NewFakeClientManager(ctx, *fakeStatusInterval)
+	} else {
+		realClientManager := manager.NewClientManagerForContext(kubeContext, client.RetryOptions{
+			MaxRetries:     *kubernetesMaxRetries,
+			InitialBackoff: *kubernetesRetryBackoff,
+			MaxBackoff:     5 * time.Second,
+			CallTimeout:    *kubernetesCallTimeout,
+			OnRetry:        metrics.RecordKubernetesRetry,
+		})
+		clientManager = &realClientManager
+	}
+	resourceManager := manager.NewResourceManager(clientManager)
+	if features.Enabled(features.ResourceCache) {
+		resourceManager.StartResourceCache(ctx, *cacheResyncPeriod, *eventCacheWorkers)
+	} else if features.Enabled(features.EventCache) {
+		resourceManager.StartEventCache(ctx, *eventCacheWorkers)
+	}
+	if *cronJobSyncPeriod > 0 {
+		resourceManager.StartRayCronJobScheduler(ctx, *cronJobSyncPeriod)
+	}
+	if *garbageCollectionPeriod > 0 {
+		resourceManager.StartGarbageCollector(ctx, *garbageCollectionPeriod)
+	}
+	if *notificationSyncPeriod > 0 {
+		manager.NewNotifier(resourceManager, manager.NewNotificationClient()).Start(ctx, *notificationSyncPeriod)
+	}
+	if *rollingRestartPeriod > 0 {
+		resourceManager.StartRollingRestarts(ctx, *rollingRestartPeriod)
+	}
+	if *workerGroupDrainPeriod > 0 {
+		resourceManager.StartWorkerGroupDrains(ctx, *workerGroupDrainPeriod)
+	}
+	if historyStore != nil {
+		resourceManager.StartJobArchiver(ctx, historyStore, kubeContext)
+	}
+	if exporter != nil {
+		options := manager.InventoryOptions{Target: kubeContext}
+		for _, key := range strings.Split(*inventoryLabelKeys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				options.LabelKeys = append(options.LabelKeys, key)
+			}
+		}
+		resourceManager.StartInventoryExporter(ctx, exporter, *inventoryInterval, options)
+	}
+	return clientManager, resourceManager
 }
 
 type RegisterHttpHandlerFromEndpoint func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error
 
-func startRpcServer(resourceManager *manager.ResourceManager) {
+// startRpcServer starts serving the gRPC services in the background, and returns the gRPC server.
+func startRpcServer(router *manager.TargetRouter, resourceManager *manager.ResourceManager, authInterceptor *interceptor.AuthInterceptor, auditInterceptor *interceptor.AuditInterceptor, historyStore datastore.Store, historyEnvelope *encryption.Envelope, healthChecker *server.HealthChecker, certReloader *certs.Reloader) *grpc.Server {
 	klog.Info("Starting gRPC server")
 
 	listener, err := net.Listen("tcp", *rpcPortFlag)
@@ -78,42 +372,142 @@ func startRpcServer(resourceManager *manager.ResourceManager) {
 		klog.Fatalf("Failed to start GPRC server: %v", err)
 	}
 
-	clusterServer := server.NewClusterServer(resourceManager, &server.ClusterServerOptions{CollectMetrics: *collectMetricsFlag})
-	templateServer := server.NewComputeTemplateServer(resourceManager, &server.ComputeTemplateServerOptions{CollectMetrics: *collectMetricsFlag})
-	jobServer := server.NewRayJobServer(resourceManager, &server.JobServerOptions{CollectMetrics: *collectMetricsFlag})
-	jobSubmissionServer := server.NewRayJobSubmissionServiceServer(clusterServer, &server.RayJobSubmissionServiceServerOptions{CollectMetrics: *collectMetricsFlag})
-	serveServer := server.NewRayServiceServer(resourceManager, &server.ServiceServerOptions{CollectMetrics: *collectMetricsFlag})
+	clusterServer := server.NewClusterServer(router, router, &server.ClusterServerOptions{CollectMetrics: *collectMetricsFlag})
+	templateServer := server.NewComputeTemplateServer(router, &server.ComputeTemplateServerOptions{CollectMetrics: *collectMetricsFlag})
+	jobServer := server.NewRayJobServer(router, router, &server.JobServerOptions{CollectMetrics: *collectMetricsFlag, History: historyStore})
+	jobSubmissionServer := server.NewRayJobSubmissionServiceServer(clusterServer, &server.RayJobSubmissionServiceServerOptions{CollectMetrics: *collectMetricsFlag, WorkingDirStore: *workingDirStore})
+	serveServer := server.NewRayServiceServer(router, router, &server.ServiceServerOptions{CollectMetrics: *collectMetricsFlag, History: historyStore})
+	backupServer := server.NewBackupServer(router, &server.BackupServerOptions{CollectMetrics: *collectMetricsFlag})
+	fleetServer := server.NewFleetServer(router, router, router, router, router, router, &server.FleetServerOptions{CollectMetrics: *collectMetricsFlag, History: historyStore})
+	cronJobServer := server.NewRayCronJobServer(router, &server.RayCronJobServerOptions{CollectMetrics: *collectMetricsFlag})
+	serviceTemplateServer := server.NewServiceTemplateServer(router, serveServer, &server.ServiceTemplateServerOptions{CollectMetrics: *collectMetricsFlag})
+	notificationServer := server.NewNotificationServer(router, &server.NotificationServerOptions{CollectMetrics: *collectMetricsFlag})
+	namespaceServer := server.NewNamespaceServer(router, &server.NamespaceServerOptions{CollectMetrics: *collectMetricsFlag})
+	sessionServer := server.NewRaySessionServer(router, &server.RaySessionServerOptions{CollectMetrics: *collectMetricsFlag, ProxyAddress: *sessionProxyAddress})
+	apiServerInfoServer := server.NewApiServerInfoServer(router, &server.ApiServerInfoServerOptions{CollectMetrics: *collectMetricsFlag})
+
+	// The request logger comes first, so that the calls rejected by the other interceptors are logged with it too.
+	streamInterceptors := []grpc.StreamServerInterceptor{interceptor.RequestLoggingStreamInterceptor}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{interceptor.RequestLoggingUnaryInterceptor}
+	if *collectMetricsFlag {
+		streamInterceptors = append(streamInterceptors, metrics.StreamServerInterceptors()...)
+		unaryInterceptors = append(unaryInterceptors, metrics.UnaryServerInterceptors()...)
+	}
+	streamInterceptors = append(streamInterceptors, interceptor.RateLimitStreamInterceptor)
+	unaryInterceptors = append(unaryInterceptors, interceptor.RateLimitUnaryInterceptor)
+	if authInterceptor != nil {
+		streamInterceptors = append(streamInterceptors, authInterceptor.Stream)
+		unaryInterceptors = append(unaryInterceptors, authInterceptor.Unary)
+	}
+	if *clientRateLimitQPS > 0 {
+		// The clients are identified by the user of the authentication.
+		clientRateLimiter := interceptor.NewClientRateLimiter(*clientRateLimitQPS, *clientRateLimitBurst)
+		streamInterceptors = append(streamInterceptors, clientRateLimiter.Stream)
+		unaryInterceptors = append(unaryInterceptors, clientRateLimiter.Unary)
+	}
+	if *impersonateCallers {
+		// The calls to Kubernetes impersonate the user of the authentication.
+		streamInterceptors = append(streamInterceptors, interceptor.ImpersonationStreamInterceptor)
+		unaryInterceptors = append(unaryInterceptors, interceptor.ImpersonationUnaryInterceptor)
+	}
+	// The mutations are limited per user of the authentication.
+	unaryInterceptors = append(unaryInterceptors, interceptor.MutationLimitUnaryInterceptor)
+	if auditInterceptor != nil {
+		// The audit records get the caller identity from the authentication.
+		unaryInterceptors = append(unaryInterceptors, auditInterceptor.Unary)
+	}
+	if historyStore != nil {
+		// The history records get the caller identity from the authentication.
+		unaryInterceptors = append(unaryInterceptors, interceptor.NewHistoryInterceptor(historyStore, historyEnvelope).Unary)
+	}
+	// The conversion hooks see the caller identity of the authentication.
+	streamInterceptors = append(streamInterceptors, interceptor.ConversionHooksStreamInterceptor)
+	unaryInterceptors = append(unaryInterceptors, interceptor.ConversionHooksUnaryInterceptor, interceptor.TargetClusterUnaryInterceptor, interceptor.ApiServerInterceptor)
 
-	s := grpc.NewServer(
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(grpc_prometheus.UnaryServerInterceptor, interceptor.ApiServerInterceptor)),
-		grpc.MaxRecvMsgSize(math.MaxInt32))
+	serverOptions := []grpc.ServerOption{
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+		grpc.MaxRecvMsgSize(maxMessageSize()),
+	}
+	if *tracingEndpoint != "" {
+		serverOptions = append(serverOptions, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+	if certReloader != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(certReloader.ServerConfig())))
+	}
+	s := grpc.NewServer(serverOptions...)
 	api.RegisterClusterServiceServer(s, clusterServer)
 	api.RegisterComputeTemplateServiceServer(s, templateServer)
 	api.RegisterRayJobServiceServer(s, jobServer)
 	api.RegisterRayJobSubmissionServiceServer(s, jobSubmissionServer)
 	api.RegisterRayServeServiceServer(s, serveServer)
+	api.RegisterBackupServiceServer(s, backupServer)
+	api.RegisterFleetServiceServer(s, fleetServer)
+	api.RegisterRayCronJobServiceServer(s, cronJobServer)
+	api.RegisterServiceTemplateServiceServer(s, serviceTemplateServer)
+	api.RegisterNotificationServiceServer(s, notificationServer)
+	api.RegisterNamespaceServiceServer(s, namespaceServer)
+	api.RegisterRaySessionServiceServer(s, sessionServer)
+	api.RegisterApiServerInfoServiceServer(s, apiServerInfoServer)
 
+	// The health service reports the status of the services registered above.
+	healthChecker.Register(s)
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
 	// Make sure all of the Prometheus metrics are initialized.
-	grpc_prometheus.Register(s)
-	// This is to enable `grpc_server_handling_seconds`, otherwise we won't have latency metrics.
-	// see https://github.com/grpc-ecosystem/go-grpc-prometheus/blob/master/README.md#histograms for details.
-	grpc_prometheus.EnableHandlingTimeHistogram()
-	if err := s.Serve(listener); err != nil {
-		klog.Fatalf("Failed to serve gRPC listener: %v", err)
+	if *collectMetricsFlag {
+		metrics.Register(s, resourceManager)
 	}
+	go func() {
+		// Serve returns nil once the server is stopped.
+		if err := s.Serve(listener); err != nil {
+			klog.Fatalf("Failed to serve gRPC listener: %v", err)
+		}
+	}()
 
 	klog.Info("gRPC server started")
+	return s
 }
 
-func startHttpProxy() {
-	klog.Info("Starting Http Proxy")
+// startSessionProxy starts serving the session proxy in the background, which the Ray clients of the interactive
+// sessions connect to, and returns its gRPC server.
+func startSessionProxy(router *manager.TargetRouter, authInterceptor *interceptor.AuthInterceptor, certReloader *certs.Reloader) *grpc.Server {
+	klog.Info("Starting session proxy")
+
+	listener, err := net.Listen("tcp", *sessionProxyPort)
+	if err != nil {
+		klog.Fatalf("Failed to start the session proxy: %v", err)
+	}
+	var authorizer server.SessionAuthorizer
+	if authInterceptor != nil {
+		authorizer = authInterceptor
+	}
+	serverOptions := server.NewSessionProxy(router, authorizer).ServerOptions()
+	if certReloader != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(certReloader.ServerConfig())))
+	}
+	s := grpc.NewServer(serverOptions...)
+	go func() {
+		if err := s.Serve(listener); err != nil {
+			klog.Fatalf("Failed to serve the session proxy listener: %v", err)
+		}
+	}()
+	return s
+}
 
-	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+// outgoingHeaderMatcher returns the delay of the rejected mutations as the standard Retry-After header, and the other
+// header metadata with the default Grpc-Metadata- prefix.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == interceptor.RetryAfterMetadataKey {
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// startHttpProxy starts serving the HTTP proxy of the gRPC services in the background, which keeps its connections to
+// the gRPC server until ctx is done, and returns the HTTP server.
+func startHttpProxy(ctx context.Context, healthChecker *server.HealthChecker, certReloader *certs.Reloader) *http.Server {
+	klog.Info("Starting Http Proxy")
 
 	// Create gRPC HTTP MUX and register services.
 	runtimeMux := runtime.NewServeMux(
@@ -127,13 +521,27 @@ func startHttpProxy() {
 			},
 		}),
 		runtime.WithErrorHandler(runtime.DefaultHTTPErrorHandler),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
 	)
+	// The proxy connects to the gRPC listener over TLS if it is enabled.
+	transportCredentials := insecure.NewCredentials()
+	if certReloader != nil {
+		transportCredentials = credentials.NewTLS(certReloader.ClientConfig())
+	}
 	// Register endpoints
-	registerHttpHandlerFromEndpoint(api.RegisterClusterServiceHandlerFromEndpoint, "ClusterService", ctx, runtimeMux)
-	registerHttpHandlerFromEndpoint(api.RegisterComputeTemplateServiceHandlerFromEndpoint, "ComputeTemplateService", ctx, runtimeMux)
-	registerHttpHandlerFromEndpoint(api.RegisterRayJobServiceHandlerFromEndpoint, "JobService", ctx, runtimeMux)
-	registerHttpHandlerFromEndpoint(api.RegisterRayServeServiceHandlerFromEndpoint, "ServeService", ctx, runtimeMux)
-	registerHttpHandlerFromEndpoint(api.RegisterRayJobSubmissionServiceHandlerFromEndpoint, "RayJobSubmissionService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterClusterServiceHandlerFromEndpoint, transportCredentials, "ClusterService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterComputeTemplateServiceHandlerFromEndpoint, transportCredentials, "ComputeTemplateService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterRayJobServiceHandlerFromEndpoint, transportCredentials, "JobService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterRayServeServiceHandlerFromEndpoint, transportCredentials, "ServeService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterRayJobSubmissionServiceHandlerFromEndpoint, transportCredentials, "RayJobSubmissionService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterBackupServiceHandlerFromEndpoint, transportCredentials, "BackupService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterFleetServiceHandlerFromEndpoint, transportCredentials, "FleetService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterRayCronJobServiceHandlerFromEndpoint, transportCredentials, "RayCronJobService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterServiceTemplateServiceHandlerFromEndpoint, transportCredentials, "ServiceTemplateService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterNotificationServiceHandlerFromEndpoint, transportCredentials, "NotificationService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterNamespaceServiceHandlerFromEndpoint, transportCredentials, "NamespaceService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterRaySessionServiceHandlerFromEndpoint, transportCredentials, "RaySessionService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterApiServerInfoServiceHandlerFromEndpoint, transportCredentials, "ApiServerInfoService", ctx, runtimeMux)
 
 	// Create a top level mux to include both Http gRPC servers and other endpoints like metrics
 	topMux := http.NewServeMux()
@@ -141,14 +549,49 @@ func startHttpProxy() {
 	topMux.Handle("/", runtimeMux)
 	topMux.Handle("/metrics", promhttp.Handler())
 	topMux.HandleFunc("/swagger/", serveSwaggerFile)
+	topMux.HandleFunc("/swagger.json", serveOpenAPISpec)
 	topMux.HandleFunc("/healthz", serveHealth)
+	topMux.HandleFunc("/readyz", healthChecker.ServeReadiness)
 	serveSwaggerUI(topMux)
+	handler := interceptor.ForwardRequestID(topMux)
+	if *maxRequestBytes > 0 {
+		handler = interceptor.LimitRequestBody(handler, *maxRequestBytes)
+	}
+	if *clientCertIdentity != "" {
+		handler = interceptor.ForwardClientCertificate(handler)
+	}
+	if *tracingEndpoint != "" {
+		handler = tracing.WrapHandler(handler)
+	}
 
-	if err := http.ListenAndServe(*httpPortFlag, topMux); err != nil {
-		klog.Fatal(err)
+	httpServer := &http.Server{Addr: *httpPortFlag, Handler: handler}
+	if certReloader != nil {
+		httpServer.TLSConfig = certReloader.ServerConfig()
 	}
+	go func() {
+		var err error
+		if certReloader != nil {
+			// The certificate comes from the TLS config, which reloads it.
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		// ErrServerClosed is returned once the server is shut down.
+		if !errors.Is(err, http.ErrServerClosed) {
+			klog.Fatal(err)
+		}
+	}()
 
 	klog.Info("Http Proxy started")
+	return httpServer
+}
+
+// maxMessageSize returns the maximum size of the gRPC request messages.
+func maxMessageSize() int {
+	if *maxRequestBytes > 0 && *maxRequestBytes < math.MaxInt32 {
+		return int(*maxRequestBytes)
+	}
+	return math.MaxInt32
 }
 
 func serveHealth(w http.ResponseWriter, r *http.Request) {
@@ -181,6 +624,20 @@ func serveSwaggerFile(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, p)
 }
 
+// serveOpenAPISpec serves the OpenAPI specification of all the services, which is embedded in the binary, unlike the
+// per service swagger files.
+func serveOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(kuberayproto.OpenAPISpec); err != nil {
+		klog.Errorf("Failed to serve the OpenAPI specification: %v", err)
+	}
+}
+
 // go-bindata --nocompress --pkg swagger -o pkg/swagger/datafile.go third_party/swagger-ui/...
 // We will need to copy third_party folder to `backend` folder when building images
 func serveSwaggerUI(mux *http.ServeMux) {
@@ -194,9 +651,13 @@ func serveSwaggerUI(mux *http.ServeMux) {
 	mux.Handle(prefix, http.StripPrefix(prefix, fileServer))
 }
 
-func registerHttpHandlerFromEndpoint(handler RegisterHttpHandlerFromEndpoint, serviceName string, ctx context.Context, mux *runtime.ServeMux) {
+func registerHttpHandlerFromEndpoint(handler RegisterHttpHandlerFromEndpoint, transportCredentials credentials.TransportCredentials, serviceName string, ctx context.Context, mux *runtime.ServeMux) {
 	endpoint := "localhost" + *rpcPortFlag
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32))}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32))}
+	if *tracingEndpoint != "" {
+		// The gRPC calls of the proxy continue the traces of the HTTP requests.
+		opts = append(opts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+	}
 
 	if err := handler(ctx, mux, endpoint, opts); err != nil {
 		klog.Fatalf("Failed to register %v handler: %v", serviceName, err)
@@ -0,0 +1,209 @@
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Reloader serves the TLS certificate of the API server from files, and reloads it when the files change, e.g. when
+// cert-manager renews the certificate, without restarting the API server. New connections use the reloaded files,
+// while the established ones keep their certificate.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	// previous is the certificate before the last reload, which the established connections of the HTTP proxy
+	// still present.
+	previous  *tls.Certificate
+	clientCAs *x509.CertPool
+	// contents are the contents of the files at the last reload.
+	contents [][]byte
+}
+
+// NewReloader loads the certificate and the key of the API server, and the CA certificates verifying the client
+// certificates if clientCAFile is set.
+func NewReloader(certFile string, keyFile string, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Watch reloads the files every interval until the context is done. Invalid files are logged and ignored, so that
+// the API server keeps serving the last valid certificate.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		changed, err := r.reload()
+		if err != nil {
+			klog.Errorf("Keeping the current TLS certificate: %v", err)
+		} else if changed {
+			klog.Infof("Reloaded the TLS certificate from %s", r.certFile)
+		}
+	}
+}
+
+// reload loads the files if they changed since the last reload, and returns whether they did.
+func (r *Reloader) reload() (bool, error) {
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		files = append(files, r.clientCAFile)
+	}
+	contents := make([][]byte, len(files))
+	for i, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return false, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		contents[i] = data
+	}
+
+	r.mu.RLock()
+	unchanged := len(contents) == len(r.contents)
+	for i := 0; unchanged && i < len(contents); i++ {
+		unchanged = bytes.Equal(contents[i], r.contents[i])
+	}
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.X509KeyPair(contents[0], contents[1])
+	if err != nil {
+		return false, fmt.Errorf("invalid certificate %s or key %s: %w", r.certFile, r.keyFile, err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(contents[2]) {
+			return false, fmt.Errorf("no CA certificates found in %s", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.previous = r.certificate
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	r.contents = contents
+	return true, nil
+}
+
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.certificate, r.clientCAs
+}
+
+// IsServerCertificate returns whether a certificate presented by a client is the certificate of the API server, i.e.
+// the client is the HTTP proxy, which is the only client with the key of the certificate.
+func (r *Reloader) IsServerCertificate(raw []byte) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, certificate := range []*tls.Certificate{r.certificate, r.previous} {
+		if certificate != nil && bytes.Equal(raw, certificate.Certificate[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServerConfig returns the TLS configuration of the gRPC and HTTP listeners. If the client CAs are set, the clients
+// have to present a certificate signed by them, i.e. mutual TLS.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			certificate, _ := r.current()
+			return certificate, nil
+		},
+		// The configuration is built on every handshake, so that new connections use the reloaded files.
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			certificate, clientCAs := r.current()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*certificate},
+				NextProtos:   []string{"h2", "http/1.1"},
+			}
+			if clientCAs != nil {
+				config.ClientAuth = tls.RequireAnyClientCert
+				config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+					return verifyClientCertificate(rawCerts, certificate, clientCAs)
+				}
+			}
+			return config, nil
+		},
+	}
+}
+
+// ClientConfig returns the TLS configuration of the HTTP proxy connecting to the gRPC listener of the API server. The
+// proxy accepts the certificate of the API server only, and presents the same certificate, so that it passes the
+// client certificate verification of mutual TLS.
+func (r *Reloader) ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		//nolint:gosec // The certificate is compared to the certificate of the API server below instead, since the
+		// proxy connects to localhost, which the certificate usually does not name.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			certificate, _ := r.current()
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], certificate.Certificate[0]) {
+				return errors.New("the gRPC listener does not serve the certificate of the API server")
+			}
+			return nil
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			certificate, _ := r.current()
+			return certificate, nil
+		},
+	}
+}
+
+// verifyClientCertificate verifies that a client certificate is signed by the client CAs, or is the certificate of
+// the API server, which the HTTP proxy presents.
+func verifyClientCertificate(rawCerts [][]byte, own *tls.Certificate, clientCAs *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("no client certificate")
+	}
+	if bytes.Equal(rawCerts[0], own.Certificate[0]) {
+		return nil
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("invalid client certificate: %w", err)
+		}
+		certs[i] = cert
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err
+}
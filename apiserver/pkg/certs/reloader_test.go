@@ -0,0 +1,160 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCertificate creates a certificate signed by the parent, or a self-signed CA certificate if parent is nil.
+func newTestCertificate(t *testing.T, name string, parent *testCertificate, usage x509.ExtKeyUsage) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (c *testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	certificate, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	require.NoError(t, err)
+	return certificate
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// handshake connects to the listener with the client configuration, and returns the error of the server side of the
+// handshake, which is where the client certificate is rejected.
+func handshake(t *testing.T, listener net.Listener, clientConfig *tls.Config) error {
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		serverErr <- conn.(*tls.Conn).Handshake()
+	}()
+	conn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+	if err == nil {
+		defer conn.Close()
+	}
+	return <-serverErr
+}
+
+func TestReloaderMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "ca", nil, x509.ExtKeyUsageAny)
+	server := newTestCertificate(t, "kuberay-apiserver", ca, x509.ExtKeyUsageServerAuth)
+	client := newTestCertificate(t, "client", ca, x509.ExtKeyUsageClientAuth)
+	untrusted := newTestCertificate(t, "untrusted", newTestCertificate(t, "other-ca", nil, x509.ExtKeyUsageAny), x509.ExtKeyUsageClientAuth)
+	writeFile(t, filepath.Join(dir, "tls.crt"), server.certPEM)
+	writeFile(t, filepath.Join(dir, "tls.key"), server.keyPEM)
+	writeFile(t, filepath.Join(dir, "ca.crt"), ca.certPEM)
+
+	reloader, err := NewReloader(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt"))
+	require.NoError(t, err)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", reloader.ServerConfig())
+	require.NoError(t, err)
+	defer listener.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientConfig := func(certificates ...tls.Certificate) *tls.Config {
+		return &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots, ServerName: "kuberay-apiserver", Certificates: certificates}
+	}
+	assert.NoError(t, handshake(t, listener, clientConfig(client.tlsCertificate(t))))
+	assert.Error(t, handshake(t, listener, clientConfig(untrusted.tlsCertificate(t))))
+	assert.Error(t, handshake(t, listener, clientConfig()))
+	// The HTTP proxy presents the certificate of the API server.
+	assert.NoError(t, handshake(t, listener, reloader.ClientConfig()))
+}
+
+func TestReloaderReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	first := newTestCertificate(t, "first", nil, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, first.certPEM)
+	writeFile(t, keyFile, first.keyPEM)
+
+	_, err := NewReloader(certFile, filepath.Join(dir, "missing.key"), "")
+	assert.Error(t, err)
+
+	reloader, err := NewReloader(certFile, keyFile, "")
+	require.NoError(t, err)
+	changed, err := reloader.reload()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	second := newTestCertificate(t, "second", nil, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, second.certPEM)
+	writeFile(t, keyFile, second.keyPEM)
+	changed, err = reloader.reload()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	certificate, clientCAs := reloader.current()
+	assert.Equal(t, second.cert.Raw, certificate.Certificate[0])
+	assert.Nil(t, clientCAs)
+	// The established connections of the HTTP proxy keep presenting the previous certificate.
+	assert.True(t, reloader.IsServerCertificate(second.cert.Raw))
+	assert.True(t, reloader.IsServerCertificate(first.cert.Raw))
+	assert.False(t, reloader.IsServerCertificate(newTestCertificate(t, "other", nil, x509.ExtKeyUsageClientAuth).cert.Raw))
+
+	// A certificate which does not match the key is ignored.
+	writeFile(t, certFile, first.certPEM)
+	_, err = reloader.reload()
+	assert.Error(t, err)
+	certificate, _ = reloader.current()
+	assert.Equal(t, second.cert.Raw, certificate.Certificate[0])
+}
@@ -25,12 +25,13 @@ func (cc RayClusterClient) RayClusterClient(namespace string) rayv1.RayClusterIn
 }
 
 func NewRayClusterClientOrFatal(initConnectionTimeout time.Duration, options util.ClientOptions) ClusterClientInterface {
-	cfg, err := config.GetConfig()
+	cfg, err := config.GetConfigWithContext(options.KubeContext)
 	if err != nil {
 		klog.Fatalf("Failed to create RayCluster client. Error: %v", err)
 	}
 	cfg.QPS = options.QPS
 	cfg.Burst = options.Burst
+	cfg.Wrap(options.WrapTransport)
 
 	rayClusterClient := rayclient.NewForConfigOrDie(cfg).RayV1()
 	return &RayClusterClient{client: rayClusterClient}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	rayfake "github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/fake"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/typed/ray/v1"
+)
+
+// FakeClients holds in-memory Kubernetes and Ray clientsets, so the API server can run without a Kubernetes cluster.
+type FakeClients struct {
+	Ray        *rayfake.Clientset
+	Kubernetes *k8sfake.Clientset
+	// Dynamic serves the OpenShift Routes and the Pod metrics.
+	Dynamic *dynamicfake.FakeDynamicClient
+}
+
+// NewFakeClients creates in-memory clientsets. Namespaces are created on demand when
+// a resource is created in them, so that listing resources in all namespaces works.
+func NewFakeClients(namespaces ...string) *FakeClients {
+	f := &FakeClients{
+		Ray:        rayfake.NewSimpleClientset(),
+		Kubernetes: k8sfake.NewSimpleClientset(),
+		Dynamic: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			RouteGVR:      "RouteList",
+			PodMetricsGVR: "PodMetricsList",
+		}),
+	}
+	for _, namespace := range namespaces {
+		f.ensureNamespace(namespace)
+	}
+	// The fake discovery serves the Ray custom resources of the version of the clientset.
+	f.Kubernetes.Resources = []*metav1.APIResourceList{{
+		GroupVersion: rayv1api.GroupVersion.String(),
+		APIResources: []metav1.APIResource{
+			{Name: "rayclusters", Namespaced: true, Kind: "RayCluster"},
+			{Name: "rayjobs", Namespaced: true, Kind: "RayJob"},
+			{Name: "rayservices", Namespaced: true, Kind: "RayService"},
+		},
+	}}
+
+	reactor := func(action k8stesting.Action) (bool, runtime.Object, error) {
+		f.ensureNamespace(action.GetNamespace())
+		// Set the creation timestamp like the Kubernetes API server does.
+		if createAction, ok := action.(k8stesting.CreateAction); ok {
+			if object, err := meta.Accessor(createAction.GetObject()); err == nil {
+				if creationTimestamp := object.GetCreationTimestamp(); creationTimestamp.IsZero() {
+					object.SetCreationTimestamp(metav1.Now())
+				}
+			}
+		}
+		// Let the default object tracker handle the creation.
+		return false, nil, nil
+	}
+	f.Ray.PrependReactor("create", "*", reactor)
+	f.Kubernetes.PrependReactor("create", "*", reactor)
+	return f
+}
+
+// ensureNamespace adds the namespace directly to the object tracker, because calling the
+// clientset from a reactor would deadlock.
+func (f *FakeClients) ensureNamespace(namespace string) {
+	if namespace == "" {
+		return
+	}
+	gvr := corev1.SchemeGroupVersion.WithResource("namespaces")
+	if _, err := f.Kubernetes.Tracker().Get(gvr, "", namespace); err == nil || !errors.IsNotFound(err) {
+		return
+	}
+	_ = f.Kubernetes.Tracker().Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
+}
+
+func (f *FakeClients) ClusterClient() ClusterClientInterface {
+	return &RayClusterClient{client: dryRunRayV1{f.Ray.RayV1()}}
+}
+
+func (f *FakeClients) JobClient() JobClientInterface {
+	return &RayJobClient{client: f.Ray.RayV1()}
+}
+
+func (f *FakeClients) ServiceClient() ServiceClientInterface {
+	return &RayServiceClient{client: dryRunRayV1{f.Ray.RayV1()}}
+}
+
+func (f *FakeClients) KubernetesClient() KubernetesClientInterface {
+	return &KubernetesClient{
+		coreV1Client:           f.Kubernetes.CoreV1(),
+		authenticationV1Client: f.Kubernetes.AuthenticationV1(),
+		authorizationV1Client:  f.Kubernetes.AuthorizationV1(),
+		networkingV1Client:     f.Kubernetes.NetworkingV1(),
+		policyV1Client:         f.Kubernetes.PolicyV1(),
+		dynamicClient:          f.Dynamic,
+		discoveryClient:        f.Kubernetes.Discovery(),
+	}
+}
+
+// dryRunRayV1 honors the DryRun create, update and patch options, which the fake clientset ignores, so that dry runs
+// do not persist resources in memory.
+type dryRunRayV1 struct {
+	rayv1.RayV1Interface
+}
+
+func (c dryRunRayV1) RayClusters(namespace string) rayv1.RayClusterInterface {
+	return dryRunRayClusters{c.RayV1Interface.RayClusters(namespace)}
+}
+
+func (c dryRunRayV1) RayServices(namespace string) rayv1.RayServiceInterface {
+	return dryRunRayServices{c.RayV1Interface.RayServices(namespace)}
+}
+
+type dryRunRayClusters struct {
+	rayv1.RayClusterInterface
+}
+
+func (c dryRunRayClusters) Create(ctx context.Context, cluster *rayv1api.RayCluster, opts metav1.CreateOptions) (*rayv1api.RayCluster, error) {
+	if len(opts.DryRun) > 0 {
+		return cluster.DeepCopy(), nil
+	}
+	return c.RayClusterInterface.Create(ctx, cluster, opts)
+}
+
+func (c dryRunRayClusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*rayv1api.RayCluster, error) {
+	if len(opts.DryRun) == 0 {
+		return c.RayClusterInterface.Patch(ctx, name, pt, data, opts, subresources...)
+	}
+	cluster, err := c.RayClusterInterface.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	patched := &rayv1api.RayCluster{}
+	if err := dryRunPatch(cluster, pt, data, patched); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+type dryRunRayServices struct {
+	rayv1.RayServiceInterface
+}
+
+func (c dryRunRayServices) Create(ctx context.Context, service *rayv1api.RayService, opts metav1.CreateOptions) (*rayv1api.RayService, error) {
+	if len(opts.DryRun) > 0 {
+		return service.DeepCopy(), nil
+	}
+	return c.RayServiceInterface.Create(ctx, service, opts)
+}
+
+func (c dryRunRayServices) Update(ctx context.Context, service *rayv1api.RayService, opts metav1.UpdateOptions) (*rayv1api.RayService, error) {
+	if len(opts.DryRun) > 0 {
+		if _, err := c.RayServiceInterface.Get(ctx, service.Name, metav1.GetOptions{}); err != nil {
+			return nil, err
+		}
+		return service.DeepCopy(), nil
+	}
+	return c.RayServiceInterface.Update(ctx, service, opts)
+}
+
+// dryRunPatch applies a JSON or merge patch to object, and decodes the patched object into patched.
+func dryRunPatch(object runtime.Object, pt types.PatchType, data []byte, patched runtime.Object) error {
+	current, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	var updated []byte
+	switch pt {
+	case types.JSONPatchType:
+		patch, err := jsonpatch.DecodePatch(data)
+		if err != nil {
+			return errors.NewBadRequest(err.Error())
+		}
+		if updated, err = patch.Apply(current); err != nil {
+			return errors.NewBadRequest(err.Error())
+		}
+	case types.MergePatchType:
+		if updated, err = jsonpatch.MergePatch(current, data); err != nil {
+			return errors.NewBadRequest(err.Error())
+		}
+	default:
+		return errors.NewBadRequest(fmt.Sprintf("unsupported dry run patch type %s", pt))
+	}
+	return json.Unmarshal(updated, patched)
+}
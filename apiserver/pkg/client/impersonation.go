@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"k8s.io/client-go/transport"
+)
+
+type impersonationKey struct{}
+
+// WithImpersonation returns a copy of ctx whose calls to Kubernetes impersonate a user, so that Kubernetes authorizes
+// them with the RBAC permissions of the user and records the user in its audit log, with the API server as the
+// impersonator.
+func WithImpersonation(ctx context.Context, user transport.ImpersonationConfig) context.Context {
+	return context.WithValue(ctx, impersonationKey{}, &user)
+}
+
+// WithoutImpersonation returns a copy of ctx whose calls to Kubernetes are made by the API server itself, for the
+// reads it makes on its own behalf, e.g. of the Nodes, which users are rarely allowed to read.
+func WithoutImpersonation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, impersonationKey{}, (*transport.ImpersonationConfig)(nil))
+}
+
+// ImpersonationFromContext returns the user impersonated by the calls to Kubernetes made with ctx, if any.
+func ImpersonationFromContext(ctx context.Context) (transport.ImpersonationConfig, bool) {
+	user, _ := ctx.Value(impersonationKey{}).(*transport.ImpersonationConfig)
+	if user == nil {
+		return transport.ImpersonationConfig{}, false
+	}
+	return *user, true
+}
+
+// impersonationTransport sets the Impersonate-User, Impersonate-Group, Impersonate-Uid and Impersonate-Extra headers
+// of the calls made with a context of WithImpersonation. The other calls are made by the API server itself.
+type impersonationTransport struct {
+	next http.RoundTripper
+}
+
+// NewImpersonationTransport wraps the transport of a Kubernetes client with the impersonation of the users of the
+// contexts of the calls.
+func NewImpersonationTransport(next http.RoundTripper) http.RoundTripper {
+	return &impersonationTransport{next: next}
+}
+
+func (t *impersonationTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	user, ok := ImpersonationFromContext(request.Context())
+	if !ok {
+		return t.next.RoundTrip(request)
+	}
+	return transport.NewImpersonatingRoundTripper(user, t.next).RoundTrip(request)
+}
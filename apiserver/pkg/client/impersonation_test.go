@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/transport"
+)
+
+func TestImpersonationTransport(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewImpersonationTransport(http.DefaultTransport)}
+	get := func(ctx context.Context) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		response, err := client.Do(request)
+		require.NoError(t, err)
+		response.Body.Close()
+	}
+
+	get(context.Background())
+	assert.Empty(t, headers.Get(transport.ImpersonateUserHeader))
+
+	ctx := WithImpersonation(context.Background(), transport.ImpersonationConfig{UserName: "alice", Groups: []string{"team-a", "developers"}})
+	get(ctx)
+	assert.Equal(t, "alice", headers.Get(transport.ImpersonateUserHeader))
+	assert.Equal(t, []string{"team-a", "developers"}, headers.Values(transport.ImpersonateGroupHeader))
+
+	get(WithoutImpersonation(ctx))
+	assert.Empty(t, headers.Get(transport.ImpersonateUserHeader))
+	assert.Empty(t, headers.Values(transport.ImpersonateGroupHeader))
+}
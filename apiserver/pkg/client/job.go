@@ -25,12 +25,13 @@ func (cc RayJobClient) RayJobClient(namespace string) rayv1.RayJobInterface {
 }
 
 func NewRayJobClientOrFatal(initConnectionTimeout time.Duration, options util.ClientOptions) JobClientInterface {
-	cfg, err := config.GetConfig()
+	cfg, err := config.GetConfigWithContext(options.KubeContext)
 	if err != nil {
 		klog.Fatalf("Failed to create RayCluster client. Error: %v", err)
 	}
 	cfg.QPS = options.QPS
 	cfg.Burst = options.Burst
+	cfg.Wrap(options.WrapTransport)
 
 	rayJobClient := rayclient.NewForConfigOrDie(cfg).RayV1()
 	return &RayJobClient{client: rayJobClient}
@@ -7,19 +7,52 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	authenticationv1 "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	networkingv1 "k8s.io/client-go/kubernetes/typed/networking/v1"
+	policyv1 "k8s.io/client-go/kubernetes/typed/policy/v1"
 )
 
+// RouteGVR is the resource of OpenShift Routes, which are operated through the dynamic client so that the apiserver
+// does not depend on the OpenShift API.
+var RouteGVR = schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}
+
+// PodMetricsGVR is the resource of the Pod metrics of the metrics server, which are read through the dynamic client so
+// that the apiserver does not depend on the metrics API.
+var PodMetricsGVR = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+
 type KubernetesClientInterface interface {
 	PodClient(namespace string) v1.PodInterface
 	ConfigMapClient(namespace string) v1.ConfigMapInterface
+	SecretClient(namespace string) v1.SecretInterface
+	ServiceClient(namespace string) v1.ServiceInterface
 	NamespaceClient() v1.NamespaceInterface
+	NodeClient() v1.NodeInterface
+	ResourceQuotaClient(namespace string) v1.ResourceQuotaInterface
 	EventsClient(namespace string) v1.EventInterface
+	NetworkPolicyClient(namespace string) networkingv1.NetworkPolicyInterface
+	IngressClient(namespace string) networkingv1.IngressInterface
+	PodDisruptionBudgetClient(namespace string) policyv1.PodDisruptionBudgetInterface
+	RouteClient(namespace string) dynamic.ResourceInterface
+	PodMetricsClient(namespace string) dynamic.ResourceInterface
+	TokenReviewClient() authenticationv1.TokenReviewInterface
+	SubjectAccessReviewClient() authorizationv1.SubjectAccessReviewInterface
+	DiscoveryClient() discovery.DiscoveryInterface
 }
 
 type KubernetesClient struct {
-	coreV1Client v1.CoreV1Interface
+	coreV1Client           v1.CoreV1Interface
+	authenticationV1Client authenticationv1.AuthenticationV1Interface
+	authorizationV1Client  authorizationv1.AuthorizationV1Interface
+	networkingV1Client     networkingv1.NetworkingV1Interface
+	policyV1Client         policyv1.PolicyV1Interface
+	dynamicClient          dynamic.Interface
+	discoveryClient        discovery.DiscoveryInterface
 }
 
 func (c *KubernetesClient) PodClient(namespace string) v1.PodInterface {
@@ -30,26 +63,87 @@ func (c *KubernetesClient) ConfigMapClient(namespace string) v1.ConfigMapInterfa
 	return c.coreV1Client.ConfigMaps(namespace)
 }
 
+func (c *KubernetesClient) SecretClient(namespace string) v1.SecretInterface {
+	return c.coreV1Client.Secrets(namespace)
+}
+
+func (c *KubernetesClient) ServiceClient(namespace string) v1.ServiceInterface {
+	return c.coreV1Client.Services(namespace)
+}
+
 func (c *KubernetesClient) EventsClient(namespace string) v1.EventInterface {
 	return c.coreV1Client.Events(namespace)
 }
 
+func (c *KubernetesClient) NetworkPolicyClient(namespace string) networkingv1.NetworkPolicyInterface {
+	return c.networkingV1Client.NetworkPolicies(namespace)
+}
+
+func (c *KubernetesClient) IngressClient(namespace string) networkingv1.IngressInterface {
+	return c.networkingV1Client.Ingresses(namespace)
+}
+
+func (c *KubernetesClient) PodDisruptionBudgetClient(namespace string) policyv1.PodDisruptionBudgetInterface {
+	return c.policyV1Client.PodDisruptionBudgets(namespace)
+}
+
+func (c *KubernetesClient) RouteClient(namespace string) dynamic.ResourceInterface {
+	return c.dynamicClient.Resource(RouteGVR).Namespace(namespace)
+}
+
+func (c *KubernetesClient) PodMetricsClient(namespace string) dynamic.ResourceInterface {
+	return c.dynamicClient.Resource(PodMetricsGVR).Namespace(namespace)
+}
+
 func (c *KubernetesClient) NamespaceClient() v1.NamespaceInterface {
 	return c.coreV1Client.Namespaces()
 }
 
+func (c *KubernetesClient) NodeClient() v1.NodeInterface {
+	return c.coreV1Client.Nodes()
+}
+
+func (c *KubernetesClient) ResourceQuotaClient(namespace string) v1.ResourceQuotaInterface {
+	return c.coreV1Client.ResourceQuotas(namespace)
+}
+
+func (c *KubernetesClient) TokenReviewClient() authenticationv1.TokenReviewInterface {
+	return c.authenticationV1Client.TokenReviews()
+}
+
+func (c *KubernetesClient) SubjectAccessReviewClient() authorizationv1.SubjectAccessReviewInterface {
+	return c.authorizationV1Client.SubjectAccessReviews()
+}
+
+func (c *KubernetesClient) DiscoveryClient() discovery.DiscoveryInterface {
+	return c.discoveryClient
+}
+
 // CreateKubernetesCoreOrFatal creates a new client for the Kubernetes pod.
 func CreateKubernetesCoreOrFatal(initConnectionTimeout time.Duration, options util.ClientOptions) KubernetesClientInterface {
-	cfg, err := config.GetConfig()
+	cfg, err := config.GetConfigWithContext(options.KubeContext)
 	if err != nil {
 		klog.Fatalf("Failed to create TokenReview client. Error: %v", err)
 	}
 	cfg.QPS = options.QPS
 	cfg.Burst = options.Burst
+	cfg.Wrap(options.WrapTransport)
 
 	clientSet, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		klog.Fatalf("Failed to create pod client. Error: %v", err)
 	}
-	return &KubernetesClient{clientSet.CoreV1()}
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		klog.Fatalf("Failed to create dynamic client. Error: %v", err)
+	}
+	return &KubernetesClient{
+		coreV1Client:           clientSet.CoreV1(),
+		authenticationV1Client: clientSet.AuthenticationV1(),
+		authorizationV1Client:  clientSet.AuthorizationV1(),
+		networkingV1Client:     clientSet.NetworkingV1(),
+		policyV1Client:         clientSet.PolicyV1(),
+		dynamicClient:          dynamicClient,
+		discoveryClient:        clientSet.Discovery(),
+	}
 }
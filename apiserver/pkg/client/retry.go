@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Reasons of the retries of the calls to Kubernetes.
+const (
+	RetryReasonTooManyRequests = "too_many_requests"
+	RetryReasonUnavailable     = "unavailable"
+	RetryReasonTimeout         = "timeout"
+	RetryReasonConflict        = "conflict"
+)
+
+// RetryOptions configures the retries of the calls to the Kubernetes API server, so that its transient failures are
+// not returned to the users.
+type RetryOptions struct {
+	// MaxRetries is the number of times a failed call is retried, zero disables the retries.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry, which doubles at every retry up to MaxBackoff. A Retry-After
+	// header of the Kubernetes API server takes precedence.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// CallTimeout bounds every attempt of a call, within the deadline of its context. Zero keeps the deadline of the
+	// context only.
+	CallTimeout time.Duration
+	// OnRetry is called before every retry with the method of the call and the reason of the retry, unless it is nil.
+	OnRetry func(method string, reason string)
+}
+
+// retryTransport retries the calls to the Kubernetes API server which failed transiently. The calls are retried while
+// they can succeed within the deadline of their context, which is the deadline of the RPC they are made for:
+//   - 429 Too Many Requests and 503 Service Unavailable, which the API server returns before processing the call.
+//   - Timeouts and connection failures of the reads, which have no effect if they are repeated.
+//   - 409 Conflicts of the patches, which the API server applies to the latest version of the object. The conflicts
+//     of the updates are not retried, since the update of an outdated object fails again, and the resource version
+//     may be a precondition set by the user.
+type retryTransport struct {
+	next    http.RoundTripper
+	options RetryOptions
+}
+
+// NewRetryTransport wraps the transport of a Kubernetes client with retries. The watches and the followed logs are
+// not retried nor bounded by the call timeout, since they last until their context is done.
+func NewRetryTransport(next http.RoundTripper, options RetryOptions) http.RoundTripper {
+	return &retryTransport{next: next, options: options}
+}
+
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	query := request.URL.Query()
+	if query.Get("watch") == "true" || query.Get("follow") == "true" {
+		return t.next.RoundTrip(request)
+	}
+	ctx := request.Context()
+	backoff := t.options.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		attemptRequest := request
+		if attempt > 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptRequest = request.Clone(ctx)
+			attemptRequest.Body = body
+		}
+		response, err := t.roundTrip(attemptRequest)
+
+		reason := retryReason(request, response, err)
+		if reason == "" || attempt >= t.options.MaxRetries || (request.Body != nil && request.GetBody == nil) {
+			return response, err
+		}
+		delay := backoff
+		if t.options.MaxBackoff > 0 {
+			delay = min(delay, t.options.MaxBackoff)
+		}
+		if after := retryAfter(response); after > 0 {
+			delay = after
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return response, err
+		}
+		if response != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+		if t.options.OnRetry != nil {
+			t.options.OnRetry(request.Method, reason)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// roundTrip makes an attempt of a call, bounded by the call timeout. The timeout also bounds the read of the body of
+// the response.
+func (t *retryTransport) roundTrip(request *http.Request) (*http.Response, error) {
+	if t.options.CallTimeout <= 0 {
+		return t.next.RoundTrip(request)
+	}
+	ctx, cancel := context.WithTimeout(request.Context(), t.options.CallTimeout)
+	response, err := t.next.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// retryReason returns why a call is retried, or an empty string if it is not.
+func retryReason(request *http.Request, response *http.Response, err error) string {
+	if request.Context().Err() != nil {
+		return ""
+	}
+	read := request.Method == http.MethodGet || request.Method == http.MethodHead
+	if err != nil {
+		if !read {
+			return ""
+		}
+		var netErr net.Error
+		if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+			return RetryReasonTimeout
+		}
+		return RetryReasonUnavailable
+	}
+	switch {
+	case response.StatusCode == http.StatusTooManyRequests:
+		return RetryReasonTooManyRequests
+	case response.StatusCode == http.StatusServiceUnavailable:
+		return RetryReasonUnavailable
+	case response.StatusCode == http.StatusGatewayTimeout && read:
+		return RetryReasonTimeout
+	case response.StatusCode == http.StatusConflict && request.Method == http.MethodPatch:
+		return RetryReasonConflict
+	default:
+		return ""
+	}
+}
+
+// retryAfter returns the delay of the Retry-After header of a response, zero if it has none.
+func retryAfter(response *http.Response) time.Duration {
+	if response == nil {
+		return 0
+	}
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// cancelOnClose cancels the context of an attempt once the body of its response is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		statuses         []int
+		expectedStatus   int
+		expectedAttempts int32
+		expectedReasons  []string
+	}{
+		{
+			name:             "A throttled create",
+			method:           http.MethodPost,
+			statuses:         []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusCreated},
+			expectedStatus:   http.StatusCreated,
+			expectedAttempts: 3,
+			expectedReasons:  []string{RetryReasonTooManyRequests, RetryReasonUnavailable},
+		},
+		{
+			name:             "A timed out get",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusGatewayTimeout, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+			expectedReasons:  []string{RetryReasonTimeout},
+		},
+		{
+			name:             "A timed out create",
+			method:           http.MethodPost,
+			statuses:         []int{http.StatusGatewayTimeout, http.StatusCreated},
+			expectedStatus:   http.StatusGatewayTimeout,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "A conflicting patch",
+			method:           http.MethodPatch,
+			statuses:         []int{http.StatusConflict, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+			expectedReasons:  []string{RetryReasonConflict},
+		},
+		{
+			name:             "A conflicting update",
+			method:           http.MethodPut,
+			statuses:         []int{http.StatusConflict, http.StatusOK},
+			expectedStatus:   http.StatusConflict,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "Too many failures",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 3,
+			expectedReasons:  []string{RetryReasonUnavailable, RetryReasonUnavailable},
+		},
+		{
+			name:             "A watch",
+			method:           http.MethodGet,
+			path:             "?watch=true",
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 1,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc // capture range variable
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := attempts.Add(1)
+				// The body of the call is sent again with every attempt.
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, "{}", string(body))
+				w.WriteHeader(tc.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			var reasons []string
+			client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{
+				MaxRetries:     2,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond,
+				CallTimeout:    time.Second,
+				OnRetry: func(method string, reason string) {
+					assert.Equal(t, tc.method, method)
+					reasons = append(reasons, reason)
+				},
+			})}
+			request, err := http.NewRequest(tc.method, server.URL+"/apis/ray.io/v1/namespaces/team-a/rayclusters"+tc.path, strings.NewReader("{}"))
+			require.NoError(t, err)
+			response, err := client.Do(request)
+			require.NoError(t, err)
+			response.Body.Close()
+			assert.Equal(t, tc.expectedStatus, response.StatusCode)
+			assert.Equal(t, tc.expectedAttempts, attempts.Load())
+			assert.Equal(t, tc.expectedReasons, reasons)
+		})
+	}
+}
+
+func TestRetryTransportDeadline(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	// The retry after 10s would miss the deadline of the call, so the throttling is returned right away.
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{MaxRetries: 3, InitialBackoff: time.Millisecond})}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	response, err := client.Do(request)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	assert.Equal(t, int32(1), attempts.Load())
+}
@@ -25,12 +25,13 @@ func (cc RayServiceClient) RayServiceClient(namespace string) rayv1.RayServiceIn
 }
 
 func NewRayServiceClientOrFatal(initConnectionTimeout time.Duration, options util.ClientOptions) ServiceClientInterface {
-	cfg, err := config.GetConfig()
+	cfg, err := config.GetConfigWithContext(options.KubeContext)
 	if err != nil {
 		klog.Fatalf("Failed to create RayService client. Error: %v", err)
 	}
 	cfg.QPS = options.QPS
 	cfg.Burst = options.Burst
+	cfg.Wrap(options.WrapTransport)
 
 	rayServiceClient := rayclient.NewForConfigOrDie(cfg).RayV1()
 	return &RayServiceClient{client: rayServiceClient}
@@ -0,0 +1,472 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// Config contains the API server policies which can be changed at runtime without
+// restarting the process. It is usually mounted from a ConfigMap.
+type Config struct {
+	// Defaults applied to resources created through the API server.
+	Defaults Defaults `json:"defaults,omitempty"`
+
+	// Quotas on the number of resources managed by the API server and on the compute resources they request.
+	Quotas Quotas `json:"quotas,omitempty"`
+
+	// Allowlists restricting what users can create through the API server.
+	Allowlists Allowlists `json:"allowlists,omitempty"`
+
+	// RateLimits applied to the gRPC and HTTP endpoints.
+	RateLimits RateLimits `json:"rateLimits,omitempty"`
+
+	// MutationLimits bound the creates, updates and deletes of every namespace and of every user.
+	MutationLimits MutationLimits `json:"mutationLimits,omitempty"`
+
+	// RoleBindings grant the built-in roles of the API server. They are only enforced when
+	// authentication is enabled.
+	RoleBindings []RoleBinding `json:"roleBindings,omitempty"`
+
+	// Validations are optional checks of the resources created through the API server.
+	Validations Validations `json:"validations,omitempty"`
+
+	// NamespaceTemplate sets up the namespaces of the teams onboarding to Ray with EnsureNamespace.
+	NamespaceTemplate NamespaceTemplate `json:"namespaceTemplate,omitempty"`
+
+	// AdmissionWebhooks validate and mutate the clusters, jobs and services created or updated through the API
+	// server before they are submitted to Kubernetes, in the order of the list.
+	AdmissionWebhooks []AdmissionWebhook `json:"admissionWebhooks,omitempty"`
+}
+
+// Defaults contains default values applied when the request does not specify them.
+type Defaults struct {
+	// ImageRepository is used to build the Ray image of a group when no image is specified.
+	// Defaults to rayproject/ray if empty.
+	ImageRepository string `json:"imageRepository,omitempty"`
+
+	// HeadGroup and WorkerGroup are the defaulting profiles of the head group and of every worker group.
+	HeadGroup   GroupDefaults `json:"headGroup,omitempty"`
+	WorkerGroup GroupDefaults `json:"workerGroup,omitempty"`
+}
+
+// GroupDefaults fills in the fields omitted by the head or worker groups of the clusters, jobs and services before
+// their custom resources are created. The values of the request always win, the maps are merged key by key.
+type GroupDefaults struct {
+	// RayStartParams are added to the ray start params of the group, e.g. dashboard-host: 0.0.0.0 for the head.
+	RayStartParams map[string]string `json:"rayStartParams,omitempty"`
+	// Labels and Annotations are added to the pods of the group.
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Validate returns an error if a ray start param, label or annotation of the defaulting profile is invalid.
+func (d GroupDefaults) Validate() error {
+	for key := range d.RayStartParams {
+		if key == "" {
+			return fmt.Errorf("ray start params can not have an empty key")
+		}
+	}
+	return validateMetadata(d.Labels, d.Annotations)
+}
+
+func validateMetadata(labels map[string]string, annotations map[string]string) error {
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value of label %s: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	for key := range annotations {
+		if errs := validation.IsQualifiedName(strings.ToLower(key)); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation key %q: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// NamespaceTemplate is applied to the namespaces by EnsureNamespace. A missing namespace is created with the labels
+// and annotations, and the resource quota, network policies and compute templates are created when they are missing,
+// so that the changes made to them afterwards are kept.
+type NamespaceTemplate struct {
+	// Labels and Annotations are added to the namespaces, e.g. the labels selected by the policies of the cluster.
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ResourceQuota is the hard limits of the ResourceQuota created in the namespaces, e.g. requests.cpu: "64".
+	// Empty creates no ResourceQuota.
+	ResourceQuota corev1.ResourceList `json:"resourceQuota,omitempty"`
+
+	// NetworkPolicies are created in the namespaces, e.g. to isolate the Ray Pods from the other namespaces.
+	NetworkPolicies []NamespaceNetworkPolicy `json:"networkPolicies,omitempty"`
+
+	// ComputeTemplates are created in the namespaces, so that the teams can create clusters right away.
+	ComputeTemplates []NamespaceComputeTemplate `json:"computeTemplates,omitempty"`
+}
+
+// NamespaceNetworkPolicy is a NetworkPolicy of the namespace template.
+type NamespaceNetworkPolicy struct {
+	Name string                         `json:"name"`
+	Spec networkingv1.NetworkPolicySpec `json:"spec"`
+}
+
+// NamespaceComputeTemplate is a compute template of the namespace template.
+type NamespaceComputeTemplate struct {
+	Name string `json:"name"`
+	// CPU is the number of CPUs and Memory the GiB of memory of the Pods.
+	CPU    uint32 `json:"cpu"`
+	Memory uint32 `json:"memory"`
+	// GPU is the number of GPUs of the Pods, of the GPUAccelerator resource, nvidia.com/gpu if empty.
+	GPU            uint32 `json:"gpu,omitempty"`
+	GPUAccelerator string `json:"gpuAccelerator,omitempty"`
+}
+
+// Validate returns an error if a label, annotation or resource name of the namespace template is invalid.
+func (t NamespaceTemplate) Validate() error {
+	if err := validateMetadata(t.Labels, t.Annotations); err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	for _, policy := range t.NetworkPolicies {
+		if errs := validation.IsDNS1123Subdomain(policy.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid network policy name %q: %s", policy.Name, strings.Join(errs, ", "))
+		}
+		if names[policy.Name] {
+			return fmt.Errorf("duplicate network policy %s", policy.Name)
+		}
+		names[policy.Name] = true
+	}
+	names = map[string]bool{}
+	for _, template := range t.ComputeTemplates {
+		if errs := validation.IsDNS1123Subdomain(template.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid compute template name %q: %s", template.Name, strings.Join(errs, ", "))
+		}
+		if names[template.Name] {
+			return fmt.Errorf("duplicate compute template %s", template.Name)
+		}
+		names[template.Name] = true
+		if template.CPU == 0 || template.Memory == 0 {
+			return fmt.Errorf("the cpu and memory of compute template %s must be positive", template.Name)
+		}
+	}
+	return nil
+}
+
+// Quotas limits the number of resources per namespace. Zero means unlimited.
+type Quotas struct {
+	MaxClustersPerNamespace int `json:"maxClustersPerNamespace,omitempty"`
+	MaxJobsPerNamespace     int `json:"maxJobsPerNamespace,omitempty"`
+	MaxServicesPerNamespace int `json:"maxServicesPerNamespace,omitempty"`
+
+	// The limits below apply to the sum of the resources of the head and worker Pods of all the RayClusters in the
+	// namespace, including the clusters of RayJobs and RayServices which are not created yet.
+	MaxCPUsPerNamespace      int `json:"maxCPUsPerNamespace,omitempty"`
+	MaxGPUsPerNamespace      int `json:"maxGPUsPerNamespace,omitempty"`
+	MaxMemoryGiBPerNamespace int `json:"maxMemoryGiBPerNamespace,omitempty"`
+}
+
+// ResourceQuotasEnabled returns whether the compute resources of the namespaces are limited.
+func (q Quotas) ResourceQuotasEnabled() bool {
+	return q.MaxCPUsPerNamespace > 0 || q.MaxGPUsPerNamespace > 0 || q.MaxMemoryGiBPerNamespace > 0
+}
+
+// Allowlists restricts namespaces and images. An empty list allows everything.
+type Allowlists struct {
+	// Namespaces in which resources can be created.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// ImageRepositories is a list of image repositories that Ray images must be in, e.g. `rayproject/ray`, which
+	// allows `rayproject/ray:2.9.0` but not `rayproject/ray-ml:2.9.0`. A trailing `/` allows a whole registry or path.
+	ImageRepositories []string `json:"imageRepositories,omitempty"`
+
+	// SchedulerNames which head and worker groups can hand their pods to. Groups which don't
+	// set a scheduler name use the default scheduler and are always allowed.
+	SchedulerNames []string `json:"schedulerNames,omitempty"`
+}
+
+// RateLimits configures a token bucket shared by all API calls. Zero QPS disables rate limiting.
+type RateLimits struct {
+	QPS   float64 `json:"qps,omitempty"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+// MutationLimits protect the API server and the operator from the bursts of creates, updates and deletes of bulk
+// client scripts, independently of the Kubernetes quotas. Zero means unlimited.
+type MutationLimits struct {
+	// MaxInFlightCreatesPerNamespace is the number of creates handled at the same time in a namespace.
+	MaxInFlightCreatesPerNamespace int `json:"maxInFlightCreatesPerNamespace,omitempty"`
+
+	// MutationsPerMinutePerNamespace and MutationsPerMinutePerUser are the creates, updates and deletes allowed every
+	// minute in a namespace and to a user, who can make them all in a burst.
+	MutationsPerMinutePerNamespace int `json:"mutationsPerMinutePerNamespace,omitempty"`
+	MutationsPerMinutePerUser      int `json:"mutationsPerMinutePerUser,omitempty"`
+
+	// MaxQueueSeconds is how long an excess call waits for its turn before it is rejected. Zero rejects it right away.
+	MaxQueueSeconds int `json:"maxQueueSeconds,omitempty"`
+}
+
+// Modes of the node capacity validation.
+const (
+	// NodeCapacityWarn returns the Pods which can never be scheduled as warnings.
+	NodeCapacityWarn = "warn"
+	// NodeCapacityReject fails the creation of the resources with Pods which can never be scheduled.
+	NodeCapacityReject = "reject"
+)
+
+// Validations configures optional checks of the resources created through the API server.
+type Validations struct {
+	// NodeCapacity checks that every Pod of the compute templates and clusters being created fits on the allocatable
+	// resources of a Node accepting it, including the extended resources like nvidia.com/gpu, so that a Pod which can
+	// never be scheduled is caught when it is created. One of warn or reject, empty disables the check. It does not
+	// suit the Kubernetes clusters whose Nodes are added by an autoscaler.
+	NodeCapacity string `json:"nodeCapacity,omitempty"`
+}
+
+// Failure policies of the admission webhooks.
+const (
+	// AdmissionFailurePolicyFail rejects the request when the webhook can't be called or answers an error.
+	AdmissionFailurePolicyFail = "Fail"
+	// AdmissionFailurePolicyIgnore admits the request when the webhook can't be called or answers an error.
+	AdmissionFailurePolicyIgnore = "Ignore"
+)
+
+// Kinds of the resources sent to the admission webhooks.
+var admissionKinds = []string{"RayCluster", "RayJob", "RayService"}
+
+// AdmissionWebhook is an HTTP endpoint enforcing the policies of the platform, e.g. the allowed image registries or
+// the labels of the cost centers. It receives a POST with the operation and the custom resource, and answers whether
+// the resource is allowed, optionally with a JSON patch of the resource.
+type AdmissionWebhook struct {
+	Name string `json:"name"`
+	// URL is the http or https URL of the webhook.
+	URL string `json:"url"`
+	// Kinds the webhook is called for, among RayCluster, RayJob and RayService. Empty calls it for all of them.
+	Kinds []string `json:"kinds,omitempty"`
+	// TimeoutSeconds bounds the calls of the webhook, between 1 and 30 seconds. Defaults to 10 seconds.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// FailurePolicy is Fail, the default, or Ignore.
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+// AppliesTo returns whether the webhook is called for the resources of the kind.
+func (w AdmissionWebhook) AppliesTo(kind string) bool {
+	return len(w.Kinds) == 0 || slices.Contains(w.Kinds, kind)
+}
+
+// Validate returns an error if the URL, a kind, the timeout or the failure policy of the webhook is invalid.
+func (w AdmissionWebhook) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("an admission webhook has no name")
+	}
+	webhookURL, err := url.Parse(w.URL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return fmt.Errorf("the url of admission webhook %s must be an absolute http or https URL", w.Name)
+	}
+	for _, kind := range w.Kinds {
+		if !slices.Contains(admissionKinds, kind) {
+			return fmt.Errorf("unknown kind %q of admission webhook %s, expected one of %s", kind, w.Name, strings.Join(admissionKinds, ", "))
+		}
+	}
+	if w.TimeoutSeconds < 0 || w.TimeoutSeconds > 30 {
+		return fmt.Errorf("the timeout of admission webhook %s must be between 1 and 30 seconds", w.Name)
+	}
+	switch w.FailurePolicy {
+	case "", AdmissionFailurePolicyFail, AdmissionFailurePolicyIgnore:
+	default:
+		return fmt.Errorf("unknown failure policy %q of admission webhook %s, expected %s or %s", w.FailurePolicy, w.Name, AdmissionFailurePolicyFail, AdmissionFailurePolicyIgnore)
+	}
+	return nil
+}
+
+// Built-in roles of the API server.
+const (
+	// RoleViewer can get and list every resource.
+	RoleViewer = "viewer"
+	// RoleJobSubmitter can also create, submit, stop and delete jobs.
+	RoleJobSubmitter = "job-submitter"
+	// RoleClusterAdmin can call every API.
+	RoleClusterAdmin = "cluster-admin"
+)
+
+// RoleBinding grants a built-in role to users and groups.
+type RoleBinding struct {
+	// Role is one of viewer, job-submitter or cluster-admin.
+	Role   string   `json:"role"`
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+
+	// Namespaces in which the role is granted. An empty list grants the role in all namespaces,
+	// which is required by the calls listing the resources of all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// Parse decodes and validates a YAML or JSON configuration.
+func Parse(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode API server config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadFile reads and parses the configuration file at path.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API server config %s: %w", path, err)
+	}
+	return Parse(data)
+}
+
+// Validate checks that the configuration values are in range.
+func (c *Config) Validate() error {
+	if c.Quotas.MaxClustersPerNamespace < 0 || c.Quotas.MaxJobsPerNamespace < 0 || c.Quotas.MaxServicesPerNamespace < 0 ||
+		c.Quotas.MaxCPUsPerNamespace < 0 || c.Quotas.MaxGPUsPerNamespace < 0 || c.Quotas.MaxMemoryGiBPerNamespace < 0 {
+		return fmt.Errorf("quotas can not be negative")
+	}
+	if c.RateLimits.QPS < 0 || c.RateLimits.Burst < 0 {
+		return fmt.Errorf("rate limits can not be negative")
+	}
+	if c.RateLimits.QPS > 0 && c.RateLimits.Burst == 0 {
+		return fmt.Errorf("rate limit burst must be positive when qps is set")
+	}
+	if c.MutationLimits.MaxInFlightCreatesPerNamespace < 0 || c.MutationLimits.MutationsPerMinutePerNamespace < 0 ||
+		c.MutationLimits.MutationsPerMinutePerUser < 0 || c.MutationLimits.MaxQueueSeconds < 0 {
+		return fmt.Errorf("mutation limits can not be negative")
+	}
+	if err := c.Defaults.HeadGroup.Validate(); err != nil {
+		return fmt.Errorf("head group defaults: %w", err)
+	}
+	if err := c.Defaults.WorkerGroup.Validate(); err != nil {
+		return fmt.Errorf("worker group defaults: %w", err)
+	}
+	if err := c.NamespaceTemplate.Validate(); err != nil {
+		return fmt.Errorf("namespace template: %w", err)
+	}
+	names := map[string]bool{}
+	for _, webhook := range c.AdmissionWebhooks {
+		if err := webhook.Validate(); err != nil {
+			return err
+		}
+		if names[webhook.Name] {
+			return fmt.Errorf("duplicate admission webhook %s", webhook.Name)
+		}
+		names[webhook.Name] = true
+	}
+	switch c.Validations.NodeCapacity {
+	case "", NodeCapacityWarn, NodeCapacityReject:
+	default:
+		return fmt.Errorf("unknown node capacity validation %q, expected %s or %s", c.Validations.NodeCapacity, NodeCapacityWarn, NodeCapacityReject)
+	}
+	for _, binding := range c.RoleBindings {
+		switch binding.Role {
+		case RoleViewer, RoleJobSubmitter, RoleClusterAdmin:
+		default:
+			return fmt.Errorf("unknown role %q, expected %s, %s or %s", binding.Role, RoleViewer, RoleJobSubmitter, RoleClusterAdmin)
+		}
+		if len(binding.Users) == 0 && len(binding.Groups) == 0 {
+			return fmt.Errorf("the binding of role %s has neither users nor groups", binding.Role)
+		}
+	}
+	return nil
+}
+
+// NamespaceAllowed returns whether resources can be created in the given namespace.
+func (c *Config) NamespaceAllowed(namespace string) bool {
+	if len(c.Allowlists.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range c.Allowlists.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// SchedulerNameAllowed returns whether pods can be scheduled by the named scheduler.
+func (c *Config) SchedulerNameAllowed(schedulerName string) bool {
+	if schedulerName == "" || len(c.Allowlists.SchedulerNames) == 0 {
+		return true
+	}
+	for _, name := range c.Allowlists.SchedulerNames {
+		if name == schedulerName {
+			return true
+		}
+	}
+	return false
+}
+
+// ImageAllowed returns whether the image is in one of the allowed image repositories, or in a repository under one
+// of them. The repositories match on their boundaries, so docker.io/rayproject/ray-evil is not in docker.io/rayproject/ray.
+func (c *Config) ImageAllowed(image string) bool {
+	if len(c.Allowlists.ImageRepositories) == 0 {
+		return true
+	}
+	for _, repository := range c.Allowlists.ImageRepositories {
+		if imageInRepository(image, repository) {
+			return true
+		}
+	}
+	return false
+}
+
+// imageInRepository returns whether the image reference is the repository, or the repository followed by a path, a tag
+// or a digest. A colon followed by a path is the port of another registry, e.g. registry.example.com:5000/ray is not in
+// the repository registry.example.com.
+func imageInRepository(image string, repository string) bool {
+	rest, ok := strings.CutPrefix(image, repository)
+	if !ok {
+		return false
+	}
+	if rest == "" || strings.HasSuffix(repository, "/") {
+		return true
+	}
+	switch rest[0] {
+	case '/', '@':
+		return true
+	case ':':
+		return !strings.Contains(rest, "/")
+	}
+	return false
+}
+
+// BoundRoles returns the roles granted to the user or to one of its groups in the given namespace.
+// An empty namespace stands for all namespaces, so only the bindings without namespaces apply.
+func (c *Config) BoundRoles(username string, groups []string, namespace string) []string {
+	var roles []string
+	for _, binding := range c.RoleBindings {
+		if !bindingAppliesTo(binding, username, groups) {
+			continue
+		}
+		if len(binding.Namespaces) > 0 && (namespace == "" || !slices.Contains(binding.Namespaces, namespace)) {
+			continue
+		}
+		roles = append(roles, binding.Role)
+	}
+	return roles
+}
+
+func bindingAppliesTo(binding RoleBinding, username string, groups []string) bool {
+	if slices.Contains(binding.Users, username) {
+		return true
+	}
+	for _, group := range groups {
+		if slices.Contains(binding.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(`
+defaults:
+  imageRepository: registry.example.com/ray
+  headGroup:
+    rayStartParams:
+      dashboard-host: 0.0.0.0
+  workerGroup:
+    labels:
+      team: platform
+quotas:
+  maxClustersPerNamespace: 3
+  maxCPUsPerNamespace: 64
+allowlists:
+  namespaces: [team-a, team-b]
+  imageRepositories: [registry.example.com/]
+  schedulerNames: [volcano]
+rateLimits:
+  qps: 10
+  burst: 20
+mutationLimits:
+  maxInFlightCreatesPerNamespace: 2
+  mutationsPerMinutePerUser: 30
+  maxQueueSeconds: 5
+validations:
+  nodeCapacity: reject
+namespaceTemplate:
+  labels:
+    ray.io/tenant: "true"
+  resourceQuota:
+    requests.cpu: "64"
+  networkPolicies:
+  - name: same-namespace
+    spec:
+      podSelector: {}
+      ingress:
+      - from:
+        - podSelector: {}
+  computeTemplates:
+  - name: small
+    cpu: 2
+    memory: 4
+admissionWebhooks:
+- name: registries
+  url: https://policies.example.com/admit
+  kinds: [RayCluster, RayService]
+  failurePolicy: Ignore
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "registry.example.com/ray", cfg.Defaults.ImageRepository)
+	assert.Equal(t, map[string]string{"dashboard-host": "0.0.0.0"}, cfg.Defaults.HeadGroup.RayStartParams)
+	assert.Equal(t, map[string]string{"team": "platform"}, cfg.Defaults.WorkerGroup.Labels)
+	assert.Equal(t, 3, cfg.Quotas.MaxClustersPerNamespace)
+	assert.Equal(t, 64, cfg.Quotas.MaxCPUsPerNamespace)
+	assert.True(t, cfg.Quotas.ResourceQuotasEnabled())
+	assert.False(t, Quotas{MaxClustersPerNamespace: 3}.ResourceQuotasEnabled())
+	assert.Equal(t, RateLimits{QPS: 10, Burst: 20}, cfg.RateLimits)
+	assert.Equal(t, MutationLimits{MaxInFlightCreatesPerNamespace: 2, MutationsPerMinutePerUser: 30, MaxQueueSeconds: 5}, cfg.MutationLimits)
+	assert.Equal(t, NodeCapacityReject, cfg.Validations.NodeCapacity)
+	assert.Equal(t, "64", cfg.NamespaceTemplate.ResourceQuota.Name(corev1.ResourceRequestsCPU, resource.DecimalSI).String())
+	require.Len(t, cfg.NamespaceTemplate.NetworkPolicies, 1)
+	assert.Len(t, cfg.NamespaceTemplate.NetworkPolicies[0].Spec.Ingress, 1)
+	assert.Equal(t, []NamespaceComputeTemplate{{Name: "small", CPU: 2, Memory: 4}}, cfg.NamespaceTemplate.ComputeTemplates)
+	require.Len(t, cfg.AdmissionWebhooks, 1)
+	assert.Equal(t, AdmissionFailurePolicyIgnore, cfg.AdmissionWebhooks[0].FailurePolicy)
+	assert.True(t, cfg.AdmissionWebhooks[0].AppliesTo("RayService"))
+	assert.False(t, cfg.AdmissionWebhooks[0].AppliesTo("RayJob"))
+	assert.True(t, cfg.NamespaceAllowed("team-a"))
+	assert.False(t, cfg.NamespaceAllowed("team-c"))
+	assert.True(t, cfg.ImageAllowed("registry.example.com/ray:2.9.0"))
+	assert.False(t, cfg.ImageAllowed("rayproject/ray:2.9.0"))
+	assert.True(t, cfg.SchedulerNameAllowed(""))
+	assert.True(t, cfg.SchedulerNameAllowed("volcano"))
+	assert.False(t, cfg.SchedulerNameAllowed("custom-scheduler"))
+
+	_, err = Parse([]byte("unknownField: true"))
+	require.Error(t, err)
+
+	_, err = Parse([]byte("rateLimits:\n  qps: 10\n"))
+	require.Error(t, err)
+
+	_, err = Parse([]byte("quotas:\n  maxGPUsPerNamespace: -1\n"))
+	require.Error(t, err)
+
+	_, err = Parse([]byte("mutationLimits:\n  maxQueueSeconds: -1\n"))
+	require.Error(t, err)
+
+	_, err = Parse([]byte("validations:\n  nodeCapacity: strict\n"))
+	require.Error(t, err)
+
+	_, err = Parse([]byte("defaults:\n  workerGroup:\n    labels:\n      team: platform team\n"))
+	require.Error(t, err)
+
+	_, err = Parse([]byte("namespaceTemplate:\n  computeTemplates:\n  - name: small\n    cpu: 2\n"))
+	require.Error(t, err)
+
+	_, err = Parse([]byte("admissionWebhooks:\n- name: registries\n  url: policies.example.com/admit\n"))
+	require.EqualError(t, err, "the url of admission webhook registries must be an absolute http or https URL")
+
+	_, err = Parse([]byte("admissionWebhooks:\n- name: registries\n  url: https://policies.example.com\n  kinds: [RayCronJob]\n"))
+	require.Error(t, err)
+}
+
+func TestImageAllowed(t *testing.T) {
+	cfg := &Config{Allowlists: Allowlists{ImageRepositories: []string{"docker.io/rayproject/ray", "registry.example.com/", "registry.internal.io"}}}
+	tests := []struct {
+		image   string
+		allowed bool
+	}{
+		{image: "docker.io/rayproject/ray", allowed: true},
+		{image: "docker.io/rayproject/ray:2.9.0", allowed: true},
+		{image: "docker.io/rayproject/ray@sha256:abc", allowed: true},
+		{image: "docker.io/rayproject/ray:2.9.0@sha256:abc", allowed: true},
+		{image: "docker.io/rayproject/ray/gpu:2.9.0", allowed: true},
+		{image: "registry.example.com/team/ray:2.9.0", allowed: true},
+		{image: "registry.internal.io/ray:2.9.0", allowed: true},
+		{image: "docker.io/rayproject/ray-evil:x", allowed: false},
+		{image: "docker.io/rayproject/rayml:2.9.0", allowed: false},
+		{image: "registry.example.com.evil.io/ray:2.9.0", allowed: false},
+		// A registry with a port is another registry.
+		{image: "registry.internal.io:5000/ray:2.9.0", allowed: false},
+		{image: "registry.internal.io:5000/ray@sha256:abc", allowed: false},
+		{image: "registry.example.com:5000/team/ray:2.9.0", allowed: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.image, func(t *testing.T) {
+			assert.Equal(t, tc.allowed, cfg.ImageAllowed(tc.image))
+		})
+	}
+}
+
+func TestBoundRoles(t *testing.T) {
+	cfg, err := Parse([]byte(`
+roleBindings:
+- role: viewer
+  groups: [developers]
+- role: job-submitter
+  users: [alice]
+  namespaces: [team-a]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{RoleViewer, RoleJobSubmitter}, cfg.BoundRoles("alice", []string{"developers"}, "team-a"))
+	assert.Equal(t, []string{RoleViewer}, cfg.BoundRoles("alice", []string{"developers"}, "team-b"))
+	assert.Equal(t, []string{RoleViewer}, cfg.BoundRoles("bob", []string{"developers"}, ""))
+	assert.Empty(t, cfg.BoundRoles("alice", nil, ""))
+
+	_, err = Parse([]byte("roleBindings:\n- role: owner\n  users: [alice]\n"))
+	require.Error(t, err)
+
+	_, err = Parse([]byte("roleBindings:\n- role: viewer\n"))
+	require.Error(t, err)
+}
+
+func TestEmptyConfigAllowsEverything(t *testing.T) {
+	cfg := &Config{}
+	assert.True(t, cfg.NamespaceAllowed("any"))
+	assert.True(t, cfg.ImageAllowed("any/image:latest"))
+	assert.True(t, cfg.SchedulerNameAllowed("custom-scheduler"))
+}
+
+func TestReload(t *testing.T) {
+	t.Cleanup(func() { Set(&Config{}) })
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte("quotas:\n  maxJobsPerNamespace: 1\n"), 0o600))
+	last := reload(path, nil)
+	assert.Equal(t, 1, Get().Quotas.MaxJobsPerNamespace)
+
+	// An unchanged file is not applied again.
+	Set(&Config{})
+	last = reload(path, last)
+	assert.Equal(t, 0, Get().Quotas.MaxJobsPerNamespace)
+
+	require.NoError(t, os.WriteFile(path, []byte("quotas:\n  maxJobsPerNamespace: 2\n"), 0o600))
+	last = reload(path, last)
+	assert.Equal(t, 2, Get().Quotas.MaxJobsPerNamespace)
+
+	// An invalid file keeps the previous configuration.
+	require.NoError(t, os.WriteFile(path, []byte("quotas:\n  maxJobsPerNamespace: -1\n"), 0o600))
+	reload(path, last)
+	assert.Equal(t, 2, Get().Quotas.MaxJobsPerNamespace)
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	klog "k8s.io/klog/v2"
+)
+
+var current atomic.Pointer[Config]
+
+func init() {
+	current.Store(&Config{})
+}
+
+// Get returns the configuration currently in effect. The returned value must not be modified.
+func Get() *Config {
+	return current.Load()
+}
+
+// Set replaces the configuration currently in effect.
+func Set(cfg *Config) {
+	current.Store(cfg)
+}
+
+// Watch polls the configuration file at path and applies it whenever its content changes.
+// Polling is used instead of inotify because ConfigMap volumes are updated through an atomic
+// symlink swap which is not reliably reported for the file itself. An invalid configuration
+// is logged and ignored, so the previous one stays in effect. Watch blocks until ctx is done.
+func Watch(ctx context.Context, path string, interval time.Duration) {
+	var last []byte
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		last = reload(path, last)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reload applies the configuration file if it differs from last, and returns the content
+// that should be compared against on the next call.
+func reload(path string, last []byte) []byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		klog.Errorf("Failed to read API server config %s: %v", path, err)
+		return last
+	}
+	if last != nil && bytes.Equal(data, last) {
+		return last
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		klog.Errorf("Ignoring invalid API server config %s: %v", path, err)
+		return data
+	}
+	Set(cfg)
+	klog.Infof("Applied API server config from %s", path)
+	return data
+}
@@ -0,0 +1,42 @@
+package datastore
+
+import "time"
+
+// ArchivedJob is the final state of a RayJob, archived so that it can still be listed once the RayJob is deleted.
+type ArchivedJob struct {
+	// UID is the UID of the RayJob. A job is archived again with the same UID when it is deleted, which replaces its
+	// previous snapshot.
+	UID           string
+	Namespace     string
+	Name          string
+	User          string
+	TargetCluster string
+	JobStatus     string
+	// EndTime is when the job finished.
+	EndTime time.Time
+	// Snapshot is the archived job, as JSON.
+	Snapshot string
+}
+
+// ArchivedJobQuery selects archived jobs. The empty fields match every job.
+type ArchivedJobQuery struct {
+	Namespace     string
+	User          string
+	TargetCluster string
+	JobStatus     string
+	// FinishedAfter and FinishedBefore select the jobs which finished in [FinishedAfter, FinishedBefore).
+	FinishedAfter  time.Time
+	FinishedBefore time.Time
+	// Limit is the maximum number of jobs returned, the most recently finished ones, or 0 for all of them.
+	Limit int
+}
+
+// Matches returns whether an archived job is selected by the query, ignoring the limit.
+func (q ArchivedJobQuery) Matches(job *ArchivedJob) bool {
+	return (q.Namespace == "" || q.Namespace == job.Namespace) &&
+		(q.User == "" || q.User == job.User) &&
+		q.TargetCluster == job.TargetCluster &&
+		(q.JobStatus == "" || q.JobStatus == job.JobStatus) &&
+		!job.EndTime.Before(q.FinishedAfter) &&
+		(q.FinishedBefore.IsZero() || job.EndTime.Before(q.FinishedBefore))
+}
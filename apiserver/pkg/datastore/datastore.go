@@ -0,0 +1,93 @@
+// Package datastore persists the history of the resources changed through the API server, which is otherwise only
+// kept in the annotations of the custom resources and lost when they are deleted.
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Record is a change of a resource made through the API server.
+type Record struct {
+	Time           time.Time
+	Kind           string
+	Namespace      string
+	Name           string
+	Action         string
+	User           string
+	TargetCluster  string
+	RequestID      string
+	IdempotencyKey string
+	// Payload is the request which made the change, as JSON.
+	Payload string
+}
+
+// Query selects records. The empty fields match every record.
+type Query struct {
+	Kind      string
+	Namespace string
+	Name      string
+	User      string
+	Since     time.Time
+	// Limit is the maximum number of records returned, the most recent ones, or 0 for all of them.
+	Limit int
+}
+
+// Matches returns whether a record is selected by the query, ignoring the limit.
+func (q Query) Matches(record *Record) bool {
+	return (q.Kind == "" || q.Kind == record.Kind) &&
+		(q.Namespace == "" || q.Namespace == record.Namespace) &&
+		(q.Name == "" || q.Name == record.Name) &&
+		(q.User == "" || q.User == record.User) &&
+		!record.Time.Before(q.Since)
+}
+
+// Store records the changes of the resources and lists them, most recent first. It also archives the finished jobs,
+// replacing the previous snapshot of a job with the same UID, and lists them, most recently finished first. Ping
+// returns an error if the store can't be reached.
+type Store interface {
+	Add(ctx context.Context, record *Record) error
+	List(ctx context.Context, query Query) ([]*Record, error)
+	ArchiveJob(ctx context.Context, job *ArchivedJob) error
+	ListArchivedJobs(ctx context.Context, query ArchivedJobQuery) ([]*ArchivedJob, error)
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// Open opens the datastore described by spec: `memory://` keeps the records in memory until the API server stops,
+// `sqlite3://<path>` stores them in a SQLite database file, and `postgres://...` in a PostgreSQL database. The SQLite
+// driver needs cgo, so it is only linked into the API server when it is built with CGO_ENABLED=1.
+func Open(spec string) (Store, error) {
+	switch {
+	case spec == "memory://":
+		return NewMemoryStore(defaultMemoryCapacity), nil
+	case strings.HasPrefix(spec, "sqlite3://"):
+		return openSQLStore("sqlite3", strings.TrimPrefix(spec, "sqlite3://"), questionPlaceholders)
+	case strings.HasPrefix(spec, "postgres://"), strings.HasPrefix(spec, "postgresql://"):
+		return openSQLStore("postgres", spec, dollarPlaceholders)
+	default:
+		return nil, fmt.Errorf("unknown datastore %q, expected memory://, sqlite3://<path> or postgres://<dsn>", spec)
+	}
+}
+
+func openSQLStore(driver string, dsn string, placeholders placeholderStyle) (Store, error) {
+	if !slices.Contains(sql.Drivers(), driver) {
+		return nil, fmt.Errorf("the %s SQL driver is not linked into the API server", driver)
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the %s datastore: %w", driver, err)
+	}
+	store := newSQLStore(db, placeholders)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := store.Migrate(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
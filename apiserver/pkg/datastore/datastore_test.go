@@ -0,0 +1,145 @@
+package datastore
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(3)
+	now := time.Now()
+	for i, record := range []*Record{
+		{Time: now.Add(-3 * time.Minute), Kind: "RayCluster", Namespace: "team-a", Name: "cluster", Action: "CreateCluster"},
+		{Time: now.Add(-time.Minute), Kind: "RayService", Namespace: "team-a", Name: "service", Action: "DeleteRayService", User: "bob"},
+		// Records added late are sorted by time.
+		{Time: now.Add(-2 * time.Minute), Kind: "RayService", Namespace: "team-a", Name: "service", Action: "CreateRayService", User: "alice"},
+		{Time: now, Kind: "RayJob", Namespace: "team-b", Name: "job", Action: "CreateRayJob"},
+	} {
+		require.NoError(t, store.Add(ctx, record), i)
+	}
+
+	records, err := store.List(ctx, Query{})
+	require.NoError(t, err)
+	require.Len(t, records, 3, "The oldest record is dropped")
+	assert.Equal(t, "CreateRayJob", records[0].Action)
+	assert.Equal(t, "DeleteRayService", records[1].Action)
+	assert.Equal(t, "CreateRayService", records[2].Action)
+
+	records, err = store.List(ctx, Query{Kind: "RayService", Namespace: "team-a", Name: "service", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "DeleteRayService", records[0].Action)
+
+	records, err = store.List(ctx, Query{User: "alice", Since: now.Add(-90 * time.Second)})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestMemoryStoreArchivedJobs(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(2)
+	now := time.Now()
+	for i, job := range []*ArchivedJob{
+		{UID: "1", Namespace: "team-a", Name: "old", User: "alice", JobStatus: "SUCCEEDED", EndTime: now.Add(-time.Hour)},
+		{UID: "2", Namespace: "team-a", Name: "failed", User: "bob", JobStatus: "FAILED", EndTime: now.Add(-time.Minute)},
+		// The job archived again when it is deleted replaces its snapshot.
+		{UID: "2", Namespace: "team-a", Name: "failed", User: "bob", JobStatus: "FAILED", EndTime: now.Add(-time.Minute), Snapshot: "deleted"},
+		{UID: "3", Namespace: "team-b", Name: "new", User: "alice", JobStatus: "SUCCEEDED", EndTime: now},
+	} {
+		require.NoError(t, store.ArchiveJob(ctx, job), i)
+	}
+
+	jobs, err := store.ListArchivedJobs(ctx, ArchivedJobQuery{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2, "The job which finished first is dropped")
+	assert.Equal(t, "new", jobs[0].Name)
+	assert.Equal(t, "deleted", jobs[1].Snapshot)
+
+	jobs, err = store.ListArchivedJobs(ctx, ArchivedJobQuery{User: "alice", JobStatus: "SUCCEEDED", FinishedAfter: now.Add(-time.Minute), FinishedBefore: now})
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+	jobs, err = store.ListArchivedJobs(ctx, ArchivedJobQuery{FinishedBefore: now, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "failed", jobs[0].Name)
+}
+
+func TestOpen(t *testing.T) {
+	store, err := Open("memory://")
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	_, err = Open("mongodb://localhost")
+	require.Error(t, err)
+}
+
+// TestPostgresStore runs against the PostgreSQL database of the KUBERAY_TEST_POSTGRES_DSN environment variable.
+func TestPostgresStore(t *testing.T) {
+	dsn := os.Getenv("KUBERAY_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("KUBERAY_TEST_POSTGRES_DSN is not set")
+	}
+	store, err := Open(dsn)
+	require.NoError(t, err)
+	defer store.Close()
+	testSQLStore(t, store)
+}
+
+// testSQLStore checks the records and the archived jobs of an empty SQL datastore.
+func testSQLStore(t *testing.T, store Store) {
+	ctx := context.Background()
+	require.NoError(t, store.Ping(ctx))
+	now := time.Now()
+	for i, record := range []*Record{
+		{Time: now.Add(-2 * time.Minute), Kind: "RayService", Namespace: "team-a", Name: "service", Action: "CreateRayService", User: "alice", Payload: `{"name":"service"}`},
+		{Time: now, Kind: "RayJob", Namespace: "team-b", Name: "job", Action: "CreateRayJob", RequestID: "request"},
+		{Time: now.Add(-time.Minute), Kind: "RayService", Namespace: "team-a", Name: "service", Action: "DeleteRayService", User: "bob"},
+	} {
+		require.NoError(t, store.Add(ctx, record), i)
+	}
+
+	records, err := store.List(ctx, Query{})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "CreateRayJob", records[0].Action)
+	assert.Equal(t, "request", records[0].RequestID)
+	assert.Equal(t, now.UnixNano(), records[0].Time.UnixNano())
+	assert.Equal(t, "CreateRayService", records[2].Action)
+	assert.Equal(t, `{"name":"service"}`, records[2].Payload)
+
+	records, err = store.List(ctx, Query{Kind: "RayService", Namespace: "team-a", Name: "service", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "DeleteRayService", records[0].Action)
+	records, err = store.List(ctx, Query{User: "alice", Since: now.Add(-90 * time.Second)})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	for i, job := range []*ArchivedJob{
+		{UID: "1", Namespace: "team-a", Name: "failed", User: "bob", JobStatus: "FAILED", EndTime: now.Add(-time.Minute)},
+		{UID: "1", Namespace: "team-a", Name: "failed", User: "bob", JobStatus: "FAILED", EndTime: now.Add(-time.Minute), Snapshot: "deleted"},
+		{UID: "2", Namespace: "team-b", Name: "new", User: "alice", JobStatus: "SUCCEEDED", EndTime: now},
+	} {
+		require.NoError(t, store.ArchiveJob(ctx, job), i)
+	}
+	jobs, err := store.ListArchivedJobs(ctx, ArchivedJobQuery{})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2, "The job archived again replaces its snapshot")
+	assert.Equal(t, "new", jobs[0].Name)
+	assert.Equal(t, "deleted", jobs[1].Snapshot)
+	jobs, err = store.ListArchivedJobs(ctx, ArchivedJobQuery{User: "alice", JobStatus: "SUCCEEDED", FinishedBefore: now.Add(-time.Second)})
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestBind(t *testing.T) {
+	statement := "SELECT name FROM resource_history WHERE kind = ? AND name = ?"
+	assert.Equal(t, statement, (&SQLStore{placeholders: questionPlaceholders}).bind(statement))
+	assert.Equal(t, "SELECT name FROM resource_history WHERE kind = $1 AND name = $2", (&SQLStore{placeholders: dollarPlaceholders}).bind(statement))
+}
@@ -0,0 +1,6 @@
+package datastore
+
+import (
+	// The PostgreSQL driver of the postgres:// datastores.
+	_ "github.com/lib/pq"
+)
@@ -0,0 +1,8 @@
+//go:build cgo
+
+package datastore
+
+import (
+	// The SQLite driver of the sqlite3:// datastores, which needs cgo.
+	_ "github.com/mattn/go-sqlite3"
+)
@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// defaultMemoryCapacity is the number of records kept by the memory datastore opened with Open.
+const defaultMemoryCapacity = 10000
+
+// MemoryStore keeps the most recent records in memory, which is enough for tests and for a single API server replica
+// that does not need the history to survive restarts.
+type MemoryStore struct {
+	mu       sync.Mutex
+	capacity int
+	// records are sorted by time, oldest first.
+	records []*Record
+	// archivedJobs are sorted by end time, oldest first.
+	archivedJobs []*ArchivedJob
+}
+
+// NewMemoryStore returns a store keeping at most capacity records and capacity archived jobs, dropping the oldest
+// ones first.
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{capacity: capacity}
+}
+
+func (s *MemoryStore) Add(_ context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *record
+	i := len(s.records)
+	for i > 0 && s.records[i-1].Time.After(record.Time) {
+		i--
+	}
+	s.records = append(s.records, nil)
+	copy(s.records[i+1:], s.records[i:])
+	s.records[i] = &copied
+	if len(s.records) > s.capacity {
+		s.records = s.records[len(s.records)-s.capacity:]
+	}
+	return nil
+}
+
+func (s *MemoryStore) List(_ context.Context, query Query) ([]*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var records []*Record
+	for i := len(s.records) - 1; i >= 0 && (query.Limit <= 0 || len(records) < query.Limit); i-- {
+		if query.Matches(s.records[i]) {
+			copied := *s.records[i]
+			records = append(records, &copied)
+		}
+	}
+	return records, nil
+}
+
+func (s *MemoryStore) ArchiveJob(_ context.Context, job *ArchivedJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.archivedJobs = slices.DeleteFunc(s.archivedJobs, func(archived *ArchivedJob) bool { return archived.UID == job.UID })
+	copied := *job
+	i := len(s.archivedJobs)
+	for i > 0 && s.archivedJobs[i-1].EndTime.After(job.EndTime) {
+		i--
+	}
+	s.archivedJobs = slices.Insert(s.archivedJobs, i, &copied)
+	if len(s.archivedJobs) > s.capacity {
+		s.archivedJobs = s.archivedJobs[len(s.archivedJobs)-s.capacity:]
+	}
+	return nil
+}
+
+func (s *MemoryStore) ListArchivedJobs(_ context.Context, query ArchivedJobQuery) ([]*ArchivedJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var jobs []*ArchivedJob
+	for i := len(s.archivedJobs) - 1; i >= 0 && (query.Limit <= 0 || len(jobs) < query.Limit); i-- {
+		if query.Matches(s.archivedJobs[i]) {
+			copied := *s.archivedJobs[i]
+			jobs = append(jobs, &copied)
+		}
+	}
+	return jobs, nil
+}
+
+func (s *MemoryStore) Ping(context.Context) error {
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
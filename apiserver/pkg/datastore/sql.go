@@ -0,0 +1,228 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// placeholderStyle is how the parameters of the SQL statements are written, which depends on the database.
+type placeholderStyle int
+
+const (
+	// questionPlaceholders are the ? parameters of SQLite and MySQL.
+	questionPlaceholders placeholderStyle = iota
+	// dollarPlaceholders are the $1, $2... parameters of PostgreSQL.
+	dollarPlaceholders
+)
+
+// The time of the records is stored in nanoseconds since the epoch, since the SQL drivers disagree on how to store
+// timestamps.
+const createHistoryTable = `CREATE TABLE IF NOT EXISTS resource_history (
+	time_ns BIGINT NOT NULL,
+	kind VARCHAR(64) NOT NULL,
+	namespace VARCHAR(253) NOT NULL,
+	name VARCHAR(253) NOT NULL,
+	action VARCHAR(128) NOT NULL,
+	user_name TEXT NOT NULL,
+	target_cluster TEXT NOT NULL,
+	request_id TEXT NOT NULL,
+	idempotency_key TEXT NOT NULL,
+	payload TEXT NOT NULL
+)`
+
+const createHistoryIndex = `CREATE INDEX IF NOT EXISTS resource_history_resource ON resource_history (namespace, name, time_ns)`
+
+const createArchivedJobsTable = `CREATE TABLE IF NOT EXISTS archived_ray_jobs (
+	uid VARCHAR(64) NOT NULL PRIMARY KEY,
+	namespace VARCHAR(253) NOT NULL,
+	name VARCHAR(253) NOT NULL,
+	user_name TEXT NOT NULL,
+	target_cluster TEXT NOT NULL,
+	job_status VARCHAR(64) NOT NULL,
+	end_time_ns BIGINT NOT NULL,
+	snapshot TEXT NOT NULL
+)`
+
+const createArchivedJobsIndex = `CREATE INDEX IF NOT EXISTS archived_ray_jobs_end_time ON archived_ray_jobs (namespace, end_time_ns)`
+
+const archivedJobColumns = "uid, namespace, name, user_name, target_cluster, job_status, end_time_ns, snapshot"
+
+const historyColumns = "time_ns, kind, namespace, name, action, user_name, target_cluster, request_id, idempotency_key, payload"
+
+// SQLStore stores the records in the resource_history table of a SQL database.
+type SQLStore struct {
+	db           *sql.DB
+	placeholders placeholderStyle
+}
+
+func newSQLStore(db *sql.DB, placeholders placeholderStyle) *SQLStore {
+	return &SQLStore{db: db, placeholders: placeholders}
+}
+
+// Migrate creates the tables of the records and of the archived jobs if they do not exist.
+func (s *SQLStore) Migrate(ctx context.Context) error {
+	for _, statement := range []string{createHistoryTable, createHistoryIndex, createArchivedJobsTable, createArchivedJobsIndex} {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create the datastore tables: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) Add(ctx context.Context, record *Record) error {
+	statement := s.bind("INSERT INTO resource_history (" + historyColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	_, err := s.db.ExecContext(ctx, statement, record.Time.UnixNano(), record.Kind, record.Namespace, record.Name,
+		record.Action, record.User, record.TargetCluster, record.RequestID, record.IdempotencyKey, record.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to insert the history record of %s %s/%s: %w", record.Kind, record.Namespace, record.Name, err)
+	}
+	return nil
+}
+
+func (s *SQLStore) List(ctx context.Context, query Query) ([]*Record, error) {
+	var conditions []string
+	var args []interface{}
+	for _, condition := range []struct {
+		column string
+		value  string
+	}{{"kind", query.Kind}, {"namespace", query.Namespace}, {"name", query.Name}, {"user_name", query.User}} {
+		if condition.value != "" {
+			conditions = append(conditions, condition.column+" = ?")
+			args = append(args, condition.value)
+		}
+	}
+	if !query.Since.IsZero() {
+		conditions = append(conditions, "time_ns >= ?")
+		args = append(args, query.Since.UnixNano())
+	}
+	statement := "SELECT " + historyColumns + " FROM resource_history"
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	statement += " ORDER BY time_ns DESC"
+	if query.Limit > 0 {
+		statement += " LIMIT " + strconv.Itoa(query.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.bind(statement), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the resource history: %w", err)
+	}
+	defer rows.Close()
+	var records []*Record
+	for rows.Next() {
+		record := &Record{}
+		var timeNs int64
+		if err := rows.Scan(&timeNs, &record.Kind, &record.Namespace, &record.Name, &record.Action, &record.User,
+			&record.TargetCluster, &record.RequestID, &record.IdempotencyKey, &record.Payload); err != nil {
+			return nil, fmt.Errorf("failed to read the resource history: %w", err)
+		}
+		record.Time = time.Unix(0, timeNs).UTC()
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the resource history: %w", err)
+	}
+	return records, nil
+}
+
+// ArchiveJob replaces the snapshot of the job with the same UID, if any, in a transaction, since the databases disagree
+// on the syntax of upserts.
+func (s *SQLStore) ArchiveJob(ctx context.Context, job *ArchivedJob) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to archive the job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	// Rolling back a committed transaction does nothing.
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, s.bind("DELETE FROM archived_ray_jobs WHERE uid = ?"), job.UID); err != nil {
+		return fmt.Errorf("failed to archive the job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	statement := s.bind("INSERT INTO archived_ray_jobs (" + archivedJobColumns + ") VALUES (?, ?, ?, ?, ?, ?, ?, ?)")
+	if _, err := tx.ExecContext(ctx, statement, job.UID, job.Namespace, job.Name, job.User, job.TargetCluster,
+		job.JobStatus, job.EndTime.UnixNano(), job.Snapshot); err != nil {
+		return fmt.Errorf("failed to archive the job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to archive the job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	return nil
+}
+
+func (s *SQLStore) ListArchivedJobs(ctx context.Context, query ArchivedJobQuery) ([]*ArchivedJob, error) {
+	conditions := []string{"target_cluster = ?"}
+	args := []interface{}{query.TargetCluster}
+	for _, condition := range []struct {
+		column string
+		value  string
+	}{{"namespace", query.Namespace}, {"user_name", query.User}, {"job_status", query.JobStatus}} {
+		if condition.value != "" {
+			conditions = append(conditions, condition.column+" = ?")
+			args = append(args, condition.value)
+		}
+	}
+	if !query.FinishedAfter.IsZero() {
+		conditions = append(conditions, "end_time_ns >= ?")
+		args = append(args, query.FinishedAfter.UnixNano())
+	}
+	if !query.FinishedBefore.IsZero() {
+		conditions = append(conditions, "end_time_ns < ?")
+		args = append(args, query.FinishedBefore.UnixNano())
+	}
+	statement := "SELECT " + archivedJobColumns + " FROM archived_ray_jobs WHERE " + strings.Join(conditions, " AND ") +
+		" ORDER BY end_time_ns DESC"
+	if query.Limit > 0 {
+		statement += " LIMIT " + strconv.Itoa(query.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.bind(statement), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the archived jobs: %w", err)
+	}
+	defer rows.Close()
+	var jobs []*ArchivedJob
+	for rows.Next() {
+		job := &ArchivedJob{}
+		var endTimeNs int64
+		if err := rows.Scan(&job.UID, &job.Namespace, &job.Name, &job.User, &job.TargetCluster, &job.JobStatus,
+			&endTimeNs, &job.Snapshot); err != nil {
+			return nil, fmt.Errorf("failed to read the archived jobs: %w", err)
+		}
+		job.EndTime = time.Unix(0, endTimeNs).UTC()
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the archived jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func (s *SQLStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+// bind rewrites the ? parameters of a statement in the placeholder style of the database.
+func (s *SQLStore) bind(statement string) string {
+	if s.placeholders != dollarPlaceholders {
+		return statement
+	}
+	var builder strings.Builder
+	n := 0
+	for _, r := range statement {
+		if r == '?' {
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
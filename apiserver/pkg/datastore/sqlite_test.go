@@ -0,0 +1,17 @@
+//go:build cgo
+
+package datastore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	store, err := Open("sqlite3://" + filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	defer store.Close()
+	testSQLStore(t, store)
+}
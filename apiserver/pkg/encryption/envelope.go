@@ -0,0 +1,184 @@
+// Package encryption seals the sensitive fields of the API requests, like the values of the environment variables,
+// before they are persisted outside of Kubernetes, e.g. in the history of the resources. Every value is encrypted with
+// its own data key, which is wrapped by the key encryption key of the tenant, the namespace of the resource, held by a
+// KeyProvider. A KMS is plugged in by implementing KeyProvider.
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// sealedPrefix starts the sealed values, so that they can be told apart from the plaintext ones.
+const sealedPrefix = "enc:v1:"
+
+// keySize is the size of the master keys and of the data keys, for AES-256.
+const keySize = 32
+
+// KeyProvider wraps and unwraps the data keys of a tenant. The key encryption keys never leave the provider.
+type KeyProvider interface {
+	// Name identifies the provider and its key. It is stored with the sealed values.
+	Name() string
+	WrapKey(ctx context.Context, tenant string, dataKey []byte) ([]byte, error)
+	UnwrapKey(ctx context.Context, tenant string, wrappedKey []byte) ([]byte, error)
+}
+
+// LocalKeyProvider derives the key encryption key of every tenant from a master key with HMAC-SHA256, and wraps the
+// data keys with AES-256-GCM.
+type LocalKeyProvider struct {
+	name      string
+	masterKey []byte
+}
+
+func NewLocalKeyProvider(masterKey []byte) (*LocalKeyProvider, error) {
+	if len(masterKey) != keySize {
+		return nil, fmt.Errorf("the master key has %d bytes, expected %d", len(masterKey), keySize)
+	}
+	fingerprint := sha256.Sum256(masterKey)
+	return &LocalKeyProvider{name: "local:" + hex.EncodeToString(fingerprint[:4]), masterKey: masterKey}, nil
+}
+
+// LoadLocalKeyProvider reads the master key, encoded in base64, from a file, e.g. a mounted Secret.
+func LoadLocalKeyProvider(path string) (*LocalKeyProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the master key: %w", err)
+	}
+	masterKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("the master key in %s is not valid base64: %w", path, err)
+	}
+	return NewLocalKeyProvider(masterKey)
+}
+
+func (p *LocalKeyProvider) Name() string {
+	return p.name
+}
+
+func (p *LocalKeyProvider) WrapKey(_ context.Context, tenant string, dataKey []byte) ([]byte, error) {
+	return seal(p.tenantKey(tenant), dataKey, []byte(tenant))
+}
+
+func (p *LocalKeyProvider) UnwrapKey(_ context.Context, tenant string, wrappedKey []byte) ([]byte, error) {
+	return open(p.tenantKey(tenant), wrappedKey, []byte(tenant))
+}
+
+func (p *LocalKeyProvider) tenantKey(tenant string) []byte {
+	mac := hmac.New(sha256.New, p.masterKey)
+	mac.Write([]byte(tenant))
+	return mac.Sum(nil)
+}
+
+// sealedValue is the JSON content of a sealed value.
+type sealedValue struct {
+	Provider   string `json:"provider"`
+	WrappedKey []byte `json:"key"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Envelope seals the values of the tenants with the keys of a KeyProvider.
+type Envelope struct {
+	provider KeyProvider
+}
+
+func NewEnvelope(provider KeyProvider) *Envelope {
+	return &Envelope{provider: provider}
+}
+
+// Seal encrypts a value of a tenant with a new data key. The tenant is authenticated, so that the value can't be
+// opened as the value of another tenant.
+func (e *Envelope) Seal(ctx context.Context, tenant string, plaintext []byte) (string, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate a data key: %w", err)
+	}
+	wrappedKey, err := e.provider.WrapKey(ctx, tenant, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap the data key of tenant %s: %w", tenant, err)
+	}
+	ciphertext, err := seal(dataKey, plaintext, []byte(tenant))
+	if err != nil {
+		return "", err
+	}
+	content, err := json.Marshal(&sealedValue{Provider: e.provider.Name(), WrappedKey: wrappedKey, Ciphertext: ciphertext})
+	if err != nil {
+		return "", err
+	}
+	return sealedPrefix + base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+// Open decrypts a value sealed for a tenant.
+func (e *Envelope) Open(ctx context.Context, tenant string, sealed string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(sealed, sealedPrefix)
+	if !ok {
+		return nil, errors.New("the value is not sealed")
+	}
+	content, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("the sealed value is not valid base64: %w", err)
+	}
+	value := &sealedValue{}
+	if err := json.Unmarshal(content, value); err != nil {
+		return nil, fmt.Errorf("the sealed value is not valid: %w", err)
+	}
+	if value.Provider != e.provider.Name() {
+		return nil, fmt.Errorf("the value is sealed by key provider %s, not %s", value.Provider, e.provider.Name())
+	}
+	dataKey, err := e.provider.UnwrapKey(ctx, tenant, value.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap the data key of tenant %s: %w", tenant, err)
+	}
+	return open(dataKey, value.Ciphertext, []byte(tenant))
+}
+
+// IsSealed returns whether a value is sealed.
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+// seal encrypts the plaintext with AES-GCM and prepends the random nonce to the ciphertext.
+func seal(key []byte, plaintext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate a nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(key []byte, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("the ciphertext is too short")
+	}
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
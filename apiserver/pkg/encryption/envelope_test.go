@@ -0,0 +1,136 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEnvelope(t *testing.T) *Envelope {
+	provider, err := NewLocalKeyProvider([]byte(strings.Repeat("k", keySize)))
+	require.NoError(t, err)
+	return NewEnvelope(provider)
+}
+
+func TestSealOpen(t *testing.T) {
+	ctx := context.Background()
+	envelope := newTestEnvelope(t)
+
+	sealed, err := envelope.Seal(ctx, "team-a", []byte("s3cr3t"))
+	require.NoError(t, err)
+	assert.True(t, IsSealed(sealed))
+	assert.NotContains(t, sealed, "s3cr3t")
+
+	plaintext, err := envelope.Open(ctx, "team-a", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(plaintext))
+
+	// Another tenant can't open the value.
+	_, err = envelope.Open(ctx, "team-b", sealed)
+	require.Error(t, err)
+
+	// Another master key can't open the value.
+	provider, err := NewLocalKeyProvider([]byte(strings.Repeat("o", keySize)))
+	require.NoError(t, err)
+	_, err = NewEnvelope(provider).Open(ctx, "team-a", sealed)
+	require.Error(t, err)
+
+	// A tampered value can't be opened.
+	content, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(sealed, sealedPrefix))
+	require.NoError(t, err)
+	content[len(content)-3] ^= 1
+	_, err = envelope.Open(ctx, "team-a", sealedPrefix+base64.RawURLEncoding.EncodeToString(content))
+	require.Error(t, err)
+
+	_, err = envelope.Open(ctx, "team-a", "s3cr3t")
+	require.Error(t, err)
+}
+
+func TestLoadLocalKeyProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", keySize)))+"\n"), 0o600))
+	provider, err := LoadLocalKeyProvider(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(provider.Name(), "local:"))
+
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0o600))
+	_, err = LoadLocalKeyProvider(path)
+	require.Error(t, err)
+}
+
+func TestSealSensitiveFields(t *testing.T) {
+	ctx := context.Background()
+	envelope := newTestEnvelope(t)
+	cluster := &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "template",
+				Environment: &api.EnvironmentVariables{
+					Values:     map[string]string{"TOKEN": "s3cr3t"},
+					ValuesFrom: map[string]*api.EnvValueFrom{"PASSWORD": {Name: "secret", Key: "password"}},
+				},
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{{
+				GroupName:   "workers",
+				Environment: &api.EnvironmentVariables{Values: map[string]string{"TOKEN": "s3cr3t"}},
+			}},
+		},
+	}
+	require.NoError(t, envelope.SealSensitiveFields(ctx, "team-a", cluster))
+
+	assert.Equal(t, "cluster", cluster.Name)
+	assert.Equal(t, "template", cluster.ClusterSpec.HeadGroupSpec.ComputeTemplate)
+	assert.Equal(t, "secret", cluster.ClusterSpec.HeadGroupSpec.Environment.ValuesFrom["PASSWORD"].Name)
+	for _, values := range []map[string]string{
+		cluster.ClusterSpec.HeadGroupSpec.Environment.Values,
+		cluster.ClusterSpec.WorkerGroupSpec[0].Environment.Values,
+	} {
+		require.True(t, IsSealed(values["TOKEN"]))
+		plaintext, err := envelope.Open(ctx, "team-a", values["TOKEN"])
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", string(plaintext))
+	}
+
+	// The values which are sealed already are kept.
+	sealed := cluster.ClusterSpec.HeadGroupSpec.Environment.Values["TOKEN"]
+	require.NoError(t, envelope.SealSensitiveFields(ctx, "team-a", cluster))
+	assert.Equal(t, sealed, cluster.ClusterSpec.HeadGroupSpec.Environment.Values["TOKEN"])
+
+	job := &api.RayJob{Name: "job", Entrypoint: "python main.py", RuntimeEnv: "env_vars:\n  TOKEN: s3cr3t\n"}
+	require.NoError(t, envelope.SealSensitiveFields(ctx, "team-a", job))
+	assert.True(t, IsSealed(job.RuntimeEnv))
+	assert.Equal(t, "python main.py", job.Entrypoint)
+
+	// The empty fields are not set, so they stay empty.
+	service := &api.RayService{Name: "service"}
+	require.NoError(t, envelope.SealSensitiveFields(ctx, "team-a", service))
+	assert.Empty(t, service.ServeConfig_V2)
+}
+
+func TestRedactSensitiveFields(t *testing.T) {
+	request := &api.CreateRayJobRequest{
+		Namespace: "team-a",
+		Job: &api.RayJob{
+			Name:       "job",
+			Entrypoint: "python main.py",
+			RuntimeEnv: "env_vars:\n  TOKEN: s3cr3t\n",
+			ClusterSpec: &api.ClusterSpec{HeadGroupSpec: &api.HeadGroupSpec{
+				Environment: &api.EnvironmentVariables{Values: map[string]string{"TOKEN": "s3cr3t", "EMPTY": ""}},
+			}},
+		},
+	}
+	RedactSensitiveFields(request)
+
+	assert.Equal(t, Redacted, request.Job.RuntimeEnv)
+	assert.Equal(t, map[string]string{"TOKEN": Redacted, "EMPTY": ""}, request.Job.ClusterSpec.HeadGroupSpec.Environment.Values)
+	assert.Equal(t, "python main.py", request.Job.Entrypoint)
+}
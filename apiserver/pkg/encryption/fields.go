@@ -0,0 +1,91 @@
+package encryption
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Redacted replaces the values of the sensitive fields when they are not sealed.
+const Redacted = "<redacted>"
+
+// sensitiveFields are the fields of the API messages which can hold secrets: the values of the environment variables,
+// the runtime envs of the jobs and the serve configs, whose applications have runtime envs as well.
+var sensitiveFields = map[protoreflect.FullName]bool{
+	"proto.EnvironmentVariables.values":       true,
+	"proto.RayJob.runtime_env":                true,
+	"proto.RayJobSubmission.runtime_env":      true,
+	"proto.RayService.serve_config_V2":        true,
+	"proto.UpdateServiceBody.serve_config_V2": true,
+}
+
+// SealSensitiveFields seals the sensitive fields of a message, and of the messages it contains, in place. The values
+// which are already sealed are kept.
+func (e *Envelope) SealSensitiveFields(ctx context.Context, tenant string, message proto.Message) error {
+	return transformFields(message.ProtoReflect(), func(value string) (string, error) {
+		if IsSealed(value) {
+			return value, nil
+		}
+		return e.Seal(ctx, tenant, []byte(value))
+	})
+}
+
+// RedactSensitiveFields replaces the non empty values of the sensitive fields of a message, and of the messages it
+// contains, with Redacted in place.
+func RedactSensitiveFields(message proto.Message) {
+	_ = transformFields(message.ProtoReflect(), func(value string) (string, error) {
+		if value == "" {
+			return value, nil
+		}
+		return Redacted, nil
+	})
+}
+
+// transformFields replaces the values of the sensitive fields of a message, and of the messages it contains.
+func transformFields(message protoreflect.Message, transform func(string) (string, error)) error {
+	var err error
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case sensitiveFields[field.FullName()]:
+			err = transformField(message, field, value, transform)
+		case field.IsMap() && field.MapValue().Kind() == protoreflect.MessageKind:
+			value.Map().Range(func(_ protoreflect.MapKey, entry protoreflect.Value) bool {
+				err = transformFields(entry.Message(), transform)
+				return err == nil
+			})
+		case field.IsList() && field.Kind() == protoreflect.MessageKind:
+			for i := 0; i < value.List().Len() && err == nil; i++ {
+				err = transformFields(value.List().Get(i).Message(), transform)
+			}
+		case !field.IsMap() && !field.IsList() && field.Kind() == protoreflect.MessageKind:
+			err = transformFields(value.Message(), transform)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// transformField replaces the value of a sensitive string field, or the values of a sensitive map of strings.
+func transformField(message protoreflect.Message, field protoreflect.FieldDescriptor, value protoreflect.Value, transform func(string) (string, error)) error {
+	switch {
+	case field.IsMap() && field.MapValue().Kind() == protoreflect.StringKind:
+		var err error
+		values := value.Map()
+		values.Range(func(key protoreflect.MapKey, entry protoreflect.Value) bool {
+			var transformed string
+			if transformed, err = transform(entry.String()); err == nil {
+				values.Set(key, protoreflect.ValueOfString(transformed))
+			}
+			return err == nil
+		})
+		return err
+	case !field.IsList() && field.Kind() == protoreflect.StringKind:
+		transformed, err := transform(value.String())
+		if err != nil {
+			return err
+		}
+		message.Set(field, protoreflect.ValueOfString(transformed))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	klog "k8s.io/klog/v2"
+)
+
+// Feature is the name of an experimental API server subsystem which can be toggled with --featureGates.
+type Feature string
+
+// PreRelease describes the maturity of a feature.
+type PreRelease string
+
+const (
+	Alpha PreRelease = "ALPHA"
+	Beta  PreRelease = "BETA"
+	GA    PreRelease = ""
+)
+
+// FeatureSpec describes the default state and the maturity of a feature.
+type FeatureSpec struct {
+	Default    bool
+	PreRelease PreRelease
+}
+
+const (
+	// alpha: v1.2
+	//
+	// Enables the server-side watch/streaming RPCs for Ray resources.
+	WatchRPCs Feature = "WatchRPCs"
+
+	// alpha: v1.2
+	//
+	// Enables routing requests to Ray resources in remote Kubernetes clusters.
+	MultiClusterRouting Feature = "MultiClusterRouting"
+
+	// alpha: v1.2
+	//
+	// Enables persisting the history of Ray resources in a database.
+	HistoryDB Feature = "HistoryDB"
+
+	// alpha: v1.2
+	//
+	// Enables attaching the events of Ray resources from an in-memory cache kept warm by an Event informer.
+	EventCache Feature = "EventCache"
+
+	// alpha: v1.2
+	//
+	// Enables serving Get and List calls of Ray resources, and their events, from shared informers.
+	ResourceCache Feature = "ResourceCache"
+
+	// alpha: v1.2
+	//
+	// Enables the RPCs injecting failures, like killing the head Pod, into Ray clusters.
+	FailureInjection Feature = "FailureInjection"
+)
+
+var defaultFeatureGates = map[Feature]FeatureSpec{
+	WatchRPCs:           {Default: false, PreRelease: Alpha},
+	MultiClusterRouting: {Default: false, PreRelease: Alpha},
+	HistoryDB:           {Default: false, PreRelease: Alpha},
+	EventCache:          {Default: false, PreRelease: Alpha},
+	ResourceCache:       {Default: false, PreRelease: Alpha},
+	FailureInjection:    {Default: false, PreRelease: Alpha},
+}
+
+var (
+	lock    sync.RWMutex
+	enabled = map[Feature]bool{}
+)
+
+// Set parses a comma separated list of key=value pairs, e.g. `WatchRPCs=true,HistoryDB=false`,
+// and overrides the state of the given features. Unknown features are rejected.
+func Set(value string) error {
+	overrides := map[Feature]bool{}
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		k, v, found := strings.Cut(s, "=")
+		if !found {
+			return fmt.Errorf("missing bool value for feature gate %s", s)
+		}
+		feature := Feature(strings.TrimSpace(k))
+		if _, ok := defaultFeatureGates[feature]; !ok {
+			return fmt.Errorf("unrecognized feature gate: %s", feature)
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("invalid value of %s=%s, err: %w", feature, v, err)
+		}
+		overrides[feature] = b
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	for feature, b := range overrides {
+		enabled[feature] = b
+	}
+	return nil
+}
+
+// Enabled returns whether the feature is enabled.
+func Enabled(f Feature) bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	if b, ok := enabled[f]; ok {
+		return b
+	}
+	return defaultFeatureGates[f].Default
+}
+
+// SetFeatureGateDuringTest is a helper method to override feature gates in tests.
+// The returned function restores the previous state.
+func SetFeatureGateDuringTest(f Feature, value bool) func() {
+	lock.Lock()
+	previous, wasSet := enabled[f]
+	enabled[f] = value
+	lock.Unlock()
+
+	return func() {
+		lock.Lock()
+		defer lock.Unlock()
+		if wasSet {
+			enabled[f] = previous
+		} else {
+			delete(enabled, f)
+		}
+	}
+}
+
+// States returns whether every feature is enabled, by feature name.
+func States() map[string]bool {
+	states := make(map[string]bool, len(defaultFeatureGates))
+	for f := range defaultFeatureGates {
+		states[string(f)] = Enabled(f)
+	}
+	return states
+}
+
+// KnownFeatures returns a description of all the features for the --featureGates help message.
+func KnownFeatures() []string {
+	known := make([]string, 0, len(defaultFeatureGates))
+	for f, spec := range defaultFeatureGates {
+		if spec.PreRelease == GA {
+			continue
+		}
+		known = append(known, fmt.Sprintf("%s=true|false (%s - default=%t)", f, spec.PreRelease, spec.Default))
+	}
+	sort.Strings(known)
+	return known
+}
+
+// LogFeatureGates logs the state of all the features.
+func LogFeatureGates() {
+	states := make([]string, 0, len(defaultFeatureGates))
+	for f := range defaultFeatureGates {
+		states = append(states, fmt.Sprintf("%s=%t", f, Enabled(f)))
+	}
+	sort.Strings(states)
+	klog.Infof("Loaded feature gates: %s", strings.Join(states, ","))
+}
@@ -0,0 +1,36 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	defer SetFeatureGateDuringTest(WatchRPCs, false)()
+	defer SetFeatureGateDuringTest(HistoryDB, false)()
+
+	assert.False(t, Enabled(WatchRPCs))
+	require.NoError(t, Set("WatchRPCs=true, HistoryDB=false"))
+	assert.True(t, Enabled(WatchRPCs))
+	assert.False(t, Enabled(HistoryDB))
+	assert.False(t, Enabled(MultiClusterRouting))
+
+	require.Error(t, Set("UnknownFeature=true"))
+	require.Error(t, Set("WatchRPCs"))
+	require.Error(t, Set("WatchRPCs=maybe"))
+
+	// A failed Set does not apply any of the gates.
+	require.Error(t, Set("HistoryDB=true,WatchRPCs=maybe"))
+	assert.False(t, Enabled(HistoryDB))
+}
+
+func TestSetFeatureGateDuringTest(t *testing.T) {
+	restore := SetFeatureGateDuringTest(MultiClusterRouting, true)
+	assert.True(t, Enabled(MultiClusterRouting))
+	assert.True(t, States()[string(MultiClusterRouting)])
+	restore()
+	assert.False(t, Enabled(MultiClusterRouting))
+	assert.False(t, States()[string(MultiClusterRouting)])
+}
@@ -6,10 +6,17 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	rpcStatus "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type KuberayAPIServerClient struct {
@@ -37,6 +44,29 @@ func IsNotFoundError(err error) bool {
 	return false
 }
 
+// ErrorInfo returns the ErrorInfo detail of a status returned by the API server, whose reason tells the errors with
+// the same code apart, e.g. QUOTA_EXCEEDED from KUBERNETES_QUOTA_EXCEEDED. Nil if the status has none.
+func ErrorInfo(status *rpcStatus.Status) *errdetails.ErrorInfo {
+	for _, detail := range status.GetDetails() {
+		info := &errdetails.ErrorInfo{}
+		if detail.MessageIs(info) && detail.UnmarshalTo(info) == nil {
+			return info
+		}
+	}
+	return nil
+}
+
+// FieldViolations returns the invalid fields of the request of a status returned by the API server.
+func FieldViolations(status *rpcStatus.Status) []*errdetails.BadRequest_FieldViolation {
+	for _, detail := range status.GetDetails() {
+		badRequest := &errdetails.BadRequest{}
+		if detail.MessageIs(badRequest) && detail.UnmarshalTo(badRequest) == nil {
+			return badRequest.FieldViolations
+		}
+	}
+	return nil
+}
+
 func NewKuberayAPIServerClient(baseURL string, httpClient *http.Client) *KuberayAPIServerClient {
 	return &KuberayAPIServerClient{
 		httpClient: httpClient,
@@ -60,7 +90,7 @@ func NewKuberayAPIServerClient(baseURL string, httpClient *http.Client) *Kuberay
 
 // CreateComputeTemplate creates a new compute template.
 func (krc *KuberayAPIServerClient) CreateComputeTemplate(request *api.CreateComputeTemplateRequest) (*api.ComputeTemplate, *rpcStatus.Status, error) {
-	createURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/compute_templates"
+	createURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/compute_templates", request.TargetCluster)
 
 	bytez, err := krc.marshaler.Marshal(request.ComputeTemplate)
 	if err != nil {
@@ -89,13 +119,66 @@ func (krc *KuberayAPIServerClient) CreateComputeTemplate(request *api.CreateComp
 
 // DeleteComputeTemplate deletes a compute template.
 func (krc *KuberayAPIServerClient) DeleteComputeTemplate(request *api.DeleteComputeTemplateRequest) (*rpcStatus.Status, error) {
-	deleteURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/compute_templates/" + request.Name
+	deleteURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/compute_templates/"+request.Name, request.TargetCluster)
 	return krc.doDelete(deleteURL)
 }
 
-// Finds a specific compute template by its name and namespace.
+// UpdateComputeTemplate creates a new revision of a compute template.
+func (krc *KuberayAPIServerClient) UpdateComputeTemplate(request *api.UpdateComputeTemplateRequest) (*api.ComputeTemplate, *rpcStatus.Status, error) {
+	updateURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/compute_templates/"+request.Name, request.TargetCluster)
+
+	bytez, err := krc.marshaler.Marshal(request.ComputeTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.ComputeTemplate to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("PUT", updateURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", updateURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, updateURL)
+	if err != nil {
+		return nil, status, err
+	}
+	computeTemplate := &api.ComputeTemplate{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, computeTemplate); err != nil {
+		return nil, status, nil
+	}
+	return computeTemplate, nil, nil
+}
+
+// ListComputeTemplateRevisions finds the revisions of a compute template, oldest first.
+func (krc *KuberayAPIServerClient) ListComputeTemplateRevisions(request *api.ListComputeTemplateRevisionsRequest) (*api.ListComputeTemplateRevisionsResponse, *rpcStatus.Status, error) {
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/compute_templates/"+request.Name+"/revisions", request.TargetCluster)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListComputeTemplateRevisionsResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// Finds a specific compute template by its name and namespace, at its latest revision unless a revision is requested.
 func (krc *KuberayAPIServerClient) GetComputeTemplate(request *api.GetComputeTemplateRequest) (*api.ComputeTemplate, *rpcStatus.Status, error) {
 	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/compute_templates/" + request.Name
+	if request.Revision != 0 {
+		getURL += "?" + url.Values{"revision": []string{strconv.Itoa(int(request.Revision))}}.Encode()
+	}
+	getURL = withTargetCluster(getURL, request.TargetCluster)
 	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
@@ -137,30 +220,1182 @@ func (krc *KuberayAPIServerClient) GetAllComputeTemplates() (*api.ListAllCompute
 
 // GetAllComputeTemplatesInNamespace Finds all compute templates in a given namespace.
 func (krc *KuberayAPIServerClient) GetAllComputeTemplatesInNamespace(request *api.ListComputeTemplatesRequest) (*api.ListComputeTemplatesResponse, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/compute_templates"
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/compute_templates", request.TargetCluster)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListComputeTemplatesResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// CreateCluster creates a new cluster.
+func (krc *KuberayAPIServerClient) CreateCluster(request *api.CreateClusterRequest) (*api.Cluster, *rpcStatus.Status, error) {
+	createURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/clusters"+createQuery(request.DryRun, request.IdempotencyKey), request.TargetCluster)
+
+	bytez, err := krc.marshaler.Marshal(request.Cluster)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.Cluster to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
+	if err != nil {
+		return nil, status, err
+	}
+	cluster := &api.Cluster{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, cluster); err != nil {
+		return nil, status, nil
+	}
+	return cluster, nil, nil
+}
+
+// DeleteCluster deletes a cluster
+func (krc *KuberayAPIServerClient) DeleteCluster(request *api.DeleteClusterRequest) (*rpcStatus.Status, error) {
+	deleteURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name
+	query := url.Values{}
+	if request.Drain {
+		query.Set("drain", "true")
+		query.Set("drainTimeoutSeconds", strconv.Itoa(int(request.DrainTimeoutSeconds)))
+	}
+	if request.Force {
+		query.Set("force", "true")
+	}
+	if request.TargetCluster != "" {
+		query.Set("targetCluster", request.TargetCluster)
+	}
+	if request.ResourceVersion != "" {
+		query.Set("resourceVersion", request.ResourceVersion)
+	}
+	if len(query) > 0 {
+		deleteURL += "?" + query.Encode()
+	}
+	return krc.doDelete(deleteURL)
+}
+
+// BatchDeleteRayClusters deletes many clusters of a namespace and returns the result of every cluster.
+func (krc *KuberayAPIServerClient) BatchDeleteRayClusters(request *api.BatchDeleteRayClustersRequest) (*api.BatchDeleteRayClustersResponse, *rpcStatus.Status, error) {
+	batchURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters:batchDelete"
+	response := &api.BatchDeleteRayClustersResponse{}
+	status, err := krc.doPostBatch(batchURL, request, response)
+	if err != nil || status != nil {
+		return nil, status, err
+	}
+	return response, nil, nil
+}
+
+// UpdateCluster updates the replicas, min replicas and max replicas of the worker groups of a cluster.
+func (krc *KuberayAPIServerClient) UpdateCluster(request *api.UpdateClusterRequest) (*api.Cluster, *rpcStatus.Status, error) {
+	updateURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name
+	bytez, err := krc.marshaler.Marshal(request.Cluster)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.Cluster to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("PUT", updateURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", updateURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, updateURL)
+	if err != nil {
+		return nil, status, err
+	}
+	cluster := &api.Cluster{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, cluster); err != nil {
+		return nil, status, nil
+	}
+	return cluster, nil, nil
+}
+
+// UpdateWorkerGroupAutoscaling updates the autoscaling bounds of a worker group of a cluster.
+func (krc *KuberayAPIServerClient) UpdateWorkerGroupAutoscaling(request *api.UpdateWorkerGroupAutoscalingRequest) (*api.Cluster, *rpcStatus.Status, error) {
+	updateURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/workergroups/" + request.GroupName + "/autoscaling"
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.UpdateWorkerGroupAutoscalingRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("PATCH", updateURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", updateURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, updateURL)
+	if err != nil {
+		return nil, status, err
+	}
+	cluster := &api.Cluster{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, cluster); err != nil {
+		return nil, status, nil
+	}
+	return cluster, nil, nil
+}
+
+// GetCluster finds a specific Cluster by ID.
+func (krc *KuberayAPIServerClient) GetCluster(request *api.GetClusterRequest) (*api.Cluster, *rpcStatus.Status, error) {
+	getURL := withEventFilter(withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/clusters/"+request.Name, request.TargetCluster), request.EventType, request.EventsSince, request.EventLimit)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	cluster := &api.Cluster{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, cluster); err != nil {
+		return nil, status, nil
+	}
+	return cluster, nil, nil
+}
+
+// ExportRayCluster returns the YAML manifest of the RayCluster custom resource of a cluster.
+func (krc *KuberayAPIServerClient) ExportRayCluster(request *api.ExportRayClusterRequest) (*api.ResourceManifest, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/manifest"
+	if request.IncludeStatus {
+		getURL += "?includeStatus=true"
+	}
+	return krc.getResourceManifest(withTargetCluster(getURL, request.TargetCluster))
+}
+
+func (krc *KuberayAPIServerClient) getResourceManifest(getURL string) (*api.ResourceManifest, *rpcStatus.Status, error) {
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	manifest := &api.ResourceManifest{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, manifest); err != nil {
+		return nil, status, nil
+	}
+	return manifest, nil, nil
+}
+
+// UpdateWorkerGroup updates the image, the compute template and the replicas of a worker group of a cluster.
+func (krc *KuberayAPIServerClient) UpdateWorkerGroup(request *api.UpdateWorkerGroupRequest) (*api.Cluster, *rpcStatus.Status, error) {
+	updateURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/workergroups/" + request.GroupName
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.UpdateWorkerGroupRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("PATCH", updateURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", updateURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, updateURL)
+	if err != nil {
+		return nil, status, err
+	}
+	cluster := &api.Cluster{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, cluster); err != nil {
+		return nil, status, nil
+	}
+	return cluster, nil, nil
+}
+
+// RestartWorkerGroup restarts the Pods of a worker group of a cluster in a rolling fashion.
+func (krc *KuberayAPIServerClient) RestartWorkerGroup(request *api.RestartWorkerGroupRequest) (*api.WorkerGroupRestart, *rpcStatus.Status, error) {
+	postURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/workergroups/" + request.GroupName + "/restart"
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.RestartWorkerGroupRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", postURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", postURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, postURL)
+	if err != nil {
+		return nil, status, err
+	}
+	restart := &api.WorkerGroupRestart{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, restart); err != nil {
+		return nil, status, nil
+	}
+	return restart, nil, nil
+}
+
+// GetWorkerGroupRestart finds the progress of the last rolling restart of a worker group of a cluster.
+func (krc *KuberayAPIServerClient) GetWorkerGroupRestart(request *api.GetWorkerGroupRestartRequest) (*api.WorkerGroupRestart, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/workergroups/" + request.GroupName + "/restart"
 	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	restart := &api.WorkerGroupRestart{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, restart); err != nil {
+		return nil, status, nil
+	}
+	return restart, nil, nil
+}
+
+// DrainWorkerGroup scales a worker group of a cluster down once the actors of the removed workers are gone.
+func (krc *KuberayAPIServerClient) DrainWorkerGroup(request *api.DrainWorkerGroupRequest) (*api.WorkerGroupDrain, *rpcStatus.Status, error) {
+	postURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/workergroups/" + request.GroupName + "/drain"
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.DrainWorkerGroupRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", postURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", postURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, postURL)
+	if err != nil {
+		return nil, status, err
+	}
+	drain := &api.WorkerGroupDrain{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, drain); err != nil {
+		return nil, status, nil
+	}
+	return drain, nil, nil
+}
+
+// GetWorkerGroupDrain finds the progress of the last drain of a worker group of a cluster.
+func (krc *KuberayAPIServerClient) GetWorkerGroupDrain(request *api.GetWorkerGroupDrainRequest) (*api.WorkerGroupDrain, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/workergroups/" + request.GroupName + "/drain"
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	drain := &api.WorkerGroupDrain{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, drain); err != nil {
+		return nil, status, nil
+	}
+	return drain, nil, nil
+}
+
+// GetClusterStatus finds the status of a specific Cluster. If the resource version in the request is the current
+// one, the returned status only has NotModified set.
+func (krc *KuberayAPIServerClient) GetClusterStatus(request *api.GetClusterStatusRequest) (*api.ClusterStatus, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/status"
+	if request.ResourceVersion != "" {
+		getURL += "?resourceVersion=" + url.QueryEscape(request.ResourceVersion)
+	}
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	clusterStatus := &api.ClusterStatus{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, clusterStatus); err != nil {
+		return nil, status, nil
+	}
+	return clusterStatus, nil, nil
+}
+
+// TestRayClusterConnectivity checks that the dashboard and the Ray client port of a specific Cluster are reachable.
+func (krc *KuberayAPIServerClient) TestRayClusterConnectivity(request *api.TestRayClusterConnectivityRequest) (*api.RayClusterConnectivity, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/connectivity"
+	if request.TimeoutSeconds != 0 {
+		getURL += "?timeoutSeconds=" + strconv.Itoa(int(request.TimeoutSeconds))
+	}
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	connectivity := &api.RayClusterConnectivity{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, connectivity); err != nil {
+		return nil, status, nil
+	}
+	return connectivity, nil, nil
+}
+
+// GetRayClusterEndpoints returns the addresses of the client server, the dashboard and the serve proxy of a Cluster.
+func (krc *KuberayAPIServerClient) GetRayClusterEndpoints(request *api.GetRayClusterEndpointsRequest) (*api.RayEndpoints, *rpcStatus.Status, error) {
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/clusters/"+request.Name+"/endpoints", request.TargetCluster)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	endpoints := &api.RayEndpoints{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, endpoints); err != nil {
+		return nil, status, nil
+	}
+	return endpoints, nil, nil
+}
+
+// CanSchedule checks whether the Pods of a cluster spec, or the replicas of a compute template, are likely to be
+// scheduled in a namespace.
+func (krc *KuberayAPIServerClient) CanSchedule(request *api.CanScheduleRequest) (*api.SchedulingAdvice, *rpcStatus.Status, error) {
+	postURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/scheduling_advice"
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.CanScheduleRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", postURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", postURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, postURL)
+	if err != nil {
+		return nil, status, err
+	}
+	advice := &api.SchedulingAdvice{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, advice); err != nil {
+		return nil, status, nil
+	}
+	return advice, nil, nil
+}
+
+// InjectClusterFailure injects a failure, e.g. kills the head Pod, into a specific Cluster.
+func (krc *KuberayAPIServerClient) InjectClusterFailure(request *api.InjectClusterFailureRequest) (*api.ClusterFailureInjection, *rpcStatus.Status, error) {
+	postURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/failures"
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.InjectClusterFailureRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", postURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", postURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, postURL)
+	if err != nil {
+		return nil, status, err
+	}
+	injection := &api.ClusterFailureInjection{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, injection); err != nil {
+		return nil, status, nil
+	}
+	return injection, nil, nil
+}
+
+// HealClusterPartitions removes the network partitions injected into a specific Cluster.
+func (krc *KuberayAPIServerClient) HealClusterPartitions(request *api.HealClusterPartitionsRequest) (*api.ClusterFailureInjection, *rpcStatus.Status, error) {
+	deleteURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name + "/failures/partitions"
+	httpRequest, err := krc.createHttpRequest("DELETE", deleteURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", deleteURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, deleteURL)
+	if err != nil {
+		return nil, status, err
+	}
+	injection := &api.ClusterFailureInjection{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, injection); err != nil {
+		return nil, status, nil
+	}
+	return injection, nil, nil
+}
+
+// CloneRayCluster creates a cluster from the spec of an existing cluster or ray service.
+func (krc *KuberayAPIServerClient) CloneRayCluster(request *api.CloneRayClusterRequest) (*api.Cluster, *rpcStatus.Status, error) {
+	createURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/clusters:clone", request.TargetCluster)
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.CloneRayClusterRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
+	if err != nil {
+		return nil, status, err
+	}
+	cluster := &api.Cluster{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, cluster); err != nil {
+		return nil, status, nil
+	}
+	return cluster, nil, nil
+}
+
+// ListCluster finds all clusters in a given namespace.
+func (krc *KuberayAPIServerClient) ListClusters(request *api.ListClustersRequest) (*api.ListClustersResponse, *rpcStatus.Status, error) {
+	getURL := withListFilter(withEventFilter(withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/clusters"+selectedPageQuery(request.PageToken, request.PageSize, request.LabelSelector, request.FieldSelector), request.TargetCluster), request.EventType, request.EventsSince, request.EventLimit), request.Filter)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListClustersResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// ListAllClusters finds all Clusters in all namespaces. Supports pagination, and sorting on certain fields.
+func (krc *KuberayAPIServerClient) ListAllClusters() (*api.ListAllClustersResponse, *rpcStatus.Status, error) {
+	return krc.ListAllClustersWithRequest(&api.ListAllClustersRequest{})
+}
+
+// ListAllClustersWithRequest is ListAllClusters with the page, the selectors and the filters of a request.
+func (krc *KuberayAPIServerClient) ListAllClustersWithRequest(request *api.ListAllClustersRequest) (*api.ListAllClustersResponse, *rpcStatus.Status, error) {
+	getURL := withResourceVersion(withListFilter(withEventFilter(withTargetCluster(krc.baseURL+"/apis/v1/clusters"+selectedPageQuery(request.PageToken, request.PageSize, request.LabelSelector, request.FieldSelector), request.TargetCluster), request.EventType, request.EventsSince, request.EventLimit), request.Filter), request.ResourceVersion)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListAllClustersResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// CreateRayJob creates a new job.
+func (krc *KuberayAPIServerClient) CreateRayJob(request *api.CreateRayJobRequest) (*api.RayJob, *rpcStatus.Status, error) {
+	createURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/jobs", request.TargetCluster)
+	bytez, err := krc.marshaler.Marshal(request.Job)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.Cluster to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
+	if err != nil {
+		return nil, status, err
+	}
+	rayJob := &api.RayJob{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayJob); err != nil {
+		return nil, status, nil
+	}
+	return rayJob, nil, nil
+}
+
+// BatchCreateRayJobs creates many jobs of a namespace and returns the result of every job.
+func (krc *KuberayAPIServerClient) BatchCreateRayJobs(request *api.BatchCreateRayJobsRequest) (*api.BatchCreateRayJobsResponse, *rpcStatus.Status, error) {
+	batchURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/jobs:batchCreate"
+	response := &api.BatchCreateRayJobsResponse{}
+	status, err := krc.doPostBatch(batchURL, request, response)
+	if err != nil || status != nil {
+		return nil, status, err
+	}
+	return response, nil, nil
+}
+
+// doPostBatch posts the request of a batch call and decodes its response.
+func (krc *KuberayAPIServerClient) doPostBatch(batchURL string, request proto.Message, response proto.Message) (*rpcStatus.Status, error) {
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the batch request to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", batchURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http request for url '%s': %w", batchURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, batchURL)
+	if err != nil {
+		return status, err
+	}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the batch response: %w", err)
+	}
+	return nil, nil
+}
+
+// GetRayJob finds a specific job by its name and namespace.
+func (krc *KuberayAPIServerClient) GetRayJob(request *api.GetRayJobRequest) (*api.RayJob, *rpcStatus.Status, error) {
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/jobs/"+request.Name, request.TargetCluster)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	rayJob := &api.RayJob{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayJob); err != nil {
+		return nil, status, nil
+	}
+	return rayJob, nil, nil
+}
+
+// GetRayJobOutput finds the status, the result and the driver logs of a job.
+func (krc *KuberayAPIServerClient) GetRayJobOutput(request *api.GetRayJobOutputRequest) (*api.RayJobOutput, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/jobs/" + request.Name + "/output"
+	if request.TailLines > 0 {
+		getURL += "?tailLines=" + strconv.FormatInt(request.TailLines, 10)
+	}
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	output := &api.RayJobOutput{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, output); err != nil {
+		return nil, status, nil
+	}
+	return output, nil, nil
+}
+
+// Finds all job in a given namespace.
+func (krc *KuberayAPIServerClient) ListRayJobs(request *api.ListRayJobsRequest) (*api.ListRayJobsResponse, *rpcStatus.Status, error) {
+	getURL := withListFilter(withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/jobs"+pageQuery(request.PageToken, request.PageSize), request.TargetCluster), request.Filter)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListRayJobsResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// ListAllRayJobs Finds all job in all namespaces.
+func (krc *KuberayAPIServerClient) ListAllRayJobs() (*api.ListAllRayJobsResponse, *rpcStatus.Status, error) {
+	return krc.ListAllRayJobsWithRequest(&api.ListAllRayJobsRequest{})
+}
+
+// ListAllRayJobsWithRequest is ListAllRayJobs with the page and the filters of a request.
+func (krc *KuberayAPIServerClient) ListAllRayJobsWithRequest(request *api.ListAllRayJobsRequest) (*api.ListAllRayJobsResponse, *rpcStatus.Status, error) {
+	getURL := withListFilter(withTargetCluster(krc.baseURL+"/apis/v1/jobs"+pageQuery(request.PageToken, request.PageSize), request.TargetCluster), request.Filter)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListAllRayJobsResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// ListArchivedRayJobs lists the jobs archived by the API server, the most recently finished first.
+func (krc *KuberayAPIServerClient) ListArchivedRayJobs(request *api.ListArchivedRayJobsRequest) (*api.ListArchivedRayJobsResponse, *rpcStatus.Status, error) {
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"namespace":     request.Namespace,
+		"user":          request.User,
+		"jobStatus":     request.JobStatus,
+		"targetCluster": request.TargetCluster,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if request.FinishedAfter != nil {
+		query.Set("finishedAfter", request.FinishedAfter.AsTime().Format(time.RFC3339Nano))
+	}
+	if request.FinishedBefore != nil {
+		query.Set("finishedBefore", request.FinishedBefore.AsTime().Format(time.RFC3339Nano))
+	}
+	if request.Limit != 0 {
+		query.Set("limit", strconv.Itoa(int(request.Limit)))
+	}
+	getURL := krc.baseURL + "/apis/v1/archived_jobs"
+	if len(query) > 0 {
+		getURL += "?" + query.Encode()
+	}
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListArchivedRayJobsResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// Deletes a job by its name and namespace.
+func (krc *KuberayAPIServerClient) DeleteRayJob(request *api.DeleteRayJobRequest) (*rpcStatus.Status, error) {
+	deleteURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/jobs/"+request.Name, request.TargetCluster)
+	return krc.doDelete(deleteURL)
+}
+
+// CreateRayCronJob creates a new cron job.
+func (krc *KuberayAPIServerClient) CreateRayCronJob(request *api.CreateRayCronJobRequest) (*api.RayCronJob, *rpcStatus.Status, error) {
+	createURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/cronjobs"
+	bytez, err := krc.marshaler.Marshal(request.CronJob)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.RayCronJob to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
+	if err != nil {
+		return nil, status, err
+	}
+	cronJob := &api.RayCronJob{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, cronJob); err != nil {
+		return nil, status, nil
+	}
+	return cronJob, nil, nil
+}
+
+// GetRayCronJob finds a specific cron job by its name and namespace.
+func (krc *KuberayAPIServerClient) GetRayCronJob(request *api.GetRayCronJobRequest) (*api.RayCronJob, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/cronjobs/" + request.Name
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.RayCronJob{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// ListRayCronJobs finds all cron jobs in a given namespace.
+func (krc *KuberayAPIServerClient) ListRayCronJobs(request *api.ListRayCronJobsRequest) (*api.ListRayCronJobsResponse, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/cronjobs"
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListRayCronJobsResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// ListRayCronJobRuns finds the runs of a cron job, most recent first.
+func (krc *KuberayAPIServerClient) ListRayCronJobRuns(request *api.ListRayCronJobRunsRequest) (*api.ListRayCronJobRunsResponse, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/cronjobs/" + request.CronJobName + "/runs"
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListRayCronJobRunsResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// GetRayCronJobRun finds a specific run of a cron job by its name.
+func (krc *KuberayAPIServerClient) GetRayCronJobRun(request *api.GetRayCronJobRunRequest) (*api.RayJob, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/cronjobs/" + request.CronJobName + "/runs/" + request.Name
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.RayJob{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// DeleteRayCronJob deletes a cron job and its runs by its name and namespace.
+func (krc *KuberayAPIServerClient) DeleteRayCronJob(request *api.DeleteRayCronJobRequest) (*rpcStatus.Status, error) {
+	deleteURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/cronjobs/" + request.Name
+	return krc.doDelete(deleteURL)
+}
+
+// CreateServiceTemplate creates a new service template.
+func (krc *KuberayAPIServerClient) CreateServiceTemplate(request *api.CreateServiceTemplateRequest) (*api.ServiceTemplate, *rpcStatus.Status, error) {
+	createURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/service_templates", request.TargetCluster)
+	bytez, err := krc.marshaler.Marshal(request.ServiceTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.ServiceTemplate to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
+	if err != nil {
+		return nil, status, err
+	}
+	template := &api.ServiceTemplate{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, template); err != nil {
+		return nil, status, nil
+	}
+	return template, nil, nil
+}
+
+// GetServiceTemplate finds a specific service template by its name and namespace.
+func (krc *KuberayAPIServerClient) GetServiceTemplate(request *api.GetServiceTemplateRequest) (*api.ServiceTemplate, *rpcStatus.Status, error) {
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/service_templates/"+request.Name, request.TargetCluster)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ServiceTemplate{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// ListServiceTemplates finds all service templates in a given namespace.
+func (krc *KuberayAPIServerClient) ListServiceTemplates(request *api.ListServiceTemplatesRequest) (*api.ListServiceTemplatesResponse, *rpcStatus.Status, error) {
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/service_templates", request.TargetCluster)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListServiceTemplatesResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// ListAllServiceTemplates finds all service templates in all namespaces.
+func (krc *KuberayAPIServerClient) ListAllServiceTemplates(request *api.ListAllServiceTemplatesRequest) (*api.ListAllServiceTemplatesResponse, *rpcStatus.Status, error) {
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/service_templates", request.TargetCluster)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListAllServiceTemplatesResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// DeleteServiceTemplate deletes a service template by its name and namespace.
+func (krc *KuberayAPIServerClient) DeleteServiceTemplate(request *api.DeleteServiceTemplateRequest) (*rpcStatus.Status, error) {
+	deleteURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/service_templates/"+request.Name, request.TargetCluster)
+	return krc.doDelete(deleteURL)
+}
+
+// CreateNotificationSubscription creates a new notification subscription.
+func (krc *KuberayAPIServerClient) CreateNotificationSubscription(request *api.CreateNotificationSubscriptionRequest) (*api.NotificationSubscription, *rpcStatus.Status, error) {
+	createURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/notification_subscriptions"
+	bytez, err := krc.marshaler.Marshal(request.Subscription)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.NotificationSubscription to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
+	if err != nil {
+		return nil, status, err
+	}
+	subscription := &api.NotificationSubscription{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, subscription); err != nil {
+		return nil, status, nil
+	}
+	return subscription, nil, nil
+}
+
+// GetNotificationSubscription finds a specific notification subscription by its name and namespace.
+func (krc *KuberayAPIServerClient) GetNotificationSubscription(request *api.GetNotificationSubscriptionRequest) (*api.NotificationSubscription, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/notification_subscriptions/" + request.Name
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.NotificationSubscription{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// ListNotificationSubscriptions finds all notification subscriptions in a given namespace.
+func (krc *KuberayAPIServerClient) ListNotificationSubscriptions(request *api.ListNotificationSubscriptionsRequest) (*api.ListNotificationSubscriptionsResponse, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/notification_subscriptions"
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListNotificationSubscriptionsResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// DeleteNotificationSubscription deletes a notification subscription by its name and namespace.
+func (krc *KuberayAPIServerClient) DeleteNotificationSubscription(request *api.DeleteNotificationSubscriptionRequest) (*rpcStatus.Status, error) {
+	deleteURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/notification_subscriptions/" + request.Name
+	return krc.doDelete(deleteURL)
+}
+
+// EnsureNamespace creates a namespace if it does not exist, and sets it up with the namespace template.
+func (krc *KuberayAPIServerClient) EnsureNamespace(request *api.EnsureNamespaceRequest) (*api.Namespace, *rpcStatus.Status, error) {
+	putURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.EnsureNamespaceRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("PUT", putURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", putURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, putURL)
+	if err != nil {
+		return nil, status, err
+	}
+	namespace := &api.Namespace{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, namespace); err != nil {
+		return nil, status, nil
+	}
+	return namespace, nil, nil
+}
+
+// GetNamespace finds a namespace by its name, with the resources of the namespace template it has.
+func (krc *KuberayAPIServerClient) GetNamespace(request *api.GetNamespaceRequest) (*api.Namespace, *rpcStatus.Status, error) {
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace, request.TargetCluster)
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.Namespace{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// CreateRaySession creates an interactive session.
+func (krc *KuberayAPIServerClient) CreateRaySession(request *api.CreateRaySessionRequest) (*api.RaySession, *rpcStatus.Status, error) {
+	createURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/sessions"
+	bytez, err := krc.marshaler.Marshal(request.Session)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.RaySession to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
+	if err != nil {
+		return nil, status, err
+	}
+	session := &api.RaySession{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, session); err != nil {
+		return nil, status, nil
+	}
+	return session, nil, nil
+}
+
+// GetRaySession finds a specific session by its name and namespace.
+func (krc *KuberayAPIServerClient) GetRaySession(request *api.GetRaySessionRequest) (*api.RaySession, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/sessions/" + request.Name
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	session := &api.RaySession{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, session); err != nil {
+		return nil, status, nil
+	}
+	return session, nil, nil
+}
+
+// ListRaySessions finds all the sessions in a given namespace.
+func (krc *KuberayAPIServerClient) ListRaySessions(request *api.ListRaySessionsRequest) (*api.ListRaySessionsResponse, *rpcStatus.Status, error) {
+	listURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/sessions"
+	if request.User != "" {
+		listURL += "?" + url.Values{"user": []string{request.User}}.Encode()
+	}
+	httpRequest, err := krc.createHttpRequest("GET", listURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", listURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, listURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.ListRaySessionsResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
+// DeleteRaySession deletes a session and its Ray cluster.
+func (krc *KuberayAPIServerClient) DeleteRaySession(request *api.DeleteRaySessionRequest) (*rpcStatus.Status, error) {
+	return krc.doDelete(krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/sessions/" + request.Name)
+}
+
+// CreateRayServiceFromTemplate creates a ray service from a service template.
+func (krc *KuberayAPIServerClient) CreateRayServiceFromTemplate(request *api.CreateRayServiceFromTemplateRequest) (*api.RayService, *rpcStatus.Status, error) {
+	createURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/service_templates/"+request.TemplateName+"/services", request.TargetCluster)
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.CreateRayServiceFromTemplateRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
 	}
 
 	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
 
-	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
 	if err != nil {
 		return nil, status, err
 	}
-	response := &api.ListComputeTemplatesResponse{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+	service := &api.RayService{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, service); err != nil {
 		return nil, status, nil
 	}
-	return response, nil, nil
+	return service, nil, nil
 }
 
-// CreateCluster creates a new cluster.
-func (krc *KuberayAPIServerClient) CreateCluster(request *api.CreateClusterRequest) (*api.Cluster, *rpcStatus.Status, error) {
-	createURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters"
-
-	bytez, err := krc.marshaler.Marshal(request.Cluster)
+// CreateRayService create a new ray serve.
+func (krc *KuberayAPIServerClient) CreateRayService(request *api.CreateRayServiceRequest) (*api.RayService, *rpcStatus.Status, error) {
+	createURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/services"+createQuery(request.DryRun, request.IdempotencyKey), request.TargetCluster)
+	bytez, err := krc.marshaler.Marshal(request.Service)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to marshal api.Cluster to JSON: %w", err)
 	}
@@ -177,112 +1412,144 @@ func (krc *KuberayAPIServerClient) CreateCluster(request *api.CreateClusterReque
 	if err != nil {
 		return nil, status, err
 	}
-	cluster := &api.Cluster{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, cluster); err != nil {
+	rayService := &api.RayService{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayService); err != nil {
 		return nil, status, nil
 	}
-	return cluster, nil, nil
+	return rayService, nil, nil
 }
 
-// DeleteCluster deletes a cluster
-func (krc *KuberayAPIServerClient) DeleteCluster(request *api.DeleteClusterRequest) (*rpcStatus.Status, error) {
-	deleteURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name
-	return krc.doDelete(deleteURL)
-}
+// UpdateRayService updates a ray serve service.
+func (krc *KuberayAPIServerClient) UpdateRayService(request *api.UpdateRayServiceRequest) (*api.RayService, *rpcStatus.Status, error) {
+	updateURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name
+	bytez, err := krc.marshaler.Marshal(request.Service)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.Cluster to JSON: %w", err)
+	}
 
-// GetCluster finds a specific Cluster by ID.
-func (krc *KuberayAPIServerClient) GetCluster(request *api.GetClusterRequest) (*api.Cluster, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters/" + request.Name
-	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	httpRequest, err := krc.createHttpRequest("PUT", updateURL, bytes.NewReader(bytez))
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", updateURL, err)
 	}
 
 	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
 
-	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	bodyBytes, status, err := krc.executeRequest(httpRequest, updateURL)
 	if err != nil {
 		return nil, status, err
 	}
-	cluster := &api.Cluster{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, cluster); err != nil {
+	rayService := &api.RayService{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayService); err != nil {
 		return nil, status, nil
 	}
-	return cluster, nil, nil
+	return rayService, nil, nil
 }
 
-// ListCluster finds all clusters in a given namespace.
-func (krc *KuberayAPIServerClient) ListClusters(request *api.ListClustersRequest) (*api.ListClustersResponse, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/clusters"
-	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+// UpdateRayServiceConfigs updates the serve config and the worker group replicas of a ray serve service in place.
+func (krc *KuberayAPIServerClient) UpdateRayServiceConfigs(request *api.UpdateRayServiceConfigsRequest) (*api.RayService, *rpcStatus.Status, error) {
+	updateURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name + "/configs"
+	if request.ResourceVersion != "" {
+		updateURL += "?" + url.Values{"resourceVersion": []string{request.ResourceVersion}}.Encode()
+	}
+	bytez, err := krc.marshaler.Marshal(request.UpdateService)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+		return nil, nil, fmt.Errorf("failed to marshal api.UpdateServiceBody to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("PATCH", updateURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", updateURL, err)
 	}
 
 	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
 
-	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	bodyBytes, status, err := krc.executeRequest(httpRequest, updateURL)
 	if err != nil {
 		return nil, status, err
 	}
-	response := &api.ListClustersResponse{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+	rayService := &api.RayService{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayService); err != nil {
 		return nil, status, nil
 	}
-	return response, nil, nil
+	return rayService, nil, nil
 }
 
-// ListAllClusters finds all Clusters in all namespaces. Supports pagination, and sorting on certain fields.
-func (krc *KuberayAPIServerClient) ListAllClusters() (*api.ListAllClustersResponse, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/clusters"
-	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+// SuspendRayService scales the worker groups of a ray serve service to zero.
+func (krc *KuberayAPIServerClient) SuspendRayService(request *api.SuspendRayServiceRequest) (*api.RayService, *rpcStatus.Status, error) {
+	return krc.postRayServiceAction(krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name + "/suspend")
+}
+
+// ResumeRayService scales the worker groups of a suspended ray serve service back.
+func (krc *KuberayAPIServerClient) ResumeRayService(request *api.ResumeRayServiceRequest) (*api.RayService, *rpcStatus.Status, error) {
+	return krc.postRayServiceAction(krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name + "/resume")
+}
+
+// StartRayServiceUpgrade starts a staged upgrade of a ray serve service, held until it is promoted.
+func (krc *KuberayAPIServerClient) StartRayServiceUpgrade(request *api.StartRayServiceUpgradeRequest) (*api.RayService, *rpcStatus.Status, error) {
+	postURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/services/"+request.Name+"/upgrade", request.TargetCluster)
+	bytez, err := krc.marshaler.Marshal(request.Service)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+		return nil, nil, fmt.Errorf("failed to marshal api.RayService to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", postURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", postURL, err)
 	}
 
 	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
 
-	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	bodyBytes, status, err := krc.executeRequest(httpRequest, postURL)
 	if err != nil {
 		return nil, status, err
 	}
-	response := &api.ListAllClustersResponse{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+	rayService := &api.RayService{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayService); err != nil {
 		return nil, status, nil
 	}
-	return response, nil, nil
+	return rayService, nil, nil
 }
 
-// CreateRayJob creates a new job.
-func (krc *KuberayAPIServerClient) CreateRayJob(request *api.CreateRayJobRequest) (*api.RayJob, *rpcStatus.Status, error) {
-	createURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/jobs"
-	bytez, err := krc.marshaler.Marshal(request.Job)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal api.Cluster to JSON: %w", err)
+// PromoteRayServiceUpgrade switches the traffic of a ray serve service to the cluster of its staged upgrade.
+func (krc *KuberayAPIServerClient) PromoteRayServiceUpgrade(request *api.PromoteRayServiceUpgradeRequest) (*api.RayService, *rpcStatus.Status, error) {
+	postURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name + "/upgrade/promote"
+	if request.Force {
+		postURL += "?force=true"
 	}
+	return krc.postRayServiceAction(withTargetCluster(postURL, request.TargetCluster))
+}
 
-	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+// RollbackRayServiceUpgrade rolls back the staged upgrade of a ray serve service.
+func (krc *KuberayAPIServerClient) RollbackRayServiceUpgrade(request *api.RollbackRayServiceUpgradeRequest) (*api.RayService, *rpcStatus.Status, error) {
+	postURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name + "/upgrade/rollback"
+	return krc.postRayServiceAction(withTargetCluster(postURL, request.TargetCluster))
+}
+
+func (krc *KuberayAPIServerClient) postRayServiceAction(postURL string) (*api.RayService, *rpcStatus.Status, error) {
+	httpRequest, err := krc.createHttpRequest("POST", postURL, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", postURL, err)
 	}
 
 	httpRequest.Header.Add("Accept", "application/json")
-	httpRequest.Header.Add("Content-Type", "application/json")
 
-	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
+	bodyBytes, status, err := krc.executeRequest(httpRequest, postURL)
 	if err != nil {
 		return nil, status, err
 	}
-	rayJob := &api.RayJob{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayJob); err != nil {
+	rayService := &api.RayService{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayService); err != nil {
 		return nil, status, nil
 	}
-	return rayJob, nil, nil
+	return rayService, nil, nil
 }
 
-// GetRayJob finds a specific job by its name and namespace.
-func (krc *KuberayAPIServerClient) GetRayJob(request *api.GetRayJobRequest) (*api.RayJob, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/jobs/" + request.Name
+// Find a specific ray serve by name and namespace.
+func (krc *KuberayAPIServerClient) GetRayService(request *api.GetRayServiceRequest) (*api.RayService, *rpcStatus.Status, error) {
+	getURL := withEventFilter(withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/services/"+request.Name, request.TargetCluster), request.EventType, request.EventsSince, request.EventLimit)
 	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
@@ -294,37 +1561,52 @@ func (krc *KuberayAPIServerClient) GetRayJob(request *api.GetRayJobRequest) (*ap
 	if err != nil {
 		return nil, status, err
 	}
-	rayJob := &api.RayJob{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayJob); err != nil {
+	response := &api.RayService{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
 		return nil, status, nil
 	}
-	return rayJob, nil, nil
+	return response, nil, nil
 }
 
-// Finds all job in a given namespace.
-func (krc *KuberayAPIServerClient) ListRayJobs(request *api.ListRayJobsRequest) (*api.ListRayJobsResponse, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/jobs"
-	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+// ExportRayService returns the YAML manifest of the RayService custom resource of a ray service.
+func (krc *KuberayAPIServerClient) ExportRayService(request *api.ExportRayServiceRequest) (*api.ResourceManifest, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name + "/manifest"
+	if request.IncludeStatus {
+		getURL += "?includeStatus=true"
+	}
+	return krc.getResourceManifest(withTargetCluster(getURL, request.TargetCluster))
+}
+
+// ImportRayService creates a ray service from the YAML manifest of a RayService custom resource.
+func (krc *KuberayAPIServerClient) ImportRayService(request *api.ImportRayServiceRequest) (*api.RayService, *rpcStatus.Status, error) {
+	postURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services:import"
+	bytez, err := krc.marshaler.Marshal(request)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+		return nil, nil, fmt.Errorf("failed to marshal api.ImportRayServiceRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", postURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", postURL, err)
 	}
 
 	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
 
-	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	bodyBytes, status, err := krc.executeRequest(httpRequest, postURL)
 	if err != nil {
 		return nil, status, err
 	}
-	response := &api.ListRayJobsResponse{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+	rayService := &api.RayService{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayService); err != nil {
 		return nil, status, nil
 	}
-	return response, nil, nil
+	return rayService, nil, nil
 }
 
-// ListAllRayJobs Finds all job in all namespaces.
-func (krc *KuberayAPIServerClient) ListAllRayJobs() (*api.ListAllRayJobsResponse, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/jobs"
+// Returns the addresses of the client server, the dashboard and the serve proxy of a ray service.
+func (krc *KuberayAPIServerClient) GetRayServiceEndpoints(request *api.GetRayServiceEndpointsRequest) (*api.RayEndpoints, *rpcStatus.Status, error) {
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/services/"+request.Name+"/endpoints", request.TargetCluster)
 	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
@@ -336,76 +1618,71 @@ func (krc *KuberayAPIServerClient) ListAllRayJobs() (*api.ListAllRayJobsResponse
 	if err != nil {
 		return nil, status, err
 	}
-	response := &api.ListAllRayJobsResponse{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+	endpoints := &api.RayEndpoints{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, endpoints); err != nil {
 		return nil, status, nil
 	}
-	return response, nil, nil
-}
-
-// Deletes a job by its name and namespace.
-func (krc *KuberayAPIServerClient) DeleteRayJob(request *api.DeleteRayJobRequest) (*rpcStatus.Status, error) {
-	deleteURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/jobs/" + request.Name
-	return krc.doDelete(deleteURL)
+	return endpoints, nil, nil
 }
 
-// CreateRayService create a new ray serve.
-func (krc *KuberayAPIServerClient) CreateRayService(request *api.CreateRayServiceRequest) (*api.RayService, *rpcStatus.Status, error) {
-	createURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services"
-	bytez, err := krc.marshaler.Marshal(request.Service)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal api.Cluster to JSON: %w", err)
+// ListRayServiceHistory lists the changes made to a ray service through the API server, most recent first.
+func (krc *KuberayAPIServerClient) ListRayServiceHistory(request *api.ListRayServiceHistoryRequest) (*api.ListResourceHistoryResponse, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name + "/history"
+	if request.Limit != 0 {
+		getURL += "?limit=" + strconv.Itoa(int(request.Limit))
 	}
-
-	httpRequest, err := krc.createHttpRequest("POST", createURL, bytes.NewReader(bytez))
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", createURL, err)
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
 	}
 
 	httpRequest.Header.Add("Accept", "application/json")
-	httpRequest.Header.Add("Content-Type", "application/json")
 
-	bodyBytes, status, err := krc.executeRequest(httpRequest, createURL)
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
 	if err != nil {
 		return nil, status, err
 	}
-	rayService := &api.RayService{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayService); err != nil {
+	history := &api.ListResourceHistoryResponse{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, history); err != nil {
 		return nil, status, nil
 	}
-	return rayService, nil, nil
+	return history, nil, nil
 }
 
-// UpdateRayService updates a ray serve service.
-func (krc *KuberayAPIServerClient) UpdateRayService(request *api.UpdateRayServiceRequest) (*api.RayService, *rpcStatus.Status, error) {
-	updateURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name
-	bytez, err := krc.marshaler.Marshal(request.Service)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal api.Cluster to JSON: %w", err)
+// HealthCheckRayService probes the Serve HTTP proxy of a ray service and the routes of its applications.
+func (krc *KuberayAPIServerClient) HealthCheckRayService(request *api.HealthCheckRayServiceRequest) (*api.RayServiceHealth, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name + "/health"
+	query := url.Values{}
+	if request.TimeoutSeconds != 0 {
+		query.Set("timeoutSeconds", strconv.Itoa(int(request.TimeoutSeconds)))
 	}
-
-	httpRequest, err := krc.createHttpRequest("PUT", updateURL, bytes.NewReader(bytez))
+	for _, route := range request.Routes {
+		query.Add("routes", route)
+	}
+	if len(query) > 0 {
+		getURL += "?" + query.Encode()
+	}
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", updateURL, err)
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
 	}
 
 	httpRequest.Header.Add("Accept", "application/json")
-	httpRequest.Header.Add("Content-Type", "application/json")
 
-	bodyBytes, status, err := krc.executeRequest(httpRequest, updateURL)
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
 	if err != nil {
 		return nil, status, err
 	}
-	rayService := &api.RayService{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, rayService); err != nil {
+	health := &api.RayServiceHealth{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, health); err != nil {
 		return nil, status, nil
 	}
-	return rayService, nil, nil
+	return health, nil, nil
 }
 
-// Find a specific ray serve by name and namespace.
-func (krc *KuberayAPIServerClient) GetRayService(request *api.GetRayServiceRequest) (*api.RayService, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name
+// GetRayServeConfig returns the serve config of a ray service, the config deployed on its cluster and their differences.
+func (krc *KuberayAPIServerClient) GetRayServeConfig(request *api.GetRayServeConfigRequest) (*api.RayServeConfig, *rpcStatus.Status, error) {
+	getURL := withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/services/"+request.Name+"/serve_config", request.TargetCluster)
 	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
@@ -417,16 +1694,16 @@ func (krc *KuberayAPIServerClient) GetRayService(request *api.GetRayServiceReque
 	if err != nil {
 		return nil, status, err
 	}
-	response := &api.RayService{}
-	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+	serveConfig := &api.RayServeConfig{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, serveConfig); err != nil {
 		return nil, status, nil
 	}
-	return response, nil, nil
+	return serveConfig, nil, nil
 }
 
 // Finds all ray services in a given namespace. Supports pagination, and sorting on certain fields.
 func (krc *KuberayAPIServerClient) ListRayServices(request *api.ListRayServicesRequest) (*api.ListRayServicesResponse, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services"
+	getURL := withListFilter(withEventFilter(withTargetCluster(krc.baseURL+"/apis/v1/namespaces/"+request.Namespace+"/services"+selectedPageQuery(request.PageToken, request.PageSize, request.LabelSelector, request.FieldSelector), request.TargetCluster), request.EventType, request.EventsSince, request.EventLimit), request.Filter)
 	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
@@ -447,7 +1724,12 @@ func (krc *KuberayAPIServerClient) ListRayServices(request *api.ListRayServicesR
 
 // Finds all ray services in a given namespace. Supports pagination, and sorting on certain fields.
 func (krc *KuberayAPIServerClient) ListAllRayServices() (*api.ListAllRayServicesResponse, *rpcStatus.Status, error) {
-	getURL := krc.baseURL + "/apis/v1/services"
+	return krc.ListAllRayServicesWithRequest(&api.ListAllRayServicesRequest{})
+}
+
+// ListAllRayServicesWithRequest is ListAllRayServices with the page, the selectors and the filters of a request.
+func (krc *KuberayAPIServerClient) ListAllRayServicesWithRequest(request *api.ListAllRayServicesRequest) (*api.ListAllRayServicesResponse, *rpcStatus.Status, error) {
+	getURL := withResourceVersion(withListFilter(withEventFilter(withTargetCluster(krc.baseURL+"/apis/v1/services"+selectedPageQuery(request.PageToken, request.PageSize, request.LabelSelector, request.FieldSelector), request.TargetCluster), request.EventType, request.EventsSince, request.EventLimit), request.Filter), request.ResourceVersion)
 	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
@@ -469,9 +1751,49 @@ func (krc *KuberayAPIServerClient) ListAllRayServices() (*api.ListAllRayServices
 // DeleteRayService deletes a ray service by its name and namespace
 func (krc *KuberayAPIServerClient) DeleteRayService(request *api.DeleteRayServiceRequest) (*rpcStatus.Status, error) {
 	deleteURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name
+	query := url.Values{}
+	if request.RetainCluster {
+		query.Set("retainCluster", "true")
+	}
+	if request.Force {
+		query.Set("force", "true")
+	}
+	if request.Foreground {
+		query.Set("foreground", "true")
+	}
+	if request.TargetCluster != "" {
+		query.Set("targetCluster", request.TargetCluster)
+	}
+	if request.ResourceVersion != "" {
+		query.Set("resourceVersion", request.ResourceVersion)
+	}
+	if len(query) > 0 {
+		deleteURL += "?" + query.Encode()
+	}
 	return krc.doDelete(deleteURL)
 }
 
+// GetRayServiceDeletionStatus finds the progress of the deletion of a ray service.
+func (krc *KuberayAPIServerClient) GetRayServiceDeletionStatus(request *api.GetRayServiceDeletionStatusRequest) (*api.RayServiceDeletionStatus, *rpcStatus.Status, error) {
+	getURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/services/" + request.Name + "/deletion"
+	httpRequest, err := krc.createHttpRequest("GET", getURL, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", getURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, getURL)
+	if err != nil {
+		return nil, status, err
+	}
+	response := &api.RayServiceDeletionStatus{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, response); err != nil {
+		return nil, status, nil
+	}
+	return response, nil, nil
+}
+
 // SubmitRayJob creates a new job on a given cluster.
 func (krc *KuberayAPIServerClient) SubmitRayJob(request *api.SubmitRayJobRequest) (*api.SubmitRayJobReply, *rpcStatus.Status, error) {
 	createURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/jobsubmissions/" + request.Clustername
@@ -587,6 +1909,34 @@ func (krc *KuberayAPIServerClient) DeleteRayJobCluster(request *api.DeleteRayJob
 	return krc.doDelete(deleteURL)
 }
 
+// UploadJobWorkingDir uploads the zip of a working directory and returns the URI to use as working_dir in the
+// runtime_env of the jobs submitted to the cluster.
+func (krc *KuberayAPIServerClient) UploadJobWorkingDir(request *api.UploadJobWorkingDirRequest) (*api.UploadJobWorkingDirReply, *rpcStatus.Status, error) {
+	uploadURL := krc.baseURL + "/apis/v1/namespaces/" + request.Namespace + "/jobsubmissions/" + request.Clustername + "/workingdir"
+	bytez, err := krc.marshaler.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal api.UploadJobWorkingDirRequest to JSON: %w", err)
+	}
+
+	httpRequest, err := krc.createHttpRequest("POST", uploadURL, bytes.NewReader(bytez))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create http request for url '%s': %w", uploadURL, err)
+	}
+
+	httpRequest.Header.Add("Accept", "application/json")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	bodyBytes, status, err := krc.executeRequest(httpRequest, uploadURL)
+	if err != nil {
+		return nil, status, err
+	}
+	reply := &api.UploadJobWorkingDirReply{}
+	if err := krc.unmarshaler.Unmarshal(bodyBytes, reply); err != nil {
+		return nil, status, nil
+	}
+	return reply, nil, nil
+}
+
 func (krc *KuberayAPIServerClient) doDelete(deleteURL string) (*rpcStatus.Status, error) {
 	httpRequest, err := krc.createHttpRequest("DELETE", deleteURL, nil)
 	if err != nil {
@@ -635,3 +1985,105 @@ func (krc *KuberayAPIServerClient) createHttpRequest(method string, endPoint str
 	}
 	return req, nil
 }
+
+// pageQuery returns the query string requesting a page of a List call, or an empty string for the first page of an
+// unpaginated call.
+func pageQuery(pageToken string, pageSize int32) string {
+	return selectedPageQuery(pageToken, pageSize, "", "")
+}
+
+// selectedPageQuery returns the query string requesting a page of a List call restricted to the resources matching
+// the label and field selectors.
+func selectedPageQuery(pageToken string, pageSize int32, labelSelector string, fieldSelector string) string {
+	query := url.Values{}
+	if pageToken != "" {
+		query.Set("pageToken", pageToken)
+	}
+	if pageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(int(pageSize)))
+	}
+	if labelSelector != "" {
+		query.Set("labelSelector", labelSelector)
+	}
+	if fieldSelector != "" {
+		query.Set("fieldSelector", fieldSelector)
+	}
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
+
+// createQuery returns the query string asking the API server to validate a resource without creating it, and to
+// return the resource created by a previous call with the same idempotency key.
+func createQuery(dryRun bool, idempotencyKey string) string {
+	query := url.Values{}
+	if dryRun {
+		query.Set("dryRun", "true")
+	}
+	if idempotencyKey != "" {
+		query.Set("idempotencyKey", idempotencyKey)
+	}
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
+
+// withTargetCluster adds the Kubernetes cluster targeted by a request to the query string of its URL.
+func withTargetCluster(rawURL string, targetCluster string) string {
+	if targetCluster == "" {
+		return rawURL
+	}
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + url.Values{"targetCluster": []string{targetCluster}}.Encode()
+}
+
+// withListFilter adds the filter expression of a List request to the query string of a URL.
+func withListFilter(rawURL string, filter string) string {
+	if filter == "" {
+		return rawURL
+	}
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + url.Values{"filter": []string{filter}}.Encode()
+}
+
+// withResourceVersion adds the resource version of a listing to the query string of a URL.
+func withResourceVersion(rawURL string, resourceVersion string) string {
+	if resourceVersion == "" {
+		return rawURL
+	}
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + url.Values{"resourceVersion": []string{resourceVersion}}.Encode()
+}
+
+// withEventFilter adds the filter of the events returned with the resources to the query string of a URL.
+func withEventFilter(rawURL string, eventType string, since *timestamppb.Timestamp, limit int32) string {
+	query := url.Values{}
+	if eventType != "" {
+		query.Set("eventType", eventType)
+	}
+	if since != nil {
+		query.Set("eventsSince", since.AsTime().Format(time.RFC3339Nano))
+	}
+	if limit > 0 {
+		query.Set("eventLimit", strconv.Itoa(int(limit)))
+	}
+	if len(query) == 0 {
+		return rawURL
+	}
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + query.Encode()
+}
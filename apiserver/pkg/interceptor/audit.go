@@ -0,0 +1,293 @@
+package interceptor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	klog "k8s.io/klog/v2"
+)
+
+// AuditRecord is the audit log entry of a mutating RPC.
+type AuditRecord struct {
+	Time          time.Time `json:"time"`
+	Method        string    `json:"method"`
+	RequestID     string    `json:"requestID,omitempty"`
+	User          string    `json:"user,omitempty"`
+	Groups        []string  `json:"groups,omitempty"`
+	Peer          string    `json:"peer,omitempty"`
+	Namespace     string    `json:"namespace,omitempty"`
+	Name          string    `json:"name,omitempty"`
+	RequestDigest string    `json:"requestDigest,omitempty"`
+	Code          string    `json:"code"`
+	Error         string    `json:"error,omitempty"`
+	DurationMs    int64     `json:"durationMs"`
+}
+
+// AuditSink stores audit records. Write must not block the RPC for long. Close flushes the records written so far
+// until ctx is done, the records written after it are dropped.
+type AuditSink interface {
+	Write(record *AuditRecord)
+	Close(ctx context.Context) error
+}
+
+// NewAuditSink creates the audit sink described by spec, which is either `stdout`, a `file://` path
+// to which records are appended, or an `http://` or `https://` webhook URL.
+func NewAuditSink(spec string) (AuditSink, error) {
+	switch {
+	case spec == "stdout":
+		return NewJSONAuditSink(os.Stdout), nil
+	case strings.HasPrefix(spec, "file://"):
+		file, err := os.OpenFile(strings.TrimPrefix(spec, "file://"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the audit log file: %w", err)
+		}
+		sink := NewJSONAuditSink(file)
+		sink.closer = file
+		return sink, nil
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return NewWebhookAuditSink(spec, http.DefaultClient), nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink %q, expected stdout, file://<path> or a webhook URL", spec)
+	}
+}
+
+// JSONAuditSink writes every audit record as a line of JSON.
+type JSONAuditSink struct {
+	mu     sync.Mutex
+	writer io.Writer
+	// closer closes the file of the records, if the sink owns it.
+	closer io.Closer
+	closed bool
+}
+
+func NewJSONAuditSink(writer io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{writer: writer}
+}
+
+func (s *JSONAuditSink) Write(record *AuditRecord) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		klog.Errorf("Failed to marshal the audit record of %s: %v", record.Method, err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		klog.Errorf("The audit sink is closed, dropping the audit record of %s", record.Method)
+		return
+	}
+	if _, err := s.writer.Write(append(line, '\n')); err != nil {
+		klog.Errorf("Failed to write the audit record of %s: %v", record.Method, err)
+	}
+}
+
+// Close closes the file of the records. The records are written synchronously, so that there is nothing to flush.
+func (s *JSONAuditSink) Close(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.closer != nil {
+		return s.closer.Close()
+	}
+	return nil
+}
+
+// WebhookAuditSink posts every audit record as JSON to a webhook. Records are sent in the background,
+// and dropped with an error log when the webhook can not keep up.
+type WebhookAuditSink struct {
+	url     string
+	client  *http.Client
+	records chan *AuditRecord
+	// done is closed once the queued records are sent after Close.
+	done chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+const webhookAuditQueueSize = 1000
+
+func NewWebhookAuditSink(url string, client *http.Client) *WebhookAuditSink {
+	s := &WebhookAuditSink{
+		url:     url,
+		client:  client,
+		records: make(chan *AuditRecord, webhookAuditQueueSize),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *WebhookAuditSink) Write(record *AuditRecord) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		klog.Errorf("The audit sink is closed, dropping the audit record of %s", record.Method)
+		return
+	}
+	select {
+	case s.records <- record:
+	default:
+		klog.Errorf("The audit webhook queue is full, dropping the audit record of %s", record.Method)
+	}
+}
+
+// Close stops queueing records and waits until the queued records are sent, or until ctx is done.
+func (s *WebhookAuditSink) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.records)
+	}
+	s.mu.Unlock()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d audit records were not sent to the webhook: %w", len(s.records), ctx.Err())
+	}
+}
+
+func (s *WebhookAuditSink) run() {
+	defer close(s.done)
+	for record := range s.records {
+		if err := s.post(record); err != nil {
+			klog.Errorf("Failed to send the audit record of %s to the webhook: %v", record.Method, err)
+		}
+	}
+}
+
+func (s *WebhookAuditSink) post(record *AuditRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("the webhook responded with %s", response.Status)
+	}
+	return nil
+}
+
+// mutatingMethodPrefixes are the prefixes of the names of the RPCs changing resources.
+var mutatingMethodPrefixes = []string{"Create", "Update", "Delete", "Import", "Submit", "Stop", "Upload", "Suspend", "Resume", "Ensure"}
+
+// AuditInterceptor records the caller, the target resource and the outcome of every mutating RPC.
+type AuditInterceptor struct {
+	sink AuditSink
+}
+
+func NewAuditInterceptor(sink AuditSink) *AuditInterceptor {
+	return &AuditInterceptor{sink: sink}
+}
+
+// Unary writes an audit record once a mutating unary call has been handled.
+func (a *AuditInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !isMutatingMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	record := &AuditRecord{
+		Time:       start.UTC(),
+		Method:     info.FullMethod,
+		RequestID:  RequestIDFromContext(ctx),
+		Code:       status.Code(err).String(),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if user, ok := UserFromContext(ctx); ok {
+		record.User = user.Username
+		record.Groups = user.Groups
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		record.Peer = p.Addr.String()
+	}
+	if message, ok := req.(proto.Message); ok {
+		record.Namespace, record.Name = requestTarget(message)
+		record.RequestDigest = requestDigest(message)
+	}
+	a.sink.Write(record)
+	return resp, err
+}
+
+func isMutatingMethod(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range mutatingMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestTarget returns the namespace and the name of the resource targeted by a request. They are
+// either fields of the request, or of the resource embedded in it, e.g. the cluster of a create request.
+func requestTarget(message proto.Message) (string, string) {
+	namespace, name := stringField(message.ProtoReflect(), "namespace"), stringField(message.ProtoReflect(), "name")
+	fields := message.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len() && name == ""; i++ {
+		field := fields.Get(i)
+		if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() || !message.ProtoReflect().Has(field) {
+			continue
+		}
+		resource := message.ProtoReflect().Get(field).Message()
+		name = stringField(resource, "name")
+		if namespace == "" {
+			namespace = stringField(resource, "namespace")
+		}
+	}
+	return namespace, name
+}
+
+func stringField(message protoreflect.Message, name protoreflect.Name) string {
+	field := message.Descriptor().Fields().ByName(name)
+	if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+		return ""
+	}
+	return message.Get(field).String()
+}
+
+// requestDigest returns the SHA-256 of the request, so that audit records identify the payloads
+// without storing them.
+func requestDigest(message proto.Message) string {
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		klog.Errorf("Failed to marshal the request to digest: %v", err)
+		return ""
+	}
+	digest := sha256.Sum256(payload)
+	return "sha256:" + hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,114 @@
+package interceptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestAuditInterceptor(t *testing.T) {
+	var buffer bytes.Buffer
+	auditInterceptor := NewAuditInterceptor(NewJSONAuditSink(&buffer))
+	ctx := context.WithValue(context.Background(), userKey{}, &authenticationv1.UserInfo{Username: "alice"})
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return nil, status.Error(codes.AlreadyExists, "cluster already exists")
+	}
+
+	request := &api.CreateClusterRequest{Namespace: "team-a", Cluster: &api.Cluster{Name: "cluster"}}
+	_, err := auditInterceptor.Unary(ctx, request, &grpc.UnaryServerInfo{FullMethod: "/proto.ClusterService/CreateCluster"}, handler)
+	require.Error(t, err)
+
+	// Read only calls are not audited.
+	_, err = auditInterceptor.Unary(ctx, &api.GetClusterRequest{}, &grpc.UnaryServerInfo{FullMethod: "/proto.ClusterService/GetCluster"}, handler)
+	require.Error(t, err)
+
+	var record AuditRecord
+	decoder := json.NewDecoder(&buffer)
+	require.NoError(t, decoder.Decode(&record))
+	assert.Equal(t, "/proto.ClusterService/CreateCluster", record.Method)
+	assert.Equal(t, "alice", record.User)
+	assert.Equal(t, "team-a", record.Namespace)
+	assert.Equal(t, "cluster", record.Name)
+	assert.Equal(t, "AlreadyExists", record.Code)
+	assert.Equal(t, requestDigest(request), record.RequestDigest)
+	assert.Contains(t, record.RequestDigest, "sha256:")
+	assert.Error(t, decoder.Decode(&record), "Only one record is written")
+}
+
+func TestRequestTarget(t *testing.T) {
+	namespace, name := requestTarget(&api.DeleteRayServiceRequest{Name: "service", Namespace: "team-a"})
+	assert.Equal(t, "team-a", namespace)
+	assert.Equal(t, "service", name)
+
+	namespace, name = requestTarget(&api.CreateRayServiceRequest{Namespace: "team-a", Service: &api.RayService{Name: "service"}})
+	assert.Equal(t, "team-a", namespace)
+	assert.Equal(t, "service", name)
+}
+
+func TestWebhookAuditSink(t *testing.T) {
+	received := make(chan AuditRecord, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var record AuditRecord
+		if err := json.Unmarshal(body, &record); err == nil {
+			received <- record
+		}
+	}))
+	defer webhook.Close()
+
+	sink, err := NewAuditSink(webhook.URL)
+	require.NoError(t, err)
+	sink.Write(&AuditRecord{Method: "/proto.RayJobService/DeleteRayJob", Code: "OK"})
+
+	select {
+	case record := <-received:
+		assert.Equal(t, "/proto.RayJobService/DeleteRayJob", record.Method)
+	case <-time.After(5 * time.Second):
+		t.Fatal("The audit record was not posted to the webhook")
+	}
+
+	// Close waits for the queued records, the records written after it are dropped.
+	sink.Write(&AuditRecord{Method: "/proto.RayJobService/StopRayJob", Code: "OK"})
+	require.NoError(t, sink.Close(context.Background()))
+	select {
+	case record := <-received:
+		assert.Equal(t, "/proto.RayJobService/StopRayJob", record.Method)
+	default:
+		t.Fatal("The queued audit record was not posted before Close returned")
+	}
+	sink.Write(&AuditRecord{Method: "/proto.RayJobService/DeleteRayJob", Code: "OK"})
+	require.NoError(t, sink.Close(context.Background()))
+
+	_, err = NewAuditSink("syslog")
+	require.Error(t, err)
+}
+
+func TestJSONAuditSinkClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewAuditSink("file://" + path)
+	require.NoError(t, err)
+	sink.Write(&AuditRecord{Method: "/proto.ClusterService/DeleteCluster", Code: "OK"})
+	require.NoError(t, sink.Close(context.Background()))
+	sink.Write(&AuditRecord{Method: "/proto.ClusterService/CreateCluster", Code: "OK"})
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, bytes.Count(content, []byte("\n")), "The records written after Close are dropped")
+	assert.Contains(t, string(content), "DeleteCluster")
+}
@@ -0,0 +1,426 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authenticationclientv1 "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationclientv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// Authenticator resolves the identity of the caller of an RPC from its bearer token.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error)
+}
+
+// Authorizer decides whether a user may perform an action on a Kubernetes resource. The returned
+// string gives the reason of a denial.
+type Authorizer interface {
+	Authorize(ctx context.Context, user *authenticationv1.UserInfo, attributes *authorizationv1.ResourceAttributes) (bool, string, error)
+}
+
+// TokenReviewAuthenticator validates bearer tokens with the Kubernetes TokenReview API, so every token
+// accepted by the Kubernetes API server is accepted, including OIDC tokens when it is configured with
+// an OIDC issuer.
+type TokenReviewAuthenticator struct {
+	client authenticationclientv1.TokenReviewInterface
+}
+
+func NewTokenReviewAuthenticator(client authenticationclientv1.TokenReviewInterface) *TokenReviewAuthenticator {
+	return &TokenReviewAuthenticator{client: client}
+}
+
+func (a *TokenReviewAuthenticator) Authenticate(ctx context.Context, token string) (*authenticationv1.UserInfo, error) {
+	review, err := a.client.Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to review the token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		if review.Status.Error != "" {
+			return nil, fmt.Errorf("the token is not authenticated: %s", review.Status.Error)
+		}
+		return nil, fmt.Errorf("the token is not authenticated")
+	}
+	return &review.Status.User, nil
+}
+
+// SubjectAccessReviewAuthorizer checks the RBAC permissions of users with the Kubernetes
+// SubjectAccessReview API.
+type SubjectAccessReviewAuthorizer struct {
+	client authorizationclientv1.SubjectAccessReviewInterface
+}
+
+func NewSubjectAccessReviewAuthorizer(client authorizationclientv1.SubjectAccessReviewInterface) *SubjectAccessReviewAuthorizer {
+	return &SubjectAccessReviewAuthorizer{client: client}
+}
+
+func (a *SubjectAccessReviewAuthorizer) Authorize(ctx context.Context, user *authenticationv1.UserInfo, attributes *authorizationv1.ResourceAttributes) (bool, string, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, values := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(values)
+	}
+	review, err := a.client.Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, "", fmt.Errorf("failed to review the access: %w", err)
+	}
+	return review.Status.Allowed, review.Status.Reason, nil
+}
+
+// methodAuthorization is the Kubernetes action an RPC performs on behalf of its caller. The namespace of the request
+// of an RPC on a cluster scoped resource, i.e. a namespace, is the name of the resource.
+type methodAuthorization struct {
+	verb          string
+	group         string
+	resource      string
+	subresource   string
+	clusterScoped bool
+}
+
+// methodAuthorizations lists the RPCs which need the caller to have RBAC permission on the resource
+// in the namespace of the request. The other RPCs only need an authenticated caller.
+var methodAuthorizations = map[string]methodAuthorization{
+	"/proto.ClusterService/CreateCluster":                        {verb: "create", group: "ray.io", resource: "rayclusters"},
+	"/proto.ClusterService/DeleteCluster":                        {verb: "delete", group: "ray.io", resource: "rayclusters"},
+	"/proto.ClusterService/BatchDeleteRayClusters":               {verb: "delete", group: "ray.io", resource: "rayclusters"},
+	"/proto.ClusterService/UpdateCluster":                        {verb: "update", group: "ray.io", resource: "rayclusters"},
+	"/proto.ClusterService/UpdateWorkerGroupAutoscaling":         {verb: "update", group: "ray.io", resource: "rayclusters"},
+	"/proto.ClusterService/UpdateWorkerGroup":                    {verb: "update", group: "ray.io", resource: "rayclusters"},
+	"/proto.ClusterService/RestartWorkerGroup":                   {verb: "update", group: "ray.io", resource: "rayclusters"},
+	"/proto.ClusterService/DrainWorkerGroup":                     {verb: "update", group: "ray.io", resource: "rayclusters"},
+	"/proto.ClusterService/InjectClusterFailure":                 {verb: "delete", resource: "pods"},
+	"/proto.ClusterService/HealClusterPartitions":                {verb: "delete", group: "networking.k8s.io", resource: "networkpolicies"},
+	"/proto.ClusterService/CloneRayCluster":                      {verb: "create", group: "ray.io", resource: "rayclusters"},
+	"/proto.RayJobService/CreateRayJob":                          {verb: "create", group: "ray.io", resource: "rayjobs"},
+	"/proto.RayJobService/BatchCreateRayJobs":                    {verb: "create", group: "ray.io", resource: "rayjobs"},
+	"/proto.RayJobService/DeleteRayJob":                          {verb: "delete", group: "ray.io", resource: "rayjobs"},
+	"/proto.RayJobService/StreamRayJobLogs":                      {verb: "get", resource: "pods", subresource: "log"},
+	"/proto.RayJobService/GetRayJobOutput":                       {verb: "get", resource: "pods", subresource: "log"},
+	"/proto.RayServeService/CreateRayService":                    {verb: "create", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/ImportRayService":                    {verb: "create", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/UpdateRayService":                    {verb: "update", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/UpdateRayServiceConfigs":             {verb: "update", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/SuspendRayService":                   {verb: "update", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/ResumeRayService":                    {verb: "update", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/StartRayServiceUpgrade":              {verb: "update", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/PromoteRayServiceUpgrade":            {verb: "update", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/RollbackRayServiceUpgrade":           {verb: "update", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/DeleteRayService":                    {verb: "delete", group: "ray.io", resource: "rayservices"},
+	"/proto.RayServeService/StreamRayServiceLogs":                {verb: "get", resource: "pods", subresource: "log"},
+	"/proto.ComputeTemplateService/CreateComputeTemplate":        {verb: "create", resource: "configmaps"},
+	"/proto.ComputeTemplateService/UpdateComputeTemplate":        {verb: "update", resource: "configmaps"},
+	"/proto.ComputeTemplateService/DeleteComputeTemplate":        {verb: "delete", resource: "configmaps"},
+	"/proto.ImageTemplateService/CreateImageTemplate":            {verb: "create", resource: "configmaps"},
+	"/proto.ImageTemplateService/DeleteImageTemplate":            {verb: "delete", resource: "configmaps"},
+	"/proto.RayCronJobService/CreateRayCronJob":                  {verb: "create", group: "ray.io", resource: "rayjobs"},
+	"/proto.RayCronJobService/DeleteRayCronJob":                  {verb: "delete", group: "ray.io", resource: "rayjobs"},
+	"/proto.ServiceTemplateService/CreateServiceTemplate":        {verb: "create", resource: "configmaps"},
+	"/proto.ServiceTemplateService/DeleteServiceTemplate":        {verb: "delete", resource: "configmaps"},
+	"/proto.ServiceTemplateService/CreateRayServiceFromTemplate": {verb: "create", group: "ray.io", resource: "rayservices"},
+	"/proto.NotificationService/CreateNotificationSubscription":  {verb: "create", resource: "configmaps"},
+	"/proto.NotificationService/DeleteNotificationSubscription":  {verb: "delete", resource: "configmaps"},
+	"/proto.NamespaceService/EnsureNamespace":                    {verb: "create", resource: "namespaces", clusterScoped: true},
+	"/proto.RaySessionService/CreateRaySession":                  {verb: "create", group: "ray.io", resource: "rayclusters"},
+	"/proto.RaySessionService/DeleteRaySession":                  {verb: "delete", group: "ray.io", resource: "rayclusters"},
+	"/proto.RaySessionService/ConnectRaySession":                 {verb: "create", group: "ray.io", resource: "rayclusters", subresource: "proxy"},
+	"/proto.RayJobSubmissionService/SubmitRayJob":                {verb: "create", group: "ray.io", resource: "rayclusters", subresource: "proxy"},
+	"/proto.RayJobSubmissionService/StopRayJob":                  {verb: "update", group: "ray.io", resource: "rayclusters", subresource: "proxy"},
+	"/proto.RayJobSubmissionService/DeleteRayJob":                {verb: "delete", group: "ray.io", resource: "rayclusters", subresource: "proxy"},
+	"/proto.RayJobSubmissionService/UploadJobWorkingDir":         {verb: "create", group: "ray.io", resource: "rayclusters", subresource: "proxy"},
+	"/proto.RayJobSubmissionService/GetJobLog":                   {verb: "get", resource: "pods", subresource: "log"},
+}
+
+// requestAuthorizations lists the RPCs whose RBAC permissions depend on their request, besides the ones in
+// methodAuthorizations.
+var requestAuthorizations = map[string]func(req interface{}) []methodAuthorization{
+	"/proto.BackupService/ExportBackup": exportBackupAuthorizations,
+	"/proto.BackupService/ImportBackup": importBackupAuthorizations,
+}
+
+// backupKindAuthorizations are the permissions needed to create the resources of each kind of a backup bundle.
+var backupKindAuthorizations = map[string]methodAuthorization{
+	model.BackupKindConfigMap:  {verb: "create", resource: "configmaps"},
+	model.BackupKindSecret:     {verb: "create", resource: "secrets"},
+	model.BackupKindRayCluster: {verb: "create", group: "ray.io", resource: "rayclusters"},
+	model.BackupKindRayService: {verb: "create", group: "ray.io", resource: "rayservices"},
+	model.BackupKindRayJob:     {verb: "create", group: "ray.io", resource: "rayjobs"},
+}
+
+// exportBackupAuthorizations needs the caller to read the exported resources, including the Secrets when they are
+// exported.
+func exportBackupAuthorizations(req interface{}) []methodAuthorization {
+	authorizations := []methodAuthorization{
+		{verb: "list", group: "ray.io", resource: "rayclusters"},
+		{verb: "list", group: "ray.io", resource: "rayservices"},
+		{verb: "list", group: "ray.io", resource: "rayjobs"},
+		{verb: "get", resource: "configmaps"},
+	}
+	if request, ok := req.(*api.ExportBackupRequest); ok && request.IncludeSecrets {
+		authorizations = append(authorizations, methodAuthorization{verb: "get", resource: "secrets"})
+	}
+	return authorizations
+}
+
+// importBackupAuthorizations needs the caller to create every kind of resource restored from the bundle. Unknown
+// kinds are rejected by the import itself.
+func importBackupAuthorizations(req interface{}) []methodAuthorization {
+	request, ok := req.(*api.ImportBackupRequest)
+	if !ok {
+		return nil
+	}
+	var authorizations []methodAuthorization
+	seen := map[string]bool{}
+	for _, resource := range request.GetBundle().GetResources() {
+		if authorization, ok := backupKindAuthorizations[resource.Kind]; ok && !seen[resource.Kind] {
+			seen[resource.Kind] = true
+			authorizations = append(authorizations, authorization)
+		}
+	}
+	return authorizations
+}
+
+type userKey struct{}
+
+// UserFromContext returns the authenticated caller of the RPC handled with ctx. The second return
+// value is false when authentication is disabled.
+func UserFromContext(ctx context.Context) (*authenticationv1.UserInfo, bool) {
+	user, ok := ctx.Value(userKey{}).(*authenticationv1.UserInfo)
+	return user, ok
+}
+
+// AuthInterceptor authenticates the callers of the API server with the bearer token of their
+// requests, or with their client certificate if clientCertificates is set and the request has no bearer token, and
+// authorizes the RPCs listed in methodAuthorizations against the namespace of the request.
+// When the API server config has role bindings, every RPC is authorized with the built-in roles instead.
+type AuthInterceptor struct {
+	authenticator      Authenticator
+	authorizer         Authorizer
+	clientCertificates *ClientCertificateAuthenticator
+}
+
+func NewAuthInterceptor(authenticator Authenticator, authorizer Authorizer, clientCertificates *ClientCertificateAuthenticator) *AuthInterceptor {
+	return &AuthInterceptor{authenticator: authenticator, authorizer: authorizer, clientCertificates: clientCertificates}
+}
+
+// Unary rejects unary calls of unauthenticated or unauthorized callers.
+func (a *AuthInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if isPublicMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	user, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.authorize(ctx, user, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, userKey{}, user), req)
+}
+
+// Stream rejects new streams of unauthenticated callers. The request of a stream is only received
+// by the handler, so the stream is authorized when the handler receives its first request.
+func (a *AuthInterceptor) Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isPublicMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	user, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authorizedStream{ServerStream: ss, interceptor: a, user: user, fullMethod: info.FullMethod})
+}
+
+// authorizedStream authorizes a stream against the namespace of its first request.
+type authorizedStream struct {
+	grpc.ServerStream
+	interceptor *AuthInterceptor
+	user        *authenticationv1.UserInfo
+	fullMethod  string
+	authorized  bool
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return context.WithValue(s.ServerStream.Context(), userKey{}, s.user)
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if !s.authorized {
+		if err := s.interceptor.authorize(s.ServerStream.Context(), s.user, s.fullMethod, m); err != nil {
+			return err
+		}
+		s.authorized = true
+	}
+	return nil
+}
+
+// AuthorizeResource authenticates the caller of a call which is not an RPC of the API server, e.g. a Ray client call
+// of the session proxy, and authorizes fullMethod on the resource name in namespace. It returns ctx with the caller.
+func (a *AuthInterceptor) AuthorizeResource(ctx context.Context, fullMethod string, namespace string, name string) (context.Context, error) {
+	user, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.authorize(ctx, user, fullMethod, resourceRequest{namespace: namespace, name: name}); err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, userKey{}, user), nil
+}
+
+// resourceRequest is the target of a call which is not an RPC of the API server.
+type resourceRequest struct {
+	namespace string
+	name      string
+}
+
+func (r resourceRequest) GetNamespace() string {
+	return r.namespace
+}
+
+func (r resourceRequest) GetName() string {
+	return r.name
+}
+
+func (a *AuthInterceptor) authenticate(ctx context.Context) (*authenticationv1.UserInfo, error) {
+	token, ok := bearerToken(ctx)
+	if !ok && a.clientCertificates != nil {
+		user, ok, err := a.clientCertificates.Authenticate(ctx)
+		if err != nil {
+			klog.FromContext(ctx).Error(err, "Failed to authenticate the caller with its client certificate")
+			return nil, status.Error(codes.Unauthenticated, "the client certificate of the request is not valid")
+		}
+		if ok {
+			return user, nil
+		}
+	}
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "the request has no bearer token")
+	}
+	user, err := a.authenticator.Authenticate(ctx, token)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to authenticate the caller")
+		return nil, status.Error(codes.Unauthenticated, "the bearer token of the request is not valid")
+	}
+	return user, nil
+}
+
+func (a *AuthInterceptor) authorize(ctx context.Context, user *authenticationv1.UserInfo, fullMethod string, req interface{}) error {
+	var namespace, name string
+	if r, ok := req.(interface{ GetNamespace() string }); ok {
+		namespace = r.GetNamespace()
+	}
+	if r, ok := req.(interface{ GetName() string }); ok {
+		name = r.GetName()
+	}
+	// The job submission calls go through the dashboard of the cluster they name.
+	if r, ok := req.(interface{ GetClustername() string }); ok {
+		name = r.GetClustername()
+	}
+	if cfg := config.Get(); len(cfg.RoleBindings) > 0 {
+		return authorizeRoles(cfg, user, fullMethod, namespace)
+	}
+
+	var authorizations []methodAuthorization
+	if authorization, ok := methodAuthorizations[fullMethod]; ok {
+		authorizations = append(authorizations, authorization)
+	}
+	if requestAuthorization, ok := requestAuthorizations[fullMethod]; ok {
+		authorizations = append(authorizations, requestAuthorization(req)...)
+	}
+	for _, authorization := range authorizations {
+		if err := a.authorizeAction(ctx, user, fullMethod, authorization, namespace, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authorizeAction checks that the caller has the RBAC permission of an action of the RPC on the resource name in
+// namespace.
+func (a *AuthInterceptor) authorizeAction(ctx context.Context, user *authenticationv1.UserInfo, fullMethod string, authorization methodAuthorization, namespace string, name string) error {
+	attributes := &authorizationv1.ResourceAttributes{
+		Verb:        authorization.verb,
+		Group:       authorization.group,
+		Resource:    authorization.resource,
+		Subresource: authorization.subresource,
+		Namespace:   namespace,
+		Name:        name,
+	}
+	if authorization.clusterScoped {
+		attributes.Namespace, attributes.Name = "", namespace
+	}
+
+	allowed, reason, err := a.authorizer.Authorize(ctx, user, attributes)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to authorize the caller", "user", user.Username)
+		return status.Errorf(codes.Internal, "failed to authorize %v", fullMethod)
+	}
+	if !allowed {
+		message := fmt.Sprintf("%s is not allowed to %s %s in namespace %s", user.Username, attributes.Verb, attributes.Resource, attributes.Namespace)
+		if authorization.clusterScoped {
+			message = fmt.Sprintf("%s is not allowed to %s %s %s", user.Username, attributes.Verb, attributes.Resource, attributes.Name)
+		}
+		if reason != "" {
+			message += ": " + reason
+		}
+		return status.Error(codes.PermissionDenied, message)
+	}
+	return nil
+}
+
+// authorizeRoles authorizes every RPC with the built-in roles bound to the caller in the namespace
+// of the request, instead of Kubernetes RBAC.
+func authorizeRoles(cfg *config.Config, user *authenticationv1.UserInfo, fullMethod string, namespace string) error {
+	if roleAllows(cfg.BoundRoles(user.Username, user.Groups, namespace), fullMethod) {
+		return nil
+	}
+	if namespace == "" {
+		return status.Errorf(codes.PermissionDenied, "%s has no role allowing %s in all namespaces", user.Username, fullMethod)
+	}
+	return status.Errorf(codes.PermissionDenied, "%s has no role allowing %s in namespace %s", user.Username, fullMethod, namespace)
+}
+
+// bearerToken returns the token of the authorization metadata. The HTTP proxy forwards the
+// Authorization header of HTTP requests as this metadata.
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	for _, value := range md.Get("authorization") {
+		if token, found := strings.CutPrefix(value, "Bearer "); found && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// isPublicMethod returns whether the RPC is served without authentication, like the gRPC
+// reflection and health services.
+func isPublicMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.")
+}
@@ -0,0 +1,328 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+type fakeAuthenticator struct{}
+
+func (fakeAuthenticator) Authenticate(_ context.Context, token string) (*authenticationv1.UserInfo, error) {
+	if token != "valid" {
+		return nil, errors.New("invalid token")
+	}
+	return &authenticationv1.UserInfo{Username: "alice"}, nil
+}
+
+// fakeAuthorizer allows every action in the team-a namespace.
+type fakeAuthorizer struct {
+	attributes *authorizationv1.ResourceAttributes
+}
+
+func (a *fakeAuthorizer) Authorize(_ context.Context, _ *authenticationv1.UserInfo, attributes *authorizationv1.ResourceAttributes) (bool, string, error) {
+	a.attributes = attributes
+	return attributes.Namespace == "team-a", "", nil
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	authorizer := &fakeAuthorizer{}
+	authInterceptor := NewAuthInterceptor(fakeAuthenticator{}, authorizer, nil)
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		user, ok := UserFromContext(ctx)
+		require.True(t, ok)
+		return user.Username, nil
+	}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	deleteInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.RayServeService/DeleteRayService"}
+
+	_, err := authInterceptor.Unary(context.Background(), &api.DeleteRayServiceRequest{}, deleteInfo, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = authInterceptor.Unary(withToken("invalid"), &api.DeleteRayServiceRequest{}, deleteInfo, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	resp, err := authInterceptor.Unary(withToken("valid"), &api.DeleteRayServiceRequest{Name: "service", Namespace: "team-a"}, deleteInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", resp)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Verb:      "delete",
+		Group:     "ray.io",
+		Resource:  "rayservices",
+		Namespace: "team-a",
+		Name:      "service",
+	}, authorizer.attributes)
+
+	_, err = authInterceptor.Unary(withToken("valid"), &api.DeleteRayServiceRequest{Name: "service", Namespace: "team-b"}, deleteInfo, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The namespace of the request is the name of the cluster scoped namespaces.
+	ensureInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.NamespaceService/EnsureNamespace"}
+	_, err = authInterceptor.Unary(withToken("valid"), &api.EnsureNamespaceRequest{Namespace: "team-a"}, ensureInfo, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, &authorizationv1.ResourceAttributes{Verb: "create", Resource: "namespaces", Name: "team-a"}, authorizer.attributes)
+
+	// The cron jobs create and delete RayJobs, so they need the permissions on RayJobs.
+	cronJobInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.RayCronJobService/CreateRayCronJob"}
+	_, err = authInterceptor.Unary(withToken("valid"), &api.CreateRayCronJobRequest{Namespace: "team-a"}, cronJobInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{Verb: "create", Group: "ray.io", Resource: "rayjobs", Namespace: "team-a"}, authorizer.attributes)
+
+	batchInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.RayJobService/BatchCreateRayJobs"}
+	_, err = authInterceptor.Unary(withToken("valid"), &api.BatchCreateRayJobsRequest{Namespace: "team-b"}, batchInfo, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, &authorizationv1.ResourceAttributes{Verb: "create", Group: "ray.io", Resource: "rayjobs", Namespace: "team-b"}, authorizer.attributes)
+
+	// The job submission calls are authorized on the cluster running the jobs.
+	submitInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.RayJobSubmissionService/SubmitRayJob"}
+	_, err = authInterceptor.Unary(withToken("valid"), &api.SubmitRayJobRequest{Namespace: "team-a", Clustername: "cluster"}, submitInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Verb:        "create",
+		Group:       "ray.io",
+		Resource:    "rayclusters",
+		Subresource: "proxy",
+		Namespace:   "team-a",
+		Name:        "cluster",
+	}, authorizer.attributes)
+
+	// The logs of the submitted jobs need the same permission as the logs of the RayJobs.
+	logInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.RayJobSubmissionService/GetJobLog"}
+	_, err = authInterceptor.Unary(withToken("valid"), &api.GetJobLogRequest{Namespace: "team-a", Clustername: "cluster", Submissionid: "job"}, logInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Verb:        "get",
+		Resource:    "pods",
+		Subresource: "log",
+		Namespace:   "team-a",
+		Name:        "cluster",
+	}, authorizer.attributes)
+
+	// Read only calls only need an authenticated caller.
+	listInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.RayServeService/ListRayServices"}
+	_, err = authInterceptor.Unary(withToken("valid"), &api.ListRayServicesRequest{Namespace: "team-b"}, listInfo, handler)
+	require.NoError(t, err)
+}
+
+// adminReadOnlyMethods are the read only RPCs which only the cluster-admin role allows, so they are not in
+// viewerMethods.
+var adminReadOnlyMethods = []string{
+	"/proto.FleetService/ListResourceHistory",
+}
+
+// TestMethodAuthorizationsCoverage checks that every RPC is either listed as read only or needs an RBAC permission, so
+// that every new RPC has to be classified.
+func TestMethodAuthorizationsCoverage(t *testing.T) {
+	readOnly := methodSet(viewerMethods, adminReadOnlyMethods)
+	protoregistry.GlobalFiles.RangeFilesByPackage("proto", func(file protoreflect.FileDescriptor) bool {
+		for i := 0; i < file.Services().Len(); i++ {
+			service := file.Services().Get(i)
+			for j := 0; j < service.Methods().Len(); j++ {
+				method := service.Methods().Get(j)
+				fullMethod := fmt.Sprintf("/%s/%s", service.FullName(), method.Name())
+				if isPublicMethod(fullMethod) || readOnly[fullMethod] {
+					continue
+				}
+				_, hasMethodAuthorization := methodAuthorizations[fullMethod]
+				_, hasRequestAuthorization := requestAuthorizations[fullMethod]
+				assert.True(t, hasMethodAuthorization || hasRequestAuthorization, "%s needs an RBAC permission", fullMethod)
+			}
+		}
+		return true
+	})
+}
+
+// recordingAuthorizer records the actions it authorizes and denies the ones on the denied resource.
+type recordingAuthorizer struct {
+	actions []string
+	denied  string
+}
+
+func (a *recordingAuthorizer) Authorize(_ context.Context, _ *authenticationv1.UserInfo, attributes *authorizationv1.ResourceAttributes) (bool, string, error) {
+	a.actions = append(a.actions, attributes.Verb+" "+attributes.Resource)
+	return attributes.Resource != a.denied, "", nil
+}
+
+func TestAuthInterceptorBackup(t *testing.T) {
+	authorizer := &recordingAuthorizer{}
+	authInterceptor := NewAuthInterceptor(fakeAuthenticator{}, authorizer, nil)
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer valid"))
+	exportInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.BackupService/ExportBackup"}
+	importInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.BackupService/ImportBackup"}
+
+	_, err := authInterceptor.Unary(ctx, &api.ExportBackupRequest{Namespace: "team-a"}, exportInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"list rayclusters", "list rayservices", "list rayjobs", "get configmaps"}, authorizer.actions)
+
+	// Exporting the Secrets needs the permission to read them.
+	authorizer.actions, authorizer.denied = nil, "secrets"
+	_, err = authInterceptor.Unary(ctx, &api.ExportBackupRequest{Namespace: "team-a", IncludeSecrets: true}, exportInfo, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Contains(t, authorizer.actions, "get secrets")
+
+	// Importing a bundle needs the permission to create every kind of resource it restores.
+	authorizer.actions, authorizer.denied = nil, ""
+	bundle := &api.BackupBundle{Resources: []*api.BackupResource{
+		{Kind: "ConfigMap", Name: "template"},
+		{Kind: "ConfigMap", Name: "config"},
+		{Kind: "RayCluster", Name: "cluster"},
+	}}
+	_, err = authInterceptor.Unary(ctx, &api.ImportBackupRequest{Namespace: "team-a", Bundle: bundle}, importInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create configmaps", "create rayclusters"}, authorizer.actions)
+
+	authorizer.denied = "rayclusters"
+	_, err = authInterceptor.Unary(ctx, &api.ImportBackupRequest{Namespace: "team-a", Bundle: bundle}, importInfo, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+// fakeServerStream receives a single request.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	request *api.WatchRayServiceRequest
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	*m.(*api.WatchRayServiceRequest) = api.WatchRayServiceRequest{Name: s.request.Name, Namespace: s.request.Namespace}
+	return nil
+}
+
+func TestAuthInterceptorStream(t *testing.T) {
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	config.Set(&config.Config{RoleBindings: []config.RoleBinding{
+		{Role: config.RoleViewer, Users: []string{"alice"}, Namespaces: []string{"team-a"}},
+	}})
+	authInterceptor := NewAuthInterceptor(fakeAuthenticator{}, &fakeAuthorizer{}, nil)
+	handler := func(_ interface{}, stream grpc.ServerStream) error {
+		user, ok := UserFromContext(stream.Context())
+		require.True(t, ok)
+		assert.Equal(t, "alice", user.Username)
+		return stream.RecvMsg(&api.WatchRayServiceRequest{})
+	}
+	info := &grpc.StreamServerInfo{FullMethod: "/proto.RayServeService/WatchRayService", IsServerStream: true}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer valid"))
+
+	err := authInterceptor.Stream(nil, &fakeServerStream{ctx: context.Background(), request: &api.WatchRayServiceRequest{Namespace: "team-a"}}, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	err = authInterceptor.Stream(nil, &fakeServerStream{ctx: ctx, request: &api.WatchRayServiceRequest{Name: "service", Namespace: "team-a"}}, info, handler)
+	require.NoError(t, err)
+
+	// The stream is authorized against the namespace of its request.
+	err = authInterceptor.Stream(nil, &fakeServerStream{ctx: ctx, request: &api.WatchRayServiceRequest{Name: "service", Namespace: "team-b"}}, info, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthorizeResource(t *testing.T) {
+	authorizer := &fakeAuthorizer{}
+	authInterceptor := NewAuthInterceptor(fakeAuthenticator{}, authorizer, nil)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer valid"))
+	const connectMethod = "/proto.RaySessionService/ConnectRaySession"
+
+	_, err := authInterceptor.AuthorizeResource(context.Background(), connectMethod, "team-a", "session")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	authorizedCtx, err := authInterceptor.AuthorizeResource(ctx, connectMethod, "team-a", "session")
+	require.NoError(t, err)
+	user, ok := UserFromContext(authorizedCtx)
+	require.True(t, ok)
+	assert.Equal(t, "alice", user.Username)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Verb:        "create",
+		Group:       "ray.io",
+		Resource:    "rayclusters",
+		Subresource: "proxy",
+		Namespace:   "team-a",
+		Name:        "session",
+	}, authorizer.attributes)
+
+	_, err = authInterceptor.AuthorizeResource(ctx, connectMethod, "team-b", "session")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestTokenReviewAuthenticator(t *testing.T) {
+	client := k8sfake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "valid" {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"developers"}}
+		}
+		return true, review, nil
+	})
+	authenticator := NewTokenReviewAuthenticator(client.AuthenticationV1().TokenReviews())
+
+	user, err := authenticator.Authenticate(context.Background(), "valid")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Username)
+	assert.Equal(t, []string{"developers"}, user.Groups)
+
+	_, err = authenticator.Authenticate(context.Background(), "invalid")
+	require.Error(t, err)
+}
+
+func TestAuthInterceptorRoles(t *testing.T) {
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	config.Set(&config.Config{RoleBindings: []config.RoleBinding{
+		{Role: config.RoleViewer, Groups: []string{"developers"}},
+		{Role: config.RoleJobSubmitter, Users: []string{"alice"}, Namespaces: []string{"team-a"}},
+	}})
+	authorizer := &fakeAuthorizer{}
+	authInterceptor := NewAuthInterceptor(fakeAuthenticator{}, authorizer, nil)
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer valid"))
+
+	_, err := authInterceptor.Unary(ctx, &api.CreateRayJobRequest{Namespace: "team-a"}, &grpc.UnaryServerInfo{FullMethod: "/proto.RayJobService/CreateRayJob"}, handler)
+	require.NoError(t, err)
+	assert.Nil(t, authorizer.attributes, "Role bindings replace the SubjectAccessReview")
+
+	_, err = authInterceptor.Unary(ctx, &api.CreateRayJobRequest{Namespace: "team-b"}, &grpc.UnaryServerInfo{FullMethod: "/proto.RayJobService/CreateRayJob"}, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = authInterceptor.Unary(ctx, &api.CreateClusterRequest{Namespace: "team-a"}, &grpc.UnaryServerInfo{FullMethod: "/proto.ClusterService/CreateCluster"}, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The job-submitter role of alice is bound to team-a only, so it does not cover all namespaces.
+	_, err = authInterceptor.Unary(ctx, &api.ListAllRayJobsRequest{}, &grpc.UnaryServerInfo{FullMethod: "/proto.RayJobService/ListAllRayJobs"}, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestRoleAllows(t *testing.T) {
+	assert.True(t, roleAllows([]string{config.RoleViewer}, "/proto.RayServeService/GetRayService"))
+	assert.False(t, roleAllows([]string{config.RoleViewer}, "/proto.RayServeService/DeleteRayService"))
+	assert.True(t, roleAllows([]string{config.RoleViewer, config.RoleJobSubmitter}, "/proto.RayJobSubmissionService/SubmitRayJob"))
+	assert.False(t, roleAllows([]string{config.RoleJobSubmitter}, "/proto.ClusterService/DeleteCluster"))
+	assert.True(t, roleAllows([]string{config.RoleClusterAdmin}, "/proto.BackupService/ImportBackup"))
+	assert.True(t, roleAllows([]string{config.RoleViewer}, "/proto.FleetService/GetFleetSummary"))
+	assert.False(t, roleAllows(nil, "/proto.ClusterService/GetCluster"))
+}
@@ -0,0 +1,117 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+// The sources of the user of a client certificate.
+const (
+	// ClientCertificateSubject maps the common name of the subject to the user, like the client certificates
+	// of Kubernetes.
+	ClientCertificateSubject = "subject"
+	// ClientCertificateSAN maps the first URI SAN, e.g. a SPIFFE ID, to the user, or the first DNS SAN or email
+	// address if the certificate has no URI SAN.
+	ClientCertificateSAN = "san"
+)
+
+// clientCertificateMetadataKey is the metadata in which the HTTP proxy forwards the client certificate of an HTTP
+// request to the gRPC listener, as base64 encoded DER.
+const clientCertificateMetadataKey = "x-kuberay-client-certificate"
+
+// ClientCertificateAuthenticator authenticates the callers presenting a client certificate of mutual TLS, so that
+// machine callers are authorized and audited without a bearer token. The organizations of the subject are the
+// groups of the user in both sources.
+type ClientCertificateAuthenticator struct {
+	source string
+	// isServerCertificate returns whether a client certificate is the certificate of the API server, which the
+	// HTTP proxy presents.
+	isServerCertificate func(raw []byte) bool
+}
+
+func NewClientCertificateAuthenticator(source string, isServerCertificate func(raw []byte) bool) (*ClientCertificateAuthenticator, error) {
+	if source != ClientCertificateSubject && source != ClientCertificateSAN {
+		return nil, fmt.Errorf("unknown client certificate identity %q, expected %s or %s", source, ClientCertificateSubject, ClientCertificateSAN)
+	}
+	return &ClientCertificateAuthenticator{source: source, isServerCertificate: isServerCertificate}, nil
+}
+
+// Authenticate returns the user of the client certificate of the call. The second return value is false when the
+// caller has no client certificate, which is the case of the HTTP requests without one.
+func (a *ClientCertificateAuthenticator) Authenticate(ctx context.Context) (*authenticationv1.UserInfo, bool, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false, nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil, false, nil
+	}
+	certificate := tlsInfo.State.PeerCertificates[0]
+	if a.isServerCertificate(certificate.Raw) {
+		// The HTTP proxy forwards the certificate of the HTTP request, which the HTTP listener verified.
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(clientCertificateMetadataKey)
+		if len(values) == 0 {
+			return nil, false, nil
+		}
+		if len(values) > 1 {
+			return nil, false, fmt.Errorf("the request has %d forwarded client certificates", len(values))
+		}
+		raw, err := base64.StdEncoding.DecodeString(values[0])
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid forwarded client certificate: %w", err)
+		}
+		if certificate, err = x509.ParseCertificate(raw); err != nil {
+			return nil, false, fmt.Errorf("invalid forwarded client certificate: %w", err)
+		}
+	}
+	user, err := ClientCertificateUser(certificate, a.source)
+	if err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
+
+// ClientCertificateUser maps a client certificate to a user with the source of the user name.
+func ClientCertificateUser(certificate *x509.Certificate, source string) (*authenticationv1.UserInfo, error) {
+	var username string
+	switch source {
+	case ClientCertificateSubject:
+		username = certificate.Subject.CommonName
+	case ClientCertificateSAN:
+		switch {
+		case len(certificate.URIs) > 0:
+			username = certificate.URIs[0].String()
+		case len(certificate.DNSNames) > 0:
+			username = certificate.DNSNames[0]
+		case len(certificate.EmailAddresses) > 0:
+			username = certificate.EmailAddresses[0]
+		}
+	}
+	if username == "" {
+		return nil, fmt.Errorf("the client certificate of %s has no %s to identify its user", certificate.Subject, source)
+	}
+	return &authenticationv1.UserInfo{Username: username, Groups: certificate.Subject.Organization}, nil
+}
+
+// ForwardClientCertificate forwards the client certificate of the HTTP requests to the gRPC listener. The header of
+// the metadata is always overwritten, so that an HTTP caller can not send a certificate it does not hold.
+func ForwardClientCertificate(handler http.Handler) http.Handler {
+	header := "Grpc-Metadata-" + clientCertificateMetadataKey
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del(header)
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			r.Header.Set(header, base64.StdEncoding.EncodeToString(r.TLS.PeerCertificates[0].Raw))
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
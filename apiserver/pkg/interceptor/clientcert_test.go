@@ -0,0 +1,181 @@
+package interceptor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// newClientCertificate creates a self-signed certificate, the authenticator does not verify the certificates.
+func newClientCertificate(t *testing.T, template *x509.Certificate) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(1)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certificate
+}
+
+func withPeerCertificate(ctx context.Context, certificate *x509.Certificate) context.Context {
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{certificate}},
+	}})
+}
+
+func TestClientCertificateUser(t *testing.T) {
+	spiffeID, err := url.Parse("spiffe://cluster.local/ns/ml/sa/pipeline")
+	require.NoError(t, err)
+	tests := []struct {
+		name     string
+		template *x509.Certificate
+		source   string
+		expected *authenticationv1.UserInfo
+		err      string
+	}{
+		{
+			name:     "The subject maps to the user and the groups",
+			template: &x509.Certificate{Subject: pkix.Name{CommonName: "pipeline", Organization: []string{"ml"}}},
+			source:   ClientCertificateSubject,
+			expected: &authenticationv1.UserInfo{Username: "pipeline", Groups: []string{"ml"}},
+		},
+		{
+			name:     "The URI SAN comes before the DNS SAN",
+			template: &x509.Certificate{Subject: pkix.Name{CommonName: "pipeline"}, URIs: []*url.URL{spiffeID}, DNSNames: []string{"pipeline.ml"}},
+			source:   ClientCertificateSAN,
+			expected: &authenticationv1.UserInfo{Username: "spiffe://cluster.local/ns/ml/sa/pipeline"},
+		},
+		{
+			name:     "The DNS SAN",
+			template: &x509.Certificate{DNSNames: []string{"pipeline.ml"}, EmailAddresses: []string{"pipeline@example.com"}},
+			source:   ClientCertificateSAN,
+			expected: &authenticationv1.UserInfo{Username: "pipeline.ml"},
+		},
+		{
+			name:     "A certificate without SAN",
+			template: &x509.Certificate{Subject: pkix.Name{CommonName: "pipeline"}},
+			source:   ClientCertificateSAN,
+			err:      "the client certificate of CN=pipeline has no san to identify its user",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc // capture range variable
+		t.Run(tc.name, func(t *testing.T) {
+			user, err := ClientCertificateUser(newClientCertificate(t, tc.template), tc.source)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, user)
+		})
+	}
+}
+
+func TestClientCertificateAuthenticator(t *testing.T) {
+	_, err := NewClientCertificateAuthenticator("issuer", nil)
+	require.Error(t, err)
+
+	server := newClientCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "kuberay-apiserver"}})
+	client := newClientCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "pipeline"}})
+	authenticator, err := NewClientCertificateAuthenticator(ClientCertificateSubject, func(raw []byte) bool {
+		return bytes.Equal(raw, server.Raw)
+	})
+	require.NoError(t, err)
+
+	_, ok, err := authenticator.Authenticate(context.Background())
+	require.NoError(t, err)
+	assert.False(t, ok, "A call without TLS has no client certificate")
+
+	user, ok, err := authenticator.Authenticate(withPeerCertificate(context.Background(), client))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "pipeline", user.Username)
+
+	// The HTTP proxy forwards the client certificate of the HTTP request.
+	_, ok, err = authenticator.Authenticate(withPeerCertificate(context.Background(), server))
+	require.NoError(t, err)
+	assert.False(t, ok, "The HTTP request has no client certificate")
+	forwarded := metadata.NewIncomingContext(context.Background(), metadata.Pairs(clientCertificateMetadataKey, base64.StdEncoding.EncodeToString(client.Raw)))
+	user, ok, err = authenticator.Authenticate(withPeerCertificate(forwarded, server))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "pipeline", user.Username)
+
+	// The forwarded certificate is only trusted from the HTTP proxy.
+	user, ok, err = authenticator.Authenticate(withPeerCertificate(forwarded, newClientCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "other"}})))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "other", user.Username)
+}
+
+func TestForwardClientCertificate(t *testing.T) {
+	client := newClientCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "pipeline"}})
+	var forwarded []string
+	handler := ForwardClientCertificate(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Values("Grpc-Metadata-" + clientCertificateMetadataKey)
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/apis/v1/clusters", nil)
+	request.Header.Set("Grpc-Metadata-X-Kuberay-Client-Certificate", "spoofed")
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	assert.Empty(t, forwarded)
+
+	request.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{client}}
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	assert.Equal(t, []string{base64.StdEncoding.EncodeToString(client.Raw)}, forwarded)
+}
+
+func TestAuthInterceptorWithClientCertificates(t *testing.T) {
+	client := newClientCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "pipeline", Organization: []string{"ml"}}})
+	clientCertificates, err := NewClientCertificateAuthenticator(ClientCertificateSubject, func([]byte) bool { return false })
+	require.NoError(t, err)
+	authorizer := &fakeAuthorizer{}
+	authInterceptor := NewAuthInterceptor(fakeAuthenticator{}, authorizer, clientCertificates)
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		user, ok := UserFromContext(ctx)
+		require.True(t, ok)
+		return user.Username, nil
+	}
+	deleteInfo := &grpc.UnaryServerInfo{FullMethod: "/proto.RayServeService/DeleteRayService"}
+
+	resp, err := authInterceptor.Unary(withPeerCertificate(context.Background(), client), &api.DeleteRayServiceRequest{Name: "service", Namespace: "team-a"}, deleteInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "pipeline", resp)
+
+	// The bearer token comes first.
+	withToken := metadata.NewIncomingContext(withPeerCertificate(context.Background(), client), metadata.Pairs("authorization", "Bearer valid"))
+	resp, err = authInterceptor.Unary(withToken, &api.DeleteRayServiceRequest{Name: "service", Namespace: "team-a"}, deleteInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", resp)
+
+	_, err = authInterceptor.Unary(context.Background(), &api.DeleteRayServiceRequest{Name: "service", Namespace: "team-a"}, deleteInfo, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,117 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/metrics"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// clientLimiterIdleTimeout is how long the token bucket of a client is kept after its last call.
+const clientLimiterIdleTimeout = 10 * time.Minute
+
+// ClientRateLimiter keeps a token bucket for every client of the API server, so that a bursty client does not take
+// the capacity of the others. Clients are identified by their authenticated user name when authentication is
+// enabled, and by their address otherwise.
+type ClientRateLimiter struct {
+	limit rate.Limit
+	burst int
+	now   func() time.Time
+
+	mu        sync.Mutex
+	limiters  map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewClientRateLimiter creates a ClientRateLimiter allowing qps calls per second to every client, with bursts of
+// burst calls.
+func NewClientRateLimiter(qps float64, burst int) *ClientRateLimiter {
+	return &ClientRateLimiter{
+		limit:    rate.Limit(qps),
+		burst:    burst,
+		now:      time.Now,
+		limiters: map[string]*clientLimiter{},
+	}
+}
+
+// allow returns false if the call of the client exceeds its rate limit.
+func (l *ClientRateLimiter) allow(client string) bool {
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Forget the clients which stopped calling, so that the buckets do not pile up.
+	if now.Sub(l.lastSweep) > clientLimiterIdleTimeout {
+		for name, limiter := range l.limiters {
+			if now.Sub(limiter.lastSeen) > clientLimiterIdleTimeout {
+				delete(l.limiters, name)
+			}
+		}
+		l.lastSweep = now
+	}
+	limiter, ok := l.limiters[client]
+	if !ok {
+		limiter = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[client] = limiter
+	}
+	limiter.lastSeen = now
+	return limiter.limiter.AllowN(now, 1)
+}
+
+// Unary rejects the unary calls of the clients exceeding their rate limit.
+func (l *ClientRateLimiter) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if client := clientIdentity(ctx); !l.allow(client) {
+		metrics.RecordRejectedRequest(metrics.RejectedByClientRateLimit, info.FullMethod)
+		return nil, util.NewRateLimitedError("%v is rejected by the rate limit of client %s, please retry later", info.FullMethod, client)
+	}
+	return handler(ctx, req)
+}
+
+// Stream rejects the new streams of the clients exceeding their rate limit.
+func (l *ClientRateLimiter) Stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if client := clientIdentity(ss.Context()); !l.allow(client) {
+		metrics.RecordRejectedRequest(metrics.RejectedByClientRateLimit, info.FullMethod)
+		return util.NewRateLimitedError("%v is rejected by the rate limit of client %s, please retry later", info.FullMethod, client)
+	}
+	return handler(srv, ss)
+}
+
+// clientIdentity returns the authenticated user of a call, or the address of the client. The calls of the HTTP
+// proxy come from the loopback address, so their client is the last forwarded address, which the proxy appends. The
+// previous ones come from the X-Forwarded-For header of the request, which the client can set to any value.
+func clientIdentity(ctx context.Context) string {
+	if user, ok := UserFromContext(ctx); ok {
+		return "user " + user.Username
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if forwarded := metadata.ValueFromIncomingContext(ctx, "x-forwarded-for"); len(forwarded) > 0 {
+			addresses := strings.Split(forwarded[len(forwarded)-1], ",")
+			if client := strings.TrimSpace(addresses[len(addresses)-1]); client != "" {
+				host = client
+			}
+		}
+	}
+	return "address " + host
+}
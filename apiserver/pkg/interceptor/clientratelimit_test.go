@@ -0,0 +1,91 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+func peerContext(address string) context.Context {
+	addr, _ := net.ResolveTCPAddr("tcp", address)
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+}
+
+func TestClientIdentity(t *testing.T) {
+	ctx := context.WithValue(peerContext("10.0.0.1:5000"), userKey{}, &authenticationv1.UserInfo{Username: "alice"})
+	assert.Equal(t, "user alice", clientIdentity(ctx))
+	assert.Equal(t, "address 10.0.0.1", clientIdentity(peerContext("10.0.0.1:5000")))
+	assert.Equal(t, "unknown", clientIdentity(context.Background()))
+
+	// The HTTP proxy appends the address of the client to the forwarded addresses, the others can be forged.
+	proxied := metadata.NewIncomingContext(peerContext("127.0.0.1:5000"), metadata.Pairs("x-forwarded-for", "1.2.3.4, 10.0.0.2"))
+	assert.Equal(t, "address 10.0.0.2", clientIdentity(proxied))
+	// Only the HTTP proxy can forward addresses.
+	forged := metadata.NewIncomingContext(peerContext("10.0.0.1:5000"), metadata.Pairs("x-forwarded-for", "10.0.0.2"))
+	assert.Equal(t, "address 10.0.0.1", clientIdentity(forged))
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewClientRateLimiter(1, 2)
+	limiter.now = func() time.Time { return now }
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(address string) error {
+		_, err := limiter.Unary(peerContext(address), nil, &grpc.UnaryServerInfo{FullMethod: "/proto.ClusterService/ListCluster"}, handler)
+		return err
+	}
+
+	assert.NoError(t, call("10.0.0.1:5000"))
+	assert.NoError(t, call("10.0.0.1:5001"))
+	err := call("10.0.0.1:5002")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, err.Error(), "address 10.0.0.1")
+	// The other clients have their own bucket.
+	assert.NoError(t, call("10.0.0.2:5000"))
+
+	now = now.Add(time.Second)
+	assert.NoError(t, call("10.0.0.1:5000"))
+
+	// The buckets of idle clients are dropped.
+	now = now.Add(2 * clientLimiterIdleTimeout)
+	assert.NoError(t, call("10.0.0.3:5000"))
+	assert.Len(t, limiter.limiters, 1)
+}
+
+func TestLimitRequestBody(t *testing.T) {
+	handler := LimitRequestBody(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Body.Read(make([]byte, 32)); err != nil && err.Error() != "EOF" {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}), 16)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/apis/v1/namespaces/default/clusters", strings.NewReader(`{"name":"a"}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/apis/v1/namespaces/default/clusters", strings.NewReader(`{"name":"a-long-cluster-name"}`)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "exceeds the limit of 16 bytes")
+
+	// A body without a content length is cut at the limit.
+	request := httptest.NewRequest(http.MethodPost, "/apis/v1/namespaces/default/clusters", strings.NewReader(`{"name":"a-long-cluster-name"}`))
+	request.ContentLength = -1
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
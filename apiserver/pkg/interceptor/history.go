@@ -0,0 +1,109 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	"github.com/ray-project/kuberay/apiserver/pkg/encryption"
+)
+
+// historyKinds are the kinds of the resources whose changes are recorded, by the gRPC service changing them.
+var historyKinds = map[string]string{
+	"proto.ClusterService":  "RayCluster",
+	"proto.RayJobService":   "RayJob",
+	"proto.RayServeService": "RayService",
+}
+
+// historyMethodPrefixes are the prefixes of the names of the RPCs whose changes are recorded. The upgrades of the
+// RayServices are recorded on top of the mutating RPCs.
+var historyMethodPrefixes = append(append([]string{}, mutatingMethodPrefixes...), "Start", "Promote", "Rollback")
+
+// HistoryInterceptor records the successful changes of the clusters, jobs and services in a datastore, so that their
+// history can be listed after the custom resources are deleted. The sensitive fields of the recorded requests are
+// sealed with the key of their namespace when envelope is set, and redacted otherwise.
+type HistoryInterceptor struct {
+	store    datastore.Store
+	envelope *encryption.Envelope
+}
+
+func NewHistoryInterceptor(store datastore.Store, envelope *encryption.Envelope) *HistoryInterceptor {
+	return &HistoryInterceptor{store: store, envelope: envelope}
+}
+
+// Unary records a change once a unary call has changed a resource. Failing to record it is logged, since the change
+// is already made.
+func (h *HistoryInterceptor) Unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	kind, action := historyMethod(info.FullMethod)
+	if kind == "" {
+		return handler(ctx, req)
+	}
+	resp, err := handler(ctx, req)
+	message, ok := req.(proto.Message)
+	if err != nil || !ok || isDryRun(message) {
+		return resp, err
+	}
+
+	record := &datastore.Record{
+		Time:           time.Now().UTC(),
+		Kind:           kind,
+		Action:         action,
+		RequestID:      RequestIDFromContext(ctx),
+		TargetCluster:  stringField(message.ProtoReflect(), targetClusterField),
+		IdempotencyKey: stringField(message.ProtoReflect(), "idempotency_key"),
+	}
+	record.Namespace, record.Name = requestTarget(message)
+	if user, ok := UserFromContext(ctx); ok {
+		record.User = user.Username
+	}
+	if payload, err := h.payload(ctx, record.Namespace, message); err == nil {
+		record.Payload = payload
+	} else {
+		klog.Errorf("Failed to marshal the request of %s to record it: %v", info.FullMethod, err)
+	}
+	if err := h.store.Add(ctx, record); err != nil {
+		klog.Errorf("Failed to record the change of %s %s/%s: %v", kind, record.Namespace, record.Name, err)
+	}
+	return resp, nil
+}
+
+// payload returns the request as JSON, with its sensitive fields sealed for the namespace or redacted.
+func (h *HistoryInterceptor) payload(ctx context.Context, namespace string, message proto.Message) (string, error) {
+	message = proto.Clone(message)
+	if h.envelope == nil {
+		encryption.RedactSensitiveFields(message)
+	} else if err := h.envelope.SealSensitiveFields(ctx, namespace, message); err != nil {
+		return "", err
+	}
+	payload, err := protojson.Marshal(message)
+	return string(payload), err
+}
+
+// historyMethod returns the kind of the resource changed by an RPC and the name of the RPC, or an empty kind if the
+// RPC does not change a recorded resource.
+func historyMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	kind := historyKinds[service]
+	if !ok || kind == "" {
+		return "", ""
+	}
+	for _, prefix := range historyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return kind, method
+		}
+	}
+	return "", ""
+}
+
+// isDryRun returns whether a request asks for a dry run, which changes nothing.
+func isDryRun(message proto.Message) bool {
+	field := message.ProtoReflect().Descriptor().Fields().ByName("dry_run")
+	return field != nil && field.Kind() == protoreflect.BoolKind && !field.IsList() && message.ProtoReflect().Get(field).Bool()
+}
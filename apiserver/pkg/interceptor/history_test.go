@@ -0,0 +1,87 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	"github.com/ray-project/kuberay/apiserver/pkg/encryption"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestHistoryInterceptor(t *testing.T) {
+	store := datastore.NewMemoryStore(10)
+	historyInterceptor := NewHistoryInterceptor(store, nil)
+	ctx := context.WithValue(context.Background(), userKey{}, &authenticationv1.UserInfo{Username: "alice"})
+	succeed := func(_ context.Context, _ interface{}) (interface{}, error) { return &api.RayService{}, nil }
+	fail := func(_ context.Context, _ interface{}) (interface{}, error) {
+		return nil, status.Error(codes.AlreadyExists, "service already exists")
+	}
+	call := func(method string, request interface{}, handler grpc.UnaryHandler) {
+		_, _ = historyInterceptor.Unary(ctx, request, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	call("/proto.RayServeService/CreateRayService", &api.CreateRayServiceRequest{Namespace: "team-a", Service: &api.RayService{Name: "service"}, IdempotencyKey: "key"}, succeed)
+	call("/proto.RayServeService/StartRayServiceUpgrade", &api.StartRayServiceUpgradeRequest{Namespace: "team-a", Name: "service"}, succeed)
+	// Failed calls, dry runs, read only calls and the other resources are not recorded.
+	call("/proto.RayServeService/CreateRayService", &api.CreateRayServiceRequest{Namespace: "team-a", Service: &api.RayService{Name: "other"}}, fail)
+	call("/proto.RayServeService/CreateRayService", &api.CreateRayServiceRequest{Namespace: "team-a", Service: &api.RayService{Name: "other"}, DryRun: true}, succeed)
+	call("/proto.RayServeService/GetRayService", &api.GetRayServiceRequest{Namespace: "team-a", Name: "service"}, succeed)
+	call("/proto.ComputeTemplateService/CreateComputeTemplate", &api.CreateComputeTemplateRequest{Namespace: "team-a"}, succeed)
+
+	records, err := store.List(context.Background(), datastore.Query{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "StartRayServiceUpgrade", records[0].Action)
+	assert.Equal(t, "CreateRayService", records[1].Action)
+	assert.Equal(t, "RayService", records[1].Kind)
+	assert.Equal(t, "team-a", records[1].Namespace)
+	assert.Equal(t, "service", records[1].Name)
+	assert.Equal(t, "alice", records[1].User)
+	assert.Equal(t, "key", records[1].IdempotencyKey)
+	assert.Contains(t, records[1].Payload, `"idempotencyKey":"key"`)
+}
+
+func TestHistoryInterceptorSensitiveFields(t *testing.T) {
+	ctx := context.Background()
+	request := &api.CreateRayJobRequest{Namespace: "team-a", Job: &api.RayJob{Name: "job", RuntimeEnv: "env_vars:\n  TOKEN: s3cr3t\n"}}
+	succeed := func(_ context.Context, _ interface{}) (interface{}, error) { return &api.RayJob{}, nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/proto.RayJobService/CreateRayJob"}
+
+	// The sensitive fields are redacted without an envelope.
+	store := datastore.NewMemoryStore(10)
+	_, err := NewHistoryInterceptor(store, nil).Unary(ctx, request, info, succeed)
+	require.NoError(t, err)
+	records, err := store.List(ctx, datastore.Query{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.NotContains(t, records[0].Payload, "s3cr3t")
+	assert.Contains(t, records[0].Payload, encryption.Redacted)
+	assert.Equal(t, "env_vars:\n  TOKEN: s3cr3t\n", request.Job.RuntimeEnv, "The request is not changed")
+
+	// They are sealed with the key of the namespace with an envelope.
+	provider, err := encryption.NewLocalKeyProvider([]byte(strings.Repeat("k", 32)))
+	require.NoError(t, err)
+	envelope := encryption.NewEnvelope(provider)
+	store = datastore.NewMemoryStore(10)
+	_, err = NewHistoryInterceptor(store, envelope).Unary(ctx, request, info, succeed)
+	require.NoError(t, err)
+	records, err = store.List(ctx, datastore.Query{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	recorded := &api.CreateRayJobRequest{}
+	require.NoError(t, protojson.Unmarshal([]byte(records[0].Payload), recorded))
+	require.True(t, encryption.IsSealed(recorded.Job.RuntimeEnv))
+	plaintext, err := envelope.Open(ctx, "team-a", recorded.Job.RuntimeEnv)
+	require.NoError(t, err)
+	assert.Equal(t, request.Job.RuntimeEnv, string(plaintext))
+}
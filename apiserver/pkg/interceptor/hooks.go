@@ -0,0 +1,37 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+)
+
+// ConversionHooksUnaryInterceptor applies the ToApi conversion hooks to the clusters, jobs and services of the
+// responses, with the context of the call, so that the hooks can depend on the caller.
+func ConversionHooksUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if message, ok := resp.(proto.Message); ok && err == nil {
+		model.ApplyToApiHooks(ctx, message)
+	}
+	return resp, err
+}
+
+// ConversionHooksStreamInterceptor applies the ToApi conversion hooks to the messages sent by the streams, e.g. the
+// services of WatchRayService.
+func ConversionHooksStreamInterceptor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &conversionHooksStream{ServerStream: ss})
+}
+
+type conversionHooksStream struct {
+	grpc.ServerStream
+}
+
+func (s *conversionHooksStream) SendMsg(m interface{}) error {
+	if message, ok := m.(proto.Message); ok {
+		model.ApplyToApiHooks(s.Context(), message)
+	}
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,33 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"k8s.io/client-go/transport"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+)
+
+// ImpersonationUnaryInterceptor makes the calls to Kubernetes of every authenticated call impersonate its caller, so
+// that Kubernetes authorizes them with the RBAC permissions of the caller, in its namespace, and records the caller in
+// its audit log. It must follow the authentication.
+func ImpersonationUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(impersonateCaller(ctx), req)
+}
+
+// ImpersonationStreamInterceptor makes the calls to Kubernetes of every authenticated stream impersonate its caller.
+func ImpersonationStreamInterceptor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: impersonateCaller(ss.Context())})
+}
+
+// impersonateCaller returns a copy of ctx impersonating the authenticated caller, or ctx itself for the public methods,
+// whose callers are not authenticated. Only the user and the groups are impersonated: the UID and the extra fields
+// would need more impersonate permissions, one per extra key, and the RBAC rules only match users and groups.
+func impersonateCaller(ctx context.Context) context.Context {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return client.WithImpersonation(ctx, transport.ImpersonationConfig{UserName: user.Username, Groups: user.Groups})
+}
@@ -0,0 +1,47 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestImpersonationInterceptor(t *testing.T) {
+	user := &authenticationv1.UserInfo{Username: "alice", UID: "42", Groups: []string{"team-a"}}
+	ctx := context.WithValue(context.Background(), userKey{}, user)
+	info := &grpc.UnaryServerInfo{FullMethod: "/proto.ClusterService/GetCluster"}
+
+	_, err := ImpersonationUnaryInterceptor(ctx, &api.GetClusterRequest{}, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		impersonated, ok := client.ImpersonationFromContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "alice", impersonated.UserName)
+		assert.Equal(t, []string{"team-a"}, impersonated.Groups)
+		// The UID and the extra fields need more impersonate permissions.
+		assert.Empty(t, impersonated.UID)
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	// The callers of the public methods are not authenticated, and so not impersonated.
+	_, err = ImpersonationUnaryInterceptor(context.Background(), &api.GetClusterRequest{}, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		_, ok := client.ImpersonationFromContext(ctx)
+		assert.False(t, ok)
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	err = ImpersonationStreamInterceptor(nil, &fakeServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/proto.ClusterService/WatchCluster"}, func(_ interface{}, stream grpc.ServerStream) error {
+		impersonated, ok := client.ImpersonationFromContext(stream.Context())
+		require.True(t, ok)
+		assert.Equal(t, "alice", impersonated.UserName)
+		return nil
+	})
+	require.NoError(t, err)
+}
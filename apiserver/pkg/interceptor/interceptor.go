@@ -4,18 +4,26 @@ import (
 	"context"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
 )
 
 // ApiServerInterceptor implements UnaryServerInterceptor that provides the common wrapping logic
-// to be executed before and after all API handler calls, e.g. Logging, error handling.
+// to be executed before and after all API handler calls, e.g. Logging, error handling. Errors are returned as gRPC
+// statuses with an ErrorInfo detail, so that clients can tell them apart without parsing their messages. The logs
+// are written with the logger of the request, see RequestLoggingUnaryInterceptor.
 // For more details, see https://github.com/grpc/grpc-go/blob/master/interceptor.go
 func ApiServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-	klog.Infof("%v handler starting", info.FullMethod)
+	logger := klog.FromContext(ctx)
+	logger.Info("Handler starting")
 	resp, err = handler(ctx, req)
 	if err != nil {
-		klog.Warning(err)
+		grpcErr := util.ToGRPCError(err)
+		logger.Error(err, "Handler failed", "code", status.Code(grpcErr).String())
+		err = grpcErr
 	}
-	klog.Infof("%v handler finished", info.FullMethod)
+	logger.Info("Handler finished")
 	return
 }
@@ -0,0 +1,264 @@
+package interceptor
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/metrics"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// RetryAfterMetadataKey is the header metadata telling the clients of a rejected mutation how many seconds to wait
+// before retrying it. The HTTP proxy returns it as the Retry-After header.
+const RetryAfterMetadataKey = "retry-after"
+
+// createMethods are the RPCs creating resources, which are limited by the creates in flight in their namespace.
+var createMethods = []string{
+	"/proto.BackupService/ImportBackup",
+	"/proto.ClusterService/CreateCluster",
+	"/proto.ClusterService/CloneRayCluster",
+	"/proto.ComputeTemplateService/CreateComputeTemplate",
+	"/proto.ImageTemplateService/CreateImageTemplate",
+	"/proto.RayCronJobService/CreateRayCronJob",
+	"/proto.RayJobService/CreateRayJob",
+	"/proto.RayJobService/BatchCreateRayJobs",
+	"/proto.RayJobSubmissionService/SubmitRayJob",
+	"/proto.RayServeService/CreateRayService",
+	"/proto.RayServeService/ImportRayService",
+	"/proto.ServiceTemplateService/CreateServiceTemplate",
+	"/proto.ServiceTemplateService/CreateRayServiceFromTemplate",
+	"/proto.NotificationService/CreateNotificationSubscription",
+	"/proto.NamespaceService/EnsureNamespace",
+	"/proto.RaySessionService/CreateRaySession",
+}
+
+// updateAndDeleteMethods are the other RPCs changing resources. They are only limited by the mutation rates.
+var updateAndDeleteMethods = []string{
+	"/proto.ClusterService/DeleteCluster",
+	"/proto.ClusterService/BatchDeleteRayClusters",
+	"/proto.ClusterService/UpdateCluster",
+	"/proto.ClusterService/UpdateWorkerGroupAutoscaling",
+	"/proto.ClusterService/UpdateWorkerGroup",
+	"/proto.ClusterService/RestartWorkerGroup",
+	"/proto.ClusterService/DrainWorkerGroup",
+	"/proto.ClusterService/InjectClusterFailure",
+	"/proto.ClusterService/HealClusterPartitions",
+	"/proto.ComputeTemplateService/UpdateComputeTemplate",
+	"/proto.ComputeTemplateService/DeleteComputeTemplate",
+	"/proto.ImageTemplateService/DeleteImageTemplate",
+	"/proto.RayCronJobService/DeleteRayCronJob",
+	"/proto.RayJobService/DeleteRayJob",
+	"/proto.RayJobSubmissionService/StopRayJob",
+	"/proto.RayJobSubmissionService/DeleteRayJob",
+	"/proto.RayJobSubmissionService/UploadJobWorkingDir",
+	"/proto.RayServeService/UpdateRayService",
+	"/proto.RayServeService/UpdateRayServiceConfigs",
+	"/proto.RayServeService/SuspendRayService",
+	"/proto.RayServeService/ResumeRayService",
+	"/proto.RayServeService/StartRayServiceUpgrade",
+	"/proto.RayServeService/PromoteRayServiceUpgrade",
+	"/proto.RayServeService/RollbackRayServiceUpgrade",
+	"/proto.RayServeService/DeleteRayService",
+	"/proto.ServiceTemplateService/DeleteServiceTemplate",
+	"/proto.NotificationService/DeleteNotificationSubscription",
+	"/proto.RaySessionService/DeleteRaySession",
+}
+
+var (
+	createMethodSet   = methodSet(createMethods)
+	mutatingMethodSet = methodSet(createMethods, updateAndDeleteMethods)
+)
+
+// mutationLimiter keeps the token buckets of the namespaces and of the users, and the creates in flight in the
+// namespaces, in sync with the mutation limits of the current API server config.
+type mutationLimiter struct {
+	now func() time.Time
+
+	mu         sync.Mutex
+	limits     config.MutationLimits
+	namespaces map[string]*mutationBucket
+	users      map[string]*mutationBucket
+	lastSweep  time.Time
+}
+
+// mutationBucket limits the mutations of a namespace or of a user. Only the namespaces have slots for the creates.
+type mutationBucket struct {
+	limiter  *rate.Limiter
+	creates  chan struct{}
+	lastSeen time.Time
+}
+
+var apiMutationLimiter = newMutationLimiter()
+
+func newMutationLimiter() *mutationLimiter {
+	return &mutationLimiter{
+		now:        time.Now,
+		namespaces: map[string]*mutationBucket{},
+		users:      map[string]*mutationBucket{},
+	}
+}
+
+// buckets returns the buckets of the namespace and of the user of a call.
+func (l *mutationLimiter) buckets(limits config.MutationLimits, namespace string, user string) (*mutationBucket, *mutationBucket) {
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limits != limits {
+		// The configuration was reloaded, start over with full buckets. The calls in flight release the slots of
+		// the previous buckets.
+		l.limits = limits
+		l.namespaces = map[string]*mutationBucket{}
+		l.users = map[string]*mutationBucket{}
+	}
+	// Forget the namespaces and users which stopped calling, so that the buckets do not pile up.
+	if now.Sub(l.lastSweep) > clientLimiterIdleTimeout {
+		for _, buckets := range []map[string]*mutationBucket{l.namespaces, l.users} {
+			for key, bucket := range buckets {
+				if now.Sub(bucket.lastSeen) > clientLimiterIdleTimeout && len(bucket.creates) == 0 {
+					delete(buckets, key)
+				}
+			}
+		}
+		l.lastSweep = now
+	}
+
+	namespaceBucket, ok := l.namespaces[namespace]
+	if !ok {
+		namespaceBucket = newMutationBucket(limits.MutationsPerMinutePerNamespace)
+		if limits.MaxInFlightCreatesPerNamespace > 0 {
+			namespaceBucket.creates = make(chan struct{}, limits.MaxInFlightCreatesPerNamespace)
+		}
+		l.namespaces[namespace] = namespaceBucket
+	}
+	userBucket, ok := l.users[user]
+	if !ok {
+		userBucket = newMutationBucket(limits.MutationsPerMinutePerUser)
+		l.users[user] = userBucket
+	}
+	namespaceBucket.lastSeen, userBucket.lastSeen = now, now
+	return namespaceBucket, userBucket
+}
+
+func newMutationBucket(perMinute int) *mutationBucket {
+	bucket := &mutationBucket{}
+	if perMinute > 0 {
+		bucket.limiter = rate.NewLimiter(rate.Limit(float64(perMinute)/60), perMinute)
+	}
+	return bucket
+}
+
+// unary queues the creates, updates and deletes exceeding the mutation limits for up to the configured queue time,
+// and rejects them afterwards with the delay after which they can be retried. The mutations are all unary calls.
+func (l *mutationLimiter) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	limits := config.Get().MutationLimits
+	if !mutatingMethodSet[info.FullMethod] || limits == (config.MutationLimits{}) {
+		return handler(ctx, req)
+	}
+	var namespace string
+	if r, ok := req.(interface{ GetNamespace() string }); ok {
+		namespace = r.GetNamespace()
+	}
+	user := clientIdentity(ctx)
+	namespaceBucket, userBucket := l.buckets(limits, namespace, user)
+	maxQueue := time.Duration(limits.MaxQueueSeconds) * time.Second
+
+	// Take a token of the namespace and of the user, waiting for the later of them.
+	now := l.now()
+	var reservations []*rate.Reservation
+	var wait time.Duration
+	for _, bucket := range []struct {
+		limiter *rate.Limiter
+		owner   string
+	}{{namespaceBucket.limiter, "namespace " + namespace}, {userBucket.limiter, user}} {
+		if bucket.limiter == nil {
+			continue
+		}
+		reservation := bucket.limiter.ReserveN(now, 1)
+		delay := reservation.DelayFrom(now)
+		if delay > maxQueue {
+			reservation.CancelAt(now)
+			for _, r := range reservations {
+				r.CancelAt(now)
+			}
+			return nil, rejectMutation(ctx, info.FullMethod, delay, "%v is rejected by the mutations per minute of %s", info.FullMethod, bucket.owner)
+		}
+		reservations = append(reservations, reservation)
+		wait = max(wait, delay)
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		return nil, err
+	}
+
+	if namespaceBucket.creates != nil && createMethodSet[info.FullMethod] {
+		acquired, err := acquireSlot(ctx, namespaceBucket.creates, maxQueue-wait)
+		if err != nil {
+			return nil, err
+		}
+		if !acquired {
+			return nil, rejectMutation(ctx, info.FullMethod, time.Second, "%v is rejected since %d creates are already in flight in namespace %s", info.FullMethod, limits.MaxInFlightCreatesPerNamespace, namespace)
+		}
+		defer func() { <-namespaceBucket.creates }()
+	}
+	return handler(ctx, req)
+}
+
+// MutationLimitUnaryInterceptor applies the mutation limits of the API server config to the unary calls.
+func MutationLimitUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return apiMutationLimiter.unary(ctx, req, info, handler)
+}
+
+// acquireSlot takes a slot of the creates in flight, waiting for up to timeout for one to be released.
+func acquireSlot(ctx context.Context, slots chan struct{}, timeout time.Duration) (bool, error) {
+	select {
+	case slots <- struct{}{}:
+		return true, nil
+	default:
+	}
+	if timeout <= 0 {
+		return false, nil
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case slots <- struct{}{}:
+		return true, nil
+	case <-timer.C:
+		return false, nil
+	case <-ctx.Done():
+		return false, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// rejectMutation returns the error of a rejected mutation, and sends the seconds after which it can be retried in
+// the header metadata. The header is sent explicitly since the metadata set on a call failing before it sends any
+// response only reaches the client as trailers.
+func rejectMutation(ctx context.Context, fullMethod string, retryAfter time.Duration, format string, a ...interface{}) error {
+	seconds := max(int(math.Ceil(retryAfter.Seconds())), 1)
+	_ = grpc.SendHeader(ctx, metadata.Pairs(RetryAfterMetadataKey, strconv.Itoa(seconds)))
+	metrics.RecordRejectedRequest(metrics.RejectedByMutationLimit, fullMethod)
+	return util.NewRateLimitedError(format+", please retry in %d seconds", append(a, seconds)...)
+}
@@ -0,0 +1,137 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// headerStream records the header metadata sent by the interceptors.
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return "" }
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+func (s *headerStream) SetTrailer(metadata.MD) error    { return nil }
+
+func userContext(username string) context.Context {
+	return context.WithValue(context.Background(), userKey{}, &authenticationv1.UserInfo{Username: username})
+}
+
+func TestMutationRateLimits(t *testing.T) {
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	config.Set(&config.Config{MutationLimits: config.MutationLimits{MutationsPerMinutePerNamespace: 3, MutationsPerMinutePerUser: 2}})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newMutationLimiter()
+	limiter.now = func() time.Time { return now }
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(ctx context.Context, method string, namespace string) error {
+		_, err := limiter.unary(ctx, &api.CreateClusterRequest{Namespace: namespace}, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	assert.NoError(t, call(userContext("alice"), "/proto.ClusterService/CreateCluster", "team-a"))
+	assert.NoError(t, call(userContext("alice"), "/proto.ClusterService/DeleteCluster", "team-b"))
+	stream := &headerStream{}
+	err := call(grpc.NewContextWithServerTransportStream(userContext("alice"), stream), "/proto.ClusterService/UpdateCluster", "team-a")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, err.Error(), "rejected by the mutations per minute of user alice, please retry in 30 seconds")
+	assert.Equal(t, []string{"30"}, stream.header.Get(RetryAfterMetadataKey))
+	// The reads are not limited.
+	assert.NoError(t, call(userContext("alice"), "/proto.ClusterService/GetCluster", "team-a"))
+
+	// The namespace is limited for all its users. The token of team-a taken by the rejected update was given back.
+	assert.NoError(t, call(userContext("bob"), "/proto.ClusterService/CreateCluster", "team-a"))
+	assert.NoError(t, call(userContext("carol"), "/proto.ClusterService/CreateCluster", "team-a"))
+	err = call(userContext("dave"), "/proto.ClusterService/CreateCluster", "team-a")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, err.Error(), "rejected by the mutations per minute of namespace team-a")
+	// The user rejected by the namespace keeps its tokens.
+	assert.NoError(t, call(userContext("dave"), "/proto.ClusterService/CreateCluster", "team-b"))
+	assert.NoError(t, call(userContext("dave"), "/proto.ClusterService/CreateCluster", "team-c"))
+
+	now = now.Add(30 * time.Second)
+	assert.NoError(t, call(userContext("alice"), "/proto.ClusterService/UpdateCluster", "team-a"))
+}
+
+func TestMutationInFlightCreates(t *testing.T) {
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	config.Set(&config.Config{MutationLimits: config.MutationLimits{MaxInFlightCreatesPerNamespace: 1}})
+	limiter := newMutationLimiter()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		close(started)
+		<-release
+		return "ok", nil
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(method string, namespace string, handler grpc.UnaryHandler) error {
+		_, err := limiter.unary(userContext("alice"), &api.CreateClusterRequest{Namespace: namespace}, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	done := make(chan error)
+	go func() { done <- call("/proto.ClusterService/CreateCluster", "team-a", blocking) }()
+	<-started
+	err := call("/proto.RayJobService/CreateRayJob", "team-a", handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, err.Error(), "1 creates are already in flight in namespace team-a")
+	// The updates and the other namespaces are not held up.
+	assert.NoError(t, call("/proto.ClusterService/UpdateCluster", "team-a", handler))
+	assert.NoError(t, call("/proto.ClusterService/CreateCluster", "team-b", handler))
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.NoError(t, call("/proto.RayJobService/CreateRayJob", "team-a", handler))
+}
+
+func TestMutationQueue(t *testing.T) {
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	config.Set(&config.Config{MutationLimits: config.MutationLimits{MaxInFlightCreatesPerNamespace: 1, MaxQueueSeconds: 30}})
+	limiter := newMutationLimiter()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		close(started)
+		<-release
+		return "ok", nil
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(ctx context.Context, handler grpc.UnaryHandler) error {
+		_, err := limiter.unary(ctx, &api.CreateClusterRequest{Namespace: "team-a"}, &grpc.UnaryServerInfo{FullMethod: "/proto.ClusterService/CreateCluster"}, handler)
+		return err
+	}
+
+	first := make(chan error)
+	go func() { first <- call(userContext("alice"), blocking) }()
+	<-started
+
+	// A queued create gives up with its caller.
+	ctx, cancel := context.WithTimeout(userContext("bob"), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(call(ctx, handler)))
+
+	// A queued create runs once the create in flight is done.
+	second := make(chan error)
+	go func() { second <- call(userContext("bob"), handler) }()
+	close(release)
+	require.NoError(t, <-first)
+	assert.NoError(t, <-second)
+}
@@ -0,0 +1,60 @@
+package interceptor
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/metrics"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// rateLimiter keeps a token bucket in sync with the rate limits of the current API server config.
+type rateLimiter struct {
+	mu      sync.Mutex
+	limits  config.RateLimits
+	limiter *rate.Limiter
+}
+
+var apiRateLimiter = &rateLimiter{}
+
+// allow returns false if the call exceeds the configured rate limits.
+func (r *rateLimiter) allow() bool {
+	limits := config.Get().RateLimits
+	if limits.QPS <= 0 {
+		return true
+	}
+
+	r.mu.Lock()
+	if r.limiter == nil || r.limits != limits {
+		// The configuration was reloaded, start over with a full bucket.
+		r.limits = limits
+		r.limiter = rate.NewLimiter(rate.Limit(limits.QPS), limits.Burst)
+	}
+	limiter := r.limiter
+	r.mu.Unlock()
+
+	return limiter.Allow()
+}
+
+// RateLimitUnaryInterceptor rejects unary calls exceeding the configured rate limits.
+func RateLimitUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !apiRateLimiter.allow() {
+		metrics.RecordRejectedRequest(metrics.RejectedByRateLimit, info.FullMethod)
+		return nil, util.NewRateLimitedError("%v is rejected by the API server rate limit, please retry later", info.FullMethod)
+	}
+	return handler(ctx, req)
+}
+
+// RateLimitStreamInterceptor rejects new streams exceeding the configured rate limits.
+// Streams that are already established are never interrupted.
+func RateLimitStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !apiRateLimiter.allow() {
+		metrics.RecordRejectedRequest(metrics.RejectedByRateLimit, info.FullMethod)
+		return util.NewRateLimitedError("%v is rejected by the API server rate limit, please retry later", info.FullMethod)
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,120 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// RequestIDHeader is the HTTP header with the ID of a request, which the callers can set and the responses have.
+	RequestIDHeader = "X-Request-Id"
+	// requestIDMetadataKey is the gRPC metadata key of the request ID, in the requests and the response headers.
+	requestIDMetadataKey = "x-request-id"
+	// maxRequestIDLength bounds the request IDs chosen by the callers, longer IDs are replaced.
+	maxRequestIDLength = 128
+)
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the ID of the request of a context.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestLoggingUnaryInterceptor tags the logger of the context of every unary call with the request ID, the
+// method, the namespace and name of the target resource and the ID of the sampled trace, and returns the request ID
+// in the response headers. The handlers log with klog.FromContext, so that the log lines of a call can be correlated with its response.
+func RequestLoggingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, id := withRequestLogger(ctx, info.FullMethod, req)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, id))
+	return handler(ctx, req)
+}
+
+// RequestLoggingStreamInterceptor tags the logger of the context of every stream with the request ID and the
+// method, and returns the request ID in the response headers.
+func RequestLoggingStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, id := withRequestLogger(ss.Context(), info.FullMethod, nil)
+	_ = ss.SetHeader(metadata.Pairs(requestIDMetadataKey, id))
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// contextServerStream replaces the context of a stream.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// withRequestLogger adds the request ID and the logger of a call to its context. The request ID is taken from the
+// metadata of the call if the caller, or the HTTP proxy, set a valid one.
+func withRequestLogger(ctx context.Context, fullMethod string, req interface{}) (context.Context, string) {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadataKey); len(values) > 0 && isValidRequestID(values[0]) {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+	keysAndValues := []interface{}{"requestID", id, "method", fullMethod}
+	if message, ok := req.(proto.Message); ok {
+		if namespace, name := requestTarget(message); namespace != "" || name != "" {
+			keysAndValues = append(keysAndValues, "namespace", namespace, "name", name)
+		}
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsSampled() {
+		keysAndValues = append(keysAndValues, "traceID", spanContext.TraceID().String())
+	}
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return klog.NewContext(ctx, klog.FromContext(ctx).WithValues(keysAndValues...)), id
+}
+
+// ForwardRequestID sets the request ID of the HTTP requests, generating one unless the caller set a valid one, and
+// returns it in the RequestIDHeader of the responses. The gRPC calls of the HTTP proxy get the same request ID.
+func ForwardRequestID(handler http.Handler) http.Handler {
+	header := "Grpc-Metadata-" + requestIDMetadataKey
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+		r.Header.Set(header, id)
+		w.Header().Set(RequestIDHeader, id)
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		klog.Errorf("Failed to generate a request ID: %v", err)
+	}
+	return hex.EncodeToString(id)
+}
+
+// isValidRequestID returns whether a request ID of a caller can be used, i.e. it is printable ASCII and not too long
+// to be logged.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
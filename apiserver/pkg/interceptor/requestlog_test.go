@@ -0,0 +1,124 @@
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	klog "k8s.io/klog/v2"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// fakeServerTransportStream records the headers set by the unary interceptors.
+type fakeServerTransportStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *fakeServerTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// fakeHeaderServerStream records the headers set by the stream interceptors.
+type fakeHeaderServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *fakeHeaderServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeHeaderServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestRequestLoggingUnaryInterceptor(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{})
+	transportStream := &fakeServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(klog.NewContext(context.Background(), logger), transportStream)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-request-id", "client-id-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/proto.ClusterService/GetCluster"}
+
+	_, err := RequestLoggingUnaryInterceptor(ctx, &api.GetClusterRequest{Name: "cluster", Namespace: "team-a"}, info,
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			assert.Equal(t, "client-id-1", RequestIDFromContext(ctx))
+			klog.FromContext(ctx).Info("Handled")
+			return nil, nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client-id-1"}, transportStream.header.Get("x-request-id"))
+	require.Len(t, lines, 1)
+	assert.Equal(t, `"level"=0 "msg"="Handled" "requestID"="client-id-1" "method"="/proto.ClusterService/GetCluster" "namespace"="team-a" "name"="cluster"`, lines[0])
+
+	// An invalid request ID of the caller is replaced.
+	transportStream = &fakeServerTransportStream{}
+	ctx = grpc.NewContextWithServerTransportStream(context.Background(), transportStream)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-request-id", "id with spaces"))
+	_, err = RequestLoggingUnaryInterceptor(ctx, &api.GetClusterRequest{}, info,
+		func(ctx context.Context, _ interface{}) (interface{}, error) {
+			assert.Len(t, RequestIDFromContext(ctx), 32)
+			return nil, nil
+		})
+	require.NoError(t, err)
+	assert.Len(t, transportStream.header.Get("x-request-id"), 1)
+}
+
+func TestRequestLoggingStreamInterceptor(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{})
+	// The calls of sampled traces are logged with the trace ID.
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(klog.NewContext(context.Background(), logger), spanContext)
+	stream := &fakeHeaderServerStream{ctx: ctx}
+	info := &grpc.StreamServerInfo{FullMethod: "/proto.RayServeService/WatchRayService", IsServerStream: true}
+	var id string
+	err := RequestLoggingStreamInterceptor(nil, stream, info, func(_ interface{}, stream grpc.ServerStream) error {
+		id = RequestIDFromContext(stream.Context())
+		klog.FromContext(stream.Context()).Info("Handled")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+	assert.Equal(t, []string{id}, stream.header.Get("x-request-id"))
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"traceID"="4bf92f3577b34da6a3ce929d0e0e4736"`)
+}
+
+func TestForwardRequestID(t *testing.T) {
+	var forwarded string
+	handler := ForwardRequestID(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get("Grpc-Metadata-X-Request-Id")
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/apis/v1/clusters", nil)
+	request.Header.Set(RequestIDHeader, "client-id-1")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, "client-id-1", forwarded)
+	assert.Equal(t, "client-id-1", recorder.Header().Get(RequestIDHeader))
+
+	request = httptest.NewRequest(http.MethodGet, "/apis/v1/clusters", nil)
+	request.Header.Set(RequestIDHeader, strings.Repeat("x", maxRequestIDLength+1))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Len(t, forwarded, 32)
+	assert.Equal(t, forwarded, recorder.Header().Get(RequestIDHeader))
+}
@@ -0,0 +1,51 @@
+package interceptor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/metrics"
+)
+
+// LimitRequestBody rejects the HTTP requests whose body is larger than maxBytes with 413 Request Entity Too Large,
+// before the HTTP proxy reads them. Bodies without a content length are cut at maxBytes, which fails the request.
+func LimitRequestBody(handler http.Handler, maxBytes int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxBytes {
+			metrics.RecordRejectedRequest(metrics.RejectedByRequestSize, "")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			message := fmt.Sprintf("The request body of %d bytes exceeds the limit of %d bytes", r.ContentLength, maxBytes)
+			if err := json.NewEncoder(w).Encode(map[string]interface{}{"code": codes.ResourceExhausted, "message": message}); err != nil {
+				klog.Errorf("Failed to write the response: %v", err)
+			}
+			return
+		}
+		if r.Body != nil {
+			r.Body = &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, maxBytes)}
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// limitedBody counts the request bodies without a content length which exceed the limit.
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesError *http.MaxBytesError
+	if !b.exceeded && errors.As(err, &maxBytesError) {
+		b.exceeded = true
+		metrics.RecordRejectedRequest(metrics.RejectedByRequestSize, "")
+	}
+	return n, err
+}
@@ -0,0 +1,109 @@
+package interceptor
+
+import (
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+)
+
+// viewerMethods are the read only RPCs.
+var viewerMethods = []string{
+	"/proto.ClusterService/GetCluster",
+	"/proto.ClusterService/ListCluster",
+	"/proto.ClusterService/ListAllClusters",
+	"/proto.ClusterService/GetClusterStatus",
+	"/proto.ClusterService/WatchClusterStatus",
+	"/proto.ClusterService/TestRayClusterConnectivity",
+	"/proto.ClusterService/GetRayClusterEndpoints",
+	"/proto.ClusterService/GetWorkerGroupRestart",
+	"/proto.ClusterService/GetWorkerGroupDrain",
+	"/proto.ClusterService/ExportRayCluster",
+	"/proto.ClusterService/CanSchedule",
+	"/proto.ClusterService/PreviewClusterUpdate",
+	"/proto.ComputeTemplateService/GetComputeTemplate",
+	"/proto.ComputeTemplateService/ListComputeTemplates",
+	"/proto.ComputeTemplateService/ListAllComputeTemplates",
+	"/proto.ComputeTemplateService/ListComputeTemplateRevisions",
+	"/proto.ImageTemplateService/GetImageTemplate",
+	"/proto.ImageTemplateService/ListImageTemplates",
+	"/proto.RayJobService/GetRayJob",
+	"/proto.RayJobService/ListRayJobs",
+	"/proto.RayJobService/ListAllRayJobs",
+	"/proto.RayJobService/StreamRayJobLogs",
+	"/proto.RayJobService/GetRayJobOutput",
+	"/proto.RayJobService/ListArchivedRayJobs",
+	"/proto.RayCronJobService/GetRayCronJob",
+	"/proto.RayCronJobService/ListRayCronJobs",
+	"/proto.RayCronJobService/ListRayCronJobRuns",
+	"/proto.RayCronJobService/GetRayCronJobRun",
+	"/proto.RayJobSubmissionService/GetJobDetails",
+	"/proto.RayJobSubmissionService/GetJobLog",
+	"/proto.RayJobSubmissionService/ListJobDetails",
+	"/proto.RayServeService/GetRayService",
+	"/proto.RayServeService/GetRayServiceEndpoints",
+	"/proto.RayServeService/HealthCheckRayService",
+	"/proto.RayServeService/GetRayServeConfig",
+	"/proto.RayServeService/ListRayServiceHistory",
+	"/proto.RayServeService/WatchRayService",
+	"/proto.RayServeService/ListRayServices",
+	"/proto.RayServeService/ListAllRayServices",
+	"/proto.RayServeService/StreamRayServiceLogs",
+	"/proto.RayServeService/GetRayServiceDeletionStatus",
+	"/proto.RayServeService/ExportRayService",
+	"/proto.RayServeService/PreviewRayServiceUpdate",
+	"/proto.ServiceTemplateService/GetServiceTemplate",
+	"/proto.ServiceTemplateService/ListServiceTemplates",
+	"/proto.ServiceTemplateService/ListAllServiceTemplates",
+	"/proto.NotificationService/GetNotificationSubscription",
+	"/proto.NotificationService/ListNotificationSubscriptions",
+	"/proto.NamespaceService/GetNamespace",
+	"/proto.RaySessionService/GetRaySession",
+	"/proto.RaySessionService/ListRaySessions",
+	"/proto.ApiServerInfoService/GetApiServerInfo",
+	"/proto.FleetService/GetFleetSummary",
+	"/proto.FleetService/GetResourceUsage",
+	"/proto.FleetService/ListNamespaceRayEvents",
+	"/proto.FleetService/ListExpiringResources",
+	"/proto.FleetService/SearchResources",
+}
+
+// jobSubmitterMethods are the RPCs running jobs, in addition to the read only ones.
+var jobSubmitterMethods = []string{
+	"/proto.RayJobService/CreateRayJob",
+	"/proto.RayJobService/BatchCreateRayJobs",
+	"/proto.RayJobService/DeleteRayJob",
+	"/proto.RayCronJobService/CreateRayCronJob",
+	"/proto.RayCronJobService/DeleteRayCronJob",
+	"/proto.RayJobSubmissionService/SubmitRayJob",
+	"/proto.RayJobSubmissionService/StopRayJob",
+	"/proto.RayJobSubmissionService/DeleteRayJob",
+	"/proto.RayJobSubmissionService/UploadJobWorkingDir",
+	"/proto.RaySessionService/CreateRaySession",
+	"/proto.RaySessionService/DeleteRaySession",
+	"/proto.RaySessionService/ConnectRaySession",
+}
+
+// roleMethods maps the built-in roles to the RPCs they allow. The cluster-admin role allows every
+// RPC and is not listed.
+var roleMethods = map[string]map[string]bool{
+	config.RoleViewer:       methodSet(viewerMethods),
+	config.RoleJobSubmitter: methodSet(viewerMethods, jobSubmitterMethods),
+}
+
+func methodSet(methodLists ...[]string) map[string]bool {
+	set := map[string]bool{}
+	for _, methods := range methodLists {
+		for _, method := range methods {
+			set[method] = true
+		}
+	}
+	return set
+}
+
+// roleAllows returns whether one of the roles allows the RPC.
+func roleAllows(roles []string, fullMethod string) bool {
+	for _, role := range roles {
+		if role == config.RoleClusterAdmin || roleMethods[role][fullMethod] {
+			return true
+		}
+	}
+	return false
+}
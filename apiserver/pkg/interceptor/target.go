@@ -0,0 +1,36 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+)
+
+// targetClusterField is the field of the requests naming the Kubernetes cluster they are sent to.
+const targetClusterField protoreflect.Name = "target_cluster"
+
+// TargetClusterUnaryInterceptor passes the target cluster of the requests with a target_cluster field to the stores in
+// the context, so that a manager.TargetRouter sends the calls to that Kubernetes cluster.
+func TargetClusterUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if target := targetCluster(req); target != "" {
+		ctx = manager.WithTargetCluster(ctx, target)
+	}
+	return handler(ctx, req)
+}
+
+func targetCluster(req interface{}) string {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	reflected := message.ProtoReflect()
+	field := reflected.Descriptor().Fields().ByName(targetClusterField)
+	if field == nil || field.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return reflected.Get(field).String()
+}
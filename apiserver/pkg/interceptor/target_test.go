@@ -0,0 +1,36 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestTargetClusterUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      interface{}
+		expected string
+	}{
+		{name: "request with a target", req: &api.GetClusterRequest{Name: "cluster", Namespace: "ns", TargetCluster: "us-east"}, expected: "us-east"},
+		{name: "request without a target", req: &api.GetClusterRequest{Name: "cluster", Namespace: "ns"}, expected: ""},
+		{name: "request without the field", req: &api.GetClusterStatusRequest{Name: "cluster", Namespace: "ns"}, expected: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var target string
+			_, err := TargetClusterUnaryInterceptor(context.Background(), tc.req, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+				target = manager.TargetClusterFromContext(ctx)
+				return nil, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, target)
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// Operations of the admission requests.
+const (
+	AdmissionCreate = "CREATE"
+	AdmissionUpdate = "UPDATE"
+)
+
+const defaultAdmissionWebhookTimeout = 10 * time.Second
+
+// AdmissionRequest is a cluster, job or service about to be submitted to Kubernetes by a create or update call.
+type AdmissionRequest struct {
+	// Operation is AdmissionCreate or AdmissionUpdate.
+	Operation string
+	// Kind is RayCluster, RayJob or RayService.
+	Kind string
+	// Object is the *rayv1api.RayCluster, *rayv1api.RayJob or *rayv1api.RayService to be submitted, which the
+	// plugins can modify. The updates are submitted with the object as it will be after the update.
+	Object metav1.Object
+}
+
+// AdmissionPlugin enforces the policies of a platform on the clusters, jobs and services, e.g. forbids the host
+// network or adds the labels of the cost centers. Unlike the ToCrd conversion hooks of the model package, the plugins
+// also see the in-place updates, such as the new image of a worker group. They are registered by the main package of
+// a build of the API server, and called before the admission webhooks of the config.
+type AdmissionPlugin interface {
+	// Admit can modify request.Object, an error rejects the call. A *util.UserError chooses the code of the error,
+	// the other errors are internal errors. The caller can be found with interceptor.UserFromContext.
+	Admit(ctx context.Context, request *AdmissionRequest) error
+}
+
+type namedAdmissionPlugin struct {
+	name   string
+	plugin AdmissionPlugin
+}
+
+var (
+	admissionPluginsLock sync.RWMutex
+	admissionPlugins     []*namedAdmissionPlugin
+)
+
+// RegisterAdmissionPlugin registers a plugin, which is called after the plugins registered before it. The name
+// identifies the plugin in the errors. It returns a function unregistering the plugin, e.g. at the end of a test.
+func RegisterAdmissionPlugin(name string, plugin AdmissionPlugin) func() {
+	registered := &namedAdmissionPlugin{name: name, plugin: plugin}
+	admissionPluginsLock.Lock()
+	defer admissionPluginsLock.Unlock()
+	admissionPlugins = append(admissionPlugins, registered)
+	return func() {
+		admissionPluginsLock.Lock()
+		defer admissionPluginsLock.Unlock()
+		for i, p := range admissionPlugins {
+			if p == registered {
+				admissionPlugins = append(admissionPlugins[:i:i], admissionPlugins[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func registeredAdmissionPlugins() []*namedAdmissionPlugin {
+	admissionPluginsLock.RLock()
+	defer admissionPluginsLock.RUnlock()
+	return admissionPlugins
+}
+
+// AdmissionWebhookRequest is the JSON payload posted to the admission webhooks.
+type AdmissionWebhookRequest struct {
+	Operation string        `json:"operation"`
+	Kind      string        `json:"kind"`
+	Namespace string        `json:"namespace"`
+	Name      string        `json:"name"`
+	Object    metav1.Object `json:"object"`
+}
+
+// AdmissionWebhookResponse is the JSON answer of the admission webhooks. Patch is an optional RFC 6902 JSON patch of
+// the object, applied if the object is allowed.
+type AdmissionWebhookResponse struct {
+	Allowed bool            `json:"allowed"`
+	Message string          `json:"message,omitempty"`
+	Patch   json.RawMessage `json:"patch,omitempty"`
+}
+
+// admit calls the admission plugins and the admission webhooks of the config with a cluster, job or service, which
+// they can modify, before it is submitted to Kubernetes.
+func (r *ResourceManager) admit(ctx context.Context, operation string, object metav1.Object) error {
+	request := &AdmissionRequest{Operation: operation, Kind: admissionKind(object), Object: object}
+	for _, p := range registeredAdmissionPlugins() {
+		if err := p.plugin.Admit(ctx, request); err != nil {
+			if _, ok := err.(*util.UserError); ok {
+				return util.Wrapf(err, "Admission plugin %q rejected %s %s", p.name, request.Kind, object.GetName())
+			}
+			return util.NewInternalServerError(err, "Admission plugin %q failed for %s %s", p.name, request.Kind, object.GetName())
+		}
+	}
+	for _, webhook := range config.Get().AdmissionWebhooks {
+		if !webhook.AppliesTo(request.Kind) {
+			continue
+		}
+		if err := r.callAdmissionWebhook(ctx, webhook, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// admitPatch admits the object a JSON patch of an in-place update results in, and returns the patch with the
+// changes of the admission appended. The changes replace the spec, labels and annotations of the object, and are
+// only applied if the object has not changed since it was read.
+func (r *ResourceManager) admitPatch(ctx context.Context, object metav1.Object, patch []byte) ([]byte, error) {
+	if len(registeredAdmissionPlugins()) == 0 && len(config.Get().AdmissionWebhooks) == 0 {
+		return patch, nil
+	}
+	current, err := json.Marshal(object)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal %s", object.GetName())
+	}
+	decodedPatch, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decode the patch of %s", object.GetName())
+	}
+	updated, err := decodedPatch.Apply(current)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to apply the patch of %s", object.GetName())
+	}
+	updatedObject := reflect.New(reflect.TypeOf(object).Elem()).Interface().(metav1.Object)
+	if err := json.Unmarshal(updated, updatedObject); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to unmarshal the patched %s", object.GetName())
+	}
+	before := admissionFields(updatedObject)
+	if err := r.admit(ctx, AdmissionUpdate, updatedObject); err != nil {
+		return nil, err
+	}
+	after := admissionFields(updatedObject)
+	if reflect.DeepEqual(before, after) {
+		return patch, nil
+	}
+
+	var operations []map[string]interface{}
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decode the patch of %s", object.GetName())
+	}
+	if resourceVersion := object.GetResourceVersion(); resourceVersion != "" {
+		operations = append(operations, map[string]interface{}{"op": "test", "path": "/metadata/resourceVersion", "value": resourceVersion})
+	}
+	for _, path := range admissionPaths {
+		if !reflect.DeepEqual(before[path], after[path]) {
+			operations = append(operations, map[string]interface{}{"op": "add", "path": path, "value": after[path]})
+		}
+	}
+	data, err := json.Marshal(operations)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the patch of %s", object.GetName())
+	}
+	return data, nil
+}
+
+// admissionPaths are the patch paths of the fields the admission can change in the in-place updates.
+var admissionPaths = []string{"/spec", "/metadata/labels", "/metadata/annotations"}
+
+// admissionFields returns the JSON of the fields of admissionPaths, by path.
+func admissionFields(object metav1.Object) map[string]interface{} {
+	fields := map[string]interface{}{}
+	data, err := json.Marshal(object)
+	if err == nil {
+		err = json.Unmarshal(data, &fields)
+	}
+	if err != nil {
+		return nil
+	}
+	metadata, _ := fields["metadata"].(map[string]interface{})
+	return map[string]interface{}{
+		"/spec":                 fields["spec"],
+		"/metadata/labels":      metadata["labels"],
+		"/metadata/annotations": metadata["annotations"],
+	}
+}
+
+func (r *ResourceManager) callAdmissionWebhook(ctx context.Context, webhook config.AdmissionWebhook, request *AdmissionRequest) error {
+	object := request.Object
+	response, err := postAdmissionWebhook(ctx, webhook, &AdmissionWebhookRequest{
+		Operation: request.Operation,
+		Kind:      request.Kind,
+		Namespace: object.GetNamespace(),
+		Name:      object.GetName(),
+		Object:    object,
+	})
+	if err != nil {
+		if webhook.FailurePolicy == config.AdmissionFailurePolicyIgnore {
+			klog.FromContext(ctx).Error(err, "Ignoring the failure of the admission webhook", "webhook", webhook.Name, "kind", request.Kind, "object", klog.KObj(object))
+			return nil
+		}
+		return util.NewInternalServerError(err, "Admission webhook %q failed for %s %s", webhook.Name, request.Kind, object.GetName())
+	}
+	if !response.Allowed {
+		message := response.Message
+		if message == "" {
+			message = "no reason given"
+		}
+		return util.NewPermissionDeniedError(fmt.Errorf("denied by admission webhook %s", webhook.Name),
+			"Admission webhook %q denied %s %s: %s", webhook.Name, request.Kind, object.GetName(), message)
+	}
+	if len(response.Patch) == 0 || string(response.Patch) == "null" {
+		return nil
+	}
+	if err := applyAdmissionPatch(object, response.Patch); err != nil {
+		return util.NewInternalServerError(err, "Failed to apply the patch of admission webhook %q to %s %s", webhook.Name, request.Kind, object.GetName())
+	}
+	return nil
+}
+
+func postAdmissionWebhook(ctx context.Context, webhook config.AdmissionWebhook, request *AdmissionWebhookRequest) (*AdmissionWebhookResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	timeout := defaultAdmissionWebhookTimeout
+	if webhook.TimeoutSeconds > 0 {
+		timeout = time.Duration(webhook.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("the webhook responded with %s", httpResponse.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(httpResponse.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	response := &AdmissionWebhookResponse{}
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, fmt.Errorf("invalid response of the webhook: %w", err)
+	}
+	return response, nil
+}
+
+// applyAdmissionPatch applies the JSON patch of a webhook to an object in place. The name and the namespace of the
+// object can not be changed.
+func applyAdmissionPatch(object metav1.Object, patch []byte) error {
+	decodedPatch, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	if data, err = decodedPatch.Apply(data); err != nil {
+		return err
+	}
+	name, namespace := object.GetName(), object.GetNamespace()
+	value := reflect.ValueOf(object).Elem()
+	patched := reflect.New(value.Type())
+	if err := json.Unmarshal(data, patched.Interface()); err != nil {
+		return err
+	}
+	patchedObject := patched.Interface().(metav1.Object)
+	if patchedObject.GetName() != name || patchedObject.GetNamespace() != namespace {
+		return fmt.Errorf("the patch changes the name or the namespace")
+	}
+	// The empty maps are lost in JSON, keep them for the callers setting their own labels and annotations.
+	if patchedObject.GetLabels() == nil && object.GetLabels() != nil {
+		patchedObject.SetLabels(map[string]string{})
+	}
+	if patchedObject.GetAnnotations() == nil && object.GetAnnotations() != nil {
+		patchedObject.SetAnnotations(map[string]string{})
+	}
+	value.Set(patched.Elem())
+	return nil
+}
+
+func admissionKind(object metav1.Object) string {
+	switch object.(type) {
+	case *rayv1api.RayCluster:
+		return string(utils.RayClusterCRD)
+	case *rayv1api.RayJob:
+		return string(utils.RayJobCRD)
+	case *rayv1api.RayService:
+		return string(utils.RayServiceCRD)
+	}
+	return reflect.TypeOf(object).Elem().Name()
+}
@@ -0,0 +1,155 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+type costCenterPlugin struct {
+	requests []string
+}
+
+// Admit labels the clusters with their cost center and limits the size of their worker groups.
+func (p *costCenterPlugin) Admit(_ context.Context, request *AdmissionRequest) error {
+	p.requests = append(p.requests, request.Operation+" "+request.Kind)
+	cluster, ok := request.Object.(*rayv1api.RayCluster)
+	if !ok {
+		return nil
+	}
+	for _, group := range cluster.Spec.WorkerGroupSpecs {
+		if group.MaxReplicas != nil && *group.MaxReplicas > 10 {
+			return util.NewInvalidInputError("worker group %s can not have more than 10 replicas", group.GroupName)
+		}
+	}
+	if cluster.Labels == nil {
+		cluster.Labels = map[string]string{}
+	}
+	cluster.Labels["cost-center"] = "ml-" + cluster.Namespace
+	return nil
+}
+
+func newAdmissionTestCluster(name string) *api.Cluster {
+	return &api.Cluster{
+		Name:      name,
+		Namespace: "team-a",
+		User:      "user",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{ComputeTemplate: "template"},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{GroupName: "small", ComputeTemplate: "template", Replicas: 1, MinReplicas: 1, MaxReplicas: 2},
+			},
+		},
+	}
+}
+
+func TestAdmissionPlugin(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: 1, Memory: 2})
+	require.NoError(t, err)
+	plugin := &costCenterPlugin{}
+	t.Cleanup(RegisterAdmissionPlugin("cost-center", plugin))
+
+	cluster, err := resourceManager.CreateCluster(ctx, newAdmissionTestCluster("cluster"), false, "")
+	require.NoError(t, err)
+	assert.Equal(t, "ml-team-a", cluster.Labels["cost-center"])
+
+	// The in-place updates are admitted with the updated cluster, and the changes of the plugins are patched.
+	_, err = clientManager.clients.Ray.RayV1().RayClusters("team-a").Patch(ctx, "cluster", types.JSONPatchType,
+		[]byte(`[{"op":"remove","path":"/metadata/labels/cost-center"}]`), metav1.PatchOptions{})
+	require.NoError(t, err)
+	cluster, err = resourceManager.UpdateWorkerGroupAutoscaling(ctx, &api.UpdateWorkerGroupAutoscalingRequest{
+		Name: "cluster", Namespace: "team-a", GroupName: "small", MinReplicas: 1, MaxReplicas: 8,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(8), *cluster.Spec.WorkerGroupSpecs[0].MaxReplicas)
+	assert.Equal(t, "ml-team-a", cluster.Labels["cost-center"])
+
+	_, err = resourceManager.UpdateWorkerGroupAutoscaling(ctx, &api.UpdateWorkerGroupAutoscalingRequest{
+		Name: "cluster", Namespace: "team-a", GroupName: "small", MinReplicas: 1, MaxReplicas: 20,
+	})
+	require.Error(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "worker group small can not have more than 10 replicas")
+	assert.Equal(t, []string{"CREATE RayCluster", "UPDATE RayCluster", "UPDATE RayCluster"}, plugin.requests)
+}
+
+func TestAdmissionWebhooks(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: 1, Memory: 2})
+	require.NoError(t, err)
+
+	policy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			Operation string `json:"operation"`
+			Kind      string `json:"kind"`
+			Name      string `json:"name"`
+			Object    struct {
+				Spec rayv1api.RayClusterSpec `json:"spec"`
+			} `json:"object"`
+		}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "CREATE", request.Operation)
+		assert.Equal(t, "RayCluster", request.Kind)
+		if request.Name == "forbidden" {
+			_, _ = w.Write([]byte(`{"allowed":false,"message":"the cluster is forbidden"}`))
+			return
+		}
+		assert.Len(t, request.Object.Spec.WorkerGroupSpecs, 1)
+		_, _ = w.Write([]byte(`{"allowed":true,"patch":[{"op":"add","path":"/metadata/labels/team","value":"a"}]}`))
+	}))
+	defer policy.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	config.Set(&config.Config{AdmissionWebhooks: []config.AdmissionWebhook{
+		{Name: "policy", URL: policy.URL, Kinds: []string{"RayCluster"}},
+		{Name: "optional", URL: broken.URL, FailurePolicy: config.AdmissionFailurePolicyIgnore},
+		{Name: "jobs", URL: broken.URL, Kinds: []string{"RayJob"}},
+	}})
+
+	cluster, err := resourceManager.CreateCluster(ctx, newAdmissionTestCluster("cluster"), false, "")
+	require.NoError(t, err)
+	assert.Equal(t, "a", cluster.Labels["team"])
+
+	_, err = resourceManager.CreateCluster(ctx, newAdmissionTestCluster("forbidden"), false, "")
+	require.Error(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied))
+	assert.Contains(t, err.Error(), "the cluster is forbidden")
+
+	config.Set(&config.Config{AdmissionWebhooks: []config.AdmissionWebhook{{Name: "required", URL: broken.URL}}})
+	_, err = resourceManager.CreateCluster(ctx, newAdmissionTestCluster("other"), false, "")
+	require.Error(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.Internal))
+	assert.Contains(t, err.Error(), `Admission webhook "required" failed`)
+}
+
+func TestApplyAdmissionPatch(t *testing.T) {
+	cluster := &rayv1api.RayCluster{}
+	cluster.Name, cluster.Namespace = "cluster", "team-a"
+	require.NoError(t, applyAdmissionPatch(cluster, []byte(`[{"op":"add","path":"/metadata/labels","value":{"team":"a"}}]`)))
+	assert.Equal(t, map[string]string{"team": "a"}, cluster.Labels)
+
+	err := applyAdmissionPatch(cluster, []byte(`[{"op":"replace","path":"/metadata/namespace","value":"team-b"}]`))
+	require.Error(t, err)
+	assert.Equal(t, "team-a", cluster.Namespace)
+}
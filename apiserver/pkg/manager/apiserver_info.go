@@ -0,0 +1,58 @@
+package manager
+
+import (
+	"context"
+	"slices"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/features"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// ApiVersions are the versions of the REST API of the API server.
+var ApiVersions = []string{"v1"}
+
+// GetApiServerInfo returns the version of the API server, the versions of the Ray custom resources which the
+// Kubernetes cluster serves and which the model converts, and the state of the feature gates.
+func (r *ResourceManager) GetApiServerInfo(_ context.Context) (*api.ApiServerInfo, error) {
+	served, err := r.servedRayAPIVersions()
+	if err != nil {
+		return nil, err
+	}
+	crdAPIVersion := rayv1api.GroupVersion.String()
+	return &api.ApiServerInfo{
+		Version:                 util.Version,
+		GitCommit:               util.BuildGitCommit(),
+		ApiVersions:             ApiVersions,
+		CrdApiVersion:           crdAPIVersion,
+		SupportedCrdApiVersions: model.RayAPIVersions,
+		ServedCrdApiVersions:    served,
+		CrdApiVersionServed:     slices.Contains(served, crdAPIVersion),
+		Features:                features.States(),
+	}, nil
+}
+
+// servedRayAPIVersions discovers the API versions of the ray.io group which the Kubernetes cluster serves, the
+// preferred version first. The discovery client does not take a context.
+func (r *ResourceManager) servedRayAPIVersions() ([]string, error) {
+	groups, err := r.clientManager.KubernetesClient().DiscoveryClient().ServerGroups()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to discover the API groups of the Kubernetes cluster")
+	}
+	for _, group := range groups.Groups {
+		if group.Name != rayv1api.GroupVersion.Group {
+			continue
+		}
+		versions := []string{group.PreferredVersion.GroupVersion}
+		for _, version := range group.Versions {
+			if version.GroupVersion != group.PreferredVersion.GroupVersion {
+				versions = append(versions, version.GroupVersion)
+			}
+		}
+		return versions, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/features"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+func TestGetApiServerInfo(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	defer features.SetFeatureGateDuringTest(features.WatchRPCs, true)()
+
+	info, err := resourceManager.GetApiServerInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, util.Version, info.Version)
+	assert.Equal(t, []string{"v1"}, info.ApiVersions)
+	assert.Equal(t, "ray.io/v1", info.CrdApiVersion)
+	assert.Equal(t, []string{"ray.io/v1", "ray.io/v1alpha1"}, info.SupportedCrdApiVersions)
+	assert.Equal(t, []string{"ray.io/v1"}, info.ServedCrdApiVersions)
+	assert.True(t, info.CrdApiVersionServed)
+	assert.True(t, info.Features[string(features.WatchRPCs)])
+	assert.Contains(t, info.Features, string(features.HistoryDB))
+
+	// An older KubeRay operator only serves ray.io/v1alpha1.
+	clientManager.clients.Kubernetes.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "ray.io/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "rayclusters", Namespaced: true, Kind: "RayCluster"}},
+	}}
+	info, err = resourceManager.GetApiServerInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ray.io/v1alpha1"}, info.ServedCrdApiVersions)
+	assert.False(t, info.CrdApiVersionServed)
+
+	// Without the KubeRay operator CRDs, no version is served.
+	clientManager.clients.Kubernetes.Resources = nil
+	info, err = resourceManager.GetApiServerInfo(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, info.ServedCrdApiVersions)
+	assert.False(t, info.CrdApiVersionServed)
+}
@@ -0,0 +1,431 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// backupReferences collects the ConfigMaps, Secrets, compute templates and images referenced by the Pod templates of backed up resources.
+type backupReferences struct {
+	configMaps       map[string]bool
+	secrets          map[string]bool
+	computeTemplates map[string]bool
+	images           map[string]bool
+}
+
+func newBackupReferences() *backupReferences {
+	return &backupReferences{
+		configMaps:       map[string]bool{},
+		secrets:          map[string]bool{},
+		computeTemplates: map[string]bool{},
+		images:           map[string]bool{},
+	}
+}
+
+// ExportBackup returns the RayClusters, RayJobs and RayServices managed by the API server in the namespace, together
+// with the compute templates, ConfigMaps and, if includeSecrets is set, Secrets they reference. The status and the
+// server populated metadata of every object are removed, so that the objects can be created in another cluster.
+func (r *ResourceManager) ExportBackup(ctx context.Context, namespace string, includeSecrets bool) (*model.BackupObjects, error) {
+	objects := &model.BackupObjects{}
+	refs := newBackupReferences()
+
+	clusters, _, err := r.ListClusters(ctx, namespace, "", 0, ResourceSelector{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters {
+		// RayClusters created by RayJobs and RayServices are recreated by the KubeRay operator.
+		if metav1.GetControllerOf(cluster) != nil || isBackupExcluded(cluster.ObjectMeta) {
+			continue
+		}
+		refs.addClusterSpec(&cluster.Spec)
+		objects.Clusters = append(objects.Clusters, &rayv1api.RayCluster{
+			ObjectMeta: backupObjectMeta(cluster.ObjectMeta),
+			Spec:       *cluster.Spec.DeepCopy(),
+		})
+	}
+
+	services, _, err := r.ListServices(ctx, namespace, "", 0, ResourceSelector{})
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		if isBackupExcluded(service.ObjectMeta) {
+			continue
+		}
+		refs.addClusterSpec(&service.Spec.RayClusterSpec)
+		objects.Services = append(objects.Services, &rayv1api.RayService{
+			ObjectMeta: backupObjectMeta(service.ObjectMeta),
+			Spec:       *service.Spec.DeepCopy(),
+		})
+	}
+
+	jobs, _, err := r.ListJobs(ctx, namespace, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if isBackupExcluded(job.ObjectMeta) {
+			continue
+		}
+		if job.Spec.RayClusterSpec != nil {
+			refs.addClusterSpec(job.Spec.RayClusterSpec)
+		}
+		if job.Spec.SubmitterPodTemplate != nil {
+			refs.addPodTemplate(job.Spec.SubmitterPodTemplate)
+		}
+		objects.Jobs = append(objects.Jobs, &rayv1api.RayJob{
+			ObjectMeta: backupObjectMeta(job.ObjectMeta),
+			Spec:       *job.Spec.DeepCopy(),
+		})
+	}
+
+	// Compute templates are stored as ConfigMaps named after the template.
+	for name := range refs.computeTemplates {
+		refs.configMaps[name] = true
+	}
+	configMapClient := r.getKubernetesConfigMapClient(namespace)
+	for _, name := range sortedKeys(refs.configMaps) {
+		configMap, err := configMapClient.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			// Pods may reference optional ConfigMaps, which are not required to restore the resources.
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, util.Wrap(err, fmt.Sprintf("Failed to get ConfigMap %s/%s", namespace, name))
+		}
+		objects.ConfigMaps = append(objects.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: backupObjectMeta(configMap.ObjectMeta),
+			Data:       configMap.Data,
+			BinaryData: configMap.BinaryData,
+		})
+	}
+
+	if includeSecrets {
+		secretClient := r.clientManager.KubernetesClient().SecretClient(namespace)
+		for _, name := range sortedKeys(refs.secrets) {
+			secret, err := secretClient.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return nil, util.Wrap(err, fmt.Sprintf("Failed to get Secret %s/%s", namespace, name))
+			}
+			objects.Secrets = append(objects.Secrets, &corev1.Secret{
+				ObjectMeta: backupObjectMeta(secret.ObjectMeta),
+				Type:       secret.Type,
+				Data:       secret.Data,
+			})
+		}
+	}
+
+	return objects, nil
+}
+
+// ImportBackup creates the objects of a backup in the namespace. Objects which already exist are left unchanged.
+// Before anything is created, the compute templates referenced by the Ray resources are checked to be part of the
+// backup or to exist in the namespace, and the images of the Ray resources are checked against the allowlist.
+func (r *ResourceManager) ImportBackup(ctx context.Context, namespace string, sourceNamespace string, objects *model.BackupObjects) (restored []string, skipped []string, err error) {
+	cfg := config.Get()
+	if err := checkNamespaceAllowed(cfg, namespace); err != nil {
+		return nil, nil, err
+	}
+	if err := r.validateBackup(ctx, cfg, namespace, objects); err != nil {
+		return nil, nil, err
+	}
+	if err := r.checkBackupQuotas(ctx, cfg, namespace, sourceNamespace, objects); err != nil {
+		return nil, nil, err
+	}
+
+	record := func(kind string, meta *metav1.ObjectMeta, err error) error {
+		resource := kind + "/" + meta.Name
+		if errors.IsAlreadyExists(err) {
+			skipped = append(skipped, resource)
+			return nil
+		}
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to restore %s in namespace %s", resource, namespace)
+		}
+		restored = append(restored, resource)
+		return nil
+	}
+
+	configMapClient := r.getKubernetesConfigMapClient(namespace)
+	for _, configMap := range objects.ConfigMaps {
+		prepareRestoredObjectMeta(&configMap.ObjectMeta, namespace, sourceNamespace)
+		// Compute templates record their namespace in their data.
+		if _, ok := configMap.Data["namespace"]; ok && configMap.Labels["ray.io/config-type"] == "compute-template" {
+			configMap.Data["namespace"] = namespace
+		}
+		_, err := configMapClient.Create(ctx, configMap, metav1.CreateOptions{})
+		if err := record(model.BackupKindConfigMap, &configMap.ObjectMeta, err); err != nil {
+			return restored, skipped, err
+		}
+	}
+	secretClient := r.clientManager.KubernetesClient().SecretClient(namespace)
+	for _, secret := range objects.Secrets {
+		prepareRestoredObjectMeta(&secret.ObjectMeta, namespace, sourceNamespace)
+		_, err := secretClient.Create(ctx, secret, metav1.CreateOptions{})
+		if err := record(model.BackupKindSecret, &secret.ObjectMeta, err); err != nil {
+			return restored, skipped, err
+		}
+	}
+	for _, cluster := range objects.Clusters {
+		prepareRestoredObjectMeta(&cluster.ObjectMeta, namespace, sourceNamespace)
+		newCluster, err := r.getRayClusterClient(namespace).Create(ctx, cluster, metav1.CreateOptions{})
+		if err := record(model.BackupKindRayCluster, &cluster.ObjectMeta, err); err != nil {
+			return restored, skipped, err
+		}
+		// The pod disruption budgets are not exported either, they are recreated from the pod templates.
+		if err == nil {
+			if err := r.syncPodDisruptionBudgets(ctx, newCluster, "RayCluster", &newCluster.Spec); err != nil {
+				return restored, skipped, err
+			}
+		}
+	}
+	for _, service := range objects.Services {
+		prepareRestoredObjectMeta(&service.ObjectMeta, namespace, sourceNamespace)
+		newService, err := r.getRayServiceClient(namespace).Create(ctx, service, metav1.CreateOptions{})
+		if err := record(model.BackupKindRayService, &service.ObjectMeta, err); err != nil {
+			return restored, skipped, err
+		}
+		// The Ingress or the Route of an exposed service is not exported, it is recreated from its expose options.
+		if err == nil {
+			if err := r.syncServiceExpose(ctx, newService); err != nil {
+				return restored, skipped, err
+			}
+			if err := r.syncPodDisruptionBudgets(ctx, newService, "RayService", &newService.Spec.RayClusterSpec); err != nil {
+				return restored, skipped, err
+			}
+		}
+	}
+	for _, job := range objects.Jobs {
+		prepareRestoredObjectMeta(&job.ObjectMeta, namespace, sourceNamespace)
+		_, err := r.getRayJobClient(namespace).Create(ctx, job, metav1.CreateOptions{})
+		if err := record(model.BackupKindRayJob, &job.ObjectMeta, err); err != nil {
+			return restored, skipped, err
+		}
+	}
+
+	return restored, skipped, nil
+}
+
+func (r *ResourceManager) validateBackup(ctx context.Context, cfg *config.Config, namespace string, objects *model.BackupObjects) error {
+	refs := newBackupReferences()
+	for _, cluster := range objects.Clusters {
+		refs.addClusterSpec(&cluster.Spec)
+	}
+	for _, service := range objects.Services {
+		refs.addClusterSpec(&service.Spec.RayClusterSpec)
+	}
+	for _, job := range objects.Jobs {
+		if job.Spec.RayClusterSpec != nil {
+			refs.addClusterSpec(job.Spec.RayClusterSpec)
+		}
+	}
+
+	bundledTemplates := map[string]bool{}
+	for _, configMap := range objects.ConfigMaps {
+		if configMap.Labels["ray.io/config-type"] == "compute-template" {
+			bundledTemplates[configMap.Name] = true
+		}
+	}
+	var missing []string
+	for _, name := range sortedKeys(refs.computeTemplates) {
+		if bundledTemplates[name] {
+			continue
+		}
+		if _, err := r.GetComputeTemplate(ctx, name, namespace); err != nil {
+			if !util.IsUserErrorCodeMatch(err, codes.NotFound) {
+				return util.Wrap(err, fmt.Sprintf("Failed to check compute template %s in namespace %s", name, namespace))
+			}
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return util.NewInvalidInputError("The backup references compute templates which are neither part of the backup nor exist in namespace %s: %s", namespace, strings.Join(missing, ", "))
+	}
+
+	for _, image := range sortedKeys(refs.images) {
+		if err := checkImageAllowed(cfg, image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBackupQuotas returns an error if restoring the Ray resources of a backup which do not exist in the namespace yet
+// would exceed the quotas of the namespace, as creating them one by one would.
+func (r *ResourceManager) checkBackupQuotas(ctx context.Context, cfg *config.Config, namespace string, sourceNamespace string, objects *model.BackupObjects) error {
+	var clusters, jobs, services int
+	var requested computeResources
+	for _, cluster := range objects.Clusters {
+		_, getErr := r.getRayClusterClient(namespace).Get(ctx, cluster.Name, metav1.GetOptions{})
+		restored, err := isRestored(getErr, namespace, model.BackupKindRayCluster, cluster.Name)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			continue
+		}
+		clusters++
+		requested = requested.add(clusterSpecResources(&cluster.Spec))
+	}
+	for _, service := range objects.Services {
+		_, getErr := r.getRayServiceClient(namespace).Get(ctx, service.Name, metav1.GetOptions{})
+		restored, err := isRestored(getErr, namespace, model.BackupKindRayService, service.Name)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			continue
+		}
+		services++
+		requested = requested.add(clusterSpecResources(&service.Spec.RayClusterSpec))
+	}
+	for _, job := range objects.Jobs {
+		_, getErr := r.getRayJobClient(namespace).Get(ctx, job.Name, metav1.GetOptions{})
+		restored, err := isRestored(getErr, namespace, model.BackupKindRayJob, job.Name)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			continue
+		}
+		jobs++
+		if job.Spec.RayClusterSpec != nil {
+			requested = requested.add(clusterSpecResources(job.Spec.RayClusterSpec))
+		}
+	}
+
+	if err := checkQuotaFor(ctx, "clusters", namespace, cfg.Quotas.MaxClustersPerNamespace, clusters, r.countClusters); err != nil {
+		return err
+	}
+	if err := checkQuotaFor(ctx, "services", namespace, cfg.Quotas.MaxServicesPerNamespace, services, r.countServices); err != nil {
+		return err
+	}
+	if err := checkQuotaFor(ctx, "jobs", namespace, cfg.Quotas.MaxJobsPerNamespace, jobs, r.countJobs); err != nil {
+		return err
+	}
+	if clusters+services+jobs == 0 {
+		return nil
+	}
+	return r.checkRequestedResources(ctx, cfg, "the backup of namespace "+sourceNamespace, namespace, requested)
+}
+
+// isRestored returns whether an object of a backup is restored, i.e. whether getting it from the namespace failed with
+// err because it does not exist.
+func isRestored(err error, namespace string, kind string, name string) (bool, error) {
+	if err == nil {
+		return false, nil
+	}
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, util.NewInternalServerError(err, "Failed to check %s/%s in namespace %s", kind, name, namespace)
+}
+
+func (b *backupReferences) addClusterSpec(spec *rayv1api.RayClusterSpec) {
+	b.addPodTemplate(&spec.HeadGroupSpec.Template)
+	for i := range spec.WorkerGroupSpecs {
+		b.addPodTemplate(&spec.WorkerGroupSpecs[i].Template)
+	}
+}
+
+func (b *backupReferences) addPodTemplate(template *corev1.PodTemplateSpec) {
+	if name, ok := template.Annotations[util.RayClusterComputeTemplateAnnotationKey]; ok && name != "" {
+		b.computeTemplates[name] = true
+	}
+	for _, secret := range template.Spec.ImagePullSecrets {
+		b.secrets[secret.Name] = true
+	}
+	for _, volume := range template.Spec.Volumes {
+		if volume.ConfigMap != nil {
+			b.configMaps[volume.ConfigMap.Name] = true
+		}
+		if volume.Secret != nil {
+			b.secrets[volume.Secret.SecretName] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					b.configMaps[source.ConfigMap.Name] = true
+				}
+				if source.Secret != nil {
+					b.secrets[source.Secret.Name] = true
+				}
+			}
+		}
+	}
+	containers := append([]corev1.Container{}, template.Spec.InitContainers...)
+	containers = append(containers, template.Spec.Containers...)
+	for _, container := range containers {
+		b.images[container.Image] = true
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				b.configMaps[envFrom.ConfigMapRef.Name] = true
+			}
+			if envFrom.SecretRef != nil {
+				b.secrets[envFrom.SecretRef.Name] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				b.configMaps[env.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				b.secrets[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+	}
+}
+
+// backupObjectMeta keeps the metadata that is needed to recreate an object.
+func backupObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        meta.Name,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+func prepareRestoredObjectMeta(meta *metav1.ObjectMeta, namespace string, sourceNamespace string) {
+	meta.Namespace = namespace
+	meta.ResourceVersion = ""
+	meta.UID = ""
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[utils.RayRestoredFromAnnotationKey] = sourceNamespace
+}
+
+func isBackupExcluded(meta metav1.ObjectMeta) bool {
+	return meta.Annotations[utils.RayBackupExcludeAnnotationKey] == "true"
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,200 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestExportAndImportBackup(t *testing.T) {
+	ctx := context.Background()
+	source := NewResourceManager(NewFakeClientManager(ctx, 0))
+
+	_, err := source.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	_, err = source.CreateCluster(ctx, &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		User:      "user",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "template",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+		},
+	}, false, "")
+	require.NoError(t, err)
+
+	// Reference a Secret from the head Pod.
+	secretClient := source.clientManager.KubernetesClient().SecretClient("team-a")
+	_, err = secretClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "team-a"},
+		Data:       map[string][]byte{"token": []byte("secret")},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	clusterClient := source.getRayClusterClient("team-a")
+	cluster, err := clusterClient.Get(ctx, "cluster", metav1.GetOptions{})
+	require.NoError(t, err)
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].EnvFrom = []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}}},
+	}
+	_, err = clusterClient.Update(ctx, cluster, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// Secrets are only exported on request.
+	objects, err := source.ExportBackup(ctx, "team-a", false)
+	require.NoError(t, err)
+	require.Len(t, objects.ConfigMaps, 1)
+	assert.Equal(t, "template", objects.ConfigMaps[0].Name)
+	assert.Empty(t, objects.Secrets)
+	require.Len(t, objects.Clusters, 1)
+	assert.Empty(t, objects.Clusters[0].ResourceVersion)
+
+	objects, err = source.ExportBackup(ctx, "team-a", true)
+	require.NoError(t, err)
+	require.Len(t, objects.Secrets, 1)
+	assert.Equal(t, "credentials", objects.Secrets[0].Name)
+
+	// Restore the bundle into another namespace of another cluster.
+	bundle, err := model.FromKubeToAPIBackupBundle("team-a", source.clientManager.Time().Now(), objects)
+	require.NoError(t, err)
+	require.Len(t, bundle.Resources, 3)
+	restoredObjects, err := model.FromAPIToKubeBackupObjects(bundle)
+	require.NoError(t, err)
+
+	target := NewResourceManager(NewFakeClientManager(ctx, 0))
+	restored, skipped, err := target.ImportBackup(ctx, "team-b", bundle.Namespace, restoredObjects)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ConfigMap/template", "Secret/credentials", "RayCluster/cluster"}, restored)
+	assert.Empty(t, skipped)
+
+	restoredCluster, err := target.GetCluster(ctx, "cluster", "team-b")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", restoredCluster.Annotations[utils.RayRestoredFromAnnotationKey])
+	template, err := target.GetComputeTemplate(ctx, "template", "team-b")
+	require.NoError(t, err)
+	assert.Equal(t, "team-b", template.Data["namespace"])
+
+	// Restoring the same bundle again leaves the existing resources unchanged.
+	restoredObjects, err = model.FromAPIToKubeBackupObjects(bundle)
+	require.NoError(t, err)
+	restored, skipped, err = target.ImportBackup(ctx, "team-b", bundle.Namespace, restoredObjects)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+	assert.Equal(t, []string{"ConfigMap/template", "Secret/credentials", "RayCluster/cluster"}, skipped)
+}
+
+func TestImportBackupMissingComputeTemplate(t *testing.T) {
+	ctx := context.Background()
+	source := NewResourceManager(NewFakeClientManager(ctx, 0))
+
+	_, err := source.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	_, err = source.CreateCluster(ctx, &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		User:      "user",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "template",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+		},
+	}, false, "")
+	require.NoError(t, err)
+
+	objects, err := source.ExportBackup(ctx, "team-a", false)
+	require.NoError(t, err)
+	// Drop the compute template from the backup.
+	objects.ConfigMaps = nil
+
+	target := NewResourceManager(NewFakeClientManager(ctx, 0))
+	_, _, err = target.ImportBackup(ctx, "team-b", "team-a", objects)
+	require.Error(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+
+	clusters, _, err := target.ListClusters(ctx, "team-b", "", 0, ResourceSelector{})
+	require.NoError(t, err)
+	assert.Empty(t, clusters)
+}
+
+func TestImportBackupQuotas(t *testing.T) {
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	ctx := context.Background()
+	source := NewResourceManager(NewFakeClientManager(ctx, 0))
+
+	_, err := source.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	for _, name := range []string{"first", "second"} {
+		_, err = source.CreateCluster(ctx, &api.Cluster{
+			Name:      name,
+			Namespace: "team-a",
+			User:      "user",
+			Version:   "2.9.0",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{
+					ComputeTemplate: "template",
+					RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+				},
+			},
+		}, false, "")
+		require.NoError(t, err)
+	}
+	objects, err := source.ExportBackup(ctx, "team-a", false)
+	require.NoError(t, err)
+
+	target := NewResourceManager(NewFakeClientManager(ctx, 0))
+	config.Set(&config.Config{Quotas: config.Quotas{MaxClustersPerNamespace: 1}})
+	_, _, err = target.ImportBackup(ctx, "team-b", "team-a", objects)
+	require.Error(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.ResourceExhausted))
+	assert.Contains(t, err.Error(), "namespace team-b already has 0 of 1 allowed clusters and 2 more are requested")
+
+	config.Set(&config.Config{Quotas: config.Quotas{MaxCPUsPerNamespace: 1}})
+	_, _, err = target.ImportBackup(ctx, "team-b", "team-a", objects)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "namespace team-b already uses 0 of 1 allowed CPUs and the backup of namespace team-a requests 2 more")
+
+	clusters, _, err := target.ListClusters(ctx, "team-b", "", 0, ResourceSelector{})
+	require.NoError(t, err)
+	assert.Empty(t, clusters, "Nothing is restored when the quotas would be exceeded")
+
+	// The resources which already exist are skipped, so they do not count towards the quotas.
+	config.Set(&config.Config{Quotas: config.Quotas{MaxClustersPerNamespace: 2}})
+	restored, _, err := target.ImportBackup(ctx, "team-b", "team-a", objects)
+	require.NoError(t, err)
+	assert.Contains(t, restored, "RayCluster/first")
+	objects, err = source.ExportBackup(ctx, "team-a", false)
+	require.NoError(t, err)
+	_, skipped, err := target.ImportBackup(ctx, "team-b", "team-a", objects)
+	require.NoError(t, err)
+	assert.Contains(t, skipped, "RayCluster/second")
+}
@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// batchConcurrency is the number of items of a batch operation processed at the same time, so that a batch of
+// hundreds of resources does not flood the Kubernetes API server.
+const batchConcurrency = 10
+
+// BatchJobResult is the result of the creation of a job of a batch: the created job, or why it could not be created.
+type BatchJobResult struct {
+	Job *rayv1api.RayJob
+	Err error
+}
+
+// BatchCreateJobs creates the jobs of a namespace concurrently and returns their results in order. The jobs over the
+// job quota of the namespace fail up front, since the concurrent creations would otherwise all count the jobs which
+// existed before the batch.
+func (r *ResourceManager) BatchCreateJobs(ctx context.Context, namespace string, apiJobs []*api.RayJob) []BatchJobResult {
+	results := make([]BatchJobResult, len(apiJobs))
+	allowed := len(apiJobs)
+	if limit := config.Get().Quotas.MaxJobsPerNamespace; limit > 0 && len(apiJobs) > 0 {
+		current, err := r.countJobs(ctx, namespace)
+		if err != nil {
+			err = util.Wrap(err, fmt.Sprintf("Failed to check jobs quota in %s", namespace))
+			for i := range results {
+				results[i].Err = err
+			}
+			return results
+		}
+		allowed = max(limit-current, 0)
+		for i := allowed; i < len(apiJobs); i++ {
+			results[i].Err = util.NewResourceExhaustedError("Quota exceeded: namespace %s already has %d of %d allowed jobs, and the batch creates %d", namespace, current, limit, len(apiJobs))
+		}
+	}
+	runBatch(ctx, min(allowed, len(apiJobs)), func(i int) {
+		results[i].Job, results[i].Err = r.CreateJob(ctx, apiJobs[i])
+	}, func(i int, err error) {
+		results[i].Err = err
+	})
+	return results
+}
+
+// BatchDeleteClusters deletes the clusters of a namespace concurrently and returns why each of them could not be
+// deleted, in order, or nil for the deleted clusters.
+func (r *ResourceManager) BatchDeleteClusters(ctx context.Context, clusterNames []string, namespace string, force bool) []error {
+	errs := make([]error, len(clusterNames))
+	runBatch(ctx, len(clusterNames), func(i int) {
+		errs[i] = r.DeleteCluster(ctx, clusterNames[i], namespace, force, "")
+	}, func(i int, err error) {
+		errs[i] = err
+	})
+	return errs
+}
+
+// runBatch calls run for the items 0 to n-1 with at most batchConcurrency calls at the same time, and returns once
+// every call returned. The items which are not started when the context is done are passed to cancel instead.
+func runBatch(ctx context.Context, n int, run func(i int), cancel func(i int, err error)) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, batchConcurrency)
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+		case slots <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			cancel(i, util.NewInternalServerError(err, "The batch was canceled before item %d was processed", i))
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			run(i)
+		}(i)
+	}
+	wg.Wait()
+}
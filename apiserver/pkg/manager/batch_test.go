@@ -0,0 +1,104 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBatchCreateJobs(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	config.Set(&config.Config{Quotas: config.Quotas{MaxJobsPerNamespace: 20}})
+
+	var apiJobs []*api.RayJob
+	for i := 0; i < 25; i++ {
+		apiJobs = append(apiJobs, &api.RayJob{
+			Name:            fmt.Sprintf("job-%d", i),
+			Namespace:       "team-a",
+			User:            "user",
+			Entrypoint:      "python train.py",
+			ClusterSelector: map[string]string{utils.RayClusterLabelKey: "cluster"},
+		})
+	}
+	// The second job-0 already exists.
+	apiJobs[1].Name = "job-0"
+
+	results := resourceManager.BatchCreateJobs(ctx, "team-a", apiJobs)
+	require.Len(t, results, 25)
+	created := 0
+	for i, result := range results {
+		switch {
+		case i >= 20:
+			assert.True(t, util.IsUserErrorCodeMatch(result.Err, codes.ResourceExhausted), "job %d is over the quota", i)
+		case result.Err == nil:
+			created++
+			assert.Equal(t, apiJobs[i].Name, result.Job.Name)
+		default:
+			assert.Contains(t, []int{0, 1}, i, "only one of the jobs named job-0 fails")
+		}
+	}
+	assert.Equal(t, 19, created)
+	count, err := resourceManager.countJobs(ctx, "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, 19, count)
+
+	// The jobs of a canceled batch are not created.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	results = resourceManager.BatchCreateJobs(canceled, "team-b", apiJobs[2:4])
+	for _, result := range results {
+		require.Error(t, result.Err)
+	}
+}
+
+func TestBatchDeleteClusters(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	var names []string
+	for i := 0; i < 12; i++ {
+		name := fmt.Sprintf("cluster-%d", i)
+		_, err = resourceManager.CreateCluster(ctx, &api.Cluster{
+			Name:      name,
+			Namespace: "team-a",
+			User:      "user",
+			Version:   "2.9.0",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{
+					ComputeTemplate: "template",
+					RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+				},
+			},
+		}, false, "")
+		require.NoError(t, err)
+		names = append(names, name)
+	}
+
+	errs := resourceManager.BatchDeleteClusters(ctx, append(names, "missing"), "team-a", false)
+	require.Len(t, errs, 13)
+	for i := range names {
+		require.NoError(t, errs[i])
+	}
+	assert.True(t, util.IsUserErrorCodeMatch(errs[12], codes.NotFound))
+	clusters, _, err := resourceManager.ListClusters(ctx, "team-a", "", 0, ResourceSelector{})
+	require.NoError(t, err)
+	assert.Empty(t, clusters)
+}
@@ -1,9 +1,11 @@
 package manager
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/tracing"
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	klog "k8s.io/klog/v2"
 )
@@ -47,15 +49,21 @@ func (c *ClientManager) Time() util.TimeInterface {
 	return c.time
 }
 
-func (c *ClientManager) init() {
+func (c *ClientManager) init(kubeContext string, retryOptions client.RetryOptions) {
 	// db, kubernetes initialization
-	klog.Info("Initializing client manager")
+	klog.Infof("Initializing client manager for kubeconfig context %q", kubeContext)
 
 	// configure configs
 	initConnectionTimeout := 15 * time.Second
 	defaultKubernetesClientConfig := util.ClientOptions{
-		QPS:   5,
-		Burst: 10,
+		QPS:         5,
+		Burst:       10,
+		KubeContext: kubeContext,
+		// The spans of the calls are children of the spans of the RPCs, they are dropped unless tracing is enabled.
+		// Every attempt of a retried call has its span.
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			return client.NewRetryTransport(tracing.WrapKubernetesTransport(client.NewImpersonationTransport(rt)), retryOptions)
+		},
 	}
 
 	// 1. utils initialization
@@ -72,8 +80,14 @@ func (c *ClientManager) init() {
 }
 
 func NewClientManager() ClientManager {
+	return NewClientManagerForContext("", client.RetryOptions{})
+}
+
+// NewClientManagerForContext creates the clients of the Kubernetes cluster of a kubeconfig context, or of the current
+// context or the in-cluster config if it is empty. The transient failures of the calls are retried with retryOptions.
+func NewClientManagerForContext(kubeContext string, retryOptions client.RetryOptions) ClientManager {
 	clientManager := ClientManager{}
-	clientManager.init()
+	clientManager.init(kubeContext, retryOptions)
 
 	return clientManager
 }
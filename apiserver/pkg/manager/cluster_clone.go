@@ -0,0 +1,97 @@
+package manager
+
+import (
+	"context"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// clonedClusterAnnotations are the annotations of a source which belong to the source rather than to its spec, and
+// are not copied to a clone. The namespace of the external storage is dropped so that the clone does not share the
+// GCS metadata of the source.
+var clonedClusterAnnotations = []string{
+	"ray.io/creation-timestamp",
+	"ray.io/update-timestamp",
+	util.IdempotencyKeyAnnotationKey,
+	util.IdempotencyPayloadHashAnnotationKey,
+	util.RayExternalStorageNSAnnotationKey,
+	corev1.LastAppliedConfigAnnotation,
+}
+
+// GetClusterClone returns the cluster created by a clone request: the spec of the source cluster, or of the cluster
+// of the source ray service, with the name, user, image and worker replicas of the request. The status and the
+// metadata of the source are left out.
+func (r *ResourceManager) GetClusterClone(ctx context.Context, request *api.CloneRayClusterRequest) (*api.Cluster, error) {
+	var clone *api.Cluster
+	if request.SourceCluster != "" {
+		cluster, err := getClusterByName(ctx, r.getRayClusterClient(request.Namespace), request.SourceCluster)
+		if err != nil {
+			return nil, util.Wrap(err, "Get source cluster failure")
+		}
+		clone = model.FromCrdToApiCluster(cluster.DeepCopy(), nil)
+	} else {
+		service, err := getServiceByName(ctx, r.getRayServiceClient(request.Namespace), request.SourceService)
+		if err != nil {
+			return nil, util.Wrap(err, "Get source service failure")
+		}
+		service = service.DeepCopy()
+		clone = &api.Cluster{
+			User:        service.Labels[util.RayClusterUserLabelKey],
+			Version:     service.Spec.RayClusterSpec.RayVersion,
+			ClusterSpec: model.PopulateRayClusterSpec(service.Spec.RayClusterSpec),
+			// The operator exposes the Serve port of the clusters of a ray service.
+			Annotations: map[string]string{"ray.io/enable-serve-service": "true"},
+		}
+	}
+
+	clone.Name = request.Name
+	clone.Namespace = request.Namespace
+	clone.ResourceVersion = ""
+	clone.CreatedAt = nil
+	clone.ClusterState = ""
+	clone.Events = nil
+	clone.ServiceEndpoint = nil
+	clone.Admission = nil
+	for _, key := range clonedClusterAnnotations {
+		delete(clone.Annotations, key)
+	}
+	if clone.ClusterSpec.GcsFaultTolerance != nil {
+		clone.ClusterSpec.GcsFaultTolerance.ExternalStorageNamespace = ""
+	}
+	if request.User != "" {
+		clone.User = request.User
+	}
+
+	if request.Image != "" {
+		if head := clone.ClusterSpec.HeadGroupSpec; head != nil {
+			head.Image = request.Image
+		}
+		for _, spec := range clone.ClusterSpec.WorkerGroupSpec {
+			spec.Image = request.Image
+		}
+	}
+	for groupName, replicas := range request.WorkerGroupReplicas {
+		var group *api.WorkerGroupSpec
+		for _, spec := range clone.ClusterSpec.WorkerGroupSpec {
+			if spec.GroupName == groupName {
+				group = spec
+				break
+			}
+		}
+		if group == nil {
+			return nil, util.NewInvalidInputError("The source of cluster %s has no worker group %s.", request.Name, groupName)
+		}
+		group.Replicas = replicas
+		if replicas < group.MinReplicas {
+			group.MinReplicas = replicas
+		}
+		if replicas > group.MaxReplicas {
+			group.MaxReplicas = replicas
+		}
+	}
+	return clone, nil
+}
@@ -0,0 +1,105 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestGetClusterClone(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: 1, Memory: 2})
+	require.NoError(t, err)
+	clusterSpec := func() *api.ClusterSpec {
+		return &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "template",
+				Image:           "rayproject/ray:2.9.0",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{GroupName: "small", ComputeTemplate: "template", Image: "rayproject/ray:2.9.0", Replicas: 2, MinReplicas: 1, MaxReplicas: 4},
+				{GroupName: "large", ComputeTemplate: "template", Image: "rayproject/ray:2.9.0", Replicas: 1, MinReplicas: 1, MaxReplicas: 2},
+			},
+		}
+	}
+	_, err = resourceManager.CreateCluster(ctx, &api.Cluster{
+		Name:        "cluster",
+		Namespace:   "team-a",
+		User:        "alice",
+		Version:     "2.9.0",
+		Annotations: map[string]string{"team": "search"},
+		ClusterSpec: clusterSpec(),
+	}, false, "key")
+	require.NoError(t, err)
+	_, err = resourceManager.CreateService(ctx, &api.RayService{
+		Name:           "service",
+		Namespace:      "team-a",
+		User:           "bob",
+		Version:        "2.9.0",
+		ServeConfig_V2: "applications: []",
+		ClusterSpec:    clusterSpec(),
+	}, false, "")
+	require.NoError(t, err)
+
+	clone, err := resourceManager.GetClusterClone(ctx, &api.CloneRayClusterRequest{
+		Namespace:           "team-a",
+		Name:                "staging",
+		SourceCluster:       "cluster",
+		Image:               "rayproject/ray:2.9.0-py310",
+		WorkerGroupReplicas: map[string]int32{"small": 6, "large": 0},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "staging", clone.Name)
+	assert.Equal(t, "team-a", clone.Namespace)
+	assert.Equal(t, "alice", clone.User)
+	assert.Equal(t, "2.9.0", clone.Version)
+	assert.Empty(t, clone.ResourceVersion)
+	assert.Nil(t, clone.CreatedAt)
+	// The annotations of the source are kept, but not those of its creation.
+	assert.Equal(t, map[string]string{"team": "search"}, clone.Annotations)
+	assert.Equal(t, "rayproject/ray:2.9.0-py310", clone.ClusterSpec.HeadGroupSpec.Image)
+	small, large := clone.ClusterSpec.WorkerGroupSpec[0], clone.ClusterSpec.WorkerGroupSpec[1]
+	assert.Equal(t, "rayproject/ray:2.9.0-py310", small.Image)
+	assert.Equal(t, []int32{6, 1, 6}, []int32{small.Replicas, small.MinReplicas, small.MaxReplicas})
+	assert.Equal(t, []int32{0, 0, 2}, []int32{large.Replicas, large.MinReplicas, large.MaxReplicas})
+
+	// The source is not changed.
+	cluster, err := resourceManager.GetCluster(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), *cluster.Spec.WorkerGroupSpecs[0].Replicas)
+	assert.Contains(t, cluster.Annotations, util.IdempotencyKeyAnnotationKey)
+
+	clone, err = resourceManager.GetClusterClone(ctx, &api.CloneRayClusterRequest{
+		Namespace:     "team-a",
+		Name:          "debug",
+		SourceService: "service",
+		User:          "carol",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "debug", clone.Name)
+	assert.Equal(t, "carol", clone.User)
+	assert.Equal(t, "2.9.0", clone.Version)
+	assert.Equal(t, map[string]string{"ray.io/enable-serve-service": "true"}, clone.Annotations)
+	assert.Equal(t, "rayproject/ray:2.9.0", clone.ClusterSpec.HeadGroupSpec.Image)
+	require.Len(t, clone.ClusterSpec.WorkerGroupSpec, 2)
+	assert.Equal(t, int32(2), clone.ClusterSpec.WorkerGroupSpec[0].Replicas)
+
+	_, err = resourceManager.GetClusterClone(ctx, &api.CloneRayClusterRequest{
+		Namespace:           "team-a",
+		Name:                "staging",
+		SourceCluster:       "cluster",
+		WorkerGroupReplicas: map[string]int32{"gpu": 1},
+	})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+
+	_, err = resourceManager.GetClusterClone(ctx, &api.CloneRayClusterRequest{Namespace: "team-a", Name: "staging", SourceService: "missing"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
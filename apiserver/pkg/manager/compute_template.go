@@ -0,0 +1,163 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// The ConfigMap of a compute template holds its latest revision. Every revision is also recorded in an immutable
+// ConfigMap, so that the clusters can be pinned to a revision, and rolled forward or back explicitly.
+
+// computeTemplateRevisionConfigType is the config type of the ConfigMaps of the revisions of the compute templates.
+const computeTemplateRevisionConfigType = "compute-template-revision"
+
+// UpdateComputeTemplate replaces a compute template with a new revision. The existing clusters are left unchanged.
+func (r *ResourceManager) UpdateComputeTemplate(ctx context.Context, template *api.ComputeTemplate) (*corev1.ConfigMap, error) {
+	client := r.getKubernetesConfigMapClient(template.Namespace)
+	current, err := getComputeTemplateByName(ctx, client, template.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Get compute template failure")
+	}
+	// The compute templates created before the revisions have no ConfigMap of their first revision.
+	if err := r.recordComputeTemplateRevision(ctx, current); err != nil {
+		return nil, err
+	}
+
+	template.Revision = util.ComputeTemplateRevision(current) + 1
+	configMap, err := util.NewComputeTemplate(template)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert compute runtime (%s/%s)", template.Namespace, template.Name)
+	}
+	// Fail instead of creating the same revision twice if the compute template is updated concurrently.
+	configMap.ResourceVersion = current.ResourceVersion
+	updated, err := client.Update(ctx, configMap, metav1.UpdateOptions{})
+	if err != nil {
+		if errors.IsConflict(err) {
+			return nil, util.NewConflictError("Compute template %s has been updated concurrently. Please retry.", template.Name)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to update compute template (%s/%s)", template.Namespace, template.Name)
+	}
+	if err := r.recordComputeTemplateRevision(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// GetComputeTemplateRevision returns a revision of a compute template, the latest one for 0.
+func (r *ResourceManager) GetComputeTemplateRevision(ctx context.Context, name string, namespace string, revision int32) (*corev1.ConfigMap, error) {
+	client := r.getKubernetesConfigMapClient(namespace)
+	current, err := getComputeTemplateByName(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	if revision == 0 || revision == util.ComputeTemplateRevision(current) {
+		return current, nil
+	}
+	configMap, err := client.Get(ctx, computeTemplateRevisionName(name, revision), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, util.NewNotFoundError(err, "Revision %d of compute template %s not found", revision, name)
+		}
+		return nil, util.Wrap(err, "Get compute template revision failed")
+	}
+	return configMap, nil
+}
+
+// ListComputeTemplateRevisions lists the revisions of a compute template, oldest first.
+func (r *ResourceManager) ListComputeTemplateRevisions(ctx context.Context, name string, namespace string) ([]*corev1.ConfigMap, error) {
+	client := r.getKubernetesConfigMapClient(namespace)
+	current, err := getComputeTemplateByName(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	configMapList, err := client.List(ctx, metav1.ListOptions{LabelSelector: computeTemplateRevisionSelector(name)})
+	if err != nil {
+		return nil, util.Wrap(err, "List compute template revisions failed")
+	}
+
+	// The latest revision is recorded after the compute template is updated, it may be missing.
+	result := []*corev1.ConfigMap{current}
+	for i := range configMapList.Items {
+		if util.ComputeTemplateRevision(&configMapList.Items[i]) != util.ComputeTemplateRevision(current) {
+			result = append(result, &configMapList.Items[i])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return util.ComputeTemplateRevision(result[i]) < util.ComputeTemplateRevision(result[j])
+	})
+	return result, nil
+}
+
+// recordComputeTemplateRevision records the current revision of a compute template in an immutable ConfigMap, which
+// is garbage collected with the compute template.
+func (r *ResourceManager) recordComputeTemplateRevision(ctx context.Context, current *corev1.ConfigMap) error {
+	name := current.Labels["ray.io/compute-template"]
+	if name == "" {
+		name = current.Name
+	}
+	revision := util.ComputeTemplateRevision(current)
+	immutable := true
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      computeTemplateRevisionName(name, revision),
+			Namespace: current.Namespace,
+			Labels: map[string]string{
+				"ray.io/config-type":                    computeTemplateRevisionConfigType,
+				"ray.io/compute-template":               name,
+				util.RayComputeTemplateRevisionLabelKey: strconv.Itoa(int(revision)),
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       current.Name,
+				UID:        current.UID,
+			}},
+		},
+		Data:      maps.Clone(current.Data),
+		Immutable: &immutable,
+	}
+	_, err := r.getKubernetesConfigMapClient(current.Namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return util.NewInternalServerError(err, "Failed to record revision %d of compute template (%s/%s)", revision, current.Namespace, name)
+	}
+	return nil
+}
+
+// deleteComputeTemplateRevisions deletes the revisions of a deleted compute template, without waiting for the
+// garbage collection, so that a compute template created with the same name starts from scratch.
+func (r *ResourceManager) deleteComputeTemplateRevisions(ctx context.Context, name string, namespace string) error {
+	client := r.getKubernetesConfigMapClient(namespace)
+	configMapList, err := client.List(ctx, metav1.ListOptions{LabelSelector: computeTemplateRevisionSelector(name)})
+	if err != nil {
+		return util.Wrap(err, "List compute template revisions failed")
+	}
+	for _, configMap := range configMapList.Items {
+		if err := client.Delete(ctx, configMap.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return util.NewInternalServerError(err, "Failed to delete revision %s of compute template %s", configMap.Name, name)
+		}
+	}
+	return nil
+}
+
+// computeTemplateRevisionName returns the name of the ConfigMap of a revision of a compute template.
+func computeTemplateRevisionName(name string, revision int32) string {
+	return fmt.Sprintf("%s.revision-%d", name, revision)
+}
+
+func computeTemplateRevisionSelector(name string) string {
+	return labels.SelectorFromSet(map[string]string{
+		"ray.io/config-type":      computeTemplateRevisionConfigType,
+		"ray.io/compute-template": name,
+	}).String()
+}
@@ -0,0 +1,133 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestComputeTemplateRevisions(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+
+	configMap, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: 1, Memory: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), model.FromKubeToAPIComputeTemplate(configMap).Revision)
+	for _, cpu := range []uint32{2, 4} {
+		configMap, err = resourceManager.UpdateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: cpu, Memory: 2})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), model.FromKubeToAPIComputeTemplate(configMap).Revision)
+
+	configMaps, err := resourceManager.ListComputeTemplateRevisions(ctx, "template", "team-a")
+	require.NoError(t, err)
+	templates := model.FromKubeToAPIComputeTemplates(configMaps)
+	require.Len(t, templates, 3)
+	for i, cpu := range []uint32{1, 2, 4} {
+		assert.Equal(t, "template", templates[i].Name)
+		assert.Equal(t, int32(i+1), templates[i].Revision)
+		assert.Equal(t, cpu, templates[i].Cpu)
+	}
+	// The revisions are not compute templates of their own.
+	configMaps, err = resourceManager.ListComputeTemplates(ctx, "team-a")
+	require.NoError(t, err)
+	assert.Len(t, configMaps, 1)
+
+	configMap, err = resourceManager.GetComputeTemplateRevision(ctx, "template", "team-a", 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), model.FromKubeToAPIComputeTemplate(configMap).Cpu)
+	configMap, err = resourceManager.GetComputeTemplateRevision(ctx, "template", "team-a", 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), model.FromKubeToAPIComputeTemplate(configMap).Cpu)
+	_, err = resourceManager.GetComputeTemplateRevision(ctx, "template", "team-a", 7)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	_, err = resourceManager.GetComputeTemplate(ctx, "template.revision-1", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	_, err = resourceManager.UpdateComputeTemplate(ctx, &api.ComputeTemplate{Name: "missing", Namespace: "team-a", Cpu: 1, Memory: 2})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	// The revisions are deleted with the compute template.
+	require.NoError(t, resourceManager.DeleteComputeTemplate(ctx, "template", "team-a"))
+	configMapList, err := resourceManager.getKubernetesConfigMapClient("team-a").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, configMapList.Items)
+}
+
+func TestComputeTemplateRevisionOfCluster(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: 1, Memory: 2})
+	require.NoError(t, err)
+	_, err = resourceManager.UpdateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: 2, Memory: 2})
+	require.NoError(t, err)
+
+	newCluster := func(headRevision int32, workerRevision int32) *api.Cluster {
+		return &api.Cluster{
+			Name:      "cluster",
+			Namespace: "team-a",
+			User:      "user",
+			Version:   "2.9.0",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{
+					ComputeTemplate:         "template",
+					ComputeTemplateRevision: headRevision,
+					RayStartParams:          map[string]string{"dashboard-host": "0.0.0.0"},
+				},
+				WorkerGroupSpec: []*api.WorkerGroupSpec{
+					{GroupName: "workers", ComputeTemplate: "template", ComputeTemplateRevision: workerRevision, Replicas: 1, MinReplicas: 1, MaxReplicas: 2},
+				},
+			},
+		}
+	}
+	_, err = resourceManager.CreateCluster(ctx, newCluster(1, 2), false, "")
+	assert.ErrorContains(t, err, "Compute template template is used at revisions 1 and 2")
+
+	// The groups are pinned to the revision of the head group.
+	cluster, err := resourceManager.CreateCluster(ctx, newCluster(1, 0), false, "")
+	require.NoError(t, err)
+	group := cluster.Spec.WorkerGroupSpecs[0]
+	assert.Equal(t, "1", cluster.Spec.HeadGroupSpec.Template.Annotations[util.RayClusterComputeTemplateRevisionAnnotationKey])
+	assert.Equal(t, "1", group.Template.Annotations[util.RayClusterComputeTemplateRevisionAnnotationKey])
+	assert.Equal(t, int32(1), model.PopulateWorkerNodeSpec([]rayv1api.WorkerGroupSpec{*group.DeepCopy()})[0].ComputeTemplateRevision)
+
+	// A new image keeps the pinned revision.
+	cluster, err = resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", Image: "rayproject/ray:2.9.0-fixed"})
+	require.NoError(t, err)
+	group = cluster.Spec.WorkerGroupSpecs[0]
+	assert.Equal(t, "1", group.Template.Annotations[util.RayClusterComputeTemplateRevisionAnnotationKey])
+
+	// The group is rolled forward explicitly.
+	cluster, err = resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", ComputeTemplateRevision: 2})
+	require.NoError(t, err)
+	group = cluster.Spec.WorkerGroupSpecs[0]
+	assert.Equal(t, "2", group.Template.Annotations[util.RayClusterComputeTemplateRevisionAnnotationKey])
+	assert.Equal(t, "rayproject/ray:2.9.0-fixed", group.Template.Annotations[util.RayClusterImageAnnotationKey])
+	container, _, ok := util.GetContainerByName(group.Template.Spec.Containers, "ray-worker")
+	require.True(t, ok)
+	assert.Equal(t, "2", container.Resources.Limits.Cpu().String())
+
+	_, err = resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", ComputeTemplateRevision: 3})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestGetComputeTemplateOfOtherConfigMap(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	_, err := resourceManager.getKubernetesConfigMapClient("team-a").Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "team-a"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = resourceManager.GetComputeTemplate(ctx, "settings", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
@@ -0,0 +1,326 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// RayCronJobs are stored in ConfigMaps and their runs are RayJobs labeled with the name of the cron job
+// and owned by its ConfigMap. The runs are created by the scheduler started with StartRayCronJobScheduler.
+
+const rayCronJobSelector = "ray.io/config-type=" + util.RayCronJobConfigType
+
+func (r *ResourceManager) CreateRayCronJob(ctx context.Context, apiCronJob *api.RayCronJob) (*corev1.ConfigMap, error) {
+	cfg := config.Get()
+	if err := checkNamespaceAllowed(cfg, apiCronJob.Namespace); err != nil {
+		return nil, err
+	}
+	if _, err := r.GetRayCronJob(ctx, apiCronJob.Name, apiCronJob.Namespace); err == nil {
+		return nil, util.NewAlreadyExistError("Cron job with name %s already exists in namespace %s", apiCronJob.Name, apiCronJob.Namespace)
+	}
+
+	// Convert the template once, so that missing compute templates or disallowed images are reported
+	// now rather than on every run.
+	if _, err := r.newRayJob(ctx, cfg, newRayCronJobRun(apiCronJob, r.clientManager.Time().Now())); err != nil {
+		return nil, err
+	}
+
+	configMap, err := util.NewRayCronJob(apiCronJob)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert cron job (%s/%s)", apiCronJob.Namespace, apiCronJob.Name)
+	}
+	newConfigMap, err := r.getKubernetesConfigMapClient(apiCronJob.Namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a cron job for (%s/%s)", apiCronJob.Namespace, apiCronJob.Name)
+	}
+	return newConfigMap, nil
+}
+
+func (r *ResourceManager) GetRayCronJob(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
+	configMap, err := r.getKubernetesConfigMapClient(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, util.NewNotFoundError(err, "Cron job %s not found", name)
+		}
+		return nil, util.Wrap(err, "Get cron job failed")
+	}
+	if configMap.Labels["ray.io/config-type"] != util.RayCronJobConfigType {
+		return nil, util.NewNotFoundError(fmt.Errorf("ConfigMap %s is not a cron job", name), "Cron job %s not found", name)
+	}
+	return configMap, nil
+}
+
+// ListRayCronJobs lists the cron jobs in a namespace, or in all namespaces for metav1.NamespaceAll.
+func (r *ResourceManager) ListRayCronJobs(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error) {
+	configMapList, err := r.getKubernetesConfigMapClient(namespace).List(ctx, metav1.ListOptions{LabelSelector: rayCronJobSelector})
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("List cron jobs failed in %s", namespace))
+	}
+
+	result := make([]*corev1.ConfigMap, 0, len(configMapList.Items))
+	for i := range configMapList.Items {
+		result = append(result, &configMapList.Items[i])
+	}
+	return result, nil
+}
+
+// DeleteRayCronJob deletes a cron job and its runs. The runs are also owned by the ConfigMap of the cron
+// job, but they are deleted explicitly so that they are gone once the call returns.
+func (r *ResourceManager) DeleteRayCronJob(ctx context.Context, name string, namespace string) error {
+	configMap, err := r.GetRayCronJob(ctx, name, namespace)
+	if err != nil {
+		return util.Wrap(err, "Get cron job failure")
+	}
+
+	runs, err := r.ListRayCronJobRuns(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		if err := r.deleteRayCronJobRun(ctx, run); err != nil {
+			return err
+		}
+	}
+
+	if err := r.getKubernetesConfigMapClient(namespace).Delete(ctx, configMap.Name, metav1.DeleteOptions{}); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete cron job %v.", name)
+	}
+	return nil
+}
+
+// ListRayCronJobRuns lists the runs of a cron job, most recent first. An empty cronJobName lists the
+// runs of all cron jobs in the namespace.
+func (r *ResourceManager) ListRayCronJobRuns(ctx context.Context, namespace string, cronJobName string) ([]*rayv1api.RayJob, error) {
+	selector := util.RayCronJobLabelKey
+	if cronJobName != "" {
+		selector += "=" + cronJobName
+	}
+	rayJobList, err := r.getRayJobClient(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("List runs of cron job %s failed in %s", cronJobName, namespace))
+	}
+
+	result := make([]*rayv1api.RayJob, 0, len(rayJobList.Items))
+	for i := range rayJobList.Items {
+		result = append(result, &rayJobList.Items[i])
+	}
+	// The scheduled times are formatted in UTC, so they sort lexicographically.
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Annotations[util.RayCronJobScheduledTimeAnnotationKey] > result[j].Annotations[util.RayCronJobScheduledTimeAnnotationKey]
+	})
+	return result, nil
+}
+
+func (r *ResourceManager) GetRayCronJobRun(ctx context.Context, cronJobName string, name string, namespace string) (*rayv1api.RayJob, error) {
+	job, err := r.GetJob(ctx, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if job.Labels[util.RayCronJobLabelKey] != cronJobName {
+		return nil, util.NewNotFoundError(fmt.Errorf("RayJob %s is not a run of cron job %s", name, cronJobName), "Run %s of cron job %s not found", name, cronJobName)
+	}
+	return job, nil
+}
+
+// StartRayCronJobScheduler syncs the cron jobs every interval until ctx is done. Runs are named after
+// the time they are scheduled for, so several API server replicas can run the scheduler at once.
+func (r *ResourceManager) StartRayCronJobScheduler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.SyncRayCronJobs(ctx, r.clientManager.Time().Now())
+			}
+		}
+	}()
+}
+
+// SyncRayCronJobs creates the run of every cron job whose schedule fired since its last run, and deletes
+// the finished runs beyond the history limits. When several activations were missed, only the most
+// recent one is run. Errors are logged, so that a broken cron job does not block the others.
+func (r *ResourceManager) SyncRayCronJobs(ctx context.Context, now time.Time) {
+	configMaps, err := r.ListRayCronJobs(ctx, metav1.NamespaceAll)
+	if err != nil {
+		klog.Errorf("Failed to list cron jobs: %v", err)
+		return
+	}
+	for _, configMap := range configMaps {
+		if err := r.syncRayCronJob(ctx, configMap, now); err != nil {
+			klog.Errorf("Failed to sync cron job %s/%s: %v", configMap.Namespace, configMap.Name, err)
+		}
+	}
+}
+
+func (r *ResourceManager) syncRayCronJob(ctx context.Context, configMap *corev1.ConfigMap, now time.Time) error {
+	cronJob, err := model.FromKubeToAPIRayCronJob(configMap, nil)
+	if err != nil {
+		return err
+	}
+	schedule, err := util.ParseCronSchedule(cronJob.Schedule)
+	if err != nil {
+		return err
+	}
+	runs, err := r.ListRayCronJobRuns(ctx, configMap.Namespace, configMap.Name)
+	if err != nil {
+		return err
+	}
+	if err := r.deleteRayCronJobHistory(ctx, cronJob, runs); err != nil {
+		return err
+	}
+
+	scheduledFrom := configMap.CreationTimestamp.Time
+	if cronJob.LastScheduleTime != nil {
+		scheduledFrom = cronJob.LastScheduleTime.AsTime()
+	}
+	if scheduledFrom.IsZero() {
+		return nil
+	}
+	var scheduledTime time.Time
+	for next := schedule.Next(scheduledFrom); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+		scheduledTime = next
+	}
+	if scheduledTime.IsZero() {
+		return nil
+	}
+
+	runName := util.RayCronJobRunName(cronJob.Name, scheduledTime)
+	var activeRuns []*rayv1api.RayJob
+	for _, run := range runs {
+		// Another API server replica may have created the run already.
+		if run.Name != runName && !util.IsRayCronJobRunFinished(run) {
+			activeRuns = append(activeRuns, run)
+		}
+	}
+	switch cronJob.ConcurrencyPolicy {
+	case api.RayCronJob_FORBID:
+		if len(activeRuns) > 0 {
+			klog.Infof("Skipping the run of cron job %s/%s scheduled at %s, %d runs are still active", cronJob.Namespace, cronJob.Name, scheduledTime, len(activeRuns))
+			return r.setRayCronJobLastScheduleTime(ctx, configMap, scheduledTime)
+		}
+	case api.RayCronJob_REPLACE:
+		for _, run := range activeRuns {
+			if err := r.deleteRayCronJobRun(ctx, run); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := r.createRayCronJobRun(ctx, configMap, cronJob, scheduledTime); err != nil {
+		return err
+	}
+	return r.setRayCronJobLastScheduleTime(ctx, configMap, scheduledTime)
+}
+
+func (r *ResourceManager) createRayCronJobRun(ctx context.Context, configMap *corev1.ConfigMap, cronJob *api.RayCronJob, scheduledTime time.Time) error {
+	cfg := config.Get()
+	if err := checkNamespaceAllowed(cfg, cronJob.Namespace); err != nil {
+		return err
+	}
+	if err := checkQuota(ctx, "jobs", cronJob.Namespace, cfg.Quotas.MaxJobsPerNamespace, r.countJobs); err != nil {
+		return err
+	}
+
+	rayJob, err := r.newRayJob(ctx, cfg, newRayCronJobRun(cronJob, scheduledTime))
+	if err != nil {
+		return err
+	}
+	if err := r.checkResourceQuota(ctx, cfg, "job", rayJob.Name, cronJob.Namespace, rayJob.Spec.RayClusterSpec); err != nil {
+		return err
+	}
+	rayJob.Labels[util.RayCronJobLabelKey] = cronJob.Name
+	// The annotations are built from the metadata of the job, which is also used in the spec.
+	annotations := map[string]string{util.RayCronJobScheduledTimeAnnotationKey: scheduledTime.UTC().Format(time.RFC3339)}
+	for key, value := range rayJob.Annotations {
+		annotations[key] = value
+	}
+	rayJob.Annotations = annotations
+	rayJob.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Name:       configMap.Name,
+		UID:        configMap.UID,
+	}}
+
+	if _, err := r.getRayJobClient(cronJob.Namespace).Create(ctx, rayJob, metav1.CreateOptions{}); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		return util.NewInternalServerError(err, "Failed to create run %s of cron job (%s/%s)", rayJob.Name, cronJob.Namespace, cronJob.Name)
+	}
+	klog.Infof("Created run %s of cron job %s/%s scheduled at %s", rayJob.Name, cronJob.Namespace, cronJob.Name, scheduledTime)
+	return nil
+}
+
+// newRayCronJobRun returns the API job of the run scheduled at the given time.
+func newRayCronJobRun(cronJob *api.RayCronJob, scheduledTime time.Time) *api.RayJob {
+	apiJob := proto.Clone(cronJob.JobTemplate).(*api.RayJob)
+	apiJob.Name = util.RayCronJobRunName(cronJob.Name, scheduledTime)
+	apiJob.Namespace = cronJob.Namespace
+	if apiJob.User == "" {
+		apiJob.User = cronJob.User
+	}
+	return apiJob
+}
+
+// deleteRayCronJobHistory deletes the oldest finished runs beyond the history limits. The runs are
+// sorted most recent first.
+func (r *ResourceManager) deleteRayCronJobHistory(ctx context.Context, cronJob *api.RayCronJob, runs []*rayv1api.RayJob) error {
+	var succeeded, failed int32
+	for _, run := range runs {
+		if !util.IsRayCronJobRunFinished(run) {
+			continue
+		}
+		if run.Status.JobStatus == rayv1api.JobStatusSucceeded {
+			succeeded++
+			if succeeded <= cronJob.SuccessfulRunsHistoryLimit {
+				continue
+			}
+		} else {
+			failed++
+			if failed <= cronJob.FailedRunsHistoryLimit {
+				continue
+			}
+		}
+		if err := r.deleteRayCronJobRun(ctx, run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ResourceManager) deleteRayCronJobRun(ctx context.Context, run *rayv1api.RayJob) error {
+	if err := r.getRayJobClient(run.Namespace).Delete(ctx, run.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return util.NewInternalServerError(err, "Failed to delete run %s of cron job %s.", run.Name, run.Labels[util.RayCronJobLabelKey])
+	}
+	return nil
+}
+
+func (r *ResourceManager) setRayCronJobLastScheduleTime(ctx context.Context, configMap *corev1.ConfigMap, scheduledTime time.Time) error {
+	configMap = configMap.DeepCopy()
+	if configMap.Annotations == nil {
+		configMap.Annotations = map[string]string{}
+	}
+	configMap.Annotations[util.RayCronJobLastScheduleTimeAnnotationKey] = scheduledTime.UTC().Format(time.RFC3339)
+	if _, err := r.getKubernetesConfigMapClient(configMap.Namespace).Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+		return util.Wrap(err, fmt.Sprintf("Failed to update the last schedule time of cron job %s/%s", configMap.Namespace, configMap.Name))
+	}
+	return nil
+}
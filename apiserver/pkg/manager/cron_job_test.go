@@ -0,0 +1,121 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func newTestRayCronJob(name string, concurrencyPolicy api.RayCronJob_ConcurrencyPolicy) *api.RayCronJob {
+	return &api.RayCronJob{
+		Name:                       name,
+		Namespace:                  "team-a",
+		User:                       "user",
+		Schedule:                   "@hourly",
+		ConcurrencyPolicy:          concurrencyPolicy,
+		SuccessfulRunsHistoryLimit: 1,
+		JobTemplate: &api.RayJob{
+			Entrypoint:      "python job.py",
+			ClusterSelector: map[string]string{"ray.io/cluster": "cluster"},
+		},
+	}
+}
+
+func TestRayCronJobRuns(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	clientManager := resourceManager.clientManager.(*FakeClientManager)
+
+	_, err := resourceManager.CreateRayCronJob(ctx, newTestRayCronJob("nightly", api.RayCronJob_ALLOW))
+	require.NoError(t, err)
+	_, err = resourceManager.CreateRayCronJob(ctx, newTestRayCronJob("nightly", api.RayCronJob_ALLOW))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.AlreadyExists))
+
+	// Nothing is due before the first activation.
+	resourceManager.SyncRayCronJobs(ctx, time.Now())
+	runs, err := resourceManager.ListRayCronJobRuns(ctx, "team-a", "nightly")
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+
+	now := time.Now().Add(time.Hour)
+	resourceManager.SyncRayCronJobs(ctx, now)
+	resourceManager.SyncRayCronJobs(ctx, now)
+	runs, err = resourceManager.ListRayCronJobRuns(ctx, "team-a", "nightly")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "nightly", runs[0].Labels[util.RayCronJobLabelKey])
+	assert.NotEmpty(t, runs[0].Annotations[util.RayCronJobScheduledTimeAnnotationKey])
+	assert.Equal(t, "python job.py", runs[0].Spec.Entrypoint)
+
+	configMap, err := resourceManager.GetRayCronJob(ctx, "nightly", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, runs[0].Annotations[util.RayCronJobScheduledTimeAnnotationKey], configMap.Annotations[util.RayCronJobLastScheduleTimeAnnotationKey])
+
+	run, err := resourceManager.GetRayCronJobRun(ctx, "nightly", runs[0].Name, "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, runs[0].Name, run.Name)
+	_, err = resourceManager.GetRayCronJobRun(ctx, "other", runs[0].Name, "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	// Only the most recent successful run is kept.
+	for i := 2; i <= 3; i++ {
+		for j := 0; j < 3; j++ {
+			clientManager.Step(ctx)
+		}
+		resourceManager.SyncRayCronJobs(ctx, time.Now().Add(time.Duration(i)*time.Hour))
+	}
+	runs, err = resourceManager.ListRayCronJobRuns(ctx, "team-a", "nightly")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.False(t, util.IsRayCronJobRunFinished(runs[0]))
+	assert.True(t, util.IsRayCronJobRunFinished(runs[1]))
+
+	require.NoError(t, resourceManager.DeleteRayCronJob(ctx, "nightly", "team-a"))
+	runs, err = resourceManager.ListRayCronJobRuns(ctx, "team-a", "nightly")
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+	_, err = resourceManager.GetRayCronJob(ctx, "nightly", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestRayCronJobConcurrencyPolicy(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+
+	_, err := resourceManager.CreateRayCronJob(ctx, newTestRayCronJob("forbid", api.RayCronJob_FORBID))
+	require.NoError(t, err)
+	_, err = resourceManager.CreateRayCronJob(ctx, newTestRayCronJob("replace", api.RayCronJob_REPLACE))
+	require.NoError(t, err)
+
+	resourceManager.SyncRayCronJobs(ctx, time.Now().Add(time.Hour))
+	forbidRuns, err := resourceManager.ListRayCronJobRuns(ctx, "team-a", "forbid")
+	require.NoError(t, err)
+	require.Len(t, forbidRuns, 1)
+	replaceRuns, err := resourceManager.ListRayCronJobRuns(ctx, "team-a", "replace")
+	require.NoError(t, err)
+	require.Len(t, replaceRuns, 1)
+
+	// The first runs are still active when the schedule fires again.
+	resourceManager.SyncRayCronJobs(ctx, time.Now().Add(2*time.Hour))
+
+	runs, err := resourceManager.ListRayCronJobRuns(ctx, "team-a", "forbid")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, forbidRuns[0].Name, runs[0].Name)
+	// The skipped activation is not run later.
+	configMap, err := resourceManager.GetRayCronJob(ctx, "forbid", "team-a")
+	require.NoError(t, err)
+	assert.NotEqual(t, forbidRuns[0].Annotations[util.RayCronJobScheduledTimeAnnotationKey], configMap.Annotations[util.RayCronJobLastScheduleTimeAnnotationKey])
+
+	runs, err = resourceManager.ListRayCronJobRuns(ctx, "team-a", "replace")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.NotEqual(t, replaceRuns[0].Name, runs[0].Name)
+}
@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// syncPodDisruptionBudgets creates or updates the pod disruption budgets of the groups of a RayCluster or a RayService
+// which have one, and deletes the ones of the groups which no longer have one. The budgets are owned by the cluster or
+// the service, so Kubernetes deletes them with it. A budget with the same name which is not managed by the apiserver
+// is left untouched.
+func (r *ResourceManager) syncPodDisruptionBudgets(ctx context.Context, owner metav1.Object, ownerKind string, spec *rayv1api.RayClusterSpec) error {
+	budgets, err := util.NewPodDisruptionBudgets(owner, ownerKind, spec)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to read the pod disruption budgets of %s %s", ownerKind, owner.GetName())
+	}
+	client := r.clientManager.KubernetesClient().PodDisruptionBudgetClient(owner.GetNamespace())
+	wanted := map[string]bool{}
+	for _, budget := range budgets {
+		wanted[budget.Name] = true
+		existing, err := client.Get(ctx, budget.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			if _, err := client.Create(ctx, budget, metav1.CreateOptions{}); err != nil {
+				return util.NewInternalServerError(err, "Failed to create pod disruption budget %s of %s %s", budget.Name, ownerKind, owner.GetName())
+			}
+			continue
+		}
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to get pod disruption budget %s of %s %s", budget.Name, ownerKind, owner.GetName())
+		}
+		if !isManagedByAPIServer(existing.Labels) {
+			return util.NewFailedPreconditionError("PodDisruptionBudget %s already exists and is not managed by %s", budget.Name, util.ComponentName)
+		}
+		budget.ResourceVersion = existing.ResourceVersion
+		if _, err := client.Update(ctx, budget, metav1.UpdateOptions{}); err != nil {
+			return util.NewInternalServerError(err, "Failed to update pod disruption budget %s of %s %s", budget.Name, ownerKind, owner.GetName())
+		}
+	}
+
+	selector := labels.SelectorFromSet(map[string]string{
+		util.KubernetesManagedByLabelKey:    util.ComponentName,
+		util.RayPodDisruptionBudgetLabelKey: owner.GetName(),
+	})
+	existing, err := client.List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to list the pod disruption budgets of %s %s", ownerKind, owner.GetName())
+	}
+	for _, budget := range existing.Items {
+		if wanted[budget.Name] {
+			continue
+		}
+		if err := client.Delete(ctx, budget.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return util.NewInternalServerError(err, "Failed to delete pod disruption budget %s of %s %s", budget.Name, ownerKind, owner.GetName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestPodDisruptionBudgets(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: 1, Memory: 2})
+	require.NoError(t, err)
+	budgetClient := clientManager.clients.Kubernetes.PolicyV1().PodDisruptionBudgets("team-a")
+
+	cluster, err := resourceManager.CreateCluster(ctx, &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		User:      "user",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate:     "template",
+				RayStartParams:      map[string]string{"dashboard-host": "0.0.0.0"},
+				RestartPolicy:       "Never",
+				PodDisruptionBudget: &api.PodDisruptionBudgetOptions{},
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{
+					GroupName:       "small",
+					ComputeTemplate: "template",
+					Replicas:        2,
+					MaxReplicas:     2,
+					TopologySpreadConstraints: []*api.TopologySpreadConstraint{
+						{TopologyKey: "topology.kubernetes.io/zone"},
+					},
+				},
+			},
+		},
+	}, false, "")
+	require.NoError(t, err)
+	budget, err := budgetClient.Get(ctx, "cluster-headgroup-pdb", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "RayCluster", budget.OwnerReferences[0].Kind)
+	assert.Equal(t, "1", budget.Spec.MinAvailable.String())
+	budgets, err := budgetClient.List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, budgets.Items, 1)
+
+	apiCluster := model.FromCrdToApiCluster(cluster, nil)
+	assert.Equal(t, "Never", apiCluster.ClusterSpec.HeadGroupSpec.RestartPolicy)
+	assert.NotNil(t, apiCluster.ClusterSpec.HeadGroupSpec.PodDisruptionBudget)
+	assert.NotContains(t, apiCluster.ClusterSpec.HeadGroupSpec.Labels, util.RayPodDisruptionBudgetLabelKey)
+	assert.Empty(t, apiCluster.ClusterSpec.HeadGroupSpec.Annotations)
+	constraints := apiCluster.ClusterSpec.WorkerGroupSpec[0].TopologySpreadConstraints
+	require.Len(t, constraints, 1)
+	assert.Equal(t, "topology.kubernetes.io/zone", constraints[0].TopologyKey)
+	assert.Equal(t, int32(1), constraints[0].MaxSkew)
+	assert.Equal(t, "DoNotSchedule", constraints[0].WhenUnsatisfiable)
+
+	// The budget of a worker group is removed with the options of the group.
+	newService := func(options *api.PodDisruptionBudgetOptions) *api.RayService {
+		return &api.RayService{
+			Name:           "service",
+			Namespace:      "team-a",
+			User:           "user",
+			Version:        "2.9.0",
+			ServeConfig_V2: "applications: []",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{ComputeTemplate: "template", RayStartParams: map[string]string{"dashboard-host": "0.0.0.0"}},
+				WorkerGroupSpec: []*api.WorkerGroupSpec{
+					{GroupName: "small", ComputeTemplate: "template", Replicas: 4, MaxReplicas: 4, PodDisruptionBudget: options},
+				},
+			},
+		}
+	}
+	_, err = resourceManager.CreateService(ctx, newService(&api.PodDisruptionBudgetOptions{MaxUnavailable: "25%"}), false, "")
+	require.NoError(t, err)
+	budget, err = budgetClient.Get(ctx, "service-small-pdb", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "25%", budget.Spec.MaxUnavailable.String())
+	assert.Equal(t, "service", budget.Spec.Selector.MatchLabels[util.RayPodDisruptionBudgetLabelKey])
+
+	_, err = resourceManager.UpdateRayService(ctx, newService(nil))
+	require.NoError(t, err)
+	budgets, err = budgetClient.List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, budgets.Items, 1)
+	assert.Equal(t, "cluster-headgroup-pdb", budgets.Items[0].Name)
+
+	// A budget which is not managed by the apiserver is not replaced.
+	_, err = budgetClient.Create(ctx, &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "service-small-pdb", Namespace: "team-a"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = resourceManager.UpdateRayService(ctx, newService(&api.PodDisruptionBudgetOptions{}))
+	require.Error(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+}
@@ -0,0 +1,163 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// GetClusterEndpoints returns the endpoints of a RayCluster, resolved from the head and serve Services and the
+// Ingress the operator creates for it.
+func (r *ResourceManager) GetClusterEndpoints(ctx context.Context, clusterName string, namespace string) (*api.RayEndpoints, error) {
+	cluster, err := getClusterByName(ctx, r.getRayClusterClient(namespace), clusterName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+	headServiceName, err := utils.GenerateHeadServiceName(utils.RayClusterCRD, cluster.Spec, cluster.Name)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the head service name of cluster %s", clusterName)
+	}
+	return r.getEndpoints(ctx, clusterName, namespace, headServiceName, utils.GenerateServeServiceName(cluster.Name), cluster.Name)
+}
+
+// GetServiceEndpoints returns the endpoints of a RayService, resolved from the head and serve Services the operator
+// creates for the RayService, which select the active RayCluster, the Ingress of the active RayCluster and the expose
+// options of the RayService.
+func (r *ResourceManager) GetServiceEndpoints(ctx context.Context, serviceName string, namespace string) (*api.RayEndpoints, error) {
+	service, err := getServiceByName(ctx, r.getRayServiceClient(namespace), serviceName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get service failure")
+	}
+	headServiceName, err := utils.GenerateHeadServiceName(utils.RayServiceCRD, service.Spec.RayClusterSpec, service.Name)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the head service name of service %s", serviceName)
+	}
+	endpoints, err := r.getEndpoints(ctx, serviceName, namespace, headServiceName, util.ServeServiceName(service), service.Status.ActiveServiceStatus.RayClusterName)
+	if err != nil {
+		return nil, err
+	}
+	expose, err := util.ExposeOptionsFromAnnotations(service.Annotations)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to read the expose options of service %s", serviceName)
+	}
+	if expose != nil && endpoints.Serve != nil {
+		endpoints.Serve.IngressUrl = util.ExposeURL(expose)
+	}
+	return endpoints, nil
+}
+
+// getEndpoints resolves the endpoints exposed by a head Service, a serve Service and the Ingress of a RayCluster. The
+// serve endpoint falls back to the serve port of the head Service when there is no serve Service.
+func (r *ResourceManager) getEndpoints(ctx context.Context, name string, namespace string, headServiceName string, serveServiceName string, clusterName string) (*api.RayEndpoints, error) {
+	serviceClient := r.getKubernetesServiceClient(namespace)
+	headService, err := serviceClient.Get(ctx, headServiceName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, util.NewFailedPreconditionError("The head service %s of %s is not created yet", headServiceName, name)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to get the head service %s of %s", headServiceName, name)
+	}
+	endpoints := &api.RayEndpoints{
+		Name:      name,
+		Namespace: namespace,
+		Client:    serviceEndpoint(headService, utils.ClientPortName),
+		Dashboard: serviceEndpoint(headService, utils.DashboardPortName),
+		Serve:     serviceEndpoint(headService, utils.ServingPortName),
+	}
+
+	serveService, err := serviceClient.Get(ctx, serveServiceName, metav1.GetOptions{})
+	if err == nil {
+		if serve := serviceEndpoint(serveService, utils.ServingPortName); serve != nil {
+			endpoints.Serve = serve
+		}
+	} else if !errors.IsNotFound(err) {
+		return nil, util.NewInternalServerError(err, "Failed to get the serve service %s of %s", serveServiceName, name)
+	}
+
+	if endpoints.Dashboard != nil && clusterName != "" {
+		ingressURL, err := r.getIngressURL(ctx, namespace, clusterName)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to get the ingress of %s", name)
+		}
+		endpoints.Dashboard.IngressUrl = ingressURL
+	}
+	return endpoints, nil
+}
+
+// serviceEndpoint returns the endpoint of the port of a Service with the given name, nil if the Service has no such
+// port.
+func serviceEndpoint(service *corev1.Service, portName string) *api.RayEndpoint {
+	for _, port := range service.Spec.Ports {
+		if port.Name != portName {
+			continue
+		}
+		endpoint := &api.RayEndpoint{
+			ServiceName: service.Name,
+			ServiceType: string(service.Spec.Type),
+			Port:        port.Port,
+			ClusterAddress: net.JoinHostPort(
+				fmt.Sprintf("%s.%s.svc.%s", service.Name, service.Namespace, utils.GetClusterDomainName()),
+				strconv.Itoa(int(port.Port))),
+		}
+		if service.Spec.Type == corev1.ServiceTypeNodePort || service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			endpoint.NodePort = port.NodePort
+		}
+		if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			for _, ingress := range service.Status.LoadBalancer.Ingress {
+				host := ingress.IP
+				if host == "" {
+					host = ingress.Hostname
+				}
+				if host != "" {
+					endpoint.LoadBalancerAddress = net.JoinHostPort(host, strconv.Itoa(int(port.Port)))
+					break
+				}
+			}
+		}
+		return endpoint
+	}
+	return nil
+}
+
+// getIngressURL returns the URL of the dashboard through the Ingress the operator creates for a RayCluster with
+// enableIngress, empty if the RayCluster has no Ingress or the Ingress has no host yet.
+func (r *ResourceManager) getIngressURL(ctx context.Context, namespace string, clusterName string) (string, error) {
+	ingress, err := r.clientManager.KubernetesClient().IngressClient(namespace).Get(ctx, utils.GenerateIngressName(clusterName), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	host := ""
+	if len(ingress.Spec.Rules) > 0 {
+		host = ingress.Spec.Rules[0].Host
+	}
+	if host == "" {
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			if host = lb.IP; host == "" {
+				host = lb.Hostname
+			}
+			if host != "" {
+				break
+			}
+		}
+	}
+	if host == "" {
+		return "", nil
+	}
+	scheme := "http"
+	if len(ingress.Spec.TLS) > 0 {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/%s/", scheme, host, clusterName), nil
+}
@@ -0,0 +1,143 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestGetClusterEndpoints(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	managedLabels := map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName}
+	_, err := clientManager.clients.Ray.RayV1().RayClusters("team-a").Create(ctx, &rayv1api.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "team-a", Labels: managedLabels},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = resourceManager.GetClusterEndpoints(ctx, "cluster", "team-a")
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "The head service is not created yet")
+
+	serviceClient := clientManager.clients.Kubernetes.CoreV1().Services("team-a")
+	_, err = serviceClient.Create(ctx, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-head-svc", Namespace: "team-a"},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{
+				{Name: "client", Port: 10001, NodePort: 30001},
+				{Name: "dashboard", Port: 8265, NodePort: 30265},
+			},
+		},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}},
+		}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	endpoints, err := resourceManager.GetClusterEndpoints(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "cluster-head-svc", endpoints.Client.ServiceName)
+	assert.Equal(t, "LoadBalancer", endpoints.Client.ServiceType)
+	assert.Equal(t, "cluster-head-svc.team-a.svc.cluster.local:10001", endpoints.Client.ClusterAddress)
+	assert.Equal(t, int32(30001), endpoints.Client.NodePort)
+	assert.Equal(t, "lb.example.com:10001", endpoints.Client.LoadBalancerAddress)
+	assert.Equal(t, int32(8265), endpoints.Dashboard.Port)
+	assert.Empty(t, endpoints.Dashboard.IngressUrl)
+	assert.Nil(t, endpoints.Serve, "The cluster exposes no serve port")
+
+	_, err = serviceClient.Create(ctx, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-serve-svc", Namespace: "team-a"},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{Name: "serve", Port: 8000}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientManager.clients.Kubernetes.NetworkingV1().Ingresses("team-a").Create(ctx, &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-head-ingress", Namespace: "team-a"},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{Host: "ray.example.com"}},
+			TLS:   []networkingv1.IngressTLS{{Hosts: []string{"ray.example.com"}}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	endpoints, err = resourceManager.GetClusterEndpoints(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ray.example.com/cluster/", endpoints.Dashboard.IngressUrl)
+	require.NotNil(t, endpoints.Serve)
+	assert.Equal(t, "cluster-serve-svc.team-a.svc.cluster.local:8000", endpoints.Serve.ClusterAddress)
+	assert.Zero(t, endpoints.Serve.NodePort)
+}
+
+func TestGetServiceEndpoints(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	serviceClient := clientManager.clients.Ray.RayV1().RayServices("team-a")
+	_, err := serviceClient.Create(ctx, &rayv1api.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "service",
+			Namespace: "team-a",
+			Labels:    map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName},
+		},
+		Status: rayv1api.RayServiceStatuses{
+			ActiveServiceStatus: rayv1api.RayServiceStatus{RayClusterName: "service-raycluster-abcde"},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	kubernetesServices := clientManager.clients.Kubernetes.CoreV1().Services("team-a")
+	_, err = kubernetesServices.Create(ctx, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-head-svc", Namespace: "team-a"},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{Name: "client", Port: 10001},
+				{Name: "dashboard", Port: 8265},
+				{Name: "serve", Port: 8000},
+			},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = clientManager.clients.Kubernetes.NetworkingV1().Ingresses("team-a").Create(ctx, &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-raycluster-abcde-head-ingress", Namespace: "team-a"},
+		Status: networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+			Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "203.0.113.10"}},
+		}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// The serve endpoint falls back to the head service until the serve service is created.
+	endpoints, err := resourceManager.GetServiceEndpoints(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "service-head-svc.team-a.svc.cluster.local:10001", endpoints.Client.ClusterAddress)
+	assert.Equal(t, "service-head-svc", endpoints.Serve.ServiceName)
+	assert.Equal(t, "http://203.0.113.10/service-raycluster-abcde/", endpoints.Dashboard.IngressUrl)
+
+	_, err = kubernetesServices.Create(ctx, &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-serve-svc", Namespace: "team-a"},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{{Name: "serve", Port: 8000, NodePort: 30800}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	endpoints, err = resourceManager.GetServiceEndpoints(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "service-serve-svc", endpoints.Serve.ServiceName)
+	assert.Equal(t, int32(30800), endpoints.Serve.NodePort)
+}
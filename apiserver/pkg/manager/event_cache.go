@@ -0,0 +1,218 @@
+package manager
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+)
+
+// The kinds of involved objects whose events are kept in the EventCache.
+var eventCacheKinds = map[string]bool{
+	"RayCluster": true,
+	"RayJob":     true,
+	"RayService": true,
+}
+
+// EventCache keeps the events of Ray resources in memory, so that Get and List calls can attach
+// events without listing them from Kubernetes for every resource. An Event informer enqueues the
+// changed events and a pool of workers applies them to a per resource cache.
+type EventCache struct {
+	informer cache.SharedIndexInformer
+	queue    workqueue.Interface
+	synced   atomic.Bool
+
+	lock sync.RWMutex
+	// The cached events by involved object key, then by event key.
+	events map[string]map[string]*corev1.Event
+	// The involved object key of every cached event, so deleted events can be dropped.
+	owners map[string]string
+}
+
+// NewEventCache creates an EventCache watching the events of all namespaces. The informer resyncs
+// with the given period, zero disables the resync. The cache is empty until Run is called.
+func NewEventCache(kubernetesClient client.KubernetesClientInterface, resyncPeriod time.Duration) *EventCache {
+	eventsClient := kubernetesClient.EventsClient(metav1.NamespaceAll)
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return eventsClient.List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return eventsClient.Watch(context.Background(), options)
+		},
+	}
+	return &EventCache{
+		informer: cache.NewSharedIndexInformer(listWatch, &corev1.Event{}, resyncPeriod, cache.Indexers{}),
+		queue:    workqueue.New(),
+		events:   make(map[string]map[string]*corev1.Event),
+		owners:   make(map[string]string),
+	}
+}
+
+// Run starts the informer and the given number of workers, and blocks until ctx is done.
+func (c *EventCache) Run(ctx context.Context, workers int) {
+	defer c.queue.ShutDown()
+
+	registration, err := c.informer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: isCachedEvent,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.enqueue,
+			UpdateFunc: func(_, newObj interface{}) { c.enqueue(newObj) },
+			DeleteFunc: c.enqueue,
+		},
+	})
+	if err != nil {
+		klog.Errorf("Failed to register the event cache handler: %v", err)
+		return
+	}
+	go c.informer.Run(ctx.Done())
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.runWorker, time.Second)
+	}
+
+	if !cache.WaitForCacheSync(ctx.Done(), registration.HasSynced) {
+		klog.Error("Timed out waiting for the event cache to sync")
+		return
+	}
+	// Events are only served from memory once the initial list has been applied.
+	err = wait.PollUntilContextCancel(ctx, 100*time.Millisecond, true, func(context.Context) (bool, error) {
+		return c.queue.Len() == 0, nil
+	})
+	if err != nil {
+		return
+	}
+	c.synced.Store(true)
+	klog.Infof("Event cache synced with %d workers", workers)
+
+	<-ctx.Done()
+}
+
+// HasSynced returns whether the initial list of events has been applied to the cache.
+func (c *EventCache) HasSynced() bool {
+	return c.synced.Load()
+}
+
+// Events returns the cached events of the given Ray resource sorted by name. The second return
+// value is false when the cache has not synced yet and the events need to be listed instead.
+func (c *EventCache) Events(kind string, namespace string, name string) ([]corev1.Event, bool) {
+	if !c.HasSynced() {
+		return nil, false
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	cached := c.events[involvedObjectKey(kind, namespace, name)]
+	events := make([]corev1.Event, 0, len(cached))
+	for _, event := range cached {
+		events = append(events, *event.DeepCopy())
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Name < events[j].Name
+	})
+	return events, true
+}
+
+// NamespaceEvents returns the cached events of the Ray resources of the given kinds in a namespace. The
+// second return value is false when the cache has not synced yet and the events need to be listed instead.
+func (c *EventCache) NamespaceEvents(namespace string, kinds []string) ([]corev1.Event, bool) {
+	if !c.HasSynced() {
+		return nil, false
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	events := []corev1.Event{}
+	for _, kind := range kinds {
+		prefix := involvedObjectKey(kind, namespace, "")
+		for owner, cached := range c.events {
+			if !strings.HasPrefix(owner, prefix) {
+				continue
+			}
+			for _, event := range cached {
+				events = append(events, *event.DeepCopy())
+			}
+		}
+	}
+	return events, true
+}
+
+func (c *EventCache) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Failed to get the key of event %v: %v", obj, err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *EventCache) runWorker(ctx context.Context) {
+	for c.processNextItem() {
+	}
+}
+
+func (c *EventCache) processNextItem() bool {
+	item, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(item)
+
+	c.sync(item.(string))
+	return true
+}
+
+// sync applies the current state of the event with the given key to the cache.
+func (c *EventCache) sync(key string) {
+	obj, exists, err := c.informer.GetIndexer().GetByKey(key)
+	if err != nil {
+		klog.Errorf("Failed to get event %s from the informer: %v", key, err)
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if owner, ok := c.owners[key]; ok {
+		delete(c.events[owner], key)
+		if len(c.events[owner]) == 0 {
+			delete(c.events, owner)
+		}
+		delete(c.owners, key)
+	}
+	if !exists {
+		return
+	}
+
+	event := obj.(*corev1.Event)
+	owner := involvedObjectKey(event.InvolvedObject.Kind, event.Namespace, event.InvolvedObject.Name)
+	if c.events[owner] == nil {
+		c.events[owner] = make(map[string]*corev1.Event)
+	}
+	c.events[owner][key] = event
+	c.owners[key] = owner
+}
+
+func isCachedEvent(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	event, ok := obj.(*corev1.Event)
+	return ok && eventCacheKinds[event.InvolvedObject.Kind]
+}
+
+func involvedObjectKey(kind string, namespace string, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
@@ -0,0 +1,72 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+)
+
+func newTestEvent(name string, kind string, involvedObjectName string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "team-a",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      kind,
+			Namespace: "team-a",
+			Name:      involvedObjectName,
+		},
+		Reason: "Created",
+	}
+}
+
+func TestEventCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kubernetesClient := client.NewFakeClients("team-a").KubernetesClient()
+	eventsClient := kubernetesClient.EventsClient("team-a")
+	for _, event := range []*corev1.Event{
+		newTestEvent("cluster.2", "RayCluster", "cluster"),
+		newTestEvent("cluster.1", "RayCluster", "cluster"),
+		newTestEvent("pod.1", "Pod", "cluster"),
+	} {
+		_, err := eventsClient.Create(ctx, event, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	eventCache := NewEventCache(kubernetesClient, 0)
+	_, ok := eventCache.Events("RayCluster", "team-a", "cluster")
+	assert.False(t, ok, "Events are not served before the cache has synced")
+
+	go eventCache.Run(ctx, 2)
+	require.Eventually(t, eventCache.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	events, ok := eventCache.Events("RayCluster", "team-a", "cluster")
+	require.True(t, ok)
+	require.Len(t, events, 2)
+	assert.Equal(t, "cluster.1", events[0].Name)
+	assert.Equal(t, "cluster.2", events[1].Name)
+
+	// Events of other kinds are not cached.
+	events, ok = eventCache.Events("Pod", "team-a", "cluster")
+	require.True(t, ok)
+	assert.Empty(t, events)
+
+	_, err := eventsClient.Create(ctx, newTestEvent("service.1", "RayService", "service"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, eventsClient.Delete(ctx, "cluster.1", metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		clusterEvents, _ := eventCache.Events("RayCluster", "team-a", "cluster")
+		serviceEvents, _ := eventCache.Events("RayService", "team-a", "service")
+		return len(clusterEvents) == 1 && len(serviceEvents) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
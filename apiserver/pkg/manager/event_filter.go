@@ -0,0 +1,58 @@
+package manager
+
+import (
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EventFilter narrows down the events returned with a Ray resource. The zero value keeps every event, in the order
+// they were found.
+type EventFilter struct {
+	// Type keeps the events of this type, Normal or Warning, if set.
+	Type string
+	// Since keeps the events which last occurred at or after this time, if set.
+	Since time.Time
+	// Limit keeps the most recent events, if positive.
+	Limit int
+}
+
+// IsEmpty returns whether the filter keeps every event.
+func (f EventFilter) IsEmpty() bool {
+	return f.Type == "" && f.Since.IsZero() && f.Limit <= 0
+}
+
+// Apply returns the events matching the filter, most recent first.
+func (f EventFilter) Apply(events []corev1.Event) []corev1.Event {
+	if f.IsEmpty() {
+		return events
+	}
+	filtered := make([]corev1.Event, 0, len(events))
+	for _, event := range events {
+		if f.Type != "" && event.Type != f.Type {
+			continue
+		}
+		if !f.Since.IsZero() && lastEventTime(event).Before(f.Since) {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return lastEventTime(filtered[i]).After(lastEventTime(filtered[j]))
+	})
+	if f.Limit > 0 && len(filtered) > f.Limit {
+		filtered = filtered[:f.Limit]
+	}
+	return filtered
+}
+
+// ApplyToMap filters the events of every resource of the map in place.
+func (f EventFilter) ApplyToMap(eventMap map[string][]corev1.Event) {
+	if f.IsEmpty() {
+		return
+	}
+	for name, events := range eventMap {
+		eventMap[name] = f.Apply(events)
+	}
+}
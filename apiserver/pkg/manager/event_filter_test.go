@@ -0,0 +1,75 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEventFilter(t *testing.T) {
+	now := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	newEvent := func(name string, eventType string, lastTime time.Time) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:    metav1.ObjectMeta{Name: name},
+			Type:          eventType,
+			LastTimestamp: metav1.NewTime(lastTime),
+		}
+	}
+	events := []corev1.Event{
+		newEvent("created", corev1.EventTypeNormal, now.Add(-time.Hour)),
+		newEvent("failed", corev1.EventTypeWarning, now.Add(-30*time.Minute)),
+		newEvent("old-failure", corev1.EventTypeWarning, now.Add(-2*time.Hour)),
+		newEvent("deleted", corev1.EventTypeNormal, now),
+	}
+	names := func(events []corev1.Event) []string {
+		result := []string{}
+		for _, event := range events {
+			result = append(result, event.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		filter   EventFilter
+		expected []string
+	}{
+		{
+			name:     "The empty filter keeps the events in order",
+			filter:   EventFilter{},
+			expected: []string{"created", "failed", "old-failure", "deleted"},
+		},
+		{
+			name:     "Only the Warning events, most recent first",
+			filter:   EventFilter{Type: corev1.EventTypeWarning},
+			expected: []string{"failed", "old-failure"},
+		},
+		{
+			name:     "The events of the last hour",
+			filter:   EventFilter{Since: now.Add(-time.Hour)},
+			expected: []string{"deleted", "failed", "created"},
+		},
+		{
+			name:     "The most recent events",
+			filter:   EventFilter{Limit: 2},
+			expected: []string{"deleted", "failed"},
+		},
+		{
+			name:     "The most recent Warning event of the last hour",
+			filter:   EventFilter{Type: corev1.EventTypeWarning, Since: now.Add(-time.Hour), Limit: 1},
+			expected: []string{"failed"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, names(tc.filter.Apply(events)))
+		})
+	}
+
+	eventMap := map[string][]corev1.Event{"cluster": events}
+	EventFilter{Type: corev1.EventTypeWarning, Limit: 1}.ApplyToMap(eventMap)
+	assert.Equal(t, []string{"failed"}, names(eventMap["cluster"]))
+}
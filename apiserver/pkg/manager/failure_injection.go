@@ -0,0 +1,194 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// failurePartitionLabelKey marks the worker Pods which are cut off the network by a partition failure.
+const failurePartitionLabelKey = "ray.io/failure-partition"
+
+// InjectClusterFailure injects a failure into the Pods of a cluster and returns the names of the affected Pods.
+// Pods are killed without a grace period, like on a node failure, and the operator recreates them as usual.
+func (r *ResourceManager) InjectClusterFailure(ctx context.Context, request *api.InjectClusterFailureRequest) ([]string, error) {
+	cluster, err := getClusterByName(ctx, r.getRayClusterClient(request.Namespace), request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+
+	switch request.Type {
+	case api.InjectClusterFailureRequest_KILL_HEAD:
+		return r.killPods(ctx, cluster, rayv1api.HeadNode, "", 1)
+	case api.InjectClusterFailureRequest_KILL_WORKERS:
+		count := int(request.Count)
+		if count == 0 {
+			count = 1
+		}
+		return r.killPods(ctx, cluster, rayv1api.WorkerNode, request.GroupName, count)
+	case api.InjectClusterFailureRequest_PARTITION_WORKER:
+		return r.partitionWorkerPod(ctx, cluster, request.GroupName)
+	default:
+		return nil, util.NewInvalidInputError("Failure type %s is not supported.", request.Type)
+	}
+}
+
+// HealClusterPartitions removes the network partitions injected into a cluster and returns the names of the Pods
+// which were partitioned.
+func (r *ResourceManager) HealClusterPartitions(ctx context.Context, clusterName string, namespace string) ([]string, error) {
+	cluster, err := getClusterByName(ctx, r.getRayClusterClient(namespace), clusterName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+
+	podClient := r.clientManager.KubernetesClient().PodClient(namespace)
+	pods, err := podClient.List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(partitionedPodLabels(cluster)).String(),
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the partitioned Pods of cluster %s", clusterName)
+	}
+
+	policyName := partitionNetworkPolicyName(cluster)
+	err = r.clientManager.KubernetesClient().NetworkPolicyClient(namespace).Delete(ctx, policyName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, util.NewInternalServerError(err, "Failed to delete network policy %s of cluster %s", policyName, clusterName)
+	}
+
+	patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:null}}}`, failurePartitionLabelKey))
+	names := []string{}
+	for _, pod := range pods.Items {
+		if _, err := podClient.Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil && !errors.IsNotFound(err) {
+			return names, util.NewInternalServerError(err, "Failed to remove the partition label of Pod %s", pod.Name)
+		}
+		names = append(names, pod.Name)
+	}
+	return names, nil
+}
+
+// killPods deletes up to count random Pods of the node type, and of the worker group if set.
+func (r *ResourceManager) killPods(ctx context.Context, cluster *rayv1api.RayCluster, nodeType rayv1api.RayNodeType, groupName string, count int) ([]string, error) {
+	pods, err := r.listLivePods(ctx, cluster, nodeType, groupName)
+	if err != nil {
+		return nil, err
+	}
+	rand.Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
+	if len(pods) > count {
+		pods = pods[:count]
+	}
+
+	podClient := r.clientManager.KubernetesClient().PodClient(cluster.Namespace)
+	gracePeriodSeconds := int64(0)
+	names := []string{}
+	for _, pod := range pods {
+		if err := podClient.Delete(ctx, pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriodSeconds}); err != nil && !errors.IsNotFound(err) {
+			return names, util.NewInternalServerError(err, "Failed to kill Pod %s of cluster %s", pod.Name, cluster.Name)
+		}
+		names = append(names, pod.Name)
+	}
+	return names, nil
+}
+
+// partitionWorkerPod cuts a random worker Pod off the network. The Pod is selected by a NetworkPolicy which denies
+// all its ingress and egress traffic, so the network plugin of the Kubernetes cluster must enforce NetworkPolicies.
+func (r *ResourceManager) partitionWorkerPod(ctx context.Context, cluster *rayv1api.RayCluster, groupName string) ([]string, error) {
+	pods, err := r.listLivePods(ctx, cluster, rayv1api.WorkerNode, groupName)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []corev1.Pod{}
+	for _, pod := range pods {
+		if _, ok := pod.Labels[failurePartitionLabelKey]; !ok {
+			candidates = append(candidates, pod)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, util.NewFailedPreconditionError("All the worker Pods of cluster %s are already partitioned", cluster.Name)
+	}
+	pod := candidates[rand.IntN(len(candidates))]
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      partitionNetworkPolicyName(cluster),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:         cluster.Name,
+				util.KubernetesManagedByLabelKey: util.ComponentName,
+			},
+			// The NetworkPolicy is garbage collected with the cluster, if it is not healed before.
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: rayv1api.GroupVersion.String(),
+				Kind:       "RayCluster",
+				Name:       cluster.Name,
+				UID:        cluster.UID,
+			}},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: partitionedPodLabels(cluster)},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+	}
+	if _, err := r.clientManager.KubernetesClient().NetworkPolicyClient(cluster.Namespace).Create(ctx, policy, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, util.NewInternalServerError(err, "Failed to create network policy %s of cluster %s", policy.Name, cluster.Name)
+	}
+
+	patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:"true"}}}`, failurePartitionLabelKey))
+	if _, err := r.clientManager.KubernetesClient().PodClient(cluster.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to partition Pod %s of cluster %s", pod.Name, cluster.Name)
+	}
+	return []string{pod.Name}, nil
+}
+
+// listLivePods lists the Pods of the node type, and of the worker group if set, which are not being deleted.
+func (r *ResourceManager) listLivePods(ctx context.Context, cluster *rayv1api.RayCluster, nodeType rayv1api.RayNodeType, groupName string) ([]corev1.Pod, error) {
+	selector := map[string]string{
+		utils.RayClusterLabelKey:  cluster.Name,
+		utils.RayNodeTypeLabelKey: string(nodeType),
+	}
+	if groupName != "" {
+		selector[utils.RayNodeGroupLabelKey] = groupName
+	}
+	pods, err := r.clientManager.KubernetesClient().PodClient(cluster.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the Pods of cluster %s", cluster.Name)
+	}
+
+	live := []corev1.Pod{}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil {
+			live = append(live, pod)
+		}
+	}
+	if len(live) == 0 {
+		if groupName != "" {
+			return nil, util.NewFailedPreconditionError("Worker group %s of cluster %s has no running Pods", groupName, cluster.Name)
+		}
+		return nil, util.NewFailedPreconditionError("Cluster %s has no running %s Pods", cluster.Name, nodeType)
+	}
+	return live, nil
+}
+
+func partitionedPodLabels(cluster *rayv1api.RayCluster) map[string]string {
+	return map[string]string{
+		utils.RayClusterLabelKey: cluster.Name,
+		failurePartitionLabelKey: "true",
+	}
+}
+
+func partitionNetworkPolicyName(cluster *rayv1api.RayCluster) string {
+	return utils.CheckName(cluster.Name + "-failure-partition")
+}
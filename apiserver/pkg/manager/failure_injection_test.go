@@ -0,0 +1,176 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func setupFailureInjectionCluster(ctx context.Context, t *testing.T, resourceManager *ResourceManager) {
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	_, err = resourceManager.CreateCluster(ctx, &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		User:      "user",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "template",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{GroupName: "small", ComputeTemplate: "template", Replicas: 3, MinReplicas: 1, MaxReplicas: 5},
+			},
+		},
+	}, false, "")
+	require.NoError(t, err)
+
+	podClient := resourceManager.clientManager.KubernetesClient().PodClient("team-a")
+	pods := map[string]rayv1api.RayNodeType{
+		"cluster-head":           rayv1api.HeadNode,
+		"cluster-small-worker-1": rayv1api.WorkerNode,
+		"cluster-small-worker-2": rayv1api.WorkerNode,
+		"cluster-small-worker-3": rayv1api.WorkerNode,
+	}
+	for name, nodeType := range pods {
+		podLabels := map[string]string{
+			utils.RayClusterLabelKey:  "cluster",
+			utils.RayNodeTypeLabelKey: string(nodeType),
+		}
+		if nodeType == rayv1api.WorkerNode {
+			podLabels[utils.RayNodeGroupLabelKey] = "small"
+		}
+		_, err := podClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Labels: podLabels},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+}
+
+func TestInjectClusterFailureKillPods(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	setupFailureInjectionCluster(ctx, t, resourceManager)
+	podClient := resourceManager.clientManager.KubernetesClient().PodClient("team-a")
+
+	pods, err := resourceManager.InjectClusterFailure(ctx, &api.InjectClusterFailureRequest{
+		Name:      "cluster",
+		Namespace: "team-a",
+		Type:      api.InjectClusterFailureRequest_KILL_HEAD,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cluster-head"}, pods)
+
+	pods, err = resourceManager.InjectClusterFailure(ctx, &api.InjectClusterFailureRequest{
+		Name:      "cluster",
+		Namespace: "team-a",
+		Type:      api.InjectClusterFailureRequest_KILL_WORKERS,
+		Count:     2,
+	})
+	require.NoError(t, err)
+	assert.Len(t, pods, 2)
+	remaining, err := podClient.List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, remaining.Items, 1)
+	assert.NotContains(t, pods, remaining.Items[0].Name)
+
+	// More workers than running are requested.
+	pods, err = resourceManager.InjectClusterFailure(ctx, &api.InjectClusterFailureRequest{
+		Name:      "cluster",
+		Namespace: "team-a",
+		Type:      api.InjectClusterFailureRequest_KILL_WORKERS,
+		Count:     5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{remaining.Items[0].Name}, pods)
+
+	// The head Pod was already killed and is not recreated without the operator.
+	_, err = resourceManager.InjectClusterFailure(ctx, &api.InjectClusterFailureRequest{
+		Name:      "cluster",
+		Namespace: "team-a",
+		Type:      api.InjectClusterFailureRequest_KILL_HEAD,
+	})
+	require.Error(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+
+	_, err = resourceManager.InjectClusterFailure(ctx, &api.InjectClusterFailureRequest{
+		Name:      "missing",
+		Namespace: "team-a",
+		Type:      api.InjectClusterFailureRequest_KILL_HEAD,
+	})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestInjectClusterFailurePartitionWorker(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	setupFailureInjectionCluster(ctx, t, resourceManager)
+	kubernetesClient := resourceManager.clientManager.KubernetesClient()
+
+	partitioned := []string{}
+	for i := 0; i < 3; i++ {
+		pods, err := resourceManager.InjectClusterFailure(ctx, &api.InjectClusterFailureRequest{
+			Name:      "cluster",
+			Namespace: "team-a",
+			Type:      api.InjectClusterFailureRequest_PARTITION_WORKER,
+			GroupName: "small",
+		})
+		require.NoError(t, err)
+		require.Len(t, pods, 1)
+		assert.NotContains(t, partitioned, pods[0])
+		partitioned = append(partitioned, pods[0])
+
+		pod, err := kubernetesClient.PodClient("team-a").Get(ctx, pods[0], metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "true", pod.Labels[failurePartitionLabelKey])
+	}
+
+	// The NetworkPolicy denies all the traffic of the partitioned Pods.
+	policy, err := kubernetesClient.NetworkPolicyClient("team-a").Get(ctx, "cluster-failure-partition", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{utils.RayClusterLabelKey: "cluster", failurePartitionLabelKey: "true"}, policy.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+	assert.Empty(t, policy.Spec.Ingress)
+	assert.Empty(t, policy.Spec.Egress)
+	assert.Equal(t, "cluster", policy.OwnerReferences[0].Name)
+
+	_, err = resourceManager.InjectClusterFailure(ctx, &api.InjectClusterFailureRequest{
+		Name:      "cluster",
+		Namespace: "team-a",
+		Type:      api.InjectClusterFailureRequest_PARTITION_WORKER,
+	})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+
+	healed, err := resourceManager.HealClusterPartitions(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, partitioned, healed)
+	_, err = kubernetesClient.NetworkPolicyClient("team-a").Get(ctx, "cluster-failure-partition", metav1.GetOptions{})
+	require.Error(t, err)
+	for _, name := range partitioned {
+		pod, err := kubernetesClient.PodClient("team-a").Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, pod.Labels, failurePartitionLabelKey)
+	}
+
+	// Healing a cluster without partitions is a no-op.
+	healed, err = resourceManager.HealClusterPartitions(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	assert.Empty(t, healed)
+}
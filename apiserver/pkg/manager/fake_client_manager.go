@@ -0,0 +1,152 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// FakeClientManager is a ClientManagerInterface backed by in-memory clientsets. Instead of the
+// KubeRay operator, a background loop moves the status of every resource one step forward on
+// each tick, so consumers of the API server can be tested without a Kubernetes cluster.
+type FakeClientManager struct {
+	clients *client.FakeClients
+	time    util.TimeInterface
+}
+
+var _ ClientManagerInterface = &FakeClientManager{}
+
+// NewFakeClientManager creates a FakeClientManager. The status of resources progresses every
+// interval until ctx is done. A zero interval disables the status progression.
+func NewFakeClientManager(ctx context.Context, interval time.Duration) *FakeClientManager {
+	klog.Info("Initializing fake client manager, resources are only kept in memory")
+	c := &FakeClientManager{
+		clients: client.NewFakeClients(DefaultNamespace),
+		time:    util.NewRealTime(),
+	}
+	if interval > 0 {
+		go c.progressStatus(ctx, interval)
+	}
+	return c
+}
+
+func (c *FakeClientManager) ClusterClient() client.ClusterClientInterface {
+	return c.clients.ClusterClient()
+}
+
+func (c *FakeClientManager) JobClient() client.JobClientInterface {
+	return c.clients.JobClient()
+}
+
+func (c *FakeClientManager) ServiceClient() client.ServiceClientInterface {
+	return c.clients.ServiceClient()
+}
+
+func (c *FakeClientManager) KubernetesClient() client.KubernetesClientInterface {
+	return c.clients.KubernetesClient()
+}
+
+func (c *FakeClientManager) Time() util.TimeInterface {
+	return c.time
+}
+
+func (c *FakeClientManager) progressStatus(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Step(ctx)
+		}
+	}
+}
+
+// Step moves the status of every resource one step forward:
+//   - RayCluster: "" -> ready
+//   - RayJob: New -> Initializing -> Running -> Complete (SUCCEEDED)
+//   - RayService: "" -> WaitForServeDeploymentReady -> Running
+func (c *FakeClientManager) Step(ctx context.Context) {
+	rayClient := c.clients.Ray.RayV1()
+
+	clusters, err := rayClient.RayClusters(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Errorf("Fake client manager failed to list RayClusters: %v", err)
+		return
+	}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if cluster.Status.State == rayv1api.Ready {
+			continue
+		}
+		cluster.Status.State = rayv1api.Ready
+		var workers int32
+		for _, group := range cluster.Spec.WorkerGroupSpecs {
+			if group.Replicas != nil {
+				workers += *group.Replicas
+			}
+		}
+		cluster.Status.AvailableWorkerReplicas = workers
+		cluster.Status.DesiredWorkerReplicas = workers
+		if _, err := rayClient.RayClusters(cluster.Namespace).UpdateStatus(ctx, cluster, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Fake client manager failed to update RayCluster %s/%s: %v", cluster.Namespace, cluster.Name, err)
+		}
+	}
+
+	jobs, err := rayClient.RayJobs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Errorf("Fake client manager failed to list RayJobs: %v", err)
+		return
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		now := metav1.NewTime(c.time.Now())
+		switch job.Status.JobDeploymentStatus {
+		case rayv1api.JobDeploymentStatusNew:
+			job.Status.JobDeploymentStatus = rayv1api.JobDeploymentStatusInitializing
+			job.Status.JobStatus = rayv1api.JobStatusPending
+			job.Status.JobId = job.Name
+			job.Status.RayClusterName = job.Name
+			job.Status.StartTime = &now
+		case rayv1api.JobDeploymentStatusInitializing:
+			job.Status.JobDeploymentStatus = rayv1api.JobDeploymentStatusRunning
+			job.Status.JobStatus = rayv1api.JobStatusRunning
+		case rayv1api.JobDeploymentStatusRunning:
+			job.Status.JobDeploymentStatus = rayv1api.JobDeploymentStatusComplete
+			job.Status.JobStatus = rayv1api.JobStatusSucceeded
+			job.Status.EndTime = &now
+		default:
+			continue
+		}
+		if _, err := rayClient.RayJobs(job.Namespace).UpdateStatus(ctx, job, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Fake client manager failed to update RayJob %s/%s: %v", job.Namespace, job.Name, err)
+		}
+	}
+
+	services, err := rayClient.RayServices(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Errorf("Fake client manager failed to list RayServices: %v", err)
+		return
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		switch service.Status.ServiceStatus {
+		case "":
+			service.Status.ServiceStatus = rayv1api.WaitForServeDeploymentReady
+		case rayv1api.WaitForServeDeploymentReady:
+			service.Status.ServiceStatus = rayv1api.Running
+		default:
+			continue
+		}
+		if _, err := rayClient.RayServices(service.Namespace).UpdateStatus(ctx, service, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Fake client manager failed to update RayService %s/%s: %v", service.Namespace, service.Name, err)
+		}
+	}
+}
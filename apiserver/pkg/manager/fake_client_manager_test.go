@@ -0,0 +1,83 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestFakeClientManager(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	clientManager := resourceManager.clientManager.(*FakeClientManager)
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+
+	_, err = resourceManager.CreateCluster(ctx, &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		User:      "user",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "template",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{
+					GroupName:       "workers",
+					ComputeTemplate: "template",
+					Replicas:        2,
+					MaxReplicas:     2,
+				},
+			},
+		},
+	}, false, "")
+	require.NoError(t, err)
+
+	// The namespace is created on demand, so the cluster is found in all namespaces.
+	clusters, _, err := resourceManager.ListAllClusters(ctx, "", 0, "", ResourceSelector{})
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	assert.Equal(t, rayv1api.ClusterState(""), clusters[0].Status.State)
+
+	clientManager.Step(ctx)
+	cluster, err := resourceManager.GetCluster(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, rayv1api.Ready, cluster.Status.State)
+	assert.Equal(t, int32(2), cluster.Status.AvailableWorkerReplicas)
+}
+
+func TestFakeClientManagerJobStatus(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	jobClient := clientManager.JobClient().RayJobClient("team-a")
+
+	_, err := jobClient.Create(ctx, &rayv1api.RayJob{ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "team-a"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	expected := []rayv1api.JobDeploymentStatus{
+		rayv1api.JobDeploymentStatusInitializing,
+		rayv1api.JobDeploymentStatusRunning,
+		rayv1api.JobDeploymentStatusComplete,
+		rayv1api.JobDeploymentStatusComplete,
+	}
+	for _, status := range expected {
+		clientManager.Step(ctx)
+		job, err := jobClient.Get(ctx, "job", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, status, job.Status.JobDeploymentStatus)
+	}
+}
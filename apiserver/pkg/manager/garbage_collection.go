@@ -0,0 +1,211 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// The kinds of the resources deleted by the garbage collection.
+const (
+	expiringKindCluster = "Cluster"
+	expiringKindJob     = "RayJob"
+)
+
+// StartGarbageCollector deletes the finished jobs and the idle clusters whose time to live expired every interval
+// until ctx is done. The deletions are idempotent, so several API server replicas can run the garbage collection at
+// once.
+func (r *ResourceManager) StartGarbageCollector(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.CollectGarbage(ctx, r.clientManager.Time().Now())
+			}
+		}
+	}()
+}
+
+// CollectGarbage deletes the resources whose time to live expired at now. Errors are logged, so that a resource
+// which can't be deleted does not block the others.
+func (r *ResourceManager) CollectGarbage(ctx context.Context, now time.Time) {
+	resources, err := r.ListExpiringResources(ctx, metav1.NamespaceAll)
+	if err != nil {
+		klog.Errorf("Failed to list the expiring resources: %v", err)
+		return
+	}
+	for _, resource := range resources {
+		if resource.ExpireTime.AsTime().After(now) {
+			break
+		}
+		switch resource.Kind {
+		case expiringKindJob:
+			err = r.DeleteJob(ctx, resource.Name, resource.Namespace)
+		case expiringKindCluster:
+			err = r.DeleteCluster(ctx, resource.Name, resource.Namespace, false, "")
+		}
+		if err != nil {
+			klog.Errorf("Failed to delete expired %s %s/%s: %v", resource.Kind, resource.Namespace, resource.Name, err)
+			continue
+		}
+		klog.Infof("Deleted %s %s/%s: %s", resource.Kind, resource.Namespace, resource.Name, resource.Reason)
+	}
+}
+
+// ListExpiringResources returns the jobs and clusters managed by the API server in a namespace, or in all the
+// namespaces, whose time to live has started, soonest expiring first. The resources with the deletion protection annotation are never deleted, so
+// they are not listed.
+func (r *ResourceManager) ListExpiringResources(ctx context.Context, namespace string) ([]*api.ExpiringResource, error) {
+	jobs, err := r.getRayJobClient(namespace).List(ctx, managedListOptions("", 0))
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("List jobs failed in %s", namespace))
+	}
+	clusters, err := r.getRayClusterClient(namespace).List(ctx, managedListOptions("", 0))
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("List clusters failed in %s", namespace))
+	}
+
+	var resources []*api.ExpiringResource
+	// busy holds the clusters selected by a job which has not finished, lastJobEnd the end of the last finished job
+	// selecting each cluster.
+	busy := map[string]bool{}
+	lastJobEnd := map[string]time.Time{}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		finished := isJobDeploymentFinished(job.Status.JobDeploymentStatus)
+		if clusterName := job.Spec.ClusterSelector[utils.RayClusterLabelKey]; clusterName != "" {
+			key := job.Namespace + "/" + clusterName
+			if !finished || job.Status.EndTime == nil {
+				busy[key] = true
+			} else if job.Status.EndTime.Time.After(lastJobEnd[key]) {
+				lastJobEnd[key] = job.Status.EndTime.Time
+			}
+		}
+		ttl := util.TTLFromAnnotations(job.Annotations, util.RayJobTTLAnnotationKey)
+		if ttl == 0 || !finished || job.Status.EndTime == nil || utils.IsDeletionProtected(job) {
+			continue
+		}
+		resources = append(resources, newExpiringResource(expiringKindJob, job.Name, job.Namespace, job.Status.EndTime.Time, ttl,
+			fmt.Sprintf("finished at %s, ttl %ds", job.Status.EndTime.UTC().Format(time.RFC3339), ttl)))
+	}
+
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		ttl := util.TTLFromAnnotations(cluster.Annotations, util.RayClusterIdleTTLAnnotationKey)
+		key := cluster.Namespace + "/" + cluster.Name
+		// Only ready clusters are known to be idle, the jobs of the others can't be listed.
+		if ttl == 0 || busy[key] || cluster.Status.State != rayv1api.Ready || utils.IsDeletionProtected(cluster) {
+			continue
+		}
+		idleSince, idle, err := r.clusterIdleSince(ctx, cluster)
+		if err != nil {
+			klog.Errorf("Failed to list the jobs of cluster %s to check whether it is idle: %v", key, err)
+			continue
+		}
+		if !idle {
+			continue
+		}
+		if lastJobEnd[key].After(idleSince) {
+			idleSince = lastJobEnd[key]
+		}
+		// The dashboard does not list the drivers of the Ray clients connected to the sessions.
+		if activeAt, ok := sessionActiveAt(cluster); ok && activeAt.After(idleSince) {
+			idleSince = activeAt
+		}
+		resources = append(resources, newExpiringResource(expiringKindCluster, cluster.Name, cluster.Namespace, idleSince, ttl,
+			fmt.Sprintf("idle since %s, ttl %ds", idleSince.UTC().Format(time.RFC3339), ttl)))
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].ExpireTime.AsTime().Before(resources[j].ExpireTime.AsTime())
+	})
+	return resources, nil
+}
+
+// clusterIdleSince returns since when a ready cluster is idle according to the jobs of its dashboard: since the end
+// of its last job, or since it was created if it never ran a job. It returns false if a job is pending or running.
+func (r *ResourceManager) clusterIdleSince(ctx context.Context, cluster *rayv1api.RayCluster) (time.Time, bool, error) {
+	dashboardClient, err := r.clusterDashboardClient(ctx, cluster)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	jobs, err := dashboardClient.ListJobs(ctx)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	idleSince := cluster.CreationTimestamp.Time
+	if jobs == nil {
+		return idleSince, true, nil
+	}
+	for _, job := range *jobs {
+		if !rayv1api.IsJobTerminal(job.JobStatus) {
+			return time.Time{}, false, nil
+		}
+		// The dashboard reports the times in milliseconds since the epoch.
+		if end := time.UnixMilli(int64(job.EndTime)); end.After(idleSince) {
+			idleSince = end
+		}
+	}
+	return idleSince, true, nil
+}
+
+// clusterDashboardClient returns a client of the dashboard of a cluster, through its head service.
+func (r *ResourceManager) clusterDashboardClient(ctx context.Context, cluster *rayv1api.RayCluster) (utils.RayDashboardClientInterface, error) {
+	headServiceName, err := utils.GenerateHeadServiceName(utils.RayClusterCRD, cluster.Spec, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	port := cluster.Status.Endpoints[utils.DashboardPortName]
+	if port == "" {
+		port = strconv.Itoa(utils.DefaultDashboardPort)
+	}
+	host := fmt.Sprintf("%s.%s.svc.%s", headServiceName, cluster.Namespace, utils.GetClusterDomainName())
+	dashboardClient := r.dashboardClientFunc()
+	if err := dashboardClient.InitClient(ctx, net.JoinHostPort(host, port), nil); err != nil {
+		return nil, err
+	}
+	// The dashboard of a cluster with dashboard auth rejects the requests without its auth token.
+	if cluster.Spec.DashboardAuth != nil {
+		token, err := r.GetDashboardAuthToken(ctx, cluster.Name, cluster.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		tokenSetter, ok := dashboardClient.(utils.RayDashboardClientAuthTokenSetter)
+		if !ok {
+			return nil, fmt.Errorf("the dashboard client does not support dashboard auth")
+		}
+		tokenSetter.SetAuthToken(token)
+	}
+	return dashboardClient, nil
+}
+
+func newExpiringResource(kind string, name string, namespace string, since time.Time, ttl int32, reason string) *api.ExpiringResource {
+	return &api.ExpiringResource{
+		Kind:       kind,
+		Name:       name,
+		Namespace:  namespace,
+		ExpireTime: timestamppb.New(since.Add(time.Duration(ttl) * time.Second)),
+		Reason:     reason,
+	}
+}
+
+func isJobDeploymentFinished(status rayv1api.JobDeploymentStatus) bool {
+	return status == rayv1api.JobDeploymentStatusComplete || status == rayv1api.JobDeploymentStatusFailed
+}
@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestCollectGarbage(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	resourceManager.dashboardClientFunc = func() utils.RayDashboardClientInterface { return &utils.FakeRayDashboardClient{} }
+	rayClient := clientManager.clients.Ray.RayV1()
+
+	labels := map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName}
+	created := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	ended := metav1.NewTime(time.Now().Add(-time.Hour))
+	newCluster := func(name string, annotations map[string]string) *rayv1api.RayCluster {
+		return &rayv1api.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", CreationTimestamp: created, Labels: labels, Annotations: annotations},
+			Status:     rayv1api.RayClusterStatus{State: rayv1api.Ready},
+		}
+	}
+	newJob := func(name string, annotations map[string]string, status rayv1api.JobDeploymentStatus) *rayv1api.RayJob {
+		return &rayv1api.RayJob{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Labels: labels, Annotations: annotations},
+			Spec:       rayv1api.RayJobSpec{ClusterSelector: map[string]string{utils.RayClusterLabelKey: "busy"}},
+			Status:     rayv1api.RayJobStatus{JobDeploymentStatus: status, EndTime: &ended},
+		}
+	}
+	ttl := func(key string, seconds string) map[string]string { return map[string]string{key: seconds} }
+
+	for _, cluster := range []*rayv1api.RayCluster{
+		newCluster("idle", ttl(util.RayClusterIdleTTLAnnotationKey, "60")),
+		newCluster("busy", ttl(util.RayClusterIdleTTLAnnotationKey, "60")),
+		newCluster("no-ttl", nil),
+		newCluster("protected", map[string]string{util.RayClusterIdleTTLAnnotationKey: "60", utils.RayDeletionProtectedAnnotationKey: "true"}),
+	} {
+		_, err := rayClient.RayClusters("team-a").Create(ctx, cluster, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	for _, job := range []*rayv1api.RayJob{
+		newJob("finished", ttl(util.RayJobTTLAnnotationKey, "600"), rayv1api.JobDeploymentStatusComplete),
+		newJob("running", ttl(util.RayJobTTLAnnotationKey, "600"), rayv1api.JobDeploymentStatusRunning),
+	} {
+		_, err := rayClient.RayJobs("team-a").Create(ctx, job, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	resources, err := resourceManager.ListExpiringResources(ctx, "team-a")
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, expiringKindCluster, resources[0].Kind)
+	assert.Equal(t, "idle", resources[0].Name)
+	assert.Equal(t, created.Add(time.Minute).Unix(), resources[0].ExpireTime.AsTime().Unix())
+	assert.Equal(t, expiringKindJob, resources[1].Kind)
+	assert.Equal(t, "finished", resources[1].Name)
+	assert.Equal(t, ended.Add(10*time.Minute).Unix(), resources[1].ExpireTime.AsTime().Unix())
+
+	// Only the idle cluster expired 55 minutes ago.
+	resourceManager.CollectGarbage(ctx, time.Now().Add(-55*time.Minute))
+	_, err = resourceManager.GetCluster(ctx, "idle", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	_, err = resourceManager.GetJob(ctx, "finished", "team-a")
+	require.NoError(t, err)
+
+	resourceManager.CollectGarbage(ctx, time.Now())
+	_, err = resourceManager.GetJob(ctx, "finished", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	for _, name := range []string{"busy", "no-ttl", "protected"} {
+		_, err = resourceManager.GetCluster(ctx, name, "team-a")
+		require.NoError(t, err, name)
+	}
+	_, err = resourceManager.GetJob(ctx, "running", "team-a")
+	require.NoError(t, err)
+}
@@ -0,0 +1,79 @@
+package manager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/typed/ray/v1"
+)
+
+// hashCreatePayload returns the hash of the payload of a create call, which is recorded next to the idempotency key
+// to tell a retry from a different resource reusing the key.
+func hashCreatePayload(payload proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(payload)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to hash the create payload")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// setIdempotencyAnnotations records the idempotency key of the create call and the hash of its payload.
+func setIdempotencyAnnotations(meta *metav1.ObjectMeta, key string, payloadHash string) {
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[util.IdempotencyKeyAnnotationKey] = key
+	meta.Annotations[util.IdempotencyPayloadHashAnnotationKey] = payloadHash
+}
+
+// checkIdempotentCreate returns nil if the existing resource was created by a call with the same idempotency key and
+// payload, so that the retried call can return it. Otherwise the retried call conflicts with the existing resource.
+func checkIdempotentCreate(kind string, meta metav1.ObjectMeta, key string, payloadHash string) error {
+	if meta.Annotations[util.IdempotencyKeyAnnotationKey] != key {
+		return util.NewAlreadyExistError("%s %s already exists and was not created with idempotency key %s.", kind, meta.Name, key)
+	}
+	if meta.Annotations[util.IdempotencyPayloadHashAnnotationKey] != payloadHash {
+		return util.NewAlreadyExistError("%s %s was created with idempotency key %s and a different payload. Please use a new key for a different %s.", kind, meta.Name, key, kind)
+	}
+	return nil
+}
+
+// getIdempotentCluster returns the cluster created by a previous call with the same idempotency key and payload, or
+// nil if there is no cluster with the name yet.
+func getIdempotentCluster(ctx context.Context, client rayv1.RayClusterInterface, name string, key string, payloadHash string) (*rayv1api.RayCluster, error) {
+	cluster, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, util.NewInternalServerError(err, "Failed to get cluster %s", name)
+	}
+	if err := checkIdempotentCreate("Cluster", cluster.ObjectMeta, key, payloadHash); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// getIdempotentService returns the service created by a previous call with the same idempotency key and payload, or
+// nil if there is no service with the name yet.
+func getIdempotentService(ctx context.Context, client rayv1.RayServiceInterface, name string, key string, payloadHash string) (*rayv1api.RayService, error) {
+	service, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, util.NewInternalServerError(err, "Failed to get service %s", name)
+	}
+	if err := checkIdempotentCreate("Service", service.ObjectMeta, key, payloadHash); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
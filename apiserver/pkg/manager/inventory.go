@@ -0,0 +1,144 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// inventoryPageSize is the number of resources listed per call when the inventory is collected.
+const inventoryPageSize = 500
+
+// InventoryRecord is the inventory entry of a cluster, service or job managed by the API server.
+type InventoryRecord struct {
+	// Time is when the inventory was collected.
+	Time time.Time `json:"time"`
+	// Target is the kubeconfig context of the Kubernetes cluster of the resource, empty for the default cluster.
+	Target    string    `json:"target,omitempty"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	UID       string    `json:"uid"`
+	Owner     string    `json:"owner,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Labels are the labels of the resource, or only the cost labels if they are configured.
+	Labels map[string]string `json:"labels,omitempty"`
+	// State is the state of a cluster, the service status of a service and the deployment status of a job.
+	State string `json:"state,omitempty"`
+}
+
+// InventoryOptions configures the export of the inventory.
+type InventoryOptions struct {
+	// Target is the kubeconfig context of the Kubernetes cluster of the ResourceManager.
+	Target string
+	// LabelKeys are the keys of the labels exported with the resources, e.g. cost centers. All labels are
+	// exported if it is empty.
+	LabelKeys []string
+}
+
+// StartInventoryExporter exports the inventory every interval until ctx is done.
+func (r *ResourceManager) StartInventoryExporter(ctx context.Context, exporter InventoryExporter, interval time.Duration, options InventoryOptions) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := r.ExportInventory(ctx, exporter, options); err != nil {
+				klog.Errorf("Failed to export the inventory: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// ExportInventory collects the clusters, services and jobs of all namespaces and publishes them with exporter.
+func (r *ResourceManager) ExportInventory(ctx context.Context, exporter InventoryExporter, options InventoryOptions) error {
+	records, err := r.CollectInventory(ctx, options)
+	if err != nil {
+		return err
+	}
+	if err := exporter.Export(ctx, records); err != nil {
+		return util.NewInternalServerError(err, "Failed to export the inventory of %d resources", len(records))
+	}
+	klog.Infof("Exported the inventory of %d resources", len(records))
+	return nil
+}
+
+// CollectInventory returns the inventory records of the clusters, services and jobs of all namespaces.
+func (r *ResourceManager) CollectInventory(ctx context.Context, options InventoryOptions) ([]*InventoryRecord, error) {
+	now := r.clientManager.Time().Now().UTC()
+	var records []*InventoryRecord
+	newRecord := func(kind string, meta metav1.ObjectMeta, state string) *InventoryRecord {
+		return &InventoryRecord{
+			Time:      now,
+			Target:    options.Target,
+			Kind:      kind,
+			Namespace: meta.Namespace,
+			Name:      meta.Name,
+			UID:       string(meta.UID),
+			Owner:     meta.Labels[util.RayClusterUserLabelKey],
+			CreatedAt: meta.CreationTimestamp.UTC(),
+			Labels:    inventoryLabels(meta.Labels, options.LabelKeys),
+			State:     state,
+		}
+	}
+
+	continueToken := ""
+	for {
+		clusters, listMeta, err := r.ListAllClusters(ctx, continueToken, inventoryPageSize, "", ResourceSelector{})
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to collect the clusters of the inventory")
+		}
+		for _, cluster := range clusters {
+			records = append(records, newRecord("RayCluster", cluster.ObjectMeta, string(cluster.Status.State)))
+		}
+		if continueToken = listMeta.Continue; continueToken == "" {
+			break
+		}
+	}
+	for {
+		services, listMeta, err := r.ListAllServices(ctx, continueToken, inventoryPageSize, "", ResourceSelector{})
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to collect the services of the inventory")
+		}
+		for _, service := range services {
+			records = append(records, newRecord("RayService", service.ObjectMeta, string(service.Status.ServiceStatus)))
+		}
+		if continueToken = listMeta.Continue; continueToken == "" {
+			break
+		}
+	}
+	for {
+		jobs, nextToken, err := r.ListAllJobs(ctx, continueToken, inventoryPageSize)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to collect the jobs of the inventory")
+		}
+		for _, job := range jobs {
+			records = append(records, newRecord("RayJob", job.ObjectMeta, string(job.Status.JobDeploymentStatus)))
+		}
+		if continueToken = nextToken; continueToken == "" {
+			break
+		}
+	}
+	return records, nil
+}
+
+func inventoryLabels(labels map[string]string, keys []string) map[string]string {
+	if len(keys) == 0 {
+		return labels
+	}
+	result := map[string]string{}
+	for _, key := range keys {
+		if value, ok := labels[key]; ok {
+			result[key] = value
+		}
+	}
+	return result
+}
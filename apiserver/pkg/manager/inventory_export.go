@@ -0,0 +1,354 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// InventoryExporter publishes the inventory to an external system. Every export is a full snapshot of the
+// resources managed by the API server.
+type InventoryExporter interface {
+	Export(ctx context.Context, records []*InventoryRecord) error
+}
+
+// NewInventoryExporter creates the inventory exporter described by spec, which is either a `file://` path to
+// which the inventory is written, an `http://` or `https://` URL to which it is posted, an `s3://<bucket>/<prefix>`
+// location to which it is uploaded, or a `bigquery://<project>/<dataset>/<table>` table to which it is streamed.
+func NewInventoryExporter(spec string, client *http.Client) (InventoryExporter, error) {
+	switch {
+	case strings.HasPrefix(spec, "file://"):
+		return &fileInventoryExporter{path: strings.TrimPrefix(spec, "file://")}, nil
+	case strings.HasPrefix(spec, "http://"), strings.HasPrefix(spec, "https://"):
+		return &httpInventoryExporter{url: spec, client: client}, nil
+	case strings.HasPrefix(spec, "s3://"):
+		bucket, prefix, _ := strings.Cut(strings.TrimPrefix(spec, "s3://"), "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("the inventory exporter %q has no bucket, expected s3://<bucket>/<prefix>", spec)
+		}
+		return newS3InventoryExporter(bucket, prefix, client)
+	case strings.HasPrefix(spec, "bigquery://"):
+		parts := strings.Split(strings.TrimPrefix(spec, "bigquery://"), "/")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid inventory exporter %q, expected bigquery://<project>/<dataset>/<table>", spec)
+		}
+		return newBigQueryInventoryExporter(parts[0], parts[1], parts[2], client), nil
+	default:
+		return nil, fmt.Errorf("unknown inventory exporter %q, expected file://<path>, an http(s) URL, s3://<bucket>/<prefix> or bigquery://<project>/<dataset>/<table>", spec)
+	}
+}
+
+// marshalInventory returns the records as JSON lines.
+func marshalInventory(records []*InventoryRecord) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// fileInventoryExporter replaces a file with the JSON lines of the inventory, so that the file always has a
+// complete snapshot.
+type fileInventoryExporter struct {
+	path string
+}
+
+func (e *fileInventoryExporter) Export(_ context.Context, records []*InventoryRecord) error {
+	content, err := marshalInventory(records)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(e.path), filepath.Base(e.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), e.path)
+}
+
+// httpInventoryExporter posts the JSON lines of the inventory to a URL.
+type httpInventoryExporter struct {
+	url    string
+	client *http.Client
+}
+
+func (e *httpInventoryExporter) Export(ctx context.Context, records []*InventoryRecord) error {
+	content, err := marshalInventory(records)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	return doInventoryRequest(e.client, request)
+}
+
+func doInventoryRequest(client *http.Client, request *http.Request) error {
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("%s responded with %s: %s", request.URL.Host, response.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// s3InventoryExporter uploads the JSON lines of every inventory as a new object of an S3 bucket, named after the
+// time of the export. The credentials and the region are read from the standard AWS environment variables, and
+// AWS_ENDPOINT_URL_S3 selects an S3 compatible store.
+type s3InventoryExporter struct {
+	bucket   string
+	prefix   string
+	region   string
+	endpoint string
+	client   *http.Client
+
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	// now returns the time of the request signatures.
+	now func() time.Time
+}
+
+func newS3InventoryExporter(bucket string, prefix string, client *http.Client) (*s3InventoryExporter, error) {
+	e := &s3InventoryExporter{
+		bucket:          bucket,
+		prefix:          prefix,
+		region:          os.Getenv("AWS_REGION"),
+		endpoint:        os.Getenv("AWS_ENDPOINT_URL_S3"),
+		client:          client,
+		accessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		now:             time.Now,
+	}
+	if e.region == "" {
+		e.region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if e.region == "" || e.accessKeyID == "" || e.secretAccessKey == "" {
+		return nil, fmt.Errorf("the S3 inventory exporter requires AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	if e.endpoint == "" {
+		e.endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", e.region)
+	}
+	return e, nil
+}
+
+func (e *s3InventoryExporter) Export(ctx context.Context, records []*InventoryRecord) error {
+	content, err := marshalInventory(records)
+	if err != nil {
+		return err
+	}
+	now := e.now().UTC()
+	key := "inventory-" + now.Format("20060102T150405Z") + ".jsonl"
+	if prefix := strings.Trim(e.prefix, "/"); prefix != "" {
+		key = prefix + "/" + key
+	}
+	// Path-style URLs work with every bucket name and with S3 compatible stores.
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, strings.TrimSuffix(e.endpoint, "/")+"/"+e.bucket+"/"+key, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	e.sign(request, content, now)
+	return doInventoryRequest(e.client, request)
+}
+
+// sign adds the AWS Signature Version 4 of a request to its headers.
+func (e *s3InventoryExporter) sign(request *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if e.sessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", e.sessionToken)
+	}
+
+	signedHeaders := []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}
+	if e.sessionToken != "" {
+		signedHeaders = append(signedHeaders, "x-amz-security-token")
+	}
+	var canonicalHeaders strings.Builder
+	for _, header := range signedHeaders {
+		value := request.Header.Get(header)
+		if header == "host" {
+			value = request.URL.Host
+		}
+		canonicalHeaders.WriteString(header + ":" + strings.TrimSpace(value) + "\n")
+	}
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + e.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+e.secretAccessKey), date)
+	for _, part := range []string{e.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		e.accessKeyID, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+func sha256Hex(data []byte) string {
+	digest := sha256.Sum256(data)
+	return hex.EncodeToString(digest[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// bigQueryInsertBatchSize is the number of rows streamed to BigQuery per request.
+const bigQueryInsertBatchSize = 500
+
+// bigQueryInventoryExporter streams the inventory records as rows of a BigQuery table. It authenticates with the
+// access token of the service account of the GKE metadata server, e.g. with Workload Identity.
+type bigQueryInventoryExporter struct {
+	insertURL string
+	tokenURL  string
+	client    *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiry      time.Time
+}
+
+func newBigQueryInventoryExporter(project string, dataset string, table string, client *http.Client) *bigQueryInventoryExporter {
+	return &bigQueryInventoryExporter{
+		insertURL: fmt.Sprintf("https://bigquery.googleapis.com/bigquery/v2/projects/%s/datasets/%s/tables/%s/insertAll",
+			url.PathEscape(project), url.PathEscape(dataset), url.PathEscape(table)),
+		tokenURL: "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token",
+		client:   client,
+	}
+}
+
+type bigQueryRow struct {
+	InsertID string           `json:"insertId"`
+	JSON     *InventoryRecord `json:"json"`
+}
+
+type bigQueryInsertResponse struct {
+	InsertErrors []struct {
+		Index  int `json:"index"`
+		Errors []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"insertErrors"`
+}
+
+func (e *bigQueryInventoryExporter) Export(ctx context.Context, records []*InventoryRecord) error {
+	token, err := e.token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get an access token from the metadata server: %w", err)
+	}
+	for start := 0; start < len(records); start += bigQueryInsertBatchSize {
+		batch := records[start:min(start+bigQueryInsertBatchSize, len(records))]
+		rows := make([]bigQueryRow, 0, len(batch))
+		for _, record := range batch {
+			// The insert ID makes retried inserts of the same snapshot idempotent.
+			rows = append(rows, bigQueryRow{
+				InsertID: fmt.Sprintf("%s/%s/%s/%s", record.Kind, record.UID, record.Target, record.Time.Format(time.RFC3339)),
+				JSON:     record,
+			})
+		}
+		body, err := json.Marshal(map[string]interface{}{"rows": rows})
+		if err != nil {
+			return err
+		}
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.insertURL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Authorization", "Bearer "+token)
+		response, err := e.client.Do(request)
+		if err != nil {
+			return err
+		}
+		insertResponse := bigQueryInsertResponse{}
+		err = json.NewDecoder(response.Body).Decode(&insertResponse)
+		response.Body.Close()
+		if response.StatusCode >= http.StatusMultipleChoices {
+			return fmt.Errorf("BigQuery responded with %s", response.Status)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the response of BigQuery: %w", err)
+		}
+		if len(insertResponse.InsertErrors) > 0 && len(insertResponse.InsertErrors[0].Errors) > 0 {
+			insertError := insertResponse.InsertErrors[0]
+			return fmt.Errorf("BigQuery rejected %d rows, row %d: %s", len(insertResponse.InsertErrors),
+				start+insertError.Index, insertError.Errors[0].Message)
+		}
+	}
+	return nil
+}
+
+// token returns the access token of the metadata server, which is cached until shortly before it expires.
+func (e *bigQueryInventoryExporter) token(ctx context.Context) (string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.accessToken != "" && time.Now().Before(e.expiry) {
+		return e.accessToken, nil
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, e.tokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Metadata-Flavor", "Google")
+	response, err := e.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the metadata server responded with %s", response.Status)
+	}
+	token := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	e.accessToken = token.AccessToken
+	e.expiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return e.accessToken, nil
+}
@@ -0,0 +1,140 @@
+package manager
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestCollectInventory(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	rayClient := clientManager.clients.Ray.RayV1()
+
+	labels := map[string]string{
+		util.KubernetesManagedByLabelKey: util.ComponentName,
+		util.RayClusterUserLabelKey:      "alice",
+		"cost-center":                    "ml-research",
+	}
+	_, err := rayClient.RayClusters("team-a").Create(ctx, &rayv1api.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "team-a", UID: "cluster-uid", Labels: labels},
+		Status:     rayv1api.RayClusterStatus{State: rayv1api.Ready},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = rayClient.RayServices("team-b").Create(ctx, &rayv1api.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "team-b", Labels: labels},
+		Status:     rayv1api.RayServiceStatuses{ServiceStatus: rayv1api.Running},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = rayClient.RayJobs("team-a").Create(ctx, &rayv1api.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "team-a", Labels: labels},
+		Status:     rayv1api.RayJobStatus{JobDeploymentStatus: rayv1api.JobDeploymentStatusComplete},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	records, err := resourceManager.CollectInventory(ctx, InventoryOptions{Target: "us-east", LabelKeys: []string{"cost-center"}})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "RayCluster", records[0].Kind)
+	assert.Equal(t, "cluster-uid", records[0].UID)
+	assert.Equal(t, "us-east", records[0].Target)
+	assert.Equal(t, "alice", records[0].Owner)
+	assert.Equal(t, map[string]string{"cost-center": "ml-research"}, records[0].Labels)
+	assert.Equal(t, "ready", records[0].State)
+	assert.Equal(t, "RayService", records[1].Kind)
+	assert.Equal(t, "Running", records[1].State)
+	assert.Equal(t, "RayJob", records[2].Kind)
+	assert.Equal(t, "Complete", records[2].State)
+
+	path := filepath.Join(t.TempDir(), "inventory.jsonl")
+	exporter, err := NewInventoryExporter("file://"+path, http.DefaultClient)
+	require.NoError(t, err)
+	require.NoError(t, resourceManager.ExportInventory(ctx, exporter, InventoryOptions{}))
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	lines := 0
+	for scanner := bufio.NewScanner(file); scanner.Scan(); lines++ {
+		record := InventoryRecord{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		assert.Len(t, record.Labels, 3, "All labels are exported without label keys")
+	}
+	assert.Equal(t, 3, lines)
+}
+
+func TestInventoryExporters(t *testing.T) {
+	ctx := context.Background()
+	records := []*InventoryRecord{
+		{Time: time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC), Kind: "RayCluster", Namespace: "team-a", Name: "cluster", UID: "uid-1"},
+		{Time: time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC), Kind: "RayJob", Namespace: "team-a", Name: "job", UID: "uid-2"},
+	}
+
+	var request *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/token":
+			_, _ = w.Write([]byte(`{"access_token":"secret","expires_in":3600}`))
+		case "/insertAll":
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	exporter, err := NewInventoryExporter(server.URL+"/inventory", server.Client())
+	require.NoError(t, err)
+	require.NoError(t, exporter.Export(ctx, records))
+	assert.Equal(t, "application/x-ndjson", request.Header.Get("Content-Type"))
+	assert.Len(t, strings.Split(strings.TrimSpace(string(body)), "\n"), 2)
+
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY")
+	t.Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+	exporter, err = NewInventoryExporter("s3://inventory/ray/", server.Client())
+	require.NoError(t, err)
+	exporter.(*s3InventoryExporter).now = func() time.Time { return time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC) }
+	require.NoError(t, exporter.Export(ctx, records))
+	assert.Equal(t, http.MethodPut, request.Method)
+	assert.Equal(t, "/inventory/ray/inventory-20240701T100000Z.jsonl", request.URL.Path)
+	assert.True(t, strings.HasPrefix(request.Header.Get("Authorization"),
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240701/us-east-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="))
+
+	exporter, err = NewInventoryExporter("bigquery://project/dataset/table", server.Client())
+	require.NoError(t, err)
+	bigQuery := exporter.(*bigQueryInventoryExporter)
+	assert.Equal(t, "https://bigquery.googleapis.com/bigquery/v2/projects/project/datasets/dataset/tables/table/insertAll", bigQuery.insertURL)
+	bigQuery.tokenURL = server.URL + "/token"
+	bigQuery.insertURL = server.URL + "/insertAll"
+	require.NoError(t, exporter.Export(ctx, records))
+	assert.Equal(t, "Bearer secret", request.Header.Get("Authorization"))
+	rows := struct {
+		Rows []bigQueryRow `json:"rows"`
+	}{}
+	require.NoError(t, json.Unmarshal(body, &rows))
+	require.Len(t, rows.Rows, 2)
+	assert.Equal(t, "RayCluster/uid-1//2024-07-01T10:00:00Z", rows.Rows[0].InsertID)
+
+	for _, spec := range []string{"stdout", "s3://", "bigquery://project/dataset"} {
+		_, err := NewInventoryExporter(spec, http.DefaultClient)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,88 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// jobArchive is where the finished RayJobs of the Kubernetes cluster of a ResourceManager are archived.
+type jobArchive struct {
+	store datastore.Store
+	// target is the kubeconfig context of the Kubernetes cluster, empty for the default cluster.
+	target string
+}
+
+// StartJobArchiver archives the final state of the RayJobs managed by the API server in store when they finish, and
+// again when they are deleted, until ctx is done. The RayJobs deleted by the operator, e.g. with a deletion strategy,
+// are archived from the watch of the RayJobs, and the ones deleted through the API server, including by the garbage
+// collection, before they are deleted. Archiving a job again replaces its snapshot, so several API server replicas can
+// archive the same jobs.
+func (r *ResourceManager) StartJobArchiver(ctx context.Context, store datastore.Store, target string) {
+	r.jobArchive = &jobArchive{store: store, target: target}
+	client := r.getRayJobClient(metav1.NamespaceAll)
+	informer := newManagedInformer(&rayv1api.RayJob{}, 0,
+		func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			return client.List(ctx, options)
+		}, client.Watch)
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if job, ok := obj.(*rayv1api.RayJob); ok && isJobDeploymentFinished(job.Status.JobDeploymentStatus) {
+				r.archiveJob(ctx, job, false)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldJob, ok := oldObj.(*rayv1api.RayJob)
+			if !ok {
+				return
+			}
+			if job, ok := newObj.(*rayv1api.RayJob); ok && isJobDeploymentFinished(job.Status.JobDeploymentStatus) &&
+				!isJobDeploymentFinished(oldJob.Status.JobDeploymentStatus) {
+				r.archiveJob(ctx, job, false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if job, ok := obj.(*rayv1api.RayJob); ok && isJobDeploymentFinished(job.Status.JobDeploymentStatus) {
+				r.archiveJob(ctx, job, true)
+			}
+		},
+	})
+	if err != nil {
+		klog.Errorf("Failed to register the job archiver handler: %v", err)
+		return
+	}
+	go informer.Run(ctx.Done())
+}
+
+// archiveJob archives a finished job, as deleted now if deleted is set. Errors are logged, so that a datastore which
+// can't be reached does not block the deletion of the jobs.
+func (r *ResourceManager) archiveJob(ctx context.Context, job *rayv1api.RayJob, deleted bool) {
+	if r.jobArchive == nil {
+		return
+	}
+	now := r.clientManager.Time().Now()
+	var deletedAt time.Time
+	if deleted {
+		deletedAt = now
+	}
+	archived, err := model.FromApiToDatastoreArchivedJob(model.FromCrdToApiArchivedJob(job, r.jobArchive.target, now, deletedAt))
+	if err == nil {
+		err = r.jobArchive.store.ArchiveJob(ctx, archived)
+	}
+	if err != nil {
+		klog.Errorf("Failed to archive job %s/%s: %v", job.Namespace, job.Name, err)
+		return
+	}
+	klog.V(2).Infof("Archived job %s/%s with status %s", job.Namespace, job.Name, job.Status.JobStatus)
+}
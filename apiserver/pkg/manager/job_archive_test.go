@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestJobArchiver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	store := datastore.NewMemoryStore(10)
+	resourceManager.StartJobArchiver(ctx, store, "")
+	jobClient := clientManager.clients.Ray.RayV1().RayJobs("team-a")
+
+	started := metav1.NewTime(time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC))
+	ended := metav1.NewTime(started.Add(time.Minute))
+	newJob := func(name string, user string) *rayv1api.RayJob {
+		return &rayv1api.RayJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "team-a",
+				UID:       types.UID("uid-" + name),
+				Labels:    map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName, util.RayClusterUserLabelKey: user},
+			},
+			Spec: rayv1api.RayJobSpec{Entrypoint: "python main.py"},
+			Status: rayv1api.RayJobStatus{
+				JobStatus:           rayv1api.JobStatusRunning,
+				JobDeploymentStatus: rayv1api.JobDeploymentStatusRunning,
+				StartTime:           &started,
+			},
+		}
+	}
+	listArchived := func(query datastore.ArchivedJobQuery) []*api.ArchivedRayJob {
+		archived, err := store.ListArchivedJobs(ctx, query)
+		require.NoError(t, err)
+		jobs, err := model.FromDatastoreToAPIArchivedJobs(archived)
+		require.NoError(t, err)
+		return jobs
+	}
+
+	// A running job is archived once it finishes.
+	job, err := jobClient.Create(ctx, newJob("train", "alice"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	job.Status.JobStatus = rayv1api.JobStatusFailed
+	job.Status.JobDeploymentStatus = rayv1api.JobDeploymentStatusFailed
+	job.Status.Message = "Job entrypoint command failed"
+	job.Status.EndTime = &ended
+	_, err = jobClient.UpdateStatus(ctx, job, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(listArchived(datastore.ArchivedJobQuery{})) == 1 }, 5*time.Second, 10*time.Millisecond)
+	archived := listArchived(datastore.ArchivedJobQuery{})[0]
+	assert.Equal(t, "train", archived.Name)
+	assert.Equal(t, "alice", archived.User)
+	assert.Equal(t, "python main.py", archived.Entrypoint)
+	assert.Equal(t, string(rayv1api.JobStatusFailed), archived.JobStatus)
+	assert.Equal(t, "Job entrypoint command failed", archived.Message)
+	assert.Equal(t, started.Time, archived.StartTime.AsTime())
+	assert.Equal(t, ended.Time, archived.EndTime.AsTime())
+	assert.Nil(t, archived.DeletedAt)
+
+	// A job deleted by the operator is archived again as deleted.
+	require.NoError(t, jobClient.Delete(ctx, "train", metav1.DeleteOptions{}))
+	require.Eventually(t, func() bool {
+		jobs := listArchived(datastore.ArchivedJobQuery{})
+		return len(jobs) == 1 && jobs[0].DeletedAt != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A job deleted through the API server is archived before it is deleted.
+	finished := newJob("report", "bob")
+	finished.Status.JobStatus = rayv1api.JobStatusSucceeded
+	finished.Status.JobDeploymentStatus = rayv1api.JobDeploymentStatusComplete
+	finished.Status.EndTime = &metav1.Time{Time: ended.Add(time.Hour)}
+	_, err = jobClient.Create(ctx, finished, metav1.CreateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, resourceManager.DeleteJob(ctx, "report", "team-a"))
+	jobs := listArchived(datastore.ArchivedJobQuery{User: "bob"})
+	require.Len(t, jobs, 1)
+	assert.NotNil(t, jobs[0].DeletedAt)
+
+	// The most recently finished jobs are listed first.
+	jobs = listArchived(datastore.ArchivedJobQuery{Namespace: "team-a"})
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "report", jobs[0].Name)
+	assert.Empty(t, listArchived(datastore.ArchivedJobQuery{JobStatus: string(rayv1api.JobStatusSucceeded), FinishedBefore: ended.Time}))
+	assert.Empty(t, listArchived(datastore.ArchivedJobQuery{TargetCluster: "other"}))
+}
@@ -0,0 +1,256 @@
+package manager
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FilterFieldType is the type of the values of a filter field, which decides how they are parsed and compared.
+type FilterFieldType string
+
+const (
+	// StringFilterField values are compared with = and != only.
+	StringFilterField FilterFieldType = "string"
+	// TimeFilterField values are RFC 3339 times or dates such as 2024-01-01, which are midnight UTC.
+	TimeFilterField FilterFieldType = "time"
+	// IntFilterField values are integers.
+	IntFilterField FilterFieldType = "int"
+)
+
+// FilterField is a field of the resources of type T which a filter expression can use. Value returns a string, a
+// time.Time or an int64, depending on the Type.
+type FilterField[T any] struct {
+	Type  FilterFieldType
+	Value func(T) any
+}
+
+// The fields of every resource.
+func commonFilterFields[T metav1.Object]() map[string]FilterField[T] {
+	return map[string]FilterField[T]{
+		"name":      {Type: StringFilterField, Value: func(o T) any { return o.GetName() }},
+		"namespace": {Type: StringFilterField, Value: func(o T) any { return o.GetNamespace() }},
+		"user":      {Type: StringFilterField, Value: func(o T) any { return o.GetLabels()[util.RayClusterUserLabelKey] }},
+		"createdAt": {Type: TimeFilterField, Value: func(o T) any { return o.GetCreationTimestamp().Time }},
+	}
+}
+
+// ClusterFilterFields are the fields of the filter expressions of the ray clusters.
+var ClusterFilterFields = withFilterFields(commonFilterFields[*rayv1api.RayCluster](), map[string]FilterField[*rayv1api.RayCluster]{
+	"state":        {Type: StringFilterField, Value: func(c *rayv1api.RayCluster) any { return string(c.Status.State) }},
+	"clusterState": {Type: StringFilterField, Value: func(c *rayv1api.RayCluster) any { return string(c.Status.State) }},
+	"version":      {Type: StringFilterField, Value: func(c *rayv1api.RayCluster) any { return c.Labels[util.RayClusterVersionLabelKey] }},
+})
+
+// JobFilterFields are the fields of the filter expressions of the ray jobs.
+var JobFilterFields = withFilterFields(commonFilterFields[*rayv1api.RayJob](), map[string]FilterField[*rayv1api.RayJob]{
+	"state":               {Type: StringFilterField, Value: func(j *rayv1api.RayJob) any { return string(j.Status.JobStatus) }},
+	"jobStatus":           {Type: StringFilterField, Value: func(j *rayv1api.RayJob) any { return string(j.Status.JobStatus) }},
+	"jobDeploymentStatus": {Type: StringFilterField, Value: func(j *rayv1api.RayJob) any { return string(j.Status.JobDeploymentStatus) }},
+	"rayClusterName":      {Type: StringFilterField, Value: func(j *rayv1api.RayJob) any { return j.Status.RayClusterName }},
+	"failedAttempts": {Type: IntFilterField, Value: func(j *rayv1api.RayJob) any {
+		if j.Status.Failed == nil {
+			return int64(0)
+		}
+		return int64(*j.Status.Failed)
+	}},
+})
+
+// ServiceFilterFields are the fields of the filter expressions of the ray services.
+var ServiceFilterFields = withFilterFields(commonFilterFields[*rayv1api.RayService](), map[string]FilterField[*rayv1api.RayService]{
+	"state":           {Type: StringFilterField, Value: func(s *rayv1api.RayService) any { return string(s.Status.ServiceStatus) }},
+	"rayClusterName":  {Type: StringFilterField, Value: func(s *rayv1api.RayService) any { return s.Status.ActiveServiceStatus.RayClusterName }},
+	"rayClusterState": {Type: StringFilterField, Value: func(s *rayv1api.RayService) any { return string(s.Status.ActiveServiceStatus.RayClusterStatus.State) }},
+})
+
+func withFilterFields[T any](fields map[string]FilterField[T], extra map[string]FilterField[T]) map[string]FilterField[T] {
+	for name, field := range extra {
+		fields[name] = field
+	}
+	return fields
+}
+
+// filterCondition compares a field of a resource with a value, e.g. createdAt>2024-01-01.
+type filterCondition[T any] struct {
+	field    FilterField[T]
+	operator string
+	value    any
+}
+
+// ListFilter narrows down the resources of a List call to the ones matching every condition of a filter expression.
+// The zero value keeps every resource.
+type ListFilter[T any] struct {
+	conditions []filterCondition[T]
+}
+
+// filterConditionPattern matches a condition, the longer operators first.
+var filterConditionPattern = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9]*)\s*(!=|>=|<=|=|>|<)\s*(.*?)\s*$`)
+
+// ParseListFilter parses a filter expression made of conditions joined by AND, such as
+// "state=FAILED AND createdAt>2024-01-01 AND user=alice". A value with spaces is double quoted.
+func ParseListFilter[T any](expression string, fields map[string]FilterField[T]) (ListFilter[T], error) {
+	filter := ListFilter[T]{}
+	if strings.TrimSpace(expression) == "" {
+		return filter, nil
+	}
+	terms, err := splitFilterExpression(expression)
+	if err != nil {
+		return filter, err
+	}
+	for _, term := range terms {
+		match := filterConditionPattern.FindStringSubmatch(term)
+		if match == nil {
+			return filter, fmt.Errorf("condition %q is not of the form <field><operator><value>", strings.TrimSpace(term))
+		}
+		name, operator, rawValue := match[1], match[2], match[3]
+		field, ok := fields[name]
+		if !ok {
+			return filter, fmt.Errorf("field %s is not supported, the supported fields are %s", name, strings.Join(filterFieldNames(fields), ", "))
+		}
+		value, err := parseFilterValue(field.Type, operator, rawValue)
+		if err != nil {
+			return filter, fmt.Errorf("condition %q: %w", strings.TrimSpace(term), err)
+		}
+		filter.conditions = append(filter.conditions, filterCondition[T]{field: field, operator: operator, value: value})
+	}
+	return filter, nil
+}
+
+// splitFilterExpression splits an expression on the AND keywords which are not quoted.
+func splitFilterExpression(expression string) ([]string, error) {
+	terms := []string{}
+	start, quoted := 0, false
+	for i := 0; i < len(expression); i++ {
+		switch {
+		case expression[i] == '\\' && quoted:
+			i++
+		case expression[i] == '"':
+			quoted = !quoted
+		case !quoted && isFilterAnd(expression, i):
+			terms = append(terms, expression[start:i])
+			start = i + len("AND")
+			i = start - 1
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("a quoted value is not closed")
+	}
+	return append(terms, expression[start:]), nil
+}
+
+// isFilterAnd returns whether the expression has the AND keyword, in any case and surrounded by spaces, at index i.
+func isFilterAnd(expression string, i int) bool {
+	if i == 0 || i+len("AND") >= len(expression) || !strings.EqualFold(expression[i:i+len("AND")], "AND") {
+		return false
+	}
+	return expression[i-1] == ' ' && expression[i+len("AND")] == ' '
+}
+
+func parseFilterValue(fieldType FilterFieldType, operator string, rawValue string) (any, error) {
+	if strings.HasPrefix(rawValue, `"`) {
+		unquoted, err := strconv.Unquote(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("value %s is not correctly quoted", rawValue)
+		}
+		rawValue = unquoted
+	} else if rawValue == "" {
+		return nil, fmt.Errorf("value is empty, use \"\" to match an empty value")
+	}
+	switch fieldType {
+	case TimeFilterField:
+		if value, err := time.Parse(time.RFC3339, rawValue); err == nil {
+			return value, nil
+		}
+		value, err := time.Parse(time.DateOnly, rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("value %s is not an RFC 3339 time or a date such as 2024-01-01", rawValue)
+		}
+		return value, nil
+	case IntFilterField:
+		value, err := strconv.ParseInt(rawValue, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %s is not an integer", rawValue)
+		}
+		return value, nil
+	default:
+		if operator != "=" && operator != "!=" {
+			return nil, fmt.Errorf("operator %s can not compare text, use = or !=", operator)
+		}
+		return rawValue, nil
+	}
+}
+
+func filterFieldNames[T any](fields map[string]FilterField[T]) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsEmpty returns whether the filter keeps every resource.
+func (f ListFilter[T]) IsEmpty() bool {
+	return len(f.conditions) == 0
+}
+
+// Matches returns whether the resource matches every condition of the filter.
+func (f ListFilter[T]) Matches(resource T) bool {
+	for _, condition := range f.conditions {
+		if !condition.matches(resource) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the resources matching the filter, in their order.
+func (f ListFilter[T]) Apply(resources []T) []T {
+	if f.IsEmpty() {
+		return resources
+	}
+	filtered := make([]T, 0, len(resources))
+	for _, resource := range resources {
+		if f.Matches(resource) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
+
+func (c filterCondition[T]) matches(resource T) bool {
+	var comparison int
+	switch value := c.field.Value(resource).(type) {
+	case time.Time:
+		comparison = value.Compare(c.value.(time.Time))
+	case int64:
+		comparison = cmp.Compare(value, c.value.(int64))
+	default:
+		if c.operator == "=" {
+			return value == c.value
+		}
+		return value != c.value
+	}
+	switch c.operator {
+	case "=":
+		return comparison == 0
+	case "!=":
+		return comparison != 0
+	case ">":
+		return comparison > 0
+	case ">=":
+		return comparison >= 0
+	case "<":
+		return comparison < 0
+	default:
+		return comparison <= 0
+	}
+}
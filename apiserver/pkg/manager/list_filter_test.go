@@ -0,0 +1,131 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestListFilter(t *testing.T) {
+	newJob := func(name string, user string, status rayv1api.JobStatus, createdAt time.Time, failed int32) *rayv1api.RayJob {
+		return &rayv1api.RayJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "team-a",
+				Labels:            map[string]string{util.RayClusterUserLabelKey: user},
+				CreationTimestamp: metav1.NewTime(createdAt),
+			},
+			Status: rayv1api.RayJobStatus{JobStatus: status, Failed: &failed},
+		}
+	}
+	jobs := []*rayv1api.RayJob{
+		newJob("old-failure", "alice", rayv1api.JobStatusFailed, time.Date(2023, 12, 31, 10, 0, 0, 0, time.UTC), 1),
+		newJob("failure", "alice", rayv1api.JobStatusFailed, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), 3),
+		newJob("other-user", "bob", rayv1api.JobStatusFailed, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), 0),
+		newJob("success", "alice", rayv1api.JobStatusSucceeded, time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC), 0),
+	}
+	names := func(jobs []*rayv1api.RayJob) []string {
+		result := []string{}
+		for _, job := range jobs {
+			result = append(result, job.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{
+			name:       "An empty expression keeps every job",
+			expression: " ",
+			expected:   []string{"old-failure", "failure", "other-user", "success"},
+		},
+		{
+			name:       "Every condition must match",
+			expression: "state=FAILED AND createdAt>2024-01-01 AND user=alice",
+			expected:   []string{"failure"},
+		},
+		{
+			name:       "The keyword is case insensitive and values can be quoted",
+			expression: `jobStatus!="FAILED" and name = success`,
+			expected:   []string{"success"},
+		},
+		{
+			name:       "Times can be RFC 3339",
+			expression: "createdAt<=2024-03-01T10:00:00Z",
+			expected:   []string{"old-failure", "failure", "other-user"},
+		},
+		{
+			name:       "Integers are compared as numbers",
+			expression: "failedAttempts>=1 AND failedAttempts<10",
+			expected:   []string{"old-failure", "failure"},
+		},
+		{
+			name:       "A quoted value can contain the keyword",
+			expression: `user="alice AND bob"`,
+			expected:   []string{},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc // capture range variable
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := ParseListFilter(tc.expression, JobFilterFields)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, names(filter.Apply(jobs)))
+		})
+	}
+}
+
+func TestParseListFilterErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{
+			name:       "An unknown field",
+			expression: "color=red",
+			expected:   "field color is not supported, the supported fields are clusterState, createdAt, name, namespace, state, user, version",
+		},
+		{
+			name:       "A condition without operator",
+			expression: "state=ready AND ready",
+			expected:   `condition "ready" is not of the form <field><operator><value>`,
+		},
+		{
+			name:       "Text compared with an ordering operator",
+			expression: "name>a",
+			expected:   `condition "name>a": operator > can not compare text, use = or !=`,
+		},
+		{
+			name:       "An invalid time",
+			expression: "createdAt>yesterday",
+			expected:   `condition "createdAt>yesterday": value yesterday is not an RFC 3339 time or a date such as 2024-01-01`,
+		},
+		{
+			name:       "An empty value",
+			expression: "user=",
+			expected:   `condition "user=": value is empty, use "" to match an empty value`,
+		},
+		{
+			name:       "A quoted value which is not closed",
+			expression: `user="alice`,
+			expected:   "a quoted value is not closed",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc // capture range variable
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseListFilter(tc.expression, ClusterFilterFields)
+			require.EqualError(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package manager
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	klog "k8s.io/klog/v2"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// submitterJobNameLabelKey is the label Kubernetes sets on the Pods of a Job, the submitter Job of a RayJob has
+// the name of the RayJob.
+const submitterJobNameLabelKey = "job-name"
+
+// PodLogOptions select the Pods and the lines of their logs which are streamed.
+type PodLogOptions struct {
+	// Follow streams the new lines until ctx is done or the Pods stop.
+	Follow bool
+	// TailLines is the number of lines from the end of the logs of every Pod to start from, all the lines if 0.
+	TailLines int64
+	// IncludeWorkers streams the logs of the worker Pods in addition to the logs of the head Pod.
+	IncludeWorkers bool
+}
+
+// StreamServiceLogs sends the log lines of the Pods of the RayCluster serving a RayService to the returned channel,
+// which is closed once all the logs are streamed. The pending RayCluster is used while there is no active one.
+func (r *ResourceManager) StreamServiceLogs(ctx context.Context, serviceName string, namespace string, options PodLogOptions) (<-chan *api.PodLogLine, error) {
+	service, err := getServiceByName(ctx, r.getRayServiceClient(namespace), serviceName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get service failure")
+	}
+	clusterName := service.Status.ActiveServiceStatus.RayClusterName
+	if clusterName == "" {
+		clusterName = service.Status.PendingServiceStatus.RayClusterName
+	}
+	if clusterName == "" {
+		return nil, util.NewFailedPreconditionError("Service %s has no ray cluster yet", serviceName)
+	}
+	pods, err := r.listClusterLogPods(ctx, namespace, clusterName, options.IncludeWorkers)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, util.NewFailedPreconditionError("Cluster %s of service %s has no Pods", clusterName, serviceName)
+	}
+	return r.streamPodLogs(ctx, namespace, pods, options), nil
+}
+
+// StreamJobLogs sends the log lines of the submitter Pods and of the Pods of the RayCluster of a RayJob to the
+// returned channel, which is closed once all the logs are streamed.
+func (r *ResourceManager) StreamJobLogs(ctx context.Context, jobName string, namespace string, options PodLogOptions) (<-chan *api.PodLogLine, error) {
+	job, err := getJobByName(ctx, r.getRayJobClient(namespace), jobName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get job failure")
+	}
+	podClient := r.clientManager.KubernetesClient().PodClient(namespace)
+	submitters, err := podClient.List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{submitterJobNameLabelKey: job.Name}).String(),
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the submitter Pods of job %s", jobName)
+	}
+	pods := submitters.Items
+	if job.Status.RayClusterName != "" {
+		clusterPods, err := r.listClusterLogPods(ctx, namespace, job.Status.RayClusterName, options.IncludeWorkers)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, clusterPods...)
+	}
+	if len(pods) == 0 {
+		return nil, util.NewFailedPreconditionError("Job %s has no Pods", jobName)
+	}
+	return r.streamPodLogs(ctx, namespace, pods, options), nil
+}
+
+// listClusterLogPods lists the head Pod of a RayCluster, followed by its worker Pods if includeWorkers is set.
+func (r *ResourceManager) listClusterLogPods(ctx context.Context, namespace string, clusterName string, includeWorkers bool) ([]corev1.Pod, error) {
+	selector := map[string]string{utils.RayClusterLabelKey: clusterName}
+	if !includeWorkers {
+		selector[utils.RayNodeTypeLabelKey] = string(rayv1api.HeadNode)
+	}
+	pods, err := r.clientManager.KubernetesClient().PodClient(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the Pods of cluster %s", clusterName)
+	}
+	head, workers := []corev1.Pod{}, []corev1.Pod{}
+	for _, pod := range pods.Items {
+		if pod.Labels[utils.RayNodeTypeLabelKey] == string(rayv1api.HeadNode) {
+			head = append(head, pod)
+		} else {
+			workers = append(workers, pod)
+		}
+	}
+	return append(head, workers...), nil
+}
+
+// streamPodLogs streams the logs of the first container of every Pod, which runs Ray or the submitter, concurrently.
+// A Pod whose logs can't be read, e.g. because it is still pending, is skipped.
+func (r *ResourceManager) streamPodLogs(ctx context.Context, namespace string, pods []corev1.Pod, options PodLogOptions) <-chan *api.PodLogLine {
+	podClient := r.clientManager.KubernetesClient().PodClient(namespace)
+	lines := make(chan *api.PodLogLine)
+	var wg sync.WaitGroup
+	for _, pod := range pods {
+		if len(pod.Spec.Containers) == 0 {
+			continue
+		}
+		podName, container := pod.Name, pod.Spec.Containers[utils.RayContainerIndex].Name
+		logOptions := &corev1.PodLogOptions{Container: container, Follow: options.Follow}
+		if options.TailLines > 0 {
+			logOptions.TailLines = &options.TailLines
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream, err := podClient.GetLogs(podName, logOptions).Stream(ctx)
+			if err != nil {
+				klog.FromContext(ctx).Error(err, "Failed to stream the logs of the Pod", "pod", klog.KRef(namespace, podName))
+				return
+			}
+			defer stream.Close()
+			scanner := bufio.NewScanner(stream)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				line := &api.PodLogLine{PodName: podName, Container: container, Line: strings.TrimSuffix(scanner.Text(), "\r")}
+				if !sendObject(ctx, lines, line) {
+					return
+				}
+			}
+			if err := scanner.Err(); err != nil && ctx.Err() == nil {
+				klog.FromContext(ctx).Error(err, "Failed to read the logs of the Pod", "pod", klog.KRef(namespace, podName))
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+	return lines
+}
+
+// GetJobSubmitterLogs returns the logs of the most recent submitter Pod of a RayJob, which prints the logs of the
+// driver of the job, or a not found error if the RayJob has no submitter Pod.
+func (r *ResourceManager) GetJobSubmitterLogs(ctx context.Context, jobName string, namespace string, tailLines int64) (string, error) {
+	podClient := r.clientManager.KubernetesClient().PodClient(namespace)
+	submitters, err := podClient.List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{submitterJobNameLabelKey: jobName}).String(),
+	})
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to list the submitter Pods of job %s", jobName)
+	}
+	var latest *corev1.Pod
+	for i, pod := range submitters.Items {
+		if len(pod.Spec.Containers) > 0 && (latest == nil || latest.CreationTimestamp.Before(&pod.CreationTimestamp)) {
+			latest = &submitters.Items[i]
+		}
+	}
+	if latest == nil {
+		return "", util.NewNotFoundError(fmt.Errorf("no Pod has the label %s=%s", submitterJobNameLabelKey, jobName), "Job %s has no submitter Pod", jobName)
+	}
+	logOptions := &corev1.PodLogOptions{Container: latest.Spec.Containers[utils.RayContainerIndex].Name}
+	if tailLines > 0 {
+		logOptions.TailLines = &tailLines
+	}
+	logs, err := podClient.GetLogs(latest.Name, logOptions).DoRaw(ctx)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to read the logs of the submitter Pod %s of job %s", latest.Name, jobName)
+	}
+	return string(logs), nil
+}
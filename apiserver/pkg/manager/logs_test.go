@@ -0,0 +1,119 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func createLogPod(ctx context.Context, t *testing.T, clientManager *FakeClientManager, name string, podLabels map[string]string) {
+	_, err := clientManager.KubernetesClient().PodClient("team-a").Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Labels: podLabels},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "ray"}}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func createClusterLogPods(ctx context.Context, t *testing.T, clientManager *FakeClientManager, clusterName string) {
+	createLogPod(ctx, t, clientManager, clusterName+"-worker", map[string]string{
+		utils.RayClusterLabelKey:  clusterName,
+		utils.RayNodeTypeLabelKey: string(rayv1api.WorkerNode),
+	})
+	createLogPod(ctx, t, clientManager, clusterName+"-head", map[string]string{
+		utils.RayClusterLabelKey:  clusterName,
+		utils.RayNodeTypeLabelKey: string(rayv1api.HeadNode),
+	})
+}
+
+func collectLogPods(lines <-chan *api.PodLogLine) []string {
+	pods := []string{}
+	for line := range lines {
+		pods = append(pods, line.PodName)
+	}
+	return pods
+}
+
+func TestStreamServiceLogs(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	managedLabels := map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName}
+
+	_, err := clientManager.clients.Ray.RayV1().RayServices("team-a").Create(ctx, &rayv1api.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "team-a", Labels: managedLabels},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = resourceManager.StreamServiceLogs(ctx, "service", "team-a", PodLogOptions{})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "The service has no cluster yet")
+
+	_, err = clientManager.clients.Ray.RayV1().RayServices("team-a").UpdateStatus(ctx, &rayv1api.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "team-a", Labels: managedLabels},
+		Status: rayv1api.RayServiceStatuses{
+			ActiveServiceStatus: rayv1api.RayServiceStatus{RayClusterName: "service-raycluster-abcde"},
+		},
+	}, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	createClusterLogPods(ctx, t, clientManager, "service-raycluster-abcde")
+
+	lines, err := resourceManager.StreamServiceLogs(ctx, "service", "team-a", PodLogOptions{TailLines: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service-raycluster-abcde-head"}, collectLogPods(lines))
+
+	lines, err = resourceManager.StreamServiceLogs(ctx, "service", "team-a", PodLogOptions{IncludeWorkers: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"service-raycluster-abcde-head", "service-raycluster-abcde-worker"}, collectLogPods(lines))
+
+	_, err = resourceManager.StreamServiceLogs(ctx, "missing", "team-a", PodLogOptions{})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestStreamJobLogs(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	job, err := clientManager.clients.Ray.RayV1().RayJobs("team-a").Create(ctx, &rayv1api.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job",
+			Namespace: "team-a",
+			Labels:    map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = resourceManager.StreamJobLogs(ctx, "job", "team-a", PodLogOptions{})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "The job has no Pods yet")
+
+	job.Status.RayClusterName = "job-raycluster-abcde"
+	_, err = clientManager.clients.Ray.RayV1().RayJobs("team-a").UpdateStatus(ctx, job, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	createClusterLogPods(ctx, t, clientManager, "job-raycluster-abcde")
+	createLogPod(ctx, t, clientManager, "job-submitter", map[string]string{submitterJobNameLabelKey: "job"})
+
+	lines, err := resourceManager.StreamJobLogs(ctx, "job", "team-a", PodLogOptions{Follow: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"job-submitter", "job-raycluster-abcde-head"}, collectLogPods(lines))
+}
+
+func TestGetJobSubmitterLogs(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	_, err := resourceManager.GetJobSubmitterLogs(ctx, "job", "team-a", 0)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	createLogPod(ctx, t, clientManager, "job-submitter", map[string]string{submitterJobNameLabelKey: "job"})
+	logs, err := resourceManager.GetJobSubmitterLogs(ctx, "job", "team-a", 10)
+	require.NoError(t, err)
+	assert.NotEmpty(t, logs)
+}
@@ -0,0 +1,189 @@
+package manager
+
+import (
+	"context"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// EnsureNamespace creates a namespace if it does not exist, and sets it up with the namespace template of the config.
+// The labels and annotations the namespace misses are added, and the resources of the template are created unless
+// a resource with the same name exists, so that the namespaces set up before the template changed are not changed.
+func (r *ResourceManager) EnsureNamespace(ctx context.Context, name string, labels map[string]string) (*api.Namespace, error) {
+	cfg := config.Get()
+	if err := checkNamespaceAllowed(cfg, name); err != nil {
+		return nil, err
+	}
+	template := cfg.NamespaceTemplate
+	// The labels of the template win over the labels of the request.
+	wantLabels := map[string]string{}
+	for key, value := range labels {
+		wantLabels[key] = value
+	}
+	for key, value := range template.Labels {
+		wantLabels[key] = value
+	}
+
+	var created []string
+	client := r.getKubernetesNamespaceClient()
+	namespace, err := client.Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: wantLabels}}
+		namespace.Labels[util.KubernetesManagedByLabelKey] = util.ComponentName
+		addMissing(&namespace.Annotations, template.Annotations)
+		if namespace, err = client.Create(ctx, namespace, metav1.CreateOptions{}); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to create namespace %s", name)
+		}
+		created = append(created, "Namespace/"+name)
+	case err != nil:
+		return nil, util.NewInternalServerError(err, "Failed to get namespace %s", name)
+	default:
+		if namespace.Status.Phase == corev1.NamespaceTerminating {
+			return nil, util.NewFailedPreconditionError("Namespace %s is being deleted", name)
+		}
+		updated := addMissing(&namespace.Labels, wantLabels)
+		updated = addMissing(&namespace.Annotations, template.Annotations) || updated
+		if updated {
+			if namespace, err = client.Update(ctx, namespace, metav1.UpdateOptions{}); err != nil {
+				return nil, util.NewInternalServerError(err, "Failed to update the labels and annotations of namespace %s", name)
+			}
+		}
+	}
+
+	if len(template.ResourceQuota) > 0 {
+		quota := &corev1.ResourceQuota{
+			ObjectMeta: namespaceTemplateObjectMeta(util.NamespaceTemplateResourceQuotaName, name),
+			Spec:       corev1.ResourceQuotaSpec{Hard: template.ResourceQuota.DeepCopy()},
+		}
+		_, err := r.clientManager.KubernetesClient().ResourceQuotaClient(name).Create(ctx, quota, metav1.CreateOptions{})
+		if created, err = appendCreated(created, "ResourceQuota/"+quota.Name, err); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to create resource quota %s in namespace %s", quota.Name, name)
+		}
+	}
+	for _, templatePolicy := range template.NetworkPolicies {
+		policy := &networkingv1.NetworkPolicy{
+			ObjectMeta: namespaceTemplateObjectMeta(templatePolicy.Name, name),
+			Spec:       *templatePolicy.Spec.DeepCopy(),
+		}
+		_, err := r.clientManager.KubernetesClient().NetworkPolicyClient(name).Create(ctx, policy, metav1.CreateOptions{})
+		if created, err = appendCreated(created, "NetworkPolicy/"+policy.Name, err); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to create network policy %s in namespace %s", policy.Name, name)
+		}
+	}
+	for _, computeTemplate := range template.ComputeTemplates {
+		_, err := r.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+			Name:           computeTemplate.Name,
+			Namespace:      name,
+			Cpu:            computeTemplate.CPU,
+			Memory:         computeTemplate.Memory,
+			Gpu:            computeTemplate.GPU,
+			GpuAccelerator: computeTemplate.GPUAccelerator,
+		})
+		if util.IsUserErrorCodeMatch(err, codes.AlreadyExists) {
+			continue
+		}
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to create compute template %s in namespace %s", computeTemplate.Name, name)
+		}
+		created = append(created, "ComputeTemplate/"+computeTemplate.Name)
+	}
+
+	apiNamespace, err := r.toAPINamespace(ctx, namespace, template)
+	if err != nil {
+		return nil, err
+	}
+	apiNamespace.CreatedResources = created
+	return apiNamespace, nil
+}
+
+// GetNamespace returns a namespace, with the resources of the namespace template of the config it has.
+func (r *ResourceManager) GetNamespace(ctx context.Context, name string) (*api.Namespace, error) {
+	namespace, err := r.getKubernetesNamespaceClient().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, util.NewNotFoundError(err, "Namespace %s not found", name)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to get namespace %s", name)
+	}
+	return r.toAPINamespace(ctx, namespace, config.Get().NamespaceTemplate)
+}
+
+// toAPINamespace converts a namespace and lists the resources of the template which exist in it.
+func (r *ResourceManager) toAPINamespace(ctx context.Context, namespace *corev1.Namespace, template config.NamespaceTemplate) (*api.Namespace, error) {
+	apiNamespace := model.FromKubeToAPINamespace(namespace)
+	kubernetesClient := r.clientManager.KubernetesClient()
+	exists := func(resource string, err error) error {
+		switch {
+		case err == nil:
+			apiNamespace.TemplateResources = append(apiNamespace.TemplateResources, resource)
+		case !errors.IsNotFound(err) && !util.IsUserErrorCodeMatch(err, codes.NotFound):
+			return util.NewInternalServerError(err, "Failed to get %s in namespace %s", resource, namespace.Name)
+		}
+		return nil
+	}
+
+	if len(template.ResourceQuota) > 0 {
+		_, err := kubernetesClient.ResourceQuotaClient(namespace.Name).Get(ctx, util.NamespaceTemplateResourceQuotaName, metav1.GetOptions{})
+		if err := exists("ResourceQuota/"+util.NamespaceTemplateResourceQuotaName, err); err != nil {
+			return nil, err
+		}
+	}
+	for _, policy := range template.NetworkPolicies {
+		_, err := kubernetesClient.NetworkPolicyClient(namespace.Name).Get(ctx, policy.Name, metav1.GetOptions{})
+		if err := exists("NetworkPolicy/"+policy.Name, err); err != nil {
+			return nil, err
+		}
+	}
+	for _, computeTemplate := range template.ComputeTemplates {
+		_, err := r.GetComputeTemplate(ctx, computeTemplate.Name, namespace.Name)
+		if err := exists("ComputeTemplate/"+computeTemplate.Name, err); err != nil {
+			return nil, err
+		}
+	}
+	return apiNamespace, nil
+}
+
+func namespaceTemplateObjectMeta(name string, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName},
+	}
+}
+
+// appendCreated appends the resource to created if it was created. A resource which already exists is not an error.
+func appendCreated(created []string, resource string, err error) ([]string, error) {
+	if err == nil {
+		return append(created, resource), nil
+	}
+	if errors.IsAlreadyExists(err) {
+		return created, nil
+	}
+	return created, err
+}
+
+// addMissing adds the entries of values whose key is not in the map, and returns whether it added one.
+func addMissing(m *map[string]string, values map[string]string) bool {
+	added := false
+	for key, value := range values {
+		if _, ok := (*m)[key]; ok {
+			continue
+		}
+		if *m == nil {
+			*m = map[string]string{}
+		}
+		(*m)[key] = value
+		added = true
+	}
+	return added
+}
@@ -0,0 +1,83 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+func TestEnsureNamespace(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	kubernetesClient := resourceManager.clientManager.KubernetesClient()
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	config.Set(&config.Config{NamespaceTemplate: config.NamespaceTemplate{
+		Labels:        map[string]string{"ray.io/tenant": "true"},
+		Annotations:   map[string]string{"owner": "platform"},
+		ResourceQuota: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("64")},
+		NetworkPolicies: []config.NamespaceNetworkPolicy{{
+			Name: "same-namespace",
+			Spec: networkingv1.NetworkPolicySpec{Ingress: []networkingv1.NetworkPolicyIngressRule{{From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}}}},
+		}},
+		ComputeTemplates: []config.NamespaceComputeTemplate{{Name: "small", CPU: 2, Memory: 4}, {Name: "gpu", CPU: 8, Memory: 32, GPU: 1}},
+	}})
+
+	_, err := resourceManager.GetNamespace(ctx, "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	namespace, err := resourceManager.EnsureNamespace(ctx, "team-a", map[string]string{"team": "a", "ray.io/tenant": "false"})
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", namespace.Name)
+	assert.Equal(t, map[string]string{"team": "a", "ray.io/tenant": "true", util.KubernetesManagedByLabelKey: util.ComponentName}, namespace.Labels)
+	assert.Equal(t, map[string]string{"owner": "platform"}, namespace.Annotations)
+	resources := []string{"ResourceQuota/ray-namespace-quota", "NetworkPolicy/same-namespace", "ComputeTemplate/small", "ComputeTemplate/gpu"}
+	assert.Equal(t, append([]string{"Namespace/team-a"}, resources...), namespace.CreatedResources)
+	assert.Equal(t, resources, namespace.TemplateResources)
+
+	quota, err := kubernetesClient.ResourceQuotaClient("team-a").Get(ctx, util.NamespaceTemplateResourceQuotaName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "64", quota.Spec.Hard.Name(corev1.ResourceRequestsCPU, resource.DecimalSI).String())
+	computeTemplate, err := resourceManager.GetComputeTemplate(ctx, "gpu", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", computeTemplate.Data["gpu"])
+
+	// Ensuring the namespace again keeps the resources changed since and creates the missing ones.
+	require.NoError(t, resourceManager.DeleteComputeTemplate(ctx, "gpu", "team-a"))
+	quota.Spec.Hard[corev1.ResourceRequestsCPU] = resource.MustParse("128")
+	_, err = kubernetesClient.ResourceQuotaClient("team-a").Update(ctx, quota, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	namespace, err = resourceManager.GetNamespace(ctx, "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, resources[:3], namespace.TemplateResources)
+
+	namespace, err = resourceManager.EnsureNamespace(ctx, "team-a", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ComputeTemplate/gpu"}, namespace.CreatedResources)
+	assert.Equal(t, resources, namespace.TemplateResources)
+	quota, err = kubernetesClient.ResourceQuotaClient("team-a").Get(ctx, util.NamespaceTemplateResourceQuotaName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "128", quota.Spec.Hard.Name(corev1.ResourceRequestsCPU, resource.DecimalSI).String())
+
+	// The missing labels and annotations are added to the existing namespaces.
+	_, err = kubernetesClient.NamespaceClient().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"ray.io/tenant": "no"}}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	namespace, err = resourceManager.EnsureNamespace(ctx, "team-b", map[string]string{"team": "b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "b", "ray.io/tenant": "no"}, namespace.Labels)
+	assert.Equal(t, map[string]string{"owner": "platform"}, namespace.Annotations)
+	assert.Equal(t, resources, namespace.CreatedResources)
+
+	config.Set(&config.Config{Allowlists: config.Allowlists{Namespaces: []string{"team-a"}}})
+	_, err = resourceManager.EnsureNamespace(ctx, "team-c", nil)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied))
+}
@@ -0,0 +1,154 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// CheckNodeCapacity checks that every Pod of a cluster spec, or of a compute template when the cluster spec is nil,
+// fits on the allocatable resources of a Node which matches its node selector and tolerations, even if nothing else
+// ran on the Node. Unlike CanSchedule, the Pods running on the Nodes are not counted, so that only the Pods which can
+// never be scheduled are reported. With the warn node capacity validation of the config, the reasons are returned as
+// warnings, with reject the creation fails. Nothing is checked if the validation is disabled or if the Kubernetes
+// cluster has no Node.
+func (r *ResourceManager) CheckNodeCapacity(ctx context.Context, namespace string, clusterSpec *api.ClusterSpec, computeTemplate *api.ComputeTemplate) ([]string, error) {
+	mode := config.Get().Validations.NodeCapacity
+	if mode == "" {
+		return nil, nil
+	}
+	var pods []podSet
+	if clusterSpec == nil {
+		spec, err := util.NewComputeTemplatePodSpec(computeTemplate)
+		if err != nil {
+			return nil, util.NewInvalidInputErrorWithDetails(err, fmt.Sprintf("Failed to build the Pods of compute template %s", computeTemplate.Name))
+		}
+		pods = []podSet{{description: "the pods of compute template " + computeTemplate.Name, spec: *spec, count: 1}}
+	} else {
+		var err error
+		if pods, err = r.podSets(ctx, namespace, clusterSpec, "", 0); err != nil {
+			return nil, err
+		}
+	}
+	// The Nodes are read by the API server itself, even for an impersonated caller, who is rarely allowed to read them.
+	nodes, err := r.clientManager.KubernetesClient().NodeClient().List(client.WithoutImpersonation(ctx), metav1.ListOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the nodes")
+	}
+	if len(nodes.Items) == 0 {
+		return nil, nil
+	}
+	reasons := nodeCapacityReasons(pods, nodes.Items)
+	if len(reasons) > 0 && mode == config.NodeCapacityReject {
+		return nil, util.NewFailedPreconditionError("%s. The pods can never be scheduled, please request less resources.", strings.Join(reasons, ". "))
+	}
+	return reasons, nil
+}
+
+// nodeCapacityReasons returns why Pods do not fit on the allocatable resources of any Node accepting them. The
+// resources are compared by name, so that e.g. Pods requesting nvidia.com/gpu do not fit on Nodes with amd.com/gpu.
+func nodeCapacityReasons(pods []podSet, nodes []corev1.Node) []string {
+	var reasons []string
+	for _, set := range pods {
+		requests := podResourceRequests(set.spec)
+		accepting := 0
+		fits := false
+		largest := corev1.ResourceList{}
+		for i := range nodes {
+			node := &nodes[i]
+			if !acceptsPods(node, set.spec) {
+				continue
+			}
+			accepting++
+			fits = true
+			for name, request := range requests {
+				allocatable := node.Status.Allocatable[name]
+				if allocatable.Cmp(request) < 0 {
+					fits = false
+				}
+				if current := largest[name]; allocatable.Cmp(current) > 0 {
+					largest[name] = allocatable
+				}
+			}
+			if fits {
+				break
+			}
+		}
+		if fits {
+			continue
+		}
+		if accepting == 0 {
+			reasons = append(reasons, fmt.Sprintf("No node matches the node selector and tolerations of %s", set.description))
+			continue
+		}
+		exceeded := false
+		for _, name := range sortedResourceNames(requests) {
+			request, allocatable := requests[name], largest[name]
+			if request.Cmp(allocatable) > 0 {
+				exceeded = true
+				reasons = append(reasons, fmt.Sprintf("No node accepting %s has %s of %s allocatable, at most %s",
+					set.description, request.String(), name, allocatable.String()))
+			}
+		}
+		if !exceeded {
+			reasons = append(reasons, fmt.Sprintf("No node accepting %s has all the resources of one of its pods allocatable: %s",
+				set.description, formatResourceList(requests)))
+		}
+	}
+	return reasons
+}
+
+// podResourceRequests returns the resources which the scheduler reserves for a Pod, by name: the requests of its
+// containers, or their limits if they have no request.
+func podResourceRequests(spec corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	add := func(name corev1.ResourceName, quantity resource.Quantity) {
+		sum := requests[name]
+		sum.Add(quantity)
+		requests[name] = sum
+	}
+	for _, container := range spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			add(name, quantity)
+		}
+		for name, quantity := range container.Resources.Limits {
+			if _, ok := container.Resources.Requests[name]; !ok {
+				add(name, quantity)
+			}
+		}
+	}
+	for name, quantity := range requests {
+		if quantity.IsZero() {
+			delete(requests, name)
+		}
+	}
+	return requests
+}
+
+func sortedResourceNames(resources corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+func formatResourceList(resources corev1.ResourceList) string {
+	parts := make([]string, 0, len(resources))
+	for _, name := range sortedResourceNames(resources) {
+		quantity := resources[name]
+		parts = append(parts, fmt.Sprintf("%s %s", quantity.String(), name))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,96 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestCheckNodeCapacity(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	kubernetes := clientManager.clients.Kubernetes
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+
+	large := &api.ComputeTemplate{Name: "large", Namespace: "team-a", Cpu: 16, Memory: 8}
+	gpu := &api.ComputeTemplate{Name: "gpu", Namespace: "team-a", Cpu: 4, Memory: 8, Gpu: 2}
+	gpuTolerated := &api.ComputeTemplate{
+		Name: "gpu-tolerated", Namespace: "team-a", Cpu: 4, Memory: 8, Gpu: 2,
+		Tolerations: []*api.PodToleration{{Key: "nvidia.com/gpu", Operator: "Exists", Effect: "NoSchedule"}},
+	}
+
+	// Nothing is checked without the validation, nor without nodes.
+	warnings, err := resourceManager.CheckNodeCapacity(ctx, "team-a", nil, large)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	config.Set(&config.Config{Validations: config.Validations{NodeCapacity: config.NodeCapacityWarn}})
+	warnings, err = resourceManager.CheckNodeCapacity(ctx, "team-a", nil, large)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	for _, node := range []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-cpu"},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("64Gi"),
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-gpu"},
+			Spec:       corev1.NodeSpec{Taints: []corev1.Taint{{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule}}},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("32"),
+				corev1.ResourceMemory: resource.MustParse("128Gi"),
+				"nvidia.com/gpu":      resource.MustParse("4"),
+			}},
+		},
+	} {
+		_, err = kubernetes.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	warnings, err = resourceManager.CheckNodeCapacity(ctx, "team-a", nil, large)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"No node accepting the pods of compute template large has 16 of cpu allocatable, at most 8"}, warnings)
+	warnings, err = resourceManager.CheckNodeCapacity(ctx, "team-a", nil, gpu)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"No node accepting the pods of compute template gpu has 2 of nvidia.com/gpu allocatable, at most 0"}, warnings)
+	warnings, err = resourceManager.CheckNodeCapacity(ctx, "team-a", nil, gpuTolerated)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	// The pods of the clusters are checked with their compute templates.
+	_, err = resourceManager.CreateComputeTemplate(ctx, gpu)
+	require.NoError(t, err)
+	_, err = resourceManager.CreateComputeTemplate(ctx, gpuTolerated)
+	require.NoError(t, err)
+	clusterSpec := &api.ClusterSpec{
+		HeadGroupSpec: &api.HeadGroupSpec{ComputeTemplate: "gpu-tolerated"},
+		WorkerGroupSpec: []*api.WorkerGroupSpec{
+			{GroupName: "workers", ComputeTemplate: "gpu", Replicas: 1},
+		},
+	}
+	warnings, err = resourceManager.CheckNodeCapacity(ctx, "team-a", clusterSpec, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"No node accepting the pods of worker group workers has 2 of nvidia.com/gpu allocatable, at most 0"}, warnings)
+
+	config.Set(&config.Config{Validations: config.Validations{NodeCapacity: config.NodeCapacityReject}})
+	_, err = resourceManager.CheckNodeCapacity(ctx, "team-a", clusterSpec, nil)
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(util.ToGRPCError(err)))
+	_, err = resourceManager.CheckNodeCapacity(ctx, "team-a", nil, gpuTolerated)
+	require.NoError(t, err)
+}
@@ -0,0 +1,401 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"syscall"
+	"time"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// Notification subscriptions are stored in ConfigMaps. The state transitions they subscribe to are found by the
+// Notifier, which polls the ray jobs and ray services.
+
+const notificationSubscriptionSelector = "ray.io/config-type=" + util.NotificationSubscriptionConfigType
+
+func (r *ResourceManager) CreateNotificationSubscription(ctx context.Context, apiSubscription *api.NotificationSubscription) (*corev1.ConfigMap, error) {
+	if err := checkNamespaceAllowed(config.Get(), apiSubscription.Namespace); err != nil {
+		return nil, err
+	}
+	if _, err := r.GetNotificationSubscription(ctx, apiSubscription.Name, apiSubscription.Namespace); err == nil {
+		return nil, util.NewAlreadyExistError("Notification subscription with name %s already exists in namespace %s", apiSubscription.Name, apiSubscription.Namespace)
+	}
+
+	configMap := util.NewNotificationSubscription(apiSubscription)
+	newConfigMap, err := r.getKubernetesConfigMapClient(apiSubscription.Namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a notification subscription for (%s/%s)", apiSubscription.Namespace, apiSubscription.Name)
+	}
+	return newConfigMap, nil
+}
+
+func (r *ResourceManager) GetNotificationSubscription(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
+	configMap, err := r.getKubernetesConfigMapClient(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, util.NewNotFoundError(err, "Notification subscription %s not found", name)
+		}
+		return nil, util.Wrap(err, "Get notification subscription failed")
+	}
+	if configMap.Labels["ray.io/config-type"] != util.NotificationSubscriptionConfigType {
+		return nil, util.NewNotFoundError(fmt.Errorf("ConfigMap %s is not a notification subscription", name), "Notification subscription %s not found", name)
+	}
+	return configMap, nil
+}
+
+// ListNotificationSubscriptions lists the notification subscriptions in a namespace, or in all namespaces for
+// metav1.NamespaceAll.
+func (r *ResourceManager) ListNotificationSubscriptions(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error) {
+	configMapList, err := r.getKubernetesConfigMapClient(namespace).List(ctx, metav1.ListOptions{LabelSelector: notificationSubscriptionSelector})
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("List notification subscriptions failed in %s", namespace))
+	}
+
+	result := make([]*corev1.ConfigMap, 0, len(configMapList.Items))
+	for i := range configMapList.Items {
+		result = append(result, &configMapList.Items[i])
+	}
+	return result, nil
+}
+
+func (r *ResourceManager) DeleteNotificationSubscription(ctx context.Context, name string, namespace string) error {
+	configMap, err := r.GetNotificationSubscription(ctx, name, namespace)
+	if err != nil {
+		return util.Wrap(err, "Get notification subscription failure")
+	}
+
+	if err := r.getKubernetesConfigMapClient(namespace).Delete(ctx, configMap.Name, metav1.DeleteOptions{}); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete notification subscription %v.", name)
+	}
+	return nil
+}
+
+// The states of the jobs and services which are notified.
+const (
+	notificationStateSucceeded = "Succeeded"
+	notificationStateFailed    = "Failed"
+	notificationStateRunning   = "Running"
+	notificationStateUnhealthy = "Unhealthy"
+)
+
+const (
+	notificationQueueSize  = 1000
+	notificationWorkers    = 8
+	notificationAttempts   = 3
+	notificationRetryDelay = 2 * time.Second
+	notificationTimeout    = 10 * time.Second
+)
+
+// Notification is the JSON payload posted to the webhooks. ID identifies the transition: the API server replicas
+// post the same ID for the same transition, so that the webhooks can drop the duplicates.
+type Notification struct {
+	ID            string    `json:"id"`
+	Time          time.Time `json:"time"`
+	Event         string    `json:"event"`
+	Kind          string    `json:"kind"`
+	Namespace     string    `json:"namespace"`
+	Name          string    `json:"name"`
+	State         string    `json:"state"`
+	PreviousState string    `json:"previousState,omitempty"`
+	Message       string    `json:"message,omitempty"`
+}
+
+// delivery is a notification to be posted to a webhook.
+type delivery struct {
+	url          string
+	subscription string
+	notification *Notification
+}
+
+// observedState is the last seen state of a job or a service, empty when it is in none of the notified states.
+type observedState struct {
+	kind       string
+	namespace  string
+	name       string
+	event      api.NotificationSubscription_Event
+	state      string
+	message    string
+	uid        string
+	generation int64
+}
+
+// Notifier polls the ray jobs and ray services and posts their state transitions to the webhooks of the notification
+// subscriptions of their namespace. The first sync only records the states, so the transitions which happened while
+// no API server was running are not notified. Notifications are posted in the background by notificationWorkers
+// workers, so that a slow webhook does not hold up the others, and dropped with an error log when the webhooks can not
+// keep up.
+type Notifier struct {
+	resourceManager *ResourceManager
+	client          *http.Client
+	deliveries      chan *delivery
+	// states are the last seen states of the jobs and services, by kind, namespace and name. They are only accessed
+	// by Sync.
+	states map[string]observedState
+	seeded bool
+}
+
+func NewNotifier(resourceManager *ResourceManager, client *http.Client) *Notifier {
+	n := &Notifier{
+		resourceManager: resourceManager,
+		client:          client,
+		deliveries:      make(chan *delivery, notificationQueueSize),
+		states:          map[string]observedState{},
+	}
+	for i := 0; i < notificationWorkers; i++ {
+		go n.run()
+	}
+	return n
+}
+
+// Start syncs the states of the jobs and services every interval until ctx is done.
+func (n *Notifier) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			n.Sync(ctx, n.resourceManager.clientManager.Time().Now())
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Sync finds the state transitions of the jobs and services since the last sync, queues their notifications for the
+// subscriptions of their namespace and returns them. The jobs and services are not listed while there are no
+// subscriptions, and the first sync after a subscription is created only records their states.
+func (n *Notifier) Sync(ctx context.Context, now time.Time) []*Notification {
+	configMaps, err := n.resourceManager.ListNotificationSubscriptions(ctx, metav1.NamespaceAll)
+	if err != nil {
+		klog.Errorf("Failed to list the notification subscriptions: %v", err)
+		return nil
+	}
+	if len(configMaps) == 0 {
+		n.states, n.seeded = map[string]observedState{}, false
+		return nil
+	}
+
+	states, err := n.observeStates(ctx)
+	if err != nil {
+		klog.Errorf("Failed to list the jobs and services to notify their state transitions: %v", err)
+		return nil
+	}
+	previousStates, seeded := n.states, n.seeded
+	n.states, n.seeded = states, true
+	if !seeded {
+		return nil
+	}
+
+	var notifications []*Notification
+	for key, state := range states {
+		previous := previousStates[key]
+		if state.state == "" || state.state == previous.state {
+			continue
+		}
+		notifications = append(notifications, &Notification{
+			ID:            fmt.Sprintf("%s/%s/%d", state.uid, state.event, state.generation),
+			Time:          now.UTC(),
+			Event:         state.event.String(),
+			Kind:          state.kind,
+			Namespace:     state.namespace,
+			Name:          state.name,
+			State:         state.state,
+			PreviousState: previous.state,
+			Message:       state.message,
+		})
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+	sort.Slice(notifications, func(i, j int) bool { return notifications[i].ID < notifications[j].ID })
+
+	for _, configMap := range configMaps {
+		subscription := model.FromKubeToAPINotificationSubscription(configMap)
+		for _, notification := range notifications {
+			if notification.Namespace == subscription.Namespace && subscribesTo(subscription, notification.Event) {
+				n.enqueue(&delivery{url: subscription.Url, subscription: subscription.Namespace + "/" + subscription.Name, notification: notification})
+			}
+		}
+	}
+	return notifications
+}
+
+// observeStates returns the current states of the jobs and services, by kind, namespace and name.
+func (n *Notifier) observeStates(ctx context.Context) (map[string]observedState, error) {
+	jobs, err := n.resourceManager.getRayJobClient(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, util.Wrap(err, "List jobs failed in all namespaces")
+	}
+	services, err := n.resourceManager.getRayServiceClient(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, util.Wrap(err, "List services failed in all namespaces")
+	}
+
+	states := make(map[string]observedState, len(jobs.Items)+len(services.Items))
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		state := jobNotificationState(job)
+		state.kind, state.namespace, state.name = "RayJob", job.Namespace, job.Name
+		state.uid, state.generation = string(job.UID), job.Generation
+		states[notificationKey(state)] = state
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		state := serviceNotificationState(service)
+		state.kind, state.namespace, state.name = "RayService", service.Namespace, service.Name
+		state.uid, state.generation = string(service.UID), service.Generation
+		states[notificationKey(state)] = state
+	}
+	return states, nil
+}
+
+// jobNotificationState returns whether a job succeeded or failed once it finished. The stopped jobs failed.
+func jobNotificationState(job *rayv1api.RayJob) observedState {
+	if !isJobDeploymentFinished(job.Status.JobDeploymentStatus) {
+		return observedState{}
+	}
+	if job.Status.JobStatus == rayv1api.JobStatusSucceeded {
+		return observedState{event: api.NotificationSubscription_JOB_SUCCEEDED, state: notificationStateSucceeded, message: job.Status.Message}
+	}
+	return observedState{event: api.NotificationSubscription_JOB_FAILED, state: notificationStateFailed, message: job.Status.Message}
+}
+
+// serviceNotificationState returns whether a service is running with all its applications running, or unhealthy
+// because an application is unhealthy or failed to deploy, or because its cluster is restarting.
+func serviceNotificationState(service *rayv1api.RayService) observedState {
+	names := make([]string, 0, len(service.Status.ActiveServiceStatus.Applications))
+	for name := range service.Status.ActiveServiceStatus.Applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	allRunning := len(names) > 0
+	for _, name := range names {
+		application := service.Status.ActiveServiceStatus.Applications[name]
+		switch application.Status {
+		case rayv1api.ApplicationStatusEnum.UNHEALTHY, rayv1api.ApplicationStatusEnum.DEPLOY_FAILED:
+			return observedState{
+				event:   api.NotificationSubscription_SERVICE_UNHEALTHY,
+				state:   notificationStateUnhealthy,
+				message: fmt.Sprintf("application %s is %s: %s", name, application.Status, application.Message),
+			}
+		case rayv1api.ApplicationStatusEnum.RUNNING:
+		default:
+			allRunning = false
+		}
+	}
+	switch {
+	case service.Status.ServiceStatus == rayv1api.Restarting:
+		return observedState{event: api.NotificationSubscription_SERVICE_UNHEALTHY, state: notificationStateUnhealthy, message: "the ray cluster is restarting"}
+	case service.Status.ServiceStatus == rayv1api.Running && allRunning:
+		return observedState{event: api.NotificationSubscription_SERVICE_RUNNING, state: notificationStateRunning}
+	}
+	return observedState{}
+}
+
+// subscribesTo returns whether a subscription is notified of an event. Subscriptions without events are notified of
+// all of them.
+func subscribesTo(subscription *api.NotificationSubscription, event string) bool {
+	if len(subscription.Events) == 0 {
+		return true
+	}
+	for _, subscribed := range subscription.Events {
+		if subscribed.String() == event {
+			return true
+		}
+	}
+	return false
+}
+
+func notificationKey(state observedState) string {
+	return state.kind + "/" + state.namespace + "/" + state.name
+}
+
+func (n *Notifier) enqueue(d *delivery) {
+	select {
+	case n.deliveries <- d:
+	default:
+		klog.Errorf("The notification queue is full, dropping notification %s for subscription %s", d.notification.ID, d.subscription)
+	}
+}
+
+func (n *Notifier) run() {
+	for d := range n.deliveries {
+		var err error
+		for attempt := 1; attempt <= notificationAttempts; attempt++ {
+			if err = n.post(d); err == nil {
+				break
+			}
+			if attempt < notificationAttempts {
+				time.Sleep(time.Duration(attempt) * notificationRetryDelay)
+			}
+		}
+		if err != nil {
+			klog.Errorf("Failed to send notification %s to subscription %s: %v", d.notification.ID, d.subscription, err)
+		}
+	}
+}
+
+// NewNotificationClient returns the HTTP client of the webhooks. The webhook URLs are chosen by the creators of the
+// subscriptions, so it refuses to connect to the loopback, link-local and unspecified addresses, e.g. to the API server
+// itself or to the metadata server of the cloud provider. The addresses are checked once resolved, so the host names
+// and the redirects pointing to them are refused as well. The other in-cluster addresses, like the Services of the
+// cluster, are reachable.
+func NewNotificationClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: notificationTimeout,
+		Control: func(_ string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+				return fmt.Errorf("webhooks can not be posted to address %s", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Transport: transport}
+}
+
+func (n *Notifier) post(d *delivery) error {
+	body, err := json.Marshal(d.notification)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := n.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("the webhook responded with %s", response.Status)
+	}
+	return nil
+}
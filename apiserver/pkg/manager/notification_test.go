@@ -0,0 +1,174 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestNotificationSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	subscription := &api.NotificationSubscription{
+		Name:      "ci",
+		Namespace: "team-a",
+		User:      "user",
+		Url:       "https://ci.example.com/hooks/ray",
+		Events:    []api.NotificationSubscription_Event{api.NotificationSubscription_JOB_SUCCEEDED, api.NotificationSubscription_JOB_FAILED},
+	}
+
+	_, err := resourceManager.CreateNotificationSubscription(ctx, subscription)
+	require.NoError(t, err)
+	_, err = resourceManager.CreateNotificationSubscription(ctx, subscription)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.AlreadyExists))
+
+	configMap, err := resourceManager.GetNotificationSubscription(ctx, "ci", "team-a")
+	require.NoError(t, err)
+	apiSubscription := model.FromKubeToAPINotificationSubscription(configMap)
+	assert.Equal(t, "user", apiSubscription.User)
+	assert.Equal(t, "https://ci.example.com/hooks/ray", apiSubscription.Url)
+	assert.Equal(t, subscription.Events, apiSubscription.Events)
+
+	// The other ConfigMaps are not notification subscriptions.
+	_, err = resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "small", Namespace: "team-a", Cpu: 1, Memory: 1})
+	require.NoError(t, err)
+	_, err = resourceManager.GetNotificationSubscription(ctx, "small", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	configMaps, err := resourceManager.ListNotificationSubscriptions(ctx, "team-a")
+	require.NoError(t, err)
+	require.Len(t, configMaps, 1)
+
+	require.NoError(t, resourceManager.DeleteNotificationSubscription(ctx, "ci", "team-a"))
+	_, err = resourceManager.GetNotificationSubscription(ctx, "ci", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestNotifierSync(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	rayClient := clientManager.clients.Ray.RayV1()
+
+	received := make(chan *Notification, 10)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		notification := &Notification{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(notification))
+		received <- notification
+	}))
+	defer webhook.Close()
+	for _, subscription := range []*api.NotificationSubscription{
+		{Name: "jobs", Namespace: "team-a", User: "user", Url: webhook.URL, Events: []api.NotificationSubscription_Event{api.NotificationSubscription_JOB_FAILED}},
+		{Name: "other-team", Namespace: "team-b", User: "user", Url: webhook.URL},
+	} {
+		_, err := resourceManager.CreateNotificationSubscription(ctx, subscription)
+		require.NoError(t, err)
+	}
+
+	_, err := rayClient.RayJobs("team-a").Create(ctx, &rayv1api.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "done-before", Namespace: "team-a", UID: "uid-0"},
+		Status:     rayv1api.RayJobStatus{JobDeploymentStatus: rayv1api.JobDeploymentStatusComplete, JobStatus: rayv1api.JobStatusFailed},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	job, err := rayClient.RayJobs("team-a").Create(ctx, &rayv1api.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "training", Namespace: "team-a", UID: "uid-1"},
+		Status:     rayv1api.RayJobStatus{JobDeploymentStatus: rayv1api.JobDeploymentStatusRunning, JobStatus: rayv1api.JobStatusRunning},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	service, err := rayClient.RayServices("team-a").Create(ctx, &rayv1api.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "fruit", Namespace: "team-a", UID: "uid-2"},
+		Status: rayv1api.RayServiceStatuses{
+			ServiceStatus: rayv1api.Running,
+			ActiveServiceStatus: rayv1api.RayServiceStatus{Applications: map[string]rayv1api.AppStatus{
+				"fruit": {Status: rayv1api.ApplicationStatusEnum.RUNNING},
+			}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// The first sync only records the states, so the job which finished before is not notified.
+	notifier := NewNotifier(resourceManager, webhook.Client())
+	assert.Empty(t, notifier.Sync(ctx, time.Now()))
+
+	job.Status.JobDeploymentStatus = rayv1api.JobDeploymentStatusFailed
+	job.Status.JobStatus = rayv1api.JobStatusFailed
+	job.Status.Message = "OOM"
+	_, err = rayClient.RayJobs("team-a").UpdateStatus(ctx, job, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	service.Status.ActiveServiceStatus.Applications["fruit"] = rayv1api.AppStatus{Status: rayv1api.ApplicationStatusEnum.UNHEALTHY, Message: "replica crashed"}
+	_, err = rayClient.RayServices("team-a").UpdateStatus(ctx, service, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	notifications := notifier.Sync(ctx, time.Now())
+	require.Len(t, notifications, 2)
+	assert.Equal(t, "uid-1/JOB_FAILED/0", notifications[0].ID)
+	assert.Equal(t, "Failed", notifications[0].State)
+	assert.Equal(t, "OOM", notifications[0].Message)
+	assert.Equal(t, "uid-2/SERVICE_UNHEALTHY/0", notifications[1].ID)
+	assert.Equal(t, "Unhealthy", notifications[1].State)
+	assert.Equal(t, "Running", notifications[1].PreviousState)
+	assert.Equal(t, "application fruit is UNHEALTHY: replica crashed", notifications[1].Message)
+
+	// Only the subscription of the namespace to the failed jobs is notified.
+	select {
+	case notification := <-received:
+		assert.Equal(t, "RayJob", notification.Kind)
+		assert.Equal(t, "training", notification.Name)
+		assert.Equal(t, "JOB_FAILED", notification.Event)
+	case <-time.After(10 * time.Second):
+		t.Fatal("The webhook was not notified")
+	}
+	assert.Empty(t, notifier.Sync(ctx, time.Now()))
+	select {
+	case notification := <-received:
+		t.Fatalf("Unexpected notification %s", notification.ID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNotifierSyncWithoutSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	rayClient := clientManager.clients.Ray.RayV1()
+	notifier := NewNotifier(resourceManager, http.DefaultClient)
+
+	job, err := rayClient.RayJobs("team-a").Create(ctx, &rayv1api.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "training", Namespace: "team-a", UID: "uid-1"},
+		Status:     rayv1api.RayJobStatus{JobDeploymentStatus: rayv1api.JobDeploymentStatusRunning, JobStatus: rayv1api.JobStatusRunning},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, notifier.Sync(ctx, time.Now()))
+	assert.Empty(t, notifier.states, "The jobs are not listed without subscriptions")
+
+	// The transitions which happened before the first subscription are not notified.
+	job.Status.JobDeploymentStatus = rayv1api.JobDeploymentStatusComplete
+	job.Status.JobStatus = rayv1api.JobStatusSucceeded
+	_, err = rayClient.RayJobs("team-a").UpdateStatus(ctx, job, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = resourceManager.CreateNotificationSubscription(ctx, &api.NotificationSubscription{Name: "jobs", Namespace: "team-a", User: "user", Url: "https://ci.example.com/hooks/ray"})
+	require.NoError(t, err)
+	assert.Empty(t, notifier.Sync(ctx, time.Now()))
+	assert.Len(t, notifier.states, 1)
+}
+
+func TestNotificationClient(t *testing.T) {
+	webhook := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer webhook.Close()
+
+	_, err := NewNotificationClient().Post(webhook.URL, "application/json", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "webhooks can not be posted to address 127.0.0.1")
+}
@@ -0,0 +1,219 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// crashingContainerReasons are the waiting reasons of the containers which keep failing, whose Pods are counted as
+// failed rather than pending.
+var crashingContainerReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"InvalidImageName":           true,
+	"RunContainerError":          true,
+}
+
+// GetClusterPodSummaries counts the Pods of every group of the clusters by their state, and tells why they are not
+// ready, from the conditions and the container statuses of the Pods and from their FailedScheduling events. The Pods
+// and the events are listed once per namespace.
+func (r *ResourceManager) GetClusterPodSummaries(ctx context.Context, clusters []*rayv1api.RayCluster) (map[types.NamespacedName][]*api.GroupPodSummary, error) {
+	clusterNames := map[string][]string{}
+	for _, cluster := range clusters {
+		clusterNames[cluster.Namespace] = append(clusterNames[cluster.Namespace], cluster.Name)
+	}
+
+	// The Pods and the scheduling failures of the clusters, by cluster.
+	pods := map[types.NamespacedName][]corev1.Pod{}
+	schedulingFailures := map[types.NamespacedName]string{}
+	for namespace, names := range clusterNames {
+		requirement, err := labels.NewRequirement(utils.RayClusterLabelKey, selection.In, names)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to select the Pods of the clusters in namespace %s", namespace)
+		}
+		podList, err := r.clientManager.KubernetesClient().PodClient(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.NewSelector().Add(*requirement).String(),
+		})
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to list the Pods of the clusters in namespace %s", namespace)
+		}
+		for _, pod := range podList.Items {
+			key := types.NamespacedName{Namespace: namespace, Name: pod.Labels[utils.RayClusterLabelKey]}
+			pods[key] = append(pods[key], pod)
+		}
+
+		events, err := r.getEventsClient(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: "involvedObject.kind=Pod,reason=FailedScheduling",
+		})
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to list the scheduling events in namespace %s", namespace)
+		}
+		// The latest event of every Pod wins.
+		sort.SliceStable(events.Items, func(i, j int) bool {
+			return lastEventTime(events.Items[i]).Before(lastEventTime(events.Items[j]))
+		})
+		for _, event := range events.Items {
+			if event.InvolvedObject.Kind == "Pod" && event.Reason == "FailedScheduling" {
+				schedulingFailures[types.NamespacedName{Namespace: namespace, Name: event.InvolvedObject.Name}] = event.Message
+			}
+		}
+	}
+
+	summaries := make(map[types.NamespacedName][]*api.GroupPodSummary, len(clusters))
+	for _, cluster := range clusters {
+		key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+		summaries[key] = summarizeGroupPods(cluster, pods[key], schedulingFailures)
+	}
+	return summaries, nil
+}
+
+// summarizeGroupPods counts the Pods of every group of a cluster, the head group first. The Pods being deleted and
+// the Pods of the groups removed from the cluster are left out.
+func summarizeGroupPods(cluster *rayv1api.RayCluster, pods []corev1.Pod, schedulingFailures map[types.NamespacedName]string) []*api.GroupPodSummary {
+	suspended := cluster.Spec.Suspend != nil && *cluster.Spec.Suspend
+	summaries := []*api.GroupPodSummary{{GroupName: utils.RayNodeHeadGroupLabelValue, Desired: 1}}
+	for _, group := range cluster.Spec.WorkerGroupSpecs {
+		summary := &api.GroupPodSummary{GroupName: group.GroupName}
+		if group.Replicas != nil {
+			summary.Desired = *group.Replicas * max(group.NumOfHosts, 1)
+		}
+		summaries = append(summaries, summary)
+	}
+	if suspended {
+		for _, summary := range summaries {
+			summary.Desired = 0
+		}
+	}
+
+	byGroup := make(map[string]*api.GroupPodSummary, len(summaries))
+	for _, summary := range summaries {
+		byGroup[summary.GroupName] = summary
+	}
+	for i := range pods {
+		pod := &pods[i]
+		groupName := pod.Labels[utils.RayNodeGroupLabelKey]
+		if pod.Labels[utils.RayNodeTypeLabelKey] == string(rayv1api.HeadNode) {
+			groupName = utils.RayNodeHeadGroupLabelValue
+		}
+		summary, ok := byGroup[groupName]
+		if !ok || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		ready := isPodReady(pod)
+		switch {
+		case pod.Status.Phase == corev1.PodFailed || isCrashing(pod):
+			summary.Failed++
+		case ready:
+			summary.Ready++
+		case pod.Status.Phase == corev1.PodPending || pod.Status.Phase == "":
+			summary.Pending++
+			if reason := unschedulableReason(pod, schedulingFailures); reason != "" {
+				summary.UnschedulableReasons = appendReason(summary.UnschedulableReasons, reason)
+			}
+		}
+		if !ready {
+			for _, reason := range podFailureReasons(pod) {
+				summary.FailureReasons = appendReason(summary.FailureReasons, reason)
+			}
+		}
+	}
+	for _, summary := range summaries {
+		sort.Strings(summary.UnschedulableReasons)
+		sort.Strings(summary.FailureReasons)
+	}
+	return summaries
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isCrashing(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && crashingContainerReasons[status.State.Waiting.Reason] {
+			return true
+		}
+	}
+	return false
+}
+
+// unschedulableReason returns why a Pod is not scheduled: the message of its PodScheduled condition, or of its
+// latest FailedScheduling event if the condition has none. Empty once the Pod is scheduled.
+func unschedulableReason(pod *corev1.Pod, schedulingFailures map[types.NamespacedName]string) string {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodScheduled {
+			continue
+		}
+		if condition.Status == corev1.ConditionTrue {
+			return ""
+		}
+		if condition.Message != "" {
+			return condition.Message
+		}
+	}
+	return schedulingFailures[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]
+}
+
+// podFailureReasons returns why a Pod failed, or why its containers are waiting or terminated, prefixed with the name
+// of the container.
+func podFailureReasons(pod *corev1.Pod) []string {
+	var reasons []string
+	if pod.Status.Phase == corev1.PodFailed && pod.Status.Reason != "" {
+		reasons = append(reasons, joinReason(pod.Status.Reason, pod.Status.Message))
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		switch {
+		case status.State.Waiting != nil && status.State.Waiting.Reason != "" && status.State.Waiting.Reason != "ContainerCreating" && status.State.Waiting.Reason != "PodInitializing":
+			reason := fmt.Sprintf("%s: %s", status.Name, joinReason(status.State.Waiting.Reason, status.State.Waiting.Message))
+			if last := status.LastTerminationState.Terminated; last != nil && last.Reason != "" {
+				reason += fmt.Sprintf(" (last terminated: %s, exit code %d)", last.Reason, last.ExitCode)
+			}
+			reasons = append(reasons, reason)
+		case status.State.Terminated != nil && status.State.Terminated.ExitCode != 0:
+			terminated := status.State.Terminated
+			reasons = append(reasons, fmt.Sprintf("%s: %s (exit code %d)", status.Name, joinReason(terminated.Reason, terminated.Message), terminated.ExitCode))
+		case status.LastTerminationState.Terminated != nil && status.LastTerminationState.Terminated.Reason == "OOMKilled":
+			reasons = append(reasons, fmt.Sprintf("%s: last terminated: OOMKilled", status.Name))
+		}
+	}
+	return reasons
+}
+
+func joinReason(reason string, message string) string {
+	if message == "" {
+		return reason
+	}
+	if reason == "" {
+		return message
+	}
+	return reason + ": " + message
+}
+
+func appendReason(reasons []string, reason string) []string {
+	for _, existing := range reasons {
+		if existing == reason {
+			return reasons
+		}
+	}
+	return append(reasons, reason)
+}
@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestGetClusterPodSummaries(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	podClient := clientManager.clients.Kubernetes.CoreV1().Pods("team-a")
+
+	cluster := &rayv1api.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "team-a"},
+		Spec: rayv1api.RayClusterSpec{WorkerGroupSpecs: []rayv1api.WorkerGroupSpec{
+			{GroupName: "cpu", Replicas: ptr.To[int32](3)},
+			{GroupName: "tpu", Replicas: ptr.To[int32](2), NumOfHosts: 2},
+		}},
+	}
+	pod := func(name string, group string, status corev1.PodStatus) {
+		nodeType := rayv1api.WorkerNode
+		if group == utils.RayNodeHeadGroupLabelValue {
+			nodeType = rayv1api.HeadNode
+		}
+		_, err := podClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Labels: map[string]string{
+				utils.RayClusterLabelKey:   "cluster",
+				utils.RayNodeTypeLabelKey:  string(nodeType),
+				utils.RayNodeGroupLabelKey: group,
+			}},
+			Status: status,
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	ready := []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}, {Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	pod("head", utils.RayNodeHeadGroupLabelValue, corev1.PodStatus{Phase: corev1.PodRunning, Conditions: ready})
+	pod("cpu-ready", "cpu", corev1.PodStatus{Phase: corev1.PodRunning, Conditions: ready})
+	pod("cpu-crashing", "cpu", corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name:                 "ray-worker",
+			State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s restarting failed container"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+		}},
+	})
+	pod("cpu-pulling", "cpu", corev1.PodStatus{
+		Phase:      corev1.PodPending,
+		Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "ray-worker",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image rayproject/ray:bad"}},
+		}},
+	})
+	unschedulable := corev1.PodStatus{
+		Phase:      corev1.PodPending,
+		Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: "Unschedulable", Message: "0/3 nodes are available: 3 Insufficient google.com/tpu."}},
+	}
+	pod("tpu-0", "tpu", unschedulable)
+	pod("tpu-1", "tpu", unschedulable)
+	// Without a message in the condition, the reason comes from the latest FailedScheduling event.
+	pod("tpu-2", "tpu", corev1.PodStatus{Phase: corev1.PodPending})
+	pod("removed-group", "gpu", corev1.PodStatus{Phase: corev1.PodPending})
+	eventClient := clientManager.clients.Kubernetes.CoreV1().Events("team-a")
+	for i, message := range []string{"0/3 nodes are available: old", "0/3 nodes are available: 3 node(s) had untolerated taint."} {
+		_, err := eventClient.Create(ctx, &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "tpu-2-" + string(rune('a'+i)), Namespace: "team-a"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "tpu-2", Namespace: "team-a"},
+			Reason:         "FailedScheduling",
+			Message:        message,
+			LastTimestamp:  metav1.Unix(int64(1000+i), 0),
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	summaries, err := resourceManager.GetClusterPodSummaries(ctx, []*rayv1api.RayCluster{cluster})
+	require.NoError(t, err)
+	assert.Equal(t, []*api.GroupPodSummary{
+		{GroupName: "headgroup", Desired: 1, Ready: 1},
+		{
+			GroupName: "cpu",
+			Desired:   3,
+			Ready:     1,
+			Pending:   1,
+			Failed:    1,
+			FailureReasons: []string{
+				"ray-worker: CrashLoopBackOff: back-off 5m0s restarting failed container (last terminated: OOMKilled, exit code 137)",
+				"ray-worker: ImagePullBackOff: Back-off pulling image rayproject/ray:bad",
+			},
+		},
+		{
+			GroupName: "tpu",
+			Desired:   4,
+			Pending:   3,
+			UnschedulableReasons: []string{
+				"0/3 nodes are available: 3 Insufficient google.com/tpu.",
+				"0/3 nodes are available: 3 node(s) had untolerated taint.",
+			},
+		},
+	}, summaries[types.NamespacedName{Namespace: "team-a", Name: "cluster"}])
+
+	// The Pods of a suspended cluster are not desired.
+	cluster.Spec.Suspend = ptr.To(true)
+	summaries, err = resourceManager.GetClusterPodSummaries(ctx, []*rayv1api.RayCluster{cluster})
+	require.NoError(t, err)
+	for _, summary := range summaries[types.NamespacedName{Namespace: "team-a", Name: "cluster"}] {
+		assert.Zero(t, summary.Desired)
+	}
+}
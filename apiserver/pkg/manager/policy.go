@@ -0,0 +1,150 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// The policies below are read from the current API server config on every call,
+// so changes of the config file are applied without restarting the API server.
+
+// checkNamespaceAllowed returns an error if resources can't be created in the namespace.
+func checkNamespaceAllowed(cfg *config.Config, namespace string) error {
+	if !cfg.NamespaceAllowed(namespace) {
+		return util.NewPermissionDeniedError(fmt.Errorf("namespace %s is not in the allowlist", namespace), "Creating resources in namespace %s is not allowed", namespace)
+	}
+	return nil
+}
+
+// applyClusterSpecDefaults sets the default image and the defaulting profiles of the config on groups which don't
+// specify them and checks that every image and scheduler name is allowed. The defaults are written to the request, so
+// that the created resource, and the response, show exactly what was materialized.
+func applyClusterSpecDefaults(cfg *config.Config, version string, clusterSpec *api.ClusterSpec) error {
+	if clusterSpec == nil || clusterSpec.HeadGroupSpec == nil {
+		return nil
+	}
+	head := clusterSpec.HeadGroupSpec
+	head.RayStartParams = mergeDefaults(head.RayStartParams, cfg.Defaults.HeadGroup.RayStartParams)
+	head.Labels = mergeDefaults(head.Labels, cfg.Defaults.HeadGroup.Labels)
+	head.Annotations = mergeDefaults(head.Annotations, cfg.Defaults.HeadGroup.Annotations)
+	if err := applyImageDefaults(cfg, version, &head.Image); err != nil {
+		return err
+	}
+	if err := checkSchedulerNameAllowed(cfg, clusterSpec.HeadGroupSpec.SchedulerName); err != nil {
+		return err
+	}
+	if err := checkSidecarImagesAllowed(cfg, head.SidecarContainers); err != nil {
+		return err
+	}
+	for _, spec := range clusterSpec.WorkerGroupSpec {
+		spec.RayStartParams = mergeDefaults(spec.RayStartParams, cfg.Defaults.WorkerGroup.RayStartParams)
+		spec.Labels = mergeDefaults(spec.Labels, cfg.Defaults.WorkerGroup.Labels)
+		spec.Annotations = mergeDefaults(spec.Annotations, cfg.Defaults.WorkerGroup.Annotations)
+		if err := applyImageDefaults(cfg, version, &spec.Image); err != nil {
+			return err
+		}
+		if err := checkSchedulerNameAllowed(cfg, spec.SchedulerName); err != nil {
+			return err
+		}
+		if err := checkSidecarImagesAllowed(cfg, spec.SidecarContainers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeDefaults adds the default entries whose keys are missing from values.
+func mergeDefaults(values map[string]string, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return values
+	}
+	if values == nil {
+		values = make(map[string]string, len(defaults))
+	}
+	for key, value := range defaults {
+		if _, ok := values[key]; !ok {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+func applyImageDefaults(cfg *config.Config, version string, image *string) error {
+	if *image == "" && cfg.Defaults.ImageRepository != "" {
+		*image = fmt.Sprintf("%s:%s", cfg.Defaults.ImageRepository, version)
+	}
+	effective := *image
+	if effective == "" {
+		effective = fmt.Sprintf("%s:%s", util.RayClusterDefaultImageRepository, version)
+	}
+	return checkImageAllowed(cfg, effective)
+}
+
+func checkImageAllowed(cfg *config.Config, image string) error {
+	if !cfg.ImageAllowed(image) {
+		return util.NewPermissionDeniedError(fmt.Errorf("image %s does not match any allowed repository", image), "Image %s is not allowed", image)
+	}
+	return nil
+}
+
+// checkSidecarImagesAllowed returns an error if the image of a sidecar container of a group is not allowed, like the
+// image of its Ray container.
+func checkSidecarImagesAllowed(cfg *config.Config, sidecars []*api.SidecarContainer) error {
+	for _, sidecar := range sidecars {
+		if err := checkImageAllowed(cfg, sidecar.Image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkSchedulerNameAllowed(cfg *config.Config, schedulerName string) error {
+	if !cfg.SchedulerNameAllowed(schedulerName) {
+		return util.NewPermissionDeniedError(fmt.Errorf("scheduler %s is not in the allowlist", schedulerName), "Scheduler %s is not allowed", schedulerName)
+	}
+	return nil
+}
+
+// checkQuota returns an error if creating one more resource would exceed the limit.
+// A limit of zero means unlimited.
+func checkQuota(ctx context.Context, kind string, namespace string, limit int, count func(context.Context, string) (int, error)) error {
+	return checkQuotaFor(ctx, kind, namespace, limit, 1, count)
+}
+
+// checkQuotaFor returns an error if creating the given number of resources would exceed the limit.
+func checkQuotaFor(ctx context.Context, kind string, namespace string, limit int, added int, count func(context.Context, string) (int, error)) error {
+	if limit <= 0 || added <= 0 {
+		return nil
+	}
+	current, err := count(ctx, namespace)
+	if err != nil {
+		return util.Wrap(err, fmt.Sprintf("Failed to check %s quota in %s", kind, namespace))
+	}
+	if current+added > limit {
+		if added == 1 {
+			return util.NewResourceExhaustedError("Quota exceeded: namespace %s already has %d of %d allowed %s", namespace, current, limit, kind)
+		}
+		return util.NewResourceExhaustedError("Quota exceeded: namespace %s already has %d of %d allowed %s and %d more are requested", namespace, current, limit, kind, added)
+	}
+	return nil
+}
+
+func (r *ResourceManager) countClusters(ctx context.Context, namespace string) (int, error) {
+	clusters, _, err := r.ListClusters(ctx, namespace, "", 0, ResourceSelector{})
+	return len(clusters), err
+}
+
+func (r *ResourceManager) countJobs(ctx context.Context, namespace string) (int, error) {
+	jobs, _, err := r.ListJobs(ctx, namespace, "", 0)
+	return len(jobs), err
+}
+
+func (r *ResourceManager) countServices(ctx context.Context, namespace string) (int, error) {
+	services, _, err := r.ListServices(ctx, namespace, "", 0, ResourceSelector{})
+	return len(services), err
+}
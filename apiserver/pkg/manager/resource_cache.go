@@ -0,0 +1,219 @@
+package manager
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	klog "k8s.io/klog/v2"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	rayv1listers "github.com/ray-project/kuberay/ray-operator/pkg/client/listers/ray/v1"
+)
+
+// ResourceCache serves the Ray resources managed by the API server and their events from shared
+// informers, so that Get and List calls do not reach the Kubernetes API server. Writes still go
+// through the clients, and the informers pick up the changes with their watches.
+type ResourceCache struct {
+	clusterInformer cache.SharedIndexInformer
+	jobInformer     cache.SharedIndexInformer
+	serviceInformer cache.SharedIndexInformer
+
+	clusterLister rayv1listers.RayClusterLister
+	jobLister     rayv1listers.RayJobLister
+	serviceLister rayv1listers.RayServiceLister
+
+	events *EventCache
+}
+
+// NewResourceCache creates a ResourceCache watching the managed Ray resources and the events of all
+// namespaces. Every informer resyncs with the given period, zero disables the resync. The cache is
+// empty until Run is called.
+func NewResourceCache(clientManager ClientManagerInterface, resyncPeriod time.Duration) *ResourceCache {
+	clusterClient := clientManager.ClusterClient().RayClusterClient(metav1.NamespaceAll)
+	jobClient := clientManager.JobClient().RayJobClient(metav1.NamespaceAll)
+	serviceClient := clientManager.ServiceClient().RayServiceClient(metav1.NamespaceAll)
+
+	clusterInformer := newManagedInformer(&rayv1api.RayCluster{}, resyncPeriod,
+		func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			return clusterClient.List(ctx, options)
+		}, clusterClient.Watch)
+	jobInformer := newManagedInformer(&rayv1api.RayJob{}, resyncPeriod,
+		func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			return jobClient.List(ctx, options)
+		}, jobClient.Watch)
+	serviceInformer := newManagedInformer(&rayv1api.RayService{}, resyncPeriod,
+		func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			return serviceClient.List(ctx, options)
+		}, serviceClient.Watch)
+
+	return &ResourceCache{
+		clusterInformer: clusterInformer,
+		jobInformer:     jobInformer,
+		serviceInformer: serviceInformer,
+		clusterLister:   rayv1listers.NewRayClusterLister(clusterInformer.GetIndexer()),
+		jobLister:       rayv1listers.NewRayJobLister(jobInformer.GetIndexer()),
+		serviceLister:   rayv1listers.NewRayServiceLister(serviceInformer.GetIndexer()),
+		events:          NewEventCache(clientManager.KubernetesClient(), resyncPeriod),
+	}
+}
+
+// newManagedInformer creates an informer for the resources labeled as managed by the API server.
+func newManagedInformer(
+	objType runtime.Object,
+	resyncPeriod time.Duration,
+	list func(context.Context, metav1.ListOptions) (runtime.Object, error),
+	watchFunc func(context.Context, metav1.ListOptions) (watch.Interface, error),
+) cache.SharedIndexInformer {
+	selector := managedListOptions("", 0).LabelSelector
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector
+			return list(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector
+			return watchFunc(context.Background(), options)
+		},
+	}
+	return cache.NewSharedIndexInformer(listWatch, objType, resyncPeriod, cache.Indexers{
+		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+	})
+}
+
+// Run starts the informers and the event cache with the given number of workers, and blocks until
+// ctx is done.
+func (c *ResourceCache) Run(ctx context.Context, eventWorkers int) {
+	go c.clusterInformer.Run(ctx.Done())
+	go c.jobInformer.Run(ctx.Done())
+	go c.serviceInformer.Run(ctx.Done())
+	go c.events.Run(ctx, eventWorkers)
+
+	if !cache.WaitForCacheSync(ctx.Done(), c.HasSynced) {
+		klog.Error("Timed out waiting for the resource cache to sync")
+		return
+	}
+	klog.Info("Resource cache synced")
+
+	<-ctx.Done()
+}
+
+// HasSynced returns whether the initial list of every Ray resource has been cached.
+func (c *ResourceCache) HasSynced() bool {
+	return c.clusterInformer.HasSynced() && c.jobInformer.HasSynced() && c.serviceInformer.HasSynced()
+}
+
+// GetCluster returns a copy of the cached RayCluster. The second return value is false when the
+// cache has not synced yet or the RayCluster is not cached.
+func (c *ResourceCache) GetCluster(namespace string, name string) (*rayv1api.RayCluster, bool) {
+	if !c.HasSynced() {
+		return nil, false
+	}
+	cluster, err := c.clusterLister.RayClusters(namespace).Get(name)
+	if err != nil {
+		return nil, false
+	}
+	return cluster.DeepCopy(), true
+}
+
+// ListClusters returns copies of the cached RayClusters of a namespace, or of all namespaces, sorted
+// like the Kubernetes API server does. The second return value is false when the cache has not synced yet.
+func (c *ResourceCache) ListClusters(namespace string) ([]*rayv1api.RayCluster, bool) {
+	if !c.HasSynced() {
+		return nil, false
+	}
+	clusters, err := c.clusterLister.RayClusters(namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list RayClusters from the resource cache: %v", err)
+		return nil, false
+	}
+	result := make([]*rayv1api.RayCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		result = append(result, cluster.DeepCopy())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return namespacedNameLess(result[i].ObjectMeta, result[j].ObjectMeta)
+	})
+	return result, true
+}
+
+// GetJob returns a copy of the cached RayJob. The second return value is false when the cache has
+// not synced yet or the RayJob is not cached.
+func (c *ResourceCache) GetJob(namespace string, name string) (*rayv1api.RayJob, bool) {
+	if !c.HasSynced() {
+		return nil, false
+	}
+	job, err := c.jobLister.RayJobs(namespace).Get(name)
+	if err != nil {
+		return nil, false
+	}
+	return job.DeepCopy(), true
+}
+
+// ListJobs returns copies of the cached RayJobs of a namespace, or of all namespaces, sorted like
+// the Kubernetes API server does. The second return value is false when the cache has not synced yet.
+func (c *ResourceCache) ListJobs(namespace string) ([]*rayv1api.RayJob, bool) {
+	if !c.HasSynced() {
+		return nil, false
+	}
+	jobs, err := c.jobLister.RayJobs(namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list RayJobs from the resource cache: %v", err)
+		return nil, false
+	}
+	result := make([]*rayv1api.RayJob, 0, len(jobs))
+	for _, job := range jobs {
+		result = append(result, job.DeepCopy())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return namespacedNameLess(result[i].ObjectMeta, result[j].ObjectMeta)
+	})
+	return result, true
+}
+
+// GetService returns a copy of the cached RayService. The second return value is false when the
+// cache has not synced yet or the RayService is not cached.
+func (c *ResourceCache) GetService(namespace string, name string) (*rayv1api.RayService, bool) {
+	if !c.HasSynced() {
+		return nil, false
+	}
+	service, err := c.serviceLister.RayServices(namespace).Get(name)
+	if err != nil {
+		return nil, false
+	}
+	return service.DeepCopy(), true
+}
+
+// ListServices returns copies of the cached RayServices of a namespace, or of all namespaces, sorted
+// like the Kubernetes API server does. The second return value is false when the cache has not synced yet.
+func (c *ResourceCache) ListServices(namespace string) ([]*rayv1api.RayService, bool) {
+	if !c.HasSynced() {
+		return nil, false
+	}
+	services, err := c.serviceLister.RayServices(namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list RayServices from the resource cache: %v", err)
+		return nil, false
+	}
+	result := make([]*rayv1api.RayService, 0, len(services))
+	for _, service := range services {
+		result = append(result, service.DeepCopy())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return namespacedNameLess(result[i].ObjectMeta, result[j].ObjectMeta)
+	})
+	return result, true
+}
+
+// namespacedNameLess orders objects by namespace, then by name.
+func namespacedNameLess(a metav1.ObjectMeta, b metav1.ObjectMeta) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,129 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/transport"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func newTestRayCluster(namespace string, name string, managed bool) *rayv1api.RayCluster {
+	cluster := &rayv1api.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{},
+		},
+	}
+	if managed {
+		cluster.Labels[util.KubernetesManagedByLabelKey] = util.ComponentName
+	}
+	return cluster
+}
+
+func TestResourceCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientManager := NewFakeClientManager(ctx, 0)
+	for _, cluster := range []*rayv1api.RayCluster{
+		newTestRayCluster("team-b", "cluster", true),
+		newTestRayCluster("team-a", "cluster-2", true),
+		newTestRayCluster("team-a", "cluster-1", true),
+		newTestRayCluster("team-a", "unmanaged", false),
+	} {
+		_, err := clientManager.ClusterClient().RayClusterClient(cluster.Namespace).Create(ctx, cluster, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	resourceCache := NewResourceCache(clientManager, 0)
+	_, ok := resourceCache.ListClusters("team-a")
+	assert.False(t, ok, "Resources are not served before the cache has synced")
+
+	go resourceCache.Run(ctx, 1)
+	require.Eventually(t, resourceCache.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	clusters, ok := resourceCache.ListClusters("team-a")
+	require.True(t, ok)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, "cluster-1", clusters[0].Name)
+	assert.Equal(t, "cluster-2", clusters[1].Name)
+
+	clusters, ok = resourceCache.ListClusters(metav1.NamespaceAll)
+	require.True(t, ok)
+	require.Len(t, clusters, 3)
+	assert.Equal(t, "team-b", clusters[2].Namespace)
+
+	// Resources not managed by the API server are not cached.
+	_, ok = resourceCache.GetCluster("team-a", "unmanaged")
+	assert.False(t, ok)
+
+	// The cache returns copies, so callers cannot modify it.
+	cluster, ok := resourceCache.GetCluster("team-a", "cluster-1")
+	require.True(t, ok)
+	cluster.Labels["modified"] = "true"
+	cluster, _ = resourceCache.GetCluster("team-a", "cluster-1")
+	assert.NotContains(t, cluster.Labels, "modified")
+
+	jobs, ok := resourceCache.ListJobs(metav1.NamespaceAll)
+	require.True(t, ok)
+	assert.Empty(t, jobs)
+}
+
+func TestResourceManagerWithResourceCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	_, err := resourceManager.getRayClusterClient("team-a").Create(ctx, newTestRayCluster("team-a", "cluster-1", true), metav1.CreateOptions{})
+	require.NoError(t, err)
+	resourceManager.StartResourceCache(ctx, 0, 1)
+	require.Eventually(t, resourceManager.resourceCache.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	clusters, nextPageToken, err := resourceManager.ListClusters(ctx, "team-a", "", 0, ResourceSelector{})
+	require.NoError(t, err)
+	require.Len(t, clusters, 1)
+	assert.Empty(t, nextPageToken)
+
+	// A RayCluster which may not have reached the cache yet is still found.
+	_, err = resourceManager.getRayClusterClient("team-a").Create(ctx, newTestRayCluster("team-a", "cluster-2", true), metav1.CreateOptions{})
+	require.NoError(t, err)
+	cluster, err := resourceManager.GetCluster(ctx, "cluster-2", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "cluster-2", cluster.Name)
+
+	// Paginated calls are served by Kubernetes. The fake clientset ignores the limit, but lists the
+	// RayCluster which may not have reached the cache yet.
+	clusters, _, err = resourceManager.ListClusters(ctx, "team-a", "", 1, ResourceSelector{})
+	require.NoError(t, err)
+	assert.Len(t, clusters, 2)
+}
+
+func TestResourceCacheBypassedWhenImpersonating(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	resourceManager.StartResourceCache(ctx, 0, 1)
+	require.Eventually(t, resourceManager.resourceCache.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	assert.NotNil(t, resourceManager.cachedResources(ctx))
+	assert.True(t, resourceManager.cacheServesList(ctx, "", 0))
+
+	// The reads of an impersonated caller are authorized by Kubernetes.
+	impersonated := client.WithImpersonation(ctx, transport.ImpersonationConfig{UserName: "alice"})
+	assert.Nil(t, resourceManager.cachedResources(impersonated))
+	assert.Nil(t, resourceManager.cachedEvents(impersonated))
+	assert.False(t, resourceManager.cacheServesList(impersonated, "", 0))
+
+	// The reads the API server makes on its own behalf still use the cache.
+	assert.NotNil(t, resourceManager.cachedResources(client.WithoutImpersonation(impersonated)))
+}
@@ -2,59 +2,104 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-
+	"maps"
+	"net/url"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
 	"github.com/ray-project/kuberay/apiserver/pkg/model"
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	klog "k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/typed/ray/v1"
 )
 
 const DefaultNamespace = "ray-system"
 
-// ResourceManagerInterface can be used by services to operate resources
-// kubernetes objects and potential db objects underneath operations should be encapsulated at this layer
-type ResourceManagerInterface interface {
-	CreateCluster(ctx context.Context, apiCluster *api.Cluster) (*rayv1api.RayCluster, error)
-	GetCluster(ctx context.Context, clusterName string, namespace string) (*rayv1api.RayCluster, error)
-	ListClusters(ctx context.Context, namespace string) ([]*rayv1api.RayCluster, error)
-	ListAllClusters(ctx context.Context) ([]*rayv1api.RayCluster, error)
-	DeleteCluster(ctx context.Context, clusterName string, namespace string) error
-	CreateComputeTemplate(ctx context.Context, runtime *api.ComputeTemplate) (*corev1.ConfigMap, error)
-	GetComputeTemplate(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error)
-	ListComputeTemplates(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error)
-	DeleteComputeTemplate(ctx context.Context, name string, namespace string) error
-	CreateJob(ctx context.Context, apiJob *api.RayJob) (*rayv1api.RayJob, error)
-	GetJob(ctx context.Context, jobName string, namespace string) (*rayv1api.RayJob, error)
-	ListJobs(ctx context.Context, namespace string) ([]*rayv1api.RayJob, error)
-	ListAllJobs(ctx context.Context) ([]*rayv1api.RayJob, error)
-	DeleteJob(ctx context.Context, jobName string, namespace string) error
-	CreateService(ctx context.Context, apiService *api.RayService) (*rayv1api.RayService, error)
-	UpdateRayService(ctx context.Context, request *api.UpdateRayServiceRequest) (*rayv1api.RayService, error)
-	GetService(ctx context.Context, serviceName, namespace string) error
-	ListServices(ctx context.Context, namespace string) ([]*rayv1api.RayService, error)
-	ListAllServices(ctx context.Context) ([]*rayv1api.RayService, error)
-	DeleteService(ctx context.Context, serviceName, namespace string) error
-	GetClusterEvents(ctx context.Context, clusterName string, namespace string) ([]corev1.Event, error)
-	GetServiceEvents(ctx context.Context, service rayv1api.RayService) ([]corev1.Event, error)
-}
-
+// ResourceManager operates the Kubernetes objects behind the API resources. It implements all the stores used by the
+// servers.
 type ResourceManager struct {
 	clientManager ClientManagerInterface
+	// eventCache serves the events of Ray resources from memory when it is set.
+	eventCache *EventCache
+	// resourceCache serves the managed Ray resources from memory when it is set.
+	resourceCache *ResourceCache
+	// jobArchive archives the finished jobs when it is set.
+	jobArchive *jobArchive
+	// dashboardClientFunc returns the clients of the Ray dashboards, which tell whether the clusters are idle.
+	dashboardClientFunc func() utils.RayDashboardClientInterface
 }
 
 // It would be easier to discover methods.
 func NewResourceManager(clientManager ClientManagerInterface) *ResourceManager {
 	return &ResourceManager{
-		clientManager: clientManager,
+		clientManager:       clientManager,
+		dashboardClientFunc: utils.GetRayDashboardClientFunc(nil, false),
+	}
+}
+
+// StartEventCache starts an EventCache kept warm by the given number of workers until ctx is done.
+// Once it has synced, events are attached from the cache instead of listing them on every call.
+func (r *ResourceManager) StartEventCache(ctx context.Context, workers int) {
+	r.eventCache = NewEventCache(r.clientManager.KubernetesClient(), 0)
+	go r.eventCache.Run(ctx, workers)
+}
+
+// StartResourceCache starts a ResourceCache resynced with the given period until ctx is done. Once it
+// has synced, Get and unpaginated List calls, and the attached events, are served from the cache.
+func (r *ResourceManager) StartResourceCache(ctx context.Context, resyncPeriod time.Duration, eventWorkers int) {
+	r.resourceCache = NewResourceCache(r.clientManager, resyncPeriod)
+	r.eventCache = r.resourceCache.events
+	go r.resourceCache.Run(ctx, eventWorkers)
+}
+
+// cachedResources returns the resource cache, or nil if there is none or if the calls of ctx impersonate their
+// caller, whose reads are authorized by Kubernetes rather than served from the cache of the API server.
+func (r *ResourceManager) cachedResources(ctx context.Context) *ResourceCache {
+	if _, impersonated := client.ImpersonationFromContext(ctx); impersonated {
+		return nil
+	}
+	return r.resourceCache
+}
+
+// cachedEvents returns the event cache, or nil if there is none or if the calls of ctx impersonate their caller.
+func (r *ResourceManager) cachedEvents(ctx context.Context) *EventCache {
+	if _, impersonated := client.ImpersonationFromContext(ctx); impersonated {
+		return nil
 	}
+	return r.eventCache
+}
+
+// cacheServesList returns whether a List call can be served from the resource cache. Paginated calls
+// are sent to Kubernetes, because the continue tokens are issued by the Kubernetes API server.
+func (r *ResourceManager) cacheServesList(ctx context.Context, continueToken string, limit int64) bool {
+	return r.cachedResources(ctx) != nil && continueToken == "" && limit == 0
+}
+
+// getCachedEvents returns the events of a Ray resource from the event cache. The second return
+// value is false when there is no synced event cache.
+func (r *ResourceManager) getCachedEvents(ctx context.Context, kind string, namespace string, name string) ([]corev1.Event, bool) {
+	eventCache := r.cachedEvents(ctx)
+	if eventCache == nil {
+		return nil, false
+	}
+	return eventCache.Events(kind, namespace, name)
 }
 
 // Clients
@@ -74,6 +119,14 @@ func (r *ResourceManager) getKubernetesConfigMapClient(namespace string) clientv
 	return r.clientManager.KubernetesClient().ConfigMapClient(namespace)
 }
 
+func (r *ResourceManager) getKubernetesSecretClient(namespace string) clientv1.SecretInterface {
+	return r.clientManager.KubernetesClient().SecretClient(namespace)
+}
+
+func (r *ResourceManager) getKubernetesServiceClient(namespace string) clientv1.ServiceInterface {
+	return r.clientManager.KubernetesClient().ServiceClient(namespace)
+}
+
 func (r *ResourceManager) getEventsClient(namespace string) clientv1.EventInterface {
 	return r.clientManager.KubernetesClient().EventsClient(namespace)
 }
@@ -82,124 +135,362 @@ func (r *ResourceManager) getKubernetesNamespaceClient() clientv1.NamespaceInter
 	return r.clientManager.KubernetesClient().NamespaceClient()
 }
 
+// CheckKubernetesConnectivity returns an error if the Kubernetes API server can't be reached, or rejects the requests
+// of the API server.
+func (r *ResourceManager) CheckKubernetesConnectivity(ctx context.Context) error {
+	if _, err := r.getKubernetesNamespaceClient().List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		return fmt.Errorf("failed to list the namespaces: %w", err)
+	}
+	return nil
+}
+
+// managedListOptions selects the resources managed by the API server. A zero limit lists all of them,
+// otherwise the continue token of the returned list can be used to fetch the next page.
+func managedListOptions(continueToken string, limit int64) metav1.ListOptions {
+	labelSelector := metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			util.KubernetesManagedByLabelKey: util.ComponentName,
+		},
+	}
+	return metav1.ListOptions{
+		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
+		Limit:         limit,
+		Continue:      continueToken,
+	}
+}
+
+// ResourceSelector narrows a List call down to the resources matching Kubernetes label and field
+// selectors. The zero value selects every managed resource.
+type ResourceSelector struct {
+	LabelSelector string
+	FieldSelector string
+}
+
+// IsEmpty returns whether the selector selects every managed resource.
+func (s ResourceSelector) IsEmpty() bool {
+	return s.LabelSelector == "" && s.FieldSelector == ""
+}
+
+// selectedListOptions adds the selector to the options listing the managed resources.
+func selectedListOptions(continueToken string, limit int64, selector ResourceSelector) metav1.ListOptions {
+	options := managedListOptions(continueToken, limit)
+	if selector.LabelSelector != "" {
+		options.LabelSelector += "," + selector.LabelSelector
+	}
+	options.FieldSelector = selector.FieldSelector
+	return options
+}
+
+// snapshotListOptions adds the resource version of a listing to the options listing the selected resources. The
+// first page is read at exactly that resource version, the continue token of the next pages already keeps reading the
+// snapshot of the first page, and Kubernetes rejects a resource version along with it.
+func snapshotListOptions(continueToken string, limit int64, resourceVersion string, selector ResourceSelector) metav1.ListOptions {
+	options := selectedListOptions(continueToken, limit, selector)
+	if resourceVersion != "" && continueToken == "" {
+		options.ResourceVersion = resourceVersion
+		options.ResourceVersionMatch = metav1.ResourceVersionMatchExact
+	}
+	return options
+}
+
+// listSnapshotError converts the errors of Kubernetes about the resource version or the continue token of a listing.
+func listSnapshotError(err error, resourceVersion string, message string) error {
+	switch {
+	case errors.IsResourceExpired(err) || errors.IsGone(err):
+		return util.NewFailedPreconditionError("The snapshot of the listing has expired. Please restart the listing without a page token and a resource version.")
+	case errors.IsBadRequest(err) || errors.IsInvalid(err):
+		return util.NewInvalidInputErrorWithDetails(err, fmt.Sprintf("Resource version %q or page token is invalid. Please specify a valid value.", resourceVersion))
+	}
+	return util.Wrap(err, message)
+}
+
+// checkListSnapshot checks that a page of a listing was read at the resource version of the listing, i.e. that the
+// continue token of the page belongs to the same listing.
+func checkListSnapshot(resourceVersion string, listMeta metav1.ListMeta) error {
+	if resourceVersion != "" && listMeta.ResourceVersion != resourceVersion {
+		return util.NewInvalidInputError("The page token belongs to the listing at resource version %s, not %s. Please specify the resource version of the first page.", listMeta.ResourceVersion, resourceVersion)
+	}
+	return nil
+}
+
+// createOptions returns the options to create a resource. With dryRun, Kubernetes fully validates and
+// defaults the resource without persisting it.
+func createOptions(dryRun bool) metav1.CreateOptions {
+	if dryRun {
+		return metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}
+	}
+	return metav1.CreateOptions{}
+}
+
+// deleteOptions returns the options deleting a resource in the foreground, i.e. after the resources it owns, or in
+// the background, only if it still has the given resource version.
+func deleteOptions(foreground bool, resourceVersion string) metav1.DeleteOptions {
+	options := metav1.DeleteOptions{}
+	if foreground {
+		propagationPolicy := metav1.DeletePropagationForeground
+		options.PropagationPolicy = &propagationPolicy
+	}
+	// Kubernetes also refuses the deletion if the object changes after it was read.
+	if resourceVersion != "" {
+		options.Preconditions = &metav1.Preconditions{ResourceVersion: &resourceVersion}
+	}
+	return options
+}
+
 // clusters
-func (r *ResourceManager) CreateCluster(ctx context.Context, apiCluster *api.Cluster) (*rayv1api.RayCluster, error) {
+func (r *ResourceManager) CreateCluster(ctx context.Context, apiCluster *api.Cluster, dryRun bool, idempotencyKey string) (*rayv1api.RayCluster, error) {
+	return r.createCluster(ctx, apiCluster, dryRun, idempotencyKey, nil)
+}
+
+// createCluster creates a RayCluster with extra labels, e.g. the label of the clusters of the interactive sessions.
+func (r *ResourceManager) createCluster(ctx context.Context, apiCluster *api.Cluster, dryRun bool, idempotencyKey string, extraLabels map[string]string) (*rayv1api.RayCluster, error) {
+	cfg := config.Get()
+	if err := checkNamespaceAllowed(cfg, apiCluster.Namespace); err != nil {
+		return nil, err
+	}
+	client := r.getRayClusterClient(apiCluster.Namespace)
+	payloadHash := ""
+	if idempotencyKey != "" && !dryRun {
+		var err error
+		if payloadHash, err = hashCreatePayload(apiCluster); err != nil {
+			return nil, err
+		}
+		// A retried create returns the cluster before checking the quota, which the cluster counts towards.
+		if cluster, err := getIdempotentCluster(ctx, client, apiCluster.Name, idempotencyKey, payloadHash); err != nil || cluster != nil {
+			return cluster, err
+		}
+	}
+	if err := checkQuota(ctx, "clusters", apiCluster.Namespace, cfg.Quotas.MaxClustersPerNamespace, r.countClusters); err != nil {
+		return nil, err
+	}
+	if err := applyClusterSpecDefaults(cfg, apiCluster.Version, apiCluster.ClusterSpec); err != nil {
+		return nil, err
+	}
+
 	// populate cluster map
 	computeTemplateDict, err := r.populateComputeTemplate(ctx, apiCluster.ClusterSpec, apiCluster.Namespace)
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to populate compute template for (%s/%s)", apiCluster.Namespace, apiCluster.Name)
 	}
+	if err := r.checkImagePullSecrets(ctx, apiCluster.ClusterSpec, apiCluster.Namespace); err != nil {
+		return nil, err
+	}
+	if err := r.checkInjectedSecrets(ctx, apiCluster.ClusterSpec, apiCluster.Namespace); err != nil {
+		return nil, err
+	}
 
 	// convert *api.Cluster to rayv1api.RayCluster
 	rayCluster, err := util.NewRayCluster(apiCluster, computeTemplateDict)
 	if err != nil {
 		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to create a Ray cluster")
 	}
+	for key, value := range extraLabels {
+		rayCluster.Labels[key] = value
+	}
+	if err := model.ApplyToCrdHooks(ctx, apiCluster, rayCluster.RayCluster); err != nil {
+		return nil, err
+	}
+	if err := r.admit(ctx, AdmissionCreate, rayCluster.RayCluster); err != nil {
+		return nil, err
+	}
+	if err := r.checkResourceQuota(ctx, cfg, "cluster", apiCluster.Name, apiCluster.Namespace, &rayCluster.Spec); err != nil {
+		return nil, err
+	}
 
 	// set our own fields.
 	clusterAt := r.clientManager.Time().Now().String()
 	rayCluster.Annotations["ray.io/creation-timestamp"] = clusterAt
+	if payloadHash != "" {
+		setIdempotencyAnnotations(&rayCluster.ObjectMeta, idempotencyKey, payloadHash)
+	}
 
-	newRayCluster, err := r.getRayClusterClient(apiCluster.Namespace).Create(ctx, rayCluster.Get(), metav1.CreateOptions{})
+	newRayCluster, err := client.Create(ctx, rayCluster.Get(), createOptions(dryRun))
 	if err != nil {
+		// A concurrent retry created the cluster first.
+		if payloadHash != "" && errors.IsAlreadyExists(err) {
+			if cluster, err := getIdempotentCluster(ctx, client, apiCluster.Name, idempotencyKey, payloadHash); err != nil || cluster != nil {
+				return cluster, err
+			}
+		}
 		return nil, util.NewInternalServerError(err, "Failed to create a cluster for (%s/%s)", rayCluster.Namespace, rayCluster.Name)
 	}
+	if !dryRun {
+		if err := r.syncPodDisruptionBudgets(ctx, newRayCluster, "RayCluster", &newRayCluster.Spec); err != nil {
+			// Delete the cluster, so that the request can be retried once the cause is fixed.
+			if deleteErr := client.Delete(ctx, newRayCluster.Name, metav1.DeleteOptions{}); deleteErr != nil {
+				klog.FromContext(ctx).Error(deleteErr, "Failed to delete the cluster whose pod disruption budgets could not be created", "cluster", klog.KObj(newRayCluster))
+			}
+			return nil, err
+		}
+	}
 
 	return newRayCluster, nil
 }
 
 // Compute template
+// populateComputeTemplate resolves the compute templates of the groups of a cluster spec, at the revisions the groups
+// are pinned to, or at their latest revisions.
 func (r *ResourceManager) populateComputeTemplate(ctx context.Context, clusterSpec *api.ClusterSpec, nameSpace string) (map[string]*api.ComputeTemplate, error) {
 	dict := map[string]*api.ComputeTemplate{}
+	populate := func(name string, revision int32) error {
+		if computeTemplate, exist := dict[name]; exist {
+			if revision != 0 && revision != computeTemplate.Revision {
+				return util.NewInvalidInputError("Compute template %s is used at revisions %d and %d, the groups of a cluster must use the same revision of a compute template.",
+					name, computeTemplate.Revision, revision)
+			}
+			return nil
+		}
+		configMap, err := r.GetComputeTemplateRevision(ctx, name, nameSpace, revision)
+		if err != nil {
+			return err
+		}
+		dict[name] = model.FromKubeToAPIComputeTemplate(configMap)
+		return nil
+	}
+
 	// populate head compute template
-	name := clusterSpec.HeadGroupSpec.ComputeTemplate
-	configMap, err := r.GetComputeTemplate(ctx, name, nameSpace)
-	if err != nil {
+	if err := populate(clusterSpec.HeadGroupSpec.ComputeTemplate, clusterSpec.HeadGroupSpec.ComputeTemplateRevision); err != nil {
 		return nil, err
 	}
-	computeTemplate := model.FromKubeToAPIComputeTemplate(configMap)
-	dict[name] = computeTemplate
-
 	// populate worker compute template
 	for _, spec := range clusterSpec.WorkerGroupSpec {
-		name := spec.ComputeTemplate
-		if _, exist := dict[name]; !exist {
-			configMap, err := r.GetComputeTemplate(ctx, name, nameSpace)
-			if err != nil {
-				return nil, err
-			}
-			computeTemplate := model.FromKubeToAPIComputeTemplate(configMap)
-			dict[name] = computeTemplate
+		if err := populate(spec.ComputeTemplate, spec.ComputeTemplateRevision); err != nil {
+			return nil, err
 		}
 	}
 
 	return dict, nil
 }
 
+// checkImagePullSecrets checks that the image pull Secrets of a cluster spec exist and hold registry credentials, since
+// the pods of the cluster can not pull their images otherwise.
+func (r *ResourceManager) checkImagePullSecrets(ctx context.Context, clusterSpec *api.ClusterSpec, namespace string) error {
+	client := r.getKubernetesSecretClient(namespace)
+	for _, name := range util.ImagePullSecretNames(clusterSpec) {
+		secret, err := client.Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return util.NewFailedPreconditionError("Image pull Secret %s/%s not found", namespace, name)
+		}
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to get image pull Secret %s/%s", namespace, name)
+		}
+		if secret.Type != corev1.SecretTypeDockerConfigJson && secret.Type != corev1.SecretTypeDockercfg {
+			return util.NewFailedPreconditionError("Image pull Secret %s/%s has type %s. Please use a Secret of type %s or %s.",
+				namespace, name, secret.Type, corev1.SecretTypeDockerConfigJson, corev1.SecretTypeDockercfg)
+		}
+	}
+	return nil
+}
+
+// checkInjectedSecrets checks that the Secrets injected into the pods of a cluster exist and have the keys set as
+// environment variables, since the pods of the cluster can not start otherwise.
+func (r *ResourceManager) checkInjectedSecrets(ctx context.Context, clusterSpec *api.ClusterSpec, namespace string) error {
+	client := r.getKubernetesSecretClient(namespace)
+	for _, injection := range clusterSpec.GetSecretInjections() {
+		secret, err := client.Get(ctx, injection.SecretName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return util.NewFailedPreconditionError("Injected Secret %s/%s not found", namespace, injection.SecretName)
+		}
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to get injected Secret %s/%s", namespace, injection.SecretName)
+		}
+		names := make([]string, 0, len(injection.Env))
+		for name := range injection.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if _, ok := secret.Data[injection.Env[name]]; !ok {
+				return util.NewFailedPreconditionError("Injected Secret %s/%s has no key %s for environment variable %s.", namespace, injection.SecretName, injection.Env[name], name)
+			}
+		}
+	}
+	return nil
+}
+
 func (r *ResourceManager) GetCluster(ctx context.Context, clusterName string, namespace string) (*rayv1api.RayCluster, error) {
+	// A RayCluster missing from the cache, e.g. because it was just created, is read from Kubernetes.
+	if resourceCache := r.cachedResources(ctx); resourceCache != nil {
+		if cluster, ok := resourceCache.GetCluster(namespace, clusterName); ok {
+			return cluster, nil
+		}
+	}
 	client := r.getRayClusterClient(namespace)
 	return getClusterByName(ctx, client, clusterName)
 }
 
-func (r *ResourceManager) ListClusters(ctx context.Context, namespace string) ([]*rayv1api.RayCluster, error) {
-	labelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			util.KubernetesManagedByLabelKey: util.ComponentName,
-		},
+// GetDashboardAuthToken returns the auth token of the Ray dashboard of a cluster with dashboard auth, which the
+// operator stores in a Secret.
+func (r *ResourceManager) GetDashboardAuthToken(ctx context.Context, clusterName string, namespace string) (string, error) {
+	secretName := utils.GenerateDashboardAuthSecretName(clusterName)
+	secret, err := r.getKubernetesSecretClient(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return "", util.Wrap(err, fmt.Sprintf("Get dashboard auth Secret %s/%s failed", namespace, secretName))
 	}
-	rayClusterList, err := r.getRayClusterClient(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
-	})
+	token := string(secret.Data[utils.DashboardAuthTokenKey])
+	if token == "" {
+		return "", util.NewInternalServerError(fmt.Errorf("the Secret has no %s key", utils.DashboardAuthTokenKey),
+			"Dashboard auth Secret %s/%s is not valid", namespace, secretName)
+	}
+	return token, nil
+}
+
+func (r *ResourceManager) ListClusters(ctx context.Context, namespace string, continueToken string, limit int64, selector ResourceSelector) ([]*rayv1api.RayCluster, string, error) {
+	// Selected lists are sent to Kubernetes, which evaluates the selectors.
+	if selector.IsEmpty() && r.cacheServesList(ctx, continueToken, limit) {
+		if clusters, ok := r.resourceCache.ListClusters(namespace); ok {
+			return clusters, "", nil
+		}
+	}
+	rayClusterList, err := r.getRayClusterClient(namespace).List(ctx, selectedListOptions(continueToken, limit, selector))
 	if err != nil {
-		return nil, util.Wrap(err, fmt.Sprintf("List RayCluster failed in %s", namespace))
+		return nil, "", util.Wrap(err, fmt.Sprintf("List RayCluster failed in %s", namespace))
 	}
 
-	var result []*rayv1api.RayCluster
-	length := len(rayClusterList.Items)
-	for i := 0; i < length; i++ {
+	result := make([]*rayv1api.RayCluster, 0, len(rayClusterList.Items))
+	for i := range rayClusterList.Items {
 		result = append(result, &rayClusterList.Items[i])
 	}
 
-	return result, nil
+	return result, rayClusterList.Continue, nil
 }
 
-func (r *ResourceManager) ListAllClusters(ctx context.Context) ([]*rayv1api.RayCluster, error) {
-	namespaces, err := r.getKubernetesNamespaceClient().List(ctx, metav1.ListOptions{})
+// ListAllClusters lists the RayClusters of all namespaces in a single call, so the continue token
+// returned by Kubernetes pages through the whole result. The returned list metadata has the continue token and the
+// resource version of the listing, which a Watch can start from. The calls are always sent to Kubernetes, because the
+// resource cache has no resource version matching its content.
+func (r *ResourceManager) ListAllClusters(ctx context.Context, continueToken string, limit int64, resourceVersion string, selector ResourceSelector) ([]*rayv1api.RayCluster, metav1.ListMeta, error) {
+	rayClusterList, err := r.getRayClusterClient(metav1.NamespaceAll).List(ctx, snapshotListOptions(continueToken, limit, resourceVersion, selector))
 	if err != nil {
-		return nil, util.Wrap(err, "Failed to fetch all Kubernetes namespaces")
+		return nil, metav1.ListMeta{}, listSnapshotError(err, resourceVersion, "List RayCluster failed in all namespaces")
 	}
-
-	var result []*rayv1api.RayCluster
-	labelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			util.KubernetesManagedByLabelKey: util.ComponentName,
-		},
+	if err := checkListSnapshot(resourceVersion, rayClusterList.ListMeta); err != nil {
+		return nil, metav1.ListMeta{}, err
 	}
-	for _, namespace := range namespaces.Items {
-		rayClusterList, err := r.getRayClusterClient(namespace.Name).List(ctx, metav1.ListOptions{
-			LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
-		})
-		if err != nil {
-			return nil, util.Wrap(err, fmt.Sprintf("List RayCluster failed in %s", namespace.Name))
-		}
 
-		length := len(rayClusterList.Items)
-		for i := 0; i < length; i++ {
-			result = append(result, &rayClusterList.Items[i])
-		}
+	result := make([]*rayv1api.RayCluster, 0, len(rayClusterList.Items))
+	for i := range rayClusterList.Items {
+		result = append(result, &rayClusterList.Items[i])
 	}
-	return result, nil
+
+	return result, rayClusterList.ListMeta, nil
 }
 
-func (r *ResourceManager) DeleteCluster(ctx context.Context, clusterName string, namespace string) error {
+func (r *ResourceManager) DeleteCluster(ctx context.Context, clusterName string, namespace string, force bool, resourceVersion string) error {
 	client := r.getRayClusterClient(namespace)
 	cluster, err := getClusterByName(ctx, client, clusterName)
 	if err != nil {
 		return util.Wrap(err, "Get cluster failure")
 	}
+	if err := checkResourceVersion(cluster, "Cluster", resourceVersion); err != nil {
+		return err
+	}
+	if err := checkDeletionProtection(cluster, "Cluster", force); err != nil {
+		return err
+	}
 
 	// Delete Kubernetes resources
-	if err := client.Delete(ctx, cluster.Name, metav1.DeleteOptions{}); err != nil {
+	if err := client.Delete(ctx, cluster.Name, deleteOptions(false, resourceVersion)); err != nil {
 		// API won't need to delete the ray cluster CR
 		return util.NewInternalServerError(err, "Failed to delete cluster %v.", clusterName)
 	}
@@ -207,16 +498,249 @@ func (r *ResourceManager) DeleteCluster(ctx context.Context, clusterName string,
 	return nil
 }
 
+// DrainAndDeleteCluster deletes a cluster once its running jobs finish, or once the timeout expires. The drain
+// finalizer is added here rather than by the operator, so that the cluster cannot be deleted before it is in place.
+func (r *ResourceManager) DrainAndDeleteCluster(ctx context.Context, clusterName string, namespace string, timeoutSeconds int32, force bool, resourceVersion string) error {
+	client := r.getRayClusterClient(namespace)
+	cluster, err := getClusterByName(ctx, client, clusterName)
+	if err != nil {
+		return util.Wrap(err, "Get cluster failure")
+	}
+	// The update enabling the drain fails too if the cluster changes after it was read.
+	if err := checkResourceVersion(cluster, "Cluster", resourceVersion); err != nil {
+		return err
+	}
+	if err := checkDeletionProtection(cluster, "Cluster", force); err != nil {
+		return err
+	}
+
+	cluster.Spec.DrainBeforeDeletion = &rayv1api.DrainBeforeDeletionConfig{}
+	if timeoutSeconds > 0 {
+		cluster.Spec.DrainBeforeDeletion.TimeoutSeconds = &timeoutSeconds
+	}
+	controllerutil.AddFinalizer(cluster, utils.RayClusterDrainFinalizer)
+	if _, err := client.Update(ctx, cluster, metav1.UpdateOptions{}); err != nil {
+		return util.NewInternalServerError(err, "Failed to enable draining for cluster %v.", clusterName)
+	}
+
+	if err := client.Delete(ctx, cluster.Name, metav1.DeleteOptions{}); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete cluster %v.", clusterName)
+	}
+
+	return nil
+}
+
+// UpdateCluster patches the replicas, min replicas and max replicas of the worker groups of a cluster. The rest of
+// the cluster must be unchanged, since the operator doesn't roll out the other changes to the running Pods.
+func (r *ResourceManager) UpdateCluster(ctx context.Context, apiCluster *api.Cluster) (*rayv1api.RayCluster, error) {
+	_, data, err := r.clusterUpdatePatch(ctx, apiCluster)
+	if err != nil {
+		return nil, err
+	}
+	newCluster, err := r.getRayClusterClient(apiCluster.Namespace).Patch(ctx, apiCluster.Name, types.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to update the replicas of cluster (%s/%s)", apiCluster.Namespace, apiCluster.Name)
+	}
+	return newCluster, nil
+}
+
+// clusterUpdatePatch returns the current RayCluster of an UpdateCluster call, and the admitted JSON patch updating
+// its worker groups.
+func (r *ResourceManager) clusterUpdatePatch(ctx context.Context, apiCluster *api.Cluster) (*rayv1api.RayCluster, []byte, error) {
+	cluster, err := getClusterByName(ctx, r.getRayClusterClient(apiCluster.Namespace), apiCluster.Name)
+	if err != nil {
+		return nil, nil, util.Wrap(err, "Get cluster failure")
+	}
+	if err := checkResourceVersion(cluster, "Cluster", apiCluster.ResourceVersion); err != nil {
+		return nil, nil, err
+	}
+
+	// The converter strips the default annotations and labels of the templates in place.
+	current := model.FromCrdToApiCluster(cluster.DeepCopy(), nil)
+	if field := immutableClusterChange(current, apiCluster); field != "" {
+		return nil, nil, util.NewInvalidInputError("The %s of cluster %s can not be updated, only the replicas, min replicas and max replicas of its worker groups can be changed.", field, apiCluster.Name)
+	}
+
+	patch := []map[string]interface{}{}
+	if apiCluster.ResourceVersion != "" {
+		// Fail instead of overwriting the changes made since the cluster was read.
+		patch = append(patch, map[string]interface{}{
+			"op": "test", "path": "/metadata/resourceVersion", "value": apiCluster.ResourceVersion,
+		})
+	}
+	for index, spec := range cluster.Spec.WorkerGroupSpecs {
+		requested := apiCluster.ClusterSpec.WorkerGroupSpec[index]
+		groupPath := fmt.Sprintf("/spec/workerGroupSpecs/%d", index)
+		patch = append(patch,
+			// Fail instead of updating another group if the worker groups were changed concurrently.
+			map[string]interface{}{"op": "test", "path": groupPath + "/groupName", "value": spec.GroupName},
+			map[string]interface{}{"op": "add", "path": groupPath + "/replicas", "value": requested.Replicas},
+			map[string]interface{}{"op": "add", "path": groupPath + "/minReplicas", "value": requested.MinReplicas},
+			map[string]interface{}{"op": "add", "path": groupPath + "/maxReplicas", "value": requested.MaxReplicas},
+		)
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, util.NewInternalServerError(err, "Failed to marshal the replicas patch of cluster %s", apiCluster.Name)
+	}
+	if data, err = r.admitPatch(ctx, cluster, data); err != nil {
+		return nil, nil, err
+	}
+	return cluster, data, nil
+}
+
+// immutableClusterChange returns the first field which differs between the current and the requested cluster,
+// ignoring the replicas of the worker groups and the output only fields, or an empty string.
+func immutableClusterChange(current *api.Cluster, requested *api.Cluster) string {
+	switch {
+	case current.Version != requested.Version:
+		return "version"
+	case current.User != requested.User:
+		return "user"
+	case current.Environment != requested.Environment:
+		return "environment"
+	case !maps.Equal(current.Annotations, requested.Annotations):
+		return "annotations"
+	}
+	currentSpec, requestedSpec := current.ClusterSpec, requested.ClusterSpec
+	if !proto.Equal(withoutRevision(currentSpec.GetHeadGroupSpec(), requestedSpec.GetHeadGroupSpec()), requestedSpec.GetHeadGroupSpec()) {
+		return "head group spec"
+	}
+	if len(currentSpec.GetWorkerGroupSpec()) != len(requestedSpec.GetWorkerGroupSpec()) {
+		return "number of worker groups"
+	}
+	for index, currentGroup := range currentSpec.GetWorkerGroupSpec() {
+		requestedGroup := requestedSpec.WorkerGroupSpec[index]
+		if currentGroup.GroupName != requestedGroup.GroupName {
+			return fmt.Sprintf("name of worker group %d", index)
+		}
+		if !proto.Equal(withoutReplicas(currentGroup, requestedGroup), withoutReplicas(requestedGroup, requestedGroup)) {
+			return fmt.Sprintf("spec of worker group %s", currentGroup.GroupName)
+		}
+	}
+	currentSpec, requestedSpec = proto.Clone(currentSpec).(*api.ClusterSpec), proto.Clone(requestedSpec).(*api.ClusterSpec)
+	currentSpec.HeadGroupSpec, requestedSpec.HeadGroupSpec = nil, nil
+	currentSpec.WorkerGroupSpec, requestedSpec.WorkerGroupSpec = nil, nil
+	if !proto.Equal(currentSpec, requestedSpec) {
+		return "cluster spec"
+	}
+	return ""
+}
+
+// withoutReplicas clears the replicas of a worker group spec, and its compute template revision unless the requested
+// spec pins one, since the requests which don't pin a revision keep the current one.
+func withoutReplicas(spec *api.WorkerGroupSpec, requested *api.WorkerGroupSpec) *api.WorkerGroupSpec {
+	spec = proto.Clone(spec).(*api.WorkerGroupSpec)
+	spec.Replicas, spec.MinReplicas, spec.MaxReplicas = 0, 0, 0
+	if requested.ComputeTemplateRevision == 0 {
+		spec.ComputeTemplateRevision = 0
+	}
+	return spec
+}
+
+// withoutRevision clears the compute template revision of a head group spec unless the requested spec pins one.
+func withoutRevision(spec *api.HeadGroupSpec, requested *api.HeadGroupSpec) *api.HeadGroupSpec {
+	if spec == nil || requested.GetComputeTemplateRevision() != 0 {
+		return spec
+	}
+	spec = proto.Clone(spec).(*api.HeadGroupSpec)
+	spec.ComputeTemplateRevision = 0
+	return spec
+}
+
+// UpdateWorkerGroupAutoscaling patches the min and max replicas of a worker group and, if set, the idle timeout
+// of the autoscaler. Only these fields are patched, so the rest of the cluster spec is left untouched.
+func (r *ResourceManager) UpdateWorkerGroupAutoscaling(ctx context.Context, request *api.UpdateWorkerGroupAutoscalingRequest) (*rayv1api.RayCluster, error) {
+	client := r.getRayClusterClient(request.Namespace)
+	cluster, err := getClusterByName(ctx, client, request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+
+	index, err := workerGroupIndex(cluster, request.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	groupPath := fmt.Sprintf("/spec/workerGroupSpecs/%d", index)
+	patch := []map[string]interface{}{
+		// Fail instead of updating another group if the worker groups were changed concurrently.
+		{"op": "test", "path": groupPath + "/groupName", "value": request.GroupName},
+		{"op": "add", "path": groupPath + "/minReplicas", "value": request.MinReplicas},
+		{"op": "add", "path": groupPath + "/maxReplicas", "value": request.MaxReplicas},
+	}
+	if request.IdleTimeoutSeconds > 0 {
+		if cluster.Spec.AutoscalerOptions == nil {
+			patch = append(patch, map[string]interface{}{
+				"op": "add", "path": "/spec/autoscalerOptions", "value": map[string]interface{}{"idleTimeoutSeconds": request.IdleTimeoutSeconds},
+			})
+		} else {
+			patch = append(patch, map[string]interface{}{
+				"op": "add", "path": "/spec/autoscalerOptions/idleTimeoutSeconds", "value": request.IdleTimeoutSeconds,
+			})
+		}
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the autoscaling patch of cluster %s", request.Name)
+	}
+	if data, err = r.admitPatch(ctx, cluster, data); err != nil {
+		return nil, err
+	}
+
+	newCluster, err := client.Patch(ctx, request.Name, types.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to update the autoscaling of worker group %s of cluster (%s/%s)", request.GroupName, request.Namespace, request.Name)
+	}
+	return newCluster, nil
+}
+
 func (r *ResourceManager) CreateJob(ctx context.Context, apiJob *api.RayJob) (*rayv1api.RayJob, error) {
+	cfg := config.Get()
+	if err := checkNamespaceAllowed(cfg, apiJob.Namespace); err != nil {
+		return nil, err
+	}
+	if err := checkQuota(ctx, "jobs", apiJob.Namespace, cfg.Quotas.MaxJobsPerNamespace, r.countJobs); err != nil {
+		return nil, err
+	}
+
+	rayJob, err := r.newRayJob(ctx, cfg, apiJob)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkResourceQuota(ctx, cfg, "job", apiJob.Name, apiJob.Namespace, rayJob.Spec.RayClusterSpec); err != nil {
+		return nil, err
+	}
+
+	newRayJob, err := r.getRayJobClient(apiJob.Namespace).Create(ctx, rayJob, metav1.CreateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a job for (%s/%s)", apiJob.Namespace, apiJob.JobId)
+	}
+
+	return newRayJob, nil
+}
+
+// newRayJob converts an API job to a RayJob, applying the defaults of the config and resolving the
+// compute templates of its cluster spec.
+func (r *ResourceManager) newRayJob(ctx context.Context, cfg *config.Config, apiJob *api.RayJob) (*rayv1api.RayJob, error) {
 	computeTemplateMap := make(map[string]*api.ComputeTemplate)
 	var err error
 
 	// populate cluster map
 	if apiJob.ClusterSpec != nil {
+		if err := applyClusterSpecDefaults(cfg, apiJob.Version, apiJob.ClusterSpec); err != nil {
+			return nil, err
+		}
 		computeTemplateMap, err = r.populateComputeTemplate(ctx, apiJob.ClusterSpec, apiJob.Namespace)
 		if err != nil {
 			return nil, util.NewInternalServerError(err, "Failed to populate compute template for (%s/%s)", apiJob.Namespace, apiJob.JobId)
 		}
+		if err := r.checkImagePullSecrets(ctx, apiJob.ClusterSpec, apiJob.Namespace); err != nil {
+			return nil, err
+		}
+		if err := r.checkInjectedSecrets(ctx, apiJob.ClusterSpec, apiJob.Namespace); err != nil {
+			return nil, err
+		}
 	}
 
 	// convert *api.Cluster to rayv1api.RayCluster
@@ -224,68 +748,81 @@ func (r *ResourceManager) CreateJob(ctx context.Context, apiJob *api.RayJob) (*r
 	if err != nil {
 		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to create a Ray Job")
 	}
+	if err := model.ApplyToCrdHooks(ctx, apiJob, rayJob.RayJob); err != nil {
+		return nil, err
+	}
+	if err := r.admit(ctx, AdmissionCreate, rayJob.RayJob); err != nil {
+		return nil, err
+	}
+	if apiJob.GitSource.GetCredentialsSecret() != "" {
+		if err := r.addGitCredentials(ctx, rayJob.Get(), apiJob.GitSource); err != nil {
+			return nil, err
+		}
+	}
+	return rayJob.Get(), nil
+}
 
-	newRayJob, err := r.getRayJobClient(apiJob.Namespace).Create(ctx, rayJob.Get(), metav1.CreateOptions{})
+// addGitCredentials sets the working_dir of a RayJob running from a private git repository to the URL of its archive
+// with the credentials of the Secret of its git source.
+func (r *ResourceManager) addGitCredentials(ctx context.Context, rayJob *rayv1api.RayJob, source *api.GitSource) error {
+	secret, err := r.getKubernetesSecretClient(rayJob.Namespace).Get(ctx, source.CredentialsSecret, metav1.GetOptions{})
 	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to create a job for (%s/%s)", apiJob.Namespace, apiJob.JobId)
+		if errors.IsNotFound(err) {
+			return util.NewFailedPreconditionError("Git credentials Secret %s/%s not found", rayJob.Namespace, source.CredentialsSecret)
+		}
+		return util.NewInternalServerError(err, "Failed to get git credentials Secret %s/%s", rayJob.Namespace, source.CredentialsSecret)
 	}
-
-	return newRayJob, nil
+	token := string(secret.Data[util.GitCredentialsTokenKey])
+	if token == "" {
+		return util.NewFailedPreconditionError("Git credentials Secret %s/%s has no %s key", rayJob.Namespace, source.CredentialsSecret, util.GitCredentialsTokenKey)
+	}
+	username := string(secret.Data[util.GitCredentialsUsernameKey])
+	if username == "" {
+		username = util.GitCredentialsDefaultUsername
+	}
+	workingDir, err := util.GitArchiveURL(source, url.UserPassword(username, token))
+	if err != nil {
+		return util.NewInvalidInputErrorWithDetails(err, "Failed to create a Ray Job")
+	}
+	if rayJob.Spec.RuntimeEnvYAML, err = util.WithWorkingDir(rayJob.Spec.RuntimeEnvYAML, workingDir); err != nil {
+		return util.NewInvalidInputErrorWithDetails(err, "Failed to create a Ray Job")
+	}
+	return nil
 }
 
 func (r *ResourceManager) GetJob(ctx context.Context, jobName string, namespace string) (*rayv1api.RayJob, error) {
+	if resourceCache := r.cachedResources(ctx); resourceCache != nil {
+		if job, ok := resourceCache.GetJob(namespace, jobName); ok {
+			return job, nil
+		}
+	}
 	client := r.getRayJobClient(namespace)
 	return getJobByName(ctx, client, jobName)
 }
 
-func (r *ResourceManager) ListJobs(ctx context.Context, namespace string) ([]*rayv1api.RayJob, error) {
-	labelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			util.KubernetesManagedByLabelKey: util.ComponentName,
-		},
+func (r *ResourceManager) ListJobs(ctx context.Context, namespace string, continueToken string, limit int64) ([]*rayv1api.RayJob, string, error) {
+	if r.cacheServesList(ctx, continueToken, limit) {
+		if jobs, ok := r.resourceCache.ListJobs(namespace); ok {
+			return jobs, "", nil
+		}
 	}
-	rayJobList, err := r.getRayJobClient(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
-	})
+	rayJobList, err := r.getRayJobClient(namespace).List(ctx, managedListOptions(continueToken, limit))
 	if err != nil {
-		return nil, util.Wrap(err, fmt.Sprintf("List RayCluster failed in %s", namespace))
+		return nil, "", util.Wrap(err, fmt.Sprintf("List RayJob failed in %s", namespace))
 	}
 
-	var result []*rayv1api.RayJob
-	length := len(rayJobList.Items)
-	for i := 0; i < length; i++ {
+	result := make([]*rayv1api.RayJob, 0, len(rayJobList.Items))
+	for i := range rayJobList.Items {
 		result = append(result, &rayJobList.Items[i])
 	}
 
-	return result, nil
+	return result, rayJobList.Continue, nil
 }
 
-func (r *ResourceManager) ListAllJobs(ctx context.Context) ([]*rayv1api.RayJob, error) {
-	namespaces, err := r.getKubernetesNamespaceClient().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, util.Wrap(err, "Failed to fetch all Kubernetes namespaces")
-	}
-
-	var result []*rayv1api.RayJob
-	labelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			util.KubernetesManagedByLabelKey: util.ComponentName,
-		},
-	}
-	for _, namespace := range namespaces.Items {
-		rayJobList, err := r.getRayJobClient(namespace.Name).List(ctx, metav1.ListOptions{
-			LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
-		})
-		if err != nil {
-			return nil, util.Wrap(err, fmt.Sprintf("List RayCluster failed in %s", namespace.Name))
-		}
-
-		length := len(rayJobList.Items)
-		for i := 0; i < length; i++ {
-			result = append(result, &rayJobList.Items[i])
-		}
-	}
-	return result, nil
+// ListAllJobs lists the RayJobs of all namespaces in a single call, so the continue token
+// returned by Kubernetes pages through the whole result.
+func (r *ResourceManager) ListAllJobs(ctx context.Context, continueToken string, limit int64) ([]*rayv1api.RayJob, string, error) {
+	return r.ListJobs(ctx, metav1.NamespaceAll, continueToken, limit)
 }
 
 func (r *ResourceManager) DeleteJob(ctx context.Context, jobName string, namespace string) error {
@@ -294,6 +831,9 @@ func (r *ResourceManager) DeleteJob(ctx context.Context, jobName string, namespa
 	if err != nil {
 		return util.Wrap(err, "Get job failure")
 	}
+	if isJobDeploymentFinished(job.Status.JobDeploymentStatus) {
+		r.archiveJob(ctx, job, true)
+	}
 
 	// Delete Kubernetes resources
 	if err := client.Delete(ctx, job.Name, metav1.DeleteOptions{}); err != nil {
@@ -304,22 +844,82 @@ func (r *ResourceManager) DeleteJob(ctx context.Context, jobName string, namespa
 	return nil
 }
 
-func (r *ResourceManager) CreateService(ctx context.Context, apiService *api.RayService) (*rayv1api.RayService, error) {
+func (r *ResourceManager) CreateService(ctx context.Context, apiService *api.RayService, dryRun bool, idempotencyKey string) (*rayv1api.RayService, error) {
+	cfg := config.Get()
+	if err := checkNamespaceAllowed(cfg, apiService.Namespace); err != nil {
+		return nil, err
+	}
+	client := r.getRayServiceClient(apiService.Namespace)
+	payloadHash := ""
+	if idempotencyKey != "" && !dryRun {
+		var err error
+		if payloadHash, err = hashCreatePayload(apiService); err != nil {
+			return nil, err
+		}
+		// A retried create returns the service before checking the quota, which the service counts towards.
+		if service, err := getIdempotentService(ctx, client, apiService.Name, idempotencyKey, payloadHash); err != nil || service != nil {
+			return service, err
+		}
+	}
+	if err := checkQuota(ctx, "services", apiService.Namespace, cfg.Quotas.MaxServicesPerNamespace, r.countServices); err != nil {
+		return nil, err
+	}
+	if err := applyClusterSpecDefaults(cfg, apiService.Version, apiService.ClusterSpec); err != nil {
+		return nil, err
+	}
+
 	// populate cluster map
 	computeTemplateDict, err := r.populateComputeTemplate(ctx, apiService.ClusterSpec, apiService.Namespace)
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to populate compute template for (%s/%s)", apiService.Namespace, apiService.Name)
 	}
+	if err := r.checkImagePullSecrets(ctx, apiService.ClusterSpec, apiService.Namespace); err != nil {
+		return nil, err
+	}
+	if err := r.checkInjectedSecrets(ctx, apiService.ClusterSpec, apiService.Namespace); err != nil {
+		return nil, err
+	}
 	rayService, err := util.NewRayService(apiService, computeTemplateDict)
 	if err != nil {
 		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to create a Ray Service")
 	}
+	if err := model.ApplyToCrdHooks(ctx, apiService, rayService.RayService); err != nil {
+		return nil, err
+	}
+	if err := r.admit(ctx, AdmissionCreate, rayService.RayService); err != nil {
+		return nil, err
+	}
+	if err := r.checkResourceQuota(ctx, cfg, "service", apiService.Name, apiService.Namespace, &rayService.Spec.RayClusterSpec); err != nil {
+		return nil, err
+	}
 	createdAt := r.clientManager.Time().Now().String()
 	rayService.Annotations["ray.io/creation-timestamp"] = createdAt
-	newRayService, err := r.getRayServiceClient(apiService.Namespace).Create(ctx, rayService.Get(), metav1.CreateOptions{})
+	if payloadHash != "" {
+		setIdempotencyAnnotations(&rayService.ObjectMeta, idempotencyKey, payloadHash)
+	}
+	newRayService, err := client.Create(ctx, rayService.Get(), createOptions(dryRun))
 	if err != nil {
+		// A concurrent retry created the service first.
+		if payloadHash != "" && errors.IsAlreadyExists(err) {
+			if service, err := getIdempotentService(ctx, client, apiService.Name, idempotencyKey, payloadHash); err != nil || service != nil {
+				return service, err
+			}
+		}
 		return nil, util.NewInternalServerError(err, "Failed to create service for (%s/%s)", rayService.Namespace, rayService.Name)
 	}
+	if !dryRun {
+		err := r.syncServiceExpose(ctx, newRayService)
+		if err == nil {
+			err = r.syncPodDisruptionBudgets(ctx, newRayService, "RayService", &newRayService.Spec.RayClusterSpec)
+		}
+		if err != nil {
+			// Delete the service, so that the request can be retried once the cause is fixed.
+			if deleteErr := client.Delete(ctx, newRayService.Name, metav1.DeleteOptions{}); deleteErr != nil {
+				klog.FromContext(ctx).Error(deleteErr, "Failed to delete the service which could not be exposed", "service", klog.KObj(newRayService))
+			}
+			return nil, err
+		}
+	}
 
 	return newRayService, nil
 }
@@ -332,74 +932,251 @@ func (r *ResourceManager) UpdateRayService(ctx context.Context, apiService *api.
 	if err != nil {
 		return nil, util.Wrap(err, fmt.Sprintf("Update service fail, no service named: %s ", name))
 	}
+	rayService, err := r.renderRayServiceUpdate(ctx, oldService, apiService)
+	if err != nil {
+		return nil, err
+	}
+	rayService.Annotations["ray.io/update-timestamp"] = r.clientManager.Time().Now().String()
+	rayService.ResourceVersion = oldService.DeepCopy().ResourceVersion
+	newRayService, err := client.Update(ctx, rayService.Get(), metav1.UpdateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to update service for (%s/%s)", rayService.Namespace, rayService.Name)
+	}
+	if err := r.syncServiceExpose(ctx, newRayService); err != nil {
+		return nil, err
+	}
+	if err := r.syncPodDisruptionBudgets(ctx, newRayService, "RayService", &newRayService.Spec.RayClusterSpec); err != nil {
+		return nil, err
+	}
+	return newRayService, nil
+}
+
+// renderRayServiceUpdate returns the admitted RayService an UpdateRayService call replaces the current one with.
+func (r *ResourceManager) renderRayServiceUpdate(ctx context.Context, oldService *rayv1api.RayService, apiService *api.RayService) (*util.RayService, error) {
+	if err := checkResourceVersion(oldService, "Ray service", apiService.ResourceVersion); err != nil {
+		return nil, err
+	}
+	if err := applyClusterSpecDefaults(config.Get(), apiService.Version, apiService.ClusterSpec); err != nil {
+		return nil, err
+	}
 	// populate cluster map
 	computeTemplateDict, err := r.populateComputeTemplate(ctx, apiService.ClusterSpec, apiService.Namespace)
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to populate compute template for (%s/%s)", apiService.Namespace, apiService.Name)
 	}
+	if err := r.checkImagePullSecrets(ctx, apiService.ClusterSpec, apiService.Namespace); err != nil {
+		return nil, err
+	}
+	if err := r.checkInjectedSecrets(ctx, apiService.ClusterSpec, apiService.Namespace); err != nil {
+		return nil, err
+	}
 	rayService, err := util.NewRayService(apiService, computeTemplateDict)
 	if err != nil {
 		return nil, err
 	}
-	rayService.Annotations["ray.io/update-timestamp"] = r.clientManager.Time().Now().String()
-	rayService.ResourceVersion = oldService.DeepCopy().ResourceVersion
-	newRayService, err := client.Update(ctx, rayService.Get(), metav1.UpdateOptions{})
+	if err := model.ApplyToCrdHooks(ctx, apiService, rayService.RayService); err != nil {
+		return nil, err
+	}
+	if err := r.admit(ctx, AdmissionUpdate, rayService.RayService); err != nil {
+		return nil, err
+	}
+	return rayService, nil
+}
+
+// UpdateRayServiceConfigs patches the serve config and the replicas of the given worker groups of a RayService.
+// Fields which are not set in the request are left untouched, so the serve config can be rolled out without
+// recreating the RayService.
+func (r *ResourceManager) UpdateRayServiceConfigs(ctx context.Context, request *api.UpdateRayServiceConfigsRequest) (*rayv1api.RayService, error) {
+	client := r.getRayServiceClient(request.Namespace)
+	service, err := getServiceByName(ctx, client, request.Name)
 	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to update service for (%s/%s)", rayService.Namespace, rayService.Name)
+		return nil, util.Wrap(err, "Get service failure")
 	}
-	return newRayService, nil
+	if err := checkResourceVersion(service, "Ray service", request.ResourceVersion); err != nil {
+		return nil, err
+	}
+
+	// Fail instead of overwriting the changes of a concurrent update.
+	patch := []map[string]interface{}{}
+	if service.ResourceVersion != "" {
+		patch = append(patch, map[string]interface{}{
+			"op": "test", "path": "/metadata/resourceVersion", "value": service.ResourceVersion,
+		})
+	}
+	updateTimestamp := r.clientManager.Time().Now().String()
+	if service.Annotations == nil {
+		patch = append(patch, map[string]interface{}{
+			"op": "add", "path": "/metadata/annotations", "value": map[string]string{"ray.io/update-timestamp": updateTimestamp},
+		})
+	} else {
+		patch = append(patch, map[string]interface{}{
+			"op": "add", "path": "/metadata/annotations/ray.io~1update-timestamp", "value": updateTimestamp,
+		})
+	}
+	serveConfigV2 := request.UpdateService.ServeConfig_V2
+	if autoscaling := request.UpdateService.DeploymentAutoscaling; len(autoscaling) > 0 {
+		if serveConfigV2 == "" {
+			serveConfigV2 = service.Spec.ServeConfigV2
+		}
+		if serveConfigV2, err = util.ApplyServeDeploymentAutoscaling(serveConfigV2, autoscaling); err != nil {
+			return nil, util.NewInvalidInputErrorWithDetails(err, fmt.Sprintf("Failed to update the deployment autoscaling of service %s", request.Name))
+		}
+	}
+	if serveConfigV2 != "" {
+		patch = append(patch, map[string]interface{}{
+			"op": "add", "path": "/spec/serveConfigV2", "value": serveConfigV2,
+		})
+	}
+	if updateSpecs := request.UpdateService.WorkerGroupUpdateSpec; len(updateSpecs) > 0 {
+		groupNames := map[string]bool{}
+		for _, spec := range service.Spec.RayClusterSpec.WorkerGroupSpecs {
+			groupNames[spec.GroupName] = true
+		}
+		for _, updateSpec := range updateSpecs {
+			if !groupNames[updateSpec.GroupName] {
+				return nil, util.NewNotFoundError(fmt.Errorf("worker group %s not found", updateSpec.GroupName), "Worker group %s not found in service %s", updateSpec.GroupName, request.Name)
+			}
+		}
+		workerGroupSpecs := util.UpdateRayServiceWorkerGroupSpecs(updateSpecs, service.Spec.RayClusterSpec.DeepCopy().WorkerGroupSpecs)
+		patch = append(patch, map[string]interface{}{
+			"op": "replace", "path": "/spec/rayClusterConfig/workerGroupSpecs", "value": workerGroupSpecs,
+		})
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the patch of service %s", request.Name)
+	}
+	if data, err = r.admitPatch(ctx, service, data); err != nil {
+		return nil, err
+	}
+
+	newService, err := client.Patch(ctx, request.Name, types.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to update the configs of service (%s/%s)", request.Namespace, request.Name)
+	}
+	return newService, nil
 }
 
 func (r *ResourceManager) GetService(ctx context.Context, serviceName, namespace string) (*rayv1api.RayService, error) {
+	if resourceCache := r.cachedResources(ctx); resourceCache != nil {
+		if service, ok := resourceCache.GetService(namespace, serviceName); ok {
+			return service, nil
+		}
+	}
 	client := r.getRayServiceClient(namespace)
 	return getServiceByName(ctx, client, serviceName)
 }
 
-func (r *ResourceManager) ListServices(ctx context.Context, namespace string) ([]*rayv1api.RayService, error) {
-	labelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			util.KubernetesManagedByLabelKey: util.ComponentName,
-		},
+func (r *ResourceManager) ListServices(ctx context.Context, namespace string, continueToken string, limit int64, selector ResourceSelector) ([]*rayv1api.RayService, string, error) {
+	// Selected lists are sent to Kubernetes, which evaluates the selectors.
+	if selector.IsEmpty() && r.cacheServesList(ctx, continueToken, limit) {
+		if services, ok := r.resourceCache.ListServices(namespace); ok {
+			return services, "", nil
+		}
 	}
-	rayServiceList, err := r.getRayServiceClient(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
-	})
+	rayServiceList, err := r.getRayServiceClient(namespace).List(ctx, selectedListOptions(continueToken, limit, selector))
 	if err != nil {
-		return nil, util.Wrap(err, fmt.Sprintf("List RayService failed in %s", namespace))
+		return nil, "", util.Wrap(err, fmt.Sprintf("List RayService failed in %s", namespace))
 	}
-	rayServices := make([]*rayv1api.RayService, 0)
-	for _, service := range rayServiceList.Items {
-		rayServices = append(rayServices, &service)
+
+	result := make([]*rayv1api.RayService, 0, len(rayServiceList.Items))
+	for i := range rayServiceList.Items {
+		result = append(result, &rayServiceList.Items[i])
 	}
 
-	return rayServices, nil
+	return result, rayServiceList.Continue, nil
 }
 
-func (r *ResourceManager) ListAllServices(ctx context.Context) ([]*rayv1api.RayService, error) {
-	rayServices := make([]*rayv1api.RayService, 0)
-
-	namespaces, err := r.getKubernetesNamespaceClient().List(ctx, metav1.ListOptions{})
+// ListAllServices lists the RayServices of all namespaces in a single call, so the continue token
+// returned by Kubernetes pages through the whole result. Like ListAllClusters, the returned list metadata has the
+// resource version of the listing and the calls are always sent to Kubernetes.
+func (r *ResourceManager) ListAllServices(ctx context.Context, continueToken string, limit int64, resourceVersion string, selector ResourceSelector) ([]*rayv1api.RayService, metav1.ListMeta, error) {
+	rayServiceList, err := r.getRayServiceClient(metav1.NamespaceAll).List(ctx, snapshotListOptions(continueToken, limit, resourceVersion, selector))
 	if err != nil {
-		return nil, util.Wrap(err, "Failed to fetch all Kubernetes namespaces")
+		return nil, metav1.ListMeta{}, listSnapshotError(err, resourceVersion, "List RayService failed in all namespaces")
+	}
+	if err := checkListSnapshot(resourceVersion, rayServiceList.ListMeta); err != nil {
+		return nil, metav1.ListMeta{}, err
 	}
 
-	for _, namespace := range namespaces.Items {
-		servicesByNamespace, err := r.ListServices(ctx, namespace.Name)
-		if err != nil {
-			return nil, util.Wrap(err, "List All Rayservices failed")
+	result := make([]*rayv1api.RayService, 0, len(rayServiceList.Items))
+	for i := range rayServiceList.Items {
+		result = append(result, &rayServiceList.Items[i])
+	}
+
+	return result, rayServiceList.ListMeta, nil
+}
+
+func (r *ResourceManager) DeleteService(ctx context.Context, serviceName, namespace string, force bool, foreground bool, resourceVersion string) error {
+	client := r.getRayServiceClient(namespace)
+	service, err := getServiceByName(ctx, client, serviceName)
+	if err != nil {
+		return util.Wrap(err, "delete ray service failure")
+	}
+	if err := checkResourceVersion(service, "Ray service", resourceVersion); err != nil {
+		return err
+	}
+	if err := checkDeletionProtection(service, "Ray service", force); err != nil {
+		return err
+	}
+	// The active cluster is deleted together with the ray service, so its protection applies as well.
+	if clusterName := service.Status.ActiveServiceStatus.RayClusterName; clusterName != "" && !force {
+		cluster, err := r.getRayClusterClient(namespace).Get(ctx, clusterName, metav1.GetOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return util.NewInternalServerError(err, "failed to get cluster %s of ray service %s.", clusterName, service.Name)
 		}
-		rayServices = append(rayServices, servicesByNamespace...)
+		if err == nil {
+			if err := checkDeletionProtection(cluster, "Cluster", force); err != nil {
+				return err
+			}
+		}
+	}
+	if err := client.Delete(ctx, service.Name, deleteOptions(foreground, resourceVersion)); err != nil {
+		return util.NewInternalServerError(err, "failed to delete ray service %s.", service.Name)
 	}
-	return rayServices, nil
+
+	return nil
 }
 
-func (r *ResourceManager) DeleteService(ctx context.Context, serviceName, namespace string) error {
+// DeleteServiceAndRetainCluster deletes a ray service but keeps its active cluster running. The cluster is released
+// from the ray service first, so that it is neither garbage collected with the ray service nor cleaned up by the next
+// ray service with the same name.
+func (r *ResourceManager) DeleteServiceAndRetainCluster(ctx context.Context, serviceName, namespace string, force bool, foreground bool, resourceVersion string) error {
 	client := r.getRayServiceClient(namespace)
 	service, err := getServiceByName(ctx, client, serviceName)
 	if err != nil {
 		return util.Wrap(err, "delete ray service failure")
 	}
-	if err := client.Delete(ctx, service.Name, metav1.DeleteOptions{}); err != nil {
+	if err := checkResourceVersion(service, "Ray service", resourceVersion); err != nil {
+		return err
+	}
+	if err := checkDeletionProtection(service, "Ray service", force); err != nil {
+		return err
+	}
+	clusterName := service.Status.ActiveServiceStatus.RayClusterName
+	if clusterName == "" {
+		return util.NewInvalidInputError("ray service %s has no active cluster to retain.", service.Name)
+	}
+
+	clusterClient := r.getRayClusterClient(namespace)
+	cluster, err := getClusterByName(ctx, clusterClient, clusterName)
+	if err != nil {
+		return util.Wrap(err, "Get cluster failure")
+	}
+	ownerReferences := make([]metav1.OwnerReference, 0, len(cluster.OwnerReferences))
+	for _, ownerReference := range cluster.OwnerReferences {
+		if ownerReference.UID != service.UID {
+			ownerReferences = append(ownerReferences, ownerReference)
+		}
+	}
+	cluster.OwnerReferences = ownerReferences
+	delete(cluster.Labels, utils.RayOriginatedFromCRNameLabelKey)
+	delete(cluster.Labels, utils.RayOriginatedFromCRDLabelKey)
+	if _, err := clusterClient.Update(ctx, cluster, metav1.UpdateOptions{}); err != nil {
+		return util.NewInternalServerError(err, "failed to release cluster %s from ray service %s.", clusterName, service.Name)
+	}
+
+	if err := client.Delete(ctx, service.Name, deleteOptions(foreground, resourceVersion)); err != nil {
 		return util.NewInternalServerError(err, "failed to delete ray service %s.", service.Name)
 	}
 
@@ -413,6 +1190,7 @@ func (r *ResourceManager) CreateComputeTemplate(ctx context.Context, runtime *ap
 		return nil, util.NewAlreadyExistError("Compute template with name %s already exists in namespace %s", runtime.Name, runtime.Namespace)
 	}
 
+	runtime.Revision = 1
 	computeTemplate, err := util.NewComputeTemplate(runtime)
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to convert compute runtime (%s/%s)", runtime.Namespace, runtime.Name)
@@ -423,6 +1201,9 @@ func (r *ResourceManager) CreateComputeTemplate(ctx context.Context, runtime *ap
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create a compute runtime for (%s/%s)", runtime.Namespace, runtime.Name)
 	}
+	if err := r.recordComputeTemplateRevision(ctx, newRuntime); err != nil {
+		return nil, err
+	}
 
 	return newRuntime, nil
 }
@@ -482,6 +1263,26 @@ func (r *ResourceManager) DeleteComputeTemplate(ctx context.Context, name string
 		return util.NewInternalServerError(err, "failed to delete compute template %v.", name)
 	}
 
+	return r.deleteComputeTemplateRevisions(ctx, name, namespace)
+}
+
+// checkDeletionProtection refuses to delete the objects with the deletion protection annotation, unless the deletion
+// is forced.
+func checkDeletionProtection(obj metav1.Object, kind string, force bool) error {
+	if !force && utils.IsDeletionProtected(obj) {
+		return util.NewFailedPreconditionError("%s %s is protected by the %s annotation. Please remove the annotation or force the deletion.",
+			kind, obj.GetName(), utils.RayDeletionProtectedAnnotationKey)
+	}
+	return nil
+}
+
+// checkResourceVersion refuses to change an object whose resource version is not the one the client read, so that
+// two clients can not overwrite each other's changes. An empty resource version skips the check.
+func checkResourceVersion(obj metav1.Object, kind string, resourceVersion string) error {
+	if resourceVersion != "" && resourceVersion != obj.GetResourceVersion() {
+		return util.NewConflictError("%s %s has been modified, its resource version is %s instead of %s. Please get it again and retry.",
+			kind, obj.GetName(), obj.GetResourceVersion(), resourceVersion)
+	}
 	return nil
 }
 
@@ -543,11 +1344,20 @@ func getComputeTemplateByName(ctx context.Context, client clientv1.ConfigMapInte
 
 		return nil, util.Wrap(err, "Get compute template failed")
 	}
+	if runtime.Labels["ray.io/config-type"] != "compute-template" {
+		return nil, util.NewNotFoundError(fmt.Errorf("ConfigMap %s is not a compute template", name), "Compute template %s not found", name)
+	}
 
 	return runtime, nil
 }
 
 func (r *ResourceManager) GetClusterEvents(ctx context.Context, clusterName string, namespace string) ([]corev1.Event, error) {
+	if events, ok := r.getCachedEvents(ctx, "RayCluster", namespace, clusterName); ok {
+		if len(events) == 0 {
+			return nil, fmt.Errorf("No Event with RayCluster name %s", clusterName)
+		}
+		return events, nil
+	}
 	client := r.getEventsClient(namespace)
 	clusterClient := r.getRayClusterClient(namespace)
 	return getRayClusterEventsByName(ctx, clusterName, client, clusterClient)
@@ -574,10 +1384,14 @@ func getRayClusterEventsByName(ctx context.Context, name string, client clientv1
 }
 
 func (r *ResourceManager) GetServiceEvents(ctx context.Context, service rayv1api.RayService) ([]corev1.Event, error) {
-	eventClient := r.getEventsClient(service.Namespace)
-	events, err := getRayServiceEventsByName(ctx, service.Name, eventClient)
-	if err != nil {
-		return nil, err
+	events, ok := r.getCachedEvents(ctx, "RayService", service.Namespace, service.Name)
+	if !ok {
+		var err error
+		eventClient := r.getEventsClient(service.Namespace)
+		events, err = getRayServiceEventsByName(ctx, service.Name, eventClient)
+		if err != nil {
+			return nil, err
+		}
 	}
 	if len(service.Status.ActiveServiceStatus.RayClusterName) > 0 {
 		clusterEvents, err := r.GetClusterEvents(ctx, service.Status.ActiveServiceStatus.RayClusterName, service.Namespace)
@@ -589,6 +1403,132 @@ func (r *ResourceManager) GetServiceEvents(ctx context.Context, service rayv1api
 	return events, nil
 }
 
+// GetServicesEvents returns the events of every given RayService and of its active RayCluster, keyed
+// by the name of the RayService. Instead of listing the events of each RayService, the events of the
+// RayServices and RayClusters are listed once per namespace and grouped in memory.
+func (r *ResourceManager) GetServicesEvents(ctx context.Context, services []*rayv1api.RayService) (map[string][]corev1.Event, error) {
+	serviceEventMap := make(map[string][]corev1.Event, len(services))
+	// The events of each namespace, by involved object key.
+	namespaceEvents := make(map[string]map[string][]corev1.Event)
+	for _, service := range services {
+		if _, ok := r.getCachedEvents(ctx, "RayService", service.Namespace, service.Name); ok {
+			events, err := r.GetServiceEvents(ctx, *service)
+			if err != nil {
+				return nil, err
+			}
+			serviceEventMap[service.Name] = events
+			continue
+		}
+
+		eventsByObject, ok := namespaceEvents[service.Namespace]
+		if !ok {
+			var err error
+			eventsByObject, err = listRayEventsByObject(ctx, r.getEventsClient(service.Namespace))
+			if err != nil {
+				return nil, err
+			}
+			namespaceEvents[service.Namespace] = eventsByObject
+		}
+		events := make([]corev1.Event, 0)
+		events = append(events, eventsByObject[involvedObjectKey("RayService", service.Namespace, service.Name)]...)
+		if clusterName := service.Status.ActiveServiceStatus.RayClusterName; len(clusterName) > 0 {
+			events = append(events, eventsByObject[involvedObjectKey("RayCluster", service.Namespace, clusterName)]...)
+		}
+		serviceEventMap[service.Name] = events
+	}
+	return serviceEventMap, nil
+}
+
+// listRayEventsByObject lists the events of the RayServices and RayClusters in the namespace of the
+// client, and groups them by involved object key.
+func listRayEventsByObject(ctx context.Context, client clientv1.EventInterface) (map[string][]corev1.Event, error) {
+	eventsByObject := make(map[string][]corev1.Event)
+	for _, kind := range []string{"RayService", "RayCluster"} {
+		events, err := client.List(ctx, metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("involvedObject.kind=%s", kind),
+		})
+		if err != nil {
+			return nil, util.Wrap(err, fmt.Sprintf("List %s events failed", kind))
+		}
+		for _, event := range events.Items {
+			if event.InvolvedObject.Kind != kind {
+				continue
+			}
+			key := involvedObjectKey(kind, event.Namespace, event.InvolvedObject.Name)
+			eventsByObject[key] = append(eventsByObject[key], event)
+		}
+	}
+	return eventsByObject, nil
+}
+
+// RayEventKinds are the kinds of the Ray resources whose events are listed by ListNamespaceEvents.
+var RayEventKinds = []string{"RayCluster", "RayJob", "RayService"}
+
+// ListNamespaceEvents returns the events of the Ray resources of the given kinds in a namespace, all the kinds
+// if none is given, sorted by the time they last occurred, most recent first. Only the events with one of the
+// given reasons are returned if any is given, and at most limit events if limit is positive.
+func (r *ResourceManager) ListNamespaceEvents(ctx context.Context, namespace string, kinds []string, reasons []string, limit int) ([]corev1.Event, error) {
+	if len(kinds) == 0 {
+		kinds = RayEventKinds
+	}
+	var events []corev1.Event
+	cached := false
+	if eventCache := r.cachedEvents(ctx); eventCache != nil {
+		events, cached = eventCache.NamespaceEvents(namespace, kinds)
+	}
+	if !cached {
+		client := r.getEventsClient(namespace)
+		for _, kind := range kinds {
+			list, err := client.List(ctx, metav1.ListOptions{
+				FieldSelector: fmt.Sprintf("involvedObject.kind=%s", kind),
+			})
+			if err != nil {
+				return nil, util.Wrap(err, fmt.Sprintf("List %s events failed", kind))
+			}
+			for _, event := range list.Items {
+				// The field selector is not applied by every client, e.g. the fake one of the tests.
+				if event.InvolvedObject.Kind == kind {
+					events = append(events, event)
+				}
+			}
+		}
+	}
+
+	if len(reasons) > 0 {
+		filtered := make([]corev1.Event, 0, len(events))
+		for _, event := range events {
+			if slices.Contains(reasons, event.Reason) {
+				filtered = append(filtered, event)
+			}
+		}
+		events = filtered
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		ti, tj := lastEventTime(events[i]), lastEventTime(events[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return events[i].Name < events[j].Name
+	})
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+// lastEventTime returns the last time an event occurred. Events recorded with the events.k8s.io API only
+// have an event time, and the creation time is the last resort.
+func lastEventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
 func getRayServiceEventsByName(ctx context.Context, name string, client clientv1.EventInterface) ([]corev1.Event, error) {
 	fieldSelectorById := fmt.Sprintf("involvedObject.name=%s", name)
 	events, err := client.List(ctx, metav1.ListOptions{
@@ -0,0 +1,187 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+const bytesPerGiB = 1 << 30
+
+// computeResources are the CPUs, GPUs and memory requested by a set of Pods.
+type computeResources struct {
+	milliCPUs   int64
+	gpus        int64
+	memoryBytes int64
+}
+
+func (c computeResources) add(other computeResources) computeResources {
+	return computeResources{
+		milliCPUs:   c.milliCPUs + other.milliCPUs,
+		gpus:        c.gpus + other.gpus,
+		memoryBytes: c.memoryBytes + other.memoryBytes,
+	}
+}
+
+func (c computeResources) sub(other computeResources) computeResources {
+	return computeResources{
+		milliCPUs:   c.milliCPUs - other.milliCPUs,
+		gpus:        c.gpus - other.gpus,
+		memoryBytes: c.memoryBytes - other.memoryBytes,
+	}
+}
+
+func (c computeResources) times(count int64) computeResources {
+	return computeResources{
+		milliCPUs:   c.milliCPUs * count,
+		gpus:        c.gpus * count,
+		memoryBytes: c.memoryBytes * count,
+	}
+}
+
+// podResources returns the resources of the containers of a Pod, i.e. their limits, or their requests if they have no
+// limits. Every resource whose name ends with gpu, e.g. nvidia.com/gpu or amd.com/gpu, counts as GPUs.
+func podResources(spec corev1.PodSpec) computeResources {
+	var resources computeResources
+	for _, container := range spec.Containers {
+		quantities := container.Resources.Requests.DeepCopy()
+		if quantities == nil {
+			quantities = corev1.ResourceList{}
+		}
+		for name, quantity := range container.Resources.Limits {
+			quantities[name] = quantity
+		}
+		resources = resources.add(resourceListResources(quantities))
+	}
+	return resources
+}
+
+// podRequests returns the resources requested by the containers of a Pod, which the scheduler fits on a Node.
+func podRequests(spec corev1.PodSpec) computeResources {
+	var resources computeResources
+	for _, container := range spec.Containers {
+		resources = resources.add(resourceListResources(container.Resources.Requests))
+	}
+	return resources
+}
+
+func resourceListResources(quantities corev1.ResourceList) computeResources {
+	var resources computeResources
+	for name, quantity := range quantities {
+		switch {
+		case name == corev1.ResourceCPU:
+			resources.milliCPUs += quantity.MilliValue()
+		case name == corev1.ResourceMemory:
+			resources.memoryBytes += quantity.Value()
+		case strings.HasSuffix(string(name), "gpu"):
+			resources.gpus += quantity.Value()
+		}
+	}
+	return resources
+}
+
+// clusterSpecResources returns the resources of the head Pod and of the desired worker Pods of a RayCluster.
+func clusterSpecResources(spec *rayv1api.RayClusterSpec) computeResources {
+	resources := podResources(spec.HeadGroupSpec.Template.Spec)
+	for _, group := range spec.WorkerGroupSpecs {
+		resources = resources.add(podResources(group.Template.Spec).times(workerGroupPods(group)))
+	}
+	return resources
+}
+
+// workerGroupPods returns the number of desired Pods of a worker group, at least its min replicas.
+func workerGroupPods(group rayv1api.WorkerGroupSpec) int64 {
+	replicas := int64(0)
+	if group.Replicas != nil {
+		replicas = int64(*group.Replicas)
+	}
+	if group.MinReplicas != nil && int64(*group.MinReplicas) > replicas {
+		replicas = int64(*group.MinReplicas)
+	}
+	if group.NumOfHosts > 1 {
+		replicas *= int64(group.NumOfHosts)
+	}
+	return replicas
+}
+
+// namespaceResources sums the resources of the RayClusters of a namespace, and of the RayClusters which the RayJobs
+// and RayServices of the namespace are about to create.
+func (r *ResourceManager) namespaceResources(ctx context.Context, namespace string) (computeResources, error) {
+	var resources computeResources
+	clusters, err := r.getRayClusterClient(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return resources, util.NewInternalServerError(err, "Failed to list the clusters of namespace %s", namespace)
+	}
+	for i := range clusters.Items {
+		resources = resources.add(clusterSpecResources(&clusters.Items[i].Spec))
+	}
+
+	jobs, err := r.getRayJobClient(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return resources, util.NewInternalServerError(err, "Failed to list the jobs of namespace %s", namespace)
+	}
+	for _, job := range jobs.Items {
+		if job.Spec.RayClusterSpec != nil && job.Status.RayClusterName == "" {
+			resources = resources.add(clusterSpecResources(job.Spec.RayClusterSpec))
+		}
+	}
+
+	services, err := r.getRayServiceClient(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return resources, util.NewInternalServerError(err, "Failed to list the services of namespace %s", namespace)
+	}
+	for _, service := range services.Items {
+		if service.Status.ActiveServiceStatus.RayClusterName == "" && service.Status.PendingServiceStatus.RayClusterName == "" {
+			resources = resources.add(clusterSpecResources(&service.Spec.RayClusterSpec))
+		}
+	}
+	return resources, nil
+}
+
+// checkResourceQuota returns an error if the RayCluster of a new resource would take the CPUs, GPUs or memory of its
+// namespace over the limits.
+func (r *ResourceManager) checkResourceQuota(ctx context.Context, cfg *config.Config, kind string, name string, namespace string, spec *rayv1api.RayClusterSpec) error {
+	if spec == nil {
+		return nil
+	}
+	return r.checkRequestedResources(ctx, cfg, kind+" "+name, namespace, clusterSpecResources(spec))
+}
+
+// checkRequestedResources returns an error if the resources requested by the requester, e.g. a new cluster, would take
+// the CPUs, GPUs or memory of the namespace over the limits.
+func (r *ResourceManager) checkRequestedResources(ctx context.Context, cfg *config.Config, requester string, namespace string, requested computeResources) error {
+	quotas := cfg.Quotas
+	if !quotas.ResourceQuotasEnabled() {
+		return nil
+	}
+	used, err := r.namespaceResources(ctx, namespace)
+	if err != nil {
+		return util.Wrap(err, fmt.Sprintf("Failed to check the resource quota of %s", namespace))
+	}
+	exceeded := func(resource string, used string, requested string, limit int) error {
+		return util.NewResourceExhaustedError("Quota exceeded: namespace %s already uses %s of %d allowed %s and %s requests %s more", namespace, used, limit, resource, requester, requested)
+	}
+	if quotas.MaxCPUsPerNamespace > 0 && used.milliCPUs+requested.milliCPUs > int64(quotas.MaxCPUsPerNamespace)*1000 {
+		return exceeded("CPUs", formatUnits(used.milliCPUs, 1000), formatUnits(requested.milliCPUs, 1000), quotas.MaxCPUsPerNamespace)
+	}
+	if quotas.MaxGPUsPerNamespace > 0 && used.gpus+requested.gpus > int64(quotas.MaxGPUsPerNamespace) {
+		return exceeded("GPUs", formatUnits(used.gpus, 1), formatUnits(requested.gpus, 1), quotas.MaxGPUsPerNamespace)
+	}
+	if quotas.MaxMemoryGiBPerNamespace > 0 && used.memoryBytes+requested.memoryBytes > int64(quotas.MaxMemoryGiBPerNamespace)*bytesPerGiB {
+		return exceeded("GiB of memory", formatUnits(used.memoryBytes, bytesPerGiB), formatUnits(requested.memoryBytes, bytesPerGiB), quotas.MaxMemoryGiBPerNamespace)
+	}
+	return nil
+}
+
+// formatUnits formats a value counted in fractions of a unit, e.g. millicores, as a number of units.
+func formatUnits(value int64, fractionsPerUnit int64) string {
+	return strconv.FormatFloat(float64(value)/float64(fractionsPerUnit), 'f', -1, 64)
+}
@@ -0,0 +1,96 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestCheckResourceQuota(t *testing.T) {
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	newCluster := func(name string) *api.Cluster {
+		// The head and two workers request 3 CPUs and 6 GiB of memory.
+		return &api.Cluster{
+			Name:      name,
+			Namespace: "team-a",
+			User:      "user",
+			Version:   "2.9.0",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{ComputeTemplate: "template"},
+				WorkerGroupSpec: []*api.WorkerGroupSpec{
+					{GroupName: "workers", ComputeTemplate: "template", Replicas: 2, MinReplicas: 1, MaxReplicas: 4},
+				},
+			},
+		}
+	}
+
+	config.Set(&config.Config{Quotas: config.Quotas{MaxCPUsPerNamespace: 8, MaxMemoryGiBPerNamespace: 10}})
+	_, err = resourceManager.CreateCluster(ctx, newCluster("first"), false, "")
+	require.NoError(t, err)
+	_, err = resourceManager.CreateCluster(ctx, newCluster("second"), false, "")
+	require.Error(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.ResourceExhausted))
+	assert.Contains(t, err.Error(), "namespace team-a already uses 6 of 10 allowed GiB of memory and cluster second requests 6 more")
+
+	// A job whose cluster is not created yet counts towards the quota.
+	config.Set(&config.Config{Quotas: config.Quotas{MaxCPUsPerNamespace: 8}})
+	_, err = clientManager.clients.Ray.RayV1().RayJobs("team-a").Create(ctx, &rayv1api.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "team-a"},
+		Spec: rayv1api.RayJobSpec{RayClusterSpec: &rayv1api.RayClusterSpec{
+			HeadGroupSpec: rayv1api.HeadGroupSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:      "ray-head",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2500m")}},
+			}}}}},
+		}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = resourceManager.CreateCluster(ctx, newCluster("second"), false, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "namespace team-a already uses 5.5 of 8 allowed CPUs and cluster second requests 3 more")
+
+	config.Set(&config.Config{Quotas: config.Quotas{MaxCPUsPerNamespace: 12}})
+	_, err = resourceManager.CreateCluster(ctx, newCluster("second"), false, "")
+	require.NoError(t, err)
+}
+
+func TestClusterSpecResources(t *testing.T) {
+	pod := func(resources corev1.ResourceRequirements) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker", Resources: resources}}}}
+	}
+	replicas := int32(2)
+	resources := clusterSpecResources(&rayv1api.RayClusterSpec{
+		HeadGroupSpec: rayv1api.HeadGroupSpec{Template: pod(corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		})},
+		WorkerGroupSpecs: []rayv1api.WorkerGroupSpec{{
+			Replicas:   &replicas,
+			NumOfHosts: 2,
+			Template: pod(corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("1")},
+			}),
+		}},
+	})
+	assert.Equal(t, computeResources{milliCPUs: 17000, gpus: 4, memoryBytes: 1 << 30}, resources)
+}
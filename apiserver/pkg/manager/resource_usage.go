@@ -0,0 +1,190 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// resourceUsage accumulates the resources of a set of RayClusters.
+type resourceUsage struct {
+	clusters  int32
+	pods      int64
+	requested computeResources
+	used      computeResources
+}
+
+func (u *resourceUsage) add(other *resourceUsage) {
+	u.clusters += other.clusters
+	u.pods += other.pods
+	u.requested = u.requested.add(other.requested)
+	u.used = u.used.add(other.used)
+}
+
+func (u *resourceUsage) toApi(includeUtilization bool) *api.ResourceUsage {
+	usage := &api.ResourceUsage{
+		Clusters: u.clusters,
+		Pods:     int32(u.pods),
+		Cpu:      resource.NewMilliQuantity(u.requested.milliCPUs, resource.DecimalSI).String(),
+		Memory:   resource.NewQuantity(u.requested.memoryBytes, resource.BinarySI).String(),
+		Gpu:      resource.NewQuantity(u.requested.gpus, resource.DecimalSI).String(),
+	}
+	if includeUtilization {
+		usage.CpuUsed = resource.NewMilliQuantity(u.used.milliCPUs, resource.DecimalSI).String()
+		usage.MemoryUsed = resource.NewQuantity(u.used.memoryBytes, resource.BinarySI).String()
+	}
+	return usage
+}
+
+// GetResourceUsage reports the resources requested by the RayClusters of a namespace, or of all the namespaces,
+// per namespace, per user and per RayCluster. The resources of a RayCluster are the ones of its head Pod and of the
+// Pods of its worker groups at their current replicas, the suspended RayClusters having no Pods. The utilization
+// which can not be retrieved from the metrics server is reported in the error of the report rather than failing the
+// call.
+func (r *ResourceManager) GetResourceUsage(ctx context.Context, namespace string, includeUtilization bool) (*api.ResourceUsageReport, error) {
+	clusters, _, err := r.ListClusters(ctx, namespace, "", 0, ResourceSelector{})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to list the clusters of the resource usage")
+	}
+	report := &api.ResourceUsageReport{}
+	var used map[types.NamespacedName]computeResources
+	if includeUtilization {
+		if used, err = r.clusterPodMetrics(ctx, namespace); err != nil {
+			report.UtilizationError = fmt.Sprintf("Failed to get the Pod metrics from the metrics server: %v", err)
+			includeUtilization = false
+		}
+	}
+
+	total := &resourceUsage{}
+	namespaces := map[string]*resourceUsage{}
+	users := map[string]*resourceUsage{}
+	for _, cluster := range clusters {
+		usage := clusterResourceUsage(cluster)
+		usage.used = used[types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}]
+		user := cluster.Labels[util.RayClusterUserLabelKey]
+		total.add(usage)
+		addResourceUsage(namespaces, cluster.Namespace, usage)
+		addResourceUsage(users, user, usage)
+
+		apiCluster := &api.ClusterResourceUsage{
+			Name:             cluster.Name,
+			Namespace:        cluster.Namespace,
+			User:             user,
+			ComputeTemplates: clusterComputeTemplates(cluster),
+			Usage:            usage.toApi(includeUtilization),
+		}
+		if owner := metav1.GetControllerOf(cluster); owner != nil && (owner.Kind == string(utils.RayJobCRD) || owner.Kind == string(utils.RayServiceCRD)) {
+			apiCluster.OwnerKind = owner.Kind
+			apiCluster.OwnerName = owner.Name
+		}
+		report.Clusters = append(report.Clusters, apiCluster)
+	}
+	sort.Slice(report.Clusters, func(i, j int) bool {
+		if report.Clusters[i].Namespace != report.Clusters[j].Namespace {
+			return report.Clusters[i].Namespace < report.Clusters[j].Namespace
+		}
+		return report.Clusters[i].Name < report.Clusters[j].Name
+	})
+	report.Total = total.toApi(includeUtilization)
+	report.Namespaces = namedResourceUsages(namespaces, includeUtilization)
+	report.Users = namedResourceUsages(users, includeUtilization)
+	return report, nil
+}
+
+// clusterResourceUsage returns the requested resources of a RayCluster at the current replicas of its worker groups.
+func clusterResourceUsage(cluster *rayv1api.RayCluster) *resourceUsage {
+	usage := &resourceUsage{clusters: 1}
+	if cluster.Spec.Suspend != nil && *cluster.Spec.Suspend {
+		return usage
+	}
+	usage.pods = 1
+	usage.requested = podRequests(cluster.Spec.HeadGroupSpec.Template.Spec)
+	for _, group := range cluster.Spec.WorkerGroupSpecs {
+		pods := workerGroupPods(group)
+		usage.pods += pods
+		usage.requested = usage.requested.add(podRequests(group.Template.Spec).times(pods))
+	}
+	return usage
+}
+
+// clusterComputeTemplates returns the distinct compute templates of the groups of a RayCluster, head group first.
+func clusterComputeTemplates(cluster *rayv1api.RayCluster) []string {
+	var templates []string
+	seen := map[string]bool{}
+	add := func(annotations map[string]string) {
+		if name := annotations[util.RayClusterComputeTemplateAnnotationKey]; name != "" && !seen[name] {
+			seen[name] = true
+			templates = append(templates, name)
+		}
+	}
+	add(cluster.Spec.HeadGroupSpec.Template.Annotations)
+	for _, group := range cluster.Spec.WorkerGroupSpecs {
+		add(group.Template.Annotations)
+	}
+	return templates
+}
+
+func addResourceUsage(usages map[string]*resourceUsage, name string, usage *resourceUsage) {
+	if usages[name] == nil {
+		usages[name] = &resourceUsage{}
+	}
+	usages[name].add(usage)
+}
+
+// namedResourceUsages returns the usages sorted by name.
+func namedResourceUsages(usages map[string]*resourceUsage, includeUtilization bool) []*api.NamedResourceUsage {
+	result := make([]*api.NamedResourceUsage, 0, len(usages))
+	for name, usage := range usages {
+		result = append(result, &api.NamedResourceUsage{Name: name, Usage: usage.toApi(includeUtilization)})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// clusterPodMetrics sums the CPUs and memory used by the containers of the Pods of the RayClusters of a namespace, or
+// of all the namespaces, per RayCluster, according to the metrics server.
+func (r *ResourceManager) clusterPodMetrics(ctx context.Context, namespace string) (map[types.NamespacedName]computeResources, error) {
+	podMetrics, err := r.clientManager.KubernetesClient().PodMetricsClient(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: utils.RayClusterLabelKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	used := map[types.NamespacedName]computeResources{}
+	for _, item := range podMetrics.Items {
+		key := types.NamespacedName{Namespace: item.GetNamespace(), Name: item.GetLabels()[utils.RayClusterLabelKey]}
+		containers, _, err := unstructured.NestedSlice(item.Object, "containers")
+		if err != nil {
+			return nil, fmt.Errorf("invalid metrics of Pod %s: %w", item.GetName(), err)
+		}
+		for _, container := range containers {
+			containerMap, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			usage, _, err := unstructured.NestedStringMap(containerMap, "usage")
+			if err != nil {
+				return nil, fmt.Errorf("invalid metrics of Pod %s: %w", item.GetName(), err)
+			}
+			var resources computeResources
+			if cpu, err := resource.ParseQuantity(usage["cpu"]); err == nil {
+				resources.milliCPUs = cpu.MilliValue()
+			}
+			if memory, err := resource.ParseQuantity(usage["memory"]); err == nil {
+				resources.memoryBytes = memory.Value()
+			}
+			used[key] = used[key].add(resources)
+		}
+	}
+	return used, nil
+}
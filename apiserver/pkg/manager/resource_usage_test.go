@@ -0,0 +1,116 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestGetResourceUsage(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: 1, Memory: 2})
+	require.NoError(t, err)
+	_, err = resourceManager.CreateCluster(ctx, &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		User:      "alice",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{ComputeTemplate: "template"},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{GroupName: "small", ComputeTemplate: "template", Replicas: 3, MinReplicas: 1, MaxReplicas: 5},
+			},
+		},
+	}, false, "")
+	require.NoError(t, err)
+
+	// A cluster of a job, with a GPU worker group, and a suspended cluster.
+	gpuWorker := corev1.PodSpec{Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("16Gi"),
+		"nvidia.com/gpu":      resource.MustParse("1"),
+	}}}}}
+	jobCluster := &rayv1api.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-cluster",
+			Namespace: "team-b",
+			Labels:    map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName, util.RayClusterUserLabelKey: "bob"},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "ray.io/v1", Kind: "RayJob", Name: "job", UID: "uid", Controller: ptr.To(true)},
+			},
+		},
+		Spec: rayv1api.RayClusterSpec{WorkerGroupSpecs: []rayv1api.WorkerGroupSpec{
+			{GroupName: "gpu", Replicas: ptr.To(int32(2)), Template: corev1.PodTemplateSpec{Spec: gpuWorker}},
+		}},
+	}
+	suspended := &rayv1api.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "suspended",
+			Namespace: "team-b",
+			Labels:    map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName, util.RayClusterUserLabelKey: "alice"},
+		},
+		Spec: rayv1api.RayClusterSpec{Suspend: ptr.To(true), WorkerGroupSpecs: []rayv1api.WorkerGroupSpec{
+			{GroupName: "gpu", Replicas: ptr.To(int32(2)), Template: corev1.PodTemplateSpec{Spec: gpuWorker}},
+		}},
+	}
+	for _, cluster := range []*rayv1api.RayCluster{jobCluster, suspended} {
+		_, err = clientManager.clients.Ray.RayV1().RayClusters("team-b").Create(ctx, cluster, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	report, err := resourceManager.GetResourceUsage(ctx, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, &api.ResourceUsage{Clusters: 3, Pods: 7, Cpu: "12", Memory: "40Gi", Gpu: "2"}, report.Total)
+	require.Len(t, report.Namespaces, 2)
+	assert.Equal(t, "team-a", report.Namespaces[0].Name)
+	assert.Equal(t, &api.ResourceUsage{Clusters: 1, Pods: 4, Cpu: "4", Memory: "8Gi", Gpu: "0"}, report.Namespaces[0].Usage)
+	require.Len(t, report.Users, 2)
+	assert.Equal(t, "alice", report.Users[0].Name)
+	assert.Equal(t, int32(2), report.Users[0].Usage.Clusters)
+	assert.Equal(t, "8", report.Users[1].Usage.Cpu)
+	require.Len(t, report.Clusters, 3)
+	assert.Equal(t, []string{"template"}, report.Clusters[0].ComputeTemplates)
+	assert.Equal(t, "RayJob", report.Clusters[1].OwnerKind)
+	assert.Equal(t, "job", report.Clusters[1].OwnerName)
+	assert.Equal(t, &api.ResourceUsage{Clusters: 1, Memory: "0", Cpu: "0", Gpu: "0"}, report.Clusters[2].Usage)
+
+	podMetrics := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"kind":       "PodMetrics",
+		"metadata": map[string]interface{}{
+			"name":      "cluster-head",
+			"namespace": "team-a",
+			"labels":    map[string]interface{}{utils.RayClusterLabelKey: "cluster"},
+		},
+		"containers": []interface{}{
+			map[string]interface{}{"name": "ray-head", "usage": map[string]interface{}{"cpu": "250m", "memory": "512Mi"}},
+			map[string]interface{}{"name": "autoscaler", "usage": map[string]interface{}{"cpu": "100m", "memory": "1Gi"}},
+		},
+	}}
+	_, err = clientManager.clients.Dynamic.Resource(client.PodMetricsGVR).Namespace("team-a").Create(ctx, podMetrics, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	report, err = resourceManager.GetResourceUsage(ctx, "team-a", true)
+	require.NoError(t, err)
+	assert.Empty(t, report.UtilizationError)
+	require.Len(t, report.Clusters, 1)
+	assert.Equal(t, "350m", report.Clusters[0].Usage.CpuUsed)
+	assert.Equal(t, "1536Mi", report.Clusters[0].Usage.MemoryUsed)
+	assert.Equal(t, "350m", report.Total.CpuUsed)
+}
@@ -0,0 +1,395 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// unlimited is the room left by a quota which does not limit a resource.
+const unlimited = math.MaxInt64
+
+// podSet is a number of Pods with the same spec, e.g. the Pods of a worker group.
+type podSet struct {
+	description string
+	spec        corev1.PodSpec
+	count       int64
+}
+
+// schedulingLimit is the limit which keeps Pods from being scheduled.
+type schedulingLimit struct {
+	dimension api.SchedulingAdvice_Dimension
+	reason    string
+}
+
+// nodeRoom is the allocatable resources of a Node which the Pods running on it do not request.
+type nodeRoom struct {
+	node *corev1.Node
+	free computeResources
+}
+
+// CanSchedule checks whether the Pods of a cluster spec, or the replicas of a compute template, fit in the
+// ResourceQuotas of a namespace, in the resource quotas of the API server and on the ready Nodes. The Pods are placed
+// on the Nodes one by one, on the first Node with room for them and which matches their node selector and
+// tolerations. Their node affinity and the other scheduling constraints are not checked.
+func (r *ResourceManager) CanSchedule(ctx context.Context, namespace string, clusterSpec *api.ClusterSpec, computeTemplate string, replicas int32) (*api.SchedulingAdvice, error) {
+	pods, err := r.podSets(ctx, namespace, clusterSpec, computeTemplate, replicas)
+	if err != nil {
+		return nil, err
+	}
+	var requests, limits computeResources
+	for _, set := range pods {
+		requests = requests.add(podRequests(set.spec).times(set.count))
+		limits = limits.add(podResources(set.spec).times(set.count))
+	}
+
+	nodes, err := r.nodeRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	available, nodeLimit := fitOnNodes(pods, nodes)
+	advice := &api.SchedulingAdvice{
+		Schedulable: true,
+		Requested:   schedulingResources(requests),
+		Available:   schedulingResources(available),
+	}
+
+	limit, err := r.resourceQuotaLimit(ctx, namespace, requests, limits)
+	if err != nil {
+		return nil, err
+	}
+	if limit == nil {
+		if limit, err = r.apiServerQuotaLimit(ctx, config.Get().Quotas, namespace, limits); err != nil {
+			return nil, err
+		}
+	}
+	if limit == nil {
+		limit = nodeLimit
+	}
+	if limit != nil {
+		advice.Schedulable = false
+		advice.LimitingDimension = limit.dimension
+		advice.Reason = limit.reason
+	}
+	return advice, nil
+}
+
+// podSets returns the head and worker Pods of a cluster spec, or the replicas of a compute template when the cluster
+// spec is nil.
+func (r *ResourceManager) podSets(ctx context.Context, namespace string, clusterSpec *api.ClusterSpec, computeTemplate string, replicas int32) ([]podSet, error) {
+	if clusterSpec == nil {
+		configMap, err := r.GetComputeTemplate(ctx, computeTemplate, namespace)
+		if err != nil {
+			return nil, err
+		}
+		spec, err := util.NewComputeTemplatePodSpec(model.FromKubeToAPIComputeTemplate(configMap))
+		if err != nil {
+			return nil, util.NewInvalidInputErrorWithDetails(err, fmt.Sprintf("Failed to build the Pods of compute template %s", computeTemplate))
+		}
+		if replicas == 0 {
+			replicas = 1
+		}
+		return []podSet{{description: "the pods of compute template " + computeTemplate, spec: *spec, count: int64(replicas)}}, nil
+	}
+
+	computeTemplateDict, err := r.populateComputeTemplate(ctx, clusterSpec, namespace)
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("Failed to populate compute template for namespace %s", namespace))
+	}
+	rayCluster, err := util.NewRayCluster(&api.Cluster{Namespace: namespace, ClusterSpec: clusterSpec}, computeTemplateDict)
+	if err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to build the Pods of the cluster spec")
+	}
+	pods := []podSet{{description: "the head pod", spec: rayCluster.Spec.HeadGroupSpec.Template.Spec, count: 1}}
+	for _, group := range rayCluster.Spec.WorkerGroupSpecs {
+		pods = append(pods, podSet{
+			description: "the pods of worker group " + group.GroupName,
+			spec:        group.Template.Spec,
+			count:       workerGroupPods(group),
+		})
+	}
+	return pods, nil
+}
+
+// nodeRooms returns the room left on the ready and schedulable Nodes by the Pods which are not terminated.
+func (r *ResourceManager) nodeRooms(ctx context.Context) ([]*nodeRoom, error) {
+	// The Nodes, and the Pods of every namespace, are read by the API server itself, even for an impersonated caller.
+	ctx = client.WithoutImpersonation(ctx)
+	nodes, err := r.clientManager.KubernetesClient().NodeClient().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the nodes")
+	}
+	rooms := []*nodeRoom{}
+	roomsByName := map[string]*nodeRoom{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Spec.Unschedulable || !isNodeReady(node) {
+			continue
+		}
+		room := &nodeRoom{node: node, free: resourceListResources(node.Status.Allocatable)}
+		rooms = append(rooms, room)
+		roomsByName[node.Name] = room
+	}
+	if len(rooms) == 0 {
+		return rooms, nil
+	}
+
+	pods, err := r.clientManager.KubernetesClient().PodClient("").List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the pods of the nodes")
+	}
+	for _, pod := range pods.Items {
+		room, ok := roomsByName[pod.Spec.NodeName]
+		if !ok || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		room.free = room.free.sub(podRequests(pod.Spec))
+	}
+	return rooms, nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// acceptsPods returns whether a Node matches the node selector of Pods, and whether the Pods tolerate the taints
+// of the Node which keep Pods away.
+func acceptsPods(node *corev1.Node, spec corev1.PodSpec) bool {
+	if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range spec.Tolerations {
+			if spec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// fitOnNodes places Pods one by one on the first Node which accepts them and has room for them. It returns the room
+// on the Nodes accepting any of the Pods, before they are placed, and the limit which keeps a Pod from being placed.
+func fitOnNodes(pods []podSet, nodes []*nodeRoom) (computeResources, *schedulingLimit) {
+	var available computeResources
+	free := make([]computeResources, len(nodes))
+	for i, room := range nodes {
+		free[i] = room.free
+		for _, set := range pods {
+			if acceptsPods(room.node, set.spec) {
+				available = available.add(room.free)
+				break
+			}
+		}
+	}
+
+	for _, set := range pods {
+		request := podRequests(set.spec)
+		for n := int64(1); n <= set.count; n++ {
+			accepting := 0
+			missing := map[api.SchedulingAdvice_Dimension]int{}
+			placed := false
+			for i, room := range nodes {
+				if !acceptsPods(room.node, set.spec) {
+					continue
+				}
+				accepting++
+				dimension := exceededDimension(request, free[i])
+				if dimension == api.SchedulingAdvice_DIMENSION_UNSPECIFIED {
+					free[i] = free[i].sub(request)
+					placed = true
+					break
+				}
+				missing[dimension]++
+			}
+			if placed {
+				continue
+			}
+			if accepting == 0 {
+				return available, &schedulingLimit{
+					reason: fmt.Sprintf("No ready node matches the node selector and tolerations of %s", set.description),
+				}
+			}
+			return available, &schedulingLimit{
+				dimension: mostMissing(missing),
+				reason: fmt.Sprintf("None of the %d ready nodes accepting %s has room for pod %d of %d, which requests %s",
+					accepting, set.description, n, set.count, formatResources(request)),
+			}
+		}
+	}
+	return available, nil
+}
+
+// mostMissing returns the resource which most Nodes lack.
+func mostMissing(missing map[api.SchedulingAdvice_Dimension]int) api.SchedulingAdvice_Dimension {
+	most := api.SchedulingAdvice_DIMENSION_UNSPECIFIED
+	for _, dimension := range []api.SchedulingAdvice_Dimension{api.SchedulingAdvice_CPU, api.SchedulingAdvice_MEMORY, api.SchedulingAdvice_GPU} {
+		if missing[dimension] > missing[most] {
+			most = dimension
+		}
+	}
+	return most
+}
+
+// resourceQuotaLimit returns the limit of the ResourceQuotas of a namespace which Pods exceed, if any. The
+// ResourceQuotas restricted to scopes, e.g. to a priority class, are not checked.
+func (r *ResourceManager) resourceQuotaLimit(ctx context.Context, namespace string, requests computeResources, limits computeResources) (*schedulingLimit, error) {
+	quotas, err := r.clientManager.KubernetesClient().ResourceQuotaClient(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the resource quotas of namespace %s", namespace)
+	}
+	for _, quota := range quotas.Items {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		names := make([]string, 0, len(quota.Spec.Hard))
+		for name := range quota.Spec.Hard {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			// A quota on cpu is a quota on requests.cpu.
+			resourceName := corev1.ResourceName(strings.TrimPrefix(strings.TrimPrefix(name, "requests."), "limits."))
+			dimension := resourceDimension(resourceName)
+			if dimension == api.SchedulingAdvice_DIMENSION_UNSPECIFIED {
+				continue
+			}
+			requested := requests
+			if strings.HasPrefix(name, "limits.") {
+				requested = limits
+			}
+			hard := resourceListResources(corev1.ResourceList{resourceName: quota.Spec.Hard[corev1.ResourceName(name)]})
+			used := resourceListResources(corev1.ResourceList{resourceName: quota.Status.Used[corev1.ResourceName(name)]})
+			left := hard.sub(used).get(dimension)
+			if requested.get(dimension) > left {
+				return &schedulingLimit{
+					dimension: dimension,
+					reason: fmt.Sprintf("ResourceQuota %s of namespace %s has %s of %s left, and the pods request %s",
+						quota.Name, namespace, formatDimension(dimension, left), name, formatDimension(dimension, requested.get(dimension))),
+				}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// apiServerQuotaLimit returns the resource quota of the API server which Pods exceed in a namespace, if any.
+func (r *ResourceManager) apiServerQuotaLimit(ctx context.Context, quotas config.Quotas, namespace string, limits computeResources) (*schedulingLimit, error) {
+	if !quotas.ResourceQuotasEnabled() {
+		return nil, nil
+	}
+	used, err := r.namespaceResources(ctx, namespace)
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("Failed to check the resource quota of %s", namespace))
+	}
+	left := computeResources{milliCPUs: unlimited, gpus: unlimited, memoryBytes: unlimited}
+	if quotas.MaxCPUsPerNamespace > 0 {
+		left.milliCPUs = int64(quotas.MaxCPUsPerNamespace)*1000 - used.milliCPUs
+	}
+	if quotas.MaxGPUsPerNamespace > 0 {
+		left.gpus = int64(quotas.MaxGPUsPerNamespace) - used.gpus
+	}
+	if quotas.MaxMemoryGiBPerNamespace > 0 {
+		left.memoryBytes = int64(quotas.MaxMemoryGiBPerNamespace)*bytesPerGiB - used.memoryBytes
+	}
+	dimension := exceededDimension(limits, left)
+	if dimension == api.SchedulingAdvice_DIMENSION_UNSPECIFIED {
+		return nil, nil
+	}
+	return &schedulingLimit{
+		dimension: dimension,
+		reason: fmt.Sprintf("The API server quota of namespace %s has %s left, and the pods request %s",
+			namespace, formatDimension(dimension, left.get(dimension)), formatDimension(dimension, limits.get(dimension))),
+	}, nil
+}
+
+// exceededDimension returns the first resource of which more is requested than is free.
+func exceededDimension(requested computeResources, free computeResources) api.SchedulingAdvice_Dimension {
+	switch {
+	case requested.milliCPUs > free.milliCPUs:
+		return api.SchedulingAdvice_CPU
+	case requested.memoryBytes > free.memoryBytes:
+		return api.SchedulingAdvice_MEMORY
+	case requested.gpus > free.gpus:
+		return api.SchedulingAdvice_GPU
+	}
+	return api.SchedulingAdvice_DIMENSION_UNSPECIFIED
+}
+
+// resourceDimension returns the dimension counting a Kubernetes resource, like podResources does.
+func resourceDimension(name corev1.ResourceName) api.SchedulingAdvice_Dimension {
+	switch {
+	case name == corev1.ResourceCPU:
+		return api.SchedulingAdvice_CPU
+	case name == corev1.ResourceMemory:
+		return api.SchedulingAdvice_MEMORY
+	case strings.HasSuffix(string(name), "gpu"):
+		return api.SchedulingAdvice_GPU
+	}
+	return api.SchedulingAdvice_DIMENSION_UNSPECIFIED
+}
+
+func (c computeResources) get(dimension api.SchedulingAdvice_Dimension) int64 {
+	switch dimension {
+	case api.SchedulingAdvice_CPU:
+		return c.milliCPUs
+	case api.SchedulingAdvice_MEMORY:
+		return c.memoryBytes
+	case api.SchedulingAdvice_GPU:
+		return c.gpus
+	}
+	return 0
+}
+
+func formatDimension(dimension api.SchedulingAdvice_Dimension, value int64) string {
+	switch dimension {
+	case api.SchedulingAdvice_CPU:
+		return formatUnits(value, 1000) + " CPUs"
+	case api.SchedulingAdvice_MEMORY:
+		return formatUnits(value, bytesPerGiB) + " GiB of memory"
+	}
+	return formatUnits(value, 1) + " GPUs"
+}
+
+func formatResources(resources computeResources) string {
+	return fmt.Sprintf("%s, %s and %s",
+		formatDimension(api.SchedulingAdvice_CPU, resources.milliCPUs),
+		formatDimension(api.SchedulingAdvice_MEMORY, resources.memoryBytes),
+		formatDimension(api.SchedulingAdvice_GPU, resources.gpus))
+}
+
+func schedulingResources(resources computeResources) *api.SchedulingResources {
+	return &api.SchedulingResources{
+		Cpus:      float64(resources.milliCPUs) / 1000,
+		MemoryGib: float64(resources.memoryBytes) / bytesPerGiB,
+		Gpus:      resources.gpus,
+	}
+}
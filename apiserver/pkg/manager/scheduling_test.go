@@ -0,0 +1,126 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestCanSchedule(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	kubernetes := clientManager.clients.Kubernetes
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       4,
+		Memory:    8,
+	})
+	require.NoError(t, err)
+	newNode := func(name string, cpu string, taints ...corev1.Taint) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse("64Gi"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	for _, node := range []*corev1.Node{
+		newNode("node-a", "8"),
+		newNode("node-b", "8"),
+		newNode("node-gpu", "64", corev1.Taint{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule}),
+	} {
+		_, err = kubernetes.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	_, err = kubernetes.CoreV1().Pods("team-b").Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "busy", Namespace: "team-b"},
+		Spec: corev1.PodSpec{
+			NodeName: "node-b",
+			Containers: []corev1.Container{{
+				Name: "busy",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("6")},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// node-a has room for 2 Pods and node-b has room for none, the tainted node is not tolerated.
+	advice, err := resourceManager.CanSchedule(ctx, "team-a", nil, "template", 2)
+	require.NoError(t, err)
+	assert.True(t, advice.Schedulable, advice.Reason)
+	assert.Equal(t, float64(8), advice.Requested.Cpus)
+	assert.Equal(t, float64(10), advice.Available.Cpus)
+
+	advice, err = resourceManager.CanSchedule(ctx, "team-a", nil, "template", 3)
+	require.NoError(t, err)
+	assert.False(t, advice.Schedulable)
+	assert.Equal(t, api.SchedulingAdvice_CPU, advice.LimitingDimension)
+	assert.Equal(t, "None of the 2 ready nodes accepting the pods of compute template template has room for pod 3 of 3, which requests 4 CPUs, 8 GiB of memory and 0 GPUs", advice.Reason)
+
+	_, err = kubernetes.CoreV1().ResourceQuotas("team-a").Create(ctx, &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("32Gi")},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Used: corev1.ResourceList{corev1.ResourceRequestsMemory: resource.MustParse("20Gi")},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	advice, err = resourceManager.CanSchedule(ctx, "team-a", nil, "template", 2)
+	require.NoError(t, err)
+	assert.False(t, advice.Schedulable)
+	assert.Equal(t, api.SchedulingAdvice_MEMORY, advice.LimitingDimension)
+	assert.Equal(t, "ResourceQuota compute of namespace team-a has 12 GiB of memory of requests.memory left, and the pods request 16 GiB of memory", advice.Reason)
+
+	advice, err = resourceManager.CanSchedule(ctx, "team-a", &api.ClusterSpec{
+		HeadGroupSpec: &api.HeadGroupSpec{
+			ComputeTemplate: "template",
+			RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+		},
+	}, "", 0)
+	require.NoError(t, err)
+	assert.True(t, advice.Schedulable, advice.Reason)
+	assert.Equal(t, float64(8), advice.Requested.MemoryGib)
+}
+
+func TestAcceptsPods(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"pool": "gpu"}},
+		Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+			{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule},
+			{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+		}},
+	}
+	toleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}
+
+	assert.False(t, acceptsPods(node, corev1.PodSpec{}))
+	assert.True(t, acceptsPods(node, corev1.PodSpec{Tolerations: []corev1.Toleration{toleration}}))
+	assert.True(t, acceptsPods(node, corev1.PodSpec{
+		NodeSelector: map[string]string{"pool": "gpu"},
+		Tolerations:  []corev1.Toleration{toleration},
+	}))
+	assert.False(t, acceptsPods(node, corev1.PodSpec{
+		NodeSelector: map[string]string{"pool": "cpu"},
+		Tolerations:  []corev1.Toleration{toleration},
+	}))
+}
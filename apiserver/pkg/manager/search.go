@@ -0,0 +1,130 @@
+package manager
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// SearchFilterFields are the fields of the filter expressions of the searches, which are the same for every kind.
+var SearchFilterFields = map[string]FilterField[*api.SearchResult]{
+	"kind":      {Type: StringFilterField, Value: func(r *api.SearchResult) any { return r.Kind }},
+	"name":      {Type: StringFilterField, Value: func(r *api.SearchResult) any { return r.Name }},
+	"namespace": {Type: StringFilterField, Value: func(r *api.SearchResult) any { return r.Namespace }},
+	"user":      {Type: StringFilterField, Value: func(r *api.SearchResult) any { return r.User }},
+	"state":     {Type: StringFilterField, Value: func(r *api.SearchResult) any { return r.State }},
+	"ownerKind": {Type: StringFilterField, Value: func(r *api.SearchResult) any { return r.OwnerKind }},
+	"ownerName": {Type: StringFilterField, Value: func(r *api.SearchResult) any { return r.OwnerName }},
+	"createdAt": {Type: TimeFilterField, Value: func(r *api.SearchResult) any { return r.CreatedAt.AsTime() }},
+}
+
+// searchOrderFields are the fields the search results can be sorted by.
+var searchOrderFields = map[string]func(a, b *api.SearchResult) int{
+	"kind":      func(a, b *api.SearchResult) int { return strings.Compare(a.Kind, b.Kind) },
+	"name":      func(a, b *api.SearchResult) int { return strings.Compare(a.Name, b.Name) },
+	"namespace": func(a, b *api.SearchResult) int { return strings.Compare(a.Namespace, b.Namespace) },
+	"user":      func(a, b *api.SearchResult) int { return strings.Compare(a.User, b.User) },
+	"state":     func(a, b *api.SearchResult) int { return strings.Compare(a.State, b.State) },
+	"createdAt": func(a, b *api.SearchResult) int {
+		return cmp.Compare(a.CreatedAt.GetSeconds(), b.CreatedAt.GetSeconds())
+	},
+}
+
+// SearchQuery selects and sorts the resources of a search.
+type SearchQuery struct {
+	// Kinds are the kinds of the resources, among RayEventKinds. All of them if empty.
+	Kinds  []string
+	Filter ListFilter[*api.SearchResult]
+	// OrderBy is the field the resources are sorted by, createdAt if empty.
+	OrderBy    string
+	Descending bool
+}
+
+// ParseSearchOrder parses the order of a search, a field followed by asc or desc, e.g. "createdAt desc". The most
+// recently created resources come first by default.
+func ParseSearchOrder(orderBy string) (string, bool, error) {
+	words := strings.Fields(orderBy)
+	if len(words) == 0 {
+		return "createdAt", true, nil
+	}
+	if _, ok := searchOrderFields[words[0]]; !ok || len(words) > 2 {
+		return "", false, util.NewInvalidFieldError("order_by", "Order %q is invalid. Please specify one of %s, optionally followed by asc or desc.", orderBy, strings.Join(searchOrderFieldNames(), ", "))
+	}
+	if len(words) == 1 {
+		return words[0], false, nil
+	}
+	switch strings.ToLower(words[1]) {
+	case "asc":
+		return words[0], false, nil
+	case "desc":
+		return words[0], true, nil
+	default:
+		return "", false, util.NewInvalidFieldError("order_by", "Order direction %s is invalid. Please specify asc or desc.", words[1])
+	}
+}
+
+// SearchResources returns the Clusters, RayJobs and RayServices of a namespace, all the namespaces if it is empty,
+// matching the query, in its order. The resources are listed from the resource cache when it is enabled.
+func (r *ResourceManager) SearchResources(ctx context.Context, namespace string, query SearchQuery) ([]*api.SearchResult, error) {
+	searched := func(kind string) bool {
+		return len(query.Kinds) == 0 || slices.Contains(query.Kinds, kind)
+	}
+	var results []*api.SearchResult
+	if searched("RayCluster") {
+		clusters, _, err := r.ListClusters(ctx, namespace, "", 0, ResourceSelector{})
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range clusters {
+			results = append(results, model.FromCrdToApiClusterSearchResult(cluster))
+		}
+	}
+	if searched("RayJob") {
+		jobs, _, err := r.ListJobs(ctx, namespace, "", 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			results = append(results, model.FromCrdToApiJobSearchResult(job))
+		}
+	}
+	if searched("RayService") {
+		services, _, err := r.ListServices(ctx, namespace, "", 0, ResourceSelector{})
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range services {
+			results = append(results, model.FromCrdToApiServiceSearchResult(service))
+		}
+	}
+	results = query.Filter.Apply(results)
+
+	compare, ok := searchOrderFields[query.OrderBy]
+	if !ok {
+		compare = searchOrderFields["createdAt"]
+	}
+	// The ties are broken by kind, namespace and name, so that the pages of a search are stable.
+	slices.SortStableFunc(results, func(a, b *api.SearchResult) int {
+		result := compare(a, b)
+		if query.Descending {
+			result = -result
+		}
+		return cmp.Or(result, strings.Compare(a.Kind, b.Kind), strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+	return results, nil
+}
+
+// searchOrderFieldNames returns the fields the search results can be sorted by, in alphabetical order.
+func searchOrderFieldNames() []string {
+	names := make([]string, 0, len(searchOrderFields))
+	for name := range searchOrderFields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,115 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestSearchResources(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	rayClient := clientManager.clients.Ray.RayV1()
+
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	meta := func(name string, namespace string, user string, age time.Duration) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(created.Add(-age)),
+			Labels: map[string]string{
+				util.KubernetesManagedByLabelKey: util.ComponentName,
+				util.RayClusterUserLabelKey:      user,
+			},
+		}
+	}
+	nightly := meta("nightly-1", "team-a", "alice", time.Hour)
+	nightly.Labels[util.RayCronJobLabelKey] = "nightly"
+	_, err := rayClient.RayJobs("team-a").Create(ctx, &rayv1api.RayJob{
+		ObjectMeta: nightly,
+		Status:     rayv1api.RayJobStatus{JobStatus: rayv1api.JobStatusFailed},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = rayClient.RayJobs("team-a").Create(ctx, &rayv1api.RayJob{
+		ObjectMeta: meta("adhoc", "team-a", "alice", 48*time.Hour),
+		Status:     rayv1api.RayJobStatus{JobStatus: rayv1api.JobStatusFailed},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = rayClient.RayJobs("team-b").Create(ctx, &rayv1api.RayJob{
+		ObjectMeta: meta("training", "team-b", "bob", 2*time.Hour),
+		Status:     rayv1api.RayJobStatus{JobStatus: rayv1api.JobStatusSucceeded},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = rayClient.RayClusters("team-a").Create(ctx, &rayv1api.RayCluster{
+		ObjectMeta: meta("cluster", "team-a", "alice", 3*time.Hour),
+		Status:     rayv1api.RayClusterStatus{State: rayv1api.Ready},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = rayClient.RayServices("team-b").Create(ctx, &rayv1api.RayService{
+		ObjectMeta: meta("service", "team-b", "bob", 0),
+		Status:     rayv1api.RayServiceStatuses{ServiceStatus: rayv1api.Running},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	names := func(results []*api.SearchResult) []string {
+		names := make([]string, 0, len(results))
+		for _, result := range results {
+			names = append(names, result.Name)
+		}
+		return names
+	}
+
+	// All the resources are returned, most recently created first.
+	results, err := resourceManager.SearchResources(ctx, metav1.NamespaceAll, SearchQuery{OrderBy: "createdAt", Descending: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service", "nightly-1", "training", "cluster", "adhoc"}, names(results))
+	assert.Equal(t, "RayCronJob", results[1].OwnerKind)
+	assert.Equal(t, "nightly", results[1].OwnerName)
+
+	filter, err := ParseListFilter("user=alice AND state=FAILED AND createdAt>2024-04-30", SearchFilterFields)
+	require.NoError(t, err)
+	results, err = resourceManager.SearchResources(ctx, metav1.NamespaceAll, SearchQuery{Filter: filter})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nightly-1"}, names(results))
+
+	results, err = resourceManager.SearchResources(ctx, "team-b", SearchQuery{Kinds: []string{"RayJob", "RayService"}, OrderBy: "name"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"service", "training"}, names(results))
+
+	filter, err = ParseListFilter("ownerKind=RayCronJob", SearchFilterFields)
+	require.NoError(t, err)
+	results, err = resourceManager.SearchResources(ctx, metav1.NamespaceAll, SearchQuery{Kinds: []string{"RayCluster"}, Filter: filter})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestParseSearchOrder(t *testing.T) {
+	orderBy, descending, err := ParseSearchOrder("")
+	require.NoError(t, err)
+	assert.Equal(t, "createdAt", orderBy)
+	assert.True(t, descending)
+
+	orderBy, descending, err = ParseSearchOrder("name")
+	require.NoError(t, err)
+	assert.Equal(t, "name", orderBy)
+	assert.False(t, descending)
+
+	orderBy, descending, err = ParseSearchOrder("state desc")
+	require.NoError(t, err)
+	assert.Equal(t, "state", orderBy)
+	assert.True(t, descending)
+
+	_, _, err = ParseSearchOrder("ownerName")
+	require.EqualError(t, err, `Invalid input error: Order "ownerName" is invalid. Please specify one of createdAt, kind, name, namespace, state, user, optionally followed by asc or desc.`)
+	_, _, err = ParseSearchOrder("name sideways")
+	require.Error(t, err)
+}
@@ -0,0 +1,209 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// GetServiceServeConfig returns the serve config of a RayService, the config of the applications deployed on its
+// active RayCluster according to the dashboard, and the differences between them. The live config which can not be
+// retrieved, e.g. because the RayService has no active RayCluster yet, is reported in the error of the result rather
+// than failing the call, so that the desired config is still returned.
+func (r *ResourceManager) GetServiceServeConfig(ctx context.Context, serviceName string, namespace string) (*api.RayServeConfig, error) {
+	service, err := r.GetService(ctx, serviceName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	serveConfig := &api.RayServeConfig{
+		Name:           service.Name,
+		Namespace:      service.Namespace,
+		RayClusterName: service.Status.ActiveServiceStatus.RayClusterName,
+		DesiredConfig:  service.Spec.ServeConfigV2,
+	}
+	desired, err := serveConfigApplications(service.Spec.ServeConfigV2)
+	if err != nil {
+		serveConfig.Error = fmt.Sprintf("The serve config of the service is invalid: %v", err)
+		return serveConfig, nil
+	}
+	if serveConfig.RayClusterName == "" {
+		serveConfig.Error = "The service has no active cluster yet"
+		return serveConfig, nil
+	}
+
+	cluster, err := r.GetCluster(ctx, serveConfig.RayClusterName, namespace)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to get the active cluster of service %s", serviceName)
+	}
+	dashboardClient, err := r.clusterDashboardClient(ctx, cluster)
+	if err != nil {
+		serveConfig.Error = fmt.Sprintf("Failed to connect to the dashboard of cluster %s: %v", cluster.Name, err)
+		return serveConfig, nil
+	}
+	details, err := dashboardClient.GetServeDetails(ctx)
+	if err != nil {
+		serveConfig.Error = fmt.Sprintf("Failed to get the serve details from the dashboard of cluster %s: %v", cluster.Name, err)
+		return serveConfig, nil
+	}
+	live := map[string]map[string]interface{}{}
+	liveApplications := make([]interface{}, 0, len(details.Applications))
+	for name, application := range details.Applications {
+		if application.DeployedAppConfig == nil {
+			// The dashboards of the Ray versions before 2.7 do not report the deployed configs.
+			serveConfig.Error = fmt.Sprintf("The dashboard of cluster %s does not report the deployed config of application %s", cluster.Name, name)
+			return serveConfig, nil
+		}
+		live[name] = application.DeployedAppConfig
+		liveApplications = append(liveApplications, application.DeployedAppConfig)
+	}
+	sort.Slice(liveApplications, func(i, j int) bool {
+		return fmt.Sprint(liveApplications[i].(map[string]interface{})["name"]) < fmt.Sprint(liveApplications[j].(map[string]interface{})["name"])
+	})
+	liveConfig, err := yaml.Marshal(map[string]interface{}{"applications": liveApplications})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the live serve config of service %s", serviceName)
+	}
+	serveConfig.LiveConfig = string(liveConfig)
+	serveConfig.Differences = diffServeConfigs(desired, live)
+	serveConfig.InSync = len(serveConfig.Differences) == 0
+	return serveConfig, nil
+}
+
+// serveConfigApplications parses a serveConfigV2 and returns its applications by name.
+func serveConfigApplications(serveConfigV2 string) (map[string]map[string]interface{}, error) {
+	config := struct {
+		Applications []map[string]interface{} `json:"applications"`
+	}{}
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &config); err != nil {
+		return nil, err
+	}
+	applications := map[string]map[string]interface{}{}
+	for _, application := range config.Applications {
+		name, _ := application["name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("an application has no name")
+		}
+		applications[name] = application
+	}
+	return applications, nil
+}
+
+// diffServeConfigs compares the applications of two serve configs, sorted by application and path.
+func diffServeConfigs(desired map[string]map[string]interface{}, live map[string]map[string]interface{}) []*api.ServeConfigDifference {
+	var differences []*api.ServeConfigDifference
+	names := map[string]bool{}
+	for name := range desired {
+		names[name] = true
+	}
+	for name := range live {
+		names[name] = true
+	}
+	for name := range names {
+		desiredApplication, inDesired := desired[name]
+		liveApplication, inLive := live[name]
+		if !inDesired || !inLive {
+			differences = append(differences, serveConfigDifference(name, "", desiredApplication, liveApplication))
+			continue
+		}
+		differences = diffServeConfigValues(differences, name, "", desiredApplication, liveApplication)
+	}
+	sort.Slice(differences, func(i, j int) bool {
+		if differences[i].Application != differences[j].Application {
+			return differences[i].Application < differences[j].Application
+		}
+		return differences[i].Path < differences[j].Path
+	})
+	return differences
+}
+
+// diffServeConfigValues appends the differences between two values of an application. Maps are compared field by
+// field, and lists of named objects, like the deployments, are compared by name.
+func diffServeConfigValues(differences []*api.ServeConfigDifference, application string, path string, desired interface{}, live interface{}) []*api.ServeConfigDifference {
+	if desiredMap, ok := desired.(map[string]interface{}); ok {
+		if liveMap, ok := live.(map[string]interface{}); ok {
+			keys := map[string]bool{}
+			for key := range desiredMap {
+				keys[key] = true
+			}
+			for key := range liveMap {
+				keys[key] = true
+			}
+			for key := range keys {
+				keyPath := key
+				if path != "" {
+					keyPath = path + "." + key
+				}
+				differences = diffServeConfigValues(differences, application, keyPath, desiredMap[key], liveMap[key])
+			}
+			return differences
+		}
+	}
+	desiredByName, desiredNamed := namedObjects(desired)
+	liveByName, liveNamed := namedObjects(live)
+	if desiredNamed && liveNamed {
+		names := map[string]bool{}
+		for name := range desiredByName {
+			names[name] = true
+		}
+		for name := range liveByName {
+			names[name] = true
+		}
+		for name := range names {
+			differences = diffServeConfigValues(differences, application, fmt.Sprintf("%s[%s]", path, name), desiredByName[name], liveByName[name])
+		}
+		return differences
+	}
+	if !reflect.DeepEqual(desired, live) {
+		differences = append(differences, serveConfigDifference(application, path, desired, live))
+	}
+	return differences
+}
+
+// namedObjects returns the objects of a list by name, if every object of the list has a name.
+func namedObjects(value interface{}) (map[string]interface{}, bool) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	objects := map[string]interface{}{}
+	for _, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		objects[name] = object
+	}
+	return objects, true
+}
+
+func serveConfigDifference(application string, path string, desired interface{}, live interface{}) *api.ServeConfigDifference {
+	return &api.ServeConfigDifference{
+		Application: application,
+		Path:        path,
+		Desired:     serveConfigJSON(desired),
+		Live:        serveConfigJSON(live),
+	}
+}
+
+// serveConfigJSON returns a value as JSON, or an empty string for a value which is not set.
+func serveConfigJSON(value interface{}) string {
+	if value == nil || reflect.ValueOf(value).Kind() == reflect.Map && reflect.ValueOf(value).IsNil() {
+		return ""
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
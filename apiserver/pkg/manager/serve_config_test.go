@@ -0,0 +1,93 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestGetServiceServeConfig(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	resourceManager.dashboardClientFunc = func() utils.RayDashboardClientInterface { return dashboardClient }
+	rayClient := clientManager.clients.Ray.RayV1()
+	labels := map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName}
+
+	serveConfigV2 := `applications:
+- name: math
+  import_path: math:app
+  deployments:
+  - name: Adder
+    num_replicas: 2
+  - name: Multiplier
+    num_replicas: 1
+- name: fruit
+  import_path: fruit:app
+`
+	service := &rayv1api.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "serve", Namespace: "team-a", Labels: labels},
+		Spec:       rayv1api.RayServiceSpec{ServeConfigV2: serveConfigV2},
+	}
+	_, err := rayClient.RayServices("team-a").Create(ctx, service, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	serveConfig, err := resourceManager.GetServiceServeConfig(ctx, "serve", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, serveConfigV2, serveConfig.DesiredConfig)
+	assert.Equal(t, "The service has no active cluster yet", serveConfig.Error)
+	assert.False(t, serveConfig.InSync)
+
+	service.Status.ActiveServiceStatus.RayClusterName = "serve-abc"
+	_, err = rayClient.RayServices("team-a").UpdateStatus(ctx, service, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = rayClient.RayClusters("team-a").Create(ctx, &rayv1api.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "serve-abc", Namespace: "team-a", Labels: labels}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	math := map[string]interface{}{
+		"name":        "math",
+		"import_path": "math:app",
+		"deployments": []interface{}{
+			map[string]interface{}{"name": "Adder", "num_replicas": float64(2)},
+			map[string]interface{}{"name": "Multiplier", "num_replicas": float64(1)},
+		},
+	}
+	fruit := map[string]interface{}{"name": "fruit", "import_path": "fruit:app"}
+	dashboardClient.SetServeDetails(utils.ServeDetails{Applications: map[string]utils.ServeApplicationDetails{
+		"math":  {DeployedAppConfig: math},
+		"fruit": {DeployedAppConfig: fruit},
+	}})
+
+	serveConfig, err = resourceManager.GetServiceServeConfig(ctx, "serve", "team-a")
+	require.NoError(t, err)
+	assert.Empty(t, serveConfig.Error)
+	assert.Equal(t, "serve-abc", serveConfig.RayClusterName)
+	assert.Empty(t, serveConfig.Differences)
+	assert.True(t, serveConfig.InSync)
+	assert.Contains(t, serveConfig.LiveConfig, "import_path: fruit:app")
+
+	// The replicas of a deployment changed and an application was removed on the cluster.
+	math["deployments"].([]interface{})[0].(map[string]interface{})["num_replicas"] = float64(3)
+	dashboardClient.SetServeDetails(utils.ServeDetails{Applications: map[string]utils.ServeApplicationDetails{
+		"math": {DeployedAppConfig: math},
+	}})
+	serveConfig, err = resourceManager.GetServiceServeConfig(ctx, "serve", "team-a")
+	require.NoError(t, err)
+	assert.False(t, serveConfig.InSync)
+	require.Len(t, serveConfig.Differences, 2)
+	assert.Equal(t, &api.ServeConfigDifference{Application: "fruit", Desired: `{"import_path":"fruit:app","name":"fruit"}`}, serveConfig.Differences[0])
+	assert.Equal(t, &api.ServeConfigDifference{Application: "math", Path: "deployments[Adder].num_replicas", Desired: "2", Live: "3"}, serveConfig.Differences[1])
+
+	dashboardClient.SetServeDetails(utils.ServeDetails{Applications: map[string]utils.ServeApplicationDetails{"math": {}}})
+	serveConfig, err = resourceManager.GetServiceServeConfig(ctx, "serve", "team-a")
+	require.NoError(t, err)
+	assert.Contains(t, serveConfig.Error, "does not report the deployed config of application math")
+}
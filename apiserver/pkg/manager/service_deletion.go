@@ -0,0 +1,71 @@
+package manager
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// generatedClusterNameSuffixLength is the length of the random suffix of the names of the RayClusters of a
+// RayService, see utils.GenerateRayClusterName.
+const generatedClusterNameSuffixLength = 5
+
+// GetServiceDeletionStatus reports whether a RayService, its RayClusters and their Pods are gone. The RayClusters are
+// found by their originated-from labels. The Pods are found by the generated names of the RayClusters, so that the
+// Pods of a RayCluster which is already deleted in the background are counted as well, while the Pods of a
+// RayCluster retained by the deletion of the RayService are not.
+func (r *ResourceManager) GetServiceDeletionStatus(ctx context.Context, serviceName string, namespace string) (*api.RayServiceDeletionStatus, error) {
+	status := &api.RayServiceDeletionStatus{Name: serviceName, Namespace: namespace}
+	service, err := getServiceByName(ctx, r.getRayServiceClient(namespace), serviceName)
+	if err != nil && !util.IsUserErrorCodeMatch(err, codes.NotFound) {
+		return nil, util.Wrap(err, "Get service failure")
+	}
+	serviceExists := err == nil
+	if serviceExists && service.DeletionTimestamp != nil {
+		status.DeletionInProgress = true
+		status.DeletionRequestedAt = &timestamppb.Timestamp{Seconds: service.DeletionTimestamp.Unix()}
+	}
+
+	clusters, err := r.getRayClusterClient(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the clusters of service %s", serviceName)
+	}
+	clusterNamePrefix := serviceName + utils.RayClusterSuffix
+	otherClusters := map[string]bool{}
+	for _, cluster := range clusters.Items {
+		if cluster.Labels[utils.RayOriginatedFromCRNameLabelKey] == serviceName &&
+			cluster.Labels[utils.RayOriginatedFromCRDLabelKey] == utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD) {
+			status.RemainingRayClusters = append(status.RemainingRayClusters, cluster.Name)
+		} else {
+			otherClusters[cluster.Name] = true
+		}
+	}
+	sort.Strings(status.RemainingRayClusters)
+
+	pods, err := r.clientManager.KubernetesClient().PodClient(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{utils.RayNodeLabelKey: "yes"}).String(),
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the Pods of service %s", serviceName)
+	}
+	for _, pod := range pods.Items {
+		clusterName := pod.Labels[utils.RayClusterLabelKey]
+		if strings.HasPrefix(clusterName, clusterNamePrefix) &&
+			len(clusterName) == len(clusterNamePrefix)+generatedClusterNameSuffixLength && !otherClusters[clusterName] {
+			status.RemainingPods++
+		}
+	}
+
+	status.Deleted = !serviceExists && len(status.RemainingRayClusters) == 0 && status.RemainingPods == 0
+	return status, nil
+}
@@ -0,0 +1,87 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestGetServiceDeletionStatus(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+	rayClient := clientManager.clients.Ray.RayV1()
+	podClient := clientManager.KubernetesClient().PodClient("team-a")
+
+	status, err := resourceManager.GetServiceDeletionStatus(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.True(t, status.Deleted, "A missing service is deleted")
+
+	// Mimic a service deleted in the foreground, whose cluster is being torn down.
+	deletionTimestamp := metav1.Now()
+	_, err = rayClient.RayServices("team-a").Create(ctx, &rayv1api.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "service",
+			Namespace:         "team-a",
+			Labels:            map[string]string{util.KubernetesManagedByLabelKey: util.ComponentName},
+			DeletionTimestamp: &deletionTimestamp,
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	for _, cluster := range []*rayv1api.RayCluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: "service-raycluster-abcde", Namespace: "team-a", Labels: map[string]string{
+			utils.RayOriginatedFromCRNameLabelKey: "service",
+			utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+		}}},
+		// A cluster retained by a previous deletion of the service.
+		{ObjectMeta: metav1.ObjectMeta{Name: "service-raycluster-zzzzz", Namespace: "team-a"}},
+	} {
+		_, err = rayClient.RayClusters("team-a").Create(ctx, cluster, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	for name, clusterName := range map[string]string{
+		"service-head":  "service-raycluster-abcde",
+		"orphan-head":   "service-raycluster-fghij",
+		"retained-head": "service-raycluster-zzzzz",
+		"other-head":    "other-raycluster-abcde",
+	} {
+		_, err = podClient.Create(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "team-a",
+			Labels:    map[string]string{utils.RayNodeLabelKey: "yes", utils.RayClusterLabelKey: clusterName},
+		}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	status, err = resourceManager.GetServiceDeletionStatus(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.False(t, status.Deleted)
+	assert.True(t, status.DeletionInProgress)
+	assert.Equal(t, deletionTimestamp.Unix(), status.DeletionRequestedAt.Seconds)
+	assert.Equal(t, []string{"service-raycluster-abcde"}, status.RemainingRayClusters)
+	assert.Equal(t, int32(2), status.RemainingPods)
+
+	// The service and its cluster are gone, but the Pods are still being garbage collected.
+	require.NoError(t, rayClient.RayServices("team-a").Delete(ctx, "service", metav1.DeleteOptions{}))
+	require.NoError(t, rayClient.RayClusters("team-a").Delete(ctx, "service-raycluster-abcde", metav1.DeleteOptions{}))
+	status, err = resourceManager.GetServiceDeletionStatus(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.False(t, status.Deleted)
+	assert.False(t, status.DeletionInProgress)
+	assert.Empty(t, status.RemainingRayClusters)
+	assert.Equal(t, int32(2), status.RemainingPods)
+
+	require.NoError(t, podClient.Delete(ctx, "service-head", metav1.DeleteOptions{}))
+	require.NoError(t, podClient.Delete(ctx, "orphan-head", metav1.DeleteOptions{}))
+	status, err = resourceManager.GetServiceDeletionStatus(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.True(t, status.Deleted)
+}
@@ -0,0 +1,141 @@
+package manager
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// syncServiceExpose creates or updates the Ingress or the OpenShift Route following the expose options of a
+// RayService, and deletes the one which is no longer exposing it. Both are owned by the RayService, so Kubernetes
+// deletes them with it. An Ingress or a Route with the same name which is not managed by the apiserver is left
+// untouched.
+func (r *ResourceManager) syncServiceExpose(ctx context.Context, service *rayv1api.RayService) error {
+	expose, err := util.ExposeOptionsFromAnnotations(service.Annotations)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to read the expose options of service %s", service.Name)
+	}
+	if expose == nil || expose.Kind != api.ExposeOptions_INGRESS {
+		if err := r.deleteServeIngress(ctx, service); err != nil {
+			return err
+		}
+	}
+	if expose == nil || expose.Kind != api.ExposeOptions_ROUTE {
+		if err := r.deleteServeRoute(ctx, service); err != nil {
+			return err
+		}
+	}
+	if expose == nil {
+		return nil
+	}
+	if expose.Kind == api.ExposeOptions_ROUTE {
+		return r.applyServeRoute(ctx, service, expose)
+	}
+	return r.applyServeIngress(ctx, service, expose)
+}
+
+func (r *ResourceManager) applyServeIngress(ctx context.Context, service *rayv1api.RayService, expose *api.ExposeOptions) error {
+	client := r.clientManager.KubernetesClient().IngressClient(service.Namespace)
+	ingress := util.NewServeIngress(service, expose)
+	existing, err := client.Get(ctx, ingress.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if _, err := client.Create(ctx, ingress, metav1.CreateOptions{}); err != nil {
+			return util.NewInternalServerError(err, "Failed to create ingress %s of service %s", ingress.Name, service.Name)
+		}
+		return nil
+	}
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get ingress %s of service %s", ingress.Name, service.Name)
+	}
+	if !isManagedByAPIServer(existing.Labels) {
+		return util.NewFailedPreconditionError("Ingress %s already exists and is not managed by %s", ingress.Name, util.ComponentName)
+	}
+	ingress.ResourceVersion = existing.ResourceVersion
+	if _, err := client.Update(ctx, ingress, metav1.UpdateOptions{}); err != nil {
+		return util.NewInternalServerError(err, "Failed to update ingress %s of service %s", ingress.Name, service.Name)
+	}
+	return nil
+}
+
+func (r *ResourceManager) applyServeRoute(ctx context.Context, service *rayv1api.RayService, expose *api.ExposeOptions) error {
+	var tlsSecret *corev1.Secret
+	if expose.TlsSecret != "" {
+		var err error
+		tlsSecret, err = r.getKubernetesSecretClient(service.Namespace).Get(ctx, expose.TlsSecret, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return util.NewFailedPreconditionError("TLS Secret %s/%s not found", service.Namespace, expose.TlsSecret)
+		}
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to get TLS Secret %s/%s", service.Namespace, expose.TlsSecret)
+		}
+	}
+	client := r.clientManager.KubernetesClient().RouteClient(service.Namespace)
+	route := util.NewServeRoute(service, expose, tlsSecret)
+	existing, err := client.Get(ctx, route.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if _, err := client.Create(ctx, route, metav1.CreateOptions{}); err != nil {
+			return util.NewInternalServerError(err, "Failed to create route %s of service %s, is the OpenShift Route API available?", route.GetName(), service.Name)
+		}
+		return nil
+	}
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get route %s of service %s", route.GetName(), service.Name)
+	}
+	if !isManagedByAPIServer(existing.GetLabels()) {
+		return util.NewFailedPreconditionError("Route %s already exists and is not managed by %s", route.GetName(), util.ComponentName)
+	}
+	route.SetResourceVersion(existing.GetResourceVersion())
+	if _, err := client.Update(ctx, route, metav1.UpdateOptions{}); err != nil {
+		return util.NewInternalServerError(err, "Failed to update route %s of service %s", route.GetName(), service.Name)
+	}
+	return nil
+}
+
+func (r *ResourceManager) deleteServeIngress(ctx context.Context, service *rayv1api.RayService) error {
+	client := r.clientManager.KubernetesClient().IngressClient(service.Namespace)
+	name := util.ServeExposeName(service.Name)
+	ingress, err := client.Get(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get ingress %s of service %s", name, service.Name)
+	}
+	if !isManagedByAPIServer(ingress.Labels) {
+		return nil
+	}
+	if err := client.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return util.NewInternalServerError(err, "Failed to delete ingress %s of service %s", name, service.Name)
+	}
+	return nil
+}
+
+func (r *ResourceManager) deleteServeRoute(ctx context.Context, service *rayv1api.RayService) error {
+	client := r.clientManager.KubernetesClient().RouteClient(service.Namespace)
+	name := util.ServeExposeName(service.Name)
+	route, err := client.Get(ctx, name, metav1.GetOptions{})
+	// The Route API is not served outside of OpenShift.
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get route %s of service %s", name, service.Name)
+	}
+	if !isManagedByAPIServer(route.GetLabels()) {
+		return nil
+	}
+	if err := client.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return util.NewInternalServerError(err, "Failed to delete route %s of service %s", name, service.Name)
+	}
+	return nil
+}
+
+func isManagedByAPIServer(labels map[string]string) bool {
+	return labels[util.KubernetesManagedByLabelKey] == util.ComponentName
+}
@@ -0,0 +1,109 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestServiceExpose(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	newService := func(expose *api.ExposeOptions) *api.RayService {
+		return &api.RayService{
+			Name:           "service",
+			Namespace:      "team-a",
+			User:           "user",
+			Version:        "2.9.0",
+			ServeConfig_V2: "applications: []",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{
+					ComputeTemplate: "template",
+					RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+				},
+			},
+			Expose: expose,
+		}
+	}
+	ingressClient := clientManager.clients.Kubernetes.NetworkingV1().Ingresses("team-a")
+	routeClient := clientManager.clients.Dynamic.Resource(client.RouteGVR).Namespace("team-a")
+
+	service, err := resourceManager.CreateService(ctx, newService(&api.ExposeOptions{
+		Host:         "serve.example.com",
+		Path:         "/api",
+		TlsSecret:    "serve-tls",
+		IngressClass: "nginx",
+		Annotations:  map[string]string{"nginx.ingress.kubernetes.io/proxy-body-size": "10m"},
+	}), false, "")
+	require.NoError(t, err)
+	ingress, err := ingressClient.Get(ctx, "service-serve", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Equal(t, "serve.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "/api", ingress.Spec.Rules[0].HTTP.Paths[0].Path)
+	assert.Equal(t, "service-serve-svc", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, "serve-tls", ingress.Spec.TLS[0].SecretName)
+	assert.Equal(t, "10m", ingress.Annotations["nginx.ingress.kubernetes.io/proxy-body-size"])
+	assert.Equal(t, "RayService", ingress.OwnerReferences[0].Kind)
+	assert.Equal(t, "serve.example.com", model.FromCrdToApiService(service, nil).Expose.Host)
+
+	// A Route needs the certificate of the TLS Secret.
+	_, err = resourceManager.UpdateRayService(ctx, newService(&api.ExposeOptions{Kind: api.ExposeOptions_ROUTE, Host: "serve.example.com", TlsSecret: "serve-tls"}))
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "The TLS Secret does not exist")
+	_, err = clientManager.clients.Kubernetes.CoreV1().Secrets("team-a").Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "serve-tls", Namespace: "team-a"},
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("a-certificate"), corev1.TLSPrivateKeyKey: []byte("a-key")},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = resourceManager.UpdateRayService(ctx, newService(&api.ExposeOptions{Kind: api.ExposeOptions_ROUTE, Host: "serve.example.com", TlsSecret: "serve-tls"}))
+	require.NoError(t, err)
+	_, err = ingressClient.Get(ctx, "service-serve", metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err), "The Ingress is replaced by the Route")
+	route, err := routeClient.Get(ctx, "service-serve", metav1.GetOptions{})
+	require.NoError(t, err)
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	assert.Equal(t, "serve.example.com", host)
+	certificate, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "certificate")
+	assert.Equal(t, "a-certificate", certificate)
+	target, _, _ := unstructured.NestedString(route.Object, "spec", "to", "name")
+	assert.Equal(t, "service-serve-svc", target)
+
+	_, err = resourceManager.UpdateRayService(ctx, newService(nil))
+	require.NoError(t, err)
+	_, err = routeClient.Get(ctx, "service-serve", metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err), "The Route is deleted once the service is no longer exposed")
+
+	// An Ingress which is not managed by the apiserver is not overwritten.
+	_, err = ingressClient.Create(ctx, &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-serve", Namespace: "team-a"},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = resourceManager.UpdateRayService(ctx, newService(&api.ExposeOptions{Host: "serve.example.com"}))
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "The Ingress is not managed by the apiserver")
+	_, err = resourceManager.UpdateRayService(ctx, newService(nil))
+	require.NoError(t, err)
+	_, err = ingressClient.Get(ctx, "service-serve", metav1.GetOptions{})
+	require.NoError(t, err, "The Ingress which is not managed by the apiserver is kept")
+}
@@ -0,0 +1,180 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// workerGroupScale is the scale of a worker group. The scale of the worker groups of a suspended RayService is
+// kept in an annotation, so that it is restored when the RayService is resumed.
+type workerGroupScale struct {
+	Replicas    *int32 `json:"replicas,omitempty"`
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
+
+// SuspendService scales the worker groups of a RayService and of its active and pending RayClusters to zero. The
+// head keeps running, so that the RayService can be resumed without a new rollout. Suspending a suspended
+// RayService does nothing.
+func (r *ResourceManager) SuspendService(ctx context.Context, serviceName string, namespace string) (*rayv1api.RayService, error) {
+	client := r.getRayServiceClient(namespace)
+	service, err := getServiceByName(ctx, client, serviceName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get service failure")
+	}
+	if _, ok := service.Annotations[util.RayServiceSuspendedWorkerGroupsAnnotationKey]; ok {
+		return service, nil
+	}
+
+	scales := map[string]workerGroupScale{}
+	zeroScales := map[string]workerGroupScale{}
+	for _, spec := range service.Spec.RayClusterSpec.WorkerGroupSpecs {
+		scales[spec.GroupName] = workerGroupScale{Replicas: spec.Replicas, MinReplicas: spec.MinReplicas, MaxReplicas: spec.MaxReplicas}
+		zero := int32(0)
+		zeroScales[spec.GroupName] = workerGroupScale{Replicas: &zero, MinReplicas: &zero, MaxReplicas: &zero}
+	}
+	annotation, err := json.Marshal(scales)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the worker groups of service %s", serviceName)
+	}
+
+	// The clusters are scaled first, so that a failed call can be retried: the service is only marked as suspended
+	// once its workers are scaled down.
+	if err := r.scaleServiceClusters(ctx, service, zeroScales); err != nil {
+		return nil, err
+	}
+	patch := servicePatch(service)
+	if service.Annotations == nil {
+		patch = append(patch, map[string]interface{}{
+			"op": "add", "path": "/metadata/annotations", "value": map[string]string{util.RayServiceSuspendedWorkerGroupsAnnotationKey: string(annotation)},
+		})
+	} else {
+		patch = append(patch, map[string]interface{}{
+			"op": "add", "path": annotationPath(util.RayServiceSuspendedWorkerGroupsAnnotationKey), "value": string(annotation),
+		})
+	}
+	patch = append(patch, workerGroupScalePatch("/spec/rayClusterConfig/workerGroupSpecs", service.Spec.RayClusterSpec.WorkerGroupSpecs, zeroScales)...)
+	return r.patchService(ctx, service, patch, "suspend")
+}
+
+// ResumeService scales the worker groups of a suspended RayService and of its RayClusters back to the scale they
+// had when the RayService was suspended. Resuming a RayService which is not suspended does nothing.
+func (r *ResourceManager) ResumeService(ctx context.Context, serviceName string, namespace string) (*rayv1api.RayService, error) {
+	client := r.getRayServiceClient(namespace)
+	service, err := getServiceByName(ctx, client, serviceName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get service failure")
+	}
+	annotation, ok := service.Annotations[util.RayServiceSuspendedWorkerGroupsAnnotationKey]
+	if !ok {
+		return service, nil
+	}
+	scales := map[string]workerGroupScale{}
+	if err := json.Unmarshal([]byte(annotation), &scales); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to read the suspended worker groups of service %s", serviceName)
+	}
+
+	if err := r.scaleServiceClusters(ctx, service, scales); err != nil {
+		return nil, err
+	}
+	patch := servicePatch(service)
+	patch = append(patch, map[string]interface{}{
+		"op": "remove", "path": annotationPath(util.RayServiceSuspendedWorkerGroupsAnnotationKey),
+	})
+	patch = append(patch, workerGroupScalePatch("/spec/rayClusterConfig/workerGroupSpecs", service.Spec.RayClusterSpec.WorkerGroupSpecs, scales)...)
+	return r.patchService(ctx, service, patch, "resume")
+}
+
+// scaleServiceClusters scales the worker groups of the active and pending RayClusters of a RayService. The
+// RayService controller ignores the replicas when it compares the RayClusters with the RayService, so they have
+// to be scaled in addition to the RayService.
+func (r *ResourceManager) scaleServiceClusters(ctx context.Context, service *rayv1api.RayService, scales map[string]workerGroupScale) error {
+	client := r.getRayClusterClient(service.Namespace)
+	for _, clusterName := range []string{service.Status.ActiveServiceStatus.RayClusterName, service.Status.PendingServiceStatus.RayClusterName} {
+		if clusterName == "" {
+			continue
+		}
+		cluster, err := getClusterByName(ctx, client, clusterName)
+		if err != nil {
+			if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+				continue
+			}
+			return util.Wrap(err, "Get cluster failure")
+		}
+		patch := workerGroupScalePatch("/spec/workerGroupSpecs", cluster.Spec.WorkerGroupSpecs, scales)
+		if len(patch) == 0 {
+			continue
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to marshal the scale patch of cluster %s", clusterName)
+		}
+		if _, err := client.Patch(ctx, clusterName, types.JSONPatchType, data, metav1.PatchOptions{}); err != nil {
+			return util.NewInternalServerError(err, "Failed to scale the worker groups of cluster (%s/%s)", service.Namespace, clusterName)
+		}
+	}
+	return nil
+}
+
+// workerGroupScalePatch returns the JSON patch operations setting the scale of the worker groups under path.
+// The worker groups without scale are left unchanged.
+func workerGroupScalePatch(path string, specs []rayv1api.WorkerGroupSpec, scales map[string]workerGroupScale) []map[string]interface{} {
+	patch := []map[string]interface{}{}
+	for i, spec := range specs {
+		scale, ok := scales[spec.GroupName]
+		if !ok {
+			continue
+		}
+		groupPath := fmt.Sprintf("%s/%d", path, i)
+		// Fail instead of scaling another group if the worker groups were changed concurrently.
+		patch = append(patch, map[string]interface{}{"op": "test", "path": groupPath + "/groupName", "value": spec.GroupName})
+		if scale.Replicas != nil {
+			patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/replicas", "value": *scale.Replicas})
+		}
+		if scale.MinReplicas != nil {
+			patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/minReplicas", "value": *scale.MinReplicas})
+		}
+		if scale.MaxReplicas != nil {
+			patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/maxReplicas", "value": *scale.MaxReplicas})
+		}
+	}
+	return patch
+}
+
+// servicePatch returns the JSON patch operation failing the patch of a RayService if it was changed concurrently.
+func servicePatch(service *rayv1api.RayService) []map[string]interface{} {
+	patch := []map[string]interface{}{}
+	if service.ResourceVersion != "" {
+		patch = append(patch, map[string]interface{}{
+			"op": "test", "path": "/metadata/resourceVersion", "value": service.ResourceVersion,
+		})
+	}
+	return patch
+}
+
+func (r *ResourceManager) patchService(ctx context.Context, service *rayv1api.RayService, patch []map[string]interface{}, action string) (*rayv1api.RayService, error) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the %s patch of service %s", action, service.Name)
+	}
+	newService, err := r.getRayServiceClient(service.Namespace).Patch(ctx, service.Name, types.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to %s service (%s/%s)", action, service.Namespace, service.Name)
+	}
+	return newService, nil
+}
+
+// annotationPath returns the JSON pointer of an annotation, whose key may contain a slash.
+func annotationPath(key string) string {
+	return "/metadata/annotations/" + strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
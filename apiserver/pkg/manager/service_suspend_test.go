@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestSuspendAndResumeService(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	clusterSpec := &api.ClusterSpec{
+		HeadGroupSpec: &api.HeadGroupSpec{
+			ComputeTemplate: "template",
+			RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+		},
+		WorkerGroupSpec: []*api.WorkerGroupSpec{
+			{GroupName: "small", ComputeTemplate: "template", Replicas: 1, MinReplicas: 1, MaxReplicas: 2},
+			{GroupName: "large", ComputeTemplate: "template", Replicas: 3, MinReplicas: 2, MaxReplicas: 6},
+		},
+	}
+	_, err = resourceManager.CreateService(ctx, &api.RayService{
+		Name:           "service",
+		Namespace:      "team-a",
+		User:           "user",
+		Version:        "2.9.0",
+		ServeConfig_V2: "applications: []",
+		ClusterSpec:    clusterSpec,
+	}, false, "")
+	require.NoError(t, err)
+	// The active cluster of the service, created by the operator.
+	_, err = resourceManager.CreateCluster(ctx, &api.Cluster{
+		Name:        "service-raycluster-abcde",
+		Namespace:   "team-a",
+		User:        "user",
+		Version:     "2.9.0",
+		ClusterSpec: clusterSpec,
+	}, false, "")
+	require.NoError(t, err)
+	serviceClient := clientManager.clients.Ray.RayV1().RayServices("team-a")
+	service, err := serviceClient.Get(ctx, "service", metav1.GetOptions{})
+	require.NoError(t, err)
+	service.Status.ActiveServiceStatus.RayClusterName = "service-raycluster-abcde"
+	// The pending cluster may already be deleted.
+	service.Status.PendingServiceStatus.RayClusterName = "service-raycluster-fghij"
+	_, err = serviceClient.UpdateStatus(ctx, service, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	service, err = resourceManager.SuspendService(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.Contains(t, service.Annotations, util.RayServiceSuspendedWorkerGroupsAnnotationKey)
+	for _, spec := range service.Spec.RayClusterSpec.WorkerGroupSpecs {
+		assert.Equal(t, int32(0), *spec.Replicas)
+		assert.Equal(t, int32(0), *spec.MinReplicas)
+		assert.Equal(t, int32(0), *spec.MaxReplicas)
+	}
+	cluster, err := resourceManager.GetCluster(ctx, "service-raycluster-abcde", "team-a")
+	require.NoError(t, err)
+	for _, spec := range cluster.Spec.WorkerGroupSpecs {
+		assert.Equal(t, int32(0), *spec.Replicas)
+		assert.Equal(t, int32(0), *spec.MaxReplicas)
+	}
+
+	// Suspending a suspended service keeps the scale recorded by the first suspension.
+	_, err = resourceManager.SuspendService(ctx, "service", "team-a")
+	require.NoError(t, err)
+
+	service, err = resourceManager.ResumeService(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.NotContains(t, service.Annotations, util.RayServiceSuspendedWorkerGroupsAnnotationKey)
+	large := service.Spec.RayClusterSpec.WorkerGroupSpecs[1]
+	assert.Equal(t, int32(3), *large.Replicas)
+	assert.Equal(t, int32(2), *large.MinReplicas)
+	assert.Equal(t, int32(6), *large.MaxReplicas)
+	assert.Equal(t, int32(2), *service.Spec.RayClusterSpec.WorkerGroupSpecs[0].MaxReplicas)
+	cluster, err = resourceManager.GetCluster(ctx, "service-raycluster-abcde", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *cluster.Spec.WorkerGroupSpecs[1].Replicas)
+	assert.Equal(t, int32(1), *cluster.Spec.WorkerGroupSpecs[0].Replicas)
+
+	// Resuming a service which is not suspended does nothing.
+	service, err = resourceManager.ResumeService(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *service.Spec.RayClusterSpec.WorkerGroupSpecs[1].Replicas)
+}
@@ -0,0 +1,78 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// Service templates are stored in ConfigMaps. The ray services created from a template do not reference it,
+// so deleting a template keeps them.
+
+const serviceTemplateSelector = "ray.io/config-type=" + util.ServiceTemplateConfigType
+
+func (r *ResourceManager) CreateServiceTemplate(ctx context.Context, apiTemplate *api.ServiceTemplate) (*corev1.ConfigMap, error) {
+	if err := checkNamespaceAllowed(config.Get(), apiTemplate.Namespace); err != nil {
+		return nil, err
+	}
+	if _, err := r.GetServiceTemplate(ctx, apiTemplate.Name, apiTemplate.Namespace); err == nil {
+		return nil, util.NewAlreadyExistError("Service template with name %s already exists in namespace %s", apiTemplate.Name, apiTemplate.Namespace)
+	}
+
+	configMap, err := util.NewServiceTemplate(apiTemplate)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert service template (%s/%s)", apiTemplate.Namespace, apiTemplate.Name)
+	}
+	newConfigMap, err := r.getKubernetesConfigMapClient(apiTemplate.Namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a service template for (%s/%s)", apiTemplate.Namespace, apiTemplate.Name)
+	}
+	return newConfigMap, nil
+}
+
+func (r *ResourceManager) GetServiceTemplate(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
+	configMap, err := r.getKubernetesConfigMapClient(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, util.NewNotFoundError(err, "Service template %s not found", name)
+		}
+		return nil, util.Wrap(err, "Get service template failed")
+	}
+	if configMap.Labels["ray.io/config-type"] != util.ServiceTemplateConfigType {
+		return nil, util.NewNotFoundError(fmt.Errorf("ConfigMap %s is not a service template", name), "Service template %s not found", name)
+	}
+	return configMap, nil
+}
+
+// ListServiceTemplates lists the service templates in a namespace, or in all namespaces for metav1.NamespaceAll.
+func (r *ResourceManager) ListServiceTemplates(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error) {
+	configMapList, err := r.getKubernetesConfigMapClient(namespace).List(ctx, metav1.ListOptions{LabelSelector: serviceTemplateSelector})
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("List service templates failed in %s", namespace))
+	}
+
+	result := make([]*corev1.ConfigMap, 0, len(configMapList.Items))
+	for i := range configMapList.Items {
+		result = append(result, &configMapList.Items[i])
+	}
+	return result, nil
+}
+
+func (r *ResourceManager) DeleteServiceTemplate(ctx context.Context, name string, namespace string) error {
+	configMap, err := r.GetServiceTemplate(ctx, name, namespace)
+	if err != nil {
+		return util.Wrap(err, "Get service template failure")
+	}
+
+	if err := r.getKubernetesConfigMapClient(namespace).Delete(ctx, configMap.Name, metav1.DeleteOptions{}); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete service template %v.", name)
+	}
+	return nil
+}
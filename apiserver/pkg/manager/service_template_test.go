@@ -0,0 +1,71 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestServiceTemplates(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	template := &api.ServiceTemplate{
+		Name:        "fruit",
+		Namespace:   "team-a",
+		User:        "user",
+		Description: "The fruit stand",
+		Service: &api.RayService{
+			Version:        "2.9.0",
+			ServeConfig_V2: "applications: []",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec:   &api.HeadGroupSpec{ComputeTemplate: "small"},
+				WorkerGroupSpec: []*api.WorkerGroupSpec{{GroupName: "workers", ComputeTemplate: "small", Replicas: 1, MaxReplicas: 2}},
+			},
+		},
+	}
+
+	_, err := resourceManager.CreateServiceTemplate(ctx, template)
+	require.NoError(t, err)
+	_, err = resourceManager.CreateServiceTemplate(ctx, template)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.AlreadyExists))
+
+	configMap, err := resourceManager.GetServiceTemplate(ctx, "fruit", "team-a")
+	require.NoError(t, err)
+	apiTemplate, err := model.FromKubeToAPIServiceTemplate(configMap)
+	require.NoError(t, err)
+	assert.Equal(t, "user", apiTemplate.User)
+	assert.Equal(t, "The fruit stand", apiTemplate.Description)
+	assert.Equal(t, "applications: []", apiTemplate.Service.ServeConfig_V2)
+	assert.Equal(t, "workers", apiTemplate.Service.ClusterSpec.WorkerGroupSpec[0].GroupName)
+
+	// The other ConfigMaps are not service templates.
+	_, err = resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "small", Namespace: "team-a", Cpu: 1, Memory: 1})
+	require.NoError(t, err)
+	_, err = resourceManager.GetServiceTemplate(ctx, "small", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	configMaps, err := resourceManager.ListServiceTemplates(ctx, "team-a")
+	require.NoError(t, err)
+	require.Len(t, configMaps, 1)
+	assert.Equal(t, "fruit", configMaps[0].Name)
+
+	template.Namespace = "team-b"
+	_, err = resourceManager.CreateServiceTemplate(ctx, template)
+	require.NoError(t, err)
+	configMaps, err = resourceManager.ListServiceTemplates(ctx, metav1.NamespaceAll)
+	require.NoError(t, err)
+	assert.Len(t, configMaps, 2)
+
+	require.NoError(t, resourceManager.DeleteServiceTemplate(ctx, "fruit", "team-a"))
+	_, err = resourceManager.GetServiceTemplate(ctx, "fruit", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	err = resourceManager.DeleteServiceTemplate(ctx, "small", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
@@ -0,0 +1,181 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// StartServiceUpgrade updates a RayService with a new cluster spec and holds the promotion of its pending
+// RayCluster: the operator prepares the pending RayCluster and its serve applications, but keeps the traffic on
+// the active RayCluster until the upgrade is promoted. The spec before the upgrade is kept in an annotation, so
+// that the upgrade can be rolled back.
+func (r *ResourceManager) StartServiceUpgrade(ctx context.Context, apiService *api.RayService) (*rayv1api.RayService, error) {
+	client := r.getRayServiceClient(apiService.Namespace)
+	oldService, err := getServiceByName(ctx, client, apiService.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Get service failure")
+	}
+	if _, ok := oldService.Annotations[util.RayServiceSuspendedWorkerGroupsAnnotationKey]; ok {
+		return nil, util.NewFailedPreconditionError("Service %s is suspended, resume it before upgrading it", apiService.Name)
+	}
+	if isServiceUpgradeInProgress(oldService) {
+		return nil, util.NewFailedPreconditionError("An upgrade of service %s is already in progress, promote or roll it back first", apiService.Name)
+	}
+	if oldService.Status.ActiveServiceStatus.RayClusterName == "" {
+		return nil, util.NewFailedPreconditionError("Service %s has no active cluster to upgrade yet", apiService.Name)
+	}
+
+	if err := applyClusterSpecDefaults(config.Get(), apiService.Version, apiService.ClusterSpec); err != nil {
+		return nil, err
+	}
+	computeTemplateDict, err := r.populateComputeTemplate(ctx, apiService.ClusterSpec, apiService.Namespace)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to populate compute template for (%s/%s)", apiService.Namespace, apiService.Name)
+	}
+	if err := r.checkImagePullSecrets(ctx, apiService.ClusterSpec, apiService.Namespace); err != nil {
+		return nil, err
+	}
+	if err := r.checkInjectedSecrets(ctx, apiService.ClusterSpec, apiService.Namespace); err != nil {
+		return nil, err
+	}
+	rayService, err := util.NewRayService(apiService, computeTemplateDict)
+	if err != nil {
+		return nil, err
+	}
+	if err := model.ApplyToCrdHooks(ctx, apiService, rayService.RayService); err != nil {
+		return nil, err
+	}
+	if err := r.admit(ctx, AdmissionUpdate, rayService.RayService); err != nil {
+		return nil, err
+	}
+	if !needsNewRayCluster(oldService.Spec.RayClusterSpec, rayService.Spec.RayClusterSpec) {
+		return nil, util.NewFailedPreconditionError("The new spec of service %s does not need a new cluster, use UpdateRayService or UpdateRayServiceConfigs instead", apiService.Name)
+	}
+
+	previousSpec, err := json.Marshal(oldService.Spec)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the spec of service %s", apiService.Name)
+	}
+	rayService.Annotations[util.RayServiceHoldUpgradePromotionAnnotationKey] = "true"
+	rayService.Annotations[util.RayServiceUpgradeRollbackAnnotationKey] = string(previousSpec)
+	rayService.Annotations["ray.io/update-timestamp"] = r.clientManager.Time().Now().String()
+	rayService.ResourceVersion = oldService.ResourceVersion
+	newRayService, err := client.Update(ctx, rayService.Get(), metav1.UpdateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to start the upgrade of service (%s/%s)", rayService.Namespace, rayService.Name)
+	}
+	if err := r.syncServiceExpose(ctx, newRayService); err != nil {
+		return nil, err
+	}
+	if err := r.syncPodDisruptionBudgets(ctx, newRayService, "RayService", &newRayService.Spec.RayClusterSpec); err != nil {
+		return nil, err
+	}
+	return newRayService, nil
+}
+
+// PromoteServiceUpgrade releases the hold of an upgrade started with StartServiceUpgrade, so that the operator
+// switches the traffic to the pending RayCluster and deletes the active one. The upgrade can not be rolled back
+// afterwards. Unless force is set, the pending RayCluster has to be ready to serve.
+func (r *ResourceManager) PromoteServiceUpgrade(ctx context.Context, serviceName string, namespace string, force bool) (*rayv1api.RayService, error) {
+	client := r.getRayServiceClient(namespace)
+	service, err := getServiceByName(ctx, client, serviceName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get service failure")
+	}
+	if _, ok := service.Annotations[util.RayServiceHoldUpgradePromotionAnnotationKey]; !ok {
+		return nil, util.NewFailedPreconditionError("Service %s has no upgrade to promote", serviceName)
+	}
+	if !force && (service.Status.UpgradeStatus == nil || !service.Status.UpgradeStatus.PendingRayClusterReady) {
+		return nil, util.NewFailedPreconditionError("The pending cluster of service %s is not ready to serve yet, retry later or promote with force", serviceName)
+	}
+
+	patch := servicePatch(service)
+	patch = append(patch, map[string]interface{}{
+		"op": "remove", "path": annotationPath(util.RayServiceHoldUpgradePromotionAnnotationKey),
+	})
+	if _, ok := service.Annotations[util.RayServiceUpgradeRollbackAnnotationKey]; ok {
+		patch = append(patch, map[string]interface{}{
+			"op": "remove", "path": annotationPath(util.RayServiceUpgradeRollbackAnnotationKey),
+		})
+	}
+	return r.patchService(ctx, service, patch, "promote the upgrade of")
+}
+
+// RollbackServiceUpgrade restores the spec a RayService had before an upgrade started with StartServiceUpgrade.
+// The operator then deletes the pending RayCluster, since the spec matches the active RayCluster again, which kept
+// serving the traffic during the upgrade.
+func (r *ResourceManager) RollbackServiceUpgrade(ctx context.Context, serviceName string, namespace string) (*rayv1api.RayService, error) {
+	client := r.getRayServiceClient(namespace)
+	service, err := getServiceByName(ctx, client, serviceName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get service failure")
+	}
+	annotation, ok := service.Annotations[util.RayServiceUpgradeRollbackAnnotationKey]
+	if !ok {
+		return nil, util.NewFailedPreconditionError("Service %s has no upgrade to roll back", serviceName)
+	}
+	previousSpec := rayv1api.RayServiceSpec{}
+	if err := json.Unmarshal([]byte(annotation), &previousSpec); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to read the spec of service %s before its upgrade", serviceName)
+	}
+
+	patch := servicePatch(service)
+	patch = append(patch,
+		map[string]interface{}{"op": "replace", "path": "/spec", "value": previousSpec},
+		map[string]interface{}{"op": "remove", "path": annotationPath(util.RayServiceUpgradeRollbackAnnotationKey)},
+	)
+	if _, ok := service.Annotations[util.RayServiceHoldUpgradePromotionAnnotationKey]; ok {
+		patch = append(patch, map[string]interface{}{
+			"op": "remove", "path": annotationPath(util.RayServiceHoldUpgradePromotionAnnotationKey),
+		})
+	}
+	rolledBack, err := r.patchService(ctx, service, patch, "roll back the upgrade of")
+	if err != nil {
+		return nil, err
+	}
+	if err := r.syncPodDisruptionBudgets(ctx, rolledBack, "RayService", &rolledBack.Spec.RayClusterSpec); err != nil {
+		return nil, err
+	}
+	return rolledBack, nil
+}
+
+// isServiceUpgradeInProgress returns whether a RayService has a held upgrade or a pending RayCluster.
+func isServiceUpgradeInProgress(service *rayv1api.RayService) bool {
+	if _, ok := service.Annotations[util.RayServiceHoldUpgradePromotionAnnotationKey]; ok {
+		return true
+	}
+	return service.Status.PendingServiceStatus.RayClusterName != ""
+}
+
+// needsNewRayCluster returns whether the operator prepares a new RayCluster for a change of the cluster spec of a
+// RayService. Like the operator, it ignores the scale of the worker groups and the worker groups appended to the
+// spec, which are updated in place.
+func needsNewRayCluster(oldSpec rayv1api.RayClusterSpec, newSpec rayv1api.RayClusterSpec) bool {
+	oldSpec = withoutWorkerGroupScale(oldSpec)
+	newSpec = withoutWorkerGroupScale(newSpec)
+	if len(newSpec.WorkerGroupSpecs) > len(oldSpec.WorkerGroupSpecs) {
+		newSpec.WorkerGroupSpecs = newSpec.WorkerGroupSpecs[:len(oldSpec.WorkerGroupSpecs)]
+	}
+	return !equality.Semantic.DeepEqual(oldSpec, newSpec)
+}
+
+func withoutWorkerGroupScale(spec rayv1api.RayClusterSpec) rayv1api.RayClusterSpec {
+	spec = *spec.DeepCopy()
+	for i := range spec.WorkerGroupSpecs {
+		spec.WorkerGroupSpecs[i].Replicas = nil
+		spec.WorkerGroupSpecs[i].MinReplicas = nil
+		spec.WorkerGroupSpecs[i].MaxReplicas = nil
+		spec.WorkerGroupSpecs[i].ScaleStrategy.WorkersToDelete = nil
+	}
+	return spec
+}
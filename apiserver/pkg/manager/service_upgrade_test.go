@@ -0,0 +1,131 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestStartPromoteAndRollbackServiceUpgrade(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	newService := func(version string, replicas int32) *api.RayService {
+		return &api.RayService{
+			Name:           "service",
+			Namespace:      "team-a",
+			User:           "user",
+			Version:        version,
+			ServeConfig_V2: "applications: []",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{
+					ComputeTemplate: "template",
+					RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+				},
+				WorkerGroupSpec: []*api.WorkerGroupSpec{
+					{GroupName: "small", ComputeTemplate: "template", Replicas: replicas, MinReplicas: 1, MaxReplicas: 4},
+				},
+			},
+		}
+	}
+	_, err = resourceManager.CreateService(ctx, newService("2.9.0", 1), false, "")
+	require.NoError(t, err)
+
+	_, err = resourceManager.StartServiceUpgrade(ctx, newService("2.10.0", 1))
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "The service has no active cluster yet")
+
+	serviceClient := clientManager.clients.Ray.RayV1().RayServices("team-a")
+	service, err := serviceClient.Get(ctx, "service", metav1.GetOptions{})
+	require.NoError(t, err)
+	service.Status.ActiveServiceStatus.RayClusterName = "service-raycluster-abcde"
+	_, err = serviceClient.UpdateStatus(ctx, service, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// The operator scales the active cluster in place.
+	_, err = resourceManager.StartServiceUpgrade(ctx, newService("2.9.0", 3))
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "A new scale does not need a new cluster")
+	_, err = resourceManager.PromoteServiceUpgrade(ctx, "service", "team-a", false)
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "The service has no upgrade to promote")
+	_, err = resourceManager.RollbackServiceUpgrade(ctx, "service", "team-a")
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "The service has no upgrade to roll back")
+
+	service, err = resourceManager.StartServiceUpgrade(ctx, newService("2.10.0", 1))
+	require.NoError(t, err)
+	assert.Equal(t, "true", service.Annotations[util.RayServiceHoldUpgradePromotionAnnotationKey])
+	assert.Contains(t, service.Annotations, util.RayServiceUpgradeRollbackAnnotationKey)
+	assert.Equal(t, "rayproject/ray:2.10.0", service.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+
+	_, err = resourceManager.StartServiceUpgrade(ctx, newService("2.11.0", 1))
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "An upgrade is already in progress")
+	_, err = resourceManager.PromoteServiceUpgrade(ctx, "service", "team-a", false)
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition), "The pending cluster is not ready yet")
+
+	service, err = resourceManager.RollbackServiceUpgrade(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.NotContains(t, service.Annotations, util.RayServiceHoldUpgradePromotionAnnotationKey)
+	assert.NotContains(t, service.Annotations, util.RayServiceUpgradeRollbackAnnotationKey)
+	assert.Equal(t, "rayproject/ray:2.9.0", service.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+
+	// The fake client does not keep the status of the service on an update, unlike the status subresource.
+	service, err = serviceClient.Get(ctx, "service", metav1.GetOptions{})
+	require.NoError(t, err)
+	service.Status.ActiveServiceStatus.RayClusterName = "service-raycluster-abcde"
+	_, err = serviceClient.UpdateStatus(ctx, service, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = resourceManager.StartServiceUpgrade(ctx, newService("2.10.0", 1))
+	require.NoError(t, err)
+	service, err = serviceClient.Get(ctx, "service", metav1.GetOptions{})
+	require.NoError(t, err)
+	service.Status.ActiveServiceStatus.RayClusterName = "service-raycluster-abcde"
+	service.Status.PendingServiceStatus.RayClusterName = "service-raycluster-fghij"
+	service.Status.UpgradeStatus = &rayv1api.RayServiceUpgradeStatus{PendingRayClusterReady: true, PromotionHeld: true}
+	_, err = serviceClient.UpdateStatus(ctx, service, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	service, err = resourceManager.PromoteServiceUpgrade(ctx, "service", "team-a", false)
+	require.NoError(t, err)
+	assert.NotContains(t, service.Annotations, util.RayServiceHoldUpgradePromotionAnnotationKey)
+	assert.NotContains(t, service.Annotations, util.RayServiceUpgradeRollbackAnnotationKey)
+	assert.Equal(t, "rayproject/ray:2.10.0", service.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+}
+
+func TestNeedsNewRayCluster(t *testing.T) {
+	replicas := int32(1)
+	group := func(name string, image string) rayv1api.WorkerGroupSpec {
+		spec := rayv1api.WorkerGroupSpec{GroupName: name, Replicas: &replicas}
+		spec.Template.Spec.Containers = []corev1.Container{{Name: "ray-worker", Image: image}}
+		return spec
+	}
+	oldSpec := rayv1api.RayClusterSpec{WorkerGroupSpecs: []rayv1api.WorkerGroupSpec{group("small", "ray:2.9.0")}}
+
+	scaled := *oldSpec.DeepCopy()
+	scaled.WorkerGroupSpecs[0].Replicas = nil
+	assert.False(t, needsNewRayCluster(oldSpec, scaled))
+
+	appended := *oldSpec.DeepCopy()
+	appended.WorkerGroupSpecs = append(appended.WorkerGroupSpecs, group("large", "ray:2.9.0"))
+	assert.False(t, needsNewRayCluster(oldSpec, appended))
+
+	upgraded := *oldSpec.DeepCopy()
+	upgraded.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = "ray:2.10.0"
+	assert.True(t, needsNewRayCluster(oldSpec, upgraded))
+
+	assert.True(t, needsNewRayCluster(oldSpec, rayv1api.RayClusterSpec{}))
+}
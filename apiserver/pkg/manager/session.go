@@ -0,0 +1,138 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// DefaultRaySessionIdleTTLSeconds is the idle TTL of the sessions created without one, so that the forgotten sessions
+// are deleted.
+const DefaultRaySessionIdleTTLSeconds = 3600
+
+// CreateRaySession creates the RayCluster of an interactive session, labeled as interactive.
+func (r *ResourceManager) CreateRaySession(ctx context.Context, apiSession *api.RaySession) (*rayv1api.RayCluster, error) {
+	idleTTLSeconds := apiSession.IdleTtlSeconds
+	if idleTTLSeconds == 0 {
+		idleTTLSeconds = DefaultRaySessionIdleTTLSeconds
+	}
+	apiCluster := &api.Cluster{
+		Name:           apiSession.Name,
+		Namespace:      apiSession.Namespace,
+		User:           apiSession.User,
+		Version:        apiSession.Version,
+		ClusterSpec:    apiSession.ClusterSpec,
+		IdleTtlSeconds: idleTTLSeconds,
+	}
+	return r.createCluster(ctx, apiCluster, false, "", map[string]string{util.RayInteractiveSessionLabelKey: "true"})
+}
+
+// GetRaySession returns the RayCluster of a session. The clusters which are not sessions are not found.
+func (r *ResourceManager) GetRaySession(ctx context.Context, name string, namespace string) (*rayv1api.RayCluster, error) {
+	cluster, err := getClusterByName(ctx, r.getRayClusterClient(namespace), name)
+	if err != nil {
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			return nil, util.NewNotFoundError(err, "Session %s not found", name)
+		}
+		return nil, util.Wrap(err, "Get session failure")
+	}
+	if cluster.Labels[util.RayInteractiveSessionLabelKey] != "true" {
+		return nil, util.NewNotFoundError(fmt.Errorf("cluster %s/%s is not an interactive session", namespace, name), "Session %s not found", name)
+	}
+	return cluster, nil
+}
+
+// ListRaySessions lists the RayClusters of the sessions of a namespace, of a user unless user is empty.
+func (r *ResourceManager) ListRaySessions(ctx context.Context, namespace string, user string) ([]*rayv1api.RayCluster, error) {
+	selector := labels.Set{
+		util.KubernetesManagedByLabelKey:   util.ComponentName,
+		util.RayInteractiveSessionLabelKey: "true",
+	}
+	if user != "" {
+		selector[util.RayClusterUserLabelKey] = user
+	}
+	clusterList, err := r.getRayClusterClient(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("List sessions failed in %s", namespace))
+	}
+	result := make([]*rayv1api.RayCluster, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		result = append(result, &clusterList.Items[i])
+	}
+	return result, nil
+}
+
+// DeleteRaySession deletes the RayCluster of a session.
+func (r *ResourceManager) DeleteRaySession(ctx context.Context, name string, namespace string) error {
+	if _, err := r.GetRaySession(ctx, name, namespace); err != nil {
+		return err
+	}
+	return r.DeleteCluster(ctx, name, namespace, false, "")
+}
+
+// GetRaySessionAddress returns the address of the Ray client server of the head of a ready session, through its head
+// service.
+func (r *ResourceManager) GetRaySessionAddress(ctx context.Context, name string, namespace string) (string, error) {
+	cluster, err := r.GetRaySession(ctx, name, namespace)
+	if err != nil {
+		return "", err
+	}
+	if cluster.Status.State != rayv1api.Ready {
+		return "", util.NewFailedPreconditionError("Session %s/%s is not ready yet", namespace, name)
+	}
+	headServiceName, err := utils.GenerateHeadServiceName(utils.RayClusterCRD, cluster.Spec, cluster.Name)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to get the head service name of session %s", name)
+	}
+	port := cluster.Status.Endpoints[utils.ClientPortName]
+	if port == "" {
+		port = strconv.Itoa(utils.DefaultClientPort)
+	}
+	host := fmt.Sprintf("%s.%s.svc.%s", headServiceName, namespace, utils.GetClusterDomainName())
+	return net.JoinHostPort(host, port), nil
+}
+
+// TouchRaySession records that a Ray client is connected to a session, which is not idle until its idle TTL has
+// passed since then.
+func (r *ResourceManager) TouchRaySession(ctx context.Context, name string, namespace string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				util.RaySessionActiveAtAnnotationKey: r.clientManager.Time().Now().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to marshal the activity of session %s/%s", namespace, name)
+	}
+	if _, err := r.getRayClusterClient(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return util.Wrap(err, fmt.Sprintf("Record the activity of session %s/%s failed", namespace, name))
+	}
+	return nil
+}
+
+// sessionActiveAt returns the last time a Ray client was connected to the cluster of a session.
+func sessionActiveAt(cluster *rayv1api.RayCluster) (time.Time, bool) {
+	value, ok := cluster.Annotations[util.RaySessionActiveAtAnnotationKey]
+	if !ok {
+		return time.Time{}, false
+	}
+	activeAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return activeAt, true
+}
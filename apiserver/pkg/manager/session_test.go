@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestRaySessions(t *testing.T) {
+	ctx := context.Background()
+	clientManager := NewFakeClientManager(ctx, 0)
+	resourceManager := NewResourceManager(clientManager)
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template", Namespace: "team-a", Cpu: 1, Memory: 2})
+	require.NoError(t, err)
+	clusterSpec := &api.ClusterSpec{HeadGroupSpec: &api.HeadGroupSpec{ComputeTemplate: "template"}}
+	session, err := resourceManager.CreateRaySession(ctx, &api.RaySession{
+		Name:        "notebook",
+		Namespace:   "team-a",
+		User:        "alice",
+		Version:     "2.9.0",
+		ClusterSpec: clusterSpec,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "true", session.Labels[util.RayInteractiveSessionLabelKey])
+	assert.Equal(t, "3600", session.Annotations[util.RayClusterIdleTTLAnnotationKey])
+	_, err = resourceManager.CreateCluster(ctx, &api.Cluster{Name: "cluster", Namespace: "team-a", User: "alice", Version: "2.9.0", ClusterSpec: clusterSpec}, false, "")
+	require.NoError(t, err)
+
+	// The clusters which are not sessions are not found.
+	_, err = resourceManager.GetRaySession(ctx, "cluster", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	sessions, err := resourceManager.ListRaySessions(ctx, "team-a", "")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "notebook", sessions[0].Name)
+	sessions, err = resourceManager.ListRaySessions(ctx, "team-a", "bob")
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	// The Ray clients can only connect to ready sessions.
+	_, err = resourceManager.GetRaySessionAddress(ctx, "notebook", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+	session.Status.State = rayv1api.Ready
+	_, err = clientManager.clients.Ray.RayV1().RayClusters("team-a").UpdateStatus(ctx, session, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	address, err := resourceManager.GetRaySessionAddress(ctx, "notebook", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "notebook-head-svc.team-a.svc.cluster.local:10001", address)
+
+	require.NoError(t, resourceManager.TouchRaySession(ctx, "notebook", "team-a"))
+	session, err = resourceManager.GetRaySession(ctx, "notebook", "team-a")
+	require.NoError(t, err)
+	_, ok := sessionActiveAt(session)
+	assert.True(t, ok)
+
+	err = resourceManager.DeleteRaySession(ctx, "cluster", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	require.NoError(t, resourceManager.DeleteRaySession(ctx, "notebook", "team-a"))
+	_, err = resourceManager.GetRaySession(ctx, "notebook", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
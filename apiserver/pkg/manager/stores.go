@@ -0,0 +1,183 @@
+package manager
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// The stores are the operations of the servers on a kind of resource. The servers only depend on the stores they
+// use, so that a backend other than ResourceManager, e.g. one routing the calls to several Kubernetes clusters, can
+// be plugged in, and so that the handlers can be tested with fake stores.
+
+// ClusterStore operates RayClusters.
+type ClusterStore interface {
+	CreateCluster(ctx context.Context, apiCluster *api.Cluster, dryRun bool, idempotencyKey string) (*rayv1api.RayCluster, error)
+	GetCluster(ctx context.Context, clusterName string, namespace string) (*rayv1api.RayCluster, error)
+	ListClusters(ctx context.Context, namespace string, continueToken string, limit int64, selector ResourceSelector) ([]*rayv1api.RayCluster, string, error)
+	ListAllClusters(ctx context.Context, continueToken string, limit int64, resourceVersion string, selector ResourceSelector) ([]*rayv1api.RayCluster, metav1.ListMeta, error)
+	WatchCluster(ctx context.Context, clusterName string, namespace string) (<-chan *rayv1api.RayCluster, error)
+	UpdateCluster(ctx context.Context, apiCluster *api.Cluster) (*rayv1api.RayCluster, error)
+	PreviewClusterUpdate(ctx context.Context, apiCluster *api.Cluster) (*api.UpdatePreview, error)
+	UpdateWorkerGroupAutoscaling(ctx context.Context, request *api.UpdateWorkerGroupAutoscalingRequest) (*rayv1api.RayCluster, error)
+	UpdateWorkerGroup(ctx context.Context, request *api.UpdateWorkerGroupRequest) (*rayv1api.RayCluster, error)
+	RestartWorkerGroup(ctx context.Context, request *api.RestartWorkerGroupRequest) (*api.WorkerGroupRestart, error)
+	GetWorkerGroupRestart(ctx context.Context, clusterName string, namespace string, groupName string) (*api.WorkerGroupRestart, error)
+	DrainWorkerGroup(ctx context.Context, request *api.DrainWorkerGroupRequest) (*api.WorkerGroupDrain, error)
+	GetWorkerGroupDrain(ctx context.Context, clusterName string, namespace string, groupName string) (*api.WorkerGroupDrain, error)
+	DeleteCluster(ctx context.Context, clusterName string, namespace string, force bool, resourceVersion string) error
+	BatchDeleteClusters(ctx context.Context, clusterNames []string, namespace string, force bool) []error
+	DrainAndDeleteCluster(ctx context.Context, clusterName string, namespace string, timeoutSeconds int32, force bool, resourceVersion string) error
+	GetDashboardAuthToken(ctx context.Context, clusterName string, namespace string) (string, error)
+	GetClusterEndpoints(ctx context.Context, clusterName string, namespace string) (*api.RayEndpoints, error)
+	CanSchedule(ctx context.Context, namespace string, clusterSpec *api.ClusterSpec, computeTemplate string, replicas int32) (*api.SchedulingAdvice, error)
+	CheckNodeCapacity(ctx context.Context, namespace string, clusterSpec *api.ClusterSpec, computeTemplate *api.ComputeTemplate) ([]string, error)
+	InjectClusterFailure(ctx context.Context, request *api.InjectClusterFailureRequest) ([]string, error)
+	HealClusterPartitions(ctx context.Context, clusterName string, namespace string) ([]string, error)
+	GetClusterClone(ctx context.Context, request *api.CloneRayClusterRequest) (*api.Cluster, error)
+	GetResourceUsage(ctx context.Context, namespace string, includeUtilization bool) (*api.ResourceUsageReport, error)
+	GetClusterPodSummaries(ctx context.Context, clusters []*rayv1api.RayCluster) (map[types.NamespacedName][]*api.GroupPodSummary, error)
+}
+
+// ServiceStore operates RayServices.
+type ServiceStore interface {
+	CreateService(ctx context.Context, apiService *api.RayService, dryRun bool, idempotencyKey string) (*rayv1api.RayService, error)
+	GetService(ctx context.Context, serviceName, namespace string) (*rayv1api.RayService, error)
+	ListServices(ctx context.Context, namespace string, continueToken string, limit int64, selector ResourceSelector) ([]*rayv1api.RayService, string, error)
+	ListAllServices(ctx context.Context, continueToken string, limit int64, resourceVersion string, selector ResourceSelector) ([]*rayv1api.RayService, metav1.ListMeta, error)
+	WatchService(ctx context.Context, serviceName string, namespace string) (<-chan *rayv1api.RayService, error)
+	UpdateRayService(ctx context.Context, apiService *api.RayService) (*rayv1api.RayService, error)
+	PreviewRayServiceUpdate(ctx context.Context, apiService *api.RayService) (*api.UpdatePreview, error)
+	UpdateRayServiceConfigs(ctx context.Context, request *api.UpdateRayServiceConfigsRequest) (*rayv1api.RayService, error)
+	SuspendService(ctx context.Context, serviceName string, namespace string) (*rayv1api.RayService, error)
+	ResumeService(ctx context.Context, serviceName string, namespace string) (*rayv1api.RayService, error)
+	StartServiceUpgrade(ctx context.Context, apiService *api.RayService) (*rayv1api.RayService, error)
+	PromoteServiceUpgrade(ctx context.Context, serviceName string, namespace string, force bool) (*rayv1api.RayService, error)
+	RollbackServiceUpgrade(ctx context.Context, serviceName string, namespace string) (*rayv1api.RayService, error)
+	DeleteService(ctx context.Context, serviceName, namespace string, force bool, foreground bool, resourceVersion string) error
+	DeleteServiceAndRetainCluster(ctx context.Context, serviceName, namespace string, force bool, foreground bool, resourceVersion string) error
+	GetServiceDeletionStatus(ctx context.Context, serviceName string, namespace string) (*api.RayServiceDeletionStatus, error)
+	GetServiceEndpoints(ctx context.Context, serviceName string, namespace string) (*api.RayEndpoints, error)
+	GetServiceServeConfig(ctx context.Context, serviceName string, namespace string) (*api.RayServeConfig, error)
+	StreamServiceLogs(ctx context.Context, serviceName string, namespace string, options PodLogOptions) (<-chan *api.PodLogLine, error)
+}
+
+// JobStore operates RayJobs.
+type JobStore interface {
+	CreateJob(ctx context.Context, apiJob *api.RayJob) (*rayv1api.RayJob, error)
+	BatchCreateJobs(ctx context.Context, namespace string, apiJobs []*api.RayJob) []BatchJobResult
+	GetJob(ctx context.Context, jobName string, namespace string) (*rayv1api.RayJob, error)
+	ListJobs(ctx context.Context, namespace string, continueToken string, limit int64) ([]*rayv1api.RayJob, string, error)
+	ListAllJobs(ctx context.Context, continueToken string, limit int64) ([]*rayv1api.RayJob, string, error)
+	DeleteJob(ctx context.Context, jobName string, namespace string) error
+	StreamJobLogs(ctx context.Context, jobName string, namespace string, options PodLogOptions) (<-chan *api.PodLogLine, error)
+	GetJobSubmitterLogs(ctx context.Context, jobName string, namespace string, tailLines int64) (string, error)
+}
+
+// CronJobStore operates RayCronJobs and the RayJobs they ran.
+type CronJobStore interface {
+	CreateRayCronJob(ctx context.Context, apiCronJob *api.RayCronJob) (*corev1.ConfigMap, error)
+	GetRayCronJob(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error)
+	ListRayCronJobs(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error)
+	DeleteRayCronJob(ctx context.Context, name string, namespace string) error
+	GetRayCronJobRun(ctx context.Context, cronJobName string, name string, namespace string) (*rayv1api.RayJob, error)
+	ListRayCronJobRuns(ctx context.Context, namespace string, cronJobName string) ([]*rayv1api.RayJob, error)
+}
+
+// TemplateStore operates compute templates.
+type TemplateStore interface {
+	CreateComputeTemplate(ctx context.Context, runtime *api.ComputeTemplate) (*corev1.ConfigMap, error)
+	GetComputeTemplate(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error)
+	UpdateComputeTemplate(ctx context.Context, template *api.ComputeTemplate) (*corev1.ConfigMap, error)
+	GetComputeTemplateRevision(ctx context.Context, name string, namespace string, revision int32) (*corev1.ConfigMap, error)
+	ListComputeTemplateRevisions(ctx context.Context, name string, namespace string) ([]*corev1.ConfigMap, error)
+	ListComputeTemplates(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error)
+	ListAllComputeTemplates(ctx context.Context) ([]*corev1.ConfigMap, error)
+	DeleteComputeTemplate(ctx context.Context, name string, namespace string) error
+	CheckNodeCapacity(ctx context.Context, namespace string, clusterSpec *api.ClusterSpec, computeTemplate *api.ComputeTemplate) ([]string, error)
+}
+
+// ServiceTemplateStore operates service templates.
+type ServiceTemplateStore interface {
+	CreateServiceTemplate(ctx context.Context, apiTemplate *api.ServiceTemplate) (*corev1.ConfigMap, error)
+	GetServiceTemplate(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error)
+	ListServiceTemplates(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error)
+	DeleteServiceTemplate(ctx context.Context, name string, namespace string) error
+}
+
+// NotificationStore operates notification subscriptions.
+type NotificationStore interface {
+	CreateNotificationSubscription(ctx context.Context, apiSubscription *api.NotificationSubscription) (*corev1.ConfigMap, error)
+	GetNotificationSubscription(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error)
+	ListNotificationSubscriptions(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error)
+	DeleteNotificationSubscription(ctx context.Context, name string, namespace string) error
+}
+
+// NamespaceStore sets up the namespaces with the namespace template of the config.
+type NamespaceStore interface {
+	EnsureNamespace(ctx context.Context, name string, labels map[string]string) (*api.Namespace, error)
+	GetNamespace(ctx context.Context, name string) (*api.Namespace, error)
+}
+
+// ApiServerInfoStore describes the API server and the versions of the Ray custom resources of the Kubernetes cluster.
+type ApiServerInfoStore interface {
+	GetApiServerInfo(ctx context.Context) (*api.ApiServerInfo, error)
+}
+
+// RaySessionStore manages the interactive sessions, the RayClusters labeled as interactive which the Ray clients
+// reach through the session proxy.
+type RaySessionStore interface {
+	CreateRaySession(ctx context.Context, apiSession *api.RaySession) (*rayv1api.RayCluster, error)
+	GetRaySession(ctx context.Context, name string, namespace string) (*rayv1api.RayCluster, error)
+	ListRaySessions(ctx context.Context, namespace string, user string) ([]*rayv1api.RayCluster, error)
+	DeleteRaySession(ctx context.Context, name string, namespace string) error
+	GetRaySessionAddress(ctx context.Context, name string, namespace string) (string, error)
+	TouchRaySession(ctx context.Context, name string, namespace string) error
+}
+
+// BackupStore exports and imports the resources of a namespace.
+type BackupStore interface {
+	ExportBackup(ctx context.Context, namespace string, includeSecrets bool) (*model.BackupObjects, error)
+	ImportBackup(ctx context.Context, namespace string, sourceNamespace string, objects *model.BackupObjects) (restored []string, skipped []string, err error)
+}
+
+// EventSource finds the Kubernetes events of the Ray resources.
+type EventSource interface {
+	GetClusterEvents(ctx context.Context, clusterName string, namespace string) ([]corev1.Event, error)
+	GetServiceEvents(ctx context.Context, service rayv1api.RayService) ([]corev1.Event, error)
+	GetServicesEvents(ctx context.Context, services []*rayv1api.RayService) (map[string][]corev1.Event, error)
+	ListNamespaceEvents(ctx context.Context, namespace string, kinds []string, reasons []string, limit int) ([]corev1.Event, error)
+}
+
+// GarbageCollector finds the resources which are deleted once their time to live expires.
+type GarbageCollector interface {
+	ListExpiringResources(ctx context.Context, namespace string) ([]*api.ExpiringResource, error)
+}
+
+// ResourceSearcher searches the clusters, jobs and services by their owner, state and creation time.
+type ResourceSearcher interface {
+	SearchResources(ctx context.Context, namespace string, query SearchQuery) ([]*api.SearchResult, error)
+}
+
+var (
+	_ ClusterStore         = (*ResourceManager)(nil)
+	_ ServiceStore         = (*ResourceManager)(nil)
+	_ JobStore             = (*ResourceManager)(nil)
+	_ CronJobStore         = (*ResourceManager)(nil)
+	_ TemplateStore        = (*ResourceManager)(nil)
+	_ ServiceTemplateStore = (*ResourceManager)(nil)
+	_ NotificationStore    = (*ResourceManager)(nil)
+	_ NamespaceStore       = (*ResourceManager)(nil)
+	_ ApiServerInfoStore   = (*ResourceManager)(nil)
+	_ RaySessionStore      = (*ResourceManager)(nil)
+	_ BackupStore          = (*ResourceManager)(nil)
+	_ EventSource          = (*ResourceManager)(nil)
+	_ GarbageCollector     = (*ResourceManager)(nil)
+	_ ResourceSearcher     = (*ResourceManager)(nil)
+)
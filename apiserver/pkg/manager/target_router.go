@@ -0,0 +1,838 @@
+package manager
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/features"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+type targetClusterKey struct{}
+
+// WithTargetCluster returns a context whose calls to a TargetRouter go to the given Kubernetes cluster. An empty
+// target is the default cluster.
+func WithTargetCluster(ctx context.Context, target string) context.Context {
+	return context.WithValue(ctx, targetClusterKey{}, target)
+}
+
+// TargetClusterFromContext returns the Kubernetes cluster targeted by the calls with the context.
+func TargetClusterFromContext(ctx context.Context) string {
+	target, _ := ctx.Value(targetClusterKey{}).(string)
+	return target
+}
+
+// TargetRouter implements the stores by routing every call to the ResourceManager of the Kubernetes cluster targeted
+// by its context, so that a single API server operates Ray resources in several Kubernetes clusters. The calls
+// without a target go to the default cluster, and the calls with a target are rejected unless the MultiClusterRouting
+// feature gate is enabled.
+type TargetRouter struct {
+	defaultManager *ResourceManager
+	targets        map[string]*ResourceManager
+}
+
+// NewTargetRouter creates a TargetRouter for the default cluster and the named target clusters.
+func NewTargetRouter(defaultManager *ResourceManager, targets map[string]*ResourceManager) *TargetRouter {
+	return &TargetRouter{defaultManager: defaultManager, targets: targets}
+}
+
+// NewTargetManagers creates the ResourceManagers of the target clusters named by their kubeconfig contexts with
+// newManager. It creates none unless the MultiClusterRouting feature gate is enabled, so that the background work of
+// the ResourceManagers, e.g. the cron job scheduler and the garbage collector, never runs in the target clusters
+// while the feature gate is disabled.
+func NewTargetManagers(kubeContexts []string, newManager func(kubeContext string) *ResourceManager) map[string]*ResourceManager {
+	targets := map[string]*ResourceManager{}
+	if !features.Enabled(features.MultiClusterRouting) {
+		return targets
+	}
+	for _, kubeContext := range kubeContexts {
+		targets[kubeContext] = newManager(kubeContext)
+	}
+	return targets
+}
+
+// TargetClusters returns the sorted names of the target clusters.
+func (r *TargetRouter) TargetClusters() []string {
+	names := make([]string, 0, len(r.targets))
+	for name := range r.targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *TargetRouter) manager(ctx context.Context) (*ResourceManager, error) {
+	target := TargetClusterFromContext(ctx)
+	if target == "" {
+		return r.defaultManager, nil
+	}
+	if !features.Enabled(features.MultiClusterRouting) {
+		return nil, util.NewUnimplementedError("Target clusters are disabled. Enable the %s feature gate to use them.", features.MultiClusterRouting)
+	}
+	resourceManager, ok := r.targets[target]
+	if !ok {
+		return nil, util.NewInvalidInputError("Target cluster %s is not configured. Please specify one of [%s] or leave it empty for the default cluster.", target, strings.Join(r.TargetClusters(), ", "))
+	}
+	return resourceManager, nil
+}
+
+func (r *TargetRouter) CreateCluster(ctx context.Context, apiCluster *api.Cluster, dryRun bool, idempotencyKey string) (*rayv1api.RayCluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CreateCluster(ctx, apiCluster, dryRun, idempotencyKey)
+}
+
+func (r *TargetRouter) GetCluster(ctx context.Context, clusterName string, namespace string) (*rayv1api.RayCluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetCluster(ctx, clusterName, namespace)
+}
+
+func (r *TargetRouter) ListClusters(ctx context.Context, namespace string, continueToken string, limit int64, selector ResourceSelector) ([]*rayv1api.RayCluster, string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return resourceManager.ListClusters(ctx, namespace, continueToken, limit, selector)
+}
+
+func (r *TargetRouter) ListAllClusters(ctx context.Context, continueToken string, limit int64, resourceVersion string, selector ResourceSelector) ([]*rayv1api.RayCluster, metav1.ListMeta, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, metav1.ListMeta{}, err
+	}
+	return resourceManager.ListAllClusters(ctx, continueToken, limit, resourceVersion, selector)
+}
+
+func (r *TargetRouter) WatchCluster(ctx context.Context, clusterName string, namespace string) (<-chan *rayv1api.RayCluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.WatchCluster(ctx, clusterName, namespace)
+}
+
+func (r *TargetRouter) UpdateCluster(ctx context.Context, apiCluster *api.Cluster) (*rayv1api.RayCluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.UpdateCluster(ctx, apiCluster)
+}
+
+func (r *TargetRouter) PreviewClusterUpdate(ctx context.Context, apiCluster *api.Cluster) (*api.UpdatePreview, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.PreviewClusterUpdate(ctx, apiCluster)
+}
+
+func (r *TargetRouter) UpdateWorkerGroupAutoscaling(ctx context.Context, request *api.UpdateWorkerGroupAutoscalingRequest) (*rayv1api.RayCluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.UpdateWorkerGroupAutoscaling(ctx, request)
+}
+
+func (r *TargetRouter) UpdateWorkerGroup(ctx context.Context, request *api.UpdateWorkerGroupRequest) (*rayv1api.RayCluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.UpdateWorkerGroup(ctx, request)
+}
+
+func (r *TargetRouter) RestartWorkerGroup(ctx context.Context, request *api.RestartWorkerGroupRequest) (*api.WorkerGroupRestart, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.RestartWorkerGroup(ctx, request)
+}
+
+func (r *TargetRouter) GetWorkerGroupRestart(ctx context.Context, clusterName string, namespace string, groupName string) (*api.WorkerGroupRestart, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetWorkerGroupRestart(ctx, clusterName, namespace, groupName)
+}
+
+func (r *TargetRouter) DrainWorkerGroup(ctx context.Context, request *api.DrainWorkerGroupRequest) (*api.WorkerGroupDrain, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.DrainWorkerGroup(ctx, request)
+}
+
+func (r *TargetRouter) GetWorkerGroupDrain(ctx context.Context, clusterName string, namespace string, groupName string) (*api.WorkerGroupDrain, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetWorkerGroupDrain(ctx, clusterName, namespace, groupName)
+}
+
+func (r *TargetRouter) DeleteCluster(ctx context.Context, clusterName string, namespace string, force bool, resourceVersion string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DeleteCluster(ctx, clusterName, namespace, force, resourceVersion)
+}
+
+func (r *TargetRouter) BatchDeleteClusters(ctx context.Context, clusterNames []string, namespace string, force bool) []error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		errs := make([]error, len(clusterNames))
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	return resourceManager.BatchDeleteClusters(ctx, clusterNames, namespace, force)
+}
+
+func (r *TargetRouter) DrainAndDeleteCluster(ctx context.Context, clusterName string, namespace string, timeoutSeconds int32, force bool, resourceVersion string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DrainAndDeleteCluster(ctx, clusterName, namespace, timeoutSeconds, force, resourceVersion)
+}
+
+func (r *TargetRouter) GetDashboardAuthToken(ctx context.Context, clusterName string, namespace string) (string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return "", err
+	}
+	return resourceManager.GetDashboardAuthToken(ctx, clusterName, namespace)
+}
+
+func (r *TargetRouter) GetClusterEndpoints(ctx context.Context, clusterName string, namespace string) (*api.RayEndpoints, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetClusterEndpoints(ctx, clusterName, namespace)
+}
+
+func (r *TargetRouter) CanSchedule(ctx context.Context, namespace string, clusterSpec *api.ClusterSpec, computeTemplate string, replicas int32) (*api.SchedulingAdvice, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CanSchedule(ctx, namespace, clusterSpec, computeTemplate, replicas)
+}
+
+func (r *TargetRouter) CheckNodeCapacity(ctx context.Context, namespace string, clusterSpec *api.ClusterSpec, computeTemplate *api.ComputeTemplate) ([]string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CheckNodeCapacity(ctx, namespace, clusterSpec, computeTemplate)
+}
+
+func (r *TargetRouter) InjectClusterFailure(ctx context.Context, request *api.InjectClusterFailureRequest) ([]string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.InjectClusterFailure(ctx, request)
+}
+
+func (r *TargetRouter) HealClusterPartitions(ctx context.Context, clusterName string, namespace string) ([]string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.HealClusterPartitions(ctx, clusterName, namespace)
+}
+
+func (r *TargetRouter) GetClusterClone(ctx context.Context, request *api.CloneRayClusterRequest) (*api.Cluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetClusterClone(ctx, request)
+}
+
+func (r *TargetRouter) GetResourceUsage(ctx context.Context, namespace string, includeUtilization bool) (*api.ResourceUsageReport, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetResourceUsage(ctx, namespace, includeUtilization)
+}
+
+func (r *TargetRouter) GetClusterPodSummaries(ctx context.Context, clusters []*rayv1api.RayCluster) (map[types.NamespacedName][]*api.GroupPodSummary, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetClusterPodSummaries(ctx, clusters)
+}
+
+func (r *TargetRouter) CreateService(ctx context.Context, apiService *api.RayService, dryRun bool, idempotencyKey string) (*rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CreateService(ctx, apiService, dryRun, idempotencyKey)
+}
+
+func (r *TargetRouter) GetService(ctx context.Context, serviceName, namespace string) (*rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetService(ctx, serviceName, namespace)
+}
+
+func (r *TargetRouter) ListServices(ctx context.Context, namespace string, continueToken string, limit int64, selector ResourceSelector) ([]*rayv1api.RayService, string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return resourceManager.ListServices(ctx, namespace, continueToken, limit, selector)
+}
+
+func (r *TargetRouter) ListAllServices(ctx context.Context, continueToken string, limit int64, resourceVersion string, selector ResourceSelector) ([]*rayv1api.RayService, metav1.ListMeta, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, metav1.ListMeta{}, err
+	}
+	return resourceManager.ListAllServices(ctx, continueToken, limit, resourceVersion, selector)
+}
+
+func (r *TargetRouter) WatchService(ctx context.Context, serviceName string, namespace string) (<-chan *rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.WatchService(ctx, serviceName, namespace)
+}
+
+func (r *TargetRouter) UpdateRayService(ctx context.Context, apiService *api.RayService) (*rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.UpdateRayService(ctx, apiService)
+}
+
+func (r *TargetRouter) PreviewRayServiceUpdate(ctx context.Context, apiService *api.RayService) (*api.UpdatePreview, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.PreviewRayServiceUpdate(ctx, apiService)
+}
+
+func (r *TargetRouter) UpdateRayServiceConfigs(ctx context.Context, request *api.UpdateRayServiceConfigsRequest) (*rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.UpdateRayServiceConfigs(ctx, request)
+}
+
+func (r *TargetRouter) SuspendService(ctx context.Context, serviceName string, namespace string) (*rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.SuspendService(ctx, serviceName, namespace)
+}
+
+func (r *TargetRouter) ResumeService(ctx context.Context, serviceName string, namespace string) (*rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ResumeService(ctx, serviceName, namespace)
+}
+
+func (r *TargetRouter) StartServiceUpgrade(ctx context.Context, apiService *api.RayService) (*rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.StartServiceUpgrade(ctx, apiService)
+}
+
+func (r *TargetRouter) PromoteServiceUpgrade(ctx context.Context, serviceName string, namespace string, force bool) (*rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.PromoteServiceUpgrade(ctx, serviceName, namespace, force)
+}
+
+func (r *TargetRouter) RollbackServiceUpgrade(ctx context.Context, serviceName string, namespace string) (*rayv1api.RayService, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.RollbackServiceUpgrade(ctx, serviceName, namespace)
+}
+
+func (r *TargetRouter) DeleteService(ctx context.Context, serviceName, namespace string, force bool, foreground bool, resourceVersion string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DeleteService(ctx, serviceName, namespace, force, foreground, resourceVersion)
+}
+
+func (r *TargetRouter) DeleteServiceAndRetainCluster(ctx context.Context, serviceName, namespace string, force bool, foreground bool, resourceVersion string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DeleteServiceAndRetainCluster(ctx, serviceName, namespace, force, foreground, resourceVersion)
+}
+
+func (r *TargetRouter) GetServiceDeletionStatus(ctx context.Context, serviceName string, namespace string) (*api.RayServiceDeletionStatus, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetServiceDeletionStatus(ctx, serviceName, namespace)
+}
+
+func (r *TargetRouter) GetServiceEndpoints(ctx context.Context, serviceName string, namespace string) (*api.RayEndpoints, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetServiceEndpoints(ctx, serviceName, namespace)
+}
+
+func (r *TargetRouter) GetServiceServeConfig(ctx context.Context, serviceName string, namespace string) (*api.RayServeConfig, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetServiceServeConfig(ctx, serviceName, namespace)
+}
+
+func (r *TargetRouter) StreamServiceLogs(ctx context.Context, serviceName string, namespace string, options PodLogOptions) (<-chan *api.PodLogLine, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.StreamServiceLogs(ctx, serviceName, namespace, options)
+}
+
+func (r *TargetRouter) CreateJob(ctx context.Context, apiJob *api.RayJob) (*rayv1api.RayJob, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CreateJob(ctx, apiJob)
+}
+
+func (r *TargetRouter) BatchCreateJobs(ctx context.Context, namespace string, apiJobs []*api.RayJob) []BatchJobResult {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		results := make([]BatchJobResult, len(apiJobs))
+		for i := range results {
+			results[i].Err = err
+		}
+		return results
+	}
+	return resourceManager.BatchCreateJobs(ctx, namespace, apiJobs)
+}
+
+func (r *TargetRouter) GetJob(ctx context.Context, jobName string, namespace string) (*rayv1api.RayJob, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetJob(ctx, jobName, namespace)
+}
+
+func (r *TargetRouter) ListJobs(ctx context.Context, namespace string, continueToken string, limit int64) ([]*rayv1api.RayJob, string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return resourceManager.ListJobs(ctx, namespace, continueToken, limit)
+}
+
+func (r *TargetRouter) ListAllJobs(ctx context.Context, continueToken string, limit int64) ([]*rayv1api.RayJob, string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return resourceManager.ListAllJobs(ctx, continueToken, limit)
+}
+
+func (r *TargetRouter) DeleteJob(ctx context.Context, jobName string, namespace string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DeleteJob(ctx, jobName, namespace)
+}
+
+func (r *TargetRouter) StreamJobLogs(ctx context.Context, jobName string, namespace string, options PodLogOptions) (<-chan *api.PodLogLine, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.StreamJobLogs(ctx, jobName, namespace, options)
+}
+
+func (r *TargetRouter) GetJobSubmitterLogs(ctx context.Context, jobName string, namespace string, tailLines int64) (string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return "", err
+	}
+	return resourceManager.GetJobSubmitterLogs(ctx, jobName, namespace, tailLines)
+}
+
+func (r *TargetRouter) CreateRayCronJob(ctx context.Context, apiCronJob *api.RayCronJob) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CreateRayCronJob(ctx, apiCronJob)
+}
+
+func (r *TargetRouter) GetRayCronJob(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetRayCronJob(ctx, name, namespace)
+}
+
+func (r *TargetRouter) ListRayCronJobs(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListRayCronJobs(ctx, namespace)
+}
+
+func (r *TargetRouter) DeleteRayCronJob(ctx context.Context, name string, namespace string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DeleteRayCronJob(ctx, name, namespace)
+}
+
+func (r *TargetRouter) GetRayCronJobRun(ctx context.Context, cronJobName string, name string, namespace string) (*rayv1api.RayJob, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetRayCronJobRun(ctx, cronJobName, name, namespace)
+}
+
+func (r *TargetRouter) ListRayCronJobRuns(ctx context.Context, namespace string, cronJobName string) ([]*rayv1api.RayJob, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListRayCronJobRuns(ctx, namespace, cronJobName)
+}
+
+func (r *TargetRouter) CreateComputeTemplate(ctx context.Context, runtime *api.ComputeTemplate) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CreateComputeTemplate(ctx, runtime)
+}
+
+func (r *TargetRouter) GetComputeTemplate(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetComputeTemplate(ctx, name, namespace)
+}
+
+func (r *TargetRouter) UpdateComputeTemplate(ctx context.Context, template *api.ComputeTemplate) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.UpdateComputeTemplate(ctx, template)
+}
+
+func (r *TargetRouter) GetComputeTemplateRevision(ctx context.Context, name string, namespace string, revision int32) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetComputeTemplateRevision(ctx, name, namespace, revision)
+}
+
+func (r *TargetRouter) ListComputeTemplateRevisions(ctx context.Context, name string, namespace string) ([]*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListComputeTemplateRevisions(ctx, name, namespace)
+}
+
+func (r *TargetRouter) ListComputeTemplates(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListComputeTemplates(ctx, namespace)
+}
+
+func (r *TargetRouter) ListAllComputeTemplates(ctx context.Context) ([]*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListAllComputeTemplates(ctx)
+}
+
+func (r *TargetRouter) DeleteComputeTemplate(ctx context.Context, name string, namespace string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DeleteComputeTemplate(ctx, name, namespace)
+}
+
+func (r *TargetRouter) CreateServiceTemplate(ctx context.Context, apiTemplate *api.ServiceTemplate) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CreateServiceTemplate(ctx, apiTemplate)
+}
+
+func (r *TargetRouter) GetServiceTemplate(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetServiceTemplate(ctx, name, namespace)
+}
+
+func (r *TargetRouter) ListServiceTemplates(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListServiceTemplates(ctx, namespace)
+}
+
+func (r *TargetRouter) DeleteServiceTemplate(ctx context.Context, name string, namespace string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DeleteServiceTemplate(ctx, name, namespace)
+}
+
+func (r *TargetRouter) CreateNotificationSubscription(ctx context.Context, apiSubscription *api.NotificationSubscription) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CreateNotificationSubscription(ctx, apiSubscription)
+}
+
+func (r *TargetRouter) GetNotificationSubscription(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetNotificationSubscription(ctx, name, namespace)
+}
+
+func (r *TargetRouter) ListNotificationSubscriptions(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListNotificationSubscriptions(ctx, namespace)
+}
+
+func (r *TargetRouter) DeleteNotificationSubscription(ctx context.Context, name string, namespace string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DeleteNotificationSubscription(ctx, name, namespace)
+}
+
+func (r *TargetRouter) EnsureNamespace(ctx context.Context, name string, labels map[string]string) (*api.Namespace, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.EnsureNamespace(ctx, name, labels)
+}
+
+func (r *TargetRouter) GetNamespace(ctx context.Context, name string) (*api.Namespace, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetNamespace(ctx, name)
+}
+
+func (r *TargetRouter) GetApiServerInfo(ctx context.Context) (*api.ApiServerInfo, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetApiServerInfo(ctx)
+}
+
+func (r *TargetRouter) CreateRaySession(ctx context.Context, apiSession *api.RaySession) (*rayv1api.RayCluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.CreateRaySession(ctx, apiSession)
+}
+
+func (r *TargetRouter) GetRaySession(ctx context.Context, name string, namespace string) (*rayv1api.RayCluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetRaySession(ctx, name, namespace)
+}
+
+func (r *TargetRouter) ListRaySessions(ctx context.Context, namespace string, user string) ([]*rayv1api.RayCluster, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListRaySessions(ctx, namespace, user)
+}
+
+func (r *TargetRouter) DeleteRaySession(ctx context.Context, name string, namespace string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.DeleteRaySession(ctx, name, namespace)
+}
+
+func (r *TargetRouter) GetRaySessionAddress(ctx context.Context, name string, namespace string) (string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return "", err
+	}
+	return resourceManager.GetRaySessionAddress(ctx, name, namespace)
+}
+
+func (r *TargetRouter) TouchRaySession(ctx context.Context, name string, namespace string) error {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return resourceManager.TouchRaySession(ctx, name, namespace)
+}
+
+func (r *TargetRouter) ExportBackup(ctx context.Context, namespace string, includeSecrets bool) (*model.BackupObjects, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ExportBackup(ctx, namespace, includeSecrets)
+}
+
+func (r *TargetRouter) ImportBackup(ctx context.Context, namespace string, sourceNamespace string, objects *model.BackupObjects) ([]string, []string, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resourceManager.ImportBackup(ctx, namespace, sourceNamespace, objects)
+}
+
+func (r *TargetRouter) GetClusterEvents(ctx context.Context, clusterName string, namespace string) ([]corev1.Event, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetClusterEvents(ctx, clusterName, namespace)
+}
+
+func (r *TargetRouter) GetServiceEvents(ctx context.Context, service rayv1api.RayService) ([]corev1.Event, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetServiceEvents(ctx, service)
+}
+
+func (r *TargetRouter) GetServicesEvents(ctx context.Context, services []*rayv1api.RayService) (map[string][]corev1.Event, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.GetServicesEvents(ctx, services)
+}
+
+func (r *TargetRouter) ListNamespaceEvents(ctx context.Context, namespace string, kinds []string, reasons []string, limit int) ([]corev1.Event, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListNamespaceEvents(ctx, namespace, kinds, reasons, limit)
+}
+
+func (r *TargetRouter) ListExpiringResources(ctx context.Context, namespace string) ([]*api.ExpiringResource, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.ListExpiringResources(ctx, namespace)
+}
+
+func (r *TargetRouter) SearchResources(ctx context.Context, namespace string, query SearchQuery) ([]*api.SearchResult, error) {
+	resourceManager, err := r.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resourceManager.SearchResources(ctx, namespace, query)
+}
+
+var (
+	_ ClusterStore         = (*TargetRouter)(nil)
+	_ ServiceStore         = (*TargetRouter)(nil)
+	_ JobStore             = (*TargetRouter)(nil)
+	_ CronJobStore         = (*TargetRouter)(nil)
+	_ TemplateStore        = (*TargetRouter)(nil)
+	_ ServiceTemplateStore = (*TargetRouter)(nil)
+	_ NotificationStore    = (*TargetRouter)(nil)
+	_ NamespaceStore       = (*TargetRouter)(nil)
+	_ ApiServerInfoStore   = (*TargetRouter)(nil)
+	_ RaySessionStore      = (*TargetRouter)(nil)
+	_ BackupStore          = (*TargetRouter)(nil)
+	_ GarbageCollector     = (*TargetRouter)(nil)
+	_ EventSource          = (*TargetRouter)(nil)
+	_ ResourceSearcher     = (*TargetRouter)(nil)
+)
@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/features"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestTargetRouter(t *testing.T) {
+	defer features.SetFeatureGateDuringTest(features.MultiClusterRouting, true)()
+	ctx := context.Background()
+	defaultManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	targetManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	router := NewTargetRouter(defaultManager, map[string]*ResourceManager{"us-east": targetManager})
+	assert.Equal(t, []string{"us-east"}, router.TargetClusters())
+
+	targetCtx := WithTargetCluster(ctx, "us-east")
+	_, err := router.CreateComputeTemplate(targetCtx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	_, err = router.CreateCluster(targetCtx, &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		User:      "user",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "template",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+		},
+	}, false, "")
+	require.NoError(t, err)
+
+	_, err = targetManager.GetCluster(ctx, "cluster", "team-a")
+	require.NoError(t, err, "The cluster is created in the target cluster")
+	_, err = router.GetCluster(targetCtx, "cluster", "team-a")
+	require.NoError(t, err)
+	_, err = router.GetCluster(ctx, "cluster", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound), "The calls without a target go to the default cluster")
+
+	_, _, err = router.ListClusters(WithTargetCluster(ctx, "eu-west"), "team-a", "", 0, ResourceSelector{})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument), "Unknown target clusters are rejected")
+
+	defer features.SetFeatureGateDuringTest(features.MultiClusterRouting, false)()
+	_, err = router.GetCluster(targetCtx, "cluster", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.Unimplemented), "Targets are rejected without the feature gate")
+	_, err = router.GetCluster(ctx, "cluster", "team-a")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound), "The calls without a target are still served")
+}
+
+func TestNewTargetManagers(t *testing.T) {
+	ctx := context.Background()
+	var created []string
+	newManager := func(kubeContext string) *ResourceManager {
+		created = append(created, kubeContext)
+		return NewResourceManager(NewFakeClientManager(ctx, 0))
+	}
+
+	restore := features.SetFeatureGateDuringTest(features.MultiClusterRouting, false)
+	assert.Empty(t, NewTargetManagers([]string{"us-east", "eu-west"}, newManager))
+	assert.Empty(t, created, "No ResourceManager is created without the feature gate")
+	restore()
+
+	defer features.SetFeatureGateDuringTest(features.MultiClusterRouting, true)()
+	targets := NewTargetManagers([]string{"us-east", "eu-west"}, newManager)
+	assert.Len(t, targets, 2)
+	assert.Equal(t, []string{"us-east", "eu-west"}, created)
+}
@@ -0,0 +1,240 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// previewIgnoredAnnotations are the annotations the API server keeps its own records in, which are not reported as
+// changes.
+var previewIgnoredAnnotations = []string{
+	"ray.io/creation-timestamp",
+	"ray.io/update-timestamp",
+	util.IdempotencyKeyAnnotationKey,
+	util.IdempotencyPayloadHashAnnotationKey,
+}
+
+// identifierPattern matches the keys which are appended to a field path with a dot, the others are quoted.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// PreviewClusterUpdate returns the changes an UpdateCluster call would make to a RayCluster. The update is validated,
+// admitted and sent to Kubernetes as a dry run, so that the changes include the defaults and the mutations of the
+// admission webhooks, but nothing is persisted.
+func (r *ResourceManager) PreviewClusterUpdate(ctx context.Context, apiCluster *api.Cluster) (*api.UpdatePreview, error) {
+	cluster, data, err := r.clusterUpdatePatch(ctx, apiCluster)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := r.getRayClusterClient(apiCluster.Namespace).Patch(ctx, apiCluster.Name, types.JSONPatchType, data, metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to preview the update of cluster (%s/%s)", apiCluster.Namespace, apiCluster.Name)
+	}
+	changes, err := resourceChanges(cluster, updated)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to compare the update of cluster (%s/%s)", apiCluster.Namespace, apiCluster.Name)
+	}
+	preview := &api.UpdatePreview{Changes: changes, Impact: api.UpdateImpact_NO_CHANGE, ImpactReason: "The update changes nothing."}
+	if len(changes) > 0 {
+		// The other changes are rejected by clusterUpdatePatch, since the operator doesn't roll them out.
+		preview.Impact = api.UpdateImpact_IN_PLACE
+		preview.ImpactReason = "The operator scales the worker groups of the running cluster, without restarting its other Pods."
+	}
+	return preview, nil
+}
+
+// PreviewRayServiceUpdate returns the changes an UpdateRayService call would make to a RayService, and whether the
+// operator would apply them to the running RayCluster or prepare a new one. Like PreviewClusterUpdate, the update is
+// sent to Kubernetes as a dry run.
+func (r *ResourceManager) PreviewRayServiceUpdate(ctx context.Context, apiService *api.RayService) (*api.UpdatePreview, error) {
+	client := r.getRayServiceClient(apiService.Namespace)
+	oldService, err := getServiceByName(ctx, client, apiService.Name)
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("Preview service update fail, no service named: %s ", apiService.Name))
+	}
+	rayService, err := r.renderRayServiceUpdate(ctx, oldService, apiService)
+	if err != nil {
+		return nil, err
+	}
+	rayService.ResourceVersion = oldService.ResourceVersion
+	updated, err := client.Update(ctx, rayService.Get(), metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to preview the update of service (%s/%s)", apiService.Namespace, apiService.Name)
+	}
+	changes, err := resourceChanges(oldService, updated)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to compare the update of service (%s/%s)", apiService.Namespace, apiService.Name)
+	}
+	preview := &api.UpdatePreview{Changes: changes}
+	switch {
+	case len(changes) == 0:
+		preview.Impact = api.UpdateImpact_NO_CHANGE
+		preview.ImpactReason = "The update changes nothing."
+	case needsNewRayCluster(oldService.Spec.RayClusterSpec, updated.Spec.RayClusterSpec) && isUpgradeDisabled(updated):
+		preview.Impact = api.UpdateImpact_IN_PLACE
+		preview.ImpactReason = "The upgrade strategy of the service is None, so the operator does not roll out the cluster spec changes and only applies the serve config to the running cluster."
+	case needsNewRayCluster(oldService.Spec.RayClusterSpec, updated.Spec.RayClusterSpec):
+		preview.Impact = api.UpdateImpact_CLUSTER_REPLACEMENT
+		preview.ImpactReason = "The cluster spec changes beyond the scale of the worker groups and the appended worker groups, so the operator prepares a new cluster and switches the traffic to it once its serve applications are ready."
+	default:
+		preview.Impact = api.UpdateImpact_IN_PLACE
+		preview.ImpactReason = "The operator applies the serve config, the scale of the worker groups and the appended worker groups to the running cluster."
+	}
+	return preview, nil
+}
+
+// isUpgradeDisabled returns whether the upgrade strategy of a RayService keeps the operator from preparing a new
+// RayCluster when its cluster spec changes.
+func isUpgradeDisabled(rayService *rayv1api.RayService) bool {
+	strategy := rayService.Spec.UpgradeStrategy
+	return strategy != nil && strategy.Type != nil && *strategy.Type == rayv1api.None
+}
+
+// resourceChanges returns the changes of the labels, the annotations and the spec between the current and the updated
+// custom resource. The status and the other metadata are maintained by Kubernetes and by the operator.
+func resourceChanges(current metav1.Object, updated metav1.Object) ([]*api.FieldChange, error) {
+	currentFields, err := previewedFields(current)
+	if err != nil {
+		return nil, err
+	}
+	updatedFields, err := previewedFields(updated)
+	if err != nil {
+		return nil, err
+	}
+	changes := []*api.FieldChange{}
+	if err := diffFields("", currentFields, updatedFields, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// previewedFields returns the labels, the annotations and the spec of a custom resource as JSON values.
+func previewedFields(object metav1.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	annotations := map[string]interface{}{}
+	for key, value := range object.GetAnnotations() {
+		annotations[key] = value
+	}
+	for _, key := range previewIgnoredAnnotations {
+		delete(annotations, key)
+	}
+	metadata := map[string]interface{}{}
+	if len(object.GetLabels()) > 0 {
+		labels := map[string]interface{}{}
+		for key, value := range object.GetLabels() {
+			labels[key] = value
+		}
+		metadata["labels"] = labels
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+	previewed := map[string]interface{}{"metadata": metadata}
+	if spec, ok := fields["spec"]; ok {
+		previewed["spec"] = spec
+	}
+	return previewed, nil
+}
+
+// diffFields appends the changes between the current and the updated JSON value at path. Objects are compared key by
+// key and arrays item by item, the other values as a whole.
+func diffFields(path string, current interface{}, updated interface{}, changes *[]*api.FieldChange) error {
+	switch {
+	case current == nil && updated == nil:
+		return nil
+	case current == nil:
+		return appendChange(path, api.FieldChange_ADDED, nil, updated, changes)
+	case updated == nil:
+		return appendChange(path, api.FieldChange_REMOVED, current, nil, changes)
+	}
+	currentObject, currentIsObject := current.(map[string]interface{})
+	updatedObject, updatedIsObject := updated.(map[string]interface{})
+	if currentIsObject && updatedIsObject {
+		keys := make([]string, 0, len(currentObject)+len(updatedObject))
+		for key := range currentObject {
+			keys = append(keys, key)
+		}
+		for key := range updatedObject {
+			if _, ok := currentObject[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := diffFields(fieldPath(path, key), currentObject[key], updatedObject[key], changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	currentArray, currentIsArray := current.([]interface{})
+	updatedArray, updatedIsArray := updated.([]interface{})
+	if currentIsArray && updatedIsArray {
+		for i := 0; i < len(currentArray) || i < len(updatedArray); i++ {
+			var currentItem, updatedItem interface{}
+			if i < len(currentArray) {
+				currentItem = currentArray[i]
+			}
+			if i < len(updatedArray) {
+				updatedItem = updatedArray[i]
+			}
+			if err := diffFields(fmt.Sprintf("%s[%d]", path, i), currentItem, updatedItem, changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if reflect.DeepEqual(current, updated) {
+		return nil
+	}
+	return appendChange(path, api.FieldChange_CHANGED, current, updated, changes)
+}
+
+// fieldPath returns the path of the field key of the object at path, e.g. spec.rayVersion or
+// metadata.annotations["ray.io/compute-template"].
+func fieldPath(path string, key string) string {
+	if !identifierPattern.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func appendChange(path string, changeType api.FieldChange_Type, current interface{}, updated interface{}, changes *[]*api.FieldChange) error {
+	change := &api.FieldChange{Path: path, Type: changeType}
+	if current != nil {
+		value, err := json.Marshal(current)
+		if err != nil {
+			return err
+		}
+		change.CurrentValue = string(value)
+	}
+	if updated != nil {
+		value, err := json.Marshal(updated)
+		if err != nil {
+			return err
+		}
+		change.NewValue = string(value)
+	}
+	*changes = append(*changes, change)
+	return nil
+}
@@ -0,0 +1,192 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestPreviewClusterUpdate(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	created, err := resourceManager.CreateCluster(ctx, &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		User:      "user",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "template",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{GroupName: "small", ComputeTemplate: "template", Replicas: 1, MinReplicas: 1, MaxReplicas: 2},
+			},
+		},
+	}, false, "")
+	require.NoError(t, err)
+
+	preview, err := resourceManager.PreviewClusterUpdate(ctx, model.FromCrdToApiCluster(created.DeepCopy(), nil))
+	require.NoError(t, err)
+	assert.Empty(t, preview.Changes)
+	assert.Equal(t, api.UpdateImpact_NO_CHANGE, preview.Impact)
+
+	apiCluster := model.FromCrdToApiCluster(created.DeepCopy(), nil)
+	apiCluster.ClusterSpec.WorkerGroupSpec[0].Replicas = 2
+	apiCluster.ClusterSpec.WorkerGroupSpec[0].MaxReplicas = 4
+	preview, err = resourceManager.PreviewClusterUpdate(ctx, apiCluster)
+	require.NoError(t, err)
+	assert.Equal(t, api.UpdateImpact_IN_PLACE, preview.Impact)
+	require.Len(t, preview.Changes, 2)
+	assert.Equal(t, &api.FieldChange{Path: "spec.workerGroupSpecs[0].maxReplicas", Type: api.FieldChange_CHANGED, CurrentValue: "2", NewValue: "4"}, preview.Changes[0])
+	assert.Equal(t, &api.FieldChange{Path: "spec.workerGroupSpecs[0].replicas", Type: api.FieldChange_CHANGED, CurrentValue: "1", NewValue: "2"}, preview.Changes[1])
+
+	// The preview is not applied.
+	cluster, err := resourceManager.GetCluster(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), *cluster.Spec.WorkerGroupSpecs[0].Replicas)
+
+	// The changes rejected by the update are rejected by the preview.
+	apiCluster.Version = "2.10.0"
+	_, err = resourceManager.PreviewClusterUpdate(ctx, apiCluster)
+	require.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestPreviewRayServiceUpdate(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+
+	_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{
+		Name:      "template",
+		Namespace: "team-a",
+		Cpu:       1,
+		Memory:    2,
+	})
+	require.NoError(t, err)
+	newService := func(version string, serveConfig string, workerGroups ...string) *api.RayService {
+		service := &api.RayService{
+			Name:           "service",
+			Namespace:      "team-a",
+			User:           "user",
+			Version:        version,
+			ServeConfig_V2: serveConfig,
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{
+					ComputeTemplate: "template",
+					RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+				},
+			},
+		}
+		for _, group := range workerGroups {
+			service.ClusterSpec.WorkerGroupSpec = append(service.ClusterSpec.WorkerGroupSpec,
+				&api.WorkerGroupSpec{GroupName: group, ComputeTemplate: "template", Replicas: 1, MinReplicas: 1, MaxReplicas: 4})
+		}
+		return service
+	}
+	_, err = resourceManager.CreateService(ctx, newService("2.9.0", "applications: []", "small"), false, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		service        *api.RayService
+		expectedImpact api.UpdateImpact
+		expectedPath   string
+	}{
+		{
+			name:           "Same service",
+			service:        newService("2.9.0", "applications: []", "small"),
+			expectedImpact: api.UpdateImpact_NO_CHANGE,
+		},
+		{
+			name:           "New serve config",
+			service:        newService("2.9.0", "applications:\n- name: app", "small"),
+			expectedImpact: api.UpdateImpact_IN_PLACE,
+			expectedPath:   "spec.serveConfigV2",
+		},
+		{
+			name:           "Appended worker group",
+			service:        newService("2.9.0", "applications: []", "small", "large"),
+			expectedImpact: api.UpdateImpact_IN_PLACE,
+			expectedPath:   "spec.rayClusterConfig.workerGroupSpecs[1]",
+		},
+		{
+			name:           "New Ray version",
+			service:        newService("2.10.0", "applications: []", "small"),
+			expectedImpact: api.UpdateImpact_CLUSTER_REPLACEMENT,
+			expectedPath:   "spec.rayClusterConfig.headGroupSpec.template.spec.containers[0].image",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			preview, err := resourceManager.PreviewRayServiceUpdate(ctx, tc.service)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedImpact, preview.Impact)
+			paths := []string{}
+			for _, change := range preview.Changes {
+				paths = append(paths, change.Path)
+			}
+			if tc.expectedPath == "" {
+				assert.Empty(t, paths)
+			} else {
+				assert.Contains(t, paths, tc.expectedPath)
+			}
+		})
+	}
+
+	// The preview is not applied.
+	service, err := resourceManager.GetService(ctx, "service", "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "rayproject/ray:2.9.0", service.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[0].Image)
+
+	// The operator does not prepare a new cluster for a service whose upgrade strategy is None.
+	unregister := model.RegisterConversionHooks(model.ConversionHooks{
+		Name: "upgrade-strategy",
+		ToCrd: func(_ context.Context, _ proto.Message, object metav1.Object) error {
+			if service, ok := object.(*rayv1api.RayService); ok {
+				service.Spec.UpgradeStrategy = &rayv1api.RayServiceUpgradeStrategy{Type: ptr.To(rayv1api.None)}
+			}
+			return nil
+		},
+	})
+	defer unregister()
+	preview, err := resourceManager.PreviewRayServiceUpdate(ctx, newService("2.10.0", "applications: []", "small"))
+	require.NoError(t, err)
+	assert.Equal(t, api.UpdateImpact_IN_PLACE, preview.Impact)
+}
+
+func TestResourceChanges(t *testing.T) {
+	current := &metav1.ObjectMeta{
+		Labels:      map[string]string{"team": "a"},
+		Annotations: map[string]string{"ray.io/compute-template": "small", "ray.io/update-timestamp": "yesterday"},
+	}
+	updated := &metav1.ObjectMeta{
+		Annotations: map[string]string{"ray.io/compute-template": "large", "ray.io/update-timestamp": "today", "owner": "alice"},
+	}
+
+	changes, err := resourceChanges(current, updated)
+	require.NoError(t, err)
+	assert.Equal(t, []*api.FieldChange{
+		{Path: "metadata.annotations.owner", Type: api.FieldChange_ADDED, NewValue: `"alice"`},
+		{Path: `metadata.annotations["ray.io/compute-template"]`, Type: api.FieldChange_CHANGED, CurrentValue: `"small"`, NewValue: `"large"`},
+		{Path: "metadata.labels", Type: api.FieldChange_REMOVED, CurrentValue: `{"team":"a"}`},
+	}, changes)
+}
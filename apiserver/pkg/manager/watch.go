@@ -0,0 +1,145 @@
+package manager
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	klog "k8s.io/klog/v2"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// watchedObject is a Kubernetes resource which can be watched.
+type watchedObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// WatchCluster sends the RayCluster to the returned channel when the watch starts and every time it
+// changes. The channel is closed when ctx is done, the RayCluster is deleted or it can no longer be
+// read from Kubernetes.
+func (r *ResourceManager) WatchCluster(ctx context.Context, clusterName string, namespace string) (<-chan *rayv1api.RayCluster, error) {
+	client := r.getRayClusterClient(namespace)
+	return watchObject(ctx, clusterName,
+		func(ctx context.Context) (*rayv1api.RayCluster, error) {
+			return getClusterByName(ctx, client, clusterName)
+		}, client.Watch)
+}
+
+// WatchService sends the RayService to the returned channel when the watch starts and every time it
+// changes. The channel is closed when ctx is done, the RayService is deleted or it can no longer be
+// read from Kubernetes.
+func (r *ResourceManager) WatchService(ctx context.Context, serviceName string, namespace string) (<-chan *rayv1api.RayService, error) {
+	client := r.getRayServiceClient(namespace)
+	return watchObject(ctx, serviceName,
+		func(ctx context.Context) (*rayv1api.RayService, error) {
+			return getServiceByName(ctx, client, serviceName)
+		}, client.Watch)
+}
+
+// watchObject sends the object returned by get, then the object of every change reported by a
+// Kubernetes watch on it. Watches closed by Kubernetes, e.g. after their timeout or because the
+// resource version expired, are restarted after reading the object again, so no change is missed.
+// An error is only returned if the object can't be read when the watch starts.
+func watchObject[T watchedObject](
+	ctx context.Context,
+	name string,
+	get func(context.Context) (T, error),
+	watchFunc func(context.Context, metav1.ListOptions) (watch.Interface, error),
+) (<-chan T, error) {
+	current, err := get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(chan T)
+	go func() {
+		defer close(objects)
+		if !sendObject(ctx, objects, current) {
+			return
+		}
+		for {
+			w, err := watchFunc(ctx, metav1.ListOptions{
+				FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+				ResourceVersion: current.GetResourceVersion(),
+			})
+			if err != nil {
+				klog.FromContext(ctx).Error(err, "Failed to watch the resource", "resource", klog.KRef(current.GetNamespace(), name))
+				return
+			}
+			var deleted, done bool
+			current, deleted, done = consumeWatch(ctx, w, name, current, objects)
+			w.Stop()
+			if deleted || done {
+				return
+			}
+
+			// The watch was closed, read the object again to get the changes made since then.
+			latest, err := get(ctx)
+			if err != nil {
+				if !util.IsUserErrorCodeMatch(err, codes.NotFound) {
+					klog.FromContext(ctx).Error(err, "Failed to get the resource after its watch was closed", "resource", klog.KRef(current.GetNamespace(), name))
+				}
+				return
+			}
+			if latest.GetResourceVersion() != current.GetResourceVersion() {
+				if !sendObject(ctx, objects, latest) {
+					return
+				}
+			}
+			current = latest
+		}
+	}()
+	return objects, nil
+}
+
+// consumeWatch sends the objects of the watch events until the watch is closed. It returns the last
+// object sent, whether the object was deleted and whether ctx is done.
+func consumeWatch[T watchedObject](ctx context.Context, w watch.Interface, name string, current T, objects chan<- T) (T, bool, bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return current, false, true
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return current, false, false
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				object, ok := event.Object.(T)
+				if !ok || object.GetName() != name {
+					continue
+				}
+				if !sendObject(ctx, objects, object) {
+					return current, false, true
+				}
+				current = object
+			case watch.Deleted:
+				if object, ok := event.Object.(T); ok && object.GetName() == name {
+					return current, true, false
+				}
+			case watch.Error:
+				// The resource version is too old or the watch failed, it is restarted by the caller.
+				klog.V(2).Infof("Watch of %s/%s failed: %v", current.GetNamespace(), name, errors.FromObject(event.Object))
+				return current, false, false
+			}
+		}
+	}
+}
+
+// sendObject sends the object unless ctx is done first, and returns whether it was sent.
+func sendObject[T any](ctx context.Context, objects chan<- T, object T) bool {
+	select {
+	case objects <- object:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
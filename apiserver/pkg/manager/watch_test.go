@@ -0,0 +1,67 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestWatchObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cluster := func(name string, resourceVersion string) *rayv1api.RayCluster {
+		return &rayv1api.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", ResourceVersion: resourceVersion}}
+	}
+	gets := []*rayv1api.RayCluster{cluster("cluster", "1"), cluster("cluster", "4")}
+	get := func(context.Context) (*rayv1api.RayCluster, error) {
+		if len(gets) == 0 {
+			return nil, util.NewNotFoundError(nil, "Cluster cluster not found")
+		}
+		next := gets[0]
+		gets = gets[1:]
+		return next, nil
+	}
+	first, second := watch.NewFake(), watch.NewFake()
+	watchers := []*watch.FakeWatcher{first, second}
+	var watchedVersions []string
+	watchFunc := func(_ context.Context, options metav1.ListOptions) (watch.Interface, error) {
+		assert.Equal(t, "metadata.name=cluster", options.FieldSelector)
+		watchedVersions = append(watchedVersions, options.ResourceVersion)
+		w := watchers[0]
+		watchers = watchers[1:]
+		return w, nil
+	}
+
+	clusters, err := watchObject(ctx, "cluster", get, watchFunc)
+	require.NoError(t, err)
+	assert.Equal(t, "1", (<-clusters).ResourceVersion)
+
+	first.Modify(cluster("other", "2"))
+	first.Modify(cluster("cluster", "3"))
+	assert.Equal(t, "3", (<-clusters).ResourceVersion)
+
+	// A closed watch is restarted after reading the cluster again.
+	first.Stop()
+	assert.Equal(t, "4", (<-clusters).ResourceVersion)
+
+	second.Delete(cluster("cluster", "5"))
+	_, ok := <-clusters
+	assert.False(t, ok, "The channel is closed when the cluster is deleted")
+	assert.Equal(t, []string{"1", "4"}, watchedVersions)
+}
+
+func TestWatchObjectNotFound(t *testing.T) {
+	resourceManager := NewResourceManager(NewFakeClientManager(context.Background(), 0))
+
+	_, err := resourceManager.WatchService(context.Background(), "missing", "team-a")
+	require.Error(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
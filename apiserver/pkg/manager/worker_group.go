@@ -0,0 +1,348 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// defaultMaxUnavailable is the number of Pods of a worker group restarted at a time by default.
+const defaultMaxUnavailable = 1
+
+// UpdateWorkerGroup patches the image, the compute template and the replicas of a worker group. The operator doesn't
+// roll a new Pod template out to the running Pods, so a change of the image or the compute template also restarts
+// the worker group. The group stays at the revision of its compute template unless a revision is requested, a new
+// compute template is used at its latest revision.
+func (r *ResourceManager) UpdateWorkerGroup(ctx context.Context, request *api.UpdateWorkerGroupRequest) (*rayv1api.RayCluster, error) {
+	client := r.getRayClusterClient(request.Namespace)
+	cluster, err := getClusterByName(ctx, client, request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+	index, err := workerGroupIndex(cluster, request.GroupName)
+	if err != nil {
+		return nil, err
+	}
+	group := cluster.Spec.WorkerGroupSpecs[index]
+
+	groupPath := fmt.Sprintf("/spec/workerGroupSpecs/%d", index)
+	patch := []map[string]interface{}{
+		// Fail instead of updating another group if the worker groups were changed concurrently.
+		{"op": "test", "path": groupPath + "/groupName", "value": request.GroupName},
+	}
+	if request.Replicas > 0 {
+		if (group.MinReplicas != nil && request.Replicas < *group.MinReplicas) || (group.MaxReplicas != nil && request.Replicas > *group.MaxReplicas) {
+			return nil, util.NewInvalidInputError("Replicas %d of worker group %s is not between its MinReplicas and MaxReplicas. Please specify a valid value.", request.Replicas, request.GroupName)
+		}
+		patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/replicas", "value": request.Replicas})
+	}
+	if request.Image != "" || request.ComputeTemplate != "" || request.ComputeTemplateRevision != 0 {
+		// The converter strips the default annotations and labels of the template in place.
+		spec := model.PopulateWorkerNodeSpec([]rayv1api.WorkerGroupSpec{*group.DeepCopy()})[0]
+		if request.Image != "" {
+			spec.Image = request.Image
+		}
+		if request.ComputeTemplate != "" && request.ComputeTemplate != spec.ComputeTemplate {
+			spec.ComputeTemplate = request.ComputeTemplate
+			spec.ComputeTemplateRevision = 0
+		}
+		if request.ComputeTemplateRevision != 0 {
+			spec.ComputeTemplateRevision = request.ComputeTemplateRevision
+		}
+		// The rebuilt Pod template is checked like the groups of a new cluster, so that the allowlists can not be
+		// bypassed by updating a group.
+		cfg := config.Get()
+		if err := applyImageDefaults(cfg, cluster.Spec.RayVersion, &spec.Image); err != nil {
+			return nil, util.NewInvalidFieldError("image", "Worker group %s can not be updated: %v. Please specify an image of an allowed repository.", request.GroupName, err)
+		}
+		if err := checkSchedulerNameAllowed(cfg, spec.SchedulerName); err != nil {
+			return nil, util.NewInvalidInputError("Worker group %s can not be updated: %v.", request.GroupName, err)
+		}
+		if err := checkSidecarImagesAllowed(cfg, spec.SidecarContainers); err != nil {
+			return nil, util.NewInvalidInputError("Worker group %s can not be updated: %v.", request.GroupName, err)
+		}
+		configMap, err := r.GetComputeTemplateRevision(ctx, spec.ComputeTemplate, request.Namespace, spec.ComputeTemplateRevision)
+		if err != nil {
+			return nil, util.Wrap(err, "Get compute template failure")
+		}
+		template, err := util.NewWorkerPodTemplate(cluster.Spec.RayVersion, spec, model.FromKubeToAPIComputeTemplate(configMap))
+		if err != nil {
+			return nil, util.NewInvalidInputError("Failed to build the Pod template of worker group %s: %v", request.GroupName, err)
+		}
+		template.Annotations[util.RayClusterRestartedAtAnnotationKey] = r.clientManager.Time().Now().UTC().Format(time.RFC3339)
+		template.Annotations[util.RayClusterMaxUnavailableAnnotationKey] = strconv.Itoa(maxUnavailableOrDefault(request.MaxUnavailable))
+		patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/template", "value": template})
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the patch of worker group %s of cluster %s", request.GroupName, request.Name)
+	}
+	if data, err = r.admitPatch(ctx, cluster, data); err != nil {
+		return nil, err
+	}
+
+	newCluster, err := client.Patch(ctx, request.Name, types.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to update worker group %s of cluster (%s/%s)", request.GroupName, request.Namespace, request.Name)
+	}
+	return newCluster, nil
+}
+
+// RestartWorkerGroup sets the restart time of a worker group, so that its Pods created before are restarted by the
+// rolling restart, and returns the progress of the restart.
+func (r *ResourceManager) RestartWorkerGroup(ctx context.Context, request *api.RestartWorkerGroupRequest) (*api.WorkerGroupRestart, error) {
+	client := r.getRayClusterClient(request.Namespace)
+	cluster, err := getClusterByName(ctx, client, request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+	index, err := workerGroupIndex(cluster, request.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	groupPath := fmt.Sprintf("/spec/workerGroupSpecs/%d", index)
+	restartAnnotations := map[string]string{
+		util.RayClusterRestartedAtAnnotationKey:    r.clientManager.Time().Now().UTC().Format(time.RFC3339),
+		util.RayClusterMaxUnavailableAnnotationKey: strconv.Itoa(maxUnavailableOrDefault(request.MaxUnavailable)),
+	}
+	patch := []map[string]interface{}{
+		{"op": "test", "path": groupPath + "/groupName", "value": request.GroupName},
+	}
+	if cluster.Spec.WorkerGroupSpecs[index].Template.Annotations == nil {
+		patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/template/metadata/annotations", "value": restartAnnotations})
+	} else {
+		for key, value := range restartAnnotations {
+			// The slash of the annotation key is escaped in the JSON pointer.
+			path := groupPath + "/template/metadata/annotations/" + strings.ReplaceAll(key, "/", "~1")
+			patch = append(patch, map[string]interface{}{"op": "add", "path": path, "value": value})
+		}
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the restart patch of cluster %s", request.Name)
+	}
+
+	newCluster, err := client.Patch(ctx, request.Name, types.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to restart worker group %s of cluster (%s/%s)", request.GroupName, request.Namespace, request.Name)
+	}
+	restart, err := r.getRollingRestart(ctx, newCluster, index)
+	if err != nil {
+		return nil, err
+	}
+	return restart.toAPI(newCluster, request.GroupName), nil
+}
+
+// GetWorkerGroupRestart returns the progress of the last rolling restart of a worker group.
+func (r *ResourceManager) GetWorkerGroupRestart(ctx context.Context, clusterName string, namespace string, groupName string) (*api.WorkerGroupRestart, error) {
+	cluster, err := getClusterByName(ctx, r.getRayClusterClient(namespace), clusterName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+	index, err := workerGroupIndex(cluster, groupName)
+	if err != nil {
+		return nil, err
+	}
+	restart, err := r.getRollingRestart(ctx, cluster, index)
+	if err != nil {
+		return nil, err
+	}
+	return restart.toAPI(cluster, groupName), nil
+}
+
+// StartRollingRestarts periodically restarts the outdated Pods of the restarted worker groups until the context is
+// done.
+func (r *ResourceManager) StartRollingRestarts(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.SyncRollingRestarts(ctx)
+			}
+		}
+	}()
+}
+
+// SyncRollingRestarts deletes the outdated Pods of every restarted worker group, as long as no more than its max
+// unavailable Pods are unavailable, and returns the names of the deleted Pods. The operator recreates the deleted
+// Pods from the current template. Errors are logged, so that a worker group which can't be restarted does not block
+// the others.
+func (r *ResourceManager) SyncRollingRestarts(ctx context.Context) []string {
+	clusters, _, err := r.ListAllClusters(ctx, "", 0, "", ResourceSelector{})
+	if err != nil {
+		klog.Errorf("Failed to list the clusters to restart: %v", err)
+		return nil
+	}
+
+	deleted := []string{}
+	for _, cluster := range clusters {
+		if cluster.DeletionTimestamp != nil {
+			continue
+		}
+		for index, group := range cluster.Spec.WorkerGroupSpecs {
+			if group.Template.Annotations[util.RayClusterRestartedAtAnnotationKey] == "" {
+				continue
+			}
+			restart, err := r.getRollingRestart(ctx, cluster, index)
+			if err != nil {
+				klog.Errorf("Failed to get the restart of worker group %s of cluster %s/%s: %v", group.GroupName, cluster.Namespace, cluster.Name, err)
+				continue
+			}
+			podClient := r.clientManager.KubernetesClient().PodClient(cluster.Namespace)
+			for _, pod := range restart.podsToDelete() {
+				if err := podClient.Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+					klog.Errorf("Failed to restart Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+					continue
+				}
+				klog.Infof("Restarted Pod %s/%s of worker group %s of cluster %s", pod.Namespace, pod.Name, group.GroupName, cluster.Name)
+				deleted = append(deleted, pod.Name)
+			}
+		}
+	}
+	return deleted
+}
+
+// rollingRestart is the progress of the rolling restart of a worker group, computed from its Pods.
+type rollingRestart struct {
+	restartedAt    string
+	maxUnavailable int
+	replicas       int
+	// outdated are the Pods created before the restart which are not being deleted, the oldest first.
+	outdated    []corev1.Pod
+	updated     int
+	ready       int
+	unavailable int
+}
+
+func (r *ResourceManager) getRollingRestart(ctx context.Context, cluster *rayv1api.RayCluster, index int) (*rollingRestart, error) {
+	group := cluster.Spec.WorkerGroupSpecs[index]
+	pods, err := r.clientManager.KubernetesClient().PodClient(cluster.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			utils.RayClusterLabelKey:   cluster.Name,
+			utils.RayNodeTypeLabelKey:  string(rayv1api.WorkerNode),
+			utils.RayNodeGroupLabelKey: group.GroupName,
+		}).String(),
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the Pods of worker group %s of cluster %s", group.GroupName, cluster.Name)
+	}
+	return newRollingRestart(group, pods.Items), nil
+}
+
+func newRollingRestart(group rayv1api.WorkerGroupSpec, pods []corev1.Pod) *rollingRestart {
+	restart := &rollingRestart{
+		restartedAt:    group.Template.Annotations[util.RayClusterRestartedAtAnnotationKey],
+		maxUnavailable: defaultMaxUnavailable,
+	}
+	if maxUnavailable, err := strconv.Atoi(group.Template.Annotations[util.RayClusterMaxUnavailableAnnotationKey]); err == nil && maxUnavailable > 0 {
+		restart.maxUnavailable = maxUnavailable
+	}
+	if group.Replicas != nil {
+		restart.replicas = int(*group.Replicas)
+	}
+
+	live := 0
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			restart.unavailable++
+			continue
+		}
+		live++
+		ready := utils.IsRunningAndReady(&pod)
+		if !ready {
+			restart.unavailable++
+		}
+		if pod.Annotations[util.RayClusterRestartedAtAnnotationKey] != restart.restartedAt {
+			restart.outdated = append(restart.outdated, pod)
+			continue
+		}
+		restart.updated++
+		if ready {
+			restart.ready++
+		}
+	}
+	// The Pods which the operator has not recreated yet are unavailable too.
+	if live < restart.replicas {
+		restart.unavailable += restart.replicas - live
+	}
+	sort.SliceStable(restart.outdated, func(i, j int) bool {
+		return restart.outdated[i].CreationTimestamp.Before(&restart.outdated[j].CreationTimestamp)
+	})
+	return restart
+}
+
+// podsToDelete returns the outdated Pods which can be deleted now. The outdated Pods which are not ready, e.g.
+// because of a bad image, are already unavailable, so they are all deleted. The ready ones are deleted as long as no
+// more than the max unavailable Pods are unavailable.
+func (r *rollingRestart) podsToDelete() []corev1.Pod {
+	pods := []corev1.Pod{}
+	budget := r.maxUnavailable - r.unavailable
+	for _, pod := range r.outdated {
+		if !utils.IsRunningAndReady(&pod) {
+			pods = append(pods, pod)
+		} else if budget > 0 {
+			pods = append(pods, pod)
+			budget--
+		}
+	}
+	return pods
+}
+
+func (r *rollingRestart) toAPI(cluster *rayv1api.RayCluster, groupName string) *api.WorkerGroupRestart {
+	restart := &api.WorkerGroupRestart{
+		Name:           cluster.Name,
+		Namespace:      cluster.Namespace,
+		GroupName:      groupName,
+		MaxUnavailable: int32(r.maxUnavailable),
+		Replicas:       int32(r.replicas),
+		UpdatedPods:    int32(r.updated),
+		OutdatedPods:   int32(len(r.outdated)),
+		ReadyPods:      int32(r.ready),
+		Done:           len(r.outdated) == 0 && r.ready >= r.replicas,
+	}
+	if restartedAt, err := time.Parse(time.RFC3339, r.restartedAt); err == nil {
+		restart.RestartedAt = timestamppb.New(restartedAt)
+	}
+	return restart
+}
+
+// workerGroupIndex returns the index of a worker group in the spec of a cluster.
+func workerGroupIndex(cluster *rayv1api.RayCluster, groupName string) (int, error) {
+	for index, spec := range cluster.Spec.WorkerGroupSpecs {
+		if spec.GroupName == groupName {
+			return index, nil
+		}
+	}
+	return -1, util.NewNotFoundError(fmt.Errorf("worker group %s not found", groupName), "Worker group %s not found in cluster %s", groupName, cluster.Name)
+}
+
+func maxUnavailableOrDefault(maxUnavailable int32) int {
+	if maxUnavailable > 0 {
+		return int(maxUnavailable)
+	}
+	return defaultMaxUnavailable
+}
@@ -0,0 +1,406 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// drainingLabelKey marks the worker Pods picked by the drain of their worker group, which are removed once no
+	// actor and no running task is left on them.
+	drainingLabelKey = "ray.io/draining"
+	// The drained Pods have the lowest deletion cost, so that they are the first to go if the group is scaled down
+	// by other means meanwhile.
+	podDeletionCostAnnotationKey = "controller.kubernetes.io/pod-deletion-cost"
+	drainedPodDeletionCost       = "-2147483648"
+)
+
+// workerGroupDrain is the drain of a worker group, stored in an annotation of its Pod template.
+type workerGroupDrain struct {
+	Replicas       int32      `json:"replicas"`
+	StartedAt      time.Time  `json:"startedAt"`
+	TimeoutSeconds int32      `json:"timeoutSeconds"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+}
+
+func (d *workerGroupDrain) deadline() time.Time {
+	return d.StartedAt.Add(time.Duration(d.TimeoutSeconds) * time.Second)
+}
+
+// getWorkerGroupDrain returns the last drain of a worker group, or nil if it was never drained.
+func getWorkerGroupDrain(group rayv1api.WorkerGroupSpec) (*workerGroupDrain, error) {
+	value, ok := group.Template.Annotations[util.RayWorkerGroupDrainAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	drain := &workerGroupDrain{}
+	if err := json.Unmarshal([]byte(value), drain); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the drain of worker group %s", group.GroupName)
+	}
+	return drain, nil
+}
+
+// DrainWorkerGroup picks the workers removed by the drain of a worker group, preferring the ones with the fewest
+// actors and running tasks and the youngest, marks them, and records the drain in the worker group. The workers are
+// removed by SyncWorkerGroupDrains.
+func (r *ResourceManager) DrainWorkerGroup(ctx context.Context, request *api.DrainWorkerGroupRequest) (*api.WorkerGroupDrain, error) {
+	client := r.getRayClusterClient(request.Namespace)
+	cluster, err := getClusterByName(ctx, client, request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+	index, err := workerGroupIndex(cluster, request.GroupName)
+	if err != nil {
+		return nil, err
+	}
+	group := cluster.Spec.WorkerGroupSpecs[index]
+	if drain, err := getWorkerGroupDrain(group); err != nil {
+		return nil, err
+	} else if drain != nil && drain.FinishedAt == nil {
+		return nil, util.NewFailedPreconditionError("Worker group %s of cluster %s is already being drained.", request.GroupName, request.Name)
+	}
+	if group.NumOfHosts > 1 {
+		return nil, util.NewFailedPreconditionError("Worker group %s of cluster %s has %d hosts per replica, which can not be drained.", request.GroupName, request.Name, group.NumOfHosts)
+	}
+	replicas := int32(0)
+	if group.Replicas != nil {
+		replicas = *group.Replicas
+	}
+	if request.Replicas >= replicas {
+		return nil, util.NewInvalidInputError("Worker group %s has %d replicas, a drain can only lower them.", request.GroupName, replicas)
+	}
+
+	drain := &workerGroupDrain{
+		Replicas:       request.Replicas,
+		StartedAt:      r.clientManager.Time().Now().UTC().Truncate(time.Second),
+		TimeoutSeconds: request.TimeoutSeconds,
+	}
+	if drain.TimeoutSeconds == 0 {
+		drain.TimeoutSeconds = utils.DefaultRayClusterDrainTimeoutSeconds
+	}
+	pods, err := r.listWorkerGroupPods(ctx, cluster, request.GroupName, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The workers are picked without their work if the dashboard can't be reached, they are still only removed once
+	// their actors and running tasks are gone.
+	work, err := r.workByNodeIP(ctx, cluster)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the actors and tasks of the cluster, picking the youngest workers to drain", "cluster", klog.KObj(cluster))
+	}
+	podClient := r.clientManager.KubernetesClient().PodClient(request.Namespace)
+	// The Pods are marked first, so that the drain never lowers the replicas without them.
+	patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:"true"},"annotations":{%q:%q}}}`, drainingLabelKey, podDeletionCostAnnotationKey, drainedPodDeletionCost))
+	for _, pod := range podsToDrain(pods, work, int(replicas-request.Replicas)) {
+		if _, err := podClient.Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil && !errors.IsNotFound(err) {
+			return nil, util.NewInternalServerError(err, "Failed to mark Pod %s to be drained", pod.Name)
+		}
+	}
+
+	newCluster, err := r.patchWorkerGroupDrain(ctx, cluster, index, drain, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.workerGroupDrainToAPI(ctx, newCluster, index, drain)
+}
+
+// GetWorkerGroupDrain returns the progress of the last drain of a worker group.
+func (r *ResourceManager) GetWorkerGroupDrain(ctx context.Context, clusterName string, namespace string, groupName string) (*api.WorkerGroupDrain, error) {
+	cluster, err := getClusterByName(ctx, r.getRayClusterClient(namespace), clusterName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+	index, err := workerGroupIndex(cluster, groupName)
+	if err != nil {
+		return nil, err
+	}
+	drain, err := getWorkerGroupDrain(cluster.Spec.WorkerGroupSpecs[index])
+	if err != nil {
+		return nil, err
+	}
+	if drain == nil {
+		return nil, util.NewNotFoundError(fmt.Errorf("worker group %s was never drained", groupName), "Worker group %s of cluster %s was never drained", groupName, clusterName)
+	}
+	return r.workerGroupDrainToAPI(ctx, cluster, index, drain)
+}
+
+// StartWorkerGroupDrains periodically removes the drained workers until the context is done.
+func (r *ResourceManager) StartWorkerGroupDrains(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.SyncWorkerGroupDrains(ctx)
+			}
+		}
+	}()
+}
+
+// SyncWorkerGroupDrains removes the drained workers which have no actor and no running task left, or all of them once
+// the drain timeout expired, and returns the names of their Pods. The replicas of the worker group are lowered along,
+// and the Pods are deleted by the operator as workers to delete. Errors are logged, so that a worker group which can't
+// be drained does not block the others.
+func (r *ResourceManager) SyncWorkerGroupDrains(ctx context.Context) []string {
+	clusters, _, err := r.ListAllClusters(ctx, "", 0, "", ResourceSelector{})
+	if err != nil {
+		klog.Errorf("Failed to list the clusters to drain: %v", err)
+		return nil
+	}
+
+	removed := []string{}
+	for _, cluster := range clusters {
+		if cluster.DeletionTimestamp != nil {
+			continue
+		}
+		for index, group := range cluster.Spec.WorkerGroupSpecs {
+			drain, err := getWorkerGroupDrain(group)
+			if err != nil {
+				klog.Errorf("Failed to get the drain of worker group %s of cluster %s/%s: %v", group.GroupName, cluster.Namespace, cluster.Name, err)
+				continue
+			}
+			if drain == nil || drain.FinishedAt != nil {
+				continue
+			}
+			names, err := r.syncWorkerGroupDrain(ctx, cluster, index, drain)
+			if err != nil {
+				klog.Errorf("Failed to drain worker group %s of cluster %s/%s: %v", group.GroupName, cluster.Namespace, cluster.Name, err)
+				continue
+			}
+			removed = append(removed, names...)
+		}
+	}
+	return removed
+}
+
+func (r *ResourceManager) syncWorkerGroupDrain(ctx context.Context, cluster *rayv1api.RayCluster, index int, drain *workerGroupDrain) ([]string, error) {
+	group := cluster.Spec.WorkerGroupSpecs[index]
+	pods, err := r.listWorkerGroupPods(ctx, cluster, group.GroupName, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The workers to delete which the operator has not deleted yet are not removed again.
+	podNames := map[string]bool{}
+	for _, pod := range pods {
+		podNames[pod.Name] = true
+	}
+	workersToDelete := []string{}
+	for _, name := range group.ScaleStrategy.WorkersToDelete {
+		if podNames[name] {
+			workersToDelete = append(workersToDelete, name)
+		}
+	}
+	draining := []corev1.Pod{}
+	for _, pod := range pods {
+		if pod.Labels[drainingLabelKey] == "true" && !slices.Contains(workersToDelete, pod.Name) {
+			draining = append(draining, pod)
+		}
+	}
+
+	now := r.clientManager.Time().Now()
+	expired := !now.Before(drain.deadline())
+	var work map[string]int
+	if len(draining) > 0 && !expired {
+		// The workers are kept until the deadline if their actors and tasks can't be listed.
+		if work, err = r.workByNodeIP(ctx, cluster); err != nil {
+			return nil, err
+		}
+	}
+	idle := []string{}
+	for _, pod := range draining {
+		if expired || work[pod.Status.PodIP] == 0 {
+			idle = append(idle, pod.Name)
+		}
+	}
+	if len(idle) == 0 && len(draining) > 0 {
+		return nil, nil
+	}
+	if len(idle) == len(draining) {
+		finishedAt := now.UTC().Truncate(time.Second)
+		drain.FinishedAt = &finishedAt
+	}
+	if _, err := r.patchWorkerGroupDrain(ctx, cluster, index, drain, idle, append(workersToDelete, idle...)); err != nil {
+		return nil, err
+	}
+	for _, name := range idle {
+		klog.Infof("Removing drained Pod %s/%s of worker group %s of cluster %s", cluster.Namespace, name, group.GroupName, cluster.Name)
+	}
+	return idle, nil
+}
+
+// patchWorkerGroupDrain records the drain in the annotation of a worker group and removes the given workers, which
+// are added to the workers to delete. The replicas are lowered by the removed workers, and to the target replicas
+// once the drain is finished, e.g. if some replicas had no Pod yet.
+func (r *ResourceManager) patchWorkerGroupDrain(ctx context.Context, cluster *rayv1api.RayCluster, index int, drain *workerGroupDrain, removed []string, workersToDelete []string) (*rayv1api.RayCluster, error) {
+	group := cluster.Spec.WorkerGroupSpecs[index]
+	value, err := json.Marshal(drain)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the drain of worker group %s", group.GroupName)
+	}
+	groupPath := fmt.Sprintf("/spec/workerGroupSpecs/%d", index)
+	patch := []map[string]interface{}{
+		{"op": "test", "path": groupPath + "/groupName", "value": group.GroupName},
+	}
+	if group.Template.Annotations == nil {
+		patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/template/metadata/annotations", "value": map[string]string{util.RayWorkerGroupDrainAnnotationKey: string(value)}})
+	} else {
+		// The slash of the annotation key is escaped in the JSON pointer.
+		path := groupPath + "/template/metadata/annotations/" + strings.ReplaceAll(util.RayWorkerGroupDrainAnnotationKey, "/", "~1")
+		patch = append(patch, map[string]interface{}{"op": "add", "path": path, "value": string(value)})
+	}
+
+	if len(removed) > 0 || drain.FinishedAt != nil {
+		replicas := drain.Replicas
+		if drain.FinishedAt == nil && group.Replicas != nil {
+			replicas = max(drain.Replicas, *group.Replicas-int32(len(removed)))
+		}
+		patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/replicas", "value": replicas})
+		if group.MinReplicas != nil && *group.MinReplicas > replicas {
+			patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/minReplicas", "value": replicas})
+		}
+		if len(removed) > 0 {
+			patch = append(patch, map[string]interface{}{"op": "add", "path": groupPath + "/scaleStrategy", "value": map[string]interface{}{"workersToDelete": workersToDelete}})
+		}
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the drain patch of cluster %s", cluster.Name)
+	}
+
+	newCluster, err := r.getRayClusterClient(cluster.Namespace).Patch(ctx, cluster.Name, types.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to drain worker group %s of cluster (%s/%s)", group.GroupName, cluster.Namespace, cluster.Name)
+	}
+	return newCluster, nil
+}
+
+func (r *ResourceManager) workerGroupDrainToAPI(ctx context.Context, cluster *rayv1api.RayCluster, index int, drain *workerGroupDrain) (*api.WorkerGroupDrain, error) {
+	group := cluster.Spec.WorkerGroupSpecs[index]
+	draining, err := r.listWorkerGroupPods(ctx, cluster, group.GroupName, map[string]string{drainingLabelKey: "true"})
+	if err != nil {
+		return nil, err
+	}
+	result := &api.WorkerGroupDrain{
+		Name:           cluster.Name,
+		Namespace:      cluster.Namespace,
+		GroupName:      group.GroupName,
+		StartedAt:      timestamppb.New(drain.StartedAt),
+		Deadline:       timestamppb.New(drain.deadline()),
+		TargetReplicas: drain.Replicas,
+		DrainingPods:   []string{},
+		Done:           drain.FinishedAt != nil,
+	}
+	if group.Replicas != nil {
+		result.Replicas = *group.Replicas
+	}
+	for _, pod := range draining {
+		result.DrainingPods = append(result.DrainingPods, pod.Name)
+	}
+	if drain.FinishedAt != nil {
+		result.FinishedAt = timestamppb.New(*drain.FinishedAt)
+	}
+	return result, nil
+}
+
+// listWorkerGroupPods returns the Pods of a worker group with the given labels which are not being deleted.
+func (r *ResourceManager) listWorkerGroupPods(ctx context.Context, cluster *rayv1api.RayCluster, groupName string, extraLabels map[string]string) ([]corev1.Pod, error) {
+	selector := map[string]string{
+		utils.RayClusterLabelKey:   cluster.Name,
+		utils.RayNodeTypeLabelKey:  string(rayv1api.WorkerNode),
+		utils.RayNodeGroupLabelKey: groupName,
+	}
+	for key, value := range extraLabels {
+		selector[key] = value
+	}
+	pods, err := r.clientManager.KubernetesClient().PodClient(cluster.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the Pods of worker group %s of cluster %s", groupName, cluster.Name)
+	}
+	live := []corev1.Pod{}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil {
+			live = append(live, pod)
+		}
+	}
+	sort.Slice(live, func(i, j int) bool { return live[i].Name < live[j].Name })
+	return live, nil
+}
+
+// workByNodeIP returns the number of live actors and running tasks on each alive Ray node of a cluster, by the IP of
+// the node, which is the IP of its Pod.
+func (r *ResourceManager) workByNodeIP(ctx context.Context, cluster *rayv1api.RayCluster) (map[string]int, error) {
+	dashboardClient, err := r.clusterDashboardClient(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := dashboardClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	actors, err := dashboardClient.ListActors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := dashboardClient.ListTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodeIPs := map[string]string{}
+	result := map[string]int{}
+	for _, node := range nodes {
+		if node.State == "ALIVE" {
+			nodeIPs[node.NodeID] = node.NodeIP
+			result[node.NodeIP] = 0
+		}
+	}
+	for _, actor := range actors {
+		if actor.State == "DEAD" {
+			continue
+		}
+		if ip, ok := nodeIPs[actor.NodeID]; ok {
+			result[ip]++
+		}
+	}
+	for _, task := range tasks {
+		if ip, ok := nodeIPs[task.NodeID]; ok {
+			result[ip]++
+		}
+	}
+	return result, nil
+}
+
+// podsToDrain picks count Pods to drain, the ones with the fewest actors and running tasks first and the youngest of
+// them first, since they have the least work to move.
+func podsToDrain(pods []corev1.Pod, work map[string]int, count int) []corev1.Pod {
+	pods = append([]corev1.Pod{}, pods...)
+	sort.SliceStable(pods, func(i, j int) bool {
+		if left, right := work[pods[i].Status.PodIP], work[pods[j].Status.PodIP]; left != right {
+			return left < right
+		}
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+	return pods[:min(count, len(pods))]
+}
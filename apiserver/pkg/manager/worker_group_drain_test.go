@@ -0,0 +1,140 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestDrainWorkerGroup(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	resourceManager.dashboardClientFunc = func() utils.RayDashboardClientInterface { return dashboardClient }
+	setupWorkerGroupCluster(ctx, t, resourceManager)
+	podClient := resourceManager.clientManager.KubernetesClient().PodClient("team-a")
+	now := time.Now()
+	for i, name := range []string{"worker-1", "worker-2", "worker-3"} {
+		createWorkerPod(ctx, t, resourceManager, name, "", true, now.Add(time.Duration(i)*time.Hour))
+		pod, err := podClient.Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		pod.Status.PodIP = "10.0.0." + name[len(name)-1:]
+		_, err = podClient.UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+	nodes := []utils.RayNodeState{
+		{NodeID: "node-1", NodeIP: "10.0.0.1", State: "ALIVE"},
+		{NodeID: "node-2", NodeIP: "10.0.0.2", State: "ALIVE"},
+		{NodeID: "node-3", NodeIP: "10.0.0.3", State: "ALIVE"},
+	}
+	dashboardClient.SetStateAPIResults(nodes, []utils.RayActorState{
+		{ActorID: "a", NodeID: "node-1", State: "ALIVE"},
+		{ActorID: "b", NodeID: "node-2", State: "ALIVE"},
+		{ActorID: "c", NodeID: "node-2", State: "ALIVE"},
+		{ActorID: "d", NodeID: "node-3", State: "DEAD"},
+	}, nil)
+
+	_, err := resourceManager.DrainWorkerGroup(ctx, &api.DrainWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", Replicas: 3})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	_, err = resourceManager.GetWorkerGroupDrain(ctx, "cluster", "team-a", "workers")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	// The workers with the fewest actors and running tasks are drained.
+	drain, err := resourceManager.DrainWorkerGroup(ctx, &api.DrainWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", Replicas: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), drain.TargetReplicas)
+	assert.Equal(t, int32(3), drain.Replicas)
+	assert.Equal(t, []string{"worker-1", "worker-3"}, drain.DrainingPods)
+	assert.Equal(t, int64(utils.DefaultRayClusterDrainTimeoutSeconds), drain.Deadline.Seconds-drain.StartedAt.Seconds)
+	assert.False(t, drain.Done)
+	pod, err := podClient.Get(ctx, "worker-3", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, drainedPodDeletionCost, pod.Annotations[podDeletionCostAnnotationKey])
+	_, err = resourceManager.DrainWorkerGroup(ctx, &api.DrainWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+
+	// The worker without actors is removed right away, the other one once its actor is gone.
+	assert.Equal(t, []string{"worker-3"}, resourceManager.SyncWorkerGroupDrains(ctx))
+	cluster, err := resourceManager.GetCluster(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	group := cluster.Spec.WorkerGroupSpecs[1]
+	assert.Equal(t, int32(2), *group.Replicas)
+	assert.Equal(t, []string{"worker-3"}, group.ScaleStrategy.WorkersToDelete)
+	// The operator has not deleted the Pod yet, which is not removed again.
+	assert.Empty(t, resourceManager.SyncWorkerGroupDrains(ctx))
+
+	// The worker is kept while a task runs on it, although its actor is gone.
+	dashboardClient.SetStateAPIResults(nodes, []utils.RayActorState{{ActorID: "b", NodeID: "node-2", State: "ALIVE"}}, nil)
+	dashboardClient.SetTasks([]utils.RayTaskState{{TaskID: "t", NodeID: "node-1", State: "RUNNING"}})
+	assert.Empty(t, resourceManager.SyncWorkerGroupDrains(ctx))
+
+	dashboardClient.SetTasks(nil)
+	assert.Equal(t, []string{"worker-1"}, resourceManager.SyncWorkerGroupDrains(ctx))
+	cluster, err = resourceManager.GetCluster(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	group = cluster.Spec.WorkerGroupSpecs[1]
+	assert.Equal(t, int32(1), *group.Replicas)
+	assert.Equal(t, int32(1), *group.MinReplicas)
+	assert.Equal(t, []string{"worker-3", "worker-1"}, group.ScaleStrategy.WorkersToDelete)
+	drain, err = resourceManager.GetWorkerGroupDrain(ctx, "cluster", "team-a", "workers")
+	require.NoError(t, err)
+	assert.True(t, drain.Done)
+	assert.NotNil(t, drain.FinishedAt)
+	assert.Empty(t, resourceManager.SyncWorkerGroupDrains(ctx))
+}
+
+func TestDrainWorkerGroupTimeout(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	resourceManager.dashboardClientFunc = func() utils.RayDashboardClientInterface { return dashboardClient }
+	setupWorkerGroupCluster(ctx, t, resourceManager)
+	createWorkerPod(ctx, t, resourceManager, "worker-1", "", true, time.Now())
+	podClient := resourceManager.clientManager.KubernetesClient().PodClient("team-a")
+	pod, err := podClient.Get(ctx, "worker-1", metav1.GetOptions{})
+	require.NoError(t, err)
+	pod.Status = corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"}
+	_, err = podClient.UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	dashboardClient.SetStateAPIResults(
+		[]utils.RayNodeState{{NodeID: "node-1", NodeIP: "10.0.0.1", State: "ALIVE"}},
+		[]utils.RayActorState{{ActorID: "a", NodeID: "node-1", State: "ALIVE"}},
+		nil,
+	)
+
+	drain, err := resourceManager.DrainWorkerGroup(ctx, &api.DrainWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", TimeoutSeconds: 60})
+	require.NoError(t, err)
+	// Two of the replicas have no Pod yet.
+	assert.Equal(t, []string{"worker-1"}, drain.DrainingPods)
+	assert.Empty(t, resourceManager.SyncWorkerGroupDrains(ctx))
+
+	// The worker is removed with its actor once the drain timeout expired.
+	clusterClient := resourceManager.getRayClusterClient("team-a")
+	cluster, err := clusterClient.Get(ctx, "cluster", metav1.GetOptions{})
+	require.NoError(t, err)
+	expired := workerGroupDrain{StartedAt: time.Now().Add(-time.Hour).UTC(), TimeoutSeconds: 60}
+	value, err := json.Marshal(expired)
+	require.NoError(t, err)
+	cluster.Spec.WorkerGroupSpecs[1].Template.Annotations[util.RayWorkerGroupDrainAnnotationKey] = string(value)
+	_, err = clusterClient.Update(ctx, cluster, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"worker-1"}, resourceManager.SyncWorkerGroupDrains(ctx))
+	cluster, err = clusterClient.Get(ctx, "cluster", metav1.GetOptions{})
+	require.NoError(t, err)
+	group := cluster.Spec.WorkerGroupSpecs[1]
+	assert.Equal(t, int32(0), *group.Replicas)
+	assert.Equal(t, int32(0), *group.MinReplicas)
+	assert.Equal(t, []string{"worker-1"}, group.ScaleStrategy.WorkersToDelete)
+}
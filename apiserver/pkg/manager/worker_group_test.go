@@ -0,0 +1,218 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/config"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func setupWorkerGroupCluster(ctx context.Context, t *testing.T, resourceManager *ResourceManager) {
+	for _, template := range []*api.ComputeTemplate{
+		{Name: "small", Namespace: "team-a", Cpu: 1, Memory: 2},
+		{Name: "large", Namespace: "team-a", Cpu: 4, Memory: 16},
+	} {
+		_, err := resourceManager.CreateComputeTemplate(ctx, template)
+		require.NoError(t, err)
+	}
+	_, err := resourceManager.CreateCluster(ctx, &api.Cluster{
+		Name:      "cluster",
+		Namespace: "team-a",
+		User:      "user",
+		Version:   "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "small",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{GroupName: "other", ComputeTemplate: "small", Replicas: 1, MinReplicas: 1, MaxReplicas: 1},
+				{
+					GroupName:       "workers",
+					ComputeTemplate: "small",
+					Image:           "rayproject/ray:2.9.0-broken",
+					Replicas:        3,
+					MinReplicas:     1,
+					MaxReplicas:     5,
+					Environment:     &api.EnvironmentVariables{Values: map[string]string{"MODE": "test"}},
+				},
+			},
+		},
+	}, false, "")
+	require.NoError(t, err)
+}
+
+// createWorkerPod creates a Pod of the workers group, like the operator does from the template of the group.
+func createWorkerPod(ctx context.Context, t *testing.T, resourceManager *ResourceManager, name string, restartedAt string, ready bool, created time.Time) {
+	status := corev1.PodStatus{Phase: corev1.PodRunning}
+	if ready {
+		status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	_, err := resourceManager.clientManager.KubernetesClient().PodClient("team-a").Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "team-a",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   "cluster",
+				utils.RayNodeTypeLabelKey:  "worker",
+				utils.RayNodeGroupLabelKey: "workers",
+			},
+			Annotations: map[string]string{util.RayClusterRestartedAtAnnotationKey: restartedAt},
+		},
+		Status: status,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func TestUpdateWorkerGroup(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	setupWorkerGroupCluster(ctx, t, resourceManager)
+
+	cluster, err := resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{
+		Name:            "cluster",
+		Namespace:       "team-a",
+		GroupName:       "workers",
+		Image:           "rayproject/ray:2.9.0-fixed",
+		ComputeTemplate: "large",
+		Replicas:        4,
+		MaxUnavailable:  2,
+	})
+	require.NoError(t, err)
+
+	group := cluster.Spec.WorkerGroupSpecs[1]
+	assert.Equal(t, int32(4), *group.Replicas)
+	assert.Equal(t, "large", group.Template.Annotations[util.RayClusterComputeTemplateAnnotationKey])
+	assert.Equal(t, "rayproject/ray:2.9.0-fixed", group.Template.Annotations[util.RayClusterImageAnnotationKey])
+	assert.NotEmpty(t, group.Template.Annotations[util.RayClusterRestartedAtAnnotationKey])
+	assert.Equal(t, "2", group.Template.Annotations[util.RayClusterMaxUnavailableAnnotationKey])
+	container, _, ok := util.GetContainerByName(group.Template.Spec.Containers, "ray-worker")
+	require.True(t, ok)
+	assert.Equal(t, "rayproject/ray:2.9.0-fixed", container.Image)
+	assert.Equal(t, "4", container.Resources.Limits.Cpu().String())
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "MODE", Value: "test"})
+	// The other worker group is left unchanged.
+	assert.Empty(t, cluster.Spec.WorkerGroupSpecs[0].Template.Annotations[util.RayClusterRestartedAtAnnotationKey])
+
+	// Only the replicas are changed, without restarting the worker group.
+	cluster, err = resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", Replicas: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), *cluster.Spec.WorkerGroupSpecs[1].Replicas)
+	assert.Equal(t, group.Template, cluster.Spec.WorkerGroupSpecs[1].Template)
+
+	_, err = resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", Replicas: 6})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	_, err = resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", ComputeTemplate: "missing"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	_, err = resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "missing", Replicas: 2})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestUpdateWorkerGroupImageAllowlist(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	setupWorkerGroupCluster(ctx, t, resourceManager)
+	t.Cleanup(func() { config.Set(&config.Config{}) })
+	config.Set(&config.Config{Allowlists: config.Allowlists{ImageRepositories: []string{"rayproject/ray"}}})
+
+	_, err := resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{
+		Name:      "cluster",
+		Namespace: "team-a",
+		GroupName: "workers",
+		Image:     "registry.example.com/ray:2.9.0",
+	})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	cluster, err := resourceManager.GetCluster(ctx, "cluster", "team-a")
+	require.NoError(t, err)
+	// The worker group is not patched.
+	assert.Equal(t, "rayproject/ray:2.9.0-broken", cluster.Spec.WorkerGroupSpecs[1].Template.Annotations[util.RayClusterImageAnnotationKey])
+	assert.Empty(t, cluster.Spec.WorkerGroupSpecs[1].Template.Annotations[util.RayClusterRestartedAtAnnotationKey])
+
+	_, err = resourceManager.UpdateWorkerGroup(ctx, &api.UpdateWorkerGroupRequest{
+		Name:      "cluster",
+		Namespace: "team-a",
+		GroupName: "workers",
+		Image:     "rayproject/ray:2.9.0-fixed",
+	})
+	require.NoError(t, err)
+}
+
+func TestRollingRestartWorkerGroup(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	setupWorkerGroupCluster(ctx, t, resourceManager)
+	podClient := resourceManager.clientManager.KubernetesClient().PodClient("team-a")
+
+	// Nothing is restarted before the worker group is restarted.
+	now := time.Now()
+	createWorkerPod(ctx, t, resourceManager, "worker-1", "", true, now.Add(-3*time.Hour))
+	createWorkerPod(ctx, t, resourceManager, "worker-2", "", true, now.Add(-2*time.Hour))
+	createWorkerPod(ctx, t, resourceManager, "worker-3", "", false, now.Add(-time.Hour))
+	assert.Empty(t, resourceManager.SyncRollingRestarts(ctx))
+
+	restart, err := resourceManager.RestartWorkerGroup(ctx, &api.RestartWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), restart.MaxUnavailable)
+	assert.Equal(t, int32(3), restart.Replicas)
+	assert.Equal(t, int32(3), restart.OutdatedPods)
+	assert.False(t, restart.Done)
+	require.NotNil(t, restart.RestartedAt)
+	restartedAt := restart.RestartedAt.AsTime().Format(time.RFC3339)
+
+	// The Pod which is not ready is already unavailable, so only it is restarted.
+	assert.Equal(t, []string{"worker-3"}, resourceManager.SyncRollingRestarts(ctx))
+	_, err = podClient.Get(ctx, "worker-3", metav1.GetOptions{})
+	require.Error(t, err)
+	// The operator has not recreated the Pod yet, so no other Pod is restarted.
+	assert.Empty(t, resourceManager.SyncRollingRestarts(ctx))
+
+	// Then the oldest ready Pod is restarted once the recreated Pod is ready.
+	createWorkerPod(ctx, t, resourceManager, "worker-4", restartedAt, true, now)
+	assert.Equal(t, []string{"worker-1"}, resourceManager.SyncRollingRestarts(ctx))
+	createWorkerPod(ctx, t, resourceManager, "worker-5", restartedAt, true, now)
+	assert.Equal(t, []string{"worker-2"}, resourceManager.SyncRollingRestarts(ctx))
+	createWorkerPod(ctx, t, resourceManager, "worker-6", restartedAt, false, now)
+
+	restart, err = resourceManager.GetWorkerGroupRestart(ctx, "cluster", "team-a", "workers")
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), restart.OutdatedPods)
+	assert.Equal(t, int32(3), restart.UpdatedPods)
+	assert.Equal(t, int32(2), restart.ReadyPods)
+	assert.False(t, restart.Done)
+
+	pod, err := podClient.Get(ctx, "worker-6", metav1.GetOptions{})
+	require.NoError(t, err)
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	_, err = podClient.UpdateStatus(ctx, pod, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	restart, err = resourceManager.GetWorkerGroupRestart(ctx, "cluster", "team-a", "workers")
+	require.NoError(t, err)
+	assert.True(t, restart.Done)
+	assert.Empty(t, resourceManager.SyncRollingRestarts(ctx))
+}
+
+func TestRollingRestartMaxUnavailable(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := NewResourceManager(NewFakeClientManager(ctx, 0))
+	setupWorkerGroupCluster(ctx, t, resourceManager)
+
+	now := time.Now()
+	for i := 1; i <= 3; i++ {
+		createWorkerPod(ctx, t, resourceManager, fmt.Sprintf("worker-%d", i), "", true, now.Add(time.Duration(i)*time.Minute))
+	}
+	_, err := resourceManager.RestartWorkerGroup(ctx, &api.RestartWorkerGroupRequest{Name: "cluster", Namespace: "team-a", GroupName: "workers", MaxUnavailable: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"worker-1", "worker-2"}, resourceManager.SyncRollingRestarts(ctx))
+}
@@ -0,0 +1,175 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+)
+
+// inFlightRequests counts the RPCs being handled, streams are counted until they are closed.
+var inFlightRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "kuberay_apiserver_grpc_requests_in_flight",
+	Help: "Number of RPCs currently handled by the API server.",
+}, []string{"grpc_service", "grpc_method"})
+
+// rejectedRequests counts the requests rejected by the rate limits and the request size limit.
+var rejectedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kuberay_apiserver_rejected_requests_total",
+	Help: "Number of requests rejected by the rate limits or the request size limit of the API server.",
+}, []string{"reason", "grpc_service", "grpc_method"})
+
+// kubernetesRetries counts the calls to the Kubernetes API server retried after a transient failure.
+var kubernetesRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kuberay_apiserver_kubernetes_retries_total",
+	Help: "Number of calls to the Kubernetes API server retried after a transient failure.",
+}, []string{"method", "reason"})
+
+// RecordKubernetesRetry counts a call to the Kubernetes API server retried for the reason.
+func RecordKubernetesRetry(method string, reason string) {
+	kubernetesRetries.WithLabelValues(method, reason).Inc()
+}
+
+// Reasons of the rejected requests.
+const (
+	RejectedByRateLimit       = "rate_limit"
+	RejectedByClientRateLimit = "client_rate_limit"
+	RejectedByRequestSize     = "request_size"
+	RejectedByMutationLimit   = "mutation_limit"
+)
+
+// RecordRejectedRequest counts a request rejected for the reason. HTTP requests rejected before they reach the gRPC
+// server have no method, which is recorded as unknown.
+func RecordRejectedRequest(reason string, fullMethod string) {
+	service, method := splitMethodName(fullMethod)
+	rejectedRequests.WithLabelValues(reason, service, method).Inc()
+}
+
+// Register registers the metrics of the API server with the default Prometheus registry, which is
+// served on /metrics. The latency and the result code of every RPC are recorded by the interceptors
+// of go-grpc-prometheus, the live Ray resources are counted when the metrics are scraped.
+func Register(server *grpc.Server, resourceManager *manager.ResourceManager) {
+	grpc_prometheus.Register(server)
+	// This is to enable `grpc_server_handling_seconds`, otherwise we won't have latency metrics.
+	// see https://github.com/grpc-ecosystem/go-grpc-prometheus/blob/master/README.md#histograms for details.
+	grpc_prometheus.EnableHandlingTimeHistogram()
+	prometheus.MustRegister(inFlightRequests)
+	prometheus.MustRegister(rejectedRequests)
+	prometheus.MustRegister(kubernetesRetries)
+	prometheus.MustRegister(NewResourceCollector(resourceManager, resourceManager, resourceManager, 10*time.Second))
+}
+
+// UnaryServerInterceptors returns the interceptors recording the metrics of unary RPCs.
+func UnaryServerInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{grpc_prometheus.UnaryServerInterceptor, inFlightUnaryInterceptor}
+}
+
+// StreamServerInterceptors returns the interceptors recording the metrics of streaming RPCs.
+func StreamServerInterceptors() []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{grpc_prometheus.StreamServerInterceptor, inFlightStreamInterceptor}
+}
+
+func inFlightUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	gauge := inFlightRequests.WithLabelValues(splitMethodName(info.FullMethod))
+	gauge.Inc()
+	defer gauge.Dec()
+	return handler(ctx, req)
+}
+
+func inFlightStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	gauge := inFlightRequests.WithLabelValues(splitMethodName(info.FullMethod))
+	gauge.Inc()
+	defer gauge.Dec()
+	return handler(srv, ss)
+}
+
+// splitMethodName splits /proto.ClusterService/GetCluster into proto.ClusterService and GetCluster,
+// like the labels of go-grpc-prometheus.
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", "unknown"
+}
+
+// ResourceCollector reports the number of RayClusters, RayJobs and RayServices managed by the API
+// server in every namespace. The resources are listed when the metrics are scraped, from the resource
+// cache when it is enabled.
+type ResourceCollector struct {
+	clusterStore manager.ClusterStore
+	jobStore     manager.JobStore
+	serviceStore manager.ServiceStore
+	// timeout bounds the time spent listing the resources of a scrape.
+	timeout  time.Duration
+	clusters *prometheus.Desc
+	jobs     *prometheus.Desc
+	services *prometheus.Desc
+}
+
+func NewResourceCollector(clusterStore manager.ClusterStore, jobStore manager.JobStore, serviceStore manager.ServiceStore, timeout time.Duration) *ResourceCollector {
+	return &ResourceCollector{
+		clusterStore: clusterStore,
+		jobStore:     jobStore,
+		serviceStore: serviceStore,
+		timeout:      timeout,
+		clusters:     prometheus.NewDesc("kuberay_apiserver_rayclusters", "Number of RayClusters managed by the API server.", []string{"namespace"}, nil),
+		jobs:         prometheus.NewDesc("kuberay_apiserver_rayjobs", "Number of RayJobs managed by the API server.", []string{"namespace"}, nil),
+		services:     prometheus.NewDesc("kuberay_apiserver_rayservices", "Number of RayServices managed by the API server.", []string{"namespace"}, nil),
+	}
+}
+
+func (c *ResourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.clusters
+	ch <- c.jobs
+	ch <- c.services
+}
+
+// Collect sends the counts of the resources which could be listed, a failed list is logged and the
+// corresponding metric is missing from the scrape rather than reporting zero.
+// The resources of all namespaces are listed with ListClusters and ListServices, which the resource cache serves.
+func (c *ResourceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if clusters, _, err := c.clusterStore.ListClusters(ctx, metav1.NamespaceAll, "", 0, manager.ResourceSelector{}); err != nil {
+		klog.Warningf("Failed to list the RayClusters for the metrics: %v", err)
+	} else {
+		counts := map[string]int{}
+		for _, cluster := range clusters {
+			counts[cluster.Namespace]++
+		}
+		sendCounts(ch, c.clusters, counts)
+	}
+	if jobs, _, err := c.jobStore.ListAllJobs(ctx, "", 0); err != nil {
+		klog.Warningf("Failed to list the RayJobs for the metrics: %v", err)
+	} else {
+		counts := map[string]int{}
+		for _, job := range jobs {
+			counts[job.Namespace]++
+		}
+		sendCounts(ch, c.jobs, counts)
+	}
+	if services, _, err := c.serviceStore.ListServices(ctx, metav1.NamespaceAll, "", 0, manager.ResourceSelector{}); err != nil {
+		klog.Warningf("Failed to list the RayServices for the metrics: %v", err)
+	} else {
+		counts := map[string]int{}
+		for _, service := range services {
+			counts[service.Namespace]++
+		}
+		sendCounts(ch, c.services, counts)
+	}
+}
+
+func sendCounts(ch chan<- prometheus.Metric, desc *prometheus.Desc, counts map[string]int) {
+	for namespace, count := range counts {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count), namespace)
+	}
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestResourceCollector(t *testing.T) {
+	ctx := context.Background()
+	resourceManager := manager.NewResourceManager(manager.NewFakeClientManager(ctx, 0))
+	for _, cluster := range []struct{ name, namespace string }{{"a", "team-a"}, {"b", "team-a"}, {"c", "team-b"}} {
+		_, err := resourceManager.CreateComputeTemplate(ctx, &api.ComputeTemplate{Name: "template-" + cluster.name, Namespace: cluster.namespace, Cpu: 1, Memory: 2})
+		require.NoError(t, err)
+		_, err = resourceManager.CreateCluster(ctx, &api.Cluster{
+			Name:      cluster.name,
+			Namespace: cluster.namespace,
+			User:      "user",
+			Version:   "2.9.0",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{ComputeTemplate: "template-" + cluster.name, RayStartParams: map[string]string{"dashboard-host": "0.0.0.0"}},
+			},
+		}, false, "")
+		require.NoError(t, err)
+	}
+
+	expected := `
+# HELP kuberay_apiserver_rayclusters Number of RayClusters managed by the API server.
+# TYPE kuberay_apiserver_rayclusters gauge
+kuberay_apiserver_rayclusters{namespace="team-a"} 2
+kuberay_apiserver_rayclusters{namespace="team-b"} 1
+`
+	collector := NewResourceCollector(resourceManager, resourceManager, resourceManager, time.Second)
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "kuberay_apiserver_rayclusters"))
+	// No RayJob nor RayService exists, so they have no metric.
+	assert.Equal(t, 2, testutil.CollectAndCount(collector))
+}
+
+func TestSplitMethodName(t *testing.T) {
+	service, method := splitMethodName("/proto.ClusterService/GetCluster")
+	assert.Equal(t, "proto.ClusterService", service)
+	assert.Equal(t, "GetCluster", method)
+
+	service, method = splitMethodName("malformed")
+	assert.Equal(t, "unknown", service)
+	assert.Equal(t, "unknown", method)
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// Kinds of the resources in a backup bundle
+const (
+	BackupKindConfigMap  = "ConfigMap"
+	BackupKindSecret     = "Secret"
+	BackupKindRayCluster = "RayCluster"
+	BackupKindRayService = "RayService"
+	BackupKindRayJob     = "RayJob"
+)
+
+// BackupObjects holds the Kubernetes objects of a backup bundle. The fields are listed in restore order,
+// so that ConfigMaps and Secrets exist before the Ray resources referencing them are created.
+type BackupObjects struct {
+	ConfigMaps []*corev1.ConfigMap
+	Secrets    []*corev1.Secret
+	Clusters   []*rayv1api.RayCluster
+	Services   []*rayv1api.RayService
+	Jobs       []*rayv1api.RayJob
+}
+
+func FromKubeToAPIBackupBundle(namespace string, createdAt time.Time, objects *BackupObjects) (*api.BackupBundle, error) {
+	bundle := &api.BackupBundle{
+		Namespace: namespace,
+		CreatedAt: timestamppb.New(createdAt),
+	}
+
+	add := func(kind string, name string, obj interface{}) error {
+		manifest, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %s: %w", kind, name, err)
+		}
+		bundle.Resources = append(bundle.Resources, &api.BackupResource{Kind: kind, Name: name, Manifest: string(manifest)})
+		return nil
+	}
+
+	for _, configMap := range objects.ConfigMaps {
+		configMap.TypeMeta = metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: BackupKindConfigMap}
+		if err := add(BackupKindConfigMap, configMap.Name, configMap); err != nil {
+			return nil, err
+		}
+	}
+	for _, secret := range objects.Secrets {
+		secret.TypeMeta = metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: BackupKindSecret}
+		if err := add(BackupKindSecret, secret.Name, secret); err != nil {
+			return nil, err
+		}
+	}
+	for _, cluster := range objects.Clusters {
+		cluster.TypeMeta = metav1.TypeMeta{APIVersion: rayv1api.GroupVersion.String(), Kind: BackupKindRayCluster}
+		if err := add(BackupKindRayCluster, cluster.Name, cluster); err != nil {
+			return nil, err
+		}
+	}
+	for _, service := range objects.Services {
+		service.TypeMeta = metav1.TypeMeta{APIVersion: rayv1api.GroupVersion.String(), Kind: BackupKindRayService}
+		if err := add(BackupKindRayService, service.Name, service); err != nil {
+			return nil, err
+		}
+	}
+	for _, job := range objects.Jobs {
+		job.TypeMeta = metav1.TypeMeta{APIVersion: rayv1api.GroupVersion.String(), Kind: BackupKindRayJob}
+		if err := add(BackupKindRayJob, job.Name, job); err != nil {
+			return nil, err
+		}
+	}
+
+	return bundle, nil
+}
+
+func FromAPIToKubeBackupObjects(bundle *api.BackupBundle) (*BackupObjects, error) {
+	objects := &BackupObjects{}
+	for _, resource := range bundle.Resources {
+		var obj interface{}
+		switch resource.Kind {
+		case BackupKindConfigMap:
+			configMap := &corev1.ConfigMap{}
+			objects.ConfigMaps = append(objects.ConfigMaps, configMap)
+			obj = configMap
+		case BackupKindSecret:
+			secret := &corev1.Secret{}
+			objects.Secrets = append(objects.Secrets, secret)
+			obj = secret
+		case BackupKindRayCluster:
+			cluster := &rayv1api.RayCluster{}
+			objects.Clusters = append(objects.Clusters, cluster)
+			obj = cluster
+		case BackupKindRayService:
+			service := &rayv1api.RayService{}
+			objects.Services = append(objects.Services, service)
+			obj = service
+		case BackupKindRayJob:
+			job := &rayv1api.RayJob{}
+			objects.Jobs = append(objects.Jobs, job)
+			obj = job
+		default:
+			return nil, fmt.Errorf("resource %s has unsupported kind %q", resource.Name, resource.Kind)
+		}
+		if err := unmarshalBackupObject([]byte(resource.Manifest), obj); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s %s: %w", resource.Kind, resource.Name, err)
+		}
+	}
+	return objects, nil
+}
+
+// unmarshalBackupObject unmarshals the manifest of a resource of a backup bundle. The Ray resources of the bundles
+// exported by older API servers, or edited by hand, may have any of the RayAPIVersions.
+func unmarshalBackupObject(manifest []byte, obj interface{}) error {
+	switch obj.(type) {
+	case *corev1.ConfigMap, *corev1.Secret:
+		return json.Unmarshal(manifest, obj)
+	}
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(manifest, &typeMeta); err != nil {
+		return err
+	}
+	apiVersion := typeMeta.APIVersion
+	if apiVersion == "" {
+		apiVersion = rayv1api.GroupVersion.String()
+	}
+	return unmarshalRayObject(apiVersion, manifest, obj, false)
+}
@@ -3,7 +3,10 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	klog "k8s.io/klog/v2"
 
@@ -11,8 +14,11 @@ import (
 	api "github.com/ray-project/kuberay/proto/go_client"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
 // Default annotations used by Ray nodes
@@ -26,6 +32,12 @@ func getNodeDefaultAnnotations() []string {
 		"cni.projectcalico.org/podIPs",
 		"cni.projectcalico.org/containerID",
 		"ray.io/compute-template",
+		"ray.io/compute-template-revision",
+		"ray.io/pod-disruption-budget",
+		"ray.io/image-pull-secrets",
+		"ray.io/scratch-volumes",
+		"ray.io/secret-injections",
+		"ray.io/worker-group-drain",
 		"k8s.v1.cni.cncf.io/network-status",
 		"k8s.v1.cni.cncf.io/networks-status",
 	}
@@ -38,6 +50,7 @@ func getNodeDefaultLabels() []string {
 		"app.kubernetes.io/name",
 		"ray.io/cluster",
 		"ray.io/cluster-dashboard",
+		"ray.io/disruption-budget",
 		"ray.io/group",
 		"ray.io/identifier",
 		"ray.io/is-ray-node",
@@ -97,13 +110,14 @@ func FromCrdToApiClusters(clusters []*rayv1api.RayCluster, clusterEventsMap map[
 
 func FromCrdToApiCluster(cluster *rayv1api.RayCluster, events []corev1.Event) *api.Cluster {
 	pbCluster := &api.Cluster{
-		Name:         cluster.Name,
-		Namespace:    cluster.Namespace,
-		Version:      cluster.Labels[util.RayClusterVersionLabelKey],
-		User:         cluster.Labels[util.RayClusterUserLabelKey],
-		Environment:  api.Cluster_Environment(api.Cluster_Environment_value[cluster.Labels[util.RayClusterEnvironmentLabelKey]]),
-		CreatedAt:    &timestamppb.Timestamp{Seconds: cluster.CreationTimestamp.Unix()},
-		ClusterState: string(cluster.Status.State),
+		Name:            cluster.Name,
+		Namespace:       cluster.Namespace,
+		Version:         cluster.Labels[util.RayClusterVersionLabelKey],
+		User:            cluster.Labels[util.RayClusterUserLabelKey],
+		Environment:     api.Cluster_Environment(api.Cluster_Environment_value[cluster.Labels[util.RayClusterEnvironmentLabelKey]]),
+		CreatedAt:       &timestamppb.Timestamp{Seconds: cluster.CreationTimestamp.Unix()},
+		ClusterState:    string(cluster.Status.State),
+		ResourceVersion: cluster.ResourceVersion,
 	}
 
 	if len(cluster.ObjectMeta.Annotations) > 0 {
@@ -112,6 +126,7 @@ func FromCrdToApiCluster(cluster *rayv1api.RayCluster, events []corev1.Event) *a
 
 	// loop container and find the resource
 	pbCluster.ClusterSpec = PopulateRayClusterSpec(cluster.Spec)
+	setExternalStorageNamespace(pbCluster.ClusterSpec, cluster.Annotations)
 
 	// parse events
 	for _, event := range events {
@@ -125,6 +140,7 @@ func FromCrdToApiCluster(cluster *rayv1api.RayCluster, events []corev1.Event) *a
 			Message:        event.Message,
 			Type:           event.Type,
 			Count:          event.Count,
+			Severity:       FromKubeToAPIEventSeverity(event),
 		}
 		pbCluster.Events = append(pbCluster.Events, clusterEvent)
 	}
@@ -133,20 +149,135 @@ func FromCrdToApiCluster(cluster *rayv1api.RayCluster, events []corev1.Event) *a
 	for name, port := range cluster.Status.Endpoints {
 		pbCluster.ServiceEndpoint[name] = port
 	}
+	pbCluster.Admission = convertClusterAdmission(cluster.Status)
+	pbCluster.Queueing = util.QueueingFromLabels(cluster.Labels)
+	pbCluster.IdleTtlSeconds = util.TTLFromAnnotations(cluster.Annotations, util.RayClusterIdleTTLAnnotationKey)
 	return pbCluster
 }
 
+// FromCrdToApiClusterStatus converts the status of a RayCluster. If resourceVersion is the current resource version
+// of the cluster, only NotModified and the identifying fields are set.
+func FromCrdToApiClusterStatus(cluster *rayv1api.RayCluster, resourceVersion string) *api.ClusterStatus {
+	pbStatus := &api.ClusterStatus{
+		Name:            cluster.Name,
+		Namespace:       cluster.Namespace,
+		ResourceVersion: cluster.ResourceVersion,
+	}
+	if resourceVersion != "" && resourceVersion == cluster.ResourceVersion {
+		pbStatus.NotModified = true
+		return pbStatus
+	}
+
+	pbStatus.ClusterState = string(cluster.Status.State)
+	pbStatus.Reason = cluster.Status.Reason
+	pbStatus.ReadyWorkerReplicas = cluster.Status.ReadyWorkerReplicas
+	pbStatus.AvailableWorkerReplicas = cluster.Status.AvailableWorkerReplicas
+	pbStatus.DesiredWorkerReplicas = cluster.Status.DesiredWorkerReplicas
+	pbStatus.MinWorkerReplicas = cluster.Status.MinWorkerReplicas
+	pbStatus.MaxWorkerReplicas = cluster.Status.MaxWorkerReplicas
+	pbStatus.HeadPodIp = cluster.Status.Head.PodIP
+	pbStatus.HeadServiceIp = cluster.Status.Head.ServiceIP
+	pbStatus.ServiceEndpoint = map[string]string{}
+	for name, port := range cluster.Status.Endpoints {
+		pbStatus.ServiceEndpoint[name] = port
+	}
+	if cluster.Status.LastUpdateTime != nil {
+		pbStatus.LastUpdateTime = &timestamppb.Timestamp{Seconds: cluster.Status.LastUpdateTime.Unix()}
+	}
+	pbStatus.Admission = convertClusterAdmission(cluster.Status)
+	return pbStatus
+}
+
+// convertClusterAdmission returns the admission of the cluster by a batch scheduler from its Admitted condition,
+// or nil if the cluster is not submitted to a batch scheduler.
+func convertClusterAdmission(status rayv1api.RayClusterStatus) *api.ClusterAdmission {
+	condition := meta.FindStatusCondition(status.Conditions, string(rayv1api.RayClusterAdmitted))
+	if condition == nil {
+		return nil
+	}
+	return &api.ClusterAdmission{
+		Queue:    status.QueueName,
+		Admitted: condition.Status == metav1.ConditionTrue,
+		Reason:   condition.Reason,
+		Message:  condition.Message,
+	}
+}
+
 func PopulateRayClusterSpec(spec rayv1api.RayClusterSpec) *api.ClusterSpec {
 	clusterSpec := &api.ClusterSpec{}
+	// The annotations of the pod templates are filtered by the conversion of the groups.
+	clusterSpec.ImagePullSecrets = util.ImagePullSecretsFromAnnotations(spec.HeadGroupSpec.Template.Annotations)
+	clusterSpec.SecretInjections = convertSecretInjections(spec.HeadGroupSpec.Template.Annotations)
 	clusterSpec.HeadGroupSpec = PopulateHeadNodeSpec(spec.HeadGroupSpec)
 	clusterSpec.WorkerGroupSpec = PopulateWorkerNodeSpec(spec.WorkerGroupSpecs)
+	// The environment variables of the injected Secrets are part of the secret injections.
+	if len(clusterSpec.SecretInjections) > 0 {
+		head := clusterSpec.HeadGroupSpec
+		head.Environment, head.EnvFrom = removeInjectedSecretEnv(clusterSpec.SecretInjections, utils.RayNodeHeadGroupLabelValue, head.Environment, head.EnvFrom)
+		for _, group := range clusterSpec.WorkerGroupSpec {
+			group.Environment, group.EnvFrom = removeInjectedSecretEnv(clusterSpec.SecretInjections, group.GroupName, group.Environment, group.EnvFrom)
+		}
+	}
 	if spec.EnableInTreeAutoscaling != nil && *spec.EnableInTreeAutoscaling {
 		clusterSpec.EnableInTreeAutoscaling = true
 		clusterSpec.AutoscalerOptions = convertAutoscalingOptions(spec.AutoscalerOptions)
 	}
+	if spec.HeadGroupSpec.HeadNodeReservation != nil {
+		clusterSpec.ReserveHeadNode = true
+	}
+	if spec.DashboardAuth != nil {
+		clusterSpec.EnableDashboardAuth = true
+	}
+	if spec.Logging != nil {
+		clusterSpec.Logging = &api.LoggingConfig{
+			LoggingLevel:    spec.Logging.LoggingLevel,
+			BackendLogLevel: spec.Logging.BackendLogLevel,
+			TempDir:         spec.Logging.TempDir,
+		}
+	}
+	clusterSpec.GcsFaultTolerance = convertGcsFaultTolerance(spec.HeadGroupSpec)
+	// The environment variables of the external Redis are part of the GCS fault tolerance options.
+	if clusterSpec.GcsFaultTolerance != nil && clusterSpec.HeadGroupSpec.Environment != nil {
+		delete(clusterSpec.HeadGroupSpec.Environment.Values, util.RayRedisAddressEnv)
+		if clusterSpec.GcsFaultTolerance.RedisPasswordSecretName != "" {
+			delete(clusterSpec.HeadGroupSpec.Environment.ValuesFrom, util.RedisPasswordEnv)
+		}
+	}
 	return clusterSpec
 }
 
+// convertGcsFaultTolerance returns the external Redis of the head node, or nil if the head node has none.
+func convertGcsFaultTolerance(spec rayv1api.HeadGroupSpec) *api.GcsFaultToleranceOptions {
+	container, _, ok := util.GetContainerByName(spec.Template.Spec.Containers, "ray-head")
+	if !ok {
+		return nil
+	}
+	var options *api.GcsFaultToleranceOptions
+	for _, env := range container.Env {
+		if env.Name == util.RayRedisAddressEnv && env.Value != "" {
+			options = &api.GcsFaultToleranceOptions{RedisAddress: env.Value}
+		}
+	}
+	if options == nil {
+		return nil
+	}
+	for _, env := range container.Env {
+		if env.Name == util.RedisPasswordEnv && env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			options.RedisPasswordSecretName = env.ValueFrom.SecretKeyRef.Name
+			options.RedisPasswordSecretKey = env.ValueFrom.SecretKeyRef.Key
+		}
+	}
+	return options
+}
+
+// setExternalStorageNamespace sets the namespace of the GCS metadata of a cluster spec from the annotations of the
+// RayCluster, RayJob or RayService.
+func setExternalStorageNamespace(clusterSpec *api.ClusterSpec, annotations map[string]string) {
+	if clusterSpec.GetGcsFaultTolerance() != nil {
+		clusterSpec.GcsFaultTolerance.ExternalStorageNamespace = annotations[util.RayExternalStorageNSAnnotationKey]
+	}
+}
+
 func convertAutoscalingOptions(opts *rayv1api.AutoscalerOptions) *api.AutoscalerOptions {
 	if opts == nil {
 		return nil
@@ -201,9 +332,9 @@ func convertAutoscalingOptions(opts *rayv1api.AutoscalerOptions) *api.Autoscaler
 		}
 	}
 	if opts.Resources != nil {
-		rlist := opts.Resources.Limits
+		rlist := opts.Resources.Requests
 		if len(rlist) == 0 {
-			rlist = opts.Resources.Requests
+			rlist = opts.Resources.Limits
 		}
 		if cc := rlist.Cpu().String(); cc != "" {
 			options.Cpu = cc
@@ -211,6 +342,14 @@ func convertAutoscalingOptions(opts *rayv1api.AutoscalerOptions) *api.Autoscaler
 		if cm := rlist.Memory().String(); cm != "" {
 			options.Memory = cm
 		}
+		// Limits are only returned if they differ from the requirements
+		llist := opts.Resources.Limits
+		if cpu, ok := llist[corev1.ResourceCPU]; ok && cpu.String() != options.Cpu {
+			options.CpuLimit = cpu.String()
+		}
+		if memory, ok := llist[corev1.ResourceMemory]; ok && memory.String() != options.Memory {
+			options.MemoryLimit = memory.String()
+		}
 	}
 
 	return &options
@@ -218,11 +357,18 @@ func convertAutoscalingOptions(opts *rayv1api.AutoscalerOptions) *api.Autoscaler
 
 func PopulateHeadNodeSpec(spec rayv1api.HeadGroupSpec) *api.HeadGroupSpec {
 	headNodeSpec := &api.HeadGroupSpec{
-		RayStartParams:  spec.RayStartParams,
-		ServiceType:     string(spec.ServiceType),
-		Image:           spec.Template.Annotations[util.RayClusterImageAnnotationKey],
-		ComputeTemplate: spec.Template.Annotations[util.RayClusterComputeTemplateAnnotationKey],
-		Volumes:         PopulateVolumes(&spec.Template),
+		RayStartParams:            spec.RayStartParams,
+		ServiceType:               string(spec.ServiceType),
+		Image:                     spec.Template.Annotations[util.RayClusterImageAnnotationKey],
+		ComputeTemplate:           spec.Template.Annotations[util.RayClusterComputeTemplateAnnotationKey],
+		ComputeTemplateRevision:   computeTemplateRevision(spec.Template.Annotations),
+		Volumes:                   PopulateVolumes(&spec.Template),
+		SharedMemorySize:          PopulateSharedMemorySize(&spec.Template),
+		ScratchVolumes:            PopulateScratchVolumes(&spec.Template),
+		RestartPolicy:             string(spec.Template.Spec.RestartPolicy),
+		ImagePullSecret:           convertImagePullSecret(spec.Template),
+		PodDisruptionBudget:       convertPodDisruptionBudget(spec.Template.Annotations),
+		TopologySpreadConstraints: convertTopologySpreadConstraints(spec.Template.Spec.TopologySpreadConstraints),
 	}
 
 	for _, annotation := range getNodeDefaultAnnotations() {
@@ -245,18 +391,30 @@ func PopulateHeadNodeSpec(spec rayv1api.HeadGroupSpec) *api.HeadGroupSpec {
 
 	// Here we update environment only for a container named 'ray-head'
 	if container, _, ok := util.GetContainerByName(spec.Template.Spec.Containers, "ray-head"); ok && len(container.Env) > 0 {
-		headNodeSpec.Environment = convertEnvVariables(container.Env, true)
+		headNodeSpec.Environment = convertEnvVariables(container.Env, getHeadNodeEnv())
+	}
+	if container, _, ok := util.GetContainerByName(spec.Template.Spec.Containers, "ray-head"); ok {
+		headNodeSpec.EnvFrom = convertEnvFromSources(container.EnvFrom)
+		headNodeSpec.Lifecycle = convertContainerLifecycle(container.Lifecycle, "")
+		headNodeSpec.EphemeralStorage, headNodeSpec.EphemeralStorageLimit = convertEphemeralStorage(container.Resources)
+		for _, port := range container.Ports {
+			if port.Name != "" && !util.IsDefaultHeadPortName(port.Name) {
+				headNodeSpec.ServicePorts = append(headNodeSpec.ServicePorts, &api.ServicePort{Name: port.Name, Port: port.ContainerPort})
+			}
+		}
+	}
+	if spec.HeadService != nil {
+		headNodeSpec.ServiceName = spec.HeadService.Name
 	}
+	headNodeSpec.SidecarContainers = convertSidecarContainers(spec.Template.Spec.Containers, "ray-head")
 
 	if len(spec.Template.Spec.ServiceAccountName) > 1 {
 		headNodeSpec.ServiceAccount = spec.Template.Spec.ServiceAccountName
 	}
 
-	if len(spec.Template.Spec.ImagePullSecrets) > 0 {
-		headNodeSpec.ImagePullSecret = spec.Template.Spec.ImagePullSecrets[0].Name
-	}
-	if spec.Template.Spec.Containers[0].ImagePullPolicy == corev1.PullAlways {
-		headNodeSpec.ImagePullPolicy = "Always"
+	headNodeSpec.ImagePullPolicy = convertImagePullPolicy(spec.Template.Spec.Containers[0].ImagePullPolicy)
+	if spec.Template.Spec.SchedulerName != "" {
+		headNodeSpec.SchedulerName = spec.Template.Spec.SchedulerName
 	}
 
 	return headNodeSpec
@@ -267,14 +425,20 @@ func PopulateWorkerNodeSpec(specs []rayv1api.WorkerGroupSpec) []*api.WorkerGroup
 
 	for _, spec := range specs {
 		workerNodeSpec := &api.WorkerGroupSpec{
-			RayStartParams:  spec.RayStartParams,
-			MaxReplicas:     *spec.MaxReplicas,
-			MinReplicas:     *spec.MinReplicas,
-			Replicas:        *spec.Replicas,
-			GroupName:       spec.GroupName,
-			Image:           spec.Template.Annotations[util.RayClusterImageAnnotationKey],
-			ComputeTemplate: spec.Template.Annotations[util.RayClusterComputeTemplateAnnotationKey],
-			Volumes:         PopulateVolumes(&spec.Template),
+			RayStartParams:            spec.RayStartParams,
+			MaxReplicas:               *spec.MaxReplicas,
+			MinReplicas:               *spec.MinReplicas,
+			Replicas:                  *spec.Replicas,
+			GroupName:                 spec.GroupName,
+			Image:                     spec.Template.Annotations[util.RayClusterImageAnnotationKey],
+			ComputeTemplate:           spec.Template.Annotations[util.RayClusterComputeTemplateAnnotationKey],
+			ComputeTemplateRevision:   computeTemplateRevision(spec.Template.Annotations),
+			Volumes:                   PopulateVolumes(&spec.Template),
+			SharedMemorySize:          PopulateSharedMemorySize(&spec.Template),
+			ScratchVolumes:            PopulateScratchVolumes(&spec.Template),
+			ImagePullSecret:           convertImagePullSecret(spec.Template),
+			PodDisruptionBudget:       convertPodDisruptionBudget(spec.Template.Annotations),
+			TopologySpreadConstraints: convertTopologySpreadConstraints(spec.Template.Spec.TopologySpreadConstraints),
 		}
 
 		for _, annotation := range getNodeDefaultAnnotations() {
@@ -293,18 +457,22 @@ func PopulateWorkerNodeSpec(specs []rayv1api.WorkerGroupSpec) []*api.WorkerGroup
 
 		// Here we update environment only for a container named 'ray-worker'
 		if container, _, ok := util.GetContainerByName(spec.Template.Spec.Containers, "ray-worker"); ok && len(container.Env) > 0 {
-			workerNodeSpec.Environment = convertEnvVariables(container.Env, false)
+			workerNodeSpec.Environment = convertEnvVariables(container.Env, getWorkNodeEnv())
+		}
+		if container, _, ok := util.GetContainerByName(spec.Template.Spec.Containers, "ray-worker"); ok {
+			workerNodeSpec.EnvFrom = convertEnvFromSources(container.EnvFrom)
+			workerNodeSpec.Lifecycle = convertContainerLifecycle(container.Lifecycle, util.WorkerPreStopCommand)
+			workerNodeSpec.EphemeralStorage, workerNodeSpec.EphemeralStorageLimit = convertEphemeralStorage(container.Resources)
 		}
+		workerNodeSpec.SidecarContainers = convertSidecarContainers(spec.Template.Spec.Containers, "ray-worker")
 
 		if len(spec.Template.Spec.ServiceAccountName) > 1 {
 			workerNodeSpec.ServiceAccount = spec.Template.Spec.ServiceAccountName
 		}
 
-		if len(spec.Template.Spec.ImagePullSecrets) > 0 {
-			workerNodeSpec.ImagePullSecret = spec.Template.Spec.ImagePullSecrets[0].Name
-		}
-		if spec.Template.Spec.Containers[0].ImagePullPolicy == corev1.PullAlways {
-			workerNodeSpec.ImagePullPolicy = "Always"
+		workerNodeSpec.ImagePullPolicy = convertImagePullPolicy(spec.Template.Spec.Containers[0].ImagePullPolicy)
+		if spec.Template.Spec.SchedulerName != "" {
+			workerNodeSpec.SchedulerName = spec.Template.Spec.SchedulerName
 		}
 		workerNodeSpecs = append(workerNodeSpecs, workerNodeSpec)
 	}
@@ -312,21 +480,198 @@ func PopulateWorkerNodeSpec(specs []rayv1api.WorkerGroupSpec) []*api.WorkerGroup
 	return workerNodeSpecs
 }
 
-func convertEnvVariables(cenv []corev1.EnvVar, header bool) *api.EnvironmentVariables {
+// Convert the image pull Secret of a group, which is not one of the image pull Secrets of its cluster
+func convertImagePullSecret(template corev1.PodTemplateSpec) string {
+	clusterSecrets := util.ImagePullSecretsFromAnnotations(template.Annotations)
+	for _, secret := range template.Spec.ImagePullSecrets {
+		if !slices.Contains(clusterSecrets, secret.Name) {
+			return secret.Name
+		}
+	}
+	return ""
+}
+
+// Convert the secret injections of the cluster of a group kept in the annotations of its pod template
+func convertSecretInjections(annotations map[string]string) []*api.SecretInjection {
+	injections, err := util.SecretInjectionsFromAnnotations(annotations)
+	if err != nil {
+		klog.Errorf("Failed to read the secret injections: %v", err)
+		return nil
+	}
+	return injections
+}
+
+// Remove the environment variables and the env from Secrets added to a group by the secret injections of its cluster
+func removeInjectedSecretEnv(injections []*api.SecretInjection, groupName string, env *api.EnvironmentVariables, envFrom []*api.EnvValueFrom) (*api.EnvironmentVariables, []*api.EnvValueFrom) {
+	envNames, envFromSecrets := util.InjectedSecretEnv(injections, groupName)
+	if env != nil && len(envNames) > 0 {
+		for _, name := range envNames {
+			delete(env.ValuesFrom, name)
+		}
+		if len(env.Values) == 0 && len(env.ValuesFrom) == 0 {
+			env = nil
+		}
+	}
+	var filtered []*api.EnvValueFrom
+	for _, source := range envFrom {
+		if index := slices.Index(envFromSecrets, source.Name); source.Source == api.EnvValueFrom_SECRET && index >= 0 {
+			// A Secret can be injected more than once, with different prefixes.
+			envFromSecrets = slices.Delete(envFromSecrets, index, index+1)
+			continue
+		}
+		filtered = append(filtered, source)
+	}
+	return env, filtered
+}
+
+// Convert the image pull policy of a container, IfNotPresent being the default one
+func convertImagePullPolicy(policy corev1.PullPolicy) string {
+	if policy == corev1.PullAlways || policy == corev1.PullNever {
+		return string(policy)
+	}
+	return ""
+}
+
+// computeTemplateRevision returns the revision of the compute template kept in the annotations of the pod template of
+// a group, 0 for the groups created before the revisions.
+func computeTemplateRevision(annotations map[string]string) int32 {
+	revision, _ := strconv.ParseInt(annotations[util.RayClusterComputeTemplateRevisionAnnotationKey], 10, 32)
+	return int32(revision)
+}
+
+// Convert the pod disruption budget options kept in the annotations of the pod template of a group
+func convertPodDisruptionBudget(annotations map[string]string) *api.PodDisruptionBudgetOptions {
+	options, err := util.PodDisruptionBudgetFromAnnotations(annotations)
+	if err != nil {
+		klog.Errorf("Failed to read the pod disruption budget options: %v", err)
+		return nil
+	}
+	return options
+}
+
+// Convert the topology spread constraints of the pod template of a group, whose label selectors are set by the API server
+func convertTopologySpreadConstraints(constraints []corev1.TopologySpreadConstraint) []*api.TopologySpreadConstraint {
+	var converted []*api.TopologySpreadConstraint
+	for _, constraint := range constraints {
+		spread := &api.TopologySpreadConstraint{
+			TopologyKey:       constraint.TopologyKey,
+			MaxSkew:           constraint.MaxSkew,
+			WhenUnsatisfiable: string(constraint.WhenUnsatisfiable),
+		}
+		if constraint.MinDomains != nil {
+			spread.MinDomains = *constraint.MinDomains
+		}
+		converted = append(converted, spread)
+	}
+	return converted
+}
+
+// Convert the ConfigMaps and Secrets whose keys are all added as environment variables of a container
+func convertEnvFromSources(sources []corev1.EnvFromSource) []*api.EnvValueFrom {
+	var converted []*api.EnvValueFrom
+	for _, source := range sources {
+		switch {
+		case source.ConfigMapRef != nil:
+			converted = append(converted, &api.EnvValueFrom{Source: api.EnvValueFrom_CONFIGMAP, Name: source.ConfigMapRef.Name})
+		case source.SecretRef != nil:
+			converted = append(converted, &api.EnvValueFrom{Source: api.EnvValueFrom_SECRET, Name: source.SecretRef.Name})
+		}
+	}
+	return converted
+}
+
+// Convert the containers of a group other than its Ray container
+func convertSidecarContainers(containers []corev1.Container, rayContainerName string) []*api.SidecarContainer {
+	var sidecars []*api.SidecarContainer
+	for _, container := range containers {
+		if container.Name == rayContainerName {
+			continue
+		}
+		sidecar := &api.SidecarContainer{
+			Name:    container.Name,
+			Image:   container.Image,
+			Command: container.Command,
+			Args:    container.Args,
+			EnvFrom: convertEnvFromSources(container.EnvFrom),
+		}
+		if len(container.Env) > 0 {
+			sidecar.Environment = convertEnvVariables(container.Env, nil)
+		}
+		for _, mount := range container.VolumeMounts {
+			sidecar.VolumeMounts = append(sidecar.VolumeMounts, &api.VolumeMount{
+				Name:      mount.Name,
+				MountPath: mount.MountPath,
+				ReadOnly:  mount.ReadOnly,
+			})
+		}
+		if cpu, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+			sidecar.Cpu = cpu.String()
+		}
+		if memory, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			sidecar.Memory = memory.String()
+		}
+		sidecar.ImagePullPolicy = convertImagePullPolicy(container.ImagePullPolicy)
+		sidecars = append(sidecars, sidecar)
+	}
+	return sidecars
+}
+
+// Convert the ephemeral storage request and limit of a Ray container
+func convertEphemeralStorage(resources corev1.ResourceRequirements) (string, string) {
+	var request, limit string
+	if quantity, ok := resources.Requests[corev1.ResourceEphemeralStorage]; ok {
+		request = quantity.String()
+	}
+	if quantity, ok := resources.Limits[corev1.ResourceEphemeralStorage]; ok {
+		limit = quantity.String()
+	}
+	return request, limit
+}
+
+// Convert lifecycle hooks of a Ray container, the default pre stop command added by the API server is removed
+func convertContainerLifecycle(lifecycle *corev1.Lifecycle, defaultPreStop string) *api.ContainerLifecycle {
+	if lifecycle == nil {
+		return nil
+	}
+	result := &api.ContainerLifecycle{
+		PostStart: getShellCommand(lifecycle.PostStart),
+		PreStop:   getShellCommand(lifecycle.PreStop),
+	}
+	if defaultPreStop != "" {
+		if result.PreStop == defaultPreStop {
+			result.PreStop = ""
+		} else {
+			result.PreStop = strings.TrimSuffix(result.PreStop, "; "+defaultPreStop)
+		}
+	}
+	if result.PostStart == "" && result.PreStop == "" {
+		return nil
+	}
+	return result
+}
+
+// Get the command of a lifecycle handler which runs a shell command
+func getShellCommand(handler *corev1.LifecycleHandler) string {
+	if handler == nil || handler.Exec == nil {
+		return ""
+	}
+	command := handler.Exec.Command
+	if len(command) == 3 && command[0] == "/bin/sh" && command[1] == "-c" {
+		return command[2]
+	}
+	return strings.Join(command, " ")
+}
+
+// Convert the environment variables of a container, the reserved ones which are set by the API server or the operator are skipped
+func convertEnvVariables(cenv []corev1.EnvVar, reserved []string) *api.EnvironmentVariables {
 	env := api.EnvironmentVariables{
 		Values:     make(map[string]string),
 		ValuesFrom: make(map[string]*api.EnvValueFrom),
 	}
 	for _, kv := range cenv {
-		if header {
-			if contains(getHeadNodeEnv(), kv.Name) {
-				continue
-			}
-		} else {
-			if contains(getWorkNodeEnv(), kv.Name) {
-				// Skip reserved names
-				continue
-			}
+		if contains(reserved, kv.Name) {
+			// Skip reserved names
+			continue
 		}
 		if kv.ValueFrom != nil {
 			// this is value from
@@ -380,11 +725,20 @@ func FromKubeToAPIComputeTemplate(configMap *corev1.ConfigMap) *api.ComputeTempl
 
 	runtime := &api.ComputeTemplate{}
 	runtime.Name = configMap.Name
+	// The ConfigMaps of the revisions are named after the revision, and labeled with the compute template.
+	if name := configMap.Labels["ray.io/compute-template"]; name != "" {
+		runtime.Name = name
+	}
 	runtime.Namespace = configMap.Namespace
+	runtime.Revision = util.ComputeTemplateRevision(configMap)
 	runtime.Cpu = uint32(cpu)
 	runtime.Memory = uint32(memory)
 	runtime.Gpu = uint32(gpu)
 	runtime.GpuAccelerator = configMap.Data["gpu_accelerator"]
+	runtime.EphemeralStorage = configMap.Data["ephemeral_storage"]
+	runtime.EphemeralStorageLimit = configMap.Data["ephemeral_storage_limit"]
+	runtime.AcceleratorType = configMap.Data["accelerator_type"]
+	runtime.AcceleratorTypeLabel = configMap.Data["accelerator_type_label"]
 	val, ok := configMap.Data["tolerations"]
 	if ok {
 		err := json.Unmarshal([]byte(val), &runtime.Tolerations)
@@ -393,6 +747,20 @@ func FromKubeToAPIComputeTemplate(configMap *corev1.ConfigMap) *api.ComputeTempl
 				runtime.Tolerations, " error ", err)
 		}
 	}
+	scheduling := map[string]interface{}{
+		"node_selector":      &runtime.NodeSelector,
+		"node_affinity":      &runtime.NodeAffinity,
+		"pod_affinity":       &runtime.PodAffinity,
+		"pod_anti_affinity":  &runtime.PodAntiAffinity,
+		"extended_resources": &runtime.ExtendedResources,
+	}
+	for key, value := range scheduling {
+		if val, ok := configMap.Data[key]; ok {
+			if err := json.Unmarshal([]byte(val), value); err != nil {
+				klog.Error("failed to unmarshall ", key, " for compute template ", runtime.Name, " value ", val, " error ", err)
+			}
+		}
+	}
 	return runtime
 }
 
@@ -433,6 +801,23 @@ func FromCrdToApiJob(job *rayv1api.RayJob) (pbJob *api.RayJob) {
 		JobStatus:                string(job.Status.JobStatus),
 		JobDeploymentStatus:      string(job.Status.JobDeploymentStatus),
 		Message:                  job.Status.Message,
+		ClusterGenerateName:      job.Spec.ClusterGenerateName,
+		RayClusterName:           job.Status.RayClusterName,
+	}
+
+	// The operator resolves the RayCluster name of clusterGenerateName from the UID, so it is known
+	// before the status of a new RayJob is set.
+	if pbJob.RayClusterName == "" && job.Spec.ClusterGenerateName != "" && job.UID != "" {
+		pbJob.RayClusterName = utils.GenerateRayClusterNameWithPrefix(job.Spec.ClusterGenerateName, job.UID)
+	}
+
+	// The working_dir of a job running from a git repository is generated from its git source, and may hold the
+	// credentials of the repository.
+	if gitSource := util.GitSourceFromAnnotations(job.Annotations); gitSource != nil {
+		pbJob.GitSource = gitSource
+		if runtimeEnv, err := util.WithoutWorkingDir(job.Spec.RuntimeEnvYAML); err == nil {
+			pbJob.RuntimeEnv = runtimeEnv
+		}
 	}
 
 	// Add optional params
@@ -442,9 +827,22 @@ func FromCrdToApiJob(job *rayv1api.RayJob) (pbJob *api.RayJob) {
 
 	if job.Spec.RayClusterSpec != nil {
 		pbJob.ClusterSpec = PopulateRayClusterSpec(*job.Spec.RayClusterSpec)
+		setExternalStorageNamespace(pbJob.ClusterSpec, job.Annotations)
 	}
 
 	pbJob.TtlSecondsAfterFinished = job.Spec.TTLSecondsAfterFinished
+	pbJob.JobTtlSecondsAfterFinished = util.TTLFromAnnotations(job.Annotations, util.RayJobTTLAnnotationKey)
+	pbJob.Queueing = util.QueueingFromLabels(job.Labels)
+
+	if job.Spec.BackoffLimit != nil {
+		pbJob.BackoffLimit = *job.Spec.BackoffLimit
+	}
+	if job.Status.Failed != nil {
+		pbJob.FailedAttempts = *job.Status.Failed
+	}
+	if job.Status.Succeeded != nil {
+		pbJob.SucceededAttempts = *job.Status.Succeeded
+	}
 
 	if job.DeletionTimestamp != nil {
 		pbJob.DeleteAt = &timestamppb.Timestamp{Seconds: job.DeletionTimestamp.Unix()}
@@ -470,10 +868,62 @@ func FromCrdToApiJob(job *rayv1api.RayJob) (pbJob *api.RayJob) {
 	if jres := job.Spec.EntrypointResources; jres != "" {
 		pbJob.EntrypointResources = jres
 	}
+	if job.Status.JobResult != nil {
+		pbJob.JobResult = FromCrdToApiJobResult(job.Status.JobResult)
+	}
+
+	return pbJob
+}
 
+// FromCrdToApiArchivedJob snapshots the final state of a finished job of a target cluster. The job finished at its
+// end time, or at archiveTime if the operator did not set it, and deletedAt is zero unless the job is being deleted.
+func FromCrdToApiArchivedJob(job *rayv1api.RayJob, targetCluster string, archiveTime time.Time, deletedAt time.Time) *api.ArchivedRayJob {
+	pbJob := &api.ArchivedRayJob{
+		Name:                job.Name,
+		Namespace:           job.Namespace,
+		User:                job.Labels[util.RayClusterUserLabelKey],
+		Uid:                 string(job.UID),
+		TargetCluster:       targetCluster,
+		Entrypoint:          job.Spec.Entrypoint,
+		JobId:               job.Status.JobId,
+		RayClusterName:      job.Status.RayClusterName,
+		JobStatus:           string(job.Status.JobStatus),
+		JobDeploymentStatus: string(job.Status.JobDeploymentStatus),
+		Message:             job.Status.Message,
+		CreatedAt:           timestamppb.New(job.CreationTimestamp.Time),
+		EndTime:             timestamppb.New(archiveTime),
+	}
+	if job.Status.JobResult != nil {
+		pbJob.JobResult = FromCrdToApiJobResult(job.Status.JobResult)
+	}
+	if job.Status.StartTime != nil {
+		pbJob.StartTime = timestamppb.New(job.Status.StartTime.Time)
+	}
+	if job.Status.EndTime != nil {
+		pbJob.EndTime = timestamppb.New(job.Status.EndTime.Time)
+	}
+	if !deletedAt.IsZero() {
+		pbJob.DeletedAt = timestamppb.New(deletedAt)
+	}
 	return pbJob
 }
 
+func FromCrdToApiJobResult(result *rayv1api.RayJobResult) *api.RayJobResult {
+	pbResult := &api.RayJobResult{
+		DriverExitCode:  -1,
+		FailureCategory: string(result.FailureCategory),
+		ErrorType:       result.ErrorType,
+		Metadata:        result.Metadata,
+	}
+	if result.DriverExitCode != nil {
+		pbResult.DriverExitCode = *result.DriverExitCode
+	}
+	if result.RuntimeSeconds != nil {
+		pbResult.RuntimeSeconds = *result.RuntimeSeconds
+	}
+	return pbResult
+}
+
 func FromCrdToApiServices(services []*rayv1api.RayService, serviceEventsMap map[string][]corev1.Event) []*api.RayService {
 	apiServices := make([]*api.RayService, 0)
 	for _, service := range services {
@@ -505,10 +955,38 @@ func FromCrdToApiService(service *rayv1api.RayService, events []corev1.Event) *a
 		RayServiceStatus:                   PoplulateRayServiceStatus(service.Name, service.Status, events),
 		CreatedAt:                          &timestamppb.Timestamp{Seconds: service.CreationTimestamp.Unix()},
 		DeleteAt:                           &timestamppb.Timestamp{Seconds: deleteTime},
+		ServeService:                       PopulateServeServiceOptions(service.Spec.ServeService),
+		ResourceVersion:                    service.ResourceVersion,
+		DeploymentAutoscaling:              util.ServeDeploymentAutoscalingFromConfig(service.Spec.ServeConfigV2),
+	}
+	setExternalStorageNamespace(pbService.ClusterSpec, service.Annotations)
+	if expose, err := util.ExposeOptionsFromAnnotations(service.Annotations); err == nil {
+		pbService.Expose = expose
+	} else {
+		klog.Warningf("failed to read the expose options of ray service %s/%s: %v", service.Namespace, service.Name, err)
 	}
+	_, pbService.Suspended = service.Annotations[util.RayServiceSuspendedWorkerGroupsAnnotationKey]
+	pbService.DeletionInProgress = service.DeletionTimestamp != nil
 	return pbService
 }
 
+// PopulateServeServiceOptions converts the routing options of the serve service, or returns nil if the RayService
+// uses the default serve service.
+func PopulateServeServiceOptions(service *corev1.Service) *api.ServeServiceOptions {
+	if service == nil {
+		return nil
+	}
+	options := &api.ServeServiceOptions{
+		SessionAffinity:       string(service.Spec.SessionAffinity),
+		ExternalTrafficPolicy: string(service.Spec.ExternalTrafficPolicy),
+		Annotations:           service.Annotations,
+	}
+	if config := service.Spec.SessionAffinityConfig; config != nil && config.ClientIP != nil && config.ClientIP.TimeoutSeconds != nil {
+		options.SessionAffinityTimeoutSeconds = *config.ClientIP.TimeoutSeconds
+	}
+	return options
+}
+
 func PoplulateUnhealthySecondThreshold(value *int32) int32 {
 	if value == nil {
 		return 0
@@ -527,9 +1005,42 @@ func PoplulateRayServiceStatus(serviceName string, serviceStatus rayv1api.RaySer
 	for name, port := range serviceStatus.ActiveServiceStatus.RayClusterStatus.Endpoints {
 		status.ServiceEndpoint[name] = port
 	}
+	status.PendingRayClusterName = serviceStatus.PendingServiceStatus.RayClusterName
+	status.PendingRayClusterState = string(serviceStatus.PendingServiceStatus.RayClusterStatus.State)
+	status.UpgradeStatus = PopulateRayServiceUpgradeStatus(serviceStatus.UpgradeStatus)
 	return status
 }
 
+// PopulateRayServiceUpgradeStatus converts the progress of a zero-downtime upgrade, or returns nil if no upgrade is in progress.
+func PopulateRayServiceUpgradeStatus(upgradeStatus *rayv1api.RayServiceUpgradeStatus) *api.RayServiceUpgradeStatus {
+	if upgradeStatus == nil {
+		return nil
+	}
+	pbUpgradeStatus := &api.RayServiceUpgradeStatus{
+		ActiveRayClusterName:   upgradeStatus.ActiveRayClusterName,
+		PendingRayClusterName:  upgradeStatus.PendingRayClusterName,
+		ActiveRayClusterReady:  upgradeStatus.ActiveRayClusterReady,
+		PendingRayClusterReady: upgradeStatus.PendingRayClusterReady,
+		PromotionHeld:          upgradeStatus.PromotionHeld,
+	}
+	if upgradeStatus.StartTime != nil {
+		pbUpgradeStatus.StartTime = &timestamppb.Timestamp{Seconds: upgradeStatus.StartTime.Unix()}
+	}
+	if upgradeStatus.EstimatedPromotionTime != nil {
+		pbUpgradeStatus.EstimatedPromotionTime = &timestamppb.Timestamp{Seconds: upgradeStatus.EstimatedPromotionTime.Unix()}
+	}
+	for _, condition := range upgradeStatus.Conditions {
+		pbUpgradeStatus.Conditions = append(pbUpgradeStatus.Conditions, &api.RayServiceUpgradeCondition{
+			Type:               condition.Type,
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: &timestamppb.Timestamp{Seconds: condition.LastTransitionTime.Unix()},
+		})
+	}
+	return pbUpgradeStatus
+}
+
 func PopulateServeApplicationStatus(serveApplicationStatuses map[string]rayv1api.AppStatus) []*api.ServeApplicationStatus {
 	appStatuses := make([]*api.ServeApplicationStatus, 0)
 	for appName, appStatus := range serveApplicationStatuses {
@@ -538,6 +1049,10 @@ func PopulateServeApplicationStatus(serveApplicationStatuses map[string]rayv1api
 			Status:                appStatus.Status,
 			Message:               appStatus.Message,
 			ServeDeploymentStatus: PopulateServeDeploymentStatus(appStatus.Deployments),
+			RoutePrefix:           appStatus.RoutePrefix,
+		}
+		if appStatus.HealthLastUpdateTime != nil {
+			ds.HealthLastUpdateTime = &timestamppb.Timestamp{Seconds: appStatus.HealthLastUpdateTime.Unix()}
 		}
 		appStatuses = append(appStatuses, ds)
 	}
@@ -548,15 +1063,57 @@ func PopulateServeDeploymentStatus(serveDeploymentStatuses map[string]rayv1api.S
 	deploymentStatuses := make([]*api.ServeDeploymentStatus, 0)
 	for deploymentName, deploymentStatus := range serveDeploymentStatuses {
 		ds := &api.ServeDeploymentStatus{
-			DeploymentName: deploymentName,
-			Status:         deploymentStatus.Status,
-			Message:        deploymentStatus.Message,
+			DeploymentName:  deploymentName,
+			Status:          deploymentStatus.Status,
+			Message:         deploymentStatus.Message,
+			RunningReplicas: deploymentStatus.RunningReplicas,
+			TargetReplicas:  deploymentStatus.TargetReplicas,
+		}
+		if deploymentStatus.HealthLastUpdateTime != nil {
+			ds.HealthLastUpdateTime = &timestamppb.Timestamp{Seconds: deploymentStatus.HealthLastUpdateTime.Unix()}
 		}
 		deploymentStatuses = append(deploymentStatuses, ds)
 	}
 	return deploymentStatuses
 }
 
+// FromKubeToAPIRayEvents converts the events of Ray resources, keeping their order.
+func FromKubeToAPIRayEvents(events []corev1.Event) []*api.RayEvent {
+	apiEvents := make([]*api.RayEvent, 0, len(events))
+	for _, event := range events {
+		apiEvents = append(apiEvents, &api.RayEvent{
+			Id:             event.Name,
+			Kind:           event.InvolvedObject.Kind,
+			ResourceName:   event.InvolvedObject.Name,
+			Reason:         event.Reason,
+			Message:        event.Message,
+			Type:           event.Type,
+			Count:          event.Count,
+			FirstTimestamp: &timestamppb.Timestamp{Seconds: event.FirstTimestamp.Unix()},
+			LastTimestamp:  &timestamppb.Timestamp{Seconds: event.LastTimestamp.Unix()},
+			Severity:       FromKubeToAPIEventSeverity(event),
+		})
+	}
+	return apiEvents
+}
+
+// FromKubeToAPIEventSeverity normalizes the severity of an event. The Warning events of failed operations and invalid
+// specs, e.g. FailedToCreateWorkerPod or InvalidRayClusterSpec, are errors which usually need an action, while the
+// other ones may resolve by themselves.
+func FromKubeToAPIEventSeverity(event corev1.Event) api.EventSeverity {
+	switch event.Type {
+	case corev1.EventTypeNormal:
+		return api.EventSeverity_INFO
+	case corev1.EventTypeWarning:
+		if strings.HasPrefix(event.Reason, "Failed") || strings.HasPrefix(event.Reason, "Invalid") {
+			return api.EventSeverity_ERROR
+		}
+		return api.EventSeverity_WARNING
+	default:
+		return api.EventSeverity_EVENT_SEVERITY_UNSPECIFIED
+	}
+}
+
 func PopulateRayServiceEvent(serviceName string, events []corev1.Event) []*api.RayServiceEvent {
 	serviceEvents := make([]*api.RayServiceEvent, 0)
 	for _, event := range events {
@@ -570,6 +1127,7 @@ func PopulateRayServiceEvent(serviceName string, events []corev1.Event) []*api.R
 			Message:        event.Message,
 			Type:           event.Type,
 			Count:          event.Count,
+			Severity:       FromKubeToAPIEventSeverity(event),
 		}
 		serviceEvents = append(serviceEvents, serviceEvent)
 	}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	util "github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -487,6 +490,21 @@ func TestPopulateHeadNodeSpec(t *testing.T) {
 	}
 }
 
+func TestPopulateHeadNodeSpecService(t *testing.T) {
+	spec := headSpecTest.DeepCopy()
+	spec.HeadService = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "my-head"}}
+	spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: "dashboard", ContainerPort: 8265},
+		{Name: "grpc", ContainerPort: 9000},
+	}
+
+	groupSpec := PopulateHeadNodeSpec(*spec)
+	assert.Equal(t, "my-head", groupSpec.ServiceName)
+	assert.Len(t, groupSpec.ServicePorts, 1)
+	assert.Equal(t, "grpc", groupSpec.ServicePorts[0].Name)
+	assert.Equal(t, int32(9000), groupSpec.ServicePorts[0].Port)
+}
+
 func TestPopulateWorkerNodeSpec(t *testing.T) {
 	groupSpec := PopulateWorkerNodeSpec([]rayv1api.WorkerGroupSpec{workerSpecTest})[0]
 
@@ -507,6 +525,152 @@ func TestPopulateWorkerNodeSpec(t *testing.T) {
 	}
 }
 
+func TestPopulateContainerLifecycle(t *testing.T) {
+	assert.Nil(t, convertContainerLifecycle(nil, ""))
+
+	// The default pre stop command of the workers is not returned.
+	defaultLifecycle := &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "ray stop"}}},
+	}
+	assert.Nil(t, convertContainerLifecycle(defaultLifecycle, util.WorkerPreStopCommand))
+
+	lifecycle := &corev1.Lifecycle{
+		PostStart: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "python warmup.py"}}},
+		PreStop:   &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "python checkpoint.py; ray stop"}}},
+	}
+	converted := convertContainerLifecycle(lifecycle, util.WorkerPreStopCommand)
+	assert.Equal(t, "python warmup.py", converted.PostStart)
+	assert.Equal(t, "python checkpoint.py", converted.PreStop)
+
+	converted = convertContainerLifecycle(lifecycle, "")
+	assert.Equal(t, "python checkpoint.py; ray stop", converted.PreStop)
+}
+
+func TestPopulateSidecarContainers(t *testing.T) {
+	cpu := resource.MustParse("100m")
+	containers := []corev1.Container{
+		{
+			Name:    "ray-worker",
+			EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}}}},
+		},
+		{
+			Name:            "fluent-bit",
+			Image:           "fluent/fluent-bit:3.0",
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         []string{"/fluent-bit/bin/fluent-bit"},
+			Env:             []corev1.EnvVar{{Name: "TYPE", Value: "logs"}},
+			EnvFrom:         []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "fluent-bit-config"}}}},
+			VolumeMounts:    []corev1.VolumeMount{{Name: "ray-logs", MountPath: "/tmp/ray", ReadOnly: true}},
+			Resources:       corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: cpu}, Limits: corev1.ResourceList{corev1.ResourceCPU: cpu}},
+		},
+	}
+
+	assert.Equal(t, []*api.EnvValueFrom{{Source: api.EnvValueFrom_SECRET, Name: "credentials"}}, convertEnvFromSources(containers[0].EnvFrom))
+	sidecars := convertSidecarContainers(containers, "ray-worker")
+	require.Len(t, sidecars, 1)
+	assert.Equal(t, "fluent-bit", sidecars[0].Name)
+	assert.Equal(t, "fluent/fluent-bit:3.0", sidecars[0].Image)
+	assert.Equal(t, "Always", sidecars[0].ImagePullPolicy)
+	assert.Equal(t, []string{"/fluent-bit/bin/fluent-bit"}, sidecars[0].Command)
+	// The environment variables of the sidecars are not reserved by the API server.
+	assert.Equal(t, map[string]string{"TYPE": "logs"}, sidecars[0].Environment.Values)
+	assert.Equal(t, []*api.EnvValueFrom{{Source: api.EnvValueFrom_CONFIGMAP, Name: "fluent-bit-config"}}, sidecars[0].EnvFrom)
+	assert.Equal(t, []*api.VolumeMount{{Name: "ray-logs", MountPath: "/tmp/ray", ReadOnly: true}}, sidecars[0].VolumeMounts)
+	assert.Equal(t, "100m", sidecars[0].Cpu)
+	assert.Empty(t, sidecars[0].Memory)
+
+	assert.Nil(t, convertSidecarContainers(containers[:1], "ray-worker"))
+}
+
+func TestPopulateEphemeralStorage(t *testing.T) {
+	request, limit := convertEphemeralStorage(corev1.ResourceRequirements{})
+	assert.Empty(t, request)
+	assert.Empty(t, limit)
+
+	request, limit = convertEphemeralStorage(corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("20Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("40Gi")},
+	})
+	assert.Equal(t, "20Gi", request)
+	assert.Equal(t, "40Gi", limit)
+}
+
+func TestPopulateClusterAdmission(t *testing.T) {
+	assert.Nil(t, convertClusterAdmission(rayv1api.RayClusterStatus{}))
+
+	status := rayv1api.RayClusterStatus{
+		QueueName: "team-a",
+		Conditions: []metav1.Condition{
+			{
+				Type:    string(rayv1api.RayClusterAdmitted),
+				Status:  metav1.ConditionFalse,
+				Reason:  rayv1api.RayClusterWaitingForAdmission,
+				Message: "Waiting for admission to queue \"team-a\" of volcano: queue resource quota insufficient",
+			},
+		},
+	}
+	admission := convertClusterAdmission(status)
+	assert.Equal(t, "team-a", admission.Queue)
+	assert.False(t, admission.Admitted)
+	assert.Equal(t, rayv1api.RayClusterWaitingForAdmission, admission.Reason)
+	assert.Equal(t, status.Conditions[0].Message, admission.Message)
+
+	status.Conditions[0].Status = metav1.ConditionTrue
+	status.Conditions[0].Reason = rayv1api.RayClusterAdmittedByScheduler
+	assert.True(t, convertClusterAdmission(status).Admitted)
+}
+
+func TestFromCrdToApiClusterStatus(t *testing.T) {
+	cluster := &rayv1api.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "raycluster-sample",
+			Namespace:       "default",
+			ResourceVersion: "12345",
+		},
+		Status: rayv1api.RayClusterStatus{
+			State:                   rayv1api.Ready,
+			ReadyWorkerReplicas:     2,
+			AvailableWorkerReplicas: 2,
+			DesiredWorkerReplicas:   3,
+			MinWorkerReplicas:       1,
+			MaxWorkerReplicas:       5,
+			Head: rayv1api.HeadInfo{
+				PodIP:     "10.0.0.1",
+				ServiceIP: "10.96.0.1",
+			},
+			Endpoints:      map[string]string{"dashboard": "8265"},
+			LastUpdateTime: &metav1.Time{Time: time.Unix(1700000000, 0)},
+		},
+	}
+
+	status := FromCrdToApiClusterStatus(cluster, "")
+	assert.Equal(t, "raycluster-sample", status.Name)
+	assert.Equal(t, "default", status.Namespace)
+	assert.Equal(t, "12345", status.ResourceVersion)
+	assert.False(t, status.NotModified)
+	assert.Equal(t, string(rayv1api.Ready), status.ClusterState)
+	assert.Equal(t, int32(2), status.ReadyWorkerReplicas)
+	assert.Equal(t, int32(3), status.DesiredWorkerReplicas)
+	assert.Equal(t, int32(5), status.MaxWorkerReplicas)
+	assert.Equal(t, "10.0.0.1", status.HeadPodIp)
+	assert.Equal(t, "10.96.0.1", status.HeadServiceIp)
+	assert.Equal(t, map[string]string{"dashboard": "8265"}, status.ServiceEndpoint)
+	assert.Equal(t, int64(1700000000), status.LastUpdateTime.Seconds)
+	assert.Nil(t, status.Admission)
+
+	// The status is not returned again if the cluster has not changed.
+	status = FromCrdToApiClusterStatus(cluster, "12345")
+	assert.True(t, status.NotModified)
+	assert.Equal(t, "12345", status.ResourceVersion)
+	assert.Empty(t, status.ClusterState)
+	assert.Empty(t, status.ServiceEndpoint)
+
+	// The status is returned if the cluster has changed since the given resource version.
+	status = FromCrdToApiClusterStatus(cluster, "12000")
+	assert.False(t, status.NotModified)
+	assert.Equal(t, string(rayv1api.Ready), status.ClusterState)
+}
+
 func TestAutoscalerOptions(t *testing.T) {
 	options := convertAutoscalingOptions(autoscalerOptions)
 	assert.Equal(t, options.IdleTimeoutSeconds, int32(60))
@@ -518,6 +682,48 @@ func TestAutoscalerOptions(t *testing.T) {
 	assert.Equal(t, len(options.Envs.Values), 1)
 	assert.Equal(t, len(options.Envs.ValuesFrom), 2)
 	assert.Equal(t, len(options.Volumes), 2)
+	assert.Empty(t, options.CpuLimit)
+	assert.Empty(t, options.MemoryLimit)
+
+	limitedOptions := autoscalerOptions.DeepCopy()
+	limitedOptions.Resources.Limits = corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1"),
+		corev1.ResourceMemory: resource.MustParse("512Mi"),
+	}
+	options = convertAutoscalingOptions(limitedOptions)
+	assert.Equal(t, "500m", options.Cpu)
+	assert.Equal(t, "1", options.CpuLimit)
+	assert.Empty(t, options.MemoryLimit)
+}
+
+func TestPopulateGcsFaultTolerance(t *testing.T) {
+	apiCluster := &api.Cluster{
+		Name:      "cluster",
+		Namespace: "default",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "foo",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+				Environment:     &api.EnvironmentVariables{Values: map[string]string{"foo": "bar"}},
+			},
+			GcsFaultTolerance: &api.GcsFaultToleranceOptions{
+				RedisAddress:             "redis:6379",
+				RedisPasswordSecretName:  "redis-password",
+				RedisPasswordSecretKey:   "token",
+				ExternalStorageNamespace: "serving",
+			},
+		},
+	}
+	rayCluster, err := util.NewRayCluster(apiCluster, map[string]*api.ComputeTemplate{"foo": {Name: "foo", Cpu: 1, Memory: 1}})
+	require.NoError(t, err)
+
+	cluster := FromCrdToApiCluster(rayCluster.Get(), []corev1.Event{})
+	assert.True(t, proto.Equal(apiCluster.ClusterSpec.GcsFaultTolerance, cluster.ClusterSpec.GcsFaultTolerance))
+	assert.Equal(t, map[string]string{"foo": "bar"}, cluster.ClusterSpec.HeadGroupSpec.Environment.Values)
+	assert.Empty(t, cluster.ClusterSpec.HeadGroupSpec.Environment.ValuesFrom)
+
+	cluster = FromCrdToApiCluster(&ClusterSpecTest, []corev1.Event{})
+	assert.Nil(t, cluster.ClusterSpec.GcsFaultTolerance)
 }
 
 func TestPopulateRayClusterSpec(t *testing.T) {
@@ -580,6 +786,36 @@ func TestPopulateTemplate(t *testing.T) {
 	}
 }
 
+func TestPopulateTemplateScheduling(t *testing.T) {
+	computeTemplate := &api.ComputeTemplate{
+		Name:         "gpu-template",
+		Namespace:    "max",
+		Cpu:          4,
+		Memory:       8,
+		NodeSelector: map[string]string{"cloud.google.com/gke-spot": "true"},
+		NodeAffinity: []*api.NodeAffinityRule{
+			{MatchExpressions: []*api.NodeSelectorRequirement{{Key: "node-pool", Operator: "In", Values: []string{"gpu"}}}, Weight: 20},
+		},
+		PodAffinity: []*api.PodAffinityRule{
+			{MatchLabels: map[string]string{"app": "cache"}, TopologyKey: "topology.kubernetes.io/zone"},
+		},
+		PodAntiAffinity: []*api.PodAffinityRule{
+			{MatchLabels: map[string]string{"ray.io/node-type": "worker"}, TopologyKey: "kubernetes.io/hostname", Weight: 10},
+		},
+		EphemeralStorage:      "20Gi",
+		EphemeralStorageLimit: "40Gi",
+		ExtendedResources:     map[string]string{"google.com/tpu": "4"},
+		AcceleratorType:       "tpu-v5-lite-podslice",
+		AcceleratorTypeLabel:  "cloud.google.com/gke-tpu-accelerator",
+		Revision:              3,
+	}
+	configMap, err := util.NewComputeTemplate(computeTemplate)
+	assert.Nil(t, err)
+
+	template := FromKubeToAPIComputeTemplate(configMap)
+	assert.True(t, proto.Equal(computeTemplate, template), "got %v, expected %v", template, computeTemplate)
+}
+
 func tolerationToString(toleration *api.PodToleration) string {
 	return "Key: " + toleration.Key + " Operator: " + string(toleration.Operator) + " Effect: " + string(toleration.Effect)
 }
@@ -614,3 +850,159 @@ func TestPopulateJob(t *testing.T) {
 	assert.Equal(t, "image", job.JobSubmitter.Image)
 	assert.Equal(t, "2", job.JobSubmitter.Cpu)
 }
+
+func TestPopulateJobRayClusterName(t *testing.T) {
+	rayJob := JobNewClusterTest.DeepCopy()
+	rayJob.UID = "8a1f3c52-9d8e-4c1b-a4d3-6f2b7e9c0d11"
+	rayJob.Spec.ClusterGenerateName = "nightly-"
+	job := FromCrdToApiJob(rayJob)
+	assert.Equal(t, "nightly-", job.ClusterGenerateName)
+	assert.Equal(t, utils.GenerateRayClusterNameWithPrefix("nightly-", rayJob.UID), job.RayClusterName)
+
+	rayJob.Status.RayClusterName = "test-raycluster-abcde"
+	assert.Equal(t, "test-raycluster-abcde", FromCrdToApiJob(rayJob).RayClusterName)
+}
+
+func TestPopulateJobRetries(t *testing.T) {
+	rayJob := JobNewClusterTest.DeepCopy()
+	job := FromCrdToApiJob(rayJob)
+	assert.Equal(t, int32(0), job.BackoffLimit)
+	assert.Equal(t, int32(0), job.FailedAttempts)
+
+	rayJob.Spec.BackoffLimit = ptr.To[int32](3)
+	rayJob.Status.Failed = ptr.To[int32](2)
+	rayJob.Status.Succeeded = ptr.To[int32](1)
+	job = FromCrdToApiJob(rayJob)
+	assert.Equal(t, int32(3), job.BackoffLimit)
+	assert.Equal(t, int32(2), job.FailedAttempts)
+	assert.Equal(t, int32(1), job.SucceededAttempts)
+}
+
+func TestPopulateJobResult(t *testing.T) {
+	rayJob := JobNewClusterTest.DeepCopy()
+	assert.Nil(t, FromCrdToApiJob(rayJob).JobResult)
+
+	rayJob.Status.JobResult = &rayv1api.RayJobResult{
+		RuntimeSeconds:  ptr.To[int64](30),
+		FailureCategory: rayv1api.InfraErrorFailure,
+		ErrorType:       "JOB_SUPERVISOR_ACTOR_DIED",
+	}
+	result := FromCrdToApiJob(rayJob).JobResult
+	assert.Equal(t, int32(-1), result.DriverExitCode)
+	assert.Equal(t, "InfraError", result.FailureCategory)
+	assert.Equal(t, int64(30), result.RuntimeSeconds)
+	assert.Equal(t, "JOB_SUPERVISOR_ACTOR_DIED", result.ErrorType)
+
+	rayJob.Status.JobResult.DriverExitCode = ptr.To[int32](1)
+	assert.Equal(t, int32(1), FromCrdToApiJob(rayJob).JobResult.DriverExitCode)
+}
+
+func TestFromKubeToAPIEventSeverity(t *testing.T) {
+	tests := []struct {
+		eventType string
+		reason    string
+		expected  api.EventSeverity
+	}{
+		{corev1.EventTypeNormal, "CreatedWorkerPod", api.EventSeverity_INFO},
+		{corev1.EventTypeWarning, "FailedToCreateWorkerPod", api.EventSeverity_ERROR},
+		{corev1.EventTypeWarning, "InvalidRayClusterSpec", api.EventSeverity_ERROR},
+		{corev1.EventTypeWarning, "DrainTimeoutExceeded", api.EventSeverity_WARNING},
+		{"Critical", "FailedToCreateWorkerPod", api.EventSeverity_EVENT_SEVERITY_UNSPECIFIED},
+	}
+	for _, tc := range tests {
+		event := corev1.Event{Type: tc.eventType, Reason: tc.reason}
+		assert.Equal(t, tc.expected, FromKubeToAPIEventSeverity(event), "%s %s", tc.eventType, tc.reason)
+	}
+	assert.Equal(t, api.EventSeverity_ERROR, PopulateRayServiceEvent("service", []corev1.Event{{Type: corev1.EventTypeWarning, Reason: "FailedToCreateIngress"}})[0].Severity)
+}
+
+func TestPopulateServeApplicationStatus(t *testing.T) {
+	unhealthySince := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	applications := map[string]rayv1api.AppStatus{
+		"fruit_app": {
+			Status:               rayv1api.ApplicationStatusEnum.UNHEALTHY,
+			Message:              "The deployment MangoStand is unhealthy",
+			RoutePrefix:          "/fruit",
+			HealthLastUpdateTime: &unhealthySince,
+			Deployments: map[string]rayv1api.ServeDeploymentStatus{
+				"MangoStand": {
+					Status:               rayv1api.DeploymentStatusEnum.UNHEALTHY,
+					RunningReplicas:      1,
+					TargetReplicas:       3,
+					HealthLastUpdateTime: &unhealthySince,
+				},
+			},
+		},
+	}
+	statuses := PopulateServeApplicationStatus(applications)
+	require.Len(t, statuses, 1)
+	app := statuses[0]
+	assert.Equal(t, "fruit_app", app.Name)
+	assert.Equal(t, "UNHEALTHY", app.Status)
+	assert.Equal(t, "/fruit", app.RoutePrefix)
+	assert.Equal(t, unhealthySince.Unix(), app.HealthLastUpdateTime.Seconds)
+	require.Len(t, app.ServeDeploymentStatus, 1)
+	deployment := app.ServeDeploymentStatus[0]
+	assert.Equal(t, "MangoStand", deployment.DeploymentName)
+	assert.Equal(t, int32(1), deployment.RunningReplicas)
+	assert.Equal(t, int32(3), deployment.TargetReplicas)
+	assert.Equal(t, unhealthySince.Unix(), deployment.HealthLastUpdateTime.Seconds)
+}
+
+func TestPopulateServeServiceOptions(t *testing.T) {
+	assert.Nil(t, PopulateServeServiceOptions(nil))
+
+	timeoutSeconds := int32(600)
+	options := PopulateServeServiceOptions(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"example.com/sticky": "true"}},
+		Spec: corev1.ServiceSpec{
+			SessionAffinity:       corev1.ServiceAffinityClientIP,
+			SessionAffinityConfig: &corev1.SessionAffinityConfig{ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeoutSeconds}},
+			ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+		},
+	})
+	assert.Equal(t, "ClientIP", options.SessionAffinity)
+	assert.Equal(t, int32(600), options.SessionAffinityTimeoutSeconds)
+	assert.Equal(t, "Local", options.ExternalTrafficPolicy)
+	assert.Equal(t, map[string]string{"example.com/sticky": "true"}, options.Annotations)
+}
+
+func TestPopulateRayServiceUpgradeStatus(t *testing.T) {
+	startTime := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	serviceStatus := rayv1api.RayServiceStatuses{
+		ActiveServiceStatus:  rayv1api.RayServiceStatus{RayClusterName: "active-cluster"},
+		PendingServiceStatus: rayv1api.RayServiceStatus{RayClusterName: "pending-cluster", RayClusterStatus: rayv1api.RayClusterStatus{State: rayv1api.Ready}},
+	}
+	status := PoplulateRayServiceStatus("test-service", serviceStatus, nil)
+	assert.Equal(t, "pending-cluster", status.PendingRayClusterName)
+	assert.Equal(t, "ready", status.PendingRayClusterState)
+	assert.Nil(t, status.UpgradeStatus)
+
+	serviceStatus.UpgradeStatus = &rayv1api.RayServiceUpgradeStatus{
+		StartTime:              &startTime,
+		EstimatedPromotionTime: &metav1.Time{Time: startTime.Add(5 * time.Minute)},
+		ActiveRayClusterName:   "active-cluster",
+		PendingRayClusterName:  "pending-cluster",
+		ActiveRayClusterReady:  true,
+		PromotionHeld:          true,
+		Conditions: []metav1.Condition{
+			{
+				Type:               string(rayv1api.PendingHeadPodReady),
+				Status:             metav1.ConditionTrue,
+				Reason:             rayv1api.HeadPodRunningAndReady,
+				LastTransitionTime: startTime,
+			},
+		},
+	}
+	upgradeStatus := PoplulateRayServiceStatus("test-service", serviceStatus, nil).UpgradeStatus
+	assert.Equal(t, "active-cluster", upgradeStatus.ActiveRayClusterName)
+	assert.Equal(t, "pending-cluster", upgradeStatus.PendingRayClusterName)
+	assert.True(t, upgradeStatus.ActiveRayClusterReady)
+	assert.False(t, upgradeStatus.PendingRayClusterReady)
+	assert.True(t, upgradeStatus.PromotionHeld)
+	assert.Equal(t, startTime.Unix(), upgradeStatus.StartTime.Seconds)
+	assert.Equal(t, startTime.Unix()+300, upgradeStatus.EstimatedPromotionTime.Seconds)
+	assert.Len(t, upgradeStatus.Conditions, 1)
+	assert.Equal(t, "PendingHeadPodReady", upgradeStatus.Conditions[0].Type)
+	assert.Equal(t, "True", upgradeStatus.Conditions[0].Status)
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	rayv1alpha1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1alpha1"
+)
+
+// RayAPIVersions are the API versions of the Ray custom resources which the model converts, the ray.io/v1 version
+// the API server reads and writes first. The fields of ray.io/v1alpha1 are a subset of the ones of ray.io/v1, so
+// that its resources are converted to ray.io/v1 without loss.
+var RayAPIVersions = []string{rayv1api.GroupVersion.String(), rayv1alpha1api.GroupVersion.String()}
+
+// unmarshalRayObject unmarshals the YAML or JSON of a Ray custom resource of one of the RayAPIVersions into its
+// ray.io/v1 object, a RayCluster, a RayService or a RayJob. The resources of the other versions are unmarshalled into
+// their own type first, so that unknown fields are rejected against their own schema when strict is set, and their
+// API version is set to ray.io/v1.
+func unmarshalRayObject(apiVersion string, data []byte, obj interface{}, strict bool) error {
+	if !slices.Contains(RayAPIVersions, apiVersion) {
+		return fmt.Errorf("unsupported API version %q, expected one of %v", apiVersion, RayAPIVersions)
+	}
+	unmarshal := func(data []byte, obj interface{}) error { return yaml.Unmarshal(data, obj) }
+	if strict {
+		unmarshal = func(data []byte, obj interface{}) error { return yaml.UnmarshalStrict(data, obj) }
+	}
+	if apiVersion == rayv1api.GroupVersion.String() {
+		return unmarshal(data, obj)
+	}
+
+	var versioned interface{}
+	switch obj.(type) {
+	case *rayv1api.RayCluster:
+		versioned = &rayv1alpha1api.RayCluster{}
+	case *rayv1api.RayService:
+		versioned = &rayv1alpha1api.RayService{}
+	case *rayv1api.RayJob:
+		versioned = &rayv1alpha1api.RayJob{}
+	default:
+		return fmt.Errorf("unsupported Ray custom resource %T", obj)
+	}
+	if err := unmarshal(data, versioned); err != nil {
+		return err
+	}
+	converted, err := json.Marshal(versioned)
+	if err != nil {
+		return fmt.Errorf("failed to convert the %s resource: %w", apiVersion, err)
+	}
+	if err := json.Unmarshal(converted, obj); err != nil {
+		return fmt.Errorf("failed to convert the %s resource: %w", apiVersion, err)
+	}
+	objectKind := obj.(runtime.Object).GetObjectKind()
+	objectKind.SetGroupVersionKind(rayv1api.GroupVersion.WithKind(objectKind.GroupVersionKind().Kind))
+	return nil
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const v1alpha1ServiceManifest = `apiVersion: ray.io/v1alpha1
+kind: RayService
+metadata:
+  name: legacy
+  namespace: test
+spec:
+  serveConfigV2: |
+    applications: []
+  rayClusterConfig:
+    rayVersion: 2.9.0
+    headGroupSpec:
+      rayStartParams: {}
+      template:
+        spec:
+          containers:
+          - name: ray-head
+            image: rayproject/ray:2.9.0
+    workerGroupSpecs:
+    - groupName: workers
+      replicas: 1
+      minReplicas: 1
+      maxReplicas: 2
+      rayStartParams: {}
+      template:
+        spec:
+          containers:
+          - name: ray-worker
+            image: rayproject/ray:2.9.0
+`
+
+func TestFromManifestToApiServiceV1alpha1(t *testing.T) {
+	service, err := FromManifestToApiService(v1alpha1ServiceManifest)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", service.Name)
+	assert.Equal(t, "2.9.0", service.Version)
+	assert.Equal(t, "applications: []\n", service.ServeConfig_V2)
+	require.Len(t, service.ClusterSpec.WorkerGroupSpec, 1)
+	assert.Equal(t, "workers", service.ClusterSpec.WorkerGroupSpec[0].GroupName)
+
+	// The same manifest in ray.io/v1 converts to the same service.
+	expected, err := FromManifestToApiService(strings.Replace(v1alpha1ServiceManifest, "ray.io/v1alpha1", "ray.io/v1", 1))
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(expected, service))
+
+	// The unknown fields are rejected against the v1alpha1 schema, which lacks the fields added in v1.
+	_, err = FromManifestToApiService(strings.Replace(v1alpha1ServiceManifest, "  serveConfigV2:", "  paused: true\n  serveConfigV2:", 1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse the manifest")
+
+	_, err = FromManifestToApiService(strings.Replace(v1alpha1ServiceManifest, "ray.io/v1alpha1", "ray.io/v2", 1))
+	require.EqualError(t, err, "expected a ray.io/v1 or ray.io/v1alpha1 RayService manifest, got ray.io/v2 RayService")
+}
+
+func TestFromAPIToKubeBackupObjectsV1alpha1(t *testing.T) {
+	bundle := &api.BackupBundle{Resources: []*api.BackupResource{
+		{Kind: BackupKindRayCluster, Name: "legacy", Manifest: `{"apiVersion":"ray.io/v1alpha1","kind":"RayCluster","metadata":{"name":"legacy"},"spec":{"rayVersion":"2.9.0"}}`},
+		{Kind: BackupKindRayJob, Name: "untyped", Manifest: `{"metadata":{"name":"untyped"},"spec":{"entrypoint":"python job.py"}}`},
+		{Kind: BackupKindRayService, Name: "future", Manifest: `{"apiVersion":"ray.io/v2","kind":"RayService","metadata":{"name":"future"}}`},
+	}}
+	_, err := FromAPIToKubeBackupObjects(bundle)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported API version "ray.io/v2"`)
+
+	bundle.Resources = bundle.Resources[:2]
+	objects, err := FromAPIToKubeBackupObjects(bundle)
+	require.NoError(t, err)
+	require.Len(t, objects.Clusters, 1)
+	assert.Equal(t, "ray.io/v1", objects.Clusters[0].APIVersion)
+	assert.Equal(t, "RayCluster", objects.Clusters[0].Kind)
+	assert.Equal(t, "2.9.0", objects.Clusters[0].Spec.RayVersion)
+	require.Len(t, objects.Jobs, 1)
+	assert.Equal(t, "python job.py", objects.Jobs[0].Spec.Entrypoint)
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// FromKubeToAPIRayCronJob converts the ConfigMap storing a RayCronJob. The runs are used to report the
+// active ones, and may be nil when they are not needed.
+func FromKubeToAPIRayCronJob(configMap *corev1.ConfigMap, runs []*rayv1api.RayJob) (*api.RayCronJob, error) {
+	jobTemplate := &api.RayJob{}
+	if err := protojson.Unmarshal([]byte(configMap.Data[util.RayCronJobJobTemplateKey]), jobTemplate); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the job template of cron job %s: %w", configMap.Name, err)
+	}
+	successfulRunsHistoryLimit, _ := strconv.ParseInt(configMap.Data[util.RayCronJobSuccessfulRunsHistoryLimitKey], 10, 32)
+	failedRunsHistoryLimit, _ := strconv.ParseInt(configMap.Data[util.RayCronJobFailedRunsHistoryLimitKey], 10, 32)
+
+	cronJob := &api.RayCronJob{
+		Name:                       configMap.Name,
+		Namespace:                  configMap.Namespace,
+		User:                       configMap.Labels[util.RayClusterUserLabelKey],
+		Schedule:                   configMap.Data[util.RayCronJobScheduleKey],
+		ConcurrencyPolicy:          api.RayCronJob_ConcurrencyPolicy(api.RayCronJob_ConcurrencyPolicy_value[configMap.Data[util.RayCronJobConcurrencyPolicyKey]]),
+		SuccessfulRunsHistoryLimit: int32(successfulRunsHistoryLimit),
+		FailedRunsHistoryLimit:     int32(failedRunsHistoryLimit),
+		JobTemplate:                jobTemplate,
+		CreatedAt:                  &timestamppb.Timestamp{Seconds: configMap.CreationTimestamp.Unix()},
+	}
+
+	// Runs are scheduled from the creation time until the first one is created.
+	scheduledFrom := configMap.CreationTimestamp.Time
+	if lastScheduleTime, err := time.Parse(time.RFC3339, configMap.Annotations[util.RayCronJobLastScheduleTimeAnnotationKey]); err == nil {
+		cronJob.LastScheduleTime = timestamppb.New(lastScheduleTime)
+		scheduledFrom = lastScheduleTime
+	}
+	if schedule, err := util.ParseCronSchedule(cronJob.Schedule); err == nil {
+		if next := schedule.Next(scheduledFrom); !next.IsZero() {
+			cronJob.NextScheduleTime = timestamppb.New(next)
+		}
+	}
+
+	for _, run := range runs {
+		if !util.IsRayCronJobRunFinished(run) {
+			cronJob.ActiveRuns = append(cronJob.ActiveRuns, run.Name)
+		}
+	}
+	return cronJob, nil
+}
@@ -0,0 +1,134 @@
+package model
+
+import (
+	"sort"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// FleetStateUnknown is the state of the resources which were not reconciled by the operator yet.
+const FleetStateUnknown = "unknown"
+
+// fleetSummaryBuilder accumulates the summary of a namespace, or of all the namespaces.
+type fleetSummaryBuilder struct {
+	summary *api.NamespaceSummary
+	cpu     resource.Quantity
+	memory  resource.Quantity
+	gpu     resource.Quantity
+	tpu     resource.Quantity
+}
+
+func newFleetSummaryBuilder(namespace string) *fleetSummaryBuilder {
+	return &fleetSummaryBuilder{
+		summary: &api.NamespaceSummary{
+			Namespace: namespace,
+			Clusters:  &api.ResourceStateCounts{States: map[string]int32{}},
+			Jobs:      &api.ResourceStateCounts{States: map[string]int32{}},
+			Services:  &api.ResourceStateCounts{States: map[string]int32{}},
+			Resources: &api.FleetResourceUsage{},
+		},
+	}
+}
+
+func (b *fleetSummaryBuilder) addCluster(cluster *rayv1api.RayCluster) {
+	countState(b.summary.Clusters, string(cluster.Status.State))
+	b.cpu.Add(cluster.Status.DesiredCPU)
+	b.memory.Add(cluster.Status.DesiredMemory)
+	b.gpu.Add(cluster.Status.DesiredGPU)
+	b.tpu.Add(cluster.Status.DesiredTPU)
+	b.summary.Resources.ReadyWorkerReplicas += cluster.Status.ReadyWorkerReplicas
+}
+
+func (b *fleetSummaryBuilder) build() *api.NamespaceSummary {
+	b.summary.Resources.Cpu = b.cpu.String()
+	b.summary.Resources.Memory = b.memory.String()
+	b.summary.Resources.Gpu = b.gpu.String()
+	b.summary.Resources.Tpu = b.tpu.String()
+	return b.summary
+}
+
+func countState(counts *api.ResourceStateCounts, state string) {
+	if state == "" {
+		state = FleetStateUnknown
+	}
+	counts.Total++
+	counts.States[state]++
+}
+
+// FromKubeToAPIFleetSummary summarizes the clusters, jobs and services per namespace and per state. Only the
+// namespaces with at least one resource are part of the summary.
+func FromKubeToAPIFleetSummary(clusters []*rayv1api.RayCluster, jobs []*rayv1api.RayJob, services []*rayv1api.RayService) *api.FleetSummary {
+	total := newFleetSummaryBuilder("")
+	namespaces := map[string]*fleetSummaryBuilder{}
+	builderFor := func(namespace string) *fleetSummaryBuilder {
+		if _, ok := namespaces[namespace]; !ok {
+			namespaces[namespace] = newFleetSummaryBuilder(namespace)
+		}
+		return namespaces[namespace]
+	}
+
+	for _, cluster := range clusters {
+		total.addCluster(cluster)
+		builderFor(cluster.Namespace).addCluster(cluster)
+	}
+	for _, job := range jobs {
+		countState(total.summary.Jobs, string(job.Status.JobDeploymentStatus))
+		countState(builderFor(job.Namespace).summary.Jobs, string(job.Status.JobDeploymentStatus))
+	}
+	for _, service := range services {
+		countState(total.summary.Services, string(service.Status.ServiceStatus))
+		countState(builderFor(service.Namespace).summary.Services, string(service.Status.ServiceStatus))
+	}
+
+	summary := &api.FleetSummary{Total: total.build()}
+	for _, builder := range namespaces {
+		summary.Namespaces = append(summary.Namespaces, builder.build())
+	}
+	sort.Slice(summary.Namespaces, func(i, j int) bool {
+		return summary.Namespaces[i].Namespace < summary.Namespaces[j].Namespace
+	})
+	return summary
+}
+
+// FromCrdToApiClusterSearchResult converts a RayCluster found by a search. The owner of a Cluster is the RayJob or
+// RayService it was created for.
+func FromCrdToApiClusterSearchResult(cluster *rayv1api.RayCluster) *api.SearchResult {
+	result := newSearchResult("RayCluster", cluster.ObjectMeta, string(cluster.Status.State))
+	if owner := metav1.GetControllerOf(cluster); owner != nil && (owner.Kind == string(utils.RayJobCRD) || owner.Kind == string(utils.RayServiceCRD)) {
+		result.OwnerKind, result.OwnerName = owner.Kind, owner.Name
+	}
+	return result
+}
+
+// FromCrdToApiJobSearchResult converts a RayJob found by a search. The owner of a RayJob is the cron job which
+// scheduled it.
+func FromCrdToApiJobSearchResult(job *rayv1api.RayJob) *api.SearchResult {
+	result := newSearchResult("RayJob", job.ObjectMeta, string(job.Status.JobStatus))
+	if cronJob := job.Labels[util.RayCronJobLabelKey]; cronJob != "" {
+		result.OwnerKind, result.OwnerName = "RayCronJob", cronJob
+	}
+	return result
+}
+
+// FromCrdToApiServiceSearchResult converts a RayService found by a search.
+func FromCrdToApiServiceSearchResult(service *rayv1api.RayService) *api.SearchResult {
+	return newSearchResult("RayService", service.ObjectMeta, string(service.Status.ServiceStatus))
+}
+
+func newSearchResult(kind string, meta metav1.ObjectMeta, state string) *api.SearchResult {
+	return &api.SearchResult{
+		Kind:      kind,
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		User:      meta.Labels[util.RayClusterUserLabelKey],
+		State:     state,
+		CreatedAt: &timestamppb.Timestamp{Seconds: meta.CreationTimestamp.Unix()},
+	}
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"testing"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestFromKubeToAPIFleetSummary(t *testing.T) {
+	clusters := []*rayv1api.RayCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "team-b"},
+			Status: rayv1api.RayClusterStatus{
+				State:               rayv1api.Ready,
+				DesiredCPU:          resource.MustParse("2500m"),
+				DesiredMemory:       resource.MustParse("4Gi"),
+				DesiredGPU:          resource.MustParse("1"),
+				ReadyWorkerReplicas: 2,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "team-a"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "suspended", Namespace: "team-b"},
+			Status: rayv1api.RayClusterStatus{
+				State:         rayv1api.Suspended,
+				DesiredCPU:    resource.MustParse("1"),
+				DesiredMemory: resource.MustParse("2Gi"),
+			},
+		},
+	}
+	jobs := []*rayv1api.RayJob{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "team-a"},
+			Status:     rayv1api.RayJobStatus{JobDeploymentStatus: rayv1api.JobDeploymentStatusRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "complete", Namespace: "team-a"},
+			Status:     rayv1api.RayJobStatus{JobDeploymentStatus: rayv1api.JobDeploymentStatusComplete},
+		},
+	}
+	services := []*rayv1api.RayService{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "team-c"},
+			Status:     rayv1api.RayServiceStatuses{ServiceStatus: rayv1api.Running},
+		},
+	}
+
+	summary := FromKubeToAPIFleetSummary(clusters, jobs, services)
+
+	assert.Equal(t, "", summary.Total.Namespace)
+	assert.Equal(t, int32(3), summary.Total.Clusters.Total)
+	assert.Equal(t, map[string]int32{"ready": 1, "suspended": 1, FleetStateUnknown: 1}, summary.Total.Clusters.States)
+	assert.Equal(t, map[string]int32{"Running": 1, "Complete": 1}, summary.Total.Jobs.States)
+	assert.Equal(t, map[string]int32{"Running": 1}, summary.Total.Services.States)
+	assert.Equal(t, "3500m", summary.Total.Resources.Cpu)
+	assert.Equal(t, "6Gi", summary.Total.Resources.Memory)
+	assert.Equal(t, "1", summary.Total.Resources.Gpu)
+	assert.Equal(t, "0", summary.Total.Resources.Tpu)
+	assert.Equal(t, int32(2), summary.Total.Resources.ReadyWorkerReplicas)
+
+	require.Len(t, summary.Namespaces, 3)
+	assert.Equal(t, []string{"team-a", "team-b", "team-c"}, []string{
+		summary.Namespaces[0].Namespace, summary.Namespaces[1].Namespace, summary.Namespaces[2].Namespace,
+	})
+	teamA := summary.Namespaces[0]
+	assert.Equal(t, int32(1), teamA.Clusters.Total)
+	assert.Equal(t, int32(2), teamA.Jobs.Total)
+	assert.Equal(t, int32(0), teamA.Services.Total)
+	assert.Equal(t, "0", teamA.Resources.Cpu)
+	teamB := summary.Namespaces[1]
+	assert.Equal(t, &api.ResourceStateCounts{Total: 2, States: map[string]int32{"ready": 1, "suspended": 1}}, teamB.Clusters)
+	assert.Equal(t, "3500m", teamB.Resources.Cpu)
+	assert.Empty(t, summary.Namespaces[2].Clusters.States)
+}
+
+func TestFromKubeToAPIFleetSummaryEmpty(t *testing.T) {
+	summary := FromKubeToAPIFleetSummary(nil, nil, nil)
+	assert.Equal(t, int32(0), summary.Total.Clusters.Total)
+	assert.Equal(t, "0", summary.Total.Resources.Memory)
+	assert.Empty(t, summary.Namespaces)
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func FromDatastoreToAPIHistory(records []*datastore.Record) []*api.ResourceHistoryEntry {
+	entries := make([]*api.ResourceHistoryEntry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, &api.ResourceHistoryEntry{
+			Time:           timestamppb.New(record.Time),
+			Kind:           record.Kind,
+			Namespace:      record.Namespace,
+			Name:           record.Name,
+			Action:         record.Action,
+			User:           record.User,
+			TargetCluster:  record.TargetCluster,
+			RequestId:      record.RequestID,
+			IdempotencyKey: record.IdempotencyKey,
+			Payload:        record.Payload,
+		})
+	}
+	return entries
+}
+
+// FromApiToDatastoreArchivedJob returns the datastore entry of an archived job, which keeps the job as JSON.
+func FromApiToDatastoreArchivedJob(job *api.ArchivedRayJob) (*datastore.ArchivedJob, error) {
+	snapshot, err := protojson.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the archived job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	return &datastore.ArchivedJob{
+		UID:           job.Uid,
+		Namespace:     job.Namespace,
+		Name:          job.Name,
+		User:          job.User,
+		TargetCluster: job.TargetCluster,
+		JobStatus:     job.JobStatus,
+		EndTime:       job.EndTime.AsTime(),
+		Snapshot:      string(snapshot),
+	}, nil
+}
+
+func FromDatastoreToAPIArchivedJobs(jobs []*datastore.ArchivedJob) ([]*api.ArchivedRayJob, error) {
+	apiJobs := make([]*api.ArchivedRayJob, 0, len(jobs))
+	for _, job := range jobs {
+		apiJob := &api.ArchivedRayJob{}
+		if err := protojson.Unmarshal([]byte(job.Snapshot), apiJob); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the archived job %s/%s: %w", job.Namespace, job.Name, err)
+		}
+		apiJobs = append(apiJobs, apiJob)
+	}
+	return apiJobs, nil
+}
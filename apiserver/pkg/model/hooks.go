@@ -0,0 +1,122 @@
+package model
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// ConversionHooks transform the clusters, jobs and services converted between the API messages and the custom
+// resources, e.g. to redact the values of environment variables for some callers or to add the annotations of an
+// organization to every resource. They are registered by the main package of a build of the API server, so that its
+// conversions can be customized without changing the model package.
+type ConversionHooks struct {
+	// Name identifies the hooks in the errors.
+	Name string
+	// ToApi is called with every *api.Cluster, *api.RayJob and *api.RayService converted from a custom resource,
+	// before it is returned to the caller of ctx. It can modify the message.
+	ToApi func(ctx context.Context, message proto.Message)
+	// ToCrd is called with every *rayv1api.RayCluster, *rayv1api.RayJob and *rayv1api.RayService converted from
+	// the API message of a create or update call of the caller of ctx, before it is sent to Kubernetes. It can modify
+	// the object, an error rejects the call. A *util.UserError chooses the code of the error, the other errors are
+	// internal errors.
+	ToCrd func(ctx context.Context, message proto.Message, object metav1.Object) error
+}
+
+var (
+	conversionHooksLock sync.RWMutex
+	conversionHooks     []*ConversionHooks
+)
+
+// convertedMessages are the API messages passed to the ToApi hooks.
+var convertedMessages = map[protoreflect.FullName]bool{
+	"proto.Cluster":    true,
+	"proto.RayJob":     true,
+	"proto.RayService": true,
+}
+
+// RegisterConversionHooks registers hooks, which are called after the hooks registered before them. It returns a
+// function unregistering the hooks, e.g. at the end of a test.
+func RegisterConversionHooks(hooks ConversionHooks) func() {
+	registered := &hooks
+	conversionHooksLock.Lock()
+	defer conversionHooksLock.Unlock()
+	conversionHooks = append(conversionHooks, registered)
+	return func() {
+		conversionHooksLock.Lock()
+		defer conversionHooksLock.Unlock()
+		for i, h := range conversionHooks {
+			if h == registered {
+				conversionHooks = append(conversionHooks[:i:i], conversionHooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func registeredConversionHooks() []*ConversionHooks {
+	conversionHooksLock.RLock()
+	defer conversionHooksLock.RUnlock()
+	return conversionHooks
+}
+
+// ApplyToApiHooks calls the ToApi hooks with the clusters, jobs and services of a response, e.g. every cluster of
+// a ListClustersResponse.
+func ApplyToApiHooks(ctx context.Context, response proto.Message) {
+	hooks := registeredConversionHooks()
+	if len(hooks) == 0 || response == nil {
+		return
+	}
+	applyToApiHooks(ctx, hooks, response.ProtoReflect())
+}
+
+func applyToApiHooks(ctx context.Context, hooks []*ConversionHooks, message protoreflect.Message) {
+	if convertedMessages[message.Descriptor().FullName()] {
+		for _, h := range hooks {
+			if h.ToApi != nil {
+				h.ToApi(ctx, message.Interface())
+			}
+		}
+		return
+	}
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsMap():
+			if field.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					applyToApiHooks(ctx, hooks, value.Message())
+					return true
+				})
+			}
+		case field.Message() == nil:
+		case field.IsList():
+			for i := 0; i < value.List().Len(); i++ {
+				applyToApiHooks(ctx, hooks, value.List().Get(i).Message())
+			}
+		default:
+			applyToApiHooks(ctx, hooks, value.Message())
+		}
+		return true
+	})
+}
+
+// ApplyToCrdHooks calls the ToCrd hooks with a custom resource converted from an API message.
+func ApplyToCrdHooks(ctx context.Context, message proto.Message, object metav1.Object) error {
+	for _, h := range registeredConversionHooks() {
+		if h.ToCrd == nil {
+			continue
+		}
+		if err := h.ToCrd(ctx, message, object); err != nil {
+			if _, ok := err.(*util.UserError); ok {
+				return util.Wrapf(err, "Conversion hooks %q rejected %s", h.Name, object.GetName())
+			}
+			return util.NewInternalServerError(err, "Conversion hooks %q failed for %s", h.Name, object.GetName())
+		}
+	}
+	return nil
+}
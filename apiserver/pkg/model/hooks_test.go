@@ -0,0 +1,70 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestConversionHooks(t *testing.T) {
+	ctx := context.Background()
+	unregisterRedaction := RegisterConversionHooks(ConversionHooks{
+		Name: "redact-env",
+		ToApi: func(_ context.Context, message proto.Message) {
+			if cluster, ok := message.(*api.Cluster); ok {
+				for key := range cluster.GetClusterSpec().GetHeadGroupSpec().GetEnvironment().GetValues() {
+					cluster.ClusterSpec.HeadGroupSpec.Environment.Values[key] = "<redacted>"
+				}
+			}
+		},
+	})
+	unregisterAnnotations := RegisterConversionHooks(ConversionHooks{
+		Name: "org-annotations",
+		ToCrd: func(_ context.Context, _ proto.Message, object metav1.Object) error {
+			if object.GetName() == "forbidden" {
+				return util.NewPermissionDeniedError(errors.New("forbidden"), "Resource %s is forbidden", object.GetName())
+			}
+			if object.GetName() == "broken" {
+				return errors.New("broken")
+			}
+			object.SetAnnotations(map[string]string{"example.com/org": "ml"})
+			return nil
+		},
+	})
+
+	response := &api.ListClustersResponse{Clusters: []*api.Cluster{{
+		Name: "cluster",
+		ClusterSpec: &api.ClusterSpec{HeadGroupSpec: &api.HeadGroupSpec{
+			Environment: &api.EnvironmentVariables{Values: map[string]string{"TOKEN": "secret"}},
+		}},
+	}}}
+	ApplyToApiHooks(ctx, response)
+	assert.Equal(t, "<redacted>", response.Clusters[0].ClusterSpec.HeadGroupSpec.Environment.Values["TOKEN"])
+
+	rayJob := &rayv1api.RayJob{ObjectMeta: metav1.ObjectMeta{Name: "job"}}
+	require.NoError(t, ApplyToCrdHooks(ctx, &api.RayJob{Name: "job"}, rayJob))
+	assert.Equal(t, "ml", rayJob.Annotations["example.com/org"])
+	err := ApplyToCrdHooks(ctx, &api.RayJob{}, &rayv1api.RayJob{ObjectMeta: metav1.ObjectMeta{Name: "forbidden"}})
+	assert.Equal(t, codes.PermissionDenied, status.Code(util.ToGRPCError(err)))
+	err = ApplyToCrdHooks(ctx, &api.RayJob{}, &rayv1api.RayJob{ObjectMeta: metav1.ObjectMeta{Name: "broken"}})
+	assert.Equal(t, codes.Internal, status.Code(util.ToGRPCError(err)))
+
+	// Unregistered hooks are not applied anymore.
+	unregisterRedaction()
+	unregisterAnnotations()
+	response.Clusters[0].ClusterSpec.HeadGroupSpec.Environment.Values["TOKEN"] = "secret"
+	ApplyToApiHooks(ctx, response)
+	assert.Equal(t, "secret", response.Clusters[0].ClusterSpec.HeadGroupSpec.Environment.Values["TOKEN"])
+	assert.NoError(t, ApplyToCrdHooks(ctx, &api.RayJob{}, &rayv1api.RayJob{ObjectMeta: metav1.ObjectMeta{Name: "broken"}}))
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// FromCrdToApiClusterManifest serializes a RayCluster as a YAML manifest. Unless includeStatus is set, the status
+// and the server populated metadata are removed, so that the manifest can be applied to any Kubernetes cluster.
+func FromCrdToApiClusterManifest(cluster *rayv1api.RayCluster, includeStatus bool) (*api.ResourceManifest, error) {
+	cluster = cluster.DeepCopy()
+	cluster.TypeMeta = metav1.TypeMeta{APIVersion: rayv1api.GroupVersion.String(), Kind: BackupKindRayCluster}
+	return toAPIManifest(cluster, &cluster.TypeMeta, &cluster.ObjectMeta, includeStatus)
+}
+
+// FromCrdToApiServiceManifest serializes a RayService as a YAML manifest, like FromCrdToApiClusterManifest.
+func FromCrdToApiServiceManifest(service *rayv1api.RayService, includeStatus bool) (*api.ResourceManifest, error) {
+	service = service.DeepCopy()
+	service.TypeMeta = metav1.TypeMeta{APIVersion: rayv1api.GroupVersion.String(), Kind: BackupKindRayService}
+	return toAPIManifest(service, &service.TypeMeta, &service.ObjectMeta, includeStatus)
+}
+
+// FromManifestToApiService converts the YAML or JSON manifest of a RayService of one of the RayAPIVersions to the API
+// model. Unknown fields are rejected, so that a typo in a manifest is not silently dropped, and the output only fields
+// are not set.
+func FromManifestToApiService(manifest string) (*api.RayService, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal([]byte(manifest), &typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest: %w", err)
+	}
+	if !slices.Contains(RayAPIVersions, typeMeta.APIVersion) || typeMeta.Kind != BackupKindRayService {
+		return nil, fmt.Errorf("expected a %s %s manifest, got %s %s", strings.Join(RayAPIVersions, " or "), BackupKindRayService, typeMeta.APIVersion, typeMeta.Kind)
+	}
+	service := &rayv1api.RayService{}
+	if err := unmarshalRayObject(typeMeta.APIVersion, []byte(manifest), service, true); err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest: %w", err)
+	}
+	if service.Name == "" {
+		return nil, errors.New("the manifest has no metadata.name")
+	}
+
+	apiService := FromCrdToApiService(service, nil)
+	if apiService == nil {
+		// The converter recovers from the specs it does not support, e.g. worker groups without min replicas.
+		return nil, errors.New("the RayService can not be converted, its worker groups must set the replicas, min replicas and max replicas")
+	}
+	apiService.Version = service.Spec.RayClusterSpec.RayVersion
+	apiService.RayServiceStatus = nil
+	apiService.CreatedAt = nil
+	apiService.DeleteAt = nil
+	apiService.ResourceVersion = ""
+	return apiService, nil
+}
+
+func toAPIManifest(obj interface{}, typeMeta *metav1.TypeMeta, meta *metav1.ObjectMeta, includeStatus bool) (*api.ResourceManifest, error) {
+	// The managed fields are only useful to the Kubernetes API server.
+	meta.ManagedFields = nil
+	if !includeStatus {
+		*meta = metav1.ObjectMeta{
+			Name:        meta.Name,
+			Namespace:   meta.Namespace,
+			Labels:      meta.Labels,
+			Annotations: meta.Annotations,
+		}
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s: %w", typeMeta.Kind, meta.Name, err)
+	}
+	if !includeStatus {
+		// The status and the zero creation timestamp are not omitted by the JSON serialization.
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s %s: %w", typeMeta.Kind, meta.Name, err)
+		}
+		delete(fields, "status")
+		if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "creationTimestamp")
+		}
+		if data, err = json.Marshal(fields); err != nil {
+			return nil, fmt.Errorf("failed to marshal %s %s: %w", typeMeta.Kind, meta.Name, err)
+		}
+	}
+	manifest, err := yaml.JSONToYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s to YAML: %w", typeMeta.Kind, meta.Name, err)
+	}
+
+	return &api.ResourceManifest{
+		ApiVersion: typeMeta.APIVersion,
+		Kind:       typeMeta.Kind,
+		Name:       meta.Name,
+		Namespace:  meta.Namespace,
+		Manifest:   string(manifest),
+	}, nil
+}
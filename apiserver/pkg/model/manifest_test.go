@@ -0,0 +1,105 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestFromCrdToApiClusterManifest(t *testing.T) {
+	cluster := ClusterSpecTest.DeepCopy()
+	cluster.UID = "uid"
+	cluster.ResourceVersion = "42"
+	cluster.CreationTimestamp = metav1.Now()
+	cluster.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+	cluster.Status.State = rayv1api.Ready
+
+	manifest, err := FromCrdToApiClusterManifest(cluster, false)
+	require.NoError(t, err)
+	assert.Equal(t, "ray.io/v1", manifest.ApiVersion)
+	assert.Equal(t, "RayCluster", manifest.Kind)
+	assert.Equal(t, "raycluster-sample", manifest.Name)
+	assert.Equal(t, "default", manifest.Namespace)
+	fields := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(manifest.Manifest), &fields))
+	assert.NotContains(t, fields, "status")
+	assert.Equal(t, map[string]interface{}{
+		"name":        "raycluster-sample",
+		"namespace":   "default",
+		"annotations": map[string]interface{}{"kubernetes.io/ingress.class": "nginx"},
+	}, fields["metadata"])
+	// The cluster itself is not changed.
+	assert.Equal(t, "42", cluster.ResourceVersion)
+
+	exported := &rayv1api.RayCluster{}
+	require.NoError(t, yaml.UnmarshalStrict([]byte(manifest.Manifest), exported))
+	assert.True(t, equality.Semantic.DeepEqual(ClusterSpecTest.Spec, exported.Spec))
+	assert.Equal(t, ClusterSpecTest.Annotations, exported.Annotations)
+
+	manifest, err = FromCrdToApiClusterManifest(cluster, true)
+	require.NoError(t, err)
+	assert.Contains(t, manifest.Manifest, "state: ready")
+	assert.Contains(t, manifest.Manifest, "resourceVersion: \"42\"")
+	assert.NotContains(t, manifest.Manifest, "managedFields:")
+}
+
+func TestFromManifestToApiService(t *testing.T) {
+	service := ServiceV2Test.DeepCopy()
+	service.Spec.RayClusterSpec.RayVersion = "2.9.0"
+	manifest, err := FromCrdToApiServiceManifest(service, false)
+	require.NoError(t, err)
+
+	apiService, err := FromManifestToApiService(manifest.Manifest)
+	require.NoError(t, err)
+	expected := FromCrdToApiService(service.DeepCopy(), nil)
+	assert.Equal(t, "test", apiService.Name)
+	assert.Equal(t, "test", apiService.Namespace)
+	assert.Equal(t, "user", apiService.User)
+	assert.Equal(t, "2.9.0", apiService.Version)
+	assert.Equal(t, "Some yaml value", apiService.ServeConfig_V2)
+	assert.True(t, proto.Equal(expected.ClusterSpec, apiService.ClusterSpec))
+	assert.Nil(t, apiService.RayServiceStatus)
+	assert.Nil(t, apiService.CreatedAt)
+
+	tests := []struct {
+		name     string
+		manifest string
+		err      string
+	}{
+		{
+			name:     "not a RayService",
+			manifest: strings.Replace(manifest.Manifest, "kind: RayService", "kind: RayCluster", 1),
+			err:      "expected a ray.io/v1 or ray.io/v1alpha1 RayService manifest, got ray.io/v1 RayCluster",
+		},
+		{
+			name:     "unknown field",
+			manifest: strings.Replace(manifest.Manifest, "serveConfigV2:", "serveConfig:", 1),
+			err:      "failed to parse the manifest",
+		},
+		{
+			name:     "without name",
+			manifest: "apiVersion: ray.io/v1\nkind: RayService\nmetadata:\n  namespace: test\n",
+			err:      "the manifest has no metadata.name",
+		},
+		{
+			name:     "worker group without min replicas",
+			manifest: "apiVersion: ray.io/v1\nkind: RayService\nmetadata:\n  name: test\nspec:\n  rayClusterConfig:\n    workerGroupSpecs:\n    - groupName: workers\n",
+			err:      "the RayService can not be converted",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := FromManifestToApiService(tc.manifest)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
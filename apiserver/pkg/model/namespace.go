@@ -0,0 +1,18 @@
+package model
+
+import (
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// FromKubeToAPINamespace converts a namespace, without the resources of the namespace template.
+func FromKubeToAPINamespace(namespace *corev1.Namespace) *api.Namespace {
+	return &api.Namespace{
+		Name:        namespace.Name,
+		Labels:      namespace.Labels,
+		Annotations: namespace.Annotations,
+		Phase:       string(namespace.Status.Phase),
+		CreatedAt:   &timestamppb.Timestamp{Seconds: namespace.CreationTimestamp.Unix()},
+	}
+}
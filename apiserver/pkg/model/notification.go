@@ -0,0 +1,30 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// FromKubeToAPINotificationSubscription converts the ConfigMap storing a notification subscription. Unknown
+// events are dropped.
+func FromKubeToAPINotificationSubscription(configMap *corev1.ConfigMap) *api.NotificationSubscription {
+	subscription := &api.NotificationSubscription{
+		Name:      configMap.Name,
+		Namespace: configMap.Namespace,
+		User:      configMap.Labels[util.RayClusterUserLabelKey],
+		Url:       configMap.Data[util.NotificationSubscriptionURLKey],
+		CreatedAt: &timestamppb.Timestamp{Seconds: configMap.CreationTimestamp.Unix()},
+	}
+	if events := configMap.Data[util.NotificationSubscriptionEventsKey]; events != "" {
+		for _, name := range strings.Split(events, ",") {
+			if event, ok := api.NotificationSubscription_Event_value[name]; ok && event != 0 {
+				subscription.Events = append(subscription.Events, api.NotificationSubscription_Event(event))
+			}
+		}
+	}
+	return subscription
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// FromKubeToAPIServiceTemplate converts the ConfigMap storing a service template.
+func FromKubeToAPIServiceTemplate(configMap *corev1.ConfigMap) (*api.ServiceTemplate, error) {
+	service := &api.RayService{}
+	if err := protojson.Unmarshal([]byte(configMap.Data[util.ServiceTemplateServiceKey]), service); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the ray service of service template %s: %w", configMap.Name, err)
+	}
+
+	return &api.ServiceTemplate{
+		Name:        configMap.Name,
+		Namespace:   configMap.Namespace,
+		User:        configMap.Labels[util.RayClusterUserLabelKey],
+		Description: configMap.Data[util.ServiceTemplateDescriptionKey],
+		Service:     service,
+		CreatedAt:   &timestamppb.Timestamp{Seconds: configMap.CreationTimestamp.Unix()},
+	}, nil
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// FromCrdToApiSession converts the RayCluster of an interactive session. The address of the session proxy is set by
+// the caller.
+func FromCrdToApiSession(cluster *rayv1api.RayCluster) *api.RaySession {
+	return &api.RaySession{
+		Name:           cluster.Name,
+		Namespace:      cluster.Namespace,
+		User:           cluster.Labels[util.RayClusterUserLabelKey],
+		Version:        cluster.Labels[util.RayClusterVersionLabelKey],
+		ClusterSpec:    PopulateRayClusterSpec(cluster.Spec),
+		IdleTtlSeconds: util.TTLFromAnnotations(cluster.Annotations, util.RayClusterIdleTTLAnnotationKey),
+		State:          string(cluster.Status.State),
+		SessionId:      cluster.Namespace + "/" + cluster.Name,
+		CreatedAt:      &timestamppb.Timestamp{Seconds: cluster.CreationTimestamp.Unix()},
+	}
+}
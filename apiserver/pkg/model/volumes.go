@@ -1,16 +1,25 @@
 package model
 
 import (
+	"slices"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// PopulateVolumes converts the volumes of a pod template, except the shared memory and the scratch volumes.
 func PopulateVolumes(podTemplate *corev1.PodTemplateSpec) []*api.Volume {
 	if len(podTemplate.Spec.Volumes) == 0 {
 		return nil
 	}
+	scratchVolumes := util.ScratchVolumeNames(podTemplate.Annotations)
+	injections := convertSecretInjections(podTemplate.Annotations)
 	var volumes []*api.Volume
 	for _, vol := range podTemplate.Spec.Volumes {
+		if util.IsSharedMemoryVolume(&vol) || slices.Contains(scratchVolumes, vol.Name) || util.IsInjectedSecretVolume(&vol, injections) {
+			continue
+		}
 		mount := GetVolumeMount(podTemplate, vol.Name)
 		if vol.VolumeSource.ConfigMap != nil {
 			volume := api.Volume{
@@ -104,6 +113,37 @@ func PopulateVolumes(podTemplate *corev1.PodTemplateSpec) []*api.Volume {
 	return volumes
 }
 
+// PopulateSharedMemorySize returns the size of the shared memory of a pod template, empty if the operator sizes it.
+func PopulateSharedMemorySize(podTemplate *corev1.PodTemplateSpec) string {
+	for _, vol := range podTemplate.Spec.Volumes {
+		if util.IsSharedMemoryVolume(&vol) && vol.EmptyDir.SizeLimit != nil {
+			return vol.EmptyDir.SizeLimit.String()
+		}
+	}
+	return ""
+}
+
+// PopulateScratchVolumes converts the scratch volumes of a pod template.
+func PopulateScratchVolumes(podTemplate *corev1.PodTemplateSpec) []*api.ScratchVolume {
+	var volumes []*api.ScratchVolume
+	for _, name := range util.ScratchVolumeNames(podTemplate.Annotations) {
+		index := slices.IndexFunc(podTemplate.Spec.Volumes, func(vol corev1.Volume) bool { return vol.Name == name })
+		if index < 0 || podTemplate.Spec.Volumes[index].EmptyDir == nil {
+			continue
+		}
+		emptyDir := podTemplate.Spec.Volumes[index].EmptyDir
+		volume := &api.ScratchVolume{Name: name, InMemory: emptyDir.Medium == corev1.StorageMediumMemory}
+		if mount := GetVolumeMount(podTemplate, name); mount != nil {
+			volume.MountPath = mount.MountPath
+		}
+		if emptyDir.SizeLimit != nil {
+			volume.Size = emptyDir.SizeLimit.String()
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
+
 func GetVolumeMount(podTemplate *corev1.PodTemplateSpec, vol string) *corev1.VolumeMount {
 	for _, container := range podTemplate.Spec.Containers {
 		for _, mount := range container.VolumeMounts {
@@ -5,6 +5,11 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -198,3 +203,29 @@ func TestPopulateVolumes(t *testing.T) {
 		}
 	}
 }
+
+func TestPopulateMemoryVolumes(t *testing.T) {
+	volumes := []*api.Volume{{Name: "data", MountPath: "/data", VolumeType: api.Volume_EMPTY_DIR, Storage: "1Gi"}}
+	scratchVolumes := []*api.ScratchVolume{
+		{Name: "spill", MountPath: "/tmp/spill", Size: "60Gi"},
+		{Name: "cache", MountPath: "/cache", Size: "4Gi", InMemory: true},
+	}
+	template, err := util.NewWorkerPodTemplate("2.9.0", &api.WorkerGroupSpec{
+		GroupName:        "workers",
+		ComputeTemplate:  "template",
+		Volumes:          volumes,
+		SharedMemorySize: "8Gi",
+		ScratchVolumes:   scratchVolumes,
+	}, &api.ComputeTemplate{Name: "template", Cpu: 2, Memory: 16})
+	require.NoError(t, err)
+
+	// The shared memory and the scratch volumes are not returned as volumes of the group.
+	assert.True(t, proto.Equal(volumes[0], PopulateVolumes(template)[0]))
+	assert.Len(t, PopulateVolumes(template), 1)
+	assert.Equal(t, "8Gi", PopulateSharedMemorySize(template))
+	populated := PopulateScratchVolumes(template)
+	require.Len(t, populated, 2)
+	for i, volume := range scratchVolumes {
+		assert.True(t, proto.Equal(volume, populated[i]), "got %v, expected %v", populated[i], volume)
+	}
+}
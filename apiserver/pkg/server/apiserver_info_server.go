@@ -0,0 +1,33 @@
+package server
+
+import (
+	"context"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+type ApiServerInfoServerOptions struct {
+	CollectMetrics bool
+}
+
+// implements `type ApiServerInfoServiceServer interface` in apiserver_info_grpc.pb.go
+// ApiServerInfoServer is the server API for ApiServerInfoService service.
+type ApiServerInfoServer struct {
+	infoStore manager.ApiServerInfoStore
+	options   *ApiServerInfoServerOptions
+	api.UnimplementedApiServerInfoServiceServer
+}
+
+func NewApiServerInfoServer(infoStore manager.ApiServerInfoStore, options *ApiServerInfoServerOptions) *ApiServerInfoServer {
+	return &ApiServerInfoServer{infoStore: infoStore, options: options}
+}
+
+func (s *ApiServerInfoServer) GetApiServerInfo(ctx context.Context, _ *api.GetApiServerInfoRequest) (*api.ApiServerInfo, error) {
+	info, err := s.infoStore.GetApiServerInfo(ctx)
+	if err != nil {
+		return nil, util.Wrap(err, "Get API server info failed.")
+	}
+	return info, nil
+}
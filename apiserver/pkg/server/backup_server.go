@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+type BackupServerOptions struct {
+	CollectMetrics bool
+}
+
+// implements `type BackupServiceServer interface` in backup_grpc.pb.go
+// BackupServer is the server API for BackupService.
+type BackupServer struct {
+	backupStore manager.BackupStore
+	options     *BackupServerOptions
+	api.UnimplementedBackupServiceServer
+}
+
+func (s *BackupServer) ExportBackup(ctx context.Context, request *api.ExportBackupRequest) (*api.BackupBundle, error) {
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	objects, err := s.backupStore.ExportBackup(ctx, request.Namespace, request.IncludeSecrets)
+	if err != nil {
+		return nil, util.Wrap(err, "Export backup failed.")
+	}
+
+	bundle, err := model.FromKubeToAPIBackupBundle(request.Namespace, time.Now(), objects)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create the backup bundle for namespace %s", request.Namespace)
+	}
+	return bundle, nil
+}
+
+func (s *BackupServer) ImportBackup(ctx context.Context, request *api.ImportBackupRequest) (*api.ImportBackupResponse, error) {
+	if err := ValidateImportBackupRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate import backup request failed.")
+	}
+
+	objects, err := model.FromAPIToKubeBackupObjects(request.Bundle)
+	if err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to read the backup bundle")
+	}
+
+	restored, skipped, err := s.backupStore.ImportBackup(ctx, request.Namespace, request.Bundle.Namespace, objects)
+	if err != nil {
+		return nil, util.Wrap(err, "Import backup failed.")
+	}
+
+	return &api.ImportBackupResponse{
+		RestoredResources: restored,
+		SkippedResources:  skipped,
+	}, nil
+}
+
+func ValidateImportBackupRequest(request *api.ImportBackupRequest) error {
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.Bundle == nil || len(request.Bundle.Resources) == 0 {
+		return util.NewInvalidInputError("Backup bundle is empty. Please specify a valid value.")
+	}
+
+	for _, resource := range request.Bundle.Resources {
+		if resource.Name == "" || resource.Manifest == "" {
+			return util.NewInvalidInputError("Backup resource of kind %s has an empty name or manifest.", resource.Kind)
+		}
+	}
+
+	return nil
+}
+
+func NewBackupServer(backupStore manager.BackupStore, options *BackupServerOptions) *BackupServer {
+	return &BackupServer{backupStore: backupStore, options: options}
+}
@@ -0,0 +1,16 @@
+package server
+
+import (
+	"google.golang.org/grpc/status"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// maxBatchSize is the maximum number of resources created or deleted by a batch call.
+const maxBatchSize = 500
+
+// batchItemError returns the gRPC status code and the message of the error of an item of a batch call.
+func batchItemError(err error) (int32, string) {
+	st := status.Convert(util.ToGRPCError(err))
+	return int32(st.Code()), st.Message()
+}
@@ -2,14 +2,24 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
 
+	"github.com/ray-project/kuberay/apiserver/pkg/features"
 	"github.com/ray-project/kuberay/apiserver/pkg/manager"
 	"github.com/ray-project/kuberay/apiserver/pkg/model"
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	klog "k8s.io/klog/v2"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
 type ClusterServerOptions struct {
@@ -19,11 +29,18 @@ type ClusterServerOptions struct {
 // implements `type ClusterServiceServer interface` in cluster_grpc.pb.go
 // ClusterServer is the server API for ClusterService service.
 type ClusterServer struct {
-	resourceManager *manager.ResourceManager
-	options         *ClusterServerOptions
+	clusterStore manager.ClusterStore
+	eventSource  manager.EventSource
+	options      *ClusterServerOptions
 	api.UnimplementedClusterServiceServer
+
+	dashboardClientFunc func() utils.RayDashboardClientInterface
+	dialContext         func(ctx context.Context, network string, address string) (net.Conn, error)
 }
 
+// defaultConnectivityTimeout is the timeout of every check of TestRayClusterConnectivity.
+const defaultConnectivityTimeout = 5 * time.Second
+
 // Creates a new Cluster.
 func (s *ClusterServer) CreateCluster(ctx context.Context, request *api.CreateClusterRequest) (*api.Cluster, error) {
 	if err := ValidateCreateClusterRequest(request); err != nil {
@@ -32,86 +49,186 @@ func (s *ClusterServer) CreateCluster(ctx context.Context, request *api.CreateCl
 
 	// use the namespace in the request to override the namespace in the cluster definition
 	request.Cluster.Namespace = request.Namespace
+	if request.Cluster.Name == "" {
+		request.Cluster.Name = util.GenerateName(request.Cluster.GenerateName)
+	}
 
-	cluster, err := s.resourceManager.CreateCluster(ctx, request.Cluster)
+	capacityWarnings, err := s.clusterStore.CheckNodeCapacity(ctx, request.Namespace, request.Cluster.ClusterSpec, nil)
 	if err != nil {
 		return nil, util.Wrap(err, "Create Cluster failed.")
 	}
-	events, err := s.resourceManager.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
+	cluster, err := s.clusterStore.CreateCluster(ctx, request.Cluster, request.DryRun, request.IdempotencyKey)
 	if err != nil {
-		klog.Warningf("Failed to get cluster's event, cluster: %s/%s, err: %v", cluster.Namespace, cluster.Name, err)
+		return nil, util.Wrap(err, "Create Cluster failed.")
+	}
+	warnings := append(ClusterSpecWarnings(request.Cluster.ClusterSpec), capacityWarnings...)
+	if request.DryRun {
+		// The cluster was not persisted, so it has no events.
+		apiCluster := model.FromCrdToApiCluster(cluster, nil)
+		apiCluster.Warnings = warnings
+		return apiCluster, nil
+	}
+	events, err := s.eventSource.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the cluster", "cluster", klog.KObj(cluster))
 	}
 
-	return model.FromCrdToApiCluster(cluster, events), nil
+	apiCluster := model.FromCrdToApiCluster(cluster, events)
+	apiCluster.Warnings = warnings
+	return apiCluster, nil
 }
 
 // Finds a specific Cluster by cluster name.
 func (s *ClusterServer) GetCluster(ctx context.Context, request *api.GetClusterRequest) (*api.Cluster, error) {
 	if request.Name == "" {
-		return nil, util.NewInvalidInputError("Cluster name is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
 	}
 
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	eventFilter, err := NewEventFilter(request.EventType, request.EventsSince, request.EventLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateResourceView(request.View); err != nil {
+		return nil, err
 	}
 
-	cluster, err := s.resourceManager.GetCluster(ctx, request.Name, request.Namespace)
+	cluster, err := s.clusterStore.GetCluster(ctx, request.Name, request.Namespace)
 	if err != nil {
 		return nil, util.Wrap(err, "Get cluster failed.")
 	}
-	events, err := s.resourceManager.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
+	if request.View == api.ResourceView_BASIC {
+		return model.FromCrdToApiCluster(cluster, nil), nil
+	}
+	events, err := s.eventSource.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
 	if err != nil {
-		klog.Warningf("Failed to get cluster's event, cluster: %s/%s, err: %v", cluster.Namespace, cluster.Name, err)
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the cluster", "cluster", klog.KObj(cluster))
 	}
 
-	return model.FromCrdToApiCluster(cluster, events), nil
+	apiCluster := model.FromCrdToApiCluster(cluster, eventFilter.Apply(events))
+	s.addPodSummaries(ctx, []*rayv1api.RayCluster{cluster}, []*api.Cluster{apiCluster})
+	return apiCluster, nil
 }
 
 // Finds all Clusters in a given namespace.
-// TODO: Supports pagination and sorting on certain fields when we have DB support. request needs to be extended.
+// TODO: Supports sorting on certain fields when we have DB support. request needs to be extended.
 func (s *ClusterServer) ListCluster(ctx context.Context, request *api.ListClustersRequest) (*api.ListClustersResponse, error) {
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if err := ValidatePageSize(request.PageSize); err != nil {
+		return nil, err
+	}
+	if err := ValidateSelector(request.LabelSelector, request.FieldSelector); err != nil {
+		return nil, err
+	}
+	eventFilter, err := NewEventFilter(request.EventType, request.EventsSince, request.EventLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateResourceView(request.View); err != nil {
+		return nil, err
+	}
+	listFilter, err := NewListFilter(request.Filter, manager.ClusterFilterFields)
+	if err != nil {
+		return nil, err
 	}
+	selector := manager.ResourceSelector{LabelSelector: request.LabelSelector, FieldSelector: request.FieldSelector}
 
-	clusters, err := s.resourceManager.ListClusters(ctx, request.Namespace)
+	clusters, nextPageToken, err := s.clusterStore.ListClusters(ctx, request.Namespace, request.PageToken, int64(request.PageSize), selector)
 	if err != nil {
 		return nil, util.Wrap(err, "List clusters failed.")
 	}
+	clusters = listFilter.Apply(clusters)
 	clusterEventMap := make(map[string][]corev1.Event)
-	for _, cluster := range clusters {
-		clusterEvents, err := s.resourceManager.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
-		if err != nil {
-			klog.Warningf("Failed to get cluster's event, cluster: %s/%s, err: %v", cluster.Namespace, cluster.Name, err)
-			continue
+	if request.View != api.ResourceView_BASIC {
+		for _, cluster := range clusters {
+			clusterEvents, err := s.eventSource.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
+			if err != nil {
+				klog.FromContext(ctx).Error(err, "Failed to get the events of the cluster", "cluster", klog.KObj(cluster))
+				continue
+			}
+			clusterEventMap[cluster.Name] = eventFilter.Apply(clusterEvents)
 		}
-		clusterEventMap[cluster.Name] = clusterEvents
+	}
+
+	apiClusters := model.FromCrdToApiClusters(clusters, clusterEventMap)
+	if request.View != api.ResourceView_BASIC {
+		s.addPodSummaries(ctx, clusters, apiClusters)
 	}
 
 	return &api.ListClustersResponse{
-		Clusters: model.FromCrdToApiClusters(clusters, clusterEventMap),
+		Clusters:      apiClusters,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
+// addPodSummaries attaches the summaries of the Pods of the clusters to their API model. The clusters are returned
+// without them if the Pods can not be listed, like without their events.
+func (s *ClusterServer) addPodSummaries(ctx context.Context, clusters []*rayv1api.RayCluster, apiClusters []*api.Cluster) {
+	if len(clusters) == 0 {
+		return
+	}
+	summaries, err := s.clusterStore.GetClusterPodSummaries(ctx, clusters)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to summarize the Pods of the clusters")
+		return
+	}
+	for i, cluster := range clusters {
+		apiClusters[i].PodSummaries = summaries[types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}]
+	}
+}
+
 // Finds all Clusters in all namespaces.
-// TODO: Supports pagination and sorting on certain fields when we have DB support. request needs to be extended.
+// TODO: Supports sorting on certain fields when we have DB support. request needs to be extended.
 func (s *ClusterServer) ListAllClusters(ctx context.Context, request *api.ListAllClustersRequest) (*api.ListAllClustersResponse, error) {
-	clusters, err := s.resourceManager.ListAllClusters(ctx)
+	if err := ValidatePageSize(request.PageSize); err != nil {
+		return nil, err
+	}
+	if err := ValidateSelector(request.LabelSelector, request.FieldSelector); err != nil {
+		return nil, err
+	}
+	eventFilter, err := NewEventFilter(request.EventType, request.EventsSince, request.EventLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateResourceView(request.View); err != nil {
+		return nil, err
+	}
+	listFilter, err := NewListFilter(request.Filter, manager.ClusterFilterFields)
+	if err != nil {
+		return nil, err
+	}
+	selector := manager.ResourceSelector{LabelSelector: request.LabelSelector, FieldSelector: request.FieldSelector}
+
+	clusters, listMeta, err := s.clusterStore.ListAllClusters(ctx, request.PageToken, int64(request.PageSize), request.ResourceVersion, selector)
 	if err != nil {
 		return nil, util.Wrap(err, "List clusters from all namespaces failed.")
 	}
+	clusters = listFilter.Apply(clusters)
 	clusterEventMap := make(map[string][]corev1.Event)
-	for _, cluster := range clusters {
-		clusterEvents, err := s.resourceManager.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
-		if err != nil {
-			klog.Warningf("Failed to get cluster's event, cluster: %s/%s, err: %v", cluster.Namespace, cluster.Name, err)
-			continue
+	if request.View != api.ResourceView_BASIC {
+		for _, cluster := range clusters {
+			clusterEvents, err := s.eventSource.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
+			if err != nil {
+				klog.FromContext(ctx).Error(err, "Failed to get the events of the cluster", "cluster", klog.KObj(cluster))
+				continue
+			}
+			clusterEventMap[cluster.Name] = eventFilter.Apply(clusterEvents)
 		}
-		clusterEventMap[cluster.Name] = clusterEvents
+	}
+
+	apiClusters := model.FromCrdToApiClusters(clusters, clusterEventMap)
+	if request.View != api.ResourceView_BASIC {
+		s.addPodSummaries(ctx, clusters, apiClusters)
 	}
 
 	return &api.ListAllClustersResponse{
-		Clusters: model.FromCrdToApiClusters(clusters, clusterEventMap),
+		Clusters:        apiClusters,
+		NextPageToken:   listMeta.Continue,
+		ResourceVersion: listMeta.ResourceVersion,
 	}, nil
 }
 
@@ -120,45 +237,760 @@ func (s *ClusterServer) ListAllClusters(ctx context.Context, request *api.ListAl
 // deleting the Cluster.
 func (s *ClusterServer) DeleteCluster(ctx context.Context, request *api.DeleteClusterRequest) (*emptypb.Empty, error) {
 	if request.Name == "" {
-		return nil, util.NewInvalidInputError("Cluster name is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
 	}
 
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.DrainTimeoutSeconds < 0 {
+		return nil, util.NewInvalidFieldError("drain_timeout_seconds", "Drain timeout must not be negative. Please specify a valid value.")
+	}
+
+	if request.Drain {
+		if err := s.clusterStore.DrainAndDeleteCluster(ctx, request.Name, request.Namespace, request.DrainTimeoutSeconds, request.Force, request.ResourceVersion); err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
 	}
 
 	// TODO: do we want to have some logics here to check cluster exist here? or put it inside resourceManager
-	if err := s.resourceManager.DeleteCluster(ctx, request.Name, request.Namespace); err != nil {
+	if err := s.clusterStore.DeleteCluster(ctx, request.Name, request.Namespace, request.Force, request.ResourceVersion); err != nil {
 		return nil, err
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// Deletes many Clusters of a namespace. A cluster which can't be deleted does not fail the others.
+func (s *ClusterServer) BatchDeleteRayClusters(ctx context.Context, request *api.BatchDeleteRayClustersRequest) (*api.BatchDeleteRayClustersResponse, error) {
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if len(request.Names) == 0 {
+		return nil, util.NewInvalidFieldError("names", "No cluster to delete. Please specify at least one cluster name.")
+	}
+	if len(request.Names) > maxBatchSize {
+		return nil, util.NewInvalidFieldError("names", "%d clusters exceed the maximum of %d clusters per batch.", len(request.Names), maxBatchSize)
+	}
+
+	results := make([]*api.BatchDeleteRayClusterResult, len(request.Names))
+	var names []string
+	var indexes []int
+	for i, name := range request.Names {
+		results[i] = &api.BatchDeleteRayClusterResult{Name: name}
+		if name == "" {
+			results[i].Code, results[i].Error = batchItemError(util.NewInvalidFieldError("names", "Cluster name %d is empty. Please specify a valid value.", i))
+			continue
+		}
+		names = append(names, name)
+		indexes = append(indexes, i)
+	}
+
+	for k, err := range s.clusterStore.BatchDeleteClusters(ctx, names, request.Namespace, request.Force) {
+		if err != nil {
+			results[indexes[k]].Code, results[indexes[k]].Error = batchItemError(err)
+		}
+	}
+	return &api.BatchDeleteRayClustersResponse{Results: results}, nil
+}
+
+// Updates the replicas, min replicas and max replicas of the worker groups of a Cluster in place.
+func (s *ClusterServer) UpdateCluster(ctx context.Context, request *api.UpdateClusterRequest) (*api.Cluster, error) {
+	if err := ValidateUpdateClusterRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate update cluster request failed.")
+	}
+
+	cluster, err := s.clusterStore.UpdateCluster(ctx, request.Cluster)
+	if err != nil {
+		return nil, util.Wrap(err, "Update cluster failed.")
+	}
+	events, err := s.eventSource.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the cluster", "cluster", klog.KObj(cluster))
+	}
+
+	return model.FromCrdToApiCluster(cluster, events), nil
+}
+
+// Returns the changes an UpdateCluster call would make to a Cluster without applying them.
+func (s *ClusterServer) PreviewClusterUpdate(ctx context.Context, request *api.UpdateClusterRequest) (*api.UpdatePreview, error) {
+	if err := ValidateUpdateClusterRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate update cluster request failed.")
+	}
+
+	preview, err := s.clusterStore.PreviewClusterUpdate(ctx, request.Cluster)
+	if err != nil {
+		return nil, util.Wrap(err, "Preview cluster update failed.")
+	}
+	return preview, nil
+}
+
+// Updates the autoscaling bounds of a worker group without changing the rest of the Cluster spec.
+func (s *ClusterServer) UpdateWorkerGroupAutoscaling(ctx context.Context, request *api.UpdateWorkerGroupAutoscalingRequest) (*api.Cluster, error) {
+	if err := ValidateUpdateWorkerGroupAutoscalingRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate update worker group autoscaling request failed.")
+	}
+
+	cluster, err := s.clusterStore.UpdateWorkerGroupAutoscaling(ctx, request)
+	if err != nil {
+		return nil, util.Wrap(err, "Update worker group autoscaling failed.")
+	}
+	events, err := s.eventSource.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the cluster", "cluster", klog.KObj(cluster))
+	}
+
+	return model.FromCrdToApiCluster(cluster, events), nil
+}
+
+// Exports a Cluster as the YAML manifest of its RayCluster custom resource.
+func (s *ClusterServer) ExportRayCluster(ctx context.Context, request *api.ExportRayClusterRequest) (*api.ResourceManifest, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	cluster, err := s.clusterStore.GetCluster(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failed.")
+	}
+	manifest, err := model.FromCrdToApiClusterManifest(cluster, request.IncludeStatus)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to export cluster (%s/%s)", request.Namespace, request.Name)
+	}
+	return manifest, nil
+}
+
+// Updates the image, the compute template and the replicas of a worker group without recreating the Cluster.
+func (s *ClusterServer) UpdateWorkerGroup(ctx context.Context, request *api.UpdateWorkerGroupRequest) (*api.Cluster, error) {
+	if err := ValidateUpdateWorkerGroupRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate update worker group request failed.")
+	}
+
+	cluster, err := s.clusterStore.UpdateWorkerGroup(ctx, request)
+	if err != nil {
+		return nil, util.Wrap(err, "Update worker group failed.")
+	}
+	events, err := s.eventSource.GetClusterEvents(ctx, cluster.Name, cluster.Namespace)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the cluster", "cluster", klog.KObj(cluster))
+	}
+
+	return model.FromCrdToApiCluster(cluster, events), nil
+}
+
+// Restarts the Pods of a worker group in a rolling fashion.
+func (s *ClusterServer) RestartWorkerGroup(ctx context.Context, request *api.RestartWorkerGroupRequest) (*api.WorkerGroupRestart, error) {
+	if err := ValidateRestartWorkerGroupRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate restart worker group request failed.")
+	}
+
+	restart, err := s.clusterStore.RestartWorkerGroup(ctx, request)
+	if err != nil {
+		return nil, util.Wrap(err, "Restart worker group failed.")
+	}
+	return restart, nil
+}
+
+// Finds the progress of the last rolling restart of a worker group.
+func (s *ClusterServer) GetWorkerGroupRestart(ctx context.Context, request *api.GetWorkerGroupRestartRequest) (*api.WorkerGroupRestart, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if request.GroupName == "" {
+		return nil, util.NewInvalidFieldError("group_name", "Worker group name is empty. Please specify a valid value.")
+	}
+
+	restart, err := s.clusterStore.GetWorkerGroupRestart(ctx, request.Name, request.Namespace, request.GroupName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get worker group restart failed.")
+	}
+	return restart, nil
+}
+
+// Scales a worker group down once the actors of the removed workers are gone.
+func (s *ClusterServer) DrainWorkerGroup(ctx context.Context, request *api.DrainWorkerGroupRequest) (*api.WorkerGroupDrain, error) {
+	if err := ValidateDrainWorkerGroupRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate drain worker group request failed.")
+	}
+
+	drain, err := s.clusterStore.DrainWorkerGroup(ctx, request)
+	if err != nil {
+		return nil, util.Wrap(err, "Drain worker group failed.")
+	}
+	return drain, nil
+}
+
+// Finds the progress of the last drain of a worker group.
+func (s *ClusterServer) GetWorkerGroupDrain(ctx context.Context, request *api.GetWorkerGroupDrainRequest) (*api.WorkerGroupDrain, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if request.GroupName == "" {
+		return nil, util.NewInvalidFieldError("group_name", "Worker group name is empty. Please specify a valid value.")
+	}
+
+	drain, err := s.clusterStore.GetWorkerGroupDrain(ctx, request.Name, request.Namespace, request.GroupName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get worker group drain failed.")
+	}
+	return drain, nil
+}
+
+// Finds the status of a specific Cluster without its spec and events.
+func (s *ClusterServer) GetClusterStatus(ctx context.Context, request *api.GetClusterStatusRequest) (*api.ClusterStatus, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	cluster, err := s.clusterStore.GetCluster(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster status failed.")
+	}
+
+	return model.FromCrdToApiClusterStatus(cluster, request.ResourceVersion), nil
+}
+
+// Streams the status of a specific Cluster when the watch starts and every time it changes, until the client
+// cancels the call or the Cluster is deleted. Only served when the WatchRPCs feature gate is enabled.
+func (s *ClusterServer) WatchClusterStatus(request *api.WatchClusterStatusRequest, stream api.ClusterService_WatchClusterStatusServer) error {
+	if !features.Enabled(features.WatchRPCs) {
+		return util.NewUnimplementedError("Watch RPCs are disabled. Enable the %s feature gate to use them.", features.WatchRPCs)
+	}
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	clusters, err := s.clusterStore.WatchCluster(stream.Context(), request.Name, request.Namespace)
+	if err != nil {
+		return util.Wrap(err, "Watch cluster status failed.")
+	}
+	var lastStatus *api.ClusterStatus
+	for cluster := range clusters {
+		status := model.FromCrdToApiClusterStatus(cluster, "")
+		if lastStatus != nil && clusterStatusEqual(lastStatus, status) {
+			continue
+		}
+		if err := stream.Send(status); err != nil {
+			return err
+		}
+		lastStatus = status
+	}
+	return nil
+}
+
+// clusterStatusEqual returns whether two statuses of a Cluster only differ in their resource version and last
+// update time, which change on every update of the Cluster.
+func clusterStatusEqual(a *api.ClusterStatus, b *api.ClusterStatus) bool {
+	a = proto.Clone(a).(*api.ClusterStatus)
+	b = proto.Clone(b).(*api.ClusterStatus)
+	a.ResourceVersion, b.ResourceVersion = "", ""
+	a.LastUpdateTime, b.LastUpdateTime = nil, nil
+	return proto.Equal(a, b)
+}
+
+// Checks that the dashboard and the Ray client server of a Cluster can be reached from the API server.
+func (s *ClusterServer) TestRayClusterConnectivity(ctx context.Context, request *api.TestRayClusterConnectivityRequest) (*api.RayClusterConnectivity, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.TimeoutSeconds < 0 {
+		return nil, util.NewInvalidFieldError("timeout_seconds", "Timeout seconds can not be negative.")
+	}
+
+	cluster, err := s.clusterStore.GetCluster(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Test cluster connectivity failed.")
+	}
+	headServiceName, err := utils.GenerateHeadServiceName(utils.RayClusterCRD, cluster.Spec, cluster.Name)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the head service name of cluster %s/%s.", cluster.Namespace, cluster.Name)
+	}
+	host := fmt.Sprintf("%s.%s.svc.%s", headServiceName, cluster.Namespace, utils.GetClusterDomainName())
+
+	timeout := defaultConnectivityTimeout
+	if request.TimeoutSeconds > 0 {
+		timeout = time.Duration(request.TimeoutSeconds) * time.Second
+	}
+	// The dashboard of a cluster with dashboard auth rejects the requests without its auth token.
+	var authToken string
+	if cluster.Spec.DashboardAuth != nil {
+		if authToken, err = s.clusterStore.GetDashboardAuthToken(ctx, cluster.Name, cluster.Namespace); err != nil {
+			return nil, util.Wrap(err, "Test cluster connectivity failed.")
+		}
+	}
+	connectivity := &api.RayClusterConnectivity{Name: cluster.Name, Namespace: cluster.Namespace}
+	dashboardAddress := net.JoinHostPort(host, clusterPort(cluster, utils.DashboardPortName, utils.DefaultDashboardPort))
+	connectivity.Dashboard, connectivity.RayVersion, connectivity.RayCommit = s.testDashboard(ctx, dashboardAddress, authToken, timeout)
+	clientAddress := net.JoinHostPort(host, clusterPort(cluster, utils.ClientPortName, utils.DefaultClientPort))
+	connectivity.Client = s.testClient(ctx, clientAddress, timeout)
+	return connectivity, nil
+}
+
+// Returns the endpoints of a Cluster, resolved from its Kubernetes Services and Ingress.
+func (s *ClusterServer) GetRayClusterEndpoints(ctx context.Context, request *api.GetRayClusterEndpointsRequest) (*api.RayEndpoints, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	endpoints, err := s.clusterStore.GetClusterEndpoints(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster endpoints failed.")
+	}
+	return endpoints, nil
+}
+
+// Checks whether the Pods of a cluster spec, or the replicas of a compute template, are likely to be scheduled.
+func (s *ClusterServer) CanSchedule(ctx context.Context, request *api.CanScheduleRequest) (*api.SchedulingAdvice, error) {
+	if err := ValidateCanScheduleRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate can schedule request failed.")
+	}
+
+	advice, err := s.clusterStore.CanSchedule(ctx, request.Namespace, request.ClusterSpec, request.ComputeTemplate, request.Replicas)
+	if err != nil {
+		return nil, util.Wrap(err, "Can schedule failed.")
+	}
+	return advice, nil
+}
+
+// Injects a failure into a Cluster. Only served when the FailureInjection feature gate is enabled.
+func (s *ClusterServer) InjectClusterFailure(ctx context.Context, request *api.InjectClusterFailureRequest) (*api.ClusterFailureInjection, error) {
+	if !features.Enabled(features.FailureInjection) {
+		return nil, util.NewUnimplementedError("Failure injection is disabled. Enable the %s feature gate to use it.", features.FailureInjection)
+	}
+	if err := ValidateInjectClusterFailureRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate inject cluster failure request failed.")
+	}
+
+	pods, err := s.clusterStore.InjectClusterFailure(ctx, request)
+	if err != nil {
+		return nil, util.Wrap(err, "Inject cluster failure failed.")
+	}
+	klog.FromContext(ctx).Info("Injected a failure into the cluster", "type", request.Type, "pods", pods)
+	return &api.ClusterFailureInjection{Pods: pods}, nil
+}
+
+// Removes the network partitions injected into a Cluster. Only served when the FailureInjection feature gate is
+// enabled.
+func (s *ClusterServer) HealClusterPartitions(ctx context.Context, request *api.HealClusterPartitionsRequest) (*api.ClusterFailureInjection, error) {
+	if !features.Enabled(features.FailureInjection) {
+		return nil, util.NewUnimplementedError("Failure injection is disabled. Enable the %s feature gate to use it.", features.FailureInjection)
+	}
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	pods, err := s.clusterStore.HealClusterPartitions(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Heal cluster partitions failed.")
+	}
+	klog.FromContext(ctx).Info("Healed the partitions of the cluster", "pods", pods)
+	return &api.ClusterFailureInjection{Pods: pods}, nil
+}
+
+// Creates a Cluster from the spec of an existing Cluster or ray service with the overrides of the request. The clone
+// is created by CreateCluster, so it is validated against the compute templates and policies of the time.
+func (s *ClusterServer) CloneRayCluster(ctx context.Context, request *api.CloneRayClusterRequest) (*api.Cluster, error) {
+	if err := ValidateCloneRayClusterRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate clone cluster request failed.")
+	}
+
+	clone, err := s.clusterStore.GetClusterClone(ctx, request)
+	if err != nil {
+		return nil, util.Wrap(err, "Clone cluster failed.")
+	}
+	return s.CreateCluster(ctx, &api.CreateClusterRequest{
+		Cluster:   clone,
+		Namespace: request.Namespace,
+		DryRun:    request.DryRun,
+	})
+}
+
+// testDashboard requests the version of the Ray dashboard, and returns the Ray version and commit it reports. The auth
+// token is only sent if it is not empty.
+func (s *ClusterServer) testDashboard(ctx context.Context, address string, authToken string, timeout time.Duration) (*api.EndpointConnectivity, string, string) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result := &api.EndpointConnectivity{Address: address}
+	dashboardClient := s.dashboardClientFunc()
+	if err := dashboardClient.InitClient(ctx, address, nil); err != nil {
+		result.Error = err.Error()
+		return result, "", ""
+	}
+	if timeoutSetter, ok := dashboardClient.(utils.RayDashboardClientTimeoutSetter); ok {
+		timeoutSetter.SetTimeout(timeout)
+	}
+	if tokenSetter, ok := dashboardClient.(utils.RayDashboardClientAuthTokenSetter); ok && authToken != "" {
+		tokenSetter.SetAuthToken(authToken)
+	}
+	start := time.Now()
+	versionInfo, err := dashboardClient.GetVersion(ctx)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result, "", ""
+	}
+	result.Reachable = true
+	return result, versionInfo.RayVersion, versionInfo.RayCommit
+}
+
+// testClient opens a TCP connection to the Ray client server. The Ray client protocol is not spoken, so a
+// reachable client server may still reject Ray clients, e.g. of another Ray version.
+func (s *ClusterServer) testClient(ctx context.Context, address string, timeout time.Duration) *api.EndpointConnectivity {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result := &api.EndpointConnectivity{Address: address}
+	start := time.Now()
+	conn, err := s.dialContext(ctx, "tcp", address)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	conn.Close()
+	result.Reachable = true
+	return result
+}
+
+// clusterPort returns the port of the head service with the given name, as reported in the cluster status.
+func clusterPort(cluster *rayv1api.RayCluster, portName string, defaultPort int) string {
+	if port, ok := cluster.Status.Endpoints[portName]; ok && port != "" {
+		return port
+	}
+	return strconv.Itoa(defaultPort)
+}
+
 func ValidateCreateClusterRequest(request *api.CreateClusterRequest) error {
+	var errs util.ValidationErrors
 	if request.Namespace == "" {
-		return util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		errs.Add(util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value."))
 	}
 
-	if request.Namespace != request.Cluster.Namespace {
-		return util.NewInvalidInputError("The namespace in the request is different from the namespace in the cluster definition.")
+	if request.Cluster == nil {
+		errs.Add(util.NewInvalidFieldError("cluster", "Cluster is empty, please input a valid payload."))
+		return errs.Err()
 	}
 
-	if request.Cluster.Name == "" {
-		return util.NewInvalidInputError("Cluster name is empty. Please specify a valid value.")
+	if request.Namespace != "" && request.Namespace != request.Cluster.Namespace {
+		errs.Add(util.NewInvalidFieldError("cluster.namespace", "The namespace in the request is different from the namespace in the cluster definition."))
 	}
 
+	nameErr := ValidateResourceName("Cluster", request.Cluster.Name, request.Cluster.GenerateName, maxClusterNameLength)
+	errs.AddField("cluster", nameErr)
+
 	if request.Cluster.User == "" {
-		return util.NewInvalidInputError("User who create the cluster is empty. Please specify a valid value.")
+		errs.Add(util.NewInvalidFieldError("cluster.user", "User who create the cluster is empty. Please specify a valid value."))
 	}
 
+	// The images and the generated names are only checked against a valid cluster spec, and the generated names against
+	// a valid name.
 	if err := ValidateClusterSpec(request.Cluster.ClusterSpec); err != nil {
-		return err
+		errs.AddField("cluster.cluster_spec", err)
+	} else {
+		errs.AddField("cluster.cluster_spec", ValidateClusterImages(request.Cluster.Version, request.Cluster.ClusterSpec))
+		if nameErr == nil {
+			name, field := resourceName(request.Cluster.Name, request.Cluster.GenerateName)
+			errs.AddField("cluster."+field, ValidateGeneratedNames(name, request.Cluster.ClusterSpec))
+		}
+	}
+	errs.AddField("cluster.queueing", ValidateQueueingOptions(request.Cluster.Queueing))
+	if request.Cluster.IdleTtlSeconds < 0 {
+		errs.Add(util.NewInvalidFieldError("cluster.idle_ttl_seconds", "Idle TTL %d is negative. Please specify a valid value.", request.Cluster.IdleTtlSeconds))
+	}
+	errs.AddField("idempotency_key", ValidateIdempotencyKey(request.IdempotencyKey))
+
+	return errs.Err()
+}
+
+func ValidateUpdateClusterRequest(request *api.UpdateClusterRequest) error {
+	if request == nil {
+		return util.NewInvalidInputError("A non nill request is expected")
+	}
+
+	var errs util.ValidationErrors
+	if request.Namespace == "" {
+		errs.Add(util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value."))
+	}
+
+	if request.Name == "" {
+		errs.Add(util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value."))
+	}
+
+	if request.Cluster == nil {
+		errs.Add(util.NewInvalidFieldError("cluster", "Cluster is empty, please input a valid payload."))
+		return errs.Err()
+	}
+
+	if request.Namespace != "" && request.Namespace != request.Cluster.Namespace {
+		errs.Add(util.NewInvalidFieldError("cluster.namespace", "The namespace in the request is different from the namespace in the cluster definition."))
+	}
+
+	if request.Name != "" && request.Name != request.Cluster.Name {
+		errs.Add(util.NewInvalidFieldError("cluster.name", "The name in the request is different from the name in the cluster definition."))
+	}
+
+	if request.Cluster.ClusterSpec == nil {
+		errs.Add(util.NewInvalidFieldError("cluster.cluster_spec", "Cluster spec is empty. Please specify a valid value."))
+		return errs.Err()
+	}
+
+	for index, spec := range request.Cluster.ClusterSpec.WorkerGroupSpec {
+		field := fmt.Sprintf("cluster.cluster_spec.worker_group_spec[%d]", index)
+		if spec.MinReplicas < 0 {
+			errs.Add(util.NewInvalidFieldError(field+".min_replicas", "WorkerNodeSpec %d MinReplicas can not be negative. Please specify a valid value.", index))
+		} else if spec.MaxReplicas == 0 {
+			errs.Add(util.NewInvalidFieldError(field+".max_replicas", "WorkerNodeSpec %d MaxReplicas can not be 0. Please specify a valid value.", index))
+		} else if spec.MinReplicas > spec.MaxReplicas {
+			errs.Add(util.NewInvalidFieldError(field+".min_replicas", "WorkerNodeSpec %d MinReplica > MaxReplicas. Please specify a valid value.", index))
+		} else if spec.Replicas < spec.MinReplicas || spec.Replicas > spec.MaxReplicas {
+			errs.Add(util.NewInvalidFieldError(field+".replicas", "WorkerNodeSpec %d Replicas is not between MinReplicas and MaxReplicas. Please specify a valid value.", index))
+		}
+	}
+
+	return errs.Err()
+}
+
+func ValidateUpdateWorkerGroupAutoscalingRequest(request *api.UpdateWorkerGroupAutoscalingRequest) error {
+	if request == nil {
+		return util.NewInvalidInputError("A non nill request is expected")
+	}
+
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.GroupName == "" {
+		return util.NewInvalidFieldError("group_name", "Worker group name is empty. Please specify a valid value.")
+	}
+
+	if request.MinReplicas < 0 {
+		return util.NewInvalidFieldError("min_replicas", "MinReplicas can not be negative. Please specify a valid value.")
+	}
+
+	if request.MaxReplicas == 0 {
+		return util.NewInvalidFieldError("max_replicas", "MaxReplicas can not be 0. Please specify a valid value.")
+	}
+
+	if request.MinReplicas > request.MaxReplicas {
+		return util.NewInvalidInputError("MinReplicas > MaxReplicas. Please specify a valid value.")
+	}
+
+	if request.IdleTimeoutSeconds < 0 {
+		return util.NewInvalidFieldError("idle_timeout_seconds", "IdleTimeoutSeconds can not be negative. Please specify a valid value.")
+	}
+
+	return nil
+}
+
+func ValidateUpdateWorkerGroupRequest(request *api.UpdateWorkerGroupRequest) error {
+	if request == nil {
+		return util.NewInvalidInputError("A non nill request is expected")
+	}
+
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.GroupName == "" {
+		return util.NewInvalidFieldError("group_name", "Worker group name is empty. Please specify a valid value.")
+	}
+
+	if request.Image == "" && request.ComputeTemplate == "" && request.ComputeTemplateRevision == 0 && request.Replicas == 0 {
+		return util.NewInvalidInputError("Nothing to update, at least one of the image, the compute template, its revision and the replicas is required.")
+	}
+
+	if request.ComputeTemplateRevision < 0 {
+		return util.NewInvalidFieldError("compute_template_revision", "Compute template revision can not be negative. Please specify a valid value.")
+	}
+
+	if request.Replicas < 0 {
+		return util.NewInvalidFieldError("replicas", "Replicas can not be negative. Please specify a valid value.")
+	}
+
+	if request.MaxUnavailable < 0 {
+		return util.NewInvalidFieldError("max_unavailable", "MaxUnavailable can not be negative. Please specify a valid value.")
 	}
 
 	return nil
 }
 
-func NewClusterServer(resourceManager *manager.ResourceManager, options *ClusterServerOptions) *ClusterServer {
-	return &ClusterServer{resourceManager: resourceManager, options: options}
+func ValidateRestartWorkerGroupRequest(request *api.RestartWorkerGroupRequest) error {
+	if request == nil {
+		return util.NewInvalidInputError("A non nill request is expected")
+	}
+
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.GroupName == "" {
+		return util.NewInvalidFieldError("group_name", "Worker group name is empty. Please specify a valid value.")
+	}
+
+	if request.MaxUnavailable < 0 {
+		return util.NewInvalidFieldError("max_unavailable", "MaxUnavailable can not be negative. Please specify a valid value.")
+	}
+
+	return nil
+}
+
+func ValidateDrainWorkerGroupRequest(request *api.DrainWorkerGroupRequest) error {
+	if request == nil {
+		return util.NewInvalidInputError("A non nill request is expected")
+	}
+
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	if request.GroupName == "" {
+		return util.NewInvalidFieldError("group_name", "Worker group name is empty. Please specify a valid value.")
+	}
+
+	if request.Replicas < 0 {
+		return util.NewInvalidFieldError("replicas", "Replicas can not be negative. Please specify a valid value.")
+	}
+
+	if request.TimeoutSeconds < 0 {
+		return util.NewInvalidFieldError("timeout_seconds", "Drain timeout can not be negative. Please specify a valid value.")
+	}
+
+	return nil
+}
+
+func ValidateCanScheduleRequest(request *api.CanScheduleRequest) error {
+	if request == nil {
+		return util.NewInvalidInputError("A non nill request is expected")
+	}
+
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if (request.ClusterSpec == nil) == (request.ComputeTemplate == "") {
+		return util.NewInvalidInputError("Either a cluster spec or a compute template is required. Please specify one of them.")
+	}
+
+	if request.Replicas < 0 {
+		return util.NewInvalidFieldError("replicas", "Replicas can not be negative. Please specify a valid value.")
+	}
+
+	if request.ClusterSpec != nil {
+		if request.Replicas != 0 {
+			return util.NewInvalidInputError("Replicas can only be set with a compute template, the cluster spec sets the replicas of its worker groups.")
+		}
+		return ValidateClusterSpec(request.ClusterSpec)
+	}
+	return nil
+}
+
+func ValidateInjectClusterFailureRequest(request *api.InjectClusterFailureRequest) error {
+	if request == nil {
+		return util.NewInvalidInputError("A non nill request is expected")
+	}
+
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+
+	switch request.Type {
+	case api.InjectClusterFailureRequest_KILL_HEAD:
+		if request.Count != 0 || request.GroupName != "" {
+			return util.NewInvalidInputError("Count and group name can not be set for KILL_HEAD. Please specify a valid value.")
+		}
+	case api.InjectClusterFailureRequest_KILL_WORKERS:
+		if request.Count < 0 {
+			return util.NewInvalidFieldError("count", "Count can not be negative. Please specify a valid value.")
+		}
+	case api.InjectClusterFailureRequest_PARTITION_WORKER:
+		if request.Count != 0 {
+			return util.NewInvalidInputError("Count can not be set for PARTITION_WORKER. Please specify a valid value.")
+		}
+	default:
+		return util.NewInvalidInputError("Failure type is not specified. Please specify a valid value.")
+	}
+
+	return nil
+}
+
+func ValidateCloneRayClusterRequest(request *api.CloneRayClusterRequest) error {
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "Cluster name is empty. Please specify a valid value.")
+	}
+	if (request.SourceCluster == "") == (request.SourceService == "") {
+		return util.NewInvalidInputError("Exactly one of source cluster and source service is expected. Please specify a valid value.")
+	}
+	for groupName, replicas := range request.WorkerGroupReplicas {
+		if groupName == "" {
+			return util.NewInvalidFieldError("worker_group_replicas", "Worker group name is empty. Please specify a valid value.")
+		}
+		if replicas < 0 {
+			return util.NewInvalidInputError("Worker replicas of group %s must not be negative.", groupName)
+		}
+	}
+	return nil
+}
+
+func NewClusterServer(clusterStore manager.ClusterStore, eventSource manager.EventSource, options *ClusterServerOptions) *ClusterServer {
+	return &ClusterServer{
+		clusterStore:        clusterStore,
+		eventSource:         eventSource,
+		options:             options,
+		dashboardClientFunc: utils.GetRayDashboardClientFunc(nil, false),
+		dialContext:         (&net.Dialer{}).DialContext,
+	}
 }
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/features"
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestTestDashboard(t *testing.T) {
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	server := &ClusterServer{
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+
+	result, _, _ := server.testDashboard(context.Background(), "cluster-head-svc.ray.svc.cluster.local:8265", "", time.Second)
+	assert.False(t, result.Reachable)
+	assert.NotEmpty(t, result.Error)
+
+	dashboardClient.SetVersion(&utils.RayVersionInfo{RayVersion: "2.9.0", RayCommit: "9be5a16"})
+	result, rayVersion, rayCommit := server.testDashboard(context.Background(), "cluster-head-svc.ray.svc.cluster.local:8265", "", time.Second)
+	assert.True(t, result.Reachable)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, "cluster-head-svc.ray.svc.cluster.local:8265", result.Address)
+	assert.Equal(t, "2.9.0", rayVersion)
+	assert.Equal(t, "9be5a16", rayCommit)
+	assert.Empty(t, dashboardClient.GetAuthToken())
+
+	// The auth token of a cluster with dashboard auth is sent with the request.
+	result, _, _ = server.testDashboard(context.Background(), "cluster-head-svc.ray.svc.cluster.local:8265", "secret-token", time.Second)
+	assert.True(t, result.Reachable)
+	assert.Equal(t, "secret-token", dashboardClient.GetAuthToken())
+}
+
+func TestTestClient(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	server := &ClusterServer{dialContext: (&net.Dialer{}).DialContext}
+
+	result := server.testClient(context.Background(), listener.Addr().String(), time.Second)
+	assert.True(t, result.Reachable)
+	assert.Empty(t, result.Error)
+
+	address := listener.Addr().String()
+	listener.Close()
+	result = server.testClient(context.Background(), address, time.Second)
+	assert.False(t, result.Reachable)
+	assert.NotEmpty(t, result.Error)
+}
+
+func TestClusterPort(t *testing.T) {
+	cluster := &rayv1api.RayCluster{}
+	assert.Equal(t, "10001", clusterPort(cluster, utils.ClientPortName, utils.DefaultClientPort))
+
+	cluster.Status.Endpoints = map[string]string{utils.ClientPortName: "10002"}
+	assert.Equal(t, "10002", clusterPort(cluster, utils.ClientPortName, utils.DefaultClientPort))
+}
+
+// fakeClusterStore serves the clusters of a map. The methods which are not overridden panic.
+type fakeClusterStore struct {
+	manager.ClusterStore
+	clusters map[string]*rayv1api.RayCluster
+}
+
+func (f *fakeClusterStore) GetCluster(_ context.Context, clusterName string, namespace string) (*rayv1api.RayCluster, error) {
+	cluster, ok := f.clusters[namespace+"/"+clusterName]
+	if !ok {
+		return nil, util.NewNotFoundError(nil, "Cluster %s not found", clusterName)
+	}
+	return cluster.DeepCopy(), nil
+}
+
+func (f *fakeClusterStore) CreateCluster(_ context.Context, apiCluster *api.Cluster, _ bool, _ string) (*rayv1api.RayCluster, error) {
+	return &rayv1api.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: apiCluster.Name, Namespace: apiCluster.Namespace},
+		Spec: rayv1api.RayClusterSpec{HeadGroupSpec: rayv1api.HeadGroupSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head"}}}},
+		}},
+	}, nil
+}
+
+func (f *fakeClusterStore) CheckNodeCapacity(context.Context, string, *api.ClusterSpec, *api.ComputeTemplate) ([]string, error) {
+	return nil, nil
+}
+
+func (f *fakeClusterStore) GetClusterPodSummaries(_ context.Context, clusters []*rayv1api.RayCluster) (map[types.NamespacedName][]*api.GroupPodSummary, error) {
+	summaries := map[types.NamespacedName][]*api.GroupPodSummary{}
+	for _, cluster := range clusters {
+		summaries[types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}] = []*api.GroupPodSummary{{GroupName: "headgroup", Desired: 1, Pending: 1}}
+	}
+	return summaries, nil
+}
+
+// fakeEventSource returns the same events for every cluster.
+type fakeEventSource struct {
+	manager.EventSource
+	events []corev1.Event
+	calls  int
+}
+
+func (f *fakeEventSource) GetClusterEvents(context.Context, string, string) ([]corev1.Event, error) {
+	f.calls++
+	return f.events, nil
+}
+
+func TestGetClusterWithFakeStores(t *testing.T) {
+	clusterStore := &fakeClusterStore{clusters: map[string]*rayv1api.RayCluster{
+		"team-a/cluster": {
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "team-a"},
+			Spec: rayv1api.RayClusterSpec{HeadGroupSpec: rayv1api.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head"}}}},
+			}},
+			Status: rayv1api.RayClusterStatus{State: rayv1api.Ready},
+		},
+	}}
+	eventSource := &fakeEventSource{events: []corev1.Event{{ObjectMeta: metav1.ObjectMeta{Name: "event"}, Reason: "Created"}}}
+	server := NewClusterServer(clusterStore, eventSource, &ClusterServerOptions{})
+
+	cluster, err := server.GetCluster(context.Background(), &api.GetClusterRequest{Name: "cluster", Namespace: "team-a"})
+	require.NoError(t, err)
+	assert.Equal(t, "cluster", cluster.Name)
+	assert.Equal(t, string(rayv1api.Ready), cluster.ClusterState)
+	require.Len(t, cluster.Events, 1)
+	assert.Equal(t, "Created", cluster.Events[0].Reason)
+	require.Len(t, cluster.PodSummaries, 1)
+	assert.Equal(t, int32(1), cluster.PodSummaries[0].Pending)
+
+	// The basic view does not fetch the events and the Pods.
+	cluster, err = server.GetCluster(context.Background(), &api.GetClusterRequest{Name: "cluster", Namespace: "team-a", View: api.ResourceView_BASIC})
+	require.NoError(t, err)
+	assert.Equal(t, string(rayv1api.Ready), cluster.ClusterState)
+	assert.Empty(t, cluster.Events)
+	assert.Empty(t, cluster.PodSummaries)
+	assert.Equal(t, 1, eventSource.calls)
+	_, err = server.GetCluster(context.Background(), &api.GetClusterRequest{Name: "cluster", Namespace: "team-a", View: api.ResourceView(7)})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+
+	_, err = server.GetCluster(context.Background(), &api.GetClusterRequest{Name: "missing", Namespace: "team-a"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	// Invalid requests are rejected before reaching the stores.
+	_, err = server.GetCluster(context.Background(), &api.GetClusterRequest{Namespace: "team-a"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestCreateClusterWithGenerateName(t *testing.T) {
+	server := NewClusterServer(&fakeClusterStore{}, &fakeEventSource{}, &ClusterServerOptions{})
+	request := &api.CreateClusterRequest{
+		Namespace: "team-a",
+		Cluster: &api.Cluster{
+			GenerateName: "batch-",
+			Namespace:    "team-a",
+			User:         "a-user",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{ComputeTemplate: "a-template", RayStartParams: map[string]string{"dashboard-host": "0.0.0.0"}},
+			},
+		},
+		DryRun: true,
+	}
+
+	cluster, err := server.CreateCluster(context.Background(), request)
+	require.NoError(t, err)
+	assert.Regexp(t, `^batch-[a-z0-9]{5}$`, cluster.Name)
+
+	// The name takes precedence over the generate name.
+	request.Cluster.Name = "cluster"
+	cluster, err = server.CreateCluster(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "cluster", cluster.Name)
+}
+
+func TestWatchClusterStatusFeatureGate(t *testing.T) {
+	defer features.SetFeatureGateDuringTest(features.WatchRPCs, false)()
+	server := NewClusterServer(&fakeClusterStore{}, &fakeEventSource{}, &ClusterServerOptions{})
+
+	err := server.WatchClusterStatus(&api.WatchClusterStatusRequest{Name: "cluster", Namespace: "team-a"}, nil)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.Unimplemented), "The watch RPCs are not served without the feature gate")
+}
@@ -3,12 +3,17 @@ package server
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/ray-project/kuberay/apiserver/pkg/manager"
 	"github.com/ray-project/kuberay/apiserver/pkg/model"
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
 	"google.golang.org/protobuf/types/known/emptypb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type ComputeTemplateServerOptions struct {
@@ -18,8 +23,8 @@ type ComputeTemplateServerOptions struct {
 // implements `type ComputeTemplateServiceServer interface` in runtime_grpc.pb.go
 // ComputeTemplateServer is the server API for ClusterRuntimeService.
 type ComputeTemplateServer struct {
-	resourceManager *manager.ResourceManager
-	options         *ComputeTemplateServerOptions
+	templateStore manager.TemplateStore
+	options       *ComputeTemplateServerOptions
 	api.UnimplementedComputeTemplateServiceServer
 }
 
@@ -31,24 +36,34 @@ func (s *ComputeTemplateServer) CreateComputeTemplate(ctx context.Context, reque
 	// use the namespace in the request to override the namespace in the compute template definition
 	request.ComputeTemplate.Namespace = request.Namespace
 
-	runtime, err := s.resourceManager.CreateComputeTemplate(ctx, request.ComputeTemplate)
+	warnings, err := s.templateStore.CheckNodeCapacity(ctx, request.Namespace, nil, request.ComputeTemplate)
+	if err != nil {
+		return nil, util.Wrap(err, "Create compute template failed.")
+	}
+	runtime, err := s.templateStore.CreateComputeTemplate(ctx, request.ComputeTemplate)
 	if err != nil {
 		return nil, util.Wrap(err, "Create compute template failed.")
 	}
 
-	return model.FromKubeToAPIComputeTemplate(runtime), nil
+	computeTemplate := model.FromKubeToAPIComputeTemplate(runtime)
+	computeTemplate.Warnings = warnings
+	return computeTemplate, nil
 }
 
 func (s *ComputeTemplateServer) GetComputeTemplate(ctx context.Context, request *api.GetComputeTemplateRequest) (*api.ComputeTemplate, error) {
 	if request.Name == "" {
-		return nil, util.NewInvalidInputError("Compute template name is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("name", "Compute template name is empty. Please specify a valid value.")
 	}
 
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
 	}
 
-	runtime, err := s.resourceManager.GetComputeTemplate(ctx, request.Name, request.Namespace)
+	if request.Revision < 0 {
+		return nil, util.NewInvalidFieldError("revision", "Revision can not be negative. Please specify a valid value.")
+	}
+
+	runtime, err := s.templateStore.GetComputeTemplateRevision(ctx, request.Name, request.Namespace, request.Revision)
 	if err != nil {
 		return nil, util.Wrap(err, "Get compute template failed.")
 	}
@@ -56,12 +71,52 @@ func (s *ComputeTemplateServer) GetComputeTemplate(ctx context.Context, request
 	return model.FromKubeToAPIComputeTemplate(runtime), nil
 }
 
+// UpdateComputeTemplate creates a new revision of a compute template. The clusters keep the revision they were created
+// with, until their groups are rolled forward to the new revision.
+func (s *ComputeTemplateServer) UpdateComputeTemplate(ctx context.Context, request *api.UpdateComputeTemplateRequest) (*api.ComputeTemplate, error) {
+	if err := ValidateUpdateComputeTemplateRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate compute template runtime request failed.")
+	}
+
+	warnings, err := s.templateStore.CheckNodeCapacity(ctx, request.Namespace, nil, request.ComputeTemplate)
+	if err != nil {
+		return nil, util.Wrap(err, "Update compute template failed.")
+	}
+	runtime, err := s.templateStore.UpdateComputeTemplate(ctx, request.ComputeTemplate)
+	if err != nil {
+		return nil, util.Wrap(err, "Update compute template failed.")
+	}
+
+	computeTemplate := model.FromKubeToAPIComputeTemplate(runtime)
+	computeTemplate.Warnings = warnings
+	return computeTemplate, nil
+}
+
+func (s *ComputeTemplateServer) ListComputeTemplateRevisions(ctx context.Context, request *api.ListComputeTemplateRevisionsRequest) (*api.ListComputeTemplateRevisionsResponse, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Compute template name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	runtimes, err := s.templateStore.ListComputeTemplateRevisions(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("List revisions of compute template %s failed.", request.Name))
+	}
+
+	return &api.ListComputeTemplateRevisionsResponse{
+		ComputeTemplates: model.FromKubeToAPIComputeTemplates(runtimes),
+	}, nil
+}
+
 func (s *ComputeTemplateServer) ListComputeTemplates(ctx context.Context, request *api.ListComputeTemplatesRequest) (*api.ListComputeTemplatesResponse, error) {
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
 	}
 
-	runtimes, err := s.resourceManager.ListComputeTemplates(ctx, request.Namespace)
+	runtimes, err := s.templateStore.ListComputeTemplates(ctx, request.Namespace)
 	if err != nil {
 		return nil, util.Wrap(err, fmt.Sprintf("List compute templates in namespace %s failed.", request.Namespace))
 	}
@@ -72,7 +127,7 @@ func (s *ComputeTemplateServer) ListComputeTemplates(ctx context.Context, reques
 }
 
 func (s *ComputeTemplateServer) ListAllComputeTemplates(ctx context.Context, request *api.ListAllComputeTemplatesRequest) (*api.ListAllComputeTemplatesResponse, error) {
-	runtimes, err := s.resourceManager.ListAllComputeTemplates(ctx)
+	runtimes, err := s.templateStore.ListAllComputeTemplates(ctx)
 	if err != nil {
 		return nil, util.Wrap(err, "List all compute templates from all namespaces failed.")
 	}
@@ -84,23 +139,39 @@ func (s *ComputeTemplateServer) ListAllComputeTemplates(ctx context.Context, req
 
 func (s *ComputeTemplateServer) DeleteComputeTemplate(ctx context.Context, request *api.DeleteComputeTemplateRequest) (*emptypb.Empty, error) {
 	if request.Name == "" {
-		return nil, util.NewInvalidInputError("Compute template name is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("name", "Compute template name is empty. Please specify a valid value.")
 	}
 
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
 	}
 
-	if err := s.resourceManager.DeleteComputeTemplate(ctx, request.Name, request.Namespace); err != nil {
+	if err := s.templateStore.DeleteComputeTemplate(ctx, request.Name, request.Namespace); err != nil {
 		return nil, err
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+func ValidateUpdateComputeTemplateRequest(request *api.UpdateComputeTemplateRequest) error {
+	if request.ComputeTemplate == nil {
+		return util.NewInvalidFieldError("compute_template", "Compute template is empty. Please specify a valid value.")
+	}
+
+	if request.Name != request.ComputeTemplate.Name {
+		return util.NewInvalidInputError("The name in the request is different from the name defined in the compute template.")
+	}
+
+	// The revision is assigned by the update.
+	return ValidateCreateComputeTemplateRequest(&api.CreateComputeTemplateRequest{
+		ComputeTemplate: request.ComputeTemplate,
+		Namespace:       request.Namespace,
+	})
+}
+
 func ValidateCreateComputeTemplateRequest(request *api.CreateComputeTemplateRequest) error {
 	if request.Namespace == "" {
-		return util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
 	}
 
 	if request.Namespace != request.ComputeTemplate.Namespace {
@@ -108,20 +179,130 @@ func ValidateCreateComputeTemplateRequest(request *api.CreateComputeTemplateRequ
 	}
 
 	if request.ComputeTemplate.Name == "" {
-		return util.NewInvalidInputError("Compute template name is empty. Please specify a valid value.")
+		return util.NewInvalidFieldError("compute_template.name", "Compute template name is empty. Please specify a valid value.")
 	}
 
 	if request.ComputeTemplate.Cpu == 0 {
-		return util.NewInvalidInputError("Cpu amount is zero. Please specify a valid value.")
+		return util.NewInvalidFieldError("compute_template.cpu", "Cpu amount is zero. Please specify a valid value.")
 	}
 
 	if request.ComputeTemplate.Memory == 0 {
-		return util.NewInvalidInputError("Memory amount is zero. Please specify a valid value.")
+		return util.NewInvalidFieldError("compute_template.memory", "Memory amount is zero. Please specify a valid value.")
+	}
+
+	if err := validateEphemeralStorage("Compute template", request.ComputeTemplate.EphemeralStorage, request.ComputeTemplate.EphemeralStorageLimit); err != nil {
+		return err
+	}
+
+	if err := validateAccelerators(request.ComputeTemplate); err != nil {
+		return err
 	}
 
+	for _, rule := range request.ComputeTemplate.NodeAffinity {
+		if err := validateNodeAffinityRule(rule); err != nil {
+			return err
+		}
+	}
+	for _, rules := range [][]*api.PodAffinityRule{request.ComputeTemplate.PodAffinity, request.ComputeTemplate.PodAntiAffinity} {
+		for _, rule := range rules {
+			if err := validatePodAffinityRule(rule); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateAccelerators validates the extended resources of a compute template and the node selector of its accelerator
+// type. Extended resources are domain prefixed resources outside of kubernetes.io, which the nodes advertise in whole
+// numbers.
+func validateAccelerators(template *api.ComputeTemplate) error {
+	gpuResource := "nvidia.com/gpu"
+	if template.GpuAccelerator != "" {
+		gpuResource = template.GpuAccelerator
+	}
+	for name, value := range template.ExtendedResources {
+		domain, _, found := strings.Cut(name, "/")
+		if errs := validation.IsQualifiedName(name); len(errs) > 0 || !found || domain == "kubernetes.io" || strings.HasSuffix(domain, ".kubernetes.io") {
+			return util.NewInvalidInputError("Extended resource name %s is invalid, expected a domain prefixed name such as google.com/tpu. Please specify a valid value.", name)
+		}
+		if template.Gpu != 0 && name == gpuResource {
+			return util.NewInvalidInputError("Extended resource %s is already requested by the gpus of the compute template. Please specify it once.", name)
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil || quantity.Sign() <= 0 || quantity.MilliValue()%1000 != 0 {
+			return util.NewInvalidInputError("Extended resource %s quantity %q is not a positive whole number. Please specify a valid value.", name, value)
+		}
+	}
+
+	if template.AcceleratorTypeLabel != "" {
+		if errs := validation.IsQualifiedName(template.AcceleratorTypeLabel); len(errs) > 0 {
+			return util.NewInvalidInputError("Accelerator type label %s is invalid: %s", template.AcceleratorTypeLabel, strings.Join(errs, ", "))
+		}
+		if template.AcceleratorType == "" {
+			return util.NewInvalidInputError("Accelerator type label %s is set without accelerator type. Please specify a valid value.", template.AcceleratorTypeLabel)
+		}
+	}
+	if template.AcceleratorType != "" {
+		if errs := validation.IsValidLabelValue(template.AcceleratorType); len(errs) > 0 {
+			return util.NewInvalidInputError("Accelerator type %s is invalid: %s", template.AcceleratorType, strings.Join(errs, ", "))
+		}
+		label := util.AcceleratorTypeLabel(template)
+		if value, ok := template.NodeSelector[label]; ok && value != template.AcceleratorType {
+			return util.NewInvalidInputError("Node selector %s=%s conflicts with accelerator type %s. Please specify a valid value.", label, value, template.AcceleratorType)
+		}
+	}
+	return nil
+}
+
+func validateNodeAffinityRule(rule *api.NodeAffinityRule) error {
+	if rule.Weight < 0 || rule.Weight > 100 {
+		return util.NewInvalidInputError("Affinity rule weight %d is not between 0 and 100. Please specify a valid value.", rule.Weight)
+	}
+	if len(rule.MatchExpressions) == 0 {
+		return util.NewInvalidInputError("Node affinity rule has no match expressions. Please specify a valid value.")
+	}
+	for _, requirement := range rule.MatchExpressions {
+		if requirement.Key == "" {
+			return util.NewInvalidInputError("Node affinity match expression key is empty. Please specify a valid value.")
+		}
+		switch corev1.NodeSelectorOperator(requirement.Operator) {
+		case corev1.NodeSelectorOpIn, corev1.NodeSelectorOpNotIn:
+			if len(requirement.Values) == 0 {
+				return util.NewInvalidInputError("Node affinity match expression on %s needs values for operator %s.", requirement.Key, requirement.Operator)
+			}
+		case corev1.NodeSelectorOpExists, corev1.NodeSelectorOpDoesNotExist:
+			if len(requirement.Values) != 0 {
+				return util.NewInvalidInputError("Node affinity match expression on %s must not have values for operator %s.", requirement.Key, requirement.Operator)
+			}
+		case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+			if len(requirement.Values) != 1 {
+				return util.NewInvalidInputError("Node affinity match expression on %s needs a single value for operator %s.", requirement.Key, requirement.Operator)
+			}
+			if _, err := strconv.ParseInt(requirement.Values[0], 10, 64); err != nil {
+				return util.NewInvalidInputError("Node affinity match expression on %s needs an integer value for operator %s.", requirement.Key, requirement.Operator)
+			}
+		default:
+			return util.NewInvalidInputError("Node affinity match expression operator %s is invalid. Please use one of In, NotIn, Exists, DoesNotExist, Gt and Lt.", requirement.Operator)
+		}
+	}
+	return nil
+}
+
+func validatePodAffinityRule(rule *api.PodAffinityRule) error {
+	if rule.Weight < 0 || rule.Weight > 100 {
+		return util.NewInvalidInputError("Affinity rule weight %d is not between 0 and 100. Please specify a valid value.", rule.Weight)
+	}
+	if len(rule.MatchLabels) == 0 {
+		return util.NewInvalidInputError("Pod affinity rule has no match labels. Please specify a valid value.")
+	}
+	if rule.TopologyKey == "" {
+		return util.NewInvalidInputError("Pod affinity rule topology key is empty. Please specify a valid value.")
+	}
 	return nil
 }
 
-func NewComputeTemplateServer(resourceManager *manager.ResourceManager, options *ComputeTemplateServerOptions) *ComputeTemplateServer {
-	return &ComputeTemplateServer{resourceManager: resourceManager, options: options}
+func NewComputeTemplateServer(templateStore manager.TemplateStore, options *ComputeTemplateServerOptions) *ComputeTemplateServer {
+	return &ComputeTemplateServer{templateStore: templateStore, options: options}
 }
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+type RayCronJobServerOptions struct {
+	CollectMetrics bool
+}
+
+// implements `type RayCronJobServiceServer interface` in cron_job_grpc.pb.go
+// RayCronJobServer is the server API for RayCronJobService service.
+type RayCronJobServer struct {
+	cronJobStore manager.CronJobStore
+	options      *RayCronJobServerOptions
+	api.UnimplementedRayCronJobServiceServer
+}
+
+func NewRayCronJobServer(cronJobStore manager.CronJobStore, options *RayCronJobServerOptions) *RayCronJobServer {
+	return &RayCronJobServer{cronJobStore: cronJobStore, options: options}
+}
+
+func (s *RayCronJobServer) CreateRayCronJob(ctx context.Context, request *api.CreateRayCronJobRequest) (*api.RayCronJob, error) {
+	if err := ValidateCreateRayCronJobRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate cron job request failed.")
+	}
+
+	configMap, err := s.cronJobStore.CreateRayCronJob(ctx, request.CronJob)
+	if err != nil {
+		return nil, util.Wrap(err, "Create cron job failed.")
+	}
+
+	cronJob, err := model.FromKubeToAPIRayCronJob(configMap, nil)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert cron job %s/%s", configMap.Namespace, configMap.Name)
+	}
+	return cronJob, nil
+}
+
+func (s *RayCronJobServer) GetRayCronJob(ctx context.Context, request *api.GetRayCronJobRequest) (*api.RayCronJob, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Cron job name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	configMap, err := s.cronJobStore.GetRayCronJob(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cron job failed.")
+	}
+	runs, err := s.cronJobStore.ListRayCronJobRuns(ctx, request.Namespace, request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "List cron job runs failed.")
+	}
+
+	cronJob, err := model.FromKubeToAPIRayCronJob(configMap, runs)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert cron job %s/%s", configMap.Namespace, configMap.Name)
+	}
+	return cronJob, nil
+}
+
+func (s *RayCronJobServer) ListRayCronJobs(ctx context.Context, request *api.ListRayCronJobsRequest) (*api.ListRayCronJobsResponse, error) {
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	configMaps, err := s.cronJobStore.ListRayCronJobs(ctx, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "List cron jobs failed.")
+	}
+	runs, err := s.cronJobStore.ListRayCronJobRuns(ctx, request.Namespace, "")
+	if err != nil {
+		return nil, util.Wrap(err, "List cron job runs failed.")
+	}
+
+	cronJobs := make([]*api.RayCronJob, 0, len(configMaps))
+	for _, configMap := range configMaps {
+		var cronJobRuns []*rayv1api.RayJob
+		for _, run := range runs {
+			if run.Labels[util.RayCronJobLabelKey] == configMap.Name {
+				cronJobRuns = append(cronJobRuns, run)
+			}
+		}
+		cronJob, err := model.FromKubeToAPIRayCronJob(configMap, cronJobRuns)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to convert cron job %s/%s", configMap.Namespace, configMap.Name)
+		}
+		cronJobs = append(cronJobs, cronJob)
+	}
+	return &api.ListRayCronJobsResponse{CronJobs: cronJobs}, nil
+}
+
+func (s *RayCronJobServer) DeleteRayCronJob(ctx context.Context, request *api.DeleteRayCronJobRequest) (*emptypb.Empty, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Cron job name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if err := s.cronJobStore.DeleteRayCronJob(ctx, request.Name, request.Namespace); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *RayCronJobServer) ListRayCronJobRuns(ctx context.Context, request *api.ListRayCronJobRunsRequest) (*api.ListRayCronJobRunsResponse, error) {
+	if request.CronJobName == "" {
+		return nil, util.NewInvalidFieldError("cron_job_name", "Cron job name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if _, err := s.cronJobStore.GetRayCronJob(ctx, request.CronJobName, request.Namespace); err != nil {
+		return nil, util.Wrap(err, "Get cron job failed.")
+	}
+	runs, err := s.cronJobStore.ListRayCronJobRuns(ctx, request.Namespace, request.CronJobName)
+	if err != nil {
+		return nil, util.Wrap(err, "List cron job runs failed.")
+	}
+	return &api.ListRayCronJobRunsResponse{Runs: model.FromCrdToApiJobs(runs)}, nil
+}
+
+func (s *RayCronJobServer) GetRayCronJobRun(ctx context.Context, request *api.GetRayCronJobRunRequest) (*api.RayJob, error) {
+	if request.CronJobName == "" {
+		return nil, util.NewInvalidFieldError("cron_job_name", "Cron job name is empty. Please specify a valid value.")
+	}
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Run name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	run, err := s.cronJobStore.GetRayCronJobRun(ctx, request.CronJobName, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cron job run failed.")
+	}
+	return model.FromCrdToApiJob(run), nil
+}
+
+func ValidateCreateRayCronJobRequest(request *api.CreateRayCronJobRequest) error {
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.CronJob == nil {
+		return util.NewInvalidFieldError("cron_job", "Cron job is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace != request.CronJob.Namespace {
+		return util.NewInvalidInputError("The namespace in the request is different from the namespace in the cron job definition.")
+	}
+
+	if request.CronJob.Name == "" {
+		return util.NewInvalidFieldError("cron_job.name", "Cron job name is empty. Please specify a valid value.")
+	}
+
+	if len(request.CronJob.Name) > util.MaxRayCronJobNameLength {
+		return util.NewInvalidInputError("Cron job name %s is longer than %d characters.", request.CronJob.Name, util.MaxRayCronJobNameLength)
+	}
+
+	if errs := validation.IsDNS1035Label(request.CronJob.Name); len(errs) > 0 {
+		return util.NewInvalidInputError("Cron job name %s is invalid: %s", request.CronJob.Name, strings.Join(errs, ", "))
+	}
+
+	if request.CronJob.User == "" {
+		return util.NewInvalidFieldError("cron_job.user", "User who create the cron job is empty. Please specify a valid value.")
+	}
+
+	schedule, err := util.ParseCronSchedule(request.CronJob.Schedule)
+	if err != nil {
+		return util.NewInvalidInputErrorWithDetails(err, "Cron job schedule is invalid.")
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return util.NewInvalidInputError("Cron job schedule %q never fires.", request.CronJob.Schedule)
+	}
+
+	if request.CronJob.SuccessfulRunsHistoryLimit < 0 || request.CronJob.FailedRunsHistoryLimit < 0 {
+		return util.NewInvalidInputError("Cron job history limits must not be negative.")
+	}
+
+	if request.CronJob.JobTemplate == nil {
+		return util.NewInvalidFieldError("cron_job.job_template", "Cron job template is empty. Please specify a valid value.")
+	}
+
+	// Every run is submitted to Ray with its own id.
+	if request.CronJob.JobTemplate.JobId != "" {
+		return util.NewInvalidInputError("The job id of the cron job template must be empty, every run gets its own id.")
+	}
+
+	// The template is validated like a job, with the name and namespace of the runs.
+	jobTemplate := proto.Clone(request.CronJob.JobTemplate).(*api.RayJob)
+	jobTemplate.Name = request.CronJob.Name
+	jobTemplate.Namespace = request.Namespace
+	if jobTemplate.User == "" {
+		jobTemplate.User = request.CronJob.User
+	}
+	return ValidateCreateJobRequest(&api.CreateRayJobRequest{Job: jobTemplate, Namespace: request.Namespace})
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type FleetServerOptions struct {
+	CollectMetrics bool
+	// History is the datastore recording the changes of the resources, nil if it is not configured.
+	History datastore.Store
+}
+
+// implements `type FleetServiceServer interface` in fleet_grpc.pb.go
+// FleetServer is the server API for FleetService.
+type FleetServer struct {
+	clusterStore manager.ClusterStore
+	jobStore     manager.JobStore
+	serviceStore manager.ServiceStore
+	eventSource  manager.EventSource
+	collector    manager.GarbageCollector
+	searcher     manager.ResourceSearcher
+	options      *FleetServerOptions
+	api.UnimplementedFleetServiceServer
+}
+
+// GetFleetSummary lists the clusters, jobs and services in one pass, from the resource cache when it is enabled,
+// and aggregates them per namespace and per state.
+func (s *FleetServer) GetFleetSummary(ctx context.Context, request *api.GetFleetSummaryRequest) (*api.FleetSummary, error) {
+	namespace := request.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceAll
+	}
+
+	clusters, _, err := s.clusterStore.ListClusters(ctx, namespace, "", 0, manager.ResourceSelector{})
+	if err != nil {
+		return nil, util.Wrap(err, "List clusters failed.")
+	}
+	jobs, _, err := s.jobStore.ListJobs(ctx, namespace, "", 0)
+	if err != nil {
+		return nil, util.Wrap(err, "List jobs failed.")
+	}
+	services, _, err := s.serviceStore.ListServices(ctx, namespace, "", 0, manager.ResourceSelector{})
+	if err != nil {
+		return nil, util.Wrap(err, "List services failed.")
+	}
+
+	return model.FromKubeToAPIFleetSummary(clusters, jobs, services), nil
+}
+
+// GetResourceUsage reports the resources requested by the clusters per namespace, per user and per cluster, and
+// optionally their utilization.
+func (s *FleetServer) GetResourceUsage(ctx context.Context, request *api.GetResourceUsageRequest) (*api.ResourceUsageReport, error) {
+	namespace := request.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceAll
+	}
+
+	report, err := s.clusterStore.GetResourceUsage(ctx, namespace, request.IncludeUtilization)
+	if err != nil {
+		return nil, util.Wrap(err, "Get resource usage failed.")
+	}
+	return report, nil
+}
+
+// ListNamespaceRayEvents lists the events of the clusters, jobs and services of a namespace in one pass, from the
+// event cache when it is enabled, most recent first.
+func (s *FleetServer) ListNamespaceRayEvents(ctx context.Context, request *api.ListNamespaceRayEventsRequest) (*api.ListNamespaceRayEventsResponse, error) {
+	if err := ValidateListNamespaceRayEventsRequest(request); err != nil {
+		return nil, util.Wrap(err, "List namespace ray events failed.")
+	}
+
+	events, err := s.eventSource.ListNamespaceEvents(ctx, request.Namespace, request.Kinds, request.Reasons, int(request.Limit))
+	if err != nil {
+		return nil, util.Wrap(err, "List namespace ray events failed.")
+	}
+
+	return &api.ListNamespaceRayEventsResponse{
+		Events: model.FromKubeToAPIRayEvents(events),
+	}, nil
+}
+
+// ListExpiringResources lists the jobs and clusters which the garbage collection deletes once their time to live
+// expires, soonest first.
+func (s *FleetServer) ListExpiringResources(ctx context.Context, request *api.ListExpiringResourcesRequest) (*api.ListExpiringResourcesResponse, error) {
+	if request.WithinSeconds < 0 {
+		return nil, util.NewInvalidFieldError("within_seconds", "Within seconds %d is negative. Please specify a valid value.", request.WithinSeconds)
+	}
+	namespace := request.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceAll
+	}
+
+	resources, err := s.collector.ListExpiringResources(ctx, namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "List expiring resources failed.")
+	}
+	if request.WithinSeconds > 0 {
+		deadline := time.Now().Add(time.Duration(request.WithinSeconds) * time.Second)
+		resources = slices.DeleteFunc(resources, func(resource *api.ExpiringResource) bool {
+			return resource.ExpireTime.AsTime().After(deadline)
+		})
+	}
+	return &api.ListExpiringResourcesResponse{Resources: resources}, nil
+}
+
+// ListResourceHistory lists the changes made to the clusters, jobs and services through the API server, most recent
+// first.
+func (s *FleetServer) ListResourceHistory(ctx context.Context, request *api.ListResourceHistoryRequest) (*api.ListResourceHistoryResponse, error) {
+	if request.Kind != "" && !slices.Contains(manager.RayEventKinds, request.Kind) {
+		return nil, util.NewInvalidFieldError("kind", "Kind %s is not supported. Please specify one of %s.", request.Kind, strings.Join(manager.RayEventKinds, ", "))
+	}
+	if request.Limit < 0 {
+		return nil, util.NewInvalidFieldError("limit", "Limit %d is negative. Please specify a valid value.", request.Limit)
+	}
+	query := datastore.Query{
+		Kind:      request.Kind,
+		Namespace: request.Namespace,
+		Name:      request.Name,
+		User:      request.User,
+		Limit:     int(request.Limit),
+	}
+	if request.Since != nil {
+		query.Since = request.Since.AsTime()
+	}
+	return listHistory(ctx, s.options.History, query)
+}
+
+// SearchResources searches the clusters, jobs and services by their owner, state and creation time, most recently
+// created first unless another order is requested.
+func (s *FleetServer) SearchResources(ctx context.Context, request *api.SearchResourcesRequest) (*api.SearchResourcesResponse, error) {
+	query, offset, err := NewSearchQuery(request)
+	if err != nil {
+		return nil, util.Wrap(err, "Validate search resources request failed.")
+	}
+	namespace := request.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceAll
+	}
+
+	results, err := s.searcher.SearchResources(ctx, namespace, query)
+	if err != nil {
+		return nil, util.Wrap(err, "Search resources failed.")
+	}
+	response := &api.SearchResourcesResponse{TotalSize: int32(len(results))}
+	results = results[min(offset, len(results)):]
+	if request.PageSize > 0 && len(results) > int(request.PageSize) {
+		results = results[:request.PageSize]
+		response.NextPageToken = strconv.Itoa(offset + int(request.PageSize))
+	}
+	response.Results = results
+	return response, nil
+}
+
+// NewSearchQuery validates a SearchResources request, and converts it to the query of the search and the offset of
+// the requested page.
+func NewSearchQuery(request *api.SearchResourcesRequest) (manager.SearchQuery, int, error) {
+	for _, kind := range request.Kinds {
+		if !slices.Contains(manager.RayEventKinds, kind) {
+			return manager.SearchQuery{}, 0, util.NewInvalidFieldError("kinds", "Kind %s is not supported. Please specify one of %s.", kind, strings.Join(manager.RayEventKinds, ", "))
+		}
+	}
+	if err := ValidatePageSize(request.PageSize); err != nil {
+		return manager.SearchQuery{}, 0, err
+	}
+	offset := 0
+	if request.PageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(request.PageToken); err != nil || offset < 0 {
+			return manager.SearchQuery{}, 0, util.NewInvalidFieldError("page_token", "Page token %q is invalid. Please pass the next_page_token of the previous page.", request.PageToken)
+		}
+	}
+	filter, err := NewListFilter(request.Filter, manager.SearchFilterFields)
+	if err != nil {
+		return manager.SearchQuery{}, 0, err
+	}
+	orderBy, descending, err := manager.ParseSearchOrder(request.OrderBy)
+	if err != nil {
+		return manager.SearchQuery{}, 0, err
+	}
+	return manager.SearchQuery{Kinds: request.Kinds, Filter: filter, OrderBy: orderBy, Descending: descending}, offset, nil
+}
+
+func ValidateListNamespaceRayEventsRequest(request *api.ListNamespaceRayEventsRequest) error {
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	for _, kind := range request.Kinds {
+		if !slices.Contains(manager.RayEventKinds, kind) {
+			return util.NewInvalidInputError("Kind %s is not supported. Please specify one of %s.", kind, strings.Join(manager.RayEventKinds, ", "))
+		}
+	}
+	if request.Limit < 0 {
+		return util.NewInvalidFieldError("limit", "Limit %d is negative. Please specify a valid value.", request.Limit)
+	}
+	return nil
+}
+
+func NewFleetServer(clusterStore manager.ClusterStore, jobStore manager.JobStore, serviceStore manager.ServiceStore, eventSource manager.EventSource, collector manager.GarbageCollector, searcher manager.ResourceSearcher, options *FleetServerOptions) *FleetServer {
+	return &FleetServer{clusterStore: clusterStore, jobStore: jobStore, serviceStore: serviceStore, eventSource: eventSource, collector: collector, searcher: searcher, options: options}
+}
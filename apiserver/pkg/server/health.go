@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	klog "k8s.io/klog/v2"
+)
+
+// readinessCheckTimeout is the timeout of every readiness check.
+const readinessCheckTimeout = 5 * time.Second
+
+// ReadinessCheck checks a dependency which the API server needs to serve requests, e.g. the Kubernetes API server.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthChecker runs the readiness checks and reports their outcome through the gRPC health service, for the whole
+// server and for every gRPC service, and through the /readyz HTTP endpoint. The API server is not ready until the
+// checks pass for the first time.
+type HealthChecker struct {
+	server *health.Server
+	checks []ReadinessCheck
+
+	mu       sync.RWMutex
+	services []string
+	checked  bool
+	// shutdown is set once the API server is shutting down, which is never ready again.
+	shutdown bool
+	// failures are the errors of the checks which failed the last time, by check name.
+	failures map[string]string
+}
+
+func NewHealthChecker(checks ...ReadinessCheck) *HealthChecker {
+	c := &HealthChecker{server: health.NewServer(), checks: checks}
+	c.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Register registers the gRPC health service on a server, which reports the status of the services registered on the
+// server so far.
+func (c *HealthChecker) Register(s *grpc.Server) {
+	c.mu.Lock()
+	for service := range s.GetServiceInfo() {
+		c.services = append(c.services, service)
+	}
+	c.mu.Unlock()
+	healthpb.RegisterHealthServer(s, c.server)
+	c.setServingStatus()
+}
+
+// Start runs the readiness checks every interval until ctx is done.
+func (c *HealthChecker) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			c.Check(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Check runs the readiness checks and updates the serving status. It returns the errors of the failed checks, by
+// check name.
+func (c *HealthChecker) Check(ctx context.Context) map[string]string {
+	failures := map[string]string{}
+	for _, check := range c.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+		err := check.Check(checkCtx)
+		cancel()
+		if err != nil {
+			failures[check.Name] = err.Error()
+		}
+	}
+
+	c.mu.Lock()
+	for name, failure := range failures {
+		if _, failed := c.failures[name]; !failed {
+			klog.Errorf("Readiness check %s failed: %s", name, failure)
+		}
+	}
+	for name := range c.failures {
+		if _, failed := failures[name]; !failed {
+			klog.Infof("Readiness check %s passed again", name)
+		}
+	}
+	c.checked = true
+	c.failures = failures
+	c.mu.Unlock()
+	c.setServingStatus()
+	return failures
+}
+
+// Shutdown reports every service as not serving, and /readyz as failing, from now on, so that the load balancers stop
+// sending requests while the API server drains the calls in flight.
+func (c *HealthChecker) Shutdown() {
+	c.mu.Lock()
+	c.shutdown = true
+	c.mu.Unlock()
+	c.server.Shutdown()
+}
+
+// ServeReadiness answers 200 if the readiness checks passed the last time they ran, and 503 with the errors of the
+// failed checks, or whether the API server is shutting down, otherwise.
+func (c *HealthChecker) ServeReadiness(w http.ResponseWriter, _ *http.Request) {
+	c.mu.RLock()
+	ready, failures, shutdown := c.ready(), c.failures, c.shutdown
+	c.mu.RUnlock()
+	if ready {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	response := struct {
+		Failures     map[string]string `json:"failures,omitempty"`
+		ShuttingDown bool              `json:"shuttingDown,omitempty"`
+	}{Failures: failures, ShuttingDown: shutdown}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		klog.Errorf("Failed to write the failed readiness checks: %v", err)
+	}
+}
+
+func (c *HealthChecker) ready() bool {
+	return c.checked && len(c.failures) == 0 && !c.shutdown
+}
+
+func (c *HealthChecker) setServingStatus() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if c.ready() {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	c.server.SetServingStatus("", status)
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
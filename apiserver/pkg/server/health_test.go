@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestHealthChecker(t *testing.T) {
+	ctx := context.Background()
+	var kubernetesErr error
+	healthChecker := NewHealthChecker(ReadinessCheck{Name: "kubernetes", Check: func(context.Context) error { return kubernetesErr }})
+	s := grpc.NewServer()
+	api.RegisterClusterServiceServer(s, &ClusterServer{})
+	healthChecker.Register(s)
+	status := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		response, err := healthChecker.server.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return response.Status
+	}
+	readiness := func() int {
+		recorder := httptest.NewRecorder()
+		healthChecker.ServeReadiness(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return recorder.Code
+	}
+
+	// The API server is not ready before the first check.
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+	assert.Equal(t, http.StatusServiceUnavailable, readiness())
+
+	assert.Empty(t, healthChecker.Check(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status("proto.ClusterService"))
+	assert.Equal(t, http.StatusOK, readiness())
+
+	kubernetesErr = errors.New("connection refused")
+	assert.Equal(t, map[string]string{"kubernetes": "connection refused"}, healthChecker.Check(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status("proto.ClusterService"))
+	assert.Equal(t, http.StatusServiceUnavailable, readiness())
+
+	kubernetesErr = nil
+	healthChecker.Check(ctx)
+	healthChecker.Shutdown()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+	assert.Equal(t, http.StatusServiceUnavailable, readiness())
+
+	// The checks passing again during the shutdown do not make the API server ready.
+	assert.Empty(t, healthChecker.Check(ctx))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+	assert.Equal(t, http.StatusServiceUnavailable, readiness())
+}
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"context"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// listHistory lists the changes recorded in the datastore of the API server, which is nil when it is not configured.
+func listHistory(ctx context.Context, store datastore.Store, query datastore.Query) (*api.ListResourceHistoryResponse, error) {
+	if store == nil {
+		return nil, util.NewUnimplementedError("The resource history is disabled. Start the API server with --datastore and the HistoryDB feature gate enabled to record it.")
+	}
+	records, err := store.List(ctx, query)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the resource history.")
+	}
+	return &api.ListResourceHistoryResponse{Entries: model.FromDatastoreToAPIHistory(records)}, nil
+}
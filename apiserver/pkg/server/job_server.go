@@ -2,31 +2,56 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"strings"
 
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
 	"github.com/ray-project/kuberay/apiserver/pkg/manager"
 	"github.com/ray-project/kuberay/apiserver/pkg/model"
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/apimachinery/pkg/util/validation"
+	klog "k8s.io/klog/v2"
 )
 
 type JobServerOptions struct {
 	CollectMetrics bool
+	// History is the datastore archiving the finished jobs, nil if it is not configured.
+	History datastore.Store
 }
 
 // implements `type RayJobServiceServer interface` in job_grpc.pb.go
 // RayJobServer is the server API for RayJobServer service.
 
-func NewRayJobServer(resourceManager *manager.ResourceManager, options *JobServerOptions) *RayJobServer {
-	return &RayJobServer{resourceManager: resourceManager, options: options}
+func NewRayJobServer(jobStore manager.JobStore, clusterStore manager.ClusterStore, options *JobServerOptions) *RayJobServer {
+	return &RayJobServer{
+		jobStore:            jobStore,
+		clusterStore:        clusterStore,
+		options:             options,
+		dashboardClientFunc: utils.GetRayDashboardClientFunc(nil, false),
+	}
 }
 
 type RayJobServer struct {
-	resourceManager *manager.ResourceManager
-	options         *JobServerOptions
+	jobStore     manager.JobStore
+	clusterStore manager.ClusterStore
+	options      *JobServerOptions
 	api.UnimplementedRayJobServiceServer
+
+	dashboardClientFunc func() utils.RayDashboardClientInterface
 }
 
+// The sources of the logs of the output of a job.
+const (
+	jobLogSourceDashboard    = "Dashboard"
+	jobLogSourceSubmitterPod = "SubmitterPod"
+)
+
 // Creates a new Ray Job.
 func (s *RayJobServer) CreateRayJob(ctx context.Context, request *api.CreateRayJobRequest) (*api.RayJob, error) {
 	if err := ValidateCreateJobRequest(request); err != nil {
@@ -36,25 +61,106 @@ func (s *RayJobServer) CreateRayJob(ctx context.Context, request *api.CreateRayJ
 	// use the namespace in the request to override the namespace in the job definition
 	request.Job.Namespace = request.Namespace
 
-	job, err := s.resourceManager.CreateJob(ctx, request.Job)
+	if len(request.Job.ClusterSelector) != 0 {
+		if err := s.validateSelectedCluster(ctx, request.Job.ClusterSelector[utils.RayClusterLabelKey], request.Namespace); err != nil {
+			return nil, util.Wrap(err, "Validate job request failed.")
+		}
+	}
+
+	job, err := s.jobStore.CreateJob(ctx, request.Job)
 	if err != nil {
 		return nil, util.Wrap(err, "Create Job failed.")
 	}
 
-	return model.FromCrdToApiJob(job), nil
+	apiJob := model.FromCrdToApiJob(job)
+	if request.Job.ClusterSpec != nil {
+		apiJob.Warnings = ClusterSpecWarnings(request.Job.ClusterSpec)
+	}
+	return apiJob, nil
+}
+
+// Creates many Ray Jobs of a namespace. A job failing the validation or the creation does not fail the others.
+func (s *RayJobServer) BatchCreateRayJobs(ctx context.Context, request *api.BatchCreateRayJobsRequest) (*api.BatchCreateRayJobsResponse, error) {
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if len(request.Jobs) == 0 {
+		return nil, util.NewInvalidFieldError("jobs", "No job to create. Please specify at least one job.")
+	}
+	if len(request.Jobs) > maxBatchSize {
+		return nil, util.NewInvalidFieldError("jobs", "%d jobs exceed the maximum of %d jobs per batch.", len(request.Jobs), maxBatchSize)
+	}
+
+	results := make([]*api.BatchCreateRayJobResult, len(request.Jobs))
+	var jobs []*api.RayJob
+	var indexes []int
+	for i, job := range request.Jobs {
+		results[i] = &api.BatchCreateRayJobResult{Name: job.GetName()}
+		if job == nil {
+			results[i].Code, results[i].Error = batchItemError(util.NewInvalidFieldError("jobs", "Job %d is empty. Please specify a valid value.", i))
+			continue
+		}
+		// The jobs can omit the namespace of the request.
+		if job.Namespace == "" {
+			job.Namespace = request.Namespace
+		}
+		err := ValidateCreateJobRequest(&api.CreateRayJobRequest{Job: job, Namespace: request.Namespace})
+		if err == nil && len(job.ClusterSelector) != 0 {
+			err = s.validateSelectedCluster(ctx, job.ClusterSelector[utils.RayClusterLabelKey], request.Namespace)
+		}
+		if err != nil {
+			results[i].Code, results[i].Error = batchItemError(util.Wrap(err, "Validate job request failed."))
+			continue
+		}
+		jobs = append(jobs, job)
+		indexes = append(indexes, i)
+	}
+
+	for k, created := range s.jobStore.BatchCreateJobs(ctx, request.Namespace, jobs) {
+		result := results[indexes[k]]
+		if created.Err != nil {
+			result.Code, result.Error = batchItemError(util.Wrap(created.Err, "Create Job failed."))
+			continue
+		}
+		result.Job = model.FromCrdToApiJob(created.Job)
+		if jobs[k].ClusterSpec != nil {
+			result.Job.Warnings = ClusterSpecWarnings(jobs[k].ClusterSpec)
+		}
+	}
+	return &api.BatchCreateRayJobsResponse{Results: results}, nil
+}
+
+// validateSelectedCluster checks that the existing ray cluster a job is submitted to is ready. The job would otherwise
+// wait for the cluster without ever being scheduled if the cluster does not exist.
+func (s *RayJobServer) validateSelectedCluster(ctx context.Context, clusterName string, namespace string) error {
+	cluster, err := s.clusterStore.GetCluster(ctx, clusterName, namespace)
+	if err != nil {
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			return util.NewInvalidInputError("Cluster %s selected by the job does not exist in namespace %s.", clusterName, namespace)
+		}
+		return err
+	}
+	if cluster.Status.State != rayv1api.Ready {
+		state := string(cluster.Status.State)
+		if state == "" {
+			state = "not ready yet"
+		}
+		return util.NewFailedPreconditionError("Cluster %s selected by the job is %s. Please wait until it is %s.", clusterName, state, rayv1api.Ready)
+	}
+	return nil
 }
 
 // Finds a specific Job by job name.
 func (s *RayJobServer) GetRayJob(ctx context.Context, request *api.GetRayJobRequest) (*api.RayJob, error) {
 	if request.Name == "" {
-		return nil, util.NewInvalidInputError("job name is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("name", "job name is empty. Please specify a valid value.")
 	}
 
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("job namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "job namespace is empty. Please specify a valid value.")
 	}
 
-	job, err := s.resourceManager.GetJob(ctx, request.Name, request.Namespace)
+	job, err := s.jobStore.GetJob(ctx, request.Name, request.Namespace)
 	if err != nil {
 		return nil, util.Wrap(err, "Get cluster failed.")
 	}
@@ -65,72 +171,341 @@ func (s *RayJobServer) GetRayJob(ctx context.Context, request *api.GetRayJobRequ
 // Finds all Jobs in a given namespace.
 func (s *RayJobServer) ListRayJobs(ctx context.Context, request *api.ListRayJobsRequest) (*api.ListRayJobsResponse, error) {
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("job namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "job namespace is empty. Please specify a valid value.")
+	}
+	if err := ValidatePageSize(request.PageSize); err != nil {
+		return nil, err
+	}
+	listFilter, err := NewListFilter(request.Filter, manager.JobFilterFields)
+	if err != nil {
+		return nil, err
 	}
 
-	jobs, err := s.resourceManager.ListJobs(ctx, request.Namespace)
+	jobs, nextPageToken, err := s.jobStore.ListJobs(ctx, request.Namespace, request.PageToken, int64(request.PageSize))
 	if err != nil {
 		return nil, util.Wrap(err, "List jobs failed.")
 	}
 
 	return &api.ListRayJobsResponse{
-		Jobs: model.FromCrdToApiJobs(jobs),
+		Jobs:          model.FromCrdToApiJobs(listFilter.Apply(jobs)),
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
 // Finds all Jobs in all namespaces.
 func (s *RayJobServer) ListAllRayJobs(ctx context.Context, request *api.ListAllRayJobsRequest) (*api.ListAllRayJobsResponse, error) {
-	jobs, err := s.resourceManager.ListAllJobs(ctx)
+	if err := ValidatePageSize(request.PageSize); err != nil {
+		return nil, err
+	}
+	listFilter, err := NewListFilter(request.Filter, manager.JobFilterFields)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, nextPageToken, err := s.jobStore.ListAllJobs(ctx, request.PageToken, int64(request.PageSize))
 	if err != nil {
 		return nil, util.Wrap(err, "List jobs failed.")
 	}
 
 	return &api.ListAllRayJobsResponse{
-		Jobs: model.FromCrdToApiJobs(jobs),
+		Jobs:          model.FromCrdToApiJobs(listFilter.Apply(jobs)),
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
 // Deletes an Job
 func (s *RayJobServer) DeleteRayJob(ctx context.Context, request *api.DeleteRayJobRequest) (*emptypb.Empty, error) {
 	if request.Name == "" {
-		return nil, util.NewInvalidInputError("job name is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("name", "job name is empty. Please specify a valid value.")
 	}
 
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("job namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "job namespace is empty. Please specify a valid value.")
 	}
 
-	if err := s.resourceManager.DeleteJob(ctx, request.Name, request.Namespace); err != nil {
+	if err := s.jobStore.DeleteJob(ctx, request.Name, request.Namespace); err != nil {
 		return nil, err
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// Lists the jobs archived in the datastore of the API server, the most recently finished first.
+func (s *RayJobServer) ListArchivedRayJobs(ctx context.Context, request *api.ListArchivedRayJobsRequest) (*api.ListArchivedRayJobsResponse, error) {
+	if request.Limit < 0 {
+		return nil, util.NewInvalidFieldError("limit", "Limit %d is negative. Please specify a valid value.", request.Limit)
+	}
+	if request.FinishedAfter != nil && request.FinishedBefore != nil && !request.FinishedBefore.AsTime().After(request.FinishedAfter.AsTime()) {
+		return nil, util.NewInvalidFieldError("finished_before", "finished_before must be after finished_after.")
+	}
+	if s.options.History == nil {
+		return nil, util.NewUnimplementedError("The job archive is disabled. Start the API server with --datastore and the HistoryDB feature gate enabled to archive the finished jobs.")
+	}
+	query := datastore.ArchivedJobQuery{
+		Namespace:     request.Namespace,
+		User:          request.User,
+		TargetCluster: request.TargetCluster,
+		JobStatus:     request.JobStatus,
+		Limit:         int(request.Limit),
+	}
+	if request.FinishedAfter != nil {
+		query.FinishedAfter = request.FinishedAfter.AsTime()
+	}
+	if request.FinishedBefore != nil {
+		query.FinishedBefore = request.FinishedBefore.AsTime()
+	}
+	archived, err := s.options.History.ListArchivedJobs(ctx, query)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the archived jobs.")
+	}
+	jobs, err := model.FromDatastoreToAPIArchivedJobs(archived)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to read the archived jobs.")
+	}
+	return &api.ListArchivedRayJobsResponse{Jobs: jobs}, nil
+}
+
+// Streams the logs of the submitter pod and of the pods of the ray cluster of a job.
+func (s *RayJobServer) StreamRayJobLogs(request *api.StreamRayJobLogsRequest, stream api.RayJobService_StreamRayJobLogsServer) error {
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "job name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "job namespace is empty. Please specify a valid value.")
+	}
+	if request.TailLines < 0 {
+		return util.NewInvalidFieldError("tail_lines", "tail lines %d is negative. Please specify a valid value.", request.TailLines)
+	}
+	lines, err := s.jobStore.StreamJobLogs(stream.Context(), request.Name, request.Namespace, manager.PodLogOptions{
+		Follow:         request.Follow,
+		TailLines:      request.TailLines,
+		IncludeWorkers: request.IncludeWorkers,
+	})
+	if err != nil {
+		return util.Wrap(err, "stream job logs failed")
+	}
+	for line := range lines {
+		if err := stream.Send(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Finds the status, the result and the driver logs of a job. The driver logs are read from the Ray dashboard while the
+// ray cluster of the job is ready, and from the logs of its submitter pod otherwise.
+func (s *RayJobServer) GetRayJobOutput(ctx context.Context, request *api.GetRayJobOutputRequest) (*api.RayJobOutput, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "job name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "job namespace is empty. Please specify a valid value.")
+	}
+	if request.TailLines < 0 {
+		return nil, util.NewInvalidFieldError("tail_lines", "tail lines %d is negative. Please specify a valid value.", request.TailLines)
+	}
+
+	job, err := s.jobStore.GetJob(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get job output failed.")
+	}
+	output := &api.RayJobOutput{
+		JobId:               job.Status.JobId,
+		JobStatus:           string(job.Status.JobStatus),
+		JobDeploymentStatus: string(job.Status.JobDeploymentStatus),
+		Message:             job.Status.Message,
+	}
+	if job.Status.JobResult != nil {
+		output.Result = model.FromCrdToApiJobResult(job.Status.JobResult)
+	}
+
+	if job.Status.JobId != "" && job.Status.RayClusterName != "" {
+		logs, err := s.getDashboardJobLogs(ctx, job.Status.RayClusterName, job.Namespace, job.Status.JobId)
+		if err == nil {
+			output.LogSource, output.Logs = jobLogSourceDashboard, tailLogLines(logs, request.TailLines)
+			return output, nil
+		}
+		klog.FromContext(ctx).Info("Failed to read the logs of the job from the Ray dashboard, reading the submitter Pod logs", "job", klog.KObj(job), "err", err)
+	}
+	logs, err := s.jobStore.GetJobSubmitterLogs(ctx, job.Name, job.Namespace, request.TailLines)
+	if err != nil {
+		if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			// The job has no logs left, e.g. it ran in the HTTP mode on a cluster which was deleted, but its result is
+			// still returned.
+			return output, nil
+		}
+		return nil, util.Wrap(err, "Get job output failed.")
+	}
+	output.LogSource, output.Logs = jobLogSourceSubmitterPod, logs
+	return output, nil
+}
+
+// getDashboardJobLogs reads the logs of a job from the dashboard of its ray cluster, which must be ready.
+func (s *RayJobServer) getDashboardJobLogs(ctx context.Context, clusterName string, namespace string, jobID string) (string, error) {
+	cluster, err := s.clusterStore.GetCluster(ctx, clusterName, namespace)
+	if err != nil {
+		return "", err
+	}
+	if cluster.Status.State != rayv1api.Ready {
+		return "", fmt.Errorf("cluster %s is %s", clusterName, cluster.Status.State)
+	}
+	headServiceName, err := utils.GenerateHeadServiceName(utils.RayClusterCRD, cluster.Spec, cluster.Name)
+	if err != nil {
+		return "", err
+	}
+	host := fmt.Sprintf("%s.%s.svc.%s", headServiceName, cluster.Namespace, utils.GetClusterDomainName())
+	dashboardClient := s.dashboardClientFunc()
+	if err := dashboardClient.InitClient(ctx, net.JoinHostPort(host, clusterPort(cluster, utils.DashboardPortName, utils.DefaultDashboardPort)), nil); err != nil {
+		return "", err
+	}
+	// The dashboard of a cluster with dashboard auth rejects the requests without its auth token.
+	if cluster.Spec.DashboardAuth != nil {
+		token, err := s.clusterStore.GetDashboardAuthToken(ctx, cluster.Name, cluster.Namespace)
+		if err != nil {
+			return "", err
+		}
+		tokenSetter, ok := dashboardClient.(utils.RayDashboardClientAuthTokenSetter)
+		if !ok {
+			return "", fmt.Errorf("the dashboard client does not support dashboard auth")
+		}
+		tokenSetter.SetAuthToken(token)
+	}
+	logs, err := dashboardClient.GetJobLog(ctx, jobID)
+	if err != nil {
+		return "", err
+	}
+	if logs == nil {
+		return "", fmt.Errorf("job %s not found in cluster %s", jobID, clusterName)
+	}
+	return *logs, nil
+}
+
+// tailLogLines returns the last tailLines lines of the logs, all of them if tailLines is 0.
+func tailLogLines(logs string, tailLines int64) string {
+	if tailLines <= 0 {
+		return logs
+	}
+	lines := strings.SplitAfter(strings.TrimSuffix(logs, "\n"), "\n")
+	if int64(len(lines)) <= tailLines {
+		return logs
+	}
+	return strings.Join(lines[int64(len(lines))-tailLines:], "") + "\n"
+}
+
 func ValidateCreateJobRequest(request *api.CreateRayJobRequest) error {
+	var errs util.ValidationErrors
 	if request.Namespace == "" {
-		return util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		errs.Add(util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value."))
+	}
+
+	if request.Job == nil {
+		errs.Add(util.NewInvalidFieldError("job", "Job is empty, please input a valid payload."))
+		return errs.Err()
 	}
 
-	if request.Namespace != request.Job.Namespace {
-		return util.NewInvalidInputError("The namespace in the request is different from the namespace in the job definition.")
+	if request.Namespace != "" && request.Namespace != request.Job.Namespace {
+		errs.Add(util.NewInvalidFieldError("job.namespace", "The namespace in the request is different from the namespace in the job definition."))
 	}
 
 	if request.Job.Name == "" {
-		return util.NewInvalidInputError("Job name is empty. Please specify a valid value.")
+		errs.Add(util.NewInvalidFieldError("job.name", "Job name is empty. Please specify a valid value."))
 	}
 
 	if request.Job.User == "" {
-		return util.NewInvalidInputError("User who create the job is empty. Please specify a valid value.")
+		errs.Add(util.NewInvalidFieldError("job.user", "User who create the job is empty. Please specify a valid value."))
+	}
+
+	if request.Job.ClusterGenerateName != "" {
+		if len(request.Job.ClusterSelector) != 0 {
+			errs.Add(util.NewInvalidFieldError("job.cluster_generate_name", "Cluster generate name and cluster selector are mutually exclusive. Please specify only one of them."))
+		} else if messages := validation.IsDNS1035Label(utils.GenerateRayClusterNameWithPrefix(request.Job.ClusterGenerateName, "")); len(messages) > 0 {
+			// The suffix of the RayCluster name does not change its validity, resolve it with any UID.
+			errs.Add(util.NewInvalidFieldError("job.cluster_generate_name", "Cluster generate name %s does not produce a valid RayCluster name: %s", request.Job.ClusterGenerateName, strings.Join(messages, ", ")))
+		}
+	}
+
+	runtimeEnv, err := validateJobRuntimeEnv(request.Job)
+	errs.Add(err)
+	errs.Add(validateJobEntrypoint(request.Job))
+	// The git source is checked against the working_dir of a valid runtime env only.
+	if err == nil {
+		errs.AddField("job.git_source", ValidateGitSource(request.Job.GitSource, runtimeEnv))
+	}
+
+	if request.Job.BackoffLimit < 0 {
+		errs.Add(util.NewInvalidFieldError("job.backoff_limit", "Backoff limit %d is negative. Please specify a valid value.", request.Job.BackoffLimit))
+	}
+
+	if request.Job.JobTtlSecondsAfterFinished < 0 {
+		errs.Add(util.NewInvalidFieldError("job.job_ttl_seconds_after_finished", "Job TTL %d is negative. Please specify a valid value.", request.Job.JobTtlSecondsAfterFinished))
 	}
 
 	if len(request.Job.ClusterSelector) != 0 {
-		return nil
+		// The operator submits the job to the cluster named by this key, and ignores the other keys.
+		if request.Job.ClusterSelector[utils.RayClusterLabelKey] == "" {
+			errs.Add(util.NewInvalidFieldError("job.cluster_selector", "Cluster selector must set %s to the name of an existing cluster.", utils.RayClusterLabelKey))
+		}
+		if request.Job.BackoffLimit > 0 {
+			// A retry deletes the RayCluster of the job, which would delete the selected cluster.
+			errs.Add(util.NewInvalidFieldError("job.backoff_limit", "Backoff limit and cluster selector are mutually exclusive. Retries need a cluster_spec."))
+		}
+		if request.Job.Queueing != nil {
+			// The selected cluster is already scheduled, only a cluster of the job can wait in a queue.
+			errs.Add(util.NewInvalidFieldError("job.queueing", "Queueing and cluster selector are mutually exclusive. Queueing needs a cluster_spec."))
+		}
+		return errs.Err()
 	}
 
+	errs.AddField("job.queueing", ValidateQueueingOptions(request.Job.Queueing))
+	if request.Job.Queueing.GetBatchScheduler() == api.QueueingOptions_KUEUE && !request.Job.ShutdownAfterJobFinishes {
+		// Kueue suspends the job, which the operator only allows if the cluster of the job is deleted once it finishes.
+		errs.Add(util.NewInvalidFieldError("job.shutdown_after_job_finishes", "Kueue requires shutdown_after_job_finishes, so that the quota of the cluster is released once the job finishes."))
+	}
+
+	// The images are only checked against a valid cluster spec.
 	if err := ValidateClusterSpec(request.Job.ClusterSpec); err != nil {
-		return err
+		errs.AddField("job.cluster_spec", err)
+	} else {
+		errs.AddField("job.cluster_spec", ValidateClusterImages(request.Job.Version, request.Job.ClusterSpec))
+	}
+
+	return errs.Err()
+}
+
+// validateJobRuntimeEnv validates the runtime_env or the runtime_environment of a job, and returns the runtime_env
+// YAML of the job.
+func validateJobRuntimeEnv(job *api.RayJob) (string, error) {
+	if job.RuntimeEnvironment == nil {
+		if err := util.ValidateRuntimeEnv(job.RuntimeEnv); err != nil {
+			return "", util.NewInvalidFieldError("job.runtime_env", "Runtime env is invalid: %s", err.Error())
+		}
+		return job.RuntimeEnv, nil
 	}
+	if job.RuntimeEnv != "" {
+		return "", util.NewInvalidFieldError("job.runtime_env", "Runtime env and runtime environment are mutually exclusive. Please specify only one of them.")
+	}
+	if err := util.ValidateRuntimeEnvironment(job.RuntimeEnvironment); err != nil {
+		return "", util.NewInvalidFieldError("job.runtime_environment", "Runtime environment is invalid: %s", err.Error())
+	}
+	runtimeEnv, err := util.RenderRuntimeEnv(job.RuntimeEnvironment)
+	if err != nil {
+		return "", util.NewInvalidFieldError("job.runtime_environment", "Runtime environment is invalid: %s", err.Error())
+	}
+	return runtimeEnv, nil
+}
 
+// validateJobEntrypoint checks that the entrypoint of a job can be rendered with its entrypoint_params.
+func validateJobEntrypoint(job *api.RayJob) error {
+	for name := range job.EntrypointParams {
+		if !util.EntrypointParamNamePattern.MatchString(name) {
+			return util.NewInvalidFieldError("job.entrypoint_params", "Entrypoint param name %q is invalid. Please use letters, digits and underscores.", name)
+		}
+		if name == util.EntrypointJobNameParam || name == util.EntrypointNamespaceParam {
+			return util.NewInvalidFieldError("job.entrypoint_params", "Entrypoint param %s is reserved. Please use another name.", name)
+		}
+	}
+	if _, err := util.RenderEntrypoint(job); err != nil {
+		return util.NewInvalidFieldError("job.entrypoint", "Entrypoint can not be rendered: %s. Please set them in entrypoint_params.", err.Error())
+	}
 	return nil
 }
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// fakeJobStore serves a single job and the logs of its submitter pod. The methods which are not overridden panic.
+type fakeJobStore struct {
+	manager.JobStore
+	job           *rayv1api.RayJob
+	submitterLogs string
+}
+
+func (f *fakeJobStore) GetJob(_ context.Context, jobName string, namespace string) (*rayv1api.RayJob, error) {
+	if f.job.Name != jobName || f.job.Namespace != namespace {
+		return nil, util.NewNotFoundError(errors.New("not found"), "Job %s not found", jobName)
+	}
+	return f.job.DeepCopy(), nil
+}
+
+func (f *fakeJobStore) CreateJob(_ context.Context, apiJob *api.RayJob) (*rayv1api.RayJob, error) {
+	job, err := util.NewRayJob(apiJob, nil)
+	if err != nil {
+		return nil, err
+	}
+	return job.Get(), nil
+}
+
+func (f *fakeJobStore) BatchCreateJobs(ctx context.Context, _ string, apiJobs []*api.RayJob) []manager.BatchJobResult {
+	results := make([]manager.BatchJobResult, len(apiJobs))
+	for i, apiJob := range apiJobs {
+		results[i].Job, results[i].Err = f.CreateJob(ctx, apiJob)
+	}
+	return results
+}
+
+func (f *fakeJobStore) GetJobSubmitterLogs(_ context.Context, jobName string, _ string, _ int64) (string, error) {
+	if f.submitterLogs == "" {
+		return "", util.NewNotFoundError(errors.New("no submitter Pod"), "Job %s has no submitter Pod", jobName)
+	}
+	return f.submitterLogs, nil
+}
+
+func TestGetRayJobOutput(t *testing.T) {
+	ctx := context.Background()
+	jobStore := &fakeJobStore{job: &rayv1api.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "team-a"},
+		Status: rayv1api.RayJobStatus{
+			JobId:               "job-abcde",
+			RayClusterName:      "job-raycluster-abcde",
+			JobStatus:           rayv1api.JobStatusSucceeded,
+			JobDeploymentStatus: rayv1api.JobDeploymentStatusComplete,
+			Message:             "Job finished successfully.",
+		},
+	}}
+	clusterStore := &fakeClusterStore{clusters: map[string]*rayv1api.RayCluster{
+		"team-a/job-raycluster-abcde": {
+			ObjectMeta: metav1.ObjectMeta{Name: "job-raycluster-abcde", Namespace: "team-a"},
+			Status:     rayv1api.RayClusterStatus{State: rayv1api.Ready},
+		},
+	}}
+	server := NewRayJobServer(jobStore, clusterStore, &JobServerOptions{})
+	server.dashboardClientFunc = func() utils.RayDashboardClientInterface { return &utils.FakeRayDashboardClient{} }
+
+	output, err := server.GetRayJobOutput(ctx, &api.GetRayJobOutputRequest{Name: "job", Namespace: "team-a"})
+	require.NoError(t, err)
+	assert.Equal(t, "job-abcde", output.JobId)
+	assert.Equal(t, string(rayv1api.JobStatusSucceeded), output.JobStatus)
+	assert.Equal(t, string(rayv1api.JobDeploymentStatusComplete), output.JobDeploymentStatus)
+	assert.Equal(t, "Job finished successfully.", output.Message)
+	assert.Equal(t, jobLogSourceDashboard, output.LogSource)
+	assert.Equal(t, "log", output.Logs)
+
+	// The logs of the submitter pod are returned once the cluster is not ready anymore.
+	clusterStore.clusters["team-a/job-raycluster-abcde"].Status.State = rayv1api.Suspended
+	jobStore.submitterLogs = "submitted\nfinished\n"
+	output, err = server.GetRayJobOutput(ctx, &api.GetRayJobOutputRequest{Name: "job", Namespace: "team-a"})
+	require.NoError(t, err)
+	assert.Equal(t, jobLogSourceSubmitterPod, output.LogSource)
+	assert.Equal(t, "submitted\nfinished\n", output.Logs)
+
+	// A job without logs still returns its status.
+	jobStore.submitterLogs = ""
+	output, err = server.GetRayJobOutput(ctx, &api.GetRayJobOutputRequest{Name: "job", Namespace: "team-a"})
+	require.NoError(t, err)
+	assert.Empty(t, output.LogSource)
+	assert.Empty(t, output.Logs)
+	assert.Equal(t, "job-abcde", output.JobId)
+
+	_, err = server.GetRayJobOutput(ctx, &api.GetRayJobOutputRequest{Name: "missing", Namespace: "team-a"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	_, err = server.GetRayJobOutput(ctx, &api.GetRayJobOutputRequest{Name: "job", Namespace: "team-a", TailLines: -1})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestListArchivedRayJobs(t *testing.T) {
+	ctx := context.Background()
+	server := NewRayJobServer(&fakeJobStore{}, &fakeClusterStore{}, &JobServerOptions{})
+	_, err := server.ListArchivedRayJobs(ctx, &api.ListArchivedRayJobsRequest{})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.Unimplemented))
+
+	store := datastore.NewMemoryStore(10)
+	server = NewRayJobServer(&fakeJobStore{}, &fakeClusterStore{}, &JobServerOptions{History: store})
+	ended := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	for _, job := range []*api.ArchivedRayJob{
+		{Uid: "1", Name: "train", Namespace: "team-a", User: "alice", JobStatus: "FAILED", EndTime: timestamppb.New(ended)},
+		{Uid: "2", Name: "report", Namespace: "team-a", User: "bob", JobStatus: "SUCCEEDED", EndTime: timestamppb.New(ended.Add(time.Hour))},
+	} {
+		archived, err := model.FromApiToDatastoreArchivedJob(job)
+		require.NoError(t, err)
+		require.NoError(t, store.ArchiveJob(ctx, archived))
+	}
+
+	response, err := server.ListArchivedRayJobs(ctx, &api.ListArchivedRayJobsRequest{Namespace: "team-a"})
+	require.NoError(t, err)
+	require.Len(t, response.Jobs, 2)
+	assert.Equal(t, "report", response.Jobs[0].Name)
+	response, err = server.ListArchivedRayJobs(ctx, &api.ListArchivedRayJobsRequest{
+		JobStatus:      "FAILED",
+		FinishedAfter:  timestamppb.New(ended.Add(-time.Minute)),
+		FinishedBefore: timestamppb.New(ended.Add(time.Minute)),
+	})
+	require.NoError(t, err)
+	require.Len(t, response.Jobs, 1)
+	assert.Equal(t, "alice", response.Jobs[0].User)
+
+	_, err = server.ListArchivedRayJobs(ctx, &api.ListArchivedRayJobsRequest{Limit: -1})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	_, err = server.ListArchivedRayJobs(ctx, &api.ListArchivedRayJobsRequest{FinishedAfter: timestamppb.New(ended), FinishedBefore: timestamppb.New(ended)})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestTailLogLines(t *testing.T) {
+	assert.Equal(t, "a\nb\nc\n", tailLogLines("a\nb\nc\n", 0))
+	assert.Equal(t, "b\nc\n", tailLogLines("a\nb\nc\n", 2))
+	assert.Equal(t, "c\n", tailLogLines("a\nb\nc", 1))
+	assert.Equal(t, "a\nb\nc\n", tailLogLines("a\nb\nc\n", 5))
+}
+
+func TestCreateRayJobWithClusterSelector(t *testing.T) {
+	ctx := context.Background()
+	clusterStore := &fakeClusterStore{clusters: map[string]*rayv1api.RayCluster{
+		"team-a/warm": {
+			ObjectMeta: metav1.ObjectMeta{Name: "warm", Namespace: "team-a"},
+			Status:     rayv1api.RayClusterStatus{State: rayv1api.Ready},
+		},
+		"team-a/starting": {
+			ObjectMeta: metav1.ObjectMeta{Name: "starting", Namespace: "team-a"},
+		},
+	}}
+	server := NewRayJobServer(&fakeJobStore{}, clusterStore, &JobServerOptions{})
+	newRequest := func(clusterName string) *api.CreateRayJobRequest {
+		return &api.CreateRayJobRequest{
+			Namespace: "team-a",
+			Job: &api.RayJob{
+				Name:            "job",
+				Namespace:       "team-a",
+				User:            "user",
+				Entrypoint:      "python main.py",
+				ClusterSelector: map[string]string{utils.RayClusterLabelKey: clusterName},
+			},
+		}
+	}
+
+	job, err := server.CreateRayJob(ctx, newRequest("warm"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{utils.RayClusterLabelKey: "warm"}, job.ClusterSelector)
+
+	_, err = server.CreateRayJob(ctx, newRequest("starting"))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+
+	_, err = server.CreateRayJob(ctx, newRequest("missing"))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestBatchCreateRayJobs(t *testing.T) {
+	ctx := context.Background()
+	clusterStore := &fakeClusterStore{clusters: map[string]*rayv1api.RayCluster{
+		"team-a/warm": {
+			ObjectMeta: metav1.ObjectMeta{Name: "warm", Namespace: "team-a"},
+			Status:     rayv1api.RayClusterStatus{State: rayv1api.Ready},
+		},
+	}}
+	server := NewRayJobServer(&fakeJobStore{}, clusterStore, &JobServerOptions{})
+	newJob := func(name string, clusterName string) *api.RayJob {
+		return &api.RayJob{
+			Name:            name,
+			User:            "user",
+			Entrypoint:      "python main.py",
+			ClusterSelector: map[string]string{utils.RayClusterLabelKey: clusterName},
+		}
+	}
+
+	response, err := server.BatchCreateRayJobs(ctx, &api.BatchCreateRayJobsRequest{
+		Namespace: "team-a",
+		Jobs:      []*api.RayJob{newJob("job-1", "warm"), newJob("job-2", "missing"), newJob("", "warm"), newJob("job-4", "warm")},
+	})
+	require.NoError(t, err)
+	require.Len(t, response.Results, 4)
+	assert.Equal(t, "job-1", response.Results[0].Job.Name)
+	assert.Equal(t, "team-a", response.Results[0].Job.Namespace)
+	assert.Equal(t, int32(codes.OK), response.Results[0].Code)
+	assert.Equal(t, "job-2", response.Results[1].Name)
+	assert.Nil(t, response.Results[1].Job)
+	assert.Equal(t, int32(codes.InvalidArgument), response.Results[1].Code)
+	assert.Contains(t, response.Results[1].Error, "Cluster missing selected by the job does not exist")
+	assert.Equal(t, int32(codes.InvalidArgument), response.Results[2].Code)
+	assert.Equal(t, "job-4", response.Results[3].Job.Name)
+
+	_, err = server.BatchCreateRayJobs(ctx, &api.BatchCreateRayJobsRequest{Namespace: "team-a"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	_, err = server.BatchCreateRayJobs(ctx, &api.BatchCreateRayJobsRequest{Namespace: "team-a", Jobs: make([]*api.RayJob, maxBatchSize+1)})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
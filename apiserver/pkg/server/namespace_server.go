@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type NamespaceServerOptions struct {
+	CollectMetrics bool
+}
+
+// implements `type NamespaceServiceServer interface` in namespace_grpc.pb.go
+// NamespaceServer is the server API for NamespaceService service.
+type NamespaceServer struct {
+	namespaceStore manager.NamespaceStore
+	options        *NamespaceServerOptions
+	api.UnimplementedNamespaceServiceServer
+}
+
+func NewNamespaceServer(namespaceStore manager.NamespaceStore, options *NamespaceServerOptions) *NamespaceServer {
+	return &NamespaceServer{namespaceStore: namespaceStore, options: options}
+}
+
+func (s *NamespaceServer) EnsureNamespace(ctx context.Context, request *api.EnsureNamespaceRequest) (*api.Namespace, error) {
+	if err := ValidateEnsureNamespaceRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate ensure namespace request failed.")
+	}
+
+	namespace, err := s.namespaceStore.EnsureNamespace(ctx, request.Namespace, request.Labels)
+	if err != nil {
+		return nil, util.Wrap(err, "Ensure namespace failed.")
+	}
+	return namespace, nil
+}
+
+func (s *NamespaceServer) GetNamespace(ctx context.Context, request *api.GetNamespaceRequest) (*api.Namespace, error) {
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	namespace, err := s.namespaceStore.GetNamespace(ctx, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get namespace failed.")
+	}
+	return namespace, nil
+}
+
+func ValidateEnsureNamespaceRequest(request *api.EnsureNamespaceRequest) error {
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if errs := validation.IsDNS1123Label(request.Namespace); len(errs) > 0 {
+		return util.NewInvalidFieldError("namespace", "Namespace %s is invalid: %s", request.Namespace, strings.Join(errs, ", "))
+	}
+
+	for key, value := range request.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return util.NewInvalidFieldError("labels", "Label key %q is invalid: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return util.NewInvalidFieldError("labels", "Value of label %s is invalid: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type NotificationServerOptions struct {
+	CollectMetrics bool
+}
+
+// implements `type NotificationServiceServer interface` in notification_grpc.pb.go
+// NotificationServer is the server API for NotificationService service.
+type NotificationServer struct {
+	notificationStore manager.NotificationStore
+	options           *NotificationServerOptions
+	api.UnimplementedNotificationServiceServer
+}
+
+func NewNotificationServer(notificationStore manager.NotificationStore, options *NotificationServerOptions) *NotificationServer {
+	return &NotificationServer{notificationStore: notificationStore, options: options}
+}
+
+func (s *NotificationServer) CreateNotificationSubscription(ctx context.Context, request *api.CreateNotificationSubscriptionRequest) (*api.NotificationSubscription, error) {
+	if err := ValidateCreateNotificationSubscriptionRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate notification subscription request failed.")
+	}
+
+	configMap, err := s.notificationStore.CreateNotificationSubscription(ctx, request.Subscription)
+	if err != nil {
+		return nil, util.Wrap(err, "Create notification subscription failed.")
+	}
+	return model.FromKubeToAPINotificationSubscription(configMap), nil
+}
+
+func (s *NotificationServer) GetNotificationSubscription(ctx context.Context, request *api.GetNotificationSubscriptionRequest) (*api.NotificationSubscription, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Notification subscription name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	configMap, err := s.notificationStore.GetNotificationSubscription(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get notification subscription failed.")
+	}
+	return model.FromKubeToAPINotificationSubscription(configMap), nil
+}
+
+func (s *NotificationServer) ListNotificationSubscriptions(ctx context.Context, request *api.ListNotificationSubscriptionsRequest) (*api.ListNotificationSubscriptionsResponse, error) {
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	configMaps, err := s.notificationStore.ListNotificationSubscriptions(ctx, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "List notification subscriptions failed.")
+	}
+	subscriptions := make([]*api.NotificationSubscription, 0, len(configMaps))
+	for _, configMap := range configMaps {
+		subscriptions = append(subscriptions, model.FromKubeToAPINotificationSubscription(configMap))
+	}
+	return &api.ListNotificationSubscriptionsResponse{Subscriptions: subscriptions}, nil
+}
+
+func (s *NotificationServer) DeleteNotificationSubscription(ctx context.Context, request *api.DeleteNotificationSubscriptionRequest) (*emptypb.Empty, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Notification subscription name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if err := s.notificationStore.DeleteNotificationSubscription(ctx, request.Name, request.Namespace); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func ValidateCreateNotificationSubscriptionRequest(request *api.CreateNotificationSubscriptionRequest) error {
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.Subscription == nil {
+		return util.NewInvalidFieldError("subscription", "Notification subscription is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace != request.Subscription.Namespace {
+		return util.NewInvalidInputError("The namespace in the request is different from the namespace in the notification subscription definition.")
+	}
+
+	if request.Subscription.Name == "" {
+		return util.NewInvalidFieldError("subscription.name", "Notification subscription name is empty. Please specify a valid value.")
+	}
+
+	if errs := validation.IsDNS1123Subdomain(request.Subscription.Name); len(errs) > 0 {
+		return util.NewInvalidInputError("Notification subscription name %s is invalid: %s", request.Subscription.Name, strings.Join(errs, ", "))
+	}
+
+	if request.Subscription.User == "" {
+		return util.NewInvalidFieldError("subscription.user", "User who create the notification subscription is empty. Please specify a valid value.")
+	}
+
+	webhookURL, err := url.Parse(request.Subscription.Url)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return util.NewInvalidFieldError("subscription.url", "Webhook URL %q is invalid, expected an http or https URL.", request.Subscription.Url)
+	}
+
+	for _, event := range request.Subscription.Events {
+		if _, ok := api.NotificationSubscription_Event_name[int32(event)]; !ok || event == api.NotificationSubscription_EVENT_UNSPECIFIED {
+			return util.NewInvalidFieldError("subscription.events", "Event %v is invalid. Please specify a valid value.", event)
+		}
+	}
+	return nil
+}
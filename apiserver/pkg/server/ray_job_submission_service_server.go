@@ -1,11 +1,16 @@
 package server
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -18,11 +23,20 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
 
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
+// MaxWorkingDirSize is the maximum size of an uploaded working directory, which is the maximum size of a
+// package stored in the GCS.
+const MaxWorkingDirSize = 500 * 1024 * 1024
+
 type RayJobSubmissionServiceServerOptions struct {
 	CollectMetrics bool
+	// WorkingDirStore is where the uploaded working directories of jobs are stored. If it is empty, they are
+	// stored in the GCS of the Ray cluster. Otherwise it is file://<dir>, a directory shared with the Ray Pods
+	// at the same path, e.g. a PVC mounted in the API server and the Ray cluster.
+	WorkingDirStore string
 }
 
 // implements `type ClusterServiceServer interface` in cluster_grpc.pb.go
@@ -46,15 +60,10 @@ func NewRayJobSubmissionServiceServer(clusterServer *ClusterServer, options *Ray
 func (s *RayJobSubmissionServiceServer) SubmitRayJob(ctx context.Context, req *api.SubmitRayJobRequest) (*api.SubmitRayJobReply, error) {
 	s.log.Info("RayJobSubmissionService submit job")
 	clusterRequest := api.GetClusterRequest{Name: req.Clustername, Namespace: req.Namespace}
-	url, err := s.getRayClusterURL(ctx, &clusterRequest)
+	rayDashboardClient, err := s.getDashboardClient(ctx, &clusterRequest)
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
-	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
 	request := &utils.RayJobRequest{Entrypoint: req.Jobsubmission.Entrypoint}
 	if req.Jobsubmission.SubmissionId != "" {
 		request.SubmissionId = req.Jobsubmission.SubmissionId
@@ -101,15 +110,10 @@ func (s *RayJobSubmissionServiceServer) SubmitRayJob(ctx context.Context, req *a
 func (s *RayJobSubmissionServiceServer) GetJobDetails(ctx context.Context, req *api.GetJobDetailsRequest) (*api.JobSubmissionInfo, error) {
 	s.log.Info("RayJobSubmissionService get service")
 	clusterRequest := api.GetClusterRequest{Name: req.Clustername, Namespace: req.Namespace}
-	url, err := s.getRayClusterURL(ctx, &clusterRequest)
+	rayDashboardClient, err := s.getDashboardClient(ctx, &clusterRequest)
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
-	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
 	nodeInfo, err := rayDashboardClient.GetJobInfo(ctx, req.Submissionid)
 	if err != nil {
 		return nil, err
@@ -124,15 +128,10 @@ func (s *RayJobSubmissionServiceServer) GetJobDetails(ctx context.Context, req *
 func (s *RayJobSubmissionServiceServer) GetJobLog(ctx context.Context, req *api.GetJobLogRequest) (*api.GetJobLogReply, error) {
 	s.log.Info("RayJobSubmissionService get job log")
 	clusterRequest := api.GetClusterRequest{Name: req.Clustername, Namespace: req.Namespace}
-	url, err := s.getRayClusterURL(ctx, &clusterRequest)
+	rayDashboardClient, err := s.getDashboardClient(ctx, &clusterRequest)
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
-	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
 	jlog, err := rayDashboardClient.GetJobLog(ctx, req.Submissionid)
 	if err != nil {
 		return nil, err
@@ -147,15 +146,10 @@ func (s *RayJobSubmissionServiceServer) GetJobLog(ctx context.Context, req *api.
 func (s *RayJobSubmissionServiceServer) ListJobDetails(ctx context.Context, req *api.ListJobDetailsRequest) (*api.ListJobSubmissionInfo, error) {
 	s.log.Info("RayJobSubmissionService get jobs list")
 	clusterRequest := api.GetClusterRequest{Name: req.Clustername, Namespace: req.Namespace}
-	url, err := s.getRayClusterURL(ctx, &clusterRequest)
+	rayDashboardClient, err := s.getDashboardClient(ctx, &clusterRequest)
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
-	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
 	nodesInfo, err := rayDashboardClient.ListJobs(ctx)
 	if err != nil {
 		return nil, err
@@ -171,15 +165,10 @@ func (s *RayJobSubmissionServiceServer) ListJobDetails(ctx context.Context, req
 func (s *RayJobSubmissionServiceServer) StopRayJob(ctx context.Context, req *api.StopRayJobSubmissionRequest) (*emptypb.Empty, error) {
 	s.log.Info("RayJobSubmissionService stop job")
 	clusterRequest := api.GetClusterRequest{Name: req.Clustername, Namespace: req.Namespace}
-	url, err := s.getRayClusterURL(ctx, &clusterRequest)
+	rayDashboardClient, err := s.getDashboardClient(ctx, &clusterRequest)
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
-	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
 	err = rayDashboardClient.StopJob(ctx, req.Submissionid)
 	if err != nil {
 		return nil, err
@@ -191,15 +180,10 @@ func (s *RayJobSubmissionServiceServer) StopRayJob(ctx context.Context, req *api
 func (s *RayJobSubmissionServiceServer) DeleteRayJob(ctx context.Context, req *api.DeleteRayJobSubmissionRequest) (*emptypb.Empty, error) {
 	s.log.Info("RayJobSubmissionService delete job")
 	clusterRequest := api.GetClusterRequest{Name: req.Clustername, Namespace: req.Namespace}
-	url, err := s.getRayClusterURL(ctx, &clusterRequest)
+	rayDashboardClient, err := s.getDashboardClient(ctx, &clusterRequest)
 	if err != nil {
 		return nil, err
 	}
-	rayDashboardClient := s.dashboardClientFunc()
-	// TODO: support proxy subresources in kuberay-apiserver
-	if err := rayDashboardClient.InitClient(ctx, *url, nil); err != nil {
-		return nil, err
-	}
 	err = rayDashboardClient.DeleteJob(ctx, req.Submissionid)
 	if err != nil {
 		return nil, err
@@ -207,8 +191,100 @@ func (s *RayJobSubmissionServiceServer) DeleteRayJob(ctx context.Context, req *a
 	return &emptypb.Empty{}, nil
 }
 
-// Internal method to get cluster for job operation
-func (s *RayJobSubmissionServiceServer) getRayClusterURL(ctx context.Context, request *api.GetClusterRequest) (*string, error) {
+// Upload the working directory of jobs
+func (s *RayJobSubmissionServiceServer) UploadJobWorkingDir(ctx context.Context, req *api.UploadJobWorkingDirRequest) (*api.UploadJobWorkingDirReply, error) {
+	s.log.Info("RayJobSubmissionService upload working directory")
+	if err := ValidateUploadJobWorkingDirRequest(req); err != nil {
+		return nil, err
+	}
+	packageName := workingDirPackageName(req.Zip)
+	if dir, ok := strings.CutPrefix(s.options.WorkingDirStore, "file://"); ok {
+		// The cluster must exist even if it is not used to store the package.
+		if _, err := s.clusterServer.GetCluster(ctx, &api.GetClusterRequest{Name: req.Clustername, Namespace: req.Namespace}); err != nil {
+			return nil, err
+		}
+		path, err := storeWorkingDir(filepath.Join(dir, req.Namespace), packageName, req.Zip)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to store the working directory of cluster %s/%s", req.Namespace, req.Clustername)
+		}
+		return &api.UploadJobWorkingDirReply{Uri: "file://" + path}, nil
+	}
+
+	clusterRequest := api.GetClusterRequest{Name: req.Clustername, Namespace: req.Namespace}
+	rayDashboardClient, err := s.getDashboardClient(ctx, &clusterRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err := rayDashboardClient.UploadPackage(ctx, packageName, req.Zip); err != nil {
+		return nil, err
+	}
+	return &api.UploadJobWorkingDirReply{Uri: "gcs://" + packageName}, nil
+}
+
+// ValidateUploadJobWorkingDirRequest validates that the uploaded working directory is a zip file which can be
+// stored as a Ray package.
+func ValidateUploadJobWorkingDirRequest(request *api.UploadJobWorkingDirRequest) error {
+	if request == nil {
+		return util.NewInvalidInputError("A non nill request is expected")
+	}
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if request.Clustername == "" {
+		return util.NewInvalidFieldError("clustername", "Cluster name is empty. Please specify a valid value.")
+	}
+	if len(request.Zip) == 0 {
+		return util.NewInvalidFieldError("zip", "Working directory zip is empty. Please specify a valid value.")
+	}
+	if len(request.Zip) > MaxWorkingDirSize {
+		return util.NewInvalidInputError("Working directory zip of %d bytes is larger than the maximum of %d bytes.", len(request.Zip), MaxWorkingDirSize)
+	}
+	if _, err := zip.NewReader(bytes.NewReader(request.Zip), int64(len(request.Zip))); err != nil {
+		return util.NewInvalidInputError("Working directory is not a valid zip file: %v. Please specify a valid value.", err)
+	}
+	return nil
+}
+
+// workingDirPackageName names the package of a working directory after the hash of its content, like Ray does,
+// so that uploading the same working directory again reuses the package.
+func workingDirPackageName(zip []byte) string {
+	hash := sha256.Sum256(zip)
+	return "_ray_pkg_" + hex.EncodeToString(hash[:8]) + ".zip"
+}
+
+// storeWorkingDir writes the zip to the directory and returns its path. The zip is written to a temporary file
+// first, so that jobs never see a partial working directory.
+func storeWorkingDir(dir string, packageName string, zip []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, packageName)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	file, err := os.CreateTemp(dir, packageName+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(zip); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// getDashboardClient returns a client of the dashboard of a ready cluster for the job operations.
+func (s *RayJobSubmissionServiceServer) getDashboardClient(ctx context.Context, request *api.GetClusterRequest) (utils.RayDashboardClientInterface, error) {
 	cls, err := s.clusterServer.GetCluster(ctx, request)
 	if err != nil {
 		return nil, err
@@ -218,7 +294,24 @@ func (s *RayJobSubmissionServiceServer) getRayClusterURL(ctx context.Context, re
 	}
 	// We are hardcoding port to the default value - 8265, as API server does not allow to modify it
 	url := request.Name + "-head-svc." + request.Namespace + ".svc.cluster.local:8265"
-	return &url, nil
+	rayDashboardClient := s.dashboardClientFunc()
+	// TODO: support proxy subresources in kuberay-apiserver
+	if err := rayDashboardClient.InitClient(ctx, url, nil); err != nil {
+		return nil, err
+	}
+	// Clusters with dashboard auth require the auth token, which the operator stores in a Secret, with every request.
+	if cls.ClusterSpec != nil && cls.ClusterSpec.EnableDashboardAuth {
+		token, err := s.clusterServer.clusterStore.GetDashboardAuthToken(ctx, request.Name, request.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		tokenSetter, ok := rayDashboardClient.(utils.RayDashboardClientAuthTokenSetter)
+		if !ok {
+			return nil, errors.New("the dashboard client does not support dashboard auth")
+		}
+		tokenSetter.SetAuthToken(token)
+	}
+	return rayDashboardClient, nil
 }
 
 func convertNodeInfo(info *utils.RayJobInfo) *api.JobSubmissionInfo {
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkingDirPackageName(t *testing.T) {
+	name := workingDirPackageName([]byte("PK\x03\x04"))
+	assert.Regexp(t, `^_ray_pkg_[0-9a-f]{16}\.zip$`, name)
+	assert.Equal(t, name, workingDirPackageName([]byte("PK\x03\x04")))
+	assert.NotEqual(t, name, workingDirPackageName([]byte("PK\x05\x06")))
+}
+
+func TestStoreWorkingDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "team-a")
+
+	path, err := storeWorkingDir(dir, "_ray_pkg_0123456789abcdef.zip", []byte("PK\x03\x04"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "_ray_pkg_0123456789abcdef.zip"), path)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("PK\x03\x04"), content)
+
+	// Storing the same package again keeps the existing file, and no temporary file is left.
+	path, err = storeWorkingDir(dir, "_ray_pkg_0123456789abcdef.zip", []byte("PK\x03\x04"))
+	require.NoError(t, err)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, filepath.Join(dir, "_ray_pkg_0123456789abcdef.zip"), path)
+}
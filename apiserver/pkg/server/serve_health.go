@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// serveProxyHealthPath is the health endpoint of the Serve HTTP proxy, which answers 200 once the proxy can route
+// requests and 503 while it drains.
+const serveProxyHealthPath = "/-/healthz"
+
+// serveRoute is a route of the Serve HTTP proxy and the application serving it, if known.
+type serveRoute struct {
+	route       string
+	application string
+}
+
+// serveConfigApplications is the part of a multi-application serve config which declares the routes.
+type serveConfigApplications struct {
+	Applications []struct {
+		Name        string `json:"name"`
+		RoutePrefix string `json:"route_prefix,omitempty"`
+	} `json:"applications"`
+}
+
+// probeService requests the health endpoint of the Serve HTTP proxy of a RayService and its routes through the serve
+// service. The routes default to the route prefixes of its applications. Unreachable routes are reported in the result
+// rather than as an error.
+func (s *RayServiceServer) probeService(ctx context.Context, rayService *rayv1api.RayService, routes []string, timeout time.Duration) *api.RayServiceHealth {
+	port := utils.DefaultServingPort
+	if containers := rayService.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers; len(containers) > utils.RayContainerIndex {
+		port = utils.FindContainerPort(&containers[utils.RayContainerIndex], utils.ServingPortName, utils.DefaultServingPort)
+	}
+	host := fmt.Sprintf("%s.%s.svc.%s", utils.GenerateServeServiceName(rayService.Name), rayService.Namespace, utils.GetClusterDomainName())
+	baseURL := "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+
+	health := &api.RayServiceHealth{Name: rayService.Name, Namespace: rayService.Namespace}
+	health.Proxy = &api.EndpointConnectivity{Address: baseURL + serveProxyHealthPath}
+	statusCode, latency, err := s.probe(ctx, health.Proxy.Address, timeout)
+	health.Proxy.LatencyMs = latency.Milliseconds()
+	if err == nil && statusCode != http.StatusOK {
+		err = fmt.Errorf("the proxy answered %d %s", statusCode, http.StatusText(statusCode))
+	}
+	if err != nil {
+		health.Proxy.Error = err.Error()
+	}
+	health.Proxy.Reachable = err == nil
+	health.Healthy = health.Proxy.Reachable
+
+	applications := rayService.Status.ActiveServiceStatus.Applications
+	for _, route := range serveRoutes(rayService, routes) {
+		routeHealth := &api.RouteHealth{Route: route.route, Application: route.application}
+		if route.application != "" {
+			routeHealth.ApplicationStatus = applications[route.application].Status
+		}
+		statusCode, latency, err := s.probe(ctx, baseURL+route.route, timeout)
+		routeHealth.StatusCode = int32(statusCode)
+		routeHealth.LatencyMs = latency.Milliseconds()
+		// Serve answers 404 for the routes of no application, and 503 for the applications which can't take requests.
+		// The other client errors come from the application itself, e.g. 405 for an application only serving POST.
+		if err == nil && (statusCode == http.StatusNotFound || statusCode >= http.StatusInternalServerError) {
+			err = fmt.Errorf("the route answered %d %s", statusCode, http.StatusText(statusCode))
+		}
+		if err != nil {
+			routeHealth.Error = err.Error()
+		}
+		routeHealth.Healthy = err == nil
+		health.Healthy = health.Healthy && routeHealth.Healthy
+		health.Routes = append(health.Routes, routeHealth)
+	}
+	return health
+}
+
+// probe sends a GET request to a URL and returns the status code of the answer and how long it took.
+func (s *RayServiceServer) probe(ctx context.Context, url string, timeout time.Duration) (int, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	start := time.Now()
+	response, err := s.httpClient.Do(request)
+	latency := time.Since(start)
+	if err != nil {
+		return 0, latency, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	return response.StatusCode, latency, nil
+}
+
+// serveRoutes returns the routes to probe: the requested routes, otherwise the route prefixes of the applications of
+// the serve config, as reported in the status of the RayService if the serve config does not set them. The Serve
+// default route prefix is /.
+func serveRoutes(rayService *rayv1api.RayService, routes []string) []serveRoute {
+	applications := rayService.Status.ActiveServiceStatus.Applications
+	applicationOf := map[string]string{}
+	for name, application := range applications {
+		if application.RoutePrefix != "" {
+			applicationOf[application.RoutePrefix] = name
+		}
+	}
+	if len(routes) > 0 {
+		result := make([]serveRoute, 0, len(routes))
+		for _, route := range routes {
+			result = append(result, serveRoute{route: route, application: applicationOf[route]})
+		}
+		return result
+	}
+
+	var config serveConfigApplications
+	if err := yaml.Unmarshal([]byte(rayService.Spec.ServeConfigV2), &config); err == nil && len(config.Applications) > 0 {
+		result := make([]serveRoute, 0, len(config.Applications))
+		for _, application := range config.Applications {
+			route := application.RoutePrefix
+			if route == "" {
+				route = applications[application.Name].RoutePrefix
+			}
+			if route == "" {
+				route = "/"
+			}
+			result = append(result, serveRoute{route: route, application: application.Name})
+		}
+		return result
+	}
+
+	result := make([]serveRoute, 0, len(applicationOf))
+	for route, application := range applicationOf {
+		result = append(result, serveRoute{route: route, application: application})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].route < result[j].route })
+	return result
+}
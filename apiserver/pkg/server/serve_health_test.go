@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestProbeService(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case serveProxyHealthPath, "/fruit":
+			w.WriteHeader(http.StatusOK)
+		case "/math":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer proxy.Close()
+	// Every address resolves to the fake proxy, like the serve service does in the Kubernetes cluster.
+	dialer := &net.Dialer{}
+	server := &RayServiceServer{httpClient: &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, proxy.Listener.Addr().String())
+		},
+	}}}
+
+	rayService := &rayv1api.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "ray"},
+		Spec: rayv1api.RayServiceSpec{ServeConfigV2: `applications:
+  - name: fruit_app
+    route_prefix: /fruit
+  - name: math_app
+    route_prefix: /math
+`},
+	}
+	rayService.Status.ActiveServiceStatus.Applications = map[string]rayv1api.AppStatus{
+		"fruit_app": {Status: "RUNNING", RoutePrefix: "/fruit"},
+		"math_app":  {Status: "DEPLOYING", RoutePrefix: "/math"},
+	}
+
+	health := server.probeService(context.Background(), rayService, nil, time.Second)
+	assert.Equal(t, "service", health.Name)
+	assert.Equal(t, "http://service-serve-svc.ray.svc.cluster.local:8000/-/healthz", health.Proxy.Address)
+	assert.True(t, health.Proxy.Reachable)
+	require.Len(t, health.Routes, 2)
+	assert.Equal(t, "/fruit", health.Routes[0].Route)
+	assert.Equal(t, "fruit_app", health.Routes[0].Application)
+	assert.Equal(t, "RUNNING", health.Routes[0].ApplicationStatus)
+	assert.True(t, health.Routes[0].Healthy)
+	assert.Equal(t, int32(http.StatusOK), health.Routes[0].StatusCode)
+	assert.Equal(t, "DEPLOYING", health.Routes[1].ApplicationStatus)
+	assert.False(t, health.Routes[1].Healthy)
+	assert.Equal(t, int32(http.StatusServiceUnavailable), health.Routes[1].StatusCode)
+	assert.NotEmpty(t, health.Routes[1].Error)
+	assert.False(t, health.Healthy)
+
+	health = server.probeService(context.Background(), rayService, []string{"/fruit"}, time.Second)
+	require.Len(t, health.Routes, 1)
+	assert.Equal(t, "fruit_app", health.Routes[0].Application)
+	assert.True(t, health.Healthy)
+
+	health = server.probeService(context.Background(), rayService, []string{"/unknown"}, time.Second)
+	assert.Equal(t, int32(http.StatusNotFound), health.Routes[0].StatusCode)
+	assert.False(t, health.Healthy)
+
+	proxy.Close()
+	health = server.probeService(context.Background(), rayService, nil, time.Second)
+	assert.False(t, health.Proxy.Reachable)
+	assert.NotEmpty(t, health.Proxy.Error)
+	assert.Equal(t, int32(0), health.Routes[0].StatusCode)
+	assert.False(t, health.Healthy)
+}
+
+func TestServeRoutes(t *testing.T) {
+	rayService := &rayv1api.RayService{Spec: rayv1api.RayServiceSpec{ServeConfigV2: `applications:
+  - name: default_app
+  - name: reported_app
+`}}
+	rayService.Status.ActiveServiceStatus.Applications = map[string]rayv1api.AppStatus{"reported_app": {RoutePrefix: "/reported"}}
+	assert.Equal(t, []serveRoute{{route: "/", application: "default_app"}, {route: "/reported", application: "reported_app"}}, serveRoutes(rayService, nil))
+
+	// Without a serve config, the routes reported in the status are probed.
+	rayService.Spec.ServeConfigV2 = ""
+	assert.Equal(t, []serveRoute{{route: "/reported", application: "reported_app"}}, serveRoutes(rayService, nil))
+}
@@ -2,11 +2,19 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/ray-project/kuberay/apiserver/pkg/datastore"
+	"github.com/ray-project/kuberay/apiserver/pkg/features"
 	"github.com/ray-project/kuberay/apiserver/pkg/manager"
 	"github.com/ray-project/kuberay/apiserver/pkg/model"
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	corev1 "k8s.io/api/core/v1"
 	klog "k8s.io/klog/v2"
@@ -14,18 +22,23 @@ import (
 
 type ServiceServerOptions struct {
 	CollectMetrics bool
+	// History is the datastore recording the changes of the resources, nil if it is not configured.
+	History datastore.Store
 }
 
 // implements `type RayServeServiceServer interface` in serve_grpc.pb.go
 // RayServiceServer is the server API for RayServeService service.
 type RayServiceServer struct {
-	resourceManager *manager.ResourceManager
-	options         *ServiceServerOptions
+	serviceStore manager.ServiceStore
+	eventSource  manager.EventSource
+	options      *ServiceServerOptions
 	api.UnimplementedRayServeServiceServer
+
+	httpClient *http.Client
 }
 
-func NewRayServiceServer(resourceManager *manager.ResourceManager, options *ServiceServerOptions) *RayServiceServer {
-	return &RayServiceServer{resourceManager: resourceManager, options: options}
+func NewRayServiceServer(serviceStore manager.ServiceStore, eventSource manager.EventSource, options *ServiceServerOptions) *RayServiceServer {
+	return &RayServiceServer{serviceStore: serviceStore, eventSource: eventSource, options: options, httpClient: &http.Client{}}
 }
 
 // Create a new Ray Service
@@ -35,16 +48,28 @@ func (s *RayServiceServer) CreateRayService(ctx context.Context, request *api.Cr
 	}
 
 	request.Service.Namespace = request.Namespace
+	if request.Service.Name == "" {
+		request.Service.Name = util.GenerateName(request.Service.GenerateName)
+	}
 
-	rayService, err := s.resourceManager.CreateService(ctx, request.Service)
+	rayService, err := s.serviceStore.CreateService(ctx, request.Service, request.DryRun, request.IdempotencyKey)
 	if err != nil {
 		return nil, util.Wrap(err, "Create ray service failed.")
 	}
-	events, err := s.resourceManager.GetServiceEvents(ctx, *rayService)
+	warnings := ServiceWarnings(request.Service)
+	if request.DryRun {
+		// The ray service was not persisted, so it has no events.
+		apiService := model.FromCrdToApiService(rayService, nil)
+		apiService.Warnings = warnings
+		return apiService, nil
+	}
+	events, err := s.eventSource.GetServiceEvents(ctx, *rayService)
 	if err != nil {
-		klog.Warningf("failed to get rayService's event, service: %s/%s, err: %v", rayService.Namespace, rayService.Name, err)
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the service", "service", klog.KObj(rayService))
 	}
-	return model.FromCrdToApiService(rayService, events), nil
+	apiService := model.FromCrdToApiService(rayService, events)
+	apiService.Warnings = warnings
+	return apiService, nil
 }
 
 func (s *RayServiceServer) UpdateRayService(ctx context.Context, request *api.UpdateRayServiceRequest) (*api.RayService, error) {
@@ -53,150 +78,653 @@ func (s *RayServiceServer) UpdateRayService(ctx context.Context, request *api.Up
 	}
 	request.Service.Namespace = request.Namespace
 
-	rayService, err := s.resourceManager.UpdateRayService(ctx, request.Service)
+	rayService, err := s.serviceStore.UpdateRayService(ctx, request.Service)
 	if err != nil {
 		return nil, util.Wrap(err, "Update ray service failed.")
 	}
-	events, err := s.resourceManager.GetServiceEvents(ctx, *rayService)
+	events, err := s.eventSource.GetServiceEvents(ctx, *rayService)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the service", "service", klog.KObj(rayService))
+	}
+	apiService := model.FromCrdToApiService(rayService, events)
+	apiService.Warnings = ServiceWarnings(request.Service)
+	return apiService, nil
+}
+
+// Returns the changes an UpdateRayService call would make to a ray service, and whether they need a new ray cluster,
+// without applying them.
+func (s *RayServiceServer) PreviewRayServiceUpdate(ctx context.Context, request *api.UpdateRayServiceRequest) (*api.UpdatePreview, error) {
+	if err := ValidateUpdateServiceRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate update service request failed.")
+	}
+	request.Service.Namespace = request.Namespace
+
+	preview, err := s.serviceStore.PreviewRayServiceUpdate(ctx, request.Service)
 	if err != nil {
-		klog.Warningf("failed to get rayService's event, service: %s/%s, err: %v", rayService.Namespace, rayService.Name, err)
+		return nil, util.Wrap(err, "Preview ray service update failed.")
+	}
+	return preview, nil
+}
+
+// Updates the serve config and the worker group replicas of a ray service in place.
+func (s *RayServiceServer) UpdateRayServiceConfigs(ctx context.Context, request *api.UpdateRayServiceConfigsRequest) (*api.RayService, error) {
+	if err := ValidateUpdateRayServiceConfigsRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate update service configs request failed.")
+	}
+
+	rayService, err := s.serviceStore.UpdateRayServiceConfigs(ctx, request)
+	if err != nil {
+		return nil, util.Wrap(err, "Update ray service configs failed.")
+	}
+	events, err := s.eventSource.GetServiceEvents(ctx, *rayService)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the service", "service", klog.KObj(rayService))
 	}
 	return model.FromCrdToApiService(rayService, events), nil
 }
 
 func (s *RayServiceServer) GetRayService(ctx context.Context, request *api.GetRayServiceRequest) (*api.RayService, error) {
 	if request.Name == "" {
-		return nil, util.NewInvalidInputError("ray service name is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
 	}
 
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("ray service namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
 	}
-	service, err := s.resourceManager.GetService(ctx, request.Name, request.Namespace)
+	eventFilter, err := NewEventFilter(request.EventType, request.EventsSince, request.EventLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateResourceView(request.View); err != nil {
+		return nil, err
+	}
+	service, err := s.serviceStore.GetService(ctx, request.Name, request.Namespace)
 	if err != nil {
 		return nil, util.Wrap(err, "get ray service failed")
 	}
-	events, err := s.resourceManager.GetServiceEvents(ctx, *service)
+	if request.View == api.ResourceView_BASIC {
+		return model.FromCrdToApiService(service, nil), nil
+	}
+	events, err := s.eventSource.GetServiceEvents(ctx, *service)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the service", "service", klog.KObj(service))
+	}
+	return model.FromCrdToApiService(service, eventFilter.Apply(events)), nil
+}
+
+// Exports a RayService as the YAML manifest of its custom resource.
+func (s *RayServiceServer) ExportRayService(ctx context.Context, request *api.ExportRayServiceRequest) (*api.ResourceManifest, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+
+	service, err := s.serviceStore.GetService(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "get ray service failed")
+	}
+	manifest, err := model.FromCrdToApiServiceManifest(service, request.IncludeStatus)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to export ray service (%s/%s)", request.Namespace, request.Name)
+	}
+	return manifest, nil
+}
+
+// Creates a RayService from the manifest of its custom resource, which is converted to the API model and then
+// validated and created like by CreateRayService.
+func (s *RayServiceServer) ImportRayService(ctx context.Context, request *api.ImportRayServiceRequest) (*api.RayService, error) {
+	service, err := ValidateImportServiceRequest(request)
+	if err != nil {
+		return nil, util.Wrap(err, "Validate import service request failed.")
+	}
+	return s.CreateRayService(ctx, &api.CreateRayServiceRequest{
+		Service:       service,
+		Namespace:     request.Namespace,
+		DryRun:        request.DryRun,
+		TargetCluster: request.TargetCluster,
+	})
+}
+
+// Returns the endpoints of a RayService, resolved from its Kubernetes Services and the Ingress of its active cluster.
+func (s *RayServiceServer) GetRayServiceEndpoints(ctx context.Context, request *api.GetRayServiceEndpointsRequest) (*api.RayEndpoints, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	endpoints, err := s.serviceStore.GetServiceEndpoints(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "get ray service endpoints failed")
+	}
+	return endpoints, nil
+}
+
+// Lists the changes made to a RayService through the API server, most recent first.
+func (s *RayServiceServer) ListRayServiceHistory(ctx context.Context, request *api.ListRayServiceHistoryRequest) (*api.ListResourceHistoryResponse, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	if request.Limit < 0 {
+		return nil, util.NewInvalidFieldError("limit", "Limit %d is negative. Please specify a valid value.", request.Limit)
+	}
+	return listHistory(ctx, s.options.History, datastore.Query{Kind: "RayService", Namespace: request.Namespace, Name: request.Name, Limit: int(request.Limit)})
+}
+
+// Probes the Serve HTTP proxy of a RayService and the routes of its applications.
+func (s *RayServiceServer) HealthCheckRayService(ctx context.Context, request *api.HealthCheckRayServiceRequest) (*api.RayServiceHealth, error) {
+	if err := ValidateHealthCheckRayServiceRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate health check ray service request failed.")
+	}
+
+	rayService, err := s.serviceStore.GetService(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "health check ray service failed")
+	}
+	timeout := defaultConnectivityTimeout
+	if request.TimeoutSeconds > 0 {
+		timeout = time.Duration(request.TimeoutSeconds) * time.Second
+	}
+	return s.probeService(ctx, rayService, request.Routes, timeout), nil
+}
+
+// GetRayServeConfig returns the serve config of a ray service, the config deployed on its active cluster and the
+// differences between them.
+func (s *RayServiceServer) GetRayServeConfig(ctx context.Context, request *api.GetRayServeConfigRequest) (*api.RayServeConfig, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	serveConfig, err := s.serviceStore.GetServiceServeConfig(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "get ray serve config failed")
+	}
+	return serveConfig, nil
+}
+
+// WatchRayService streams the ray service when the watch starts and every time its status changes, until the
+// client cancels the call or the ray service is deleted. The events of the ray service are not streamed.
+// Suspends a RayService by scaling its worker groups to zero, the head keeps running.
+func (s *RayServiceServer) SuspendRayService(ctx context.Context, request *api.SuspendRayServiceRequest) (*api.RayService, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	service, err := s.serviceStore.SuspendService(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "suspend ray service failed")
+	}
+	events, err := s.eventSource.GetServiceEvents(ctx, *service)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the service", "service", klog.KObj(service))
+	}
+	return model.FromCrdToApiService(service, events), nil
+}
+
+// Resumes a suspended RayService by scaling its worker groups back to their scale before the suspension.
+func (s *RayServiceServer) ResumeRayService(ctx context.Context, request *api.ResumeRayServiceRequest) (*api.RayService, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	service, err := s.serviceStore.ResumeService(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "resume ray service failed")
+	}
+	events, err := s.eventSource.GetServiceEvents(ctx, *service)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the service", "service", klog.KObj(service))
+	}
+	return model.FromCrdToApiService(service, events), nil
+}
+
+// Starts a staged upgrade of a ray service, whose new cluster is prepared without receiving the traffic until the
+// upgrade is promoted.
+func (s *RayServiceServer) StartRayServiceUpgrade(ctx context.Context, request *api.StartRayServiceUpgradeRequest) (*api.RayService, error) {
+	if err := ValidateStartRayServiceUpgradeRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate start service upgrade request failed.")
+	}
+	request.Service.Namespace = request.Namespace
+
+	service, err := s.serviceStore.StartServiceUpgrade(ctx, request.Service)
+	if err != nil {
+		return nil, util.Wrap(err, "start ray service upgrade failed")
+	}
+	events, err := s.eventSource.GetServiceEvents(ctx, *service)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the service", "service", klog.KObj(service))
+	}
+	apiService := model.FromCrdToApiService(service, events)
+	apiService.Warnings = ServiceWarnings(request.Service)
+	return apiService, nil
+}
+
+// Promotes a staged upgrade of a ray service, switching its traffic to the new cluster.
+func (s *RayServiceServer) PromoteRayServiceUpgrade(ctx context.Context, request *api.PromoteRayServiceUpgradeRequest) (*api.RayService, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	service, err := s.serviceStore.PromoteServiceUpgrade(ctx, request.Name, request.Namespace, request.Force)
+	if err != nil {
+		return nil, util.Wrap(err, "promote ray service upgrade failed")
+	}
+	events, err := s.eventSource.GetServiceEvents(ctx, *service)
+	if err != nil {
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the service", "service", klog.KObj(service))
+	}
+	return model.FromCrdToApiService(service, events), nil
+}
+
+// Rolls back a staged upgrade of a ray service, which keeps serving from its current cluster.
+func (s *RayServiceServer) RollbackRayServiceUpgrade(ctx context.Context, request *api.RollbackRayServiceUpgradeRequest) (*api.RayService, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	service, err := s.serviceStore.RollbackServiceUpgrade(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "rollback ray service upgrade failed")
+	}
+	events, err := s.eventSource.GetServiceEvents(ctx, *service)
 	if err != nil {
-		klog.Warningf("failed to get rayService's event, service: %s/%s, err: %v", service.Namespace, service.Name, err)
+		klog.FromContext(ctx).Error(err, "Failed to get the events of the service", "service", klog.KObj(service))
 	}
 	return model.FromCrdToApiService(service, events), nil
 }
 
+// Streams a specific ray service when the watch starts and every time it changes. Only served when the WatchRPCs
+// feature gate is enabled.
+func (s *RayServiceServer) WatchRayService(request *api.WatchRayServiceRequest, stream api.RayServeService_WatchRayServiceServer) error {
+	if !features.Enabled(features.WatchRPCs) {
+		return util.NewUnimplementedError("Watch RPCs are disabled. Enable the %s feature gate to use them.", features.WatchRPCs)
+	}
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	services, err := s.serviceStore.WatchService(stream.Context(), request.Name, request.Namespace)
+	if err != nil {
+		return util.Wrap(err, "watch ray service failed")
+	}
+	var lastStatus *api.RayServiceStatus
+	for service := range services {
+		apiService := model.FromCrdToApiService(service, nil)
+		if lastStatus != nil && proto.Equal(lastStatus, apiService.RayServiceStatus) {
+			continue
+		}
+		if err := stream.Send(apiService); err != nil {
+			return err
+		}
+		lastStatus = apiService.RayServiceStatus
+	}
+	return nil
+}
+
+// Streams the logs of the pods of the ray cluster serving a ray service.
+func (s *RayServiceServer) StreamRayServiceLogs(request *api.StreamRayServiceLogsRequest, stream api.RayServeService_StreamRayServiceLogsServer) error {
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	if request.TailLines < 0 {
+		return util.NewInvalidFieldError("tail_lines", "tail lines %d is negative. Please specify a valid value.", request.TailLines)
+	}
+	lines, err := s.serviceStore.StreamServiceLogs(stream.Context(), request.Name, request.Namespace, manager.PodLogOptions{
+		Follow:         request.Follow,
+		TailLines:      request.TailLines,
+		IncludeWorkers: request.IncludeWorkers,
+	})
+	if err != nil {
+		return util.Wrap(err, "stream ray service logs failed")
+	}
+	for line := range lines {
+		if err := stream.Send(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *RayServiceServer) ListRayServices(ctx context.Context, request *api.ListRayServicesRequest) (*api.ListRayServicesResponse, error) {
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("ray service namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
 	}
-	services, err := s.resourceManager.ListServices(ctx, request.Namespace)
+	if err := ValidatePageSize(request.PageSize); err != nil {
+		return nil, err
+	}
+	if err := ValidateSelector(request.LabelSelector, request.FieldSelector); err != nil {
+		return nil, err
+	}
+	eventFilter, err := NewEventFilter(request.EventType, request.EventsSince, request.EventLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateResourceView(request.View); err != nil {
+		return nil, err
+	}
+	listFilter, err := NewListFilter(request.Filter, manager.ServiceFilterFields)
+	if err != nil {
+		return nil, err
+	}
+	selector := manager.ResourceSelector{LabelSelector: request.LabelSelector, FieldSelector: request.FieldSelector}
+
+	services, nextPageToken, err := s.serviceStore.ListServices(ctx, request.Namespace, request.PageToken, int64(request.PageSize), selector)
 	if err != nil {
 		return nil, util.Wrap(err, "failed to list rayservice.")
 	}
+	services = listFilter.Apply(services)
 	serviceEventMap := make(map[string][]corev1.Event)
-	for _, service := range services {
-		serviceEvents, err := s.resourceManager.GetServiceEvents(ctx, *service)
-		if err != nil {
-			klog.Warningf("Failed to get cluster's event, cluster: %s/%s, err: %v", service.Namespace, service.Name, err)
-			continue
+	if request.View != api.ResourceView_BASIC {
+		if serviceEventMap, err = s.eventSource.GetServicesEvents(ctx, services); err != nil {
+			klog.FromContext(ctx).Error(err, "Failed to get the events of the services", "services", len(services))
+			serviceEventMap = make(map[string][]corev1.Event)
 		}
-		serviceEventMap[service.Name] = serviceEvents
+		eventFilter.ApplyToMap(serviceEventMap)
 	}
 	return &api.ListRayServicesResponse{
-		Services: model.FromCrdToApiServices(services, serviceEventMap),
+		Services:      model.FromCrdToApiServices(services, serviceEventMap),
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
 func (s *RayServiceServer) ListAllRayServices(ctx context.Context, request *api.ListAllRayServicesRequest) (*api.ListAllRayServicesResponse, error) {
-	services, err := s.resourceManager.ListAllServices(ctx)
+	if err := ValidatePageSize(request.PageSize); err != nil {
+		return nil, err
+	}
+	if err := ValidateSelector(request.LabelSelector, request.FieldSelector); err != nil {
+		return nil, err
+	}
+	eventFilter, err := NewEventFilter(request.EventType, request.EventsSince, request.EventLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateResourceView(request.View); err != nil {
+		return nil, err
+	}
+	listFilter, err := NewListFilter(request.Filter, manager.ServiceFilterFields)
+	if err != nil {
+		return nil, err
+	}
+	selector := manager.ResourceSelector{LabelSelector: request.LabelSelector, FieldSelector: request.FieldSelector}
+
+	services, listMeta, err := s.serviceStore.ListAllServices(ctx, request.PageToken, int64(request.PageSize), request.ResourceVersion, selector)
 	if err != nil {
 		return nil, util.Wrap(err, "list all services failed.")
 	}
+	services = listFilter.Apply(services)
 	serviceEventMap := make(map[string][]corev1.Event)
-	for _, service := range services {
-		serviceEvents, err := s.resourceManager.GetServiceEvents(ctx, *service)
-		if err != nil {
-			klog.Warningf("Failed to get cluster's event, cluster: %s/%s, err: %v", service.Namespace, service.Name, err)
-			continue
+	if request.View != api.ResourceView_BASIC {
+		if serviceEventMap, err = s.eventSource.GetServicesEvents(ctx, services); err != nil {
+			klog.FromContext(ctx).Error(err, "Failed to get the events of the services", "services", len(services))
+			serviceEventMap = make(map[string][]corev1.Event)
 		}
-		serviceEventMap[service.Name] = serviceEvents
+		eventFilter.ApplyToMap(serviceEventMap)
 	}
 	return &api.ListAllRayServicesResponse{
-		Services: model.FromCrdToApiServices(services, serviceEventMap),
+		Services:        model.FromCrdToApiServices(services, serviceEventMap),
+		NextPageToken:   listMeta.Continue,
+		ResourceVersion: listMeta.ResourceVersion,
 	}, nil
 }
 
 func (s *RayServiceServer) DeleteRayService(ctx context.Context, request *api.DeleteRayServiceRequest) (*emptypb.Empty, error) {
 	if request.Name == "" {
-		return nil, util.NewInvalidInputError("ray service name is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
 	}
 
 	if request.Namespace == "" {
-		return nil, util.NewInvalidInputError("ray service namespace is empty. Please specify a valid value.")
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+	if request.RetainCluster {
+		if err := s.serviceStore.DeleteServiceAndRetainCluster(ctx, request.Name, request.Namespace, request.Force, request.Foreground, request.ResourceVersion); err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
 	}
-	if err := s.resourceManager.DeleteService(ctx, request.Name, request.Namespace); err != nil {
+
+	if err := s.serviceStore.DeleteService(ctx, request.Name, request.Namespace, request.Force, request.Foreground, request.ResourceVersion); err != nil {
 		return nil, err
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// Finds the progress of the deletion of a ray service.
+func (s *RayServiceServer) GetRayServiceDeletionStatus(ctx context.Context, request *api.GetRayServiceDeletionStatusRequest) (*api.RayServiceDeletionStatus, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "ray service name is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "ray service namespace is empty. Please specify a valid value.")
+	}
+
+	status, err := s.serviceStore.GetServiceDeletionStatus(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get ray service deletion status failed.")
+	}
+	return status, nil
+}
+
+// ValidateImportServiceRequest converts the manifest of an import request to the ray service to be created, which
+// is then validated like a created ray service.
+func ValidateImportServiceRequest(request *api.ImportRayServiceRequest) (*api.RayService, error) {
+	if request == nil {
+		return nil, util.NewInvalidInputError("A non nill request is expected")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if request.Manifest == "" {
+		return nil, util.NewInvalidFieldError("manifest", "Manifest is empty. Please specify a valid value.")
+	}
+
+	service, err := model.FromManifestToApiService(request.Manifest)
+	if err != nil {
+		return nil, util.NewInvalidFieldError("manifest", "Manifest is invalid: %v", err)
+	}
+	if service.Namespace == "" {
+		service.Namespace = request.Namespace
+	} else if service.Namespace != request.Namespace {
+		return nil, util.NewInvalidInputError("The namespace in the request is different from the namespace in the manifest.")
+	}
+	if service.User == "" {
+		service.User = request.User
+	}
+	return service, nil
+}
+
 func ValidateCreateServiceRequest(request *api.CreateRayServiceRequest) error {
 	if request == nil {
 		return util.NewInvalidInputError("A non nill request is expected")
 	}
+	var errs util.ValidationErrors
 	if request.Namespace == "" {
-		return util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		errs.Add(util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value."))
 	}
+	validateService(&errs, request.Namespace, request.Service, true)
+	errs.AddField("idempotency_key", ValidateIdempotencyKey(request.IdempotencyKey))
+	return errs.Err()
+}
 
-	if request.Service == nil {
-		return util.NewInvalidInputError("Service is empty, please input a valid payload.")
+func ValidateUpdateServiceRequest(request *api.UpdateRayServiceRequest) error {
+	var errs util.ValidationErrors
+	if request.Name == "" {
+		errs.Add(util.NewInvalidFieldError("name", "Service name is empty. Please specify a valid value."))
+	}
+	if request.Namespace == "" {
+		errs.Add(util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value."))
 	}
+	validateService(&errs, request.Namespace, request.Service, false)
+	return errs.Err()
+}
 
-	if request.Namespace != request.Service.Namespace {
-		return util.NewInvalidInputError("The namespace in the request is different from the namespace in the service definition.")
+// validateService adds the invalid fields of the service of a create or an update request to the errors of the
+// request. The name of a service to update is only required, as the existing services may have longer names.
+func validateService(errs *util.ValidationErrors, namespace string, service *api.RayService, create bool) {
+	if service == nil {
+		errs.Add(util.NewInvalidFieldError("service", "Service is empty, please input a valid payload."))
+		return
 	}
 
-	if request.Service.Name == "" {
-		return util.NewInvalidInputError("Service name is empty. Please specify a valid value.")
+	if namespace != "" && namespace != service.Namespace {
+		errs.Add(util.NewInvalidFieldError("service.namespace", "The namespace in the request is different from the namespace in the service definition."))
 	}
 
-	if request.Service.User == "" {
-		return util.NewInvalidInputError("User who create the Service is empty. Please specify a valid value.")
+	var nameErr error
+	if create {
+		nameErr = ValidateResourceName("Service", service.Name, service.GenerateName, maxServiceNameLength)
+		errs.AddField("service", nameErr)
+	} else if service.Name == "" {
+		errs.Add(util.NewInvalidFieldError("service.name", "Service name is empty. Please specify a valid value."))
 	}
 
-	if err := ValidateClusterSpec(request.Service.ClusterSpec); err != nil {
-		return err
+	if service.User == "" {
+		errs.Add(util.NewInvalidFieldError("service.user", "User who create the Service is empty. Please specify a valid value."))
 	}
 
+	// The images and the generated names are only checked against a valid cluster spec, and the generated names against
+	// a valid name.
+	if err := ValidateClusterSpec(service.ClusterSpec); err != nil {
+		errs.AddField("service.cluster_spec", err)
+	} else {
+		errs.AddField("service.cluster_spec", ValidateClusterImages(service.Version, service.ClusterSpec))
+		// The RayClusters of a RayService are named after the service with a suffix.
+		if nameErr == nil {
+			name, field := resourceName(service.Name, service.GenerateName)
+			errs.AddField("service."+field, ValidateGeneratedNames(utils.GenerateRayClusterName(name), service.ClusterSpec))
+		}
+	}
+	errs.AddField("service.serve_service", ValidateServeServiceOptions(service.ServeService, service.ClusterSpec))
+	errs.AddField("service.expose", ValidateExposeOptions(service.Expose))
+	errs.Add(ValidateServeDeploymentAutoscaling("service.deployment_autoscaling", service.ServeConfig_V2, service.DeploymentAutoscaling))
+}
+
+// ValidateStartRayServiceUpgradeRequest validates the new service of an upgrade like the service of an update.
+func ValidateStartRayServiceUpgradeRequest(request *api.StartRayServiceUpgradeRequest) error {
+	if err := ValidateUpdateServiceRequest(&api.UpdateRayServiceRequest{
+		Service:   request.Service,
+		Namespace: request.Namespace,
+		Name:      request.Name,
+	}); err != nil {
+		return err
+	}
+	if request.Name != request.Service.Name {
+		return util.NewInvalidInputError("The name in the request is different from the name in the service definition.")
+	}
 	return nil
 }
 
-func ValidateUpdateServiceRequest(request *api.UpdateRayServiceRequest) error {
+func ValidateHealthCheckRayServiceRequest(request *api.HealthCheckRayServiceRequest) error {
 	if request.Name == "" {
-		return util.NewInvalidInputError("Service name is empty. Please specify a valid value.")
+		return util.NewInvalidFieldError("name", "Service name is empty. Please specify a valid value.")
 	}
 	if request.Namespace == "" {
-		return util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
 	}
-
-	if request.Service == nil {
-		return util.NewInvalidInputError("Service is empty, please input a valid payload.")
+	if request.TimeoutSeconds < 0 {
+		return util.NewInvalidFieldError("timeout_seconds", "Timeout seconds can not be negative.")
 	}
-
-	if request.Namespace != request.Service.Namespace {
-		return util.NewInvalidInputError("The namespace in the request is different from the namespace in the service definition.")
+	for _, route := range request.Routes {
+		if !strings.HasPrefix(route, "/") {
+			return util.NewInvalidFieldError("routes", "Route %q does not start with /. Please specify a valid value.", route)
+		}
 	}
+	return nil
+}
 
-	if request.Service.Name == "" {
-		return util.NewInvalidInputError("Service name is empty. Please specify a valid value.")
+func ValidateUpdateRayServiceConfigsRequest(request *api.UpdateRayServiceConfigsRequest) error {
+	var errs util.ValidationErrors
+	if request.Name == "" {
+		errs.Add(util.NewInvalidFieldError("name", "Service name is empty. Please specify a valid value."))
+	}
+	if request.Namespace == "" {
+		errs.Add(util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value."))
 	}
 
-	if request.Service.User == "" {
-		return util.NewInvalidInputError("User who create the Service is empty. Please specify a valid value.")
+	updateService := request.UpdateService
+	if updateService == nil || (updateService.ServeConfig_V2 == "" && len(updateService.WorkerGroupUpdateSpec) == 0 && len(updateService.DeploymentAutoscaling) == 0) {
+		errs.Add(util.NewInvalidFieldError("update_service", "Update service body is empty. Please specify the serve config, the deployment autoscaling or the worker groups to be updated."))
+		return errs.Err()
 	}
+	errs.Add(ValidateServeDeploymentAutoscaling("update_service.deployment_autoscaling", updateService.ServeConfig_V2, updateService.DeploymentAutoscaling))
 
-	if err := ValidateClusterSpec(request.Service.ClusterSpec); err != nil {
-		return err
+	for index, spec := range updateService.WorkerGroupUpdateSpec {
+		field := fmt.Sprintf("update_service.worker_group_update_spec[%d]", index)
+		if spec == nil || spec.GroupName == "" {
+			errs.Add(util.NewInvalidFieldError(field+".group_name", "Worker group name is empty. Please specify a valid value."))
+			continue
+		}
+		if spec.MinReplicas < 0 {
+			errs.Add(util.NewInvalidFieldError(field+".min_replicas", "MinReplicas can not be negative. Please specify a valid value."))
+		} else if spec.MaxReplicas == 0 {
+			errs.Add(util.NewInvalidFieldError(field+".max_replicas", "MaxReplicas can not be 0. Please specify a valid value."))
+		} else if spec.MinReplicas > spec.MaxReplicas {
+			errs.Add(util.NewInvalidFieldError(field+".min_replicas", "MinReplicas > MaxReplicas. Please specify a valid value."))
+		} else if spec.Replicas < spec.MinReplicas || spec.Replicas > spec.MaxReplicas {
+			errs.Add(util.NewInvalidFieldError(field+".replicas", "Replicas should be between MinReplicas and MaxReplicas. Please specify a valid value."))
+		}
 	}
 
+	return errs.Err()
+}
+
+// ValidateServeDeploymentAutoscaling validates the replicas and the autoscaling of the deployments, and that their
+// applications are in the serve config. An empty serve config, which is not updated, is not checked.
+func ValidateServeDeploymentAutoscaling(field string, serveConfigV2 string, deployments []*api.ServeDeploymentAutoscaling) error {
+	names := map[string]bool{}
+	for _, deployment := range deployments {
+		if deployment == nil || deployment.Application == "" || deployment.Deployment == "" {
+			return util.NewInvalidFieldError(field, "The application and the deployment of the deployment autoscaling are required. Please specify valid values.")
+		}
+		name := deployment.Application + "/" + deployment.Deployment
+		if names[name] {
+			return util.NewInvalidFieldError(field, "Deployment %s is listed more than once.", name)
+		}
+		names[name] = true
+
+		autoscaled := deployment.MinReplicas != 0 || deployment.MaxReplicas != 0 || deployment.TargetNumOngoingRequests != 0 ||
+			deployment.UpscaleDelaySeconds != 0 || deployment.DownscaleDelaySeconds != 0 || deployment.ScaleToZero
+		switch {
+		case deployment.NumReplicas < 0 || deployment.MinReplicas < 0 || deployment.MaxReplicas < 0 || deployment.TargetNumOngoingRequests < 0 ||
+			deployment.UpscaleDelaySeconds < 0 || deployment.DownscaleDelaySeconds < 0:
+			return util.NewInvalidFieldError(field, "The replicas, the target and the delays of deployment %s can not be negative. Please specify valid values.", name)
+		case deployment.NumReplicas > 0 && autoscaled:
+			return util.NewInvalidFieldError(field, "Deployment %s sets both a number of replicas and autoscaling. Please specify one of them.", name)
+		case deployment.NumReplicas == 0 && !autoscaled:
+			return util.NewInvalidFieldError(field, "Deployment %s sets neither a number of replicas nor autoscaling. Please specify one of them.", name)
+		case deployment.MaxReplicas > 0 && deployment.MinReplicas > deployment.MaxReplicas:
+			return util.NewInvalidFieldError(field, "The min replicas of deployment %s are more than its max replicas. Please specify valid values.", name)
+		case deployment.ScaleToZero && deployment.MinReplicas > 0:
+			return util.NewInvalidFieldError(field, "Deployment %s can not scale to zero with %d min replicas. Please specify one of them.", name, deployment.MinReplicas)
+		}
+	}
+	if serveConfigV2 != "" {
+		if _, err := util.ApplyServeDeploymentAutoscaling(serveConfigV2, deployments); err != nil {
+			return util.NewInvalidFieldError(field, "The deployment autoscaling can not be rendered into the serve config: %v.", err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// imageTagPattern matches the tags of the images: at most 128 word characters, dots and dashes, not starting with a
+// dot or a dash.
+var imageTagPattern = regexp.MustCompile(`^\w[\w.-]{0,127}$`)
+
+type ServiceTemplateServerOptions struct {
+	CollectMetrics bool
+}
+
+// implements `type ServiceTemplateServiceServer interface` in service_template_grpc.pb.go
+// ServiceTemplateServer is the server API for ServiceTemplateService service.
+type ServiceTemplateServer struct {
+	templateStore manager.ServiceTemplateStore
+	// serviceServer creates the ray services, so that they are validated and created like with CreateRayService.
+	serviceServer *RayServiceServer
+	options       *ServiceTemplateServerOptions
+	api.UnimplementedServiceTemplateServiceServer
+}
+
+func NewServiceTemplateServer(templateStore manager.ServiceTemplateStore, serviceServer *RayServiceServer, options *ServiceTemplateServerOptions) *ServiceTemplateServer {
+	return &ServiceTemplateServer{templateStore: templateStore, serviceServer: serviceServer, options: options}
+}
+
+func (s *ServiceTemplateServer) CreateServiceTemplate(ctx context.Context, request *api.CreateServiceTemplateRequest) (*api.ServiceTemplate, error) {
+	if err := ValidateCreateServiceTemplateRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate service template request failed.")
+	}
+
+	configMap, err := s.templateStore.CreateServiceTemplate(ctx, request.ServiceTemplate)
+	if err != nil {
+		return nil, util.Wrap(err, "Create service template failed.")
+	}
+	return convertServiceTemplate(configMap)
+}
+
+func (s *ServiceTemplateServer) GetServiceTemplate(ctx context.Context, request *api.GetServiceTemplateRequest) (*api.ServiceTemplate, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Service template name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	configMap, err := s.templateStore.GetServiceTemplate(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get service template failed.")
+	}
+	return convertServiceTemplate(configMap)
+}
+
+func (s *ServiceTemplateServer) ListServiceTemplates(ctx context.Context, request *api.ListServiceTemplatesRequest) (*api.ListServiceTemplatesResponse, error) {
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	templates, err := s.listServiceTemplates(ctx, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListServiceTemplatesResponse{ServiceTemplates: templates}, nil
+}
+
+func (s *ServiceTemplateServer) ListAllServiceTemplates(ctx context.Context, _ *api.ListAllServiceTemplatesRequest) (*api.ListAllServiceTemplatesResponse, error) {
+	templates, err := s.listServiceTemplates(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return &api.ListAllServiceTemplatesResponse{ServiceTemplates: templates}, nil
+}
+
+func (s *ServiceTemplateServer) DeleteServiceTemplate(ctx context.Context, request *api.DeleteServiceTemplateRequest) (*emptypb.Empty, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Service template name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if err := s.templateStore.DeleteServiceTemplate(ctx, request.Name, request.Namespace); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Creates a ray service from the ray service of a template with the overrides of the request. The ray service is
+// created by CreateRayService, so it is validated against the compute templates and policies of the time.
+func (s *ServiceTemplateServer) CreateRayServiceFromTemplate(ctx context.Context, request *api.CreateRayServiceFromTemplateRequest) (*api.RayService, error) {
+	if err := ValidateCreateRayServiceFromTemplateRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate create ray service from template request failed.")
+	}
+
+	configMap, err := s.templateStore.GetServiceTemplate(ctx, request.TemplateName, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get service template failed.")
+	}
+	template, err := convertServiceTemplate(configMap)
+	if err != nil {
+		return nil, err
+	}
+	service, err := NewRayServiceFromTemplate(template, request)
+	if err != nil {
+		return nil, err
+	}
+	return s.serviceServer.CreateRayService(ctx, &api.CreateRayServiceRequest{
+		Service:   service,
+		Namespace: request.Namespace,
+		DryRun:    request.DryRun,
+	})
+}
+
+func (s *ServiceTemplateServer) listServiceTemplates(ctx context.Context, namespace string) ([]*api.ServiceTemplate, error) {
+	configMaps, err := s.templateStore.ListServiceTemplates(ctx, namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "List service templates failed.")
+	}
+
+	templates := make([]*api.ServiceTemplate, 0, len(configMaps))
+	for _, configMap := range configMaps {
+		template, err := convertServiceTemplate(configMap)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func convertServiceTemplate(configMap *corev1.ConfigMap) (*api.ServiceTemplate, error) {
+	template, err := model.FromKubeToAPIServiceTemplate(configMap)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert service template %s/%s", configMap.Namespace, configMap.Name)
+	}
+	return template, nil
+}
+
+// NewRayServiceFromTemplate returns the ray service of a template with the name, namespace and user of the
+// request, and its image tag and worker replicas overrides applied.
+func NewRayServiceFromTemplate(template *api.ServiceTemplate, request *api.CreateRayServiceFromTemplateRequest) (*api.RayService, error) {
+	service := proto.Clone(template.Service).(*api.RayService)
+	service.Name = request.Name
+	service.Namespace = request.Namespace
+	service.User = request.User
+	if service.ClusterSpec == nil {
+		return nil, util.NewFailedPreconditionError("Service template %s has no cluster spec.", template.Name)
+	}
+
+	if request.ImageTag != "" {
+		// The groups without image run the default image of the version.
+		service.Version = request.ImageTag
+		if head := service.ClusterSpec.HeadGroupSpec; head != nil && head.Image != "" {
+			head.Image = withImageTag(head.Image, request.ImageTag)
+		}
+		for _, spec := range service.ClusterSpec.WorkerGroupSpec {
+			if spec.Image != "" {
+				spec.Image = withImageTag(spec.Image, request.ImageTag)
+			}
+		}
+	}
+
+	for groupName, replicas := range request.WorkerReplicas {
+		var group *api.WorkerGroupSpec
+		for _, spec := range service.ClusterSpec.WorkerGroupSpec {
+			if spec.GroupName == groupName {
+				group = spec
+				break
+			}
+		}
+		if group == nil {
+			return nil, util.NewInvalidInputError("Service template %s has no worker group %s.", template.Name, groupName)
+		}
+		group.Replicas = replicas
+		if replicas < group.MinReplicas {
+			group.MinReplicas = replicas
+		}
+		if replicas > group.MaxReplicas {
+			group.MaxReplicas = replicas
+		}
+	}
+	return service, nil
+}
+
+// withImageTag replaces the tag of an image, which is also pinned by the tag rather than by its digest. The port of
+// a registry is not a tag.
+func withImageTag(image string, tag string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + ":" + tag
+}
+
+func ValidateCreateServiceTemplateRequest(request *api.CreateServiceTemplateRequest) error {
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if request.ServiceTemplate == nil {
+		return util.NewInvalidFieldError("service_template", "Service template is empty. Please specify a valid value.")
+	}
+
+	if request.Namespace != request.ServiceTemplate.Namespace {
+		return util.NewInvalidInputError("The namespace in the request is different from the namespace in the service template definition.")
+	}
+
+	if request.ServiceTemplate.Name == "" {
+		return util.NewInvalidFieldError("service_template.name", "Service template name is empty. Please specify a valid value.")
+	}
+
+	if errs := validation.IsDNS1123Subdomain(request.ServiceTemplate.Name); len(errs) > 0 {
+		return util.NewInvalidInputError("Service template name %s is invalid: %s", request.ServiceTemplate.Name, strings.Join(errs, ", "))
+	}
+
+	if request.ServiceTemplate.User == "" {
+		return util.NewInvalidFieldError("service_template.user", "User who create the service template is empty. Please specify a valid value.")
+	}
+
+	if request.ServiceTemplate.Service == nil {
+		return util.NewInvalidFieldError("service_template.service", "Service of the service template is empty. Please specify a valid value.")
+	}
+
+	// The ray service is validated like a ray service, with the name and namespace of the template.
+	service := proto.Clone(request.ServiceTemplate.Service).(*api.RayService)
+	service.Name = request.ServiceTemplate.Name
+	service.Namespace = request.Namespace
+	service.User = request.ServiceTemplate.User
+	return ValidateCreateServiceRequest(&api.CreateRayServiceRequest{Service: service, Namespace: request.Namespace})
+}
+
+func ValidateCreateRayServiceFromTemplateRequest(request *api.CreateRayServiceFromTemplateRequest) error {
+	if request.TemplateName == "" {
+		return util.NewInvalidFieldError("template_name", "Service template name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if request.Name == "" {
+		return util.NewInvalidFieldError("name", "Service name is empty. Please specify a valid value.")
+	}
+	if request.User == "" {
+		return util.NewInvalidFieldError("user", "User who create the Service is empty. Please specify a valid value.")
+	}
+	if request.ImageTag != "" && !imageTagPattern.MatchString(request.ImageTag) {
+		return util.NewInvalidInputError("Image tag %s is invalid.", request.ImageTag)
+	}
+	for groupName, replicas := range request.WorkerReplicas {
+		if replicas < 0 {
+			return util.NewInvalidInputError("Worker replicas of group %s must not be negative.", groupName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+func TestWithImageTag(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{image: "rayproject/ray", expected: "rayproject/ray:2.9.0"},
+		{image: "rayproject/ray:2.8.0-py310", expected: "rayproject/ray:2.9.0"},
+		{image: "registry:5000/ray", expected: "registry:5000/ray:2.9.0"},
+		{image: "registry:5000/ray:2.8.0", expected: "registry:5000/ray:2.9.0"},
+		{image: "rayproject/ray:2.8.0@sha256:0123", expected: "rayproject/ray:2.9.0"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, withImageTag(tc.image, "2.9.0"), tc.image)
+	}
+}
+
+func TestNewRayServiceFromTemplate(t *testing.T) {
+	template := &api.ServiceTemplate{
+		Name:      "fruit",
+		Namespace: "team-a",
+		User:      "owner",
+		Service: &api.RayService{
+			Name:    "ignored",
+			Version: "2.8.0",
+			ClusterSpec: &api.ClusterSpec{
+				HeadGroupSpec: &api.HeadGroupSpec{ComputeTemplate: "small", Image: "rayproject/ray:2.8.0"},
+				WorkerGroupSpec: []*api.WorkerGroupSpec{
+					{GroupName: "cpu", ComputeTemplate: "small", Replicas: 2, MinReplicas: 1, MaxReplicas: 4},
+					{GroupName: "gpu", ComputeTemplate: "gpu", Replicas: 1, MinReplicas: 1, MaxReplicas: 1},
+				},
+			},
+		},
+	}
+
+	service, err := NewRayServiceFromTemplate(template, &api.CreateRayServiceFromTemplateRequest{
+		TemplateName:   "fruit",
+		Namespace:      "team-a",
+		Name:           "fruit-canary",
+		User:           "user",
+		ImageTag:       "2.9.0",
+		WorkerReplicas: map[string]int32{"cpu": 0, "gpu": 3},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "fruit-canary", service.Name)
+	assert.Equal(t, "team-a", service.Namespace)
+	assert.Equal(t, "user", service.User)
+	assert.Equal(t, "2.9.0", service.Version)
+	assert.Equal(t, "rayproject/ray:2.9.0", service.ClusterSpec.HeadGroupSpec.Image)
+	// The groups without image run the default image of the version.
+	assert.Empty(t, service.ClusterSpec.WorkerGroupSpec[0].Image)
+	assert.Equal(t, []int32{0, 0, 4}, []int32{service.ClusterSpec.WorkerGroupSpec[0].Replicas, service.ClusterSpec.WorkerGroupSpec[0].MinReplicas, service.ClusterSpec.WorkerGroupSpec[0].MaxReplicas})
+	assert.Equal(t, []int32{3, 1, 3}, []int32{service.ClusterSpec.WorkerGroupSpec[1].Replicas, service.ClusterSpec.WorkerGroupSpec[1].MinReplicas, service.ClusterSpec.WorkerGroupSpec[1].MaxReplicas})
+	// The template is not modified.
+	assert.Equal(t, "ignored", template.Service.Name)
+	assert.Equal(t, int32(2), template.Service.ClusterSpec.WorkerGroupSpec[0].Replicas)
+
+	_, err = NewRayServiceFromTemplate(template, &api.CreateRayServiceFromTemplateRequest{
+		TemplateName:   "fruit",
+		Namespace:      "team-a",
+		Name:           "fruit-canary",
+		User:           "user",
+		WorkerReplicas: map[string]int32{"tpu": 1},
+	})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
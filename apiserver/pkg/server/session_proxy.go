@@ -0,0 +1,272 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	klog "k8s.io/klog/v2"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+const (
+	// ConnectRaySessionMethod is the method the Ray clients are authorized for when they connect to a session through
+	// the session proxy. It is not an RPC of the API server.
+	ConnectRaySessionMethod = "/proto.RaySessionService/ConnectRaySession"
+	// RaySessionMetadataKey is the metadata of the calls of the Ray clients with the id of their session,
+	// namespace/name.
+	RaySessionMetadataKey = "kuberay-session"
+	// sessionActivityInterval is how often the activity of the sessions with connected Ray clients is recorded.
+	sessionActivityInterval = time.Minute
+)
+
+// SessionAuthorizer authenticates the callers of the session proxy and authorizes them to connect to a session.
+type SessionAuthorizer interface {
+	AuthorizeResource(ctx context.Context, fullMethod string, namespace string, name string) (context.Context, error)
+}
+
+// SessionProxy forwards the calls of the Ray clients to the Ray client server of the head of their session, so that
+// notebooks connect with ray.init("ray://<session proxy>", _metadata=[("kuberay-session", "<namespace>/<name>")])
+// without port-forwarding the head pod. The messages are forwarded as is, so the proxy does not depend on the
+// protocol of the Ray client.
+type SessionProxy struct {
+	sessionStore manager.RaySessionStore
+	// authorizer authorizes the Ray clients, unless it is nil.
+	authorizer SessionAuthorizer
+
+	lock sync.Mutex
+	// sessions are the sessions with connected Ray clients, by id.
+	sessions map[string]*proxiedSession
+}
+
+// proxiedSession is a session with connected Ray clients, whose calls share a connection to its head.
+type proxiedSession struct {
+	conn    *grpc.ClientConn
+	streams int
+	// stop stops recording the activity of the session.
+	stop context.CancelFunc
+}
+
+func NewSessionProxy(sessionStore manager.RaySessionStore, authorizer SessionAuthorizer) *SessionProxy {
+	return &SessionProxy{sessionStore: sessionStore, authorizer: authorizer, sessions: map[string]*proxiedSession{}}
+}
+
+// ServerOptions returns the options of the gRPC server of the session proxy, which forwards every call it receives.
+func (p *SessionProxy) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{grpc.UnknownServiceHandler(p.handleStream), grpc.ForceServerCodec(rawCodec{})}
+}
+
+func (p *SessionProxy) handleStream(_ interface{}, serverStream grpc.ServerStream) error {
+	ctx := serverStream.Context()
+	fullMethod, ok := grpc.MethodFromServerStream(serverStream)
+	if !ok {
+		return status.Error(codes.Internal, "the method of the call is unknown")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	namespace, name, err := parseSessionID(md.Get(RaySessionMetadataKey))
+	if err != nil {
+		return util.ToGRPCError(err)
+	}
+	if p.authorizer != nil {
+		if ctx, err = p.authorizer.AuthorizeResource(ctx, ConnectRaySessionMethod, namespace, name); err != nil {
+			return err
+		}
+	}
+	address, err := p.sessionStore.GetRaySessionAddress(ctx, name, namespace)
+	if err != nil {
+		return util.ToGRPCError(err)
+	}
+	conn, err := p.connect(namespace, name, address)
+	if err != nil {
+		return util.ToGRPCError(err)
+	}
+	defer p.disconnect(namespace, name)
+
+	// The credentials of the API server and the session id are not forwarded to the Ray cluster.
+	outgoing := md.Copy()
+	delete(outgoing, RaySessionMetadataKey)
+	delete(outgoing, "authorization")
+	clientCtx, cancel := context.WithCancel(metadata.NewOutgoingContext(ctx, outgoing))
+	defer cancel()
+	clientStream, err := conn.NewStream(clientCtx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, fullMethod, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return err
+	}
+	return forwardStream(serverStream, clientStream, cancel)
+}
+
+// forwardStream forwards the messages of a call in both directions until the Ray client server ends it, and returns
+// its status.
+func forwardStream(serverStream grpc.ServerStream, clientStream grpc.ClientStream, cancel context.CancelFunc) error {
+	fromRayClient := make(chan error, 1)
+	go func() {
+		for {
+			frame := &rawFrame{}
+			if err := serverStream.RecvMsg(frame); err != nil {
+				fromRayClient <- err
+				return
+			}
+			if err := clientStream.SendMsg(frame); err != nil {
+				fromRayClient <- err
+				return
+			}
+		}
+	}()
+	fromRayCluster := make(chan error, 1)
+	go func() {
+		for first := true; ; first = false {
+			frame := &rawFrame{}
+			if err := clientStream.RecvMsg(frame); err != nil {
+				fromRayCluster <- err
+				return
+			}
+			if first {
+				// The headers are sent by the server before its first message.
+				header, err := clientStream.Header()
+				if err == nil {
+					err = serverStream.SendHeader(header)
+				}
+				if err != nil {
+					fromRayCluster <- err
+					return
+				}
+			}
+			if err := serverStream.SendMsg(frame); err != nil {
+				fromRayCluster <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case err := <-fromRayClient:
+			if err == io.EOF {
+				// The Ray client closed its side of the stream, the server may still send messages.
+				_ = clientStream.CloseSend()
+				fromRayClient = nil
+				continue
+			}
+			cancel()
+			return status.Errorf(codes.Internal, "failed to forward the call of the Ray client: %v", err)
+		case err := <-fromRayCluster:
+			serverStream.SetTrailer(clientStream.Trailer())
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// connect returns the connection to the head of a session, and records the activity of the session while it has
+// connected Ray clients.
+func (p *SessionProxy) connect(namespace string, name string, address string) (*grpc.ClientConn, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	id := namespace + "/" + name
+	if session, ok := p.sessions[id]; ok {
+		session.streams++
+		return session.conn, nil
+	}
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to connect to session %s", id)
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	p.sessions[id] = &proxiedSession{conn: conn, streams: 1, stop: stop}
+	go func() {
+		ticker := time.NewTicker(sessionActivityInterval)
+		defer ticker.Stop()
+		for {
+			p.touch(namespace, name)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return conn, nil
+}
+
+// disconnect closes the connection to the head of a session once its last Ray client call ends.
+func (p *SessionProxy) disconnect(namespace string, name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	id := namespace + "/" + name
+	session, ok := p.sessions[id]
+	if !ok {
+		return
+	}
+	if session.streams--; session.streams > 0 {
+		return
+	}
+	delete(p.sessions, id)
+	session.stop()
+	if err := session.conn.Close(); err != nil {
+		klog.Warningf("Failed to close the connection to session %s: %v", id, err)
+	}
+	// The session is idle from now on.
+	go p.touch(namespace, name)
+}
+
+func (p *SessionProxy) touch(namespace string, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := p.sessionStore.TouchRaySession(ctx, name, namespace); err != nil {
+		klog.Errorf("Failed to record the activity of session %s/%s: %v", namespace, name, err)
+	}
+}
+
+// parseSessionID returns the namespace and the name of the session of the kuberay-session metadata of a call.
+func parseSessionID(values []string) (string, string, error) {
+	if len(values) != 1 {
+		return "", "", util.NewInvalidInputError("The call has no %s metadata. Please pass the id of the session, namespace/name, with the _metadata of ray.init.", RaySessionMetadataKey)
+	}
+	namespace, name, ok := strings.Cut(values[0], "/")
+	if !ok || namespace == "" || name == "" {
+		return "", "", util.NewInvalidInputError("The session id %q is invalid. Please specify namespace/name.", values[0])
+	}
+	return namespace, name, nil
+}
+
+// rawFrame is a message of a Ray client call, which is forwarded without being decoded.
+type rawFrame struct {
+	payload []byte
+}
+
+// rawCodec passes the messages of the Ray client calls through as is. It is named proto so that the calls keep their
+// content type.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	frame, ok := v.(*rawFrame)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return frame.payload, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	frame, ok := v.(*rawFrame)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	frame.payload = append([]byte(nil), data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
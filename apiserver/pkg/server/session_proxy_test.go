@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+)
+
+// fakeSessionStore resolves the session team-a/notebook to address.
+type fakeSessionStore struct {
+	manager.RaySessionStore
+	address string
+
+	lock    sync.Mutex
+	touched int
+}
+
+func (s *fakeSessionStore) GetRaySessionAddress(_ context.Context, name string, namespace string) (string, error) {
+	if namespace != "team-a" || name != "notebook" {
+		return "", util.NewNotFoundError(nil, "Session %s not found", name)
+	}
+	return s.address, nil
+}
+
+func (s *fakeSessionStore) TouchRaySession(_ context.Context, _ string, _ string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.touched++
+	return nil
+}
+
+func TestSessionProxy(t *testing.T) {
+	// The Ray client server of the head is played by a health server recording the metadata of the calls.
+	var received metadata.MD
+	backend := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		received, _ = metadata.FromIncomingContext(ctx)
+		return handler(ctx, req)
+	}))
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("ray", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(backend, healthServer)
+	backendListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = backend.Serve(backendListener) }()
+	t.Cleanup(backend.Stop)
+
+	store := &fakeSessionStore{address: backendListener.Addr().String()}
+	proxy := grpc.NewServer(NewSessionProxy(store, nil).ServerOptions()...)
+	proxyListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = proxy.Serve(proxyListener) }()
+	t.Cleanup(proxy.Stop)
+
+	conn, err := grpc.NewClient(proxyListener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := healthpb.NewHealthClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		RaySessionMetadataKey, "team-a/notebook", "authorization", "Bearer token", "client_id", "abc")
+	response, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "ray"})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.Status)
+	// The credentials of the API server and the session id are not forwarded.
+	assert.Equal(t, []string{"abc"}, received.Get("client_id"))
+	assert.Empty(t, received.Get("authorization"))
+	assert.Empty(t, received.Get(RaySessionMetadataKey))
+
+	// The status of the Ray client server is returned as is.
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The streams are forwarded too.
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "ray"})
+	require.NoError(t, err)
+	watched, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, watched.Status)
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "ray"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.Check(metadata.AppendToOutgoingContext(context.Background(), RaySessionMetadataKey, "notebook"), &healthpb.HealthCheckRequest{Service: "ray"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.Check(metadata.AppendToOutgoingContext(context.Background(), RaySessionMetadataKey, "team-a/other"), &healthpb.HealthCheckRequest{Service: "ray"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The activity of the session is recorded while the Ray clients are connected.
+	assert.Eventually(t, func() bool {
+		store.lock.Lock()
+		defer store.lock.Unlock()
+		return store.touched > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+type RaySessionServerOptions struct {
+	CollectMetrics bool
+	// ProxyAddress is the address of the session proxy returned to the clients, e.g. ray://kuberay-apiserver:10001.
+	// Empty if the session proxy is disabled.
+	ProxyAddress string
+}
+
+// implements `type RaySessionServiceServer interface` in session_grpc.pb.go
+// RaySessionServer is the server API for RaySessionService service.
+type RaySessionServer struct {
+	sessionStore manager.RaySessionStore
+	options      *RaySessionServerOptions
+	api.UnimplementedRaySessionServiceServer
+}
+
+func NewRaySessionServer(sessionStore manager.RaySessionStore, options *RaySessionServerOptions) *RaySessionServer {
+	return &RaySessionServer{sessionStore: sessionStore, options: options}
+}
+
+// Creates a new interactive session.
+func (s *RaySessionServer) CreateRaySession(ctx context.Context, request *api.CreateRaySessionRequest) (*api.RaySession, error) {
+	if err := ValidateCreateRaySessionRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate create session request failed.")
+	}
+
+	cluster, err := s.sessionStore.CreateRaySession(ctx, request.Session)
+	if err != nil {
+		return nil, util.Wrap(err, "Create session failed.")
+	}
+	return s.toApiSession(cluster), nil
+}
+
+// Finds a specific session by its name and namespace.
+func (s *RaySessionServer) GetRaySession(ctx context.Context, request *api.GetRaySessionRequest) (*api.RaySession, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Session name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	cluster, err := s.sessionStore.GetRaySession(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get session failed.")
+	}
+	return s.toApiSession(cluster), nil
+}
+
+// Finds all the sessions in a given namespace.
+func (s *RaySessionServer) ListRaySessions(ctx context.Context, request *api.ListRaySessionsRequest) (*api.ListRaySessionsResponse, error) {
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	clusters, err := s.sessionStore.ListRaySessions(ctx, request.Namespace, request.User)
+	if err != nil {
+		return nil, util.Wrap(err, "List sessions failed.")
+	}
+	sessions := make([]*api.RaySession, 0, len(clusters))
+	for _, cluster := range clusters {
+		sessions = append(sessions, s.toApiSession(cluster))
+	}
+	return &api.ListRaySessionsResponse{Sessions: sessions}, nil
+}
+
+// Deletes a session and its Ray cluster.
+func (s *RaySessionServer) DeleteRaySession(ctx context.Context, request *api.DeleteRaySessionRequest) (*emptypb.Empty, error) {
+	if request.Name == "" {
+		return nil, util.NewInvalidFieldError("name", "Session name is empty. Please specify a valid value.")
+	}
+	if request.Namespace == "" {
+		return nil, util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+
+	if err := s.sessionStore.DeleteRaySession(ctx, request.Name, request.Namespace); err != nil {
+		return nil, util.Wrap(err, "Delete session failed.")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *RaySessionServer) toApiSession(cluster *rayv1api.RayCluster) *api.RaySession {
+	session := model.FromCrdToApiSession(cluster)
+	session.Address = s.options.ProxyAddress
+	return session
+}
+
+func ValidateCreateRaySessionRequest(request *api.CreateRaySessionRequest) error {
+	if request.Namespace == "" {
+		return util.NewInvalidFieldError("namespace", "Namespace is empty. Please specify a valid value.")
+	}
+	if request.Session == nil {
+		return util.NewInvalidFieldError("session", "Session is empty. Please specify a valid value.")
+	}
+	if request.Namespace != request.Session.Namespace {
+		return util.NewInvalidInputError("The namespace in the request is different from the namespace in the session definition.")
+	}
+	if request.Session.Name == "" {
+		return util.NewInvalidFieldError("session.name", "Session name is empty. Please specify a valid value.")
+	}
+	if request.Session.User == "" {
+		return util.NewInvalidFieldError("session.user", "User who create the session is empty. Please specify a valid value.")
+	}
+	if err := ValidateClusterSpec(request.Session.ClusterSpec); err != nil {
+		return err
+	}
+	if err := ValidateClusterImages(request.Session.Version, request.Session.ClusterSpec); err != nil {
+		return err
+	}
+	if err := ValidateGeneratedNames(request.Session.Name, request.Session.ClusterSpec); err != nil {
+		return err
+	}
+	if request.Session.IdleTtlSeconds < 0 {
+		return util.NewInvalidFieldError("session.idle_ttl_seconds", "Idle TTL %d is negative. Please specify a valid value.", request.Session.IdleTtlSeconds)
+	}
+	return nil
+}
@@ -1,46 +1,768 @@
 package server
 
 import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
 // ValidateClusterSpec validates that the *api.ClusterSpec is not nil and
-// has all the required fields
+// has all the required fields. All the invalid fields are returned, with
+// their paths in the cluster spec.
 func ValidateClusterSpec(clusterSpec *api.ClusterSpec) error {
 	if clusterSpec == nil {
 		return util.NewInvalidInputError("A ClusterSpec object is required. Please specify one.")
 	}
-	if clusterSpec.HeadGroupSpec == nil {
-		return util.NewInvalidInputError("Cluster Spec Object requires HeadGroupSpec to be populated. Please specify one.")
-	}
-	if len(clusterSpec.HeadGroupSpec.ComputeTemplate) == 0 {
-		return util.NewInvalidInputError("HeadGroupSpec compute template is empty. Please specify a valid value.")
-	}
-	if len(clusterSpec.HeadGroupSpec.RayStartParams) == 0 {
-		return util.NewInvalidInputError("HeadGroupSpec RayStartParams is empty. Please specify values.")
-	}
-	if len(clusterSpec.HeadGroupSpec.ImagePullPolicy) > 0 &&
-		clusterSpec.HeadGroupSpec.ImagePullPolicy != "Always" && clusterSpec.HeadGroupSpec.ImagePullPolicy != "IfNotPresent" {
-		return util.NewInvalidInputError("HeadGroupSpec unsupported value for Image pull policy. Please specify Always or IfNotPresent")
+	var errs util.ValidationErrors
+	if headGroupSpec := clusterSpec.HeadGroupSpec; headGroupSpec == nil {
+		errs.Add(util.NewInvalidFieldError("head_group_spec", "Cluster Spec Object requires HeadGroupSpec to be populated. Please specify one."))
+	} else {
+		validateHeadGroupSpec(&errs, headGroupSpec)
 	}
 
+	groupNames := map[string]bool{}
 	for index, spec := range clusterSpec.WorkerGroupSpec {
+		field := fmt.Sprintf("worker_group_spec[%d]", index)
+		owner := fmt.Sprintf("WorkerNodeSpec %d", index)
 		if len(spec.GroupName) == 0 {
-			return util.NewInvalidInputError("WorkerNodeSpec %d group name is empty. Please specify a valid value.", index)
+			errs.Add(util.NewInvalidFieldError(field+".group_name", "WorkerNodeSpec %d group name is empty. Please specify a valid value.", index))
+		} else if messages := validation.IsDNS1123Label(spec.GroupName); len(messages) > 0 {
+			// The group name is used as a label value and in the names of the worker Pods.
+			errs.Add(util.NewInvalidFieldError(field+".group_name", "WorkerNodeSpec %d group name %s is invalid: %s", index, spec.GroupName, strings.Join(messages, ", ")))
+		} else if groupNames[spec.GroupName] {
+			errs.Add(util.NewInvalidFieldError(field+".group_name", "WorkerNodeSpec %d group name %s is already used. Please specify a unique name.", index, spec.GroupName))
 		}
+		groupNames[spec.GroupName] = true
 		if len(spec.ComputeTemplate) == 0 {
-			return util.NewInvalidInputError("WorkerNodeSpec %d compute template is empty. Please specify a valid value.", index)
+			errs.Add(util.NewInvalidFieldError(field+".compute_template", "WorkerNodeSpec %d compute template is empty. Please specify a valid value.", index))
 		}
 		if spec.MaxReplicas == 0 {
-			return util.NewInvalidInputError("WorkerNodeSpec %d MaxReplicas can not be 0. Please specify a valid value.", index)
+			errs.Add(util.NewInvalidFieldError(field+".max_replicas", "WorkerNodeSpec %d MaxReplicas can not be 0. Please specify a valid value.", index))
+		} else if spec.MinReplicas > spec.MaxReplicas {
+			errs.Add(util.NewInvalidFieldError(field+".min_replicas", "WorkerNodeSpec %d MinReplica > MaxReplicas. Please specify a valid value.", index))
+		}
+		if len(spec.ImagePullPolicy) > 0 && !slices.Contains(imagePullPolicies, spec.ImagePullPolicy) {
+			errs.Add(util.NewInvalidFieldError(field+".imagePullPolicy", "Worker GroupSpec unsupported value for Image pull policy. Please specify Always, IfNotPresent or Never"))
+		}
+		errs.AddField(field+".ephemeral_storage", validateEphemeralStorage(owner, spec.EphemeralStorage, spec.EphemeralStorageLimit))
+		errs.AddField(field+".env_from", validateEnvFrom(owner, spec.EnvFrom))
+		errs.AddField(field+".sidecar_containers", validateSidecarContainers(owner, spec.SidecarContainers, spec.Volumes))
+		errs.AddField(field+".pod_disruption_budget", validatePodDisruptionBudget(owner, spec.PodDisruptionBudget))
+		errs.AddField(field+".topology_spread_constraints", validateTopologySpreadConstraints(owner, spec.TopologySpreadConstraints))
+		errs.AddField(field+".scratch_volumes", validateMemoryVolumes(owner, spec.SharedMemorySize, spec.ScratchVolumes, spec.Volumes))
+	}
+	if logging := clusterSpec.Logging; logging != nil {
+		if logging.LoggingLevel != "" && !slices.Contains(loggingLevels, logging.LoggingLevel) {
+			errs.Add(util.NewInvalidFieldError("logging.logging_level", "Logging level %s is not supported. Please specify one of %s.", logging.LoggingLevel, strings.Join(loggingLevels, ", ")))
+		}
+		if logging.BackendLogLevel != "" && !slices.Contains(backendLogLevels, logging.BackendLogLevel) {
+			errs.Add(util.NewInvalidFieldError("logging.backend_log_level", "Backend log level %s is not supported. Please specify one of %s.", logging.BackendLogLevel, strings.Join(backendLogLevels, ", ")))
+		}
+		if logging.TempDir != "" && !strings.HasPrefix(logging.TempDir, "/") {
+			errs.Add(util.NewInvalidFieldError("logging.temp_dir", "Temp dir %s is not an absolute path. Please specify a valid value.", logging.TempDir))
+		}
+	}
+	imagePullSecrets := map[string]bool{}
+	for index, name := range clusterSpec.ImagePullSecrets {
+		field := fmt.Sprintf("image_pull_secrets[%d]", index)
+		if messages := validation.IsDNS1123Subdomain(name); len(messages) > 0 {
+			errs.Add(util.NewInvalidFieldError(field, "Image pull secret name %q is invalid: %s", name, strings.Join(messages, ", ")))
+		} else if imagePullSecrets[name] {
+			errs.Add(util.NewInvalidFieldError(field, "Image pull secret %s is specified more than once. Please specify unique names.", name))
+		}
+		imagePullSecrets[name] = true
+	}
+	if options := clusterSpec.GcsFaultTolerance; options != nil {
+		if options.RedisAddress == "" {
+			errs.Add(util.NewInvalidFieldError("gcs_fault_tolerance.redis_address", "GCS fault tolerance requires a Redis address. Please specify one."))
+		}
+		if options.RedisPasswordSecretKey != "" && options.RedisPasswordSecretName == "" {
+			errs.Add(util.NewInvalidFieldError("gcs_fault_tolerance.redis_password_secret_name", "Redis password secret key %s is specified without a secret name. Please specify the secret name.", options.RedisPasswordSecretKey))
+		}
+		if options.RedisPasswordSecretName != "" {
+			if messages := validation.IsDNS1123Subdomain(options.RedisPasswordSecretName); len(messages) > 0 {
+				errs.Add(util.NewInvalidFieldError("gcs_fault_tolerance.redis_password_secret_name", "Redis password secret name %s is invalid: %s", options.RedisPasswordSecretName, strings.Join(messages, ", ")))
+			}
+		}
+	}
+	validateSecretInjections(&errs, clusterSpec)
+	if options := clusterSpec.AutoscalerOptions; options != nil {
+		if options.IdleTimeoutSeconds < 0 {
+			errs.Add(util.NewInvalidFieldError("autoscalerOptions.idleTimeoutSeconds", "Autoscaler idle timeout %d is negative. Please specify a valid value.", options.IdleTimeoutSeconds))
+		}
+		if options.UpscalingMode != "" && !slices.Contains(upscalingModes, options.UpscalingMode) {
+			errs.Add(util.NewInvalidFieldError("autoscalerOptions.upscalingMode", "Autoscaler upscaling mode %s is not supported. Please specify one of %s.", options.UpscalingMode, strings.Join(upscalingModes, ", ")))
+		}
+	}
+	return errs.Err()
+}
+
+// validateSecretInjections adds the invalid fields of the secret injections to the errors of the cluster spec. An
+// environment variable or a mount path can only be injected once into a group.
+func validateSecretInjections(errs *util.ValidationErrors, clusterSpec *api.ClusterSpec) {
+	groupNames := []string{utils.RayNodeHeadGroupLabelValue}
+	for _, spec := range clusterSpec.WorkerGroupSpec {
+		groupNames = append(groupNames, spec.GroupName)
+	}
+	injected := map[string]bool{}
+	for index, injection := range clusterSpec.SecretInjections {
+		field := fmt.Sprintf("secret_injections[%d]", index)
+		if injection.SecretName == "" {
+			errs.Add(util.NewInvalidFieldError(field+".secret_name", "Injected Secret name is empty. Please specify a valid value."))
+		} else if messages := validation.IsDNS1123Subdomain(injection.SecretName); len(messages) > 0 {
+			errs.Add(util.NewInvalidFieldError(field+".secret_name", "Injected Secret name %q is invalid: %s", injection.SecretName, strings.Join(messages, ", ")))
+		}
+		names := make([]string, 0, len(injection.Env))
+		for name := range injection.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			key := injection.Env[name]
+			if messages := validation.IsEnvVarName(name); len(messages) > 0 {
+				errs.Add(util.NewInvalidFieldError(field+".env", "Environment variable name %q of Secret %s is invalid: %s", name, injection.SecretName, strings.Join(messages, ", ")))
+			}
+			if messages := validation.IsConfigMapKey(key); len(messages) > 0 {
+				errs.Add(util.NewInvalidFieldError(field+".env", "Key %q of Secret %s is invalid: %s", key, injection.SecretName, strings.Join(messages, ", ")))
+			}
+		}
+		if injection.EnvPrefix != "" {
+			if len(injection.Env) > 0 || injection.MountPath != "" {
+				errs.Add(util.NewInvalidFieldError(field+".env_prefix", "Env prefix of Secret %s only applies when all its keys are set as environment variables. Please remove env and mount_path, or the env prefix.", injection.SecretName))
+			} else if messages := validation.IsEnvVarName(injection.EnvPrefix); len(messages) > 0 {
+				errs.Add(util.NewInvalidFieldError(field+".env_prefix", "Env prefix %q of Secret %s is invalid: %s", injection.EnvPrefix, injection.SecretName, strings.Join(messages, ", ")))
+			}
+		}
+		if injection.MountPath != "" && !strings.HasPrefix(injection.MountPath, "/") {
+			errs.Add(util.NewInvalidFieldError(field+".mount_path", "Mount path %s of Secret %s is not an absolute path. Please specify a valid value.", injection.MountPath, injection.SecretName))
+		}
+		for _, groupName := range injection.Groups {
+			if !slices.Contains(groupNames, groupName) {
+				errs.Add(util.NewInvalidFieldError(field+".groups", "Secret %s is injected into group %s, which is not a group of the cluster. Please specify %s or the name of a worker group.",
+					injection.SecretName, groupName, utils.RayNodeHeadGroupLabelValue))
+			}
+		}
+		targets := []string{}
+		for _, name := range names {
+			targets = append(targets, "environment variable "+name)
+		}
+		if injection.MountPath != "" {
+			targets = append(targets, "mount path "+injection.MountPath)
+		}
+		for _, target := range targets {
+			for _, groupName := range groupNames {
+				if !util.InjectsSecret(injection, groupName) {
+					continue
+				}
+				if injected[groupName+"/"+target] {
+					errs.Add(util.NewInvalidFieldError(field, "Secret %s sets %s, which is already injected into group %s. Please specify it once.", injection.SecretName, target, groupName))
+					break
+				}
+				injected[groupName+"/"+target] = true
+			}
+		}
+	}
+}
+
+// validateHeadGroupSpec adds the invalid fields of the head group to the errors of the cluster spec.
+func validateHeadGroupSpec(errs *util.ValidationErrors, spec *api.HeadGroupSpec) {
+	if len(spec.ComputeTemplate) == 0 {
+		errs.Add(util.NewInvalidFieldError("head_group_spec.compute_template", "HeadGroupSpec compute template is empty. Please specify a valid value."))
+	}
+	if len(spec.RayStartParams) == 0 {
+		errs.Add(util.NewInvalidFieldError("head_group_spec.ray_start_params", "HeadGroupSpec RayStartParams is empty. Please specify values."))
+	}
+	if len(spec.ImagePullPolicy) > 0 && !slices.Contains(imagePullPolicies, spec.ImagePullPolicy) {
+		errs.Add(util.NewInvalidFieldError("head_group_spec.imagePullPolicy", "HeadGroupSpec unsupported value for Image pull policy. Please specify Always, IfNotPresent or Never"))
+	}
+	portNames := map[string]bool{}
+	for index, port := range spec.ServicePorts {
+		field := fmt.Sprintf("head_group_spec.service_ports[%d]", index)
+		if len(port.Name) == 0 {
+			errs.Add(util.NewInvalidFieldError(field+".name", "HeadGroupSpec service port name is empty. Please specify a valid value."))
+		} else if util.IsDefaultHeadPortName(port.Name) || portNames[port.Name] {
+			errs.Add(util.NewInvalidFieldError(field+".name", "HeadGroupSpec service port name %s is already used. Please specify a unique name.", port.Name))
+		}
+		if port.Port <= 0 || port.Port > 65535 {
+			errs.Add(util.NewInvalidFieldError(field+".port", "HeadGroupSpec service port %s has an invalid port number %d. Please specify a value between 1 and 65535.", port.Name, port.Port))
+		}
+		portNames[port.Name] = true
+	}
+	errs.AddField("head_group_spec.ephemeral_storage", validateEphemeralStorage("HeadGroupSpec", spec.EphemeralStorage, spec.EphemeralStorageLimit))
+	errs.AddField("head_group_spec.env_from", validateEnvFrom("HeadGroupSpec", spec.EnvFrom))
+	errs.AddField("head_group_spec.sidecar_containers", validateSidecarContainers("HeadGroupSpec", spec.SidecarContainers, spec.Volumes))
+	if restartPolicy := spec.RestartPolicy; restartPolicy != "" && !slices.Contains(restartPolicies, restartPolicy) {
+		errs.Add(util.NewInvalidFieldError("head_group_spec.restart_policy", "HeadGroupSpec restart policy %s is not supported. Please specify one of %s.", restartPolicy, strings.Join(restartPolicies, ", ")))
+	}
+	errs.AddField("head_group_spec.pod_disruption_budget", validatePodDisruptionBudget("HeadGroupSpec", spec.PodDisruptionBudget))
+	errs.AddField("head_group_spec.topology_spread_constraints", validateTopologySpreadConstraints("HeadGroupSpec", spec.TopologySpreadConstraints))
+	errs.AddField("head_group_spec.scratch_volumes", validateMemoryVolumes("HeadGroupSpec", spec.SharedMemorySize, spec.ScratchVolumes, spec.Volumes))
+}
+
+// validateEphemeralStorage validates the ephemeral storage request and limit of a group or a compute template.
+// The limit is only compared to the request if both are set, as the other one may come from the compute template.
+func validateEphemeralStorage(owner string, request string, limit string) error {
+	var requestQuantity, limitQuantity resource.Quantity
+	var err error
+	if request != "" {
+		if requestQuantity, err = resource.ParseQuantity(request); err != nil || requestQuantity.Sign() <= 0 {
+			return util.NewInvalidInputError("%s ephemeral storage %q is not a positive quantity. Please specify a valid value, e.g. 10Gi.", owner, request)
+		}
+	}
+	if limit != "" {
+		if limitQuantity, err = resource.ParseQuantity(limit); err != nil || limitQuantity.Sign() <= 0 {
+			return util.NewInvalidInputError("%s ephemeral storage limit %q is not a positive quantity. Please specify a valid value, e.g. 10Gi.", owner, limit)
+		}
+	}
+	if request != "" && limit != "" && limitQuantity.Cmp(requestQuantity) < 0 {
+		return util.NewInvalidInputError("%s ephemeral storage limit %s is lower than the ephemeral storage request %s. Please specify a valid value.", owner, limit, request)
+	}
+	return nil
+}
+
+// validateEnvFrom validates the ConfigMaps and Secrets whose keys are all added as environment variables of a container.
+func validateEnvFrom(owner string, sources []*api.EnvValueFrom) error {
+	for _, source := range sources {
+		if source.Source != api.EnvValueFrom_CONFIGMAP && source.Source != api.EnvValueFrom_SECRET {
+			return util.NewInvalidInputError("%s env from source %s is not supported. Please specify CONFIGMAP or SECRET.", owner, source.Source)
+		}
+		if source.Name == "" {
+			return util.NewInvalidInputError("%s env from %s name is empty. Please specify a valid value.", owner, source.Source)
+		}
+	}
+	return nil
+}
+
+// imagePullPolicies are the image pull policies of the containers.
+var imagePullPolicies = []string{string(corev1.PullAlways), string(corev1.PullIfNotPresent), string(corev1.PullNever)}
+
+// restartPolicies are the restart policies of the head pod.
+var restartPolicies = []string{string(corev1.RestartPolicyAlways), string(corev1.RestartPolicyOnFailure), string(corev1.RestartPolicyNever)}
+
+// validatePodDisruptionBudget validates the pod disruption budget options of a group, whose values are a number of pods
+// or a percentage of the pods of the group.
+func validatePodDisruptionBudget(owner string, options *api.PodDisruptionBudgetOptions) error {
+	if options == nil {
+		return nil
+	}
+	if options.MaxUnavailable != "" && options.MinAvailable != "" {
+		return util.NewInvalidInputError("%s pod disruption budget max unavailable and min available are mutually exclusive. Please specify one of them.", owner)
+	}
+	for field, value := range map[string]string{"max unavailable": options.MaxUnavailable, "min available": options.MinAvailable} {
+		if value == "" {
+			continue
+		}
+		parsed := intstr.Parse(value)
+		if scaled, err := intstr.GetScaledValueFromIntOrPercent(&parsed, 100, true); err != nil || scaled < 0 ||
+			(parsed.Type == intstr.String && scaled > 100) {
+			return util.NewInvalidInputError("%s pod disruption budget %s %s is invalid. Please specify a number of pods or a percentage.", owner, field, value)
+		}
+	}
+	return nil
+}
+
+// validateTopologySpreadConstraints validates the topology spread constraints of the pods of a group.
+func validateTopologySpreadConstraints(owner string, constraints []*api.TopologySpreadConstraint) error {
+	for _, constraint := range constraints {
+		if constraint.TopologyKey == "" {
+			return util.NewInvalidInputError("%s topology spread constraint topology key is empty. Please specify a valid value.", owner)
+		}
+		if constraint.MaxSkew < 0 {
+			return util.NewInvalidInputError("%s topology spread constraint %s max skew %d is invalid. Please specify a positive value.", owner, constraint.TopologyKey, constraint.MaxSkew)
+		}
+		switch corev1.UnsatisfiableConstraintAction(constraint.WhenUnsatisfiable) {
+		case "", corev1.DoNotSchedule:
+		case corev1.ScheduleAnyway:
+			if constraint.MinDomains > 0 {
+				return util.NewInvalidInputError("%s topology spread constraint %s min domains requires DoNotSchedule.", owner, constraint.TopologyKey)
+			}
+		default:
+			return util.NewInvalidInputError("%s topology spread constraint %s when unsatisfiable %s is not supported. Please specify DoNotSchedule or ScheduleAnyway.", owner, constraint.TopologyKey, constraint.WhenUnsatisfiable)
+		}
+		if constraint.MinDomains < 0 {
+			return util.NewInvalidInputError("%s topology spread constraint %s min domains %d is invalid. Please specify a positive value.", owner, constraint.TopologyKey, constraint.MinDomains)
+		}
+	}
+	return nil
+}
+
+// validateMemoryVolumes validates the shared memory and the scratch volumes of a group. Their sizes are compared to the
+// memory and the ephemeral storage of the group once its compute template is known.
+func validateMemoryVolumes(owner string, sharedMemorySize string, scratchVolumes []*api.ScratchVolume, volumes []*api.Volume) error {
+	if sharedMemorySize != "" {
+		if quantity, err := resource.ParseQuantity(sharedMemorySize); err != nil || quantity.Sign() <= 0 {
+			return util.NewInvalidInputError("%s shared memory size %q is not a positive quantity. Please specify a valid value, e.g. 8Gi.", owner, sharedMemorySize)
+		}
+	}
+	names := map[string]bool{util.SharedMemoryVolumeName: true}
+	mountPaths := map[string]bool{util.SharedMemoryMountPath: true}
+	for _, volume := range volumes {
+		names[volume.Name] = true
+		mountPaths[volume.MountPath] = true
+		if sharedMemorySize != "" && volume.MountPath == util.SharedMemoryMountPath {
+			return util.NewInvalidInputError("%s volume %s is mounted at %s, which conflicts with the shared memory size. Please specify only one of them.", owner, volume.Name, util.SharedMemoryMountPath)
+		}
+	}
+	for _, volume := range scratchVolumes {
+		if errs := validation.IsDNS1123Label(volume.Name); len(errs) > 0 {
+			return util.NewInvalidInputError("%s scratch volume name %q is invalid: %s", owner, volume.Name, strings.Join(errs, ", "))
+		}
+		if names[volume.Name] {
+			return util.NewInvalidInputError("%s scratch volume name %s is already used. Please specify a unique name.", owner, volume.Name)
+		}
+		names[volume.Name] = true
+		if !strings.HasPrefix(volume.MountPath, "/") {
+			return util.NewInvalidInputError("%s scratch volume %s mount path %q is not an absolute path. Please specify a valid value.", owner, volume.Name, volume.MountPath)
+		}
+		if mountPaths[volume.MountPath] {
+			return util.NewInvalidInputError("%s scratch volume %s mount path %s is already used. Please specify a unique path.", owner, volume.Name, volume.MountPath)
+		}
+		mountPaths[volume.MountPath] = true
+		if quantity, err := resource.ParseQuantity(volume.Size); err != nil || quantity.Sign() <= 0 {
+			return util.NewInvalidInputError("%s scratch volume %s size %q is not a positive quantity. Please specify a valid value, e.g. 50Gi.", owner, volume.Name, volume.Size)
+		}
+	}
+	return nil
+}
+
+// reservedContainerNames are the names of the containers added to the Ray pods by the API server and the operator.
+var reservedContainerNames = []string{"ray-head", "ray-worker", "autoscaler"}
+
+// validateSidecarContainers validates the sidecar containers of a group, which can only mount the volumes of the group.
+func validateSidecarContainers(owner string, sidecars []*api.SidecarContainer, volumes []*api.Volume) error {
+	names := map[string]bool{}
+	for _, sidecar := range sidecars {
+		if sidecar.Name == "" {
+			return util.NewInvalidInputError("%s sidecar container name is empty. Please specify a valid value.", owner)
+		}
+		if errs := validation.IsDNS1123Label(sidecar.Name); len(errs) > 0 {
+			return util.NewInvalidInputError("%s sidecar container name %s is invalid: %s", owner, sidecar.Name, strings.Join(errs, ", "))
+		}
+		if names[sidecar.Name] || slices.Contains(reservedContainerNames, sidecar.Name) {
+			return util.NewInvalidInputError("%s sidecar container name %s is already used. Please specify a unique name.", owner, sidecar.Name)
+		}
+		names[sidecar.Name] = true
+		if sidecar.Image == "" {
+			return util.NewInvalidInputError("%s sidecar container %s image is empty. Please specify a valid value.", owner, sidecar.Name)
+		}
+		if len(sidecar.ImagePullPolicy) > 0 && !slices.Contains(imagePullPolicies, sidecar.ImagePullPolicy) {
+			return util.NewInvalidInputError("%s sidecar container %s unsupported value for Image pull policy. Please specify Always, IfNotPresent or Never", owner, sidecar.Name)
+		}
+		for name, value := range map[string]string{"cpu": sidecar.Cpu, "memory": sidecar.Memory} {
+			if value == "" {
+				continue
+			}
+			if quantity, err := resource.ParseQuantity(value); err != nil || quantity.Sign() <= 0 {
+				return util.NewInvalidInputError("%s sidecar container %s %s %q is not a positive quantity. Please specify a valid value.", owner, sidecar.Name, name, value)
+			}
+		}
+		if err := validateEnvFrom(fmt.Sprintf("%s sidecar container %s", owner, sidecar.Name), sidecar.EnvFrom); err != nil {
+			return err
+		}
+		for _, mount := range sidecar.VolumeMounts {
+			if !slices.ContainsFunc(volumes, func(volume *api.Volume) bool { return volume.Name == mount.Name }) {
+				return util.NewInvalidInputError("%s sidecar container %s mounts volume %s, which is not a volume of the group. Please specify one of the volumes of the group.", owner, sidecar.Name, mount.Name)
+			}
+			if !strings.HasPrefix(mount.MountPath, "/") {
+				return util.NewInvalidInputError("%s sidecar container %s mount path %q of volume %s is not an absolute path. Please specify a valid value.", owner, sidecar.Name, mount.MountPath, mount.Name)
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	// loggingLevels are the logging levels of the Python components of Ray.
+	loggingLevels = []string{"debug", "info", "warning", "error", "critical"}
+	// backendLogLevels are the log levels of the C++ components of Ray.
+	backendLogLevels = []string{"trace", "debug", "info", "warning", "error", "fatal"}
+	// upscalingModes are the upscaling modes of the Ray autoscaler.
+	upscalingModes = []string{"Default", "Aggressive", "Conservative"}
+)
+
+// ClusterSpecWarnings returns the non-fatal issues of a valid *api.ClusterSpec, which are returned
+// to the user without failing the request.
+func ClusterSpecWarnings(clusterSpec *api.ClusterSpec) []string {
+	var warnings []string
+	if len(clusterSpec.WorkerGroupSpec) > 0 && !clusterSpec.ReserveHeadNode && clusterSpec.HeadGroupSpec.RayStartParams["num-cpus"] != "0" {
+		warnings = append(warnings, "The head node is not reserved, so tasks and actors can be scheduled on it. Set reserve_head_node to keep them on the worker nodes.")
+	}
+	warnings = append(warnings, volumeWarnings("HeadGroupSpec", clusterSpec.HeadGroupSpec.Volumes)...)
+	for _, spec := range clusterSpec.WorkerGroupSpec {
+		warnings = append(warnings, volumeWarnings("WorkerNodeSpec "+spec.GroupName, spec.Volumes)...)
+	}
+	return warnings
+}
+
+func volumeWarnings(group string, volumes []*api.Volume) []string {
+	var warnings []string
+	for _, volume := range volumes {
+		if volume.VolumeType == api.Volume_EMPTY_DIR && volume.Storage == "" {
+			warnings = append(warnings, fmt.Sprintf("%s empty dir volume %s has no size limit, so it can fill the disk of the node. Set its storage to limit its size.", group, volume.Name))
+		}
+	}
+	return warnings
+}
+
+// ServiceWarnings returns the non-fatal issues of a valid *api.RayService, including the ones of its cluster spec.
+func ServiceWarnings(service *api.RayService) []string {
+	var warnings []string
+	//nolint:staticcheck // The deprecated fields are checked to warn the users who still set them.
+	if service.ServiceUnhealthySecondThreshold != 0 || service.DeploymentUnhealthySecondThreshold != 0 {
+		warnings = append(warnings, "service_unhealthy_second_threshold and deployment_unhealthy_second_threshold are deprecated and ignored by the operator.")
+	}
+	return append(warnings, ClusterSpecWarnings(service.ClusterSpec)...)
+}
+
+// maxPodNamePrefixLength is the length the operator truncates the generateName prefix of the Ray Pods to:
+// 63 - (max(8,6) + 5), as "-head-" or "-worker-" and 5 random characters are appended to the prefix.
+const maxPodNamePrefixLength = 50
+
+// maxSessionAffinityTimeoutSeconds is the longest ClientIP session affinity Kubernetes accepts.
+const maxSessionAffinityTimeoutSeconds = 86400
+
+// ValidateServeServiceOptions validates the routing options of the serve service of a ray service.
+func ValidateServeServiceOptions(options *api.ServeServiceOptions, clusterSpec *api.ClusterSpec) error {
+	if options == nil {
+		return nil
+	}
+	switch corev1.ServiceAffinity(options.SessionAffinity) {
+	case "", corev1.ServiceAffinityNone, corev1.ServiceAffinityClientIP:
+	default:
+		return util.NewInvalidInputError("Session affinity %s is not supported, it must be %s or %s. Please specify a valid value.",
+			options.SessionAffinity, corev1.ServiceAffinityNone, corev1.ServiceAffinityClientIP)
+	}
+	if options.SessionAffinityTimeoutSeconds != 0 {
+		if corev1.ServiceAffinity(options.SessionAffinity) != corev1.ServiceAffinityClientIP {
+			return util.NewInvalidInputError("Session affinity timeout seconds can only be set with %s session affinity.", corev1.ServiceAffinityClientIP)
+		}
+		if options.SessionAffinityTimeoutSeconds < 0 || options.SessionAffinityTimeoutSeconds > maxSessionAffinityTimeoutSeconds {
+			return util.NewInvalidInputError("Session affinity timeout seconds %d must be between 1 and %d. Please specify a valid value.",
+				options.SessionAffinityTimeoutSeconds, maxSessionAffinityTimeoutSeconds)
+		}
+	}
+	switch corev1.ServiceExternalTrafficPolicy(options.ExternalTrafficPolicy) {
+	case "":
+	case corev1.ServiceExternalTrafficPolicyCluster, corev1.ServiceExternalTrafficPolicyLocal:
+		// The serve service has the type of the head service.
+		serviceType := ""
+		if clusterSpec != nil && clusterSpec.HeadGroupSpec != nil {
+			serviceType = clusterSpec.HeadGroupSpec.ServiceType
+		}
+		if serviceType != string(corev1.ServiceTypeNodePort) && serviceType != string(corev1.ServiceTypeLoadBalancer) {
+			return util.NewInvalidInputError("External traffic policy can only be set for %s and %s services, the head service type is %q.",
+				corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer, serviceType)
+		}
+	default:
+		return util.NewInvalidInputError("External traffic policy %s is not supported, it must be %s or %s. Please specify a valid value.",
+			options.ExternalTrafficPolicy, corev1.ServiceExternalTrafficPolicyCluster, corev1.ServiceExternalTrafficPolicyLocal)
+	}
+	return nil
+}
+
+// The longest names of the clusters and of the ray services. The names of the Pods of a cluster are prefixed with its
+// name, and the RayClusters of a ray service are named <service>-raycluster-<5 random characters>.
+const (
+	maxClusterNameLength = maxPodNamePrefixLength
+	maxServiceNameLength = maxPodNamePrefixLength - len(utils.RayClusterSuffix) - util.GeneratedNameSuffixLength
+)
+
+// ValidateResourceName validates the name of a cluster or a ray service to create, or its generate_name prefix if the
+// name is empty, before Kubernetes rejects the resources the operator derives from it. The name must be a DNS-1035
+// label, as the names of the services of the cluster, of at most maxLength characters, the random characters of a
+// generated name included. The error is reported on the name or the generate_name field.
+func ValidateResourceName(kind string, name string, generateName string, maxLength int) error {
+	if name == "" && generateName == "" {
+		return util.NewInvalidFieldError("name", "%s name is empty. Please specify a valid value.", kind)
+	}
+	if name == "" {
+		if maxPrefixLength := maxLength - util.GeneratedNameSuffixLength; len(generateName) > maxPrefixLength {
+			return util.NewInvalidFieldError("generate_name", "%s generate name %s is longer than %d characters, the names generated from it would be longer than %d characters. Please specify a shorter prefix.",
+				kind, generateName, maxPrefixLength, maxLength)
+		}
+		// The random characters are lowercase alphanumeric, any of them stands for all of them.
+		if messages := validation.IsDNS1035Label(generateName + strings.Repeat("a", util.GeneratedNameSuffixLength)); len(messages) > 0 {
+			return util.NewInvalidFieldError("generate_name", "%s generate name %s does not produce valid names: %s", kind, generateName, strings.Join(messages, ", "))
+		}
+		return nil
+	}
+	if len(name) > maxLength {
+		return util.NewInvalidFieldError("name", "%s name %s is longer than %d characters, the names of the resources generated from it would be longer than 63 characters. Please specify a shorter name.",
+			kind, name, maxLength)
+	}
+	if messages := validation.IsDNS1035Label(name); len(messages) > 0 {
+		return util.NewInvalidFieldError("name", "%s name %s is not valid: %s", kind, name, strings.Join(messages, ", "))
+	}
+	return nil
+}
+
+// resourceName returns the name of a resource to create, or a name generated from its generate_name prefix with the
+// same length, and the field it is reported on.
+func resourceName(name string, generateName string) (string, string) {
+	if name == "" && generateName != "" {
+		return generateName + strings.Repeat("a", util.GeneratedNameSuffixLength), "generate_name"
+	}
+	return name, "name"
+}
+
+// ValidateGeneratedNames validates that the names of the Pods the operator generates for the RayCluster
+// are not truncated. The operator truncates long names, so that worker groups whose names only differ in
+// their end would share the same Pod names.
+func ValidateGeneratedNames(clusterName string, clusterSpec *api.ClusterSpec) error {
+	if len(clusterName) > maxPodNamePrefixLength {
+		return util.NewInvalidInputError("Cluster name %s is longer than %d characters. Please specify a shorter name.", clusterName, maxPodNamePrefixLength)
+	}
+	for _, spec := range clusterSpec.WorkerGroupSpec {
+		// The worker Pods are named after the cluster name and the group name, e.g. <cluster>-<group>-worker-<random>.
+		if prefix := clusterName + "-" + spec.GroupName; len(prefix) > maxPodNamePrefixLength {
+			return util.NewInvalidInputError("WorkerNodeSpec %s generates Pod names with a prefix of %d characters, which is longer than %d characters. Please specify a shorter cluster or group name.", spec.GroupName, len(prefix), maxPodNamePrefixLength)
+		}
+	}
+	return nil
+}
+
+// rayVersionPattern matches the Ray version at the start of a version or of the tag of a Ray image,
+// e.g. 2.9.0 in rayproject/ray:2.9.0-py310-cu118.
+var rayVersionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+`)
+
+// ValidateClusterImages validates that the images of the head and worker groups run the same Ray
+// version as the cluster, so that groups can use different images, e.g. a CUDA image for GPU workers.
+// A group without image uses the Ray image of the cluster version. Images whose tag does not start
+// with a Ray version, e.g. custom or nightly images, are not checked.
+func ValidateClusterImages(version string, clusterSpec *api.ClusterSpec) error {
+	clusterVersion := rayVersionPattern.FindString(version)
+	if image := clusterSpec.HeadGroupSpec.Image; image != "" {
+		headVersion := imageRayVersion(image)
+		if headVersion != "" && clusterVersion != "" && headVersion != clusterVersion {
+			return util.NewInvalidInputError("HeadGroupSpec image %s runs Ray %s, but the cluster version is %s. Please use images of the same Ray version.", image, headVersion, clusterVersion)
+		}
+		if headVersion != "" {
+			clusterVersion = headVersion
+		}
+	}
+	for _, spec := range clusterSpec.WorkerGroupSpec {
+		if spec.Image == "" {
+			continue
+		}
+		workerVersion := imageRayVersion(spec.Image)
+		if workerVersion != "" && clusterVersion != "" && workerVersion != clusterVersion {
+			return util.NewInvalidInputError("WorkerNodeSpec %s image %s runs Ray %s, but the head group runs Ray %s. Please use images of the same Ray version.", spec.GroupName, spec.Image, workerVersion, clusterVersion)
+		}
+	}
+	return nil
+}
+
+// imageRayVersion returns the Ray version of the image tag, or an empty string if it is unknown.
+func imageRayVersion(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	i := strings.LastIndex(image, ":")
+	if i < 0 || i < strings.LastIndex(image, "/") {
+		return ""
+	}
+	return rayVersionPattern.FindString(image[i+1:])
+}
+
+// ValidatePageSize validates the page size of a List request. A zero page size lists all the resources.
+func ValidatePageSize(pageSize int32) error {
+	if pageSize < 0 {
+		return util.NewInvalidInputError("Page size %d is negative. Please specify a valid value.", pageSize)
+	}
+	return nil
+}
+
+// ValidateSelector validates the label and field selectors of a List request, so that a malformed
+// selector is reported as invalid input rather than as a failure of the Kubernetes list call.
+func ValidateSelector(labelSelector string, fieldSelector string) error {
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return util.NewInvalidInputError("Label selector %q is invalid: %v. Please specify a valid value.", labelSelector, err)
+	}
+	if _, err := fields.ParseSelector(fieldSelector); err != nil {
+		return util.NewInvalidInputError("Field selector %q is invalid: %v. Please specify a valid value.", fieldSelector, err)
+	}
+	return nil
+}
+
+// maxIdempotencyKeyLength bounds the idempotency key of a create request, which is stored as an annotation.
+const maxIdempotencyKeyLength = 128
+
+// ValidateIdempotencyKey validates the idempotency key of a create request, an empty key disables idempotency.
+func ValidateIdempotencyKey(key string) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return util.NewInvalidInputError("Idempotency key is longer than %d characters. Please specify a valid value.", maxIdempotencyKeyLength)
+	}
+	return nil
+}
+
+// ValidateQueueingOptions validates the batch scheduler queue of a cluster or a job. The gating labels can not
+// overwrite the labels of the apiserver, the operator or the queue.
+func ValidateQueueingOptions(queueing *api.QueueingOptions) error {
+	if queueing == nil {
+		return nil
+	}
+	if queueing.BatchScheduler == api.QueueingOptions_BATCH_SCHEDULER_UNSPECIFIED {
+		return util.NewInvalidInputError("Queueing batch scheduler is unspecified. Please specify one of KUEUE, VOLCANO or YUNIKORN.")
+	}
+	if queueing.QueueName == "" {
+		return util.NewInvalidInputError("Queue name is empty. Please specify a valid value.")
+	}
+	if errs := validation.IsDNS1123Subdomain(queueing.QueueName); len(errs) > 0 {
+		return util.NewInvalidInputError("Queue name %q is invalid: %s", queueing.QueueName, strings.Join(errs, ", "))
+	}
+	if queueing.PriorityClassName != "" {
+		if queueing.BatchScheduler == api.QueueingOptions_YUNIKORN {
+			return util.NewInvalidInputError("YuniKorn takes the priority from the queue, priority class name %s is not supported.", queueing.PriorityClassName)
+		}
+		if errs := validation.IsDNS1123Subdomain(queueing.PriorityClassName); len(errs) > 0 {
+			return util.NewInvalidInputError("Priority class name %q is invalid: %s", queueing.PriorityClassName, strings.Join(errs, ", "))
+		}
+	}
+	for key, value := range queueing.GatingLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return util.NewInvalidInputError("Gating label key %q is invalid: %s", key, strings.Join(errs, ", "))
 		}
-		if spec.MinReplicas > spec.MaxReplicas {
-			return util.NewInvalidInputError("WorkerNodeSpec %d MinReplica > MaxReplicas. Please specify a valid value.", index)
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return util.NewInvalidInputError("Gating label %s value %q is invalid: %s", key, value, strings.Join(errs, ", "))
 		}
-		if len(spec.ImagePullPolicy) > 0 && spec.ImagePullPolicy != "Always" && spec.ImagePullPolicy != "IfNotPresent" {
-			return util.NewInvalidInputError("Worker GroupSpec unsupported value for Image pull policy. Please specify Always or IfNotPresent")
+		if isReservedLabel(key) {
+			return util.NewInvalidInputError("Gating label %s is reserved. Please specify a label outside of the ray.io, kueue.x-k8s.io and app.kubernetes.io prefixes.", key)
 		}
 	}
 	return nil
 }
+
+// ValidateExposeOptions validates the Ingress or the OpenShift Route exposing the serve service of a ray service.
+func ValidateExposeOptions(expose *api.ExposeOptions) error {
+	if expose == nil {
+		return nil
+	}
+	if expose.Host == "" {
+		return util.NewInvalidInputError("Expose host is empty. Please specify a valid value.")
+	}
+	if errs := validation.IsDNS1123Subdomain(expose.Host); len(errs) > 0 {
+		return util.NewInvalidInputError("Expose host %q is invalid: %s", expose.Host, strings.Join(errs, ", "))
+	}
+	if expose.Path != "" && !strings.HasPrefix(expose.Path, "/") {
+		return util.NewInvalidInputError("Expose path %q must start with /. Please specify a valid value.", expose.Path)
+	}
+	if expose.TlsSecret != "" {
+		if errs := validation.IsDNS1123Subdomain(expose.TlsSecret); len(errs) > 0 {
+			return util.NewInvalidInputError("Expose TLS Secret name %q is invalid: %s", expose.TlsSecret, strings.Join(errs, ", "))
+		}
+	}
+	if expose.IngressClass != "" {
+		if expose.Kind == api.ExposeOptions_ROUTE {
+			return util.NewInvalidInputError("Ingress class can not be set for a Route. Please remove it or expose the service through an Ingress.")
+		}
+		if errs := validation.IsDNS1123Subdomain(expose.IngressClass); len(errs) > 0 {
+			return util.NewInvalidInputError("Ingress class %q is invalid: %s", expose.IngressClass, strings.Join(errs, ", "))
+		}
+	}
+	for key := range expose.Annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return util.NewInvalidInputError("Expose annotation key %q is invalid: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// gitRefPattern matches the commit SHAs, branches and tags which can be put in the archive URL of a repository.
+var gitRefPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// ValidateGitSource validates the git source of a job, which replaces the working_dir of its runtime_env.
+func ValidateGitSource(source *api.GitSource, runtimeEnv string) error {
+	if source == nil {
+		return nil
+	}
+	repository, err := url.Parse(source.Repository)
+	if err != nil || repository.Scheme != "https" || repository.Host == "" || strings.Trim(repository.Path, "/") == "" {
+		return util.NewInvalidInputError("Git repository %q is not an HTTPS URL. Please specify a valid value, e.g. https://github.com/ray-project/kuberay.", source.Repository)
+	}
+	if repository.User != nil || repository.RawQuery != "" || repository.Fragment != "" {
+		return util.NewInvalidInputError("Git repository %q can not have credentials, a query or a fragment. Please put the credentials in the credentials Secret.", source.Repository)
+	}
+	if source.Ref == "" {
+		return util.NewInvalidInputError("Git ref is empty. Please specify a commit SHA, a branch or a tag.")
+	}
+	if !gitRefPattern.MatchString(source.Ref) || strings.Contains(source.Ref, "..") || strings.HasSuffix(source.Ref, "/") {
+		return util.NewInvalidInputError("Git ref %q is invalid. Please specify a commit SHA, a branch or a tag.", source.Ref)
+	}
+	if source.CredentialsSecret != "" {
+		if errs := validation.IsDNS1123Subdomain(source.CredentialsSecret); len(errs) > 0 {
+			return util.NewInvalidInputError("Git credentials Secret name %q is invalid: %s", source.CredentialsSecret, strings.Join(errs, ", "))
+		}
+	}
+	hasWorkingDir, err := util.HasWorkingDir(runtimeEnv)
+	if err != nil {
+		return util.NewInvalidInputError("Runtime env is invalid: %s", err.Error())
+	}
+	if hasWorkingDir {
+		return util.NewInvalidInputError("Git source and the working_dir of the runtime env are mutually exclusive. Please specify only one of them.")
+	}
+	return nil
+}
+
+// reservedLabelKeys are the labels which the apiserver sets outside of the reserved prefixes.
+var reservedLabelKeys = []string{util.VolcanoQueueNameLabelKey, util.YuniKornQueueLabelKey}
+
+func isReservedLabel(key string) bool {
+	if slices.Contains(reservedLabelKeys, key) {
+		return true
+	}
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	return prefix == "ray.io" || strings.HasSuffix(prefix, ".ray.io") || prefix == "kueue.x-k8s.io" || prefix == "app.kubernetes.io"
+}
+
+// NewListFilter parses the filter expression of a List request.
+func NewListFilter[T any](expression string, fields map[string]manager.FilterField[T]) (manager.ListFilter[T], error) {
+	filter, err := manager.ParseListFilter(expression, fields)
+	if err != nil {
+		return manager.ListFilter[T]{}, util.NewInvalidInputError("Filter %q is invalid: %v. Please specify a valid value.", expression, err)
+	}
+	return filter, nil
+}
+
+// NewEventFilter validates the event options of a Get or List request, and converts them to the filter of the events
+// returned with the resources.
+func NewEventFilter(eventType string, since *timestamppb.Timestamp, limit int32) (manager.EventFilter, error) {
+	if eventType != "" && eventType != corev1.EventTypeNormal && eventType != corev1.EventTypeWarning {
+		return manager.EventFilter{}, util.NewInvalidInputError("Event type %s is not supported. Please specify %s or %s.", eventType, corev1.EventTypeNormal, corev1.EventTypeWarning)
+	}
+	if limit < 0 {
+		return manager.EventFilter{}, util.NewInvalidInputError("Event limit %d is negative. Please specify a valid value.", limit)
+	}
+	filter := manager.EventFilter{Type: eventType, Limit: int(limit)}
+	if since != nil {
+		if err := since.CheckValid(); err != nil {
+			return manager.EventFilter{}, util.NewInvalidInputError("Events since time is invalid: %v. Please specify a valid value.", err)
+		}
+		filter.Since = since.AsTime()
+	}
+	return filter, nil
+}
+
+// ValidateResourceView validates the view of a Get or List request.
+func ValidateResourceView(view api.ResourceView) error {
+	if _, ok := api.ResourceView_name[int32(view)]; !ok {
+		return util.NewInvalidInputError("View %d is not supported. Please specify BASIC or FULL.", view)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Package tracing exports OpenTelemetry traces of the API server: a span for every RPC, with child spans for the
+// calls to Kubernetes made while handling it.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ServiceName is the default name of the service of the spans, OTEL_SERVICE_NAME overrides it.
+const ServiceName = "kuberay-apiserver"
+
+// Setup installs a global tracer provider exporting the spans with OTLP over gRPC to endpoint, e.g.
+// `http://otel-collector:4317`. The `http` scheme disables TLS. sampleRatio is the fraction of the traces started by
+// the API server which are sampled, the traces of the callers keep their sampling decision. It returns a function
+// flushing the spans and stopping the export.
+func Setup(ctx context.Context, endpoint string, sampleRatio float64) (func(context.Context) error, error) {
+	options := []otlptracegrpc.Option{}
+	if strings.Contains(endpoint, "://") {
+		options = append(options, otlptracegrpc.WithEndpointURL(endpoint))
+	} else {
+		options = append(options, otlptracegrpc.WithEndpoint(endpoint))
+	}
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// WrapKubernetesTransport wraps the transport of a Kubernetes client, so that every call to Kubernetes gets a span,
+// which is a child of the span of the RPC the call is made for. The spans are not recorded unless Setup was called.
+func WrapKubernetesTransport(rt http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(rt, otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return "kubernetes " + r.Method + " " + kubernetesResource(r.URL.Path)
+	}))
+}
+
+// kubernetesResource returns the resource, and subresource, of the path of a Kubernetes API call, e.g. `rayservices`
+// or `pods/log`, so that the span names do not depend on the namespaces and names of the resources.
+func kubernetesResource(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(segments) >= 2 && segments[0] == "api":
+		segments = segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		segments = segments[3:]
+	default:
+		return path
+	}
+	if len(segments) >= 3 && segments[0] == "namespaces" {
+		segments = segments[2:]
+	}
+	switch len(segments) {
+	case 0:
+		return "namespaces"
+	case 1, 2:
+		return segments[0]
+	default:
+		return segments[0] + "/" + segments[2]
+	}
+}
+
+// WrapHandler wraps the HTTP handler of the REST proxy, so that the REST calls continue the traces of their callers.
+func WrapHandler(handler http.Handler) http.Handler {
+	return otelhttp.NewHandler(handler, "http", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method + " " + r.URL.Path
+	}))
+}
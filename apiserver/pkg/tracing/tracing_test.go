@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWrapKubernetesTransport(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "CreateRayService")
+	client := &http.Client{Transport: WrapKubernetesTransport(http.DefaultTransport)}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/apis/ray.io/v1/namespaces/team-a/rayservices", nil)
+	require.NoError(t, err)
+	response, err := client.Do(request)
+	require.NoError(t, err)
+	response.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "kubernetes POST rayservices", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func TestKubernetesResource(t *testing.T) {
+	for path, resource := range map[string]string{
+		"/api/v1/namespaces":                                   "namespaces",
+		"/api/v1/namespaces/team-a":                            "namespaces",
+		"/api/v1/namespaces/team-a/events":                     "events",
+		"/api/v1/namespaces/team-a/pods/head/log":              "pods/log",
+		"/apis/ray.io/v1/rayclusters":                          "rayclusters",
+		"/apis/ray.io/v1/namespaces/team-a/rayclusters/ray":    "rayclusters",
+		"/apis/ray.io/v1/namespaces/team-a/rayjobs/job/status": "rayjobs/status",
+		"/version": "/version",
+	} {
+		assert.Equal(t, resource, kubernetesResource(path), path)
+	}
+}
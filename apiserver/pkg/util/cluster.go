@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -16,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
 type RayCluster struct {
@@ -46,6 +48,11 @@ func NewRayCluster(apiCluster *api.Cluster, computeTemplateMap map[string]*api.C
 		},
 		Spec: *spec,
 	}
+	SetPodDisruptionBudgetLabels(&rayCluster.Spec, apiCluster.Name)
+	if isQueuedByKueue(apiCluster.Queueing) {
+		suspend := true
+		rayCluster.Spec.Suspend = &suspend
+	}
 
 	return &RayCluster{rayCluster}, nil
 }
@@ -59,15 +66,65 @@ func buildRayClusterLabels(cluster *api.Cluster) map[string]string {
 	labels[RayClusterEnvironmentLabelKey] = cluster.Environment.String()
 	labels[KubernetesApplicationNameLabelKey] = ApplicationName
 	labels[KubernetesManagedByLabelKey] = ComponentName
+	addQueueingLabels(labels, cluster.Queueing)
 	return labels
 }
 
 // Build cluster annotations
 func buildRayClusterAnnotations(cluster *api.Cluster) map[string]string {
-	if cluster.Annotations == nil {
-		return map[string]string{}
+	annotations := cluster.Annotations
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations = withTTLAnnotation(annotations, RayClusterIdleTTLAnnotationKey, cluster.IdleTtlSeconds)
+	return buildGcsFaultToleranceAnnotations(annotations, cluster.ClusterSpec)
+}
+
+// buildGcsFaultToleranceAnnotations adds the annotations enabling GCS fault tolerance to a copy of the annotations
+// of a RayCluster, or of the RayJob or RayService creating it, if the cluster spec stores the GCS metadata in Redis.
+func buildGcsFaultToleranceAnnotations(annotations map[string]string, clusterSpec *api.ClusterSpec) map[string]string {
+	if clusterSpec.GetGcsFaultTolerance() == nil {
+		return annotations
+	}
+	result := make(map[string]string, len(annotations)+2)
+	for key, value := range annotations {
+		result[key] = value
+	}
+	result[RayFTEnabledAnnotationKey] = "true"
+	if namespace := clusterSpec.GcsFaultTolerance.ExternalStorageNamespace; namespace != "" {
+		result[RayExternalStorageNSAnnotationKey] = namespace
+	}
+	return result
+}
+
+// setGcsFaultTolerance passes the address and the password of the external Redis to the head node.
+func setGcsFaultTolerance(headGroupSpec *rayv1api.HeadGroupSpec, options *api.GcsFaultToleranceOptions) {
+	container := &headGroupSpec.Template.Spec.Containers[0]
+	container.Env = append(container.Env, corev1.EnvVar{Name: RayRedisAddressEnv, Value: options.RedisAddress})
+	if options.RedisPasswordSecretName == "" {
+		return
+	}
+	key := options.RedisPasswordSecretKey
+	if key == "" {
+		key = DefaultRedisPasswordSecretKey
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name: RedisPasswordEnv,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: options.RedisPasswordSecretName},
+				Key:                  key,
+			},
+		},
+	})
+	if _, ok := headGroupSpec.RayStartParams["redis-password"]; !ok {
+		rayStartParams := make(map[string]string, len(headGroupSpec.RayStartParams)+1)
+		for name, value := range headGroupSpec.RayStartParams {
+			rayStartParams[name] = value
+		}
+		rayStartParams["redis-password"] = "$" + RedisPasswordEnv
+		headGroupSpec.RayStartParams = rayStartParams
 	}
-	return cluster.Annotations
 }
 
 // TODO(Basasuya & MissionToMars): The job spec depends on ClusterSpec which not all cluster-related configs are included,
@@ -88,11 +145,41 @@ func buildRayClusterSpec(imageVersion string, envs *api.EnvironmentVariables, cl
 		WorkerGroupSpecs: []rayv1api.WorkerGroupSpec{},
 	}
 
+	// If the head service name is specified, the operator uses it instead of the generated name.
+	if clusterSpec.HeadGroupSpec.ServiceName != "" {
+		rayClusterSpec.HeadGroupSpec.HeadService = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterSpec.HeadGroupSpec.ServiceName},
+		}
+	}
+
 	// If enable ingress is specified, add it to the head node spec.
 	if clusterSpec.HeadGroupSpec.EnableIngress {
 		rayClusterSpec.HeadGroupSpec.EnableIngress = &clusterSpec.HeadGroupSpec.EnableIngress
 	}
 
+	// The resources of a reserved head node come from its compute template.
+	if clusterSpec.ReserveHeadNode {
+		rayClusterSpec.HeadGroupSpec.HeadNodeReservation = &rayv1api.HeadNodeReservation{}
+	}
+
+	// The head node stores the GCS metadata in the external Redis.
+	if options := clusterSpec.GcsFaultTolerance; options != nil {
+		setGcsFaultTolerance(&rayClusterSpec.HeadGroupSpec, options)
+	}
+
+	// The operator provisions the auth token and the auth proxy sidecar of the dashboard.
+	if clusterSpec.EnableDashboardAuth {
+		rayClusterSpec.DashboardAuth = &rayv1api.DashboardAuthConfig{}
+	}
+
+	if logging := clusterSpec.Logging; logging != nil && (logging.LoggingLevel != "" || logging.BackendLogLevel != "" || logging.TempDir != "") {
+		rayClusterSpec.Logging = &rayv1api.RayLoggingConfig{
+			LoggingLevel:    logging.LoggingLevel,
+			BackendLogLevel: logging.BackendLogLevel,
+			TempDir:         logging.TempDir,
+		}
+	}
+
 	// Build worker groups
 	for _, spec := range clusterSpec.WorkerGroupSpec {
 		computeTemplate = computeTemplateMap[spec.ComputeTemplate]
@@ -122,6 +209,21 @@ func buildRayClusterSpec(imageVersion string, envs *api.EnvironmentVariables, cl
 		rayClusterSpec.WorkerGroupSpecs = append(rayClusterSpec.WorkerGroupSpecs, workerNodeSpec)
 	}
 
+	// The image pull Secrets of the cluster are added to the pods of all the groups.
+	addImagePullSecrets(&rayClusterSpec.HeadGroupSpec.Template, clusterSpec.ImagePullSecrets)
+	for i := range rayClusterSpec.WorkerGroupSpecs {
+		addImagePullSecrets(&rayClusterSpec.WorkerGroupSpecs[i].Template, clusterSpec.ImagePullSecrets)
+	}
+	if err := addSecretInjections(&rayClusterSpec.HeadGroupSpec.Template, "ray-head", utils.RayNodeHeadGroupLabelValue, clusterSpec.SecretInjections); err != nil {
+		return nil, err
+	}
+	for i := range rayClusterSpec.WorkerGroupSpecs {
+		group := &rayClusterSpec.WorkerGroupSpecs[i]
+		if err := addSecretInjections(&group.Template, "ray-worker", group.GroupName, clusterSpec.SecretInjections); err != nil {
+			return nil, err
+		}
+	}
+
 	if clusterSpec.EnableInTreeAutoscaling {
 		// This is a cluster with auto scaler
 		rayClusterSpec.EnableInTreeAutoscaling = &clusterSpec.EnableInTreeAutoscaling
@@ -137,10 +239,66 @@ func buildRayClusterSpec(imageVersion string, envs *api.EnvironmentVariables, cl
 	return rayClusterSpec, nil
 }
 
+// addImagePullSecrets adds the image pull Secrets of a cluster to the pod template of a group, and lists them in its
+// annotations to tell them apart from the image pull Secret of the group.
+func addImagePullSecrets(template *corev1.PodTemplateSpec, secrets []string) {
+	if len(secrets) == 0 {
+		return
+	}
+	for _, secret := range secrets {
+		if !slices.ContainsFunc(template.Spec.ImagePullSecrets, func(reference corev1.LocalObjectReference) bool { return reference.Name == secret }) {
+			template.Spec.ImagePullSecrets = append(template.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+		}
+	}
+	template.Annotations[RayClusterImagePullSecretsAnnotationKey] = strings.Join(secrets, ",")
+}
+
+// ImagePullSecretsFromAnnotations returns the image pull Secrets of the cluster of a group from the annotations of its
+// pod template.
+func ImagePullSecretsFromAnnotations(annotations map[string]string) []string {
+	if annotations[RayClusterImagePullSecretsAnnotationKey] == "" {
+		return nil
+	}
+	return strings.Split(annotations[RayClusterImagePullSecretsAnnotationKey], ",")
+}
+
+// ImagePullSecretNames returns the names of the image pull Secrets referenced by a cluster spec.
+func ImagePullSecretNames(clusterSpec *api.ClusterSpec) []string {
+	names := []string{}
+	add := func(name string) {
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	add(clusterSpec.GetHeadGroupSpec().GetImagePullSecret())
+	for _, spec := range clusterSpec.GetWorkerGroupSpec() {
+		add(spec.ImagePullSecret)
+	}
+	for _, name := range clusterSpec.GetImagePullSecrets() {
+		add(name)
+	}
+	return names
+}
+
+// buildImagePullPolicy returns the image pull policy of a container, IfNotPresent unless Always or Never is set.
+func buildImagePullPolicy(policy string) corev1.PullPolicy {
+	switch strings.ToLower(policy) {
+	case "always":
+		return corev1.PullAlways
+	case "never":
+		return corev1.PullNever
+	default:
+		return corev1.PullIfNotPresent
+	}
+}
+
 // Annotations common to both head and worker nodes
 func buildNodeGroupAnnotations(computeTemplate *api.ComputeTemplate, image string) map[string]string {
 	annotations := map[string]string{}
 	annotations[RayClusterComputeTemplateAnnotationKey] = computeTemplate.Name
+	if computeTemplate.Revision > 0 {
+		annotations[RayClusterComputeTemplateRevisionAnnotationKey] = strconv.Itoa(int(computeTemplate.Revision))
+	}
 	annotations[RayClusterImageAnnotationKey] = image
 	return annotations
 }
@@ -152,12 +310,7 @@ func buildHeadPodTemplate(imageVersion string, envs *api.EnvironmentVariables, s
 		image = spec.Image
 	}
 
-	// Image pull policy. Kubernetes default image pull policy IfNotPresent, so we here only
-	// Overwrite it if it is Always
-	imagePullPolicy := corev1.PullIfNotPresent
-	if len(spec.ImagePullPolicy) > 0 && strings.ToLower(spec.ImagePullPolicy) == "always" {
-		imagePullPolicy = corev1.PullAlways
-	}
+	imagePullPolicy := buildImagePullPolicy(spec.ImagePullPolicy)
 
 	// calculate resources
 	cpu := fmt.Sprint(computeRuntime.GetCpu())
@@ -241,6 +394,15 @@ func buildHeadPodTemplate(imageVersion string, envs *api.EnvironmentVariables, s
 			container.Resources.Requests[corev1.ResourceName(accelerator)] = resource.MustParse(fmt.Sprint(gpu))
 			container.Resources.Limits[corev1.ResourceName(accelerator)] = resource.MustParse(fmt.Sprint(gpu))
 		}
+		if err := setExtendedResources(&container, computeRuntime); err != nil {
+			return nil, err
+		}
+		if err := setEphemeralStorage(&container, computeRuntime, spec.EphemeralStorage, spec.EphemeralStorageLimit); err != nil {
+			return nil, err
+		}
+		if err := addMemoryVolumes(&podTemplateSpec, &container, spec.SharedMemorySize, spec.ScratchVolumes, spec.RayStartParams); err != nil {
+			return nil, err
+		}
 		globalEnv := convertEnvironmentVariables(envs)
 		if len(globalEnv) > 0 {
 			container.Env = append(container.Env, globalEnv...)
@@ -251,6 +413,7 @@ func buildHeadPodTemplate(imageVersion string, envs *api.EnvironmentVariables, s
 		if len(specEnv) > 0 {
 			container.Env = append(container.Env, specEnv...)
 		}
+		container.EnvFrom = convertEnvFromSources(spec.EnvFrom)
 
 		// If enableServeService add port
 		if enableServeService {
@@ -258,10 +421,25 @@ func buildHeadPodTemplate(imageVersion string, envs *api.EnvironmentVariables, s
 			container.Ports = append(container.Ports, corev1.ContainerPort{Name: "serve", ContainerPort: 8000})
 		}
 
+		// Add user defined ports, the operator exposes all ports of the head container in the head service
+		for _, port := range spec.ServicePorts {
+			container.Ports = append(container.Ports, corev1.ContainerPort{Name: port.Name, ContainerPort: port.Port})
+		}
+
+		// Add lifecycle hooks
+		container.Lifecycle = buildContainerLifecycle(spec.Lifecycle, "")
+
 		// Replace container
 		podTemplateSpec.Spec.Containers[index] = container
 	}
 
+	// Add sidecar containers after the Ray container, which has to stay the first one
+	sidecars, err := buildSidecarContainers(spec.SidecarContainers)
+	if err != nil {
+		return nil, err
+	}
+	podTemplateSpec.Spec.Containers = append(podTemplateSpec.Spec.Containers, sidecars...)
+
 	// Add specific annotations
 	if spec.Annotations != nil {
 		for k, v := range spec.Annotations {
@@ -285,6 +463,10 @@ func buildHeadPodTemplate(imageVersion string, envs *api.EnvironmentVariables, s
 		}
 	}
 
+	// Add node selector and affinity
+	podTemplateSpec.Spec.NodeSelector = buildNodeSelector(computeRuntime)
+	podTemplateSpec.Spec.Affinity = buildAffinity(computeRuntime)
+
 	// If service account is specified, add it to the pod spec.
 	if len(spec.ServiceAccount) > 1 {
 		podTemplateSpec.Spec.ServiceAccountName = spec.ServiceAccount
@@ -299,9 +481,154 @@ func buildHeadPodTemplate(imageVersion string, envs *api.EnvironmentVariables, s
 		}
 	}
 
+	// If scheduler name is specified, hand the pod to that scheduler.
+	if spec.SchedulerName != "" {
+		podTemplateSpec.Spec.SchedulerName = spec.SchedulerName
+	}
+
+	// Add the high availability options
+	if spec.RestartPolicy != "" {
+		podTemplateSpec.Spec.RestartPolicy = corev1.RestartPolicy(spec.RestartPolicy)
+	}
+	podTemplateSpec.Spec.TopologySpreadConstraints = buildTopologySpreadConstraints(utils.RayNodeHeadGroupLabelValue, spec.TopologySpreadConstraints)
+	if err := setPodDisruptionBudgetAnnotation(&podTemplateSpec, spec.PodDisruptionBudget); err != nil {
+		return nil, err
+	}
+
 	return &podTemplateSpec, nil
 }
 
+// Names of the ports which the API server defines for the head container, they can't be used for user defined ports
+var defaultHeadPortNames = map[string]bool{
+	"redis":           true,
+	"head":            true,
+	"dashboard":       true,
+	"metrics":         true,
+	"dashboard-agent": true,
+	"serve":           true,
+}
+
+// IsDefaultHeadPortName returns true for the names of the ports which the API server defines for the head container.
+func IsDefaultHeadPortName(name string) bool {
+	return defaultHeadPortNames[name]
+}
+
+// WorkerPreStopCommand is run by the preStop hook of the worker containers after the user defined command.
+const WorkerPreStopCommand = "ray stop"
+
+const (
+	// RayRedisAddressEnv is the environment variable of the head node holding the address of the external Redis.
+	RayRedisAddressEnv = "RAY_REDIS_ADDRESS"
+	// RedisPasswordEnv is the environment variable of the head node holding the password of the external Redis.
+	RedisPasswordEnv = "REDIS_PASSWORD"
+	// DefaultRedisPasswordSecretKey is the default key of the Redis password in its Secret.
+	DefaultRedisPasswordSecretKey = "password"
+)
+
+// Build lifecycle hooks of a Ray container. The default pre stop command is run after the user defined one.
+// setEphemeralStorage sets the ephemeral storage request and limit of a Ray container, so that its pod isn't the
+// first one evicted when objects are spilled to the local disk. The values of the group override the ones of the
+// compute template, and the limit defaults to the request.
+func setEphemeralStorage(container *corev1.Container, computeRuntime *api.ComputeTemplate, request string, limit string) error {
+	if request == "" {
+		request = computeRuntime.GetEphemeralStorage()
+	}
+	if limit == "" {
+		limit = computeRuntime.GetEphemeralStorageLimit()
+	}
+	if limit == "" {
+		limit = request
+	}
+	if request != "" {
+		quantity, err := resource.ParseQuantity(request)
+		if err != nil {
+			return fmt.Errorf("ephemeral storage %q is not specified correctly: %w", request, err)
+		}
+		container.Resources.Requests[corev1.ResourceEphemeralStorage] = quantity
+	}
+	if limit != "" {
+		quantity, err := resource.ParseQuantity(limit)
+		if err != nil {
+			return fmt.Errorf("ephemeral storage limit %q is not specified correctly: %w", limit, err)
+		}
+		if requestQuantity, ok := container.Resources.Requests[corev1.ResourceEphemeralStorage]; ok && quantity.Cmp(requestQuantity) < 0 {
+			return fmt.Errorf("ephemeral storage limit %s is lower than the ephemeral storage request %s", limit, request)
+		}
+		container.Resources.Limits[corev1.ResourceEphemeralStorage] = quantity
+	}
+	return nil
+}
+
+// DefaultAcceleratorTypeLabel is the node label holding the type of the accelerators of the nodes when the compute
+// template does not set one, the label of the accelerator node pools of GKE.
+const DefaultAcceleratorTypeLabel = "cloud.google.com/gke-accelerator"
+
+// setExtendedResources requests the extended resources of the compute template, e.g. google.com/tpu, for a Ray
+// container. Extended resources can't be overcommitted, so their limits are their requests.
+func setExtendedResources(container *corev1.Container, computeRuntime *api.ComputeTemplate) error {
+	for name, value := range computeRuntime.GetExtendedResources() {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("extended resource %s %q is not specified correctly: %w", name, value, err)
+		}
+		container.Resources.Requests[corev1.ResourceName(name)] = quantity
+		container.Resources.Limits[corev1.ResourceName(name)] = quantity
+	}
+	return nil
+}
+
+// buildNodeSelector returns the node selector of the compute template, with the accelerator type on its label.
+func buildNodeSelector(computeRuntime *api.ComputeTemplate) map[string]string {
+	if computeRuntime.GetAcceleratorType() == "" {
+		if len(computeRuntime.GetNodeSelector()) == 0 {
+			return nil
+		}
+		return computeRuntime.GetNodeSelector()
+	}
+	nodeSelector := make(map[string]string, len(computeRuntime.GetNodeSelector())+1)
+	for key, value := range computeRuntime.GetNodeSelector() {
+		nodeSelector[key] = value
+	}
+	nodeSelector[AcceleratorTypeLabel(computeRuntime)] = computeRuntime.GetAcceleratorType()
+	return nodeSelector
+}
+
+// AcceleratorTypeLabel returns the node label holding the type of the accelerators of a compute template.
+func AcceleratorTypeLabel(computeRuntime *api.ComputeTemplate) string {
+	if computeRuntime.GetAcceleratorTypeLabel() != "" {
+		return computeRuntime.GetAcceleratorTypeLabel()
+	}
+	return DefaultAcceleratorTypeLabel
+}
+
+func buildContainerLifecycle(lifecycle *api.ContainerLifecycle, defaultPreStop string) *corev1.Lifecycle {
+	postStart := lifecycle.GetPostStart()
+	preStop := lifecycle.GetPreStop()
+	if defaultPreStop != "" {
+		if preStop != "" {
+			preStop = preStop + "; " + defaultPreStop
+		} else {
+			preStop = defaultPreStop
+		}
+	}
+	if postStart == "" && preStop == "" {
+		return nil
+	}
+
+	result := &corev1.Lifecycle{}
+	if postStart != "" {
+		result.PostStart = &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", postStart}},
+		}
+	}
+	if preStop != "" {
+		result.PreStop = &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", preStop}},
+		}
+	}
+	return result
+}
+
 // Convert environment variables
 func convertEnvironmentVariables(envs *api.EnvironmentVariables) []corev1.EnvVar {
 	converted := []corev1.EnvVar{}
@@ -369,6 +696,58 @@ func convertEnvironmentVariables(envs *api.EnvironmentVariables) []corev1.EnvVar
 	return converted
 }
 
+// Convert the ConfigMaps and Secrets whose keys are all added as environment variables of a container
+func convertEnvFromSources(sources []*api.EnvValueFrom) []corev1.EnvFromSource {
+	var converted []corev1.EnvFromSource
+	for _, source := range sources {
+		if envFrom := convertEnvFrom(source); envFrom != nil {
+			converted = append(converted, *envFrom)
+		}
+	}
+	return converted
+}
+
+// Build the sidecar containers of a group, their requests and limits are the same
+func buildSidecarContainers(sidecars []*api.SidecarContainer) ([]corev1.Container, error) {
+	var containers []corev1.Container
+	for _, sidecar := range sidecars {
+		container := corev1.Container{
+			Name:            sidecar.Name,
+			Image:           sidecar.Image,
+			ImagePullPolicy: buildImagePullPolicy(sidecar.ImagePullPolicy),
+			Command:         sidecar.Command,
+			Args:            sidecar.Args,
+			EnvFrom:         convertEnvFromSources(sidecar.EnvFrom),
+		}
+		if env := convertEnvironmentVariables(sidecar.Environment); len(env) > 0 {
+			container.Env = env
+		}
+		for _, mount := range sidecar.VolumeMounts {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      mount.Name,
+				MountPath: mount.MountPath,
+				ReadOnly:  mount.ReadOnly,
+			})
+		}
+		resources := corev1.ResourceList{}
+		for name, value := range map[corev1.ResourceName]string{corev1.ResourceCPU: sidecar.Cpu, corev1.ResourceMemory: sidecar.Memory} {
+			if value == "" {
+				continue
+			}
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s of sidecar container %s is not specified correctly: %w", name, sidecar.Name, err)
+			}
+			resources[name] = quantity
+		}
+		if len(resources) > 0 {
+			container.Resources = corev1.ResourceRequirements{Requests: resources, Limits: resources.DeepCopy()}
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
 // Convert Toleration operator from string
 func convertTolerationOperator(val string) corev1.TolerationOperator {
 	if val == "Exists" {
@@ -388,11 +767,80 @@ func convertTaintEffect(val string) corev1.TaintEffect {
 	return corev1.TaintEffectPreferNoSchedule
 }
 
+// Build the affinity of the pods from the rules of the compute template. Rules with a weight are preferences.
+func buildAffinity(computeRuntime *api.ComputeTemplate) *corev1.Affinity {
+	affinity := &corev1.Affinity{}
+	if len(computeRuntime.NodeAffinity) > 0 {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+		for _, rule := range computeRuntime.NodeAffinity {
+			term := corev1.NodeSelectorTerm{}
+			for _, requirement := range rule.MatchExpressions {
+				term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+					Key: requirement.Key, Operator: corev1.NodeSelectorOperator(requirement.Operator), Values: requirement.Values,
+				})
+			}
+			if rule.Weight > 0 {
+				affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+					corev1.PreferredSchedulingTerm{Weight: rule.Weight, Preference: term})
+				continue
+			}
+			if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+				affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+			}
+			// The node has to match one of the required terms.
+			affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = append(
+				affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, term)
+		}
+	}
+	if len(computeRuntime.PodAffinity) > 0 {
+		required, preferred := buildPodAffinityTerms(computeRuntime.PodAffinity)
+		affinity.PodAffinity = &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+	if len(computeRuntime.PodAntiAffinity) > 0 {
+		required, preferred := buildPodAffinityTerms(computeRuntime.PodAntiAffinity)
+		affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+	if affinity.NodeAffinity == nil && affinity.PodAffinity == nil && affinity.PodAntiAffinity == nil {
+		return nil
+	}
+	return affinity
+}
+
+// Split pod affinity rules into required and preferred terms
+func buildPodAffinityTerms(rules []*api.PodAffinityRule) ([]corev1.PodAffinityTerm, []corev1.WeightedPodAffinityTerm) {
+	var required []corev1.PodAffinityTerm
+	var preferred []corev1.WeightedPodAffinityTerm
+	for _, rule := range rules {
+		term := corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: rule.MatchLabels},
+			TopologyKey:   rule.TopologyKey,
+		}
+		if rule.Weight > 0 {
+			preferred = append(preferred, corev1.WeightedPodAffinityTerm{Weight: rule.Weight, PodAffinityTerm: term})
+		} else {
+			required = append(required, term)
+		}
+	}
+	return required, preferred
+}
+
 // Construct Ray image
 func constructRayImage(containerImage string, version string) string {
 	return fmt.Sprintf("%s:%s", containerImage, version)
 }
 
+// NewWorkerPodTemplate builds the Pod template of a worker group of an existing cluster, e.g. to update its image or
+// compute template in place. The cluster environment variables are already part of the environment of the group.
+func NewWorkerPodTemplate(imageVersion string, spec *api.WorkerGroupSpec, computeRuntime *api.ComputeTemplate) (*corev1.PodTemplateSpec, error) {
+	return buildWorkerPodTemplate(imageVersion, nil, spec, computeRuntime)
+}
+
 // Build worker pod template
 func buildWorkerPodTemplate(imageVersion string, envs *api.EnvironmentVariables, spec *api.WorkerGroupSpec, computeRuntime *api.ComputeTemplate) (*corev1.PodTemplateSpec, error) {
 	// If user doesn't provide the image, let's use the default image instead.
@@ -402,12 +850,7 @@ func buildWorkerPodTemplate(imageVersion string, envs *api.EnvironmentVariables,
 		image = spec.Image
 	}
 
-	// Image pull policy. Kubernetes default image pull policy IfNotPresent, so we here only
-	// Overwrite it if it is Always
-	imagePullPolicy := corev1.PullIfNotPresent
-	if len(spec.ImagePullPolicy) > 0 && strings.ToLower(spec.ImagePullPolicy) == "always" {
-		imagePullPolicy = corev1.PullAlways
-	}
+	imagePullPolicy := buildImagePullPolicy(spec.ImagePullPolicy)
 
 	// calculate resources
 	cpu := fmt.Sprint(computeRuntime.GetCpu())
@@ -503,7 +946,7 @@ func buildWorkerPodTemplate(imageVersion string, envs *api.EnvironmentVariables,
 						PreStop: &corev1.LifecycleHandler{
 							Exec: &corev1.ExecAction{
 								Command: []string{
-									"/bin/sh", "-c", "ray stop",
+									"/bin/sh", "-c", WorkerPreStopCommand,
 								},
 							},
 						},
@@ -539,6 +982,15 @@ func buildWorkerPodTemplate(imageVersion string, envs *api.EnvironmentVariables,
 			container.Resources.Requests[corev1.ResourceName(accelerator)] = resource.MustParse(fmt.Sprint(gpu))
 			container.Resources.Limits[corev1.ResourceName(accelerator)] = resource.MustParse(fmt.Sprint(gpu))
 		}
+		if err := setExtendedResources(&container, computeRuntime); err != nil {
+			return nil, err
+		}
+		if err := setEphemeralStorage(&container, computeRuntime, spec.EphemeralStorage, spec.EphemeralStorageLimit); err != nil {
+			return nil, err
+		}
+		if err := addMemoryVolumes(&podTemplateSpec, &container, spec.SharedMemorySize, spec.ScratchVolumes, spec.RayStartParams); err != nil {
+			return nil, err
+		}
 
 		globalEnv := convertEnvironmentVariables(envs)
 		if len(globalEnv) > 0 {
@@ -550,11 +1002,22 @@ func buildWorkerPodTemplate(imageVersion string, envs *api.EnvironmentVariables,
 		if len(specEnv) > 0 {
 			container.Env = append(container.Env, specEnv...)
 		}
+		container.EnvFrom = convertEnvFromSources(spec.EnvFrom)
+
+		// Add lifecycle hooks, `ray stop` is always run before a worker is stopped
+		container.Lifecycle = buildContainerLifecycle(spec.Lifecycle, WorkerPreStopCommand)
 
 		// Replace container
 		podTemplateSpec.Spec.Containers[index] = container
 	}
 
+	// Add sidecar containers after the Ray container, which has to stay the first one
+	sidecars, err := buildSidecarContainers(spec.SidecarContainers)
+	if err != nil {
+		return nil, err
+	}
+	podTemplateSpec.Spec.Containers = append(podTemplateSpec.Spec.Containers, sidecars...)
+
 	// Add specific annotations
 	if spec.Annotations != nil {
 		for k, v := range spec.Annotations {
@@ -578,6 +1041,10 @@ func buildWorkerPodTemplate(imageVersion string, envs *api.EnvironmentVariables,
 		}
 	}
 
+	// Add node selector and affinity
+	podTemplateSpec.Spec.NodeSelector = buildNodeSelector(computeRuntime)
+	podTemplateSpec.Spec.Affinity = buildAffinity(computeRuntime)
+
 	// If service account is specified, add it to the pod spec.
 	if len(spec.ServiceAccount) > 1 {
 		podTemplateSpec.Spec.ServiceAccountName = spec.ServiceAccount
@@ -592,9 +1059,30 @@ func buildWorkerPodTemplate(imageVersion string, envs *api.EnvironmentVariables,
 		}
 	}
 
+	// If scheduler name is specified, hand the pod to that scheduler.
+	if spec.SchedulerName != "" {
+		podTemplateSpec.Spec.SchedulerName = spec.SchedulerName
+	}
+
+	// Add the high availability options
+	podTemplateSpec.Spec.TopologySpreadConstraints = buildTopologySpreadConstraints(spec.GroupName, spec.TopologySpreadConstraints)
+	if err := setPodDisruptionBudgetAnnotation(&podTemplateSpec, spec.PodDisruptionBudget); err != nil {
+		return nil, err
+	}
+
 	return &podTemplateSpec, nil
 }
 
+// NewComputeTemplatePodSpec returns the spec of a worker Pod of a compute template, with its resources, tolerations,
+// node selector and affinity, e.g. to check on which Nodes such Pods fit.
+func NewComputeTemplatePodSpec(computeTemplate *api.ComputeTemplate) (*corev1.PodSpec, error) {
+	podTemplate, err := buildWorkerPodTemplate("", nil, &api.WorkerGroupSpec{ComputeTemplate: computeTemplate.Name}, computeTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return &podTemplate.Spec, nil
+}
+
 // Build Volume mounts
 func buildVolumeMounts(apiVolumes []*api.Volume) []corev1.VolumeMount {
 	var (
@@ -809,6 +1297,18 @@ func NewComputeTemplate(runtime *api.ComputeTemplate) (*corev1.ConfigMap, error)
 		"gpu":             strconv.FormatUint(uint64(runtime.Gpu), 10),
 		"gpu_accelerator": runtime.GpuAccelerator,
 	}
+	if runtime.EphemeralStorage != "" {
+		dmap["ephemeral_storage"] = runtime.EphemeralStorage
+	}
+	if runtime.EphemeralStorageLimit != "" {
+		dmap["ephemeral_storage_limit"] = runtime.EphemeralStorageLimit
+	}
+	if runtime.AcceleratorType != "" {
+		dmap["accelerator_type"] = runtime.AcceleratorType
+	}
+	if runtime.AcceleratorTypeLabel != "" {
+		dmap["accelerator_type_label"] = runtime.AcceleratorTypeLabel
+	}
 	// Add tolerations in defined
 	if runtime.Tolerations != nil && len(runtime.Tolerations) > 0 {
 		t, err := json.Marshal(runtime.Tolerations)
@@ -820,6 +1320,31 @@ func NewComputeTemplate(runtime *api.ComputeTemplate) (*corev1.ConfigMap, error)
 		}
 	}
 
+	// Add node selector, affinity and extended resources if defined
+	scheduling := map[string]interface{}{}
+	if len(runtime.NodeSelector) > 0 {
+		scheduling["node_selector"] = runtime.NodeSelector
+	}
+	if len(runtime.NodeAffinity) > 0 {
+		scheduling["node_affinity"] = runtime.NodeAffinity
+	}
+	if len(runtime.PodAffinity) > 0 {
+		scheduling["pod_affinity"] = runtime.PodAffinity
+	}
+	if len(runtime.PodAntiAffinity) > 0 {
+		scheduling["pod_anti_affinity"] = runtime.PodAntiAffinity
+	}
+	if len(runtime.ExtendedResources) > 0 {
+		scheduling["extended_resources"] = runtime.ExtendedResources
+	}
+	for key, value := range scheduling {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s for compute template %s: %w", key, runtime.Name, err)
+		}
+		dmap[key] = string(data)
+	}
+
 	config := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      runtime.Name,
@@ -831,10 +1356,23 @@ func NewComputeTemplate(runtime *api.ComputeTemplate) (*corev1.ConfigMap, error)
 		},
 		Data: dmap,
 	}
+	if runtime.Revision > 0 {
+		config.Labels[RayComputeTemplateRevisionLabelKey] = strconv.Itoa(int(runtime.Revision))
+	}
 
 	return config, nil
 }
 
+// ComputeTemplateRevision returns the revision of the ConfigMap of a compute template or of one of its revisions. The
+// compute templates created before the revisions are at their first revision.
+func ComputeTemplateRevision(configMap *corev1.ConfigMap) int32 {
+	revision, err := strconv.ParseInt(configMap.Labels[RayComputeTemplateRevisionLabelKey], 10, 32)
+	if err != nil || revision < 1 {
+		return 1
+	}
+	return int32(revision)
+}
+
 // GetNodeHostIP returns the provided node's IP, based on the priority:
 // 1. NodeInternalIP
 // 2. NodeExternalIP
@@ -876,9 +1414,15 @@ func buildAutoscalerOptions(autoscalerOptions *api.AutoscalerOptions) (*rayv1api
 	if len(autoscalerOptions.Image) > 0 {
 		options.Image = &autoscalerOptions.Image
 	}
-	if len(autoscalerOptions.ImagePullPolicy) > 0 && strings.ToLower(autoscalerOptions.ImagePullPolicy) == "always" {
-		policy := corev1.PullAlways
-		options.ImagePullPolicy = &policy
+	if len(autoscalerOptions.ImagePullPolicy) > 0 {
+		switch strings.ToLower(autoscalerOptions.ImagePullPolicy) {
+		case "always":
+			policy := corev1.PullAlways
+			options.ImagePullPolicy = &policy
+		case "ifnotpresent":
+			policy := corev1.PullIfNotPresent
+			options.ImagePullPolicy = &policy
+		}
 	}
 
 	if autoscalerOptions.Envs != nil {
@@ -902,7 +1446,7 @@ func buildAutoscalerOptions(autoscalerOptions *api.AutoscalerOptions) (*rayv1api
 	if autoscalerOptions.Volumes != nil && len(autoscalerOptions.Volumes) > 0 {
 		options.VolumeMounts = buildVolumeMounts(autoscalerOptions.Volumes)
 	}
-	if len(autoscalerOptions.Cpu) > 0 || len(autoscalerOptions.Memory) > 0 {
+	if len(autoscalerOptions.Cpu) > 0 || len(autoscalerOptions.Memory) > 0 || len(autoscalerOptions.CpuLimit) > 0 || len(autoscalerOptions.MemoryLimit) > 0 {
 		rcpu := "500m"
 		rmemory := "512Mi"
 		if len(autoscalerOptions.Cpu) > 0 {
@@ -919,10 +1463,27 @@ func buildAutoscalerOptions(autoscalerOptions *api.AutoscalerOptions) (*rayv1api
 		if err != nil {
 			return nil, errors.New("memory for autoscaler is not specified correctly")
 		}
+		// Limits default to the requirements
+		lcpu := rcpu
+		lmemory := rmemory
+		if len(autoscalerOptions.CpuLimit) > 0 {
+			lcpu = autoscalerOptions.CpuLimit
+		}
+		if len(autoscalerOptions.MemoryLimit) > 0 {
+			lmemory = autoscalerOptions.MemoryLimit
+		}
+		_, err = resource.ParseQuantity(lcpu)
+		if err != nil {
+			return nil, errors.New("cpu limit for autoscaler is not specified correctly")
+		}
+		_, err = resource.ParseQuantity(lmemory)
+		if err != nil {
+			return nil, errors.New("memory limit for autoscaler is not specified correctly")
+		}
 		options.Resources = &corev1.ResourceRequirements{
 			Limits: corev1.ResourceList{
-				corev1.ResourceCPU:    resource.MustParse(rcpu),
-				corev1.ResourceMemory: resource.MustParse(rmemory),
+				corev1.ResourceCPU:    resource.MustParse(lcpu),
+				corev1.ResourceMemory: resource.MustParse(lmemory),
 			},
 			Requests: corev1.ResourceList{
 				corev1.ResourceCPU:    resource.MustParse(rcpu),
@@ -7,6 +7,8 @@ import (
 
 	api "github.com/ray-project/kuberay/proto/go_client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -182,6 +184,11 @@ var workerGroup = api.WorkerGroupSpec{
 	},
 	ServiceAccount:  "account",
 	ImagePullSecret: "foo",
+	SchedulerName:   "custom-scheduler",
+	Lifecycle: &api.ContainerLifecycle{
+		PostStart: "python /home/ray/warmup.py",
+		PreStop:   "python /home/ray/checkpoint.py",
+	},
 	Environment: &api.EnvironmentVariables{
 		Values: map[string]string{
 			"foo": "bar",
@@ -528,6 +535,9 @@ func TestBuildHeadPodTemplate(t *testing.T) {
 	if len(podSpec.Spec.Containers[0].Ports) != 4 {
 		t.Errorf("failed build ports")
 	}
+	if podSpec.Spec.Containers[0].Lifecycle != nil {
+		t.Errorf("unexpected lifecycle hooks, got %v", podSpec.Spec.Containers[0].Lifecycle)
+	}
 	// Sort values for comparison
 	sort.SliceStable(podSpec.Spec.Containers[0].Env, func(i, j int) bool {
 		return podSpec.Spec.Containers[0].Env[i].Name < podSpec.Spec.Containers[0].Env[j].Name
@@ -572,6 +582,22 @@ func TestConvertAutoscalerOptions(t *testing.T) {
 	assert.Equal(t, len(options.VolumeMounts), 2)
 	assert.Equal(t, options.Resources.Requests.Cpu().String(), "300m")
 	assert.Equal(t, options.Resources.Requests.Memory().String(), "512Mi")
+	assert.Equal(t, options.Resources.Limits.Cpu().String(), "300m")
+
+	limitedOptions := proto.Clone(&testAutoscalerOptions).(*api.AutoscalerOptions)
+	limitedOptions.ImagePullPolicy = "IfNotPresent"
+	limitedOptions.CpuLimit = "1"
+	limitedOptions.MemoryLimit = "1Gi"
+	options, err = buildAutoscalerOptions(limitedOptions)
+	assert.Nil(t, err)
+	assert.Equal(t, corev1.PullIfNotPresent, *options.ImagePullPolicy)
+	assert.Equal(t, "300m", options.Resources.Requests.Cpu().String())
+	assert.Equal(t, "1", options.Resources.Limits.Cpu().String())
+	assert.Equal(t, "1Gi", options.Resources.Limits.Memory().String())
+
+	limitedOptions.CpuLimit = "one"
+	_, err = buildAutoscalerOptions(limitedOptions)
+	assert.NotNil(t, err)
 }
 
 func TestBuildRayCluster(t *testing.T) {
@@ -588,6 +614,176 @@ func TestBuildRayCluster(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, *cluster.Spec.EnableInTreeAutoscaling, true)
 	assert.NotEqual(t, cluster.Spec.AutoscalerOptions, nil)
+	assert.Nil(t, cluster.Spec.HeadGroupSpec.HeadNodeReservation)
+
+	reservedHeadCluster := &api.Cluster{
+		Name:      rayCluster.Name,
+		Namespace: rayCluster.Namespace,
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec:   rayCluster.ClusterSpec.HeadGroupSpec,
+			WorkerGroupSpec: rayCluster.ClusterSpec.WorkerGroupSpec,
+			ReserveHeadNode: true,
+		},
+	}
+	cluster, err = NewRayCluster(reservedHeadCluster, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	assert.NotNil(t, cluster.Spec.HeadGroupSpec.HeadNodeReservation)
+}
+
+func TestBuildRayClusterHeadService(t *testing.T) {
+	cluster := &api.Cluster{
+		Name:      "test_cluster",
+		Namespace: "foo",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "foo",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+				ServiceName:     "my-head",
+				ServicePorts:    []*api.ServicePort{{Name: "grpc", Port: 9000}},
+			},
+		},
+	}
+	rayCluster, err := NewRayCluster(cluster, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	assert.Equal(t, "my-head", rayCluster.Spec.HeadGroupSpec.HeadService.Name)
+	container := rayCluster.Spec.HeadGroupSpec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Ports, corev1.ContainerPort{Name: "grpc", ContainerPort: 9000})
+
+	cluster.ClusterSpec.HeadGroupSpec.ServiceName = ""
+	rayCluster, err = NewRayCluster(cluster, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	assert.Nil(t, rayCluster.Spec.HeadGroupSpec.HeadService)
+}
+
+func TestBuildRayClusterGcsFaultTolerance(t *testing.T) {
+	cluster := &api.Cluster{
+		Name:        "test_cluster",
+		Namespace:   "foo",
+		Annotations: map[string]string{"team": "serving"},
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "foo",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+			GcsFaultTolerance: &api.GcsFaultToleranceOptions{
+				RedisAddress:             "redis:6379",
+				RedisPasswordSecretName:  "redis-password",
+				ExternalStorageNamespace: "serving",
+			},
+		},
+	}
+	rayCluster, err := NewRayCluster(cluster, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"team":                            "serving",
+		RayFTEnabledAnnotationKey:         "true",
+		RayExternalStorageNSAnnotationKey: "serving",
+	}, rayCluster.Annotations)
+	container := rayCluster.Spec.HeadGroupSpec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: RayRedisAddressEnv, Value: "redis:6379"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: RedisPasswordEnv, ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "redis-password"},
+			Key:                  DefaultRedisPasswordSecretKey,
+		},
+	}})
+	assert.Equal(t, "$REDIS_PASSWORD", rayCluster.Spec.HeadGroupSpec.RayStartParams["redis-password"])
+	// The request is not modified.
+	assert.Equal(t, map[string]string{"team": "serving"}, cluster.Annotations)
+	assert.Equal(t, map[string]string{"dashboard-host": "0.0.0.0"}, cluster.ClusterSpec.HeadGroupSpec.RayStartParams)
+
+	// Without a password, the head node only gets the Redis address.
+	cluster.ClusterSpec.GcsFaultTolerance = &api.GcsFaultToleranceOptions{RedisAddress: "redis:6379"}
+	rayCluster, err = NewRayCluster(cluster, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	assert.Equal(t, "true", rayCluster.Annotations[RayFTEnabledAnnotationKey])
+	assert.NotContains(t, rayCluster.Annotations, RayExternalStorageNSAnnotationKey)
+	assert.NotContains(t, rayCluster.Spec.HeadGroupSpec.RayStartParams, "redis-password")
+}
+
+func TestBuildRayClusterImagePullSecrets(t *testing.T) {
+	cluster := &api.Cluster{
+		Name:      "test_cluster",
+		Namespace: "foo",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "foo",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+				ImagePullSecret: "head-registry",
+				ImagePullPolicy: "Never",
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{GroupName: "small", ComputeTemplate: "foo", Replicas: 1, MaxReplicas: 1, ImagePullSecret: "registry"},
+			},
+			ImagePullSecrets: []string{"registry", "mirror"},
+		},
+	}
+	rayCluster, err := NewRayCluster(cluster, map[string]*api.ComputeTemplate{"foo": &template})
+	require.NoError(t, err)
+	head := rayCluster.Spec.HeadGroupSpec.Template
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "head-registry"}, {Name: "registry"}, {Name: "mirror"}}, head.Spec.ImagePullSecrets)
+	assert.Equal(t, corev1.PullNever, head.Spec.Containers[0].ImagePullPolicy)
+	assert.Equal(t, []string{"registry", "mirror"}, ImagePullSecretsFromAnnotations(head.Annotations))
+	worker := rayCluster.Spec.WorkerGroupSpecs[0].Template
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}}, worker.Spec.ImagePullSecrets)
+	assert.Equal(t, corev1.PullIfNotPresent, worker.Spec.Containers[0].ImagePullPolicy)
+
+	assert.Equal(t, []string{"head-registry", "registry", "mirror"}, ImagePullSecretNames(cluster.ClusterSpec))
+}
+
+func TestBuildRayClusterSecretInjections(t *testing.T) {
+	injections := []*api.SecretInjection{
+		{SecretName: "s3", Env: map[string]string{"AWS_SECRET_ACCESS_KEY": "secret-key", "AWS_ACCESS_KEY_ID": "access-key"}},
+		{SecretName: "hf", EnvPrefix: "HF_", Groups: []string{"small"}},
+		{SecretName: "certs", MountPath: "/etc/certs", Groups: []string{"headgroup"}},
+	}
+	cluster := &api.Cluster{
+		Name:      "test_cluster",
+		Namespace: "foo",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "foo",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{GroupName: "small", ComputeTemplate: "foo", Replicas: 1, MaxReplicas: 1},
+			},
+			SecretInjections: injections,
+		},
+	}
+	rayCluster, err := NewRayCluster(cluster, map[string]*api.ComputeTemplate{"foo": &template})
+	require.NoError(t, err)
+
+	s3Env := []corev1.EnvVar{
+		{Name: "AWS_ACCESS_KEY_ID", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s3"}, Key: "access-key"}}},
+		{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "s3"}, Key: "secret-key"}}},
+	}
+	head := rayCluster.Spec.HeadGroupSpec.Template
+	headContainer, _, ok := GetContainerByName(head.Spec.Containers, "ray-head")
+	require.True(t, ok)
+	assert.Subset(t, headContainer.Env, s3Env)
+	assert.Empty(t, headContainer.EnvFrom)
+	assert.Contains(t, head.Spec.Volumes, corev1.Volume{Name: "injected-secret-2", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "certs"}}})
+	assert.Contains(t, headContainer.VolumeMounts, corev1.VolumeMount{Name: "injected-secret-2", MountPath: "/etc/certs", ReadOnly: true})
+
+	worker := rayCluster.Spec.WorkerGroupSpecs[0].Template
+	workerContainer, _, ok := GetContainerByName(worker.Spec.Containers, "ray-worker")
+	require.True(t, ok)
+	assert.Subset(t, workerContainer.Env, s3Env)
+	assert.Equal(t, []corev1.EnvFromSource{{Prefix: "HF_", SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "hf"}}}}, workerContainer.EnvFrom)
+	for _, volume := range worker.Spec.Volumes {
+		assert.NotEqual(t, "injected-secret-2", volume.Name)
+	}
+
+	// All the groups keep the injections of the cluster.
+	for _, annotations := range []map[string]string{head.Annotations, worker.Annotations} {
+		fromAnnotations, err := SecretInjectionsFromAnnotations(annotations)
+		require.NoError(t, err)
+		require.Len(t, fromAnnotations, 3)
+		for i, injection := range fromAnnotations {
+			assert.True(t, proto.Equal(injections[i], injection))
+		}
+	}
 }
 
 func TestBuilWorkerPodTemplate(t *testing.T) {
@@ -600,12 +796,18 @@ func TestBuilWorkerPodTemplate(t *testing.T) {
 	if podSpec.Spec.ImagePullSecrets[0].Name != "foo" {
 		t.Errorf("failed to propagate image pull secret")
 	}
+	if podSpec.Spec.SchedulerName != "custom-scheduler" {
+		t.Errorf("failed to propagate scheduler name")
+	}
 	if (string)(podSpec.Spec.Containers[0].ImagePullPolicy) != "Always" {
 		t.Errorf("failed to propagate image pull policy")
 	}
 	if !containsEnv(podSpec.Spec.Containers[0].Env, "foo", "bar") {
 		t.Errorf("failed to propagate environment")
 	}
+	lifecycle := podSpec.Spec.Containers[0].Lifecycle
+	assert.Equal(t, []string{"/bin/sh", "-c", "python /home/ray/warmup.py"}, lifecycle.PostStart.Exec.Command)
+	assert.Equal(t, []string{"/bin/sh", "-c", "python /home/ray/checkpoint.py; ray stop"}, lifecycle.PreStop.Exec.Command)
 	if len(podSpec.Spec.Tolerations) != 1 {
 		t.Errorf("failed to propagate tolerations, expected 1, got %d", len(podSpec.Spec.Tolerations))
 	}
@@ -621,6 +823,216 @@ func TestBuilWorkerPodTemplate(t *testing.T) {
 	}
 }
 
+func TestBuildEphemeralStorage(t *testing.T) {
+	computeTemplate := &api.ComputeTemplate{Name: "spill-template", Namespace: "default", Cpu: 2, Memory: 8, EphemeralStorage: "20Gi"}
+
+	// The limit defaults to the request of the compute template.
+	worker := &api.WorkerGroupSpec{GroupName: "workers", ComputeTemplate: "spill-template", MaxReplicas: 1}
+	podSpec, err := buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, worker, computeTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, resource.MustParse("20Gi"), podSpec.Spec.Containers[0].Resources.Requests[corev1.ResourceEphemeralStorage])
+	assert.Equal(t, resource.MustParse("20Gi"), podSpec.Spec.Containers[0].Resources.Limits[corev1.ResourceEphemeralStorage])
+
+	// The values of the group override the ones of the compute template.
+	head := &api.HeadGroupSpec{ComputeTemplate: "spill-template", RayStartParams: map[string]string{}, EphemeralStorage: "50Gi", EphemeralStorageLimit: "100Gi"}
+	podSpec, err = buildHeadPodTemplate("2.4", &api.EnvironmentVariables{}, head, computeTemplate, false)
+	require.NoError(t, err)
+	assert.Equal(t, resource.MustParse("50Gi"), podSpec.Spec.Containers[0].Resources.Requests[corev1.ResourceEphemeralStorage])
+	assert.Equal(t, resource.MustParse("100Gi"), podSpec.Spec.Containers[0].Resources.Limits[corev1.ResourceEphemeralStorage])
+
+	worker.EphemeralStorageLimit = "10Gi"
+	_, err = buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, worker, computeTemplate)
+	require.Error(t, err)
+
+	// No ephemeral storage is reserved by default.
+	podSpec, err = buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, &workerGroup, &template)
+	require.NoError(t, err)
+	assert.NotContains(t, podSpec.Spec.Containers[0].Resources.Requests, corev1.ResourceEphemeralStorage)
+	assert.NotContains(t, podSpec.Spec.Containers[0].Resources.Limits, corev1.ResourceEphemeralStorage)
+}
+
+func TestBuildMemoryVolumes(t *testing.T) {
+	computeTemplate := &api.ComputeTemplate{Name: "object-store-template", Namespace: "default", Cpu: 2, Memory: 16, EphemeralStorageLimit: "100Gi"}
+	worker := &api.WorkerGroupSpec{
+		GroupName:        "workers",
+		ComputeTemplate:  "object-store-template",
+		MaxReplicas:      1,
+		RayStartParams:   map[string]string{"object-store-memory": "4000000000"},
+		SharedMemorySize: "8Gi",
+		ScratchVolumes: []*api.ScratchVolume{
+			{Name: "spill", MountPath: "/tmp/spill", Size: "60Gi"},
+			{Name: "cache", MountPath: "/cache", Size: "4Gi", InMemory: true},
+		},
+	}
+	podSpec, err := buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, worker, computeTemplate)
+	require.NoError(t, err)
+	sharedMemory, spill, cache := resource.MustParse("8Gi"), resource.MustParse("60Gi"), resource.MustParse("4Gi")
+	assert.Equal(t, []corev1.Volume{
+		{Name: SharedMemoryVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory, SizeLimit: &sharedMemory}}},
+		{Name: "spill", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &spill}}},
+		{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory, SizeLimit: &cache}}},
+	}, podSpec.Spec.Volumes)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: SharedMemoryVolumeName, MountPath: SharedMemoryMountPath},
+		{Name: "spill", MountPath: "/tmp/spill"},
+		{Name: "cache", MountPath: "/cache"},
+	}, podSpec.Spec.Containers[0].VolumeMounts)
+	assert.Equal(t, "spill,cache", podSpec.Annotations[RayClusterScratchVolumesAnnotationKey])
+
+	// The volumes in memory can not exceed the memory of the compute template.
+	worker.SharedMemorySize = "14Gi"
+	_, err = buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, worker, computeTemplate)
+	require.ErrorContains(t, err, "exceeds the memory 16Gi of the group")
+
+	// The volumes on disk can not exceed the ephemeral storage limit.
+	worker.SharedMemorySize = "8Gi"
+	worker.ScratchVolumes[0].Size = "120Gi"
+	_, err = buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, worker, computeTemplate)
+	require.ErrorContains(t, err, "exceeds the ephemeral storage limit 100Gi of the group")
+
+	// The object store has to fit in the shared memory.
+	head := &api.HeadGroupSpec{ComputeTemplate: "object-store-template", RayStartParams: map[string]string{"object-store-memory": "10000000000"}, SharedMemorySize: "8Gi"}
+	_, err = buildHeadPodTemplate("2.4", &api.EnvironmentVariables{}, head, computeTemplate, false)
+	require.ErrorContains(t, err, "exceeds the shared memory size 8Gi")
+}
+
+func TestBuildAccelerators(t *testing.T) {
+	computeTemplate := &api.ComputeTemplate{
+		Name:              "tpu-template",
+		Namespace:         "default",
+		Cpu:               8,
+		Memory:            32,
+		ExtendedResources: map[string]string{"google.com/tpu": "4"},
+		AcceleratorType:   "tpu-v5-lite-podslice",
+		NodeSelector:      map[string]string{"cloud.google.com/gke-tpu-topology": "2x2"},
+	}
+
+	worker := &api.WorkerGroupSpec{GroupName: "workers", ComputeTemplate: "tpu-template", MaxReplicas: 1}
+	podSpec, err := buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, worker, computeTemplate)
+	require.NoError(t, err)
+	assert.Equal(t, resource.MustParse("4"), podSpec.Spec.Containers[0].Resources.Requests["google.com/tpu"])
+	assert.Equal(t, resource.MustParse("4"), podSpec.Spec.Containers[0].Resources.Limits["google.com/tpu"])
+	assert.Equal(t, map[string]string{
+		"cloud.google.com/gke-tpu-topology": "2x2",
+		DefaultAcceleratorTypeLabel:         "tpu-v5-lite-podslice",
+	}, podSpec.Spec.NodeSelector)
+	// The node selector of the compute template is not changed.
+	assert.Len(t, computeTemplate.NodeSelector, 1)
+
+	computeTemplate.AcceleratorTypeLabel = "cloud.google.com/gke-tpu-accelerator"
+	head := &api.HeadGroupSpec{ComputeTemplate: "tpu-template", RayStartParams: map[string]string{}}
+	podSpec, err = buildHeadPodTemplate("2.4", &api.EnvironmentVariables{}, head, computeTemplate, false)
+	require.NoError(t, err)
+	assert.Equal(t, resource.MustParse("4"), podSpec.Spec.Containers[0].Resources.Requests["google.com/tpu"])
+	assert.Equal(t, "tpu-v5-lite-podslice", podSpec.Spec.NodeSelector["cloud.google.com/gke-tpu-accelerator"])
+	assert.NotContains(t, podSpec.Spec.NodeSelector, DefaultAcceleratorTypeLabel)
+
+	computeTemplate.ExtendedResources["habana.ai/gaudi"] = "eight"
+	_, err = buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, worker, computeTemplate)
+	require.Error(t, err)
+}
+
+func TestBuildSidecarContainers(t *testing.T) {
+	worker := &api.WorkerGroupSpec{
+		GroupName:       "workers",
+		ComputeTemplate: "default",
+		MaxReplicas:     1,
+		Volumes:         []*api.Volume{testEmptyDirVolume},
+		EnvFrom: []*api.EnvValueFrom{
+			{Source: api.EnvValueFrom_SECRET, Name: "credentials"},
+			{Source: api.EnvValueFrom_FIELD, Name: "ignored"},
+		},
+		SidecarContainers: []*api.SidecarContainer{
+			{
+				Name:         "fluent-bit",
+				Image:        "fluent/fluent-bit:3.0",
+				Args:         []string{"-c", "/fluent-bit/etc/fluent-bit.conf"},
+				Environment:  &api.EnvironmentVariables{Values: map[string]string{"LOG_LEVEL": "info"}},
+				EnvFrom:      []*api.EnvValueFrom{{Source: api.EnvValueFrom_CONFIGMAP, Name: "fluent-bit-config"}},
+				VolumeMounts: []*api.VolumeMount{{Name: testEmptyDirVolume.Name, MountPath: "/tmp/ray", ReadOnly: true}},
+				Cpu:          "100m",
+				Memory:       "128Mi",
+			},
+		},
+	}
+	podSpec, err := buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, worker, &template)
+	require.NoError(t, err)
+	require.Len(t, podSpec.Spec.Containers, 2)
+
+	// The Ray container stays the first one, and the keys of the secret are added to its environment.
+	assert.Equal(t, "ray-worker", podSpec.Spec.Containers[0].Name)
+	assert.Equal(t, []corev1.EnvFromSource{
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}}},
+	}, podSpec.Spec.Containers[0].EnvFrom)
+
+	sidecar := podSpec.Spec.Containers[1]
+	assert.Equal(t, "fluent-bit", sidecar.Name)
+	assert.Equal(t, "fluent/fluent-bit:3.0", sidecar.Image)
+	assert.Equal(t, corev1.PullIfNotPresent, sidecar.ImagePullPolicy)
+	assert.Equal(t, []string{"-c", "/fluent-bit/etc/fluent-bit.conf"}, sidecar.Args)
+	assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}}, sidecar.Env)
+	assert.Equal(t, "fluent-bit-config", sidecar.EnvFrom[0].ConfigMapRef.Name)
+	assert.Equal(t, []corev1.VolumeMount{{Name: testEmptyDirVolume.Name, MountPath: "/tmp/ray", ReadOnly: true}}, sidecar.VolumeMounts)
+	assert.Equal(t, resource.MustParse("100m"), sidecar.Resources.Requests[corev1.ResourceCPU])
+	assert.Equal(t, resource.MustParse("128Mi"), sidecar.Resources.Limits[corev1.ResourceMemory])
+
+	head := &api.HeadGroupSpec{
+		ComputeTemplate:   "default",
+		RayStartParams:    map[string]string{},
+		SidecarContainers: []*api.SidecarContainer{{Name: "agent", Image: "agent:1.0", Cpu: "a lot"}},
+	}
+	_, err = buildHeadPodTemplate("2.4", &api.EnvironmentVariables{}, head, &template, false)
+	require.Error(t, err)
+}
+
+func TestBuildAffinity(t *testing.T) {
+	assert.Nil(t, buildAffinity(&template))
+
+	computeTemplate := &api.ComputeTemplate{
+		Name:         "gpu-template",
+		Cpu:          2,
+		Memory:       8,
+		NodeSelector: map[string]string{"cloud.google.com/gke-accelerator": "nvidia-tesla-t4"},
+		NodeAffinity: []*api.NodeAffinityRule{
+			{MatchExpressions: []*api.NodeSelectorRequirement{{Key: "node-pool", Operator: "In", Values: []string{"gpu-a", "gpu-b"}}}},
+			{MatchExpressions: []*api.NodeSelectorRequirement{{Key: "spot", Operator: "Exists"}}, Weight: 50},
+		},
+		PodAntiAffinity: []*api.PodAffinityRule{
+			{MatchLabels: map[string]string{"ray.io/node-type": "worker"}, TopologyKey: "kubernetes.io/hostname", Weight: 10},
+		},
+	}
+	expected := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "node-pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"gpu-a", "gpu-b"}}},
+				}},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+				Weight: 50,
+				Preference: corev1.NodeSelectorTerm{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "spot", Operator: corev1.NodeSelectorOpExists}},
+				},
+			}},
+		},
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+				Weight: 10,
+				PodAffinityTerm: corev1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ray.io/node-type": "worker"}},
+					TopologyKey:   "kubernetes.io/hostname",
+				},
+			}},
+		},
+	}
+	assert.Equal(t, expected, buildAffinity(computeTemplate))
+
+	podSpec, err := buildWorkerPodTemplate("2.4", &api.EnvironmentVariables{}, &workerGroup, computeTemplate)
+	assert.Nil(t, err)
+	assert.Equal(t, computeTemplate.NodeSelector, podSpec.Spec.NodeSelector)
+	assert.Equal(t, expected, podSpec.Spec.Affinity)
+}
+
 func containsEnv(envs []corev1.EnvVar, key string, val string) bool {
 	for _, env := range envs {
 		if env.Name == key && env.Value == val {
@@ -1,9 +1,16 @@
 package util
 
+import "net/http"
+
 // ClientOptions contains configuration needed to create a Kubernetes client
 type ClientOptions struct {
 	QPS   float32
 	Burst int
+	// KubeContext is the kubeconfig context of the Kubernetes cluster, empty for the current context or the in-cluster
+	// config.
+	KubeContext string
+	// WrapTransport wraps the transport of the client, e.g. to trace the calls, unless it is nil.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 // TODO: this needs to be revised.
@@ -15,13 +22,71 @@ const (
 	RayClusterEnvironmentLabelKey     = "ray.io/environment"
 	KubernetesApplicationNameLabelKey = "app.kubernetes.io/name"
 	KubernetesManagedByLabelKey       = "app.kubernetes.io/managed-by"
+	RayCronJobLabelKey                = "ray.io/cron-job"
+	// The pods of the groups with a pod disruption budget are labeled with the name of their cluster or service.
+	RayPodDisruptionBudgetLabelKey = "ray.io/disruption-budget"
+	// The clusters of the interactive sessions, which the Ray clients reach through the session proxy.
+	RayInteractiveSessionLabelKey = "ray.io/interactive-session"
+	// The revision of a compute template, on the ConfigMaps of the template and of its revisions.
+	RayComputeTemplateRevisionLabelKey = "ray.io/compute-template-revision"
+	// Batch scheduler level
+	RaySchedulerNameLabelKey     = "ray.io/scheduler-name"
+	RayPriorityClassNameLabelKey = "ray.io/priority-class-name"
+	KueueQueueNameLabelKey       = "kueue.x-k8s.io/queue-name"
+	KueuePriorityClassLabelKey   = "kueue.x-k8s.io/priority-class"
+	VolcanoQueueNameLabelKey     = "volcano.sh/queue-name"
+	YuniKornQueueLabelKey        = "yunikorn.apache.org/queue"
 
 	// Annotation keys
 	// Role level
 	RayClusterComputeTemplateAnnotationKey = "ray.io/compute-template"
 	RayClusterImageAnnotationKey           = "ray.io/compute-image"
+	// The revision of the compute template the Pods of a group were created with.
+	RayClusterComputeTemplateRevisionAnnotationKey = "ray.io/compute-template-revision"
+	// The Pods of a worker group created before its restart time are restarted, a few at a time.
+	RayClusterRestartedAtAnnotationKey    = "ray.io/restarted-at"
+	RayClusterMaxUnavailableAnnotationKey = "ray.io/restart-max-unavailable"
+	// The last drain of a worker group, which scales it down once the actors of the drained workers are gone.
+	RayWorkerGroupDrainAnnotationKey = "ray.io/worker-group-drain"
+	// The pod disruption budget options of a group, created by the API server for the clusters and services.
+	RayPodDisruptionBudgetAnnotationKey = "ray.io/pod-disruption-budget"
+	// The image pull Secrets of the cluster of a group, which are added to the image pull Secrets of the group.
+	RayClusterImagePullSecretsAnnotationKey = "ray.io/image-pull-secrets"
+	// The names of the scratch volumes of a group, which are emptyDir volumes of its pod template.
+	RayClusterScratchVolumesAnnotationKey = "ray.io/scratch-volumes"
+	// The Secrets injected into the groups of the cluster of a group, which are added to the Ray container of the group.
+	RaySecretInjectionsAnnotationKey = "ray.io/secret-injections"
+	// RayCronJob level
+	RayCronJobLastScheduleTimeAnnotationKey = "ray.io/last-schedule-time"
+	RayCronJobScheduledTimeAnnotationKey    = "ray.io/scheduled-time"
+	// Cluster level
+	RayFTEnabledAnnotationKey         = "ray.io/ft-enabled"
+	RayExternalStorageNSAnnotationKey = "ray.io/external-storage-namespace"
+	RayClusterIdleTTLAnnotationKey    = "ray.io/idle-ttl-seconds"
+	// The last time a Ray client was connected to the cluster of a session through the session proxy.
+	RaySessionActiveAtAnnotationKey = "ray.io/session-active-at"
+	// RayService level
+	RayServiceSuspendedWorkerGroupsAnnotationKey = "ray.io/suspended-worker-groups"
+	RayServiceHoldUpgradePromotionAnnotationKey  = "ray.io/hold-upgrade-promotion"
+	RayServiceUpgradeRollbackAnnotationKey       = "ray.io/upgrade-rollback-spec"
+	RayServiceExposeAnnotationKey                = "ray.io/expose"
+	// RayJob level
+	RayJobGitRepositoryAnnotationKey        = "ray.io/git-repository"
+	RayJobGitRefAnnotationKey               = "ray.io/git-ref"
+	RayJobGitCredentialsSecretAnnotationKey = "ray.io/git-credentials-secret"
+	RayJobTTLAnnotationKey                  = "ray.io/job-ttl-seconds-after-finished"
+	// Resource level
+	IdempotencyKeyAnnotationKey         = "ray.io/idempotency-key"
+	IdempotencyPayloadHashAnnotationKey = "ray.io/idempotency-payload-hash"
 
 	RayClusterDefaultImageRepository = "rayproject/ray"
+
+	// The name of the ResourceQuota created by the namespace template
+	NamespaceTemplateResourceQuotaName = "ray-namespace-quota"
+
+	// The names of the batch schedulers of the KubeRay operator
+	VolcanoSchedulerName  = "volcano"
+	YuniKornSchedulerName = "yunikorn"
 )
 
 const (
@@ -0,0 +1,82 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// RayCronJobs are stored in ConfigMaps, like compute templates, and their runs are RayJobs.
+const (
+	RayCronJobConfigType = "ray-cron-job"
+
+	// The keys of the ConfigMap data.
+	RayCronJobScheduleKey                   = "schedule"
+	RayCronJobConcurrencyPolicyKey          = "concurrencyPolicy"
+	RayCronJobSuccessfulRunsHistoryLimitKey = "successfulRunsHistoryLimit"
+	RayCronJobFailedRunsHistoryLimitKey     = "failedRunsHistoryLimit"
+	RayCronJobJobTemplateKey                = "jobTemplate"
+
+	DefaultRayCronJobSuccessfulRunsHistoryLimit = 3
+	DefaultRayCronJobFailedRunsHistoryLimit     = 1
+
+	// MaxRayCronJobNameLength leaves room for the suffix of the run names in the 63 characters of a label value.
+	MaxRayCronJobNameLength = 52
+)
+
+// NewRayCronJob creates the ConfigMap storing a RayCronJob. Unset history limits are defaulted. The job
+// template is kept in the API format, so that every run is created like a RayJob created with CreateRayJob.
+func NewRayCronJob(apiCronJob *api.RayCronJob) (*corev1.ConfigMap, error) {
+	jobTemplate, err := protojson.Marshal(apiCronJob.JobTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the job template of cron job %s: %w", apiCronJob.Name, err)
+	}
+	successfulRunsHistoryLimit := apiCronJob.SuccessfulRunsHistoryLimit
+	if successfulRunsHistoryLimit == 0 {
+		successfulRunsHistoryLimit = DefaultRayCronJobSuccessfulRunsHistoryLimit
+	}
+	failedRunsHistoryLimit := apiCronJob.FailedRunsHistoryLimit
+	if failedRunsHistoryLimit == 0 {
+		failedRunsHistoryLimit = DefaultRayCronJobFailedRunsHistoryLimit
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiCronJob.Name,
+			Namespace: apiCronJob.Namespace,
+			Labels: map[string]string{
+				"ray.io/config-type":              RayCronJobConfigType,
+				RayCronJobLabelKey:                apiCronJob.Name,
+				RayClusterUserLabelKey:            apiCronJob.User,
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesManagedByLabelKey:       ComponentName,
+			},
+		},
+		Data: map[string]string{
+			RayCronJobScheduleKey:                   apiCronJob.Schedule,
+			RayCronJobConcurrencyPolicyKey:          apiCronJob.ConcurrencyPolicy.String(),
+			RayCronJobSuccessfulRunsHistoryLimitKey: strconv.Itoa(int(successfulRunsHistoryLimit)),
+			RayCronJobFailedRunsHistoryLimitKey:     strconv.Itoa(int(failedRunsHistoryLimit)),
+			RayCronJobJobTemplateKey:                string(jobTemplate),
+		},
+	}, nil
+}
+
+// RayCronJobRunName returns the name of the run scheduled at the given time. Like the Jobs of a CronJob,
+// runs are named after the minute they were scheduled for, so a run is never created twice.
+func RayCronJobRunName(cronJobName string, scheduledTime time.Time) string {
+	return fmt.Sprintf("%s-%d", cronJobName, scheduledTime.Unix()/60)
+}
+
+// IsRayCronJobRunFinished returns whether a run has completed or failed.
+func IsRayCronJobRunFinished(job *rayv1api.RayJob) bool {
+	return job.Status.JobDeploymentStatus == rayv1api.JobDeploymentStatusComplete ||
+		job.Status.JobDeploymentStatus == rayv1api.JobDeploymentStatusFailed
+}
@@ -0,0 +1,58 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	from := time.Date(2024, time.January, 31, 10, 17, 30, 0, time.UTC) // A Wednesday.
+	tests := map[string]time.Time{
+		"* * * * *":        time.Date(2024, time.January, 31, 10, 18, 0, 0, time.UTC),
+		"*/15 * * * *":     time.Date(2024, time.January, 31, 10, 30, 0, 0, time.UTC),
+		"5/20 * * * *":     time.Date(2024, time.January, 31, 10, 25, 0, 0, time.UTC),
+		"0 9-17 * * *":     time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC),
+		"@hourly":          time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC),
+		"@daily":           time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 * * SUN":      time.Date(2024, time.February, 4, 0, 0, 0, 0, time.UTC),
+		"0 0 * * 7":        time.Date(2024, time.February, 4, 0, 0, 0, 0, time.UTC),
+		"30 6 29 feb *":    time.Date(2024, time.February, 29, 6, 30, 0, 0, time.UTC),
+		"0 0 1,15 * *":     time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 13 * fri":     time.Date(2024, time.February, 2, 0, 0, 0, 0, time.UTC),
+		"0 0 1 1 *":        time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 30 2 *":       {},
+		"@YEARLY":          time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		"17 10 31 1 *":     time.Date(2025, time.January, 31, 10, 17, 0, 0, time.UTC),
+		"0 12 * * mon-fri": time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC),
+	}
+	for spec, expected := range tests {
+		t.Run(spec, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(spec)
+			require.NoError(t, err)
+			assert.Equal(t, expected, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseCronScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"1,,2 * * * *",
+		"@every 5m",
+	} {
+		_, err := ParseCronSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
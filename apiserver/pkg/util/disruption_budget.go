@@ -0,0 +1,150 @@
+package util
+
+import (
+	"fmt"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// setPodDisruptionBudgetAnnotation keeps the pod disruption budget options of a group in the annotations of its pod
+// template, from which the budget is created with the cluster or service.
+func setPodDisruptionBudgetAnnotation(template *corev1.PodTemplateSpec, options *api.PodDisruptionBudgetOptions) error {
+	if options == nil {
+		return nil
+	}
+	value, err := protojson.Marshal(options)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the pod disruption budget options: %w", err)
+	}
+	template.Annotations[RayPodDisruptionBudgetAnnotationKey] = string(value)
+	return nil
+}
+
+// PodDisruptionBudgetFromAnnotations returns the pod disruption budget options of a group, nil if it has no budget.
+func PodDisruptionBudgetFromAnnotations(annotations map[string]string) (*api.PodDisruptionBudgetOptions, error) {
+	value, ok := annotations[RayPodDisruptionBudgetAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	options := &api.PodDisruptionBudgetOptions{}
+	if err := protojson.Unmarshal([]byte(value), options); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the pod disruption budget options: %w", err)
+	}
+	return options, nil
+}
+
+// buildTopologySpreadConstraints spreads the pods of a group of a Ray cluster, which the operator labels with the
+// name of the cluster and of the group.
+func buildTopologySpreadConstraints(groupName string, constraints []*api.TopologySpreadConstraint) []corev1.TopologySpreadConstraint {
+	result := make([]corev1.TopologySpreadConstraint, 0, len(constraints))
+	for _, constraint := range constraints {
+		spread := corev1.TopologySpreadConstraint{
+			MaxSkew:           constraint.MaxSkew,
+			TopologyKey:       constraint.TopologyKey,
+			WhenUnsatisfiable: corev1.UnsatisfiableConstraintAction(constraint.WhenUnsatisfiable),
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{utils.RayNodeGroupLabelKey: groupName}},
+			MatchLabelKeys:    []string{utils.RayClusterLabelKey},
+		}
+		if spread.MaxSkew == 0 {
+			spread.MaxSkew = 1
+		}
+		if spread.WhenUnsatisfiable == "" {
+			spread.WhenUnsatisfiable = corev1.DoNotSchedule
+		}
+		if constraint.MinDomains > 0 {
+			minDomains := constraint.MinDomains
+			spread.MinDomains = &minDomains
+		}
+		result = append(result, spread)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// SetPodDisruptionBudgetLabels labels the pod templates of the groups with a pod disruption budget with the name of
+// their cluster or service, so that the budget selects the pods of all the Ray clusters of a service.
+func SetPodDisruptionBudgetLabels(spec *rayv1api.RayClusterSpec, ownerName string) {
+	templates := []*corev1.PodTemplateSpec{&spec.HeadGroupSpec.Template}
+	for i := range spec.WorkerGroupSpecs {
+		templates = append(templates, &spec.WorkerGroupSpecs[i].Template)
+	}
+	for _, template := range templates {
+		if _, ok := template.Annotations[RayPodDisruptionBudgetAnnotationKey]; !ok {
+			continue
+		}
+		if template.Labels == nil {
+			template.Labels = map[string]string{}
+		}
+		template.Labels[RayPodDisruptionBudgetLabelKey] = ownerName
+	}
+}
+
+// PodDisruptionBudgetName returns the name of the pod disruption budget of a group of a cluster or service.
+func PodDisruptionBudgetName(ownerName string, groupName string) string {
+	return fmt.Sprintf("%s-%s-pdb", ownerName, groupName)
+}
+
+// NewPodDisruptionBudgets creates the pod disruption budgets of the groups of the spec of a cluster or service, which
+// are owned by it. By default, the head pod can not be evicted and one worker pod of a group is evicted at a time.
+func NewPodDisruptionBudgets(owner metav1.Object, ownerKind string, spec *rayv1api.RayClusterSpec) ([]*policyv1.PodDisruptionBudget, error) {
+	var budgets []*policyv1.PodDisruptionBudget
+	add := func(groupName string, template corev1.PodTemplateSpec, defaults *api.PodDisruptionBudgetOptions) error {
+		options, err := PodDisruptionBudgetFromAnnotations(template.Annotations)
+		if err != nil || options == nil {
+			return err
+		}
+		if options.MaxUnavailable == "" && options.MinAvailable == "" {
+			options = defaults
+		}
+		budget := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      PodDisruptionBudgetName(owner.GetName(), groupName),
+				Namespace: owner.GetNamespace(),
+				Labels: map[string]string{
+					KubernetesManagedByLabelKey:    ComponentName,
+					RayPodDisruptionBudgetLabelKey: owner.GetName(),
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: rayv1api.GroupVersion.String(),
+					Kind:       ownerKind,
+					Name:       owner.GetName(),
+					UID:        owner.GetUID(),
+				}},
+			},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+					RayPodDisruptionBudgetLabelKey: owner.GetName(),
+					utils.RayNodeGroupLabelKey:     groupName,
+				}},
+			},
+		}
+		if options.MaxUnavailable != "" {
+			maxUnavailable := intstr.Parse(options.MaxUnavailable)
+			budget.Spec.MaxUnavailable = &maxUnavailable
+		} else {
+			minAvailable := intstr.Parse(options.MinAvailable)
+			budget.Spec.MinAvailable = &minAvailable
+		}
+		budgets = append(budgets, budget)
+		return nil
+	}
+	if err := add(utils.RayNodeHeadGroupLabelValue, spec.HeadGroupSpec.Template, &api.PodDisruptionBudgetOptions{MinAvailable: "1"}); err != nil {
+		return nil, err
+	}
+	for _, group := range spec.WorkerGroupSpecs {
+		if err := add(group.GroupName, group.Template, &api.PodDisruptionBudgetOptions{MaxUnavailable: "1"}); err != nil {
+			return nil, err
+		}
+	}
+	return budgets, nil
+}
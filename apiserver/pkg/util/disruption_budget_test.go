@@ -0,0 +1,76 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestNewPodDisruptionBudgets(t *testing.T) {
+	spec := &rayv1api.RayClusterSpec{
+		HeadGroupSpec: rayv1api.HeadGroupSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}},
+		WorkerGroupSpecs: []rayv1api.WorkerGroupSpec{
+			{GroupName: "small", Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}},
+			{GroupName: "large", Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}},
+			{GroupName: "spot", Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}},
+		},
+	}
+	require.NoError(t, setPodDisruptionBudgetAnnotation(&spec.HeadGroupSpec.Template, &api.PodDisruptionBudgetOptions{}))
+	require.NoError(t, setPodDisruptionBudgetAnnotation(&spec.WorkerGroupSpecs[0].Template, &api.PodDisruptionBudgetOptions{}))
+	require.NoError(t, setPodDisruptionBudgetAnnotation(&spec.WorkerGroupSpecs[1].Template, &api.PodDisruptionBudgetOptions{MinAvailable: "50%"}))
+	SetPodDisruptionBudgetLabels(spec, "service")
+	assert.Equal(t, "service", spec.HeadGroupSpec.Template.Labels[RayPodDisruptionBudgetLabelKey])
+	assert.NotContains(t, spec.WorkerGroupSpecs[2].Template.Labels, RayPodDisruptionBudgetLabelKey)
+
+	owner := &rayv1api.RayService{ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "team-a", UID: "uid"}}
+	budgets, err := NewPodDisruptionBudgets(owner, "RayService", spec)
+	require.NoError(t, err)
+	require.Len(t, budgets, 3)
+
+	head := budgets[0]
+	assert.Equal(t, "service-headgroup-pdb", head.Name)
+	assert.Equal(t, "team-a", head.Namespace)
+	assert.Equal(t, ComponentName, head.Labels[KubernetesManagedByLabelKey])
+	assert.Equal(t, "RayService", head.OwnerReferences[0].Kind)
+	assert.Equal(t, map[string]string{RayPodDisruptionBudgetLabelKey: "service", "ray.io/group": "headgroup"}, head.Spec.Selector.MatchLabels)
+	assert.Equal(t, ptr.To(intstr.FromInt32(1)), head.Spec.MinAvailable)
+	assert.Nil(t, head.Spec.MaxUnavailable)
+
+	assert.Equal(t, "service-small-pdb", budgets[1].Name)
+	assert.Equal(t, ptr.To(intstr.FromInt32(1)), budgets[1].Spec.MaxUnavailable)
+	assert.Equal(t, ptr.To(intstr.FromString("50%")), budgets[2].Spec.MinAvailable)
+}
+
+func TestBuildTopologySpreadConstraints(t *testing.T) {
+	assert.Nil(t, buildTopologySpreadConstraints("small", nil))
+
+	constraints := buildTopologySpreadConstraints("small", []*api.TopologySpreadConstraint{
+		{TopologyKey: "topology.kubernetes.io/zone", MinDomains: 3},
+		{TopologyKey: "kubernetes.io/hostname", MaxSkew: 2, WhenUnsatisfiable: "ScheduleAnyway"},
+	})
+	assert.Equal(t, []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"ray.io/group": "small"}},
+			MatchLabelKeys:    []string{"ray.io/cluster"},
+			MinDomains:        ptr.To(int32(3)),
+		},
+		{
+			MaxSkew:           2,
+			TopologyKey:       "kubernetes.io/hostname",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"ray.io/group": "small"}},
+			MatchLabelKeys:    []string{"ray.io/cluster"},
+		},
+	}, constraints)
+}
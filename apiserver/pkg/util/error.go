@@ -2,13 +2,16 @@ package util
 
 import (
 	"fmt"
+	"strings"
 
 	klog "k8s.io/klog/v2"
 
 	"github.com/go-openapi/runtime"
 	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 	k8errors "k8s.io/apimachinery/pkg/api/errors"
 	k8metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -65,6 +68,45 @@ func HasCustomCode(err error, code CustomCode) bool {
 	}
 }
 
+// ErrorDomain is the domain of the ErrorInfo detail of the errors returned by the API server.
+const ErrorDomain = "kuberay.ray.io"
+
+// The reasons of the ErrorInfo detail of the errors returned by the API server. They tell apart the errors with the
+// same status code, e.g. a quota of the API server from a ResourceQuota of Kubernetes, and do not change across
+// releases, unlike the error messages.
+const (
+	ErrorReasonInvalidInput       = "INVALID_INPUT"
+	ErrorReasonNotFound           = "NOT_FOUND"
+	ErrorReasonAlreadyExists      = "ALREADY_EXISTS"
+	ErrorReasonConflict           = "CONFLICT"
+	ErrorReasonUnauthenticated    = "UNAUTHENTICATED"
+	ErrorReasonPermissionDenied   = "PERMISSION_DENIED"
+	ErrorReasonQuotaExceeded      = "QUOTA_EXCEEDED"
+	ErrorReasonRateLimited        = "RATE_LIMITED"
+	ErrorReasonFailedPrecondition = "FAILED_PRECONDITION"
+	ErrorReasonUnimplemented      = "UNIMPLEMENTED"
+	ErrorReasonInternal           = "INTERNAL"
+
+	// The reasons of the errors returned by Kubernetes, e.g. when a resource is rejected by its validation or by a
+	// ResourceQuota. The Kubernetes reason is in the kubernetesReason metadata of the ErrorInfo detail.
+	ErrorReasonKubernetesInvalid       = "KUBERNETES_INVALID"
+	ErrorReasonKubernetesQuotaExceeded = "KUBERNETES_QUOTA_EXCEEDED"
+	ErrorReasonKubernetesUnavailable   = "KUBERNETES_UNAVAILABLE"
+)
+
+// defaultErrorReasons are the reasons of the errors built from a status code only.
+var defaultErrorReasons = map[codes.Code]string{
+	codes.InvalidArgument:    ErrorReasonInvalidInput,
+	codes.NotFound:           ErrorReasonNotFound,
+	codes.AlreadyExists:      ErrorReasonAlreadyExists,
+	codes.Aborted:            ErrorReasonConflict,
+	codes.Unauthenticated:    ErrorReasonUnauthenticated,
+	codes.PermissionDenied:   ErrorReasonPermissionDenied,
+	codes.ResourceExhausted:  ErrorReasonQuotaExceeded,
+	codes.FailedPrecondition: ErrorReasonFailedPrecondition,
+	codes.Unimplemented:      ErrorReasonUnimplemented,
+}
+
 type UserError struct {
 	// Error for internal debugging.
 	internalError error
@@ -72,15 +114,25 @@ type UserError struct {
 	externalMessage string
 	// Status code for the external client.
 	externalStatusCode codes.Code
+	// Reason and metadata of the ErrorInfo detail for the external client.
+	reason   string
+	metadata map[string]string
+	// Invalid fields of the request, returned in a BadRequest detail.
+	fieldViolations []*errdetails.BadRequest_FieldViolation
 }
 
 func newUserError(internalError error, externalMessage string,
 	externalStatusCode codes.Code,
 ) *UserError {
+	reason, ok := defaultErrorReasons[externalStatusCode]
+	if !ok {
+		reason = ErrorReasonInternal
+	}
 	return &UserError{
 		internalError:      internalError,
 		externalMessage:    externalMessage,
 		externalStatusCode: externalStatusCode,
+		reason:             reason,
 	}
 }
 
@@ -122,16 +174,68 @@ func ExtractErrorForCLI(err error, isDebugMode bool) error {
 	}
 }
 
+// NewInternalServerError returns an error for an unexpected failure. A failure of a Kubernetes call is returned
+// with the status code and the reason matching the Kubernetes error instead, e.g. NotFound, or ResourceExhausted for
+// a ResourceQuota exceeded, so that clients can tell it apart from a bug of the API server.
 func NewInternalServerError(err error, internalMessageFormat string,
 	a ...interface{},
 ) *UserError {
 	internalMessage := fmt.Sprintf(internalMessageFormat, a...)
+	if kubernetesError := newKubernetesError(err, internalMessage); kubernetesError != nil {
+		return kubernetesError
+	}
 	return newUserError(
 		errors.Wrapf(err, fmt.Sprintf("InternalServerError: %v", internalMessage)),
 		"Internal Server Error",
 		codes.Internal)
 }
 
+// newKubernetesError returns the error matching a Kubernetes error, nil if err is not a Kubernetes error the client
+// can act on.
+func newKubernetesError(err error, message string) *UserError {
+	var apiStatus k8errors.APIStatus
+	if !errors.As(err, &apiStatus) {
+		return nil
+	}
+	kubernetesStatus := apiStatus.Status()
+	var code codes.Code
+	var reason string
+	switch kubernetesStatus.Reason {
+	case k8metav1.StatusReasonNotFound:
+		code, reason = codes.NotFound, ErrorReasonNotFound
+	case k8metav1.StatusReasonAlreadyExists:
+		code, reason = codes.AlreadyExists, ErrorReasonAlreadyExists
+	case k8metav1.StatusReasonConflict:
+		code, reason = codes.Aborted, ErrorReasonConflict
+	case k8metav1.StatusReasonInvalid:
+		code, reason = codes.InvalidArgument, ErrorReasonKubernetesInvalid
+	case k8metav1.StatusReasonForbidden:
+		// Kubernetes rejects the resources exceeding a ResourceQuota as forbidden.
+		if !strings.Contains(kubernetesStatus.Message, "exceeded quota") {
+			return nil
+		}
+		code, reason = codes.ResourceExhausted, ErrorReasonKubernetesQuotaExceeded
+	case k8metav1.StatusReasonTimeout, k8metav1.StatusReasonServerTimeout, k8metav1.StatusReasonTooManyRequests, k8metav1.StatusReasonServiceUnavailable:
+		code, reason = codes.Unavailable, ErrorReasonKubernetesUnavailable
+	default:
+		return nil
+	}
+	externalMessage := fmt.Sprintf("%v: %v", message, kubernetesStatus.Message)
+	userError := newUserError(errors.Wrapf(err, fmt.Sprintf("KubernetesError: %v", message)), externalMessage, code)
+	userError.reason = reason
+	userError.metadata = map[string]string{"kubernetesReason": string(kubernetesStatus.Reason)}
+	if kubernetesStatus.Details != nil {
+		for _, cause := range kubernetesStatus.Details.Causes {
+			if cause.Field != "" {
+				userError.fieldViolations = append(userError.fieldViolations, &errdetails.BadRequest_FieldViolation{
+					Field: cause.Field, Description: cause.Message,
+				})
+			}
+		}
+	}
+	return userError
+}
+
 func NewNotFoundError(err error, externalMessageFormat string,
 	a ...interface{},
 ) *UserError {
@@ -163,6 +267,94 @@ func NewInvalidInputError(messageFormat string, a ...interface{}) *UserError {
 	return newUserError(errors.Errorf("Invalid input error: %v", message), message, codes.InvalidArgument)
 }
 
+// NewInvalidFieldError returns an invalid input error for a field of the request, e.g. cluster.name. The field is
+// returned in a BadRequest detail, so that clients can show the error next to the field.
+func NewInvalidFieldError(field string, messageFormat string, a ...interface{}) *UserError {
+	userError := NewInvalidInputError(messageFormat, a...)
+	userError.fieldViolations = []*errdetails.BadRequest_FieldViolation{{Field: field, Description: userError.externalMessage}}
+	return userError
+}
+
+// ValidationErrors collects the invalid input errors of a request, so that all the invalid fields of the request are
+// returned at once rather than one at a time.
+type ValidationErrors struct {
+	errs []*UserError
+	// The first error which is not an invalid input error, e.g. an internal error of a validation.
+	other error
+}
+
+// Add adds the error of a validation of the request, if any.
+func (v *ValidationErrors) Add(err error) {
+	v.AddField("", err)
+}
+
+// AddField adds the error of the validation of a field of the request, if any. The fields of the error are nested in
+// the field, e.g. head_group_spec.compute_template in cluster.cluster_spec, and an error without field is reported on
+// the field itself.
+func (v *ValidationErrors) AddField(field string, err error) {
+	if err == nil {
+		return
+	}
+	var userError *UserError
+	if !errors.As(err, &userError) || userError.externalStatusCode != codes.InvalidArgument {
+		if v.other == nil {
+			v.other = err
+		}
+		return
+	}
+	nested := *userError
+	nested.fieldViolations = nil
+	for _, violation := range userError.fieldViolations {
+		nested.fieldViolations = append(nested.fieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field: nestedField(field, violation.Field), Description: violation.Description,
+		})
+	}
+	if len(nested.fieldViolations) == 0 && field != "" {
+		nested.fieldViolations = []*errdetails.BadRequest_FieldViolation{{Field: field, Description: userError.externalMessage}}
+	}
+	v.errs = append(v.errs, &nested)
+}
+
+// Err returns nil if the request is valid, the error of the validation if a single one failed, or else an invalid
+// input error with the messages of all the errors and a BadRequest detail listing all their invalid fields. An error
+// which is not an invalid input error is returned as is.
+func (v *ValidationErrors) Err() error {
+	if v.other != nil {
+		return v.other
+	}
+	switch len(v.errs) {
+	case 0:
+		return nil
+	case 1:
+		return v.errs[0]
+	}
+	messages := make([]string, 0, len(v.errs))
+	var fieldViolations []*errdetails.BadRequest_FieldViolation
+	for _, err := range v.errs {
+		message := err.externalMessage
+		if !strings.HasSuffix(message, ".") {
+			message += "."
+		}
+		messages = append(messages, message)
+		fieldViolations = append(fieldViolations, err.fieldViolations...)
+	}
+	userError := NewInvalidInputError("%d validations failed: %s", len(v.errs), strings.Join(messages, " "))
+	userError.fieldViolations = fieldViolations
+	return userError
+}
+
+func nestedField(parent string, field string) string {
+	switch {
+	case parent == "":
+		return field
+	case field == "":
+		return parent
+	case strings.HasPrefix(field, "["):
+		return parent + field
+	}
+	return parent + "." + field
+}
+
 func NewInvalidInputErrorWithDetails(err error, externalMessage string) *UserError {
 	return newUserError(
 		errors.Wrapf(err, fmt.Sprintf("InvalidInputError: %v", externalMessage)),
@@ -199,6 +391,47 @@ func NewPermissionDeniedError(err error, externalFormat string, a ...interface{}
 		codes.PermissionDenied)
 }
 
+func NewResourceExhaustedError(externalFormat string, a ...interface{}) *UserError {
+	externalMessage := fmt.Sprintf(externalFormat, a...)
+	return newUserError(
+		errors.Errorf("ResourceExhausted: %v", externalMessage),
+		externalMessage,
+		codes.ResourceExhausted)
+}
+
+// NewRateLimitedError returns an error for a request rejected by a rate limit, which can be retried later.
+func NewRateLimitedError(externalFormat string, a ...interface{}) *UserError {
+	userError := NewResourceExhaustedError(externalFormat, a...)
+	userError.reason = ErrorReasonRateLimited
+	return userError
+}
+
+func NewFailedPreconditionError(externalFormat string, a ...interface{}) *UserError {
+	externalMessage := fmt.Sprintf(externalFormat, a...)
+	return newUserError(
+		errors.Errorf("FailedPrecondition: %v", externalMessage),
+		externalMessage,
+		codes.FailedPrecondition)
+}
+
+// NewConflictError returns an error for a request made against a stale version of a resource, which the client can
+// retry after reading the resource again.
+func NewConflictError(externalFormat string, a ...interface{}) *UserError {
+	externalMessage := fmt.Sprintf(externalFormat, a...)
+	return newUserError(
+		errors.Errorf("Conflict: %v", externalMessage),
+		externalMessage,
+		codes.Aborted)
+}
+
+func NewUnimplementedError(externalFormat string, a ...interface{}) *UserError {
+	externalMessage := fmt.Sprintf(externalFormat, a...)
+	return newUserError(
+		errors.Errorf("Unimplemented: %v", externalMessage),
+		externalMessage,
+		codes.Unimplemented)
+}
+
 func (e *UserError) ExternalMessage() string {
 	return e.externalMessage
 }
@@ -207,6 +440,16 @@ func (e *UserError) ExternalStatusCode() codes.Code {
 	return e.externalStatusCode
 }
 
+// Reason returns the reason of the ErrorInfo detail of the error.
+func (e *UserError) Reason() string {
+	return e.reason
+}
+
+// FieldViolations returns the invalid fields of the request.
+func (e *UserError) FieldViolations() []*errdetails.BadRequest_FieldViolation {
+	return e.fieldViolations
+}
+
 func (e *UserError) Error() string {
 	return e.internalError.Error()
 }
@@ -227,18 +470,32 @@ func (e *UserError) ErrorStringWithoutStackTrace() string {
 // GRPCStatus implements `GRPCStatus` to make sure `FromError` in grpc-go can honor the code.
 // Otherwise, it will always return codes.Unknown(2).
 // https://github.com/grpc/grpc-go/blob/2c0949c22d46095edc579d9e66edcd025192b98c/status/status.go#L91-L92
+// The status has an ErrorInfo detail with the reason of the error, and a BadRequest detail with the invalid fields
+// of the request, if any.
 func (e *UserError) GRPCStatus() *status.Status {
-	return status.New(e.externalStatusCode, e.ErrorStringWithoutStackTrace())
+	grpcStatus := status.New(e.externalStatusCode, e.ErrorStringWithoutStackTrace())
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: e.reason, Domain: ErrorDomain, Metadata: e.metadata}}
+	if len(e.fieldViolations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: e.fieldViolations})
+	}
+	withDetails, err := grpcStatus.WithDetails(details...)
+	if err != nil {
+		klog.Errorf("Failed to add the details of error %v: %v", e.externalMessage, err)
+		return grpcStatus
+	}
+	return withDetails
 }
 
 func (e *UserError) wrapf(format string, args ...interface{}) *UserError {
-	return newUserError(errors.Wrapf(e.internalError, format, args...),
-		e.externalMessage, e.externalStatusCode)
+	wrapped := *e
+	wrapped.internalError = errors.Wrapf(e.internalError, format, args...)
+	return &wrapped
 }
 
 func (e *UserError) wrap(message string) *UserError {
-	return newUserError(errors.Wrap(e.internalError, message),
-		e.externalMessage, e.externalStatusCode)
+	wrapped := *e
+	wrapped.internalError = errors.Wrap(e.internalError, message)
+	return &wrapped
 }
 
 func (e *UserError) Log() {
@@ -298,6 +555,27 @@ func IsNotFound(err error) bool {
 	return reasonForError(err) == k8metav1.StatusReasonNotFound
 }
 
+// ToGRPCError returns an error carrying a gRPC status with an ErrorInfo detail: a user error as is, and any other error
+// which is not a gRPC status already as an internal error.
+func ToGRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*UserError); ok {
+		return err
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return NewInternalServerError(err, "Unexpected error")
+}
+
+// IsUserErrorReasonMatch returns whether the error is a user error with the specified reason.
+func IsUserErrorReasonMatch(err error, reason string) bool {
+	userError, ok := err.(*UserError)
+	return ok && userError.reason == reason
+}
+
 // IsUserErrorCodeMatch returns whether the error is a user error with specified code.
 func IsUserErrorCodeMatch(err error, code codes.Code) bool {
 	userError, ok := err.(*UserError)
@@ -0,0 +1,164 @@
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// statusDetails returns the ErrorInfo and BadRequest details of the status of an error.
+func statusDetails(t *testing.T, err error) (*errdetails.ErrorInfo, *errdetails.BadRequest) {
+	grpcStatus, ok := status.FromError(err)
+	require.True(t, ok)
+	var info *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range grpcStatus.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	require.NotNil(t, info)
+	return info, badRequest
+}
+
+func TestUserErrorDetails(t *testing.T) {
+	err := Wrap(NewInvalidFieldError("cluster.name", "Cluster name is empty. Please specify a valid value."), "Validate request failed.")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	info, badRequest := statusDetails(t, err)
+	assert.Equal(t, ErrorReasonInvalidInput, info.Reason)
+	assert.Equal(t, ErrorDomain, info.Domain)
+	require.NotNil(t, badRequest)
+	require.Len(t, badRequest.FieldViolations, 1)
+	assert.Equal(t, "cluster.name", badRequest.FieldViolations[0].Field)
+	assert.Equal(t, "Cluster name is empty. Please specify a valid value.", badRequest.FieldViolations[0].Description)
+
+	info, badRequest = statusDetails(t, NewResourceExhaustedError("Quota exceeded"))
+	assert.Equal(t, ErrorReasonQuotaExceeded, info.Reason)
+	assert.Nil(t, badRequest)
+	info, _ = statusDetails(t, NewRateLimitedError("Rejected by the rate limit"))
+	assert.Equal(t, ErrorReasonRateLimited, info.Reason)
+	assert.True(t, IsUserErrorReasonMatch(NewNotFoundError(errors.New("missing"), "Cluster not found"), ErrorReasonNotFound))
+}
+
+func TestValidationErrors(t *testing.T) {
+	var errs ValidationErrors
+	require.NoError(t, errs.Err())
+	errs.Add(nil)
+	errs.AddField("cluster.cluster_spec", nil)
+	require.NoError(t, errs.Err())
+
+	// A single error is returned as is, with its fields nested in the field it is added to.
+	errs.AddField("cluster.cluster_spec", NewInvalidFieldError("head_group_spec.compute_template", "HeadGroupSpec compute template is empty."))
+	require.EqualError(t, errs.Err(), "Invalid input error: HeadGroupSpec compute template is empty.")
+	_, badRequest := statusDetails(t, errs.Err())
+	require.NotNil(t, badRequest)
+	require.Len(t, badRequest.FieldViolations, 1)
+	assert.Equal(t, "cluster.cluster_spec.head_group_spec.compute_template", badRequest.FieldViolations[0].Field)
+
+	errs.Add(NewInvalidFieldError("cluster.name", "Cluster name is empty. Please specify a valid value."))
+	errs.AddField("cluster.queueing", NewInvalidInputError("Queue name is empty"))
+	errs.Add(NewInvalidInputError("The request is invalid."))
+	err := errs.Err()
+	require.EqualError(t, err, "Invalid input error: 4 validations failed: HeadGroupSpec compute template is empty. "+
+		"Cluster name is empty. Please specify a valid value. Queue name is empty. The request is invalid.")
+	assert.True(t, IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	info, badRequest := statusDetails(t, err)
+	assert.Equal(t, ErrorReasonInvalidInput, info.Reason)
+	require.NotNil(t, badRequest)
+	var fields []string
+	for _, violation := range badRequest.FieldViolations {
+		fields = append(fields, violation.Field)
+	}
+	assert.Equal(t, []string{"cluster.cluster_spec.head_group_spec.compute_template", "cluster.name", "cluster.queueing"}, fields)
+	assert.Equal(t, "Queue name is empty", badRequest.FieldViolations[2].Description)
+
+	// An error which is not an invalid input error is not aggregated.
+	errs.Add(NewInternalServerError(errors.New("boom"), "Failed to list the compute templates"))
+	assert.True(t, IsUserErrorCodeMatch(errs.Err(), codes.Internal))
+}
+
+func TestNewInternalServerErrorFromKubernetesError(t *testing.T) {
+	resource := schema.GroupResource{Group: "ray.io", Resource: "rayclusters"}
+	tests := []struct {
+		name   string
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{
+			name:   "not found",
+			err:    k8errors.NewNotFound(resource, "cluster"),
+			code:   codes.NotFound,
+			reason: ErrorReasonNotFound,
+		},
+		{
+			name:   "conflict",
+			err:    k8errors.NewConflict(resource, "cluster", errors.New("the object has been modified")),
+			code:   codes.Aborted,
+			reason: ErrorReasonConflict,
+		},
+		{
+			name:   "resource quota exceeded",
+			err:    k8errors.NewForbidden(resource, "cluster", errors.New("exceeded quota: compute, requested: requests.cpu=4, used: requests.cpu=8, limited: requests.cpu=10")),
+			code:   codes.ResourceExhausted,
+			reason: ErrorReasonKubernetesQuotaExceeded,
+		},
+		{
+			name:   "forbidden by a webhook",
+			err:    k8errors.NewForbidden(resource, "cluster", errors.New("denied by the webhook")),
+			code:   codes.Internal,
+			reason: ErrorReasonInternal,
+		},
+		{
+			name:   "not a Kubernetes error",
+			err:    errors.New("connection refused"),
+			code:   codes.Internal,
+			reason: ErrorReasonInternal,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewInternalServerError(tc.err, "Failed to create a cluster")
+			assert.Equal(t, tc.code, status.Code(err))
+			info, _ := statusDetails(t, err)
+			assert.Equal(t, tc.reason, info.Reason)
+		})
+	}
+
+	invalid := k8errors.NewInvalid(schema.GroupKind{Group: "ray.io", Kind: "RayCluster"}, "cluster", field.ErrorList{
+		field.Invalid(field.NewPath("metadata", "name"), "Cluster", "must be lowercase"),
+	})
+	err := NewInternalServerError(invalid, "Failed to create a cluster")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	info, badRequest := statusDetails(t, err)
+	assert.Equal(t, ErrorReasonKubernetesInvalid, info.Reason)
+	assert.Equal(t, "Invalid", info.Metadata["kubernetesReason"])
+	require.NotNil(t, badRequest)
+	assert.Equal(t, "metadata.name", badRequest.FieldViolations[0].Field)
+}
+
+func TestToGRPCError(t *testing.T) {
+	assert.Nil(t, ToGRPCError(nil))
+
+	userError := NewFailedPreconditionError("Cluster is suspended")
+	assert.Equal(t, userError, ToGRPCError(userError))
+
+	grpcError := status.Error(codes.Unauthenticated, "no token")
+	assert.Equal(t, grpcError, ToGRPCError(grpcError))
+
+	err := ToGRPCError(errors.New("unexpected"))
+	assert.Equal(t, codes.Internal, status.Code(err))
+	info, _ := statusDetails(t, err)
+	assert.Equal(t, ErrorReasonInternal, info.Reason)
+}
@@ -0,0 +1,146 @@
+package util
+
+import (
+	"fmt"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// ExposeOptionsFromAnnotations returns the expose options of a RayService, nil if it is not exposed.
+func ExposeOptionsFromAnnotations(annotations map[string]string) (*api.ExposeOptions, error) {
+	value, ok := annotations[RayServiceExposeAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	expose := &api.ExposeOptions{}
+	if err := protojson.Unmarshal([]byte(value), expose); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the expose options: %w", err)
+	}
+	return expose, nil
+}
+
+// ServeExposeName returns the name of the Ingress or the Route exposing the serve service of a RayService.
+func ServeExposeName(serviceName string) string {
+	return serviceName + "-serve"
+}
+
+// ServeServiceName returns the name of the serve service the operator creates for a RayService.
+func ServeServiceName(service *rayv1api.RayService) string {
+	if service.Spec.ServeService != nil && service.Spec.ServeService.Name != "" {
+		return service.Spec.ServeService.Name
+	}
+	return utils.GenerateServeServiceName(service.Name)
+}
+
+// ExposeURL returns the URL the serve service of a RayService is exposed on.
+func ExposeURL(expose *api.ExposeOptions) string {
+	scheme := "http"
+	if expose.TlsSecret != "" {
+		scheme = "https"
+	}
+	return scheme + "://" + expose.Host + exposePath(expose)
+}
+
+func exposePath(expose *api.ExposeOptions) string {
+	if expose.Path == "" {
+		return "/"
+	}
+	return expose.Path
+}
+
+// exposeObjectMeta returns the metadata of the Ingress or the Route of a RayService, which is garbage collected with
+// the RayService.
+func exposeObjectMeta(service *rayv1api.RayService, expose *api.ExposeOptions) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        ServeExposeName(service.Name),
+		Namespace:   service.Namespace,
+		Labels:      map[string]string{KubernetesManagedByLabelKey: ComponentName},
+		Annotations: expose.Annotations,
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: rayv1api.GroupVersion.String(),
+			Kind:       "RayService",
+			Name:       service.Name,
+			UID:        service.UID,
+		}},
+	}
+}
+
+// NewServeIngress creates the Ingress exposing the serve service of a RayService.
+func NewServeIngress(service *rayv1api.RayService, expose *api.ExposeOptions) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: exposeObjectMeta(service, expose),
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{
+				Host: expose.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     exposePath(expose),
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: ServeServiceName(service),
+									Port: networkingv1.ServiceBackendPort{Name: utils.ServingPortName},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+	if expose.IngressClass != "" {
+		ingressClass := expose.IngressClass
+		ingress.Spec.IngressClassName = &ingressClass
+	}
+	if expose.TlsSecret != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{expose.Host}, SecretName: expose.TlsSecret}}
+	}
+	return ingress
+}
+
+// NewServeRoute creates the OpenShift Route exposing the serve service of a RayService. A Route can not reference a
+// Secret, so the certificate of the TLS Secret is copied into the Route.
+func NewServeRoute(service *rayv1api.RayService, expose *api.ExposeOptions, tlsSecret *corev1.Secret) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"host": expose.Host,
+		"path": exposePath(expose),
+		"to": map[string]interface{}{
+			"kind":   "Service",
+			"name":   ServeServiceName(service),
+			"weight": int64(100),
+		},
+		"port": map[string]interface{}{
+			"targetPort": utils.ServingPortName,
+		},
+	}
+	if tlsSecret != nil {
+		spec["tls"] = map[string]interface{}{
+			"termination":                   "edge",
+			"insecureEdgeTerminationPolicy": "Redirect",
+			"certificate":                   string(tlsSecret.Data[corev1.TLSCertKey]),
+			"key":                           string(tlsSecret.Data[corev1.TLSPrivateKeyKey]),
+		}
+	}
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"spec":       spec,
+	}}
+	meta := exposeObjectMeta(service, expose)
+	route.SetName(meta.Name)
+	route.SetNamespace(meta.Namespace)
+	route.SetLabels(meta.Labels)
+	route.SetAnnotations(meta.Annotations)
+	route.SetOwnerReferences(meta.OwnerReferences)
+	return route
+}
@@ -0,0 +1,121 @@
+package util
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// GitCredentialsTokenKey and GitCredentialsUsernameKey are the keys of the Secret with the credentials of a private
+// git repository. GitCredentialsDefaultUsername is the user name used with the token when the Secret has none,
+// which GitLab requires and GitHub and Bitbucket ignore.
+const (
+	GitCredentialsTokenKey        = "token"
+	GitCredentialsUsernameKey     = "username"
+	GitCredentialsDefaultUsername = "oauth2"
+)
+
+// runtimeEnvWorkingDirKey is the key of the working directory in a Ray runtime_env.
+const runtimeEnvWorkingDirKey = "working_dir"
+
+// GitArchiveURL returns the URL of the zip archive of a ref of a git repository, which Ray downloads as a remote
+// working_dir. The credentials are added to the URL when they are set.
+func GitArchiveURL(source *api.GitSource, credentials *url.Userinfo) (string, error) {
+	repository, err := url.Parse(strings.TrimSuffix(strings.TrimSuffix(source.Repository, "/"), ".git"))
+	if err != nil {
+		return "", fmt.Errorf("repository %s is not a valid URL: %w", source.Repository, err)
+	}
+	repository.User = credentials
+	switch {
+	case repository.Host == "gitlab.com" || strings.HasPrefix(repository.Host, "gitlab."):
+		name := path.Base(repository.Path)
+		repository = repository.JoinPath("-", "archive", source.Ref, name+"-"+strings.ReplaceAll(source.Ref, "/", "-")+".zip")
+	case repository.Host == "bitbucket.org":
+		repository = repository.JoinPath("get", source.Ref+".zip")
+	default:
+		repository = repository.JoinPath("archive", source.Ref+".zip")
+	}
+	return repository.String(), nil
+}
+
+// WithWorkingDir returns a runtime_env YAML with its working_dir set to the given URI.
+func WithWorkingDir(runtimeEnvYAML string, workingDir string) (string, error) {
+	runtimeEnv, err := parseRuntimeEnv(runtimeEnvYAML)
+	if err != nil {
+		return "", err
+	}
+	runtimeEnv[runtimeEnvWorkingDirKey] = workingDir
+	bytes, err := yaml.Marshal(runtimeEnv)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the runtime_env: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// WithoutWorkingDir returns a runtime_env YAML without its working_dir, empty if the working_dir was its only key.
+func WithoutWorkingDir(runtimeEnvYAML string) (string, error) {
+	runtimeEnv, err := parseRuntimeEnv(runtimeEnvYAML)
+	if err != nil {
+		return "", err
+	}
+	delete(runtimeEnv, runtimeEnvWorkingDirKey)
+	if len(runtimeEnv) == 0 {
+		return "", nil
+	}
+	bytes, err := yaml.Marshal(runtimeEnv)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the runtime_env: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// HasWorkingDir returns whether a runtime_env YAML sets a working_dir.
+func HasWorkingDir(runtimeEnvYAML string) (bool, error) {
+	runtimeEnv, err := parseRuntimeEnv(runtimeEnvYAML)
+	if err != nil {
+		return false, err
+	}
+	_, ok := runtimeEnv[runtimeEnvWorkingDirKey]
+	return ok, nil
+}
+
+func parseRuntimeEnv(runtimeEnvYAML string) (map[string]interface{}, error) {
+	runtimeEnv := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(runtimeEnvYAML), &runtimeEnv); err != nil {
+		return nil, fmt.Errorf("runtime_env is not a valid YAML object: %w", err)
+	}
+	if runtimeEnv == nil {
+		runtimeEnv = map[string]interface{}{}
+	}
+	return runtimeEnv, nil
+}
+
+// GitSourceFromAnnotations returns the git source of a RayJob from its annotations, nil if the job does not run from
+// a git repository.
+func GitSourceFromAnnotations(annotations map[string]string) *api.GitSource {
+	repository, ok := annotations[RayJobGitRepositoryAnnotationKey]
+	if !ok {
+		return nil
+	}
+	return &api.GitSource{
+		Repository:        repository,
+		Ref:               annotations[RayJobGitRefAnnotationKey],
+		CredentialsSecret: annotations[RayJobGitCredentialsSecretAnnotationKey],
+	}
+}
+
+func addGitSourceAnnotations(annotations map[string]string, source *api.GitSource) {
+	if source == nil {
+		return
+	}
+	annotations[RayJobGitRepositoryAnnotationKey] = source.Repository
+	annotations[RayJobGitRefAnnotationKey] = source.Ref
+	if source.CredentialsSecret != "" {
+		annotations[RayJobGitCredentialsSecretAnnotationKey] = source.CredentialsSecret
+	}
+}
@@ -17,6 +17,16 @@ type RayJob struct {
 
 // NewRayJob creates a RayJob.
 func NewRayJob(apiJob *api.RayJob, computeTemplateMap map[string]*api.ComputeTemplate) (*RayJob, error) {
+	entrypoint, err := RenderEntrypoint(apiJob)
+	if err != nil {
+		return nil, err
+	}
+	runtimeEnv := apiJob.RuntimeEnv
+	if apiJob.RuntimeEnvironment != nil {
+		if runtimeEnv, err = RenderRuntimeEnv(apiJob.RuntimeEnvironment); err != nil {
+			return nil, err
+		}
+	}
 	rayJob := &rayv1api.RayJob{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        apiJob.Name,
@@ -25,16 +35,33 @@ func NewRayJob(apiJob *api.RayJob, computeTemplateMap map[string]*api.ComputeTem
 			Annotations: buildRayJobAnnotations(apiJob),
 		},
 		Spec: rayv1api.RayJobSpec{
-			Entrypoint:               apiJob.Entrypoint,
+			Entrypoint:               entrypoint,
 			Metadata:                 apiJob.Metadata,
-			RuntimeEnvYAML:           apiJob.RuntimeEnv,
+			RuntimeEnvYAML:           runtimeEnv,
 			ShutdownAfterJobFinishes: apiJob.ShutdownAfterJobFinishes,
 			TTLSecondsAfterFinished:  apiJob.TtlSecondsAfterFinished,
 			JobId:                    apiJob.JobId,
 			RayClusterSpec:           nil,
 			ClusterSelector:          apiJob.ClusterSelector,
+			ClusterGenerateName:      apiJob.ClusterGenerateName,
 		},
 	}
+	if apiJob.GitSource != nil {
+		// The credentials of a private repository are added by the resource manager, which reads their Secret.
+		workingDir, err := GitArchiveURL(apiJob.GitSource, nil)
+		if err != nil {
+			return nil, err
+		}
+		if rayJob.Spec.RuntimeEnvYAML, err = WithWorkingDir(runtimeEnv, workingDir); err != nil {
+			return nil, err
+		}
+	}
+	if isQueuedByKueue(apiJob.Queueing) {
+		rayJob.Spec.Suspend = true
+	}
+	if apiJob.BackoffLimit > 0 {
+		rayJob.Spec.BackoffLimit = &apiJob.BackoffLimit
+	}
 	if apiJob.ClusterSpec != nil {
 		clusterSpec, err := buildRayClusterSpec(apiJob.Version, nil, apiJob.ClusterSpec, computeTemplateMap, false)
 		if err != nil {
@@ -110,9 +137,21 @@ func buildRayJobLabels(job *api.RayJob) map[string]string {
 	labels[RayClusterUserLabelKey] = job.User
 	labels[KubernetesApplicationNameLabelKey] = ApplicationName
 	labels[KubernetesManagedByLabelKey] = ComponentName
+	addQueueingLabels(labels, job.Queueing)
 	return labels
 }
 
 func buildRayJobAnnotations(job *api.RayJob) map[string]string {
-	return job.Metadata
+	// The operator copies the annotations of the RayJob to its RayCluster.
+	annotations := buildGcsFaultToleranceAnnotations(job.Metadata, job.ClusterSpec)
+	annotations = withTTLAnnotation(annotations, RayJobTTLAnnotationKey, job.JobTtlSecondsAfterFinished)
+	if job.GitSource == nil {
+		return annotations
+	}
+	result := make(map[string]string, len(annotations)+3)
+	for key, value := range annotations {
+		result[key] = value
+	}
+	addGitSourceAnnotations(result, job.GitSource)
+	return result
 }
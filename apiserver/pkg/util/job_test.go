@@ -5,6 +5,7 @@ import (
 
 	api "github.com/ray-project/kuberay/proto/go_client"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 var apiJobNewCluster = &api.RayJob{
@@ -86,6 +87,35 @@ func TestBuildRayJob(t *testing.T) {
 	assert.Equal(t, 1, len(job.Spec.Metadata))
 	assert.Nil(t, job.Spec.ClusterSelector)
 	assert.NotNil(t, job.Spec.RayClusterSpec)
+	assert.Nil(t, job.Spec.BackoffLimit)
+
+	// Test request with retries
+	retriedJob := proto.Clone(apiJobNewCluster).(*api.RayJob)
+	retriedJob.BackoffLimit = 3
+	job, err = NewRayJob(retriedJob, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), *job.Spec.BackoffLimit)
+
+	// Test request queued by Kueue
+	queuedJob := proto.Clone(apiJobNewCluster).(*api.RayJob)
+	queuedJob.Queueing = &api.QueueingOptions{BatchScheduler: api.QueueingOptions_KUEUE, QueueName: "team-a", PriorityClassName: "high"}
+	job, err = NewRayJob(queuedJob, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	assert.True(t, job.Spec.Suspend)
+	assert.Equal(t, "team-a", job.ObjectMeta.Labels[KueueQueueNameLabelKey])
+	assert.Equal(t, "high", job.ObjectMeta.Labels[KueuePriorityClassLabelKey])
+
+	// Test request with a structured runtime environment and entrypoint params
+	parameterizedJob := proto.Clone(apiJobNewCluster).(*api.RayJob)
+	parameterizedJob.RuntimeEnv = ""
+	parameterizedJob.RuntimeEnvironment = &api.RuntimeEnvironment{Pip: []string{"requests==2.26.0"}}
+	parameterizedJob.Entrypoint = "python train.py --epochs {{epochs}}"
+	parameterizedJob.EntrypointParams = map[string]string{"epochs": "5"}
+	parameterizedJob.EntrypointArgs = []string{"--run={{job_name}}"}
+	job, err = NewRayJob(parameterizedJob, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	assert.Equal(t, "pip:\n- requests==2.26.0\n", job.Spec.RuntimeEnvYAML)
+	assert.Equal(t, "python train.py --epochs 5 --run=test", job.Spec.Entrypoint)
 
 	// Test request without cluster creation
 	job, err = NewRayJob(apiJobExistingCluster, map[string]*api.ComputeTemplate{"foo": &template})
@@ -0,0 +1,14 @@
+package util
+
+import utilrand "k8s.io/apimachinery/pkg/util/rand"
+
+// GeneratedNameSuffixLength is the number of random characters appended to the generate_name prefix of a resource,
+// as many as Kubernetes appends to metadata.generateName.
+const GeneratedNameSuffixLength = 5
+
+// GenerateName returns a name for a resource created with a generate_name prefix. Unlike metadata.generateName, the
+// name is generated by the API server before the resource is created, so that the names of the resources derived
+// from it are known, and validated, upfront.
+func GenerateName(prefix string) string {
+	return prefix + utilrand.String(GeneratedNameSuffixLength)
+}
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"strings"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Notification subscriptions are stored in ConfigMaps, like compute templates and cron jobs.
+const (
+	NotificationSubscriptionConfigType = "notification-subscription"
+
+	// The keys of the ConfigMap data.
+	NotificationSubscriptionURLKey    = "url"
+	NotificationSubscriptionEventsKey = "events"
+)
+
+// NewNotificationSubscription creates the ConfigMap storing a notification subscription. The events are stored
+// by name, comma separated.
+func NewNotificationSubscription(apiSubscription *api.NotificationSubscription) *corev1.ConfigMap {
+	events := make([]string, 0, len(apiSubscription.Events))
+	for _, event := range apiSubscription.Events {
+		events = append(events, event.String())
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiSubscription.Name,
+			Namespace: apiSubscription.Namespace,
+			Labels: map[string]string{
+				"ray.io/config-type":              NotificationSubscriptionConfigType,
+				RayClusterUserLabelKey:            apiSubscription.User,
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesManagedByLabelKey:       ComponentName,
+			},
+		},
+		Data: map[string]string{
+			NotificationSubscriptionURLKey:    apiSubscription.Url,
+			NotificationSubscriptionEventsKey: strings.Join(events, ","),
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// addQueueingLabels adds the labels submitting a RayCluster or a RayJob to the queue of a batch scheduler. The
+// operator copies the labels of a RayJob to its RayCluster and the labels of a RayCluster to its Pods.
+func addQueueingLabels(labels map[string]string, queueing *api.QueueingOptions) {
+	if queueing == nil {
+		return
+	}
+	for key, value := range queueing.GatingLabels {
+		labels[key] = value
+	}
+	switch queueing.BatchScheduler {
+	case api.QueueingOptions_KUEUE:
+		labels[KueueQueueNameLabelKey] = queueing.QueueName
+		if queueing.PriorityClassName != "" {
+			labels[KueuePriorityClassLabelKey] = queueing.PriorityClassName
+		}
+	case api.QueueingOptions_VOLCANO:
+		labels[RaySchedulerNameLabelKey] = VolcanoSchedulerName
+		labels[VolcanoQueueNameLabelKey] = queueing.QueueName
+		if queueing.PriorityClassName != "" {
+			labels[RayPriorityClassNameLabelKey] = queueing.PriorityClassName
+		}
+	case api.QueueingOptions_YUNIKORN:
+		labels[RaySchedulerNameLabelKey] = YuniKornSchedulerName
+		labels[YuniKornQueueLabelKey] = queueing.QueueName
+	}
+}
+
+// isQueuedByKueue returns whether Kueue admits the workload, which is then created suspended until Kueue unsuspends
+// it once its queue has quota.
+func isQueuedByKueue(queueing *api.QueueingOptions) bool {
+	return queueing.GetBatchScheduler() == api.QueueingOptions_KUEUE
+}
+
+// QueueingFromLabels returns the queueing options of a RayCluster or a RayJob from its labels, nil if it is not
+// submitted to a batch scheduler. The gating labels can not be told apart from the other labels and are not
+// returned.
+func QueueingFromLabels(labels map[string]string) *api.QueueingOptions {
+	if queueName, ok := labels[KueueQueueNameLabelKey]; ok {
+		return &api.QueueingOptions{
+			BatchScheduler:    api.QueueingOptions_KUEUE,
+			QueueName:         queueName,
+			PriorityClassName: labels[KueuePriorityClassLabelKey],
+		}
+	}
+	switch labels[RaySchedulerNameLabelKey] {
+	case VolcanoSchedulerName:
+		return &api.QueueingOptions{
+			BatchScheduler:    api.QueueingOptions_VOLCANO,
+			QueueName:         labels[VolcanoQueueNameLabelKey],
+			PriorityClassName: labels[RayPriorityClassNameLabelKey],
+		}
+	case YuniKornSchedulerName:
+		return &api.QueueingOptions{
+			BatchScheduler: api.QueueingOptions_YUNIKORN,
+			QueueName:      labels[YuniKornQueueLabelKey],
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"testing"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestQueueingLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		queueing *api.QueueingOptions
+		expected map[string]string
+	}{
+		{
+			name:     "Kueue",
+			queueing: &api.QueueingOptions{BatchScheduler: api.QueueingOptions_KUEUE, QueueName: "team-a", PriorityClassName: "high"},
+			expected: map[string]string{KueueQueueNameLabelKey: "team-a", KueuePriorityClassLabelKey: "high"},
+		},
+		{
+			name:     "Volcano",
+			queueing: &api.QueueingOptions{BatchScheduler: api.QueueingOptions_VOLCANO, QueueName: "team-a", PriorityClassName: "high"},
+			expected: map[string]string{RaySchedulerNameLabelKey: VolcanoSchedulerName, VolcanoQueueNameLabelKey: "team-a", RayPriorityClassNameLabelKey: "high"},
+		},
+		{
+			name:     "YuniKorn",
+			queueing: &api.QueueingOptions{BatchScheduler: api.QueueingOptions_YUNIKORN, QueueName: "root.team-a"},
+			expected: map[string]string{RaySchedulerNameLabelKey: YuniKornSchedulerName, YuniKornQueueLabelKey: "root.team-a"},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc // capture range variable
+		t.Run(tc.name, func(t *testing.T) {
+			labels := map[string]string{}
+			addQueueingLabels(labels, tc.queueing)
+			assert.Equal(t, tc.expected, labels)
+			assert.True(t, proto.Equal(tc.queueing, QueueingFromLabels(labels)))
+		})
+	}
+	assert.Nil(t, QueueingFromLabels(map[string]string{RayClusterUserLabelKey: "user"}))
+
+	cluster, err := NewRayCluster(&api.Cluster{
+		Name:        "cluster",
+		Namespace:   "namespace",
+		ClusterSpec: &api.ClusterSpec{HeadGroupSpec: &headGroup},
+		Queueing:    &api.QueueingOptions{BatchScheduler: api.QueueingOptions_KUEUE, QueueName: "team-a", GatingLabels: map[string]string{"example.com/admission-check": "gpu"}},
+	}, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.Nil(t, err)
+	assert.True(t, *cluster.Spec.Suspend)
+	assert.Equal(t, "team-a", cluster.Labels[KueueQueueNameLabelKey])
+	assert.Equal(t, "gpu", cluster.Labels["example.com/admission-check"])
+}
@@ -0,0 +1,243 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+)
+
+// The placeholders of the entrypoints which are always defined.
+const (
+	EntrypointJobNameParam   = "job_name"
+	EntrypointNamespaceParam = "namespace"
+)
+
+var (
+	// entrypointPlaceholderPattern matches the {{name}} placeholders of an entrypoint.
+	entrypointPlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	// EntrypointParamNamePattern matches the valid names of the entrypoint params.
+	EntrypointParamNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// shellSafePattern matches the words which need no quoting in a shell.
+	shellSafePattern = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+	// envVarNamePattern matches the valid names of environment variables.
+	envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// RenderEntrypoint returns the entrypoint of a job with its placeholders substituted and its entrypoint_args
+// appended, each value being quoted for the shell. The entrypoint of a job without entrypoint_params nor
+// entrypoint_args is returned as is, so that the braces of the existing entrypoints keep their meaning.
+func RenderEntrypoint(apiJob *api.RayJob) (string, error) {
+	if len(apiJob.EntrypointParams) == 0 && len(apiJob.EntrypointArgs) == 0 {
+		return apiJob.Entrypoint, nil
+	}
+	params := map[string]string{
+		EntrypointJobNameParam:   apiJob.Name,
+		EntrypointNamespaceParam: apiJob.Namespace,
+	}
+	for name, value := range apiJob.EntrypointParams {
+		params[name] = value
+	}
+	entrypoint, err := renderPlaceholders(apiJob.Entrypoint, params)
+	if err != nil {
+		return "", err
+	}
+	words := []string{entrypoint}
+	for _, arg := range apiJob.EntrypointArgs {
+		rendered, err := renderPlaceholders(arg, params)
+		if err != nil {
+			return "", err
+		}
+		words = append(words, shellQuote(rendered))
+	}
+	return strings.Join(words, " "), nil
+}
+
+// renderPlaceholders substitutes the placeholders of a string with their quoted values.
+func renderPlaceholders(value string, params map[string]string) (string, error) {
+	var missing []string
+	rendered := entrypointPlaceholderPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		name := entrypointPlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		param, ok := params[name]
+		if !ok {
+			missing = append(missing, name)
+			return placeholder
+		}
+		return shellQuote(param)
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("the entrypoint params %s are not set", strings.Join(missing, ", "))
+	}
+	return rendered, nil
+}
+
+// shellQuote quotes a word for a POSIX shell, unless it only has characters which need no quoting.
+func shellQuote(word string) string {
+	if shellSafePattern.MatchString(word) {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'"'"'`) + "'"
+}
+
+// RenderRuntimeEnv returns the runtime_env YAML of a structured runtime environment, empty if it sets nothing.
+func RenderRuntimeEnv(environment *api.RuntimeEnvironment) (string, error) {
+	runtimeEnv := map[string]interface{}{}
+	if len(environment.GetPip()) > 0 {
+		runtimeEnv["pip"] = environment.Pip
+	}
+	if conda := environment.GetConda(); conda != "" {
+		// A conda environment.yml is an object, a single line is the name of an existing environment.
+		var condaEnvironment map[string]interface{}
+		if strings.Contains(conda, "\n") {
+			if err := yaml.Unmarshal([]byte(conda), &condaEnvironment); err != nil {
+				return "", fmt.Errorf("conda is not a valid environment.yml: %w", err)
+			}
+		}
+		if condaEnvironment != nil {
+			runtimeEnv["conda"] = condaEnvironment
+		} else {
+			runtimeEnv["conda"] = conda
+		}
+	}
+	if workingDir := environment.GetWorkingDir(); workingDir != "" {
+		runtimeEnv[runtimeEnvWorkingDirKey] = workingDir
+	}
+	if len(environment.GetEnvVars()) > 0 {
+		runtimeEnv["env_vars"] = environment.EnvVars
+	}
+	if len(environment.GetPyModules()) > 0 {
+		runtimeEnv["py_modules"] = environment.PyModules
+	}
+	if len(runtimeEnv) == 0 {
+		return "", nil
+	}
+	bytes, err := yaml.Marshal(runtimeEnv)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the runtime_env: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// ValidateRuntimeEnv checks that a runtime_env YAML is an object whose known fields have the types Ray expects, so
+// that the mistakes are reported when the job is created rather than when Ray starts it. The fields unknown to the
+// API server, e.g. the ones of the runtime env plugins, are not checked.
+func ValidateRuntimeEnv(runtimeEnvYAML string) error {
+	runtimeEnv, err := parseRuntimeEnv(runtimeEnvYAML)
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{runtimeEnvWorkingDirKey, "py_executable", "image_uri"} {
+		if value, ok := runtimeEnv[key]; ok {
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("the %s of the runtime_env must be a string", key)
+			}
+		}
+	}
+	for _, key := range []string{"py_modules", "excludes", "java_jars"} {
+		if value, ok := runtimeEnv[key]; ok && !isStringList(value) {
+			return fmt.Errorf("the %s of the runtime_env must be a list of strings", key)
+		}
+	}
+	for _, key := range []string{"pip", "uv"} {
+		if value, ok := runtimeEnv[key]; ok {
+			if err := validatePackages(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	if value, ok := runtimeEnv["conda"]; ok {
+		switch value.(type) {
+		case string, map[string]interface{}:
+		default:
+			return fmt.Errorf("the conda of the runtime_env must be the name of an environment or an environment.yml object")
+		}
+	}
+	var packageManagers []string
+	for _, key := range []string{"conda", "pip", "uv"} {
+		if _, ok := runtimeEnv[key]; ok {
+			packageManagers = append(packageManagers, key)
+		}
+	}
+	if len(packageManagers) > 1 {
+		return fmt.Errorf("the %s of the runtime_env are mutually exclusive", strings.Join(packageManagers, " and "))
+	}
+	if value, ok := runtimeEnv["env_vars"]; ok {
+		envVars, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("the env_vars of the runtime_env must be an object")
+		}
+		names := make([]string, 0, len(envVars))
+		for name := range envVars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if !envVarNamePattern.MatchString(name) {
+				return fmt.Errorf("the env var %q of the runtime_env is not a valid name", name)
+			}
+			if _, ok := envVars[name].(string); !ok {
+				// Ray rejects the numbers and booleans, which YAML does not quote.
+				return fmt.Errorf("the value of the env var %s of the runtime_env must be a string, quote it", name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateRuntimeEnvironment checks a structured runtime environment.
+func ValidateRuntimeEnvironment(environment *api.RuntimeEnvironment) error {
+	if environment == nil {
+		return nil
+	}
+	if len(environment.Pip) > 0 && environment.Conda != "" {
+		return fmt.Errorf("the pip and conda of the runtime environment are mutually exclusive")
+	}
+	for name := range environment.EnvVars {
+		if !envVarNamePattern.MatchString(name) {
+			return fmt.Errorf("the env var %q of the runtime environment is not a valid name", name)
+		}
+	}
+	for _, value := range append(append([]string{}, environment.Pip...), environment.PyModules...) {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("the pip packages and py_modules of the runtime environment can not be empty")
+		}
+	}
+	_, err := RenderRuntimeEnv(environment)
+	return err
+}
+
+// validatePackages checks the pip or uv field of a runtime_env, a list of packages, the path of a requirements
+// file, or an object with the list of packages.
+func validatePackages(key string, value interface{}) error {
+	switch packages := value.(type) {
+	case string:
+		return nil
+	case map[string]interface{}:
+		if !isStringList(packages["packages"]) {
+			return fmt.Errorf("the packages of the %s of the runtime_env must be a list of strings", key)
+		}
+		return nil
+	default:
+		if !isStringList(value) {
+			return fmt.Errorf("the %s of the runtime_env must be a list of packages, the path of a requirements file or an object with the packages", key)
+		}
+		return nil
+	}
+}
+
+func isStringList(value interface{}) bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
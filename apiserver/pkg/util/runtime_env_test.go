@@ -0,0 +1,115 @@
+package util
+
+import (
+	"testing"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderEntrypoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      *api.RayJob
+		expected string
+	}{
+		{
+			name:     "An entrypoint without params is not rendered",
+			job:      &api.RayJob{Name: "job", Entrypoint: `python -c "print('{{x}}')"`},
+			expected: `python -c "print('{{x}}')"`,
+		},
+		{
+			name: "Params and args are quoted",
+			job: &api.RayJob{
+				Name:             "train",
+				Namespace:        "team-a",
+				Entrypoint:       "python train.py --epochs {{ epochs }} --output s3://bucket/{{namespace}}/{{job_name}}",
+				EntrypointParams: map[string]string{"epochs": "10"},
+				EntrypointArgs:   []string{"--message=it's {{job_name}}", "--dry-run"},
+			},
+			expected: `python train.py --epochs 10 --output s3://bucket/team-a/train '--message=it'"'"'s train' --dry-run`,
+		},
+		{
+			name: "A param with spaces can not inject commands",
+			job: &api.RayJob{
+				Name:             "job",
+				Entrypoint:       "python main.py --name {{name}}",
+				EntrypointParams: map[string]string{"name": "a; rm -rf /"},
+			},
+			expected: `python main.py --name 'a; rm -rf /'`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			entrypoint, err := RenderEntrypoint(tc.job)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, entrypoint)
+		})
+	}
+
+	_, err := RenderEntrypoint(&api.RayJob{Entrypoint: "python {{script}}", EntrypointArgs: []string{"{{x}}"}})
+	require.EqualError(t, err, "the entrypoint params script are not set")
+}
+
+func TestRenderRuntimeEnv(t *testing.T) {
+	runtimeEnv, err := RenderRuntimeEnv(&api.RuntimeEnvironment{
+		Pip:        []string{"requests==2.31.0"},
+		WorkingDir: "https://example.com/code.zip",
+		EnvVars:    map[string]string{"DEBUG": "1"},
+		PyModules:  []string{"https://example.com/module.whl"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `env_vars:
+  DEBUG: "1"
+pip:
+- requests==2.31.0
+py_modules:
+- https://example.com/module.whl
+working_dir: https://example.com/code.zip
+`, runtimeEnv)
+	require.NoError(t, ValidateRuntimeEnv(runtimeEnv))
+
+	runtimeEnv, err = RenderRuntimeEnv(&api.RuntimeEnvironment{Conda: "dependencies:\n- pip:\n  - requests\n"})
+	require.NoError(t, err)
+	assert.Equal(t, "conda:\n  dependencies:\n  - pip:\n    - requests\n", runtimeEnv)
+
+	runtimeEnv, err = RenderRuntimeEnv(&api.RuntimeEnvironment{Conda: "my-env"})
+	require.NoError(t, err)
+	assert.Equal(t, "conda: my-env\n", runtimeEnv)
+
+	runtimeEnv, err = RenderRuntimeEnv(&api.RuntimeEnvironment{})
+	require.NoError(t, err)
+	assert.Empty(t, runtimeEnv)
+}
+
+func TestValidateRuntimeEnv(t *testing.T) {
+	tests := []struct {
+		name          string
+		runtimeEnv    string
+		expectedError string
+	}{
+		{name: "Empty", runtimeEnv: ""},
+		{name: "Pip packages object", runtimeEnv: "pip:\n  packages: [requests]\n  pip_check: false\n"},
+		{name: "Pip requirements file", runtimeEnv: "pip: requirements.txt"},
+		{name: "Unknown plugin field", runtimeEnv: "my_plugin: {a: 1}"},
+		{name: "Not an object", runtimeEnv: "- a", expectedError: "runtime_env is not a valid YAML object"},
+		{name: "Working dir list", runtimeEnv: "working_dir: [a]", expectedError: "the working_dir of the runtime_env must be a string"},
+		{name: "Py modules string", runtimeEnv: "py_modules: a.zip", expectedError: "the py_modules of the runtime_env must be a list of strings"},
+		{name: "Pip numbers", runtimeEnv: "pip: [1]", expectedError: "the pip of the runtime_env must be a list of packages"},
+		{name: "Pip and uv", runtimeEnv: "pip: [a]\nuv: [b]", expectedError: "the pip and uv of the runtime_env are mutually exclusive"},
+		{name: "Env vars list", runtimeEnv: "env_vars: [a]", expectedError: "the env_vars of the runtime_env must be an object"},
+		{name: "Env var boolean", runtimeEnv: "env_vars: {DEBUG: true}", expectedError: "the value of the env var DEBUG of the runtime_env must be a string"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRuntimeEnv(tc.runtimeEnv)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// injectedSecretVolumePrefix prefixes the names of the volumes of the mounted Secrets, which are numbered after the
+// secret injections of the cluster.
+const injectedSecretVolumePrefix = "injected-secret-"
+
+// InjectsSecret returns whether a Secret is injected into a group, headgroup being the head group.
+func InjectsSecret(injection *api.SecretInjection, groupName string) bool {
+	return len(injection.Groups) == 0 || slices.Contains(injection.Groups, groupName)
+}
+
+// InjectedSecretVolumeName returns the name of the volume of the Secret of a secret injection of a cluster.
+func InjectedSecretVolumeName(index int) string {
+	return fmt.Sprintf("%s%d", injectedSecretVolumePrefix, index)
+}
+
+// addSecretInjections injects the Secrets of a cluster into the Ray container of a group. The secret injections are
+// kept in the annotations of the pod template, so that the environment variables and the volumes they add are not
+// returned as the ones of the group.
+func addSecretInjections(template *corev1.PodTemplateSpec, containerName string, groupName string, injections []*api.SecretInjection) error {
+	if len(injections) == 0 {
+		return nil
+	}
+	container, containerIndex, ok := GetContainerByName(template.Spec.Containers, containerName)
+	if !ok {
+		return nil
+	}
+	for index, injection := range injections {
+		if !InjectsSecret(injection, groupName) {
+			continue
+		}
+		reference := corev1.LocalObjectReference{Name: injection.SecretName}
+		names := make([]string, 0, len(injection.Env))
+		for name := range injection.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name: name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: reference, Key: injection.Env[name]},
+				},
+			})
+		}
+		if injection.MountPath != "" {
+			volumeName := InjectedSecretVolumeName(index)
+			template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+				Name:         volumeName,
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: injection.SecretName}},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: injection.MountPath, ReadOnly: true})
+		}
+		if len(injection.Env) == 0 && injection.MountPath == "" {
+			container.EnvFrom = append(container.EnvFrom, corev1.EnvFromSource{Prefix: injection.EnvPrefix, SecretRef: &corev1.SecretEnvSource{LocalObjectReference: reference}})
+		}
+	}
+	template.Spec.Containers[containerIndex] = container
+
+	values := make([]json.RawMessage, 0, len(injections))
+	for _, injection := range injections {
+		value, err := protojson.Marshal(injection)
+		if err != nil {
+			return fmt.Errorf("failed to marshal the injection of Secret %s: %w", injection.SecretName, err)
+		}
+		values = append(values, value)
+	}
+	value, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the secret injections: %w", err)
+	}
+	template.Annotations[RaySecretInjectionsAnnotationKey] = string(value)
+	return nil
+}
+
+// SecretInjectionsFromAnnotations returns the secret injections of the cluster of a group from the annotations of its
+// pod template.
+func SecretInjectionsFromAnnotations(annotations map[string]string) ([]*api.SecretInjection, error) {
+	value, ok := annotations[RaySecretInjectionsAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var values []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the secret injections: %w", err)
+	}
+	injections := make([]*api.SecretInjection, 0, len(values))
+	for _, value := range values {
+		injection := &api.SecretInjection{}
+		if err := protojson.Unmarshal(value, injection); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the secret injections: %w", err)
+		}
+		injections = append(injections, injection)
+	}
+	return injections, nil
+}
+
+// InjectedSecretEnv returns the names of the environment variables and of the env from Secrets which the secret
+// injections add to the Ray container of a group.
+func InjectedSecretEnv(injections []*api.SecretInjection, groupName string) (envNames []string, envFromSecrets []string) {
+	for _, injection := range injections {
+		if !InjectsSecret(injection, groupName) {
+			continue
+		}
+		for name := range injection.Env {
+			envNames = append(envNames, name)
+		}
+		if len(injection.Env) == 0 && injection.MountPath == "" {
+			envFromSecrets = append(envFromSecrets, injection.SecretName)
+		}
+	}
+	return envNames, envFromSecrets
+}
+
+// IsInjectedSecretVolume returns whether a volume of a pod template is the volume of a Secret mounted by a secret
+// injection.
+func IsInjectedSecretVolume(volume *corev1.Volume, injections []*api.SecretInjection) bool {
+	for index, injection := range injections {
+		if volume.Name == InjectedSecretVolumeName(index) && volume.Secret != nil && volume.Secret.SecretName == injection.SecretName {
+			return true
+		}
+	}
+	return false
+}
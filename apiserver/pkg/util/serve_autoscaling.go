@@ -0,0 +1,144 @@
+package util
+
+import (
+	"fmt"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"sigs.k8s.io/yaml"
+)
+
+// The target of the autoscaler is named target_ongoing_requests since Ray 2.32. The former name is still accepted,
+// so it is used unless the serve config already uses the new one.
+const (
+	serveTargetOngoingRequestsKey              = "target_ongoing_requests"
+	serveTargetNumOngoingRequestsPerReplicaKey = "target_num_ongoing_requests_per_replica"
+)
+
+// ApplyServeDeploymentAutoscaling renders the replicas and the autoscaling of the deployments into the num_replicas
+// and the autoscaling_config of a serve config. The serve config is returned as is when no deployment is given, so
+// that its formatting and comments are kept.
+func ApplyServeDeploymentAutoscaling(serveConfigV2 string, deployments []*api.ServeDeploymentAutoscaling) (string, error) {
+	if len(deployments) == 0 {
+		return serveConfigV2, nil
+	}
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &config); err != nil {
+		return "", fmt.Errorf("failed to parse the serve config: %w", err)
+	}
+	applications, _ := config["applications"].([]interface{})
+	for _, autoscaling := range deployments {
+		application := findServeConfigEntry(applications, autoscaling.Application)
+		if application == nil {
+			return "", fmt.Errorf("application %s of deployment %s is not in the serve config", autoscaling.Application, autoscaling.Deployment)
+		}
+		applicationDeployments, _ := application["deployments"].([]interface{})
+		deployment := findServeConfigEntry(applicationDeployments, autoscaling.Deployment)
+		if deployment == nil {
+			// The deployments of the serve config override the options of the deployments of the application code.
+			deployment = map[string]interface{}{"name": autoscaling.Deployment}
+			application["deployments"] = append(applicationDeployments, deployment)
+		}
+		setServeDeploymentAutoscaling(deployment, autoscaling)
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the serve config: %w", err)
+	}
+	return string(data), nil
+}
+
+func findServeConfigEntry(entries []interface{}, name string) map[string]interface{} {
+	for _, entry := range entries {
+		if entry, ok := entry.(map[string]interface{}); ok && entry["name"] == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+// setServeDeploymentAutoscaling sets the fixed replicas or the autoscaling of a deployment, which Ray does not
+// accept together. The autoscaling fields which are not set keep the values of the serve config.
+func setServeDeploymentAutoscaling(deployment map[string]interface{}, autoscaling *api.ServeDeploymentAutoscaling) {
+	if autoscaling.NumReplicas > 0 {
+		deployment["num_replicas"] = autoscaling.NumReplicas
+		delete(deployment, "autoscaling_config")
+		return
+	}
+	delete(deployment, "num_replicas")
+	config, _ := deployment["autoscaling_config"].(map[string]interface{})
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	if autoscaling.MinReplicas > 0 || autoscaling.ScaleToZero {
+		config["min_replicas"] = autoscaling.MinReplicas
+	}
+	if autoscaling.MaxReplicas > 0 {
+		config["max_replicas"] = autoscaling.MaxReplicas
+	}
+	if autoscaling.TargetNumOngoingRequests > 0 {
+		key := serveTargetNumOngoingRequestsPerReplicaKey
+		if _, ok := config[serveTargetOngoingRequestsKey]; ok {
+			key = serveTargetOngoingRequestsKey
+		}
+		config[key] = autoscaling.TargetNumOngoingRequests
+	}
+	if autoscaling.UpscaleDelaySeconds > 0 {
+		config["upscale_delay_s"] = autoscaling.UpscaleDelaySeconds
+	}
+	if autoscaling.DownscaleDelaySeconds > 0 {
+		config["downscale_delay_s"] = autoscaling.DownscaleDelaySeconds
+	}
+	deployment["autoscaling_config"] = config
+}
+
+// ServeDeploymentAutoscalingFromConfig returns the replicas and the autoscaling of the deployments of a serve config
+// which set them, in the order of the serve config. It returns nil if the serve config can not be parsed.
+func ServeDeploymentAutoscalingFromConfig(serveConfigV2 string) []*api.ServeDeploymentAutoscaling {
+	config := struct {
+		Applications []struct {
+			Name        string `json:"name"`
+			Deployments []struct {
+				Name string `json:"name"`
+				// num_replicas is auto to autoscale with the defaults of Ray.
+				NumReplicas       interface{} `json:"num_replicas"`
+				AutoscalingConfig *struct {
+					MinReplicas                        *int32  `json:"min_replicas"`
+					MaxReplicas                        int32   `json:"max_replicas"`
+					TargetOngoingRequests              float64 `json:"target_ongoing_requests"`
+					TargetNumOngoingRequestsPerReplica float64 `json:"target_num_ongoing_requests_per_replica"`
+					UpscaleDelaySeconds                float64 `json:"upscale_delay_s"`
+					DownscaleDelaySeconds              float64 `json:"downscale_delay_s"`
+				} `json:"autoscaling_config"`
+			} `json:"deployments"`
+		} `json:"applications"`
+	}{}
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &config); err != nil {
+		return nil
+	}
+
+	var result []*api.ServeDeploymentAutoscaling
+	for _, application := range config.Applications {
+		for _, deployment := range application.Deployments {
+			autoscaling := &api.ServeDeploymentAutoscaling{Application: application.Name, Deployment: deployment.Name}
+			if numReplicas, ok := deployment.NumReplicas.(float64); ok && numReplicas > 0 {
+				autoscaling.NumReplicas = int32(numReplicas)
+			} else if autoscalingConfig := deployment.AutoscalingConfig; autoscalingConfig != nil {
+				if autoscalingConfig.MinReplicas != nil {
+					autoscaling.MinReplicas = *autoscalingConfig.MinReplicas
+					autoscaling.ScaleToZero = *autoscalingConfig.MinReplicas == 0
+				}
+				autoscaling.MaxReplicas = autoscalingConfig.MaxReplicas
+				autoscaling.TargetNumOngoingRequests = autoscalingConfig.TargetOngoingRequests
+				if autoscaling.TargetNumOngoingRequests == 0 {
+					autoscaling.TargetNumOngoingRequests = autoscalingConfig.TargetNumOngoingRequestsPerReplica
+				}
+				autoscaling.UpscaleDelaySeconds = autoscalingConfig.UpscaleDelaySeconds
+				autoscaling.DownscaleDelaySeconds = autoscalingConfig.DownscaleDelaySeconds
+			} else {
+				continue
+			}
+			result = append(result, autoscaling)
+		}
+	}
+	return result
+}
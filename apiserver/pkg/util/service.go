@@ -2,8 +2,11 @@ package util
 
 import (
 	"errors"
+	"fmt"
 
 	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
@@ -33,6 +36,15 @@ func NewRayService(apiService *api.RayService, computeTemplateMap map[string]*ap
 		},
 		Spec: *spec,
 	}
+	SetPodDisruptionBudgetLabels(&rayService.Spec.RayClusterSpec, apiService.Name)
+	if apiService.Expose != nil {
+		// The apiserver creates the Ingress or the Route of the expose options after the RayService.
+		expose, err := protojson.Marshal(apiService.Expose)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the expose options of service %s: %w", apiService.Name, err)
+		}
+		rayService.Annotations[RayServiceExposeAnnotationKey] = string(expose)
+	}
 	return &RayService{rayService}, nil
 }
 
@@ -47,7 +59,8 @@ func buildRayServiceLabels(apiService *api.RayService) map[string]string {
 func buildRayServiceAnnotations(apiService *api.RayService) map[string]string {
 	annotations := map[string]string{}
 	// TODO: Add optional annotations
-	return annotations
+	// The operator copies the annotations of the RayService to its RayClusters.
+	return buildGcsFaultToleranceAnnotations(annotations, apiService.ClusterSpec)
 }
 
 func buildRayServiceSpec(apiService *api.RayService, computeTemplateMap map[string]*api.ComputeTemplate) (*rayv1api.RayServiceSpec, error) {
@@ -57,6 +70,11 @@ func buildRayServiceSpec(apiService *api.RayService, computeTemplateMap map[stri
 		return nil, errors.New("serve configuration is not defined")
 	}
 
+	serveConfigV2, err := ApplyServeDeploymentAutoscaling(apiService.ServeConfig_V2, apiService.DeploymentAutoscaling)
+	if err != nil {
+		return nil, err
+	}
+
 	// generate Ray cluster spec and buid cluster
 	newRayClusterSpec, err := buildRayClusterSpec(apiService.Version, nil, apiService.ClusterSpec, computeTemplateMap, true)
 	if err != nil {
@@ -76,13 +94,38 @@ func buildRayServiceSpec(apiService *api.RayService, computeTemplateMap map[stri
 	}
 	// V2 definition
 	return &rayv1api.RayServiceSpec{
-		ServeConfigV2:                      apiService.ServeConfig_V2,
+		ServeConfigV2:                      serveConfigV2,
 		RayClusterSpec:                     *newRayClusterSpec,
 		ServiceUnhealthySecondThreshold:    serviceUnhealthySecondThreshold,
 		DeploymentUnhealthySecondThreshold: deploymentUnhealthySecondThreshold,
+		ServeService:                       buildServeService(apiService.ServeService),
 	}, nil
 }
 
+// buildServeService returns the serve service carrying the routing options, or nil to let the operator create the
+// default one. The operator fills in the name, selector, ports and type of the service.
+func buildServeService(options *api.ServeServiceOptions) *corev1.Service {
+	if options == nil {
+		return nil
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: options.Annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			SessionAffinity:       corev1.ServiceAffinity(options.SessionAffinity),
+			ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicy(options.ExternalTrafficPolicy),
+		},
+	}
+	if options.SessionAffinityTimeoutSeconds > 0 {
+		timeoutSeconds := options.SessionAffinityTimeoutSeconds
+		service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeoutSeconds},
+		}
+	}
+	return service
+}
+
 func UpdateRayServiceWorkerGroupSpecs(updateSpecs []*api.WorkerGroupUpdateSpec, workerGroupSpecs []rayv1api.WorkerGroupSpec) []rayv1api.WorkerGroupSpec {
 	specMap := map[string]*api.WorkerGroupUpdateSpec{}
 	for _, spec := range updateSpecs {
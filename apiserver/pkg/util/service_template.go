@@ -0,0 +1,46 @@
+package util
+
+import (
+	"fmt"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Service templates are stored in ConfigMaps, like compute templates and cron jobs.
+const (
+	ServiceTemplateConfigType = "service-template"
+
+	// The keys of the ConfigMap data.
+	ServiceTemplateDescriptionKey = "description"
+	ServiceTemplateServiceKey     = "service"
+)
+
+// NewServiceTemplate creates the ConfigMap storing a service template. The ray service is kept in the API
+// format, so that every ray service created from the template is created like one created with
+// CreateRayService, with the compute templates of the time.
+func NewServiceTemplate(apiTemplate *api.ServiceTemplate) (*corev1.ConfigMap, error) {
+	service, err := protojson.Marshal(apiTemplate.Service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the ray service of service template %s: %w", apiTemplate.Name, err)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiTemplate.Name,
+			Namespace: apiTemplate.Namespace,
+			Labels: map[string]string{
+				"ray.io/config-type":              ServiceTemplateConfigType,
+				RayClusterUserLabelKey:            apiTemplate.User,
+				KubernetesApplicationNameLabelKey: ApplicationName,
+				KubernetesManagedByLabelKey:       ComponentName,
+			},
+		},
+		Data: map[string]string{
+			ServiceTemplateDescriptionKey: apiTemplate.Description,
+			ServiceTemplateServiceKey:     string(service),
+		},
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	api "github.com/ray-project/kuberay/proto/go_client"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -38,3 +39,74 @@ func TestBuildService(t *testing.T) {
 	assert.NotNil(t, got.Spec.ServiceUnhealthySecondThreshold)
 	assert.Nil(t, got.Spec.DeploymentUnhealthySecondThreshold)
 }
+
+func TestBuildServeService(t *testing.T) {
+	assert.Nil(t, buildServeService(nil))
+
+	service := buildServeService(&api.ServeServiceOptions{
+		SessionAffinity:               "ClientIP",
+		SessionAffinityTimeoutSeconds: 600,
+		ExternalTrafficPolicy:         "Local",
+		Annotations:                   map[string]string{"example.com/sticky": "true"},
+	})
+	assert.Equal(t, corev1.ServiceAffinityClientIP, service.Spec.SessionAffinity)
+	assert.Equal(t, int32(600), *service.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds)
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyLocal, service.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, map[string]string{"example.com/sticky": "true"}, service.Annotations)
+	// The operator fills in the type and ports of the serve service.
+	assert.Empty(t, service.Spec.Type)
+	assert.Empty(t, service.Spec.Ports)
+
+	service = buildServeService(&api.ServeServiceOptions{SessionAffinity: "ClientIP"})
+	assert.Nil(t, service.Spec.SessionAffinityConfig)
+}
+
+func TestServeDeploymentAutoscaling(t *testing.T) {
+	serveConfigV2 := `applications:
+- name: fruits
+  import_path: fruit.deployment_graph
+  deployments:
+  - name: MangoStand
+    num_replicas: 1
+    user_config:
+      price: 3
+  - name: PearStand
+    autoscaling_config:
+      target_ongoing_requests: 5
+      max_replicas: 4
+`
+	got, err := ApplyServeDeploymentAutoscaling(serveConfigV2, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, serveConfigV2, got)
+
+	got, err = ApplyServeDeploymentAutoscaling(serveConfigV2, []*api.ServeDeploymentAutoscaling{
+		{Application: "fruits", Deployment: "MangoStand", MaxReplicas: 10, TargetNumOngoingRequests: 8, UpscaleDelaySeconds: 15},
+		{Application: "fruits", Deployment: "PearStand", ScaleToZero: true, DownscaleDelaySeconds: 300},
+		{Application: "fruits", Deployment: "FruitMarket", NumReplicas: 2},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, got, "price: 3")
+	assert.Equal(t, []*api.ServeDeploymentAutoscaling{
+		{Application: "fruits", Deployment: "MangoStand", MaxReplicas: 10, TargetNumOngoingRequests: 8, UpscaleDelaySeconds: 15},
+		// The target keeps the name used by the serve config.
+		{Application: "fruits", Deployment: "PearStand", ScaleToZero: true, MaxReplicas: 4, TargetNumOngoingRequests: 5, DownscaleDelaySeconds: 300},
+		{Application: "fruits", Deployment: "FruitMarket", NumReplicas: 2},
+	}, ServeDeploymentAutoscalingFromConfig(got))
+	assert.Contains(t, got, "target_num_ongoing_requests_per_replica: 8")
+	assert.Contains(t, got, "target_ongoing_requests: 5")
+	assert.NotContains(t, got, "num_replicas: 1")
+
+	_, err = ApplyServeDeploymentAutoscaling(serveConfigV2, []*api.ServeDeploymentAutoscaling{{Application: "vegetables", Deployment: "Carrot", NumReplicas: 1}})
+	assert.EqualError(t, err, "application vegetables of deployment Carrot is not in the serve config")
+
+	service, err := NewRayService(&api.RayService{
+		Name:                  "test",
+		Namespace:             "test",
+		User:                  "test",
+		ServeConfig_V2:        serveConfigV2,
+		ClusterSpec:           rayCluster.ClusterSpec,
+		DeploymentAutoscaling: []*api.ServeDeploymentAutoscaling{{Application: "fruits", Deployment: "MangoStand", NumReplicas: 3}},
+	}, map[string]*api.ComputeTemplate{"foo": &template})
+	assert.NoError(t, err)
+	assert.Contains(t, service.Spec.ServeConfigV2, "num_replicas: 3")
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// SharedMemoryVolumeName and SharedMemoryMountPath are the volume of /dev/shm, which the operator adds to the Ray
+	// containers unless they already mount one.
+	SharedMemoryVolumeName = "shared-mem"
+	SharedMemoryMountPath  = "/dev/shm"
+)
+
+// addMemoryVolumes mounts the shared memory and the scratch volumes of a group in its Ray container. The volumes
+// backed by memory can not exceed the memory of the container, and the ones on the disk its ephemeral storage limit,
+// since the pod would be evicted before they are full.
+func addMemoryVolumes(template *corev1.PodTemplateSpec, container *corev1.Container, sharedMemorySize string, scratchVolumes []*api.ScratchVolume, rayStartParams map[string]string) error {
+	var inMemory, onDisk resource.Quantity
+	if sharedMemorySize != "" {
+		size, err := resource.ParseQuantity(sharedMemorySize)
+		if err != nil {
+			return fmt.Errorf("shared memory size %q is not specified correctly: %w", sharedMemorySize, err)
+		}
+		if objectStoreMemory, ok := rayStartParams["object-store-memory"]; ok {
+			if bytes, err := strconv.ParseInt(objectStoreMemory, 10, 64); err == nil && bytes > size.Value() {
+				return fmt.Errorf("object store memory %s bytes exceeds the shared memory size %s", objectStoreMemory, sharedMemorySize)
+			}
+		}
+		inMemory.Add(size)
+		addEmptyDirVolume(template, container, SharedMemoryVolumeName, SharedMemoryMountPath, size, corev1.StorageMediumMemory)
+	}
+
+	names := make([]string, 0, len(scratchVolumes))
+	for _, volume := range scratchVolumes {
+		size, err := resource.ParseQuantity(volume.Size)
+		if err != nil {
+			return fmt.Errorf("size %q of scratch volume %s is not specified correctly: %w", volume.Size, volume.Name, err)
+		}
+		medium := corev1.StorageMediumDefault
+		if volume.InMemory {
+			medium = corev1.StorageMediumMemory
+			inMemory.Add(size)
+		} else {
+			onDisk.Add(size)
+		}
+		addEmptyDirVolume(template, container, volume.Name, volume.MountPath, size, medium)
+		names = append(names, volume.Name)
+	}
+	if len(names) > 0 {
+		template.Annotations[RayClusterScratchVolumesAnnotationKey] = strings.Join(names, ",")
+	}
+
+	if memory, ok := container.Resources.Requests[corev1.ResourceMemory]; ok && inMemory.Cmp(memory) > 0 {
+		return fmt.Errorf("the shared memory and the scratch volumes in memory use %s, which exceeds the memory %s of the group", inMemory.String(), memory.String())
+	}
+	if limit, ok := container.Resources.Limits[corev1.ResourceEphemeralStorage]; ok && onDisk.Cmp(limit) > 0 {
+		return fmt.Errorf("the scratch volumes on disk use %s, which exceeds the ephemeral storage limit %s of the group", onDisk.String(), limit.String())
+	}
+	return nil
+}
+
+func addEmptyDirVolume(template *corev1.PodTemplateSpec, container *corev1.Container, name string, mountPath string, size resource.Quantity, medium corev1.StorageMedium) {
+	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{Medium: medium, SizeLimit: &size},
+		},
+	})
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: name, MountPath: mountPath})
+}
+
+// IsSharedMemoryVolume returns whether a volume of a pod template is the shared memory added by the API server.
+func IsSharedMemoryVolume(volume *corev1.Volume) bool {
+	return volume.Name == SharedMemoryVolumeName && volume.EmptyDir != nil && volume.EmptyDir.Medium == corev1.StorageMediumMemory
+}
+
+// ScratchVolumeNames returns the names of the scratch volumes of a group kept in the annotations of its pod template.
+func ScratchVolumeNames(annotations map[string]string) []string {
+	if annotations[RayClusterScratchVolumesAnnotationKey] == "" {
+		return nil
+	}
+	return strings.Split(annotations[RayClusterScratchVolumesAnnotationKey], ",")
+}
@@ -0,0 +1,27 @@
+package util
+
+import "strconv"
+
+// withTTLAnnotation returns a copy of the annotations with the time to live after which the API server deletes a
+// resource, or the annotations themselves if the time to live is 0.
+func withTTLAnnotation(annotations map[string]string, key string, seconds int32) map[string]string {
+	if seconds <= 0 {
+		return annotations
+	}
+	result := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		result[k] = v
+	}
+	result[key] = strconv.Itoa(int(seconds))
+	return result
+}
+
+// TTLFromAnnotations returns the time to live in seconds stored in an annotation of a resource, or 0 if the resource
+// has no valid time to live.
+func TTLFromAnnotations(annotations map[string]string, key string) int32 {
+	seconds, err := strconv.ParseInt(annotations[key], 10, 32)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return int32(seconds)
+}
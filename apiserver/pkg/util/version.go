@@ -0,0 +1,26 @@
+package util
+
+import "runtime/debug"
+
+// Version and GitCommit are the version of the API server and the git commit it was built from. They are set when the
+// API server is built, e.g. with -ldflags "-X github.com/ray-project/kuberay/apiserver/pkg/util.Version=v1.2.0".
+var (
+	Version   = "dev"
+	GitCommit = ""
+)
+
+// BuildGitCommit returns the git commit the API server was built from, GitCommit or the revision which the go
+// toolchain stamped into the binary.
+func BuildGitCommit() string {
+	if GitCommit != "" {
+		return GitCommit
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return ""
+}
@@ -241,11 +241,11 @@ func (e2etc *End2EndTestingContext) CreateComputeTemplate(t *testing.T) {
 }
 
 func (e2etc *End2EndTestingContext) DeleteComputeTemplate(t *testing.T) {
-	deleteComputeTemplateRequest := &api.DeleteClusterRequest{
+	deleteComputeTemplateRequest := &api.DeleteComputeTemplateRequest{
 		Name:      e2etc.computeTemplateName,
 		Namespace: e2etc.namespaceName,
 	}
-	status, err := e2etc.kuberayAPIServerClient.DeleteComputeTemplate((*api.DeleteComputeTemplateRequest)(deleteComputeTemplateRequest))
+	status, err := e2etc.kuberayAPIServerClient.DeleteComputeTemplate(deleteComputeTemplateRequest)
 	if !assert.NoErrorf(t, err, "No error expected while deleting a compute template (%s, %s)", e2etc.computeTemplateName, e2etc.namespaceName) {
 		t.Fatalf("Received status of %v when attempting to create compute template", status)
 	}
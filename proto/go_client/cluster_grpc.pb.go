@@ -31,6 +31,62 @@ type ClusterServiceClient interface {
 	// avoid unexpected behaviors, delete an cluster's runs and jobs before
 	// deleting the cluster.
 	DeleteCluster(ctx context.Context, in *DeleteClusterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Deletes many clusters of a namespace in one call. The clusters are deleted concurrently, and one result is returned
+	// per cluster, in the order of the request, so that a cluster which can't be deleted does not fail the others.
+	BatchDeleteRayClusters(ctx context.Context, in *BatchDeleteRayClustersRequest, opts ...grpc.CallOption) (*BatchDeleteRayClustersResponse, error)
+	// Updates the replicas, min replicas and max replicas of the worker groups of a cluster in place. The request
+	// carries the cluster returned by GetCluster with the new replicas, any change to another field is rejected as
+	// the running Pods are not updated.
+	UpdateCluster(ctx context.Context, in *UpdateClusterRequest, opts ...grpc.CallOption) (*Cluster, error)
+	// Previews an UpdateCluster call without applying it: returns the fields of the RayCluster custom resource it would
+	// change and how they would be applied. The request is validated like the update, so changes which the update
+	// rejects are rejected as well.
+	PreviewClusterUpdate(ctx context.Context, in *UpdateClusterRequest, opts ...grpc.CallOption) (*UpdatePreview, error)
+	// Updates the autoscaling bounds of a worker group without changing the rest of the Cluster spec.
+	UpdateWorkerGroupAutoscaling(ctx context.Context, in *UpdateWorkerGroupAutoscalingRequest, opts ...grpc.CallOption) (*Cluster, error)
+	// Updates the image, the compute template and the replicas of a worker group without recreating the Cluster. The
+	// Pods of the worker group are restarted in a rolling fashion when its image or compute template changes.
+	UpdateWorkerGroup(ctx context.Context, in *UpdateWorkerGroupRequest, opts ...grpc.CallOption) (*Cluster, error)
+	// Restarts the Pods of a worker group in a rolling fashion, e.g. to pull a fixed image with the same tag. The API
+	// server deletes the outdated Pods a few at a time, and the operator recreates them.
+	RestartWorkerGroup(ctx context.Context, in *RestartWorkerGroupRequest, opts ...grpc.CallOption) (*WorkerGroupRestart, error)
+	// Finds the progress of the last rolling restart of a worker group.
+	GetWorkerGroupRestart(ctx context.Context, in *GetWorkerGroupRestartRequest, opts ...grpc.CallOption) (*WorkerGroupRestart, error)
+	// Scales a worker group down without killing the Ray workloads running on it, unlike lowering its replicas. The API
+	// server picks the workers to be removed, preferring the ones without actors, marks them with the lowest Pod
+	// deletion cost, and removes each of them once no actor is left on its Ray node, or once the drain timeout expires.
+	DrainWorkerGroup(ctx context.Context, in *DrainWorkerGroupRequest, opts ...grpc.CallOption) (*WorkerGroupDrain, error)
+	// Finds the progress of the last drain of a worker group.
+	GetWorkerGroupDrain(ctx context.Context, in *GetWorkerGroupDrainRequest, opts ...grpc.CallOption) (*WorkerGroupDrain, error)
+	// Finds the status of a specific Cluster without its spec and events. This is cheaper than GetCluster for
+	// clients which poll the status frequently.
+	GetClusterStatus(ctx context.Context, in *GetClusterStatusRequest, opts ...grpc.CallOption) (*ClusterStatus, error)
+	// Streams the status of a specific Cluster when the watch starts and every time it changes, so that clients
+	// do not need to poll GetClusterStatus. The stream ends when the Cluster is deleted.
+	WatchClusterStatus(ctx context.Context, in *WatchClusterStatusRequest, opts ...grpc.CallOption) (ClusterService_WatchClusterStatusClient, error)
+	// Checks that the dashboard and the Ray client server of a Cluster can be reached from the API server, and
+	// returns their latencies and the Ray version reported by the dashboard.
+	TestRayClusterConnectivity(ctx context.Context, in *TestRayClusterConnectivityRequest, opts ...grpc.CallOption) (*RayClusterConnectivity, error)
+	// Returns the addresses of the Ray client server, the dashboard and the Serve HTTP proxy of a Cluster, resolved from
+	// its Kubernetes Services and Ingress, so that clients can connect to it without inspecting Kubernetes objects.
+	GetRayClusterEndpoints(ctx context.Context, in *GetRayClusterEndpointsRequest, opts ...grpc.CallOption) (*RayEndpoints, error)
+	// Exports a Cluster as the YAML manifest of its RayCluster custom resource, as materialized on the Kubernetes
+	// cluster, e.g. to commit it to a GitOps repository.
+	ExportRayCluster(ctx context.Context, in *ExportRayClusterRequest, opts ...grpc.CallOption) (*ResourceManifest, error)
+	// Checks whether the Pods of a cluster spec, or the replicas of a compute template, are likely to be scheduled: whether
+	// they fit in the ResourceQuotas of the namespace, in the resource quotas of the API server and on the Nodes with
+	// free resources. Nothing is created, and other workloads may take the free resources before the cluster is created.
+	CanSchedule(ctx context.Context, in *CanScheduleRequest, opts ...grpc.CallOption) (*SchedulingAdvice, error)
+	// Injects a failure into a Cluster, e.g. kills its head Pod, to test the fault tolerance of the Ray
+	// applications running on it. Only served when the FailureInjection feature gate is enabled.
+	InjectClusterFailure(ctx context.Context, in *InjectClusterFailureRequest, opts ...grpc.CallOption) (*ClusterFailureInjection, error)
+	// Removes the network partitions injected into a Cluster by InjectClusterFailure. Only served when the
+	// FailureInjection feature gate is enabled.
+	HealClusterPartitions(ctx context.Context, in *HealClusterPartitionsRequest, opts ...grpc.CallOption) (*ClusterFailureInjection, error)
+	// Creates a Cluster from the spec of an existing Cluster, or of the cluster of a ray service, with a new name and
+	// optionally another image and other replicas, e.g. to run a staging copy of a production service or to reproduce
+	// the configuration of a failing cluster. The copy is created like a new Cluster, with the same validations.
+	CloneRayCluster(ctx context.Context, in *CloneRayClusterRequest, opts ...grpc.CallOption) (*Cluster, error)
 }
 
 type clusterServiceClient struct {
@@ -86,6 +142,191 @@ func (c *clusterServiceClient) DeleteCluster(ctx context.Context, in *DeleteClus
 	return out, nil
 }
 
+func (c *clusterServiceClient) BatchDeleteRayClusters(ctx context.Context, in *BatchDeleteRayClustersRequest, opts ...grpc.CallOption) (*BatchDeleteRayClustersResponse, error) {
+	out := new(BatchDeleteRayClustersResponse)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/BatchDeleteRayClusters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) UpdateCluster(ctx context.Context, in *UpdateClusterRequest, opts ...grpc.CallOption) (*Cluster, error) {
+	out := new(Cluster)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/UpdateCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) PreviewClusterUpdate(ctx context.Context, in *UpdateClusterRequest, opts ...grpc.CallOption) (*UpdatePreview, error) {
+	out := new(UpdatePreview)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/PreviewClusterUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) UpdateWorkerGroupAutoscaling(ctx context.Context, in *UpdateWorkerGroupAutoscalingRequest, opts ...grpc.CallOption) (*Cluster, error) {
+	out := new(Cluster)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/UpdateWorkerGroupAutoscaling", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) UpdateWorkerGroup(ctx context.Context, in *UpdateWorkerGroupRequest, opts ...grpc.CallOption) (*Cluster, error) {
+	out := new(Cluster)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/UpdateWorkerGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) RestartWorkerGroup(ctx context.Context, in *RestartWorkerGroupRequest, opts ...grpc.CallOption) (*WorkerGroupRestart, error) {
+	out := new(WorkerGroupRestart)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/RestartWorkerGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) GetWorkerGroupRestart(ctx context.Context, in *GetWorkerGroupRestartRequest, opts ...grpc.CallOption) (*WorkerGroupRestart, error) {
+	out := new(WorkerGroupRestart)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/GetWorkerGroupRestart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) DrainWorkerGroup(ctx context.Context, in *DrainWorkerGroupRequest, opts ...grpc.CallOption) (*WorkerGroupDrain, error) {
+	out := new(WorkerGroupDrain)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/DrainWorkerGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) GetWorkerGroupDrain(ctx context.Context, in *GetWorkerGroupDrainRequest, opts ...grpc.CallOption) (*WorkerGroupDrain, error) {
+	out := new(WorkerGroupDrain)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/GetWorkerGroupDrain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) GetClusterStatus(ctx context.Context, in *GetClusterStatusRequest, opts ...grpc.CallOption) (*ClusterStatus, error) {
+	out := new(ClusterStatus)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/GetClusterStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) WatchClusterStatus(ctx context.Context, in *WatchClusterStatusRequest, opts ...grpc.CallOption) (ClusterService_WatchClusterStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ClusterService_ServiceDesc.Streams[0], "/proto.ClusterService/WatchClusterStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clusterServiceWatchClusterStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ClusterService_WatchClusterStatusClient interface {
+	Recv() (*ClusterStatus, error)
+	grpc.ClientStream
+}
+
+type clusterServiceWatchClusterStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *clusterServiceWatchClusterStatusClient) Recv() (*ClusterStatus, error) {
+	m := new(ClusterStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *clusterServiceClient) TestRayClusterConnectivity(ctx context.Context, in *TestRayClusterConnectivityRequest, opts ...grpc.CallOption) (*RayClusterConnectivity, error) {
+	out := new(RayClusterConnectivity)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/TestRayClusterConnectivity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) GetRayClusterEndpoints(ctx context.Context, in *GetRayClusterEndpointsRequest, opts ...grpc.CallOption) (*RayEndpoints, error) {
+	out := new(RayEndpoints)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/GetRayClusterEndpoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) ExportRayCluster(ctx context.Context, in *ExportRayClusterRequest, opts ...grpc.CallOption) (*ResourceManifest, error) {
+	out := new(ResourceManifest)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/ExportRayCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) CanSchedule(ctx context.Context, in *CanScheduleRequest, opts ...grpc.CallOption) (*SchedulingAdvice, error) {
+	out := new(SchedulingAdvice)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/CanSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) InjectClusterFailure(ctx context.Context, in *InjectClusterFailureRequest, opts ...grpc.CallOption) (*ClusterFailureInjection, error) {
+	out := new(ClusterFailureInjection)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/InjectClusterFailure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) HealClusterPartitions(ctx context.Context, in *HealClusterPartitionsRequest, opts ...grpc.CallOption) (*ClusterFailureInjection, error) {
+	out := new(ClusterFailureInjection)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/HealClusterPartitions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) CloneRayCluster(ctx context.Context, in *CloneRayClusterRequest, opts ...grpc.CallOption) (*Cluster, error) {
+	out := new(Cluster)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/CloneRayCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClusterServiceServer is the server API for ClusterService service.
 // All implementations must embed UnimplementedClusterServiceServer
 // for forward compatibility
@@ -102,6 +343,62 @@ type ClusterServiceServer interface {
 	// avoid unexpected behaviors, delete an cluster's runs and jobs before
 	// deleting the cluster.
 	DeleteCluster(context.Context, *DeleteClusterRequest) (*emptypb.Empty, error)
+	// Deletes many clusters of a namespace in one call. The clusters are deleted concurrently, and one result is returned
+	// per cluster, in the order of the request, so that a cluster which can't be deleted does not fail the others.
+	BatchDeleteRayClusters(context.Context, *BatchDeleteRayClustersRequest) (*BatchDeleteRayClustersResponse, error)
+	// Updates the replicas, min replicas and max replicas of the worker groups of a cluster in place. The request
+	// carries the cluster returned by GetCluster with the new replicas, any change to another field is rejected as
+	// the running Pods are not updated.
+	UpdateCluster(context.Context, *UpdateClusterRequest) (*Cluster, error)
+	// Previews an UpdateCluster call without applying it: returns the fields of the RayCluster custom resource it would
+	// change and how they would be applied. The request is validated like the update, so changes which the update
+	// rejects are rejected as well.
+	PreviewClusterUpdate(context.Context, *UpdateClusterRequest) (*UpdatePreview, error)
+	// Updates the autoscaling bounds of a worker group without changing the rest of the Cluster spec.
+	UpdateWorkerGroupAutoscaling(context.Context, *UpdateWorkerGroupAutoscalingRequest) (*Cluster, error)
+	// Updates the image, the compute template and the replicas of a worker group without recreating the Cluster. The
+	// Pods of the worker group are restarted in a rolling fashion when its image or compute template changes.
+	UpdateWorkerGroup(context.Context, *UpdateWorkerGroupRequest) (*Cluster, error)
+	// Restarts the Pods of a worker group in a rolling fashion, e.g. to pull a fixed image with the same tag. The API
+	// server deletes the outdated Pods a few at a time, and the operator recreates them.
+	RestartWorkerGroup(context.Context, *RestartWorkerGroupRequest) (*WorkerGroupRestart, error)
+	// Finds the progress of the last rolling restart of a worker group.
+	GetWorkerGroupRestart(context.Context, *GetWorkerGroupRestartRequest) (*WorkerGroupRestart, error)
+	// Scales a worker group down without killing the Ray workloads running on it, unlike lowering its replicas. The API
+	// server picks the workers to be removed, preferring the ones without actors, marks them with the lowest Pod
+	// deletion cost, and removes each of them once no actor is left on its Ray node, or once the drain timeout expires.
+	DrainWorkerGroup(context.Context, *DrainWorkerGroupRequest) (*WorkerGroupDrain, error)
+	// Finds the progress of the last drain of a worker group.
+	GetWorkerGroupDrain(context.Context, *GetWorkerGroupDrainRequest) (*WorkerGroupDrain, error)
+	// Finds the status of a specific Cluster without its spec and events. This is cheaper than GetCluster for
+	// clients which poll the status frequently.
+	GetClusterStatus(context.Context, *GetClusterStatusRequest) (*ClusterStatus, error)
+	// Streams the status of a specific Cluster when the watch starts and every time it changes, so that clients
+	// do not need to poll GetClusterStatus. The stream ends when the Cluster is deleted.
+	WatchClusterStatus(*WatchClusterStatusRequest, ClusterService_WatchClusterStatusServer) error
+	// Checks that the dashboard and the Ray client server of a Cluster can be reached from the API server, and
+	// returns their latencies and the Ray version reported by the dashboard.
+	TestRayClusterConnectivity(context.Context, *TestRayClusterConnectivityRequest) (*RayClusterConnectivity, error)
+	// Returns the addresses of the Ray client server, the dashboard and the Serve HTTP proxy of a Cluster, resolved from
+	// its Kubernetes Services and Ingress, so that clients can connect to it without inspecting Kubernetes objects.
+	GetRayClusterEndpoints(context.Context, *GetRayClusterEndpointsRequest) (*RayEndpoints, error)
+	// Exports a Cluster as the YAML manifest of its RayCluster custom resource, as materialized on the Kubernetes
+	// cluster, e.g. to commit it to a GitOps repository.
+	ExportRayCluster(context.Context, *ExportRayClusterRequest) (*ResourceManifest, error)
+	// Checks whether the Pods of a cluster spec, or the replicas of a compute template, are likely to be scheduled: whether
+	// they fit in the ResourceQuotas of the namespace, in the resource quotas of the API server and on the Nodes with
+	// free resources. Nothing is created, and other workloads may take the free resources before the cluster is created.
+	CanSchedule(context.Context, *CanScheduleRequest) (*SchedulingAdvice, error)
+	// Injects a failure into a Cluster, e.g. kills its head Pod, to test the fault tolerance of the Ray
+	// applications running on it. Only served when the FailureInjection feature gate is enabled.
+	InjectClusterFailure(context.Context, *InjectClusterFailureRequest) (*ClusterFailureInjection, error)
+	// Removes the network partitions injected into a Cluster by InjectClusterFailure. Only served when the
+	// FailureInjection feature gate is enabled.
+	HealClusterPartitions(context.Context, *HealClusterPartitionsRequest) (*ClusterFailureInjection, error)
+	// Creates a Cluster from the spec of an existing Cluster, or of the cluster of a ray service, with a new name and
+	// optionally another image and other replicas, e.g. to run a staging copy of a production service or to reproduce
+	// the configuration of a failing cluster. The copy is created like a new Cluster, with the same validations.
+	CloneRayCluster(context.Context, *CloneRayClusterRequest) (*Cluster, error)
 	mustEmbedUnimplementedClusterServiceServer()
 }
 
@@ -124,6 +421,60 @@ func (UnimplementedClusterServiceServer) ListAllClusters(context.Context, *ListA
 func (UnimplementedClusterServiceServer) DeleteCluster(context.Context, *DeleteClusterRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCluster not implemented")
 }
+func (UnimplementedClusterServiceServer) BatchDeleteRayClusters(context.Context, *BatchDeleteRayClustersRequest) (*BatchDeleteRayClustersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteRayClusters not implemented")
+}
+func (UnimplementedClusterServiceServer) UpdateCluster(context.Context, *UpdateClusterRequest) (*Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCluster not implemented")
+}
+func (UnimplementedClusterServiceServer) PreviewClusterUpdate(context.Context, *UpdateClusterRequest) (*UpdatePreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewClusterUpdate not implemented")
+}
+func (UnimplementedClusterServiceServer) UpdateWorkerGroupAutoscaling(context.Context, *UpdateWorkerGroupAutoscalingRequest) (*Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorkerGroupAutoscaling not implemented")
+}
+func (UnimplementedClusterServiceServer) UpdateWorkerGroup(context.Context, *UpdateWorkerGroupRequest) (*Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWorkerGroup not implemented")
+}
+func (UnimplementedClusterServiceServer) RestartWorkerGroup(context.Context, *RestartWorkerGroupRequest) (*WorkerGroupRestart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartWorkerGroup not implemented")
+}
+func (UnimplementedClusterServiceServer) GetWorkerGroupRestart(context.Context, *GetWorkerGroupRestartRequest) (*WorkerGroupRestart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkerGroupRestart not implemented")
+}
+func (UnimplementedClusterServiceServer) DrainWorkerGroup(context.Context, *DrainWorkerGroupRequest) (*WorkerGroupDrain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainWorkerGroup not implemented")
+}
+func (UnimplementedClusterServiceServer) GetWorkerGroupDrain(context.Context, *GetWorkerGroupDrainRequest) (*WorkerGroupDrain, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkerGroupDrain not implemented")
+}
+func (UnimplementedClusterServiceServer) GetClusterStatus(context.Context, *GetClusterStatusRequest) (*ClusterStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterStatus not implemented")
+}
+func (UnimplementedClusterServiceServer) WatchClusterStatus(*WatchClusterStatusRequest, ClusterService_WatchClusterStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchClusterStatus not implemented")
+}
+func (UnimplementedClusterServiceServer) TestRayClusterConnectivity(context.Context, *TestRayClusterConnectivityRequest) (*RayClusterConnectivity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestRayClusterConnectivity not implemented")
+}
+func (UnimplementedClusterServiceServer) GetRayClusterEndpoints(context.Context, *GetRayClusterEndpointsRequest) (*RayEndpoints, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRayClusterEndpoints not implemented")
+}
+func (UnimplementedClusterServiceServer) ExportRayCluster(context.Context, *ExportRayClusterRequest) (*ResourceManifest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportRayCluster not implemented")
+}
+func (UnimplementedClusterServiceServer) CanSchedule(context.Context, *CanScheduleRequest) (*SchedulingAdvice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanSchedule not implemented")
+}
+func (UnimplementedClusterServiceServer) InjectClusterFailure(context.Context, *InjectClusterFailureRequest) (*ClusterFailureInjection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectClusterFailure not implemented")
+}
+func (UnimplementedClusterServiceServer) HealClusterPartitions(context.Context, *HealClusterPartitionsRequest) (*ClusterFailureInjection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealClusterPartitions not implemented")
+}
+func (UnimplementedClusterServiceServer) CloneRayCluster(context.Context, *CloneRayClusterRequest) (*Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneRayCluster not implemented")
+}
 func (UnimplementedClusterServiceServer) mustEmbedUnimplementedClusterServiceServer() {}
 
 // UnsafeClusterServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -227,6 +578,333 @@ func _ClusterService_DeleteCluster_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClusterService_BatchDeleteRayClusters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteRayClustersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).BatchDeleteRayClusters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/BatchDeleteRayClusters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).BatchDeleteRayClusters(ctx, req.(*BatchDeleteRayClustersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_UpdateCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).UpdateCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/UpdateCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).UpdateCluster(ctx, req.(*UpdateClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_PreviewClusterUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).PreviewClusterUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/PreviewClusterUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).PreviewClusterUpdate(ctx, req.(*UpdateClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_UpdateWorkerGroupAutoscaling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWorkerGroupAutoscalingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).UpdateWorkerGroupAutoscaling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/UpdateWorkerGroupAutoscaling",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).UpdateWorkerGroupAutoscaling(ctx, req.(*UpdateWorkerGroupAutoscalingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_UpdateWorkerGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWorkerGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).UpdateWorkerGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/UpdateWorkerGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).UpdateWorkerGroup(ctx, req.(*UpdateWorkerGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_RestartWorkerGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartWorkerGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).RestartWorkerGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/RestartWorkerGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).RestartWorkerGroup(ctx, req.(*RestartWorkerGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_GetWorkerGroupRestart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkerGroupRestartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).GetWorkerGroupRestart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/GetWorkerGroupRestart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).GetWorkerGroupRestart(ctx, req.(*GetWorkerGroupRestartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_DrainWorkerGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainWorkerGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).DrainWorkerGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/DrainWorkerGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).DrainWorkerGroup(ctx, req.(*DrainWorkerGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_GetWorkerGroupDrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkerGroupDrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).GetWorkerGroupDrain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/GetWorkerGroupDrain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).GetWorkerGroupDrain(ctx, req.(*GetWorkerGroupDrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_GetClusterStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClusterStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).GetClusterStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/GetClusterStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).GetClusterStatus(ctx, req.(*GetClusterStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_WatchClusterStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchClusterStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClusterServiceServer).WatchClusterStatus(m, &clusterServiceWatchClusterStatusServer{stream})
+}
+
+type ClusterService_WatchClusterStatusServer interface {
+	Send(*ClusterStatus) error
+	grpc.ServerStream
+}
+
+type clusterServiceWatchClusterStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *clusterServiceWatchClusterStatusServer) Send(m *ClusterStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ClusterService_TestRayClusterConnectivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestRayClusterConnectivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).TestRayClusterConnectivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/TestRayClusterConnectivity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).TestRayClusterConnectivity(ctx, req.(*TestRayClusterConnectivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_GetRayClusterEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRayClusterEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).GetRayClusterEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/GetRayClusterEndpoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).GetRayClusterEndpoints(ctx, req.(*GetRayClusterEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_ExportRayCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRayClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).ExportRayCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/ExportRayCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).ExportRayCluster(ctx, req.(*ExportRayClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_CanSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).CanSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/CanSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).CanSchedule(ctx, req.(*CanScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_InjectClusterFailure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectClusterFailureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).InjectClusterFailure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/InjectClusterFailure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).InjectClusterFailure(ctx, req.(*InjectClusterFailureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_HealClusterPartitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealClusterPartitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).HealClusterPartitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/HealClusterPartitions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).HealClusterPartitions(ctx, req.(*HealClusterPartitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_CloneRayCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneRayClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).CloneRayCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/CloneRayCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).CloneRayCluster(ctx, req.(*CloneRayClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClusterService_ServiceDesc is the grpc.ServiceDesc for ClusterService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -254,7 +932,81 @@ var ClusterService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCluster",
 			Handler:    _ClusterService_DeleteCluster_Handler,
 		},
+		{
+			MethodName: "BatchDeleteRayClusters",
+			Handler:    _ClusterService_BatchDeleteRayClusters_Handler,
+		},
+		{
+			MethodName: "UpdateCluster",
+			Handler:    _ClusterService_UpdateCluster_Handler,
+		},
+		{
+			MethodName: "PreviewClusterUpdate",
+			Handler:    _ClusterService_PreviewClusterUpdate_Handler,
+		},
+		{
+			MethodName: "UpdateWorkerGroupAutoscaling",
+			Handler:    _ClusterService_UpdateWorkerGroupAutoscaling_Handler,
+		},
+		{
+			MethodName: "UpdateWorkerGroup",
+			Handler:    _ClusterService_UpdateWorkerGroup_Handler,
+		},
+		{
+			MethodName: "RestartWorkerGroup",
+			Handler:    _ClusterService_RestartWorkerGroup_Handler,
+		},
+		{
+			MethodName: "GetWorkerGroupRestart",
+			Handler:    _ClusterService_GetWorkerGroupRestart_Handler,
+		},
+		{
+			MethodName: "DrainWorkerGroup",
+			Handler:    _ClusterService_DrainWorkerGroup_Handler,
+		},
+		{
+			MethodName: "GetWorkerGroupDrain",
+			Handler:    _ClusterService_GetWorkerGroupDrain_Handler,
+		},
+		{
+			MethodName: "GetClusterStatus",
+			Handler:    _ClusterService_GetClusterStatus_Handler,
+		},
+		{
+			MethodName: "TestRayClusterConnectivity",
+			Handler:    _ClusterService_TestRayClusterConnectivity_Handler,
+		},
+		{
+			MethodName: "GetRayClusterEndpoints",
+			Handler:    _ClusterService_GetRayClusterEndpoints_Handler,
+		},
+		{
+			MethodName: "ExportRayCluster",
+			Handler:    _ClusterService_ExportRayCluster_Handler,
+		},
+		{
+			MethodName: "CanSchedule",
+			Handler:    _ClusterService_CanSchedule_Handler,
+		},
+		{
+			MethodName: "InjectClusterFailure",
+			Handler:    _ClusterService_InjectClusterFailure_Handler,
+		},
+		{
+			MethodName: "HealClusterPartitions",
+			Handler:    _ClusterService_HealClusterPartitions_Handler,
+		},
+		{
+			MethodName: "CloneRayCluster",
+			Handler:    _ClusterService_CloneRayCluster_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchClusterStatus",
+			Handler:       _ClusterService_WatchClusterStatus_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "cluster.proto",
 }
@@ -23,6 +23,11 @@ type ComputeTemplateServiceClient interface {
 	CreateComputeTemplate(ctx context.Context, in *CreateComputeTemplateRequest, opts ...grpc.CallOption) (*ComputeTemplate, error)
 	// Finds a specific compute template by its name and namespace.
 	GetComputeTemplate(ctx context.Context, in *GetComputeTemplateRequest, opts ...grpc.CallOption) (*ComputeTemplate, error)
+	// Updates a compute template with a new revision. The clusters keep the revision they were created with until
+	// their worker groups are rolled forward with UpdateWorkerGroup.
+	UpdateComputeTemplate(ctx context.Context, in *UpdateComputeTemplateRequest, opts ...grpc.CallOption) (*ComputeTemplate, error)
+	// Finds all the revisions of a compute template, oldest first.
+	ListComputeTemplateRevisions(ctx context.Context, in *ListComputeTemplateRevisionsRequest, opts ...grpc.CallOption) (*ListComputeTemplateRevisionsResponse, error)
 	// Finds all compute templates in a given namespace. Supports pagination, and sorting on certain fields.
 	ListComputeTemplates(ctx context.Context, in *ListComputeTemplatesRequest, opts ...grpc.CallOption) (*ListComputeTemplatesResponse, error)
 	// Finds all compute templates in all namespaces. Supports pagination, and sorting on certain fields.
@@ -57,6 +62,24 @@ func (c *computeTemplateServiceClient) GetComputeTemplate(ctx context.Context, i
 	return out, nil
 }
 
+func (c *computeTemplateServiceClient) UpdateComputeTemplate(ctx context.Context, in *UpdateComputeTemplateRequest, opts ...grpc.CallOption) (*ComputeTemplate, error) {
+	out := new(ComputeTemplate)
+	err := c.cc.Invoke(ctx, "/proto.ComputeTemplateService/UpdateComputeTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *computeTemplateServiceClient) ListComputeTemplateRevisions(ctx context.Context, in *ListComputeTemplateRevisionsRequest, opts ...grpc.CallOption) (*ListComputeTemplateRevisionsResponse, error) {
+	out := new(ListComputeTemplateRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/proto.ComputeTemplateService/ListComputeTemplateRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *computeTemplateServiceClient) ListComputeTemplates(ctx context.Context, in *ListComputeTemplatesRequest, opts ...grpc.CallOption) (*ListComputeTemplatesResponse, error) {
 	out := new(ListComputeTemplatesResponse)
 	err := c.cc.Invoke(ctx, "/proto.ComputeTemplateService/ListComputeTemplates", in, out, opts...)
@@ -92,6 +115,11 @@ type ComputeTemplateServiceServer interface {
 	CreateComputeTemplate(context.Context, *CreateComputeTemplateRequest) (*ComputeTemplate, error)
 	// Finds a specific compute template by its name and namespace.
 	GetComputeTemplate(context.Context, *GetComputeTemplateRequest) (*ComputeTemplate, error)
+	// Updates a compute template with a new revision. The clusters keep the revision they were created with until
+	// their worker groups are rolled forward with UpdateWorkerGroup.
+	UpdateComputeTemplate(context.Context, *UpdateComputeTemplateRequest) (*ComputeTemplate, error)
+	// Finds all the revisions of a compute template, oldest first.
+	ListComputeTemplateRevisions(context.Context, *ListComputeTemplateRevisionsRequest) (*ListComputeTemplateRevisionsResponse, error)
 	// Finds all compute templates in a given namespace. Supports pagination, and sorting on certain fields.
 	ListComputeTemplates(context.Context, *ListComputeTemplatesRequest) (*ListComputeTemplatesResponse, error)
 	// Finds all compute templates in all namespaces. Supports pagination, and sorting on certain fields.
@@ -111,6 +139,12 @@ func (UnimplementedComputeTemplateServiceServer) CreateComputeTemplate(context.C
 func (UnimplementedComputeTemplateServiceServer) GetComputeTemplate(context.Context, *GetComputeTemplateRequest) (*ComputeTemplate, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetComputeTemplate not implemented")
 }
+func (UnimplementedComputeTemplateServiceServer) UpdateComputeTemplate(context.Context, *UpdateComputeTemplateRequest) (*ComputeTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComputeTemplate not implemented")
+}
+func (UnimplementedComputeTemplateServiceServer) ListComputeTemplateRevisions(context.Context, *ListComputeTemplateRevisionsRequest) (*ListComputeTemplateRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComputeTemplateRevisions not implemented")
+}
 func (UnimplementedComputeTemplateServiceServer) ListComputeTemplates(context.Context, *ListComputeTemplatesRequest) (*ListComputeTemplatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListComputeTemplates not implemented")
 }
@@ -170,6 +204,42 @@ func _ComputeTemplateService_GetComputeTemplate_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ComputeTemplateService_UpdateComputeTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateComputeTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComputeTemplateServiceServer).UpdateComputeTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ComputeTemplateService/UpdateComputeTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComputeTemplateServiceServer).UpdateComputeTemplate(ctx, req.(*UpdateComputeTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ComputeTemplateService_ListComputeTemplateRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListComputeTemplateRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComputeTemplateServiceServer).ListComputeTemplateRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ComputeTemplateService/ListComputeTemplateRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComputeTemplateServiceServer).ListComputeTemplateRevisions(ctx, req.(*ListComputeTemplateRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ComputeTemplateService_ListComputeTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListComputeTemplatesRequest)
 	if err := dec(in); err != nil {
@@ -239,6 +309,14 @@ var ComputeTemplateService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetComputeTemplate",
 			Handler:    _ComputeTemplateService_GetComputeTemplate_Handler,
 		},
+		{
+			MethodName: "UpdateComputeTemplate",
+			Handler:    _ComputeTemplateService_UpdateComputeTemplate_Handler,
+		},
+		{
+			MethodName: "ListComputeTemplateRevisions",
+			Handler:    _ComputeTemplateService_ListComputeTemplateRevisions_Handler,
+		},
 		{
 			MethodName: "ListComputeTemplates",
 			Handler:    _ComputeTemplateService_ListComputeTemplates_Handler,
@@ -21,6 +21,10 @@ const _ = grpc.SupportPackageIsVersion7
 type RayJobServiceClient interface {
 	// Creates a new job.
 	CreateRayJob(ctx context.Context, in *CreateRayJobRequest, opts ...grpc.CallOption) (*RayJob, error)
+	// Creates many jobs of a namespace in one call, for clients creating hundreds of jobs programmatically. The jobs are
+	// created concurrently, and one result is returned per job, in the order of the request, so that a job which can't
+	// be created does not fail the others.
+	BatchCreateRayJobs(ctx context.Context, in *BatchCreateRayJobsRequest, opts ...grpc.CallOption) (*BatchCreateRayJobsResponse, error)
 	// Finds a specific job by its name and namespace.
 	GetRayJob(ctx context.Context, in *GetRayJobRequest, opts ...grpc.CallOption) (*RayJob, error)
 	// Finds all job in a given namespace. Supports pagination, and sorting on certain fields.
@@ -29,6 +33,17 @@ type RayJobServiceClient interface {
 	ListAllRayJobs(ctx context.Context, in *ListAllRayJobsRequest, opts ...grpc.CallOption) (*ListAllRayJobsResponse, error)
 	// Deletes a job by its name and namespace.
 	DeleteRayJob(ctx context.Context, in *DeleteRayJobRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Streams the logs of the submitter pod and of the head pod of the ray cluster of a job and, optionally, of its
+	// worker pods, through the Kubernetes pod log API. The lines of the pods are interleaved.
+	StreamRayJobLogs(ctx context.Context, in *StreamRayJobLogsRequest, opts ...grpc.CallOption) (RayJobService_StreamRayJobLogsClient, error)
+	// Finds the output of a job: the logs of its driver and how its entrypoint exited, so that the results of a
+	// completed job can be read without access to the Kubernetes cluster. The driver logs are read from the Ray
+	// dashboard while the ray cluster of the job is ready, and from the logs of the submitter pod otherwise.
+	GetRayJobOutput(ctx context.Context, in *GetRayJobOutputRequest, opts ...grpc.CallOption) (*RayJobOutput, error)
+	// Finds the jobs archived by the API server, the most recently finished first. The API server archives the final
+	// status of the jobs it manages once they finish, and again when they are deleted, so that the jobs deleted by their
+	// time to live or by the operator can still be found. Requires the datastore of the API server.
+	ListArchivedRayJobs(ctx context.Context, in *ListArchivedRayJobsRequest, opts ...grpc.CallOption) (*ListArchivedRayJobsResponse, error)
 }
 
 type rayJobServiceClient struct {
@@ -48,6 +63,15 @@ func (c *rayJobServiceClient) CreateRayJob(ctx context.Context, in *CreateRayJob
 	return out, nil
 }
 
+func (c *rayJobServiceClient) BatchCreateRayJobs(ctx context.Context, in *BatchCreateRayJobsRequest, opts ...grpc.CallOption) (*BatchCreateRayJobsResponse, error) {
+	out := new(BatchCreateRayJobsResponse)
+	err := c.cc.Invoke(ctx, "/proto.RayJobService/BatchCreateRayJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rayJobServiceClient) GetRayJob(ctx context.Context, in *GetRayJobRequest, opts ...grpc.CallOption) (*RayJob, error) {
 	out := new(RayJob)
 	err := c.cc.Invoke(ctx, "/proto.RayJobService/GetRayJob", in, out, opts...)
@@ -84,12 +108,66 @@ func (c *rayJobServiceClient) DeleteRayJob(ctx context.Context, in *DeleteRayJob
 	return out, nil
 }
 
+func (c *rayJobServiceClient) StreamRayJobLogs(ctx context.Context, in *StreamRayJobLogsRequest, opts ...grpc.CallOption) (RayJobService_StreamRayJobLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RayJobService_ServiceDesc.Streams[0], "/proto.RayJobService/StreamRayJobLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rayJobServiceStreamRayJobLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RayJobService_StreamRayJobLogsClient interface {
+	Recv() (*PodLogLine, error)
+	grpc.ClientStream
+}
+
+type rayJobServiceStreamRayJobLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *rayJobServiceStreamRayJobLogsClient) Recv() (*PodLogLine, error) {
+	m := new(PodLogLine)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rayJobServiceClient) GetRayJobOutput(ctx context.Context, in *GetRayJobOutputRequest, opts ...grpc.CallOption) (*RayJobOutput, error) {
+	out := new(RayJobOutput)
+	err := c.cc.Invoke(ctx, "/proto.RayJobService/GetRayJobOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayJobServiceClient) ListArchivedRayJobs(ctx context.Context, in *ListArchivedRayJobsRequest, opts ...grpc.CallOption) (*ListArchivedRayJobsResponse, error) {
+	out := new(ListArchivedRayJobsResponse)
+	err := c.cc.Invoke(ctx, "/proto.RayJobService/ListArchivedRayJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RayJobServiceServer is the server API for RayJobService service.
 // All implementations must embed UnimplementedRayJobServiceServer
 // for forward compatibility
 type RayJobServiceServer interface {
 	// Creates a new job.
 	CreateRayJob(context.Context, *CreateRayJobRequest) (*RayJob, error)
+	// Creates many jobs of a namespace in one call, for clients creating hundreds of jobs programmatically. The jobs are
+	// created concurrently, and one result is returned per job, in the order of the request, so that a job which can't
+	// be created does not fail the others.
+	BatchCreateRayJobs(context.Context, *BatchCreateRayJobsRequest) (*BatchCreateRayJobsResponse, error)
 	// Finds a specific job by its name and namespace.
 	GetRayJob(context.Context, *GetRayJobRequest) (*RayJob, error)
 	// Finds all job in a given namespace. Supports pagination, and sorting on certain fields.
@@ -98,6 +176,17 @@ type RayJobServiceServer interface {
 	ListAllRayJobs(context.Context, *ListAllRayJobsRequest) (*ListAllRayJobsResponse, error)
 	// Deletes a job by its name and namespace.
 	DeleteRayJob(context.Context, *DeleteRayJobRequest) (*emptypb.Empty, error)
+	// Streams the logs of the submitter pod and of the head pod of the ray cluster of a job and, optionally, of its
+	// worker pods, through the Kubernetes pod log API. The lines of the pods are interleaved.
+	StreamRayJobLogs(*StreamRayJobLogsRequest, RayJobService_StreamRayJobLogsServer) error
+	// Finds the output of a job: the logs of its driver and how its entrypoint exited, so that the results of a
+	// completed job can be read without access to the Kubernetes cluster. The driver logs are read from the Ray
+	// dashboard while the ray cluster of the job is ready, and from the logs of the submitter pod otherwise.
+	GetRayJobOutput(context.Context, *GetRayJobOutputRequest) (*RayJobOutput, error)
+	// Finds the jobs archived by the API server, the most recently finished first. The API server archives the final
+	// status of the jobs it manages once they finish, and again when they are deleted, so that the jobs deleted by their
+	// time to live or by the operator can still be found. Requires the datastore of the API server.
+	ListArchivedRayJobs(context.Context, *ListArchivedRayJobsRequest) (*ListArchivedRayJobsResponse, error)
 	mustEmbedUnimplementedRayJobServiceServer()
 }
 
@@ -108,6 +197,9 @@ type UnimplementedRayJobServiceServer struct {
 func (UnimplementedRayJobServiceServer) CreateRayJob(context.Context, *CreateRayJobRequest) (*RayJob, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateRayJob not implemented")
 }
+func (UnimplementedRayJobServiceServer) BatchCreateRayJobs(context.Context, *BatchCreateRayJobsRequest) (*BatchCreateRayJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateRayJobs not implemented")
+}
 func (UnimplementedRayJobServiceServer) GetRayJob(context.Context, *GetRayJobRequest) (*RayJob, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRayJob not implemented")
 }
@@ -120,6 +212,15 @@ func (UnimplementedRayJobServiceServer) ListAllRayJobs(context.Context, *ListAll
 func (UnimplementedRayJobServiceServer) DeleteRayJob(context.Context, *DeleteRayJobRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteRayJob not implemented")
 }
+func (UnimplementedRayJobServiceServer) StreamRayJobLogs(*StreamRayJobLogsRequest, RayJobService_StreamRayJobLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRayJobLogs not implemented")
+}
+func (UnimplementedRayJobServiceServer) GetRayJobOutput(context.Context, *GetRayJobOutputRequest) (*RayJobOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRayJobOutput not implemented")
+}
+func (UnimplementedRayJobServiceServer) ListArchivedRayJobs(context.Context, *ListArchivedRayJobsRequest) (*ListArchivedRayJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArchivedRayJobs not implemented")
+}
 func (UnimplementedRayJobServiceServer) mustEmbedUnimplementedRayJobServiceServer() {}
 
 // UnsafeRayJobServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -151,6 +252,24 @@ func _RayJobService_CreateRayJob_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RayJobService_BatchCreateRayJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateRayJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayJobServiceServer).BatchCreateRayJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayJobService/BatchCreateRayJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayJobServiceServer).BatchCreateRayJobs(ctx, req.(*BatchCreateRayJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RayJobService_GetRayJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRayJobRequest)
 	if err := dec(in); err != nil {
@@ -223,6 +342,63 @@ func _RayJobService_DeleteRayJob_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RayJobService_StreamRayJobLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRayJobLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RayJobServiceServer).StreamRayJobLogs(m, &rayJobServiceStreamRayJobLogsServer{stream})
+}
+
+type RayJobService_StreamRayJobLogsServer interface {
+	Send(*PodLogLine) error
+	grpc.ServerStream
+}
+
+type rayJobServiceStreamRayJobLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *rayJobServiceStreamRayJobLogsServer) Send(m *PodLogLine) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RayJobService_GetRayJobOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRayJobOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayJobServiceServer).GetRayJobOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayJobService/GetRayJobOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayJobServiceServer).GetRayJobOutput(ctx, req.(*GetRayJobOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayJobService_ListArchivedRayJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArchivedRayJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayJobServiceServer).ListArchivedRayJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayJobService/ListArchivedRayJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayJobServiceServer).ListArchivedRayJobs(ctx, req.(*ListArchivedRayJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RayJobService_ServiceDesc is the grpc.ServiceDesc for RayJobService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -234,6 +410,10 @@ var RayJobService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateRayJob",
 			Handler:    _RayJobService_CreateRayJob_Handler,
 		},
+		{
+			MethodName: "BatchCreateRayJobs",
+			Handler:    _RayJobService_BatchCreateRayJobs_Handler,
+		},
 		{
 			MethodName: "GetRayJob",
 			Handler:    _RayJobService_GetRayJob_Handler,
@@ -250,7 +430,21 @@ var RayJobService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRayJob",
 			Handler:    _RayJobService_DeleteRayJob_Handler,
 		},
+		{
+			MethodName: "GetRayJobOutput",
+			Handler:    _RayJobService_GetRayJobOutput_Handler,
+		},
+		{
+			MethodName: "ListArchivedRayJobs",
+			Handler:    _RayJobService_ListArchivedRayJobs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRayJobLogs",
+			Handler:       _RayJobService_StreamRayJobLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "job.proto",
 }
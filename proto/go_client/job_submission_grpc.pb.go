@@ -31,6 +31,8 @@ type RayJobSubmissionServiceClient interface {
 	StopRayJob(ctx context.Context, in *StopRayJobSubmissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Deletes a job by its name and namespace.
 	DeleteRayJob(ctx context.Context, in *DeleteRayJobSubmissionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Uploads a zip of the working directory of jobs and returns the URI to use as working_dir in their runtime_env.
+	UploadJobWorkingDir(ctx context.Context, in *UploadJobWorkingDirRequest, opts ...grpc.CallOption) (*UploadJobWorkingDirReply, error)
 }
 
 type rayJobSubmissionServiceClient struct {
@@ -95,6 +97,15 @@ func (c *rayJobSubmissionServiceClient) DeleteRayJob(ctx context.Context, in *De
 	return out, nil
 }
 
+func (c *rayJobSubmissionServiceClient) UploadJobWorkingDir(ctx context.Context, in *UploadJobWorkingDirRequest, opts ...grpc.CallOption) (*UploadJobWorkingDirReply, error) {
+	out := new(UploadJobWorkingDirReply)
+	err := c.cc.Invoke(ctx, "/proto.RayJobSubmissionService/UploadJobWorkingDir", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RayJobSubmissionServiceServer is the server API for RayJobSubmissionService service.
 // All implementations must embed UnimplementedRayJobSubmissionServiceServer
 // for forward compatibility
@@ -111,6 +122,8 @@ type RayJobSubmissionServiceServer interface {
 	StopRayJob(context.Context, *StopRayJobSubmissionRequest) (*emptypb.Empty, error)
 	// Deletes a job by its name and namespace.
 	DeleteRayJob(context.Context, *DeleteRayJobSubmissionRequest) (*emptypb.Empty, error)
+	// Uploads a zip of the working directory of jobs and returns the URI to use as working_dir in their runtime_env.
+	UploadJobWorkingDir(context.Context, *UploadJobWorkingDirRequest) (*UploadJobWorkingDirReply, error)
 	mustEmbedUnimplementedRayJobSubmissionServiceServer()
 }
 
@@ -136,6 +149,9 @@ func (UnimplementedRayJobSubmissionServiceServer) StopRayJob(context.Context, *S
 func (UnimplementedRayJobSubmissionServiceServer) DeleteRayJob(context.Context, *DeleteRayJobSubmissionRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteRayJob not implemented")
 }
+func (UnimplementedRayJobSubmissionServiceServer) UploadJobWorkingDir(context.Context, *UploadJobWorkingDirRequest) (*UploadJobWorkingDirReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadJobWorkingDir not implemented")
+}
 func (UnimplementedRayJobSubmissionServiceServer) mustEmbedUnimplementedRayJobSubmissionServiceServer() {
 }
 
@@ -258,6 +274,24 @@ func _RayJobSubmissionService_DeleteRayJob_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RayJobSubmissionService_UploadJobWorkingDir_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadJobWorkingDirRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayJobSubmissionServiceServer).UploadJobWorkingDir(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayJobSubmissionService/UploadJobWorkingDir",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayJobSubmissionServiceServer).UploadJobWorkingDir(ctx, req.(*UploadJobWorkingDirRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RayJobSubmissionService_ServiceDesc is the grpc.ServiceDesc for RayJobSubmissionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +323,10 @@ var RayJobSubmissionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRayJob",
 			Handler:    _RayJobSubmissionService_DeleteRayJob_Handler,
 		},
+		{
+			MethodName: "UploadJobWorkingDir",
+			Handler:    _RayJobSubmissionService_UploadJobWorkingDir_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "job_submission.proto",
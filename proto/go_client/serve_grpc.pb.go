@@ -24,14 +24,68 @@ type RayServeServiceClient interface {
 	// Update a ray serve.
 	// We may
 	UpdateRayService(ctx context.Context, in *UpdateRayServiceRequest, opts ...grpc.CallOption) (*RayService, error)
+	// Previews an UpdateRayService call without applying it: returns the fields of the RayService custom resource it
+	// would change, and whether the operator would roll them out to the running ray cluster in place or replace it
+	// with a new ray cluster.
+	PreviewRayServiceUpdate(ctx context.Context, in *UpdateRayServiceRequest, opts ...grpc.CallOption) (*UpdatePreview, error)
+	// Update the serve config and the worker group replicas of a ray serve in place.
+	// Fields which are not set in the request are left unchanged.
+	UpdateRayServiceConfigs(ctx context.Context, in *UpdateRayServiceConfigsRequest, opts ...grpc.CallOption) (*RayService, error)
 	// Find a specific ray serve by name and namespace.
 	GetRayService(ctx context.Context, in *GetRayServiceRequest, opts ...grpc.CallOption) (*RayService, error)
+	// Returns the addresses of the Ray client server, the dashboard and the Serve HTTP proxy of a ray service, resolved
+	// from its Kubernetes Services and the Ingress of its active ray cluster. The addresses do not change when a new
+	// ray cluster is promoted.
+	GetRayServiceEndpoints(ctx context.Context, in *GetRayServiceEndpointsRequest, opts ...grpc.CallOption) (*RayEndpoints, error)
+	// Lists the changes made to a ray service through the API server, which are kept in the datastore of the API server
+	// even after the ray service is deleted.
+	ListRayServiceHistory(ctx context.Context, in *ListRayServiceHistoryRequest, opts ...grpc.CallOption) (*ListResourceHistoryResponse, error)
+	// Probes the Serve HTTP proxy of a ray service and the routes of its applications through the serve service, so that
+	// load balancers and deployment pipelines can wait for the applications to actually answer requests.
+	HealthCheckRayService(ctx context.Context, in *HealthCheckRayServiceRequest, opts ...grpc.CallOption) (*RayServiceHealth, error)
+	// Returns the serve config of a ray service, the config deployed on its active cluster according to the dashboard,
+	// and the differences between them, so that operators can detect the drift between the desired and the actual
+	// Serve applications.
+	GetRayServeConfig(ctx context.Context, in *GetRayServeConfigRequest, opts ...grpc.CallOption) (*RayServeConfig, error)
+	// Streams a specific ray service when the watch starts and every time its status changes, e.g. when
+	// it transitions between deploying, running and failed or the health of a Serve application changes.
+	// The stream ends when the ray service is deleted.
+	WatchRayService(ctx context.Context, in *WatchRayServiceRequest, opts ...grpc.CallOption) (RayServeService_WatchRayServiceClient, error)
+	// Exports a ray service as the YAML manifest of its RayService custom resource, as materialized on the Kubernetes
+	// cluster, e.g. to commit it to a GitOps repository.
+	ExportRayService(ctx context.Context, in *ExportRayServiceRequest, opts ...grpc.CallOption) (*ResourceManifest, error)
+	// Creates a ray service from the YAML manifest of a RayService custom resource, e.g. one exported by
+	// ExportRayService or kept in a GitOps repository. The manifest is converted to the API model and validated like
+	// a created ray service, so its Pod templates must reference compute templates.
+	ImportRayService(ctx context.Context, in *ImportRayServiceRequest, opts ...grpc.CallOption) (*RayService, error)
 	// Finds all ray services in a given namespace. Supports pagination, and sorting on certain fields.
 	ListRayServices(ctx context.Context, in *ListRayServicesRequest, opts ...grpc.CallOption) (*ListRayServicesResponse, error)
 	// Finds all ray services in a given namespace. Supports pagination, and sorting on certain fields.
 	ListAllRayServices(ctx context.Context, in *ListAllRayServicesRequest, opts ...grpc.CallOption) (*ListAllRayServicesResponse, error)
 	// Deletes a ray service by its name and namespace
 	DeleteRayService(ctx context.Context, in *DeleteRayServiceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Finds the progress of the deletion of a ray service, so that automation can wait until the ray service, its
+	// ray clusters and their Pods are gone before creating a ray service with the same name. A ray service which
+	// does not exist is reported as deleted.
+	GetRayServiceDeletionStatus(ctx context.Context, in *GetRayServiceDeletionStatusRequest, opts ...grpc.CallOption) (*RayServiceDeletionStatus, error)
+	// Suspends a ray service by scaling the worker groups of its ray clusters to zero, e.g. to park an idle service
+	// overnight. The head and the configuration of the ray service are kept.
+	SuspendRayService(ctx context.Context, in *SuspendRayServiceRequest, opts ...grpc.CallOption) (*RayService, error)
+	// Resumes a suspended ray service by scaling its worker groups back to the replicas they had when it was suspended.
+	ResumeRayService(ctx context.Context, in *ResumeRayServiceRequest, opts ...grpc.CallOption) (*RayService, error)
+	// Starts a staged upgrade of a ray service to a new definition which needs a new ray cluster. The pending ray
+	// cluster is prepared while the active ray cluster keeps serving traffic, and it is only promoted by
+	// PromoteRayServiceUpgrade once the caller has checked its serve applications.
+	StartRayServiceUpgrade(ctx context.Context, in *StartRayServiceUpgradeRequest, opts ...grpc.CallOption) (*RayService, error)
+	// Promotes the pending ray cluster of a staged upgrade once it is ready: it then serves the traffic and the previous
+	// ray cluster is deleted.
+	PromoteRayServiceUpgrade(ctx context.Context, in *PromoteRayServiceUpgradeRequest, opts ...grpc.CallOption) (*RayService, error)
+	// Rolls back a staged upgrade before it is promoted: the ray service is reverted to its definition before the
+	// upgrade, the active ray cluster keeps serving traffic and the pending ray cluster is deleted.
+	RollbackRayServiceUpgrade(ctx context.Context, in *RollbackRayServiceUpgradeRequest, opts ...grpc.CallOption) (*RayService, error)
+	// Streams the logs of the head pod of the ray cluster serving a ray service and, optionally, of its worker pods
+	// running the Serve replicas, through the Kubernetes pod log API. The lines of the pods are interleaved.
+	StreamRayServiceLogs(ctx context.Context, in *StreamRayServiceLogsRequest, opts ...grpc.CallOption) (RayServeService_StreamRayServiceLogsClient, error)
 }
 
 type rayServeServiceClient struct {
@@ -60,6 +114,24 @@ func (c *rayServeServiceClient) UpdateRayService(ctx context.Context, in *Update
 	return out, nil
 }
 
+func (c *rayServeServiceClient) PreviewRayServiceUpdate(ctx context.Context, in *UpdateRayServiceRequest, opts ...grpc.CallOption) (*UpdatePreview, error) {
+	out := new(UpdatePreview)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/PreviewRayServiceUpdate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) UpdateRayServiceConfigs(ctx context.Context, in *UpdateRayServiceConfigsRequest, opts ...grpc.CallOption) (*RayService, error) {
+	out := new(RayService)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/UpdateRayServiceConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rayServeServiceClient) GetRayService(ctx context.Context, in *GetRayServiceRequest, opts ...grpc.CallOption) (*RayService, error) {
 	out := new(RayService)
 	err := c.cc.Invoke(ctx, "/proto.RayServeService/GetRayService", in, out, opts...)
@@ -69,6 +141,92 @@ func (c *rayServeServiceClient) GetRayService(ctx context.Context, in *GetRaySer
 	return out, nil
 }
 
+func (c *rayServeServiceClient) GetRayServiceEndpoints(ctx context.Context, in *GetRayServiceEndpointsRequest, opts ...grpc.CallOption) (*RayEndpoints, error) {
+	out := new(RayEndpoints)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/GetRayServiceEndpoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) ListRayServiceHistory(ctx context.Context, in *ListRayServiceHistoryRequest, opts ...grpc.CallOption) (*ListResourceHistoryResponse, error) {
+	out := new(ListResourceHistoryResponse)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/ListRayServiceHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) HealthCheckRayService(ctx context.Context, in *HealthCheckRayServiceRequest, opts ...grpc.CallOption) (*RayServiceHealth, error) {
+	out := new(RayServiceHealth)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/HealthCheckRayService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) GetRayServeConfig(ctx context.Context, in *GetRayServeConfigRequest, opts ...grpc.CallOption) (*RayServeConfig, error) {
+	out := new(RayServeConfig)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/GetRayServeConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) WatchRayService(ctx context.Context, in *WatchRayServiceRequest, opts ...grpc.CallOption) (RayServeService_WatchRayServiceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RayServeService_ServiceDesc.Streams[0], "/proto.RayServeService/WatchRayService", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rayServeServiceWatchRayServiceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RayServeService_WatchRayServiceClient interface {
+	Recv() (*RayService, error)
+	grpc.ClientStream
+}
+
+type rayServeServiceWatchRayServiceClient struct {
+	grpc.ClientStream
+}
+
+func (x *rayServeServiceWatchRayServiceClient) Recv() (*RayService, error) {
+	m := new(RayService)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rayServeServiceClient) ExportRayService(ctx context.Context, in *ExportRayServiceRequest, opts ...grpc.CallOption) (*ResourceManifest, error) {
+	out := new(ResourceManifest)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/ExportRayService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) ImportRayService(ctx context.Context, in *ImportRayServiceRequest, opts ...grpc.CallOption) (*RayService, error) {
+	out := new(RayService)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/ImportRayService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rayServeServiceClient) ListRayServices(ctx context.Context, in *ListRayServicesRequest, opts ...grpc.CallOption) (*ListRayServicesResponse, error) {
 	out := new(ListRayServicesResponse)
 	err := c.cc.Invoke(ctx, "/proto.RayServeService/ListRayServices", in, out, opts...)
@@ -96,6 +254,92 @@ func (c *rayServeServiceClient) DeleteRayService(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *rayServeServiceClient) GetRayServiceDeletionStatus(ctx context.Context, in *GetRayServiceDeletionStatusRequest, opts ...grpc.CallOption) (*RayServiceDeletionStatus, error) {
+	out := new(RayServiceDeletionStatus)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/GetRayServiceDeletionStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) SuspendRayService(ctx context.Context, in *SuspendRayServiceRequest, opts ...grpc.CallOption) (*RayService, error) {
+	out := new(RayService)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/SuspendRayService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) ResumeRayService(ctx context.Context, in *ResumeRayServiceRequest, opts ...grpc.CallOption) (*RayService, error) {
+	out := new(RayService)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/ResumeRayService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) StartRayServiceUpgrade(ctx context.Context, in *StartRayServiceUpgradeRequest, opts ...grpc.CallOption) (*RayService, error) {
+	out := new(RayService)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/StartRayServiceUpgrade", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) PromoteRayServiceUpgrade(ctx context.Context, in *PromoteRayServiceUpgradeRequest, opts ...grpc.CallOption) (*RayService, error) {
+	out := new(RayService)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/PromoteRayServiceUpgrade", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) RollbackRayServiceUpgrade(ctx context.Context, in *RollbackRayServiceUpgradeRequest, opts ...grpc.CallOption) (*RayService, error) {
+	out := new(RayService)
+	err := c.cc.Invoke(ctx, "/proto.RayServeService/RollbackRayServiceUpgrade", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServeServiceClient) StreamRayServiceLogs(ctx context.Context, in *StreamRayServiceLogsRequest, opts ...grpc.CallOption) (RayServeService_StreamRayServiceLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RayServeService_ServiceDesc.Streams[1], "/proto.RayServeService/StreamRayServiceLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rayServeServiceStreamRayServiceLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RayServeService_StreamRayServiceLogsClient interface {
+	Recv() (*PodLogLine, error)
+	grpc.ClientStream
+}
+
+type rayServeServiceStreamRayServiceLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *rayServeServiceStreamRayServiceLogsClient) Recv() (*PodLogLine, error) {
+	m := new(PodLogLine)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RayServeServiceServer is the server API for RayServeService service.
 // All implementations must embed UnimplementedRayServeServiceServer
 // for forward compatibility
@@ -105,14 +349,68 @@ type RayServeServiceServer interface {
 	// Update a ray serve.
 	// We may
 	UpdateRayService(context.Context, *UpdateRayServiceRequest) (*RayService, error)
+	// Previews an UpdateRayService call without applying it: returns the fields of the RayService custom resource it
+	// would change, and whether the operator would roll them out to the running ray cluster in place or replace it
+	// with a new ray cluster.
+	PreviewRayServiceUpdate(context.Context, *UpdateRayServiceRequest) (*UpdatePreview, error)
+	// Update the serve config and the worker group replicas of a ray serve in place.
+	// Fields which are not set in the request are left unchanged.
+	UpdateRayServiceConfigs(context.Context, *UpdateRayServiceConfigsRequest) (*RayService, error)
 	// Find a specific ray serve by name and namespace.
 	GetRayService(context.Context, *GetRayServiceRequest) (*RayService, error)
+	// Returns the addresses of the Ray client server, the dashboard and the Serve HTTP proxy of a ray service, resolved
+	// from its Kubernetes Services and the Ingress of its active ray cluster. The addresses do not change when a new
+	// ray cluster is promoted.
+	GetRayServiceEndpoints(context.Context, *GetRayServiceEndpointsRequest) (*RayEndpoints, error)
+	// Lists the changes made to a ray service through the API server, which are kept in the datastore of the API server
+	// even after the ray service is deleted.
+	ListRayServiceHistory(context.Context, *ListRayServiceHistoryRequest) (*ListResourceHistoryResponse, error)
+	// Probes the Serve HTTP proxy of a ray service and the routes of its applications through the serve service, so that
+	// load balancers and deployment pipelines can wait for the applications to actually answer requests.
+	HealthCheckRayService(context.Context, *HealthCheckRayServiceRequest) (*RayServiceHealth, error)
+	// Returns the serve config of a ray service, the config deployed on its active cluster according to the dashboard,
+	// and the differences between them, so that operators can detect the drift between the desired and the actual
+	// Serve applications.
+	GetRayServeConfig(context.Context, *GetRayServeConfigRequest) (*RayServeConfig, error)
+	// Streams a specific ray service when the watch starts and every time its status changes, e.g. when
+	// it transitions between deploying, running and failed or the health of a Serve application changes.
+	// The stream ends when the ray service is deleted.
+	WatchRayService(*WatchRayServiceRequest, RayServeService_WatchRayServiceServer) error
+	// Exports a ray service as the YAML manifest of its RayService custom resource, as materialized on the Kubernetes
+	// cluster, e.g. to commit it to a GitOps repository.
+	ExportRayService(context.Context, *ExportRayServiceRequest) (*ResourceManifest, error)
+	// Creates a ray service from the YAML manifest of a RayService custom resource, e.g. one exported by
+	// ExportRayService or kept in a GitOps repository. The manifest is converted to the API model and validated like
+	// a created ray service, so its Pod templates must reference compute templates.
+	ImportRayService(context.Context, *ImportRayServiceRequest) (*RayService, error)
 	// Finds all ray services in a given namespace. Supports pagination, and sorting on certain fields.
 	ListRayServices(context.Context, *ListRayServicesRequest) (*ListRayServicesResponse, error)
 	// Finds all ray services in a given namespace. Supports pagination, and sorting on certain fields.
 	ListAllRayServices(context.Context, *ListAllRayServicesRequest) (*ListAllRayServicesResponse, error)
 	// Deletes a ray service by its name and namespace
 	DeleteRayService(context.Context, *DeleteRayServiceRequest) (*emptypb.Empty, error)
+	// Finds the progress of the deletion of a ray service, so that automation can wait until the ray service, its
+	// ray clusters and their Pods are gone before creating a ray service with the same name. A ray service which
+	// does not exist is reported as deleted.
+	GetRayServiceDeletionStatus(context.Context, *GetRayServiceDeletionStatusRequest) (*RayServiceDeletionStatus, error)
+	// Suspends a ray service by scaling the worker groups of its ray clusters to zero, e.g. to park an idle service
+	// overnight. The head and the configuration of the ray service are kept.
+	SuspendRayService(context.Context, *SuspendRayServiceRequest) (*RayService, error)
+	// Resumes a suspended ray service by scaling its worker groups back to the replicas they had when it was suspended.
+	ResumeRayService(context.Context, *ResumeRayServiceRequest) (*RayService, error)
+	// Starts a staged upgrade of a ray service to a new definition which needs a new ray cluster. The pending ray
+	// cluster is prepared while the active ray cluster keeps serving traffic, and it is only promoted by
+	// PromoteRayServiceUpgrade once the caller has checked its serve applications.
+	StartRayServiceUpgrade(context.Context, *StartRayServiceUpgradeRequest) (*RayService, error)
+	// Promotes the pending ray cluster of a staged upgrade once it is ready: it then serves the traffic and the previous
+	// ray cluster is deleted.
+	PromoteRayServiceUpgrade(context.Context, *PromoteRayServiceUpgradeRequest) (*RayService, error)
+	// Rolls back a staged upgrade before it is promoted: the ray service is reverted to its definition before the
+	// upgrade, the active ray cluster keeps serving traffic and the pending ray cluster is deleted.
+	RollbackRayServiceUpgrade(context.Context, *RollbackRayServiceUpgradeRequest) (*RayService, error)
+	// Streams the logs of the head pod of the ray cluster serving a ray service and, optionally, of its worker pods
+	// running the Serve replicas, through the Kubernetes pod log API. The lines of the pods are interleaved.
+	StreamRayServiceLogs(*StreamRayServiceLogsRequest, RayServeService_StreamRayServiceLogsServer) error
 	mustEmbedUnimplementedRayServeServiceServer()
 }
 
@@ -126,9 +424,36 @@ func (UnimplementedRayServeServiceServer) CreateRayService(context.Context, *Cre
 func (UnimplementedRayServeServiceServer) UpdateRayService(context.Context, *UpdateRayServiceRequest) (*RayService, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateRayService not implemented")
 }
+func (UnimplementedRayServeServiceServer) PreviewRayServiceUpdate(context.Context, *UpdateRayServiceRequest) (*UpdatePreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewRayServiceUpdate not implemented")
+}
+func (UnimplementedRayServeServiceServer) UpdateRayServiceConfigs(context.Context, *UpdateRayServiceConfigsRequest) (*RayService, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRayServiceConfigs not implemented")
+}
 func (UnimplementedRayServeServiceServer) GetRayService(context.Context, *GetRayServiceRequest) (*RayService, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRayService not implemented")
 }
+func (UnimplementedRayServeServiceServer) GetRayServiceEndpoints(context.Context, *GetRayServiceEndpointsRequest) (*RayEndpoints, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRayServiceEndpoints not implemented")
+}
+func (UnimplementedRayServeServiceServer) ListRayServiceHistory(context.Context, *ListRayServiceHistoryRequest) (*ListResourceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRayServiceHistory not implemented")
+}
+func (UnimplementedRayServeServiceServer) HealthCheckRayService(context.Context, *HealthCheckRayServiceRequest) (*RayServiceHealth, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HealthCheckRayService not implemented")
+}
+func (UnimplementedRayServeServiceServer) GetRayServeConfig(context.Context, *GetRayServeConfigRequest) (*RayServeConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRayServeConfig not implemented")
+}
+func (UnimplementedRayServeServiceServer) WatchRayService(*WatchRayServiceRequest, RayServeService_WatchRayServiceServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRayService not implemented")
+}
+func (UnimplementedRayServeServiceServer) ExportRayService(context.Context, *ExportRayServiceRequest) (*ResourceManifest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportRayService not implemented")
+}
+func (UnimplementedRayServeServiceServer) ImportRayService(context.Context, *ImportRayServiceRequest) (*RayService, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportRayService not implemented")
+}
 func (UnimplementedRayServeServiceServer) ListRayServices(context.Context, *ListRayServicesRequest) (*ListRayServicesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRayServices not implemented")
 }
@@ -138,6 +463,27 @@ func (UnimplementedRayServeServiceServer) ListAllRayServices(context.Context, *L
 func (UnimplementedRayServeServiceServer) DeleteRayService(context.Context, *DeleteRayServiceRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteRayService not implemented")
 }
+func (UnimplementedRayServeServiceServer) GetRayServiceDeletionStatus(context.Context, *GetRayServiceDeletionStatusRequest) (*RayServiceDeletionStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRayServiceDeletionStatus not implemented")
+}
+func (UnimplementedRayServeServiceServer) SuspendRayService(context.Context, *SuspendRayServiceRequest) (*RayService, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendRayService not implemented")
+}
+func (UnimplementedRayServeServiceServer) ResumeRayService(context.Context, *ResumeRayServiceRequest) (*RayService, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeRayService not implemented")
+}
+func (UnimplementedRayServeServiceServer) StartRayServiceUpgrade(context.Context, *StartRayServiceUpgradeRequest) (*RayService, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartRayServiceUpgrade not implemented")
+}
+func (UnimplementedRayServeServiceServer) PromoteRayServiceUpgrade(context.Context, *PromoteRayServiceUpgradeRequest) (*RayService, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteRayServiceUpgrade not implemented")
+}
+func (UnimplementedRayServeServiceServer) RollbackRayServiceUpgrade(context.Context, *RollbackRayServiceUpgradeRequest) (*RayService, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackRayServiceUpgrade not implemented")
+}
+func (UnimplementedRayServeServiceServer) StreamRayServiceLogs(*StreamRayServiceLogsRequest, RayServeService_StreamRayServiceLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRayServiceLogs not implemented")
+}
 func (UnimplementedRayServeServiceServer) mustEmbedUnimplementedRayServeServiceServer() {}
 
 // UnsafeRayServeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -187,6 +533,42 @@ func _RayServeService_UpdateRayService_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RayServeService_PreviewRayServiceUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRayServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).PreviewRayServiceUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/PreviewRayServiceUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).PreviewRayServiceUpdate(ctx, req.(*UpdateRayServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_UpdateRayServiceConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRayServiceConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).UpdateRayServiceConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/UpdateRayServiceConfigs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).UpdateRayServiceConfigs(ctx, req.(*UpdateRayServiceConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RayServeService_GetRayService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetRayServiceRequest)
 	if err := dec(in); err != nil {
@@ -205,6 +587,135 @@ func _RayServeService_GetRayService_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RayServeService_GetRayServiceEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRayServiceEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).GetRayServiceEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/GetRayServiceEndpoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).GetRayServiceEndpoints(ctx, req.(*GetRayServiceEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_ListRayServiceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRayServiceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).ListRayServiceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/ListRayServiceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).ListRayServiceHistory(ctx, req.(*ListRayServiceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_HealthCheckRayService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthCheckRayServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).HealthCheckRayService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/HealthCheckRayService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).HealthCheckRayService(ctx, req.(*HealthCheckRayServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_GetRayServeConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRayServeConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).GetRayServeConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/GetRayServeConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).GetRayServeConfig(ctx, req.(*GetRayServeConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_WatchRayService_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRayServiceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RayServeServiceServer).WatchRayService(m, &rayServeServiceWatchRayServiceServer{stream})
+}
+
+type RayServeService_WatchRayServiceServer interface {
+	Send(*RayService) error
+	grpc.ServerStream
+}
+
+type rayServeServiceWatchRayServiceServer struct {
+	grpc.ServerStream
+}
+
+func (x *rayServeServiceWatchRayServiceServer) Send(m *RayService) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RayServeService_ExportRayService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRayServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).ExportRayService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/ExportRayService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).ExportRayService(ctx, req.(*ExportRayServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_ImportRayService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRayServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).ImportRayService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/ImportRayService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).ImportRayService(ctx, req.(*ImportRayServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RayServeService_ListRayServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListRayServicesRequest)
 	if err := dec(in); err != nil {
@@ -259,6 +770,135 @@ func _RayServeService_DeleteRayService_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RayServeService_GetRayServiceDeletionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRayServiceDeletionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).GetRayServiceDeletionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/GetRayServiceDeletionStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).GetRayServiceDeletionStatus(ctx, req.(*GetRayServiceDeletionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_SuspendRayService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendRayServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).SuspendRayService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/SuspendRayService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).SuspendRayService(ctx, req.(*SuspendRayServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_ResumeRayService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRayServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).ResumeRayService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/ResumeRayService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).ResumeRayService(ctx, req.(*ResumeRayServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_StartRayServiceUpgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRayServiceUpgradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).StartRayServiceUpgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/StartRayServiceUpgrade",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).StartRayServiceUpgrade(ctx, req.(*StartRayServiceUpgradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_PromoteRayServiceUpgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteRayServiceUpgradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).PromoteRayServiceUpgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/PromoteRayServiceUpgrade",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).PromoteRayServiceUpgrade(ctx, req.(*PromoteRayServiceUpgradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_RollbackRayServiceUpgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRayServiceUpgradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServeServiceServer).RollbackRayServiceUpgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServeService/RollbackRayServiceUpgrade",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServeServiceServer).RollbackRayServiceUpgrade(ctx, req.(*RollbackRayServiceUpgradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServeService_StreamRayServiceLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRayServiceLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RayServeServiceServer).StreamRayServiceLogs(m, &rayServeServiceStreamRayServiceLogsServer{stream})
+}
+
+type RayServeService_StreamRayServiceLogsServer interface {
+	Send(*PodLogLine) error
+	grpc.ServerStream
+}
+
+type rayServeServiceStreamRayServiceLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *rayServeServiceStreamRayServiceLogsServer) Send(m *PodLogLine) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // RayServeService_ServiceDesc is the grpc.ServiceDesc for RayServeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -274,10 +914,42 @@ var RayServeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateRayService",
 			Handler:    _RayServeService_UpdateRayService_Handler,
 		},
+		{
+			MethodName: "PreviewRayServiceUpdate",
+			Handler:    _RayServeService_PreviewRayServiceUpdate_Handler,
+		},
+		{
+			MethodName: "UpdateRayServiceConfigs",
+			Handler:    _RayServeService_UpdateRayServiceConfigs_Handler,
+		},
 		{
 			MethodName: "GetRayService",
 			Handler:    _RayServeService_GetRayService_Handler,
 		},
+		{
+			MethodName: "GetRayServiceEndpoints",
+			Handler:    _RayServeService_GetRayServiceEndpoints_Handler,
+		},
+		{
+			MethodName: "ListRayServiceHistory",
+			Handler:    _RayServeService_ListRayServiceHistory_Handler,
+		},
+		{
+			MethodName: "HealthCheckRayService",
+			Handler:    _RayServeService_HealthCheckRayService_Handler,
+		},
+		{
+			MethodName: "GetRayServeConfig",
+			Handler:    _RayServeService_GetRayServeConfig_Handler,
+		},
+		{
+			MethodName: "ExportRayService",
+			Handler:    _RayServeService_ExportRayService_Handler,
+		},
+		{
+			MethodName: "ImportRayService",
+			Handler:    _RayServeService_ImportRayService_Handler,
+		},
 		{
 			MethodName: "ListRayServices",
 			Handler:    _RayServeService_ListRayServices_Handler,
@@ -290,7 +962,42 @@ var RayServeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRayService",
 			Handler:    _RayServeService_DeleteRayService_Handler,
 		},
+		{
+			MethodName: "GetRayServiceDeletionStatus",
+			Handler:    _RayServeService_GetRayServiceDeletionStatus_Handler,
+		},
+		{
+			MethodName: "SuspendRayService",
+			Handler:    _RayServeService_SuspendRayService_Handler,
+		},
+		{
+			MethodName: "ResumeRayService",
+			Handler:    _RayServeService_ResumeRayService_Handler,
+		},
+		{
+			MethodName: "StartRayServiceUpgrade",
+			Handler:    _RayServeService_StartRayServiceUpgrade_Handler,
+		},
+		{
+			MethodName: "PromoteRayServiceUpgrade",
+			Handler:    _RayServeService_PromoteRayServiceUpgrade_Handler,
+		},
+		{
+			MethodName: "RollbackRayServiceUpgrade",
+			Handler:    _RayServeService_RollbackRayServiceUpgrade_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRayService",
+			Handler:       _RayServeService_WatchRayService_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamRayServiceLogs",
+			Handler:       _RayServeService_StreamRayServiceLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "serve.proto",
 }
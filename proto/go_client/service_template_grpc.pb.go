@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package go_client
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ServiceTemplateServiceClient is the client API for ServiceTemplateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ServiceTemplateServiceClient interface {
+	// Creates a new service template, a reusable ray service definition.
+	CreateServiceTemplate(ctx context.Context, in *CreateServiceTemplateRequest, opts ...grpc.CallOption) (*ServiceTemplate, error)
+	// Finds a specific service template by its name and namespace.
+	GetServiceTemplate(ctx context.Context, in *GetServiceTemplateRequest, opts ...grpc.CallOption) (*ServiceTemplate, error)
+	// Finds all service templates in a given namespace.
+	ListServiceTemplates(ctx context.Context, in *ListServiceTemplatesRequest, opts ...grpc.CallOption) (*ListServiceTemplatesResponse, error)
+	// Finds all service templates in all namespaces.
+	ListAllServiceTemplates(ctx context.Context, in *ListAllServiceTemplatesRequest, opts ...grpc.CallOption) (*ListAllServiceTemplatesResponse, error)
+	// Deletes a service template by its name and namespace. The ray services created from it are kept.
+	DeleteServiceTemplate(ctx context.Context, in *DeleteServiceTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Creates a ray service from a service template, with the overrides of the request.
+	CreateRayServiceFromTemplate(ctx context.Context, in *CreateRayServiceFromTemplateRequest, opts ...grpc.CallOption) (*RayService, error)
+}
+
+type serviceTemplateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewServiceTemplateServiceClient(cc grpc.ClientConnInterface) ServiceTemplateServiceClient {
+	return &serviceTemplateServiceClient{cc}
+}
+
+func (c *serviceTemplateServiceClient) CreateServiceTemplate(ctx context.Context, in *CreateServiceTemplateRequest, opts ...grpc.CallOption) (*ServiceTemplate, error) {
+	out := new(ServiceTemplate)
+	err := c.cc.Invoke(ctx, "/proto.ServiceTemplateService/CreateServiceTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceTemplateServiceClient) GetServiceTemplate(ctx context.Context, in *GetServiceTemplateRequest, opts ...grpc.CallOption) (*ServiceTemplate, error) {
+	out := new(ServiceTemplate)
+	err := c.cc.Invoke(ctx, "/proto.ServiceTemplateService/GetServiceTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceTemplateServiceClient) ListServiceTemplates(ctx context.Context, in *ListServiceTemplatesRequest, opts ...grpc.CallOption) (*ListServiceTemplatesResponse, error) {
+	out := new(ListServiceTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/proto.ServiceTemplateService/ListServiceTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceTemplateServiceClient) ListAllServiceTemplates(ctx context.Context, in *ListAllServiceTemplatesRequest, opts ...grpc.CallOption) (*ListAllServiceTemplatesResponse, error) {
+	out := new(ListAllServiceTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/proto.ServiceTemplateService/ListAllServiceTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceTemplateServiceClient) DeleteServiceTemplate(ctx context.Context, in *DeleteServiceTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.ServiceTemplateService/DeleteServiceTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceTemplateServiceClient) CreateRayServiceFromTemplate(ctx context.Context, in *CreateRayServiceFromTemplateRequest, opts ...grpc.CallOption) (*RayService, error) {
+	out := new(RayService)
+	err := c.cc.Invoke(ctx, "/proto.ServiceTemplateService/CreateRayServiceFromTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ServiceTemplateServiceServer is the server API for ServiceTemplateService service.
+// All implementations must embed UnimplementedServiceTemplateServiceServer
+// for forward compatibility
+type ServiceTemplateServiceServer interface {
+	// Creates a new service template, a reusable ray service definition.
+	CreateServiceTemplate(context.Context, *CreateServiceTemplateRequest) (*ServiceTemplate, error)
+	// Finds a specific service template by its name and namespace.
+	GetServiceTemplate(context.Context, *GetServiceTemplateRequest) (*ServiceTemplate, error)
+	// Finds all service templates in a given namespace.
+	ListServiceTemplates(context.Context, *ListServiceTemplatesRequest) (*ListServiceTemplatesResponse, error)
+	// Finds all service templates in all namespaces.
+	ListAllServiceTemplates(context.Context, *ListAllServiceTemplatesRequest) (*ListAllServiceTemplatesResponse, error)
+	// Deletes a service template by its name and namespace. The ray services created from it are kept.
+	DeleteServiceTemplate(context.Context, *DeleteServiceTemplateRequest) (*emptypb.Empty, error)
+	// Creates a ray service from a service template, with the overrides of the request.
+	CreateRayServiceFromTemplate(context.Context, *CreateRayServiceFromTemplateRequest) (*RayService, error)
+	mustEmbedUnimplementedServiceTemplateServiceServer()
+}
+
+// UnimplementedServiceTemplateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedServiceTemplateServiceServer struct {
+}
+
+func (UnimplementedServiceTemplateServiceServer) CreateServiceTemplate(context.Context, *CreateServiceTemplateRequest) (*ServiceTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceTemplate not implemented")
+}
+func (UnimplementedServiceTemplateServiceServer) GetServiceTemplate(context.Context, *GetServiceTemplateRequest) (*ServiceTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceTemplate not implemented")
+}
+func (UnimplementedServiceTemplateServiceServer) ListServiceTemplates(context.Context, *ListServiceTemplatesRequest) (*ListServiceTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServiceTemplates not implemented")
+}
+func (UnimplementedServiceTemplateServiceServer) ListAllServiceTemplates(context.Context, *ListAllServiceTemplatesRequest) (*ListAllServiceTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAllServiceTemplates not implemented")
+}
+func (UnimplementedServiceTemplateServiceServer) DeleteServiceTemplate(context.Context, *DeleteServiceTemplateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteServiceTemplate not implemented")
+}
+func (UnimplementedServiceTemplateServiceServer) CreateRayServiceFromTemplate(context.Context, *CreateRayServiceFromTemplateRequest) (*RayService, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRayServiceFromTemplate not implemented")
+}
+func (UnimplementedServiceTemplateServiceServer) mustEmbedUnimplementedServiceTemplateServiceServer() {
+}
+
+// UnsafeServiceTemplateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ServiceTemplateServiceServer will
+// result in compilation errors.
+type UnsafeServiceTemplateServiceServer interface {
+	mustEmbedUnimplementedServiceTemplateServiceServer()
+}
+
+func RegisterServiceTemplateServiceServer(s grpc.ServiceRegistrar, srv ServiceTemplateServiceServer) {
+	s.RegisterService(&ServiceTemplateService_ServiceDesc, srv)
+}
+
+func _ServiceTemplateService_CreateServiceTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceTemplateServiceServer).CreateServiceTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ServiceTemplateService/CreateServiceTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceTemplateServiceServer).CreateServiceTemplate(ctx, req.(*CreateServiceTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceTemplateService_GetServiceTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceTemplateServiceServer).GetServiceTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ServiceTemplateService/GetServiceTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceTemplateServiceServer).GetServiceTemplate(ctx, req.(*GetServiceTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceTemplateService_ListServiceTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServiceTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceTemplateServiceServer).ListServiceTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ServiceTemplateService/ListServiceTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceTemplateServiceServer).ListServiceTemplates(ctx, req.(*ListServiceTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceTemplateService_ListAllServiceTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAllServiceTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceTemplateServiceServer).ListAllServiceTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ServiceTemplateService/ListAllServiceTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceTemplateServiceServer).ListAllServiceTemplates(ctx, req.(*ListAllServiceTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceTemplateService_DeleteServiceTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServiceTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceTemplateServiceServer).DeleteServiceTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ServiceTemplateService/DeleteServiceTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceTemplateServiceServer).DeleteServiceTemplate(ctx, req.(*DeleteServiceTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServiceTemplateService_CreateRayServiceFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRayServiceFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceTemplateServiceServer).CreateRayServiceFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ServiceTemplateService/CreateRayServiceFromTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceTemplateServiceServer).CreateRayServiceFromTemplate(ctx, req.(*CreateRayServiceFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ServiceTemplateService_ServiceDesc is the grpc.ServiceDesc for ServiceTemplateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ServiceTemplateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ServiceTemplateService",
+	HandlerType: (*ServiceTemplateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateServiceTemplate",
+			Handler:    _ServiceTemplateService_CreateServiceTemplate_Handler,
+		},
+		{
+			MethodName: "GetServiceTemplate",
+			Handler:    _ServiceTemplateService_GetServiceTemplate_Handler,
+		},
+		{
+			MethodName: "ListServiceTemplates",
+			Handler:    _ServiceTemplateService_ListServiceTemplates_Handler,
+		},
+		{
+			MethodName: "ListAllServiceTemplates",
+			Handler:    _ServiceTemplateService_ListAllServiceTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteServiceTemplate",
+			Handler:    _ServiceTemplateService_DeleteServiceTemplate_Handler,
+		},
+		{
+			MethodName: "CreateRayServiceFromTemplate",
+			Handler:    _ServiceTemplateService_CreateRayServiceFromTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service_template.proto",
+}
@@ -28,5 +28,14 @@ jq -s 'reduce .[] as $item ({}; . * $item) | .info.title = "KubeRay API" | .info
   /go/src/github.com/ray-project/kuberay/proto/swagger/config.swagger.json \
   /go/src/github.com/ray-project/kuberay/proto/swagger/error.swagger.json \
   /go/src/github.com/ray-project/kuberay/proto/swagger/job.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/job_submission.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/cron_job.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/backup.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/fleet.swagger.json \
   /go/src/github.com/ray-project/kuberay/proto/swagger/serve.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/service_template.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/notification.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/namespace.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/session.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/apiserver_info.swagger.json \
   > "/go/src/github.com/ray-project/kuberay/proto/kuberay_api.swagger.json"
@@ -0,0 +1,10 @@
+// Package proto embeds the OpenAPI specification generated from the KubeRay API protos.
+package proto
+
+import _ "embed"
+
+// OpenAPISpec is the OpenAPI v2 specification of all the services of the KubeRay API, merged from the swagger files
+// generated by hack/generate.sh, so that it can be served without the files being present on disk.
+//
+//go:embed kuberay_api.swagger.json
+var OpenAPISpec []byte
@@ -0,0 +1,78 @@
+package proto
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	_ "github.com/ray-project/kuberay/proto/go_client"
+)
+
+var pathParameter = regexp.MustCompile(`\{[^}]*\}`)
+
+// TestOpenAPISpecCoversAllMethods checks that every method of the KubeRay API has a REST mapping, and that the mapping
+// is documented in the embedded OpenAPI specification.
+func TestOpenAPISpecCoversAllMethods(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(OpenAPISpec, &spec); err != nil {
+		t.Fatalf("Failed to parse the OpenAPI specification: %v", err)
+	}
+	// The path parameters are named in camel case in the specification, so only the shape of the paths is compared.
+	operations := map[string]bool{}
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			operations[method+" "+pathParameter.ReplaceAllString(path, "{}")] = true
+		}
+	}
+
+	methods := 0
+	protoregistry.GlobalFiles.RangeFilesByPackage("proto", func(file protoreflect.FileDescriptor) bool {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			serviceMethods := services.Get(i).Methods()
+			for j := 0; j < serviceMethods.Len(); j++ {
+				methods++
+				method := serviceMethods.Get(j)
+				rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+				if !ok || rule == nil {
+					t.Errorf("%s has no REST mapping", method.FullName())
+					continue
+				}
+				verb, path := httpRulePattern(rule)
+				if !operations[verb+" "+pathParameter.ReplaceAllString(path, "{}")] {
+					t.Errorf("%s %s of %s is missing from the OpenAPI specification", strings.ToUpper(verb), path, method.FullName())
+				}
+			}
+		}
+		return true
+	})
+	if methods == 0 {
+		t.Fatal("No method of the KubeRay API is registered")
+	}
+}
+
+func httpRulePattern(rule *annotations.HttpRule) (string, string) {
+	switch pattern := rule.Pattern.(type) {
+	case *annotations.HttpRule_Get:
+		return "get", pattern.Get
+	case *annotations.HttpRule_Put:
+		return "put", pattern.Put
+	case *annotations.HttpRule_Post:
+		return "post", pattern.Post
+	case *annotations.HttpRule_Delete:
+		return "delete", pattern.Delete
+	case *annotations.HttpRule_Patch:
+		return "patch", pattern.Patch
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.Kind, pattern.Custom.Path
+	}
+	return "", ""
+}
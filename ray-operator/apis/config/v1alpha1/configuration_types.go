@@ -55,6 +55,10 @@ type Configuration struct {
 	// to inject into every Worker pod.
 	WorkerSidecarContainers []corev1.Container `json:"workerSidecarContainers,omitempty"`
 
+	// PodMutationPlugins is the ordered list of registered pod mutation plugins to enable.
+	// The plugins are called after the batch scheduler, before Ray Pods are created.
+	PodMutationPlugins []string `json:"podMutationPlugins,omitempty"`
+
 	// ReconcileConcurrency is the max concurrency for each reconciler.
 	ReconcileConcurrency int `json:"reconcileConcurrency,omitempty"`
 
@@ -70,6 +74,33 @@ type Configuration struct {
 
 	// DeleteRayJobAfterJobFinishes deletes the RayJob CR itself if shutdownAfterJobFinishes is set to true.
 	DeleteRayJobAfterJobFinishes bool `json:"deleteRayJobAfterJobFinishes,omitempty"`
+
+	// DryRun makes the operator log the Kubernetes objects it would create, update, or delete, including
+	// the diff against the live object, without sending any of these writes to the API server. This is
+	// useful for validating a KubeRay upgrade against existing clusters before enabling writes.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// MaxConcurrentRayJobsPerNamespace limits how many RayJobs may run concurrently in each namespace.
+	// RayJobs beyond the limit stay in the `New` status and are started in the order they were created
+	// once a running RayJob finishes. A value of 0 means no limit.
+	MaxConcurrentRayJobsPerNamespace int `json:"maxConcurrentRayJobsPerNamespace,omitempty"`
+
+	// NamingTemplates customizes the names of the Pods and Services generated by the operator, with prefixes,
+	// suffixes, a maximum length, and how longer names are truncated.
+	NamingTemplates utils.NamingTemplates `json:"namingTemplates,omitempty"`
+
+	// RequeuePolicy configures how often the custom resources are reconciled again, while the operator waits for
+	// a change, when nothing changes, and after a failed reconciliation.
+	RequeuePolicy utils.RequeuePolicy `json:"requeuePolicy,omitempty"`
+
+	// PodBatching limits the worker Pods created and deleted per reconciliation of a RayCluster, to scale worker
+	// groups with thousands of replicas without overloading the Kubernetes API server and etcd.
+	PodBatching utils.PodBatchPolicy `json:"podBatching,omitempty"`
+
+	// AcceleratorResources maps the Kubernetes extended resources of accelerators to the Ray resources advertised
+	// by the Ray Pods which request them, e.g. `habana.ai/gaudi: HPU`. The entries are added to the well-known
+	// accelerators, or override them. An extended resource mapped to `GPU` sets the number of GPUs of Ray.
+	AcceleratorResources utils.AcceleratorResources `json:"acceleratorResources,omitempty"`
 }
 
 func (config Configuration) GetDashboardClient(mgr manager.Manager) func() utils.RayDashboardClientInterface {
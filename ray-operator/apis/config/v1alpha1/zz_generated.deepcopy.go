@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -32,6 +33,18 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodMutationPlugins != nil {
+		in, out := &in.PodMutationPlugins, &out.PodMutationPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AcceleratorResources != nil {
+		in, out := &in.AcceleratorResources, &out.AcceleratorResources
+		*out = make(utils.AcceleratorResources, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
@@ -4,6 +4,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -19,6 +21,45 @@ type RayClusterSpec struct {
 	HeadServiceAnnotations map[string]string  `json:"headServiceAnnotations,omitempty"`
 	// EnableInTreeAutoscaling indicates whether operator should create in tree autoscaling configs
 	EnableInTreeAutoscaling *bool `json:"enableInTreeAutoscaling,omitempty"`
+	// UpgradeStrategy defines how running Pods are replaced when the image of their group changes.
+	// By default, image changes only apply to Pods created afterwards.
+	UpgradeStrategy *RayClusterUpgradeStrategy `json:"upgradeStrategy,omitempty"`
+	// ResourceQuota caps the total resources requested by the Ray Pods across the head and all worker groups,
+	// e.g. cpu, memory, and nvidia.com/gpu. Worker Pods which would exceed it are not created, which also caps
+	// scale-up requested by the autoscaler.
+	ResourceQuota corev1.ResourceList `json:"resourceQuota,omitempty"`
+	// RemoteCluster makes the operator create and manage the Pods and Services of this RayCluster in another
+	// Kubernetes cluster, using the kubeconfig stored in a Secret. The RayCluster status is still reported here.
+	RemoteCluster *RemoteClusterConfig `json:"remoteCluster,omitempty"`
+	// Metrics makes the operator create a metrics Service which selects all Ray Pods of the RayCluster and,
+	// optionally, a Prometheus Operator ServiceMonitor or PodMonitor, so that the Ray metrics are scraped.
+	Metrics *MetricsConfig `json:"metrics,omitempty"`
+	// ImagePrePull makes the operator pull the images of the Ray Pods on the nodes which the head and worker groups
+	// can be scheduled on before it creates the Ray Pods, which shortens the cold start of RayClusters with large images.
+	ImagePrePull *ImagePrePullConfig `json:"imagePrePull,omitempty"`
+	// SystemConfig mounts a ConfigMap which holds the Ray system config as JSON into all Ray Pods and passes it to
+	// the head Pod with `ray start --system-config`. The Ray Pods are recreated when the ConfigMap changes.
+	SystemConfig *RaySystemConfigSource `json:"systemConfig,omitempty"`
+	// DrainBeforeDeletion makes the deletion of the RayCluster wait until the Ray jobs running on it finish, up to a
+	// timeout, before its Pods are deleted. The RayCluster is in the draining state meanwhile.
+	DrainBeforeDeletion *DrainBeforeDeletionConfig `json:"drainBeforeDeletion,omitempty"`
+	// Logging sets the logging levels of the Ray processes and their temp directory in all Ray Pods, without having to
+	// know the ray start params and environment variables which configure them.
+	Logging *RayLoggingConfig `json:"logging,omitempty"`
+	// Egress injects a corporate CA bundle and the HTTP(S) proxy settings into all Ray Pods, for the environments
+	// where the outbound traffic, e.g. the pip installs of runtime environments, goes through a proxy.
+	Egress *EgressConfig `json:"egress,omitempty"`
+	// DashboardAuth requires an auth token for the Ray dashboard and the job API of the head Pod. The operator stores
+	// the token in the Secret named after the RayCluster with the suffix "-dashboard-auth", and serves the dashboard
+	// port through an auth proxy sidecar.
+	DashboardAuth *DashboardAuthConfig `json:"dashboardAuth,omitempty"`
+	// UsageSnapshots makes the operator periodically record lightweight usage snapshots of the RayCluster in its
+	// status, such as the ready workers and the pending Pods, which gives a short timeline without external monitoring.
+	UsageSnapshots *UsageSnapshotConfig `json:"usageSnapshots,omitempty"`
+	// ReadinessTimeouts overrides how long the Ray processes of the Pods may take to become ready before the liveness
+	// probes restart them, for Ray containers which legitimately start slowly, e.g. because they install large
+	// dependencies before `ray start`.
+	ReadinessTimeouts *ReadinessTimeoutsConfig `json:"readinessTimeouts,omitempty"`
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	// HeadGroupSpecs are the spec for the head pod
@@ -33,16 +74,76 @@ type RayClusterSpec struct {
 type HeadGroupSpec struct {
 	// ServiceType is Kubernetes service type of the head service. it will be used by the workers to connect to the head pod
 	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
-	// HeadService is the Kubernetes service of the head pod.
+	// HeadService is the Kubernetes service of the head pod. Its name replaces the generated name of the head service,
+	// and its ports are exposed in addition to the default ports. Ports with the name of a default port replace it.
 	HeadService *corev1.Service `json:"headService,omitempty"`
 	// EnableIngress indicates whether operator should create ingress object for head service or not.
 	EnableIngress *bool `json:"enableIngress,omitempty"`
 	// RayStartParams are the params of the start command: node-manager-port, object-store-memory, ...
 	RayStartParams map[string]string `json:"rayStartParams"`
+	// PodTemplatePatch is a strategic merge patch applied to the head pod after the operator builds it.
+	// It is an escape hatch for pod fields which are overwritten or not modeled by KubeRay.
+	PodTemplatePatch *runtime.RawExtension `json:"podTemplatePatch,omitempty"`
+	// HeadNodeReservation reserves the head Pod for the Ray system processes, such as the GCS. If set, num-cpus and
+	// num-gpus are set to 0 in the ray start params of the head, so that no tasks and actors are scheduled on it.
+	HeadNodeReservation *HeadNodeReservation `json:"headNodeReservation,omitempty"`
+	// PersistentStorage backs the Ray logs and the object spilling directory of the head Pod with a
+	// PersistentVolumeClaim which outlives the head Pod.
+	PersistentStorage *RayPersistentStorage `json:"persistentStorage,omitempty"`
+	// Placement pins or prefers the head Pod to failure domains, e.g. zones or node pools. With GCS fault tolerance,
+	// it can also keep capacity for the head Pod reserved in another failure domain.
+	Placement *HeadPlacementPolicy `json:"placement,omitempty"`
 	// Template is the exact pod template used in K8s depoyments, statefulsets, etc.
 	Template corev1.PodTemplateSpec `json:"template"`
 }
 
+// RayPersistentStorage configures a persistent volume for the Ray logs and the object spilling directory, so that
+// they do not fill up the node-local disk.
+type RayPersistentStorage struct {
+	// Size is the requested capacity of the volume.
+	Size resource.Quantity `json:"size"`
+	// StorageClassName is the StorageClass of the volume. The default StorageClass is used if it is not set.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// HeadPlacementPolicy places the head Pod in failure domains, which are the values of a node label.
+type HeadPlacementPolicy struct {
+	// TopologyKey is the node label whose values are the failure domains. Defaults to topology.kubernetes.io/zone.
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// Domains are the failure domains of the head Pod, in order of preference.
+	// +kubebuilder:validation:MaxItems=100
+	Domains []string `json:"domains,omitempty"`
+	// Mode is Required to only schedule the head Pod in the Domains, or Preferred to fall back to other failure
+	// domains if none of the Domains has capacity. Defaults to Required.
+	Mode HeadPlacementMode `json:"mode,omitempty"`
+	// Standby keeps a placeholder Pod with the resource requests of the head Pod scheduled in another failure domain
+	// than the head Pod, so that capacity is available to reschedule the head Pod after an outage of its failure
+	// domain. It requires GCS fault tolerance, which restores the cluster state in the rescheduled head Pod.
+	Standby *HeadStandby `json:"standby,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Required;Preferred
+type HeadPlacementMode string
+
+const (
+	HeadPlacementRequired  HeadPlacementMode = "Required"
+	HeadPlacementPreferred HeadPlacementMode = "Preferred"
+)
+
+// HeadStandby configures the placeholder Pod which reserves capacity for the head Pod in another failure domain.
+type HeadStandby struct {
+	// PriorityClassName is the priority class of the standby Pod. It should have a lower priority than the head Pod,
+	// so that the head Pod can preempt the standby Pod when it is rescheduled.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// HeadNodeReservation configures the head Pod which is reserved for the Ray system processes.
+type HeadNodeReservation struct {
+	// Resources overrides the resource requests and limits of the Ray container of the head Pod, e.g. to give
+	// the GCS more memory. Resources which are not listed keep the values from the head Pod template.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
 // WorkerGroupSpec are the specs for the worker pods
 type WorkerGroupSpec struct {
 	// we can have multiple worker groups, we distinguish them by name
@@ -58,6 +159,9 @@ type WorkerGroupSpec struct {
 	MaxReplicas *int32 `json:"maxReplicas"`
 	// RayStartParams are the params of the start command: address, object-store-memory, ...
 	RayStartParams map[string]string `json:"rayStartParams"`
+	// PodTemplatePatch is a strategic merge patch applied to the worker pods after the operator builds them.
+	// It is an escape hatch for pod fields which are overwritten or not modeled by KubeRay.
+	PodTemplatePatch *runtime.RawExtension `json:"podTemplatePatch,omitempty"`
 	// Template is a pod template for the worker
 	Template corev1.PodTemplateSpec `json:"template"`
 	// ScaleStrategy defines which pods to remove
@@ -65,6 +169,216 @@ type WorkerGroupSpec struct {
 	// NumOfHosts denotes the number of hosts to create per replica. The default value is 1.
 	// +kubebuilder:default:=1
 	NumOfHosts int32 `json:"numOfHosts,omitempty"`
+	// ScalingSchedules override MinReplicas and MaxReplicas during recurring time windows, e.g. to keep
+	// capacity warm during business hours. The first schedule whose window contains the current time applies.
+	ScalingSchedules []ScalingSchedule `json:"scalingSchedules,omitempty"`
+	// PersistentStorage backs the Ray logs and the object spilling directory of each worker Pod with an
+	// ephemeral volume, which is deleted together with the Pod.
+	PersistentStorage *RayPersistentStorage `json:"persistentStorage,omitempty"`
+	// NodeFill declares the worker group from the Nodes matching a selector, e.g. a dedicated GPU pool. The worker
+	// group then runs one worker Pod on every matching Node, and its replicas track the number of these Nodes
+	// instead of Replicas, MinReplicas and MaxReplicas.
+	NodeFill *NodeFillPolicy `json:"nodeFill,omitempty"`
+	// RolloutStrategy bounds the capacity of the worker group while its Pods are replaced by a GroupByGroup
+	// upgrade. By default, all the outdated Pods of the worker group are replaced at once.
+	RolloutStrategy *WorkerGroupRolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// WorkerGroupRolloutStrategy bounds the Pods of a worker group which are unavailable or in excess while its outdated
+// Pods are replaced, like the rolling update of a Deployment. The values are numbers of Pods or percentages of the
+// desired Pods of the worker group.
+type WorkerGroupRolloutStrategy struct {
+	// MaxUnavailable is the maximum number of desired Pods of the worker group which can be unavailable during the
+	// rollout. A percentage is rounded down. The default value is 1, and it is 1 if both values are 0.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MaxSurge is the maximum number of Pods created above the desired Pods of the worker group during the rollout,
+	// so that new Pods are ready before outdated Pods are deleted. A percentage is rounded up. The default value is 0.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// NodeFillPolicy places one worker Pod on every Node matching a selector.
+type NodeFillPolicy struct {
+	// NodeSelector selects the Nodes of the worker group. Only the Nodes which are ready and schedulable count.
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// DeriveResources sets the requests and limits of the Ray container from the allocatable CPU, memory and GPUs
+	// of the matching Nodes, minus Reserved. The smallest Node determines the resources of all the worker Pods.
+	DeriveResources bool `json:"deriveResources,omitempty"`
+	// Reserved are the resources of every Node left to the daemons and system Pods when DeriveResources is set.
+	Reserved corev1.ResourceList `json:"reserved,omitempty"`
+}
+
+// ScalingScheduleDay is a day of the week.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type ScalingScheduleDay string
+
+// ScalingSchedule overrides the replica bounds of a worker group during a recurring time window.
+type ScalingSchedule struct {
+	// Name identifies the schedule.
+	Name string `json:"name"`
+	// Days are the days of the week on which the window starts. The window starts every day if Days is empty.
+	Days []ScalingScheduleDay `json:"days,omitempty"`
+	// StartTime is the start of the window in the format "HH:MM".
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+	// EndTime is the end of the window in the format "HH:MM". If EndTime is not after StartTime,
+	// the window ends on the next day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	EndTime string `json:"endTime"`
+	// TimeZone is the IANA time zone of StartTime and EndTime, e.g. "America/New_York". The default value is "UTC".
+	TimeZone string `json:"timeZone,omitempty"`
+	// MinReplicas overrides the MinReplicas of the worker group during the window.
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas overrides the MaxReplicas of the worker group during the window.
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
+
+// RayClusterUpgradeType is the type of the upgrade strategy of a RayCluster.
+// +kubebuilder:validation:Enum=None;GroupByGroup
+type RayClusterUpgradeType string
+
+const (
+	// NoneUpgrade never replaces running Pods when the image of their group changes.
+	NoneUpgrade RayClusterUpgradeType = "None"
+	// GroupByGroupUpgrade replaces the Pods of one worker group at a time, in the order of WorkerGroupSpecs,
+	// and waits for all Pods to be running and ready before moving on to the next group.
+	GroupByGroupUpgrade RayClusterUpgradeType = "GroupByGroup"
+)
+
+// RayClusterUpgradeStrategy defines how running Pods are replaced when the image of their group changes.
+type RayClusterUpgradeStrategy struct {
+	// Type is the upgrade strategy, either "None" or "GroupByGroup". The default value is "None".
+	Type *RayClusterUpgradeType `json:"type,omitempty"`
+	// UpgradeHead indicates whether the head Pod is recreated after all worker groups are upgraded.
+	// It requires GCS fault tolerance so that the cluster state survives the head Pod restart.
+	UpgradeHead *bool `json:"upgradeHead,omitempty"`
+}
+
+// RemoteClusterConfig references the Kubernetes cluster which runs the Pods and Services of a RayCluster.
+type RemoteClusterConfig struct {
+	// KubeconfigSecretName is the name of a Secret in the namespace of the RayCluster which stores the
+	// kubeconfig of the remote cluster.
+	KubeconfigSecretName string `json:"kubeconfigSecretName"`
+	// KubeconfigSecretKey is the key of the kubeconfig in the Secret. The default value is "kubeconfig".
+	KubeconfigSecretKey string `json:"kubeconfigSecretKey,omitempty"`
+}
+
+// ImagePrePullConfig configures pulling the images of the Ray Pods before they are created.
+type ImagePrePullConfig struct {
+	// TimeoutSeconds is how long the operator waits for the images to be pulled before it creates the Ray Pods anyway.
+	// The default value is 600.
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// RaySystemConfigSource references the ConfigMap which holds the Ray system config.
+type RaySystemConfigSource struct {
+	// ConfigMapName is the name of a ConfigMap in the namespace of the RayCluster.
+	ConfigMapName string `json:"configMapName"`
+	// Key is the key of the JSON system config in the ConfigMap. The default value is "system_config.json".
+	Key string `json:"key,omitempty"`
+}
+
+// DrainBeforeDeletionConfig configures how long the deletion of a RayCluster waits for its running Ray jobs.
+type DrainBeforeDeletionConfig struct {
+	// TimeoutSeconds is the maximum time to wait for the running Ray jobs, counted from the deletion request.
+	// The RayCluster is deleted when it expires even if jobs are still running. The default value is 600.
+	// +kubebuilder:validation:Minimum=0
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// ReadinessTimeoutsConfig configures how long the Ray processes of the Pods may take to become ready. Without a
+// timeout, the liveness probe restarts a Ray container which is not ready about 10 minutes after it started.
+type ReadinessTimeoutsConfig struct {
+	// GCSReadySeconds is how long the GCS and the raylet of the head Pod may take to become ready.
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=7200
+	GCSReadySeconds *int32 `json:"gcsReadySeconds,omitempty"`
+	// DashboardAgentReadySeconds is how long the raylet and the dashboard agent of the worker Pods may take to
+	// become ready.
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=7200
+	DashboardAgentReadySeconds *int32 `json:"dashboardAgentReadySeconds,omitempty"`
+}
+
+// UsageSnapshotConfig configures how often the usage snapshots of a RayCluster are recorded and how many are kept.
+type UsageSnapshotConfig struct {
+	// IntervalSeconds is the minimum time between two snapshots. The default value is 300.
+	// +kubebuilder:validation:Minimum=1
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+	// MaxHistory is the number of snapshots kept in the status. The oldest snapshots are dropped first.
+	// The default value is 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MaxHistory *int32 `json:"maxHistory,omitempty"`
+}
+
+// RayLoggingConfig configures the logging of the Ray processes. The ray start params and the environment variables
+// of the Ray container take precedence over it.
+type RayLoggingConfig struct {
+	// LoggingLevel is the logging level of the Python components of Ray, passed with `ray start --logging-level`.
+	// +kubebuilder:validation:Enum=debug;info;warning;error;critical
+	LoggingLevel string `json:"loggingLevel,omitempty"`
+	// BackendLogLevel is the log level of the C++ components of Ray, such as the raylet and the GCS, set with the
+	// RAY_BACKEND_LOG_LEVEL environment variable.
+	// +kubebuilder:validation:Enum=trace;debug;info;warning;error;fatal
+	BackendLogLevel string `json:"backendLogLevel,omitempty"`
+	// TempDir is the root temporary directory of the Ray processes, which holds the session logs. It is passed to the
+	// head with `ray start --temp-dir`, and the worker nodes use the same directory.
+	TempDir string `json:"tempDir,omitempty"`
+}
+
+// EgressConfig configures the outbound traffic of the Ray containers. The environment variables of the Ray container
+// take precedence over it.
+type EgressConfig struct {
+	// CABundle is appended to the system CA certificates of the Ray containers. The combined bundle is referenced by
+	// the SSL_CERT_FILE, REQUESTS_CA_BUNDLE and PIP_CERT environment variables.
+	CABundle *CABundleSource `json:"caBundle,omitempty"`
+	// HTTPProxy is the proxy of the HTTP requests, set with the HTTP_PROXY and http_proxy environment variables.
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is the proxy of the HTTPS requests, set with the HTTPS_PROXY and https_proxy environment variables.
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is a comma-separated list of the hosts and domains which are reached without the proxy, set with the
+	// NO_PROXY and no_proxy environment variables. The local and in-cluster addresses are always appended to it, and
+	// it should also list the Pod CIDR, because Ray nodes connect to each other through their Pod IPs.
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// DashboardAuthConfig configures the auth proxy sidecar which requires the token of the RayCluster for the requests
+// to the Ray dashboard. The Ray dashboard itself only listens on the loopback interface of the head Pod.
+type DashboardAuthConfig struct {
+	// ProxyImage is the image of the auth proxy sidecar, which has to be compatible with nginxinc/nginx-unprivileged.
+	// The default value is nginxinc/nginx-unprivileged:1.27-alpine.
+	ProxyImage string `json:"proxyImage,omitempty"`
+}
+
+// CABundleSource references the ConfigMap which holds the PEM-encoded CA certificates.
+type CABundleSource struct {
+	// ConfigMapName is the name of a ConfigMap in the namespace of the RayCluster.
+	ConfigMapName string `json:"configMapName"`
+	// Key is the key of the CA certificates in the ConfigMap. The default value is "ca.crt".
+	Key string `json:"key,omitempty"`
+}
+
+// MetricsMonitorType is the kind of the Prometheus Operator resource which scrapes the Ray metrics.
+type MetricsMonitorType string
+
+const (
+	ServiceMonitorType MetricsMonitorType = "ServiceMonitor"
+	PodMonitorType     MetricsMonitorType = "PodMonitor"
+)
+
+// MetricsConfig configures the resources which the operator creates to scrape the Ray metrics.
+type MetricsConfig struct {
+	// MonitorLabels are added to the ServiceMonitor or PodMonitor. Prometheus selects monitors by labels,
+	// e.g. `release: prometheus` for the kube-prometheus-stack Helm chart.
+	MonitorLabels map[string]string `json:"monitorLabels,omitempty"`
+	// MonitorType is the kind of the Prometheus Operator resource created for the RayCluster. If it is empty,
+	// only the metrics Service is created. The Prometheus Operator CRDs have to be installed.
+	// +kubebuilder:validation:Enum=ServiceMonitor;PodMonitor
+	MonitorType MetricsMonitorType `json:"monitorType,omitempty"`
+	// ScrapeInterval is the interval at which Prometheus scrapes the metrics, e.g. 30s.
+	// The default interval of Prometheus is used if it is empty.
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
 }
 
 // ScaleStrategy to remove workers
@@ -114,6 +428,9 @@ const (
 	// Failed is deprecated, but we keep it to avoid compilation errors in projects that import the KubeRay Golang module.
 	Failed    ClusterState = "failed"
 	Suspended ClusterState = "suspended"
+	// Draining is the state of a RayCluster with spec.drainBeforeDeletion which is waiting for its Ray jobs to finish
+	// before being deleted.
+	Draining ClusterState = "draining"
 )
 
 // RayClusterStatus defines the observed state of RayCluster
@@ -143,6 +460,8 @@ type RayClusterStatus struct {
 	Head HeadInfo `json:"head,omitempty"`
 	// Reason provides more information about current State
 	Reason string `json:"reason,omitempty"`
+	// QueueName is the batch scheduler queue the RayCluster is submitted to, if any.
+	QueueName string `json:"queueName,omitempty"`
 
 	// Represents the latest available observations of a RayCluster's current state.
 	// +patchMergeKey=type
@@ -150,6 +469,17 @@ type RayClusterStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// UpgradeStatus reports the progress of an upgrade triggered by an image change. It is only set while
+	// Pods running an outdated image are being replaced.
+	UpgradeStatus *RayClusterUpgradeStatus `json:"upgradeStatus,omitempty"`
+	// PodBatching reports the worker Pods which remain to be created or deleted when the operator limits the Pods
+	// created and deleted per reconciliation. It is only set while Pods remain.
+	PodBatching *RayClusterPodBatchingStatus `json:"podBatching,omitempty"`
+	// SystemConfigHash is the hash of the Ray system config referenced by spec.systemConfig. Ray Pods which
+	// were created with another system config are recreated.
+	SystemConfigHash string `json:"systemConfigHash,omitempty"`
+	// UsageSnapshots are the latest usage snapshots recorded with spec.usageSnapshots, oldest first.
+	UsageSnapshots []RayClusterUsageSnapshot `json:"usageSnapshots,omitempty"`
 
 	// ReadyWorkerReplicas indicates how many worker replicas are ready in the cluster
 	ReadyWorkerReplicas int32 `json:"readyWorkerReplicas,omitempty"`
@@ -166,6 +496,49 @@ type RayClusterStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// RayClusterUpgradeStatus reports the progress of an upgrade of the Ray Pods.
+type RayClusterUpgradeStatus struct {
+	// StartTime is the time when the upgrade started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CurrentGroup is the group being upgraded. It is "headgroup" for the head Pod.
+	CurrentGroup string `json:"currentGroup,omitempty"`
+	// PendingGroups are the groups that still have Pods running an outdated image, in upgrade order.
+	PendingGroups []string `json:"pendingGroups,omitempty"`
+}
+
+// RayClusterPodBatchingStatus reports the progress of worker Pod creations and deletions spread over several
+// reconciliations.
+type RayClusterPodBatchingStatus struct {
+	// Groups are the worker groups with Pods which remain to be created or deleted.
+	Groups []string `json:"groups,omitempty"`
+	// PendingCreations is the number of worker Pods which remain to be created.
+	PendingCreations int32 `json:"pendingCreations,omitempty"`
+	// PendingDeletions is the number of worker Pods which remain to be deleted.
+	PendingDeletions int32 `json:"pendingDeletions,omitempty"`
+	// LastBatchTime is when the last batch of worker Pods was created or deleted. The next batch waits for the
+	// batch interval of the operator.
+	LastBatchTime *metav1.Time `json:"lastBatchTime,omitempty"`
+}
+
+// RayClusterUsageSnapshot is the usage of a RayCluster at a point in time.
+type RayClusterUsageSnapshot struct {
+	// Time is when the snapshot was recorded.
+	Time metav1.Time `json:"time"`
+	// ReadyWorkerReplicas is the number of ready worker Pods.
+	ReadyWorkerReplicas int32 `json:"readyWorkerReplicas"`
+	// DesiredWorkerReplicas is the number of desired worker Pods.
+	DesiredWorkerReplicas int32 `json:"desiredWorkerReplicas"`
+	// PendingPods is the number of Ray Pods in the Pending phase.
+	PendingPods int32 `json:"pendingPods"`
+	// LastScaleTime is the last time a worker Pod was created or deleted.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	// LastHeadRestartTime is the last time the Ray container of the head Pod started, which changes when the
+	// head Pod is recreated or its container restarts.
+	// +optional
+	LastHeadRestartTime *metav1.Time `json:"lastHeadRestartTime,omitempty"`
+}
+
 type RayClusterConditionType string
 
 // Custom Reason for RayClusterCondition
@@ -174,6 +547,14 @@ const (
 	RayClusterPodsProvisioning     = "RayClusterPodsProvisioning"
 	HeadPodNotFound                = "HeadPodNotFound"
 	HeadPodRunningAndReady         = "HeadPodRunningAndReady"
+	RayClusterUpgradeInProgress    = "UpgradeInProgress"
+	RayClusterUpgradeCompleted     = "UpgradeCompleted"
+	RecoveryWaitingForHeadPod      = "WaitingForHeadPod"
+	RecoveryWaitingForGCS          = "WaitingForGCS"
+	RecoveryWaitingForWorkers      = "WaitingForWorkers"
+	RecoveryCompleted              = "RecoveryCompleted"
+	RayClusterWaitingForAdmission  = "WaitingForAdmission"
+	RayClusterAdmittedByScheduler  = "AdmittedByScheduler"
 	// UnknownReason says that the reason for the condition is unknown.
 	UnknownReason = "Unknown"
 )
@@ -186,6 +567,16 @@ const (
 	HeadPodReady RayClusterConditionType = "HeadPodReady"
 	// RayClusterReplicaFailure is added in a RayCluster when one of its pods fails to be created or deleted.
 	RayClusterReplicaFailure RayClusterConditionType = "ReplicaFailure"
+	// RayClusterUpgrading indicates whether Pods running an outdated image are being replaced.
+	RayClusterUpgrading RayClusterConditionType = "Upgrading"
+	// RayClusterRecovering indicates whether the RayCluster is recovering from a head Pod failure with GCS fault tolerance.
+	// The head Pod is restarted first, then the GCS restores the cluster state from Redis, and finally the worker Pods
+	// register with the restored GCS.
+	RayClusterRecovering RayClusterConditionType = "Recovering"
+	// RayClusterAdmitted indicates whether a batch scheduler, e.g. Kueue, Volcano or YuniKorn, admitted the Ray Pods.
+	// It is only set for RayClusters submitted to a batch scheduler. While it is false, the Ray Pods are pending
+	// because the queue lacks quota rather than because the RayCluster is broken.
+	RayClusterAdmitted RayClusterConditionType = "Admitted"
 )
 
 // HeadInfo gives info about head
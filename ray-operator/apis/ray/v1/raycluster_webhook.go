@@ -1,7 +1,10 @@
 package v1
 
 import (
+	"encoding/json"
 	"regexp"
+	"strings"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,6 +22,9 @@ var (
 	nameRegex, _  = regexp.Compile("^[a-z]([-a-z0-9]*[a-z0-9])?$")
 )
 
+// rayFTEnabledAnnotationKey mirrors utils.RayFTEnabledAnnotationKey, which cannot be imported from the API package.
+const rayFTEnabledAnnotationKey = "ray.io/ft-enabled"
+
 func (r *RayCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
@@ -59,6 +65,22 @@ func (r *RayCluster) validateRayCluster() error {
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, r.validatePodTemplatePatches()...)
+	allErrs = append(allErrs, r.validateScalingSchedules()...)
+	allErrs = append(allErrs, r.validateNodeFill()...)
+
+	if err := r.validateUpgradeStrategy(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateRemoteCluster(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateHeadPlacement(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -87,3 +109,114 @@ func (r *RayCluster) validateWorkerGroups() *field.Error {
 
 	return nil
 }
+
+func (r *RayCluster) validatePodTemplatePatches() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if err := validatePodTemplatePatch(r.Spec.HeadGroupSpec.PodTemplatePatch, field.NewPath("spec").Child("headGroupSpec").Child("podTemplatePatch")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		if err := validatePodTemplatePatch(workerGroup.PodTemplatePatch, field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("podTemplatePatch")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	return allErrs
+}
+
+func validatePodTemplatePatch(patch *runtime.RawExtension, path *field.Path) *field.Error {
+	if patch == nil || len(patch.Raw) == 0 {
+		return nil
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(patch.Raw, &obj); err != nil {
+		return field.Invalid(path, string(patch.Raw), "podTemplatePatch must be a JSON object")
+	}
+	return nil
+}
+
+func (r *RayCluster) validateScalingSchedules() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		scheduleNames := make(map[string]bool)
+		for j, schedule := range workerGroup.ScalingSchedules {
+			path := field.NewPath("spec").Child("workerGroupSpecs").Index(i).Child("scalingSchedules").Index(j)
+			if scheduleNames[schedule.Name] {
+				allErrs = append(allErrs, field.Duplicate(path.Child("name"), schedule.Name))
+			}
+			scheduleNames[schedule.Name] = true
+			if schedule.TimeZone != "" {
+				if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+					allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), schedule.TimeZone, err.Error()))
+				}
+			}
+			if schedule.MinReplicas != nil && schedule.MaxReplicas != nil && *schedule.MinReplicas > *schedule.MaxReplicas {
+				allErrs = append(allErrs, field.Invalid(path.Child("minReplicas"), *schedule.MinReplicas, "minReplicas must not be greater than maxReplicas"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (r *RayCluster) validateNodeFill() field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		if workerGroup.NodeFill == nil {
+			continue
+		}
+		path := field.NewPath("spec").Child("workerGroupSpecs").Index(i)
+		if len(workerGroup.NodeFill.NodeSelector) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("nodeFill").Child("nodeSelector"), "nodeSelector must select the Nodes of the worker group"))
+		}
+		if len(workerGroup.ScalingSchedules) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("scalingSchedules"), "scalingSchedules cannot be combined with nodeFill"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *RayCluster) validateUpgradeStrategy() *field.Error {
+	strategy := r.Spec.UpgradeStrategy
+	if strategy == nil || strategy.UpgradeHead == nil || !*strategy.UpgradeHead {
+		return nil
+	}
+	// Recreating the head Pod without GCS fault tolerance would lose the cluster state.
+	if v, ok := r.Annotations[rayFTEnabledAnnotationKey]; !ok || strings.ToLower(v) != "true" {
+		return field.Invalid(field.NewPath("spec").Child("upgradeStrategy").Child("upgradeHead"), *strategy.UpgradeHead,
+			"upgradeHead requires GCS fault tolerance to be enabled with the "+rayFTEnabledAnnotationKey+" annotation")
+	}
+	return nil
+}
+
+func (r *RayCluster) validateHeadPlacement() *field.Error {
+	placement := r.Spec.HeadGroupSpec.Placement
+	if placement == nil || placement.Standby == nil {
+		return nil
+	}
+	// A head Pod rescheduled into the failure domain of the standby Pod would start without the cluster state otherwise.
+	if v, ok := r.Annotations[rayFTEnabledAnnotationKey]; !ok || strings.ToLower(v) != "true" {
+		return field.Invalid(field.NewPath("spec").Child("headGroupSpec").Child("placement").Child("standby"), *placement.Standby,
+			"standby requires GCS fault tolerance to be enabled with the "+rayFTEnabledAnnotationKey+" annotation")
+	}
+	return nil
+}
+
+func (r *RayCluster) validateRemoteCluster() *field.Error {
+	if r.Spec.RemoteCluster == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("remoteCluster")
+	if r.Spec.RemoteCluster.KubeconfigSecretName == "" {
+		return field.Required(path.Child("kubeconfigSecretName"), "kubeconfigSecretName must be set")
+	}
+	// The autoscaler runs in the remote head Pod and cannot reach the RayCluster in the local cluster.
+	if r.Spec.EnableInTreeAutoscaling != nil && *r.Spec.EnableInTreeAutoscaling {
+		return field.Invalid(path, r.Spec.RemoteCluster.KubeconfigSecretName, "remoteCluster cannot be used together with enableInTreeAutoscaling")
+	}
+	return nil
+}
@@ -54,6 +54,18 @@ const (
 	AppFailed        JobFailedReason = "AppFailed"
 )
 
+// JobFailureCategory classifies the failure of a RayJob, so that users can decide whether to retry or alert.
+type JobFailureCategory string
+
+const (
+	// UserErrorFailure means that the entrypoint of the Ray job failed, e.g. because of an exception in the user code.
+	UserErrorFailure JobFailureCategory = "UserError"
+	// InfraErrorFailure means that the Ray job could not be submitted or started, e.g. because the job supervisor died.
+	InfraErrorFailure JobFailureCategory = "InfraError"
+	// DeadlineFailure means that the RayJob ran longer than activeDeadlineSeconds.
+	DeadlineFailure JobFailureCategory = "Deadline"
+)
+
 type JobSubmissionMode string
 
 const (
@@ -67,6 +79,18 @@ type SubmitterConfig struct {
 	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
 }
 
+// RayJobStage is a step of a RayJob pipeline. The stages of a RayJob run one after another on the same RayCluster.
+type RayJobStage struct {
+	// Name of the stage, which must be unique within the RayJob. The Ray job of the stage is submitted with
+	// the submission ID `<jobId>-<name>`.
+	Name string `json:"name"`
+	// Entrypoint is the command of the stage.
+	Entrypoint string `json:"entrypoint"`
+	// RuntimeEnvYAML is the runtime environment of the stage provided as a multi-line YAML string.
+	// If it is not set, the runtimeEnvYAML of the RayJob is used.
+	RuntimeEnvYAML string `json:"runtimeEnvYAML,omitempty"`
+}
+
 // RayJobSpec defines the desired state of RayJob
 type RayJobSpec struct {
 	// ActiveDeadlineSeconds is the duration in seconds that the RayJob may be active before
@@ -84,6 +108,10 @@ type RayJobSpec struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 	// clusterSelector is used to select running rayclusters by labels
 	ClusterSelector map[string]string `json:"clusterSelector,omitempty"`
+	// ClusterGenerateName is the prefix of the name of the RayCluster created for the RayJob. Like with
+	// metadata.generateName, a random suffix of 5 characters is appended to it. Defaults to `<RayJob name>-raycluster-`.
+	// +optional
+	ClusterGenerateName string `json:"clusterGenerateName,omitempty"`
 	// Configurations of submitter k8s job.
 	SubmitterConfig *SubmitterConfig `json:"submitterConfig,omitempty"`
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -118,6 +146,11 @@ type RayJobSpec struct {
 	// If the RayCluster is already created, it will be deleted.
 	// In case of transition to false a new RayCluster will be created.
 	Suspend bool `json:"suspend,omitempty"`
+	// Stages run sequentially on the same RayCluster, each one as a separate Ray job which is only submitted
+	// once the previous one has succeeded. The RayJob fails as soon as one of its stages fails.
+	// Stages replace the entrypoint and are only supported in "HTTPMode".
+	// +optional
+	Stages []RayJobStage `json:"stages,omitempty"`
 }
 
 // RayJobStatus defines the observed state of RayJob
@@ -143,14 +176,49 @@ type RayJobStatus struct {
 	// Failed is the number of times this job failed.
 	// +kubebuilder:default:=0
 	Failed *int32 `json:"failed,omitempty"`
+	// JobResult is the structured result of the Ray job. It is set once the RayJob is 'Complete' or 'Failed'.
+	JobResult *RayJobResult `json:"jobResult,omitempty"`
 	// RayClusterStatus is the status of the RayCluster running the job.
 	RayClusterStatus RayClusterStatus `json:"rayClusterStatus,omitempty"`
+	// Stages is the status of every stage of the RayJob, in the order of spec.stages.
+	Stages []RayJobStageStatus `json:"stages,omitempty"`
 
 	// observedGeneration is the most recent generation observed for this RayJob. It corresponds to the
 	// RayJob's generation, which is updated on mutation by the API Server.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// RayJobStageStatus is the observed state of a stage of a RayJob.
+type RayJobStageStatus struct {
+	// Name of the stage.
+	Name string `json:"name"`
+	// JobId is the submission ID of the Ray job running the stage.
+	JobId string `json:"jobId,omitempty"`
+	// JobStatus is the status of the Ray job running the stage. It is empty until the stage is submitted.
+	JobStatus JobStatus `json:"jobStatus,omitempty"`
+	// Message is the message of the Ray job running the stage as reported by Ray.
+	Message string `json:"message,omitempty"`
+	// StartTime is the time when the stage was submitted.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EndTime is the time when the Ray job of the stage reached a terminal status.
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// RayJobResult is the structured result of a finished Ray job.
+type RayJobResult struct {
+	// DriverExitCode is the exit code of the driver process, if reported by Ray.
+	DriverExitCode *int32 `json:"driverExitCode,omitempty"`
+	// RuntimeSeconds is how long the Ray job ran, measured from its start to its end as reported by Ray.
+	// If Ray did not report them, the StartTime and EndTime of the RayJob are used instead.
+	RuntimeSeconds *int64 `json:"runtimeSeconds,omitempty"`
+	// Metadata is the metadata of the Ray job as reported by Ray.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// FailureCategory classifies the failure of the RayJob. It is empty if the RayJob succeeded.
+	FailureCategory JobFailureCategory `json:"failureCategory,omitempty"`
+	// ErrorType is the error type reported by Ray, e.g. JOB_ENTRYPOINT_COMMAND_ERROR.
+	ErrorType string `json:"errorType,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories=all
 // +kubebuilder:subresource:status
@@ -18,6 +18,7 @@ const (
 	Restarting                       ServiceStatus = "Restarting"
 	FailedToUpdateServingPodLabel    ServiceStatus = "FailedToUpdateServingPodLabel"
 	FailedToUpdateService            ServiceStatus = "FailedToUpdateService"
+	Paused                           ServiceStatus = "Paused"
 )
 
 // These statuses should match Ray Serve's application statuses
@@ -55,14 +56,62 @@ type RayServiceSpec struct {
 	ServiceUnhealthySecondThreshold *int32 `json:"serviceUnhealthySecondThreshold,omitempty"`
 	// Deprecated: This field is not used anymore. ref: https://github.com/ray-project/kuberay/issues/1685
 	DeploymentUnhealthySecondThreshold *int32 `json:"deploymentUnhealthySecondThreshold,omitempty"`
+	// HealthCheckPolicy configures how the controller queries the Serve application statuses from the Ray dashboard
+	// and how failed queries are retried.
+	HealthCheckPolicy *RayServiceHealthCheckPolicy `json:"healthCheckPolicy,omitempty"`
+	// UpgradeStrategy defines how the RayCluster is upgraded when the changes of RayClusterSpec need a new cluster.
+	// It overrides the ENABLE_ZERO_DOWNTIME environment variable of the operator.
+	// +optional
+	UpgradeStrategy *RayServiceUpgradeStrategy `json:"upgradeStrategy,omitempty"`
 	// ServeService is the Kubernetes service for head node and worker nodes who have healthy http proxy to serve traffics.
 	ServeService *corev1.Service `json:"serveService,omitempty"`
+	// Paused stops the controller from reconciling the RayService while set. The RayClusters of the RayService
+	// are neither created, updated nor deleted, so that a live cluster can be debugged.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
 	// Important: Run "make" to regenerate code after modifying this file
 	// Defines the applications and deployments to deploy, should be a YAML multi-line scalar string.
+	// Changes of its http_options and proxy_location restart Serve on the running RayCluster to apply them.
 	ServeConfigV2  string         `json:"serveConfigV2,omitempty"`
 	RayClusterSpec RayClusterSpec `json:"rayClusterConfig,omitempty"`
 }
 
+// RayServiceUpgradeType is the type of the upgrade strategy of a RayService.
+// +kubebuilder:validation:Enum=NewCluster;None
+type RayServiceUpgradeType string
+
+const (
+	// NewCluster creates a new RayCluster and switches the traffic to it once its Serve applications are ready.
+	NewCluster RayServiceUpgradeType = "NewCluster"
+	// None does not upgrade the RayCluster. The changes of RayClusterSpec are not rolled out to the running cluster.
+	None RayServiceUpgradeType = "None"
+)
+
+// RayServiceUpgradeStrategy defines how the RayCluster of a RayService is upgraded.
+type RayServiceUpgradeStrategy struct {
+	// Type is the upgrade strategy, either "NewCluster" or "None". The default value is "NewCluster", unless the
+	// ENABLE_ZERO_DOWNTIME environment variable of the operator is "false".
+	Type *RayServiceUpgradeType `json:"type,omitempty"`
+}
+
+// RayServiceHealthCheckPolicy configures the health checks of the Serve applications.
+type RayServiceHealthCheckPolicy struct {
+	// TimeoutSeconds is the timeout of each request to the Ray dashboard. The default value is 2.
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// FailureThreshold is the number of consecutive failed health checks after which the RayService reports
+	// FailedToGetServeDeploymentStatus. Fewer failures are only retried. The default value is 1.
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	// InitialBackoffSeconds is the delay before a failed health check is retried. The delay doubles with every
+	// consecutive failure up to MaxBackoffSeconds. The default value is 2.
+	// +kubebuilder:validation:Minimum=1
+	InitialBackoffSeconds *int32 `json:"initialBackoffSeconds,omitempty"`
+	// MaxBackoffSeconds caps the delay before a failed health check is retried. The default value is 60.
+	// +kubebuilder:validation:Minimum=1
+	MaxBackoffSeconds *int32 `json:"maxBackoffSeconds,omitempty"`
+}
+
 // RayServiceStatuses defines the observed state of RayService
 type RayServiceStatuses struct {
 	// LastUpdateTime represents the timestamp when the RayService status was last updated.
@@ -78,13 +127,74 @@ type RayServiceStatuses struct {
 	// observedGeneration is the most recent generation observed for this RayService. It corresponds to the
 	// RayService's generation, which is updated on mutation by the API Server.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// UpgradeStatus reports the progress of a zero-downtime upgrade. It is only set while a pending RayCluster
+	// is prepared to replace the active RayCluster.
+	UpgradeStatus *RayServiceUpgradeStatus `json:"upgradeStatus,omitempty"`
+	// LastUpgradeDurationSeconds is how long the most recent zero-downtime upgrade took, from the creation of the
+	// pending RayCluster to its promotion. It is used to estimate the promotion time of the next upgrade.
+	LastUpgradeDurationSeconds int32 `json:"lastUpgradeDurationSeconds,omitempty"`
+}
+
+// RayServiceUpgradeStatus describes a pending RayCluster that is prepared while the active RayCluster keeps
+// serving traffic. The pending RayCluster is promoted once its head Pod and Serve applications are ready.
+type RayServiceUpgradeStatus struct {
+	// StartTime is the time when the pending RayCluster was created.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EstimatedPromotionTime is when the pending RayCluster is expected to be promoted, based on the duration of
+	// the previous upgrade. It is unset until an upgrade of the RayService has completed.
+	EstimatedPromotionTime *metav1.Time `json:"estimatedPromotionTime,omitempty"`
+	// ActiveRayClusterName is the RayCluster serving traffic until the pending RayCluster is promoted.
+	ActiveRayClusterName string `json:"activeRayClusterName,omitempty"`
+	// PendingRayClusterName is the RayCluster replacing the active RayCluster.
+	PendingRayClusterName string `json:"pendingRayClusterName,omitempty"`
+	// ActiveRayClusterReady indicates whether all Serve applications of the active RayCluster are running.
+	ActiveRayClusterReady bool `json:"activeRayClusterReady,omitempty"`
+	// PendingRayClusterReady indicates whether the head Pod and all Serve applications of the pending RayCluster are ready.
+	PendingRayClusterReady bool `json:"pendingRayClusterReady,omitempty"`
+	// PromotionHeld indicates that the pending RayCluster is not promoted, even once it is ready, until the
+	// ray.io/hold-upgrade-promotion annotation is removed from the RayService.
+	PromotionHeld bool `json:"promotionHeld,omitempty"`
+	// Conditions are the requirements the pending RayCluster has to meet before it is promoted.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+type RayServiceUpgradeConditionType string
+
+const (
+	// PendingHeadPodReady indicates whether the head Pod of the pending RayCluster is ready.
+	PendingHeadPodReady RayServiceUpgradeConditionType = "PendingHeadPodReady"
+	// PendingServeApplicationsReady indicates whether all Serve applications of the pending RayCluster are running.
+	PendingServeApplicationsReady RayServiceUpgradeConditionType = "PendingServeApplicationsReady"
+)
+
+// Custom Reason for RayServiceUpgradeCondition
+const (
+	HeadPodNotReady           = "HeadPodNotReady"
+	ServeApplicationsRunning  = "ServeApplicationsRunning"
+	ServeApplicationsNotReady = "ServeApplicationsNotReady"
+)
+
 type RayServiceStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	Applications     map[string]AppStatus `json:"applicationStatuses,omitempty"`
 	RayClusterName   string               `json:"rayClusterName,omitempty"`
 	RayClusterStatus RayClusterStatus     `json:"rayClusterStatus,omitempty"`
+	// ConsecutiveHealthCheckFailures is the number of health checks of the RayCluster which failed in a row.
+	ConsecutiveHealthCheckFailures int32 `json:"consecutiveHealthCheckFailures,omitempty"`
+	// HealthCheckFailures are the most recent failed health checks of the RayCluster, oldest first.
+	HealthCheckFailures []HealthCheckFailure `json:"healthCheckFailures,omitempty"`
+}
+
+// HealthCheckFailure records a failed query of the Serve application statuses.
+type HealthCheckFailure struct {
+	// Time is when the health check failed.
+	Time metav1.Time `json:"time"`
+	// Message is the error returned by the health check.
+	Message string `json:"message,omitempty"`
 }
 
 type AppStatus struct {
@@ -94,6 +204,8 @@ type AppStatus struct {
 	Deployments          map[string]ServeDeploymentStatus `json:"serveDeploymentStatuses,omitempty"`
 	Status               string                           `json:"status,omitempty"`
 	Message              string                           `json:"message,omitempty"`
+	// RoutePrefix is the HTTP route prefix of the application, empty if it is not exposed over HTTP.
+	RoutePrefix string `json:"routePrefix,omitempty"`
 }
 
 // ServeDeploymentStatus defines the current state of a Serve deployment
@@ -105,6 +217,10 @@ type ServeDeploymentStatus struct {
 	// TODO: change status type to enum
 	Status  string `json:"status,omitempty"`
 	Message string `json:"message,omitempty"`
+	// RunningReplicas is the number of replicas of the deployment which are running.
+	RunningReplicas int32 `json:"runningReplicas,omitempty"`
+	// TargetReplicas is the number of replicas the deployment is scaled to.
+	TargetReplicas int32 `json:"targetReplicas,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -8,6 +8,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -102,6 +103,76 @@ func (in *AutoscalerOptions) DeepCopy() *AutoscalerOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleSource) DeepCopyInto(out *CABundleSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleSource.
+func (in *CABundleSource) DeepCopy() *CABundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardAuthConfig) DeepCopyInto(out *DashboardAuthConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardAuthConfig.
+func (in *DashboardAuthConfig) DeepCopy() *DashboardAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainBeforeDeletionConfig) DeepCopyInto(out *DrainBeforeDeletionConfig) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainBeforeDeletionConfig.
+func (in *DrainBeforeDeletionConfig) DeepCopy() *DrainBeforeDeletionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainBeforeDeletionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressConfig) DeepCopyInto(out *EgressConfig) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CABundleSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressConfig.
+func (in *EgressConfig) DeepCopy() *EgressConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeadGroupSpec) DeepCopyInto(out *HeadGroupSpec) {
 	*out = *in
@@ -122,6 +193,26 @@ func (in *HeadGroupSpec) DeepCopyInto(out *HeadGroupSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.PodTemplatePatch != nil {
+		in, out := &in.PodTemplatePatch, &out.PodTemplatePatch
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeadNodeReservation != nil {
+		in, out := &in.HeadNodeReservation, &out.HeadNodeReservation
+		*out = new(HeadNodeReservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PersistentStorage != nil {
+		in, out := &in.PersistentStorage, &out.PersistentStorage
+		*out = new(RayPersistentStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(HeadPlacementPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Template.DeepCopyInto(&out.Template)
 }
 
@@ -150,6 +241,153 @@ func (in *HeadInfo) DeepCopy() *HeadInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeadNodeReservation) DeepCopyInto(out *HeadNodeReservation) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadNodeReservation.
+func (in *HeadNodeReservation) DeepCopy() *HeadNodeReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(HeadNodeReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeadPlacementPolicy) DeepCopyInto(out *HeadPlacementPolicy) {
+	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Standby != nil {
+		in, out := &in.Standby, &out.Standby
+		*out = new(HeadStandby)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadPlacementPolicy.
+func (in *HeadPlacementPolicy) DeepCopy() *HeadPlacementPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HeadPlacementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeadStandby) DeepCopyInto(out *HeadStandby) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadStandby.
+func (in *HeadStandby) DeepCopy() *HeadStandby {
+	if in == nil {
+		return nil
+	}
+	out := new(HeadStandby)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckFailure) DeepCopyInto(out *HealthCheckFailure) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckFailure.
+func (in *HealthCheckFailure) DeepCopy() *HealthCheckFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePrePullConfig) DeepCopyInto(out *ImagePrePullConfig) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrePullConfig.
+func (in *ImagePrePullConfig) DeepCopy() *ImagePrePullConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrePullConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsConfig) DeepCopyInto(out *MetricsConfig) {
+	*out = *in
+	if in.MonitorLabels != nil {
+		in, out := &in.MonitorLabels, &out.MonitorLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsConfig.
+func (in *MetricsConfig) DeepCopy() *MetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeFillPolicy) DeepCopyInto(out *NodeFillPolicy) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Reserved != nil {
+		in, out := &in.Reserved, &out.Reserved
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeFillPolicy.
+func (in *NodeFillPolicy) DeepCopy() *NodeFillPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeFillPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayCluster) DeepCopyInto(out *RayCluster) {
 	*out = *in
@@ -209,6 +447,30 @@ func (in *RayClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayClusterPodBatchingStatus) DeepCopyInto(out *RayClusterPodBatchingStatus) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastBatchTime != nil {
+		in, out := &in.LastBatchTime, &out.LastBatchTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterPodBatchingStatus.
+func (in *RayClusterPodBatchingStatus) DeepCopy() *RayClusterPodBatchingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayClusterPodBatchingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
 	*out = *in
@@ -234,6 +496,68 @@ func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(RayClusterUpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.RemoteCluster != nil {
+		in, out := &in.RemoteCluster, &out.RemoteCluster
+		*out = new(RemoteClusterConfig)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePrePull != nil {
+		in, out := &in.ImagePrePull, &out.ImagePrePull
+		*out = new(ImagePrePullConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SystemConfig != nil {
+		in, out := &in.SystemConfig, &out.SystemConfig
+		*out = new(RaySystemConfigSource)
+		**out = **in
+	}
+	if in.DrainBeforeDeletion != nil {
+		in, out := &in.DrainBeforeDeletion, &out.DrainBeforeDeletion
+		*out = new(DrainBeforeDeletionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(RayLoggingConfig)
+		**out = **in
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(EgressConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DashboardAuth != nil {
+		in, out := &in.DashboardAuth, &out.DashboardAuth
+		*out = new(DashboardAuthConfig)
+		**out = **in
+	}
+	if in.UsageSnapshots != nil {
+		in, out := &in.UsageSnapshots, &out.UsageSnapshots
+		*out = new(UsageSnapshotConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessTimeouts != nil {
+		in, out := &in.ReadinessTimeouts, &out.ReadinessTimeouts
+		*out = new(ReadinessTimeoutsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	in.HeadGroupSpec.DeepCopyInto(&out.HeadGroupSpec)
 	if in.WorkerGroupSpecs != nil {
 		in, out := &in.WorkerGroupSpecs, &out.WorkerGroupSpecs
@@ -287,6 +611,23 @@ func (in *RayClusterStatus) DeepCopyInto(out *RayClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpgradeStatus != nil {
+		in, out := &in.UpgradeStatus, &out.UpgradeStatus
+		*out = new(RayClusterUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodBatching != nil {
+		in, out := &in.PodBatching, &out.PodBatching
+		*out = new(RayClusterPodBatchingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageSnapshots != nil {
+		in, out := &in.UsageSnapshots, &out.UsageSnapshots
+		*out = make([]RayClusterUsageSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterStatus.
@@ -299,6 +640,79 @@ func (in *RayClusterStatus) DeepCopy() *RayClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayClusterUpgradeStatus) DeepCopyInto(out *RayClusterUpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingGroups != nil {
+		in, out := &in.PendingGroups, &out.PendingGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterUpgradeStatus.
+func (in *RayClusterUpgradeStatus) DeepCopy() *RayClusterUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayClusterUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayClusterUpgradeStrategy) DeepCopyInto(out *RayClusterUpgradeStrategy) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(RayClusterUpgradeType)
+		**out = **in
+	}
+	if in.UpgradeHead != nil {
+		in, out := &in.UpgradeHead, &out.UpgradeHead
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterUpgradeStrategy.
+func (in *RayClusterUpgradeStrategy) DeepCopy() *RayClusterUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RayClusterUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayClusterUsageSnapshot) DeepCopyInto(out *RayClusterUsageSnapshot) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastHeadRestartTime != nil {
+		in, out := &in.LastHeadRestartTime, &out.LastHeadRestartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterUsageSnapshot.
+func (in *RayClusterUsageSnapshot) DeepCopy() *RayClusterUsageSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(RayClusterUsageSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayJob) DeepCopyInto(out *RayJob) {
 	*out = *in
@@ -340,24 +754,56 @@ func (in *RayJobList) DeepCopyInto(out *RayJobList) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobList.
-func (in *RayJobList) DeepCopy() *RayJobList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobList.
+func (in *RayJobList) DeepCopy() *RayJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobResult) DeepCopyInto(out *RayJobResult) {
+	*out = *in
+	if in.DriverExitCode != nil {
+		in, out := &in.DriverExitCode, &out.DriverExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RuntimeSeconds != nil {
+		in, out := &in.RuntimeSeconds, &out.RuntimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobResult.
+func (in *RayJobResult) DeepCopy() *RayJobResult {
 	if in == nil {
 		return nil
 	}
-	out := new(RayJobList)
+	out := new(RayJobResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RayJobList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayJobSpec) DeepCopyInto(out *RayJobSpec) {
 	*out = *in
@@ -400,6 +846,11 @@ func (in *RayJobSpec) DeepCopyInto(out *RayJobSpec) {
 		*out = new(SubmitterConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]RayJobStage, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobSpec.
@@ -412,6 +863,44 @@ func (in *RayJobSpec) DeepCopy() *RayJobSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobStage) DeepCopyInto(out *RayJobStage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStage.
+func (in *RayJobStage) DeepCopy() *RayJobStage {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJobStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobStageStatus) DeepCopyInto(out *RayJobStageStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStageStatus.
+func (in *RayJobStageStatus) DeepCopy() *RayJobStageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJobStageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayJobStatus) DeepCopyInto(out *RayJobStatus) {
 	*out = *in
@@ -433,7 +922,19 @@ func (in *RayJobStatus) DeepCopyInto(out *RayJobStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.JobResult != nil {
+		in, out := &in.JobResult, &out.JobResult
+		*out = new(RayJobResult)
+		(*in).DeepCopyInto(*out)
+	}
 	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]RayJobStageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStatus.
@@ -446,6 +947,42 @@ func (in *RayJobStatus) DeepCopy() *RayJobStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayLoggingConfig) DeepCopyInto(out *RayLoggingConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayLoggingConfig.
+func (in *RayLoggingConfig) DeepCopy() *RayLoggingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RayLoggingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayPersistentStorage) DeepCopyInto(out *RayPersistentStorage) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayPersistentStorage.
+func (in *RayPersistentStorage) DeepCopy() *RayPersistentStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(RayPersistentStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayService) DeepCopyInto(out *RayService) {
 	*out = *in
@@ -473,6 +1010,41 @@ func (in *RayService) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceHealthCheckPolicy) DeepCopyInto(out *RayServiceHealthCheckPolicy) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InitialBackoffSeconds != nil {
+		in, out := &in.InitialBackoffSeconds, &out.InitialBackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackoffSeconds != nil {
+		in, out := &in.MaxBackoffSeconds, &out.MaxBackoffSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceHealthCheckPolicy.
+func (in *RayServiceHealthCheckPolicy) DeepCopy() *RayServiceHealthCheckPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceHealthCheckPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RayServiceList) DeepCopyInto(out *RayServiceList) {
 	*out = *in
@@ -518,6 +1090,16 @@ func (in *RayServiceSpec) DeepCopyInto(out *RayServiceSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.HealthCheckPolicy != nil {
+		in, out := &in.HealthCheckPolicy, &out.HealthCheckPolicy
+		*out = new(RayServiceHealthCheckPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(RayServiceUpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServeService != nil {
 		in, out := &in.ServeService, &out.ServeService
 		*out = new(corev1.Service)
@@ -547,6 +1129,13 @@ func (in *RayServiceStatus) DeepCopyInto(out *RayServiceStatus) {
 		}
 	}
 	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
+	if in.HealthCheckFailures != nil {
+		in, out := &in.HealthCheckFailures, &out.HealthCheckFailures
+		*out = make([]HealthCheckFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceStatus.
@@ -568,6 +1157,11 @@ func (in *RayServiceStatuses) DeepCopyInto(out *RayServiceStatuses) {
 	}
 	in.ActiveServiceStatus.DeepCopyInto(&out.ActiveServiceStatus)
 	in.PendingServiceStatus.DeepCopyInto(&out.PendingServiceStatus)
+	if in.UpgradeStatus != nil {
+		in, out := &in.UpgradeStatus, &out.UpgradeStatus
+		*out = new(RayServiceUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceStatuses.
@@ -580,6 +1174,111 @@ func (in *RayServiceStatuses) DeepCopy() *RayServiceStatuses {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceUpgradeStatus) DeepCopyInto(out *RayServiceUpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EstimatedPromotionTime != nil {
+		in, out := &in.EstimatedPromotionTime, &out.EstimatedPromotionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceUpgradeStatus.
+func (in *RayServiceUpgradeStatus) DeepCopy() *RayServiceUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceUpgradeStrategy) DeepCopyInto(out *RayServiceUpgradeStrategy) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(RayServiceUpgradeType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceUpgradeStrategy.
+func (in *RayServiceUpgradeStrategy) DeepCopy() *RayServiceUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RaySystemConfigSource) DeepCopyInto(out *RaySystemConfigSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RaySystemConfigSource.
+func (in *RaySystemConfigSource) DeepCopy() *RaySystemConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RaySystemConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessTimeoutsConfig) DeepCopyInto(out *ReadinessTimeoutsConfig) {
+	*out = *in
+	if in.GCSReadySeconds != nil {
+		in, out := &in.GCSReadySeconds, &out.GCSReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DashboardAgentReadySeconds != nil {
+		in, out := &in.DashboardAgentReadySeconds, &out.DashboardAgentReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessTimeoutsConfig.
+func (in *ReadinessTimeoutsConfig) DeepCopy() *ReadinessTimeoutsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessTimeoutsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterConfig) DeepCopyInto(out *RemoteClusterConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterConfig.
+func (in *RemoteClusterConfig) DeepCopy() *RemoteClusterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleStrategy) DeepCopyInto(out *ScaleStrategy) {
 	*out = *in
@@ -600,6 +1299,36 @@ func (in *ScaleStrategy) DeepCopy() *ScaleStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingSchedule) DeepCopyInto(out *ScalingSchedule) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]ScalingScheduleDay, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingSchedule.
+func (in *ScalingSchedule) DeepCopy() *ScalingSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServeDeploymentStatus) DeepCopyInto(out *ServeDeploymentStatus) {
 	*out = *in
@@ -639,6 +1368,56 @@ func (in *SubmitterConfig) DeepCopy() *SubmitterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageSnapshotConfig) DeepCopyInto(out *UsageSnapshotConfig) {
+	*out = *in
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxHistory != nil {
+		in, out := &in.MaxHistory, &out.MaxHistory
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageSnapshotConfig.
+func (in *UsageSnapshotConfig) DeepCopy() *UsageSnapshotConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageSnapshotConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerGroupRolloutStrategy) DeepCopyInto(out *WorkerGroupRolloutStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerGroupRolloutStrategy.
+func (in *WorkerGroupRolloutStrategy) DeepCopy() *WorkerGroupRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerGroupRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerGroupSpec) DeepCopyInto(out *WorkerGroupSpec) {
 	*out = *in
@@ -664,8 +1443,35 @@ func (in *WorkerGroupSpec) DeepCopyInto(out *WorkerGroupSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.PodTemplatePatch != nil {
+		in, out := &in.PodTemplatePatch, &out.PodTemplatePatch
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Template.DeepCopyInto(&out.Template)
 	in.ScaleStrategy.DeepCopyInto(&out.ScaleStrategy)
+	if in.ScalingSchedules != nil {
+		in, out := &in.ScalingSchedules, &out.ScalingSchedules
+		*out = make([]ScalingSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PersistentStorage != nil {
+		in, out := &in.PersistentStorage, &out.PersistentStorage
+		*out = new(RayPersistentStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeFill != nil {
+		in, out := &in.NodeFill, &out.NodeFill
+		*out = new(NodeFillPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(WorkerGroupRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerGroupSpec.
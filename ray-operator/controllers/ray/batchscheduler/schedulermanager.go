@@ -1,9 +1,11 @@
 package batchscheduler
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 
@@ -15,9 +17,14 @@ import (
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/plugins"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
+// PluginName is the name of the batch scheduler pod mutation plugin.
+const PluginName = "batch-scheduler"
+
+// SchedulerManager is the builtin pod mutation plugin submitting RayClusters to the configured batch scheduler.
 type SchedulerManager struct {
 	config     *rest.Config
 	factory    schedulerinterface.BatchSchedulerFactory
@@ -100,3 +107,45 @@ func (batch *SchedulerManager) ConfigureReconciler(b *builder.Builder) *builder.
 func (batch *SchedulerManager) AddToScheme(scheme *runtime.Scheme) {
 	batch.factory.AddToScheme(scheme)
 }
+
+var _ plugins.Plugin = &SchedulerManager{}
+
+var _ plugins.SetupPlugin = &SchedulerManager{}
+
+var _ plugins.AdmissionPlugin = &SchedulerManager{}
+
+func (batch *SchedulerManager) Name() string {
+	return PluginName
+}
+
+// AfterClusterCreate submits the RayCluster to the batch scheduler.
+func (batch *SchedulerManager) AfterClusterCreate(ctx context.Context, app *rayv1.RayCluster) error {
+	scheduler, err := batch.GetSchedulerForCluster(app)
+	if err != nil {
+		return err
+	}
+	return scheduler.DoBatchSchedulingOnSubmission(ctx, app)
+}
+
+// BeforePodCreate adds the metadata needed by the batch scheduler to the Pod.
+func (batch *SchedulerManager) BeforePodCreate(_ context.Context, app *rayv1.RayCluster, groupName string, pod *corev1.Pod) error {
+	scheduler, err := batch.GetSchedulerForCluster(app)
+	if err != nil {
+		return err
+	}
+	scheduler.AddMetadataToPod(app, groupName, pod)
+	return nil
+}
+
+// GetAdmissionStatus reports the admission state of the RayCluster if the batch scheduler manages its admission.
+func (batch *SchedulerManager) GetAdmissionStatus(ctx context.Context, app *rayv1.RayCluster, pods []corev1.Pod) (*plugins.AdmissionStatus, error) {
+	scheduler, err := batch.GetSchedulerForCluster(app)
+	if err != nil {
+		return nil, err
+	}
+	admission, ok := scheduler.(plugins.AdmissionPlugin)
+	if !ok {
+		return nil, nil
+	}
+	return admission.GetAdmissionStatus(ctx, app, pods)
+}
@@ -24,6 +24,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/plugins"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
@@ -146,6 +147,39 @@ func (v *VolcanoBatchScheduler) AddMetadataToPod(app *rayv1.RayCluster, groupNam
 	pod.Spec.SchedulerName = v.Name()
 }
 
+// GetAdmissionStatus reports whether Volcano admitted the PodGroup of the RayCluster. It returns nil until the
+// PodGroup is created.
+func (v *VolcanoBatchScheduler) GetAdmissionStatus(ctx context.Context, app *rayv1.RayCluster, _ []corev1.Pod) (*plugins.AdmissionStatus, error) {
+	pg, err := v.volcanoClient.SchedulingV1beta1().PodGroups(app.Namespace).Get(ctx, getAppPodGroupName(app), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return getPodGroupAdmissionStatus(pg), nil
+}
+
+// getPodGroupAdmissionStatus converts the status of a PodGroup. A PodGroup is admitted once Volcano moved it
+// out of the Pending phase, i.e. once its queue has enough quota for the whole gang.
+func getPodGroupAdmissionStatus(pg *v1beta1.PodGroup) *plugins.AdmissionStatus {
+	status := &plugins.AdmissionStatus{
+		Scheduler: GetPluginName(),
+		QueueName: pg.Spec.Queue,
+		Admitted:  pg.Status.Phase != "" && pg.Status.Phase != v1beta1.PodGroupPending,
+	}
+	if status.Admitted {
+		return status
+	}
+	status.Message = "PodGroup " + pg.Name + " is pending"
+	for _, condition := range pg.Status.Conditions {
+		if condition.Type == v1beta1.PodGroupUnschedulableType && condition.Status == corev1.ConditionTrue && condition.Message != "" {
+			status.Message = condition.Message
+		}
+	}
+	return status
+}
+
 func (vf *VolcanoBatchSchedulerFactory) New(config *rest.Config) (schedulerinterface.BatchScheduler, error) {
 	vkClient, err := volcanoclient.NewForConfig(config)
 	if err != nil {
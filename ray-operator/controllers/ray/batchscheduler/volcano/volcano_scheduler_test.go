@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
@@ -96,3 +97,35 @@ func TestCreatePodGroup(t *testing.T) {
 	// 2 GPUs total
 	a.Equal("2", pg.Spec.MinResources.Name("nvidia.com/gpu", resource.BinarySI).String())
 }
+
+func TestGetPodGroupAdmissionStatus(t *testing.T) {
+	a := assert.New(t)
+
+	pg := &v1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "ray-raycluster-sample-pg"},
+		Spec:       v1beta1.PodGroupSpec{Queue: "team-a"},
+		Status:     v1beta1.PodGroupStatus{Phase: v1beta1.PodGroupPending},
+	}
+	status := getPodGroupAdmissionStatus(pg)
+	a.Equal("volcano", status.Scheduler)
+	a.Equal("team-a", status.QueueName)
+	a.False(status.Admitted)
+	a.Equal("PodGroup ray-raycluster-sample-pg is pending", status.Message)
+
+	// The message of the Unschedulable condition explains why the PodGroup is pending.
+	pg.Status.Conditions = []v1beta1.PodGroupCondition{
+		{
+			Type:    v1beta1.PodGroupUnschedulableType,
+			Status:  corev1.ConditionTrue,
+			Message: "3/3 tasks in gang unschedulable: queue resource quota insufficient",
+		},
+	}
+	status = getPodGroupAdmissionStatus(pg)
+	a.False(status.Admitted)
+	a.Equal("3/3 tasks in gang unschedulable: queue resource quota insufficient", status.Message)
+
+	pg.Status.Phase = v1beta1.PodGroupInqueue
+	status = getPodGroupAdmissionStatus(pg)
+	a.True(status.Admitted)
+	a.Empty(status.Message)
+}
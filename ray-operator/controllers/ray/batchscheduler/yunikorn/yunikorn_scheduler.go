@@ -2,6 +2,7 @@ package yunikorn
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -12,6 +13,7 @@ import (
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	schedulerinterface "github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/interface"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/plugins"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
@@ -107,6 +109,32 @@ func (y *YuniKornScheduler) populateTaskGroupsAnnotationToPod(app *rayv1.RayClus
 		"RayCluster", app.Name, "Namespace", app.Namespace)
 }
 
+// GetAdmissionStatus reports whether YuniKorn admitted the Ray Pods. YuniKorn keeps the Pods unscheduled
+// until their queue has enough quota, so a Pod with a false PodScheduled condition is waiting for admission.
+// It returns nil for RayClusters which neither use a queue nor gang scheduling.
+func (y *YuniKornScheduler) GetAdmissionStatus(_ context.Context, app *rayv1.RayCluster, pods []corev1.Pod) (*plugins.AdmissionStatus, error) {
+	queue, hasQueue := app.Labels[RayClusterQueueLabelName]
+	if !hasQueue && !y.isGangSchedulingEnabled(app) {
+		return nil, nil
+	}
+
+	status := &plugins.AdmissionStatus{
+		Scheduler: GetPluginName(),
+		QueueName: queue,
+		Admitted:  true,
+	}
+	for _, pod := range pods {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+				status.Admitted = false
+				status.Message = fmt.Sprintf("Pod %s is not scheduled: %s", pod.Name, condition.Message)
+				return status, nil
+			}
+		}
+	}
+	return status, nil
+}
+
 func (yf *YuniKornSchedulerFactory) New(_ *rest.Config) (schedulerinterface.BatchScheduler, error) {
 	return &YuniKornScheduler{
 		log: logf.Log.WithName(SchedulerName),
@@ -1,6 +1,7 @@
 package yunikorn
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -164,6 +165,36 @@ func TestPopulateGangSchedulingAnnotations(t *testing.T) {
 	assert.Equal(t, resource.MustParse("1"), workerGroup.MinResource["nvidia.com/gpu"])
 }
 
+func TestGetAdmissionStatus(t *testing.T) {
+	yk := &YuniKornScheduler{}
+
+	// RayClusters without a queue and without gang scheduling are not reported.
+	rayCluster := createRayClusterWithLabels("ray-cluster", "test", nil)
+	status, err := yk.GetAdmissionStatus(context.Background(), rayCluster, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+
+	rayCluster = createRayClusterWithLabels("ray-cluster", "test", map[string]string{
+		RayClusterQueueLabelName: "root.default",
+	})
+	scheduled := *createPod("head", "test")
+	scheduled.Status.Conditions = []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionTrue}}
+	status, err = yk.GetAdmissionStatus(context.Background(), rayCluster, []v1.Pod{scheduled})
+	assert.NoError(t, err)
+	assert.Equal(t, "yunikorn", status.Scheduler)
+	assert.Equal(t, "root.default", status.QueueName)
+	assert.True(t, status.Admitted)
+
+	pending := *createPod("worker", "test")
+	pending.Status.Conditions = []v1.PodCondition{
+		{Type: v1.PodScheduled, Status: v1.ConditionFalse, Message: "queue root.default has not enough quota"},
+	}
+	status, err = yk.GetAdmissionStatus(context.Background(), rayCluster, []v1.Pod{scheduled, pending})
+	assert.NoError(t, err)
+	assert.False(t, status.Admitted)
+	assert.Equal(t, "Pod worker is not scheduled: queue root.default has not enough quota", status.Message)
+}
+
 func createRayClusterWithLabels(name string, namespace string, labels map[string]string) *rayv1.RayCluster {
 	rayCluster := &rayv1.RayCluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -21,6 +21,13 @@ func RayClusterServeServiceNamespacedName(instance *rayv1.RayCluster) types.Name
 	}
 }
 
+func RayClusterMetricsServiceNamespacedName(instance *rayv1.RayCluster) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: instance.Namespace,
+		Name:      utils.GenerateMetricsServiceName(instance.Name),
+	}
+}
+
 func RayClusterAutoscalerRoleNamespacedName(instance *rayv1.RayCluster) types.NamespacedName {
 	return types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
 }
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// dashboardAuthProxyCommand writes the nginx config of the auth proxy sidecar and starts nginx. The token is
+// substituted by the shell, while the nginx variables are escaped. The GCS health check stays unauthenticated,
+// because the probes of the Ray container query it through the dashboard port.
+const dashboardAuthProxyCommand = `cat > /etc/nginx/conf.d/default.conf <<EOF
+server {
+    listen %[1]d;
+    client_max_body_size 0;
+    location = /%[3]s {
+        proxy_pass http://127.0.0.1:%[2]d;
+    }
+    location / {
+        if (\$http_authorization != "Bearer ${%[4]s}") {
+            return 401;
+        }
+        proxy_pass http://127.0.0.1:%[2]d;
+        proxy_http_version 1.1;
+        proxy_set_header Upgrade \$http_upgrade;
+        proxy_set_header Connection \$http_connection;
+        proxy_buffering off;
+        proxy_read_timeout 1h;
+    }
+}
+EOF
+exec nginx -g 'daemon off;'`
+
+// BuildDashboardAuthSecret builds the Secret which holds a random auth token for the Ray dashboard of the RayCluster.
+func BuildDashboardAuthSecret(cluster *rayv1.RayCluster) (*corev1.Secret, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateDashboardAuthSecretName(cluster.Name),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:                cluster.Name,
+				utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+				utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			utils.DashboardAuthTokenKey: []byte(hex.EncodeToString(token)),
+		},
+	}, nil
+}
+
+// DashboardAuthTokenEnvVar returns the environment variable which holds the dashboard auth token of the RayCluster.
+func DashboardAuthTokenEnvVar(clusterName string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: utils.RAY_DASHBOARD_AUTH_TOKEN,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: utils.GenerateDashboardAuthSecretName(clusterName)},
+				Key:                  utils.DashboardAuthTokenKey,
+			},
+		},
+	}
+}
+
+// addDashboardAuthProxy moves the Ray dashboard of the head Pod to the loopback interface and serves the dashboard
+// port with an auth proxy sidecar, which requires the auth token of the RayCluster. The dashboard-host and
+// dashboard-port ray start params are overridden.
+func addDashboardAuthProxy(instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec, rayStartParams map[string]string) {
+	dashboardAuth := instance.Spec.DashboardAuth
+	if dashboardAuth == nil {
+		return
+	}
+	dashboardPort := utils.FindContainerPort(&podTemplate.Spec.Containers[utils.RayContainerIndex], utils.DashboardPortName, utils.DefaultDashboardPort)
+	rayStartParams["dashboard-host"] = "127.0.0.1"
+	rayStartParams["dashboard-port"] = strconv.Itoa(utils.DashboardAuthUpstreamPort)
+
+	image := dashboardAuth.ProxyImage
+	if image == "" {
+		image = utils.DefaultDashboardAuthProxyImage
+	}
+	proxyContainer := corev1.Container{
+		Name:    utils.DashboardAuthProxyContainerName,
+		Image:   image,
+		Command: []string{"/bin/sh", "-c"},
+		Args: []string{
+			fmt.Sprintf(dashboardAuthProxyCommand, dashboardPort, utils.DashboardAuthUpstreamPort, utils.RayDashboardGCSHealthPath, utils.RAY_DASHBOARD_AUTH_TOKEN),
+		},
+		Env: []corev1.EnvVar{DashboardAuthTokenEnvVar(instance.Name)},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+	}
+	// The containers are shared with the RayCluster spec, so copy them before appending the sidecar.
+	podTemplate.Spec.Containers = append(append([]corev1.Container(nil), podTemplate.Spec.Containers...), proxyContainer)
+}
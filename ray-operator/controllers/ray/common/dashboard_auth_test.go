@@ -0,0 +1,61 @@
+package common
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildDashboardAuthSecret(t *testing.T) {
+	cluster := instance.DeepCopy()
+
+	secret, err := BuildDashboardAuthSecret(cluster)
+	require.NoError(t, err)
+	assert.Equal(t, utils.GenerateDashboardAuthSecretName(cluster.Name), secret.Name)
+	assert.Equal(t, cluster.Namespace, secret.Namespace)
+	assert.Equal(t, cluster.Name, secret.Labels[utils.RayClusterLabelKey])
+	assert.Len(t, secret.Data[utils.DashboardAuthTokenKey], 64)
+
+	// Every Secret gets a new random token.
+	other, err := BuildDashboardAuthSecret(cluster)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret.Data[utils.DashboardAuthTokenKey], other.Data[utils.DashboardAuthTokenKey])
+}
+
+func TestDefaultHeadPodTemplateWithDashboardAuth(t *testing.T) {
+	cluster := instance.DeepCopy()
+	ctx := context.Background()
+	podName := cluster.Name + utils.DashSymbol + string(rayv1.HeadNode)
+
+	// Without dashboardAuth, no auth proxy is added.
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	for _, container := range podTemplateSpec.Spec.Containers {
+		assert.NotEqual(t, utils.DashboardAuthProxyContainerName, container.Name)
+	}
+	assert.NotContains(t, cluster.Spec.HeadGroupSpec.RayStartParams, "dashboard-port")
+
+	cluster.Spec.DashboardAuth = &rayv1.DashboardAuthConfig{}
+	numContainers := len(cluster.Spec.HeadGroupSpec.Template.Spec.Containers)
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	require.Len(t, podTemplateSpec.Spec.Containers, numContainers+1)
+	proxy := podTemplateSpec.Spec.Containers[numContainers]
+	assert.Equal(t, utils.DashboardAuthProxyContainerName, proxy.Name)
+	assert.Equal(t, utils.DefaultDashboardAuthProxyImage, proxy.Image)
+	assert.Equal(t, utils.GenerateDashboardAuthSecretName(cluster.Name), proxy.Env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Contains(t, proxy.Args[0], "listen "+strconv.Itoa(utils.DefaultDashboardPort)+";")
+	// The Ray dashboard only listens on the loopback interface behind the proxy.
+	assert.Equal(t, "127.0.0.1", cluster.Spec.HeadGroupSpec.RayStartParams["dashboard-host"])
+	assert.Equal(t, strconv.Itoa(utils.DashboardAuthUpstreamPort), cluster.Spec.HeadGroupSpec.RayStartParams["dashboard-port"])
+	// The containers of the RayCluster spec are not modified.
+	assert.Len(t, cluster.Spec.HeadGroupSpec.Template.Spec.Containers, numContainers)
+
+	cluster.Spec.DashboardAuth.ProxyImage = "my-registry/nginx:latest"
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	assert.Equal(t, "my-registry/nginx:latest", podTemplateSpec.Spec.Containers[numContainers].Image)
+}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// BuildHeadStandbyPod builds the placeholder Pod which reserves capacity for the head Pod in another failure domain
+// than the head Pod. It requests the resources of the Ray container of the head Pod and is scheduled on the same
+// nodes as the head Pod, except for the failure domain the head Pod runs in.
+func BuildHeadStandbyPod(cluster rayv1.RayCluster) *corev1.Pod {
+	headSpec := cluster.Spec.HeadGroupSpec
+	placement := headSpec.Placement
+	template := *headSpec.Template.DeepCopy()
+	if headSpec.HeadNodeReservation != nil {
+		// The ray start params are not used by the standby Pod.
+		reserveHeadNode(&template, map[string]string{}, headSpec.HeadNodeReservation)
+	}
+	addHeadPlacementAffinity(&template, placement)
+
+	resources := template.Spec.Containers[utils.RayContainerIndex].Resources
+	requests := resources.Requests
+	if len(requests) == 0 {
+		// Kubernetes defaults the requests to the limits.
+		requests = resources.Limits
+	}
+
+	// Only the constraints which decide the nodes of the head Pod are copied. The standby Pod avoids the failure
+	// domain of the head Pod instead of following the pod affinity of the head Pod.
+	affinity := &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							utils.RayClusterLabelKey:  cluster.Name,
+							utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+						},
+					},
+					TopologyKey: headPlacementTopologyKey(placement),
+				},
+			},
+		},
+	}
+	if template.Spec.Affinity != nil && template.Spec.Affinity.NodeAffinity != nil {
+		affinity.NodeAffinity = template.Spec.Affinity.NodeAffinity
+	}
+
+	var priorityClassName string
+	if placement.Standby != nil {
+		priorityClassName = placement.Standby.PriorityClassName
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateHeadStandbyPodName(cluster.Name),
+			Namespace: cluster.Namespace,
+			// The Pod is deliberately not labeled with ray.io/cluster, so that it is not selected by the Services
+			// and the Pod lists of the RayCluster.
+			Labels: map[string]string{
+				utils.RayClusterHeadStandbyLabelKey:     cluster.Name,
+				utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+				utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "pause",
+					Image: utils.ImagePrePullPauseImage,
+					Resources: corev1.ResourceRequirements{
+						Requests: requests,
+					},
+				},
+			},
+			NodeSelector:      template.Spec.NodeSelector,
+			Affinity:          affinity,
+			Tolerations:       template.Spec.Tolerations,
+			ImagePullSecrets:  template.Spec.ImagePullSecrets,
+			PriorityClassName: priorityClassName,
+		},
+	}
+}
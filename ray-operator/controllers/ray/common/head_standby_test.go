@@ -0,0 +1,70 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildHeadStandbyPod(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Spec.NodeSelector = map[string]string{"node-pool": "cpu"}
+	cluster.Spec.HeadGroupSpec.Placement = &rayv1.HeadPlacementPolicy{
+		Domains: []string{"zone-a", "zone-b"},
+		Standby: &rayv1.HeadStandby{PriorityClassName: "low-priority"},
+	}
+
+	pod := BuildHeadStandbyPod(*cluster)
+	assert.Equal(t, utils.GenerateHeadStandbyPodName(cluster.Name), pod.Name)
+	assert.Equal(t, cluster.Namespace, pod.Namespace)
+	assert.Equal(t, cluster.Name, pod.Labels[utils.RayClusterHeadStandbyLabelKey])
+	// The standby Pod must not be selected as a Pod of the RayCluster.
+	assert.NotContains(t, pod.Labels, utils.RayClusterLabelKey)
+	assert.Equal(t, "low-priority", pod.Spec.PriorityClassName)
+	assert.Equal(t, map[string]string{"node-pool": "cpu"}, pod.Spec.NodeSelector)
+
+	// The standby Pod requests the resources of the Ray container of the head Pod.
+	assert.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, utils.ImagePrePullPauseImage, pod.Spec.Containers[0].Image)
+	assert.Equal(t, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Resources.Requests, pod.Spec.Containers[0].Resources.Requests)
+
+	// The standby Pod avoids the failure domain of the head Pod, but is restricted to the domains of the head Pod.
+	antiAffinity := pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	assert.Len(t, antiAffinity, 1)
+	assert.Equal(t, utils.DefaultHeadPlacementTopologyKey, antiAffinity[0].TopologyKey)
+	assert.Equal(t, map[string]string{
+		utils.RayClusterLabelKey:  cluster.Name,
+		utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+	}, antiAffinity[0].LabelSelector.MatchLabels)
+	assert.Equal(t, []string{"zone-a", "zone-b"}, pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Values)
+	// The RayCluster spec is not modified.
+	assert.Nil(t, cluster.Spec.HeadGroupSpec.Template.Spec.Affinity)
+}
+
+func TestBuildHeadStandbyPodWithHeadNodeReservation(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Placement = &rayv1.HeadPlacementPolicy{
+		TopologyKey: "node-pool",
+		Standby:     &rayv1.HeadStandby{},
+	}
+	// Without requests, the requests of the head Pod default to the limits.
+	cluster.Spec.HeadGroupSpec.HeadNodeReservation = &rayv1.HeadNodeReservation{
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+		},
+	}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Resources.Requests = nil
+
+	pod := BuildHeadStandbyPod(*cluster)
+	requests := pod.Spec.Containers[0].Resources.Requests
+	assert.True(t, requests.Memory().Equal(resource.MustParse("8Gi")))
+	assert.True(t, requests.Cpu().Equal(resource.MustParse("1")))
+	assert.Equal(t, "node-pool", pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey)
+	// Without domains, the standby Pod has no node affinity.
+	assert.Nil(t, pod.Spec.Affinity.NodeAffinity)
+}
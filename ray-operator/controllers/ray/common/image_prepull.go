@@ -0,0 +1,138 @@
+package common
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// imagePrePullResources are the resources of the containers of the image pre-pull Pods. They are kept small,
+// so that the Pods fit next to the Ray Pods on the nodes.
+var imagePrePullResources = corev1.ResourceRequirements{
+	Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("10m"),
+		corev1.ResourceMemory: resource.MustParse("16Mi"),
+	},
+	Limits: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("10m"),
+		corev1.ResourceMemory: resource.MustParse("16Mi"),
+	},
+}
+
+// RayClusterImagePrePullDaemonSetsAssociationOptions selects the image pre-pull DaemonSets of a RayCluster.
+func RayClusterImagePrePullDaemonSetsAssociationOptions(instance *rayv1.RayCluster) AssociationOptions {
+	return AssociationOptions{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{
+			utils.RayClusterLabelKey: instance.Name,
+		},
+		client.HasLabels{utils.RayClusterImagePrePullLabelKey},
+	}
+}
+
+// BuildImagePrePullDaemonSets builds one DaemonSet for the head group and for every worker group of the RayCluster.
+// Each DaemonSet runs on the nodes which the Pods of its group can be scheduled on and pulls the images of the group
+// in init containers, so that the images are cached on the nodes before the Ray Pods are created.
+func BuildImagePrePullDaemonSets(cluster rayv1.RayCluster) ([]*appsv1.DaemonSet, error) {
+	headTemplate, err := patchedPodTemplate(cluster.Spec.HeadGroupSpec.Template, cluster.Spec.HeadGroupSpec.PodTemplatePatch)
+	if err != nil {
+		return nil, err
+	}
+	daemonSets := []*appsv1.DaemonSet{buildImagePrePullDaemonSet(cluster, utils.RayNodeHeadGroupLabelValue, headTemplate)}
+	for _, worker := range cluster.Spec.WorkerGroupSpecs {
+		template, err := patchedPodTemplate(worker.Template, worker.PodTemplatePatch)
+		if err != nil {
+			return nil, fmt.Errorf("worker group %s: %w", worker.GroupName, err)
+		}
+		daemonSets = append(daemonSets, buildImagePrePullDaemonSet(cluster, worker.GroupName, template))
+	}
+	return daemonSets, nil
+}
+
+// IsImagePrePullDaemonSetDone returns true once a Pod of the DaemonSet is ready on every node it is scheduled on,
+// i.e. the images were pulled on all the nodes.
+func IsImagePrePullDaemonSetDone(daemonSet *appsv1.DaemonSet) bool {
+	return daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+		daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled
+}
+
+// patchedPodTemplate applies the podTemplatePatch of a group, which can change the images of the Ray Pods.
+func patchedPodTemplate(template corev1.PodTemplateSpec, patch *runtime.RawExtension) (corev1.PodTemplateSpec, error) {
+	pod := corev1.Pod{ObjectMeta: *template.ObjectMeta.DeepCopy(), Spec: *template.Spec.DeepCopy()}
+	if err := ApplyPodTemplatePatch(&pod, patch); err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+	return corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec}, nil
+}
+
+func buildImagePrePullDaemonSet(cluster rayv1.RayCluster, groupName string, template corev1.PodTemplateSpec) *appsv1.DaemonSet {
+	name := utils.GenerateImagePrePullDaemonSetName(cluster.Name, groupName)
+	// The Pods are deliberately not labeled with ray.io/cluster, so that they are not selected by the Services
+	// and the Pod lists of the RayCluster.
+	selector := map[string]string{utils.RayClusterImagePrePullLabelKey: name}
+
+	var initContainers []corev1.Container
+	seen := map[string]bool{}
+	for _, container := range append(template.Spec.InitContainers, template.Spec.Containers...) {
+		if container.Image == "" || seen[container.Image] {
+			continue
+		}
+		seen[container.Image] = true
+		initContainers = append(initContainers, corev1.Container{
+			Name:            fmt.Sprintf("pre-pull-%d", len(initContainers)),
+			Image:           container.Image,
+			ImagePullPolicy: container.ImagePullPolicy,
+			Command:         []string{"sh", "-c", "true"},
+			Resources:       imagePrePullResources,
+		})
+	}
+
+	// Only the constraints which decide the nodes of the group are copied. The pod affinity of the Ray Pods refers to
+	// other Pods and does not apply to the pre-pull Pods.
+	var affinity *corev1.Affinity
+	if template.Spec.Affinity != nil && template.Spec.Affinity.NodeAffinity != nil {
+		affinity = &corev1.Affinity{NodeAffinity: template.Spec.Affinity.NodeAffinity.DeepCopy()}
+	}
+
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:                cluster.Name,
+				utils.RayNodeGroupLabelKey:              groupName,
+				utils.RayClusterImagePrePullLabelKey:    name,
+				utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+				utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+			},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: selector},
+				Spec: corev1.PodSpec{
+					InitContainers: initContainers,
+					Containers: []corev1.Container{
+						{
+							Name:      "pause",
+							Image:     utils.ImagePrePullPauseImage,
+							Resources: imagePrePullResources,
+						},
+					},
+					NodeSelector:     template.Spec.NodeSelector,
+					Affinity:         affinity,
+					Tolerations:      template.Spec.Tolerations,
+					ImagePullSecrets: template.Spec.ImagePullSecrets,
+				},
+			},
+		},
+	}
+}
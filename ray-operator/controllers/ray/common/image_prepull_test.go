@@ -0,0 +1,123 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildImagePrePullDaemonSets(t *testing.T) {
+	cluster := rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raycluster-sample",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayClusterSpec{
+			ImagePrePull: &rayv1.ImagePrePullConfig{},
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "ray-head", Image: "rayproject/ray:2.9.0", ImagePullPolicy: corev1.PullIfNotPresent},
+							{Name: "sidecar", Image: "rayproject/ray:2.9.0"},
+						},
+						NodeSelector: map[string]string{"pool": "cpu"},
+					},
+				},
+			},
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{
+					GroupName: "gpu-group",
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							InitContainers: []corev1.Container{{Name: "init", Image: "busybox:1.28"}},
+							Containers:     []corev1.Container{{Name: "ray-worker", Image: "rayproject/ray:2.9.0"}},
+							Affinity: &corev1.Affinity{
+								NodeAffinity: &corev1.NodeAffinity{
+									RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{},
+								},
+								PodAntiAffinity: &corev1.PodAntiAffinity{},
+							},
+							Tolerations:      []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}},
+							ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+						},
+					},
+					// The podTemplatePatch can change the image of the Ray container.
+					PodTemplatePatch: &runtime.RawExtension{
+						Raw: []byte(`{"spec":{"containers":[{"name":"ray-worker","image":"rayproject/ray:2.9.0-gpu"}]}}`),
+					},
+				},
+			},
+		},
+	}
+
+	daemonSets, err := BuildImagePrePullDaemonSets(cluster)
+	require.NoError(t, err)
+	require.Len(t, daemonSets, 2)
+
+	head := daemonSets[0]
+	assert.Equal(t, "raycluster-sample-headgroup-pre-pull", head.Name)
+	assert.Equal(t, "default", head.Namespace)
+	assert.Equal(t, cluster.Name, head.Labels[utils.RayClusterLabelKey])
+	assert.Equal(t, utils.RayNodeHeadGroupLabelValue, head.Labels[utils.RayNodeGroupLabelKey])
+	// The pre-pull Pods must not be selected as Ray Pods.
+	selector := map[string]string{utils.RayClusterImagePrePullLabelKey: head.Name}
+	assert.Equal(t, selector, head.Spec.Selector.MatchLabels)
+	assert.Equal(t, selector, head.Spec.Template.Labels)
+	assert.Equal(t, map[string]string{"pool": "cpu"}, head.Spec.Template.Spec.NodeSelector)
+	assert.Nil(t, head.Spec.Template.Spec.Affinity)
+	// Every image is pulled once.
+	require.Len(t, head.Spec.Template.Spec.InitContainers, 1)
+	assert.Equal(t, "rayproject/ray:2.9.0", head.Spec.Template.Spec.InitContainers[0].Image)
+	assert.Equal(t, corev1.PullIfNotPresent, head.Spec.Template.Spec.InitContainers[0].ImagePullPolicy)
+	require.Len(t, head.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, utils.ImagePrePullPauseImage, head.Spec.Template.Spec.Containers[0].Image)
+
+	worker := daemonSets[1]
+	assert.Equal(t, "raycluster-sample-gpu-group-pre-pull", worker.Name)
+	assert.Equal(t, "gpu-group", worker.Labels[utils.RayNodeGroupLabelKey])
+	var images []string
+	for _, container := range worker.Spec.Template.Spec.InitContainers {
+		images = append(images, container.Image)
+	}
+	assert.Equal(t, []string{"busybox:1.28", "rayproject/ray:2.9.0-gpu"}, images)
+	// Only the node affinity decides the nodes of the group.
+	require.NotNil(t, worker.Spec.Template.Spec.Affinity)
+	assert.NotNil(t, worker.Spec.Template.Spec.Affinity.NodeAffinity)
+	assert.Nil(t, worker.Spec.Template.Spec.Affinity.PodAntiAffinity)
+	assert.Equal(t, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Tolerations, worker.Spec.Template.Spec.Tolerations)
+	assert.Equal(t, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.ImagePullSecrets, worker.Spec.Template.Spec.ImagePullSecrets)
+
+	// An invalid podTemplatePatch is reported.
+	cluster.Spec.WorkerGroupSpecs[0].PodTemplatePatch = &runtime.RawExtension{Raw: []byte(`{"spec":`)}
+	_, err = BuildImagePrePullDaemonSets(cluster)
+	assert.ErrorContains(t, err, "gpu-group")
+}
+
+func TestIsImagePrePullDaemonSetDone(t *testing.T) {
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration:     0,
+			DesiredNumberScheduled: 2,
+			NumberReady:            2,
+		},
+	}
+	// The status is not observed yet.
+	assert.False(t, IsImagePrePullDaemonSetDone(daemonSet))
+
+	daemonSet.Status.ObservedGeneration = 1
+	daemonSet.Status.NumberReady = 1
+	assert.False(t, IsImagePrePullDaemonSetDone(daemonSet))
+
+	daemonSet.Status.NumberReady = 2
+	assert.True(t, IsImagePrePullDaemonSetDone(daemonSet))
+}
@@ -0,0 +1,105 @@
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// The Go types of the Prometheus Operator are not a dependency of KubeRay, so the monitors are built as
+// unstructured objects.
+var prometheusOperatorGroupVersion = schema.GroupVersion{Group: "monitoring.coreos.com", Version: "v1"}
+
+// BuildMetricsServiceForRayCluster builds a headless Service which selects the head and worker Pods of the
+// RayCluster, so that Prometheus can discover the metrics endpoint of every Ray node.
+func BuildMetricsServiceForRayCluster(cluster rayv1.RayCluster) *corev1.Service {
+	labels := map[string]string{
+		utils.RayClusterLabelKey:                cluster.Name,
+		utils.RayClusterMetricsServiceLabelKey:  cluster.Name,
+		utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+		utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateMetricsServiceName(cluster.Name),
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "None",
+			Selector: map[string]string{
+				utils.RayClusterLabelKey: cluster.Name,
+			},
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name: utils.MetricsPortName,
+					Port: utils.DefaultMetricsPort,
+					// The target port is referenced by name, so that Pods which override the metrics port are scraped too.
+					TargetPort: intstr.FromString(utils.MetricsPortName),
+				},
+			},
+		},
+	}
+}
+
+// BuildMonitorForRayCluster builds the Prometheus Operator ServiceMonitor or PodMonitor configured by
+// spec.metrics.monitorType. It returns nil if no monitor is requested.
+func BuildMonitorForRayCluster(cluster rayv1.RayCluster) *unstructured.Unstructured {
+	metrics := cluster.Spec.Metrics
+	if metrics == nil || metrics.MonitorType == "" {
+		return nil
+	}
+
+	endpoint := map[string]interface{}{
+		"port": utils.MetricsPortName,
+	}
+	if metrics.ScrapeInterval != "" {
+		endpoint["interval"] = metrics.ScrapeInterval
+	}
+	spec := map[string]interface{}{
+		"jobLabel": utils.RayClusterLabelKey,
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []interface{}{cluster.Namespace},
+		},
+		// Add the cluster, node type and group name of the Pods to the scraped metrics.
+		"podTargetLabels": []interface{}{utils.RayClusterLabelKey, utils.RayNodeTypeLabelKey, utils.RayNodeGroupLabelKey},
+	}
+
+	switch metrics.MonitorType {
+	case rayv1.ServiceMonitorType:
+		spec["selector"] = map[string]interface{}{
+			"matchLabels": map[string]interface{}{utils.RayClusterMetricsServiceLabelKey: cluster.Name},
+		}
+		spec["endpoints"] = []interface{}{endpoint}
+	case rayv1.PodMonitorType:
+		spec["selector"] = map[string]interface{}{
+			"matchLabels": map[string]interface{}{utils.RayClusterLabelKey: cluster.Name},
+		}
+		spec["podMetricsEndpoints"] = []interface{}{endpoint}
+	default:
+		return nil
+	}
+
+	labels := map[string]string{
+		utils.RayClusterLabelKey:                cluster.Name,
+		utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+		utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+	}
+	for k, v := range metrics.MonitorLabels {
+		labels[k] = v
+	}
+
+	monitor := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	monitor.SetGroupVersionKind(prometheusOperatorGroupVersion.WithKind(string(metrics.MonitorType)))
+	monitor.SetName(cluster.Name)
+	monitor.SetNamespace(cluster.Namespace)
+	monitor.SetLabels(labels)
+	return monitor
+}
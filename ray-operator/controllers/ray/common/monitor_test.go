@@ -0,0 +1,83 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func metricsTestCluster(metrics *rayv1.MetricsConfig) rayv1.RayCluster {
+	return rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raycluster-sample",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayClusterSpec{
+			Metrics: metrics,
+		},
+	}
+}
+
+func TestBuildMetricsServiceForRayCluster(t *testing.T) {
+	cluster := metricsTestCluster(&rayv1.MetricsConfig{})
+	svc := BuildMetricsServiceForRayCluster(cluster)
+
+	assert.Equal(t, "raycluster-sample-metrics-svc", svc.Name)
+	assert.Equal(t, "default", svc.Namespace)
+	assert.Equal(t, cluster.Name, svc.Labels[utils.RayClusterMetricsServiceLabelKey])
+	assert.Equal(t, map[string]string{utils.RayClusterLabelKey: cluster.Name}, svc.Spec.Selector)
+	assert.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+	require.Len(t, svc.Spec.Ports, 1)
+	assert.Equal(t, utils.MetricsPortName, svc.Spec.Ports[0].Name)
+	assert.Equal(t, int32(utils.DefaultMetricsPort), svc.Spec.Ports[0].Port)
+	assert.Equal(t, intstr.FromString(utils.MetricsPortName), svc.Spec.Ports[0].TargetPort)
+}
+
+func TestBuildMonitorForRayCluster(t *testing.T) {
+	// No monitor is built without spec.metrics.monitorType.
+	assert.Nil(t, BuildMonitorForRayCluster(metricsTestCluster(nil)))
+	assert.Nil(t, BuildMonitorForRayCluster(metricsTestCluster(&rayv1.MetricsConfig{})))
+
+	cluster := metricsTestCluster(&rayv1.MetricsConfig{
+		MonitorType:    rayv1.ServiceMonitorType,
+		MonitorLabels:  map[string]string{"release": "prometheus"},
+		ScrapeInterval: "30s",
+	})
+	monitor := BuildMonitorForRayCluster(cluster)
+	require.NotNil(t, monitor)
+	assert.Equal(t, "monitoring.coreos.com/v1", monitor.GetAPIVersion())
+	assert.Equal(t, "ServiceMonitor", monitor.GetKind())
+	assert.Equal(t, cluster.Name, monitor.GetName())
+	assert.Equal(t, "default", monitor.GetNamespace())
+	assert.Equal(t, "prometheus", monitor.GetLabels()["release"])
+
+	selector, _, err := unstructured.NestedStringMap(monitor.Object, "spec", "selector", "matchLabels")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{utils.RayClusterMetricsServiceLabelKey: cluster.Name}, selector)
+	endpoints, _, err := unstructured.NestedSlice(monitor.Object, "spec", "endpoints")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"port": utils.MetricsPortName, "interval": "30s"}}, endpoints)
+
+	cluster.Spec.Metrics.MonitorType = rayv1.PodMonitorType
+	cluster.Spec.Metrics.ScrapeInterval = ""
+	monitor = BuildMonitorForRayCluster(cluster)
+	require.NotNil(t, monitor)
+	assert.Equal(t, "PodMonitor", monitor.GetKind())
+	selector, _, err = unstructured.NestedStringMap(monitor.Object, "spec", "selector", "matchLabels")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{utils.RayClusterLabelKey: cluster.Name}, selector)
+	endpoints, _, err = unstructured.NestedSlice(monitor.Object, "spec", "podMetricsEndpoints")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"port": utils.MetricsPortName}}, endpoints)
+
+	// The monitor can be deep copied, e.g. by the fake client.
+	assert.Equal(t, monitor, monitor.DeepCopy())
+}
@@ -3,8 +3,10 @@ package common
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -16,6 +18,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -24,6 +28,15 @@ const (
 	SharedMemoryVolumeMountPath = "/dev/shm"
 	RayLogVolumeName            = "ray-logs"
 	RayLogVolumeMountPath       = "/tmp/ray"
+	RayObjectSpillingDirectory  = RayLogVolumeMountPath + "/spill"
+	RaySystemConfigVolumeName   = "ray-system-config"
+	RaySystemConfigMountPath    = "/etc/ray/system-config"
+	RayCABundleVolumeName       = "ray-ca-bundle"
+	RayCABundleMountPath        = "/etc/ray/ca-bundle"
+	RayCertsVolumeName          = "ray-certs"
+	RayCertsMountPath           = "/etc/ray/certs"
+	RayCertsFile                = RayCertsMountPath + "/ca-certificates.crt"
+	RayCABundleContainerName    = "ray-ca-bundle"
 	AutoscalerContainerName     = "autoscaler"
 	RayHeadContainer            = "ray-head"
 	ObjectStoreMemoryKey        = "object-store-memory"
@@ -85,6 +98,192 @@ func initTemplateAnnotations(instance rayv1.RayCluster, podTemplate *corev1.PodT
 			podTemplate.Annotations[utils.RayExternalStorageNSAnnotationKey] = v
 		}
 	}
+	// Record the system config the Pod is created with, so that the Pod is recreated when the system config changes.
+	if instance.Spec.SystemConfig != nil {
+		podTemplate.Annotations[utils.RaySystemConfigHashAnnotationKey] = instance.Status.SystemConfigHash
+	}
+}
+
+// GetRaySystemConfigKey returns the key of the Ray system config in the ConfigMap referenced by spec.systemConfig.
+func GetRaySystemConfigKey(systemConfig *rayv1.RaySystemConfigSource) string {
+	if systemConfig.Key == "" {
+		return utils.DefaultRaySystemConfigKey
+	}
+	return systemConfig.Key
+}
+
+// addSystemConfigVolume mounts the ConfigMap referenced by spec.systemConfig into the Ray container.
+func addSystemConfigVolume(instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec) {
+	if instance.Spec.SystemConfig == nil {
+		return
+	}
+
+	// The containers and volumes are shared with the RayCluster spec, so copy them before modifying them.
+	podTemplate.Spec.Containers = append([]corev1.Container(nil), podTemplate.Spec.Containers...)
+	rayContainer := &podTemplate.Spec.Containers[utils.RayContainerIndex]
+	if checkIfVolumeMounted(rayContainer, RaySystemConfigMountPath) {
+		return
+	}
+	podTemplate.Spec.Volumes = append(append([]corev1.Volume(nil), podTemplate.Spec.Volumes...), corev1.Volume{
+		Name: RaySystemConfigVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: instance.Spec.SystemConfig.ConfigMapName},
+			},
+		},
+	})
+	rayContainer.VolumeMounts = append(append([]corev1.VolumeMount(nil), rayContainer.VolumeMounts...), corev1.VolumeMount{
+		Name:      RaySystemConfigVolumeName,
+		MountPath: RaySystemConfigMountPath,
+		ReadOnly:  true,
+	})
+}
+
+// addLoggingConfig passes the logging levels and the temp directory of spec.logging to the Ray processes, unless the
+// Ray start params or the environment variables of the Ray container already set them.
+func addLoggingConfig(instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec, rayStartParams map[string]string, nodeType rayv1.RayNodeType) {
+	logging := instance.Spec.Logging
+	if logging == nil {
+		return
+	}
+
+	if _, ok := rayStartParams["logging-level"]; !ok && logging.LoggingLevel != "" {
+		rayStartParams["logging-level"] = logging.LoggingLevel
+	}
+	// `ray start --temp-dir` only takes effect on the head, and the worker nodes use the temp directory of the head.
+	if _, ok := rayStartParams["temp-dir"]; !ok && logging.TempDir != "" && nodeType == rayv1.HeadNode {
+		rayStartParams["temp-dir"] = logging.TempDir
+	}
+	if logging.BackendLogLevel == "" {
+		return
+	}
+	// The containers are shared with the RayCluster spec, so copy them before modifying them.
+	podTemplate.Spec.Containers = append([]corev1.Container(nil), podTemplate.Spec.Containers...)
+	rayContainer := &podTemplate.Spec.Containers[utils.RayContainerIndex]
+	if !utils.EnvVarExists(utils.RAY_BACKEND_LOG_LEVEL, rayContainer.Env) {
+		rayContainer.Env = append(append([]corev1.EnvVar(nil), rayContainer.Env...), corev1.EnvVar{
+			Name:  utils.RAY_BACKEND_LOG_LEVEL,
+			Value: logging.BackendLogLevel,
+		})
+	}
+}
+
+// addEgressConfig injects the proxy settings and the CA bundle of spec.egress into the Ray container, unless its
+// environment variables already set them. The CA bundle is appended to the system CA certificates of the Ray image by
+// an init container, because the CA certificates are read from a single file by most HTTP clients.
+func addEgressConfig(instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec) {
+	egress := instance.Spec.Egress
+	if egress == nil {
+		return
+	}
+
+	// The containers and volumes are shared with the RayCluster spec, so copy them before modifying them.
+	podTemplate.Spec.Containers = append([]corev1.Container(nil), podTemplate.Spec.Containers...)
+	rayContainer := &podTemplate.Spec.Containers[utils.RayContainerIndex]
+	env := append([]corev1.EnvVar(nil), rayContainer.Env...)
+	setEnv := func(value string, names ...string) {
+		for _, name := range names {
+			if value != "" && !utils.EnvVarExists(name, env) {
+				env = append(env, corev1.EnvVar{Name: name, Value: value})
+			}
+		}
+	}
+	// Some tools only read the lowercase variables, and others only the uppercase ones.
+	setEnv(egress.HTTPProxy, utils.HTTP_PROXY, strings.ToLower(utils.HTTP_PROXY))
+	setEnv(egress.HTTPSProxy, utils.HTTPS_PROXY, strings.ToLower(utils.HTTPS_PROXY))
+	if egress.HTTPProxy != "" || egress.HTTPSProxy != "" {
+		// gRPC also honors the proxy variables, so the connections between the Ray nodes must bypass the proxy.
+		noProxy := utils.DefaultNoProxy
+		if egress.NoProxy != "" {
+			noProxy = egress.NoProxy + "," + noProxy
+		}
+		setEnv(noProxy, utils.NO_PROXY, strings.ToLower(utils.NO_PROXY))
+	}
+
+	if egress.CABundle != nil && !checkIfVolumeMounted(rayContainer, RayCertsMountPath) {
+		caBundleKey := egress.CABundle.Key
+		if caBundleKey == "" {
+			caBundleKey = utils.DefaultCABundleKey
+		}
+		podTemplate.Spec.Volumes = append(append([]corev1.Volume(nil), podTemplate.Spec.Volumes...),
+			corev1.Volume{
+				Name: RayCABundleVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: egress.CABundle.ConfigMapName},
+					},
+				},
+			},
+			corev1.Volume{
+				Name:         RayCertsVolumeName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			},
+		)
+		podTemplate.Spec.InitContainers = append(append([]corev1.Container(nil), podTemplate.Spec.InitContainers...), corev1.Container{
+			Name:            RayCABundleContainerName,
+			Image:           rayContainer.Image,
+			ImagePullPolicy: rayContainer.ImagePullPolicy,
+			Command:         []string{"/bin/sh", "-c"},
+			// The system CA certificates are at different paths in the Debian and the Red Hat based images.
+			Args: []string{fmt.Sprintf(
+				"{ cat /etc/ssl/certs/ca-certificates.crt /etc/pki/tls/certs/ca-bundle.crt 2>/dev/null; cat %s/%s; } > %s",
+				RayCABundleMountPath, caBundleKey, RayCertsFile,
+			)},
+			SecurityContext: rayContainer.SecurityContext.DeepCopy(),
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: RayCABundleVolumeName, MountPath: RayCABundleMountPath, ReadOnly: true},
+				{Name: RayCertsVolumeName, MountPath: RayCertsMountPath},
+			},
+			// If users specify a ResourceQuota for the namespace, the init container needs to specify resources explicitly.
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+			},
+		})
+		rayContainer.VolumeMounts = append(append([]corev1.VolumeMount(nil), rayContainer.VolumeMounts...), corev1.VolumeMount{
+			Name:      RayCertsVolumeName,
+			MountPath: RayCertsMountPath,
+			ReadOnly:  true,
+		})
+		setEnv(RayCertsFile, utils.SSL_CERT_FILE, utils.REQUESTS_CA_BUNDLE, utils.PIP_CERT)
+	}
+	rayContainer.Env = env
+}
+
+// addPersistentStorageVolume mounts the given volume at the Ray log directory of the Ray container and spills objects
+// into it. The volume is named like the log volume, so that the autoscaler container shares it.
+func addPersistentStorageVolume(podTemplate *corev1.PodTemplateSpec, volumeSource corev1.VolumeSource) {
+	// The containers and volumes are shared with the RayCluster spec, so copy them before modifying them.
+	podTemplate.Spec.Containers = append([]corev1.Container(nil), podTemplate.Spec.Containers...)
+	rayContainer := &podTemplate.Spec.Containers[utils.RayContainerIndex]
+	if checkIfVolumeMounted(rayContainer, RayLogVolumeMountPath) {
+		return
+	}
+	for _, volume := range podTemplate.Spec.Volumes {
+		if volume.Name == RayLogVolumeName {
+			return
+		}
+	}
+	podTemplate.Spec.Volumes = append(append([]corev1.Volume(nil), podTemplate.Spec.Volumes...), corev1.Volume{
+		Name:         RayLogVolumeName,
+		VolumeSource: volumeSource,
+	})
+	rayContainer.VolumeMounts = append(append([]corev1.VolumeMount(nil), rayContainer.VolumeMounts...), corev1.VolumeMount{
+		Name:      RayLogVolumeName,
+		MountPath: RayLogVolumeMountPath,
+	})
+	if !utils.EnvVarExists(utils.RAY_OBJECT_SPILLING_CONFIG, rayContainer.Env) {
+		rayContainer.Env = append(append([]corev1.EnvVar(nil), rayContainer.Env...), corev1.EnvVar{
+			Name:  utils.RAY_OBJECT_SPILLING_CONFIG,
+			Value: fmt.Sprintf(`{"type":"filesystem","params":{"directory_path":"%s"}}`, RayObjectSpillingDirectory),
+		})
+	}
 }
 
 // DefaultHeadPodTemplate sets the config values
@@ -103,9 +302,36 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 	}
 	podTemplate.Labels = labelPod(rayv1.HeadNode, instance.Name, utils.RayNodeHeadGroupLabelValue, instance.Spec.HeadGroupSpec.Template.ObjectMeta.Labels)
 	headSpec.RayStartParams = setMissingRayStartParams(ctx, headSpec.RayStartParams, rayv1.HeadNode, headPort, "")
+	if headSpec.HeadNodeReservation != nil {
+		reserveHeadNode(&podTemplate, headSpec.RayStartParams, headSpec.HeadNodeReservation)
+	}
+	if headSpec.Placement != nil {
+		addHeadPlacementAffinity(&podTemplate, headSpec.Placement)
+	}
 
 	initTemplateAnnotations(instance, &podTemplate)
 
+	// The head passes the system config to the Ray cluster, so that the worker nodes pick it up from the GCS.
+	if instance.Spec.SystemConfig != nil {
+		addSystemConfigVolume(instance, &podTemplate)
+		if _, ok := headSpec.RayStartParams["system-config"]; !ok {
+			headSpec.RayStartParams["system-config"] = fmt.Sprintf(`"$(cat %s/%s)"`, RaySystemConfigMountPath, GetRaySystemConfigKey(instance.Spec.SystemConfig))
+		}
+	}
+
+	addLoggingConfig(instance, &podTemplate, headSpec.RayStartParams, rayv1.HeadNode)
+	addEgressConfig(instance, &podTemplate)
+	addStartupProbe(instance, &podTemplate, rayv1.HeadNode)
+
+	// The head Pod mounts a PersistentVolumeClaim which is created by the controller and outlives the Pod.
+	if headSpec.PersistentStorage != nil {
+		addPersistentStorageVolume(&podTemplate, corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: utils.GenerateHeadPersistentStorageName(instance.Name),
+			},
+		})
+	}
+
 	// if in-tree autoscaling is enabled, then autoscaler container should be injected into head pod.
 	if instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling {
 		// The default autoscaler is not compatible with Kubernetes. As a result, we disable
@@ -123,6 +349,8 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, autoscalerContainer)
 	}
 
+	addDashboardAuthProxy(instance, &podTemplate, headSpec.RayStartParams)
+
 	// If the metrics port does not exist in the Ray container, add a default one for Prometheus.
 	isMetricsPortExists := utils.FindContainerPort(&podTemplate.Spec.Containers[utils.RayContainerIndex], utils.MetricsPortName, -1) != -1
 	if !isMetricsPortExists {
@@ -136,6 +364,92 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 	return podTemplate
 }
 
+// reserveHeadNode keeps tasks and actors off the head Pod by advertising no CPUs and GPUs to Ray, and applies the
+// resource overrides of the reservation to the Ray container.
+func reserveHeadNode(podTemplate *corev1.PodTemplateSpec, rayStartParams map[string]string, reservation *rayv1.HeadNodeReservation) {
+	rayStartParams["num-cpus"] = "0"
+	rayStartParams["num-gpus"] = "0"
+	if reservation.Resources == nil {
+		return
+	}
+
+	// The containers are shared with the RayCluster spec, so copy them before modifying the Ray container.
+	podTemplate.Spec.Containers = append([]corev1.Container(nil), podTemplate.Spec.Containers...)
+	resources := podTemplate.Spec.Containers[utils.RayContainerIndex].Resources.DeepCopy()
+	if resources.Requests == nil && len(reservation.Resources.Requests) > 0 {
+		resources.Requests = corev1.ResourceList{}
+	}
+	for name, quantity := range reservation.Resources.Requests {
+		resources.Requests[name] = quantity
+	}
+	if resources.Limits == nil && len(reservation.Resources.Limits) > 0 {
+		resources.Limits = corev1.ResourceList{}
+	}
+	for name, quantity := range reservation.Resources.Limits {
+		resources.Limits[name] = quantity
+	}
+	podTemplate.Spec.Containers[utils.RayContainerIndex].Resources = *resources
+}
+
+// headPlacementTopologyKey returns the node label of the failure domains of the head placement policy.
+func headPlacementTopologyKey(placement *rayv1.HeadPlacementPolicy) string {
+	if placement.TopologyKey != "" {
+		return placement.TopologyKey
+	}
+	return utils.DefaultHeadPlacementTopologyKey
+}
+
+// addHeadPlacementAffinity adds node affinity for the failure domains of the head placement policy to the Pod. The
+// domains are preferred in their order. In the Required mode, the domains are also added to every node selector term
+// of the Pod, so that the Pod is only scheduled in them.
+func addHeadPlacementAffinity(podTemplate *corev1.PodTemplateSpec, placement *rayv1.HeadPlacementPolicy) {
+	if len(placement.Domains) == 0 {
+		return
+	}
+	topologyKey := headPlacementTopologyKey(placement)
+
+	// The affinity is shared with the RayCluster spec, so copy it before modifying it.
+	affinity := &corev1.Affinity{}
+	if podTemplate.Spec.Affinity != nil {
+		affinity = podTemplate.Spec.Affinity.DeepCopy()
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := affinity.NodeAffinity
+
+	if placement.Mode != rayv1.HeadPlacementPreferred {
+		requirement := corev1.NodeSelectorRequirement{
+			Key:      topologyKey,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   placement.Domains,
+		}
+		if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+			nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+		}
+		nodeSelector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		if len(nodeSelector.NodeSelectorTerms) == 0 {
+			nodeSelector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+		}
+		for i := range nodeSelector.NodeSelectorTerms {
+			nodeSelector.NodeSelectorTerms[i].MatchExpressions = append(nodeSelector.NodeSelectorTerms[i].MatchExpressions, requirement)
+		}
+	}
+
+	// Earlier domains get higher weights. The number of domains is limited to 100, the maximum weight.
+	for i, domain := range placement.Domains {
+		nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.PreferredSchedulingTerm{
+			Weight: int32(100 - i),
+			Preference: corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: topologyKey, Operator: corev1.NodeSelectorOpIn, Values: []string{domain}},
+				},
+			},
+		})
+	}
+	podTemplate.Spec.Affinity = affinity
+}
+
 func getEnableInitContainerInjection() bool {
 	if s := os.Getenv(EnableInitContainerInjectionEnvKey); strings.ToLower(s) == "false" {
 		return false
@@ -225,6 +539,21 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 	workerSpec.RayStartParams = setMissingRayStartParams(ctx, workerSpec.RayStartParams, rayv1.WorkerNode, headPort, fqdnRayIP)
 
 	initTemplateAnnotations(instance, &podTemplate)
+	addSystemConfigVolume(instance, &podTemplate)
+	addLoggingConfig(instance, &podTemplate, workerSpec.RayStartParams, rayv1.WorkerNode)
+	addEgressConfig(instance, &podTemplate)
+	addStartupProbe(instance, &podTemplate, rayv1.WorkerNode)
+
+	// Each worker Pod gets its own volume, which is deleted together with the Pod.
+	if workerSpec.PersistentStorage != nil {
+		addPersistentStorageVolume(&podTemplate, corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					Spec: BuildPersistentStorageClaimSpec(workerSpec.PersistentStorage),
+				},
+			},
+		})
+	}
 
 	// If the metrics port does not exist in the Ray container, add a default one for Prometheus.
 	isMetricsPortExists := utils.FindContainerPort(&podTemplate.Spec.Containers[utils.RayContainerIndex], utils.MetricsPortName, -1) != -1
@@ -239,7 +568,10 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 	return podTemplate
 }
 
-func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType rayv1.RayNodeType, creatorCRDType utils.CRDType) {
+// rayHealthCheckCommands returns the commands checking the health of the Ray processes of a Pod.
+// For head node => Check GCS and Raylet status.
+// For worker node => Check Raylet status.
+func rayHealthCheckCommands(rayNodeType rayv1.RayNodeType) []string {
 	rayAgentRayletHealthCommand := fmt.Sprintf(
 		utils.BaseWgetHealthCommand,
 		utils.DefaultReadinessProbeTimeoutSeconds,
@@ -252,16 +584,52 @@ func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType r
 		utils.DefaultDashboardPort,
 		utils.RayDashboardGCSHealthPath,
 	)
+	if rayNodeType == rayv1.HeadNode {
+		return []string{rayAgentRayletHealthCommand, rayDashboardGCSHealthCommand}
+	}
+	return []string{rayAgentRayletHealthCommand}
+}
 
-	// Generally, the liveness and readiness probes perform the same checks.
-	// For head node => Check GCS and Raylet status.
-	// For worker node => Check Raylet status.
-	commands := []string{}
+// readyTimeoutSeconds returns how long the Ray processes of the Pods of the given type may take to become ready,
+// or nil if the RayCluster does not override it.
+func readyTimeoutSeconds(timeouts *rayv1.ReadinessTimeoutsConfig, rayNodeType rayv1.RayNodeType) *int32 {
+	if timeouts == nil {
+		return nil
+	}
 	if rayNodeType == rayv1.HeadNode {
-		commands = append(commands, rayAgentRayletHealthCommand, rayDashboardGCSHealthCommand)
-	} else {
-		commands = append(commands, rayAgentRayletHealthCommand)
+		return timeouts.GCSReadySeconds
 	}
+	return timeouts.DashboardAgentReadySeconds
+}
+
+// addStartupProbe gives the Ray processes of a Pod the ready timeout of the RayCluster to start. The liveness and
+// readiness probes only run once the startup probe succeeds, so a slow start does not make the liveness probe restart
+// the Ray container. A startup probe of the user is kept.
+func addStartupProbe(instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec, rayNodeType rayv1.RayNodeType) {
+	timeoutSeconds := readyTimeoutSeconds(instance.Spec.ReadinessTimeouts, rayNodeType)
+	rayContainer := &podTemplate.Spec.Containers[utils.RayContainerIndex]
+	if timeoutSeconds == nil || rayContainer.StartupProbe != nil || !getEnableProbesInjection() {
+		return
+	}
+	probeTimeout := int32(utils.DefaultReadinessProbeTimeoutSeconds)
+	if rayNodeType == rayv1.HeadNode {
+		probeTimeout = int32(utils.DefaultHeadReadinessProbeTimeoutSeconds)
+	}
+	periodSeconds := int32(utils.DefaultReadinessProbePeriodSeconds)
+	rayContainer.StartupProbe = &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{Command: []string{"bash", "-c", strings.Join(rayHealthCheckCommands(rayNodeType), " && ")}},
+		},
+		TimeoutSeconds:   probeTimeout,
+		PeriodSeconds:    periodSeconds,
+		SuccessThreshold: 1,
+		FailureThreshold: (*timeoutSeconds + periodSeconds - 1) / periodSeconds,
+	}
+}
+
+func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType rayv1.RayNodeType, creatorCRDType utils.CRDType) {
+	// Generally, the liveness and readiness probes perform the same checks.
+	commands := rayHealthCheckCommands(rayNodeType)
 
 	if rayContainer.LivenessProbe == nil {
 		probeTimeout := int32(utils.DefaultLivenessProbeTimeoutSeconds)
@@ -401,6 +769,29 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 	return pod
 }
 
+// ApplyPodTemplatePatch applies the user provided strategic merge patch to a pod built by the operator.
+// The patch has the shape of a PodTemplateSpec, so only the metadata and the spec of the pod are patched.
+func ApplyPodTemplatePatch(pod *corev1.Pod, patch *runtime.RawExtension) error {
+	if patch == nil || len(patch.Raw) == 0 {
+		return nil
+	}
+	original, err := json.Marshal(corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec})
+	if err != nil {
+		return err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, patch.Raw, corev1.PodTemplateSpec{})
+	if err != nil {
+		return fmt.Errorf("failed to apply podTemplatePatch: %w", err)
+	}
+	template := corev1.PodTemplateSpec{}
+	if err := json.Unmarshal(patched, &template); err != nil {
+		return fmt.Errorf("failed to decode patched pod template: %w", err)
+	}
+	pod.ObjectMeta = template.ObjectMeta
+	pod.Spec = template.Spec
+	return nil
+}
+
 // BuildAutoscalerContainer builds a Ray autoscaler container which can be appended to the head pod.
 func BuildAutoscalerContainer(autoscalerImage string) corev1.Container {
 	container := corev1.Container{
@@ -761,6 +1152,8 @@ func generateRayStartCommand(ctx context.Context, nodeType rayv1.RayNodeType, ra
 		}
 	}
 
+	addAcceleratorResources(ctx, rayStartParams, resource.Limits)
+
 	rayStartCmd := ""
 	switch nodeType {
 	case rayv1.HeadNode:
@@ -774,6 +1167,56 @@ func generateRayStartCommand(ctx context.Context, nodeType rayv1.RayNodeType, ra
 	return rayStartCmd
 }
 
+// addAcceleratorResources advertises the accelerators of the Ray container to Ray, with the Ray resources their
+// extended resources are mapped to by the operator configuration. The accelerators already set by the `num-gpus` or
+// `resources` Ray start params are left unchanged.
+func addAcceleratorResources(ctx context.Context, rayStartParams map[string]string, limits corev1.ResourceList) {
+	log := ctrl.LoggerFrom(ctx)
+
+	accelerators := utils.GetAcceleratorResources()
+	resourceNames := make([]string, 0, len(limits))
+	for resourceName := range limits {
+		resourceNames = append(resourceNames, string(resourceName))
+	}
+	sort.Strings(resourceNames)
+
+	customResources := map[string]float64{}
+	if value, ok := rayStartParams["resources"]; ok {
+		var err error
+		if customResources, err = utils.ParseRayResourcesParam(value); err != nil {
+			log.Info("The accelerator resources are not added to the Ray start params", "error", err)
+			return
+		}
+	}
+	added := false
+	for _, resourceName := range resourceNames {
+		rayResourceName, ok := accelerators[corev1.ResourceName(resourceName)]
+		quantity := limits[corev1.ResourceName(resourceName)]
+		if !ok || quantity.IsZero() {
+			continue
+		}
+		if rayResourceName == utils.RayGPUResourceName {
+			if _, ok := rayStartParams["num-gpus"]; !ok {
+				rayStartParams["num-gpus"] = strconv.FormatInt(quantity.Value(), 10)
+			}
+			continue
+		}
+		if _, ok := customResources[rayResourceName]; !ok {
+			customResources[rayResourceName] = float64(quantity.Value())
+			added = true
+		}
+	}
+	if !added {
+		return
+	}
+	value, err := utils.FormatRayResourcesParam(customResources)
+	if err != nil {
+		log.Error(err, "Failed to format the resources Ray start param", "resources", customResources)
+		return
+	}
+	rayStartParams["resources"] = value
+}
+
 func convertParamMap(rayStartParams map[string]string) (s string) {
 	flags := new(bytes.Buffer)
 	// specialParameterOptions' arguments can be true or false.
@@ -10,11 +10,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
@@ -823,6 +825,225 @@ func TestDefaultHeadPodTemplateWithConfigurablePorts(t *testing.T) {
 	}
 }
 
+func TestDefaultHeadPodTemplateWithHeadNodeReservation(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.RayStartParams["num-cpus"] = "1"
+	cluster.Spec.HeadGroupSpec.HeadNodeReservation = &rayv1.HeadNodeReservation{
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+		},
+	}
+	originalResources := cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Resources.DeepCopy()
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+
+	// No tasks and actors are scheduled on the head.
+	assert.Equal(t, "0", cluster.Spec.HeadGroupSpec.RayStartParams["num-cpus"])
+	assert.Equal(t, "0", cluster.Spec.HeadGroupSpec.RayStartParams["num-gpus"])
+
+	// The memory limit is overridden, and the other resources are kept.
+	resources := podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Resources
+	assert.True(t, resources.Limits.Memory().Equal(resource.MustParse("8Gi")))
+	assert.True(t, resources.Limits.Cpu().Equal(*originalResources.Limits.Cpu()))
+	// The RayCluster spec is not modified.
+	assert.Equal(t, *originalResources, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Resources)
+}
+
+func TestDefaultHeadPodTemplateWithPlacement(t *testing.T) {
+	ctx := context.Background()
+	podName := strings.ToLower(instance.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+
+	// Without domains, the affinity of the template is kept.
+	cluster := instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Placement = &rayv1.HeadPlacementPolicy{}
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	assert.Nil(t, podTemplateSpec.Spec.Affinity)
+
+	// The Required mode adds the domains to every required node selector term of the template.
+	cluster = instance.DeepCopy()
+	existingRequirement := corev1.NodeSelectorRequirement{Key: "node-pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"cpu"}}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{existingRequirement}},
+				},
+			},
+		},
+	}
+	cluster.Spec.HeadGroupSpec.Placement = &rayv1.HeadPlacementPolicy{Domains: []string{"zone-a", "zone-b"}}
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	nodeAffinity := podTemplateSpec.Spec.Affinity.NodeAffinity
+	assert.Equal(t, []corev1.NodeSelectorRequirement{
+		existingRequirement,
+		{Key: utils.DefaultHeadPlacementTopologyKey, Operator: corev1.NodeSelectorOpIn, Values: []string{"zone-a", "zone-b"}},
+	}, nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions)
+	// Earlier domains are preferred.
+	assert.Len(t, nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 2)
+	assert.Equal(t, int32(100), nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Weight)
+	assert.Equal(t, []string{"zone-a"}, nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Preference.MatchExpressions[0].Values)
+	assert.Equal(t, int32(99), nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[1].Weight)
+	// The RayCluster spec is not modified.
+	assert.Len(t, cluster.Spec.HeadGroupSpec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, 1)
+	assert.Empty(t, cluster.Spec.HeadGroupSpec.Template.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+
+	// The Preferred mode only adds preferred terms.
+	cluster = instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Placement = &rayv1.HeadPlacementPolicy{
+		TopologyKey: "node-pool",
+		Domains:     []string{"pool-a"},
+		Mode:        rayv1.HeadPlacementPreferred,
+	}
+	podTemplateSpec = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	nodeAffinity = podTemplateSpec.Spec.Affinity.NodeAffinity
+	assert.Nil(t, nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+	assert.Equal(t, []corev1.PreferredSchedulingTerm{
+		{
+			Weight: 100,
+			Preference: corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "node-pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"pool-a"}},
+				},
+			},
+		},
+	}, nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+}
+
+func TestDefaultPodTemplateWithSystemConfig(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	cluster.Spec.SystemConfig = &rayv1.RaySystemConfigSource{ConfigMapName: "ray-system-config"}
+	cluster.Status.SystemConfigHash = "hash"
+	numVolumes := len(cluster.Spec.HeadGroupSpec.Template.Spec.Volumes)
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+
+	// The ConfigMap is mounted into the Ray container and passed to ray start.
+	volume := podTemplateSpec.Spec.Volumes[len(podTemplateSpec.Spec.Volumes)-1]
+	assert.Equal(t, RaySystemConfigVolumeName, volume.Name)
+	assert.Equal(t, "ray-system-config", volume.ConfigMap.Name)
+	assert.True(t, checkIfVolumeMounted(&podTemplateSpec.Spec.Containers[utils.RayContainerIndex], RaySystemConfigMountPath))
+	assert.Equal(t, `"$(cat /etc/ray/system-config/system_config.json)"`, cluster.Spec.HeadGroupSpec.RayStartParams["system-config"])
+	assert.Equal(t, "hash", podTemplateSpec.Annotations[utils.RaySystemConfigHashAnnotationKey])
+	// The RayCluster spec is not modified.
+	assert.Len(t, cluster.Spec.HeadGroupSpec.Template.Spec.Volumes, numVolumes)
+	assert.False(t, checkIfVolumeMounted(&cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex], RaySystemConfigMountPath))
+
+	// The worker Pods mount the ConfigMap too, and get the system config from the head.
+	cluster.Spec.SystemConfig.Key = "config.json"
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	assert.True(t, checkIfVolumeMounted(&podTemplateSpec.Spec.Containers[utils.RayContainerIndex], RaySystemConfigMountPath))
+	assert.NotContains(t, worker.RayStartParams, "system-config")
+	assert.Equal(t, "hash", podTemplateSpec.Annotations[utils.RaySystemConfigHashAnnotationKey])
+	assert.Equal(t, "config.json", GetRaySystemConfigKey(cluster.Spec.SystemConfig))
+}
+
+func TestDefaultPodTemplateWithLogging(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	cluster.Spec.Logging = &rayv1.RayLoggingConfig{LoggingLevel: "debug", BackendLogLevel: "debug", TempDir: "/data/ray"}
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+
+	assert.Equal(t, "debug", cluster.Spec.HeadGroupSpec.RayStartParams["logging-level"])
+	assert.Equal(t, "/data/ray", cluster.Spec.HeadGroupSpec.RayStartParams["temp-dir"])
+	checkContainerEnv(t, podTemplateSpec.Spec.Containers[utils.RayContainerIndex], utils.RAY_BACKEND_LOG_LEVEL, "debug")
+	// The RayCluster spec is not modified.
+	assert.False(t, utils.EnvVarExists(utils.RAY_BACKEND_LOG_LEVEL, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Env))
+
+	// The worker nodes use the temp directory of the head, and the ray start params take precedence.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.RayStartParams["logging-level"] = "warning"
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	assert.Equal(t, "warning", worker.RayStartParams["logging-level"])
+	assert.NotContains(t, worker.RayStartParams, "temp-dir")
+	checkContainerEnv(t, podTemplateSpec.Spec.Containers[utils.RayContainerIndex], utils.RAY_BACKEND_LOG_LEVEL, "debug")
+}
+
+func TestDefaultPodTemplateWithEgress(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	cluster.Spec.Egress = &rayv1.EgressConfig{
+		CABundle:   &rayv1.CABundleSource{ConfigMapName: "corporate-ca"},
+		HTTPSProxy: "http://proxy.corp:3128",
+		NoProxy:    "10.0.0.0/8",
+	}
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+
+	rayContainer := podTemplateSpec.Spec.Containers[utils.RayContainerIndex]
+	checkContainerEnv(t, rayContainer, utils.HTTPS_PROXY, "http://proxy.corp:3128")
+	checkContainerEnv(t, rayContainer, "https_proxy", "http://proxy.corp:3128")
+	checkContainerEnv(t, rayContainer, utils.NO_PROXY, "10.0.0.0/8,"+utils.DefaultNoProxy)
+	assert.False(t, utils.EnvVarExists(utils.HTTP_PROXY, rayContainer.Env))
+	checkContainerEnv(t, rayContainer, utils.SSL_CERT_FILE, RayCertsFile)
+	checkContainerEnv(t, rayContainer, utils.PIP_CERT, RayCertsFile)
+	assert.True(t, checkIfVolumeMounted(&rayContainer, RayCertsMountPath))
+
+	initContainer := podTemplateSpec.Spec.InitContainers[len(podTemplateSpec.Spec.InitContainers)-1]
+	assert.Equal(t, RayCABundleContainerName, initContainer.Name)
+	assert.Equal(t, rayContainer.Image, initContainer.Image)
+	assert.Contains(t, initContainer.Args[0], RayCABundleMountPath+"/"+utils.DefaultCABundleKey)
+	// The RayCluster spec is not modified.
+	assert.Empty(t, cluster.Spec.HeadGroupSpec.Template.Spec.InitContainers)
+	assert.False(t, utils.EnvVarExists(utils.HTTPS_PROXY, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Env))
+
+	// The environment variables of the Ray container take precedence.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.Template.Spec.Containers[utils.RayContainerIndex].Env = append(worker.Template.Spec.Containers[utils.RayContainerIndex].Env,
+		corev1.EnvVar{Name: utils.HTTPS_PROXY, Value: "http://other-proxy:3128"})
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	checkContainerEnv(t, podTemplateSpec.Spec.Containers[utils.RayContainerIndex], utils.HTTPS_PROXY, "http://other-proxy:3128")
+	checkContainerEnv(t, podTemplateSpec.Spec.Containers[utils.RayContainerIndex], utils.REQUESTS_CA_BUNDLE, RayCertsFile)
+}
+
+func TestDefaultPodTemplateWithPersistentStorage(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	storage := &rayv1.RayPersistentStorage{Size: resource.MustParse("100Gi"), StorageClassName: ptr.To("ssd")}
+	cluster.Spec.HeadGroupSpec.PersistentStorage = storage
+	cluster.Spec.WorkerGroupSpecs[0].PersistentStorage = storage
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+
+	// The head Pod mounts the PersistentVolumeClaim created by the controller at the Ray log directory.
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	volume := podTemplateSpec.Spec.Volumes[len(podTemplateSpec.Spec.Volumes)-1]
+	assert.Equal(t, RayLogVolumeName, volume.Name)
+	assert.Equal(t, utils.GenerateHeadPersistentStorageName(cluster.Name), volume.PersistentVolumeClaim.ClaimName)
+	rayContainer := podTemplateSpec.Spec.Containers[utils.RayContainerIndex]
+	assert.True(t, checkIfVolumeMounted(&rayContainer, RayLogVolumeMountPath))
+	assert.Contains(t, rayContainer.Env, corev1.EnvVar{
+		Name:  utils.RAY_OBJECT_SPILLING_CONFIG,
+		Value: `{"type":"filesystem","params":{"directory_path":"/tmp/ray/spill"}}`,
+	})
+	// The RayCluster spec is not modified.
+	assert.False(t, checkIfVolumeMounted(&cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex], RayLogVolumeMountPath))
+
+	// The worker Pods get an ephemeral volume.
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	volume = podTemplateSpec.Spec.Volumes[len(podTemplateSpec.Spec.Volumes)-1]
+	assert.Equal(t, RayLogVolumeName, volume.Name)
+	assert.Equal(t, BuildPersistentStorageClaimSpec(storage), volume.Ephemeral.VolumeClaimTemplate.Spec)
+	assert.True(t, checkIfVolumeMounted(&podTemplateSpec.Spec.Containers[utils.RayContainerIndex], RayLogVolumeMountPath))
+
+	// A volume mounted by the user at the Ray log directory is kept.
+	worker.Template.Spec.Containers[utils.RayContainerIndex].VolumeMounts = []corev1.VolumeMount{{Name: "logs", MountPath: RayLogVolumeMountPath}}
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, worker, podName, fqdnRayIP, "6379")
+	assert.False(t, checkIfVolumeExists(&corev1.Pod{Spec: podTemplateSpec.Spec}, RayLogVolumeName))
+}
+
 func TestDefaultWorkerPodTemplateWithConfigurablePorts(t *testing.T) {
 	ctx := context.Background()
 
@@ -1052,6 +1273,42 @@ func TestSetMissingRayStartParamsDashboardHost(t *testing.T) {
 	assert.Equal(t, "localhost", rayStartParams["dashboard-host"], fmt.Sprintf("Expected `%v` but got `%v`", "localhost", rayStartParams["dashboard-host"]))
 }
 
+func TestAddAcceleratorResources(t *testing.T) {
+	ctx := context.Background()
+	defer utils.SetAcceleratorResources(nil)
+	utils.SetAcceleratorResources(utils.AcceleratorResources{"gpu.intel.com/i915": "GPU"})
+
+	// Case 1: The well-known accelerators are advertised as Ray custom resources.
+	rayStartParams := map[string]string{}
+	addAcceleratorResources(ctx, rayStartParams, corev1.ResourceList{
+		"habana.ai/gaudi":           resource.MustParse("2"),
+		"aws.amazon.com/neuroncore": resource.MustParse("0"),
+		corev1.ResourceCPU:          resource.MustParse("1"),
+	})
+	assert.Equal(t, map[string]string{"resources": `"{\"HPU\":2}"`}, rayStartParams)
+
+	// Case 2: The custom resources set by the user are kept, and take precedence.
+	rayStartParams = map[string]string{"resources": `"{\"Custom1\": 1, \"TPU\": 8}"`}
+	addAcceleratorResources(ctx, rayStartParams, corev1.ResourceList{
+		"habana.ai/gaudi": resource.MustParse("2"),
+		"google.com/tpu":  resource.MustParse("4"),
+	})
+	assert.Equal(t, `"{\"Custom1\":1,\"HPU\":2,\"TPU\":8}"`, rayStartParams["resources"])
+
+	// Case 3: An accelerator mapped to GPU sets the number of GPUs, unless it is already set.
+	rayStartParams = map[string]string{}
+	addAcceleratorResources(ctx, rayStartParams, corev1.ResourceList{"gpu.intel.com/i915": resource.MustParse("1")})
+	assert.Equal(t, map[string]string{"num-gpus": "1"}, rayStartParams)
+	rayStartParams = map[string]string{"num-gpus": "0"}
+	addAcceleratorResources(ctx, rayStartParams, corev1.ResourceList{"gpu.intel.com/i915": resource.MustParse("1")})
+	assert.Equal(t, map[string]string{"num-gpus": "0"}, rayStartParams)
+
+	// Case 4: Resources Ray start params which can't be parsed are left unchanged.
+	rayStartParams = map[string]string{"resources": "HPU=1"}
+	addAcceleratorResources(ctx, rayStartParams, corev1.ResourceList{"habana.ai/gaudi": resource.MustParse("2")})
+	assert.Equal(t, "HPU=1", rayStartParams["resources"])
+}
+
 func TestGetCustomWorkerInitImage(t *testing.T) {
 	// cleanup
 	defer os.Unsetenv(EnableInitContainerInjectionEnvKey)
@@ -1155,3 +1412,81 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 	assert.Equal(t, int32(5), rayContainer.LivenessProbe.TimeoutSeconds)
 	assert.Equal(t, int32(5), rayContainer.ReadinessProbe.TimeoutSeconds)
 }
+
+func TestDefaultPodTemplateWithReadinessTimeouts(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	// The templates share the containers of the spec, which the probes are added to.
+	headTemplate := func() corev1.PodTemplateSpec {
+		cluster := cluster.DeepCopy()
+		return DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	}
+	workerTemplate := func() corev1.PodTemplateSpec {
+		cluster := cluster.DeepCopy()
+		return DefaultWorkerPodTemplate(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0], podName, "", "6379")
+	}
+
+	// Without timeouts, the liveness probe bounds the start of the Ray processes.
+	assert.Nil(t, headTemplate().Spec.Containers[utils.RayContainerIndex].StartupProbe)
+
+	cluster.Spec.ReadinessTimeouts = &rayv1.ReadinessTimeoutsConfig{
+		GCSReadySeconds:            ptr.To[int32](1800),
+		DashboardAgentReadySeconds: ptr.To[int32](62),
+	}
+	startupProbe := headTemplate().Spec.Containers[utils.RayContainerIndex].StartupProbe
+	require.NotNil(t, startupProbe)
+	assert.Equal(t, int32(360), startupProbe.FailureThreshold)
+	assert.Equal(t, int32(5), startupProbe.PeriodSeconds)
+	assert.Contains(t, strings.Join(startupProbe.Exec.Command, " "), utils.RayDashboardGCSHealthPath)
+
+	startupProbe = workerTemplate().Spec.Containers[utils.RayContainerIndex].StartupProbe
+	require.NotNil(t, startupProbe)
+	// The timeout is rounded up to a whole number of periods.
+	assert.Equal(t, int32(13), startupProbe.FailureThreshold)
+	assert.NotContains(t, strings.Join(startupProbe.Exec.Command, " "), utils.RayDashboardGCSHealthPath)
+
+	// The startup probe of the user is kept.
+	userProbe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(6379)}}}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].StartupProbe = userProbe
+	assert.Equal(t, userProbe, headTemplate().Spec.Containers[utils.RayContainerIndex].StartupProbe)
+
+	// No probe is injected when the probes injection is disabled.
+	t.Setenv(utils.ENABLE_PROBES_INJECTION, "false")
+	assert.Nil(t, workerTemplate().Spec.Containers[utils.RayContainerIndex].StartupProbe)
+}
+
+func TestApplyPodTemplatePatch(t *testing.T) {
+	cluster := instance.DeepCopy()
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(context.Background(), *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	pod := BuildPod(context.Background(), podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", nil, utils.RayClusterCRD, "")
+	containerCount := len(pod.Spec.Containers)
+
+	// A nil patch leaves the pod untouched.
+	expected := pod.DeepCopy()
+	err := ApplyPodTemplatePatch(&pod, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, *expected, pod)
+
+	// Containers are merged by name, and fields KubeRay does not model can be added.
+	patch := &runtime.RawExtension{Raw: []byte(`{
+		"metadata": {"labels": {"patched": "true"}},
+		"spec": {
+			"priorityClassName": "high-priority",
+			"containers": [{"name": "ray-head", "env": [{"name": "PATCHED_ENV", "value": "1"}]}]
+		}
+	}`)}
+	err = ApplyPodTemplatePatch(&pod, patch)
+	assert.Nil(t, err)
+	assert.Equal(t, "true", pod.Labels["patched"])
+	assert.Equal(t, string(rayv1.HeadNode), pod.Labels[utils.RayNodeTypeLabelKey])
+	assert.Equal(t, "high-priority", pod.Spec.PriorityClassName)
+	assert.Equal(t, containerCount, len(pod.Spec.Containers))
+	assert.True(t, utils.EnvVarExists("PATCHED_ENV", pod.Spec.Containers[utils.RayContainerIndex].Env))
+	assert.Equal(t, expected.Spec.Containers[utils.RayContainerIndex].Image, pod.Spec.Containers[utils.RayContainerIndex].Image)
+
+	// An invalid patch is reported.
+	err = ApplyPodTemplatePatch(&pod, &runtime.RawExtension{Raw: []byte(`{"spec": [1]}`)})
+	assert.NotNil(t, err)
+}
@@ -100,8 +100,17 @@ func BuildServiceForHeadPod(ctx context.Context, cluster rayv1.RayCluster, label
 			headService.ObjectMeta.Annotations[k] = v
 		}
 
-		// Append default ports.
-		headService.Spec.Ports = append(headService.Spec.Ports, ports...)
+		// Append default ports. Ports of the custom HeadService take precedence over default ports with the same name,
+		// so that users can expose additional ports and change the port numbers of the default ones.
+		customPortNames := make(map[string]bool, len(headService.Spec.Ports))
+		for _, port := range headService.Spec.Ports {
+			customPortNames[port.Name] = true
+		}
+		for _, port := range ports {
+			if !customPortNames[port.Name] {
+				headService.Spec.Ports = append(headService.Spec.Ports, port)
+			}
+		}
 
 		setLabelsforUserProvidedService(headService, labelsForService)
 		setNameforUserProvidedService(ctx, headService, defaultName)
@@ -289,7 +298,7 @@ func BuildServeService(ctx context.Context, rayService rayv1.RayService, rayClus
 
 // BuildHeadlessService builds the headless service for workers in multi-host worker groups to communicate
 func BuildHeadlessServiceForRayCluster(rayCluster rayv1.RayCluster) *corev1.Service {
-	name := rayCluster.Name + utils.DashSymbol + utils.HeadlessServiceSuffix
+	name := utils.GenerateServiceName(rayCluster.Name + utils.DashSymbol + utils.HeadlessServiceSuffix)
 	namespace := rayCluster.Namespace
 
 	labels := map[string]string{
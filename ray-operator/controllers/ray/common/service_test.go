@@ -417,6 +417,32 @@ func TestUserSpecifiedHeadService(t *testing.T) {
 	validateNameAndNamespaceForUserSpecifiedService(headService, testRayClusterWithHeadService.ObjectMeta.Namespace, userName, t)
 }
 
+func TestUserSpecifiedHeadServicePortOverride(t *testing.T) {
+	testRayClusterWithHeadService := instanceWithWrongSvc.DeepCopy()
+
+	// Override the port of the default "serve" port and expose an additional application port.
+	testRayClusterWithHeadService.Spec.HeadGroupSpec.HeadService = &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: utils.ServingPortName, Port: 9000},
+				{Name: "grpc", Port: 9001},
+			},
+		},
+	}
+	headService, err := BuildServiceForHeadPod(context.Background(), *testRayClusterWithHeadService, nil, nil)
+	assert.Nil(t, err)
+
+	ports := map[string]int32{}
+	for _, port := range headService.Spec.Ports {
+		_, duplicated := ports[port.Name]
+		assert.False(t, duplicated, "duplicated port %s", port.Name)
+		ports[port.Name] = port.Port
+	}
+	assert.Equal(t, int32(9000), ports[utils.ServingPortName])
+	assert.Equal(t, int32(9001), ports["grpc"])
+	assert.Equal(t, int32(6379), ports["gcs"])
+}
+
 func TestNilMapDoesntErrorInUserSpecifiedHeadService(t *testing.T) {
 	// Use any RayCluster instance as a base for the test.
 	testRayClusterWithHeadService := instanceWithWrongSvc.DeepCopy()
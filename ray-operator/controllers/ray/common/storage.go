@@ -0,0 +1,40 @@
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// BuildPersistentStorageClaimSpec builds the spec of the PersistentVolumeClaim which backs the persistent storage of a Ray Pod.
+func BuildPersistentStorageClaimSpec(storage *rayv1.RayPersistentStorage) corev1.PersistentVolumeClaimSpec {
+	return corev1.PersistentVolumeClaimSpec{
+		AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		StorageClassName: storage.StorageClassName,
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: storage.Size,
+			},
+		},
+	}
+}
+
+// BuildHeadPersistentStorageClaim builds the PersistentVolumeClaim of the persistent storage of the head Pod. Unlike
+// the volumes of the worker Pods, it is not bound to the lifetime of the Pod, so that the logs survive head Pod restarts.
+func BuildHeadPersistentStorageClaim(cluster *rayv1.RayCluster) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateHeadPersistentStorageName(cluster.Name),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:                cluster.Name,
+				utils.RayNodeTypeLabelKey:               string(rayv1.HeadNode),
+				utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+				utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+			},
+		},
+		Spec: BuildPersistentStorageClaimSpec(cluster.Spec.HeadGroupSpec.PersistentStorage),
+	}
+}
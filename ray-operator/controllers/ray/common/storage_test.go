@@ -0,0 +1,32 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildHeadPersistentStorageClaim(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.PersistentStorage = &rayv1.RayPersistentStorage{Size: resource.MustParse("50Gi")}
+
+	pvc := BuildHeadPersistentStorageClaim(cluster)
+	assert.Equal(t, utils.GenerateHeadPersistentStorageName(cluster.Name), pvc.Name)
+	assert.Equal(t, cluster.Namespace, pvc.Namespace)
+	assert.Equal(t, cluster.Name, pvc.Labels[utils.RayClusterLabelKey])
+	assert.Equal(t, string(rayv1.HeadNode), pvc.Labels[utils.RayNodeTypeLabelKey])
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes)
+	assert.Equal(t, resource.MustParse("50Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+	// The default StorageClass is used if no StorageClass is set.
+	assert.Nil(t, pvc.Spec.StorageClassName)
+
+	cluster.Spec.HeadGroupSpec.PersistentStorage.StorageClassName = ptr.To("ssd")
+	pvc = BuildHeadPersistentStorageClaim(cluster)
+	assert.Equal(t, "ssd", *pvc.Spec.StorageClassName)
+}
@@ -0,0 +1,177 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// Plugin hooks into the RayCluster reconciliation to mutate Ray Pods and to manage additional
+// resources, e.g. to integrate a scheduler or to inject containers, without forking the controller.
+type Plugin interface {
+	// Name uniquely identifies the plugin.
+	Name() string
+
+	// AfterClusterCreate is called on every reconciliation of an existing RayCluster, before its
+	// Pods are reconciled. It must be idempotent. Returning an error aborts the reconciliation.
+	AfterClusterCreate(ctx context.Context, cluster *rayv1.RayCluster) error
+
+	// BeforePodCreate is called after the operator has built a head or worker Pod and before the
+	// Pod is created. The Pod can be mutated in place. Returning an error skips the Pod creation.
+	BeforePodCreate(ctx context.Context, cluster *rayv1.RayCluster, groupName string, pod *corev1.Pod) error
+}
+
+// SetupPlugin is optionally implemented by plugins which need their own custom resource types or watches.
+type SetupPlugin interface {
+	// AddToScheme adds the types used by the plugin to the given scheme.
+	AddToScheme(scheme *runtime.Scheme)
+
+	// ConfigureReconciler adds the watches needed by the plugin to the RayCluster reconciler being built.
+	ConfigureReconciler(b *builder.Builder) *builder.Builder
+}
+
+// AdmissionPlugin is optionally implemented by plugins which integrate a scheduler that admits the Ray Pods
+// as a gang, so that users can tell a RayCluster waiting for quota from a broken one.
+type AdmissionPlugin interface {
+	// GetAdmissionStatus returns the admission state of the RayCluster, or nil if the scheduler does not
+	// manage the RayCluster. The pods are the current Ray Pods of the RayCluster.
+	GetAdmissionStatus(ctx context.Context, cluster *rayv1.RayCluster, pods []corev1.Pod) (*AdmissionStatus, error)
+}
+
+// AdmissionStatus is the state of a RayCluster in the queue of a scheduler.
+type AdmissionStatus struct {
+	// Scheduler is the name of the scheduler, e.g. "volcano".
+	Scheduler string
+	// QueueName is the queue the RayCluster is submitted to. It is empty if the scheduler has no queues.
+	QueueName string
+	// Message explains the admission state, e.g. why the Ray Pods are not admitted yet.
+	Message string
+	// Admitted is true once the scheduler admitted the Ray Pods.
+	Admitted bool
+}
+
+// Factory creates a Plugin.
+type Factory func(config *rest.Config) (Plugin, error)
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]Factory{}
+)
+
+// Register makes a plugin available under the given name, so that it can be enabled with the
+// `podMutationPlugins` operator configuration. It is meant to be called from an init function
+// and panics if the name is registered twice.
+func Register(name string, factory Factory) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("pod mutation plugin %s is already registered", name))
+	}
+	registry[name] = factory
+}
+
+// Registered returns the sorted names of all the registered plugins.
+func Registered() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Manager calls the enabled plugins in order.
+type Manager struct {
+	plugins []Plugin
+}
+
+// NewManager creates the registered plugins with the given names and returns a Manager calling
+// the builtin plugins first, followed by the named plugins in the given order.
+func NewManager(names []string, config *rest.Config, builtin ...Plugin) (*Manager, error) {
+	m := &Manager{plugins: builtin}
+
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	for _, name := range names {
+		factory, ok := registry[name]
+		if !ok {
+			return nil, fmt.Errorf("the pod mutation plugin is not registered, name=%s", name)
+		}
+		plugin, err := factory(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pod mutation plugin %s: %w", name, err)
+		}
+		m.plugins = append(m.plugins, plugin)
+	}
+	return m, nil
+}
+
+// AddToScheme adds the types used by the plugins to the given scheme.
+func (m *Manager) AddToScheme(scheme *runtime.Scheme) {
+	for _, plugin := range m.plugins {
+		if setup, ok := plugin.(SetupPlugin); ok {
+			setup.AddToScheme(scheme)
+		}
+	}
+}
+
+// ConfigureReconciler adds the watches needed by the plugins to the RayCluster reconciler being built.
+func (m *Manager) ConfigureReconciler(b *builder.Builder) *builder.Builder {
+	for _, plugin := range m.plugins {
+		if setup, ok := plugin.(SetupPlugin); ok {
+			b = setup.ConfigureReconciler(b)
+		}
+	}
+	return b
+}
+
+// AfterClusterCreate calls AfterClusterCreate of every plugin and stops at the first error.
+func (m *Manager) AfterClusterCreate(ctx context.Context, cluster *rayv1.RayCluster) error {
+	for _, plugin := range m.plugins {
+		if err := plugin.AfterClusterCreate(ctx, cluster); err != nil {
+			return fmt.Errorf("pod mutation plugin %s failed: %w", plugin.Name(), err)
+		}
+	}
+	return nil
+}
+
+// BeforePodCreate calls BeforePodCreate of every plugin and stops at the first error.
+func (m *Manager) BeforePodCreate(ctx context.Context, cluster *rayv1.RayCluster, groupName string, pod *corev1.Pod) error {
+	for _, plugin := range m.plugins {
+		if err := plugin.BeforePodCreate(ctx, cluster, groupName, pod); err != nil {
+			return fmt.Errorf("pod mutation plugin %s failed: %w", plugin.Name(), err)
+		}
+	}
+	return nil
+}
+
+// GetAdmissionStatus returns the admission state reported by the first plugin which manages the admission of
+// the RayCluster, or nil if no plugin does.
+func (m *Manager) GetAdmissionStatus(ctx context.Context, cluster *rayv1.RayCluster, pods []corev1.Pod) (*AdmissionStatus, error) {
+	for _, plugin := range m.plugins {
+		admission, ok := plugin.(AdmissionPlugin)
+		if !ok {
+			continue
+		}
+		status, err := admission.GetAdmissionStatus(ctx, cluster, pods)
+		if err != nil {
+			return nil, fmt.Errorf("pod mutation plugin %s failed: %w", plugin.Name(), err)
+		}
+		if status != nil {
+			return status, nil
+		}
+	}
+	return nil, nil
+}
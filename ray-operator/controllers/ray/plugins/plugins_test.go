@@ -0,0 +1,121 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+type labelPlugin struct {
+	err   error
+	calls *[]string
+	name  string
+}
+
+func (p *labelPlugin) Name() string {
+	return p.name
+}
+
+func (p *labelPlugin) AfterClusterCreate(_ context.Context, _ *rayv1.RayCluster) error {
+	*p.calls = append(*p.calls, p.name)
+	return p.err
+}
+
+func (p *labelPlugin) BeforePodCreate(_ context.Context, _ *rayv1.RayCluster, groupName string, pod *corev1.Pod) error {
+	*p.calls = append(*p.calls, p.name)
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[p.name] = groupName
+	return p.err
+}
+
+func TestManager(t *testing.T) {
+	var calls []string
+	// The registry is global, remove the test plugin so that it does not leak into the other tests.
+	t.Cleanup(func() {
+		registryLock.Lock()
+		defer registryLock.Unlock()
+		delete(registry, "test-injector")
+	})
+	Register("test-injector", func(_ *rest.Config) (Plugin, error) {
+		return &labelPlugin{name: "test-injector", calls: &calls}, nil
+	})
+	assert.Contains(t, Registered(), "test-injector")
+	assert.Panics(t, func() {
+		Register("test-injector", func(_ *rest.Config) (Plugin, error) { return nil, nil })
+	})
+
+	_, err := NewManager([]string{"not-registered"}, nil)
+	require.Error(t, err)
+
+	m, err := NewManager([]string{"test-injector"}, nil, &labelPlugin{name: "builtin", calls: &calls})
+	require.NoError(t, err)
+
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	require.NoError(t, m.AfterClusterCreate(context.Background(), cluster))
+	assert.Equal(t, []string{"builtin", "test-injector"}, calls)
+
+	pod := &corev1.Pod{}
+	require.NoError(t, m.BeforePodCreate(context.Background(), cluster, "workers", pod))
+	assert.Equal(t, map[string]string{"builtin": "workers", "test-injector": "workers"}, pod.Labels)
+}
+
+func TestManagerStopsAtFirstError(t *testing.T) {
+	var calls []string
+	m, err := NewManager(nil, nil,
+		&labelPlugin{name: "failing", calls: &calls, err: errors.New("boom")},
+		&labelPlugin{name: "skipped", calls: &calls},
+	)
+	require.NoError(t, err)
+
+	err = m.BeforePodCreate(context.Background(), &rayv1.RayCluster{}, "workers", &corev1.Pod{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failing")
+	assert.Equal(t, []string{"failing"}, calls)
+}
+
+type admissionPlugin struct {
+	labelPlugin
+	status *AdmissionStatus
+}
+
+func (p *admissionPlugin) GetAdmissionStatus(_ context.Context, _ *rayv1.RayCluster, _ []corev1.Pod) (*AdmissionStatus, error) {
+	return p.status, p.err
+}
+
+func TestManagerGetAdmissionStatus(t *testing.T) {
+	var calls []string
+	cluster := &rayv1.RayCluster{}
+
+	// Plugins which do not manage the admission are skipped.
+	m, err := NewManager(nil, nil, &labelPlugin{name: "builtin", calls: &calls})
+	require.NoError(t, err)
+	status, err := m.GetAdmissionStatus(context.Background(), cluster, nil)
+	require.NoError(t, err)
+	assert.Nil(t, status)
+
+	waiting := &AdmissionStatus{Scheduler: "scheduler", QueueName: "queue", Message: "not enough quota"}
+	m, err = NewManager(nil, nil,
+		&admissionPlugin{labelPlugin: labelPlugin{name: "unmanaged", calls: &calls}},
+		&admissionPlugin{labelPlugin: labelPlugin{name: "scheduler", calls: &calls}, status: waiting},
+	)
+	require.NoError(t, err)
+	status, err = m.GetAdmissionStatus(context.Background(), cluster, nil)
+	require.NoError(t, err)
+	assert.Equal(t, waiting, status)
+
+	m, err = NewManager(nil, nil, &admissionPlugin{labelPlugin: labelPlugin{name: "failing", calls: &calls, err: errors.New("boom")}})
+	require.NoError(t, err)
+	_, err = m.GetAdmissionStatus(context.Background(), cluster, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failing")
+}
@@ -7,20 +7,23 @@ import (
 	"os"
 	"reflect"
 	"runtime"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/ptr"
 
 	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/plugins"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
@@ -38,6 +41,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,8 +55,6 @@ import (
 type reconcileFunc func(context.Context, *rayv1.RayCluster) error
 
 var (
-	DefaultRequeueDuration = 2 * time.Second
-
 	// Definition of a index field for pod name
 	podUIDIndexField = "metadata.uid"
 )
@@ -114,18 +116,27 @@ func NewReconciler(ctx context.Context, mgr manager.Manager, options RayClusterR
 		panic(err)
 	}
 
+	// the batch scheduler always runs first, followed by the configured pod mutation plugins
+	pluginMgr, err := plugins.NewManager(rayConfigs.PodMutationPlugins, mgr.GetConfig(), schedulerMgr)
+	if err != nil {
+		panic(err)
+	}
+
 	// add schema to runtime
-	schedulerMgr.AddToScheme(mgr.GetScheme())
+	pluginMgr.AddToScheme(mgr.GetScheme())
 
 	return &RayClusterReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		Recorder:          mgr.GetEventRecorderFor("raycluster-controller"),
-		BatchSchedulerMgr: schedulerMgr,
-		IsOpenShift:       isOpenShift,
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    mgr.GetEventRecorderFor("raycluster-controller"),
+		PluginMgr:   pluginMgr,
+		IsOpenShift: isOpenShift,
 
+		dashboardClientFunc:     rayConfigs.GetDashboardClient(mgr),
 		headSidecarContainers:   options.HeadSidecarContainers,
 		workerSidecarContainers: options.WorkerSidecarContainers,
+		apiReader:               mgr.GetAPIReader(),
+		remoteClients:           newRemoteClientCache(),
 	}
 }
 
@@ -134,13 +145,18 @@ var _ reconcile.Reconciler = &RayClusterReconciler{}
 // RayClusterReconciler reconciles a RayCluster object
 type RayClusterReconciler struct {
 	client.Client
-	Scheme            *k8sruntime.Scheme
-	Recorder          record.EventRecorder
-	BatchSchedulerMgr *batchscheduler.SchedulerManager
+	Scheme    *k8sruntime.Scheme
+	Recorder  record.EventRecorder
+	PluginMgr *plugins.Manager
 
+	dashboardClientFunc     func() utils.RayDashboardClientInterface
 	headSidecarContainers   []corev1.Container
 	workerSidecarContainers []corev1.Container
 
+	// apiReader reads objects which are not cached by the manager, e.g. the kubeconfig Secrets of remote clusters.
+	apiReader     client.Reader
+	remoteClients *remoteClientCache
+
 	IsOpenShift bool
 }
 
@@ -155,7 +171,12 @@ type RayClusterReconcilerOptions struct {
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;delete;deletecollection
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;create
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
@@ -164,6 +185,7 @@ type RayClusterReconcilerOptions struct {
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;create;delete
 // +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
@@ -217,6 +239,27 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 	// Please do NOT modify `originalRayClusterInstance` in the following code.
 	originalRayClusterInstance := instance.DeepCopy()
 
+	// The Pods and Services of a RayCluster with spec.remoteCluster are managed in the remote cluster through
+	// a copy of the reconciler whose client routes the requests for them there.
+	if instance.Spec.RemoteCluster != nil {
+		remoteReconciler, result, err := r.remoteClusterReconciler(ctx, instance)
+		if remoteReconciler == nil {
+			return result, err
+		}
+		r = remoteReconciler
+	}
+
+	// A RayCluster with spec.drainBeforeDeletion keeps its Pods until its Ray jobs finish, so the drain must
+	// happen before any other finalizer starts tearing the RayCluster down.
+	if instance.DeletionTimestamp.IsZero() {
+		if updated, err := r.reconcileDrainFinalizer(ctx, instance); err != nil || updated {
+			// Only start the RayCluster reconciliation after the finalizer is updated.
+			return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
+		}
+	} else if controllerutil.ContainsFinalizer(instance, utils.RayClusterDrainFinalizer) {
+		return r.drainRayCluster(ctx, instance)
+	}
+
 	// The `enableGCSFTRedisCleanup` is a feature flag introduced in KubeRay v1.0.0. It determines whether
 	// the Redis cleanup job should be activated. Users can disable the feature by setting the environment
 	// variable `ENABLE_GCS_FT_REDIS_CLEANUP` to `false`, and undertake the Redis storage namespace cleanup
@@ -232,10 +275,10 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 				controllerutil.AddFinalizer(instance, utils.GCSFaultToleranceRedisCleanupFinalizer)
 				if err := r.Update(ctx, instance); err != nil {
 					err = fmt.Errorf("Failed to add the finalizer %s to the RayCluster: %w", utils.GCSFaultToleranceRedisCleanupFinalizer, err)
-					return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+					return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
 				}
 				// Only start the RayCluster reconciliation after the finalizer is added.
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+				return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, nil
 			}
 		} else {
 			logger.Info(
@@ -246,18 +289,18 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 			// Delete the head Pod if it exists.
 			headPods, err := r.deleteAllPods(ctx, common.RayClusterHeadPodsAssociationOptions(instance))
 			if err != nil {
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
 			}
 			// Delete all worker Pods if they exist.
 			if _, err = r.deleteAllPods(ctx, common.RayClusterWorkerPodsAssociationOptions(instance)); err != nil {
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
 			}
 			if len(headPods.Items) > 0 {
 				logger.Info(fmt.Sprintf(
 					"Wait for the head Pod %s to be terminated before initiating the Redis cleanup process. "+
 						"The storage namespace %s in Redis cannot be fully deleted if the GCS process on the head Pod is still writing to it.",
 					headPods.Items[0].Name, headPods.Items[0].Annotations[utils.RayExternalStorageNSAnnotationKey]))
-				// Requeue after 10 seconds because it takes much longer than DefaultRequeueDuration (2 seconds) for the head Pod to be terminated.
+				// Requeue after 10 seconds because it takes much longer than utils.RayClusterRequeueDuration() (2 seconds by default) for the head Pod to be terminated.
 				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 			}
 
@@ -265,7 +308,7 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 			filterLabels := client.MatchingLabels{utils.RayClusterLabelKey: instance.Name, utils.RayNodeTypeLabelKey: string(rayv1.RedisCleanupNode)}
 			redisCleanupJobs := batchv1.JobList{}
 			if err := r.List(ctx, &redisCleanupJobs, client.InNamespace(instance.Namespace), filterLabels); err != nil {
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
 			}
 
 			if len(redisCleanupJobs.Items) != 0 {
@@ -276,7 +319,7 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 				if condition, finished := utils.IsJobFinished(&redisCleanupJob); finished {
 					controllerutil.RemoveFinalizer(instance, utils.GCSFaultToleranceRedisCleanupFinalizer)
 					if err := r.Update(ctx, instance); err != nil {
-						return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+						return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
 					}
 					switch condition {
 					case batchv1.JobComplete:
@@ -294,30 +337,39 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 					return ctrl.Result{}, nil
 				}
 				// the redisCleanupJob is still running
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+				return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, nil
 			}
 			redisCleanupJob := r.buildRedisCleanupJob(ctx, *instance)
 			if err := r.Create(ctx, &redisCleanupJob); err != nil {
 				if errors.IsAlreadyExists(err) {
 					logger.Info("Redis cleanup Job already exists. Requeue the RayCluster CR.")
-					return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+					return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, nil
 				}
 				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateRedisCleanupJob),
 					"Failed to create Redis cleanup Job %s/%s, %v", redisCleanupJob.Namespace, redisCleanupJob.Name, err)
-				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
 			}
 			logger.Info("Created Redis cleanup Job", "name", redisCleanupJob.Name)
 			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedRedisCleanupJob),
 				"Created Redis cleanup Job %s/%s", redisCleanupJob.Namespace, redisCleanupJob.Name)
-			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+			return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, nil
 		}
 	}
 
 	if instance.DeletionTimestamp != nil && !instance.DeletionTimestamp.IsZero() {
+		if instance.Spec.RemoteCluster != nil && controllerutil.ContainsFinalizer(instance, utils.RemoteClusterCleanupFinalizer) {
+			return r.cleanupRemoteCluster(ctx, instance)
+		}
 		logger.Info("RayCluster is being deleted, just ignore", "cluster name", request.Name)
 		return ctrl.Result{}, nil
 	}
 
+	// Fail fast, instead of creating Ray Pods which crash-loop, when the Ray version does not support a requested feature.
+	if err := utils.ValidateRayVersion(&instance.Spec, utils.RayClusterVersionRequirements(&instance.Spec)...); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.IncompatibleRayVersion), "Invalid RayCluster %s/%s: %v", instance.Namespace, instance.Name, err)
+		return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
+	}
+
 	reconcileFuncs := []reconcileFunc{
 		r.reconcileAutoscalerServiceAccount,
 		r.reconcileAutoscalerRole,
@@ -326,7 +378,13 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		r.reconcileHeadService,
 		r.reconcileHeadlessService,
 		r.reconcileServeService,
+		r.reconcileMetrics,
+		r.reconcileSystemConfig,
+		r.reconcileHeadPersistentStorage,
+		r.reconcileDashboardAuth,
 		r.reconcilePods,
+		r.reconcileHeadStandby,
+		r.reconcileUpgrade,
 	}
 
 	for _, fn := range reconcileFuncs {
@@ -356,19 +414,27 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, request
 		err = updateErr
 	}
 	if err != nil {
-		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
 	}
 
-	// Unconditionally requeue after the number of seconds specified in the
-	// environment variable RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV. If the
-	// environment variable is not set, requeue after the default value.
-	requeueAfterSeconds, err := strconv.Atoi(os.Getenv(utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV))
-	if err != nil {
-		logger.Info(fmt.Sprintf("Environment variable %s is not set, using default value of %d seconds", utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV, utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS), "cluster name", request.Name)
-		requeueAfterSeconds = utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS
+	// Unconditionally requeue after the periodic reconcile interval of the RayCluster, which is set with the
+	// `ray.io/reconcile-interval` annotation, the requeue policy of the operator, or the environment variable
+	// RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV.
+	requeueAfter := utils.PeriodicReconcileInterval(instance)
+	// Requeue when the next scaling schedule window starts or ends, so that the replica bounds change on time.
+	if next, ok := utils.NextScalingScheduleTransition(instance, time.Now()); ok && time.Until(next) < requeueAfter {
+		requeueAfter = time.Until(next) + time.Second
+	}
+	// Requeue when the next usage snapshot is due.
+	if next, ok := nextUsageSnapshotTime(newInstance); ok && time.Until(next) < requeueAfter {
+		requeueAfter = max(time.Until(next), 0) + time.Second
 	}
-	logger.Info("Unconditional requeue after", "cluster name", request.Name, "seconds", requeueAfterSeconds)
-	return ctrl.Result{RequeueAfter: time.Duration(requeueAfterSeconds) * time.Second}, nil
+	// Requeue when the next batch of worker Pods can be created or deleted.
+	if next, ok := nextPodBatchTime(newInstance); ok && time.Until(next) < requeueAfter {
+		requeueAfter = max(time.Until(next), 0) + time.Second
+	}
+	logger.Info("Unconditional requeue after", "cluster name", request.Name, "seconds", requeueAfter.Seconds())
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // Checks whether the old and new RayClusterStatus are inconsistent by comparing different fields. If the only
@@ -414,6 +480,22 @@ func (r *RayClusterReconciler) inconsistentRayClusterStatus(ctx context.Context,
 		logger.Info("inconsistentRayClusterStatus", "old conditions", oldStatus.Conditions, "new conditions", newStatus.Conditions)
 		return true
 	}
+	if !reflect.DeepEqual(oldStatus.UpgradeStatus, newStatus.UpgradeStatus) {
+		logger.Info("inconsistentRayClusterStatus", "old UpgradeStatus", oldStatus.UpgradeStatus, "new UpgradeStatus", newStatus.UpgradeStatus)
+		return true
+	}
+	if !reflect.DeepEqual(oldStatus.PodBatching, newStatus.PodBatching) {
+		logger.Info("inconsistentRayClusterStatus", "old PodBatching", oldStatus.PodBatching, "new PodBatching", newStatus.PodBatching)
+		return true
+	}
+	if oldStatus.SystemConfigHash != newStatus.SystemConfigHash {
+		logger.Info("inconsistentRayClusterStatus", "old SystemConfigHash", oldStatus.SystemConfigHash, "new SystemConfigHash", newStatus.SystemConfigHash)
+		return true
+	}
+	if !reflect.DeepEqual(oldStatus.UsageSnapshots, newStatus.UsageSnapshots) {
+		logger.Info("inconsistentRayClusterStatus", "old UsageSnapshots", len(oldStatus.UsageSnapshots), "new UsageSnapshots", len(newStatus.UsageSnapshots))
+		return true
+	}
 	return false
 }
 
@@ -577,6 +659,131 @@ func (r *RayClusterReconciler) reconcileServeService(ctx context.Context, instan
 	return err
 }
 
+// Return nil only when the metrics Service and the monitor requested by spec.metrics are successfully created or
+// already exist. A missing Prometheus Operator CRD is reported as an event and does not block the reconciliation.
+func (r *RayClusterReconciler) reconcileMetrics(ctx context.Context, instance *rayv1.RayCluster) error {
+	if instance.Spec.Metrics == nil {
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	svc := &corev1.Service{}
+	err := r.Get(ctx, common.RayClusterMetricsServiceNamespacedName(instance), svc)
+	if errors.IsNotFound(err) {
+		if err := r.createService(ctx, common.BuildMetricsServiceForRayCluster(*instance), instance); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	monitor := common.BuildMonitorForRayCluster(*instance)
+	if monitor == nil {
+		return nil
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(monitor.GroupVersionKind())
+	err = r.Get(ctx, client.ObjectKeyFromObject(monitor), existing)
+	if err == nil {
+		// monitor exists, do nothing
+		return nil
+	} else if meta.IsNoMatchError(err) {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateMonitor),
+			"Failed creating %s %s/%s, the Prometheus Operator CRDs are not installed", monitor.GetKind(), monitor.GetNamespace(), monitor.GetName())
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err := ctrl.SetControllerReference(instance, monitor, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, monitor); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateMonitor),
+			"Failed creating %s %s/%s, %v", monitor.GetKind(), monitor.GetNamespace(), monitor.GetName(), err)
+		return err
+	}
+	logger.Info("Created monitor for RayCluster", "kind", monitor.GetKind(), "name", monitor.GetName())
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedMonitor),
+		"Created %s %s/%s", monitor.GetKind(), monitor.GetNamespace(), monitor.GetName())
+	return nil
+}
+
+// reconcileImagePrePull creates the DaemonSets which pre-pull the images of the Ray Pods requested by spec.imagePrePull.
+// It returns true while the images are being pulled and the Ray Pods should not be created yet, i.e. until the images
+// are pulled on all the nodes or the timeout expires. The DaemonSets are deleted once the Ray Pods exist.
+func (r *RayClusterReconciler) reconcileImagePrePull(ctx context.Context, instance *rayv1.RayCluster) (bool, error) {
+	if instance.Spec.ImagePrePull == nil {
+		return false, nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	// The images are only pre-pulled for the initial Ray Pods. Once Ray Pods exist, the DaemonSets are no longer needed.
+	allPods := corev1.PodList{}
+	if err := r.List(ctx, &allPods, common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return false, err
+	}
+	if len(allPods.Items) > 0 {
+		existing := appsv1.DaemonSetList{}
+		if err := r.List(ctx, &existing, common.RayClusterImagePrePullDaemonSetsAssociationOptions(instance).ToListOptions()...); err != nil {
+			return false, err
+		}
+		if len(existing.Items) == 0 {
+			return false, nil
+		}
+		logger.Info("Deleting the image pre-pull DaemonSets of the RayCluster", "count", len(existing.Items))
+		return false, r.DeleteAllOf(ctx, &appsv1.DaemonSet{}, append(
+			common.RayClusterImagePrePullDaemonSetsAssociationOptions(instance).ToDeleteOptions(),
+			client.PropagationPolicy(metav1.DeletePropagationBackground))...)
+	}
+
+	daemonSets, err := common.BuildImagePrePullDaemonSets(*instance)
+	if err != nil {
+		return false, err
+	}
+
+	timeout := time.Duration(utils.DefaultImagePrePullTimeoutSeconds) * time.Second
+	if instance.Spec.ImagePrePull.TimeoutSeconds != nil {
+		timeout = time.Duration(*instance.Spec.ImagePrePull.TimeoutSeconds) * time.Second
+	}
+
+	pulling := false
+	for _, daemonSet := range daemonSets {
+		existing := &appsv1.DaemonSet{}
+		err := r.Get(ctx, client.ObjectKeyFromObject(daemonSet), existing)
+		if errors.IsNotFound(err) {
+			if err := ctrl.SetControllerReference(instance, daemonSet, r.Scheme); err != nil {
+				return false, err
+			}
+			if err := r.Create(ctx, daemonSet); err != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateImagePrePullDaemonSet),
+					"Failed creating image pre-pull DaemonSet %s/%s, %v", daemonSet.Namespace, daemonSet.Name, err)
+				return false, err
+			}
+			logger.Info("Created image pre-pull DaemonSet for RayCluster", "name", daemonSet.Name)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedImagePrePullDaemonSet),
+				"Created image pre-pull DaemonSet %s/%s", daemonSet.Namespace, daemonSet.Name)
+			pulling = true
+			continue
+		} else if err != nil {
+			return false, err
+		}
+
+		if common.IsImagePrePullDaemonSetDone(existing) {
+			continue
+		}
+		if time.Since(existing.CreationTimestamp.Time) > timeout {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.ImagePrePullTimedOut),
+				"Image pre-pull DaemonSet %s/%s did not finish within %v, %d of %d nodes are ready; creating the Ray Pods anyway",
+				existing.Namespace, existing.Name, timeout, existing.Status.NumberReady, existing.Status.DesiredNumberScheduled)
+			continue
+		}
+		pulling = true
+	}
+
+	return pulling, nil
+}
+
 // Return nil only when the headless service for multi-host worker groups is successfully created or already exists.
 func (r *RayClusterReconciler) reconcileHeadlessService(ctx context.Context, instance *rayv1.RayCluster) error {
 	// Check if there are worker groups with NumOfHosts > 1 in the cluster
@@ -634,18 +841,23 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 	if err := r.List(ctx, &headPods, common.RayClusterHeadPodsAssociationOptions(instance).ToListOptions()...); err != nil {
 		return err
 	}
-	// check if the batch scheduler integration is enabled
-	// call the scheduler plugin if so
-	if r.BatchSchedulerMgr != nil {
-		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(instance); err == nil {
-			if err := scheduler.DoBatchSchedulingOnSubmission(ctx, instance); err != nil {
-				return err
-			}
-		} else {
+	// call the pod mutation plugins, e.g. to submit the cluster to the batch scheduler
+	if r.PluginMgr != nil {
+		if err := r.PluginMgr.AfterClusterCreate(ctx, instance); err != nil {
 			return err
 		}
 	}
 
+	// Before the Ray Pods are created, wait for their images to be pre-pulled on the nodes.
+	pulling, err := r.reconcileImagePrePull(ctx, instance)
+	if err != nil {
+		return err
+	}
+	if pulling {
+		logger.Info("reconcilePods", "Waiting for the images to be pre-pulled before creating the Ray Pods", instance.Name)
+		return nil
+	}
+
 	// Reconcile head Pod
 	if len(headPods.Items) == 1 {
 		headPod := headPods.Items[0]
@@ -697,9 +909,51 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 		}
 	}
 
+	// With GCS fault tolerance, a restarted head Pod restores the cluster state from Redis. Worker Pods are only
+	// reconciled once the head Pod is ready again, so that new and restarted workers register with the restored GCS.
+	if isWaitingForHeadRecovery(instance) {
+		logger.Info("reconcilePods", "Skip reconciling worker Pods until the head Pod recovers", instance.Name)
+		return nil
+	}
+
+	// usedResources tracks the resources requested by the Ray Pods, so that new worker Pods do not exceed spec.resourceQuota.
+	enforceResourceQuota := len(instance.Spec.ResourceQuota) > 0
+	var usedResources corev1.ResourceList
+	if enforceResourceQuota {
+		allPods := corev1.PodList{}
+		if err := r.List(ctx, &allPods, common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+			return err
+		}
+		usedResources = utils.CalculatePodsResource(allPods.Items)
+	}
+
 	// Reconcile worker pods now
+	now := time.Now()
+	// The pod batch policy of the operator limits the worker Pods created and deleted by this reconciliation.
+	batch := newPodBatch(instance, metav1.NewTime(now))
 	for _, worker := range instance.Spec.WorkerGroupSpecs {
 		// workerReplicas will store the target number of pods for this worker group.
+		// The active scaling schedule of the worker group overrides its minReplicas and maxReplicas.
+		if schedule := utils.GetActiveScalingSchedule(ctx, worker, now); schedule != nil {
+			logger.Info("reconcilePods", "worker group", worker.GroupName, "active scaling schedule", schedule.Name)
+			worker = utils.ApplyScalingSchedule(ctx, worker, now)
+		}
+		// A node fill worker group runs one worker Pod on every fillable Node matching its selector.
+		var fillableNodes map[string]bool
+		if worker.NodeFill != nil {
+			nodes, err := r.listNodeFillNodes(ctx, worker.NodeFill)
+			if err != nil {
+				return err
+			}
+			worker = utils.ApplyNodeFill(worker, instance.Name, nodes)
+			fillableNodes = make(map[string]bool)
+			for _, node := range nodes {
+				if utils.IsNodeFillable(node) {
+					fillableNodes[node.Name] = true
+				}
+			}
+			logger.Info("reconcilePods", "worker group", worker.GroupName, "node fill replicas", *worker.Replicas)
+		}
 		var workerReplicas int32 = utils.GetWorkerGroupDesiredReplicas(ctx, worker)
 		logger.Info("reconcilePods", "desired workerReplicas (always adhering to minReplicas/maxReplica)", workerReplicas, "worker group", worker.GroupName, "maxReplicas", worker.MaxReplicas, "minReplicas", worker.MinReplicas, "replicas", worker.Replicas)
 
@@ -770,6 +1024,8 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 			worker.NumOfHosts = 1
 		}
 		numExpectedPods := int(workerReplicas * worker.NumOfHosts)
+		// The worker group being upgraded may run surge Pods in addition to its desired Pods.
+		numExpectedPods += getRolloutSurge(ctx, instance, worker, runningPods.Items, numExpectedPods)
 		diff := numExpectedPods - len(runningPods.Items)
 
 		logger.Info("reconcilePods", "workerReplicas", workerReplicas, "NumOfHosts", worker.NumOfHosts, "runningPods", len(runningPods.Items), "diff", diff)
@@ -778,11 +1034,33 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 			// pods need to be added
 			logger.Info("reconcilePods", "Number workers to add", diff, "Worker group", worker.GroupName)
 			// create all workers of this group
+			podResource := utils.CalculatePodResource(*worker.Template.Spec.DeepCopy())
+			if batched := batch.take(worker.GroupName, diff, false); batched < diff {
+				logger.Info("reconcilePods", "Number workers to add in this batch", batched, "Worker group", worker.GroupName)
+				diff = batched
+			}
 			for i := 0; i < diff; i++ {
+				if enforceResourceQuota {
+					if name, exceeded := utils.ExceedsResourceQuota(instance.Spec.ResourceQuota, usedResources, podResource); exceeded {
+						quota := instance.Spec.ResourceQuota[name]
+						logger.Info("reconcilePods", "Resource quota exceeded; skip creating workers for group", worker.GroupName, "resource", name, "quota", quota.String(), "skipped", diff-i)
+						r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.ResourceQuotaExceeded),
+							"Skipped creating %d worker Pods of group %s because the total %s of the RayCluster would exceed its resource quota of %s",
+							diff-i, worker.GroupName, name, quota.String())
+						break
+					}
+				}
 				logger.Info("reconcilePods", "creating worker for group", worker.GroupName, fmt.Sprintf("index %d", i), fmt.Sprintf("in total %d", diff))
 				if err := r.createWorkerPod(ctx, *instance, *worker.DeepCopy()); err != nil {
 					return errstd.Join(utils.ErrFailedCreateWorkerPod, err)
 				}
+				if enforceResourceQuota {
+					for name, quantity := range podResource {
+						total := usedResources[name]
+						total.Add(quantity)
+						usedResources[name] = total
+					}
+				}
 			}
 		} else if diff == 0 {
 			logger.Info("reconcilePods", "all workers already exist for group", worker.GroupName)
@@ -805,12 +1083,25 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 			// Case 1: If Autoscaler is disabled, we will always enable random Pod deletion no matter the value of the feature flag.
 			// Case 2: If Autoscaler is enabled, we will respect the value of the feature flag. If the feature flag environment variable
 			// is not set, we will disable random Pod deletion by default.
-			if !enableInTreeAutoscaling || enableRandomPodDelete {
+			// The replicas of a node fill worker group follow its Nodes rather than the Autoscaler.
+			if !enableInTreeAutoscaling || enableRandomPodDelete || worker.NodeFill != nil {
 				// diff < 0 means that we need to delete some Pods to meet the desired number of replicas.
-				randomlyRemovedWorkers := -diff
-				logger.Info("reconcilePods", "Number workers to delete randomly", randomlyRemovedWorkers, "Worker group", worker.GroupName)
+				randomlyRemovedWorkers := batch.take(worker.GroupName, -diff, true)
+				logger.Info("reconcilePods", "Number workers to delete randomly", randomlyRemovedWorkers, "Worker group", worker.GroupName, "pending", -diff-randomlyRemovedWorkers)
+				var detachedWorkloads map[string]string
+				if features.Enabled(features.DetachedWorkloadAwareScaleDown) {
+					detachedWorkloads = r.getPodsWithDetachedWorkloads(ctx, instance, runningPods.Items)
+					sortPodsByDetachedWorkloads(runningPods.Items, detachedWorkloads)
+				}
+				if fillableNodes != nil {
+					sortPodsByFillableNodes(runningPods.Items, fillableNodes)
+				}
 				for i := 0; i < randomlyRemovedWorkers; i++ {
 					randomPodToDelete := runningPods.Items[i]
+					if workloads, ok := detachedWorkloads[randomPodToDelete.Name]; ok {
+						r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.DeletedWorkerPodWithDetachedWorkloads),
+							"Deleting Pod %s/%s which hosts %s", randomPodToDelete.Namespace, randomPodToDelete.Name, workloads)
+					}
 					logger.Info("Randomly deleting Pod", "progress", fmt.Sprintf("%d / %d", i+1, randomlyRemovedWorkers), "with name", randomPodToDelete.Name)
 					if err := r.Delete(ctx, &randomPodToDelete); err != nil {
 						if !errors.IsNotFound(err) {
@@ -826,9 +1117,602 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 			}
 		}
 	}
+	instance.Status.PodBatching = batch.result()
+	return nil
+}
+
+// isWaitingForHeadRecovery returns whether the RayCluster is recovering from a head Pod failure and the head Pod
+// is not ready yet.
+func isWaitingForHeadRecovery(instance *rayv1.RayCluster) bool {
+	if !features.Enabled(features.RayClusterStatusConditions) || !common.IsGCSFaultToleranceEnabled(*instance) {
+		return false
+	}
+	cond := meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.RayClusterRecovering))
+	return cond != nil && cond.Status == metav1.ConditionTrue &&
+		(cond.Reason == rayv1.RecoveryWaitingForHeadPod || cond.Reason == rayv1.RecoveryWaitingForGCS)
+}
+
+// reconcileHeadPersistentStorage creates the PersistentVolumeClaim which backs the Ray logs and the object spilling
+// directory of the head Pod. The PersistentVolumeClaim is owned by the RayCluster and is deleted together with it.
+func (r *RayClusterReconciler) reconcileHeadPersistentStorage(ctx context.Context, instance *rayv1.RayCluster) error {
+	if instance.Spec.HeadGroupSpec.PersistentStorage == nil {
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	// PersistentVolumeClaims are read without the cache, so that the operator does not have to watch all of them.
+	reader := r.apiReader
+	if reader == nil {
+		reader = r.Client
+	}
+	pvc := common.BuildHeadPersistentStorageClaim(instance)
+	if err := reader.Get(ctx, client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{}); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err := ctrl.SetControllerReference(instance, pvc, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, pvc); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreatePersistentVolumeClaim),
+			"Failed creating PersistentVolumeClaim %s/%s, %v", pvc.Namespace, pvc.Name, err)
+		return err
+	}
+	logger.Info("Created the PersistentVolumeClaim of the head Pod", "name", pvc.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedPersistentVolumeClaim),
+		"Created PersistentVolumeClaim %s/%s", pvc.Namespace, pvc.Name)
+	return nil
+}
+
+// reconcileDashboardAuth creates the Secret which holds the auth token of the Ray dashboard before the head Pod, whose
+// auth proxy sidecar reads it. The token is never rotated, and the Secret is owned by the RayCluster, so it is kept
+// when dashboard auth is disabled and deleted together with the RayCluster.
+func (r *RayClusterReconciler) reconcileDashboardAuth(ctx context.Context, instance *rayv1.RayCluster) error {
+	if instance.Spec.DashboardAuth == nil {
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	// Secrets are read without the cache, so that the operator does not have to watch all Secrets.
+	reader := r.apiReader
+	if reader == nil {
+		reader = r.Client
+	}
+	key := client.ObjectKey{Namespace: instance.Namespace, Name: utils.GenerateDashboardAuthSecretName(instance.Name)}
+	if err := reader.Get(ctx, key, &corev1.Secret{}); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	secret, err := common.BuildDashboardAuthSecret(instance)
+	if err != nil {
+		return err
+	}
+	if err := ctrl.SetControllerReference(instance, secret, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, secret); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateDashboardAuthSecret),
+			"Failed creating dashboard auth Secret %s/%s, %v", secret.Namespace, secret.Name, err)
+		return err
+	}
+	logger.Info("Created the dashboard auth Secret", "name", secret.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedDashboardAuthSecret),
+		"Created dashboard auth Secret %s/%s", secret.Namespace, secret.Name)
+	return nil
+}
+
+// reconcileHeadStandby keeps the standby Pod of the head placement policy, which reserves capacity for the head Pod in
+// another failure domain. The standby Pod is recreated after it is preempted by the head Pod or evicted, and deleted
+// once the standby is disabled or the RayCluster is suspended.
+func (r *RayClusterReconciler) reconcileHeadStandby(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	placement := instance.Spec.HeadGroupSpec.Placement
+	enabled := placement != nil && placement.Standby != nil && !(instance.Spec.Suspend != nil && *instance.Spec.Suspend)
+	if enabled && !common.IsGCSFaultToleranceEnabled(*instance) {
+		logger.Info("The head standby Pod requires GCS fault tolerance to be enabled, skipping it")
+		enabled = false
+	}
+
+	standbyPod := &corev1.Pod{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: utils.GenerateHeadStandbyPodName(instance.Name)}, standbyPod)
+	if err == nil {
+		if enabled && standbyPod.Status.Phase != corev1.PodFailed {
+			return nil
+		}
+		if !standbyPod.DeletionTimestamp.IsZero() {
+			return nil
+		}
+		if err := r.Delete(ctx, standbyPod); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		logger.Info("Deleted the head standby Pod", "name", standbyPod.Name, "phase", standbyPod.Status.Phase)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedHeadStandbyPod),
+			"Deleted head standby Pod %s/%s", standbyPod.Namespace, standbyPod.Name)
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	standbyPod = common.BuildHeadStandbyPod(*instance)
+	if err := ctrl.SetControllerReference(instance, standbyPod, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, standbyPod); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateHeadStandbyPod),
+			"Failed creating head standby Pod %s/%s, %v", standbyPod.Namespace, standbyPod.Name, err)
+		return err
+	}
+	logger.Info("Created the head standby Pod", "name", standbyPod.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedHeadStandbyPod),
+		"Created head standby Pod %s/%s", standbyPod.Namespace, standbyPod.Name)
+	return nil
+}
+
+// reconcileSystemConfig records the hash of the Ray system config referenced by spec.systemConfig in the status, so that
+// new Ray Pods are annotated with it, and recreates the Ray Pods which were created with another system config. The
+// head Pod is recreated first because it passes the system config to the Ray cluster, followed by the worker groups in
+// the order of WorkerGroupSpecs. The next group is only recreated once all Pods are running and ready again.
+func (r *RayClusterReconciler) reconcileSystemConfig(ctx context.Context, instance *rayv1.RayCluster) error {
+	if instance.Spec.SystemConfig == nil {
+		instance.Status.SystemConfigHash = ""
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	// ConfigMaps are read without the cache, so that the operator does not have to watch all ConfigMaps.
+	reader := r.apiReader
+	if reader == nil {
+		reader = r.Client
+	}
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: instance.Namespace, Name: instance.Spec.SystemConfig.ConfigMapName}
+	if err := reader.Get(ctx, key, configMap); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToGetSystemConfig),
+			"Failed getting the Ray system config ConfigMap %s/%s, %v", key.Namespace, key.Name, err)
+		return err
+	}
+	dataKey := common.GetRaySystemConfigKey(instance.Spec.SystemConfig)
+	systemConfig, ok := configMap.Data[dataKey]
+	if !ok {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToGetSystemConfig),
+			"The Ray system config ConfigMap %s/%s has no key %s", key.Namespace, key.Name, dataKey)
+		return fmt.Errorf("the Ray system config ConfigMap %s has no key %s", key.Name, dataKey)
+	}
+	hash, err := utils.GenerateJsonHash(systemConfig)
+	if err != nil {
+		return err
+	}
+	instance.Status.SystemConfigHash = hash
+
+	if instance.Spec.Suspend != nil && *instance.Spec.Suspend {
+		return nil
+	}
+	allPods := corev1.PodList{}
+	if err := r.List(ctx, &allPods, common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+	group, outdatedPods := getPodsWithOutdatedSystemConfig(instance, allPods.Items, hash)
+	if len(outdatedPods) == 0 {
+		return nil
+	}
+	if !isRayClusterSettled(ctx, instance, allPods) {
+		logger.Info("reconcileSystemConfig", "Waiting for all Pods to be running and ready before applying the system config to group", group)
+		return nil
+	}
+
+	deletedEvent, failedEvent, failedErr := utils.DeletedWorkerPod, utils.FailedToDeleteWorkerPod, utils.ErrFailedDeleteWorkerPod
+	if group == utils.RayNodeHeadGroupLabelValue {
+		deletedEvent, failedEvent, failedErr = utils.DeletedHeadPod, utils.FailedToDeleteHeadPod, utils.ErrFailedDeleteHeadPod
+	}
+	for _, pod := range outdatedPods {
+		if err := r.Delete(ctx, &pod); err != nil {
+			if !errors.IsNotFound(err) {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(failedEvent),
+					"Failed deleting Pod %s/%s of group %s to apply the new Ray system config, %v", pod.Namespace, pod.Name, group, err)
+				return errstd.Join(failedErr, err)
+			}
+			continue
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(deletedEvent),
+			"Deleted Pod %s/%s of group %s to apply the new Ray system config", pod.Namespace, pod.Name, group)
+	}
+	return nil
+}
+
+// getPodsWithOutdatedSystemConfig returns the first group, in restart order, with Pods that were created with another
+// Ray system config than the given hash, and the outdated Pods of that group. The head group always comes first.
+func getPodsWithOutdatedSystemConfig(instance *rayv1.RayCluster, pods []corev1.Pod, hash string) (string, []corev1.Pod) {
+	outdatedPods := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Annotations[utils.RaySystemConfigHashAnnotationKey] == hash {
+			continue
+		}
+		group := pod.Labels[utils.RayNodeGroupLabelKey]
+		if pod.Labels[utils.RayNodeTypeLabelKey] == string(rayv1.HeadNode) {
+			group = utils.RayNodeHeadGroupLabelValue
+		}
+		outdatedPods[group] = append(outdatedPods[group], pod)
+	}
+
+	groups := []string{utils.RayNodeHeadGroupLabelValue}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		groups = append(groups, worker.GroupName)
+	}
+	for _, group := range groups {
+		if len(outdatedPods[group]) > 0 {
+			return group, outdatedPods[group]
+		}
+	}
+	return "", nil
+}
+
+// reconcileUpgrade replaces Pods whose Ray container image differs from the image in the RayCluster spec when the
+// upgrade strategy is GroupByGroup. Worker groups are upgraded one at a time in the order of WorkerGroupSpecs: the
+// outdated Pods of a group are deleted and recreated by reconcilePods, and the next group is only upgraded once all
+// Pods of the RayCluster are running and ready again. The head Pod is recreated last if UpgradeHead is set. The
+// outdated Pods of a worker group with a rollout strategy are replaced in batches bounded by its maxUnavailable and
+// maxSurge instead of all at once.
+func (r *RayClusterReconciler) reconcileUpgrade(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	strategy := instance.Spec.UpgradeStrategy
+	if strategy == nil || strategy.Type == nil || *strategy.Type != rayv1.GroupByGroupUpgrade ||
+		(instance.Spec.Suspend != nil && *instance.Spec.Suspend) {
+		instance.Status.UpgradeStatus = nil
+		return nil
+	}
+
+	upgradeHead := strategy.UpgradeHead != nil && *strategy.UpgradeHead
+	if upgradeHead && !common.IsGCSFaultToleranceEnabled(*instance) {
+		logger.Info("reconcileUpgrade", "The head Pod will not be upgraded because GCS fault tolerance is disabled", instance.Name)
+		upgradeHead = false
+	}
+
+	allPods := corev1.PodList{}
+	if err := r.List(ctx, &allPods, common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+
+	pendingGroups, outdatedPods := getOutdatedPodsByGroup(instance, allPods.Items, upgradeHead)
+	if len(pendingGroups) == 0 {
+		if instance.Status.UpgradeStatus != nil {
+			logger.Info("reconcileUpgrade", "All Pods run the desired image", instance.Name)
+		}
+		instance.Status.UpgradeStatus = nil
+		return nil
+	}
+
+	if instance.Status.UpgradeStatus == nil {
+		now := metav1.Now()
+		instance.Status.UpgradeStatus = &rayv1.RayClusterUpgradeStatus{StartTime: &now}
+	}
+	currentGroup := pendingGroups[0]
+	isUpgradingGroup := instance.Status.UpgradeStatus.CurrentGroup == currentGroup
+	instance.Status.UpgradeStatus.CurrentGroup = currentGroup
+	instance.Status.UpgradeStatus.PendingGroups = pendingGroups[1:]
+
+	// Wait for the Pods replaced in the previous step to be running and ready before moving on. The Pods of a worker
+	// group with a rollout strategy are replaced in batches bounded by the strategy, so only its first batch waits.
+	var worker *rayv1.WorkerGroupSpec
+	for i := range instance.Spec.WorkerGroupSpecs {
+		if instance.Spec.WorkerGroupSpecs[i].GroupName == currentGroup {
+			worker = &instance.Spec.WorkerGroupSpecs[i]
+		}
+	}
+	hasRolloutStrategy := worker != nil && worker.RolloutStrategy != nil
+	if (!hasRolloutStrategy || !isUpgradingGroup) && !isRayClusterSettled(ctx, instance, allPods) {
+		logger.Info("reconcileUpgrade", "Waiting for all Pods to be running and ready before upgrading group", currentGroup)
+		return nil
+	}
+	podsToDelete := outdatedPods[currentGroup]
+	if hasRolloutStrategy {
+		var err error
+		if podsToDelete, err = getRolloutPodsToDelete(ctx, *worker, allPods.Items, podsToDelete); err != nil {
+			return err
+		}
+		if len(podsToDelete) == 0 {
+			logger.Info("reconcileUpgrade", "Waiting for the rollout strategy to allow replacing more Pods of group", currentGroup)
+			return nil
+		}
+	}
+
+	deletedEvent, failedEvent, failedErr := utils.DeletedWorkerPod, utils.FailedToDeleteWorkerPod, utils.ErrFailedDeleteWorkerPod
+	if currentGroup == utils.RayNodeHeadGroupLabelValue {
+		deletedEvent, failedEvent, failedErr = utils.DeletedHeadPod, utils.FailedToDeleteHeadPod, utils.ErrFailedDeleteHeadPod
+	}
+	for _, pod := range podsToDelete {
+		if err := r.Delete(ctx, &pod); err != nil {
+			if !errors.IsNotFound(err) {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(failedEvent),
+					"Failed deleting outdated Pod %s/%s of group %s, %v", pod.Namespace, pod.Name, currentGroup, err)
+				return errstd.Join(failedErr, err)
+			}
+			logger.Info("reconcileUpgrade", "The outdated Pod has already been deleted", pod.Name)
+			continue
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(deletedEvent),
+			"Deleted outdated Pod %s/%s of group %s running image %s", pod.Namespace, pod.Name, currentGroup,
+			pod.Spec.Containers[utils.RayContainerIndex].Image)
+	}
 	return nil
 }
 
+// getOutdatedPodsByGroup returns the groups that have Pods running an outdated Ray container image, in upgrade order,
+// and the outdated Pods of each group. The head group is only considered if upgradeHead is true, and always comes last.
+func getOutdatedPodsByGroup(instance *rayv1.RayCluster, pods []corev1.Pod, upgradeHead bool) ([]string, map[string][]corev1.Pod) {
+	var groups []string
+	images := make(map[string]string)
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		groups = append(groups, worker.GroupName)
+		images[worker.GroupName] = worker.Template.Spec.Containers[utils.RayContainerIndex].Image
+	}
+	if upgradeHead {
+		groups = append(groups, utils.RayNodeHeadGroupLabelValue)
+		images[utils.RayNodeHeadGroupLabelValue] = instance.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Image
+	}
+
+	outdatedPods := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		group := pod.Labels[utils.RayNodeGroupLabelKey]
+		if pod.Labels[utils.RayNodeTypeLabelKey] == string(rayv1.HeadNode) {
+			group = utils.RayNodeHeadGroupLabelValue
+		}
+		image, ok := images[group]
+		if !ok || pod.DeletionTimestamp != nil || len(pod.Spec.Containers) <= utils.RayContainerIndex {
+			continue
+		}
+		if pod.Spec.Containers[utils.RayContainerIndex].Image != image {
+			outdatedPods[group] = append(outdatedPods[group], pod)
+		}
+	}
+
+	var pendingGroups []string
+	for _, group := range groups {
+		if len(outdatedPods[group]) > 0 {
+			pendingGroups = append(pendingGroups, group)
+		}
+	}
+	return pendingGroups, outdatedPods
+}
+
+// getRolloutBounds returns the maximum numbers of unavailable Pods and of surge Pods of a worker group during a
+// rollout, out of its desired Pods.
+func getRolloutBounds(strategy *rayv1.WorkerGroupRolloutStrategy, desiredPods int) (int, int, error) {
+	maxUnavailable, maxSurge := 1, 0
+	var err error
+	if strategy.MaxUnavailable != nil {
+		if maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(strategy.MaxUnavailable, desiredPods, false); err != nil {
+			return 0, 0, fmt.Errorf("invalid maxUnavailable: %w", err)
+		}
+	}
+	if strategy.MaxSurge != nil {
+		if maxSurge, err = intstr.GetScaledValueFromIntOrPercent(strategy.MaxSurge, desiredPods, true); err != nil {
+			return 0, 0, fmt.Errorf("invalid maxSurge: %w", err)
+		}
+	}
+	maxUnavailable, maxSurge = max(maxUnavailable, 0), max(maxSurge, 0)
+	// Like a Deployment, a rollout without surge replaces at least one Pod at a time.
+	if maxUnavailable == 0 && maxSurge == 0 {
+		maxUnavailable = 1
+	}
+	return maxUnavailable, maxSurge, nil
+}
+
+// getRolloutSurge returns the number of Pods created above the desired Pods of a worker group while it is upgraded
+// with a rollout strategy: the surge of the strategy, down to the number of outdated Pods left. The terminating
+// outdated Pods count, so that the surge only shrinks once they are gone.
+func getRolloutSurge(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec, pods []corev1.Pod, desiredPods int) int {
+	if worker.RolloutStrategy == nil || instance.Status.UpgradeStatus == nil || instance.Status.UpgradeStatus.CurrentGroup != worker.GroupName {
+		return 0
+	}
+	_, maxSurge, err := getRolloutBounds(worker.RolloutStrategy, desiredPods)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Info("getRolloutSurge", "Ignoring the surge of worker group", worker.GroupName, "error", err)
+		return 0
+	}
+	image := worker.Template.Spec.Containers[utils.RayContainerIndex].Image
+	numOutdatedPods := 0
+	for _, pod := range pods {
+		if len(pod.Spec.Containers) > utils.RayContainerIndex && pod.Spec.Containers[utils.RayContainerIndex].Image != image {
+			numOutdatedPods++
+		}
+	}
+	return min(maxSurge, numOutdatedPods)
+}
+
+// getRolloutPodsToDelete returns the outdated Pods of a worker group which can be deleted without exceeding the
+// maxUnavailable of its rollout strategy. The outdated Pods which are not available are deleted first, since they
+// do not reduce the capacity of the worker group.
+func getRolloutPodsToDelete(ctx context.Context, worker rayv1.WorkerGroupSpec, pods []corev1.Pod, outdatedPods []corev1.Pod) ([]corev1.Pod, error) {
+	numGroupPods, numAvailablePods := 0, 0
+	for _, pod := range pods {
+		if pod.Labels[utils.RayNodeGroupLabelKey] != worker.GroupName || pod.Labels[utils.RayNodeTypeLabelKey] == string(rayv1.HeadNode) ||
+			pod.DeletionTimestamp != nil {
+			continue
+		}
+		numGroupPods++
+		if utils.IsRunningAndReady(&pod) {
+			numAvailablePods++
+		}
+	}
+	// The replicas of a node fill worker group depend on its Nodes, which are not known here, so its current Pods
+	// are its desired Pods.
+	desiredPods := numGroupPods
+	if worker.NodeFill == nil {
+		numOfHosts := max(worker.NumOfHosts, 1)
+		desiredPods = int(utils.GetWorkerGroupDesiredReplicas(ctx, utils.ApplyScalingSchedule(ctx, worker, time.Now())) * numOfHosts)
+	}
+	maxUnavailable, _, err := getRolloutBounds(worker.RolloutStrategy, desiredPods)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rollout strategy of worker group %s: %w", worker.GroupName, err)
+	}
+
+	var podsToDelete []corev1.Pod
+	for _, pod := range outdatedPods {
+		if !utils.IsRunningAndReady(&pod) {
+			podsToDelete = append(podsToDelete, pod)
+		}
+	}
+	budget := numAvailablePods - (desiredPods - maxUnavailable)
+	for _, pod := range outdatedPods {
+		if budget <= 0 {
+			break
+		}
+		if utils.IsRunningAndReady(&pod) {
+			podsToDelete = append(podsToDelete, pod)
+			budget--
+		}
+	}
+	return podsToDelete, nil
+}
+
+// isRayClusterSettled returns whether no Pod is terminating, all desired Pods exist, and all of them are running and ready.
+func isRayClusterSettled(ctx context.Context, instance *rayv1.RayCluster, pods corev1.PodList) bool {
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			return false
+		}
+	}
+	numExpectedPods := 1
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		// The replicas of a node fill worker group depend on its Nodes, which are not known here, so every Pod of the
+		// worker group running is enough.
+		if worker.NodeFill != nil {
+			continue
+		}
+		numOfHosts := worker.NumOfHosts
+		if numOfHosts <= 0 {
+			numOfHosts = 1
+		}
+		numExpectedPods += int(utils.GetWorkerGroupDesiredReplicas(ctx, utils.ApplyScalingSchedule(ctx, worker, time.Now())) * numOfHosts)
+	}
+	return len(pods.Items) >= numExpectedPods && utils.CheckAllPodsRunning(ctx, pods)
+}
+
+// getPodsWithDetachedWorkloads queries the Ray state API of the head Pod for detached actors and placement group bundles,
+// and returns a description of them keyed by the name of the worker Pod hosting them. Errors are logged and result in an
+// empty map, so that scale-down is not blocked by an unreachable head Pod.
+func (r *RayClusterReconciler) getPodsWithDetachedWorkloads(ctx context.Context, instance *rayv1.RayCluster, pods []corev1.Pod) map[string]string {
+	logger := ctrl.LoggerFrom(ctx)
+	detachedWorkloads := make(map[string]string)
+
+	url, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		logger.Info("Failed to get the dashboard URL; deleting worker Pods without checking detached workloads", "error", err)
+		return detachedWorkloads
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, url, instance); err != nil {
+		logger.Info("Failed to initialize the dashboard client; deleting worker Pods without checking detached workloads", "error", err)
+		return detachedWorkloads
+	}
+	nodes, err := rayDashboardClient.ListNodes(ctx)
+	if err != nil {
+		logger.Info("Failed to list Ray nodes; deleting worker Pods without checking detached workloads", "error", err)
+		return detachedWorkloads
+	}
+	actors, err := rayDashboardClient.ListActors(ctx)
+	if err != nil {
+		logger.Info("Failed to list Ray actors; deleting worker Pods without checking detached workloads", "error", err)
+		return detachedWorkloads
+	}
+	placementGroups, err := rayDashboardClient.ListPlacementGroups(ctx)
+	if err != nil {
+		logger.Info("Failed to list Ray placement groups; deleting worker Pods without checking detached workloads", "error", err)
+		return detachedWorkloads
+	}
+
+	nodeIPs := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		nodeIPs[node.NodeID] = node.NodeIP
+	}
+	workloadsByIP := make(map[string][]string)
+	for _, actor := range actors {
+		if !actor.IsDetached {
+			continue
+		}
+		name := actor.Name
+		if name == "" {
+			name = actor.ActorID
+		}
+		ip := nodeIPs[actor.NodeID]
+		workloadsByIP[ip] = append(workloadsByIP[ip], "detached actor "+name)
+	}
+	for _, placementGroup := range placementGroups {
+		name := placementGroup.Name
+		if name == "" {
+			name = placementGroup.PlacementGroupID
+		}
+		seen := make(map[string]bool)
+		for _, bundle := range placementGroup.Bundles {
+			ip := nodeIPs[bundle.NodeID]
+			if seen[ip] {
+				continue
+			}
+			seen[ip] = true
+			workloadsByIP[ip] = append(workloadsByIP[ip], "placement group "+name)
+		}
+	}
+
+	for _, pod := range pods {
+		if pod.Status.PodIP == "" {
+			continue
+		}
+		if workloads, ok := workloadsByIP[pod.Status.PodIP]; ok {
+			detachedWorkloads[pod.Name] = strings.Join(workloads, ", ")
+		}
+	}
+	return detachedWorkloads
+}
+
+// sortPodsByFillableNodes moves the Pods which are not on a fillable Node of a node fill worker group, e.g. on a
+// Node which was cordoned or removed from the pool, to the front, so that they are deleted first.
+func sortPodsByFillableNodes(pods []corev1.Pod, fillableNodes map[string]bool) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return !fillableNodes[pods[i].Spec.NodeName] && fillableNodes[pods[j].Spec.NodeName]
+	})
+}
+
+// listNodeFillNodes lists the Nodes matching the selector of a node fill worker group. Nodes are read without the
+// cache, so that the operator does not have to watch all of them; the periodic requeue picks up Node changes.
+func (r *RayClusterReconciler) listNodeFillNodes(ctx context.Context, policy *rayv1.NodeFillPolicy) ([]corev1.Node, error) {
+	reader := r.apiReader
+	if reader == nil {
+		reader = r.Client
+	}
+	nodes := corev1.NodeList{}
+	if err := reader.List(ctx, &nodes, client.MatchingLabels(policy.NodeSelector)); err != nil {
+		return nil, fmt.Errorf("failed to list the Nodes matching %v: %w", policy.NodeSelector, err)
+	}
+	return nodes.Items, nil
+}
+
+// sortPodsByDetachedWorkloads moves the Pods hosting detached workloads to the end of the slice, so that they are
+// deleted last on scale-down. The relative order of the other Pods is kept.
+func sortPodsByDetachedWorkloads(pods []corev1.Pod, detachedWorkloads map[string]string) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		_, iHosts := detachedWorkloads[pods[i].Name]
+		_, jHosts := detachedWorkloads[pods[j].Name]
+		return !iHosts && jHosts
+	})
+}
+
 // shouldDeletePod returns whether the Pod should be deleted and the reason
 //
 // @param pod: The Pod to be checked.
@@ -951,7 +1835,7 @@ func (r *RayClusterReconciler) createService(ctx context.Context, svc *corev1.Se
 	logger := ctrl.LoggerFrom(ctx)
 
 	// making sure the name is valid
-	svc.Name = utils.CheckName(svc.Name)
+	svc.Name = utils.CheckServiceName(svc.Name)
 	if err := controllerutil.SetControllerReference(instance, svc, r.Scheme); err != nil {
 		return err
 	}
@@ -970,12 +1854,13 @@ func (r *RayClusterReconciler) createHeadPod(ctx context.Context, instance rayv1
 
 	// build the pod then create it
 	pod := r.buildHeadPod(ctx, instance)
-	// check if the batch scheduler integration is enabled
-	// call the scheduler plugin if so
-	if r.BatchSchedulerMgr != nil {
-		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
-			scheduler.AddMetadataToPod(&instance, utils.RayNodeHeadGroupLabelValue, &pod)
-		} else {
+	if err := common.ApplyPodTemplatePatch(&pod, instance.Spec.HeadGroupSpec.PodTemplatePatch); err != nil {
+		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateHeadPod), "Failed to create head Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+		return err
+	}
+	// call the pod mutation plugins, e.g. to add the batch scheduler metadata
+	if r.PluginMgr != nil {
+		if err := r.PluginMgr.BeforePodCreate(ctx, &instance, utils.RayNodeHeadGroupLabelValue, &pod); err != nil {
 			return err
 		}
 	}
@@ -994,10 +1879,12 @@ func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance ray
 
 	// build the pod then create it
 	pod := r.buildWorkerPod(ctx, instance, worker)
-	if r.BatchSchedulerMgr != nil {
-		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(&instance); err == nil {
-			scheduler.AddMetadataToPod(&instance, worker.GroupName, &pod)
-		} else {
+	if err := common.ApplyPodTemplatePatch(&pod, worker.PodTemplatePatch); err != nil {
+		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateWorkerPod), "Failed to create worker Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+		return err
+	}
+	if r.PluginMgr != nil {
+		if err := r.PluginMgr.BeforePodCreate(ctx, &instance, worker.GroupName, &pod); err != nil {
 			return err
 		}
 	}
@@ -1147,15 +2034,17 @@ func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 			predicate.AnnotationChangedPredicate{},
 		))).
 		Owns(&corev1.Pod{}).
-		Owns(&corev1.Service{})
+		Owns(&corev1.Service{}).
+		Owns(&appsv1.DaemonSet{})
 
-	if r.BatchSchedulerMgr != nil {
-		r.BatchSchedulerMgr.ConfigureReconciler(b)
+	if r.PluginMgr != nil {
+		b = r.PluginMgr.ConfigureReconciler(b)
 	}
 
 	return b.
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
+			RateLimiter:             utils.ErrorRateLimiter(),
 			LogConstructor: func(request *reconcile.Request) logr.Logger {
 				logger := ctrl.Log.WithName("controllers").WithName("RayCluster")
 				if request != nil {
@@ -1237,6 +2126,30 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 			meta.SetStatusCondition(&newInstance.Status.Conditions, headPodReadyCondition)
 		}
 
+		if common.IsGCSFaultToleranceEnabled(*newInstance) {
+			r.setRecoveringCondition(ctx, newInstance, headPod, runtimePods)
+		}
+
+		if err := r.setAdmittedCondition(ctx, newInstance, runtimePods.Items); err != nil {
+			return nil, err
+		}
+
+		if newInstance.Status.UpgradeStatus != nil {
+			meta.SetStatusCondition(&newInstance.Status.Conditions, metav1.Condition{
+				Type:    string(rayv1.RayClusterUpgrading),
+				Status:  metav1.ConditionTrue,
+				Reason:  rayv1.RayClusterUpgradeInProgress,
+				Message: fmt.Sprintf("Upgrading group %s", newInstance.Status.UpgradeStatus.CurrentGroup),
+			})
+		} else if meta.FindStatusCondition(newInstance.Status.Conditions, string(rayv1.RayClusterUpgrading)) != nil {
+			meta.SetStatusCondition(&newInstance.Status.Conditions, metav1.Condition{
+				Type:    string(rayv1.RayClusterUpgrading),
+				Status:  metav1.ConditionFalse,
+				Reason:  rayv1.RayClusterUpgradeCompleted,
+				Message: "All Ray Pods run the desired image",
+			})
+		}
+
 		if !meta.IsStatusConditionTrue(newInstance.Status.Conditions, string(rayv1.RayClusterProvisioned)) {
 			// RayClusterProvisioned indicates whether all Ray Pods are ready when the RayCluster is first created.
 			// Note RayClusterProvisioned StatusCondition will not be updated after all Ray Pods are ready for the first time.
@@ -1273,6 +2186,7 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 
 	timeNow := metav1.Now()
 	newInstance.Status.LastUpdateTime = &timeNow
+	recordUsageSnapshot(newInstance, runtimePods.Items, timeNow)
 
 	if instance.Status.State != newInstance.Status.State { //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
 		if newInstance.Status.StateTransitionTimes == nil {
@@ -1284,6 +2198,134 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 	return newInstance, nil
 }
 
+// setAdmittedCondition reflects the admission of the RayCluster by a batch scheduler, so that users can tell
+// a RayCluster waiting for quota from a broken one. Kueue admits a RayCluster by unsuspending it, while the
+// other batch schedulers report the admission through the plugins.
+func (r *RayClusterReconciler) setAdmittedCondition(ctx context.Context, instance *rayv1.RayCluster, runtimePods []corev1.Pod) error {
+	var admission *plugins.AdmissionStatus
+	if queue, ok := instance.Labels[utils.KueueQueueNameLabelKey]; ok {
+		admission = &plugins.AdmissionStatus{
+			Scheduler: "kueue",
+			QueueName: queue,
+			Admitted:  instance.Spec.Suspend == nil || !*instance.Spec.Suspend,
+		}
+		if !admission.Admitted {
+			admission.Message = "the RayCluster is suspended until Kueue reserves quota for it"
+		}
+	} else if r.PluginMgr != nil {
+		var err error
+		if admission, err = r.PluginMgr.GetAdmissionStatus(ctx, instance, runtimePods); err != nil {
+			return err
+		}
+	}
+
+	if admission == nil {
+		instance.Status.QueueName = ""
+		meta.RemoveStatusCondition(&instance.Status.Conditions, string(rayv1.RayClusterAdmitted))
+		return nil
+	}
+
+	instance.Status.QueueName = admission.QueueName
+	target := admission.Scheduler
+	if admission.QueueName != "" {
+		target = fmt.Sprintf("queue %q of %s", admission.QueueName, admission.Scheduler)
+	}
+	cond := metav1.Condition{
+		Type:    string(rayv1.RayClusterAdmitted),
+		Status:  metav1.ConditionTrue,
+		Reason:  rayv1.RayClusterAdmittedByScheduler,
+		Message: "Admitted to " + target,
+	}
+	if !admission.Admitted {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = rayv1.RayClusterWaitingForAdmission
+		cond.Message = fmt.Sprintf("Waiting for admission to %s: %s", target, admission.Message)
+	}
+	meta.SetStatusCondition(&instance.Status.Conditions, cond)
+	return nil
+}
+
+// setRecoveringCondition tracks the recovery of a provisioned RayCluster with GCS fault tolerance from a head Pod failure.
+// The recovery starts when the head Pod is gone or not ready, and completes once the head Pod and all worker Pods are
+// running and ready again, and all the worker Pods are registered as alive nodes with the restored GCS.
+func (r *RayClusterReconciler) setRecoveringCondition(ctx context.Context, instance *rayv1.RayCluster, headPod *corev1.Pod, runtimePods corev1.PodList) {
+	logger := ctrl.LoggerFrom(ctx)
+	headPodReady := headPod != nil && headPod.DeletionTimestamp == nil && utils.IsRunningAndReady(headPod)
+	recovering := meta.IsStatusConditionTrue(instance.Status.Conditions, string(rayv1.RayClusterRecovering))
+	if !recovering && (headPodReady || !meta.IsStatusConditionTrue(instance.Status.Conditions, string(rayv1.RayClusterProvisioned))) {
+		return
+	}
+
+	cond := metav1.Condition{
+		Type:   string(rayv1.RayClusterRecovering),
+		Status: metav1.ConditionTrue,
+	}
+	switch {
+	case headPod == nil || headPod.DeletionTimestamp != nil || headPod.Status.Phase != corev1.PodRunning:
+		cond.Reason = rayv1.RecoveryWaitingForHeadPod
+		cond.Message = "Waiting for the head Pod to be restarted"
+	case !headPodReady:
+		cond.Reason = rayv1.RecoveryWaitingForGCS
+		cond.Message = "Waiting for the GCS to restore the cluster state from Redis"
+	case !utils.CheckAllPodsRunning(ctx, runtimePods):
+		cond.Reason = rayv1.RecoveryWaitingForWorkers
+		cond.Message = "Waiting for the worker Pods to be running and ready"
+	default:
+		if unregistered, err := r.getUnregisteredWorkerPods(ctx, instance, runtimePods.Items); err != nil {
+			cond.Reason = rayv1.RecoveryWaitingForWorkers
+			cond.Message = fmt.Sprintf("Failed to list the nodes registered with the restored GCS: %v", err)
+		} else if len(unregistered) > 0 {
+			cond.Reason = rayv1.RecoveryWaitingForWorkers
+			cond.Message = fmt.Sprintf("Waiting for the worker Pods %s to register with the restored GCS", strings.Join(unregistered, ", "))
+		} else {
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = rayv1.RecoveryCompleted
+			cond.Message = "The head Pod and all worker Pods are ready and registered with the GCS"
+		}
+	}
+	if !recovering {
+		logger.Info("setRecoveringCondition", "The head Pod failed; recovering the RayCluster", instance.Name)
+	} else if cond.Status == metav1.ConditionFalse {
+		logger.Info("setRecoveringCondition", "Recovered the RayCluster from the head Pod failure", instance.Name)
+	}
+	meta.SetStatusCondition(&instance.Status.Conditions, cond)
+}
+
+// getUnregisteredWorkerPods queries the Ray state API of the head Pod for the alive nodes registered with the GCS,
+// and returns the sorted names of the worker Pods whose IP is not one of them.
+func (r *RayClusterReconciler) getUnregisteredWorkerPods(ctx context.Context, instance *rayv1.RayCluster, pods []corev1.Pod) ([]string, error) {
+	url, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return nil, err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, url, instance); err != nil {
+		return nil, err
+	}
+	nodes, err := rayDashboardClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aliveIPs := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		if node.State == "ALIVE" {
+			aliveIPs[node.NodeIP] = true
+		}
+	}
+	var unregistered []string
+	for _, pod := range pods {
+		if pod.Labels[utils.RayNodeTypeLabelKey] != string(rayv1.WorkerNode) || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if !aliveIPs[pod.Status.PodIP] {
+			unregistered = append(unregistered, pod.Name)
+		}
+	}
+	sort.Strings(unregistered)
+	return unregistered, nil
+}
+
 func (r *RayClusterReconciler) getHeadServiceIPAndName(ctx context.Context, instance *rayv1.RayCluster) (string, string, error) {
 	runtimeServices := corev1.ServiceList{}
 	if err := r.List(ctx, &runtimeServices, common.RayClusterHeadServiceListOptions(instance)...); err != nil {
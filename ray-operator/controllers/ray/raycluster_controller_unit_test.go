@@ -25,15 +25,19 @@ import (
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/plugins"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/scheme"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -1669,6 +1673,11 @@ func TestInconsistentRayClusterStatus(t *testing.T) {
 	newStatus = oldStatus.DeepCopy()
 	meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{Type: string(rayv1.RayClusterReplicaFailure), Status: metav1.ConditionTrue})
 	assert.True(t, r.inconsistentRayClusterStatus(ctx, oldStatus, *newStatus))
+
+	// Case 13: `UsageSnapshots` is different => return true
+	newStatus = oldStatus.DeepCopy()
+	newStatus.UsageSnapshots = []rayv1.RayClusterUsageSnapshot{{Time: timeNow, ReadyWorkerReplicas: 1}}
+	assert.True(t, r.inconsistentRayClusterStatus(ctx, oldStatus, *newStatus))
 }
 
 func TestCalculateStatus(t *testing.T) {
@@ -1863,6 +1872,190 @@ func TestRayClusterProvisionedCondition(t *testing.T) {
 	assert.Equal(t, rayClusterProvisionedCondition.Reason, rayv1.AllPodRunningAndReadyFirstTime)
 }
 
+type admissionTestPlugin struct {
+	status *plugins.AdmissionStatus
+}
+
+func (p *admissionTestPlugin) Name() string {
+	return "admission-test"
+}
+
+func (p *admissionTestPlugin) AfterClusterCreate(_ context.Context, _ *rayv1.RayCluster) error {
+	return nil
+}
+
+func (p *admissionTestPlugin) BeforePodCreate(_ context.Context, _ *rayv1.RayCluster, _ string, _ *corev1.Pod) error {
+	return nil
+}
+
+func (p *admissionTestPlugin) GetAdmissionStatus(_ context.Context, _ *rayv1.RayCluster, _ []corev1.Pod) (*plugins.AdmissionStatus, error) {
+	return p.status, nil
+}
+
+func TestSetAdmittedCondition(t *testing.T) {
+	ctx := context.Background()
+	plugin := &admissionTestPlugin{}
+	pluginMgr, err := plugins.NewManager(nil, nil, plugin)
+	require.NoError(t, err)
+	r := &RayClusterReconciler{PluginMgr: pluginMgr}
+
+	// RayClusters which are not submitted to a batch scheduler have no Admitted condition.
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	require.NoError(t, r.setAdmittedCondition(ctx, cluster, nil))
+	assert.Nil(t, meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterAdmitted)))
+
+	plugin.status = &plugins.AdmissionStatus{Scheduler: "volcano", QueueName: "team-a", Message: "queue resource quota insufficient"}
+	require.NoError(t, r.setAdmittedCondition(ctx, cluster, nil))
+	cond := meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterAdmitted))
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, rayv1.RayClusterWaitingForAdmission, cond.Reason)
+	assert.Equal(t, `Waiting for admission to queue "team-a" of volcano: queue resource quota insufficient`, cond.Message)
+	assert.Equal(t, "team-a", cluster.Status.QueueName)
+
+	plugin.status = &plugins.AdmissionStatus{Scheduler: "volcano", QueueName: "team-a", Admitted: true}
+	require.NoError(t, r.setAdmittedCondition(ctx, cluster, nil))
+	assert.True(t, meta.IsStatusConditionTrue(cluster.Status.Conditions, string(rayv1.RayClusterAdmitted)))
+
+	// Kueue admits a RayCluster by unsuspending it.
+	cluster.Labels = map[string]string{utils.KueueQueueNameLabelKey: "user-queue"}
+	cluster.Spec.Suspend = ptr.To(true)
+	require.NoError(t, r.setAdmittedCondition(ctx, cluster, nil))
+	cond = meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterAdmitted))
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "user-queue", cluster.Status.QueueName)
+
+	cluster.Spec.Suspend = ptr.To(false)
+	require.NoError(t, r.setAdmittedCondition(ctx, cluster, nil))
+	cond = meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterAdmitted))
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, rayv1.RayClusterAdmittedByScheduler, cond.Reason)
+	assert.Equal(t, `Admitted to queue "user-queue" of kueue`, cond.Message)
+}
+
+func TestRayClusterRecoveringCondition(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayClusterStatusConditions, true)()
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	readyStatus := corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+	}
+	unReadyStatus := corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionFalse,
+			},
+		},
+	}
+
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "headNode",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  instanceName,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+		Status: readyStatus,
+	}
+	workerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workerNode",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   instanceName,
+				utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+				utils.RayNodeGroupLabelKey: groupNameStr,
+			},
+		},
+		Status: readyStatus,
+	}
+	workerPod.Status.PodIP = "10.0.0.2"
+
+	runtimeObjects := append([]runtime.Object{headPod, workerPod}, testServices...)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	ctx := context.Background()
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	r := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface {
+			return fakeDashboardClient
+		},
+	}
+	testRayCluster.Annotations = map[string]string{utils.RayFTEnabledAnnotationKey: "true"}
+
+	// All Ray Pods are ready, so the RayCluster is provisioned and not recovering.
+	testRayCluster, _ = r.calculateStatus(ctx, testRayCluster, nil)
+	assert.True(t, meta.IsStatusConditionTrue(testRayCluster.Status.Conditions, string(rayv1.RayClusterProvisioned)))
+	assert.Nil(t, meta.FindStatusCondition(testRayCluster.Status.Conditions, string(rayv1.RayClusterRecovering)))
+
+	// The head Pod is gone. Worker Pods are not reconciled until the head Pod recovers.
+	err := fakeClient.Delete(ctx, headPod)
+	assert.Nil(t, err)
+	testRayCluster, _ = r.calculateStatus(ctx, testRayCluster, nil)
+	cond := meta.FindStatusCondition(testRayCluster.Status.Conditions, string(rayv1.RayClusterRecovering))
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, rayv1.RecoveryWaitingForHeadPod, cond.Reason)
+	assert.True(t, isWaitingForHeadRecovery(testRayCluster))
+
+	// The head Pod is restarted, but the GCS has not restored the cluster state yet.
+	headPod.ResourceVersion = ""
+	headPod.Status = unReadyStatus
+	err = fakeClient.Create(ctx, headPod)
+	assert.Nil(t, err)
+	workerPod.Status = unReadyStatus
+	_ = fakeClient.Status().Update(ctx, workerPod)
+	testRayCluster, _ = r.calculateStatus(ctx, testRayCluster, nil)
+	cond = meta.FindStatusCondition(testRayCluster.Status.Conditions, string(rayv1.RayClusterRecovering))
+	assert.Equal(t, rayv1.RecoveryWaitingForGCS, cond.Reason)
+	assert.True(t, isWaitingForHeadRecovery(testRayCluster))
+
+	// The head Pod is ready, and the worker Pods are registering with the restored GCS.
+	headPod.Status = readyStatus
+	_ = fakeClient.Status().Update(ctx, headPod)
+	testRayCluster, _ = r.calculateStatus(ctx, testRayCluster, nil)
+	cond = meta.FindStatusCondition(testRayCluster.Status.Conditions, string(rayv1.RayClusterRecovering))
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, rayv1.RecoveryWaitingForWorkers, cond.Reason)
+	assert.False(t, isWaitingForHeadRecovery(testRayCluster))
+
+	// All Ray Pods are ready again, but the worker Pod is not registered with the restored GCS yet.
+	workerPod.Status = readyStatus
+	workerPod.Status.PodIP = "10.0.0.2"
+	_ = fakeClient.Status().Update(ctx, workerPod)
+	fakeDashboardClient.SetStateAPIResults([]utils.RayNodeState{{NodeID: "head", NodeIP: "10.0.0.1", State: "ALIVE", IsHeadNode: true}}, nil, nil)
+	testRayCluster, _ = r.calculateStatus(ctx, testRayCluster, nil)
+	cond = meta.FindStatusCondition(testRayCluster.Status.Conditions, string(rayv1.RayClusterRecovering))
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, rayv1.RecoveryWaitingForWorkers, cond.Reason)
+	assert.Contains(t, cond.Message, "workerNode")
+
+	// The worker Pod is registered as an alive node.
+	fakeDashboardClient.SetStateAPIResults([]utils.RayNodeState{
+		{NodeID: "head", NodeIP: "10.0.0.1", State: "ALIVE", IsHeadNode: true},
+		{NodeID: "worker", NodeIP: "10.0.0.2", State: "ALIVE"},
+	}, nil, nil)
+	testRayCluster, _ = r.calculateStatus(ctx, testRayCluster, nil)
+	cond = meta.FindStatusCondition(testRayCluster.Status.Conditions, string(rayv1.RayClusterRecovering))
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, rayv1.RecoveryCompleted, cond.Reason)
+}
+
 func TestStateTransitionTimes_NoStateChange(t *testing.T) {
 	setupTest(t)
 
@@ -3139,3 +3332,776 @@ func TestEvents_FailedPodCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileUpgrade(t *testing.T) {
+	setupTest(t)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+
+	// Without an upgrade strategy, Pods running an outdated image are left alone.
+	err := testRayClusterReconciler.reconcileUpgrade(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Nil(t, testRayCluster.Status.UpgradeStatus)
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err)
+	assert.Equal(t, len(testPods), len(podList.Items))
+
+	// pod1, pod2, and pod3 run "rayproject/autoscaler" while the worker group template uses "rayproject/ray:2.9.0".
+	testRayCluster.Annotations = map[string]string{utils.RayFTEnabledAnnotationKey: "true"}
+	testRayCluster.Spec.UpgradeStrategy = &rayv1.RayClusterUpgradeStrategy{
+		Type:        ptr.To(rayv1.GroupByGroupUpgrade),
+		UpgradeHead: ptr.To(true),
+	}
+	err = testRayClusterReconciler.reconcileUpgrade(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.NotNil(t, testRayCluster.Status.UpgradeStatus)
+	assert.Equal(t, groupNameStr, testRayCluster.Status.UpgradeStatus.CurrentGroup)
+	assert.Equal(t, []string{utils.RayNodeHeadGroupLabelValue}, testRayCluster.Status.UpgradeStatus.PendingGroups)
+
+	err = fakeClient.List(ctx, &podList, &client.ListOptions{
+		LabelSelector: workerSelector,
+		Namespace:     namespaceStr,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(podList.Items))
+	for _, pod := range podList.Items {
+		assert.Equal(t, "rayproject/ray:2.9.0", pod.Spec.Containers[utils.RayContainerIndex].Image)
+	}
+
+	// The head Pod is not recreated until the worker group has all of its desired Pods again.
+	err = testRayClusterReconciler.reconcileUpgrade(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Equal(t, utils.RayNodeHeadGroupLabelValue, testRayCluster.Status.UpgradeStatus.CurrentGroup)
+	assert.Empty(t, testRayCluster.Status.UpgradeStatus.PendingGroups)
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr), client.MatchingLabels{utils.RayNodeTypeLabelKey: string(rayv1.HeadNode)})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(podList.Items))
+
+	testRayCluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](2)
+	err = testRayClusterReconciler.reconcileUpgrade(ctx, testRayCluster)
+	assert.Nil(t, err)
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr), client.MatchingLabels{utils.RayNodeTypeLabelKey: string(rayv1.HeadNode)})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(podList.Items))
+
+	// The upgrade is done once no Pod runs an outdated image.
+	err = testRayClusterReconciler.reconcileUpgrade(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Nil(t, testRayCluster.Status.UpgradeStatus)
+}
+
+func TestGetOutdatedPodsByGroup(t *testing.T) {
+	setupTest(t)
+
+	pods := []corev1.Pod{}
+	for _, obj := range testPods {
+		pods = append(pods, *obj.(*corev1.Pod))
+	}
+
+	// The head group is only upgraded if upgradeHead is true.
+	pendingGroups, outdatedPods := getOutdatedPodsByGroup(testRayCluster, pods, false)
+	assert.Equal(t, []string{groupNameStr}, pendingGroups)
+	outdatedPodNames := []string{}
+	for _, pod := range outdatedPods[groupNameStr] {
+		outdatedPodNames = append(outdatedPodNames, pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"pod1", "pod2", "pod3"}, outdatedPodNames)
+
+	pendingGroups, outdatedPods = getOutdatedPodsByGroup(testRayCluster, pods, true)
+	assert.Equal(t, []string{groupNameStr, utils.RayNodeHeadGroupLabelValue}, pendingGroups)
+	assert.Equal(t, 1, len(outdatedPods[utils.RayNodeHeadGroupLabelValue]))
+	assert.Equal(t, headNodeName, outdatedPods[utils.RayNodeHeadGroupLabelValue][0].Name)
+}
+
+func TestGetRolloutBounds(t *testing.T) {
+	tests := []struct {
+		strategy               rayv1.WorkerGroupRolloutStrategy
+		name                   string
+		expectedMaxUnavailable int
+		expectedMaxSurge       int
+	}{
+		{
+			name:                   "The default strategy replaces one Pod at a time",
+			strategy:               rayv1.WorkerGroupRolloutStrategy{},
+			expectedMaxUnavailable: 1,
+		},
+		{
+			name:                   "Percentages are rounded down for maxUnavailable and up for maxSurge",
+			strategy:               rayv1.WorkerGroupRolloutStrategy{MaxUnavailable: ptr.To(intstr.FromString("25%")), MaxSurge: ptr.To(intstr.FromString("25%"))},
+			expectedMaxUnavailable: 2,
+			expectedMaxSurge:       3,
+		},
+		{
+			name:             "A rollout without capacity dip",
+			strategy:         rayv1.WorkerGroupRolloutStrategy{MaxUnavailable: ptr.To(intstr.FromInt32(0)), MaxSurge: ptr.To(intstr.FromInt32(2))},
+			expectedMaxSurge: 2,
+		},
+		{
+			name:                   "A rollout without unavailable and surge Pods still replaces one Pod at a time",
+			strategy:               rayv1.WorkerGroupRolloutStrategy{MaxUnavailable: ptr.To(intstr.FromString("0%"))},
+			expectedMaxUnavailable: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			maxUnavailable, maxSurge, err := getRolloutBounds(&tc.strategy, 10)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMaxUnavailable, maxUnavailable)
+			assert.Equal(t, tc.expectedMaxSurge, maxSurge)
+		})
+	}
+
+	_, _, err := getRolloutBounds(&rayv1.WorkerGroupRolloutStrategy{MaxSurge: ptr.To(intstr.FromString("all"))}, 10)
+	assert.Error(t, err)
+}
+
+func TestRolloutStrategy(t *testing.T) {
+	newWorkerPod := func(name string, image string, ready bool) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{utils.RayNodeGroupLabelKey: "small-group", utils.RayNodeTypeLabelKey: string(rayv1.WorkerNode)},
+			},
+			Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker", Image: image}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+	worker := rayv1.WorkerGroupSpec{
+		GroupName:       "small-group",
+		Replicas:        ptr.To[int32](4),
+		MinReplicas:     ptr.To[int32](0),
+		MaxReplicas:     ptr.To[int32](10),
+		Template:        corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker", Image: "rayproject/ray:2.10.0"}}}},
+		RolloutStrategy: &rayv1.WorkerGroupRolloutStrategy{MaxUnavailable: ptr.To(intstr.FromInt32(0)), MaxSurge: ptr.To(intstr.FromInt32(1))},
+	}
+	instance := &rayv1.RayCluster{
+		Spec:   rayv1.RayClusterSpec{WorkerGroupSpecs: []rayv1.WorkerGroupSpec{worker}},
+		Status: rayv1.RayClusterStatus{UpgradeStatus: &rayv1.RayClusterUpgradeStatus{CurrentGroup: "small-group"}},
+	}
+	pods := []corev1.Pod{
+		newWorkerPod("outdated-1", "rayproject/ray:2.9.0", true),
+		newWorkerPod("outdated-2", "rayproject/ray:2.9.0", true),
+		newWorkerPod("outdated-3", "rayproject/ray:2.9.0", true),
+		newWorkerPod("upgraded", "rayproject/ray:2.10.0", true),
+	}
+	ctx := context.Background()
+
+	// A surge Pod is created before any outdated Pod is deleted.
+	assert.Equal(t, 1, getRolloutSurge(ctx, instance, worker, pods, 4))
+	podsToDelete, err := getRolloutPodsToDelete(ctx, worker, pods, pods[:3])
+	require.NoError(t, err)
+	assert.Empty(t, podsToDelete)
+
+	// Once the surge Pod is ready, one outdated Pod can be deleted.
+	pods = append(pods, newWorkerPod("surge", "rayproject/ray:2.10.0", true))
+	podsToDelete, err = getRolloutPodsToDelete(ctx, worker, pods, pods[:3])
+	require.NoError(t, err)
+	require.Len(t, podsToDelete, 1)
+	assert.Equal(t, "outdated-1", podsToDelete[0].Name)
+
+	// The outdated Pods which are not ready do not count towards maxUnavailable.
+	pods[1] = newWorkerPod("outdated-2", "rayproject/ray:2.9.0", false)
+	podsToDelete, err = getRolloutPodsToDelete(ctx, worker, pods, pods[:3])
+	require.NoError(t, err)
+	require.Len(t, podsToDelete, 1)
+	assert.Equal(t, "outdated-2", podsToDelete[0].Name)
+
+	// The surge shrinks with the outdated Pods left, and only applies to the worker group being upgraded.
+	assert.Equal(t, 0, getRolloutSurge(ctx, instance, worker, pods[3:], 4))
+	instance.Status.UpgradeStatus.CurrentGroup = "large-group"
+	assert.Equal(t, 0, getRolloutSurge(ctx, instance, worker, pods, 4))
+}
+
+func TestGetPodsWithOutdatedSystemConfig(t *testing.T) {
+	setupTest(t)
+
+	pods := []corev1.Pod{}
+	for _, obj := range testPods {
+		pods = append(pods, *obj.(*corev1.Pod))
+	}
+
+	// The head Pod is recreated first.
+	group, outdatedPods := getPodsWithOutdatedSystemConfig(testRayCluster, pods, "hash")
+	assert.Equal(t, utils.RayNodeHeadGroupLabelValue, group)
+	require.Len(t, outdatedPods, 1)
+	assert.Equal(t, headNodeName, outdatedPods[0].Name)
+
+	// Then the worker groups.
+	pods[0].Annotations = map[string]string{utils.RaySystemConfigHashAnnotationKey: "hash"}
+	group, outdatedPods = getPodsWithOutdatedSystemConfig(testRayCluster, pods, "hash")
+	assert.Equal(t, groupNameStr, group)
+	outdatedPodNames := []string{}
+	for _, pod := range outdatedPods {
+		outdatedPodNames = append(outdatedPodNames, pod.Name)
+	}
+	assert.Contains(t, outdatedPodNames, "pod1")
+	assert.NotContains(t, outdatedPodNames, headNodeName)
+
+	for i := range pods {
+		pods[i].Annotations = map[string]string{utils.RaySystemConfigHashAnnotationKey: "hash"}
+	}
+	group, outdatedPods = getPodsWithOutdatedSystemConfig(testRayCluster, pods, "hash")
+	assert.Empty(t, group)
+	assert.Empty(t, outdatedPods)
+}
+
+func TestReconcileHeadPersistentStorage(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	recorder := record.NewFakeRecorder(10)
+	r := &RayClusterReconciler{
+		Client:   clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(testRayCluster).Build(),
+		Recorder: recorder,
+		Scheme:   newScheme,
+	}
+
+	// Without persistent storage, no PersistentVolumeClaim is created.
+	require.NoError(t, r.reconcileHeadPersistentStorage(ctx, testRayCluster))
+	pvcs := corev1.PersistentVolumeClaimList{}
+	require.NoError(t, r.List(ctx, &pvcs, client.InNamespace(namespaceStr)))
+	assert.Empty(t, pvcs.Items)
+
+	// The PersistentVolumeClaim is created once and owned by the RayCluster.
+	testRayCluster.Spec.HeadGroupSpec.PersistentStorage = &rayv1.RayPersistentStorage{Size: resource.MustParse("10Gi")}
+	require.NoError(t, r.reconcileHeadPersistentStorage(ctx, testRayCluster))
+	require.NoError(t, r.reconcileHeadPersistentStorage(ctx, testRayCluster))
+	require.NoError(t, r.List(ctx, &pvcs, client.InNamespace(namespaceStr)))
+	require.Len(t, pvcs.Items, 1)
+	assert.Equal(t, utils.GenerateHeadPersistentStorageName(testRayCluster.Name), pvcs.Items[0].Name)
+	assert.Equal(t, testRayCluster.Name, pvcs.Items[0].OwnerReferences[0].Name)
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, string(utils.CreatedPersistentVolumeClaim))
+}
+
+func TestReconcileHeadStandby(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	recorder := record.NewFakeRecorder(10)
+	r := &RayClusterReconciler{
+		Client:   clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(testRayCluster).Build(),
+		Recorder: recorder,
+		Scheme:   newScheme,
+	}
+	listStandbyPods := func() []corev1.Pod {
+		pods := corev1.PodList{}
+		require.NoError(t, r.List(ctx, &pods, client.InNamespace(namespaceStr), client.HasLabels{utils.RayClusterHeadStandbyLabelKey}))
+		return pods.Items
+	}
+
+	// Without GCS fault tolerance, no standby Pod is created.
+	testRayCluster.Spec.HeadGroupSpec.Placement = &rayv1.HeadPlacementPolicy{Standby: &rayv1.HeadStandby{}}
+	require.NoError(t, r.reconcileHeadStandby(ctx, testRayCluster))
+	assert.Empty(t, listStandbyPods())
+
+	// The standby Pod is created once and owned by the RayCluster.
+	testRayCluster.Annotations = map[string]string{utils.RayFTEnabledAnnotationKey: "true"}
+	require.NoError(t, r.reconcileHeadStandby(ctx, testRayCluster))
+	require.NoError(t, r.reconcileHeadStandby(ctx, testRayCluster))
+	standbyPods := listStandbyPods()
+	require.Len(t, standbyPods, 1)
+	assert.Equal(t, utils.GenerateHeadStandbyPodName(testRayCluster.Name), standbyPods[0].Name)
+	assert.Equal(t, testRayCluster.Name, standbyPods[0].OwnerReferences[0].Name)
+	assert.Contains(t, <-recorder.Events, string(utils.CreatedHeadStandbyPod))
+
+	// A failed standby Pod is deleted, and recreated in the next reconciliation.
+	standbyPods[0].Status.Phase = corev1.PodFailed
+	require.NoError(t, r.Status().Update(ctx, &standbyPods[0]))
+	require.NoError(t, r.reconcileHeadStandby(ctx, testRayCluster))
+	assert.Empty(t, listStandbyPods())
+	assert.Contains(t, <-recorder.Events, string(utils.DeletedHeadStandbyPod))
+	require.NoError(t, r.reconcileHeadStandby(ctx, testRayCluster))
+	assert.Len(t, listStandbyPods(), 1)
+	assert.Contains(t, <-recorder.Events, string(utils.CreatedHeadStandbyPod))
+
+	// The standby Pod is deleted when the standby is disabled.
+	testRayCluster.Spec.HeadGroupSpec.Placement = nil
+	require.NoError(t, r.reconcileHeadStandby(ctx, testRayCluster))
+	assert.Empty(t, listStandbyPods())
+	assert.Contains(t, <-recorder.Events, string(utils.DeletedHeadStandbyPod))
+}
+
+func TestReconcileSystemConfig(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ray-system-config", Namespace: namespaceStr},
+		Data:       map[string]string{utils.DefaultRaySystemConfigKey: `{"health_check_period_ms": 5000}`},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &RayClusterReconciler{
+		Client:   clientFake.NewClientBuilder().WithRuntimeObjects(configMap).Build(),
+		Recorder: recorder,
+		Scheme:   scheme.Scheme,
+	}
+
+	// Without spec.systemConfig, no hash is recorded.
+	testRayCluster.Status.SystemConfigHash = "stale"
+	require.NoError(t, r.reconcileSystemConfig(ctx, testRayCluster))
+	assert.Empty(t, testRayCluster.Status.SystemConfigHash)
+
+	// The hash changes with the content of the ConfigMap.
+	testRayCluster.Spec.SystemConfig = &rayv1.RaySystemConfigSource{ConfigMapName: configMap.Name}
+	require.NoError(t, r.reconcileSystemConfig(ctx, testRayCluster))
+	hash := testRayCluster.Status.SystemConfigHash
+	assert.NotEmpty(t, hash)
+
+	configMap.Data[utils.DefaultRaySystemConfigKey] = `{"health_check_period_ms": 3000}`
+	require.NoError(t, r.Update(ctx, configMap))
+	require.NoError(t, r.reconcileSystemConfig(ctx, testRayCluster))
+	assert.NotEqual(t, hash, testRayCluster.Status.SystemConfigHash)
+
+	// A missing key or ConfigMap is reported.
+	testRayCluster.Spec.SystemConfig.Key = "missing.json"
+	require.Error(t, r.reconcileSystemConfig(ctx, testRayCluster))
+	assert.Contains(t, <-recorder.Events, string(utils.FailedToGetSystemConfig))
+
+	testRayCluster.Spec.SystemConfig = &rayv1.RaySystemConfigSource{ConfigMapName: "missing"}
+	err := r.reconcileSystemConfig(ctx, testRayCluster)
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Contains(t, <-recorder.Events, string(utils.FailedToGetSystemConfig))
+}
+
+func TestGetPodsWithDetachedWorkloads(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(testServices...).Build()
+	ctx := context.Background()
+
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	fakeDashboardClient.SetStateAPIResults(
+		[]utils.RayNodeState{
+			{NodeID: "node1", NodeIP: "10.0.0.1"},
+			{NodeID: "node2", NodeIP: "10.0.0.2"},
+			{NodeID: "node3", NodeIP: "10.0.0.3"},
+		},
+		[]utils.RayActorState{
+			{ActorID: "actor1", Name: "detached-actor", NodeID: "node1", State: "ALIVE", IsDetached: true},
+			{ActorID: "actor2", NodeID: "node2", State: "ALIVE", IsDetached: false},
+		},
+		[]utils.RayPlacementGroupState{
+			{PlacementGroupID: "pg1", State: "CREATED", Bundles: []utils.RayPlacementGroupBundle{
+				{BundleID: "bundle1", NodeID: "node1"},
+				{BundleID: "bundle2", NodeID: "node1"},
+			}},
+		},
+	)
+	r := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface {
+			return fakeDashboardClient
+		},
+	}
+
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}, Status: corev1.PodStatus{PodIP: "10.0.0.1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod2"}, Status: corev1.PodStatus{PodIP: "10.0.0.2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pod3"}, Status: corev1.PodStatus{PodIP: "10.0.0.3"}},
+	}
+	detachedWorkloads := r.getPodsWithDetachedWorkloads(ctx, testRayCluster, pods)
+	assert.Equal(t, map[string]string{"pod1": "detached actor detached-actor, placement group pg1"}, detachedWorkloads)
+
+	// Pods hosting detached workloads are deleted last.
+	sortPodsByDetachedWorkloads(pods, detachedWorkloads)
+	assert.Equal(t, "pod2", pods[0].Name)
+	assert.Equal(t, "pod3", pods[1].Name)
+	assert.Equal(t, "pod1", pods[2].Name)
+}
+
+func TestReconcile_ResourceQuota(t *testing.T) {
+	setupTest(t)
+
+	// Only the head Pod exists, and the worker group wants 3 replicas with 1 CPU each.
+	testRayCluster.Spec.ResourceQuota = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	testRayCluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("1"),
+	}
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).Build()
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(10)
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Scheme:   scheme.Scheme,
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.Nil(t, err)
+
+	// Only 2 worker Pods fit into the quota.
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, &client.ListOptions{
+		LabelSelector: workerSelector,
+		Namespace:     namespaceStr,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(podList.Items))
+
+	var foundQuotaEvent bool
+	for len(recorder.Events) > 0 {
+		if strings.Contains(<-recorder.Events, string(utils.ResourceQuotaExceeded)) {
+			foundQuotaEvent = true
+		}
+	}
+	assert.True(t, foundQuotaEvent)
+}
+
+func TestReconcile_PodBatching(t *testing.T) {
+	setupTest(t)
+	defer utils.SetPodBatchPolicy(utils.PodBatchPolicy{})
+
+	// Only the head Pod exists, and the worker group wants 3 replicas, 2 per batch.
+	utils.SetPodBatchPolicy(utils.PodBatchPolicy{MaxPodsPerReconcile: 2, BatchInterval: metav1.Duration{Duration: time.Hour}})
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods[0]).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+	countWorkerPods := func() int {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, &client.ListOptions{
+			LabelSelector: workerSelector,
+			Namespace:     namespaceStr,
+		})
+		assert.Nil(t, err)
+		return len(podList.Items)
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, countWorkerPods())
+	status := testRayCluster.Status.PodBatching
+	if assert.NotNil(t, status) {
+		assert.Equal(t, []string{testRayCluster.Spec.WorkerGroupSpecs[0].GroupName}, status.Groups)
+		assert.Equal(t, int32(1), status.PendingCreations)
+		assert.NotNil(t, status.LastBatchTime)
+	}
+	next, ok := nextPodBatchTime(testRayCluster)
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), next, time.Minute)
+
+	// The next batch waits for the batch interval.
+	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, countWorkerPods())
+	assert.Equal(t, int32(1), testRayCluster.Status.PodBatching.PendingCreations)
+
+	lastBatchTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	testRayCluster.Status.PodBatching.LastBatchTime = &lastBatchTime
+	err = testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, countWorkerPods())
+	assert.Nil(t, testRayCluster.Status.PodBatching)
+}
+
+func TestReconcile_RemoteCluster(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
+
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	localClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(testRayCluster).Build()
+	remoteClient := clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   newRemoteClusterClient(localClient, remoteClient),
+		Recorder: record.NewFakeRecorder(10),
+		Scheme:   newScheme,
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	assert.Nil(t, err)
+	err = testRayClusterReconciler.reconcileHeadService(ctx, testRayCluster)
+	assert.Nil(t, err)
+
+	// The Pods and Services are created in the remote cluster without owner references.
+	podList := corev1.PodList{}
+	err = localClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err)
+	assert.Empty(t, podList.Items)
+	err = remoteClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err)
+	assert.NotEmpty(t, podList.Items)
+	for _, pod := range podList.Items {
+		assert.Empty(t, pod.OwnerReferences)
+	}
+	serviceList := corev1.ServiceList{}
+	err = remoteClient.List(ctx, &serviceList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err)
+	assert.Len(t, serviceList.Items, 1)
+
+	// The RayCluster itself is still read from the local cluster.
+	rayCluster := &rayv1.RayCluster{}
+	err = testRayClusterReconciler.Get(ctx, client.ObjectKeyFromObject(testRayCluster), rayCluster)
+	assert.Nil(t, err)
+
+	// The remote resources are deleted with the RayCluster.
+	err = testRayClusterReconciler.deleteRemoteClusterResources(ctx, testRayCluster)
+	assert.Nil(t, err)
+	err = remoteClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err)
+	assert.Empty(t, podList.Items)
+	err = remoteClient.List(ctx, &serviceList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err)
+	assert.Empty(t, serviceList.Items)
+}
+
+func TestDrainRayCluster(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	ctx := context.Background()
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	jobStatus := rayv1.JobStatusRunning
+	getJobInfo := func(_ context.Context, _ string) (*utils.RayJobInfo, error) {
+		return &utils.RayJobInfo{JobStatus: jobStatus}, nil
+	}
+	fakeDashboardClient.GetJobInfoMock.Store(&getJobInfo)
+
+	newDrainingCluster := func(timeoutSeconds int32) *rayv1.RayCluster {
+		cluster := testRayCluster.DeepCopy()
+		cluster.Spec.DrainBeforeDeletion = &rayv1.DrainBeforeDeletionConfig{TimeoutSeconds: ptr.To(timeoutSeconds)}
+		cluster.Finalizers = []string{utils.RayClusterDrainFinalizer}
+		cluster.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		return cluster
+	}
+	newReconciler := func(cluster *rayv1.RayCluster) (*RayClusterReconciler, client.Client) {
+		objects := append([]runtime.Object{cluster, testPods[0]}, testServices...)
+		fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(objects...).WithStatusSubresource(cluster).Build()
+		return &RayClusterReconciler{
+			Client:   fakeClient,
+			Recorder: record.NewFakeRecorder(10),
+			Scheme:   newScheme,
+			dashboardClientFunc: func() utils.RayDashboardClientInterface {
+				return fakeDashboardClient
+			},
+		}, fakeClient
+	}
+
+	// The deletion waits for the running Ray job.
+	r, fakeClient := newReconciler(newDrainingCluster(600))
+	cluster := &rayv1.RayCluster{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(testRayCluster), cluster))
+	result, err := r.drainRayCluster(ctx, cluster)
+	require.NoError(t, err)
+	assert.Positive(t, result.RequeueAfter)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(testRayCluster), cluster))
+	assert.Equal(t, rayv1.Draining, cluster.Status.State) //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+	assert.Contains(t, cluster.Finalizers, utils.RayClusterDrainFinalizer)
+
+	// The RayCluster is deleted once the Ray job finishes.
+	jobStatus = rayv1.JobStatusSucceeded
+	_, err = r.drainRayCluster(ctx, cluster)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(testRayCluster), cluster)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// The RayCluster is deleted after the drain timeout even if a Ray job is still running.
+	jobStatus = rayv1.JobStatusRunning
+	r, fakeClient = newReconciler(newDrainingCluster(0))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(testRayCluster), cluster))
+	_, err = r.drainRayCluster(ctx, cluster)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(testRayCluster), cluster)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestReconcileDrainFinalizer(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.DrainBeforeDeletion = &rayv1.DrainBeforeDeletionConfig{}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).Build()
+	ctx := context.Background()
+	r := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: record.NewFakeRecorder(10),
+		Scheme:   newScheme,
+	}
+
+	updated, err := r.reconcileDrainFinalizer(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Contains(t, cluster.Finalizers, utils.RayClusterDrainFinalizer)
+
+	updated, err = r.reconcileDrainFinalizer(ctx, cluster)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	// The finalizer is removed when the drain is disabled.
+	cluster.Spec.DrainBeforeDeletion = nil
+	updated, err = r.reconcileDrainFinalizer(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.NotContains(t, cluster.Finalizers, utils.RayClusterDrainFinalizer)
+}
+
+func TestReconcileImagePrePull(t *testing.T) {
+	setupTest(t)
+	ctx := context.Background()
+	testRayCluster.Spec.ImagePrePull = &rayv1.ImagePrePullConfig{TimeoutSeconds: ptr.To[int32](60)}
+
+	newReconciler := func(objects ...runtime.Object) (*RayClusterReconciler, *record.FakeRecorder) {
+		recorder := record.NewFakeRecorder(10)
+		return &RayClusterReconciler{
+			Client:   clientFake.NewClientBuilder().WithRuntimeObjects(objects...).Build(),
+			Recorder: recorder,
+			Scheme:   scheme.Scheme,
+		}, recorder
+	}
+	existingDaemonSets := func(age time.Duration, done bool) []runtime.Object {
+		daemonSets, err := common.BuildImagePrePullDaemonSets(*testRayCluster)
+		require.NoError(t, err)
+		objects := make([]runtime.Object, 0, len(daemonSets))
+		for _, daemonSet := range daemonSets {
+			daemonSet.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+			daemonSet.Status.DesiredNumberScheduled = 2
+			if done {
+				daemonSet.Status.NumberReady = 2
+			}
+			objects = append(objects, daemonSet)
+		}
+		return objects
+	}
+	listDaemonSets := func(r *RayClusterReconciler) []appsv1.DaemonSet {
+		daemonSets := appsv1.DaemonSetList{}
+		err := r.List(ctx, &daemonSets, common.RayClusterImagePrePullDaemonSetsAssociationOptions(testRayCluster).ToListOptions()...)
+		require.NoError(t, err)
+		return daemonSets.Items
+	}
+
+	// The DaemonSets are created for the head group and the worker group.
+	r, recorder := newReconciler()
+	pulling, err := r.reconcileImagePrePull(ctx, testRayCluster)
+	require.NoError(t, err)
+	assert.True(t, pulling)
+	assert.Len(t, listDaemonSets(r), 2)
+	assert.Contains(t, <-recorder.Events, string(utils.CreatedImagePrePullDaemonSet))
+
+	// The Ray Pods wait until the images are pulled.
+	r, _ = newReconciler(existingDaemonSets(time.Second, false)...)
+	pulling, err = r.reconcileImagePrePull(ctx, testRayCluster)
+	require.NoError(t, err)
+	assert.True(t, pulling)
+
+	r, _ = newReconciler(existingDaemonSets(time.Second, true)...)
+	pulling, err = r.reconcileImagePrePull(ctx, testRayCluster)
+	require.NoError(t, err)
+	assert.False(t, pulling)
+
+	// The Ray Pods are created anyway once the timeout expires.
+	r, recorder = newReconciler(existingDaemonSets(2*time.Minute, false)...)
+	pulling, err = r.reconcileImagePrePull(ctx, testRayCluster)
+	require.NoError(t, err)
+	assert.False(t, pulling)
+	assert.Contains(t, <-recorder.Events, string(utils.ImagePrePullTimedOut))
+
+	// The DaemonSets are deleted once the Ray Pods exist.
+	r, _ = newReconciler(append(existingDaemonSets(time.Second, false), testPods[0])...)
+	pulling, err = r.reconcileImagePrePull(ctx, testRayCluster)
+	require.NoError(t, err)
+	assert.False(t, pulling)
+	assert.Empty(t, listDaemonSets(r))
+}
+
+func TestRecordUsageSnapshot(t *testing.T) {
+	start := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	headStart := metav1.NewTime(start.Add(-time.Hour))
+	workerCreation := metav1.NewTime(start.Add(-time.Minute))
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "head", Labels: map[string]string{utils.RayNodeTypeLabelKey: string(rayv1.HeadNode)}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head"}}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "ray-head", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: headStart}}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Labels: map[string]string{utils.RayNodeTypeLabelKey: string(rayv1.WorkerNode)}, CreationTimestamp: workerCreation},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		},
+	}
+	instance := &rayv1.RayCluster{
+		Spec: rayv1.RayClusterSpec{
+			UsageSnapshots: &rayv1.UsageSnapshotConfig{IntervalSeconds: ptr.To[int32](60), MaxHistory: ptr.To[int32](2)},
+		},
+		Status: rayv1.RayClusterStatus{ReadyWorkerReplicas: 0, DesiredWorkerReplicas: 1},
+	}
+
+	recordUsageSnapshot(instance, pods, start)
+	require.Len(t, instance.Status.UsageSnapshots, 1)
+	snapshot := instance.Status.UsageSnapshots[0]
+	assert.Equal(t, start, snapshot.Time)
+	assert.Equal(t, int32(1), snapshot.DesiredWorkerReplicas)
+	assert.Equal(t, int32(1), snapshot.PendingPods)
+	assert.Equal(t, workerCreation, *snapshot.LastScaleTime)
+	assert.Equal(t, headStart, *snapshot.LastHeadRestartTime)
+
+	// No snapshot is recorded before the interval elapses.
+	recordUsageSnapshot(instance, pods, metav1.NewTime(start.Add(30*time.Second)))
+	require.Len(t, instance.Status.UsageSnapshots, 1)
+	next, ok := nextUsageSnapshotTime(instance)
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(time.Minute), next)
+
+	// The worker Pod was deleted, so the last scale time is carried over from the previous snapshot.
+	recordUsageSnapshot(instance, pods[:1], metav1.NewTime(start.Add(time.Minute)))
+	require.Len(t, instance.Status.UsageSnapshots, 2)
+	assert.Equal(t, workerCreation, *instance.Status.UsageSnapshots[1].LastScaleTime)
+	assert.Equal(t, int32(0), instance.Status.UsageSnapshots[1].PendingPods)
+
+	// The oldest snapshots are dropped beyond the maximum history.
+	recordUsageSnapshot(instance, pods, metav1.NewTime(start.Add(2*time.Minute)))
+	require.Len(t, instance.Status.UsageSnapshots, 2)
+	assert.Equal(t, start.Add(time.Minute), instance.Status.UsageSnapshots[0].Time.Time)
+
+	// The snapshots are removed when usage snapshots are disabled.
+	instance.Spec.UsageSnapshots = nil
+	recordUsageSnapshot(instance, pods, metav1.NewTime(start.Add(3*time.Minute)))
+	assert.Empty(t, instance.Status.UsageSnapshots)
+	_, ok = nextUsageSnapshotTime(instance)
+	assert.False(t, ok)
+}
@@ -0,0 +1,130 @@
+package ray
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// drainRequeueDuration is how often a draining RayCluster checks whether its Ray jobs have finished.
+const drainRequeueDuration = 10 * time.Second
+
+// reconcileDrainFinalizer adds the drain finalizer to the RayClusters with spec.drainBeforeDeletion, and removes it
+// when the field is unset. It returns true when the RayCluster was updated, so that the reconciliation restarts.
+func (r *RayClusterReconciler) reconcileDrainFinalizer(ctx context.Context, instance *rayv1.RayCluster) (bool, error) {
+	hasFinalizer := controllerutil.ContainsFinalizer(instance, utils.RayClusterDrainFinalizer)
+	switch {
+	case instance.Spec.DrainBeforeDeletion != nil && !hasFinalizer:
+		controllerutil.AddFinalizer(instance, utils.RayClusterDrainFinalizer)
+	case instance.Spec.DrainBeforeDeletion == nil && hasFinalizer:
+		controllerutil.RemoveFinalizer(instance, utils.RayClusterDrainFinalizer)
+	default:
+		return false, nil
+	}
+	if err := r.Update(ctx, instance); err != nil {
+		return false, fmt.Errorf("Failed to update the finalizer %s of the RayCluster: %w", utils.RayClusterDrainFinalizer, err)
+	}
+	return true, nil
+}
+
+// drainRayCluster holds the deletion of a RayCluster with the drain finalizer until no Ray job is running on it anymore,
+// or until the drain timeout expires. The Pods are left untouched meanwhile, so that the running jobs can finish.
+func (r *RayClusterReconciler) drainRayCluster(ctx context.Context, instance *rayv1.RayCluster) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	timeoutSeconds := int32(utils.DefaultRayClusterDrainTimeoutSeconds)
+	if instance.Spec.DrainBeforeDeletion != nil && instance.Spec.DrainBeforeDeletion.TimeoutSeconds != nil {
+		timeoutSeconds = *instance.Spec.DrainBeforeDeletion.TimeoutSeconds
+	}
+	deadline := instance.DeletionTimestamp.Add(time.Duration(timeoutSeconds) * time.Second)
+
+	runningJobs, err := r.countRunningRayJobs(ctx, instance)
+	switch {
+	case err == nil && runningJobs == 0:
+		logger.Info("No Ray job is running on the draining RayCluster anymore; deleting it")
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DrainedRayCluster),
+			"Drained RayCluster %s/%s", instance.Namespace, instance.Name)
+		return ctrl.Result{}, r.removeDrainFinalizer(ctx, instance)
+	case !time.Now().Before(deadline):
+		logger.Info("The drain timeout expired; deleting the RayCluster", "timeoutSeconds", timeoutSeconds, "runningJobs", runningJobs, "error", err)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.DrainTimeoutExceeded),
+			"Deleting RayCluster %s/%s after the drain timeout of %d seconds expired", instance.Namespace, instance.Name, timeoutSeconds)
+		return ctrl.Result{}, r.removeDrainFinalizer(ctx, instance)
+	case err != nil:
+		// The jobs may still be running, so keep waiting until the deadline.
+		logger.Info("Failed to list the Ray jobs of the draining RayCluster", "error", err)
+	}
+
+	if instance.Status.State != rayv1.Draining { //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		newInstance := instance.DeepCopy()
+		now := metav1.Now()
+		newInstance.Status.State = rayv1.Draining //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		newInstance.Status.Reason = fmt.Sprintf("Waiting for %d running Ray jobs before deleting the RayCluster", runningJobs)
+		newInstance.Status.LastUpdateTime = &now
+		if newInstance.Status.StateTransitionTimes == nil { //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+			newInstance.Status.StateTransitionTimes = make(map[rayv1.ClusterState]*metav1.Time) //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		}
+		newInstance.Status.StateTransitionTimes[rayv1.Draining] = &now //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		if err := r.Status().Update(ctx, newInstance); err != nil {
+			return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DrainingRayCluster),
+			"Draining RayCluster %s/%s for up to %d seconds before deleting it", instance.Namespace, instance.Name, timeoutSeconds)
+	}
+
+	requeueAfter := drainRequeueDuration
+	if untilDeadline := time.Until(deadline); untilDeadline < requeueAfter {
+		requeueAfter = untilDeadline
+	}
+	logger.Info("Waiting for the Ray jobs of the draining RayCluster", "runningJobs", runningJobs, "deadline", deadline)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// countRunningRayJobs returns the number of Ray jobs of the RayCluster which are not terminal yet. No job can run
+// without a running head Pod, so such RayClusters have none.
+func (r *RayClusterReconciler) countRunningRayJobs(ctx context.Context, instance *rayv1.RayCluster) (int, error) {
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return 0, err
+	}
+	if headPod == nil || headPod.DeletionTimestamp != nil || headPod.Status.Phase != corev1.PodRunning {
+		return 0, nil
+	}
+
+	url, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return 0, err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, url, instance); err != nil {
+		return 0, err
+	}
+	jobs, err := rayDashboardClient.ListJobs(ctx)
+	if err != nil || jobs == nil {
+		return 0, err
+	}
+	runningJobs := 0
+	for _, job := range *jobs {
+		if !rayv1.IsJobTerminal(job.JobStatus) {
+			runningJobs++
+		}
+	}
+	return runningJobs, nil
+}
+
+func (r *RayClusterReconciler) removeDrainFinalizer(ctx context.Context, instance *rayv1.RayCluster) error {
+	controllerutil.RemoveFinalizer(instance, utils.RayClusterDrainFinalizer)
+	if err := r.Update(ctx, instance); err != nil {
+		return fmt.Errorf("Failed to remove the finalizer %s from the RayCluster: %w", utils.RayClusterDrainFinalizer, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ray
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// podBatch counts the worker Pods created and deleted by a reconciliation of a RayCluster, up to the maximum number
+// of Pods per reconciliation of the pod batch policy, and the Pods left to the next reconciliations.
+type podBatch struct {
+	// remaining is the number of Pods which the reconciliation can still create or delete, or -1 without limit.
+	remaining int
+	now       metav1.Time
+	status    rayv1.RayClusterPodBatchingStatus
+}
+
+// newPodBatch returns the batch of a reconciliation. It is empty until the batch interval has elapsed since the last
+// batch recorded in the status of the RayCluster.
+func newPodBatch(instance *rayv1.RayCluster, now metav1.Time) *podBatch {
+	batch := &podBatch{remaining: utils.MaxPodsPerReconcile(), now: now}
+	if batch.remaining <= 0 {
+		batch.remaining = -1
+		return batch
+	}
+	if next, ok := nextPodBatchTime(instance); ok && now.Time.Before(next) {
+		batch.remaining = 0
+		batch.status.LastBatchTime = instance.Status.PodBatching.LastBatchTime
+	}
+	return batch
+}
+
+// take returns how many of n worker Pods of a group the reconciliation creates, or deletes, and records the others as
+// pending.
+func (b *podBatch) take(group string, n int, deletion bool) int {
+	taken := n
+	if b.remaining >= 0 {
+		taken = min(n, b.remaining)
+		b.remaining -= taken
+		if taken > 0 {
+			b.status.LastBatchTime = &b.now
+		}
+	}
+	if pending := int32(n - taken); pending > 0 {
+		if deletion {
+			b.status.PendingDeletions += pending
+		} else {
+			b.status.PendingCreations += pending
+		}
+		b.status.Groups = append(b.status.Groups, group)
+	}
+	return taken
+}
+
+// result returns the podBatching status of the RayCluster, or nil if no Pod is pending.
+func (b *podBatch) result() *rayv1.RayClusterPodBatchingStatus {
+	if len(b.status.Groups) == 0 {
+		return nil
+	}
+	return b.status.DeepCopy()
+}
+
+// nextPodBatchTime returns when the next batch of worker Pods of the RayCluster can be created or deleted, if Pods
+// are pending.
+func nextPodBatchTime(instance *rayv1.RayCluster) (time.Time, bool) {
+	status := instance.Status.PodBatching
+	if status == nil || status.LastBatchTime == nil {
+		return time.Time{}, false
+	}
+	return status.LastBatchTime.Add(utils.PodBatchInterval()), true
+}
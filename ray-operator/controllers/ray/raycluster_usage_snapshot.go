@@ -0,0 +1,90 @@
+package ray
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// usageSnapshotInterval returns the minimum time between two usage snapshots of the RayCluster.
+func usageSnapshotInterval(config *rayv1.UsageSnapshotConfig) time.Duration {
+	intervalSeconds := int32(utils.DefaultUsageSnapshotIntervalSeconds)
+	if config.IntervalSeconds != nil {
+		intervalSeconds = *config.IntervalSeconds
+	}
+	return time.Duration(intervalSeconds) * time.Second
+}
+
+// nextUsageSnapshotTime returns when the next usage snapshot of the RayCluster is due, if usage snapshots are enabled.
+func nextUsageSnapshotTime(instance *rayv1.RayCluster) (time.Time, bool) {
+	config := instance.Spec.UsageSnapshots
+	if config == nil {
+		return time.Time{}, false
+	}
+	snapshots := instance.Status.UsageSnapshots
+	if len(snapshots) == 0 {
+		return time.Now(), true
+	}
+	return snapshots[len(snapshots)-1].Time.Add(usageSnapshotInterval(config)), true
+}
+
+// recordUsageSnapshot appends a usage snapshot to the status of the RayCluster when the snapshot interval has elapsed
+// since the last one, and drops the oldest snapshots beyond the maximum history. The snapshots are removed when
+// spec.usageSnapshots is unset.
+func recordUsageSnapshot(instance *rayv1.RayCluster, runtimePods []corev1.Pod, now metav1.Time) {
+	config := instance.Spec.UsageSnapshots
+	if config == nil {
+		instance.Status.UsageSnapshots = nil
+		return
+	}
+	snapshot := rayv1.RayClusterUsageSnapshot{
+		Time:                  now,
+		ReadyWorkerReplicas:   instance.Status.ReadyWorkerReplicas,
+		DesiredWorkerReplicas: instance.Status.DesiredWorkerReplicas,
+	}
+	if len(instance.Status.UsageSnapshots) > 0 {
+		previous := instance.Status.UsageSnapshots[len(instance.Status.UsageSnapshots)-1]
+		if now.Time.Before(previous.Time.Add(usageSnapshotInterval(config))) {
+			return
+		}
+		// Worker Pods deleted since the previous snapshot are gone, so the previous scale time is carried over.
+		snapshot.LastScaleTime = previous.LastScaleTime
+	}
+	for _, pod := range runtimePods {
+		if pod.Status.Phase == corev1.PodPending {
+			snapshot.PendingPods++
+		}
+		switch rayv1.RayNodeType(pod.Labels[utils.RayNodeTypeLabelKey]) {
+		case rayv1.WorkerNode:
+			scaleTime := pod.CreationTimestamp
+			if pod.DeletionTimestamp != nil {
+				scaleTime = *pod.DeletionTimestamp
+			}
+			if snapshot.LastScaleTime == nil || snapshot.LastScaleTime.Before(&scaleTime) {
+				snapshot.LastScaleTime = scaleTime.DeepCopy()
+			}
+		case rayv1.HeadNode:
+			if len(pod.Spec.Containers) == 0 {
+				continue
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.Name == pod.Spec.Containers[utils.RayContainerIndex].Name && status.State.Running != nil {
+					snapshot.LastHeadRestartTime = status.State.Running.StartedAt.DeepCopy()
+				}
+			}
+		}
+	}
+
+	maxHistory := utils.DefaultUsageSnapshotMaxHistory
+	if config.MaxHistory != nil {
+		maxHistory = int(*config.MaxHistory)
+	}
+	instance.Status.UsageSnapshots = append(instance.Status.UsageSnapshots, snapshot)
+	if overflow := len(instance.Status.UsageSnapshots) - maxHistory; overflow > 0 {
+		instance.Status.UsageSnapshots = instance.Status.UsageSnapshots[overflow:]
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -30,7 +33,6 @@ import (
 )
 
 const (
-	RayJobDefaultRequeueDuration    = 3 * time.Second
 	RayJobDefaultClusterSelectorKey = "ray.io/cluster"
 	PythonUnbufferedEnvVarName      = "PYTHONUNBUFFERED"
 )
@@ -42,16 +44,25 @@ type RayJobReconciler struct {
 	Recorder record.EventRecorder
 
 	dashboardClientFunc func() utils.RayDashboardClientInterface
+	options             RayJobReconcilerOptions
+}
+
+// RayJobReconcilerOptions contains operator-level policies applied to all RayJobs.
+type RayJobReconcilerOptions struct {
+	// MaxConcurrentRayJobsPerNamespace is the maximum number of RayJobs that may run concurrently in
+	// a namespace. A value of 0 means no limit.
+	MaxConcurrentRayJobsPerNamespace int
 }
 
 // NewRayJobReconciler returns a new reconcile.Reconciler
-func NewRayJobReconciler(_ context.Context, mgr manager.Manager, provider utils.ClientProvider) *RayJobReconciler {
+func NewRayJobReconciler(_ context.Context, mgr manager.Manager, options RayJobReconcilerOptions, provider utils.ClientProvider) *RayJobReconciler {
 	dashboardClientFunc := provider.GetDashboardClient(mgr)
 	return &RayJobReconciler{
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		Recorder:            mgr.GetEventRecorderFor("rayjob-controller"),
 		dashboardClientFunc: dashboardClientFunc,
+		options:             options,
 	}
 }
 
@@ -89,7 +100,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		}
 		// Error reading the object - requeue the request.
 		logger.Error(err, "Failed to get RayJob")
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 	}
 
 	if !rayJobInstance.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -108,7 +119,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			if err != nil {
 				logger.Error(err, "Failed to initialize dashboard client")
 			}
-			err = rayDashboardClient.StopJob(ctx, rayJobInstance.Status.JobId)
+			err = rayDashboardClient.StopJob(ctx, activeRayJobId(rayJobInstance))
 			if err != nil {
 				logger.Error(err, "Failed to stop job for RayJob")
 			}
@@ -119,19 +130,19 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		err := r.Update(ctx, rayJobInstance)
 		if err != nil {
 			logger.Error(err, "Failed to remove finalizer for RayJob")
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 		}
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 	}
 
 	if err := validateRayJobSpec(rayJobInstance); err != nil {
 		logger.Error(err, "The RayJob spec is invalid")
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 	}
 
 	if err := validateRayJobStatus(rayJobInstance); err != nil {
 		logger.Error(err, "The RayJob status is invalid")
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 	}
 
 	// Please do NOT modify `originalRayJobInstance` in the following code.
@@ -145,14 +156,19 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			controllerutil.AddFinalizer(rayJobInstance, utils.RayJobStopJobFinalizer)
 			if err := r.Update(ctx, rayJobInstance); err != nil {
 				logger.Error(err, "Failed to update RayJob with finalizer")
-				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 			}
 		}
+		// If the operator limits the number of concurrent RayJobs per namespace, keep the RayJob in the `New`
+		// status until it is admitted. RayJobs are admitted in the order they were created.
+		if admitted, err := r.isRayJobAdmitted(ctx, rayJobInstance); err != nil || !admitted {
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
+		}
 		// Set `Status.JobDeploymentStatus` to `JobDeploymentStatusInitializing`, and initialize `Status.JobId`
 		// and `Status.RayClusterName` prior to avoid duplicate job submissions and cluster creations.
 		logger.Info("JobDeploymentStatusNew", "RayJob", rayJobInstance.Name)
 		if err = r.initRayJobStatusIfNeed(ctx, rayJobInstance); err != nil {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 		}
 	case rayv1.JobDeploymentStatusInitializing:
 		if shouldUpdate := r.updateStatusToSuspendingIfNeeded(ctx, rayJobInstance); shouldUpdate {
@@ -165,19 +181,19 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 
 		var rayClusterInstance *rayv1.RayCluster
 		if rayClusterInstance, err = r.getOrCreateRayClusterInstance(ctx, rayJobInstance); err != nil {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 		}
 
 		// Check the current status of RayCluster before submitting.
 		if clientURL := rayJobInstance.Status.DashboardURL; clientURL == "" {
 			if rayClusterInstance.Status.State != rayv1.Ready { //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
 				logger.Info("Wait for the RayCluster.Status.State to be ready before submitting the job.", "RayCluster", rayClusterInstance.Name, "State", rayClusterInstance.Status.State) //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
-				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 			}
 
 			if clientURL, err = utils.FetchHeadServiceURL(ctx, r.Client, rayClusterInstance, utils.DashboardPortName); err != nil || clientURL == "" {
 				logger.Error(err, "Failed to get the dashboard URL after the RayCluster is ready!", "RayCluster", rayClusterInstance.Name)
-				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 			}
 			rayJobInstance.Status.DashboardURL = clientURL
 		}
@@ -190,7 +206,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 
 		if rayJobInstance.Spec.SubmissionMode == rayv1.K8sJobMode {
 			if err := r.createK8sJobIfNeed(ctx, rayJobInstance, rayClusterInstance); err != nil {
-				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 			}
 		}
 
@@ -202,7 +218,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		rayJobId, found := rayJobInstance.ObjectMeta.Annotations[utils.RayJobSubmissionIdLabelKey]
 		logger.Info("Get Ray job id from the Ray job annotations", "RayJobId", rayJobId, "Found", found)
 		if !found {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, nil
 		}
 		rayJobInstance.Status.JobId = rayJobId
 		rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusRunning
@@ -225,7 +241,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			namespacedName := common.RayJobK8sJobNamespacedName(rayJobInstance)
 			if err := r.Client.Get(ctx, namespacedName, job); err != nil {
 				logger.Error(err, "Failed to get the submitter Kubernetes Job for RayJob", "NamespacedName", namespacedName)
-				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 			}
 			if shouldUpdate := r.checkK8sJobAndUpdateStatusIfNeeded(ctx, rayJobInstance, job); shouldUpdate {
 				break
@@ -236,28 +252,32 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		// TODO (kevin85421): Maybe we only need to `get` the RayCluster because the RayCluster should have been created
 		// before transitioning the status from `Initializing` to `Running`.
 		if rayClusterInstance, err = r.getOrCreateRayClusterInstance(ctx, rayJobInstance); err != nil {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 		}
 
 		// Check the current status of ray jobs
 		rayDashboardClient := r.dashboardClientFunc()
 		if err := rayDashboardClient.InitClient(ctx, rayJobInstance.Status.DashboardURL, rayClusterInstance); err != nil {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 		}
 
-		jobInfo, err := rayDashboardClient.GetJobInfo(ctx, rayJobInstance.Status.JobId)
-		if err != nil {
+		var jobInfo *utils.RayJobInfo
+		if len(rayJobInstance.Spec.Stages) > 0 {
+			if jobInfo, err = r.reconcileRayJobStages(ctx, rayJobInstance, rayDashboardClient); err != nil {
+				return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
+			}
+		} else if jobInfo, err = rayDashboardClient.GetJobInfo(ctx, rayJobInstance.Status.JobId); err != nil {
 			// If the Ray job was not found, GetJobInfo returns a BadRequest error.
 			if rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode && errors.IsBadRequest(err) {
 				logger.Info("The Ray job was not found. Submit a Ray job via an HTTP request.", "JobId", rayJobInstance.Status.JobId)
 				if _, err := rayDashboardClient.SubmitJob(ctx, rayJobInstance); err != nil {
 					logger.Error(err, "Failed to submit the Ray job", "JobId", rayJobInstance.Status.JobId)
-					return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+					return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 				}
-				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+				return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, nil
 			}
 			logger.Error(err, "Failed to get job info", "JobId", rayJobInstance.Status.JobId)
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 		}
 		logger.Info("GetJobInfo", "Job Info", jobInfo)
 
@@ -280,6 +300,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 				jobDeploymentStatus = rayv1.JobDeploymentStatusFailed
 				reason = rayv1.AppFailed
 			}
+			rayJobInstance.Status.JobResult = newRayJobResult(jobInfo)
 		}
 
 		// Always update RayClusterStatus along with JobStatus and JobDeploymentStatus updates.
@@ -299,16 +320,16 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		// users need to set the Pod's preStop hook by themselves.
 		isClusterDeleted, err := r.deleteClusterResources(ctx, rayJobInstance)
 		if err != nil {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 		}
 		isJobDeleted, err := r.deleteSubmitterJob(ctx, rayJobInstance)
 		if err != nil {
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 		}
 		if !isClusterDeleted || !isJobDeleted {
 			logger.Info("The release of the compute resources has not been completed yet. " +
 				"Wait for the resources to be deleted before the status transitions to avoid a resource leak.")
-			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+			return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, nil
 		}
 
 		// Reset the RayCluster and Ray job related status.
@@ -318,6 +339,8 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		rayJobInstance.Status.JobId = ""
 		rayJobInstance.Status.Message = ""
 		rayJobInstance.Status.Reason = ""
+		rayJobInstance.Status.JobResult = nil
+		rayJobInstance.Status.Stages = nil
 		// Reset the JobStatus to JobStatusNew and transition the JobDeploymentStatus to `Suspended`.
 		rayJobInstance.Status.JobStatus = rayv1.JobStatusNew
 
@@ -335,7 +358,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			break
 		}
 		// TODO (kevin85421): We may not need to requeue the RayJob if it has already been suspended.
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+		return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, nil
 	case rayv1.JobDeploymentStatusComplete, rayv1.JobDeploymentStatusFailed:
 		// If this RayJob uses an existing RayCluster (i.e., ClusterSelector is set), we should not delete the RayCluster.
 		logger.Info(string(rayJobInstance.Status.JobDeploymentStatus), "RayJob", rayJobInstance.Name, "ShutdownAfterJobFinishes", rayJobInstance.Spec.ShutdownAfterJobFinishes, "ClusterSelector", rayJobInstance.Spec.ClusterSelector)
@@ -365,14 +388,14 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 				logger.Info("RayCluster is deleted", "RayCluster", rayJobInstance.Status.RayClusterName)
 			}
 			if err != nil {
-				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+				return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 			}
 		}
 		// If the RayJob is completed, we should not requeue it.
 		return ctrl.Result{}, nil
 	default:
 		logger.Info("Unknown JobDeploymentStatus", "JobDeploymentStatus", rayJobInstance.Status.JobDeploymentStatus)
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+		return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, nil
 	}
 	checkBackoffLimitAndUpdateStatusIfNeeded(ctx, rayJobInstance)
 
@@ -380,9 +403,9 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 	// between `checkBackoffLimitAndUpdateStatusIfNeeded` and the following code.
 	if err = r.updateRayJobStatus(ctx, originalRayJobInstance, rayJobInstance); err != nil {
 		logger.Info("Failed to update RayJob status", "error", err)
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, err
 	}
-	return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+	return ctrl.Result{RequeueAfter: utils.RayJobRequeueDuration()}, nil
 }
 
 // checkBackoffLimitAndUpdateStatusIfNeeded determines if a RayJob is eligible for retry based on the configured backoff limit,
@@ -491,6 +514,17 @@ func (r *RayJobReconciler) getSubmitterTemplate(ctx context.Context, rayJobInsta
 		Value: rayJobInstance.Status.JobId,
 	})
 
+	// The Ray job CLI sends the headers in `RAY_JOB_HEADERS` with every request, which authenticates the submitter
+	// to a RayCluster with dashboard auth. The token is expanded from the preceding environment variable.
+	if rayClusterInstance != nil && rayClusterInstance.Spec.DashboardAuth != nil {
+		submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env = append(submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env,
+			common.DashboardAuthTokenEnvVar(rayClusterInstance.Name),
+			corev1.EnvVar{
+				Name:  utils.RAY_JOB_HEADERS,
+				Value: fmt.Sprintf(`{"Authorization": "Bearer $(%s)"}`, utils.RAY_DASHBOARD_AUTH_TOKEN),
+			})
+	}
+
 	return submitterTemplate, nil
 }
 
@@ -593,6 +627,11 @@ func (r *RayJobReconciler) deleteClusterResources(ctx context.Context, rayJobIns
 	} else {
 		if !cluster.DeletionTimestamp.IsZero() {
 			logger.Info("The deletion of the associated RayCluster for RayJob is ongoing.", "RayJob", rayJobInstance.Name, "RayCluster", cluster.Name)
+		} else if utils.IsDeletionProtected(&cluster) {
+			// The compute resources are not released until the annotation is removed.
+			logger.Info("The associated RayCluster for RayJob is protected from deletion", "RayCluster", clusterIdentifier, "RayJob", rayJobInstance.Name)
+			r.Recorder.Eventf(rayJobInstance, corev1.EventTypeWarning, string(utils.DeletionProtectedRayCluster),
+				"Not deleting cluster %s/%s, which has the %s annotation", cluster.Namespace, cluster.Name, utils.RayDeletionProtectedAnnotationKey)
 		} else {
 			if err := r.Delete(ctx, &cluster); err != nil {
 				r.Recorder.Eventf(rayJobInstance, corev1.EventTypeWarning, string(utils.FailedToDeleteRayCluster), "Failed to delete cluster %s/%s: %v", cluster.Namespace, cluster.Name, err)
@@ -616,6 +655,7 @@ func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurren
 		Owns(&batchv1.Job{}).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
+			RateLimiter:             utils.ErrorRateLimiter(),
 			LogConstructor: func(request *reconcile.Request) logr.Logger {
 				logger := ctrl.Log.WithName("controllers").WithName("RayJob")
 				if request != nil {
@@ -658,6 +698,8 @@ func (r *RayJobReconciler) initRayJobStatusIfNeed(ctx context.Context, rayJob *r
 				return fmt.Errorf("failed to get cluster name in ClusterSelector map, the default key is %v", RayJobDefaultClusterSelectorKey)
 			}
 			rayJob.Status.RayClusterName = useValue
+		} else if rayJob.Spec.ClusterGenerateName != "" {
+			rayJob.Status.RayClusterName = utils.GenerateRayClusterNameWithPrefix(rayJob.Spec.ClusterGenerateName, rayJob.UID)
 		} else {
 			rayJob.Status.RayClusterName = utils.GenerateRayClusterName(rayJob.Name)
 		}
@@ -671,6 +713,142 @@ func (r *RayJobReconciler) initRayJobStatusIfNeed(ctx context.Context, rayJob *r
 	return nil
 }
 
+// isRayJobAdmitted returns whether the RayJob may leave the `New` status under the per-namespace concurrency
+// limit. A RayJob is admitted only if it is among the oldest RayJobs waiting in the `New` status that fit into
+// the remaining capacity of the namespace, so waiting RayJobs are started in FIFO order.
+func (r *RayJobReconciler) isRayJobAdmitted(ctx context.Context, rayJob *rayv1.RayJob) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	limit := r.options.MaxConcurrentRayJobsPerNamespace
+	if limit <= 0 {
+		return true, nil
+	}
+
+	rayJobList := rayv1.RayJobList{}
+	if err := r.List(ctx, &rayJobList, client.InNamespace(rayJob.Namespace)); err != nil {
+		logger.Error(err, "Failed to list RayJobs to enforce the concurrency limit", "namespace", rayJob.Namespace)
+		return false, err
+	}
+
+	active := 0
+	queued := []*rayv1.RayJob{}
+	for i := range rayJobList.Items {
+		job := &rayJobList.Items[i]
+		switch job.Status.JobDeploymentStatus {
+		case rayv1.JobDeploymentStatusInitializing, rayv1.JobDeploymentStatusRunning, rayv1.JobDeploymentStatusWaiting,
+			rayv1.JobDeploymentStatusSuspending, rayv1.JobDeploymentStatusRetrying:
+			if job.UID != rayJob.UID {
+				active++
+			}
+		case rayv1.JobDeploymentStatusNew:
+			if job.DeletionTimestamp.IsZero() || job.UID == rayJob.UID {
+				queued = append(queued, job)
+			}
+		}
+	}
+	sort.SliceStable(queued, func(i, j int) bool {
+		if !queued[i].CreationTimestamp.Equal(&queued[j].CreationTimestamp) {
+			return queued[i].CreationTimestamp.Before(&queued[j].CreationTimestamp)
+		}
+		return queued[i].Name < queued[j].Name
+	})
+
+	// The informer cache may not include the RayJob yet, so treat it as the last one in the queue in that case.
+	position := len(queued)
+	for i, job := range queued {
+		if job.UID == rayJob.UID {
+			position = i
+			break
+		}
+	}
+	if active+position < limit {
+		return true, nil
+	}
+	logger.Info("The namespace has reached the limit of concurrent RayJobs. Keep the RayJob in the `New` status.",
+		"limit", limit, "activeRayJobs", active, "positionInQueue", position)
+	return false, nil
+}
+
+// reconcileRayJobStages submits the stages of a RayJob one after another on its RayCluster and returns the job
+// information of the whole pipeline: that of the first stage which has not succeeded yet, or that of the last stage
+// once all of them have succeeded. A pipeline is only reported as pending until its first stage starts.
+func (r *RayJobReconciler) reconcileRayJobStages(ctx context.Context, rayJob *rayv1.RayJob, rayDashboardClient utils.RayDashboardClientInterface) (*utils.RayJobInfo, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	initRayJobStageStatusesIfNeed(rayJob)
+
+	lastStage := len(rayJob.Spec.Stages) - 1
+	for i, stage := range rayJob.Spec.Stages {
+		stageStatus := &rayJob.Status.Stages[i]
+		if stageStatus.JobStatus == rayv1.JobStatusSucceeded && i < lastStage {
+			continue
+		}
+
+		jobInfo, err := rayDashboardClient.GetJobInfo(ctx, stageStatus.JobId)
+		if err != nil {
+			// If the Ray job was not found, GetJobInfo returns a BadRequest error.
+			if !errors.IsBadRequest(err) {
+				logger.Error(err, "Failed to get job info", "stage", stage.Name, "JobId", stageStatus.JobId)
+				return nil, err
+			}
+			logger.Info("The Ray job of the stage was not found. Submit it via an HTTP request.", "stage", stage.Name, "JobId", stageStatus.JobId)
+			request, err := utils.ConvertRayJobStageToReq(rayJob, stage, stageStatus.JobId)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := rayDashboardClient.SubmitJobReq(ctx, request, &rayJob.Name); err != nil {
+				r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, string(utils.FailedToSubmitRayJobStage), "Failed to submit stage %s as Ray job %s: %v", stage.Name, stageStatus.JobId, err)
+				return nil, err
+			}
+			r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, string(utils.SubmittedRayJobStage), "Submitted stage %s as Ray job %s", stage.Name, stageStatus.JobId)
+			jobInfo = &utils.RayJobInfo{JobStatus: rayv1.JobStatusPending, SubmissionId: stageStatus.JobId}
+		}
+
+		stageStatus.JobStatus = jobInfo.JobStatus
+		stageStatus.Message = jobInfo.Message
+		if stageStatus.StartTime == nil {
+			stageStatus.StartTime = &metav1.Time{Time: time.Now()}
+		}
+		if rayv1.IsJobTerminal(jobInfo.JobStatus) && stageStatus.EndTime == nil {
+			stageStatus.EndTime = &metav1.Time{Time: time.Now()}
+		}
+		if jobInfo.JobStatus == rayv1.JobStatusSucceeded && i < lastStage {
+			continue
+		}
+		if jobInfo.JobStatus == rayv1.JobStatusPending && i > 0 {
+			pipelineInfo := *jobInfo
+			pipelineInfo.JobStatus = rayv1.JobStatusRunning
+			return &pipelineInfo, nil
+		}
+		return jobInfo, nil
+	}
+	return nil, fmt.Errorf("RayJob %s/%s has no stages", rayJob.Namespace, rayJob.Name)
+}
+
+// initRayJobStageStatusesIfNeed initializes the status of every stage of the RayJob. The Ray job of a stage is
+// submitted with the ID `<Status.JobId>-<stage name>`, so every retry of the RayJob submits new Ray jobs.
+func initRayJobStageStatusesIfNeed(rayJob *rayv1.RayJob) {
+	if len(rayJob.Status.Stages) == len(rayJob.Spec.Stages) {
+		return
+	}
+	rayJob.Status.Stages = make([]rayv1.RayJobStageStatus, 0, len(rayJob.Spec.Stages))
+	for _, stage := range rayJob.Spec.Stages {
+		rayJob.Status.Stages = append(rayJob.Status.Stages, rayv1.RayJobStageStatus{
+			Name:  stage.Name,
+			JobId: fmt.Sprintf("%s-%s", rayJob.Status.JobId, stage.Name),
+		})
+	}
+}
+
+// activeRayJobId returns the ID of the Ray job which the RayJob is currently running. If the RayJob has stages, it
+// is the Ray job of the first stage which has not finished yet.
+func activeRayJobId(rayJob *rayv1.RayJob) string {
+	for _, stage := range rayJob.Status.Stages {
+		if !rayv1.IsJobTerminal(stage.JobStatus) {
+			return stage.JobId
+		}
+	}
+	return rayJob.Status.JobId
+}
+
 func (r *RayJobReconciler) updateRayJobStatus(ctx context.Context, oldRayJob *rayv1.RayJob, newRayJob *rayv1.RayJob) error {
 	logger := ctrl.LoggerFrom(ctx)
 	oldRayJobStatus := oldRayJob.Status
@@ -678,11 +856,14 @@ func (r *RayJobReconciler) updateRayJobStatus(ctx context.Context, oldRayJob *ra
 	logger.Info("updateRayJobStatus", "oldRayJobStatus", oldRayJobStatus, "newRayJobStatus", newRayJobStatus)
 	// If a status field is crucial for the RayJob state machine, it MUST be
 	// updated with a distinct JobStatus or JobDeploymentStatus value.
+	// The progress of the stages is also persisted, so that users can follow a pipeline while it is running.
 	if oldRayJobStatus.JobStatus != newRayJobStatus.JobStatus ||
-		oldRayJobStatus.JobDeploymentStatus != newRayJobStatus.JobDeploymentStatus {
+		oldRayJobStatus.JobDeploymentStatus != newRayJobStatus.JobDeploymentStatus ||
+		!equality.Semantic.DeepEqual(oldRayJobStatus.Stages, newRayJobStatus.Stages) {
 
 		if newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete || newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed {
 			newRayJob.Status.EndTime = &metav1.Time{Time: time.Now()}
+			completeRayJobResult(&newRayJob.Status)
 		}
 
 		logger.Info("updateRayJobStatus", "old JobStatus", oldRayJobStatus.JobStatus, "new JobStatus", newRayJobStatus.JobStatus,
@@ -694,6 +875,50 @@ func (r *RayJobReconciler) updateRayJobStatus(ctx context.Context, oldRayJob *ra
 	return nil
 }
 
+// newRayJobResult builds the result of a finished Ray job from the job information reported by Ray.
+func newRayJobResult(jobInfo *utils.RayJobInfo) *rayv1.RayJobResult {
+	result := &rayv1.RayJobResult{
+		DriverExitCode: jobInfo.DriverExitCode,
+		Metadata:       jobInfo.Metadata,
+	}
+	if jobInfo.ErrorType != nil {
+		result.ErrorType = *jobInfo.ErrorType
+	}
+	// Ray reports the start and end time of a job in milliseconds.
+	if jobInfo.StartTime > 0 && jobInfo.EndTime >= jobInfo.StartTime {
+		result.RuntimeSeconds = ptr.To(int64((jobInfo.EndTime - jobInfo.StartTime) / 1000))
+	}
+	return result
+}
+
+// completeRayJobResult sets the failure category and, if Ray did not report it, the runtime in the result of a
+// RayJob which has just transitioned to `Complete` or `Failed`.
+func completeRayJobResult(status *rayv1.RayJobStatus) {
+	if status.JobResult == nil {
+		status.JobResult = &rayv1.RayJobResult{}
+	}
+	result := status.JobResult
+	if status.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed {
+		result.FailureCategory = getJobFailureCategory(status.Reason, result.ErrorType)
+	}
+	if result.RuntimeSeconds == nil && status.StartTime != nil && status.EndTime != nil {
+		result.RuntimeSeconds = ptr.To(int64(status.EndTime.Sub(status.StartTime.Time).Seconds()))
+	}
+}
+
+func getJobFailureCategory(reason rayv1.JobFailedReason, errorType string) rayv1.JobFailureCategory {
+	switch reason {
+	case rayv1.DeadlineExceeded:
+		return rayv1.DeadlineFailure
+	case rayv1.SubmissionFailed:
+		return rayv1.InfraErrorFailure
+	}
+	if errorType != "" && errorType != utils.RayJobEntrypointCommandErrorType {
+		return rayv1.InfraErrorFailure
+	}
+	return rayv1.UserErrorFailure
+}
+
 func (r *RayJobReconciler) getOrCreateRayClusterInstance(ctx context.Context, rayJobInstance *rayv1.RayJob) (*rayv1.RayCluster, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	rayClusterNamespacedName := common.RayJobRayClusterNamespacedName(rayJobInstance)
@@ -824,6 +1049,15 @@ func validateRayJobSpec(rayJob *rayv1.RayJob) error {
 	if rayJob.Spec.RayClusterSpec == nil && len(rayJob.Spec.ClusterSelector) == 0 {
 		return fmt.Errorf("one of RayClusterSpec or ClusterSelector must be set")
 	}
+	if rayJob.Spec.ClusterGenerateName != "" {
+		if len(rayJob.Spec.ClusterSelector) != 0 {
+			return fmt.Errorf("clusterGenerateName and ClusterSelector are mutually exclusive")
+		}
+		clusterName := utils.GenerateRayClusterNameWithPrefix(rayJob.Spec.ClusterGenerateName, rayJob.UID)
+		if errs := validation.IsDNS1035Label(clusterName); len(errs) > 0 {
+			return fmt.Errorf("clusterGenerateName %s does not produce a valid RayCluster name: %s", rayJob.Spec.ClusterGenerateName, strings.Join(errs, ", "))
+		}
+	}
 	// Validate whether RuntimeEnvYAML is a valid YAML string. Note that this only checks its validity
 	// as a YAML string, not its adherence to the runtime environment schema.
 	if _, err := utils.UnmarshalRuntimeEnvYAML(rayJob.Spec.RuntimeEnvYAML); err != nil {
@@ -835,6 +1069,35 @@ func validateRayJobSpec(rayJob *rayv1.RayJob) error {
 	if rayJob.Spec.BackoffLimit != nil && *rayJob.Spec.BackoffLimit < 0 {
 		return fmt.Errorf("backoffLimit must be a positive integer")
 	}
+	if rayJob.Spec.RayClusterSpec != nil {
+		if err := utils.ValidateRayVersion(rayJob.Spec.RayClusterSpec, utils.RayJobVersionRequirements(rayJob)...); err != nil {
+			return err
+		}
+	}
+	if len(rayJob.Spec.Stages) > 0 {
+		if rayJob.Spec.SubmissionMode != rayv1.HTTPMode {
+			return fmt.Errorf("stages are only supported in HTTPMode")
+		}
+		if rayJob.Spec.Entrypoint != "" {
+			return fmt.Errorf("entrypoint and stages are mutually exclusive")
+		}
+		stageNames := make(map[string]bool, len(rayJob.Spec.Stages))
+		for i, stage := range rayJob.Spec.Stages {
+			if stage.Name == "" {
+				return fmt.Errorf("the name of stage %d is empty", i)
+			}
+			if stageNames[stage.Name] {
+				return fmt.Errorf("stage name %s is not unique", stage.Name)
+			}
+			stageNames[stage.Name] = true
+			if stage.Entrypoint == "" {
+				return fmt.Errorf("the entrypoint of stage %s is empty", stage.Name)
+			}
+			if _, err := utils.UnmarshalRuntimeEnvYAML(stage.RuntimeEnvYAML); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -374,6 +376,225 @@ func TestValidateRayJobSpec(t *testing.T) {
 		},
 	})
 	assert.Error(t, err, "The RayJob is invalid because the backoffLimit must be a positive integer.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{UID: "uid"},
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:      &rayv1.RayClusterSpec{},
+			ClusterGenerateName: "nightly-",
+		},
+	})
+	assert.NoError(t, err, "The RayJob is valid.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			ClusterSelector:     map[string]string{"key": "value"},
+			ClusterGenerateName: "nightly-",
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because clusterGenerateName and ClusterSelector are mutually exclusive.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec:      &rayv1.RayClusterSpec{},
+			ClusterGenerateName: "Nightly_",
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because clusterGenerateName does not produce a valid RayCluster name.")
+
+	stages := []rayv1.RayJobStage{
+		{Name: "preprocess", Entrypoint: "python preprocess.py"},
+		{Name: "train", Entrypoint: "python train.py"},
+	}
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+			Stages:         stages,
+		},
+	})
+	assert.NoError(t, err, "The RayJob with stages is valid.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.K8sJobMode,
+			Stages:         stages,
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because stages are only supported in HTTPMode.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+			Entrypoint:     "python main.py",
+			Stages:         stages,
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because entrypoint and stages are mutually exclusive.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+			Stages:         append(stages, rayv1.RayJobStage{Name: "train", Entrypoint: "python train.py"}),
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the stage names are not unique.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+			SubmissionMode: rayv1.HTTPMode,
+			Stages:         []rayv1.RayJobStage{{Name: "train"}},
+		},
+	})
+	assert.Error(t, err, "The RayJob is invalid because the entrypoint of a stage is empty.")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RayClusterSpec: &rayv1.RayClusterSpec{RayVersion: "2.5.0"},
+			Metadata:       map[string]string{"team": "ml"},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.metadata requires Ray 2.6.0 or later, but the Ray version is 2.5.0 according to spec.rayVersion")
+}
+
+func TestReconcileRayJobStages(t *testing.T) {
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pipeline",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode: rayv1.HTTPMode,
+			Stages: []rayv1.RayJobStage{
+				{Name: "preprocess", Entrypoint: "python preprocess.py"},
+				{Name: "train", Entrypoint: "python train.py"},
+			},
+		},
+		Status: rayv1.RayJobStatus{
+			JobId: "pipeline-abc",
+		},
+	}
+
+	// The Ray jobs known by the fake dashboard. Unknown Ray jobs are reported with a BadRequest error.
+	jobStatuses := map[string]rayv1.JobStatus{}
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	getJobInfo := func(_ context.Context, jobId string) (*utils.RayJobInfo, error) {
+		jobStatus, ok := jobStatuses[jobId]
+		if !ok {
+			return nil, apierrors.NewBadRequest("job not found")
+		}
+		return &utils.RayJobInfo{JobStatus: jobStatus, SubmissionId: jobId}, nil
+	}
+	fakeDashboardClient.GetJobInfoMock.Store(&getJobInfo)
+
+	recorder := record.NewFakeRecorder(100)
+	r := &RayJobReconciler{Recorder: recorder}
+	ctx := context.Background()
+
+	// The first stage is submitted and the pipeline is pending.
+	jobInfo, err := r.reconcileRayJobStages(ctx, rayJob, fakeDashboardClient)
+	assert.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusPending, jobInfo.JobStatus)
+	assert.Len(t, rayJob.Status.Stages, 2)
+	assert.Equal(t, "pipeline-abc-preprocess", rayJob.Status.Stages[0].JobId)
+	assert.Equal(t, rayv1.JobStatusPending, rayJob.Status.Stages[0].JobStatus)
+	assert.NotNil(t, rayJob.Status.Stages[0].StartTime)
+	assert.Equal(t, rayv1.JobStatusNew, rayJob.Status.Stages[1].JobStatus)
+	assert.Contains(t, <-recorder.Events, string(utils.SubmittedRayJobStage))
+	assert.Equal(t, "pipeline-abc-preprocess", activeRayJobId(rayJob))
+
+	// Once the first stage has succeeded, the second stage is submitted and the pipeline keeps running.
+	jobStatuses["pipeline-abc-preprocess"] = rayv1.JobStatusSucceeded
+	jobInfo, err = r.reconcileRayJobStages(ctx, rayJob, fakeDashboardClient)
+	assert.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusRunning, jobInfo.JobStatus)
+	assert.Equal(t, rayv1.JobStatusSucceeded, rayJob.Status.Stages[0].JobStatus)
+	assert.NotNil(t, rayJob.Status.Stages[0].EndTime)
+	assert.Equal(t, rayv1.JobStatusPending, rayJob.Status.Stages[1].JobStatus)
+	assert.Equal(t, "pipeline-abc-train", activeRayJobId(rayJob))
+
+	// The pipeline fails as soon as a stage fails.
+	jobStatuses["pipeline-abc-train"] = rayv1.JobStatusFailed
+	jobInfo, err = r.reconcileRayJobStages(ctx, rayJob, fakeDashboardClient)
+	assert.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusFailed, jobInfo.JobStatus)
+	assert.Equal(t, rayv1.JobStatusFailed, rayJob.Status.Stages[1].JobStatus)
+
+	// The pipeline succeeds once its last stage has succeeded.
+	jobStatuses["pipeline-abc-train"] = rayv1.JobStatusSucceeded
+	jobInfo, err = r.reconcileRayJobStages(ctx, rayJob, fakeDashboardClient)
+	assert.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusSucceeded, jobInfo.JobStatus)
+	assert.Equal(t, "pipeline-abc", activeRayJobId(rayJob))
+}
+
+func TestCompleteRayJobResult(t *testing.T) {
+	startTime := metav1.Now()
+	endTime := metav1.NewTime(startTime.Add(90 * time.Second))
+
+	tests := map[string]struct {
+		jobInfo                 *utils.RayJobInfo
+		reason                  rayv1.JobFailedReason
+		jobDeploymentStatus     rayv1.JobDeploymentStatus
+		expectedFailureCategory rayv1.JobFailureCategory
+		expectedRuntimeSeconds  int64
+	}{
+		"succeeded job uses the runtime reported by Ray": {
+			jobInfo:                &utils.RayJobInfo{StartTime: 1000, EndTime: 31000, DriverExitCode: ptr.To[int32](0)},
+			jobDeploymentStatus:    rayv1.JobDeploymentStatusComplete,
+			expectedRuntimeSeconds: 30,
+		},
+		"entrypoint failure is a user error": {
+			jobInfo:                 &utils.RayJobInfo{StartTime: 1000, EndTime: 31000, DriverExitCode: ptr.To[int32](1), ErrorType: ptr.To(utils.RayJobEntrypointCommandErrorType)},
+			reason:                  rayv1.AppFailed,
+			jobDeploymentStatus:     rayv1.JobDeploymentStatusFailed,
+			expectedFailureCategory: rayv1.UserErrorFailure,
+			expectedRuntimeSeconds:  30,
+		},
+		"job supervisor failure is an infra error": {
+			jobInfo:                 &utils.RayJobInfo{ErrorType: ptr.To("JOB_SUPERVISOR_ACTOR_DIED")},
+			reason:                  rayv1.AppFailed,
+			jobDeploymentStatus:     rayv1.JobDeploymentStatusFailed,
+			expectedFailureCategory: rayv1.InfraErrorFailure,
+			expectedRuntimeSeconds:  90,
+		},
+		"submission failure is an infra error": {
+			reason:                  rayv1.SubmissionFailed,
+			jobDeploymentStatus:     rayv1.JobDeploymentStatusFailed,
+			expectedFailureCategory: rayv1.InfraErrorFailure,
+			expectedRuntimeSeconds:  90,
+		},
+		"deadline exceeded": {
+			reason:                  rayv1.DeadlineExceeded,
+			jobDeploymentStatus:     rayv1.JobDeploymentStatusFailed,
+			expectedFailureCategory: rayv1.DeadlineFailure,
+			expectedRuntimeSeconds:  90,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := rayv1.RayJobStatus{
+				JobDeploymentStatus: tc.jobDeploymentStatus,
+				Reason:              tc.reason,
+				StartTime:           &startTime,
+				EndTime:             &endTime,
+			}
+			if tc.jobInfo != nil {
+				status.JobResult = newRayJobResult(tc.jobInfo)
+			}
+			completeRayJobResult(&status)
+			assert.Equal(t, tc.expectedFailureCategory, status.JobResult.FailureCategory)
+			assert.Equal(t, tc.expectedRuntimeSeconds, *status.JobResult.RuntimeSeconds)
+			if tc.jobInfo != nil {
+				assert.Equal(t, tc.jobInfo.DriverExitCode, status.JobResult.DriverExitCode)
+			}
+		})
+	}
 }
 
 func TestFailedToCreateRayJobSubmitterEvent(t *testing.T) {
@@ -575,3 +796,130 @@ func TestFailedDeleteRayClusterEvent(t *testing.T) {
 
 	assert.Truef(t, foundFailureEvent, "Expected event to be generated for cluster deletion failure, got events: %s", strings.Join(events, "\n"))
 }
+
+func TestDeleteClusterResourcesWithDeletionProtection(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-raycluster",
+			Namespace:   "default",
+			Annotations: map[string]string{utils.RayDeletionProtectedAnnotationKey: "true"},
+		},
+	}
+
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+		},
+		Status: rayv1.RayJobStatus{
+			RayClusterName: "test-raycluster",
+		},
+	}
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayCluster).Build()
+	recorder := record.NewFakeRecorder(100)
+	reconciler := &RayJobReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Scheme:   scheme.Scheme,
+	}
+
+	isClusterDeleted, err := reconciler.deleteClusterResources(context.Background(), rayJob)
+	assert.NoError(t, err)
+	assert.False(t, isClusterDeleted)
+
+	// The protected RayCluster is kept.
+	err = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(rayCluster), &rayv1.RayCluster{})
+	assert.NoError(t, err)
+	assert.Contains(t, <-recorder.Events, string(utils.DeletionProtectedRayCluster))
+}
+
+func TestIsRayJobAdmitted(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	now := time.Now()
+	newRayJob := func(name string, namespace string, age time.Duration, status rayv1.JobDeploymentStatus) *rayv1.RayJob {
+		return &rayv1.RayJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				UID:               types.UID(namespace + "/" + name),
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Status: rayv1.RayJobStatus{
+				JobDeploymentStatus: status,
+			},
+		}
+	}
+
+	rayJobs := []*rayv1.RayJob{
+		newRayJob("running", "default", 10*time.Minute, rayv1.JobDeploymentStatusRunning),
+		newRayJob("complete", "default", 9*time.Minute, rayv1.JobDeploymentStatusComplete),
+		newRayJob("suspended", "default", 8*time.Minute, rayv1.JobDeploymentStatusSuspended),
+		newRayJob("first", "default", 3*time.Minute, rayv1.JobDeploymentStatusNew),
+		newRayJob("second", "default", 2*time.Minute, rayv1.JobDeploymentStatusNew),
+		newRayJob("third", "default", 1*time.Minute, rayv1.JobDeploymentStatusNew),
+		newRayJob("other-running", "other", 10*time.Minute, rayv1.JobDeploymentStatusRunning),
+		newRayJob("other-initializing", "other", 10*time.Minute, rayv1.JobDeploymentStatusInitializing),
+	}
+	runtimeObjects := []runtime.Object{}
+	for _, rayJob := range rayJobs {
+		runtimeObjects = append(runtimeObjects, rayJob)
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+
+	tests := map[string]struct {
+		rayJob   *rayv1.RayJob
+		limit    int
+		admitted bool
+	}{
+		"No limit": {
+			rayJob:   rayJobs[5],
+			limit:    0,
+			admitted: true,
+		},
+		"The oldest waiting RayJob fits into the limit": {
+			rayJob:   rayJobs[3],
+			limit:    2,
+			admitted: true,
+		},
+		"A newer waiting RayJob has to wait for the older ones": {
+			rayJob:   rayJobs[4],
+			limit:    2,
+			admitted: false,
+		},
+		"Several waiting RayJobs fit into the limit": {
+			rayJob:   rayJobs[4],
+			limit:    3,
+			admitted: true,
+		},
+		"RayJobs in other namespaces do not count towards the limit": {
+			rayJob:   rayJobs[5],
+			limit:    4,
+			admitted: true,
+		},
+		"The namespace is full": {
+			rayJob:   newRayJob("new", "other", 0, rayv1.JobDeploymentStatusNew),
+			limit:    2,
+			admitted: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &RayJobReconciler{
+				Client:   fakeClient,
+				Recorder: &record.FakeRecorder{},
+				Scheme:   newScheme,
+				options:  RayJobReconcilerOptions{MaxConcurrentRayJobsPerNamespace: tc.limit},
+			}
+			admitted, err := r.isRayJobAdmitted(context.Background(), tc.rayJob)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.admitted, admitted)
+		})
+	}
+}
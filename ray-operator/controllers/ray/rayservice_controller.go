@@ -33,9 +33,11 @@ import (
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -45,11 +47,20 @@ import (
 )
 
 const (
-	ServiceDefaultRequeueDuration   = 2 * time.Second
 	RayClusterDeletionDelayDuration = 60 * time.Second
 	ENABLE_ZERO_DOWNTIME            = "ENABLE_ZERO_DOWNTIME"
 )
 
+// Defaults of the RayService health check policy.
+const (
+	DefaultHealthCheckTimeoutSeconds        int32 = 2
+	DefaultHealthCheckFailureThreshold      int32 = 1
+	DefaultHealthCheckInitialBackoffSeconds int32 = 2
+	DefaultHealthCheckMaxBackoffSeconds     int32 = 60
+	// MaxHealthCheckFailureHistory is the number of failed health checks kept in the RayService status.
+	MaxHealthCheckFailureHistory = 10
+)
+
 // RayServiceReconciler reconciles a RayService object
 type RayServiceReconciler struct {
 	client.Client
@@ -62,10 +73,13 @@ type RayServiceReconciler struct {
 
 	dashboardClientFunc func() utils.RayDashboardClientInterface
 	httpProxyClientFunc func() utils.RayHttpProxyClientInterface
+	// serveStatusPoller polls the statuses of the Serve applications in the background. Without it, the statuses
+	// are fetched from the dashboard in every reconciliation.
+	serveStatusPoller *serveStatusPoller
 }
 
 // NewRayServiceReconciler returns a new reconcile.Reconciler
-func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider utils.ClientProvider) *RayServiceReconciler {
+func NewRayServiceReconciler(ctx context.Context, mgr manager.Manager, provider utils.ClientProvider) *RayServiceReconciler {
 	dashboardClientFunc := provider.GetDashboardClient(mgr)
 	httpProxyClientFunc := provider.GetHttpProxyClient(mgr)
 	return &RayServiceReconciler{
@@ -77,6 +91,7 @@ func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider ut
 
 		dashboardClientFunc: dashboardClientFunc,
 		httpProxyClientFunc: httpProxyClientFunc,
+		serveStatusPoller:   newServeStatusPoller(ctx, dashboardClientFunc),
 	}
 }
 
@@ -121,20 +136,40 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 
 	// Resolve the CR from request.
 	if rayServiceInstance, err = r.getRayServiceInstance(ctx, request); err != nil {
+		if errors.IsNotFound(err) && r.serveStatusPoller != nil {
+			r.serveStatusPoller.cleanUp(request.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 	originalRayServiceInstance := rayServiceInstance.DeepCopy()
 	r.cleanUpServeConfigCache(ctx, rayServiceInstance)
+	if r.serveStatusPoller != nil {
+		r.serveStatusPoller.cleanUp(request.NamespacedName,
+			rayServiceInstance.Status.ActiveServiceStatus.RayClusterName, rayServiceInstance.Status.PendingServiceStatus.RayClusterName)
+	}
 
 	// TODO (kevin85421): ObservedGeneration should be used to determine whether to update this CR or not.
 	rayServiceInstance.Status.ObservedGeneration = rayServiceInstance.ObjectMeta.Generation
 
+	if rayServiceInstance.Spec.Paused {
+		return r.reconcilePaused(ctx, originalRayServiceInstance, rayServiceInstance)
+	}
+	if originalRayServiceInstance.Status.ServiceStatus == rayv1.Paused {
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.ResumedRayService), "Resumed the reconciliation of RayService %s/%s", rayServiceInstance.Namespace, rayServiceInstance.Name)
+	}
+
+	// Fail fast, instead of creating a RayCluster whose Ray version does not support the requested features.
+	if err := utils.ValidateRayVersion(&rayServiceInstance.Spec.RayClusterSpec, utils.RayServiceVersionRequirements(rayServiceInstance)...); err != nil {
+		err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToGetOrCreateRayCluster, err)
+		return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, err
+	}
+
 	// Find active and pending ray cluster objects given current service name.
 	var activeRayClusterInstance *rayv1.RayCluster
 	var pendingRayClusterInstance *rayv1.RayCluster
 	if activeRayClusterInstance, pendingRayClusterInstance, err = r.reconcileRayCluster(ctx, rayServiceInstance); err != nil {
 		err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToGetOrCreateRayCluster, err)
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, client.IgnoreNotFound(err)
+		return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, client.IgnoreNotFound(err)
 	}
 
 	// Check if we need to create pending RayCluster.
@@ -142,10 +177,10 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		// Update RayService Status since reconcileRayCluster may mark RayCluster restart.
 		if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
 			logger.Error(errStatus, "Fail to update status of RayService after RayCluster changes", "rayServiceInstance", rayServiceInstance)
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+			return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, nil
 		}
 		logger.Info("Done reconcileRayCluster update status, enter next loop to create new ray cluster.")
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+		return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, nil
 	}
 
 	/*
@@ -157,6 +192,7 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	if activeRayClusterInstance != nil && pendingRayClusterInstance == nil {
 		logger.Info("Reconciling the Serve component. Only the active Ray cluster exists.")
 		rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
+		rayServiceInstance.Status.UpgradeStatus = nil
 		if ctrlResult, isReady, err = r.reconcileServe(ctx, rayServiceInstance, activeRayClusterInstance, true); err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
 			return ctrlResult, nil
@@ -167,6 +203,7 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		if err = r.updateStatusForActiveCluster(ctx, rayServiceInstance, activeRayClusterInstance); err != nil {
 			logger.Error(err, "Failed to update active Ray cluster's status.")
 		}
+		r.updateUpgradeStatus(ctx, rayServiceInstance, activeRayClusterInstance, pendingRayClusterInstance)
 
 		if ctrlResult, isReady, err = r.reconcileServe(ctx, rayServiceInstance, pendingRayClusterInstance, false); err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
@@ -174,6 +211,7 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		}
 	} else if activeRayClusterInstance == nil && pendingRayClusterInstance != nil {
 		rayServiceInstance.Status.ActiveServiceStatus = rayv1.RayServiceStatus{}
+		rayServiceInstance.Status.UpgradeStatus = nil
 		if ctrlResult, isReady, err = r.reconcileServe(ctx, rayServiceInstance, pendingRayClusterInstance, false); err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
 			return ctrlResult, nil
@@ -182,24 +220,25 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		logger.Info("Reconciling the Serve component. No Ray cluster exists.")
 		rayServiceInstance.Status.ActiveServiceStatus = rayv1.RayServiceStatus{}
 		rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
+		rayServiceInstance.Status.UpgradeStatus = nil
 	}
 
 	if !isReady {
-		logger.Info("Ray Serve applications are not ready to serve requests", "requeue_duration", ServiceDefaultRequeueDuration.String())
-		r.Recorder.Eventf(rayServiceInstance, "Normal", "ServiceNotReady", "The service is not ready yet. Controller will perform a round of actions in %s.", ServiceDefaultRequeueDuration)
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+		logger.Info("Ray Serve applications are not ready to serve requests", "requeue_duration", utils.RayServiceRequeueDuration().String())
+		r.Recorder.Eventf(rayServiceInstance, "Normal", "ServiceNotReady", "The service is not ready yet. Controller will perform a round of actions in %s.", utils.RayServiceRequeueDuration())
+		return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, nil
 	}
 
 	// Get the ready Ray cluster instance for service and ingress update.
 	var rayClusterInstance *rayv1.RayCluster
-	if pendingRayClusterInstance != nil {
+	if pendingRayClusterInstance != nil && !isUpgradePromotionHeld(rayServiceInstance) {
 		rayClusterInstance = pendingRayClusterInstance
 		logger.Info("Reconciling the ingress and service resources " +
 			"on the pending Ray cluster.")
 	} else if activeRayClusterInstance != nil {
 		rayClusterInstance = activeRayClusterInstance
 		logger.Info("Reconciling the ingress and service resources " +
-			"on the active Ray cluster. No pending Ray cluster found.")
+			"on the active Ray cluster. No pending Ray cluster found or its promotion is held.")
 	} else {
 		rayClusterInstance = nil
 		logger.Info("No Ray cluster found. Skipping ingress and service reconciliation.")
@@ -208,20 +247,20 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	if rayClusterInstance != nil {
 		if err := r.reconcileServices(ctx, rayServiceInstance, rayClusterInstance, utils.HeadService); err != nil {
 			err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToUpdateService, err)
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, err
 		}
 		if err := r.labelHeadPodForServeStatus(ctx, rayClusterInstance); err != nil {
 			err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToUpdateServingPodLabel, err)
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, err
 		}
 		if err := r.reconcileServices(ctx, rayServiceInstance, rayClusterInstance, utils.ServingService); err != nil {
 			err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToUpdateService, err)
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+			return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, err
 		}
 	}
 
 	if err := r.calculateStatus(ctx, rayServiceInstance); err != nil {
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+		return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, err
 	}
 
 	// Final status update for any CR modification.
@@ -229,11 +268,31 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		rayServiceInstance.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
 		if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
 			logger.Error(errStatus, "Failed to update RayService status", "rayServiceInstance", rayServiceInstance)
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, errStatus
+			return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, errStatus
 		}
 	}
 
-	return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+	return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, nil
+}
+
+// reconcilePaused marks the RayService as paused without touching its RayClusters, Kubernetes services or
+// Serve applications. The reconciliation resumes once spec.paused is unset.
+func (r *RayServiceReconciler) reconcilePaused(ctx context.Context, originalRayServiceInstance *rayv1.RayService, rayServiceInstance *rayv1.RayService) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	rayServiceInstance.Status.ServiceStatus = rayv1.Paused
+	if !r.inconsistentRayServiceStatuses(ctx, originalRayServiceInstance.Status, rayServiceInstance.Status) {
+		return ctrl.Result{}, nil
+	}
+	rayServiceInstance.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
+	if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
+		logger.Error(errStatus, "Failed to update RayService status", "rayServiceInstance", rayServiceInstance)
+		return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, errStatus
+	}
+	if originalRayServiceInstance.Status.ServiceStatus != rayv1.Paused {
+		logger.Info("RayService is paused, skipping the reconciliation of its RayClusters")
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.PausedRayService), "Paused the reconciliation of RayService %s/%s", rayServiceInstance.Namespace, rayServiceInstance.Name)
+	}
+	return ctrl.Result{}, nil
 }
 
 func (r *RayServiceReconciler) calculateStatus(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
@@ -266,6 +325,11 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatus(ctx context.Context,
 		return true
 	}
 
+	if oldStatus.ConsecutiveHealthCheckFailures != newStatus.ConsecutiveHealthCheckFailures {
+		logger.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService ConsecutiveHealthCheckFailures changed from %d to %d", oldStatus.ConsecutiveHealthCheckFailures, newStatus.ConsecutiveHealthCheckFailures))
+		return true
+	}
+
 	if len(oldStatus.Applications) != len(newStatus.Applications) {
 		return true
 	}
@@ -284,6 +348,9 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatus(ctx context.Context,
 		} else if oldAppStatus.Message != newAppStatus.Message {
 			logger.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService application %s status message changed from %v to %v", appName, oldAppStatus.Message, newAppStatus.Message))
 			return true
+		} else if oldAppStatus.RoutePrefix != newAppStatus.RoutePrefix {
+			logger.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService application %s route prefix changed from %v to %v", appName, oldAppStatus.RoutePrefix, newAppStatus.RoutePrefix))
+			return true
 		}
 
 		if len(oldAppStatus.Deployments) != len(newAppStatus.Deployments) {
@@ -303,6 +370,10 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatus(ctx context.Context,
 			} else if oldDeploymentStatus.Message != newDeploymentStatus.Message {
 				logger.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService deployment status message changed from %v to %v", oldDeploymentStatus.Message, newDeploymentStatus.Message))
 				return true
+			} else if oldDeploymentStatus.RunningReplicas != newDeploymentStatus.RunningReplicas || oldDeploymentStatus.TargetReplicas != newDeploymentStatus.TargetReplicas {
+				logger.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService deployment %s replicas changed from %d/%d to %d/%d", deploymentName,
+					oldDeploymentStatus.RunningReplicas, oldDeploymentStatus.TargetReplicas, newDeploymentStatus.RunningReplicas, newDeploymentStatus.TargetReplicas))
+				return true
 			}
 		}
 	}
@@ -333,12 +404,22 @@ func (r *RayServiceReconciler) inconsistentRayServiceStatuses(ctx context.Contex
 		return true
 	}
 
+	if !reflect.DeepEqual(oldStatus.UpgradeStatus, newStatus.UpgradeStatus) {
+		logger.Info("inconsistentRayServiceStatus RayService UpgradeStatus changed", "old UpgradeStatus", oldStatus.UpgradeStatus, "new UpgradeStatus", newStatus.UpgradeStatus)
+		return true
+	}
+
+	if oldStatus.LastUpgradeDurationSeconds != newStatus.LastUpgradeDurationSeconds {
+		logger.Info(fmt.Sprintf("inconsistentRayServiceStatus RayService LastUpgradeDurationSeconds changed from %d to %d", oldStatus.LastUpgradeDurationSeconds, newStatus.LastUpgradeDurationSeconds))
+		return true
+	}
+
 	return false
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1.RayService{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.LabelChangedPredicate{},
@@ -346,9 +427,15 @@ func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 		))).
 		Owns(&rayv1.RayCluster{}).
 		Owns(&corev1.Service{}).
-		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.Ingress{})
+	if r.serveStatusPoller != nil {
+		// Reconcile a RayService as soon as the statuses of the Serve applications of one of its RayClusters change.
+		b = b.WatchesRawSource(&source.Channel{Source: r.serveStatusPoller.events}, &handler.EnqueueRequestForObject{})
+	}
+	return b.
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
+			RateLimiter:             utils.ErrorRateLimiter(),
 			LogConstructor: func(request *reconcile.Request) logr.Logger {
 				logger := ctrl.Log.WithName("controllers").WithName("RayService")
 				if request != nil {
@@ -405,20 +492,23 @@ func (r *RayServiceReconciler) reconcileRayCluster(ctx context.Context, rayServi
 		return nil, nil, err
 	}
 
+	if r.shouldAbandonPendingRayCluster(ctx, rayServiceInstance, activeRayCluster) {
+		// The pending RayCluster is deleted by cleanUpRayClusterInstance once it is no longer in the status.
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.AbandonedUpgrade),
+			"Abandoned the upgrade to RayCluster %s, the RayService config matches the active RayCluster %s", rayServiceInstance.Status.PendingServiceStatus.RayClusterName, activeRayCluster.Name)
+		rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
+		rayServiceInstance.Status.UpgradeStatus = nil
+		return activeRayCluster, nil, nil
+	}
+
 	clusterAction := r.shouldPrepareNewRayCluster(ctx, rayServiceInstance, activeRayCluster)
 	if clusterAction == RolloutNew {
-		// For LLM serving, some users might not have sufficient GPU resources to run two RayClusters simultaneously.
-		// Therefore, KubeRay offers ENABLE_ZERO_DOWNTIME as a feature flag for zero-downtime upgrades.
-		enableZeroDowntime := true
-		if s := os.Getenv(ENABLE_ZERO_DOWNTIME); strings.ToLower(s) == "false" {
-			enableZeroDowntime = false
-		}
-		if enableZeroDowntime || !enableZeroDowntime && activeRayCluster == nil {
+		if isZeroDowntimeUpgradeEnabled(rayServiceInstance) || activeRayCluster == nil {
 			// Add a pending cluster name. In the next reconcile loop, shouldPrepareNewRayCluster will return DoNothing and we will
 			// actually create the pending RayCluster instance.
 			r.markRestartAndAddPendingClusterName(ctx, rayServiceInstance)
 		} else {
-			logger.Info("Zero-downtime upgrade is disabled (ENABLE_ZERO_DOWNTIME: false). Skip preparing a new RayCluster.")
+			logger.Info("Zero-downtime upgrade is disabled. Skip preparing a new RayCluster.")
 		}
 		return activeRayCluster, nil, nil
 	} else if clusterAction == Update {
@@ -468,7 +558,11 @@ func (r *RayServiceReconciler) cleanUpRayClusterInstance(ctx context.Context, ra
 						"immediately.", cachedTimestamp, rayClusterInstance.Name)
 				}
 
-				if reasonForDeletion != "" {
+				if reasonForDeletion != "" && utils.IsDeletionProtected(&rayClusterInstance) {
+					logger.Info("The dangling RayCluster is protected from deletion; skipping its deletion", "RayCluster", rayClusterInstance.Name)
+					r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.DeletionProtectedRayCluster),
+						"Not deleting the dangling RayCluster %s/%s, which has the %s annotation", rayClusterInstance.Namespace, rayClusterInstance.Name, utils.RayDeletionProtectedAnnotationKey)
+				} else if reasonForDeletion != "" {
 					logger.Info("reconcileRayCluster", "delete Ray cluster", rayClusterInstance.Name, "reason", reasonForDeletion)
 					if err := r.Delete(ctx, &rayClusterInstance, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
 						return err
@@ -599,6 +693,43 @@ func (r *RayServiceReconciler) shouldPrepareNewRayCluster(ctx context.Context, r
 	return DoNothing
 }
 
+// shouldAbandonPendingRayCluster checks if the RayService config was reverted to the config of the active RayCluster
+// while a pending RayCluster is prepared, e.g. to roll back an upgrade whose promotion is held. The active RayCluster
+// then keeps serving traffic and the pending RayCluster is not promoted.
+func (r *RayServiceReconciler) shouldAbandonPendingRayCluster(ctx context.Context, rayServiceInstance *rayv1.RayService, activeRayCluster *rayv1.RayCluster) bool {
+	logger := ctrl.LoggerFrom(ctx)
+	if rayServiceInstance.Status.PendingServiceStatus.RayClusterName == "" || activeRayCluster == nil {
+		return false
+	}
+	if activeRayCluster.ObjectMeta.Annotations[utils.KubeRayVersion] != utils.KUBERAY_VERSION {
+		return false
+	}
+	goalClusterHash, err := generateHashWithoutReplicasAndWorkersToDelete(rayServiceInstance.Spec.RayClusterSpec)
+	if err != nil {
+		logger.Error(err, "Failed to serialize the goal RayCluster config.")
+		return false
+	}
+	return activeRayCluster.ObjectMeta.Annotations[utils.HashWithoutReplicasAndWorkersToDeleteKey] == goalClusterHash
+}
+
+// isUpgradePromotionHeld returns whether the pending RayCluster of a RayService must not be promoted, even once it
+// is ready. A RayService without active RayCluster has nothing to keep serving, so its promotion is never held.
+func isUpgradePromotionHeld(rayServiceInstance *rayv1.RayService) bool {
+	return rayServiceInstance.Annotations[utils.RayServiceHoldUpgradePromotionAnnotationKey] == "true" &&
+		rayServiceInstance.Status.ActiveServiceStatus.RayClusterName != ""
+}
+
+// isZeroDowntimeUpgradeEnabled returns whether a new RayCluster is prepared when the changes of RayClusterSpec need one.
+// For LLM serving, some users might not have sufficient GPU resources to run two RayClusters simultaneously, so
+// zero-downtime upgrades can be disabled with the UpgradeStrategy of the RayService, or for all RayServices with
+// ENABLE_ZERO_DOWNTIME. The UpgradeStrategy takes precedence.
+func isZeroDowntimeUpgradeEnabled(rayServiceInstance *rayv1.RayService) bool {
+	if strategy := rayServiceInstance.Spec.UpgradeStrategy; strategy != nil && strategy.Type != nil {
+		return *strategy.Type != rayv1.None
+	}
+	return strings.ToLower(os.Getenv(ENABLE_ZERO_DOWNTIME)) != "false"
+}
+
 // createRayClusterInstanceIfNeeded checks if we need to create a new RayCluster instance. If so, create one.
 func (r *RayServiceReconciler) createRayClusterInstanceIfNeeded(ctx context.Context, rayServiceInstance *rayv1.RayService, pendingRayCluster *rayv1.RayCluster) (*rayv1.RayCluster, error) {
 	logger := ctrl.LoggerFrom(ctx)
@@ -813,9 +944,10 @@ func (r *RayServiceReconciler) checkIfNeedSubmitServeDeployment(ctx context.Cont
 	return shouldUpdate
 }
 
-func (r *RayServiceReconciler) updateServeDeployment(ctx context.Context, rayServiceInstance *rayv1.RayService, rayDashboardClient utils.RayDashboardClientInterface, clusterName string) error {
+func (r *RayServiceReconciler) updateServeDeployment(ctx context.Context, rayServiceInstance *rayv1.RayService, rayDashboardClient utils.RayDashboardClientInterface, rayClusterInstance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
 	logger.Info("updateServeDeployment", "V2 config", rayServiceInstance.Spec.ServeConfigV2)
+	clusterName := rayClusterInstance.Name
 
 	serveConfig := make(map[string]interface{})
 	if err := yaml.Unmarshal([]byte(rayServiceInstance.Spec.ServeConfigV2), &serveConfig); err != nil {
@@ -827,6 +959,16 @@ func (r *RayServiceReconciler) updateServeDeployment(ctx context.Context, raySer
 		return fmt.Errorf("Failed to marshal converted serve config into bytes: %w", err)
 	}
 	logger.Info("updateServeDeployment", "MULTI_APP json config", string(configJson))
+
+	cacheKey := r.generateConfigKey(rayServiceInstance, clusterName)
+	if appliedServeConfigV2, exist := r.ServeConfigs.Get(cacheKey); exist && shouldRestartServe(ctx, appliedServeConfigV2, rayServiceInstance.Spec.ServeConfigV2, rayClusterInstance) {
+		logger.Info("The HTTP options or the proxy location of the Serve config changed; restarting Serve to apply them", "RayCluster", clusterName)
+		if err := rayDashboardClient.DeleteServeApplications(ctx); err != nil {
+			return fmt.Errorf("Failed to shut down Serve to apply the new HTTP options and proxy location: %w", err)
+		}
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.RestartedServe),
+			"Restarted Serve on cluster %s to apply the new HTTP options and proxy location", clusterName)
+	}
 	if err := rayDashboardClient.UpdateDeployments(ctx, configJson); err != nil {
 		err = fmt.Errorf(
 			"Fail to create / update Serve applications. If you observe this error consistently, "+
@@ -836,12 +978,38 @@ func (r *RayServiceReconciler) updateServeDeployment(ctx context.Context, raySer
 		return err
 	}
 
-	cacheKey := r.generateConfigKey(rayServiceInstance, clusterName)
 	r.ServeConfigs.Set(cacheKey, rayServiceInstance.Spec.ServeConfigV2)
 	logger.Info("updateServeDeployment", "message", fmt.Sprintf("Cached Serve config for Ray cluster %s with key %s", clusterName, cacheKey))
 	return nil
 }
 
+// shouldRestartServe returns true if the HTTP options or the proxy location of the Serve config differ from the ones of
+// the Serve config applied to the RayCluster. Serve ignores their changes while it is running, so they are applied by
+// restarting Serve rather than by replacing the RayCluster. A new HTTP port is only applied if it is the serve port of
+// the Ray container, because the serve Service would not reach Serve otherwise; it requires a new RayCluster.
+func shouldRestartServe(ctx context.Context, appliedServeConfigV2 string, serveConfigV2 string, rayClusterInstance *rayv1.RayCluster) bool {
+	logger := ctrl.LoggerFrom(ctx)
+	var appliedOptions, options utils.ServeGlobalOptions
+	if err := yaml.Unmarshal([]byte(appliedServeConfigV2), &appliedOptions); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &options); err != nil {
+		return false
+	}
+	if reflect.DeepEqual(appliedOptions, options) {
+		return false
+	}
+	if options.HTTPOptions != nil && options.HTTPOptions.Port != 0 {
+		rayContainer := &rayClusterInstance.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex]
+		if servePort := utils.FindContainerPort(rayContainer, utils.ServingPortName, utils.DefaultServingPort); int(options.HTTPOptions.Port) != servePort {
+			logger.Info("The new HTTP port of the Serve config is not the serve port of the Ray container; not restarting Serve",
+				"httpPort", options.HTTPOptions.Port, "servePort", servePort)
+			return false
+		}
+	}
+	return true
+}
+
 // `getAndCheckServeStatus` gets Serve applications' and deployments' statuses and check whether the
 // Serve applications are ready to serve incoming traffic or not. It returns two values:
 //
@@ -876,6 +1044,7 @@ func (r *RayServiceReconciler) getAndCheckServeStatus(ctx context.Context, dashb
 		applicationStatus := rayv1.AppStatus{
 			Message:              app.Message,
 			Status:               app.Status,
+			RoutePrefix:          app.RoutePrefix,
 			HealthLastUpdateTime: &timeNow,
 			Deployments:          make(map[string]rayv1.ServeDeploymentStatus),
 		}
@@ -903,6 +1072,8 @@ func (r *RayServiceReconciler) getAndCheckServeStatus(ctx context.Context, dashb
 			deploymentStatus := rayv1.ServeDeploymentStatus{
 				Status:               deployment.Status,
 				Message:              deployment.Message,
+				RunningReplicas:      deployment.RunningReplicas(),
+				TargetReplicas:       deployment.TargetNumReplicas,
 				HealthLastUpdateTime: &timeNow,
 			}
 
@@ -951,9 +1122,79 @@ func (r *RayServiceReconciler) updateRayClusterInfo(ctx context.Context, rayServ
 	logger := ctrl.LoggerFrom(ctx)
 	logger.Info("updateRayClusterInfo", "ActiveRayClusterName", rayServiceInstance.Status.ActiveServiceStatus.RayClusterName, "healthyClusterName", healthyClusterName)
 	if rayServiceInstance.Status.ActiveServiceStatus.RayClusterName != healthyClusterName {
+		if upgradeStatus := rayServiceInstance.Status.UpgradeStatus; upgradeStatus != nil && upgradeStatus.StartTime != nil {
+			rayServiceInstance.Status.LastUpgradeDurationSeconds = int32(time.Since(upgradeStatus.StartTime.Time).Seconds())
+		}
 		rayServiceInstance.Status.ActiveServiceStatus = rayServiceInstance.Status.PendingServiceStatus
 		rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
+		rayServiceInstance.Status.UpgradeStatus = nil
+	}
+}
+
+// updateUpgradeStatus reports the progress of the zero-downtime upgrade from the active to the pending RayCluster,
+// so that the rollout can be monitored without inspecting the Pods of both RayClusters.
+func (r *RayServiceReconciler) updateUpgradeStatus(ctx context.Context, rayServiceInstance *rayv1.RayService, activeRayClusterInstance *rayv1.RayCluster, pendingRayClusterInstance *rayv1.RayCluster) {
+	logger := ctrl.LoggerFrom(ctx)
+	var isHeadPodReady bool
+	if features.Enabled(features.RayClusterStatusConditions) {
+		isHeadPodReady = meta.IsStatusConditionTrue(pendingRayClusterInstance.Status.Conditions, string(rayv1.HeadPodReady))
+	} else {
+		var err error
+		if isHeadPodReady, err = r.isHeadPodRunningAndReady(ctx, pendingRayClusterInstance); err != nil {
+			logger.Info("Failed to check if the head Pod of the pending RayCluster is ready", "error", err.Error())
+		}
 	}
+	setUpgradeStatus(rayServiceInstance, activeRayClusterInstance.Name, pendingRayClusterInstance, isHeadPodReady)
+}
+
+// setUpgradeStatus sets the upgrade status of the RayService. The Serve application statuses of both RayClusters are
+// taken from the RayService status. The promotion time of a new upgrade is estimated from the duration of the last one.
+func setUpgradeStatus(rayServiceInstance *rayv1.RayService, activeRayClusterName string, pendingRayClusterInstance *rayv1.RayCluster, isPendingHeadPodReady bool) {
+	status := &rayServiceInstance.Status
+	if status.UpgradeStatus == nil || status.UpgradeStatus.PendingRayClusterName != pendingRayClusterInstance.Name {
+		startTime := pendingRayClusterInstance.CreationTimestamp
+		status.UpgradeStatus = &rayv1.RayServiceUpgradeStatus{
+			StartTime:             &startTime,
+			PendingRayClusterName: pendingRayClusterInstance.Name,
+		}
+		if status.LastUpgradeDurationSeconds > 0 {
+			estimatedPromotionTime := metav1.NewTime(startTime.Add(time.Duration(status.LastUpgradeDurationSeconds) * time.Second))
+			status.UpgradeStatus.EstimatedPromotionTime = &estimatedPromotionTime
+		}
+	}
+	upgradeStatus := status.UpgradeStatus
+	upgradeStatus.ActiveRayClusterName = activeRayClusterName
+	upgradeStatus.ActiveRayClusterReady = areServeApplicationsRunning(status.ActiveServiceStatus.Applications)
+
+	headPodCondition := metav1.Condition{
+		Type:    string(rayv1.PendingHeadPodReady),
+		Status:  metav1.ConditionTrue,
+		Reason:  rayv1.HeadPodRunningAndReady,
+		Message: fmt.Sprintf("The head Pod of RayCluster %s is ready", pendingRayClusterInstance.Name),
+	}
+	if !isPendingHeadPodReady {
+		headPodCondition.Status = metav1.ConditionFalse
+		headPodCondition.Reason = rayv1.HeadPodNotReady
+		headPodCondition.Message = fmt.Sprintf("Waiting for the head Pod of RayCluster %s to be ready", pendingRayClusterInstance.Name)
+	}
+	meta.SetStatusCondition(&upgradeStatus.Conditions, headPodCondition)
+
+	isServeReady := areServeApplicationsRunning(status.PendingServiceStatus.Applications)
+	serveCondition := metav1.Condition{
+		Type:    string(rayv1.PendingServeApplicationsReady),
+		Status:  metav1.ConditionTrue,
+		Reason:  rayv1.ServeApplicationsRunning,
+		Message: fmt.Sprintf("All Serve applications of RayCluster %s are running", pendingRayClusterInstance.Name),
+	}
+	if !isServeReady {
+		serveCondition.Status = metav1.ConditionFalse
+		serveCondition.Reason = rayv1.ServeApplicationsNotReady
+		serveCondition.Message = fmt.Sprintf("Waiting for all Serve applications of RayCluster %s to be running", pendingRayClusterInstance.Name)
+	}
+	meta.SetStatusCondition(&upgradeStatus.Conditions, serveCondition)
+
+	upgradeStatus.PendingRayClusterReady = isPendingHeadPodReady && isServeReady
+	upgradeStatus.PromotionHeld = isUpgradePromotionHeld(rayServiceInstance)
 }
 
 func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, serviceType utils.ServiceType) error {
@@ -1037,11 +1278,15 @@ func (r *RayServiceReconciler) updateStatusForActiveCluster(ctx context.Context,
 	if err := rayDashboardClient.InitClient(ctx, clientURL, rayClusterInstance); err != nil {
 		return err
 	}
+	setHealthCheckTimeout(rayDashboardClient, rayServiceInstance.Spec.HealthCheckPolicy)
+	rayDashboardClient = r.polledDashboardClient(ctx, rayServiceInstance, rayClusterInstance, clientURL, rayDashboardClient)
 
 	var isReady bool
 	if isReady, err = r.getAndCheckServeStatus(ctx, rayDashboardClient, rayServiceStatus); err != nil {
+		recordHealthCheckFailure(rayServiceInstance.Spec.HealthCheckPolicy, rayServiceStatus, err)
 		return err
 	}
+	rayServiceStatus.ConsecutiveHealthCheckFailures = 0
 
 	logger.Info("Check serve health", "isReady", isReady)
 
@@ -1052,7 +1297,6 @@ func (r *RayServiceReconciler) updateStatusForActiveCluster(ctx context.Context,
 // The `isReady` flag indicates whether the RayCluster is ready to handle incoming traffic.
 func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, isActive bool) (ctrl.Result, bool, error) {
 	logger := ctrl.LoggerFrom(ctx)
-	rayServiceInstance.Status.ActiveServiceStatus.RayClusterStatus = rayClusterInstance.Status
 	var err error
 	var clientURL string
 	var rayServiceStatus *rayv1.RayServiceStatus
@@ -1063,6 +1307,7 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 	} else {
 		rayServiceStatus = &rayServiceInstance.Status.PendingServiceStatus
 	}
+	rayServiceStatus.RayClusterStatus = rayClusterInstance.Status
 
 	// Check if head pod is running and ready. If not, requeue the resource event to avoid
 	// redundant custom resource status updates.
@@ -1076,7 +1321,7 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 	if features.Enabled(features.RayClusterStatusConditions) {
 		if !meta.IsStatusConditionTrue(rayClusterInstance.Status.Conditions, string(rayv1.HeadPodReady)) {
 			logger.Info("The head Pod is not ready, requeue the resource event to avoid redundant custom resource status updates.")
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, nil
+			return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, false, nil
 		}
 	} else {
 		if isRunningAndReady, err := r.isHeadPodRunningAndReady(ctx, rayClusterInstance); err != nil || !isRunningAndReady {
@@ -1085,26 +1330,28 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 			} else {
 				logger.Info("Skipping the update of Serve deployments because the Ray head Pod is not ready.")
 			}
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, err
+			return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, false, err
 		}
 	}
 
 	// TODO(architkulkarni): Check the RayVersion. If < 2.8.0, error.
 
 	if clientURL, err = utils.FetchHeadServiceURL(ctx, r.Client, rayClusterInstance, utils.DashboardPortName); err != nil || clientURL == "" {
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, err
+		return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, false, err
 	}
 
 	rayDashboardClient := r.dashboardClientFunc()
 	if err := rayDashboardClient.InitClient(ctx, clientURL, rayClusterInstance); err != nil {
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, err
+		return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, false, err
 	}
+	setHealthCheckTimeout(rayDashboardClient, rayServiceInstance.Spec.HealthCheckPolicy)
+	rayDashboardClient = r.polledDashboardClient(ctx, rayServiceInstance, rayClusterInstance, clientURL, rayDashboardClient)
 
 	shouldUpdate := r.checkIfNeedSubmitServeDeployment(ctx, rayServiceInstance, rayClusterInstance, rayServiceStatus)
 	if shouldUpdate {
-		if err = r.updateServeDeployment(ctx, rayServiceInstance, rayDashboardClient, rayClusterInstance.Name); err != nil {
+		if err = r.updateServeDeployment(ctx, rayServiceInstance, rayDashboardClient, rayClusterInstance); err != nil {
 			err = r.updateState(ctx, rayServiceInstance, rayv1.WaitForServeDeploymentReady, err)
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, err
+			return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, false, err
 		}
 
 		r.Recorder.Eventf(rayServiceInstance, "Normal", "SubmittedServeDeployment",
@@ -1113,25 +1360,104 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 
 	var isReady bool
 	if isReady, err = r.getAndCheckServeStatus(ctx, rayDashboardClient, rayServiceStatus); err != nil {
+		thresholdReached, backoff := recordHealthCheckFailure(rayServiceInstance.Spec.HealthCheckPolicy, rayServiceStatus, err)
+		if !thresholdReached {
+			// Slow-starting applications are retried with a backoff before the failure is reported.
+			logger.Info("The health check failed, retrying with backoff", "consecutiveFailures", rayServiceStatus.ConsecutiveHealthCheckFailures, "backoff", backoff.String(), "error", err.Error())
+			if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
+				return ctrl.Result{RequeueAfter: backoff}, false, errStatus
+			}
+			return ctrl.Result{RequeueAfter: backoff}, false, err
+		}
 		err = r.updateState(ctx, rayServiceInstance, rayv1.FailedToGetServeDeploymentStatus, err)
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, err
+		return ctrl.Result{RequeueAfter: backoff}, false, err
 	}
+	rayServiceStatus.ConsecutiveHealthCheckFailures = 0
 
 	logger.Info("Check serve health", "isReady", isReady, "isActive", isActive)
 
-	if isReady {
+	if isReady && !isActive && isUpgradePromotionHeld(rayServiceInstance) {
+		// The active RayCluster keeps serving traffic until the hold is removed.
+		rayServiceInstance.Status.ServiceStatus = rayv1.Running
+		logger.Info("The pending RayCluster is ready, but its promotion is held", "rayCluster", rayClusterInstance.Name)
+		if upgradeStatus := rayServiceInstance.Status.UpgradeStatus; upgradeStatus == nil || !upgradeStatus.PendingRayClusterReady {
+			r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.HeldUpgrade),
+				"RayCluster %s is ready, its promotion is held until the %s annotation is removed", rayClusterInstance.Name, utils.RayServiceHoldUpgradePromotionAnnotationKey)
+		}
+	} else if isReady {
 		rayServiceInstance.Status.ServiceStatus = rayv1.Running
 		r.updateRayClusterInfo(ctx, rayServiceInstance, rayClusterInstance.Name)
 		r.Recorder.Event(rayServiceInstance, "Normal", "Running", "The Serve application is now running and healthy.")
 	} else {
 		rayServiceInstance.Status.ServiceStatus = rayv1.WaitForServeDeploymentReady
 		if err := r.Status().Update(ctx, rayServiceInstance); err != nil {
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, false, err
+			return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, false, err
 		}
 		logger.Info("Mark cluster as waiting for Serve deployments", "rayCluster", rayClusterInstance)
 	}
 
-	return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, isReady, nil
+	return ctrl.Result{RequeueAfter: utils.RayServiceRequeueDuration()}, isReady, nil
+}
+
+// polledDashboardClient returns a dashboard client whose Serve application statuses are read from the status
+// poller, or the given client if the reconciler has no status poller.
+func (r *RayServiceReconciler) polledDashboardClient(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, clientURL string, dashboardClient utils.RayDashboardClientInterface) utils.RayDashboardClientInterface {
+	if r.serveStatusPoller == nil {
+		return dashboardClient
+	}
+	return r.serveStatusPoller.dashboardClient(ctx, rayServiceInstance, rayClusterInstance, clientURL, dashboardClient)
+}
+
+// setHealthCheckTimeout applies the timeout of the health check policy to the dashboard client.
+func setHealthCheckTimeout(dashboardClient utils.RayDashboardClientInterface, policy *rayv1.RayServiceHealthCheckPolicy) {
+	timeoutSetter, ok := dashboardClient.(utils.RayDashboardClientTimeoutSetter)
+	if !ok {
+		return
+	}
+	timeoutSeconds := DefaultHealthCheckTimeoutSeconds
+	if policy != nil && policy.TimeoutSeconds != nil {
+		timeoutSeconds = *policy.TimeoutSeconds
+	}
+	timeoutSetter.SetTimeout(time.Duration(timeoutSeconds) * time.Second)
+}
+
+// recordHealthCheckFailure records a failed health check in the status of the RayCluster. It returns whether the
+// failure threshold of the health check policy is reached and the delay before the health check is retried.
+func recordHealthCheckFailure(policy *rayv1.RayServiceHealthCheckPolicy, rayServiceStatus *rayv1.RayServiceStatus, err error) (bool, time.Duration) {
+	failureThreshold := DefaultHealthCheckFailureThreshold
+	initialBackoffSeconds := DefaultHealthCheckInitialBackoffSeconds
+	maxBackoffSeconds := DefaultHealthCheckMaxBackoffSeconds
+	if policy != nil {
+		if policy.FailureThreshold != nil {
+			failureThreshold = *policy.FailureThreshold
+		}
+		if policy.InitialBackoffSeconds != nil {
+			initialBackoffSeconds = *policy.InitialBackoffSeconds
+		}
+		if policy.MaxBackoffSeconds != nil {
+			maxBackoffSeconds = *policy.MaxBackoffSeconds
+		}
+	}
+
+	rayServiceStatus.ConsecutiveHealthCheckFailures++
+	rayServiceStatus.HealthCheckFailures = append(rayServiceStatus.HealthCheckFailures, rayv1.HealthCheckFailure{
+		Time:    metav1.Now(),
+		Message: err.Error(),
+	})
+	if overflow := len(rayServiceStatus.HealthCheckFailures) - MaxHealthCheckFailureHistory; overflow > 0 {
+		rayServiceStatus.HealthCheckFailures = rayServiceStatus.HealthCheckFailures[overflow:]
+	}
+
+	// The backoff doubles with every consecutive failure and is capped at the maximum backoff.
+	backoff := time.Duration(initialBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(maxBackoffSeconds) * time.Second
+	for i := int32(1); i < rayServiceStatus.ConsecutiveHealthCheckFailures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return rayServiceStatus.ConsecutiveHealthCheckFailures >= failureThreshold, backoff
 }
 
 func (r *RayServiceReconciler) labelHeadPodForServeStatus(ctx context.Context, rayClusterInstance *rayv1.RayCluster) error {
@@ -1248,6 +1574,19 @@ func (r *RayServiceReconciler) isHeadPodRunningAndReady(ctx context.Context, ins
 	return utils.IsRunningAndReady(headPod), nil
 }
 
+// areServeApplicationsRunning returns whether there are Serve applications and all of them are running.
+func areServeApplicationsRunning(applications map[string]rayv1.AppStatus) bool {
+	if len(applications) == 0 {
+		return false
+	}
+	for _, app := range applications {
+		if app.Status != rayv1.ApplicationStatusEnum.RUNNING {
+			return false
+		}
+	}
+	return true
+}
+
 func isServeAppUnhealthyOrDeployedFailed(appStatus string) bool {
 	return appStatus == rayv1.ApplicationStatusEnum.UNHEALTHY || appStatus == rayv1.ApplicationStatusEnum.DEPLOY_FAILED
 }
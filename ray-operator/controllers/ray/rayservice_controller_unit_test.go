@@ -12,10 +12,12 @@ import (
 	cmap "github.com/orcaman/concurrent-map/v2"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -252,6 +254,20 @@ func TestInconsistentRayServiceStatus(t *testing.T) {
 		newStatus.Applications[appName] = application
 	}
 	assert.False(t, r.inconsistentRayServiceStatus(ctx, oldStatus, *newStatus))
+
+	// Test 2: The number of running replicas of a deployment changes.
+	newStatus = oldStatus.DeepCopy()
+	deployment := newStatus.Applications["app1"].Deployments["serve-1"]
+	deployment.RunningReplicas = 2
+	newStatus.Applications["app1"].Deployments["serve-1"] = deployment
+	assert.True(t, r.inconsistentRayServiceStatus(ctx, oldStatus, *newStatus))
+
+	// Test 3: The route prefix of an application changes.
+	newStatus = oldStatus.DeepCopy()
+	application := newStatus.Applications["app2"]
+	application.RoutePrefix = "/app2"
+	newStatus.Applications["app2"] = application
+	assert.True(t, r.inconsistentRayServiceStatus(ctx, oldStatus, *newStatus))
 }
 
 func TestIsHeadPodRunningAndReady(t *testing.T) {
@@ -709,6 +725,187 @@ applications:
 	assert.True(t, shouldCreate)
 }
 
+func TestShouldRestartServe(t *testing.T) {
+	cluster := &rayv1.RayCluster{
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "ray-head", Ports: []corev1.ContainerPort{{Name: utils.ServingPortName, ContainerPort: 8001}}},
+						},
+					},
+				},
+			},
+		},
+	}
+	appliedServeConfigV2 := `
+http_options:
+  host: 0.0.0.0
+  port: 8001
+applications:
+- name: myapp
+  import_path: fruit.deployment_graph`
+
+	tests := []struct {
+		name          string
+		serveConfigV2 string
+		shouldRestart bool
+	}{
+		{
+			name: "Only the applications changed",
+			serveConfigV2: `
+http_options:
+  host: 0.0.0.0
+  port: 8001
+applications:
+- name: new_app_name
+  import_path: fruit.deployment_graph`,
+			shouldRestart: false,
+		},
+		{
+			name: "The request timeout changed",
+			serveConfigV2: `
+http_options:
+  host: 0.0.0.0
+  port: 8001
+  request_timeout_s: 30
+applications:
+- name: myapp
+  import_path: fruit.deployment_graph`,
+			shouldRestart: true,
+		},
+		{
+			name: "The proxy location changed",
+			serveConfigV2: `
+proxy_location: HeadOnly
+http_options:
+  host: 0.0.0.0
+  port: 8001
+applications:
+- name: myapp
+  import_path: fruit.deployment_graph`,
+			shouldRestart: true,
+		},
+		{
+			name: "The port is not the serve port of the Ray container",
+			serveConfigV2: `
+http_options:
+  host: 0.0.0.0
+  port: 9000
+applications:
+- name: myapp
+  import_path: fruit.deployment_graph`,
+			shouldRestart: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.shouldRestart, shouldRestartServe(context.TODO(), appliedServeConfigV2, tc.serveConfigV2, cluster))
+		})
+	}
+}
+
+func TestSetUpgradeStatus(t *testing.T) {
+	startTime := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	pendingCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "pending-cluster",
+			CreationTimestamp: startTime,
+		},
+	}
+	rayService := &rayv1.RayService{
+		Status: rayv1.RayServiceStatuses{
+			ActiveServiceStatus: rayv1.RayServiceStatus{
+				RayClusterName: "active-cluster",
+				Applications: map[string]rayv1.AppStatus{
+					utils.DefaultServeAppName: {Status: rayv1.ApplicationStatusEnum.RUNNING},
+				},
+			},
+			PendingServiceStatus: rayv1.RayServiceStatus{
+				RayClusterName: "pending-cluster",
+				Applications: map[string]rayv1.AppStatus{
+					utils.DefaultServeAppName: {Status: rayv1.ApplicationStatusEnum.DEPLOYING},
+				},
+			},
+			LastUpgradeDurationSeconds: 300,
+		},
+	}
+
+	// The head Pod of the pending RayCluster is not ready yet.
+	setUpgradeStatus(rayService, "active-cluster", pendingCluster, false)
+	upgradeStatus := rayService.Status.UpgradeStatus
+	assert.NotNil(t, upgradeStatus)
+	assert.Equal(t, "active-cluster", upgradeStatus.ActiveRayClusterName)
+	assert.Equal(t, "pending-cluster", upgradeStatus.PendingRayClusterName)
+	assert.True(t, upgradeStatus.ActiveRayClusterReady)
+	assert.False(t, upgradeStatus.PendingRayClusterReady)
+	assert.Equal(t, startTime, *upgradeStatus.StartTime)
+	assert.Equal(t, startTime.Add(5*time.Minute), upgradeStatus.EstimatedPromotionTime.Time)
+	assert.True(t, meta.IsStatusConditionFalse(upgradeStatus.Conditions, string(rayv1.PendingHeadPodReady)))
+	assert.True(t, meta.IsStatusConditionFalse(upgradeStatus.Conditions, string(rayv1.PendingServeApplicationsReady)))
+
+	// The head Pod is ready and the Serve applications are running.
+	rayService.Status.PendingServiceStatus.Applications[utils.DefaultServeAppName] = rayv1.AppStatus{Status: rayv1.ApplicationStatusEnum.RUNNING}
+	setUpgradeStatus(rayService, "active-cluster", pendingCluster, true)
+	upgradeStatus = rayService.Status.UpgradeStatus
+	assert.True(t, upgradeStatus.PendingRayClusterReady)
+	assert.True(t, meta.IsStatusConditionTrue(upgradeStatus.Conditions, string(rayv1.PendingHeadPodReady)))
+	assert.True(t, meta.IsStatusConditionTrue(upgradeStatus.Conditions, string(rayv1.PendingServeApplicationsReady)))
+
+	assert.False(t, upgradeStatus.PromotionHeld)
+
+	// The promotion is held while the RayService has the annotation.
+	rayService.Annotations = map[string]string{utils.RayServiceHoldUpgradePromotionAnnotationKey: "true"}
+	setUpgradeStatus(rayService, "active-cluster", pendingCluster, true)
+	assert.True(t, rayService.Status.UpgradeStatus.PromotionHeld)
+	rayService.Annotations = nil
+
+	// The promotion of the pending RayCluster records the duration of the upgrade.
+	r := &RayServiceReconciler{}
+	r.updateRayClusterInfo(context.Background(), rayService, "pending-cluster")
+	assert.Nil(t, rayService.Status.UpgradeStatus)
+	assert.Equal(t, "pending-cluster", rayService.Status.ActiveServiceStatus.RayClusterName)
+	assert.InDelta(t, 60, rayService.Status.LastUpgradeDurationSeconds, 5)
+}
+
+func TestRecordHealthCheckFailure(t *testing.T) {
+	healthCheckErr := fmt.Errorf("context deadline exceeded")
+
+	// Without a policy, the first failure is reported.
+	status := rayv1.RayServiceStatus{}
+	thresholdReached, backoff := recordHealthCheckFailure(nil, &status, healthCheckErr)
+	assert.True(t, thresholdReached)
+	assert.Equal(t, utils.RayServiceRequeueDuration(), backoff)
+	assert.Equal(t, int32(1), status.ConsecutiveHealthCheckFailures)
+	assert.Len(t, status.HealthCheckFailures, 1)
+	assert.Equal(t, healthCheckErr.Error(), status.HealthCheckFailures[0].Message)
+
+	// The failures below the threshold are retried with an exponential backoff.
+	policy := &rayv1.RayServiceHealthCheckPolicy{
+		FailureThreshold:      ptr.To[int32](4),
+		InitialBackoffSeconds: ptr.To[int32](5),
+		MaxBackoffSeconds:     ptr.To[int32](15),
+	}
+	status = rayv1.RayServiceStatus{}
+	expectedBackoffs := []time.Duration{5 * time.Second, 10 * time.Second, 15 * time.Second, 15 * time.Second}
+	for i, expectedBackoff := range expectedBackoffs {
+		thresholdReached, backoff = recordHealthCheckFailure(policy, &status, healthCheckErr)
+		assert.Equal(t, i == len(expectedBackoffs)-1, thresholdReached)
+		assert.Equal(t, expectedBackoff, backoff)
+	}
+	assert.Equal(t, int32(4), status.ConsecutiveHealthCheckFailures)
+
+	// Only the most recent failures are kept.
+	for i := 0; i < MaxHealthCheckFailureHistory; i++ {
+		recordHealthCheckFailure(policy, &status, fmt.Errorf("failure %d", i))
+	}
+	assert.Len(t, status.HealthCheckFailures, MaxHealthCheckFailureHistory)
+	assert.Equal(t, "failure 0", status.HealthCheckFailures[0].Message)
+	assert.Equal(t, fmt.Sprintf("failure %d", MaxHealthCheckFailureHistory-1), status.HealthCheckFailures[MaxHealthCheckFailureHistory-1].Message)
+}
+
 func TestReconcileRayCluster(t *testing.T) {
 	defer os.Unsetenv(ENABLE_ZERO_DOWNTIME)
 	// Create a new scheme with CRDs schemes.
@@ -741,6 +938,7 @@ func TestReconcileRayCluster(t *testing.T) {
 
 	tests := map[string]struct {
 		activeCluster           *rayv1.RayCluster
+		upgradeType             *rayv1.RayServiceUpgradeType
 		kubeRayVersion          string
 		updateRayClusterSpec    bool
 		enableZeroDowntime      bool
@@ -793,6 +991,22 @@ func TestReconcileRayCluster(t *testing.T) {
 			updateKubeRayVersion:    true,
 			kubeRayVersion:          "new-version",
 		},
+		// Test 7: The UpgradeStrategy of the RayService disables the zero-downtime upgrade although ENABLE_ZERO_DOWNTIME enables it.
+		"Upgrade type is None. The active cluster exists. Skip the zero-downtime upgrade.": {
+			activeCluster:           activeCluster.DeepCopy(),
+			upgradeType:             ptr.To(rayv1.None),
+			updateRayClusterSpec:    true,
+			enableZeroDowntime:      true,
+			shouldPrepareNewCluster: false,
+		},
+		// Test 8: The UpgradeStrategy of the RayService enables the zero-downtime upgrade although ENABLE_ZERO_DOWNTIME disables it.
+		"Upgrade type is NewCluster. The active cluster exists. Trigger the zero-downtime upgrade.": {
+			activeCluster:           activeCluster.DeepCopy(),
+			upgradeType:             ptr.To(rayv1.NewCluster),
+			updateRayClusterSpec:    true,
+			enableZeroDowntime:      false,
+			shouldPrepareNewCluster: true,
+		},
 	}
 
 	for name, tc := range tests {
@@ -816,6 +1030,9 @@ func TestReconcileRayCluster(t *testing.T) {
 				Scheme: newScheme,
 			}
 			service := rayService.DeepCopy()
+			if tc.upgradeType != nil {
+				service.Spec.UpgradeStrategy = &rayv1.RayServiceUpgradeStrategy{Type: tc.upgradeType}
+			}
 			if tc.updateRayClusterSpec {
 				service.Spec.RayClusterSpec.RayVersion = "new-version"
 			}
@@ -841,9 +1058,145 @@ func TestReconcileRayCluster(t *testing.T) {
 	}
 }
 
+func TestIsUpgradePromotionHeld(t *testing.T) {
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{utils.RayServiceHoldUpgradePromotionAnnotationKey: "true"},
+		},
+	}
+	// A RayService without active RayCluster has nothing to keep serving.
+	assert.False(t, isUpgradePromotionHeld(rayService))
+
+	rayService.Status.ActiveServiceStatus.RayClusterName = "active-cluster"
+	assert.True(t, isUpgradePromotionHeld(rayService))
+
+	rayService.Annotations[utils.RayServiceHoldUpgradePromotionAnnotationKey] = "false"
+	assert.False(t, isUpgradePromotionHeld(rayService))
+}
+
+func TestReconcileRayClusterAbandonsPendingCluster(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	ctx := context.TODO()
+	namespace := "ray"
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+		Status: rayv1.RayServiceStatuses{
+			ActiveServiceStatus:  rayv1.RayServiceStatus{RayClusterName: "active-cluster"},
+			PendingServiceStatus: rayv1.RayServiceStatus{RayClusterName: "pending-cluster"},
+			UpgradeStatus:        &rayv1.RayServiceUpgradeStatus{PendingRayClusterName: "pending-cluster"},
+		},
+	}
+	hash, err := generateHashWithoutReplicasAndWorkersToDelete(rayService.Spec.RayClusterSpec)
+	assert.Nil(t, err)
+	activeCluster := rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "active-cluster",
+			Namespace: namespace,
+			Annotations: map[string]string{
+				utils.HashWithoutReplicasAndWorkersToDeleteKey: hash,
+				utils.NumWorkerGroupsKey:                       "0",
+				utils.KubeRayVersion:                           utils.KUBERAY_VERSION,
+			},
+		},
+	}
+	pendingCluster := rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pending-cluster",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayClusterSpec{RayVersion: "new-version"},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(activeCluster.DeepCopy(), pendingCluster.DeepCopy()).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := RayServiceReconciler{
+		Client:                       fakeClient,
+		Scheme:                       newScheme,
+		Recorder:                     recorder,
+		RayClusterDeletionTimestamps: cmap.New[time.Time](),
+	}
+
+	// The RayService is upgraded: the pending RayCluster is kept.
+	service := rayService.DeepCopy()
+	service.Spec.RayClusterSpec.RayVersion = "new-version"
+	activeRayCluster, pendingRayCluster, err := r.reconcileRayCluster(ctx, service)
+	assert.Nil(t, err)
+	assert.Equal(t, "active-cluster", activeRayCluster.Name)
+	assert.Equal(t, "pending-cluster", pendingRayCluster.Name)
+	assert.Equal(t, "pending-cluster", service.Status.PendingServiceStatus.RayClusterName)
+
+	// The RayService is reverted to the config of the active RayCluster: the upgrade is abandoned.
+	service = rayService.DeepCopy()
+	activeRayCluster, pendingRayCluster, err = r.reconcileRayCluster(ctx, service)
+	assert.Nil(t, err)
+	assert.Equal(t, "active-cluster", activeRayCluster.Name)
+	assert.Nil(t, pendingRayCluster)
+	assert.Equal(t, "", service.Status.PendingServiceStatus.RayClusterName)
+	assert.Nil(t, service.Status.UpgradeStatus)
+	assert.Contains(t, <-recorder.Events, string(utils.AbandonedUpgrade))
+}
+
 func initFakeDashboardClient(appName string, deploymentStatus string, appStatus string) utils.RayDashboardClientInterface {
 	fakeDashboardClient := utils.FakeRayDashboardClient{}
 	status := generateServeStatus(deploymentStatus, appStatus)
 	fakeDashboardClient.SetMultiApplicationStatuses(map[string]*utils.ServeApplicationStatus{appName: &status})
 	return &fakeDashboardClient
 }
+
+func TestReconcilePausedRayService(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	ctx := context.TODO()
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: "ray",
+		},
+		Spec: rayv1.RayServiceSpec{
+			Paused: true,
+		},
+		Status: rayv1.RayServiceStatuses{
+			ServiceStatus: rayv1.Running,
+			ActiveServiceStatus: rayv1.RayServiceStatus{
+				RayClusterName: "active-cluster",
+			},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayService).WithStatusSubresource(rayService).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := RayServiceReconciler{
+		Client:       fakeClient,
+		Scheme:       newScheme,
+		Recorder:     recorder,
+		ServeConfigs: cmap.New[string](),
+	}
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rayService)}
+
+	// The active RayCluster does not exist, but no RayCluster is created while the RayService is paused.
+	result, err := r.Reconcile(ctx, request)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+
+	rayClusterList := rayv1.RayClusterList{}
+	err = fakeClient.List(ctx, &rayClusterList, client.InNamespace(rayService.Namespace))
+	assert.Nil(t, err)
+	assert.Empty(t, rayClusterList.Items)
+
+	updatedRayService := &rayv1.RayService{}
+	err = fakeClient.Get(ctx, request.NamespacedName, updatedRayService)
+	assert.Nil(t, err)
+	assert.Equal(t, rayv1.Paused, updatedRayService.Status.ServiceStatus)
+	assert.Equal(t, "active-cluster", updatedRayService.Status.ActiveServiceStatus.RayClusterName)
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, string(utils.PausedRayService))
+
+	// Reconciling the paused RayService again is a no-op.
+	_, err = r.Reconcile(ctx, request)
+	assert.Nil(t, err)
+	assert.Empty(t, recorder.Events)
+}
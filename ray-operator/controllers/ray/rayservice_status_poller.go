@@ -0,0 +1,228 @@
+package ray
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// serveStatusPollJitterFactor spreads the polls of the workers, so that the dashboards of a large fleet of
+	// RayServices are not all polled at the same time.
+	serveStatusPollJitterFactor = 0.2
+	// serveStatusWorkerIdleTimeout is how long a worker keeps polling a RayCluster whose statuses are not read.
+	serveStatusWorkerIdleTimeout = 5 * time.Minute
+	// serveStatusEventBufferSize is the buffer size of the channel of the reconcile requests of the poller.
+	serveStatusEventBufferSize = 1024
+)
+
+// serveStatusPoller polls the statuses of the Serve applications of the RayClusters of the RayServices in the
+// background, with one worker per RayCluster, instead of calling the dashboard in every reconciliation. The
+// reconciliations read the last statuses polled by the workers, and a RayService is reconciled as soon as the
+// statuses of one of its RayClusters change.
+type serveStatusPoller struct {
+	// ctx is the context of the workers. The workers stop when it is done.
+	ctx                 context.Context
+	dashboardClientFunc func() utils.RayDashboardClientInterface
+	// interval is how often the workers poll the statuses, before the jitter.
+	interval time.Duration
+	// events receives a generic event for a RayService whenever the statuses of one of its RayClusters change.
+	events chan event.GenericEvent
+
+	mu sync.Mutex
+	// workers are the workers, by RayCluster.
+	workers map[types.NamespacedName]*serveStatusWorker
+}
+
+// serveStatusWorker polls the statuses of the Serve applications of a RayCluster.
+type serveStatusWorker struct {
+	rayService types.NamespacedName
+	rayCluster types.UID
+	clientURL  string
+	interval   time.Duration
+	cancel     context.CancelFunc
+
+	mu       sync.Mutex
+	result   *serveStatusResult
+	lastRead time.Time
+}
+
+// serveStatusResult is the result of a poll of the statuses of the Serve applications of a RayCluster.
+type serveStatusResult struct {
+	statuses map[string]*utils.ServeApplicationStatus
+	err      error
+	time     time.Time
+}
+
+func newServeStatusPoller(ctx context.Context, dashboardClientFunc func() utils.RayDashboardClientInterface) *serveStatusPoller {
+	return &serveStatusPoller{
+		ctx:                 ctx,
+		dashboardClientFunc: dashboardClientFunc,
+		interval:            utils.ServeStatusPollDuration(),
+		events:              make(chan event.GenericEvent, serveStatusEventBufferSize),
+		workers:             map[types.NamespacedName]*serveStatusWorker{},
+	}
+}
+
+// polledDashboardClient wraps a dashboard client of a RayCluster, so that the statuses of the Serve applications
+// are read from the worker polling the RayCluster. All other calls go to the wrapped client.
+type polledDashboardClient struct {
+	utils.RayDashboardClientInterface
+	worker *serveStatusWorker
+}
+
+func (c *polledDashboardClient) GetMultiApplicationStatus(ctx context.Context) (map[string]*utils.ServeApplicationStatus, error) {
+	return c.worker.statuses(ctx, c.RayDashboardClientInterface)
+}
+
+// UpdateDeployments updates the Serve applications and discards the polled statuses, which predate the update.
+func (c *polledDashboardClient) UpdateDeployments(ctx context.Context, configJson []byte) error {
+	if err := c.RayDashboardClientInterface.UpdateDeployments(ctx, configJson); err != nil {
+		return err
+	}
+	c.worker.mu.Lock()
+	defer c.worker.mu.Unlock()
+	c.worker.result = nil
+	return nil
+}
+
+// dashboardClient returns a dashboard client of a RayCluster whose Serve application statuses are polled in the
+// background. It starts a worker for the RayCluster if there is none yet, or if the RayCluster or its head service
+// URL changed. The client falls back to the given client if the worker can not be started.
+func (p *serveStatusPoller) dashboardClient(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, clientURL string, dashboardClient utils.RayDashboardClientInterface) utils.RayDashboardClientInterface {
+	logger := ctrl.LoggerFrom(ctx)
+	key := types.NamespacedName{Namespace: rayClusterInstance.Namespace, Name: rayClusterInstance.Name}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	worker, ok := p.workers[key]
+	if ok && worker.rayCluster == rayClusterInstance.UID && worker.clientURL == clientURL {
+		return &polledDashboardClient{RayDashboardClientInterface: dashboardClient, worker: worker}
+	}
+	if ok {
+		worker.cancel()
+		delete(p.workers, key)
+	}
+
+	// The worker has its own client, the client of the reconciliation is not shared across goroutines.
+	workerClient := p.dashboardClientFunc()
+	if err := workerClient.InitClient(ctx, clientURL, rayClusterInstance.DeepCopy()); err != nil {
+		logger.Error(err, "Failed to start polling the Serve application statuses", "RayCluster", key)
+		return dashboardClient
+	}
+	setHealthCheckTimeout(workerClient, rayServiceInstance.Spec.HealthCheckPolicy)
+
+	workerCtx, cancel := context.WithCancel(p.ctx)
+	worker = &serveStatusWorker{
+		rayService: types.NamespacedName{Namespace: rayServiceInstance.Namespace, Name: rayServiceInstance.Name},
+		rayCluster: rayClusterInstance.UID,
+		clientURL:  clientURL,
+		interval:   p.interval,
+		cancel:     cancel,
+		lastRead:   time.Now(),
+	}
+	p.workers[key] = worker
+	logger.Info("Start polling the Serve application statuses", "RayCluster", key, "interval", p.interval)
+	go p.run(workerCtx, key, worker, workerClient)
+	return &polledDashboardClient{RayDashboardClientInterface: dashboardClient, worker: worker}
+}
+
+// run polls the statuses of the Serve applications of a RayCluster until the worker is stopped or idle.
+func (p *serveStatusPoller) run(ctx context.Context, key types.NamespacedName, worker *serveStatusWorker, dashboardClient utils.RayDashboardClientInterface) {
+	logger := ctrl.Log.WithName("controllers").WithName("RayService").WithValues("RayService", worker.rayService, "RayCluster", key)
+	wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		if worker.idle() {
+			logger.Info("Stop polling the Serve application statuses of an idle RayCluster")
+			p.stopWorker(key, worker)
+			return
+		}
+		statuses, err := dashboardClient.GetMultiApplicationStatus(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if worker.record(statuses, err) {
+			p.notify(worker.rayService)
+		}
+	}, worker.interval, serveStatusPollJitterFactor, true)
+}
+
+// notify requests a reconciliation of a RayService. The request is dropped if the channel is full, the RayService
+// is requeued periodically anyway.
+func (p *serveStatusPoller) notify(rayService types.NamespacedName) {
+	select {
+	case p.events <- event.GenericEvent{Object: &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: rayService.Namespace, Name: rayService.Name},
+	}}:
+	default:
+	}
+}
+
+// stopWorker stops a worker, unless it was already replaced.
+func (p *serveStatusPoller) stopWorker(key types.NamespacedName, worker *serveStatusWorker) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	worker.cancel()
+	if p.workers[key] == worker {
+		delete(p.workers, key)
+	}
+}
+
+// cleanUp stops the workers of the RayClusters of a RayService, except the workers of the given RayClusters, e.g.
+// the active and pending RayClusters. Without RayClusters, e.g. because the RayService is deleted, all its workers stop.
+func (p *serveStatusPoller) cleanUp(rayService types.NamespacedName, rayClusterNames ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, worker := range p.workers {
+		if worker.rayService != rayService || slices.Contains(rayClusterNames, key.Name) {
+			continue
+		}
+		worker.cancel()
+		delete(p.workers, key)
+	}
+}
+
+// statuses returns the last statuses polled by the worker. If the worker has not polled the statuses yet, or the
+// last poll is outdated, e.g. because the dashboard is slow to respond, the statuses are fetched with the given client.
+func (w *serveStatusWorker) statuses(ctx context.Context, dashboardClient utils.RayDashboardClientInterface) (map[string]*utils.ServeApplicationStatus, error) {
+	w.mu.Lock()
+	w.lastRead = time.Now()
+	result := w.result
+	w.mu.Unlock()
+	// A poll is outdated once the worker missed it, considering the jitter.
+	if result != nil && time.Since(result.time) < 2*w.interval {
+		return result.statuses, result.err
+	}
+
+	statuses, err := dashboardClient.GetMultiApplicationStatus(ctx)
+	w.record(statuses, err)
+	return statuses, err
+}
+
+// record records the result of a poll. It returns whether the statuses changed since the previous poll.
+func (w *serveStatusWorker) record(statuses map[string]*utils.ServeApplicationStatus, err error) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	previous := w.result
+	w.result = &serveStatusResult{statuses: statuses, err: err, time: time.Now()}
+	if previous == nil {
+		return false
+	}
+	return (previous.err == nil) != (err == nil) || !reflect.DeepEqual(previous.statuses, statuses)
+}
+
+func (w *serveStatusWorker) idle() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return time.Since(w.lastRead) > serveStatusWorkerIdleTimeout
+}
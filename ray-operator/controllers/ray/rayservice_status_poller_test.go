@@ -0,0 +1,76 @@
+package ray
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestServeStatusPoller(t *testing.T) {
+	// The workers poll once when they start, and then not again during the test.
+	utils.SetRequeuePolicy(utils.RequeuePolicy{ServeStatusPollInterval: metav1.Duration{Duration: time.Hour}})
+	defer utils.SetRequeuePolicy(utils.RequeuePolicy{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	poller := newServeStatusPoller(ctx, func() utils.RayDashboardClientInterface {
+		return initFakeDashboardClient("app", rayv1.DeploymentStatusEnum.HEALTHY, rayv1.ApplicationStatusEnum.RUNNING)
+	})
+	rayService := &rayv1.RayService{ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "ray"}}
+	rayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "ray", UID: "uid-1"}}
+	clusterKey := types.NamespacedName{Namespace: "ray", Name: "cluster"}
+	serviceKey := types.NamespacedName{Namespace: "ray", Name: "service"}
+
+	// The first read is served by the client of the reconciliation if the worker has not polled yet.
+	reconcileClient := initFakeDashboardClient("app", rayv1.DeploymentStatusEnum.HEALTHY, rayv1.ApplicationStatusEnum.RUNNING)
+	dashboardClient := poller.dashboardClient(ctx, rayService, rayCluster, "cluster-head-svc:8265", reconcileClient)
+	statuses, err := dashboardClient.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.RUNNING, statuses["app"].Status)
+	worker := poller.workers[clusterKey]
+	require.NotNil(t, worker)
+
+	// Later reads are served by the worker, and a change of the statuses triggers a reconciliation of the RayService.
+	unhealthy := generateServeStatus(rayv1.DeploymentStatusEnum.UNHEALTHY, rayv1.ApplicationStatusEnum.UNHEALTHY)
+	if worker.record(map[string]*utils.ServeApplicationStatus{"app": &unhealthy}, nil) {
+		poller.notify(worker.rayService)
+	}
+	statuses, err = dashboardClient.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.UNHEALTHY, statuses["app"].Status)
+	select {
+	case e := <-poller.events:
+		assert.Equal(t, "service", e.Object.GetName())
+	default:
+		t.Fatal("expected a reconcile request for the RayService")
+	}
+	assert.False(t, worker.record(map[string]*utils.ServeApplicationStatus{"app": &unhealthy}, nil))
+
+	// Updating the Serve applications discards the polled statuses.
+	require.NoError(t, dashboardClient.UpdateDeployments(ctx, []byte("{}")))
+	statuses, err = dashboardClient.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.RUNNING, statuses["app"].Status)
+
+	// The worker of the same RayCluster is reused, and replaced if the RayCluster is recreated.
+	poller.dashboardClient(ctx, rayService, rayCluster, "cluster-head-svc:8265", reconcileClient)
+	assert.Same(t, worker, poller.workers[clusterKey])
+	recreated := rayCluster.DeepCopy()
+	recreated.UID = "uid-2"
+	poller.dashboardClient(ctx, rayService, recreated, "cluster-head-svc:8265", reconcileClient)
+	assert.NotSame(t, worker, poller.workers[clusterKey])
+
+	// The workers of the RayClusters which are neither active nor pending are stopped.
+	poller.cleanUp(serviceKey, "cluster")
+	assert.Len(t, poller.workers, 1)
+	poller.cleanUp(serviceKey, "other-cluster")
+	assert.Empty(t, poller.workers)
+}
@@ -0,0 +1,239 @@
+package ray
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+)
+
+// remoteClusterClient routes the requests for the KubeRay custom resources to the local cluster, and
+// all other requests, e.g. for Pods and Services, to the remote cluster.
+type remoteClusterClient struct {
+	client.Client
+	remote client.Client
+}
+
+func newRemoteClusterClient(local client.Client, remote client.Client) client.Client {
+	return &remoteClusterClient{Client: local, remote: remote}
+}
+
+func (c *remoteClusterClient) clientFor(obj runtime.Object) client.Client {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err == nil && gvk.Group == rayv1.GroupVersion.Group {
+		return c.Client
+	}
+	return c.remote
+}
+
+func (c *remoteClusterClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.clientFor(obj).Get(ctx, key, obj, opts...)
+}
+
+func (c *remoteClusterClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.clientFor(list).List(ctx, list, opts...)
+}
+
+func (c *remoteClusterClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	target := c.clientFor(obj)
+	if target == c.remote {
+		// The owner RayCluster does not exist in the remote cluster, so the garbage collector of the remote
+		// cluster would delete the objects right away. They are deleted by the remote cluster finalizer instead.
+		obj.SetOwnerReferences(nil)
+	}
+	return target.Create(ctx, obj, opts...)
+}
+
+func (c *remoteClusterClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.clientFor(obj).Update(ctx, obj, opts...)
+}
+
+func (c *remoteClusterClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.clientFor(obj).Patch(ctx, obj, patch, opts...)
+}
+
+func (c *remoteClusterClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.clientFor(obj).Delete(ctx, obj, opts...)
+}
+
+func (c *remoteClusterClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return c.clientFor(obj).DeleteAllOf(ctx, obj, opts...)
+}
+
+// remoteClientCache caches the clients of the remote clusters. A client is rebuilt when the kubeconfig Secret changes.
+type remoteClientCache struct {
+	clients map[types.NamespacedName]remoteClientEntry
+	mu      sync.Mutex
+}
+
+type remoteClientEntry struct {
+	client          client.Client
+	resourceVersion string
+}
+
+func newRemoteClientCache() *remoteClientCache {
+	return &remoteClientCache{clients: map[types.NamespacedName]remoteClientEntry{}}
+}
+
+// getRemoteClient returns a client for the remote cluster referenced by spec.remoteCluster of the RayCluster.
+func (r *RayClusterReconciler) getRemoteClient(ctx context.Context, instance *rayv1.RayCluster) (client.Client, error) {
+	remoteCluster := instance.Spec.RemoteCluster
+	key := remoteCluster.KubeconfigSecretKey
+	if key == "" {
+		key = utils.DefaultRemoteClusterKubeconfigSecretKey
+	}
+
+	// Secrets are read without the cache, so that the operator does not have to watch all Secrets.
+	reader := r.apiReader
+	if reader == nil {
+		reader = r.Client
+	}
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: remoteCluster.KubeconfigSecretName}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the kubeconfig Secret %s: %w", remoteCluster.KubeconfigSecretName, err)
+	}
+
+	cacheKey := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	if r.remoteClients != nil {
+		r.remoteClients.mu.Lock()
+		defer r.remoteClients.mu.Unlock()
+		if entry, ok := r.remoteClients.clients[cacheKey]; ok && entry.resourceVersion == secret.ResourceVersion {
+			return entry.client, nil
+		}
+	}
+
+	kubeconfig, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("the kubeconfig Secret %s has no key %s", secret.Name, key)
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the kubeconfig in Secret %s: %w", secret.Name, err)
+	}
+	remoteClient, err := client.New(config, client.Options{Scheme: r.Scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a client for the remote cluster: %w", err)
+	}
+
+	if r.remoteClients != nil {
+		r.remoteClients.clients[cacheKey] = remoteClientEntry{client: remoteClient, resourceVersion: secret.ResourceVersion}
+	}
+	return remoteClient, nil
+}
+
+// forgetRemoteClient removes the cached client of a RayCluster.
+func (r *RayClusterReconciler) forgetRemoteClient(instance *rayv1.RayCluster) {
+	if r.remoteClients == nil {
+		return
+	}
+	r.remoteClients.mu.Lock()
+	defer r.remoteClients.mu.Unlock()
+	delete(r.remoteClients.clients, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name})
+}
+
+// remoteClusterReconciler returns a copy of the reconciler whose client manages the Pods and Services of the
+// RayCluster in the remote cluster. It returns nil if the reconciliation should stop with the returned result.
+func (r *RayClusterReconciler) remoteClusterReconciler(ctx context.Context, instance *rayv1.RayCluster) (*RayClusterReconciler, ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	if !features.Enabled(features.RemoteClusterManagement) {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.RemoteClusterManagementDisabled),
+			"spec.remoteCluster is set, but the %s feature gate is disabled", features.RemoteClusterManagement)
+		return nil, ctrl.Result{}, nil
+	}
+
+	remoteClient, err := r.getRemoteClient(ctx, instance)
+	if err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToConnectRemoteCluster),
+			"Failed to connect to the remote cluster: %v", err)
+		return nil, ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
+	}
+	remoteReconciler := *r
+	remoteReconciler.Client = newRemoteClusterClient(r.Client, remoteClient)
+
+	if instance.DeletionTimestamp.IsZero() && !controllerutil.ContainsFinalizer(instance, utils.RemoteClusterCleanupFinalizer) {
+		logger.Info("Adding a finalizer to delete the resources in the remote cluster once the RayCluster is deleted",
+			"finalizer", utils.RemoteClusterCleanupFinalizer)
+		controllerutil.AddFinalizer(instance, utils.RemoteClusterCleanupFinalizer)
+		if err := r.Update(ctx, instance); err != nil {
+			return nil, ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
+		}
+	}
+	return &remoteReconciler, ctrl.Result{}, nil
+}
+
+// cleanupRemoteCluster deletes the resources of a deleted RayCluster in the remote cluster and removes the finalizer.
+func (r *RayClusterReconciler) cleanupRemoteCluster(ctx context.Context, instance *rayv1.RayCluster) (ctrl.Result, error) {
+	if err := r.deleteRemoteClusterResources(ctx, instance); err != nil {
+		return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedRemoteClusterResources),
+		"Deleted the Pods and Services of RayCluster %s/%s in the remote cluster", instance.Namespace, instance.Name)
+	controllerutil.RemoveFinalizer(instance, utils.RemoteClusterCleanupFinalizer)
+	if err := r.Update(ctx, instance); err != nil {
+		return ctrl.Result{RequeueAfter: utils.RayClusterRequeueDuration()}, err
+	}
+	r.forgetRemoteClient(instance)
+	return ctrl.Result{}, nil
+}
+
+// deleteRemoteClusterResources deletes the Pods, Services, Ingresses and monitors which the operator created in the
+// remote cluster. They are not garbage collected because they have no owner references there.
+func (r *RayClusterReconciler) deleteRemoteClusterResources(ctx context.Context, instance *rayv1.RayCluster) error {
+	if _, err := r.deleteAllPods(ctx, common.RayClusterAllPodsAssociationOptions(instance)); err != nil {
+		return err
+	}
+
+	listOptions := common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()
+	services := corev1.ServiceList{}
+	if err := r.List(ctx, &services, listOptions...); err != nil {
+		return err
+	}
+	for i := range services.Items {
+		if err := r.Delete(ctx, &services.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	ingresses := networkingv1.IngressList{}
+	if err := r.List(ctx, &ingresses, listOptions...); err != nil {
+		return err
+	}
+	for i := range ingresses.Items {
+		if err := r.Delete(ctx, &ingresses.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	if monitor := common.BuildMonitorForRayCluster(*instance); monitor != nil {
+		if err := r.Delete(ctx, monitor); client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+			return err
+		}
+	}
+	if instance.Spec.ImagePrePull != nil {
+		daemonSets := appsv1.DaemonSetList{}
+		if err := r.List(ctx, &daemonSets, common.RayClusterImagePrePullDaemonSetsAssociationOptions(instance).ToListOptions()...); err != nil {
+			return err
+		}
+		for i := range daemonSets.Items {
+			if err := r.Delete(ctx, &daemonSets.Items[i]); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
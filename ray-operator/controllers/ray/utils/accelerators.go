@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// RayGPUResourceName is the Ray resource of GPUs, which is advertised with `ray start --num-gpus` instead of
+// `ray start --resources`.
+const RayGPUResourceName = "GPU"
+
+// AcceleratorResources maps the Kubernetes extended resources of accelerators, e.g. `habana.ai/gaudi`, to the Ray
+// resources advertised by the Ray Pods which request them, e.g. `HPU`, so that the non-NVIDIA accelerators can be
+// used without setting the `resources` Ray start param of every group. Mapping an extended resource to `GPU` sets
+// the `num-gpus` Ray start param instead.
+type AcceleratorResources map[corev1.ResourceName]string
+
+// DefaultAcceleratorResources are the well-known accelerators, they can be overridden by the operator configuration.
+// The extended resources ending with "gpu", like `nvidia.com/gpu`, are advertised as GPUs without being listed here.
+var DefaultAcceleratorResources = AcceleratorResources{
+	"aws.amazon.com/neuroncore": "neuron_cores",
+	"google.com/tpu":            "TPU",
+	"habana.ai/gaudi":           "HPU",
+}
+
+// rayReservedResourceNames are the Ray resources with a dedicated Ray start param, derived from the container resources.
+var rayReservedResourceNames = []string{"CPU", "memory", "object_store_memory"}
+
+// acceleratorResources is set once at startup, before the controllers build any Pod.
+var acceleratorResources = DefaultAcceleratorResources
+
+// SetAcceleratorResources adds the given accelerators to the default ones, or overrides them.
+func SetAcceleratorResources(resources AcceleratorResources) {
+	acceleratorResources = maps.Clone(DefaultAcceleratorResources)
+	maps.Copy(acceleratorResources, resources)
+}
+
+// GetAcceleratorResources returns the accelerators which are advertised to Ray.
+func GetAcceleratorResources() AcceleratorResources {
+	return acceleratorResources
+}
+
+// ParseAcceleratorResources parses a comma separated list of `<extended resource>=<Ray resource>` pairs, e.g.
+// `habana.ai/gaudi=HPU,gpu.intel.com/i915=GPU`.
+func ParseAcceleratorResources(value string) (AcceleratorResources, error) {
+	resources := AcceleratorResources{}
+	if value == "" {
+		return resources, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		resourceName, rayResourceName, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("accelerator resource %q must be of the form <extended resource>=<Ray resource>", pair)
+		}
+		resources[corev1.ResourceName(resourceName)] = rayResourceName
+	}
+	return resources, nil
+}
+
+// Validate returns an error if a key is not the name of an extended resource, or a value is not a Ray custom
+// resource or `GPU`.
+func (a AcceleratorResources) Validate() error {
+	for resourceName, rayResourceName := range a {
+		if errs := validation.IsQualifiedName(string(resourceName)); len(errs) > 0 {
+			return fmt.Errorf("accelerator resource %q is not a valid resource name: %s", resourceName, strings.Join(errs, ", "))
+		}
+		if domain, _, ok := strings.Cut(string(resourceName), "/"); !ok || domain == "kubernetes.io" || strings.HasSuffix(domain, ".kubernetes.io") {
+			return fmt.Errorf("accelerator resource %q must be an extended resource with a domain prefix", resourceName)
+		}
+		if rayResourceName == "" {
+			return fmt.Errorf("the Ray resource of accelerator resource %q must not be empty", resourceName)
+		}
+		if Contains(rayReservedResourceNames, rayResourceName) {
+			return fmt.Errorf("accelerator resource %q can't be mapped to the Ray resource %q", resourceName, rayResourceName)
+		}
+	}
+	return nil
+}
+
+// ParseRayResourcesParam parses the value of the `resources` Ray start param, a JSON object quoted for the shell
+// like `"{\"HPU\": 2}"`.
+func ParseRayResourcesParam(value string) (map[string]float64, error) {
+	value = strings.Trim(strings.TrimSpace(value), `'`)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	resources := map[string]float64{}
+	if err := json.Unmarshal([]byte(value), &resources); err != nil {
+		return nil, fmt.Errorf("failed to parse the resources Ray start param %q: %w", value, err)
+	}
+	return resources, nil
+}
+
+// FormatRayResourcesParam formats the Ray custom resources as the value of the `resources` Ray start param.
+func FormatRayResourcesParam(resources map[string]float64) (string, error) {
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Quote(string(data)), nil
+}
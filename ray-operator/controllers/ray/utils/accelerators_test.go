@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAcceleratorResources(t *testing.T) {
+	defer SetAcceleratorResources(nil)
+
+	assert.Equal(t, DefaultAcceleratorResources, GetAcceleratorResources())
+
+	SetAcceleratorResources(AcceleratorResources{"habana.ai/gaudi": "Gaudi", "gpu.intel.com/i915": "GPU"})
+	assert.Equal(t, AcceleratorResources{
+		"aws.amazon.com/neuroncore": "neuron_cores",
+		"google.com/tpu":            "TPU",
+		"habana.ai/gaudi":           "Gaudi",
+		"gpu.intel.com/i915":        "GPU",
+	}, GetAcceleratorResources())
+	assert.Equal(t, "HPU", DefaultAcceleratorResources["habana.ai/gaudi"], "The defaults are not modified")
+}
+
+func TestParseAcceleratorResources(t *testing.T) {
+	resources, err := ParseAcceleratorResources("")
+	require.NoError(t, err)
+	assert.Empty(t, resources)
+
+	resources, err = ParseAcceleratorResources("habana.ai/gaudi=HPU, gpu.intel.com/i915=GPU")
+	require.NoError(t, err)
+	assert.Equal(t, AcceleratorResources{"habana.ai/gaudi": "HPU", "gpu.intel.com/i915": "GPU"}, resources)
+
+	_, err = ParseAcceleratorResources("habana.ai/gaudi")
+	require.EqualError(t, err, `accelerator resource "habana.ai/gaudi" must be of the form <extended resource>=<Ray resource>`)
+}
+
+func TestValidateAcceleratorResources(t *testing.T) {
+	require.NoError(t, AcceleratorResources{}.Validate())
+	require.NoError(t, DefaultAcceleratorResources.Validate())
+	require.NoError(t, AcceleratorResources{"gpu.intel.com/i915": "GPU"}.Validate())
+	require.EqualError(t, AcceleratorResources{"gaudi": "HPU"}.Validate(),
+		`accelerator resource "gaudi" must be an extended resource with a domain prefix`)
+	require.EqualError(t, AcceleratorResources{"hugepages.kubernetes.io/2Mi": "hugepages"}.Validate(),
+		`accelerator resource "hugepages.kubernetes.io/2Mi" must be an extended resource with a domain prefix`)
+	require.EqualError(t, AcceleratorResources{"habana.ai/gaudi": ""}.Validate(),
+		`the Ray resource of accelerator resource "habana.ai/gaudi" must not be empty`)
+	require.EqualError(t, AcceleratorResources{"example.com/cpu": "CPU"}.Validate(),
+		`accelerator resource "example.com/cpu" can't be mapped to the Ray resource "CPU"`)
+	require.Error(t, AcceleratorResources{"habana.ai/gaudi card": "HPU"}.Validate())
+}
+
+func TestRayResourcesParam(t *testing.T) {
+	for _, value := range []string{`"{\"Custom1\": 1, \"HPU\": 2}"`, `'{"Custom1": 1, "HPU": 2}'`, `{"Custom1": 1, "HPU": 2}`} {
+		resources, err := ParseRayResourcesParam(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, map[string]float64{"Custom1": 1, "HPU": 2}, resources, value)
+	}
+	_, err := ParseRayResourcesParam("HPU=2")
+	require.Error(t, err)
+
+	value, err := FormatRayResourcesParam(map[string]float64{"Custom1": 1, "HPU": 2})
+	require.NoError(t, err)
+	assert.Equal(t, `"{\"Custom1\":1,\"HPU\":2}"`, value)
+}
@@ -23,6 +23,9 @@ const (
 	RayIDLabelKey                            = "ray.io/identifier"
 	RayClusterServingServiceLabelKey         = "ray.io/serve"
 	RayClusterHeadlessServiceLabelKey        = "ray.io/headless-worker-svc"
+	RayClusterMetricsServiceLabelKey         = "ray.io/metrics-svc"
+	RayClusterImagePrePullLabelKey           = "ray.io/image-pre-pull"
+	RayClusterHeadStandbyLabelKey            = "ray.io/head-standby"
 	HashWithoutReplicasAndWorkersToDeleteKey = "ray.io/hash-without-replicas-and-workers-to-delete"
 	NumWorkerGroupsKey                       = "ray.io/num-worker-groups"
 	KubeRayVersion                           = "ray.io/kuberay-version"
@@ -36,6 +39,8 @@ const (
 	RaySchedulerName                = "ray.io/scheduler-name"
 	RayPriorityClassName            = "ray.io/priority-class-name"
 	RayClusterGangSchedulingEnabled = "ray.io/gang-scheduling-enabled"
+	// Kueue admits a suspended RayCluster labeled with its LocalQueue by unsuspending it.
+	KueueQueueNameLabelKey = "kueue.x-k8s.io/queue-name"
 
 	// Ray GCS FT related annotations
 	RayFTEnabledAnnotationKey         = "ray.io/ft-enabled"
@@ -46,9 +51,52 @@ const (
 	// `KUBERAY_GEN_RAY_START_CMD`.
 	RayOverwriteContainerCmdAnnotationKey = "ray.io/overwrite-container-cmd"
 
+	// If this annotation is set to "true" on a RayCluster, RayJob, or RayService, the resource is left out of
+	// the backups exported by the KubeRay API server.
+	RayBackupExcludeAnnotationKey = "ray.io/backup-exclude"
+	// RayRestoredFromAnnotationKey is set on resources restored from a backup. Its value is the namespace
+	// the backup was exported from.
+	RayRestoredFromAnnotationKey = "ray.io/restored-from"
+	// If this annotation is set to "true" on a RayCluster or RayService, the KubeRay API server refuses to delete it
+	// unless the deletion is forced, and the operator does not delete such RayClusters, e.g. when it cleans up the
+	// RayClusters of a RayService after an upgrade, or the RayCluster of a finished RayJob.
+	RayDeletionProtectedAnnotationKey = "ray.io/deletion-protected"
+
+	// RaySystemConfigHashAnnotationKey is set on the Ray Pods of a RayCluster with spec.systemConfig. Its value is
+	// the hash of the Ray system config the Pod was created with.
+	RaySystemConfigHashAnnotationKey = "ray.io/system-config-hash"
+	// RayReconcileIntervalAnnotationKey overrides how often the operator reconciles a RayCluster when nothing changes.
+	// Its value is a duration, e.g. "30s" or "10m".
+	RayReconcileIntervalAnnotationKey = "ray.io/reconcile-interval"
+	// If this annotation is set to "true" on a RayService, the operator prepares the pending RayCluster of a
+	// zero-downtime upgrade but does not promote it once it is ready, so that its Serve applications can be checked
+	// before they serve traffic. The pending RayCluster is promoted once the annotation is removed, and the upgrade
+	// is abandoned if the RayService is reverted to the config of the active RayCluster.
+	RayServiceHoldUpgradePromotionAnnotationKey = "ray.io/hold-upgrade-promotion"
+
 	// Finalizers for GCS fault tolerance
 	GCSFaultToleranceRedisCleanupFinalizer = "ray.io/gcs-ft-redis-cleanup-finalizer"
 
+	// Finalizer which makes the deletion of a RayCluster with spec.drainBeforeDeletion wait for its running Ray jobs
+	RayClusterDrainFinalizer = "ray.io/drain-finalizer"
+	// DefaultRayClusterDrainTimeoutSeconds is the default value of spec.drainBeforeDeletion.timeoutSeconds
+	DefaultRayClusterDrainTimeoutSeconds = 600
+
+	// Defaults of spec.usageSnapshots of a RayCluster
+	DefaultUsageSnapshotIntervalSeconds = 300
+	DefaultUsageSnapshotMaxHistory      = 10
+
+	// Finalizer for RayClusters managed in a remote Kubernetes cluster
+	RemoteClusterCleanupFinalizer = "ray.io/remote-cluster-cleanup-finalizer"
+	// DefaultRemoteClusterKubeconfigSecretKey is the default key of the kubeconfig in the Secret referenced by spec.remoteCluster
+	DefaultRemoteClusterKubeconfigSecretKey = "kubeconfig"
+	// DefaultRaySystemConfigKey is the default key of the Ray system config in the ConfigMap referenced by spec.systemConfig
+	DefaultRaySystemConfigKey = "system_config.json"
+	// DefaultCABundleKey is the default key of the CA certificates in the ConfigMap referenced by spec.egress.caBundle
+	DefaultCABundleKey = "ca.crt"
+	// DefaultNoProxy lists the local and in-cluster addresses which are appended to spec.egress.noProxy
+	DefaultNoProxy = "localhost,127.0.0.1,.svc,.cluster.local"
+
 	// EnableServeServiceKey is exclusively utilized to indicate if a RayCluster is directly used for serving.
 	// See https://github.com/ray-project/kuberay/pull/1672 for more details.
 	EnableServeServiceKey  = "ray.io/enable-serve-service"
@@ -89,10 +137,48 @@ const (
 	// The default name for kuberay operator
 	ComponentName = "kuberay-operator"
 
+	// The default suffix for the metrics Service of a RayCluster.
+	// The full name will be of the form "${RayCluster_Name}-metrics-svc".
+	MetricsServiceSuffix = "metrics-svc"
+
 	// The default suffix for Headless Service for multi-host worker groups.
 	// The full name will be of the form "${RayCluster_Name}-headless-worker-svc".
 	HeadlessServiceSuffix = "headless-worker-svc"
 
+	// The default suffix for the DaemonSets which pre-pull the images of a RayCluster.
+	// The full name will be of the form "${RayCluster_Name}-${Group_Name}-pre-pull".
+	ImagePrePullSuffix = "pre-pull"
+
+	// The image of the main container of the image pre-pull DaemonSets and of the head standby Pod, which only keeps the Pods running.
+	ImagePrePullPauseImage = "registry.k8s.io/pause:3.9"
+
+	// The default time the operator waits for the images of a RayCluster to be pre-pulled.
+	DefaultImagePrePullTimeoutSeconds = 600
+
+	// The default suffix for the PersistentVolumeClaim of the persistent storage of the head Pod.
+	// The full name will be of the form "${RayCluster_Name}-head-storage".
+	HeadPersistentStorageSuffix = "head-storage"
+
+	// The default suffix for the standby Pod which reserves capacity for the head Pod in another failure domain.
+	// The full name will be of the form "${RayCluster_Name}-head-standby".
+	HeadStandbySuffix = "head-standby"
+
+	// The default node label of the failure domains of the head placement policy.
+	DefaultHeadPlacementTopologyKey = "topology.kubernetes.io/zone"
+
+	// The default suffix for the Secret which holds the auth token of the Ray dashboard.
+	// The full name will be of the form "${RayCluster_Name}-dashboard-auth".
+	DashboardAuthSecretSuffix = "dashboard-auth"
+	// DashboardAuthTokenKey is the key of the auth token in the dashboard auth Secret
+	DashboardAuthTokenKey = "token"
+	// The default image of the auth proxy sidecar which serves the dashboard port of the head Pod.
+	DefaultDashboardAuthProxyImage = "nginxinc/nginx-unprivileged:1.27-alpine"
+	// DashboardAuthProxyContainerName is the name of the auth proxy sidecar of the head Pod.
+	DashboardAuthProxyContainerName = "dashboard-auth-proxy"
+	// With dashboard auth, the Ray dashboard only listens on this port of the loopback interface of the head Pod,
+	// and the auth proxy sidecar forwards the authenticated requests to it.
+	DashboardAuthUpstreamPort = 18265
+
 	// Use as container env variable
 	RAY_CLUSTER_NAME                        = "RAY_CLUSTER_NAME"
 	RAY_IP                                  = "RAY_IP"
@@ -108,6 +194,14 @@ const (
 	RAY_SERVE_KV_TIMEOUT_S                  = "RAY_SERVE_KV_TIMEOUT_S"
 	RAY_USAGE_STATS_KUBERAY_IN_USE          = "RAY_USAGE_STATS_KUBERAY_IN_USE"
 	RAY_USAGE_STATS_EXTRA_TAGS              = "RAY_USAGE_STATS_EXTRA_TAGS"
+	RAY_OBJECT_SPILLING_CONFIG              = "RAY_object_spilling_config"
+	RAY_BACKEND_LOG_LEVEL                   = "RAY_BACKEND_LOG_LEVEL"
+	HTTP_PROXY                              = "HTTP_PROXY"
+	HTTPS_PROXY                             = "HTTPS_PROXY"
+	NO_PROXY                                = "NO_PROXY"
+	SSL_CERT_FILE                           = "SSL_CERT_FILE"
+	REQUESTS_CA_BUNDLE                      = "REQUESTS_CA_BUNDLE"
+	PIP_CERT                                = "PIP_CERT"
 	RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV  = "RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV"
 	RAYCLUSTER_DEFAULT_REQUEUE_SECONDS      = 300
 	KUBERAY_GEN_RAY_START_CMD               = "KUBERAY_GEN_RAY_START_CMD"
@@ -116,6 +210,9 @@ const (
 	// Example: ray job submit --address=http://$RAY_DASHBOARD_ADDRESS --submission-id=$RAY_JOB_SUBMISSION_ID ...
 	RAY_DASHBOARD_ADDRESS = "RAY_DASHBOARD_ADDRESS"
 	RAY_JOB_SUBMISSION_ID = "RAY_JOB_SUBMISSION_ID"
+	// RAY_DASHBOARD_AUTH_TOKEN holds the dashboard auth token. RAY_JOB_HEADERS makes the Ray job CLI send it.
+	RAY_DASHBOARD_AUTH_TOKEN = "RAY_DASHBOARD_AUTH_TOKEN"
+	RAY_JOB_HEADERS          = "RAY_JOB_HEADERS"
 
 	// Environment variables for Ray Autoscaler V2.
 	// The value of RAY_CLOUD_INSTANCE_ID is the Pod name for Autoscaler V2 alpha. This may change in the future.
@@ -181,6 +278,10 @@ const (
 	// Finalizers for RayJob
 	RayJobStopJobFinalizer = "ray.io/rayjob-finalizer"
 
+	// RayJobEntrypointCommandErrorType is the error type Ray reports when the entrypoint command of a job exits with
+	// a non-zero exit code. Other error types mean that the job could not be started.
+	RayJobEntrypointCommandErrorType = "JOB_ENTRYPOINT_COMMAND_ERROR"
+
 	// RayNodeHeadGroupLabelValue is the value for the RayNodeGroupLabelKey label on a head node
 	RayNodeHeadGroupLabelValue = "headgroup"
 
@@ -240,10 +341,18 @@ const (
 	FailedToDeleteHeadPod K8sEventType = "FailedToDeleteHeadPod"
 
 	// Worker Pod event list
-	CreatedWorkerPod        K8sEventType = "CreatedWorkerPod"
-	FailedToCreateWorkerPod K8sEventType = "FailedToCreateWorkerPod"
-	DeletedWorkerPod        K8sEventType = "DeletedWorkerPod"
-	FailedToDeleteWorkerPod K8sEventType = "FailedToDeleteWorkerPod"
+	CreatedWorkerPod                      K8sEventType = "CreatedWorkerPod"
+	FailedToCreateWorkerPod               K8sEventType = "FailedToCreateWorkerPod"
+	DeletedWorkerPod                      K8sEventType = "DeletedWorkerPod"
+	FailedToDeleteWorkerPod               K8sEventType = "FailedToDeleteWorkerPod"
+	DeletedWorkerPodWithDetachedWorkloads K8sEventType = "DeletedWorkerPodWithDetachedWorkloads"
+	ResourceQuotaExceeded                 K8sEventType = "ResourceQuotaExceeded"
+	IncompatibleRayVersion                K8sEventType = "IncompatibleRayVersion"
+
+	// RayCluster drain event list
+	DrainingRayCluster   K8sEventType = "DrainingRayCluster"
+	DrainedRayCluster    K8sEventType = "DrainedRayCluster"
+	DrainTimeoutExceeded K8sEventType = "DrainTimeoutExceeded"
 
 	// Redis Cleanup Job event list
 	CreatedRedisCleanupJob        K8sEventType = "CreatedRedisCleanupJob"
@@ -258,11 +367,24 @@ const (
 	DeletedRayCluster             K8sEventType = "DeletedRayCluster"
 	FailedToCreateRayCluster      K8sEventType = "FailedToCreateRayCluster"
 	FailedToDeleteRayCluster      K8sEventType = "FailedToDeleteRayCluster"
+	DeletionProtectedRayCluster   K8sEventType = "DeletionProtectedRayCluster"
+	SubmittedRayJobStage          K8sEventType = "SubmittedRayJobStage"
+	FailedToSubmitRayJobStage     K8sEventType = "FailedToSubmitRayJobStage"
+
+	// RayService event list
+	PausedRayService  K8sEventType = "PausedRayService"
+	ResumedRayService K8sEventType = "ResumedRayService"
+	RestartedServe    K8sEventType = "RestartedServe"
+	HeldUpgrade       K8sEventType = "HeldUpgrade"
+	AbandonedUpgrade  K8sEventType = "AbandonedUpgrade"
 
 	// Generic Pod event list
 	DeletedPod        K8sEventType = "DeletedPod"
 	FailedToDeletePod K8sEventType = "FailedToDeletePod"
 
+	// Ray system config event list
+	FailedToGetSystemConfig K8sEventType = "FailedToGetSystemConfig"
+
 	// Ingress event list
 	CreatedIngress        K8sEventType = "CreatedIngress"
 	FailedToCreateIngress K8sEventType = "FailedToCreateIngress"
@@ -275,6 +397,28 @@ const (
 	CreatedService        K8sEventType = "CreatedService"
 	FailedToCreateService K8sEventType = "FailedToCreateService"
 
+	// Prometheus Operator monitor event list
+	CreatedMonitor        K8sEventType = "CreatedMonitor"
+	FailedToCreateMonitor K8sEventType = "FailedToCreateMonitor"
+
+	// Image pre-pull event list
+	CreatedImagePrePullDaemonSet        K8sEventType = "CreatedImagePrePullDaemonSet"
+	FailedToCreateImagePrePullDaemonSet K8sEventType = "FailedToCreateImagePrePullDaemonSet"
+	ImagePrePullTimedOut                K8sEventType = "ImagePrePullTimedOut"
+
+	// PersistentVolumeClaim event list
+	CreatedPersistentVolumeClaim        K8sEventType = "CreatedPersistentVolumeClaim"
+	FailedToCreatePersistentVolumeClaim K8sEventType = "FailedToCreatePersistentVolumeClaim"
+
+	// Head standby Pod event list
+	CreatedHeadStandbyPod        K8sEventType = "CreatedHeadStandbyPod"
+	FailedToCreateHeadStandbyPod K8sEventType = "FailedToCreateHeadStandbyPod"
+	DeletedHeadStandbyPod        K8sEventType = "DeletedHeadStandbyPod"
+
+	// Dashboard auth Secret event list
+	CreatedDashboardAuthSecret        K8sEventType = "CreatedDashboardAuthSecret"
+	FailedToCreateDashboardAuthSecret K8sEventType = "FailedToCreateDashboardAuthSecret"
+
 	// ServiceAccount event list
 	CreatedServiceAccount            K8sEventType = "CreatedServiceAccount"
 	FailedToCreateServiceAccount     K8sEventType = "FailedToCreateServiceAccount"
@@ -287,4 +431,9 @@ const (
 	// RoleBinding list
 	CreatedRoleBinding        K8sEventType = "CreatedRoleBinding"
 	FailedToCreateRoleBinding K8sEventType = "FailedToCreateRoleBinding"
+
+	// Remote cluster event list
+	FailedToConnectRemoteCluster    K8sEventType = "FailedToConnectRemoteCluster"
+	RemoteClusterManagementDisabled K8sEventType = "RemoteClusterManagementDisabled"
+	DeletedRemoteClusterResources   K8sEventType = "DeletedRemoteClusterResources"
 )
@@ -28,11 +28,22 @@ var (
 	DeployPathV2     = "/api/serve/applications/"
 	// Job URL paths
 	JobPath = "/api/jobs/"
+	// State API URL paths
+	NodesPath           = "/api/v0/nodes"
+	ActorsPath          = "/api/v0/actors"
+	PlacementGroupsPath = "/api/v0/placement_groups"
+	TasksPath           = "/api/v0/tasks"
+	// Version URL path
+	VersionPath = "/api/version"
+	// PackagesPath is the URL path of the packages stored in the GCS, e.g. the working directories of jobs.
+	PackagesPath = "/api/packages/gcs/"
 )
 
 type RayDashboardClientInterface interface {
 	InitClient(ctx context.Context, url string, rayCluster *rayv1.RayCluster) error
 	UpdateDeployments(ctx context.Context, configJson []byte) error
+	// DeleteServeApplications shuts down Serve and deletes all its applications.
+	DeleteServeApplications(ctx context.Context) error
 	// V2/multi-app Rest API
 	GetServeDetails(ctx context.Context) (*ServeDetails, error)
 	GetMultiApplicationStatus(context.Context) (map[string]*ServeApplicationStatus, error)
@@ -43,11 +54,47 @@ type RayDashboardClientInterface interface {
 	GetJobLog(ctx context.Context, jobName string) (*string, error)
 	StopJob(ctx context.Context, jobName string) error
 	DeleteJob(ctx context.Context, jobName string) error
+	// State API
+	ListNodes(ctx context.Context) ([]RayNodeState, error)
+	ListActors(ctx context.Context) ([]RayActorState, error)
+	ListTasks(ctx context.Context) ([]RayTaskState, error)
+	ListPlacementGroups(ctx context.Context) ([]RayPlacementGroupState, error)
+	GetVersion(ctx context.Context) (*RayVersionInfo, error)
+	// UploadPackage stores a zip file in the GCS, where it can be used as the working directory of jobs with the
+	// gcs://<packageName> URI.
+	UploadPackage(ctx context.Context, packageName string, zip []byte) error
+}
+
+// RayDashboardClientTimeoutSetter is optionally implemented by dashboard clients whose request timeout can be
+// changed after InitClient, e.g. to apply the health check policy of a RayService.
+type RayDashboardClientTimeoutSetter interface {
+	SetTimeout(timeout time.Duration)
+}
+
+// RayDashboardClientAuthTokenSetter is optionally implemented by dashboard clients which can send the auth token
+// required by RayClusters with dashboard auth.
+type RayDashboardClientAuthTokenSetter interface {
+	SetAuthToken(token string)
 }
 
 type BaseDashboardClient struct {
 	client       *http.Client
 	dashboardURL string
+	// authToken is sent as a bearer token with every request if it is set.
+	authToken string
+}
+
+// SetAuthToken sets the bearer token which is sent with every request to the Ray dashboard.
+func (r *BaseDashboardClient) SetAuthToken(token string) {
+	r.authToken = token
+}
+
+// do sends the request with the auth token, if the client has one.
+func (r *BaseDashboardClient) do(req *http.Request) (*http.Response, error) {
+	if r.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+r.authToken)
+	}
+	return r.client.Do(req)
 }
 
 func GetRayDashboardClientFunc(mgr ctrl.Manager, useKubernetesProxy bool) func() RayDashboardClientInterface {
@@ -108,9 +155,33 @@ func FetchHeadServiceURL(ctx context.Context, cli client.Client, rayCluster *ray
 	return headServiceURL, nil
 }
 
+// FetchDashboardAuthToken returns the auth token of the Ray dashboard of a RayCluster with dashboard auth.
+func FetchDashboardAuthToken(ctx context.Context, reader client.Reader, rayCluster *rayv1.RayCluster) (string, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: rayCluster.Namespace, Name: GenerateDashboardAuthSecretName(rayCluster.Name)}
+	if err := reader.Get(ctx, key, secret); err != nil {
+		return "", err
+	}
+	token := string(secret.Data[DashboardAuthTokenKey])
+	if token == "" {
+		return "", fmtErrors.Errorf("the dashboard auth Secret %s/%s has no %s key", key.Namespace, key.Name, DashboardAuthTokenKey)
+	}
+	return token, nil
+}
+
 func (r *RayDashboardClient) InitClient(ctx context.Context, url string, rayCluster *rayv1.RayCluster) error {
 	log := ctrl.LoggerFrom(ctx)
 
+	// RayClusters with dashboard auth require the auth token with every request. The Secret is read without the
+	// cache, so that the operator does not have to watch all Secrets.
+	if rayCluster != nil && rayCluster.Spec.DashboardAuth != nil && r.mgr != nil {
+		token, err := FetchDashboardAuthToken(ctx, r.mgr.GetAPIReader(), rayCluster)
+		if err != nil {
+			return err
+		}
+		r.authToken = token
+	}
+
 	if r.useKubernetesProxy {
 		var err error
 		headSvcName := rayCluster.Status.Head.ServiceName
@@ -135,6 +206,17 @@ func (r *RayDashboardClient) InitClient(ctx context.Context, url string, rayClus
 	return nil
 }
 
+// SetTimeout sets the timeout of the requests sent by the client. It must be called after InitClient.
+func (r *RayDashboardClient) SetTimeout(timeout time.Duration) {
+	if r.client == nil {
+		return
+	}
+	// The HTTP client of the manager is shared in the Kubernetes proxy mode, so it is copied before being changed.
+	httpClient := *r.client
+	httpClient.Timeout = timeout
+	r.client = &httpClient
+}
+
 // UpdateDeployments update the deployments in the Ray cluster.
 func (r *RayDashboardClient) UpdateDeployments(ctx context.Context, configJson []byte) error {
 	var req *http.Request
@@ -145,7 +227,7 @@ func (r *RayDashboardClient) UpdateDeployments(ctx context.Context, configJson [
 
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := r.client.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
 		return err
 	}
@@ -159,6 +241,28 @@ func (r *RayDashboardClient) UpdateDeployments(ctx context.Context, configJson [
 	return nil
 }
 
+// DeleteServeApplications shuts down Serve and deletes all its applications. Serve is started again by the next
+// UpdateDeployments, with the global options of the new config.
+func (r *RayDashboardClient) DeleteServeApplications(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, r.dashboardURL+DeployPathV2, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("DeleteServeApplications fail: %s %s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
 func (r *RayDashboardClient) GetMultiApplicationStatus(ctx context.Context) (map[string]*ServeApplicationStatus, error) {
 	serveDetails, err := r.GetServeDetails(ctx)
 	if err != nil {
@@ -175,7 +279,7 @@ func (r *RayDashboardClient) GetServeDetails(ctx context.Context) (*ServeDetails
 		return nil, err
 	}
 
-	resp, err := r.client.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -215,16 +319,24 @@ type RuntimeEnvType map[string]interface{}
 // Reference to https://docs.ray.io/en/latest/cluster/running-applications/job-submission/rest.html#ray-job-rest-api-spec
 // Reference to https://github.com/ray-project/ray/blob/cfbf98c315cfb2710c56039a3c96477d196de049/dashboard/modules/job/pydantic_models.py#L38-L107
 type RayJobInfo struct {
-	ErrorType    *string           `json:"error_type,omitempty"`
-	Metadata     map[string]string `json:"metadata,omitempty"`
-	RuntimeEnv   RuntimeEnvType    `json:"runtime_env,omitempty"`
-	JobStatus    rayv1.JobStatus   `json:"status,omitempty"`
-	Entrypoint   string            `json:"entrypoint,omitempty"`
-	JobId        string            `json:"job_id,omitempty"`
-	SubmissionId string            `json:"submission_id,omitempty"`
-	Message      string            `json:"message,omitempty"`
-	StartTime    uint64            `json:"start_time,omitempty"`
-	EndTime      uint64            `json:"end_time,omitempty"`
+	ErrorType      *string           `json:"error_type,omitempty"`
+	DriverExitCode *int32            `json:"driver_exit_code,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+	RuntimeEnv     RuntimeEnvType    `json:"runtime_env,omitempty"`
+	JobStatus      rayv1.JobStatus   `json:"status,omitempty"`
+	Entrypoint     string            `json:"entrypoint,omitempty"`
+	JobId          string            `json:"job_id,omitempty"`
+	SubmissionId   string            `json:"submission_id,omitempty"`
+	Message        string            `json:"message,omitempty"`
+	StartTime      uint64            `json:"start_time,omitempty"`
+	EndTime        uint64            `json:"end_time,omitempty"`
+}
+
+// RayVersionInfo is the response of the version API of the Ray dashboard.
+type RayVersionInfo struct {
+	Version    string `json:"version"`
+	RayVersion string `json:"ray_version"`
+	RayCommit  string `json:"ray_commit"`
 }
 
 // RayJobRequest is the request body to submit.
@@ -260,7 +372,7 @@ func (r *RayDashboardClient) GetJobInfo(ctx context.Context, jobId string) (*Ray
 		return nil, err
 	}
 
-	resp, err := r.client.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -290,7 +402,7 @@ func (r *RayDashboardClient) ListJobs(ctx context.Context) (*[]RayJobInfo, error
 		return nil, err
 	}
 
-	resp, err := r.client.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -338,7 +450,7 @@ func (r *RayDashboardClient) SubmitJobReq(ctx context.Context, request *RayJobRe
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := r.client.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
 		return
 	}
@@ -368,7 +480,7 @@ func (r *RayDashboardClient) GetJobLog(ctx context.Context, jobName string) (*st
 	if err != nil {
 		return nil, err
 	}
-	resp, err := r.client.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -404,7 +516,7 @@ func (r *RayDashboardClient) StopJob(ctx context.Context, jobName string) (err e
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := r.client.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
 		return err
 	}
@@ -440,7 +552,7 @@ func (r *RayDashboardClient) DeleteJob(ctx context.Context, jobName string) erro
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := r.client.Do(req)
+	resp, err := r.do(req)
 	if err != nil {
 		return err
 	}
@@ -449,6 +561,123 @@ func (r *RayDashboardClient) DeleteJob(ctx context.Context, jobName string) erro
 	return nil
 }
 
+// ListNodes lists the alive nodes of the Ray cluster.
+func (r *RayDashboardClient) ListNodes(ctx context.Context) ([]RayNodeState, error) {
+	var nodes []RayNodeState
+	if err := r.listStateAPI(ctx, NodesPath+"?filter_keys=state&filter_predicates=%3D&filter_values=ALIVE", &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// ListActors lists the alive actors of the Ray cluster, including whether they are detached.
+func (r *RayDashboardClient) ListActors(ctx context.Context) ([]RayActorState, error) {
+	var actors []RayActorState
+	if err := r.listStateAPI(ctx, ActorsPath+"?detail=true&filter_keys=state&filter_predicates=%3D&filter_values=ALIVE", &actors); err != nil {
+		return nil, err
+	}
+	return actors, nil
+}
+
+// ListTasks lists the running tasks of the Ray cluster.
+func (r *RayDashboardClient) ListTasks(ctx context.Context) ([]RayTaskState, error) {
+	var tasks []RayTaskState
+	if err := r.listStateAPI(ctx, TasksPath+"?filter_keys=state&filter_predicates=%3D&filter_values=RUNNING", &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListPlacementGroups lists the created placement groups of the Ray cluster, including their bundles.
+func (r *RayDashboardClient) ListPlacementGroups(ctx context.Context) ([]RayPlacementGroupState, error) {
+	var placementGroups []RayPlacementGroupState
+	if err := r.listStateAPI(ctx, PlacementGroupsPath+"?detail=true&filter_keys=state&filter_predicates=%3D&filter_values=CREATED", &placementGroups); err != nil {
+		return nil, err
+	}
+	return placementGroups, nil
+}
+
+// GetVersion returns the Ray version and commit reported by the dashboard. It is a cheap call to check that the
+// dashboard answers.
+func (r *RayDashboardClient) GetVersion(ctx context.Context) (*RayVersionInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+VersionPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("GetVersion fail: %s %s", resp.Status, string(body))
+	}
+
+	var versionInfo RayVersionInfo
+	if err = json.Unmarshal(body, &versionInfo); err != nil {
+		return nil, fmt.Errorf("GetVersion fail: %s", string(body))
+	}
+	return &versionInfo, nil
+}
+
+// UploadPackage stores the zip file in the GCS of the Ray cluster, like `ray job submit` does with the local
+// working directory. The package can then be referenced as gcs://<packageName> in the runtime_env of jobs.
+func (r *RayDashboardClient) UploadPackage(ctx context.Context, packageName string, zip []byte) error {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Upload a package", "packageName", packageName, "size", len(zip))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, r.dashboardURL+PackagesPath+packageName, bytes.NewReader(zip))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("UploadPackage fail: %s %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+func (r *RayDashboardClient) listStateAPI(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("list %s fail: %s %s", path, resp.Status, string(body))
+	}
+
+	var stateResp stateAPIListResponse
+	if err = json.Unmarshal(body, &stateResp); err != nil {
+		return fmt.Errorf("list %s fail: %s", path, string(body))
+	}
+	if !stateResp.Result {
+		return fmt.Errorf("list %s fail: %s", path, stateResp.Msg)
+	}
+	if len(stateResp.Data.Result.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(stateResp.Data.Result.Result, result)
+}
+
 func ConvertRayJobToReq(rayJob *rayv1.RayJob) (*RayJobRequest, error) {
 	req := &RayJobRequest{
 		Entrypoint:   rayJob.Spec.Entrypoint,
@@ -472,6 +701,26 @@ func ConvertRayJobToReq(rayJob *rayv1.RayJob) (*RayJobRequest, error) {
 	return req, nil
 }
 
+// ConvertRayJobStageToReq builds the request submitting a stage of a RayJob with the given submission ID. The stage
+// inherits the metadata and the entrypoint resources of the RayJob, as well as its runtime environment unless the
+// stage sets its own.
+func ConvertRayJobStageToReq(rayJob *rayv1.RayJob, stage rayv1.RayJobStage, submissionId string) (*RayJobRequest, error) {
+	req, err := ConvertRayJobToReq(rayJob)
+	if err != nil {
+		return nil, err
+	}
+	req.Entrypoint = stage.Entrypoint
+	req.SubmissionId = submissionId
+	if len(stage.RuntimeEnvYAML) != 0 {
+		runtimeEnv, err := UnmarshalRuntimeEnvYAML(stage.RuntimeEnvYAML)
+		if err != nil {
+			return nil, err
+		}
+		req.RuntimeEnv = runtimeEnv
+	}
+	return req, nil
+}
+
 func UnmarshalRuntimeEnvYAML(runtimeEnvYAML string) (RuntimeEnvType, error) {
 	var runtimeEnv RuntimeEnvType
 	err := yaml.Unmarshal([]byte(runtimeEnvYAML), &runtimeEnv)
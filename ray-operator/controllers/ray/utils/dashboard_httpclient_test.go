@@ -3,6 +3,7 @@ package utils
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/jarcoal/httpmock"
@@ -87,6 +88,27 @@ var _ = Describe("RayFrameworkGenerator", func() {
 		Expect(err).Should(MatchError(ContainSubstring("json: cannot unmarshal")))
 	})
 
+	It("Test ConvertRayJobStageToReq", func() {
+		rayJobRequest, err := ConvertRayJobStageToReq(rayJob, rayv1.RayJobStage{
+			Name:       "train",
+			Entrypoint: "python train.py",
+		}, "rayjob-sample-train")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rayJobRequest.Entrypoint).To(Equal("python train.py"))
+		Expect(rayJobRequest.SubmissionId).To(Equal("rayjob-sample-train"))
+		Expect(rayJobRequest.Metadata).To(Equal(rayJob.Spec.Metadata))
+		// The stage inherits the runtime environment of the RayJob.
+		Expect(rayJobRequest.RuntimeEnv).To(HaveLen(4))
+
+		rayJobRequest, err = ConvertRayJobStageToReq(rayJob, rayv1.RayJobStage{
+			Name:           "evaluate",
+			Entrypoint:     "python evaluate.py",
+			RuntimeEnvYAML: `working_dir: "./evaluate"`,
+		}, "rayjob-sample-evaluate")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rayJobRequest.RuntimeEnv).To(Equal(RuntimeEnvType{"working_dir": "./evaluate"}))
+	})
+
 	It("Test submitting/getting rayJob", func() {
 		httpmock.Activate()
 		defer httpmock.DeactivateAndReset()
@@ -171,4 +193,104 @@ var _ = Describe("RayFrameworkGenerator", func() {
 		err := rayDashboardClient.StopJob(context.TODO(), "stop-job-1")
 		Expect(err).ToNot(HaveOccurred())
 	})
+
+	It("Test listing detached actors", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ActorsPath,
+			func(_ *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(200, `{"result": true, "msg": "", "data": {"result": {"total": 1, "result": [
+					{"actor_id": "actor1", "name": "detached-actor", "node_id": "node1", "state": "ALIVE", "is_detached": true}]}}}`), nil
+			})
+
+		actors, err := rayDashboardClient.ListActors(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actors).To(HaveLen(1))
+		Expect(actors[0].Name).To(Equal("detached-actor"))
+		Expect(actors[0].NodeID).To(Equal("node1"))
+		Expect(actors[0].IsDetached).To(BeTrue())
+	})
+
+	It("Test listing running tasks", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+TasksPath,
+			func(request *http.Request) (*http.Response, error) {
+				Expect(request.URL.Query().Get("filter_values")).To(Equal("RUNNING"))
+				return httpmock.NewStringResponse(200, `{"result": true, "msg": "", "data": {"result": {"total": 1, "result": [
+					{"task_id": "task1", "name": "train", "node_id": "node1", "state": "RUNNING"}]}}}`), nil
+			})
+
+		tasks, err := rayDashboardClient.ListTasks(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks).To(HaveLen(1))
+		Expect(tasks[0].Name).To(Equal("train"))
+		Expect(tasks[0].NodeID).To(Equal("node1"))
+	})
+
+	It("Test listing placement groups fails", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+PlacementGroupsPath,
+			func(_ *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(200, `{"result": false, "msg": "state API is unavailable", "data": {}}`), nil
+			})
+
+		_, err := rayDashboardClient.ListPlacementGroups(context.TODO())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("state API is unavailable"))
+	})
+
+	It("Test getting the Ray version", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+VersionPath,
+			func(_ *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(200, `{"version": "4", "ray_version": "2.9.0", "ray_commit": "9be5a16"}`), nil
+			})
+
+		versionInfo, err := rayDashboardClient.GetVersion(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versionInfo.RayVersion).To(Equal("2.9.0"))
+		Expect(versionInfo.RayCommit).To(Equal("9be5a16"))
+	})
+	It("Test uploading a package", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		var uploaded []byte
+		httpmock.RegisterResponder("PUT", rayDashboardClient.dashboardURL+PackagesPath+"_ray_pkg_0123456789abcdef.zip",
+			func(req *http.Request) (*http.Response, error) {
+				uploaded, _ = io.ReadAll(req.Body)
+				return httpmock.NewStringResponse(200, `{"result": true, "msg": "Successfully uploaded package", "data": {}}`), nil
+			})
+
+		err := rayDashboardClient.UploadPackage(context.TODO(), "_ray_pkg_0123456789abcdef.zip", []byte("PK\x03\x04"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(uploaded).To(Equal([]byte("PK\x03\x04")))
+
+		httpmock.RegisterResponder("PUT", rayDashboardClient.dashboardURL+PackagesPath+"_ray_pkg_fedcba9876543210.zip",
+			httpmock.NewStringResponder(500, "GCS is unavailable"))
+		err = rayDashboardClient.UploadPackage(context.TODO(), "_ray_pkg_fedcba9876543210.zip", []byte("PK\x03\x04"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("GCS is unavailable"))
+	})
+
+	It("Test getting the multi-application status", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ServeDetailsPath,
+			func(_ *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(200, `{"applications": {"fruit_app": {"name": "fruit_app", "route_prefix": "/fruit",
+					"status": "RUNNING", "message": "", "deployments": {"MangoStand": {"name": "MangoStand", "status": "UPDATING",
+					"message": "", "target_num_replicas": 2, "replicas": [{"replica_id": "1", "state": "RUNNING"}, {"replica_id": "2", "state": "STARTING"}]}}}}}`), nil
+			})
+
+		statuses, err := rayDashboardClient.GetMultiApplicationStatus(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statuses).To(HaveKey("fruit_app"))
+		Expect(statuses["fruit_app"].RoutePrefix).To(Equal("/fruit"))
+		deployment := statuses["fruit_app"].Deployments["MangoStand"]
+		Expect(deployment.TargetNumReplicas).To(Equal(int32(2)))
+		Expect(deployment.RunningReplicas()).To(Equal(int32(1)))
+	})
 })
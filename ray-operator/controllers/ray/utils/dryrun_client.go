@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/json"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dryRunClient wraps a client.Client so that reads are served as usual while every write is
+// logged instead of being sent to the API server. For updates and patches, the log contains the
+// JSON merge patch between the live object and the object KubeRay would have written.
+type dryRunClient struct {
+	client.Client
+}
+
+// NewDryRunClient returns a client that only logs the writes it would make. It is used by the
+// operator's dry-run mode to validate a KubeRay upgrade against existing resources.
+func NewDryRunClient(c client.Client) client.Client {
+	return &dryRunClient{Client: c}
+}
+
+func (c *dryRunClient) Create(ctx context.Context, obj client.Object, _ ...client.CreateOption) error {
+	c.logWrite(ctx, "create", obj, "", marshalObject(obj))
+	return nil
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.logWrite(ctx, "update", obj, "", c.diffAgainstLive(ctx, obj))
+	return nil
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+	c.logWrite(ctx, "patch", obj, "", patchData(patch, obj))
+	return nil
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	c.logWrite(ctx, "delete", obj, "", "")
+	return nil
+}
+
+func (c *dryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	deleteAllOfOptions := &client.DeleteAllOfOptions{}
+	deleteAllOfOptions.ApplyOptions(opts)
+	ctrl.LoggerFrom(ctx).Info("[dry-run] Skipping write to the API server", "operation", "deleteAllOf",
+		"kind", c.kindOf(obj), "namespace", deleteAllOfOptions.Namespace, "labelSelector", deleteAllOfOptions.LabelSelector)
+	return nil
+}
+
+func (c *dryRunClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *dryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		parent:            c,
+		subResource:       subResource,
+	}
+}
+
+// diffAgainstLive returns the JSON merge patch that would turn the live object into obj.
+func (c *dryRunClient) diffAgainstLive(ctx context.Context, obj client.Object) string {
+	live, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return ""
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		return marshalObject(obj)
+	}
+	return patchData(client.MergeFrom(live), obj)
+}
+
+func (c *dryRunClient) kindOf(obj client.Object) string {
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return obj.GetObjectKind().GroupVersionKind().Kind
+	}
+	return gvk.Kind
+}
+
+func (c *dryRunClient) logWrite(ctx context.Context, operation string, obj client.Object, subResource string, diff string) {
+	name := obj.GetName()
+	if name == "" {
+		name = obj.GetGenerateName()
+	}
+	keysAndValues := []interface{}{"operation", operation, "kind", c.kindOf(obj), "namespace", obj.GetNamespace(), "name", name}
+	if subResource != "" {
+		keysAndValues = append(keysAndValues, "subResource", subResource)
+	}
+	if diff != "" {
+		keysAndValues = append(keysAndValues, "diff", diff)
+	}
+	ctrl.LoggerFrom(ctx).Info("[dry-run] Skipping write to the API server", keysAndValues...)
+}
+
+// dryRunSubResourceClient logs writes to subresources such as status instead of sending them.
+type dryRunSubResourceClient struct {
+	client.SubResourceClient
+	parent      *dryRunClient
+	subResource string
+}
+
+func (c *dryRunSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, _ ...client.SubResourceCreateOption) error {
+	c.parent.logWrite(ctx, "create", obj, c.subResource, marshalObject(subResource))
+	return nil
+}
+
+func (c *dryRunSubResourceClient) Update(ctx context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	c.parent.logWrite(ctx, "update", obj, c.subResource, c.parent.diffAgainstLive(ctx, obj))
+	return nil
+}
+
+func (c *dryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, _ ...client.SubResourcePatchOption) error {
+	c.parent.logWrite(ctx, "patch", obj, c.subResource, patchData(patch, obj))
+	return nil
+}
+
+func marshalObject(obj client.Object) string {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func patchData(patch client.Patch, obj client.Object) string {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
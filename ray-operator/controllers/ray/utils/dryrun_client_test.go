@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDryRunClient(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	existingPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing-pod",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "ray"},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(scheme).WithObjects(existingPod).WithStatusSubresource(existingPod).Build()
+	dryRunClient := NewDryRunClient(fakeClient)
+
+	// Create is skipped.
+	newPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "new-pod", Namespace: "default"}}
+	require.NoError(t, dryRunClient.Create(ctx, newPod))
+	podList := corev1.PodList{}
+	require.NoError(t, fakeClient.List(ctx, &podList))
+	assert.Len(t, podList.Items, 1)
+
+	// Update is skipped.
+	pod := &corev1.Pod{}
+	require.NoError(t, dryRunClient.Get(ctx, types.NamespacedName{Name: "existing-pod", Namespace: "default"}, pod))
+	pod.Labels["app"] = "updated"
+	require.NoError(t, dryRunClient.Update(ctx, pod))
+
+	// Patch is skipped.
+	patchedPod := pod.DeepCopy()
+	patchedPod.Labels["patched"] = "true"
+	require.NoError(t, dryRunClient.Patch(ctx, patchedPod, client.MergeFrom(pod)))
+
+	// Status updates are skipped.
+	pod.Status.Phase = corev1.PodRunning
+	require.NoError(t, dryRunClient.Status().Update(ctx, pod))
+
+	// Delete and DeleteAllOf are skipped.
+	require.NoError(t, dryRunClient.Delete(ctx, pod))
+	require.NoError(t, dryRunClient.DeleteAllOf(ctx, &corev1.Pod{}, client.InNamespace("default")))
+
+	livePod := &corev1.Pod{}
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "existing-pod", Namespace: "default"}, livePod))
+	assert.Equal(t, map[string]string{"app": "ray"}, livePod.Labels)
+	assert.Empty(t, livePod.Status.Phase)
+}
+
+func TestDryRunClientDiffAgainstLive(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	existingService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "existing-svc",
+			Namespace: "default",
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(scheme).WithObjects(existingService).Build()
+	dryRunClient := &dryRunClient{Client: fakeClient}
+
+	svc := &corev1.Service{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(existingService), svc))
+	svc.Labels = map[string]string{"app": "ray"}
+	assert.Equal(t, `{"metadata":{"labels":{"app":"ray"}}}`, dryRunClient.diffAgainstLive(ctx, svc))
+
+	// Objects that do not exist yet are logged in full.
+	newService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "new-svc", Namespace: "default"}}
+	assert.Contains(t, dryRunClient.diffAgainstLive(ctx, newService), `"name":"new-svc"`)
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// dryRunManager wraps a manager.Manager so that the event recorders it hands out log the events
+// instead of creating Event objects in the API server.
+type dryRunManager struct {
+	manager.Manager
+}
+
+// NewDryRunManager returns a manager whose event recorders only log the events they would record.
+// It is used with NewDryRunClient by the operator's dry-run mode, since the event recorders write
+// to the API server with their own client.
+func NewDryRunManager(mgr manager.Manager) manager.Manager {
+	return &dryRunManager{Manager: mgr}
+}
+
+func (m *dryRunManager) GetEventRecorderFor(name string) record.EventRecorder {
+	return NewDryRunEventRecorder(name)
+}
+
+// dryRunEventRecorder logs the events instead of recording them.
+type dryRunEventRecorder struct {
+	component string
+}
+
+// NewDryRunEventRecorder returns an event recorder that only logs the events of the given component.
+func NewDryRunEventRecorder(component string) record.EventRecorder {
+	return &dryRunEventRecorder{component: component}
+}
+
+func (r *dryRunEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	keysAndValues := []interface{}{"component", r.component, "type", eventtype, "reason", reason, "message", message}
+	if accessor, err := meta.Accessor(object); err == nil {
+		keysAndValues = append(keysAndValues, "namespace", accessor.GetNamespace(), "name", accessor.GetName())
+	}
+	ctrl.Log.WithName("dry-run").Info("[dry-run] Skipping event", keysAndValues...)
+}
+
+func (r *dryRunEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *dryRunEventRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDryRunManager(t *testing.T) {
+	recorder := NewDryRunManager(nil).GetEventRecorderFor("raycluster-controller")
+	assert.Equal(t, &dryRunEventRecorder{component: "raycluster-controller"}, recorder)
+
+	// The events are only logged.
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}}
+	recorder.Eventf(pod, corev1.EventTypeNormal, "Created", "Created Pod %s", pod.Name)
+	recorder.AnnotatedEventf(pod, map[string]string{"key": "value"}, corev1.EventTypeWarning, "Failed", "Failed to create Pod %s", pod.Name)
+}
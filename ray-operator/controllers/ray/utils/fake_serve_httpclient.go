@@ -12,8 +12,17 @@ import (
 type FakeRayDashboardClient struct {
 	multiAppStatuses map[string]*ServeApplicationStatus
 	GetJobInfoMock   atomic.Pointer[func(context.Context, string) (*RayJobInfo, error)]
+	// DeleteServeApplicationsCalls counts the calls of DeleteServeApplications.
+	DeleteServeApplicationsCalls atomic.Int32
 	BaseDashboardClient
-	serveDetails ServeDetails
+	nodes           []RayNodeState
+	actors          []RayActorState
+	tasks           []RayTaskState
+	placementGroups []RayPlacementGroupState
+	serveDetails    ServeDetails
+	versionInfo     *RayVersionInfo
+	// UploadedPackages are the zip files stored with UploadPackage, by package name.
+	UploadedPackages map[string][]byte
 }
 
 var _ RayDashboardClientInterface = (*FakeRayDashboardClient)(nil)
@@ -29,6 +38,11 @@ func (r *FakeRayDashboardClient) UpdateDeployments(_ context.Context, _ []byte)
 	return nil
 }
 
+func (r *FakeRayDashboardClient) DeleteServeApplications(_ context.Context) error {
+	r.DeleteServeApplicationsCalls.Add(1)
+	return nil
+}
+
 func (r *FakeRayDashboardClient) GetMultiApplicationStatus(_ context.Context) (map[string]*ServeApplicationStatus, error) {
 	return r.multiAppStatuses, nil
 }
@@ -41,6 +55,11 @@ func (r *FakeRayDashboardClient) SetMultiApplicationStatuses(statuses map[string
 	r.multiAppStatuses = statuses
 }
 
+// SetServeDetails sets the serve details returned by GetServeDetails.
+func (r *FakeRayDashboardClient) SetServeDetails(serveDetails ServeDetails) {
+	r.serveDetails = serveDetails
+}
+
 func (r *FakeRayDashboardClient) GetJobInfo(ctx context.Context, jobId string) (*RayJobInfo, error) {
 	if mock := r.GetJobInfoMock.Load(); mock != nil {
 		return (*mock)(ctx, jobId)
@@ -79,3 +98,53 @@ func (r *FakeRayDashboardClient) StopJob(_ context.Context, _ string) (err error
 func (r *FakeRayDashboardClient) DeleteJob(_ context.Context, _ string) error {
 	return nil
 }
+
+func (r *FakeRayDashboardClient) ListNodes(_ context.Context) ([]RayNodeState, error) {
+	return r.nodes, nil
+}
+
+func (r *FakeRayDashboardClient) ListActors(_ context.Context) ([]RayActorState, error) {
+	return r.actors, nil
+}
+
+func (r *FakeRayDashboardClient) ListTasks(_ context.Context) ([]RayTaskState, error) {
+	return r.tasks, nil
+}
+
+func (r *FakeRayDashboardClient) ListPlacementGroups(_ context.Context) ([]RayPlacementGroupState, error) {
+	return r.placementGroups, nil
+}
+
+func (r *FakeRayDashboardClient) GetVersion(_ context.Context) (*RayVersionInfo, error) {
+	if r.versionInfo == nil {
+		return nil, fmt.Errorf("the version of the fake dashboard is not set")
+	}
+	return r.versionInfo, nil
+}
+
+func (r *FakeRayDashboardClient) UploadPackage(_ context.Context, packageName string, zip []byte) error {
+	if r.UploadedPackages == nil {
+		r.UploadedPackages = map[string][]byte{}
+	}
+	r.UploadedPackages[packageName] = zip
+	return nil
+}
+
+func (r *FakeRayDashboardClient) SetVersion(versionInfo *RayVersionInfo) {
+	r.versionInfo = versionInfo
+}
+
+// GetAuthToken returns the auth token set with SetAuthToken.
+func (r *FakeRayDashboardClient) GetAuthToken() string {
+	return r.authToken
+}
+
+func (r *FakeRayDashboardClient) SetStateAPIResults(nodes []RayNodeState, actors []RayActorState, placementGroups []RayPlacementGroupState) {
+	r.nodes = nodes
+	r.actors = actors
+	r.placementGroups = placementGroups
+}
+
+func (r *FakeRayDashboardClient) SetTasks(tasks []RayTaskState) {
+	r.tasks = tasks
+}
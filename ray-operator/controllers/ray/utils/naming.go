@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"crypto/sha1" //nolint:gosec // We are not using this for security purposes
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// NameTruncationStrategy is how a generated name longer than the maximum name length is shortened.
+type NameTruncationStrategy string
+
+const (
+	// TruncateStart drops characters from the start of the name.
+	TruncateStart NameTruncationStrategy = "TruncateStart"
+	// TruncateEnd drops characters from the end of the name.
+	TruncateEnd NameTruncationStrategy = "TruncateEnd"
+	// TruncateHash drops characters from the end of the name and appends a short hash of the full name,
+	// so that the names which only differ in their end stay unique.
+	TruncateHash NameTruncationStrategy = "Hash"
+)
+
+const (
+	// DefaultMaxGeneratedNameLength is the maximum length of a DNS label.
+	DefaultMaxGeneratedNameLength = 63
+	// MinMaxGeneratedNameLength leaves enough room for the hash and the random suffix of Pod names.
+	MinMaxGeneratedNameLength = 16
+	// generatedPodNameSuffixLength is the length of the random suffix Kubernetes appends to metadata.generateName.
+	generatedPodNameSuffixLength = 5
+	nameHashLength               = 5
+)
+
+var namingAffixRegexp = regexp.MustCompile(`^[a-z0-9-]*$`)
+
+// NamingTemplates customizes the names of the Pods and Services generated by the operator, for the organizations
+// whose network policies or DNS tooling require specific naming conventions. The names of the existing Pods and
+// Services are not changed, so the templates should be set before the RayClusters are created.
+type NamingTemplates struct {
+	// PodNamePrefix is prepended to the names of the Ray Pods.
+	PodNamePrefix string `json:"podNamePrefix,omitempty"`
+	// PodNameSuffix is appended to the names of the Ray Pods, before the random suffix added by Kubernetes.
+	PodNameSuffix string `json:"podNameSuffix,omitempty"`
+	// ServiceNamePrefix is prepended to the names of the Services of RayClusters and RayServices.
+	ServiceNamePrefix string `json:"serviceNamePrefix,omitempty"`
+	// ServiceNameSuffix is appended to the names of the Services of RayClusters and RayServices.
+	ServiceNameSuffix string `json:"serviceNameSuffix,omitempty"`
+	// MaxNameLength is the maximum length of the generated names, including the random suffix of the Pod names.
+	// Defaults to 63.
+	MaxNameLength int `json:"maxNameLength,omitempty"`
+	// TruncationStrategy is how the names longer than MaxNameLength are shortened. Valid values are
+	// `TruncateStart`, `TruncateEnd` and `Hash`. Defaults to `Hash`.
+	TruncationStrategy NameTruncationStrategy `json:"truncationStrategy,omitempty"`
+}
+
+// namingTemplates is set once at startup, before the controllers generate any name.
+var namingTemplates NamingTemplates
+
+// SetNamingTemplates sets the naming templates of the names generated by the operator.
+func SetNamingTemplates(templates NamingTemplates) {
+	namingTemplates = templates
+}
+
+// IsZero returns true if no naming template is configured, in which case the names are generated as before.
+func (t NamingTemplates) IsZero() bool {
+	return t == NamingTemplates{}
+}
+
+// Validate returns an error if the naming templates could generate invalid names.
+func (t NamingTemplates) Validate() error {
+	for name, affix := range map[string]string{
+		"podNamePrefix":     t.PodNamePrefix,
+		"podNameSuffix":     t.PodNameSuffix,
+		"serviceNamePrefix": t.ServiceNamePrefix,
+		"serviceNameSuffix": t.ServiceNameSuffix,
+	} {
+		if !namingAffixRegexp.MatchString(affix) {
+			return fmt.Errorf("%s %q must only contain lowercase alphanumeric characters or '-'", name, affix)
+		}
+	}
+	if t.ServiceNamePrefix != "" && !unicode.IsLetter(rune(t.ServiceNamePrefix[0])) {
+		return fmt.Errorf("serviceNamePrefix %q must start with a letter", t.ServiceNamePrefix)
+	}
+	if t.MaxNameLength != 0 && (t.MaxNameLength < MinMaxGeneratedNameLength || t.MaxNameLength > DefaultMaxGeneratedNameLength) {
+		return fmt.Errorf("maxNameLength %d must be between %d and %d", t.MaxNameLength, MinMaxGeneratedNameLength, DefaultMaxGeneratedNameLength)
+	}
+	switch t.TruncationStrategy {
+	case "", TruncateStart, TruncateEnd, TruncateHash:
+	default:
+		return fmt.Errorf("truncationStrategy %q is not supported, valid values are %s, %s and %s",
+			t.TruncationStrategy, TruncateStart, TruncateEnd, TruncateHash)
+	}
+	return nil
+}
+
+func (t NamingTemplates) maxNameLength() int {
+	if t.MaxNameLength == 0 {
+		return DefaultMaxGeneratedNameLength
+	}
+	return t.MaxNameLength
+}
+
+// shorten shortens the name to maxLength characters with the truncation strategy.
+func (t NamingTemplates) shorten(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	switch t.TruncationStrategy {
+	case TruncateStart:
+		return strings.TrimLeft(name[len(name)-maxLength:], "-")
+	case TruncateEnd:
+		return strings.TrimRight(name[:maxLength], "-")
+	default:
+		hash := sha1.Sum([]byte(name)) //nolint:gosec // We are not using this for security purposes
+		return strings.TrimRight(name[:maxLength-nameHashLength-1], "-") + DashSymbol + fmt.Sprintf("%x", hash)[:nameHashLength]
+	}
+}
+
+// GenerateServiceName applies the naming templates to the default name of a Service generated by the operator.
+// Without naming templates, the default name is only passed through CheckName.
+func GenerateServiceName(name string) string {
+	if namingTemplates.IsZero() {
+		return CheckName(name)
+	}
+	name = namingTemplates.shorten(namingTemplates.ServiceNamePrefix+name+namingTemplates.ServiceNameSuffix, namingTemplates.maxNameLength())
+	// Service names must start with a letter.
+	if !unicode.IsLetter(rune(name[0])) {
+		name = "r" + name[1:]
+	}
+	return name
+}
+
+// CheckServiceName makes sure the name of a Service is valid before it is created. The names generated with naming
+// templates are already valid, and must not be shortened again.
+func CheckServiceName(name string) string {
+	if namingTemplates.IsZero() {
+		return CheckName(name)
+	}
+	return name
+}
+
+// podGenerateNameWithTemplates returns the metadata.generateName of a Ray Pod with the naming templates applied.
+func podGenerateNameWithTemplates(prefix string, nodeType rayv1.RayNodeType) string {
+	name := strings.ToLower(namingTemplates.PodNamePrefix + prefix + DashSymbol + string(nodeType) + namingTemplates.PodNameSuffix)
+	// Leave room for the dash and the random suffix appended by Kubernetes.
+	name = namingTemplates.shorten(name, namingTemplates.maxNameLength()-generatedPodNameSuffixLength-1)
+	return name + DashSymbol
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestNamingTemplates(t *testing.T) {
+	defer SetNamingTemplates(NamingTemplates{})
+
+	SetNamingTemplates(NamingTemplates{
+		PodNamePrefix:     "org-",
+		PodNameSuffix:     "-x",
+		ServiceNamePrefix: "svc-",
+		ServiceNameSuffix: "-int",
+	})
+	assert.Equal(t, "org-ray-cluster-head-x-", PodGenerateName("ray-cluster", rayv1.HeadNode))
+	assert.Equal(t, "svc-my-service-serve-svc-int", GenerateServeServiceName("my-service"))
+	headSvcName, err := GenerateHeadServiceName(RayClusterCRD, rayv1.RayClusterSpec{}, "ray")
+	require.NoError(t, err)
+	assert.Equal(t, "svc-ray-head-svc-int", headSvcName)
+	// The names generated with templates are not shortened again.
+	assert.Equal(t, headSvcName, CheckServiceName(headSvcName))
+}
+
+func TestNamingTemplatesTruncation(t *testing.T) {
+	defer SetNamingTemplates(NamingTemplates{})
+
+	tests := []struct {
+		strategy        NameTruncationStrategy
+		expectedService string
+	}{
+		{strategy: TruncateStart, expectedService: "klmnopqrstuvwxyz-int"},
+		{strategy: TruncateEnd, expectedService: "svc-abcdefghijklmnop"},
+		{strategy: TruncateHash, expectedService: "svc-abcdefghij-39c7d"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.strategy), func(t *testing.T) {
+			SetNamingTemplates(NamingTemplates{
+				ServiceNamePrefix:  "svc-",
+				ServiceNameSuffix:  "-int",
+				MaxNameLength:      20,
+				TruncationStrategy: test.strategy,
+			})
+			assert.Equal(t, test.expectedService, GenerateServiceName("abcdefghijklmnopqrstuvwxyz"))
+		})
+	}
+
+	// The random suffix of the Pod names counts towards the maximum length.
+	SetNamingTemplates(NamingTemplates{PodNamePrefix: "org-", PodNameSuffix: "-x", MaxNameLength: 20})
+	podName := PodGenerateName("ray-cluster-with-a-long-name", rayv1.WorkerNode)
+	assert.Equal(t, "org-ray-f9e55-", podName)
+	assert.LessOrEqual(t, len(podName)+5, 20)
+}
+
+func TestValidateNamingTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates NamingTemplates
+		wantErr   bool
+	}{
+		{name: "empty", templates: NamingTemplates{}},
+		{name: "valid", templates: NamingTemplates{PodNamePrefix: "org-", ServiceNameSuffix: "-int", MaxNameLength: 40, TruncationStrategy: TruncateEnd}},
+		{name: "uppercase prefix", templates: NamingTemplates{PodNamePrefix: "Org-"}, wantErr: true},
+		{name: "service prefix starting with a digit", templates: NamingTemplates{ServiceNamePrefix: "1-"}, wantErr: true},
+		{name: "max length too short", templates: NamingTemplates{MaxNameLength: 10}, wantErr: true},
+		{name: "max length too long", templates: NamingTemplates{MaxNameLength: 64}, wantErr: true},
+		{name: "unknown strategy", templates: NamingTemplates{TruncationStrategy: "Middle"}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.templates.Validate()
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodBatchPolicy limits the worker Pods which the RayCluster controller creates and deletes per reconciliation, so
+// that scaling worker groups with thousands of replicas neither gets throttled by the Kubernetes API server nor puts
+// pressure on etcd. The remaining Pods are created or deleted by the next reconciliations, one batch per batch
+// interval, and reported in the podBatching status of the RayCluster. Zero values disable the batching.
+type PodBatchPolicy struct {
+	// MaxPodsPerReconcile is the maximum number of worker Pods created or deleted by a reconciliation of a RayCluster.
+	// 0 means no limit.
+	MaxPodsPerReconcile int `json:"maxPodsPerReconcile,omitempty"`
+	// BatchInterval is the minimum delay between two batches of worker Pods of a RayCluster. Defaults to the
+	// RayCluster requeue interval.
+	BatchInterval metav1.Duration `json:"batchInterval,omitempty"`
+}
+
+// podBatchPolicy is set once at startup, before the controllers are started.
+var podBatchPolicy PodBatchPolicy
+
+// SetPodBatchPolicy sets how many worker Pods the RayCluster controller creates and deletes per reconciliation.
+func SetPodBatchPolicy(policy PodBatchPolicy) {
+	podBatchPolicy = policy
+}
+
+// Validate returns an error if the pod batch policy has a negative value.
+func (p PodBatchPolicy) Validate() error {
+	if p.MaxPodsPerReconcile < 0 {
+		return fmt.Errorf("maxPodsPerReconcile %d must not be negative", p.MaxPodsPerReconcile)
+	}
+	if p.BatchInterval.Duration < 0 {
+		return fmt.Errorf("batchInterval %s must not be negative", p.BatchInterval.Duration)
+	}
+	return nil
+}
+
+// MaxPodsPerReconcile returns the maximum number of worker Pods created or deleted by a reconciliation of a
+// RayCluster, or 0 if it is not limited.
+func MaxPodsPerReconcile() int {
+	return podBatchPolicy.MaxPodsPerReconcile
+}
+
+// PodBatchInterval returns the minimum delay between two batches of worker Pods of a RayCluster.
+func PodBatchInterval() time.Duration {
+	return durationOrDefault(podBatchPolicy.BatchInterval, RayClusterRequeueDuration())
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodBatchPolicy(t *testing.T) {
+	defer SetPodBatchPolicy(PodBatchPolicy{})
+	defer SetRequeuePolicy(RequeuePolicy{})
+
+	assert.Equal(t, 0, MaxPodsPerReconcile())
+	assert.Equal(t, DefaultRayClusterRequeueDuration, PodBatchInterval())
+
+	// The batch interval defaults to the RayCluster requeue interval.
+	SetRequeuePolicy(RequeuePolicy{RayClusterRequeueInterval: metav1.Duration{Duration: 10 * time.Second}})
+	assert.Equal(t, 10*time.Second, PodBatchInterval())
+
+	SetPodBatchPolicy(PodBatchPolicy{MaxPodsPerReconcile: 100, BatchInterval: metav1.Duration{Duration: 30 * time.Second}})
+	assert.Equal(t, 100, MaxPodsPerReconcile())
+	assert.Equal(t, 30*time.Second, PodBatchInterval())
+
+	assert.NoError(t, PodBatchPolicy{MaxPodsPerReconcile: 100}.Validate())
+	assert.Error(t, PodBatchPolicy{MaxPodsPerReconcile: -1}.Validate())
+	assert.Error(t, PodBatchPolicy{BatchInterval: metav1.Duration{Duration: -time.Second}}.Validate())
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+const (
+	// RayEnableAutoscalerV2EnvVar enables the autoscaler v2 of Ray when it is set to 1 or true in the head Pod.
+	RayEnableAutoscalerV2EnvVar = "RAY_enable_autoscaler_v2"
+)
+
+var (
+	// rayImageRepositoryRegex matches the names of the images published by Ray, e.g. rayproject/ray and
+	// rayproject/ray-ml, whose tags start with the Ray version.
+	rayImageRepositoryRegex = regexp.MustCompile(`^ray(-[a-z]+)?$`)
+	rayImageTagRegex        = regexp.MustCompile(`^(\d+\.\d+\.\d+)(?:[-.].*)?$`)
+)
+
+// RayVersionRequirement is a feature of a custom resource which requires a minimum Ray version.
+type RayVersionRequirement struct {
+	// Feature describes the feature in the error messages, e.g. with the field requesting it.
+	Feature string
+	// MinVersion is the oldest Ray version supporting the feature.
+	MinVersion string
+}
+
+// RayClusterVersionRequirements returns the features requested by a RayCluster spec which require a minimum Ray
+// version.
+func RayClusterVersionRequirements(spec *rayv1.RayClusterSpec) []RayVersionRequirement {
+	var requirements []RayVersionRequirement
+	if containers := spec.HeadGroupSpec.Template.Spec.Containers; len(containers) > RayContainerIndex {
+		for _, env := range containers[RayContainerIndex].Env {
+			if env.Name == RayEnableAutoscalerV2EnvVar && (env.Value == "1" || strings.EqualFold(env.Value, "true")) {
+				requirements = append(requirements, RayVersionRequirement{Feature: "autoscaler v2 (" + RayEnableAutoscalerV2EnvVar + ")", MinVersion: "2.10.0"})
+			}
+		}
+	}
+	return requirements
+}
+
+// RayJobVersionRequirements returns the features requested by a RayJob, and by the spec of its RayCluster, which
+// require a minimum Ray version.
+func RayJobVersionRequirements(rayJob *rayv1.RayJob) []RayVersionRequirement {
+	var requirements []RayVersionRequirement
+	if rayJob.Spec.RayClusterSpec != nil {
+		requirements = RayClusterVersionRequirements(rayJob.Spec.RayClusterSpec)
+	}
+	if len(rayJob.Spec.Metadata) > 0 {
+		requirements = append(requirements, RayVersionRequirement{Feature: "spec.metadata", MinVersion: "2.6.0"})
+	}
+	return requirements
+}
+
+// RayServiceVersionRequirements returns the features requested by a RayService, and by the spec of its RayCluster,
+// which require a minimum Ray version.
+func RayServiceVersionRequirements(rayService *rayv1.RayService) []RayVersionRequirement {
+	requirements := RayClusterVersionRequirements(&rayService.Spec.RayClusterSpec)
+	if rayService.Spec.ServeConfigV2 != "" {
+		// The multi-application Serve config of serveConfigV2 is applied with the REST API of Ray 2.4.0.
+		requirements = append(requirements, RayVersionRequirement{Feature: "spec.serveConfigV2", MinVersion: "2.4.0"})
+	}
+	return requirements
+}
+
+// GetRayVersion returns the Ray version of a RayCluster spec and where it was found: spec.rayVersion if it is set,
+// otherwise the tag of the Ray image of the head Pod, if it is an image published by Ray, like rayproject/ray:2.9.0
+// or rayproject/ray-ml:2.9.0-py310-gpu. It returns nil if the version is unknown, e.g. for the nightly images and the
+// custom images without spec.rayVersion.
+func GetRayVersion(spec *rayv1.RayClusterSpec) (*semver.Version, string) {
+	if spec.RayVersion != "" {
+		version, err := semver.NewVersion(spec.RayVersion)
+		if err != nil {
+			return nil, ""
+		}
+		return version, "spec.rayVersion"
+	}
+	containers := spec.HeadGroupSpec.Template.Spec.Containers
+	if len(containers) <= RayContainerIndex {
+		return nil, ""
+	}
+	image := containers[RayContainerIndex].Image
+	tag := rayImageVersion(image)
+	if tag == "" {
+		return nil, ""
+	}
+	version, err := semver.NewVersion(tag)
+	if err != nil {
+		return nil, ""
+	}
+	return version, "the tag of image " + image
+}
+
+// rayImageVersion returns the Ray version of the tag of an image published by Ray, or "".
+func rayImageVersion(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	slash := strings.LastIndex(image, "/")
+	colon := strings.LastIndex(image, ":")
+	if colon <= slash || !rayImageRepositoryRegex.MatchString(image[slash+1:colon]) {
+		return ""
+	}
+	if match := rayImageTagRegex.FindStringSubmatch(image[colon+1:]); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// ValidateRayVersion returns an error naming the first feature which the Ray version of a RayCluster spec does not
+// support, so that the custom resource fails fast instead of crash-looping Ray Pods. Nothing is checked when the
+// Ray version is unknown.
+func ValidateRayVersion(spec *rayv1.RayClusterSpec, requirements ...RayVersionRequirement) error {
+	if len(requirements) == 0 {
+		return nil
+	}
+	version, source := GetRayVersion(spec)
+	if version == nil {
+		return nil
+	}
+	for _, requirement := range requirements {
+		minVersion := semver.MustParse(requirement.MinVersion)
+		if version.LessThan(minVersion) {
+			return fmt.Errorf("%s requires Ray %s or later, but the Ray version is %s according to %s; "+
+				"upgrade the Ray image, or set spec.rayVersion if the image runs another Ray version",
+				requirement.Feature, requirement.MinVersion, version, source)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestRayImageVersion(t *testing.T) {
+	for image, version := range map[string]string{
+		"rayproject/ray:2.9.0":                        "2.9.0",
+		"rayproject/ray-ml:2.9.0-py310-gpu":           "2.9.0",
+		"docker.io/rayproject/ray:2.10.0.ab12cd-py39": "2.10.0",
+		"localhost:5000/ray:2.9.0@sha256:0123":        "2.9.0",
+		"rayproject/ray:nightly":                      "",
+		"rayproject/ray":                              "",
+		"localhost:5000/ray":                          "",
+		"example.com/ml/trainer:1.2.3":                "",
+	} {
+		assert.Equal(t, version, rayImageVersion(image), image)
+	}
+}
+
+func TestValidateRayVersion(t *testing.T) {
+	newSpec := func(image string, env ...corev1.EnvVar) *rayv1.RayClusterSpec {
+		return &rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: image, Env: env}}},
+				},
+			},
+		}
+	}
+	autoscalerV2 := corev1.EnvVar{Name: RayEnableAutoscalerV2EnvVar, Value: "1"}
+
+	spec := newSpec("rayproject/ray:2.9.0", autoscalerV2)
+	err := ValidateRayVersion(spec, RayClusterVersionRequirements(spec)...)
+	assert.EqualError(t, err, "autoscaler v2 (RAY_enable_autoscaler_v2) requires Ray 2.10.0 or later, but the Ray version is 2.9.0 "+
+		"according to the tag of image rayproject/ray:2.9.0; upgrade the Ray image, or set spec.rayVersion if the image runs another Ray version")
+
+	// spec.rayVersion takes precedence over the image tag.
+	spec.RayVersion = "2.10.0"
+	assert.NoError(t, ValidateRayVersion(spec, RayClusterVersionRequirements(spec)...))
+
+	// Nothing is checked when the Ray version is unknown.
+	spec = newSpec("example.com/ml/trainer:1.2.3", autoscalerV2)
+	assert.NoError(t, ValidateRayVersion(spec, RayClusterVersionRequirements(spec)...))
+	spec = newSpec("rayproject/ray:2.9.0")
+	assert.NoError(t, ValidateRayVersion(spec, RayClusterVersionRequirements(spec)...))
+
+	rayService := &rayv1.RayService{Spec: rayv1.RayServiceSpec{
+		ServeConfigV2:  "applications: []",
+		RayClusterSpec: *newSpec("rayproject/ray:2.3.1"),
+	}}
+	err = ValidateRayVersion(&rayService.Spec.RayClusterSpec, RayServiceVersionRequirements(rayService)...)
+	assert.ErrorContains(t, err, "spec.serveConfigV2 requires Ray 2.4.0 or later")
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	DefaultRayClusterRequeueDuration = 2 * time.Second
+	DefaultRayJobRequeueDuration     = 3 * time.Second
+	DefaultRayServiceRequeueDuration = 2 * time.Second
+	DefaultServeStatusPollDuration   = 2 * time.Second
+	// DefaultErrorBaseBackoff and DefaultErrorMaxBackoff are the defaults of controller-runtime.
+	DefaultErrorBaseBackoff = 5 * time.Millisecond
+	DefaultErrorMaxBackoff  = 1000 * time.Second
+)
+
+// RequeuePolicy configures how often the controllers reconcile the custom resources again. Large fleets can
+// requeue less often to reduce the load on the Kubernetes API server, while development environments can
+// requeue more often to converge faster. Zero values keep the defaults.
+type RequeuePolicy struct {
+	// RayClusterRequeueInterval is how long the RayCluster controller waits before it reconciles a RayCluster
+	// again while it waits for a change, e.g. for Pods to be deleted. Defaults to 2s.
+	RayClusterRequeueInterval metav1.Duration `json:"rayClusterRequeueInterval,omitempty"`
+	// RayJobRequeueInterval is how long the RayJob controller waits before it reconciles a RayJob again, e.g.
+	// to check the status of the Ray job. Defaults to 3s.
+	RayJobRequeueInterval metav1.Duration `json:"rayJobRequeueInterval,omitempty"`
+	// RayServiceRequeueInterval is how long the RayService controller waits before it reconciles a RayService
+	// again, e.g. to check the status of the Serve applications. Defaults to 2s.
+	RayServiceRequeueInterval metav1.Duration `json:"rayServiceRequeueInterval,omitempty"`
+	// ServeStatusPollInterval is how often the RayService controller polls the statuses of the Serve applications
+	// from the dashboards of the RayClusters in the background. The polls are jittered by up to 20%. Defaults to 2s.
+	ServeStatusPollInterval metav1.Duration `json:"serveStatusPollInterval,omitempty"`
+	// PeriodicReconcileInterval is how often a RayCluster is reconciled when nothing changes. It can be overridden
+	// for a RayCluster with the `ray.io/reconcile-interval` annotation. Defaults to the value of the
+	// RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV environment variable, or 300s if it is not set.
+	PeriodicReconcileInterval metav1.Duration `json:"periodicReconcileInterval,omitempty"`
+	// ErrorBaseBackoff is the delay before a resource whose reconciliation failed is reconciled again. The delay
+	// doubles with every consecutive failure, up to ErrorMaxBackoff. Defaults to 5ms.
+	ErrorBaseBackoff metav1.Duration `json:"errorBaseBackoff,omitempty"`
+	// ErrorMaxBackoff is the maximum delay before a resource whose reconciliation failed is reconciled again.
+	// Defaults to 1000s.
+	ErrorMaxBackoff metav1.Duration `json:"errorMaxBackoff,omitempty"`
+}
+
+// requeuePolicy is set once at startup, before the controllers are started.
+var requeuePolicy RequeuePolicy
+
+// SetRequeuePolicy sets how often the controllers reconcile the custom resources again.
+func SetRequeuePolicy(policy RequeuePolicy) {
+	requeuePolicy = policy
+}
+
+// Validate returns an error if a duration of the requeue policy is negative, or the backoffs are inconsistent.
+func (p RequeuePolicy) Validate() error {
+	for name, duration := range map[string]metav1.Duration{
+		"rayClusterRequeueInterval": p.RayClusterRequeueInterval,
+		"rayJobRequeueInterval":     p.RayJobRequeueInterval,
+		"rayServiceRequeueInterval": p.RayServiceRequeueInterval,
+		"serveStatusPollInterval":   p.ServeStatusPollInterval,
+		"periodicReconcileInterval": p.PeriodicReconcileInterval,
+		"errorBaseBackoff":          p.ErrorBaseBackoff,
+		"errorMaxBackoff":           p.ErrorMaxBackoff,
+	} {
+		if duration.Duration < 0 {
+			return fmt.Errorf("%s %s must not be negative", name, duration.Duration)
+		}
+	}
+	if baseBackoff, maxBackoff := p.errorBaseBackoff(), p.errorMaxBackoff(); baseBackoff > maxBackoff {
+		return fmt.Errorf("errorBaseBackoff %s must not be greater than errorMaxBackoff %s", baseBackoff, maxBackoff)
+	}
+	return nil
+}
+
+func (p RequeuePolicy) errorBaseBackoff() time.Duration {
+	return durationOrDefault(p.ErrorBaseBackoff, DefaultErrorBaseBackoff)
+}
+
+func (p RequeuePolicy) errorMaxBackoff() time.Duration {
+	return durationOrDefault(p.ErrorMaxBackoff, DefaultErrorMaxBackoff)
+}
+
+// RayClusterRequeueDuration returns how long to wait before reconciling a RayCluster again.
+func RayClusterRequeueDuration() time.Duration {
+	return durationOrDefault(requeuePolicy.RayClusterRequeueInterval, DefaultRayClusterRequeueDuration)
+}
+
+// RayJobRequeueDuration returns how long to wait before reconciling a RayJob again.
+func RayJobRequeueDuration() time.Duration {
+	return durationOrDefault(requeuePolicy.RayJobRequeueInterval, DefaultRayJobRequeueDuration)
+}
+
+// RayServiceRequeueDuration returns how long to wait before reconciling a RayService again.
+func RayServiceRequeueDuration() time.Duration {
+	return durationOrDefault(requeuePolicy.RayServiceRequeueInterval, DefaultRayServiceRequeueDuration)
+}
+
+// ServeStatusPollDuration returns how often the statuses of the Serve applications of a RayCluster are polled.
+func ServeStatusPollDuration() time.Duration {
+	return durationOrDefault(requeuePolicy.ServeStatusPollInterval, DefaultServeStatusPollDuration)
+}
+
+// PeriodicReconcileInterval returns how often the RayCluster is reconciled when nothing changes. The
+// `ray.io/reconcile-interval` annotation of the RayCluster takes precedence over the requeue policy, which takes
+// precedence over the RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV environment variable. Invalid annotations are ignored.
+func PeriodicReconcileInterval(instance metav1.Object) time.Duration {
+	if value, ok := instance.GetAnnotations()[RayReconcileIntervalAnnotationKey]; ok {
+		if interval, err := time.ParseDuration(value); err == nil && interval > 0 {
+			return interval
+		}
+	}
+	if requeuePolicy.PeriodicReconcileInterval.Duration > 0 {
+		return requeuePolicy.PeriodicReconcileInterval.Duration
+	}
+	requeueAfterSeconds, err := strconv.Atoi(os.Getenv(RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV))
+	if err != nil {
+		requeueAfterSeconds = RAYCLUSTER_DEFAULT_REQUEUE_SECONDS
+	}
+	return time.Duration(requeueAfterSeconds) * time.Second
+}
+
+// ErrorRateLimiter returns the rate limiter of the workqueues of the controllers. It is the default rate limiter of
+// controller-runtime, with the per-item exponential backoff of the requeue policy.
+func ErrorRateLimiter() workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(requeuePolicy.errorBaseBackoff(), requeuePolicy.errorMaxBackoff()),
+		// 10 qps, 100 bucket size. This only limits the overall retry speed, not the retries of each item.
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
+
+func durationOrDefault(duration metav1.Duration, defaultDuration time.Duration) time.Duration {
+	if duration.Duration > 0 {
+		return duration.Duration
+	}
+	return defaultDuration
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestRequeuePolicy(t *testing.T) {
+	defer SetRequeuePolicy(RequeuePolicy{})
+
+	assert.Equal(t, DefaultRayClusterRequeueDuration, RayClusterRequeueDuration())
+	assert.Equal(t, DefaultRayJobRequeueDuration, RayJobRequeueDuration())
+	assert.Equal(t, DefaultRayServiceRequeueDuration, RayServiceRequeueDuration())
+	assert.Equal(t, DefaultServeStatusPollDuration, ServeStatusPollDuration())
+
+	SetRequeuePolicy(RequeuePolicy{
+		RayClusterRequeueInterval: metav1.Duration{Duration: 10 * time.Second},
+		RayJobRequeueInterval:     metav1.Duration{Duration: 20 * time.Second},
+		RayServiceRequeueInterval: metav1.Duration{Duration: 30 * time.Second},
+		ServeStatusPollInterval:   metav1.Duration{Duration: 40 * time.Second},
+	})
+	assert.Equal(t, 10*time.Second, RayClusterRequeueDuration())
+	assert.Equal(t, 20*time.Second, RayJobRequeueDuration())
+	assert.Equal(t, 30*time.Second, RayServiceRequeueDuration())
+	assert.Equal(t, 40*time.Second, ServeStatusPollDuration())
+}
+
+func TestPeriodicReconcileInterval(t *testing.T) {
+	defer SetRequeuePolicy(RequeuePolicy{})
+
+	cluster := &rayv1.RayCluster{}
+	t.Setenv(RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV, "")
+	assert.Equal(t, RAYCLUSTER_DEFAULT_REQUEUE_SECONDS*time.Second, PeriodicReconcileInterval(cluster))
+
+	t.Setenv(RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV, "60")
+	assert.Equal(t, 60*time.Second, PeriodicReconcileInterval(cluster))
+
+	// The requeue policy takes precedence over the environment variable.
+	SetRequeuePolicy(RequeuePolicy{PeriodicReconcileInterval: metav1.Duration{Duration: 10 * time.Minute}})
+	assert.Equal(t, 10*time.Minute, PeriodicReconcileInterval(cluster))
+
+	// The annotation of the RayCluster takes precedence over the requeue policy.
+	cluster.Annotations = map[string]string{RayReconcileIntervalAnnotationKey: "30s"}
+	assert.Equal(t, 30*time.Second, PeriodicReconcileInterval(cluster))
+
+	// Invalid annotations are ignored.
+	for _, value := range []string{"soon", "0s", "-1m"} {
+		cluster.Annotations[RayReconcileIntervalAnnotationKey] = value
+		assert.Equal(t, 10*time.Minute, PeriodicReconcileInterval(cluster), value)
+	}
+}
+
+func TestValidateRequeuePolicy(t *testing.T) {
+	require.NoError(t, RequeuePolicy{}.Validate())
+	require.NoError(t, RequeuePolicy{ErrorBaseBackoff: metav1.Duration{Duration: time.Second}}.Validate())
+	require.EqualError(t, RequeuePolicy{RayJobRequeueInterval: metav1.Duration{Duration: -time.Second}}.Validate(),
+		"rayJobRequeueInterval -1s must not be negative")
+	require.EqualError(t, RequeuePolicy{ErrorBaseBackoff: metav1.Duration{Duration: time.Hour}}.Validate(),
+		"errorBaseBackoff 1h0m0s must not be greater than errorMaxBackoff 16m40s")
+}
+
+func TestErrorRateLimiter(t *testing.T) {
+	defer SetRequeuePolicy(RequeuePolicy{})
+
+	SetRequeuePolicy(RequeuePolicy{
+		ErrorBaseBackoff: metav1.Duration{Duration: time.Second},
+		ErrorMaxBackoff:  metav1.Duration{Duration: 3 * time.Second},
+	})
+	limiter := ErrorRateLimiter()
+	assert.Equal(t, time.Second, limiter.When("cluster"))
+	assert.Equal(t, 2*time.Second, limiter.When("cluster"))
+	assert.Equal(t, 3*time.Second, limiter.When("cluster"))
+	assert.Equal(t, time.Second, limiter.When("other-cluster"))
+}
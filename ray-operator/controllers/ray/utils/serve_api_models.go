@@ -8,9 +8,17 @@ package utils
 // be returned by the GetMultiApplicationStatus method of the dashboard client
 // Describes the status of a deployment
 type ServeDeploymentStatus struct {
-	Name    string `json:"name,omitempty"`
-	Status  string `json:"status,omitempty"`
-	Message string `json:"message,omitempty"`
+	Name              string               `json:"name,omitempty"`
+	Status            string               `json:"status,omitempty"`
+	Message           string               `json:"message,omitempty"`
+	Replicas          []ServeReplicaStatus `json:"replicas,omitempty"`
+	TargetNumReplicas int32                `json:"target_num_replicas,omitempty"`
+}
+
+// Describes the state of a replica of a deployment
+type ServeReplicaStatus struct {
+	ReplicaId string `json:"replica_id,omitempty"`
+	State     string `json:"state,omitempty"`
 }
 
 // Describes the status of an application
@@ -19,6 +27,21 @@ type ServeApplicationStatus struct {
 	Name        string                           `json:"name,omitempty"`
 	Status      string                           `json:"status"`
 	Message     string                           `json:"message,omitempty"`
+	RoutePrefix string                           `json:"route_prefix,omitempty"`
+}
+
+// ServeReplicaStateRunning is the state of the replicas which are ready to serve requests.
+const ServeReplicaStateRunning = "RUNNING"
+
+// RunningReplicas returns the number of replicas of the deployment which are running.
+func (s ServeDeploymentStatus) RunningReplicas() int32 {
+	var running int32
+	for _, replica := range s.Replicas {
+		if replica.State == ServeReplicaStateRunning {
+			running++
+		}
+	}
+	return running
 }
 
 // V2 Serve API Response format. These extend the ServeDeploymentStatus and ServeApplicationStatus structs,
@@ -32,11 +55,29 @@ type ServeDeploymentDetails struct {
 type ServeApplicationDetails struct {
 	Deployments map[string]ServeDeploymentDetails `json:"deployments"`
 	ServeApplicationStatus
-	RoutePrefix string `json:"route_prefix,omitempty"`
-	DocsPath    string `json:"docs_path,omitempty"`
+	DocsPath string `json:"docs_path,omitempty"`
+	// DeployedAppConfig is the config of the application as it was last deployed, in the format of an application of
+	// the serveConfigV2 of a RayService.
+	DeployedAppConfig map[string]interface{} `json:"deployed_app_config,omitempty"`
 }
 
 type ServeDetails struct {
 	Applications map[string]ServeApplicationDetails `json:"applications"`
 	DeployMode   string                             `json:"deploy_mode,omitempty"`
 }
+
+// ServeHTTPOptions are the HTTP options of Serve, which are shared by all the applications of a RayCluster.
+type ServeHTTPOptions struct {
+	RequestTimeoutS   *float64 `json:"request_timeout_s,omitempty"`
+	KeepAliveTimeoutS *int32   `json:"keep_alive_timeout_s,omitempty"`
+	Host              string   `json:"host,omitempty"`
+	RootPath          string   `json:"root_path,omitempty"`
+	Port              int32    `json:"port,omitempty"`
+}
+
+// ServeGlobalOptions are the options of a Serve config which apply to the whole Serve instance of a RayCluster rather
+// than to a single application. Serve ignores their changes while it is running.
+type ServeGlobalOptions struct {
+	HTTPOptions   *ServeHTTPOptions `json:"http_options,omitempty"`
+	ProxyLocation string            `json:"proxy_location,omitempty"`
+}
@@ -0,0 +1,60 @@
+package utils
+
+import "encoding/json"
+
+// Please see the Ray state API docs
+// https://docs.ray.io/en/latest/ray-observability/reference/api.html#state-apis for the schemas.
+
+// RayNodeState describes a node of the Ray cluster as returned by the state API.
+type RayNodeState struct {
+	NodeID     string `json:"node_id"`
+	NodeIP     string `json:"node_ip"`
+	NodeName   string `json:"node_name,omitempty"`
+	State      string `json:"state"`
+	IsHeadNode bool   `json:"is_head_node"`
+}
+
+// RayActorState describes an actor as returned by the state API with detail=true.
+type RayActorState struct {
+	ActorID      string `json:"actor_id"`
+	ClassName    string `json:"class_name,omitempty"`
+	Name         string `json:"name,omitempty"`
+	RayNamespace string `json:"ray_namespace,omitempty"`
+	NodeID       string `json:"node_id,omitempty"`
+	State        string `json:"state"`
+	IsDetached   bool   `json:"is_detached"`
+}
+
+// RayTaskState describes a task as returned by the state API.
+type RayTaskState struct {
+	TaskID string `json:"task_id"`
+	Name   string `json:"name,omitempty"`
+	NodeID string `json:"node_id,omitempty"`
+	State  string `json:"state"`
+}
+
+// RayPlacementGroupBundle describes a bundle of a placement group and the node it is placed on.
+type RayPlacementGroupBundle struct {
+	BundleID string `json:"bundle_id"`
+	NodeID   string `json:"node_id,omitempty"`
+}
+
+// RayPlacementGroupState describes a placement group as returned by the state API with detail=true.
+type RayPlacementGroupState struct {
+	PlacementGroupID string                    `json:"placement_group_id"`
+	Name             string                    `json:"name,omitempty"`
+	State            string                    `json:"state"`
+	Bundles          []RayPlacementGroupBundle `json:"bundles,omitempty"`
+	IsDetached       bool                      `json:"is_detached"`
+}
+
+// stateAPIListResponse is the envelope of the list endpoints of the state API.
+type stateAPIListResponse struct {
+	Msg  string `json:"msg"`
+	Data struct {
+		Result struct {
+			Result json.RawMessage `json:"result"`
+		} `json:"result"`
+	} `json:"data"`
+	Result bool `json:"result"`
+}
@@ -8,8 +8,10 @@ import (
 	"math"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -23,6 +25,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
@@ -141,6 +144,10 @@ func CheckRouteName(ctx context.Context, s string, n string) string {
 // PodGenerateName returns the value that should be used for a Pod's generateName
 // based on the RayCluster name and node type (head or worker).
 func PodGenerateName(prefix string, nodeType rayv1.RayNodeType) string {
+	if !namingTemplates.IsZero() {
+		return podGenerateNameWithTemplates(prefix, nodeType)
+	}
+
 	maxPrefixLength := 50 // 63 - (max(8,6) + 5 ) // 6 to 8 char are consumed at the end with "-head-" or -worker- + 5 generated.
 
 	var podPrefix string
@@ -227,9 +234,9 @@ func GetNamespace(metaData metav1.ObjectMeta) string {
 func GenerateHeadServiceName(crdType CRDType, clusterSpec rayv1.RayClusterSpec, ownerName string) (string, error) {
 	switch crdType {
 	case RayServiceCRD:
-		return CheckName(fmt.Sprintf("%s-%s-%s", ownerName, rayv1.HeadNode, "svc")), nil
+		return GenerateServiceName(fmt.Sprintf("%s-%s-%s", ownerName, rayv1.HeadNode, "svc")), nil
 	case RayClusterCRD:
-		headSvcName := CheckName(fmt.Sprintf("%s-%s-%s", ownerName, rayv1.HeadNode, "svc"))
+		headSvcName := GenerateServiceName(fmt.Sprintf("%s-%s-%s", ownerName, rayv1.HeadNode, "svc"))
 		if clusterSpec.HeadGroupSpec.HeadService != nil && clusterSpec.HeadGroupSpec.HeadService.Name != "" {
 			headSvcName = clusterSpec.HeadGroupSpec.HeadService.Name
 		}
@@ -258,7 +265,7 @@ func ExtractRayIPFromFQDN(fqdnRayIP string) string {
 
 // GenerateServeServiceName generates name for serve service.
 func GenerateServeServiceName(serviceName string) string {
-	return CheckName(fmt.Sprintf("%s-%s-%s", serviceName, ServeName, "svc"))
+	return GenerateServiceName(fmt.Sprintf("%s-%s-%s", serviceName, ServeName, "svc"))
 }
 
 // GenerateServeServiceLabel generates label value for serve service selector.
@@ -266,6 +273,31 @@ func GenerateServeServiceLabel(serviceName string) string {
 	return fmt.Sprintf("%s-%s", serviceName, ServeName)
 }
 
+// GenerateHeadPersistentStorageName generates name for the PersistentVolumeClaim of the persistent storage of the head Pod.
+func GenerateHeadPersistentStorageName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, HeadPersistentStorageSuffix))
+}
+
+// GenerateHeadStandbyPodName generates name for the standby Pod which reserves capacity for the head Pod.
+func GenerateHeadStandbyPodName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, HeadStandbySuffix))
+}
+
+// GenerateDashboardAuthSecretName generates name for the Secret which holds the auth token of the Ray dashboard.
+func GenerateDashboardAuthSecretName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, DashboardAuthSecretSuffix))
+}
+
+// GenerateImagePrePullDaemonSetName generates name for the DaemonSet which pre-pulls the images of a group of a RayCluster.
+func GenerateImagePrePullDaemonSetName(clusterName string, groupName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", clusterName, groupName, ImagePrePullSuffix))
+}
+
+// GenerateMetricsServiceName generates name for the metrics Service of a RayCluster.
+func GenerateMetricsServiceName(clusterName string) string {
+	return GenerateServiceName(fmt.Sprintf("%s-%s", clusterName, MetricsServiceSuffix))
+}
+
 // GenerateIngressName generates an ingress name from cluster name
 func GenerateIngressName(clusterName string) string {
 	return fmt.Sprintf("%s-%s-%s", clusterName, rayv1.HeadNode, "ingress")
@@ -281,6 +313,14 @@ func GenerateRayClusterName(serviceName string) string {
 	return fmt.Sprintf("%s%s%s", serviceName, RayClusterSuffix, rand.String(5))
 }
 
+// GenerateRayClusterNameWithPrefix generates the name of the RayCluster of a RayJob with spec.clusterGenerateName.
+// Like metadata.generateName, a 5 character suffix is appended to the prefix, but the suffix is derived from the
+// UID of the RayJob so that clients can resolve the name as soon as the RayJob is created.
+func GenerateRayClusterNameWithPrefix(prefix string, uid types.UID) string {
+	hash := sha1.Sum([]byte(uid)) //nolint:gosec // We are not using this for security purposes
+	return prefix + fmt.Sprintf("%x", hash)[:5]
+}
+
 // GenerateRayJobId generates a ray job id for submission
 func GenerateRayJobId(rayjob string) string {
 	return fmt.Sprintf("%s-%s", rayjob, rand.String(5))
@@ -311,6 +351,216 @@ func GetWorkerGroupDesiredReplicas(ctx context.Context, workerGroupSpec rayv1.Wo
 	return workerReplicas
 }
 
+// scalingScheduleWindows returns the windows of the scaling schedule which start between the day before `now`
+// and `days` days after it, in chronological order.
+func scalingScheduleWindows(schedule rayv1.ScalingSchedule, now time.Time, days int) ([][2]time.Time, error) {
+	location := time.UTC
+	if schedule.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return nil, err
+		}
+	}
+	start, err := time.Parse("15:04", schedule.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse("15:04", schedule.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	// The window ends on the next day if the end time is not after the start time, e.g. 22:00-06:00.
+	endDayOffset := 0
+	if !end.After(start) {
+		endDayOffset = 1
+	}
+
+	localNow := now.In(location)
+	var windows [][2]time.Time
+	for offset := -1; offset <= days; offset++ {
+		windowStart := time.Date(localNow.Year(), localNow.Month(), localNow.Day()+offset, start.Hour(), start.Minute(), 0, 0, location)
+		if len(schedule.Days) > 0 {
+			matched := false
+			for _, day := range schedule.Days {
+				if string(day) == windowStart.Weekday().String() {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+		windowEnd := time.Date(localNow.Year(), localNow.Month(), localNow.Day()+offset+endDayOffset, end.Hour(), end.Minute(), 0, 0, location)
+		windows = append(windows, [2]time.Time{windowStart, windowEnd})
+	}
+	return windows, nil
+}
+
+// GetActiveScalingSchedule returns the first scaling schedule of the worker group whose window contains `now`,
+// or nil if no scaling schedule is active. Invalid scaling schedules are ignored.
+func GetActiveScalingSchedule(ctx context.Context, workerGroupSpec rayv1.WorkerGroupSpec, now time.Time) *rayv1.ScalingSchedule {
+	log := ctrl.LoggerFrom(ctx)
+	for i, schedule := range workerGroupSpec.ScalingSchedules {
+		windows, err := scalingScheduleWindows(schedule, now, 0)
+		if err != nil {
+			log.Info("Ignoring the invalid scaling schedule", "worker group", workerGroupSpec.GroupName, "scaling schedule", schedule.Name, "error", err.Error())
+			continue
+		}
+		for _, window := range windows {
+			if !now.Before(window[0]) && now.Before(window[1]) {
+				return &workerGroupSpec.ScalingSchedules[i]
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyScalingSchedule returns a copy of the worker group whose MinReplicas and MaxReplicas are overridden by the
+// active scaling schedule of the worker group, if any.
+func ApplyScalingSchedule(ctx context.Context, workerGroupSpec rayv1.WorkerGroupSpec, now time.Time) rayv1.WorkerGroupSpec {
+	schedule := GetActiveScalingSchedule(ctx, workerGroupSpec, now)
+	if schedule == nil {
+		return workerGroupSpec
+	}
+	if schedule.MinReplicas != nil {
+		workerGroupSpec.MinReplicas = schedule.MinReplicas
+	}
+	if schedule.MaxReplicas != nil {
+		workerGroupSpec.MaxReplicas = schedule.MaxReplicas
+	}
+	return workerGroupSpec
+}
+
+// NextScalingScheduleTransition returns the earliest time after `now` at which a window of a scaling schedule of
+// the RayCluster starts or ends. The second return value is false if the RayCluster has no valid scaling schedules.
+func NextScalingScheduleTransition(cluster *rayv1.RayCluster, now time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, workerGroupSpec := range cluster.Spec.WorkerGroupSpecs {
+		for _, schedule := range workerGroupSpec.ScalingSchedules {
+			// Every window starts within a week, so looking 7 days ahead finds the next transition.
+			windows, err := scalingScheduleWindows(schedule, now, 7)
+			if err != nil {
+				continue
+			}
+			for _, window := range windows {
+				for _, transition := range window {
+					if transition.After(now) && (next.IsZero() || transition.Before(next)) {
+						next = transition
+					}
+				}
+			}
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// IsNodeFillable returns whether a node fill worker group places a worker Pod on the Node, i.e. whether the Node is
+// ready and schedulable.
+func IsNodeFillable(node corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// ApplyNodeFill returns a copy of the node fill worker group whose replicas are the number of fillable Nodes among
+// `nodes`, and whose Pod template places one worker Pod on each of them. If the node fill policy derives the
+// resources, the Ray container requests the allocatable resources of the smallest Node minus the reserved ones.
+func ApplyNodeFill(workerGroupSpec rayv1.WorkerGroupSpec, clusterName string, nodes []corev1.Node) rayv1.WorkerGroupSpec {
+	policy := workerGroupSpec.NodeFill
+	if policy == nil {
+		return workerGroupSpec
+	}
+	var fillableNodes []corev1.Node
+	for _, node := range nodes {
+		if IsNodeFillable(node) {
+			fillableNodes = append(fillableNodes, node)
+		}
+	}
+	replicas := int32(len(fillableNodes))
+	workerGroupSpec.Replicas = &replicas
+	workerGroupSpec.MinReplicas = &replicas
+	workerGroupSpec.MaxReplicas = &replicas
+	workerGroupSpec.NumOfHosts = 1
+
+	template := workerGroupSpec.Template.DeepCopy()
+	if template.Spec.NodeSelector == nil {
+		template.Spec.NodeSelector = map[string]string{}
+	}
+	for key, value := range policy.NodeSelector {
+		template.Spec.NodeSelector[key] = value
+	}
+	if template.Spec.Affinity == nil {
+		template.Spec.Affinity = &corev1.Affinity{}
+	}
+	if template.Spec.Affinity.PodAntiAffinity == nil {
+		template.Spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	template.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+		template.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+		corev1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+				RayClusterLabelKey:   clusterName,
+				RayNodeGroupLabelKey: workerGroupSpec.GroupName,
+			}},
+			TopologyKey: corev1.LabelHostname,
+		})
+
+	if policy.DeriveResources && len(fillableNodes) > 0 && len(template.Spec.Containers) > RayContainerIndex {
+		resources := nodeFillResources(fillableNodes, policy.Reserved)
+		container := &template.Spec.Containers[RayContainerIndex]
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = corev1.ResourceList{}
+		}
+		if container.Resources.Limits == nil {
+			container.Resources.Limits = corev1.ResourceList{}
+		}
+		for name, quantity := range resources {
+			container.Resources.Requests[name] = quantity
+			container.Resources.Limits[name] = quantity
+		}
+	}
+	workerGroupSpec.Template = *template
+	return workerGroupSpec
+}
+
+// nodeFillResources returns the smallest allocatable CPU, memory and GPUs of the Nodes minus the reserved resources.
+func nodeFillResources(nodes []corev1.Node, reserved corev1.ResourceList) corev1.ResourceList {
+	resources := corev1.ResourceList{}
+	for i, node := range nodes {
+		for name, quantity := range node.Status.Allocatable {
+			if name != corev1.ResourceCPU && name != corev1.ResourceMemory && !strings.HasSuffix(string(name), "gpu") {
+				continue
+			}
+			if current, ok := resources[name]; i == 0 || (ok && quantity.Cmp(current) < 0) {
+				resources[name] = quantity.DeepCopy()
+			}
+		}
+		// A resource which some Nodes do not have cannot be requested by all the worker Pods.
+		for name := range resources {
+			if _, ok := node.Status.Allocatable[name]; !ok {
+				delete(resources, name)
+			}
+		}
+	}
+	for name, quantity := range reserved {
+		if current, ok := resources[name]; ok {
+			current.Sub(quantity)
+			if current.Sign() < 0 {
+				current = resource.MustParse("0")
+			}
+			resources[name] = current
+		}
+	}
+	return resources
+}
+
 // CalculateDesiredReplicas calculate desired worker replicas at the cluster level
 func CalculateDesiredReplicas(ctx context.Context, cluster *rayv1.RayCluster) int32 {
 	count := int32(0)
@@ -425,6 +675,36 @@ func CalculatePodResource(podSpec corev1.PodSpec) corev1.ResourceList {
 	return podResource
 }
 
+// CalculatePodsResource returns the total resources of the Pods which are neither terminating nor terminated.
+func CalculatePodsResource(pods []corev1.Pod) corev1.ResourceList {
+	resourcesList := []corev1.ResourceList{{}}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		resourcesList = append(resourcesList, CalculatePodResource(pod.Spec))
+	}
+	return sumResourceList(resourcesList)
+}
+
+// ExceedsResourceQuota returns whether adding podResource to usedResource exceeds the quota, and the first
+// exceeded resource in alphabetical order. Resources which are not in the quota are not limited.
+func ExceedsResourceQuota(quota corev1.ResourceList, usedResource corev1.ResourceList, podResource corev1.ResourceList) (corev1.ResourceName, bool) {
+	names := make([]string, 0, len(quota))
+	for name := range quota {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		total := usedResource[corev1.ResourceName(name)].DeepCopy()
+		total.Add(podResource[corev1.ResourceName(name)])
+		if total.Cmp(quota[corev1.ResourceName(name)]) > 0 {
+			return corev1.ResourceName(name), true
+		}
+	}
+	return "", false
+}
+
 func ConvertResourceListToMapString(resourceList corev1.ResourceList) map[string]resource.Quantity {
 	result := make(map[string]resource.Quantity)
 	for key, value := range resourceList {
@@ -559,6 +839,11 @@ func EnvVarExists(envName string, envVars []corev1.EnvVar) bool {
 	return false
 }
 
+// IsDeletionProtected returns true if the object is protected from deletion with the deletion protection annotation.
+func IsDeletionProtected(obj metav1.Object) bool {
+	return obj.GetAnnotations()[RayDeletionProtectedAnnotationKey] == "true"
+}
+
 // EnvVarByName returns an entry in []corev1.EnvVar that matches a name.
 // Also returns a bool for whether the env var exists.
 func EnvVarByName(envName string, envVars []corev1.EnvVar) (corev1.EnvVar, bool) {
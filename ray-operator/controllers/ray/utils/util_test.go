@@ -3,10 +3,14 @@ package utils
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	corev1 "k8s.io/api/core/v1"
@@ -432,6 +436,16 @@ func TestFindContainerPort(t *testing.T) {
 	assert.Equal(t, port, -1, "expect port3 not found")
 }
 
+func TestGenerateRayClusterNameWithPrefix(t *testing.T) {
+	name := GenerateRayClusterNameWithPrefix("nightly-", types.UID("8a1f3c52-9d8e-4c1b-a4d3-6f2b7e9c0d11"))
+	assert.Len(t, name, len("nightly-")+5)
+	assert.True(t, strings.HasPrefix(name, "nightly-"))
+
+	// The name only depends on the prefix and the UID of the RayJob.
+	assert.Equal(t, name, GenerateRayClusterNameWithPrefix("nightly-", types.UID("8a1f3c52-9d8e-4c1b-a4d3-6f2b7e9c0d11")))
+	assert.NotEqual(t, name, GenerateRayClusterNameWithPrefix("nightly-", types.UID("0c7e5a21-3b4f-4d6a-9e8c-1f2a3b4c5d6e")))
+}
+
 func TestGenerateHeadServiceName(t *testing.T) {
 	// GenerateHeadServiceName generates a Ray head service name. Note that there are two types of head services:
 	//
@@ -579,6 +593,164 @@ func TestCalculateDesiredReplicas(t *testing.T) {
 	}
 }
 
+func TestScalingSchedules(t *testing.T) {
+	ctx := context.Background()
+	workerGroupSpec := rayv1.WorkerGroupSpec{
+		GroupName:   "workers",
+		MinReplicas: ptr.To[int32](1),
+		MaxReplicas: ptr.To[int32](20),
+		ScalingSchedules: []rayv1.ScalingSchedule{
+			{
+				Name:        "business-hours",
+				Days:        []rayv1.ScalingScheduleDay{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+				StartTime:   "09:00",
+				EndTime:     "18:00",
+				TimeZone:    "America/New_York",
+				MinReplicas: ptr.To[int32](10),
+			},
+			{
+				// The window ends on the next day.
+				Name:        "nights",
+				StartTime:   "22:00",
+				EndTime:     "06:00",
+				MinReplicas: ptr.To[int32](0),
+				MaxReplicas: ptr.To[int32](2),
+			},
+			{
+				// Invalid scaling schedules are ignored.
+				Name:        "invalid",
+				StartTime:   "00:00",
+				EndTime:     "00:00",
+				TimeZone:    "Mars/Olympus_Mons",
+				MinReplicas: ptr.To[int32](100),
+			},
+		},
+	}
+	cluster := &rayv1.RayCluster{Spec: rayv1.RayClusterSpec{WorkerGroupSpecs: []rayv1.WorkerGroupSpec{workerGroupSpec}}}
+
+	// Monday 2024-06-03 10:00 in New York.
+	monday := time.Date(2024, 6, 3, 14, 0, 0, 0, time.UTC)
+	schedule := GetActiveScalingSchedule(ctx, workerGroupSpec, monday)
+	assert.NotNil(t, schedule)
+	assert.Equal(t, "business-hours", schedule.Name)
+	scheduled := ApplyScalingSchedule(ctx, workerGroupSpec, monday)
+	assert.Equal(t, int32(10), *scheduled.MinReplicas)
+	assert.Equal(t, int32(20), *scheduled.MaxReplicas)
+	assert.Equal(t, int32(1), *workerGroupSpec.MinReplicas)
+
+	// Saturday 2024-06-08 10:00 in New York.
+	saturday := time.Date(2024, 6, 8, 14, 0, 0, 0, time.UTC)
+	assert.Nil(t, GetActiveScalingSchedule(ctx, workerGroupSpec, saturday))
+	assert.Equal(t, workerGroupSpec, ApplyScalingSchedule(ctx, workerGroupSpec, saturday))
+
+	// The night window which started on Monday 22:00 is still active on Tuesday 03:00.
+	night := time.Date(2024, 6, 4, 3, 0, 0, 0, time.UTC)
+	schedule = GetActiveScalingSchedule(ctx, workerGroupSpec, night)
+	assert.NotNil(t, schedule)
+	assert.Equal(t, "nights", schedule.Name)
+	scheduled = ApplyScalingSchedule(ctx, workerGroupSpec, night)
+	assert.Equal(t, int32(0), *scheduled.MinReplicas)
+	assert.Equal(t, int32(2), *scheduled.MaxReplicas)
+
+	// The next transition on Monday 08:00 in New York is the start of the business hours.
+	next, ok := NextScalingScheduleTransition(cluster, time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 6, 3, 13, 0, 0, 0, time.UTC), next.UTC())
+	// The next transition on Tuesday 03:00 is the end of the night window.
+	next, ok = NextScalingScheduleTransition(cluster, night)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 6, 4, 6, 0, 0, 0, time.UTC), next.UTC())
+
+	_, ok = NextScalingScheduleTransition(&rayv1.RayCluster{}, night)
+	assert.False(t, ok)
+}
+
+func TestApplyNodeFill(t *testing.T) {
+	node := func(name string, ready bool, unschedulable bool, cpu string, memory string, gpus string) corev1.Node {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		allocatable := corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}
+		if gpus != "" {
+			allocatable["nvidia.com/gpu"] = resource.MustParse(gpus)
+		}
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+			Status: corev1.NodeStatus{
+				Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+				Allocatable: allocatable,
+			},
+		}
+	}
+	nodes := []corev1.Node{
+		node("gpu-1", true, false, "16", "64Gi", "8"),
+		node("gpu-2", true, false, "8", "128Gi", "8"),
+		// Nodes which are not ready or cordoned do not get a worker Pod.
+		node("gpu-3", false, false, "16", "64Gi", "8"),
+		node("gpu-4", true, true, "16", "64Gi", "8"),
+	}
+	assert.True(t, IsNodeFillable(nodes[0]))
+	assert.False(t, IsNodeFillable(nodes[2]))
+	assert.False(t, IsNodeFillable(nodes[3]))
+
+	workerGroupSpec := rayv1.WorkerGroupSpec{
+		GroupName:   "gpu",
+		Replicas:    ptr.To[int32](1),
+		MinReplicas: ptr.To[int32](0),
+		MaxReplicas: ptr.To[int32](1),
+		NodeFill: &rayv1.NodeFillPolicy{
+			NodeSelector:    map[string]string{"pool": "gpu"},
+			DeriveResources: true,
+			Reserved:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+		},
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				NodeSelector: map[string]string{"zone": "a"},
+				Containers:   []corev1.Container{{Name: "ray-worker"}},
+			},
+		},
+	}
+	filled := ApplyNodeFill(workerGroupSpec, "cluster", nodes)
+	assert.Equal(t, int32(2), *filled.Replicas)
+	assert.Equal(t, int32(2), *filled.MinReplicas)
+	assert.Equal(t, int32(2), *filled.MaxReplicas)
+	assert.Equal(t, int32(1), filled.NumOfHosts)
+	assert.Equal(t, map[string]string{"zone": "a", "pool": "gpu"}, filled.Template.Spec.NodeSelector)
+	terms := filled.Template.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	assert.Len(t, terms, 1)
+	assert.Equal(t, corev1.LabelHostname, terms[0].TopologyKey)
+	assert.Equal(t, map[string]string{RayClusterLabelKey: "cluster", RayNodeGroupLabelKey: "gpu"}, terms[0].LabelSelector.MatchLabels)
+
+	// The smallest Node determines the resources, minus the reserved ones.
+	requests := filled.Template.Spec.Containers[RayContainerIndex].Resources.Requests
+	assert.Equal(t, "7500m", requests.Cpu().String())
+	assert.Equal(t, "60Gi", requests.Memory().String())
+	gpus := requests["nvidia.com/gpu"]
+	assert.Equal(t, "8", gpus.String())
+	assert.Equal(t, requests, filled.Template.Spec.Containers[RayContainerIndex].Resources.Limits)
+
+	// The worker group itself is not modified.
+	assert.Equal(t, int32(1), *workerGroupSpec.Replicas)
+	assert.Equal(t, map[string]string{"zone": "a"}, workerGroupSpec.Template.Spec.NodeSelector)
+	assert.Nil(t, workerGroupSpec.Template.Spec.Affinity)
+	assert.Nil(t, workerGroupSpec.Template.Spec.Containers[RayContainerIndex].Resources.Requests)
+
+	// GPUs which some Nodes do not have are not requested.
+	filled = ApplyNodeFill(workerGroupSpec, "cluster", append(nodes, node("cpu-1", true, false, "32", "256Gi", "")))
+	assert.Equal(t, int32(3), *filled.Replicas)
+	_, ok := filled.Template.Spec.Containers[RayContainerIndex].Resources.Requests["nvidia.com/gpu"]
+	assert.False(t, ok)
+
+	// Without matching Nodes, the worker group has no replicas.
+	filled = ApplyNodeFill(workerGroupSpec, "cluster", nil)
+	assert.Equal(t, int32(0), *filled.Replicas)
+}
+
 func TestUnmarshalRuntimeEnv(t *testing.T) {
 	tests := map[string]struct {
 		runtimeEnvYAML string
@@ -662,3 +834,55 @@ func TestErrRayClusterReplicaFailureReason(t *testing.T) {
 	assert.Equal(t, RayClusterReplicaFailureReason(errors.Join(ErrFailedCreateWorkerPod, errors.New("other error"))), "FailedCreateWorkerPod")
 	assert.Equal(t, RayClusterReplicaFailureReason(errors.New("other error")), "")
 }
+
+func TestExceedsResourceQuota(t *testing.T) {
+	quota := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+	}
+	podResource := corev1.ResourceList{
+		corev1.ResourceCPU:                    resource.MustParse("1"),
+		corev1.ResourceMemory:                 resource.MustParse("2Gi"),
+		corev1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+	}
+
+	// Resources which are not in the quota are not limited.
+	name, exceeded := ExceedsResourceQuota(quota, corev1.ResourceList{}, podResource)
+	assert.False(t, exceeded)
+	assert.Empty(t, name)
+
+	// Reaching the quota exactly is allowed.
+	used := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("3"),
+		corev1.ResourceMemory: resource.MustParse("6Gi"),
+	}
+	_, exceeded = ExceedsResourceQuota(quota, used, podResource)
+	assert.False(t, exceeded)
+
+	used[corev1.ResourceMemory] = resource.MustParse("7Gi")
+	name, exceeded = ExceedsResourceQuota(quota, used, podResource)
+	assert.True(t, exceeded)
+	assert.Equal(t, corev1.ResourceMemory, name)
+}
+
+func TestCalculatePodsResource(t *testing.T) {
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+				},
+			},
+		},
+	}
+	pods := []corev1.Pod{
+		{Spec: podSpec, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+		{Spec: podSpec, Status: corev1.PodStatus{Phase: corev1.PodPending}},
+		{Spec: podSpec, Status: corev1.PodStatus{Phase: corev1.PodFailed}},
+		{Spec: podSpec, ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{}}},
+	}
+
+	// Terminated and terminating Pods are not counted.
+	total := CalculatePodsResource(pods)
+	assert.Equal(t, int64(2), total.Cpu().Value())
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	// The time zones of the scaling schedules are resolved without relying on the time zone database of the image.
+	_ "time/tzdata"
 
 	"github.com/go-logr/zapr"
 	routev1 "github.com/openshift/api/route/v1"
@@ -13,6 +15,7 @@ import (
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -21,6 +24,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -68,6 +72,14 @@ func main() {
 	var featureGates string
 	var enableBatchScheduler bool
 	var batchScheduler string
+	var podMutationPlugins string
+	var dryRun bool
+	var maxConcurrentRayJobsPerNamespace int
+	var namingTemplates utils.NamingTemplates
+	var requeuePolicy utils.RequeuePolicy
+	var podBatchPolicy utils.PodBatchPolicy
+	var nameTruncationStrategy string
+	var acceleratorResources string
 
 	// TODO: remove flag-based config once Configuration API graduates to v1.
 	flag.StringVar(&metricsAddr, "metrics-addr", configapi.DefaultMetricsAddr, "The address the metric endpoint binds to.")
@@ -94,9 +106,43 @@ func main() {
 		"(Deprecated) Enable batch scheduler. Currently is volcano, which supports gang scheduler policy. Please use --batch-scheduler instead.")
 	flag.StringVar(&batchScheduler, "batch-scheduler", "",
 		"Batch scheduler name, supported values are volcano and yunikorn.")
+	flag.StringVar(&podMutationPlugins, "pod-mutation-plugins", "",
+		"Comma separated list of registered pod mutation plugins to enable, in order.")
 	flag.StringVar(&configFile, "config", "", "Path to structured config file. Flags are ignored if config file is set.")
 	flag.BoolVar(&useKubernetesProxy, "use-kubernetes-proxy", false,
 		"Use Kubernetes proxy subresource when connecting to the Ray Head node.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"Log the Pods, Services, and other objects the operator would create, update, or delete without sending the writes to the API server.")
+	flag.IntVar(&maxConcurrentRayJobsPerNamespace, "max-concurrent-rayjobs-per-namespace", 0,
+		"The maximum number of RayJobs that may run concurrently in each namespace. The rest are started in creation order. 0 means no limit.")
+	flag.StringVar(&namingTemplates.PodNamePrefix, "pod-name-prefix", "", "Prefix of the names of the Ray Pods generated by the operator.")
+	flag.StringVar(&namingTemplates.PodNameSuffix, "pod-name-suffix", "", "Suffix of the names of the Ray Pods generated by the operator.")
+	flag.StringVar(&namingTemplates.ServiceNamePrefix, "service-name-prefix", "", "Prefix of the names of the Services generated by the operator.")
+	flag.StringVar(&namingTemplates.ServiceNameSuffix, "service-name-suffix", "", "Suffix of the names of the Services generated by the operator.")
+	flag.IntVar(&namingTemplates.MaxNameLength, "max-generated-name-length", 0,
+		"The maximum length of the names of the Pods and Services generated by the operator. 0 means 63.")
+	flag.StringVar(&nameTruncationStrategy, "name-truncation-strategy", "",
+		"How generated names longer than the maximum length are shortened. Valid values are 'TruncateStart', 'TruncateEnd' and 'Hash'. Defaults to 'Hash'.")
+	flag.DurationVar(&requeuePolicy.RayClusterRequeueInterval.Duration, "raycluster-requeue-interval", 0,
+		"How long to wait before reconciling a RayCluster again while waiting for a change. Defaults to 2s.")
+	flag.DurationVar(&requeuePolicy.RayJobRequeueInterval.Duration, "rayjob-requeue-interval", 0,
+		"How long to wait before reconciling a RayJob again while waiting for a change. Defaults to 3s.")
+	flag.DurationVar(&requeuePolicy.RayServiceRequeueInterval.Duration, "rayservice-requeue-interval", 0,
+		"How long to wait before reconciling a RayService again while waiting for a change. Defaults to 2s.")
+	flag.DurationVar(&requeuePolicy.ServeStatusPollInterval.Duration, "serve-status-poll-interval", 0,
+		"How often the statuses of the Serve applications are polled from the dashboards of the RayClusters of the RayServices. Defaults to 2s.")
+	flag.DurationVar(&requeuePolicy.PeriodicReconcileInterval.Duration, "periodic-reconcile-interval", 0,
+		"How often a RayCluster is reconciled when nothing changes. Defaults to 300s.")
+	flag.DurationVar(&requeuePolicy.ErrorBaseBackoff.Duration, "error-base-backoff", 0,
+		"The delay before reconciling a resource again after a failed reconciliation, doubled with every consecutive failure. Defaults to 5ms.")
+	flag.DurationVar(&requeuePolicy.ErrorMaxBackoff.Duration, "error-max-backoff", 0,
+		"The maximum delay before reconciling a resource again after a failed reconciliation. Defaults to 1000s.")
+	flag.IntVar(&podBatchPolicy.MaxPodsPerReconcile, "max-pods-per-reconcile", 0,
+		"The maximum number of worker Pods created or deleted per reconciliation of a RayCluster. The rest are created or deleted in the next batches. 0 means no limit.")
+	flag.DurationVar(&podBatchPolicy.BatchInterval.Duration, "pod-batch-interval", 0,
+		"The minimum delay between two batches of worker Pods of a RayCluster. Defaults to the RayCluster requeue interval.")
+	flag.StringVar(&acceleratorResources, "accelerator-resources", "",
+		"Comma separated list of <extended resource>=<Ray resource> pairs advertising accelerators to Ray, e.g. 'habana.ai/gaudi=HPU'. Added to the well-known accelerators.")
 	flag.StringVar(&featureGates, "feature-gates", "", "A set of key=value pairs that describe feature gates. E.g. FeatureOne=true,FeatureTwo=false,...")
 
 	opts := k8szap.Options{
@@ -125,8 +171,20 @@ func main() {
 		config.LogStdoutEncoder = logStdoutEncoder
 		config.EnableBatchScheduler = enableBatchScheduler
 		config.BatchScheduler = batchScheduler
+		if podMutationPlugins != "" {
+			config.PodMutationPlugins = strings.Split(podMutationPlugins, ",")
+		}
 		config.UseKubernetesProxy = useKubernetesProxy
 		config.DeleteRayJobAfterJobFinishes = os.Getenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES) == "true"
+		config.DryRun = dryRun
+		config.MaxConcurrentRayJobsPerNamespace = maxConcurrentRayJobsPerNamespace
+		namingTemplates.TruncationStrategy = utils.NameTruncationStrategy(nameTruncationStrategy)
+		config.NamingTemplates = namingTemplates
+		config.RequeuePolicy = requeuePolicy
+		config.PodBatching = podBatchPolicy
+		var err error
+		config.AcceleratorResources, err = utils.ParseAcceleratorResources(acceleratorResources)
+		exitOnError(err, "failed to parse the accelerator resources")
 	}
 
 	stdoutEncoder, err := newLogEncoder(logStdoutEncoder)
@@ -167,6 +225,26 @@ func main() {
 		exitOnError(err, "batch scheduler configs validation failed")
 	}
 
+	if err := config.NamingTemplates.Validate(); err != nil {
+		exitOnError(err, "naming templates validation failed")
+	}
+	utils.SetNamingTemplates(config.NamingTemplates)
+
+	if err := config.RequeuePolicy.Validate(); err != nil {
+		exitOnError(err, "requeue policy validation failed")
+	}
+	utils.SetRequeuePolicy(config.RequeuePolicy)
+
+	if err := config.PodBatching.Validate(); err != nil {
+		exitOnError(err, "pod batching validation failed")
+	}
+	utils.SetPodBatchPolicy(config.PodBatching)
+
+	if err := config.AcceleratorResources.Validate(); err != nil {
+		exitOnError(err, "accelerator resources validation failed")
+	}
+	utils.SetAcceleratorResources(config.AcceleratorResources)
+
 	if err := utilfeature.DefaultMutableFeatureGate.Set(featureGates); err != nil {
 		exitOnError(err, "Unable to set flag gates for known features")
 	}
@@ -187,6 +265,17 @@ func main() {
 		LeaderElectionNamespace: config.LeaderElectionNamespace,
 	}
 
+	if config.DryRun {
+		setupLog.Info("Dry-run mode is enabled. Writes to the API server and events are logged and skipped.")
+		options.NewClient = func(restConfig *rest.Config, clientOptions client.Options) (client.Client, error) {
+			c, err := client.New(restConfig, clientOptions)
+			if err != nil {
+				return nil, err
+			}
+			return utils.NewDryRunClient(c), nil
+		}
+	}
+
 	// Manager Cache
 	// Set the informers label selectors to narrow the scope of the resources being watched and cached.
 	// This improves the scalability of the system, both for KubeRay itself by reducing the size of the
@@ -217,6 +306,10 @@ func main() {
 	restConfig.UserAgent = userAgent
 	mgr, err := ctrl.NewManager(restConfig, options)
 	exitOnError(err, "unable to start manager")
+	if config.DryRun {
+		// The event recorders write Events with their own client, so they are replaced as well.
+		mgr = utils.NewDryRunManager(mgr)
+	}
 
 	rayClusterOptions := ray.RayClusterReconcilerOptions{
 		HeadSidecarContainers:   config.HeadSidecarContainers,
@@ -227,7 +320,10 @@ func main() {
 		"unable to create controller", "controller", "RayCluster")
 	exitOnError(ray.NewRayServiceReconciler(ctx, mgr, config).SetupWithManager(mgr, config.ReconcileConcurrency),
 		"unable to create controller", "controller", "RayService")
-	exitOnError(ray.NewRayJobReconciler(ctx, mgr, config).SetupWithManager(mgr, config.ReconcileConcurrency),
+	rayJobOptions := ray.RayJobReconcilerOptions{
+		MaxConcurrentRayJobsPerNamespace: config.MaxConcurrentRayJobsPerNamespace,
+	}
+	exitOnError(ray.NewRayJobReconciler(ctx, mgr, rayJobOptions, config).SetupWithManager(mgr, config.ReconcileConcurrency),
 		"unable to create controller", "controller", "RayJob")
 
 	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
@@ -251,7 +347,8 @@ func cacheSelectors() (map[client.Object]cache.ByObject, error) {
 	selector := labels.NewSelector().Add(*label)
 
 	return map[client.Object]cache.ByObject{
-		&batchv1.Job{}: {Label: selector},
+		&batchv1.Job{}:      {Label: selector},
+		&appsv1.DaemonSet{}: {Label: selector},
 	}, nil
 }
 
@@ -13,6 +13,7 @@ type AppStatusApplyConfiguration struct {
 	Deployments          map[string]ServeDeploymentStatusApplyConfiguration `json:"serveDeploymentStatuses,omitempty"`
 	Status               *string                                            `json:"status,omitempty"`
 	Message              *string                                            `json:"message,omitempty"`
+	RoutePrefix          *string                                            `json:"routePrefix,omitempty"`
 }
 
 // AppStatusApplyConfiguration constructs an declarative configuration of the AppStatus type for use with
@@ -58,3 +59,11 @@ func (b *AppStatusApplyConfiguration) WithMessage(value string) *AppStatusApplyC
 	b.Message = &value
 	return b
 }
+
+// WithRoutePrefix sets the RoutePrefix field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RoutePrefix field is set to the value of the last call.
+func (b *AppStatusApplyConfiguration) WithRoutePrefix(value string) *AppStatusApplyConfiguration {
+	b.RoutePrefix = &value
+	return b
+}
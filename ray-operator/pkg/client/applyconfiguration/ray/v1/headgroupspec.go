@@ -4,17 +4,22 @@ package v1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
 // HeadGroupSpecApplyConfiguration represents an declarative configuration of the HeadGroupSpec type for use
 // with apply.
 type HeadGroupSpecApplyConfiguration struct {
-	ServiceType    *v1.ServiceType                           `json:"serviceType,omitempty"`
-	HeadService    *v1.Service                               `json:"headService,omitempty"`
-	EnableIngress  *bool                                     `json:"enableIngress,omitempty"`
-	RayStartParams map[string]string                         `json:"rayStartParams,omitempty"`
-	Template       *corev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
+	ServiceType         *v1.ServiceType                           `json:"serviceType,omitempty"`
+	HeadService         *v1.Service                               `json:"headService,omitempty"`
+	EnableIngress       *bool                                     `json:"enableIngress,omitempty"`
+	RayStartParams      map[string]string                         `json:"rayStartParams,omitempty"`
+	PodTemplatePatch    *runtime.RawExtension                     `json:"podTemplatePatch,omitempty"`
+	HeadNodeReservation *HeadNodeReservationApplyConfiguration    `json:"headNodeReservation,omitempty"`
+	PersistentStorage   *RayPersistentStorageApplyConfiguration   `json:"persistentStorage,omitempty"`
+	Placement           *HeadPlacementPolicyApplyConfiguration    `json:"placement,omitempty"`
+	Template            *corev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
 }
 
 // HeadGroupSpecApplyConfiguration constructs an declarative configuration of the HeadGroupSpec type for use with
@@ -61,6 +66,38 @@ func (b *HeadGroupSpecApplyConfiguration) WithRayStartParams(entries map[string]
 	return b
 }
 
+// WithPodTemplatePatch sets the PodTemplatePatch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodTemplatePatch field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithPodTemplatePatch(value runtime.RawExtension) *HeadGroupSpecApplyConfiguration {
+	b.PodTemplatePatch = &value
+	return b
+}
+
+// WithHeadNodeReservation sets the HeadNodeReservation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HeadNodeReservation field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithHeadNodeReservation(value *HeadNodeReservationApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.HeadNodeReservation = value
+	return b
+}
+
+// WithPersistentStorage sets the PersistentStorage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PersistentStorage field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithPersistentStorage(value *RayPersistentStorageApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.PersistentStorage = value
+	return b
+}
+
+// WithPlacement sets the Placement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Placement field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithPlacement(value *HeadPlacementPolicyApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.Placement = value
+	return b
+}
+
 // WithTemplate sets the Template field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Template field is set to the value of the last call.
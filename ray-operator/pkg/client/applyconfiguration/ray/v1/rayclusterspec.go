@@ -2,16 +2,32 @@
 
 package v1
 
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
 // RayClusterSpecApplyConfiguration represents an declarative configuration of the RayClusterSpec type for use
 // with apply.
 type RayClusterSpecApplyConfiguration struct {
-	Suspend                 *bool                                `json:"suspend,omitempty"`
-	AutoscalerOptions       *AutoscalerOptionsApplyConfiguration `json:"autoscalerOptions,omitempty"`
-	HeadServiceAnnotations  map[string]string                    `json:"headServiceAnnotations,omitempty"`
-	EnableInTreeAutoscaling *bool                                `json:"enableInTreeAutoscaling,omitempty"`
-	HeadGroupSpec           *HeadGroupSpecApplyConfiguration     `json:"headGroupSpec,omitempty"`
-	RayVersion              *string                              `json:"rayVersion,omitempty"`
-	WorkerGroupSpecs        []WorkerGroupSpecApplyConfiguration  `json:"workerGroupSpecs,omitempty"`
+	Suspend                 *bool                                        `json:"suspend,omitempty"`
+	AutoscalerOptions       *AutoscalerOptionsApplyConfiguration         `json:"autoscalerOptions,omitempty"`
+	HeadServiceAnnotations  map[string]string                            `json:"headServiceAnnotations,omitempty"`
+	EnableInTreeAutoscaling *bool                                        `json:"enableInTreeAutoscaling,omitempty"`
+	UpgradeStrategy         *RayClusterUpgradeStrategyApplyConfiguration `json:"upgradeStrategy,omitempty"`
+	ResourceQuota           *v1.ResourceList                             `json:"resourceQuota,omitempty"`
+	RemoteCluster           *RemoteClusterConfigApplyConfiguration       `json:"remoteCluster,omitempty"`
+	Metrics                 *MetricsConfigApplyConfiguration             `json:"metrics,omitempty"`
+	ImagePrePull            *ImagePrePullConfigApplyConfiguration        `json:"imagePrePull,omitempty"`
+	SystemConfig            *RaySystemConfigSourceApplyConfiguration     `json:"systemConfig,omitempty"`
+	DrainBeforeDeletion     *DrainBeforeDeletionConfigApplyConfiguration `json:"drainBeforeDeletion,omitempty"`
+	Logging                 *RayLoggingConfigApplyConfiguration          `json:"logging,omitempty"`
+	Egress                  *EgressConfigApplyConfiguration              `json:"egress,omitempty"`
+	DashboardAuth           *DashboardAuthConfigApplyConfiguration       `json:"dashboardAuth,omitempty"`
+	UsageSnapshots          *UsageSnapshotConfigApplyConfiguration       `json:"usageSnapshots,omitempty"`
+	ReadinessTimeouts       *ReadinessTimeoutsConfigApplyConfiguration   `json:"readinessTimeouts,omitempty"`
+	HeadGroupSpec           *HeadGroupSpecApplyConfiguration             `json:"headGroupSpec,omitempty"`
+	RayVersion              *string                                      `json:"rayVersion,omitempty"`
+	WorkerGroupSpecs        []WorkerGroupSpecApplyConfiguration          `json:"workerGroupSpecs,omitempty"`
 }
 
 // RayClusterSpecApplyConfiguration constructs an declarative configuration of the RayClusterSpec type for use with
@@ -58,6 +74,102 @@ func (b *RayClusterSpecApplyConfiguration) WithEnableInTreeAutoscaling(value boo
 	return b
 }
 
+// WithUpgradeStrategy sets the UpgradeStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeStrategy field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithUpgradeStrategy(value *RayClusterUpgradeStrategyApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.UpgradeStrategy = value
+	return b
+}
+
+// WithResourceQuota sets the ResourceQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceQuota field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithResourceQuota(value v1.ResourceList) *RayClusterSpecApplyConfiguration {
+	b.ResourceQuota = &value
+	return b
+}
+
+// WithRemoteCluster sets the RemoteCluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RemoteCluster field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithRemoteCluster(value *RemoteClusterConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.RemoteCluster = value
+	return b
+}
+
+// WithMetrics sets the Metrics field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Metrics field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithMetrics(value *MetricsConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.Metrics = value
+	return b
+}
+
+// WithImagePrePull sets the ImagePrePull field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImagePrePull field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithImagePrePull(value *ImagePrePullConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.ImagePrePull = value
+	return b
+}
+
+// WithSystemConfig sets the SystemConfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SystemConfig field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithSystemConfig(value *RaySystemConfigSourceApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.SystemConfig = value
+	return b
+}
+
+// WithDrainBeforeDeletion sets the DrainBeforeDeletion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrainBeforeDeletion field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithDrainBeforeDeletion(value *DrainBeforeDeletionConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.DrainBeforeDeletion = value
+	return b
+}
+
+// WithLogging sets the Logging field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Logging field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithLogging(value *RayLoggingConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.Logging = value
+	return b
+}
+
+// WithEgress sets the Egress field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Egress field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithEgress(value *EgressConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.Egress = value
+	return b
+}
+
+// WithDashboardAuth sets the DashboardAuth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DashboardAuth field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithDashboardAuth(value *DashboardAuthConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.DashboardAuth = value
+	return b
+}
+
+// WithUsageSnapshots sets the UsageSnapshots field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UsageSnapshots field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithUsageSnapshots(value *UsageSnapshotConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.UsageSnapshots = value
+	return b
+}
+
+// WithReadinessTimeouts sets the ReadinessTimeouts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadinessTimeouts field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithReadinessTimeouts(value *ReadinessTimeoutsConfigApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.ReadinessTimeouts = value
+	return b
+}
+
 // WithHeadGroupSpec sets the HeadGroupSpec field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the HeadGroupSpec field is set to the value of the last call.
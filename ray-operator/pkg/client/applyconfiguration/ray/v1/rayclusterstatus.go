@@ -11,23 +11,28 @@ import (
 // RayClusterStatusApplyConfiguration represents an declarative configuration of the RayClusterStatus type for use
 // with apply.
 type RayClusterStatusApplyConfiguration struct {
-	State                   *v1.ClusterState                 `json:"state,omitempty"`
-	DesiredCPU              *resource.Quantity               `json:"desiredCPU,omitempty"`
-	DesiredMemory           *resource.Quantity               `json:"desiredMemory,omitempty"`
-	DesiredGPU              *resource.Quantity               `json:"desiredGPU,omitempty"`
-	DesiredTPU              *resource.Quantity               `json:"desiredTPU,omitempty"`
-	LastUpdateTime          *metav1.Time                     `json:"lastUpdateTime,omitempty"`
-	StateTransitionTimes    map[v1.ClusterState]*metav1.Time `json:"stateTransitionTimes,omitempty"`
-	Endpoints               map[string]string                `json:"endpoints,omitempty"`
-	Head                    *HeadInfoApplyConfiguration      `json:"head,omitempty"`
-	Reason                  *string                          `json:"reason,omitempty"`
-	Conditions              []metav1.Condition               `json:"conditions,omitempty"`
-	ReadyWorkerReplicas     *int32                           `json:"readyWorkerReplicas,omitempty"`
-	AvailableWorkerReplicas *int32                           `json:"availableWorkerReplicas,omitempty"`
-	DesiredWorkerReplicas   *int32                           `json:"desiredWorkerReplicas,omitempty"`
-	MinWorkerReplicas       *int32                           `json:"minWorkerReplicas,omitempty"`
-	MaxWorkerReplicas       *int32                           `json:"maxWorkerReplicas,omitempty"`
-	ObservedGeneration      *int64                           `json:"observedGeneration,omitempty"`
+	State                   *v1.ClusterState                               `json:"state,omitempty"`
+	DesiredCPU              *resource.Quantity                             `json:"desiredCPU,omitempty"`
+	DesiredMemory           *resource.Quantity                             `json:"desiredMemory,omitempty"`
+	DesiredGPU              *resource.Quantity                             `json:"desiredGPU,omitempty"`
+	DesiredTPU              *resource.Quantity                             `json:"desiredTPU,omitempty"`
+	LastUpdateTime          *metav1.Time                                   `json:"lastUpdateTime,omitempty"`
+	StateTransitionTimes    map[v1.ClusterState]*metav1.Time               `json:"stateTransitionTimes,omitempty"`
+	Endpoints               map[string]string                              `json:"endpoints,omitempty"`
+	Head                    *HeadInfoApplyConfiguration                    `json:"head,omitempty"`
+	Reason                  *string                                        `json:"reason,omitempty"`
+	QueueName               *string                                        `json:"queueName,omitempty"`
+	Conditions              []metav1.Condition                             `json:"conditions,omitempty"`
+	UpgradeStatus           *RayClusterUpgradeStatusApplyConfiguration     `json:"upgradeStatus,omitempty"`
+	PodBatching             *RayClusterPodBatchingStatusApplyConfiguration `json:"podBatching,omitempty"`
+	SystemConfigHash        *string                                        `json:"systemConfigHash,omitempty"`
+	UsageSnapshots          []RayClusterUsageSnapshotApplyConfiguration    `json:"usageSnapshots,omitempty"`
+	ReadyWorkerReplicas     *int32                                         `json:"readyWorkerReplicas,omitempty"`
+	AvailableWorkerReplicas *int32                                         `json:"availableWorkerReplicas,omitempty"`
+	DesiredWorkerReplicas   *int32                                         `json:"desiredWorkerReplicas,omitempty"`
+	MinWorkerReplicas       *int32                                         `json:"minWorkerReplicas,omitempty"`
+	MaxWorkerReplicas       *int32                                         `json:"maxWorkerReplicas,omitempty"`
+	ObservedGeneration      *int64                                         `json:"observedGeneration,omitempty"`
 }
 
 // RayClusterStatusApplyConfiguration constructs an declarative configuration of the RayClusterStatus type for use with
@@ -128,6 +133,14 @@ func (b *RayClusterStatusApplyConfiguration) WithReason(value string) *RayCluste
 	return b
 }
 
+// WithQueueName sets the QueueName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the QueueName field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithQueueName(value string) *RayClusterStatusApplyConfiguration {
+	b.QueueName = &value
+	return b
+}
+
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
@@ -138,6 +151,43 @@ func (b *RayClusterStatusApplyConfiguration) WithConditions(values ...metav1.Con
 	return b
 }
 
+// WithUpgradeStatus sets the UpgradeStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeStatus field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithUpgradeStatus(value *RayClusterUpgradeStatusApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	b.UpgradeStatus = value
+	return b
+}
+
+// WithPodBatching sets the PodBatching field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodBatching field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithPodBatching(value *RayClusterPodBatchingStatusApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	b.PodBatching = value
+	return b
+}
+
+// WithSystemConfigHash sets the SystemConfigHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SystemConfigHash field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithSystemConfigHash(value string) *RayClusterStatusApplyConfiguration {
+	b.SystemConfigHash = &value
+	return b
+}
+
+// WithUsageSnapshots adds the given value to the UsageSnapshots field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UsageSnapshots field.
+func (b *RayClusterStatusApplyConfiguration) WithUsageSnapshots(values ...*RayClusterUsageSnapshotApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUsageSnapshots")
+		}
+		b.UsageSnapshots = append(b.UsageSnapshots, *values[i])
+	}
+	return b
+}
+
 // WithReadyWorkerReplicas sets the ReadyWorkerReplicas field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ReadyWorkerReplicas field is set to the value of the last call.
@@ -16,6 +16,7 @@ type RayJobSpecApplyConfiguration struct {
 	SubmitterPodTemplate     *corev1.PodTemplateSpecApplyConfiguration `json:"submitterPodTemplate,omitempty"`
 	Metadata                 map[string]string                         `json:"metadata,omitempty"`
 	ClusterSelector          map[string]string                         `json:"clusterSelector,omitempty"`
+	ClusterGenerateName      *string                                   `json:"clusterGenerateName,omitempty"`
 	SubmitterConfig          *SubmitterConfigApplyConfiguration        `json:"submitterConfig,omitempty"`
 	Entrypoint               *string                                   `json:"entrypoint,omitempty"`
 	RuntimeEnvYAML           *string                                   `json:"runtimeEnvYAML,omitempty"`
@@ -27,6 +28,7 @@ type RayJobSpecApplyConfiguration struct {
 	TTLSecondsAfterFinished  *int32                                    `json:"ttlSecondsAfterFinished,omitempty"`
 	ShutdownAfterJobFinishes *bool                                     `json:"shutdownAfterJobFinishes,omitempty"`
 	Suspend                  *bool                                     `json:"suspend,omitempty"`
+	Stages                   []RayJobStageApplyConfiguration           `json:"stages,omitempty"`
 }
 
 // RayJobSpecApplyConfiguration constructs an declarative configuration of the RayJobSpec type for use with
@@ -95,6 +97,14 @@ func (b *RayJobSpecApplyConfiguration) WithClusterSelector(entries map[string]st
 	return b
 }
 
+// WithClusterGenerateName sets the ClusterGenerateName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterGenerateName field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithClusterGenerateName(value string) *RayJobSpecApplyConfiguration {
+	b.ClusterGenerateName = &value
+	return b
+}
+
 // WithSubmitterConfig sets the SubmitterConfig field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the SubmitterConfig field is set to the value of the last call.
@@ -182,3 +192,16 @@ func (b *RayJobSpecApplyConfiguration) WithSuspend(value bool) *RayJobSpecApplyC
 	b.Suspend = &value
 	return b
 }
+
+// WithStages adds the given value to the Stages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Stages field.
+func (b *RayJobSpecApplyConfiguration) WithStages(values ...*RayJobStageApplyConfiguration) *RayJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithStages")
+		}
+		b.Stages = append(b.Stages, *values[i])
+	}
+	return b
+}
@@ -10,19 +10,21 @@ import (
 // RayJobStatusApplyConfiguration represents an declarative configuration of the RayJobStatus type for use
 // with apply.
 type RayJobStatusApplyConfiguration struct {
-	JobId               *string                             `json:"jobId,omitempty"`
-	RayClusterName      *string                             `json:"rayClusterName,omitempty"`
-	DashboardURL        *string                             `json:"dashboardURL,omitempty"`
-	JobStatus           *v1.JobStatus                       `json:"jobStatus,omitempty"`
-	JobDeploymentStatus *v1.JobDeploymentStatus             `json:"jobDeploymentStatus,omitempty"`
-	Reason              *v1.JobFailedReason                 `json:"reason,omitempty"`
-	Message             *string                             `json:"message,omitempty"`
-	StartTime           *metav1.Time                        `json:"startTime,omitempty"`
-	EndTime             *metav1.Time                        `json:"endTime,omitempty"`
-	Succeeded           *int32                              `json:"succeeded,omitempty"`
-	Failed              *int32                              `json:"failed,omitempty"`
-	RayClusterStatus    *RayClusterStatusApplyConfiguration `json:"rayClusterStatus,omitempty"`
-	ObservedGeneration  *int64                              `json:"observedGeneration,omitempty"`
+	JobId               *string                               `json:"jobId,omitempty"`
+	RayClusterName      *string                               `json:"rayClusterName,omitempty"`
+	DashboardURL        *string                               `json:"dashboardURL,omitempty"`
+	JobStatus           *v1.JobStatus                         `json:"jobStatus,omitempty"`
+	JobDeploymentStatus *v1.JobDeploymentStatus               `json:"jobDeploymentStatus,omitempty"`
+	Reason              *v1.JobFailedReason                   `json:"reason,omitempty"`
+	Message             *string                               `json:"message,omitempty"`
+	StartTime           *metav1.Time                          `json:"startTime,omitempty"`
+	EndTime             *metav1.Time                          `json:"endTime,omitempty"`
+	Succeeded           *int32                                `json:"succeeded,omitempty"`
+	Failed              *int32                                `json:"failed,omitempty"`
+	JobResult           *RayJobResultApplyConfiguration       `json:"jobResult,omitempty"`
+	RayClusterStatus    *RayClusterStatusApplyConfiguration   `json:"rayClusterStatus,omitempty"`
+	Stages              []RayJobStageStatusApplyConfiguration `json:"stages,omitempty"`
+	ObservedGeneration  *int64                                `json:"observedGeneration,omitempty"`
 }
 
 // RayJobStatusApplyConfiguration constructs an declarative configuration of the RayJobStatus type for use with
@@ -119,6 +121,14 @@ func (b *RayJobStatusApplyConfiguration) WithFailed(value int32) *RayJobStatusAp
 	return b
 }
 
+// WithJobResult sets the JobResult field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the JobResult field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithJobResult(value *RayJobResultApplyConfiguration) *RayJobStatusApplyConfiguration {
+	b.JobResult = value
+	return b
+}
+
 // WithRayClusterStatus sets the RayClusterStatus field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RayClusterStatus field is set to the value of the last call.
@@ -127,6 +137,19 @@ func (b *RayJobStatusApplyConfiguration) WithRayClusterStatus(value *RayClusterS
 	return b
 }
 
+// WithStages adds the given value to the Stages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Stages field.
+func (b *RayJobStatusApplyConfiguration) WithStages(values ...*RayJobStageStatusApplyConfiguration) *RayJobStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithStages")
+		}
+		b.Stages = append(b.Stages, *values[i])
+	}
+	return b
+}
+
 // WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ObservedGeneration field is set to the value of the last call.
@@ -9,11 +9,14 @@ import (
 // RayServiceSpecApplyConfiguration represents an declarative configuration of the RayServiceSpec type for use
 // with apply.
 type RayServiceSpecApplyConfiguration struct {
-	ServiceUnhealthySecondThreshold    *int32                            `json:"serviceUnhealthySecondThreshold,omitempty"`
-	DeploymentUnhealthySecondThreshold *int32                            `json:"deploymentUnhealthySecondThreshold,omitempty"`
-	ServeService                       *v1.Service                       `json:"serveService,omitempty"`
-	ServeConfigV2                      *string                           `json:"serveConfigV2,omitempty"`
-	RayClusterSpec                     *RayClusterSpecApplyConfiguration `json:"rayClusterConfig,omitempty"`
+	ServiceUnhealthySecondThreshold    *int32                                         `json:"serviceUnhealthySecondThreshold,omitempty"`
+	DeploymentUnhealthySecondThreshold *int32                                         `json:"deploymentUnhealthySecondThreshold,omitempty"`
+	HealthCheckPolicy                  *RayServiceHealthCheckPolicyApplyConfiguration `json:"healthCheckPolicy,omitempty"`
+	UpgradeStrategy                    *RayServiceUpgradeStrategyApplyConfiguration   `json:"upgradeStrategy,omitempty"`
+	ServeService                       *v1.Service                                    `json:"serveService,omitempty"`
+	Paused                             *bool                                          `json:"paused,omitempty"`
+	ServeConfigV2                      *string                                        `json:"serveConfigV2,omitempty"`
+	RayClusterSpec                     *RayClusterSpecApplyConfiguration              `json:"rayClusterConfig,omitempty"`
 }
 
 // RayServiceSpecApplyConfiguration constructs an declarative configuration of the RayServiceSpec type for use with
@@ -38,6 +41,22 @@ func (b *RayServiceSpecApplyConfiguration) WithDeploymentUnhealthySecondThreshol
 	return b
 }
 
+// WithHealthCheckPolicy sets the HealthCheckPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HealthCheckPolicy field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithHealthCheckPolicy(value *RayServiceHealthCheckPolicyApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.HealthCheckPolicy = value
+	return b
+}
+
+// WithUpgradeStrategy sets the UpgradeStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeStrategy field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithUpgradeStrategy(value *RayServiceUpgradeStrategyApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.UpgradeStrategy = value
+	return b
+}
+
 // WithServeService sets the ServeService field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ServeService field is set to the value of the last call.
@@ -46,6 +65,14 @@ func (b *RayServiceSpecApplyConfiguration) WithServeService(value v1.Service) *R
 	return b
 }
 
+// WithPaused sets the Paused field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Paused field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithPaused(value bool) *RayServiceSpecApplyConfiguration {
+	b.Paused = &value
+	return b
+}
+
 // WithServeConfigV2 sets the ServeConfigV2 field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ServeConfigV2 field is set to the value of the last call.
@@ -5,9 +5,11 @@ package v1
 // RayServiceStatusApplyConfiguration represents an declarative configuration of the RayServiceStatus type for use
 // with apply.
 type RayServiceStatusApplyConfiguration struct {
-	Applications     map[string]AppStatusApplyConfiguration `json:"applicationStatuses,omitempty"`
-	RayClusterName   *string                                `json:"rayClusterName,omitempty"`
-	RayClusterStatus *RayClusterStatusApplyConfiguration    `json:"rayClusterStatus,omitempty"`
+	Applications                   map[string]AppStatusApplyConfiguration `json:"applicationStatuses,omitempty"`
+	RayClusterName                 *string                                `json:"rayClusterName,omitempty"`
+	RayClusterStatus               *RayClusterStatusApplyConfiguration    `json:"rayClusterStatus,omitempty"`
+	ConsecutiveHealthCheckFailures *int32                                 `json:"consecutiveHealthCheckFailures,omitempty"`
+	HealthCheckFailures            []HealthCheckFailureApplyConfiguration `json:"healthCheckFailures,omitempty"`
 }
 
 // RayServiceStatusApplyConfiguration constructs an declarative configuration of the RayServiceStatus type for use with
@@ -45,3 +47,24 @@ func (b *RayServiceStatusApplyConfiguration) WithRayClusterStatus(value *RayClus
 	b.RayClusterStatus = value
 	return b
 }
+
+// WithConsecutiveHealthCheckFailures sets the ConsecutiveHealthCheckFailures field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConsecutiveHealthCheckFailures field is set to the value of the last call.
+func (b *RayServiceStatusApplyConfiguration) WithConsecutiveHealthCheckFailures(value int32) *RayServiceStatusApplyConfiguration {
+	b.ConsecutiveHealthCheckFailures = &value
+	return b
+}
+
+// WithHealthCheckFailures adds the given value to the HealthCheckFailures field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the HealthCheckFailures field.
+func (b *RayServiceStatusApplyConfiguration) WithHealthCheckFailures(values ...*HealthCheckFailureApplyConfiguration) *RayServiceStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithHealthCheckFailures")
+		}
+		b.HealthCheckFailures = append(b.HealthCheckFailures, *values[i])
+	}
+	return b
+}
@@ -10,12 +10,14 @@ import (
 // RayServiceStatusesApplyConfiguration represents an declarative configuration of the RayServiceStatuses type for use
 // with apply.
 type RayServiceStatusesApplyConfiguration struct {
-	LastUpdateTime       *v1.Time                            `json:"lastUpdateTime,omitempty"`
-	ServiceStatus        *rayv1.ServiceStatus                `json:"serviceStatus,omitempty"`
-	ActiveServiceStatus  *RayServiceStatusApplyConfiguration `json:"activeServiceStatus,omitempty"`
-	PendingServiceStatus *RayServiceStatusApplyConfiguration `json:"pendingServiceStatus,omitempty"`
-	NumServeEndpoints    *int32                              `json:"numServeEndpoints,omitempty"`
-	ObservedGeneration   *int64                              `json:"observedGeneration,omitempty"`
+	LastUpdateTime             *v1.Time                                   `json:"lastUpdateTime,omitempty"`
+	ServiceStatus              *rayv1.ServiceStatus                       `json:"serviceStatus,omitempty"`
+	ActiveServiceStatus        *RayServiceStatusApplyConfiguration        `json:"activeServiceStatus,omitempty"`
+	PendingServiceStatus       *RayServiceStatusApplyConfiguration        `json:"pendingServiceStatus,omitempty"`
+	NumServeEndpoints          *int32                                     `json:"numServeEndpoints,omitempty"`
+	ObservedGeneration         *int64                                     `json:"observedGeneration,omitempty"`
+	UpgradeStatus              *RayServiceUpgradeStatusApplyConfiguration `json:"upgradeStatus,omitempty"`
+	LastUpgradeDurationSeconds *int32                                     `json:"lastUpgradeDurationSeconds,omitempty"`
 }
 
 // RayServiceStatusesApplyConfiguration constructs an declarative configuration of the RayServiceStatuses type for use with
@@ -71,3 +73,19 @@ func (b *RayServiceStatusesApplyConfiguration) WithObservedGeneration(value int6
 	b.ObservedGeneration = &value
 	return b
 }
+
+// WithUpgradeStatus sets the UpgradeStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeStatus field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithUpgradeStatus(value *RayServiceUpgradeStatusApplyConfiguration) *RayServiceStatusesApplyConfiguration {
+	b.UpgradeStatus = value
+	return b
+}
+
+// WithLastUpgradeDurationSeconds sets the LastUpgradeDurationSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUpgradeDurationSeconds field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithLastUpgradeDurationSeconds(value int32) *RayServiceStatusesApplyConfiguration {
+	b.LastUpgradeDurationSeconds = &value
+	return b
+}
@@ -12,6 +12,8 @@ type ServeDeploymentStatusApplyConfiguration struct {
 	HealthLastUpdateTime *v1.Time `json:"healthLastUpdateTime,omitempty"`
 	Status               *string  `json:"status,omitempty"`
 	Message              *string  `json:"message,omitempty"`
+	RunningReplicas      *int32   `json:"runningReplicas,omitempty"`
+	TargetReplicas       *int32   `json:"targetReplicas,omitempty"`
 }
 
 // ServeDeploymentStatusApplyConfiguration constructs an declarative configuration of the ServeDeploymentStatus type for use with
@@ -43,3 +45,19 @@ func (b *ServeDeploymentStatusApplyConfiguration) WithMessage(value string) *Ser
 	b.Message = &value
 	return b
 }
+
+// WithRunningReplicas sets the RunningReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RunningReplicas field is set to the value of the last call.
+func (b *ServeDeploymentStatusApplyConfiguration) WithRunningReplicas(value int32) *ServeDeploymentStatusApplyConfiguration {
+	b.RunningReplicas = &value
+	return b
+}
+
+// WithTargetReplicas sets the TargetReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetReplicas field is set to the value of the last call.
+func (b *ServeDeploymentStatusApplyConfiguration) WithTargetReplicas(value int32) *ServeDeploymentStatusApplyConfiguration {
+	b.TargetReplicas = &value
+	return b
+}
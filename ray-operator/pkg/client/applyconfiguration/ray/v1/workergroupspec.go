@@ -3,20 +3,26 @@
 package v1
 
 import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1 "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
 // WorkerGroupSpecApplyConfiguration represents an declarative configuration of the WorkerGroupSpec type for use
 // with apply.
 type WorkerGroupSpecApplyConfiguration struct {
-	GroupName      *string                               `json:"groupName,omitempty"`
-	Replicas       *int32                                `json:"replicas,omitempty"`
-	MinReplicas    *int32                                `json:"minReplicas,omitempty"`
-	MaxReplicas    *int32                                `json:"maxReplicas,omitempty"`
-	RayStartParams map[string]string                     `json:"rayStartParams,omitempty"`
-	Template       *v1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
-	ScaleStrategy  *ScaleStrategyApplyConfiguration      `json:"scaleStrategy,omitempty"`
-	NumOfHosts     *int32                                `json:"numOfHosts,omitempty"`
+	GroupName         *string                                       `json:"groupName,omitempty"`
+	Replicas          *int32                                        `json:"replicas,omitempty"`
+	MinReplicas       *int32                                        `json:"minReplicas,omitempty"`
+	MaxReplicas       *int32                                        `json:"maxReplicas,omitempty"`
+	RayStartParams    map[string]string                             `json:"rayStartParams,omitempty"`
+	PodTemplatePatch  *runtime.RawExtension                         `json:"podTemplatePatch,omitempty"`
+	Template          *v1.PodTemplateSpecApplyConfiguration         `json:"template,omitempty"`
+	ScaleStrategy     *ScaleStrategyApplyConfiguration              `json:"scaleStrategy,omitempty"`
+	NumOfHosts        *int32                                        `json:"numOfHosts,omitempty"`
+	ScalingSchedules  []ScalingScheduleApplyConfiguration           `json:"scalingSchedules,omitempty"`
+	PersistentStorage *RayPersistentStorageApplyConfiguration       `json:"persistentStorage,omitempty"`
+	NodeFill          *NodeFillPolicyApplyConfiguration             `json:"nodeFill,omitempty"`
+	RolloutStrategy   *WorkerGroupRolloutStrategyApplyConfiguration `json:"rolloutStrategy,omitempty"`
 }
 
 // WorkerGroupSpecApplyConfiguration constructs an declarative configuration of the WorkerGroupSpec type for use with
@@ -71,6 +77,14 @@ func (b *WorkerGroupSpecApplyConfiguration) WithRayStartParams(entries map[strin
 	return b
 }
 
+// WithPodTemplatePatch sets the PodTemplatePatch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodTemplatePatch field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithPodTemplatePatch(value runtime.RawExtension) *WorkerGroupSpecApplyConfiguration {
+	b.PodTemplatePatch = &value
+	return b
+}
+
 // WithTemplate sets the Template field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Template field is set to the value of the last call.
@@ -94,3 +108,40 @@ func (b *WorkerGroupSpecApplyConfiguration) WithNumOfHosts(value int32) *WorkerG
 	b.NumOfHosts = &value
 	return b
 }
+
+// WithScalingSchedules adds the given value to the ScalingSchedules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ScalingSchedules field.
+func (b *WorkerGroupSpecApplyConfiguration) WithScalingSchedules(values ...*ScalingScheduleApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithScalingSchedules")
+		}
+		b.ScalingSchedules = append(b.ScalingSchedules, *values[i])
+	}
+	return b
+}
+
+// WithPersistentStorage sets the PersistentStorage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PersistentStorage field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithPersistentStorage(value *RayPersistentStorageApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.PersistentStorage = value
+	return b
+}
+
+// WithNodeFill sets the NodeFill field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeFill field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithNodeFill(value *NodeFillPolicyApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.NodeFill = value
+	return b
+}
+
+// WithRolloutStrategy sets the RolloutStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RolloutStrategy field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithRolloutStrategy(value *WorkerGroupRolloutStrategyApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.RolloutStrategy = value
+	return b
+}
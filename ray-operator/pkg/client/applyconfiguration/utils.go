@@ -17,36 +17,94 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &rayv1.AppStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("AutoscalerOptions"):
 		return &rayv1.AutoscalerOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CABundleSource"):
+		return &rayv1.CABundleSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DashboardAuthConfig"):
+		return &rayv1.DashboardAuthConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DrainBeforeDeletionConfig"):
+		return &rayv1.DrainBeforeDeletionConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EgressConfig"):
+		return &rayv1.EgressConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HeadGroupSpec"):
 		return &rayv1.HeadGroupSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HeadInfo"):
 		return &rayv1.HeadInfoApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HeadNodeReservation"):
+		return &rayv1.HeadNodeReservationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HeadPlacementPolicy"):
+		return &rayv1.HeadPlacementPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HeadStandby"):
+		return &rayv1.HeadStandbyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HealthCheckFailure"):
+		return &rayv1.HealthCheckFailureApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ImagePrePullConfig"):
+		return &rayv1.ImagePrePullConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MetricsConfig"):
+		return &rayv1.MetricsConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("NodeFillPolicy"):
+		return &rayv1.NodeFillPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayCluster"):
 		return &rayv1.RayClusterApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayClusterPodBatchingStatus"):
+		return &rayv1.RayClusterPodBatchingStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayClusterSpec"):
 		return &rayv1.RayClusterSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayClusterStatus"):
 		return &rayv1.RayClusterStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayClusterUpgradeStatus"):
+		return &rayv1.RayClusterUpgradeStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayClusterUpgradeStrategy"):
+		return &rayv1.RayClusterUpgradeStrategyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayClusterUsageSnapshot"):
+		return &rayv1.RayClusterUsageSnapshotApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJob"):
 		return &rayv1.RayJobApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayJobResult"):
+		return &rayv1.RayJobResultApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJobSpec"):
 		return &rayv1.RayJobSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayJobStage"):
+		return &rayv1.RayJobStageApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayJobStageStatus"):
+		return &rayv1.RayJobStageStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJobStatus"):
 		return &rayv1.RayJobStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayLoggingConfig"):
+		return &rayv1.RayLoggingConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayPersistentStorage"):
+		return &rayv1.RayPersistentStorageApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayService"):
 		return &rayv1.RayServiceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayServiceHealthCheckPolicy"):
+		return &rayv1.RayServiceHealthCheckPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceSpec"):
 		return &rayv1.RayServiceSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceStatus"):
 		return &rayv1.RayServiceStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceStatuses"):
 		return &rayv1.RayServiceStatusesApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayServiceUpgradeStatus"):
+		return &rayv1.RayServiceUpgradeStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayServiceUpgradeStrategy"):
+		return &rayv1.RayServiceUpgradeStrategyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RaySystemConfigSource"):
+		return &rayv1.RaySystemConfigSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ReadinessTimeoutsConfig"):
+		return &rayv1.ReadinessTimeoutsConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RemoteClusterConfig"):
+		return &rayv1.RemoteClusterConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ScaleStrategy"):
 		return &rayv1.ScaleStrategyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ScalingSchedule"):
+		return &rayv1.ScalingScheduleApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ServeDeploymentStatus"):
 		return &rayv1.ServeDeploymentStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SubmitterConfig"):
 		return &rayv1.SubmitterConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("UsageSnapshotConfig"):
+		return &rayv1.UsageSnapshotConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WorkerGroupRolloutStrategy"):
+		return &rayv1.WorkerGroupRolloutStrategyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("WorkerGroupSpec"):
 		return &rayv1.WorkerGroupSpecApplyConfiguration{}
 
@@ -17,6 +17,18 @@ const (
 	//
 	// Enables new conditions in RayCluster status
 	RayClusterStatusConditions featuregate.Feature = "RayClusterStatusConditions"
+
+	// alpha: v1.2
+	//
+	// Queries the Ray head for detached actors and placement group bundles before deleting worker Pods on scale-down,
+	// deletes Pods that host them last, and emits a warning event when such a Pod has to be deleted anyway.
+	DetachedWorkloadAwareScaleDown featuregate.Feature = "DetachedWorkloadAwareScaleDown"
+
+	// alpha: v1.2
+	//
+	// Allows a RayCluster to set spec.remoteCluster, so that its Pods and Services are created in the Kubernetes cluster
+	// described by a kubeconfig Secret instead of the cluster the operator runs in.
+	RemoteClusterManagement featuregate.Feature = "RemoteClusterManagement"
 )
 
 func init() {
@@ -24,7 +36,9 @@ func init() {
 }
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-	RayClusterStatusConditions: {Default: false, PreRelease: featuregate.Alpha},
+	RayClusterStatusConditions:     {Default: false, PreRelease: featuregate.Alpha},
+	DetachedWorkloadAwareScaleDown: {Default: false, PreRelease: featuregate.Alpha},
+	RemoteClusterManagement:        {Default: false, PreRelease: featuregate.Alpha},
 }
 
 // SetFeatureGateDuringTest is a helper method to override feature gates in tests.